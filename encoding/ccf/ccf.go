@@ -0,0 +1,248 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ccf implements the Cadence Compact Format (CCF),
+// a deterministic, compact, CBOR-based encoding of Cadence values and types.
+//
+// An encoded message is a CBOR array of two elements:
+// the definitions of all composite and interface types used in the message,
+// followed by the encoded value (or type).
+//
+// Composite and interface types are only defined once per message,
+// and are referred to by their index in the type definitions.
+// This keeps messages compact, e.g. the field names of an event type
+// are not repeated for each event, and allows recursive types to be encoded.
+//
+// Values are encoded as tagged CBOR data items, where the tag determines
+// the kind of the value, except for Bool and String values,
+// which are encoded as plain CBOR booleans and text strings.
+//
+// The encoding is deterministic: it does not use CBOR maps,
+// and type definitions are ordered by their first occurrence in the message.
+package ccf
+
+import (
+	"github.com/onflow/cadence"
+)
+
+// CBORTagBase is the first CBOR tag number used by CCF.
+//
+// !!! *WARNING* !!!
+//
+// Only add new tags by appending them.
+// DO *NOT* REPLACE OR REORDER EXISTING TAGS!
+//
+const CBORTagBase = 128
+
+// Value tags
+//
+const (
+	CBORTagVoid = CBORTagBase + iota
+	CBORTagOptional
+	CBORTagBytes
+	CBORTagAddress
+	CBORTagInt
+	CBORTagInt8
+	CBORTagInt16
+	CBORTagInt32
+	CBORTagInt64
+	CBORTagInt128
+	CBORTagInt256
+	CBORTagUInt
+	CBORTagUInt8
+	CBORTagUInt16
+	CBORTagUInt32
+	CBORTagUInt64
+	CBORTagUInt128
+	CBORTagUInt256
+	CBORTagWord8
+	CBORTagWord16
+	CBORTagWord32
+	CBORTagWord64
+	CBORTagFix64
+	CBORTagUFix64
+	CBORTagArray
+	CBORTagDictionary
+	CBORTagStruct
+	CBORTagResource
+	CBORTagEvent
+	CBORTagContract
+	CBORTagEnum
+	CBORTagLink
+	CBORTagPath
+	CBORTagTypeValue
+	CBORTagCapability
+	CBORTagAttachment
+)
+
+// Type tags
+//
+const (
+	CBORTagSimpleType = CBORTagBase + 64 + iota
+	CBORTagOptionalType
+	CBORTagVariableSizedArrayType
+	CBORTagConstantSizedArrayType
+	CBORTagDictionaryType
+	CBORTagReferenceType
+	CBORTagRestrictedType
+	CBORTagCapabilityType
+	CBORTagFunctionType
+	CBORTagTypeRef
+)
+
+// Type definition tags
+//
+const (
+	CBORTagStructTypeDef = CBORTagBase + 96 + iota
+	CBORTagResourceTypeDef
+	CBORTagEventTypeDef
+	CBORTagContractTypeDef
+	CBORTagEnumTypeDef
+	CBORTagStructInterfaceTypeDef
+	CBORTagResourceInterfaceTypeDef
+	CBORTagContractInterfaceTypeDef
+	CBORTagAttachmentTypeDef
+)
+
+// simpleType is the encoding of a type which has no parameters,
+// i.e. which is fully identified by its kind.
+//
+// !!! *WARNING* !!!
+//
+// Only add new simple types by appending them.
+// DO *NOT* REPLACE OR REORDER EXISTING SIMPLE TYPES!
+//
+type simpleType uint64
+
+const (
+	simpleTypeAny simpleType = iota
+	simpleTypeAnyStruct
+	simpleTypeAnyResource
+	simpleTypeMeta
+	simpleTypeVoid
+	simpleTypeNever
+	simpleTypeBool
+	simpleTypeString
+	simpleTypeCharacter
+	simpleTypeBytes
+	simpleTypeAddress
+	simpleTypeNumber
+	simpleTypeSignedNumber
+	simpleTypeInteger
+	simpleTypeSignedInteger
+	simpleTypeFixedPoint
+	simpleTypeSignedFixedPoint
+	simpleTypeInt
+	simpleTypeInt8
+	simpleTypeInt16
+	simpleTypeInt32
+	simpleTypeInt64
+	simpleTypeInt128
+	simpleTypeInt256
+	simpleTypeUInt
+	simpleTypeUInt8
+	simpleTypeUInt16
+	simpleTypeUInt32
+	simpleTypeUInt64
+	simpleTypeUInt128
+	simpleTypeUInt256
+	simpleTypeWord8
+	simpleTypeWord16
+	simpleTypeWord32
+	simpleTypeWord64
+	simpleTypeFix64
+	simpleTypeUFix64
+	simpleTypeBlock
+	simpleTypePath
+	simpleTypeCapabilityPath
+	simpleTypeStoragePath
+	simpleTypePublicPath
+	simpleTypePrivatePath
+	simpleTypeAuthAccount
+	simpleTypePublicAccount
+	simpleTypeDeployedContract
+	simpleTypeAuthAccountContracts
+	simpleTypePublicAccountContracts
+	simpleTypeAuthAccountKeys
+	simpleTypePublicAccountKeys
+	simpleTypeAccountKey
+	simpleTypeBlockHeader
+)
+
+var simpleTypes = map[simpleType]cadence.Type{
+	simpleTypeAny:                    cadence.AnyType{},
+	simpleTypeAnyStruct:              cadence.AnyStructType{},
+	simpleTypeAnyResource:            cadence.AnyResourceType{},
+	simpleTypeMeta:                   cadence.MetaType{},
+	simpleTypeVoid:                   cadence.VoidType{},
+	simpleTypeNever:                  cadence.NeverType{},
+	simpleTypeBool:                   cadence.BoolType{},
+	simpleTypeString:                 cadence.StringType{},
+	simpleTypeCharacter:              cadence.CharacterType{},
+	simpleTypeBytes:                  cadence.BytesType{},
+	simpleTypeAddress:                cadence.AddressType{},
+	simpleTypeNumber:                 cadence.NumberType{},
+	simpleTypeSignedNumber:           cadence.SignedNumberType{},
+	simpleTypeInteger:                cadence.IntegerType{},
+	simpleTypeSignedInteger:          cadence.SignedIntegerType{},
+	simpleTypeFixedPoint:             cadence.FixedPointType{},
+	simpleTypeSignedFixedPoint:       cadence.SignedFixedPointType{},
+	simpleTypeInt:                    cadence.IntType{},
+	simpleTypeInt8:                   cadence.Int8Type{},
+	simpleTypeInt16:                  cadence.Int16Type{},
+	simpleTypeInt32:                  cadence.Int32Type{},
+	simpleTypeInt64:                  cadence.Int64Type{},
+	simpleTypeInt128:                 cadence.Int128Type{},
+	simpleTypeInt256:                 cadence.Int256Type{},
+	simpleTypeUInt:                   cadence.UIntType{},
+	simpleTypeUInt8:                  cadence.UInt8Type{},
+	simpleTypeUInt16:                 cadence.UInt16Type{},
+	simpleTypeUInt32:                 cadence.UInt32Type{},
+	simpleTypeUInt64:                 cadence.UInt64Type{},
+	simpleTypeUInt128:                cadence.UInt128Type{},
+	simpleTypeUInt256:                cadence.UInt256Type{},
+	simpleTypeWord8:                  cadence.Word8Type{},
+	simpleTypeWord16:                 cadence.Word16Type{},
+	simpleTypeWord32:                 cadence.Word32Type{},
+	simpleTypeWord64:                 cadence.Word64Type{},
+	simpleTypeFix64:                  cadence.Fix64Type{},
+	simpleTypeUFix64:                 cadence.UFix64Type{},
+	simpleTypeBlock:                  cadence.BlockType{},
+	simpleTypePath:                   cadence.PathType{},
+	simpleTypeCapabilityPath:         cadence.CapabilityPathType{},
+	simpleTypeStoragePath:            cadence.StoragePathType{},
+	simpleTypePublicPath:             cadence.PublicPathType{},
+	simpleTypePrivatePath:            cadence.PrivatePathType{},
+	simpleTypeAuthAccount:            cadence.AuthAccountType{},
+	simpleTypePublicAccount:          cadence.PublicAccountType{},
+	simpleTypeDeployedContract:       cadence.DeployedContractType{},
+	simpleTypeAuthAccountContracts:   cadence.AuthAccountContractsType{},
+	simpleTypePublicAccountContracts: cadence.PublicAccountContractsType{},
+	simpleTypeAuthAccountKeys:        cadence.AuthAccountKeysType{},
+	simpleTypePublicAccountKeys:      cadence.PublicAccountKeysType{},
+	simpleTypeAccountKey:             cadence.AccountKeyType{},
+	simpleTypeBlockHeader:            cadence.BlockHeaderType{},
+}
+
+var simpleTypesByID = func() map[string]simpleType {
+	result := make(map[string]simpleType, len(simpleTypes))
+	for simple, typ := range simpleTypes {
+		result[typ.ID()] = simple
+	}
+	return result
+}()
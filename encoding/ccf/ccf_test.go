@@ -0,0 +1,704 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type encodeTest struct {
+	name string
+	val  cadence.Value
+}
+
+func TestEncodeSimpleValues(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"Void", cadence.NewVoid()},
+		{"Nil", cadence.NewOptional(nil)},
+		{"Some", cadence.NewOptional(cadence.NewInt(42))},
+		{"Some nil", cadence.NewOptional(cadence.NewOptional(nil))},
+		{"True", cadence.NewBool(true)},
+		{"False", cadence.NewBool(false)},
+		{"Empty string", cadence.String("")},
+		{"String", cadence.String("foo ✌️")},
+		{"Empty bytes", cadence.NewBytes([]byte{})},
+		{"Bytes", cadence.NewBytes([]byte{1, 2, 3})},
+		{"Address", cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1})},
+		{"Int zero", cadence.NewInt(0)},
+		{"Int negative", cadence.NewInt(-42)},
+		{"Int large", cadence.NewIntFromBig(new(big.Int).Lsh(big.NewInt(1), 300))},
+		{"Int8 min", cadence.NewInt8(math.MinInt8)},
+		{"Int8 max", cadence.NewInt8(math.MaxInt8)},
+		{"Int16 min", cadence.NewInt16(math.MinInt16)},
+		{"Int32 min", cadence.NewInt32(math.MinInt32)},
+		{"Int64 min", cadence.NewInt64(math.MinInt64)},
+		{"Int64 max", cadence.NewInt64(math.MaxInt64)},
+		{"Int128 min", cadence.Int128{Value: sema.Int128TypeMinIntBig}},
+		{"Int128 max", cadence.Int128{Value: sema.Int128TypeMaxIntBig}},
+		{"Int256 min", cadence.Int256{Value: sema.Int256TypeMinIntBig}},
+		{"Int256 max", cadence.Int256{Value: sema.Int256TypeMaxIntBig}},
+		{"UInt", cadence.NewUInt(42)},
+		{"UInt8 max", cadence.NewUInt8(math.MaxUint8)},
+		{"UInt16 max", cadence.NewUInt16(math.MaxUint16)},
+		{"UInt32 max", cadence.NewUInt32(math.MaxUint32)},
+		{"UInt64 max", cadence.NewUInt64(math.MaxUint64)},
+		{"UInt128 max", cadence.UInt128{Value: sema.UInt128TypeMaxIntBig}},
+		{"UInt256 max", cadence.UInt256{Value: sema.UInt256TypeMaxIntBig}},
+		{"Word8", cadence.NewWord8(math.MaxUint8)},
+		{"Word16", cadence.NewWord16(math.MaxUint16)},
+		{"Word32", cadence.NewWord32(math.MaxUint32)},
+		{"Word64", cadence.NewWord64(math.MaxUint64)},
+		{"Fix64 min", cadence.Fix64(math.MinInt64)},
+		{"Fix64 max", cadence.Fix64(math.MaxInt64)},
+		{"UFix64", cadence.UFix64(math.MaxUint64)},
+		{"Path", cadence.Path{Domain: "storage", Identifier: "foo"}},
+		{
+			"Link",
+			cadence.NewLink(
+				cadence.Path{Domain: "storage", Identifier: "foo"},
+				"Int",
+			),
+		},
+		{
+			"Capability",
+			cadence.Capability{
+				Path:       cadence.Path{Domain: "public", Identifier: "foo"},
+				Address:    cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 2}),
+				BorrowType: cadence.ReferenceType{Type: cadence.IntType{}},
+			},
+		},
+		{
+			"Capability without borrow type",
+			cadence.Capability{
+				Path:    cadence.Path{Domain: "public", Identifier: "foo"},
+				Address: cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 2}),
+			},
+		},
+		{"Type", cadence.NewTypeValue(cadence.IntType{})},
+		{"Type without static type", cadence.NewTypeValue(nil)},
+	}...)
+}
+
+func TestEncodeArray(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"Empty", cadence.NewArray([]cadence.Value{})},
+		{
+			"Untyped",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.String("two"),
+			}),
+		},
+		{
+			"Variable-sized",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewInt(2),
+			}).WithType(cadence.VariableSizedArrayType{
+				ElementType: cadence.IntType{},
+			}),
+		},
+		{
+			"Constant-sized",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewUInt8(1),
+				cadence.NewUInt8(2),
+			}).WithType(cadence.ConstantSizedArrayType{
+				Size:        2,
+				ElementType: cadence.UInt8Type{},
+			}),
+		},
+		{
+			"Nested",
+			cadence.NewArray([]cadence.Value{
+				cadence.NewArray([]cadence.Value{
+					cadence.NewBool(true),
+				}),
+			}),
+		},
+	}...)
+}
+
+func TestEncodeDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{"Empty", cadence.NewDictionary([]cadence.KeyValuePair{})},
+		{
+			"Typed",
+			cadence.NewDictionary([]cadence.KeyValuePair{
+				{Key: cadence.String("b"), Value: cadence.NewInt(2)},
+				{Key: cadence.String("a"), Value: cadence.NewInt(1)},
+			}).WithType(cadence.DictionaryType{
+				KeyType:     cadence.StringType{},
+				ElementType: cadence.IntType{},
+			}),
+		},
+	}...)
+}
+
+var fooResourceType = &cadence.ResourceType{
+	Location:            utils.TestLocation,
+	QualifiedIdentifier: "Foo",
+	Fields: []cadence.Field{
+		{
+			Identifier: "uuid",
+			Type:       cadence.UInt64Type{},
+		},
+		{
+			Identifier: "bar",
+			Type:       cadence.IntType{},
+		},
+	},
+	Initializers: [][]cadence.Parameter{
+		{
+			{
+				Label:      "_",
+				Identifier: "bar",
+				Type:       cadence.IntType{},
+			},
+		},
+	},
+}
+
+var transferEventType = &cadence.EventType{
+	Location:            common.AddressLocation{Address: common.Address{0x1}, Name: "Token"},
+	QualifiedIdentifier: "Token.Transfer",
+	Fields: []cadence.Field{
+		{
+			Identifier: "amount",
+			Type:       cadence.UFix64Type{},
+		},
+		{
+			Identifier: "to",
+			Type:       cadence.OptionalType{Type: cadence.AddressType{}},
+		},
+	},
+	Initializer: []cadence.Parameter{
+		{
+			Label:      "amount",
+			Identifier: "amount",
+			Type:       cadence.UFix64Type{},
+		},
+		{
+			Label:      "to",
+			Identifier: "to",
+			Type:       cadence.OptionalType{Type: cadence.AddressType{}},
+		},
+	},
+}
+
+func newTransferEvent(amount uint64) cadence.Event {
+	return cadence.NewEvent([]cadence.Value{
+		cadence.UFix64(amount),
+		cadence.NewOptional(cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 2})),
+	}).WithType(transferEventType)
+}
+
+func TestEncodeComposites(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Struct",
+			cadence.NewStruct([]cadence.Value{
+				cadence.String("foo"),
+			}).WithType(&cadence.StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "Foo",
+				Fields: []cadence.Field{
+					{
+						Identifier: "bar",
+						Type:       cadence.StringType{},
+					},
+				},
+				Initializers: [][]cadence.Parameter{},
+			}),
+		},
+		{
+			"Struct without type",
+			cadence.NewStruct([]cadence.Value{
+				cadence.String("foo"),
+			}),
+		},
+		{
+			"Resource",
+			cadence.NewResource([]cadence.Value{
+				cadence.NewUInt64(1),
+				cadence.NewInt(42),
+			}).WithType(fooResourceType),
+		},
+		{
+			"Event",
+			newTransferEvent(100),
+		},
+		{
+			"Contract",
+			cadence.NewContract([]cadence.Value{}).WithType(&cadence.ContractType{
+				Location:            common.AddressLocation{Address: common.Address{0x1}, Name: "Token"},
+				QualifiedIdentifier: "Token",
+				Fields:              []cadence.Field{},
+				Initializers:        [][]cadence.Parameter{},
+			}),
+		},
+		{
+			"Enum",
+			cadence.NewEnum([]cadence.Value{
+				cadence.NewUInt8(1),
+			}).WithType(&cadence.EnumType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "Color",
+				RawType:             cadence.UInt8Type{},
+				Fields: []cadence.Field{
+					{
+						Identifier: sema.EnumRawValueFieldName,
+						Type:       cadence.UInt8Type{},
+					},
+				},
+				Initializers: [][]cadence.Parameter{},
+			}),
+		},
+		{
+			"Attachment",
+			cadence.NewAttachment([]cadence.Value{
+				cadence.NewInt(42),
+			}).WithType(&cadence.AttachmentType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "A",
+				BaseType:            fooResourceType,
+				Fields: []cadence.Field{
+					{
+						Identifier: "bar",
+						Type:       cadence.IntType{},
+					},
+				},
+				Initializers: [][]cadence.Parameter{},
+			}),
+		},
+		{
+			"Nested composite",
+			cadence.NewResource([]cadence.Value{
+				cadence.NewUInt64(2),
+				cadence.NewArray([]cadence.Value{
+					cadence.NewResource([]cadence.Value{
+						cadence.NewUInt64(1),
+						cadence.NewInt(42),
+					}).WithType(fooResourceType),
+				}),
+			}).WithType(&cadence.ResourceType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "Collection",
+				Fields: []cadence.Field{
+					{
+						Identifier: "uuid",
+						Type:       cadence.UInt64Type{},
+					},
+					{
+						Identifier: "items",
+						Type: cadence.VariableSizedArrayType{
+							ElementType: fooResourceType,
+						},
+					},
+				},
+				Initializers: [][]cadence.Parameter{},
+			}),
+		},
+	}...)
+}
+
+func TestEncodeTypeDefinitionsOnce(t *testing.T) {
+
+	t.Parallel()
+
+	events := cadence.NewArray([]cadence.Value{
+		newTransferEvent(1),
+		newTransferEvent(2),
+		newTransferEvent(3),
+	})
+
+	encoded := testEncodeAndDecode(t, events)
+
+	// The type definition, and hence the type ID and the field names,
+	// are only encoded once, independent of the number of events.
+	// "amount" is the name of a field, and the label and identifier of an initializer parameter
+
+	assert.Equal(t, 1, countOccurrences(encoded, transferEventType.ID()))
+	assert.Equal(t, 3, countOccurrences(encoded, "amount"))
+}
+
+func countOccurrences(data []byte, s string) int {
+	count := 0
+	for i := 0; i+len(s) <= len(data); i++ {
+		if string(data[i:i+len(s)]) == s {
+			count++
+		}
+	}
+	return count
+}
+
+func TestEncodeSmallerThanJSON(t *testing.T) {
+
+	t.Parallel()
+
+	for _, value := range []cadence.Value{
+		newTransferEvent(100),
+		cadence.NewArray([]cadence.Value{
+			newTransferEvent(1),
+			newTransferEvent(2),
+		}),
+	} {
+		encodedCCF, err := ccf.Encode(value)
+		require.NoError(t, err)
+
+		encodedJSON, err := json.Encode(value)
+		require.NoError(t, err)
+
+		assert.Less(t, len(encodedCCF)*2, len(encodedJSON))
+	}
+}
+
+func TestEncodeDeterministic(t *testing.T) {
+
+	t.Parallel()
+
+	value := cadence.NewArray([]cadence.Value{
+		newTransferEvent(1),
+		cadence.NewResource([]cadence.Value{
+			cadence.NewUInt64(1),
+			cadence.NewInt(42),
+		}).WithType(fooResourceType),
+		newTransferEvent(2),
+	})
+
+	first, err := ccf.Encode(value)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		encoded, err := ccf.Encode(value)
+		require.NoError(t, err)
+		require.Equal(t, first, encoded)
+	}
+}
+
+func TestEncodeRecursiveType(t *testing.T) {
+
+	t.Parallel()
+
+	ty := &cadence.StructType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Node",
+		Fields: []cadence.Field{
+			{
+				Identifier: "next",
+			},
+		},
+		Initializers: [][]cadence.Parameter{},
+	}
+
+	ty.Fields[0].Type = cadence.OptionalType{
+		Type: ty,
+	}
+
+	testEncodeAndDecode(
+		t,
+		cadence.NewStruct([]cadence.Value{
+			cadence.NewOptional(
+				cadence.NewStruct([]cadence.Value{
+					cadence.NewOptional(nil),
+				}).WithType(ty),
+			),
+		}).WithType(ty),
+	)
+
+	testEncodeAndDecodeType(t, cadence.VariableSizedArrayType{
+		ElementType: ty,
+	})
+}
+
+func TestEncodeType(t *testing.T) {
+
+	t.Parallel()
+
+	simpleTypes := []cadence.Type{
+		cadence.AnyType{},
+		cadence.AnyStructType{},
+		cadence.AnyResourceType{},
+		cadence.MetaType{},
+		cadence.VoidType{},
+		cadence.NeverType{},
+		cadence.BoolType{},
+		cadence.StringType{},
+		cadence.CharacterType{},
+		cadence.BytesType{},
+		cadence.AddressType{},
+		cadence.NumberType{},
+		cadence.SignedNumberType{},
+		cadence.IntegerType{},
+		cadence.SignedIntegerType{},
+		cadence.FixedPointType{},
+		cadence.SignedFixedPointType{},
+		cadence.IntType{},
+		cadence.Int8Type{},
+		cadence.Int16Type{},
+		cadence.Int32Type{},
+		cadence.Int64Type{},
+		cadence.Int128Type{},
+		cadence.Int256Type{},
+		cadence.UIntType{},
+		cadence.UInt8Type{},
+		cadence.UInt16Type{},
+		cadence.UInt32Type{},
+		cadence.UInt64Type{},
+		cadence.UInt128Type{},
+		cadence.UInt256Type{},
+		cadence.Word8Type{},
+		cadence.Word16Type{},
+		cadence.Word32Type{},
+		cadence.Word64Type{},
+		cadence.Fix64Type{},
+		cadence.UFix64Type{},
+		cadence.BlockType{},
+		cadence.BlockHeaderType{},
+		cadence.PathType{},
+		cadence.CapabilityPathType{},
+		cadence.StoragePathType{},
+		cadence.PublicPathType{},
+		cadence.PrivatePathType{},
+		cadence.AuthAccountType{},
+		cadence.PublicAccountType{},
+		cadence.DeployedContractType{},
+		cadence.AuthAccountContractsType{},
+		cadence.PublicAccountContractsType{},
+		cadence.AuthAccountKeysType{},
+		cadence.PublicAccountKeysType{},
+		cadence.AccountKeyType{},
+	}
+
+	for _, ty := range simpleTypes {
+		ty := ty
+		t.Run(ty.ID(), func(t *testing.T) {
+			t.Parallel()
+
+			testEncodeAndDecodeType(t, ty)
+		})
+	}
+
+	interfaceType := &cadence.ResourceInterfaceType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Receiver",
+		Fields:              []cadence.Field{},
+		Initializers:        [][]cadence.Parameter{},
+	}
+
+	complexTypes := []cadence.Type{
+		cadence.OptionalType{Type: cadence.IntType{}},
+		cadence.DictionaryType{
+			KeyType:     cadence.StringType{},
+			ElementType: cadence.OptionalType{Type: cadence.AnyStructType{}},
+		},
+		cadence.ReferenceType{Authorized: true, Type: fooResourceType},
+		cadence.ReferenceType{
+			Authorization: &cadence.EntitlementSetAuthorization{
+				Entitlements: []common.TypeID{"S.test.E", "S.test.F"},
+				Kind:         cadence.Conjunction,
+			},
+			Type: fooResourceType,
+		},
+		cadence.RestrictedType{
+			Type:         fooResourceType,
+			Restrictions: []cadence.Type{interfaceType},
+		}.WithID("S.test.Foo{S.test.Receiver}"),
+		cadence.CapabilityType{},
+		cadence.CapabilityType{
+			BorrowType: cadence.ReferenceType{Type: interfaceType},
+		},
+		cadence.FunctionType{
+			Parameters: []cadence.Parameter{
+				{
+					Label:      "_",
+					Identifier: "x",
+					Type:       cadence.IntType{},
+				},
+			},
+			ReturnType: cadence.StringType{},
+		}.WithID("((Int):String)"),
+		&cadence.StructInterfaceType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "SI",
+			Fields:              []cadence.Field{},
+			Initializers:        [][]cadence.Parameter{},
+		},
+		&cadence.ContractInterfaceType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "CI",
+			Fields:              []cadence.Field{},
+			Initializers:        [][]cadence.Parameter{},
+		},
+		transferEventType,
+	}
+
+	for _, ty := range complexTypes {
+		ty := ty
+		t.Run(ty.ID(), func(t *testing.T) {
+			t.Parallel()
+
+			testEncodeAndDecodeType(t, ty)
+		})
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("non-UTF-8 string", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ccf.Encode(cadence.String("\xbd\xb2\x3d\xbc\x20\xe2"))
+		require.Error(t, err)
+	})
+}
+
+func TestDecodeInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	valid := ccf.MustEncode(cadence.NewInt8(1))
+
+	test := func(name string, data []byte) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ccf.Decode(data)
+			require.Error(t, err)
+		})
+	}
+
+	test("empty", []byte{})
+	test("trailing data", append(append([]byte{}, valid...), 0x0))
+	test("truncated", valid[:len(valid)-1])
+	// [[], tag(Int8) 200]
+	test("Int8 out of range", []byte{0x82, 0x80, 0xd8, ccf.CBORTagInt8, 0x18, 0xc8})
+	// [[], tag(Struct) [0, []]]
+	test("invalid type definition index", []byte{0x82, 0x80, 0xd8, ccf.CBORTagStruct, 0x82, 0x0, 0x80})
+	// [[], tag(255) null]
+	test("unknown tag", []byte{0x82, 0x80, 0xd8, 0xff, 0xf6})
+	// [[], tag(Address) h'01']
+	test("invalid address", []byte{0x82, 0x80, 0xd8, ccf.CBORTagAddress, 0x41, 0x1})
+	// [[], tag(UInt) -1]
+	test("negative UInt", []byte{0x82, 0x80, 0xd8, ccf.CBORTagUInt, 0xc3, 0x40})
+}
+
+func TestFuzz(t *testing.T) {
+
+	t.Parallel()
+
+	corpus := [][]byte{
+		ccf.MustEncode(newTransferEvent(100)),
+		ccf.MustEncode(cadence.NewArray([]cadence.Value{
+			cadence.NewResource([]cadence.Value{
+				cadence.NewUInt64(1),
+				cadence.NewInt(42),
+			}).WithType(fooResourceType),
+			cadence.NewDictionary([]cadence.KeyValuePair{
+				{Key: cadence.String("a"), Value: cadence.NewOptional(nil)},
+			}),
+		})),
+	}
+
+	for _, data := range corpus {
+		require.Equal(t, 1, ccf.Fuzz(data))
+	}
+
+	random := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 10000; i++ {
+		data := append([]byte{}, corpus[random.Intn(len(corpus))]...)
+
+		mutations := 1 + random.Intn(4)
+		for j := 0; j < mutations; j++ {
+			data[random.Intn(len(data))] = byte(random.Intn(256))
+		}
+
+		require.NotPanics(t, func() {
+			ccf.Fuzz(data)
+		})
+	}
+}
+
+func testAllEncodeAndDecode(t *testing.T, tests ...encodeTest) {
+
+	test := func(testCase encodeTest) {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			testEncodeAndDecode(t, testCase.val)
+		})
+	}
+
+	for _, testCase := range tests {
+		test(testCase)
+	}
+}
+
+func testEncodeAndDecode(t *testing.T, val cadence.Value) []byte {
+	encoded, err := ccf.Encode(val)
+	require.NoError(t, err)
+
+	decoded, err := ccf.Decode(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, val, decoded)
+
+	return encoded
+}
+
+func testEncodeAndDecodeType(t *testing.T, ty cadence.Type) {
+	encoded, err := ccf.EncodeType(ty)
+	require.NoError(t, err)
+
+	decoded, err := ccf.DecodeType(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, ty, decoded)
+}
@@ -0,0 +1,1309 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// CBORDecMode
+//
+// See https://github.com/fxamacker/cbor:
+// "For best performance, reuse EncMode and DecMode after creating them."
+//
+var CBORDecMode = func() cbor.DecMode {
+	decMode, err := cbor.DecOptions{
+		IntDec:           cbor.IntDecConvertNone,
+		MaxArrayElements: math.MaxInt64,
+		MaxMapPairs:      math.MaxInt64,
+		MaxNestedLevels:  math.MaxInt16,
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return decMode
+}()
+
+var ErrInvalidCCF = errors.New("invalid CCF")
+
+// A Decoder decodes CCF-encoded representations of Cadence values.
+//
+type Decoder struct {
+	dec *cbor.StreamDecoder
+}
+
+// Decode returns a Cadence value decoded from its CCF-encoded representation.
+//
+// This function returns an error if the bytes are malformed
+// or do not conform to the CCF specification.
+//
+func Decode(b []byte) (cadence.Value, error) {
+	dec := CBORDecMode.NewByteStreamDecoder(b)
+
+	value, err := (&Decoder{dec: dec}).Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	if dec.NumBytesDecoded() != len(b) {
+		return nil, fmt.Errorf("failed to decode value: %w: trailing data", ErrInvalidCCF)
+	}
+
+	return value, nil
+}
+
+// DecodeType returns a Cadence type decoded from its CCF-encoded representation.
+//
+// This function returns an error if the bytes are malformed
+// or do not conform to the CCF specification.
+//
+func DecodeType(b []byte) (cadence.Type, error) {
+	dec := CBORDecMode.NewByteStreamDecoder(b)
+
+	var typ cadence.Type
+	err := decodeMessage(dec, func(d *decoder) (err error) {
+		typ, err = d.decodeType()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode type: %w", err)
+	}
+
+	if dec.NumBytesDecoded() != len(b) {
+		return nil, fmt.Errorf("failed to decode type: %w: trailing data", ErrInvalidCCF)
+	}
+
+	return typ, nil
+}
+
+// NewDecoder initializes a Decoder that will decode CCF-encoded bytes from the
+// given io.Reader.
+//
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: CBORDecMode.NewStreamDecoder(r)}
+}
+
+// Decode reads CCF-encoded bytes from the io.Reader and decodes them to a
+// Cadence value.
+//
+// This function returns an error if the bytes are malformed
+// or do not conform to the CCF specification.
+//
+func (d *Decoder) Decode() (value cadence.Value, err error) {
+	err = decodeMessage(d.dec, func(d *decoder) (err error) {
+		value, err = d.decodeValue()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode value: %w", err)
+	}
+
+	return value, nil
+}
+
+// decoder decodes the body of a message,
+// resolving references to the type definitions of the message.
+//
+type decoder struct {
+	dec   *cbor.StreamDecoder
+	types []cadence.Type
+}
+
+// decodeMessage reads a message, i.e. the type definitions and the body.
+//
+// The type definitions are decoded in two passes:
+// First, all types are created, so that definitions can refer to each other,
+// independent of their order, and recursively.
+// Second, the fields and initializers of the types are decoded.
+//
+func decodeMessage(dec *cbor.StreamDecoder, decodeBody func(d *decoder) error) error {
+	err := decodeArrayHead(dec, 2)
+	if err != nil {
+		return err
+	}
+
+	count, err := dec.DecodeArrayHead()
+	if err != nil {
+		return err
+	}
+
+	var definitions [][]byte
+	for i := uint64(0); i < count; i++ {
+		definition, err := dec.DecodeRawBytes()
+		if err != nil {
+			return err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	d := &decoder{
+		dec: dec,
+	}
+
+	for _, definition := range definitions {
+		typ, err := newDefinedType(CBORDecMode.NewByteStreamDecoder(definition))
+		if err != nil {
+			return err
+		}
+		d.types = append(d.types, typ)
+	}
+
+	for i, definition := range definitions {
+		definitionDecoder := &decoder{
+			dec:   CBORDecMode.NewByteStreamDecoder(definition),
+			types: d.types,
+		}
+		err = definitionDecoder.decodeTypeDefinition(d.types[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return decodeBody(d)
+}
+
+func decodeArrayHead(dec *cbor.StreamDecoder, expectedLength uint64) error {
+	length, err := dec.DecodeArrayHead()
+	if err != nil {
+		return err
+	}
+	if length != expectedLength {
+		return fmt.Errorf(
+			"%w: expected array of length %d, got %d",
+			ErrInvalidCCF,
+			expectedLength,
+			length,
+		)
+	}
+	return nil
+}
+
+func (d *decoder) decodeValue() (cadence.Value, error) {
+	t, err := d.dec.NextType()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case cbor.BoolType:
+		b, err := d.dec.DecodeBool()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewBool(b), nil
+
+	case cbor.TextStringType:
+		s, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.String(s), nil
+
+	case cbor.TagType:
+		// handled below
+
+	default:
+		return nil, fmt.Errorf("%w: unexpected value of type %s", ErrInvalidCCF, t)
+	}
+
+	tag, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case CBORTagVoid:
+		err = d.dec.DecodeNil()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewVoid(), nil
+
+	case CBORTagOptional:
+		return d.decodeOptional()
+
+	case CBORTagBytes:
+		b, err := d.dec.DecodeBytes()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewBytes(b), nil
+
+	case CBORTagAddress:
+		address, err := d.decodeAddress()
+		if err != nil {
+			return nil, err
+		}
+		return address, nil
+
+	case CBORTagInt:
+		i, err := d.dec.DecodeBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewIntFromBig(i), nil
+
+	case CBORTagInt8:
+		i, err := d.decodeInt64(math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt8(int8(i)), nil
+
+	case CBORTagInt16:
+		i, err := d.decodeInt64(math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt16(int16(i)), nil
+
+	case CBORTagInt32:
+		i, err := d.decodeInt64(math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt32(int32(i)), nil
+
+	case CBORTagInt64:
+		i, err := d.decodeInt64(math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt64(i), nil
+
+	case CBORTagInt128:
+		i, err := d.dec.DecodeBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt128FromBig(i)
+
+	case CBORTagInt256:
+		i, err := d.dec.DecodeBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewInt256FromBig(i)
+
+	case CBORTagUInt:
+		i, err := d.dec.DecodeBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUIntFromBig(i)
+
+	case CBORTagUInt8:
+		i, err := d.decodeUint64(math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt8(uint8(i)), nil
+
+	case CBORTagUInt16:
+		i, err := d.decodeUint64(math.MaxUint16)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt16(uint16(i)), nil
+
+	case CBORTagUInt32:
+		i, err := d.decodeUint64(math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt32(uint32(i)), nil
+
+	case CBORTagUInt64:
+		i, err := d.decodeUint64(math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt64(i), nil
+
+	case CBORTagUInt128:
+		i, err := d.dec.DecodeBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt128FromBig(i)
+
+	case CBORTagUInt256:
+		i, err := d.dec.DecodeBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewUInt256FromBig(i)
+
+	case CBORTagWord8:
+		i, err := d.decodeUint64(math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord8(uint8(i)), nil
+
+	case CBORTagWord16:
+		i, err := d.decodeUint64(math.MaxUint16)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord16(uint16(i)), nil
+
+	case CBORTagWord32:
+		i, err := d.decodeUint64(math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord32(uint32(i)), nil
+
+	case CBORTagWord64:
+		i, err := d.decodeUint64(math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewWord64(i), nil
+
+	case CBORTagFix64:
+		i, err := d.decodeInt64(math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.Fix64(i), nil
+
+	case CBORTagUFix64:
+		i, err := d.decodeUint64(math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.UFix64(i), nil
+
+	case CBORTagArray:
+		return d.decodeArray()
+
+	case CBORTagDictionary:
+		return d.decodeDictionary()
+
+	case CBORTagStruct:
+		typ, fields, err := d.decodeComposite()
+		if err != nil {
+			return nil, err
+		}
+		value := cadence.NewStruct(fields)
+		if typ != nil {
+			structType, ok := typ.(*cadence.StructType)
+			if !ok {
+				return nil, unexpectedTypeError(typ)
+			}
+			value = value.WithType(structType)
+		}
+		return value, nil
+
+	case CBORTagResource:
+		typ, fields, err := d.decodeComposite()
+		if err != nil {
+			return nil, err
+		}
+		value := cadence.NewResource(fields)
+		if typ != nil {
+			resourceType, ok := typ.(*cadence.ResourceType)
+			if !ok {
+				return nil, unexpectedTypeError(typ)
+			}
+			value = value.WithType(resourceType)
+		}
+		return value, nil
+
+	case CBORTagEvent:
+		typ, fields, err := d.decodeComposite()
+		if err != nil {
+			return nil, err
+		}
+		value := cadence.NewEvent(fields)
+		if typ != nil {
+			eventType, ok := typ.(*cadence.EventType)
+			if !ok {
+				return nil, unexpectedTypeError(typ)
+			}
+			value = value.WithType(eventType)
+		}
+		return value, nil
+
+	case CBORTagContract:
+		typ, fields, err := d.decodeComposite()
+		if err != nil {
+			return nil, err
+		}
+		value := cadence.NewContract(fields)
+		if typ != nil {
+			contractType, ok := typ.(*cadence.ContractType)
+			if !ok {
+				return nil, unexpectedTypeError(typ)
+			}
+			value = value.WithType(contractType)
+		}
+		return value, nil
+
+	case CBORTagEnum:
+		typ, fields, err := d.decodeComposite()
+		if err != nil {
+			return nil, err
+		}
+		value := cadence.NewEnum(fields)
+		if typ != nil {
+			enumType, ok := typ.(*cadence.EnumType)
+			if !ok {
+				return nil, unexpectedTypeError(typ)
+			}
+			value = value.WithType(enumType)
+		}
+		return value, nil
+
+	case CBORTagAttachment:
+		typ, fields, err := d.decodeComposite()
+		if err != nil {
+			return nil, err
+		}
+		value := cadence.NewAttachment(fields)
+		if typ != nil {
+			attachmentType, ok := typ.(*cadence.AttachmentType)
+			if !ok {
+				return nil, unexpectedTypeError(typ)
+			}
+			value = value.WithType(attachmentType)
+		}
+		return value, nil
+
+	case CBORTagLink:
+		return d.decodeLink()
+
+	case CBORTagPath:
+		return d.decodePath()
+
+	case CBORTagTypeValue:
+		staticType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewTypeValue(staticType), nil
+
+	case CBORTagCapability:
+		return d.decodeCapability()
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported value tag: %d", ErrInvalidCCF, tag)
+	}
+}
+
+func unexpectedTypeError(typ cadence.Type) error {
+	return fmt.Errorf("%w: unexpected type: %s", ErrInvalidCCF, typ.ID())
+}
+
+func (d *decoder) decodeInt64(min, max int64) (int64, error) {
+	i, err := d.dec.DecodeInt64()
+	if err != nil {
+		return 0, err
+	}
+	if i < min || i > max {
+		return 0, fmt.Errorf("%w: integer out of range: %d", ErrInvalidCCF, i)
+	}
+	return i, nil
+}
+
+func (d *decoder) decodeUint64(max uint64) (uint64, error) {
+	i, err := d.dec.DecodeUint64()
+	if err != nil {
+		return 0, err
+	}
+	if i > max {
+		return 0, fmt.Errorf("%w: integer out of range: %d", ErrInvalidCCF, i)
+	}
+	return i, nil
+}
+
+func (d *decoder) decodeAddress() (cadence.Address, error) {
+	b, err := d.dec.DecodeBytes()
+	if err != nil {
+		return cadence.Address{}, err
+	}
+	if len(b) != cadence.AddressLength {
+		return cadence.Address{}, fmt.Errorf("%w: invalid address length: %d", ErrInvalidCCF, len(b))
+	}
+	return cadence.BytesToAddress(b), nil
+}
+
+func (d *decoder) decodeOptional() (cadence.Value, error) {
+	t, err := d.dec.NextType()
+	if err != nil {
+		return nil, err
+	}
+
+	if t == cbor.NilType {
+		err = d.dec.DecodeNil()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(nil), nil
+	}
+
+	value, err := d.decodeValue()
+	if err != nil {
+		return nil, err
+	}
+	return cadence.NewOptional(value), nil
+}
+
+func (d *decoder) decodeArray() (cadence.Value, error) {
+	err := decodeArrayHead(d.dec, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	typ, err := d.decodeType()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]cadence.Value, 0)
+	for i := uint64(0); i < count; i++ {
+		value, err := d.decodeValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	array := cadence.NewArray(values)
+	if typ != nil {
+		arrayType, ok := typ.(cadence.ArrayType)
+		if !ok {
+			return nil, unexpectedTypeError(typ)
+		}
+		array = array.WithType(arrayType)
+	}
+	return array, nil
+}
+
+func (d *decoder) decodeDictionary() (cadence.Value, error) {
+	err := decodeArrayHead(d.dec, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	typ, err := d.decodeType()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+	if count%2 != 0 {
+		return nil, fmt.Errorf("%w: odd number of dictionary keys and values", ErrInvalidCCF)
+	}
+
+	pairs := make([]cadence.KeyValuePair, 0)
+	for i := uint64(0); i < count; i += 2 {
+		key, err := d.decodeValue()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := d.decodeValue()
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, cadence.KeyValuePair{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	dictionary := cadence.NewDictionary(pairs)
+	dictionary.DictionaryType = typ
+	return dictionary, nil
+}
+
+func (d *decoder) decodeComposite() (cadence.Type, []cadence.Value, error) {
+	err := decodeArrayHead(d.dec, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var typ cadence.Type
+
+	t, err := d.dec.NextType()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if t == cbor.NilType {
+		err = d.dec.DecodeNil()
+	} else {
+		typ, err = d.decodeTypeIndex()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := make([]cadence.Value, 0)
+	for i := uint64(0); i < count; i++ {
+		field, err := d.decodeValue()
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	return typ, fields, nil
+}
+
+func (d *decoder) decodeTypeIndex() (cadence.Type, error) {
+	index, err := d.dec.DecodeUint64()
+	if err != nil {
+		return nil, err
+	}
+	if index >= uint64(len(d.types)) {
+		return nil, fmt.Errorf("%w: invalid type definition index: %d", ErrInvalidCCF, index)
+	}
+	return d.types[index], nil
+}
+
+func (d *decoder) decodeLink() (cadence.Value, error) {
+	err := decodeArrayHead(d.dec, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	targetPath, err := d.decodePath()
+	if err != nil {
+		return nil, err
+	}
+
+	borrowType, err := d.dec.DecodeString()
+	if err != nil {
+		return nil, err
+	}
+
+	return cadence.NewLink(targetPath, borrowType), nil
+}
+
+func (d *decoder) decodePath() (cadence.Path, error) {
+	err := decodeArrayHead(d.dec, 2)
+	if err != nil {
+		return cadence.Path{}, err
+	}
+
+	domain, err := d.dec.DecodeString()
+	if err != nil {
+		return cadence.Path{}, err
+	}
+
+	identifier, err := d.dec.DecodeString()
+	if err != nil {
+		return cadence.Path{}, err
+	}
+
+	return cadence.Path{
+		Domain:     domain,
+		Identifier: identifier,
+	}, nil
+}
+
+func (d *decoder) decodeCapability() (cadence.Value, error) {
+	// The ID is optional, it is only encoded for capabilities issued by capability controllers
+	length, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+	if length != 3 && length != 4 {
+		return nil, fmt.Errorf(
+			"%w: expected array of length 3 or 4, got %d",
+			ErrInvalidCCF,
+			length,
+		)
+	}
+
+	path, err := d.decodePath()
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := d.decodeAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	borrowType, err := d.decodeType()
+	if err != nil {
+		return nil, err
+	}
+
+	var id uint64
+	if length == 4 {
+		id, err = d.dec.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cadence.Capability{
+		Path:       path,
+		Address:    address,
+		BorrowType: borrowType,
+		ID:         cadence.UInt64(id),
+	}, nil
+}
+
+func (d *decoder) decodeType() (cadence.Type, error) {
+	t, err := d.dec.NextType()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case cbor.NilType:
+		return nil, d.dec.DecodeNil()
+
+	case cbor.TagType:
+		// handled below
+
+	default:
+		return nil, fmt.Errorf("%w: unexpected type of type %s", ErrInvalidCCF, t)
+	}
+
+	tag, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case CBORTagSimpleType:
+		simple, err := d.dec.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+		typ, ok := simpleTypes[simpleType(simple)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported simple type: %d", ErrInvalidCCF, simple)
+		}
+		return typ, nil
+
+	case CBORTagOptionalType:
+		innerType, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.OptionalType{Type: innerType}, nil
+
+	case CBORTagVariableSizedArrayType:
+		elementType, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.VariableSizedArrayType{ElementType: elementType}, nil
+
+	case CBORTagConstantSizedArrayType:
+		err = decodeArrayHead(d.dec, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := d.decodeUint64(math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+
+		elementType, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.ConstantSizedArrayType{
+			Size:        uint(size),
+			ElementType: elementType,
+		}, nil
+
+	case CBORTagDictionaryType:
+		err = decodeArrayHead(d.dec, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		keyType, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+
+		elementType, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+
+		return cadence.DictionaryType{
+			KeyType:     keyType,
+			ElementType: elementType,
+		}, nil
+
+	case CBORTagReferenceType:
+		// The authorization is only encoded for entitled references
+		length, err := d.dec.DecodeArrayHead()
+		if err != nil {
+			return nil, err
+		}
+		if length != 2 && length != 3 {
+			return nil, fmt.Errorf(
+				"%w: expected array of length 2 or 3, got %d",
+				ErrInvalidCCF,
+				length,
+			)
+		}
+
+		authorized, err := d.dec.DecodeBool()
+		if err != nil {
+			return nil, err
+		}
+
+		referencedType, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+
+		var authorization *cadence.EntitlementSetAuthorization
+		if length == 3 {
+			authorization, err = d.decodeEntitlementSetAuthorization()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return cadence.ReferenceType{
+			Authorized:    authorized,
+			Authorization: authorization,
+			Type:          referencedType,
+		}, nil
+
+	case CBORTagRestrictedType:
+		return d.decodeRestrictedType()
+
+	case CBORTagCapabilityType:
+		borrowType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+		return cadence.CapabilityType{BorrowType: borrowType}, nil
+
+	case CBORTagFunctionType:
+		return d.decodeFunctionType()
+
+	case CBORTagTypeRef:
+		return d.decodeTypeIndex()
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported type tag: %d", ErrInvalidCCF, tag)
+	}
+}
+
+// decodeNonNilType decodes a type which must be present,
+// e.g. the inner type of an optional type.
+//
+func (d *decoder) decodeNonNilType() (cadence.Type, error) {
+	typ, err := d.decodeType()
+	if err != nil {
+		return nil, err
+	}
+	if typ == nil {
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidCCF)
+	}
+	return typ, nil
+}
+
+func (d *decoder) decodeRestrictedType() (cadence.Type, error) {
+	err := decodeArrayHead(d.dec, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, err := d.dec.DecodeString()
+	if err != nil {
+		return nil, err
+	}
+
+	restrictedType, err := d.decodeType()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions := make([]cadence.Type, 0)
+	for i := uint64(0); i < count; i++ {
+		restriction, err := d.decodeNonNilType()
+		if err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, restriction)
+	}
+
+	return cadence.RestrictedType{
+		Type:         restrictedType,
+		Restrictions: restrictions,
+	}.WithID(typeID), nil
+}
+
+func (d *decoder) decodeFunctionType() (cadence.Type, error) {
+	err := decodeArrayHead(d.dec, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, err := d.dec.DecodeString()
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := d.decodeParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	returnType, err := d.decodeType()
+	if err != nil {
+		return nil, err
+	}
+
+	return cadence.FunctionType{
+		Parameters: parameters,
+		ReturnType: returnType,
+	}.WithID(typeID), nil
+}
+
+// newDefinedType creates the composite or interface type
+// for the given type definition, without its fields and initializers.
+//
+func newDefinedType(dec *cbor.StreamDecoder) (cadence.Type, error) {
+	tag, err := dec.DecodeTagNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, err := dec.DecodeString()
+	if err != nil {
+		return nil, err
+	}
+
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid type ID %q: %s", ErrInvalidCCF, typeID, err)
+	}
+
+	switch tag {
+	case CBORTagStructTypeDef:
+		return &cadence.StructType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagResourceTypeDef:
+		return &cadence.ResourceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagEventTypeDef:
+		return &cadence.EventType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagContractTypeDef:
+		return &cadence.ContractType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagEnumTypeDef:
+		return &cadence.EnumType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagAttachmentTypeDef:
+		return &cadence.AttachmentType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagStructInterfaceTypeDef:
+		return &cadence.StructInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagResourceInterfaceTypeDef:
+		return &cadence.ResourceInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	case CBORTagContractInterfaceTypeDef:
+		return &cadence.ContractInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported type definition tag: %d", ErrInvalidCCF, tag)
+	}
+}
+
+// decodeTypeDefinition decodes the fields and initializers of the given type,
+// which was created by newDefinedType from the same definition.
+//
+func (d *decoder) decodeTypeDefinition(typ cadence.Type) error {
+	_, err := d.dec.DecodeTagNumber()
+	if err != nil {
+		return err
+	}
+
+	enumType, isEnum := typ.(*cadence.EnumType)
+	attachmentType, isAttachment := typ.(*cadence.AttachmentType)
+
+	length := uint64(3)
+	if isEnum || isAttachment {
+		length++
+	}
+
+	err = decodeArrayHead(d.dec, length)
+	if err != nil {
+		return err
+	}
+
+	// The type ID was already decoded by newDefinedType
+	err = d.dec.Skip()
+	if err != nil {
+		return err
+	}
+
+	if isEnum {
+		enumType.RawType, err = d.decodeType()
+		if err != nil {
+			return err
+		}
+	}
+
+	if isAttachment {
+		attachmentType.BaseType, err = d.decodeType()
+		if err != nil {
+			return err
+		}
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return err
+	}
+
+	fields := make([]cadence.Field, 0)
+	for i := uint64(0); i < count; i++ {
+		err = decodeArrayHead(d.dec, 2)
+		if err != nil {
+			return err
+		}
+
+		identifier, err := d.dec.DecodeString()
+		if err != nil {
+			return err
+		}
+
+		fieldType, err := d.decodeType()
+		if err != nil {
+			return err
+		}
+
+		fields = append(fields, cadence.Field{
+			Identifier: identifier,
+			Type:       fieldType,
+		})
+	}
+
+	if eventType, ok := typ.(*cadence.EventType); ok {
+		eventType.Fields = fields
+		eventType.Initializer, err = d.decodeParameters()
+		return err
+	}
+
+	count, err = d.dec.DecodeArrayHead()
+	if err != nil {
+		return err
+	}
+
+	initializers := make([][]cadence.Parameter, 0)
+	for i := uint64(0); i < count; i++ {
+		parameters, err := d.decodeParameters()
+		if err != nil {
+			return err
+		}
+		initializers = append(initializers, parameters)
+	}
+
+	switch t := typ.(type) {
+	case *cadence.StructType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.ResourceType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.ContractType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.EnumType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.AttachmentType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.StructInterfaceType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.ResourceInterfaceType:
+		t.Fields = fields
+		t.Initializers = initializers
+	case *cadence.ContractInterfaceType:
+		t.Fields = fields
+		t.Initializers = initializers
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeParameters() ([]cadence.Parameter, error) {
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := make([]cadence.Parameter, 0)
+	for i := uint64(0); i < count; i++ {
+		err = decodeArrayHead(d.dec, 3)
+		if err != nil {
+			return nil, err
+		}
+
+		label, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		identifier, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+
+		parameterType, err := d.decodeType()
+		if err != nil {
+			return nil, err
+		}
+
+		parameters = append(parameters, cadence.Parameter{
+			Label:      label,
+			Identifier: identifier,
+			Type:       parameterType,
+		})
+	}
+
+	return parameters, nil
+}
+
+// decodeEntitlementSetAuthorization decodes the authorization of an entitled reference type,
+// encoded as [kind: uint, entitlements: [+ string]]
+//
+func (d *decoder) decodeEntitlementSetAuthorization() (*cadence.EntitlementSetAuthorization, error) {
+	err := decodeArrayHead(d.dec, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, err := d.dec.DecodeUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	switch cadence.EntitlementSetKind(kind) {
+	case cadence.Conjunction, cadence.Disjunction:
+		break
+	default:
+		return nil, fmt.Errorf(
+			"%w: invalid entitlement set kind %d",
+			ErrInvalidCCF,
+			kind,
+		)
+	}
+
+	count, err := d.dec.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, fmt.Errorf(
+			"%w: missing entitlements",
+			ErrInvalidCCF,
+		)
+	}
+
+	entitlements := make([]common.TypeID, count)
+	for i := range entitlements {
+		entitlement, err := d.dec.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		entitlements[i] = common.TypeID(entitlement)
+	}
+
+	return &cadence.EntitlementSetAuthorization{
+		Entitlements: entitlements,
+		Kind:         cadence.EntitlementSetKind(kind),
+	}, nil
+}
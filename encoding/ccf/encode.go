@@ -0,0 +1,1094 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence"
+)
+
+// CBOREncMode
+//
+// See https://github.com/fxamacker/cbor:
+// "For best performance, reuse EncMode and DecMode after creating them."
+//
+var CBOREncMode = func() cbor.EncMode {
+	options := cbor.CanonicalEncOptions()
+	options.BigIntConvert = cbor.BigIntConvertNone
+	encMode, err := options.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return encMode
+}()
+
+// An Encoder converts Cadence values into CCF-encoded bytes.
+//
+type Encoder struct {
+	w io.Writer
+}
+
+// Encode returns the CCF-encoded representation of the given value.
+//
+// This function returns an error if the Cadence value cannot be represented in CCF.
+//
+func Encode(value cadence.Value) ([]byte, error) {
+	var w bytes.Buffer
+	enc := NewEncoder(&w)
+
+	err := enc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// MustEncode returns the CCF-encoded representation of the given value, or panics
+// if the value cannot be represented in CCF.
+//
+func MustEncode(value cadence.Value) []byte {
+	b, err := Encode(value)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// EncodeType returns the CCF-encoded representation of the given type.
+//
+// This function returns an error if the Cadence type cannot be represented in CCF.
+//
+func EncodeType(typ cadence.Type) ([]byte, error) {
+	var w bytes.Buffer
+
+	err := encodeMessage(&w, func(e *encoder) error {
+		return e.encodeType(typ)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode type: %w", err)
+	}
+
+	return w.Bytes(), nil
+}
+
+// NewEncoder initializes an Encoder that will write CCF-encoded bytes to the
+// given io.Writer.
+//
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the CCF-encoded representation of the given value to this
+// encoder's io.Writer.
+//
+// This function returns an error if the given value's type is not supported
+// by this encoder.
+//
+func (e *Encoder) Encode(value cadence.Value) error {
+	err := encodeMessage(e.w, func(e *encoder) error {
+		return e.encodeValue(value)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	return nil
+}
+
+// encoder encodes the body of a message,
+// and collects the composite and interface types the body refers to.
+//
+type encoder struct {
+	enc         *cbor.StreamEncoder
+	types       []cadence.Type
+	typeIndices map[cadence.Type]int
+}
+
+// encodeMessage writes a message, which consists of the definitions of all
+// composite and interface types used in the body, and the body itself.
+//
+// The body is encoded first, as encoding it determines the required type definitions.
+// Type definitions may themselves refer to further types, which are appended
+// to the definitions while they are encoded.
+//
+func encodeMessage(w io.Writer, encodeBody func(e *encoder) error) error {
+	var body bytes.Buffer
+
+	e := &encoder{
+		enc:         CBOREncMode.NewStreamEncoder(&body),
+		typeIndices: map[cadence.Type]int{},
+	}
+
+	err := encodeBody(e)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.Flush()
+	if err != nil {
+		return err
+	}
+
+	var definitions bytes.Buffer
+	e.enc = CBOREncMode.NewStreamEncoder(&definitions)
+
+	for i := 0; i < len(e.types); i++ {
+		err = e.encodeTypeDefinition(e.types[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = e.enc.Flush()
+	if err != nil {
+		return err
+	}
+
+	enc := CBOREncMode.NewStreamEncoder(w)
+
+	err = enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = enc.EncodeArrayHead(uint64(len(e.types)))
+	if err != nil {
+		return err
+	}
+
+	err = enc.EncodeRawBytes(definitions.Bytes())
+	if err != nil {
+		return err
+	}
+
+	err = enc.EncodeRawBytes(body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+func (e *encoder) encodeValue(value cadence.Value) error {
+	switch v := value.(type) {
+	case cadence.Void:
+		err := e.enc.EncodeTagHead(CBORTagVoid)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeNil()
+
+	case cadence.Optional:
+		err := e.enc.EncodeTagHead(CBORTagOptional)
+		if err != nil {
+			return err
+		}
+		if v.Value == nil {
+			return e.enc.EncodeNil()
+		}
+		return e.encodeValue(v.Value)
+
+	case cadence.Bool:
+		return e.enc.EncodeBool(bool(v))
+
+	case cadence.String:
+		if !utf8.ValidString(string(v)) {
+			return fmt.Errorf("invalid UTF-8 string: %q", string(v))
+		}
+		return e.enc.EncodeString(string(v))
+
+	case cadence.Bytes:
+		err := e.enc.EncodeTagHead(CBORTagBytes)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBytes(v)
+
+	case cadence.Address:
+		err := e.enc.EncodeTagHead(CBORTagAddress)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBytes(v.Bytes())
+
+	case cadence.Int:
+		err := e.enc.EncodeTagHead(CBORTagInt)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBigInt(v.Value)
+
+	case cadence.Int8:
+		err := e.enc.EncodeTagHead(CBORTagInt8)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeInt8(int8(v))
+
+	case cadence.Int16:
+		err := e.enc.EncodeTagHead(CBORTagInt16)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeInt16(int16(v))
+
+	case cadence.Int32:
+		err := e.enc.EncodeTagHead(CBORTagInt32)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeInt32(int32(v))
+
+	case cadence.Int64:
+		err := e.enc.EncodeTagHead(CBORTagInt64)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeInt64(int64(v))
+
+	case cadence.Int128:
+		err := e.enc.EncodeTagHead(CBORTagInt128)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBigInt(v.Value)
+
+	case cadence.Int256:
+		err := e.enc.EncodeTagHead(CBORTagInt256)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBigInt(v.Value)
+
+	case cadence.UInt:
+		err := e.enc.EncodeTagHead(CBORTagUInt)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBigInt(v.Value)
+
+	case cadence.UInt8:
+		err := e.enc.EncodeTagHead(CBORTagUInt8)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint8(uint8(v))
+
+	case cadence.UInt16:
+		err := e.enc.EncodeTagHead(CBORTagUInt16)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint16(uint16(v))
+
+	case cadence.UInt32:
+		err := e.enc.EncodeTagHead(CBORTagUInt32)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint32(uint32(v))
+
+	case cadence.UInt64:
+		err := e.enc.EncodeTagHead(CBORTagUInt64)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(uint64(v))
+
+	case cadence.UInt128:
+		err := e.enc.EncodeTagHead(CBORTagUInt128)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBigInt(v.Value)
+
+	case cadence.UInt256:
+		err := e.enc.EncodeTagHead(CBORTagUInt256)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeBigInt(v.Value)
+
+	case cadence.Word8:
+		err := e.enc.EncodeTagHead(CBORTagWord8)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint8(uint8(v))
+
+	case cadence.Word16:
+		err := e.enc.EncodeTagHead(CBORTagWord16)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint16(uint16(v))
+
+	case cadence.Word32:
+		err := e.enc.EncodeTagHead(CBORTagWord32)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint32(uint32(v))
+
+	case cadence.Word64:
+		err := e.enc.EncodeTagHead(CBORTagWord64)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(uint64(v))
+
+	case cadence.Fix64:
+		err := e.enc.EncodeTagHead(CBORTagFix64)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeInt64(int64(v))
+
+	case cadence.UFix64:
+		err := e.enc.EncodeTagHead(CBORTagUFix64)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(uint64(v))
+
+	case cadence.Array:
+		return e.encodeArray(v)
+
+	case cadence.Dictionary:
+		return e.encodeDictionary(v)
+
+	case cadence.Struct:
+		var typ cadence.Type
+		if v.StructType != nil {
+			typ = v.StructType
+		}
+		return e.encodeComposite(CBORTagStruct, typ, v.Fields)
+
+	case cadence.Resource:
+		var typ cadence.Type
+		if v.ResourceType != nil {
+			typ = v.ResourceType
+		}
+		return e.encodeComposite(CBORTagResource, typ, v.Fields)
+
+	case cadence.Event:
+		var typ cadence.Type
+		if v.EventType != nil {
+			typ = v.EventType
+		}
+		return e.encodeComposite(CBORTagEvent, typ, v.Fields)
+
+	case cadence.Contract:
+		var typ cadence.Type
+		if v.ContractType != nil {
+			typ = v.ContractType
+		}
+		return e.encodeComposite(CBORTagContract, typ, v.Fields)
+
+	case cadence.Enum:
+		var typ cadence.Type
+		if v.EnumType != nil {
+			typ = v.EnumType
+		}
+		return e.encodeComposite(CBORTagEnum, typ, v.Fields)
+
+	case cadence.Attachment:
+		var typ cadence.Type
+		if v.AttachmentType != nil {
+			typ = v.AttachmentType
+		}
+		return e.encodeComposite(CBORTagAttachment, typ, v.Fields)
+
+	case cadence.Link:
+		return e.encodeLink(v)
+
+	case cadence.Path:
+		err := e.enc.EncodeTagHead(CBORTagPath)
+		if err != nil {
+			return err
+		}
+		return e.encodePath(v)
+
+	case cadence.TypeValue:
+		err := e.enc.EncodeTagHead(CBORTagTypeValue)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(v.StaticType)
+
+	case cadence.Capability:
+		return e.encodeCapability(v)
+
+	default:
+		return fmt.Errorf("unsupported value: %T, %v", value, value)
+	}
+}
+
+// encodeArray encodes an array value as
+//
+//	cbor.Tag{
+//			Number: CBORTagArray,
+//			Content: [type, [elements...]],
+//	}
+//
+func (e *encoder) encodeArray(v cadence.Array) error {
+	err := e.enc.EncodeTagHead(CBORTagArray)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	var arrayType cadence.Type
+	if v.ArrayType != nil {
+		arrayType = v.ArrayType
+	}
+
+	err = e.encodeType(arrayType)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(v.Values)))
+	if err != nil {
+		return err
+	}
+
+	for _, element := range v.Values {
+		err = e.encodeValue(element)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeDictionary encodes a dictionary value as
+//
+//	cbor.Tag{
+//			Number: CBORTagDictionary,
+//			Content: [type, [key, value, key, value, ...]],
+//	}
+//
+// The pairs are encoded in the order of the value,
+// so the encoding is deterministic if the value is.
+//
+func (e *encoder) encodeDictionary(v cadence.Dictionary) error {
+	err := e.enc.EncodeTagHead(CBORTagDictionary)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeType(v.DictionaryType)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(v.Pairs) * 2))
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range v.Pairs {
+		err = e.encodeValue(pair.Key)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeValue(pair.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeComposite encodes a composite value as
+//
+//	cbor.Tag{
+//			Number: tag,
+//			Content: [type definition index, [fields...]],
+//	}
+//
+// The field names are not encoded, they are part of the type definition.
+//
+func (e *encoder) encodeComposite(tag uint64, typ cadence.Type, fields []cadence.Value) error {
+	err := e.enc.EncodeTagHead(tag)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	if typ == nil {
+		err = e.enc.EncodeNil()
+	} else {
+		err = e.enc.EncodeUint64(uint64(e.typeIndex(typ)))
+	}
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(fields)))
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		err = e.encodeValue(field)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeLink encodes a link value as
+//
+//	cbor.Tag{
+//			Number: CBORTagLink,
+//			Content: [[domain, identifier], borrow type ID],
+//	}
+//
+func (e *encoder) encodeLink(v cadence.Link) error {
+	err := e.enc.EncodeTagHead(CBORTagLink)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = e.encodePath(v.TargetPath)
+	if err != nil {
+		return err
+	}
+
+	return e.enc.EncodeString(v.BorrowType)
+}
+
+// encodePath encodes a path as [domain, identifier]
+//
+func (e *encoder) encodePath(v cadence.Path) error {
+	err := e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeString(v.Domain)
+	if err != nil {
+		return err
+	}
+
+	return e.enc.EncodeString(v.Identifier)
+}
+
+// encodeCapability encodes a capability value as
+//
+//	cbor.Tag{
+//			Number: CBORTagCapability,
+//			Content: [[domain, identifier], address, borrow type, id],
+//	}
+//
+// The ID is only encoded for capabilities issued by capability controllers,
+// i.e. if it is not zero.
+//
+func (e *encoder) encodeCapability(v cadence.Capability) error {
+	err := e.enc.EncodeTagHead(CBORTagCapability)
+	if err != nil {
+		return err
+	}
+
+	var length uint64 = 3
+	if v.ID != 0 {
+		length = 4
+	}
+
+	err = e.enc.EncodeArrayHead(length)
+	if err != nil {
+		return err
+	}
+
+	err = e.encodePath(v.Path)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeBytes(v.Address.Bytes())
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeType(v.BorrowType)
+	if err != nil {
+		return err
+	}
+
+	if v.ID == 0 {
+		return nil
+	}
+
+	return e.enc.EncodeUint64(uint64(v.ID))
+}
+
+// typeIndex returns the index of the definition of the given composite or interface type,
+// and schedules the definition to be encoded if the type was not encountered before.
+//
+func (e *encoder) typeIndex(typ cadence.Type) int {
+	index, ok := e.typeIndices[typ]
+	if !ok {
+		index = len(e.types)
+		e.types = append(e.types, typ)
+		e.typeIndices[typ] = index
+	}
+	return index
+}
+
+func (e *encoder) encodeType(typ cadence.Type) error {
+	switch t := typ.(type) {
+	case nil:
+		return e.enc.EncodeNil()
+
+	case cadence.OptionalType:
+		err := e.enc.EncodeTagHead(CBORTagOptionalType)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(t.Type)
+
+	case cadence.VariableSizedArrayType:
+		err := e.enc.EncodeTagHead(CBORTagVariableSizedArrayType)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(t.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		err := e.enc.EncodeTagHead(CBORTagConstantSizedArrayType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeUint64(uint64(t.Size))
+		if err != nil {
+			return err
+		}
+
+		return e.encodeType(t.ElementType)
+
+	case cadence.DictionaryType:
+		err := e.enc.EncodeTagHead(CBORTagDictionaryType)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(t.KeyType)
+		if err != nil {
+			return err
+		}
+
+		return e.encodeType(t.ElementType)
+
+	case cadence.ReferenceType:
+		err := e.enc.EncodeTagHead(CBORTagReferenceType)
+		if err != nil {
+			return err
+		}
+
+		// The authorization is only encoded for entitled references
+		length := uint64(2)
+		if t.Authorization != nil {
+			length = 3
+		}
+
+		err = e.enc.EncodeArrayHead(length)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeBool(t.Authorized)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(t.Type)
+		if err != nil {
+			return err
+		}
+
+		if t.Authorization == nil {
+			return nil
+		}
+
+		return e.encodeEntitlementSetAuthorization(t.Authorization)
+
+	case cadence.RestrictedType:
+		return e.encodeRestrictedType(t)
+
+	case cadence.CapabilityType:
+		err := e.enc.EncodeTagHead(CBORTagCapabilityType)
+		if err != nil {
+			return err
+		}
+		return e.encodeType(t.BorrowType)
+
+	case cadence.FunctionType:
+		return e.encodeFunctionType(t)
+
+	case *cadence.StructType,
+		*cadence.ResourceType,
+		*cadence.EventType,
+		*cadence.ContractType,
+		*cadence.EnumType,
+		*cadence.AttachmentType,
+		*cadence.StructInterfaceType,
+		*cadence.ResourceInterfaceType,
+		*cadence.ContractInterfaceType:
+
+		err := e.enc.EncodeTagHead(CBORTagTypeRef)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(uint64(e.typeIndex(t)))
+
+	default:
+		simple, ok := simpleTypesByID[typ.ID()]
+		if !ok {
+			return fmt.Errorf("unsupported type: %T, %v", typ, typ)
+		}
+
+		err := e.enc.EncodeTagHead(CBORTagSimpleType)
+		if err != nil {
+			return err
+		}
+		return e.enc.EncodeUint64(uint64(simple))
+	}
+}
+
+// encodeRestrictedType encodes a restricted type as
+//
+//	cbor.Tag{
+//			Number: CBORTagRestrictedType,
+//			Content: [type ID, type, [restrictions...]],
+//	}
+//
+func (e *encoder) encodeRestrictedType(t cadence.RestrictedType) error {
+	err := e.enc.EncodeTagHead(CBORTagRestrictedType)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(3)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeString(t.ID())
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeType(t.Type)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(t.Restrictions)))
+	if err != nil {
+		return err
+	}
+
+	for _, restriction := range t.Restrictions {
+		err = e.encodeType(restriction)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeFunctionType encodes a function type as
+//
+//	cbor.Tag{
+//			Number: CBORTagFunctionType,
+//			Content: [type ID, [parameters...], return type],
+//	}
+//
+func (e *encoder) encodeFunctionType(t cadence.FunctionType) error {
+	err := e.enc.EncodeTagHead(CBORTagFunctionType)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(3)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeString(t.ID())
+	if err != nil {
+		return err
+	}
+
+	err = e.encodeParameters(t.Parameters)
+	if err != nil {
+		return err
+	}
+
+	return e.encodeType(t.ReturnType)
+}
+
+// encodeTypeDefinition encodes the definition of a composite or interface type as
+//
+//	cbor.Tag{
+//			Number: definition tag,
+//			Content: [type ID, fields, initializers],
+//	}
+//
+// The definition of an enum type additionally contains its raw type after the type ID,
+// the definition of an attachment type contains its base type after the type ID,
+// and the definition of an event type contains its single initializer.
+//
+func (e *encoder) encodeTypeDefinition(typ cadence.Type) error {
+	var tag uint64
+	var fields []cadence.Field
+	var initializers [][]cadence.Parameter
+
+	switch t := typ.(type) {
+	case *cadence.StructType:
+		tag = CBORTagStructTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.ResourceType:
+		tag = CBORTagResourceTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.EventType:
+		tag = CBORTagEventTypeDef
+		fields = t.Fields
+
+	case *cadence.ContractType:
+		tag = CBORTagContractTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.EnumType:
+		tag = CBORTagEnumTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.AttachmentType:
+		tag = CBORTagAttachmentTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.StructInterfaceType:
+		tag = CBORTagStructInterfaceTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.ResourceInterfaceType:
+		tag = CBORTagResourceInterfaceTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	case *cadence.ContractInterfaceType:
+		tag = CBORTagContractInterfaceTypeDef
+		fields = t.Fields
+		initializers = t.Initializers
+
+	default:
+		return fmt.Errorf("unsupported type definition: %T, %v", typ, typ)
+	}
+
+	err := e.enc.EncodeTagHead(tag)
+	if err != nil {
+		return err
+	}
+
+	enumType, isEnum := typ.(*cadence.EnumType)
+	attachmentType, isAttachment := typ.(*cadence.AttachmentType)
+
+	length := uint64(3)
+	if isEnum || isAttachment {
+		length++
+	}
+
+	err = e.enc.EncodeArrayHead(length)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeString(typ.ID())
+	if err != nil {
+		return err
+	}
+
+	if isEnum {
+		err = e.encodeType(enumType.RawType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isAttachment {
+		err = e.encodeType(attachmentType.BaseType)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(fields)))
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		err = e.enc.EncodeArrayHead(2)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(field.Identifier)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(field.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	if eventType, ok := typ.(*cadence.EventType); ok {
+		return e.encodeParameters(eventType.Initializer)
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(initializers)))
+	if err != nil {
+		return err
+	}
+
+	for _, parameters := range initializers {
+		err = e.encodeParameters(parameters)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeParameters encodes parameters as [[label, identifier, type]...]
+//
+func (e *encoder) encodeParameters(parameters []cadence.Parameter) error {
+	err := e.enc.EncodeArrayHead(uint64(len(parameters)))
+	if err != nil {
+		return err
+	}
+
+	for _, parameter := range parameters {
+		err = e.enc.EncodeArrayHead(3)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(parameter.Label)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeString(parameter.Identifier)
+		if err != nil {
+			return err
+		}
+
+		err = e.encodeType(parameter.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeEntitlementSetAuthorization encodes the authorization of an entitled reference type as
+// [kind: uint, entitlements: [+ string]]
+//
+func (e *encoder) encodeEntitlementSetAuthorization(authorization *cadence.EntitlementSetAuthorization) error {
+	err := e.enc.EncodeArrayHead(2)
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeUint8(uint8(authorization.Kind))
+	if err != nil {
+		return err
+	}
+
+	err = e.enc.EncodeArrayHead(uint64(len(authorization.Entitlements)))
+	if err != nil {
+		return err
+	}
+
+	for _, entitlement := range authorization.Entitlements {
+		err = e.enc.EncodeString(string(entitlement))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ccf
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Fuzz decodes the given data, and checks that the decoded value
+// can be encoded again and that the encoding is stable.
+//
+func Fuzz(data []byte) int {
+
+	value, err := Decode(data)
+	if err != nil {
+		return 0
+	}
+
+	encoded, err := Encode(value)
+	if err != nil {
+		panic(fmt.Errorf("failed to encode decoded value: %w", err))
+	}
+
+	decoded, err := Decode(encoded)
+	if err != nil {
+		panic(fmt.Errorf("failed to decode encoded value: %w", err))
+	}
+
+	reencoded, err := Encode(decoded)
+	if err != nil {
+		panic(fmt.Errorf("failed to re-encode decoded value: %w", err))
+	}
+
+	if !bytes.Equal(encoded, reencoded) {
+		panic(fmt.Errorf("encoding is not deterministic: %x != %x", encoded, reencoded))
+	}
+
+	return 1
+}
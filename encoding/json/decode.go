@@ -27,6 +27,7 @@ import (
 	"io"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
@@ -81,7 +82,15 @@ func (d *Decoder) Decode() (value cadence.Value, err error) {
 				panic(r)
 			}
 
-			err = fmt.Errorf("failed to decode value: %w", panicErr)
+			decodingErr, ok := panicErr.(*DecodingError)
+			if !ok {
+				decodingErr = &DecodingError{
+					Err: panicErr,
+				}
+			}
+			decodingErr.Path = rootPath + decodingErr.Path
+
+			err = decodingErr
 		}
 	}()
 
@@ -90,32 +99,118 @@ func (d *Decoder) Decode() (value cadence.Value, err error) {
 }
 
 const (
-	typeKey         = "type"
-	kindKey         = "kind"
-	valueKey        = "value"
-	keyKey          = "key"
-	nameKey         = "name"
-	fieldsKey       = "fields"
-	initializersKey = "initializers"
-	idKey           = "id"
-	targetPathKey   = "targetPath"
-	borrowTypeKey   = "borrowType"
-	domainKey       = "domain"
-	identifierKey   = "identifier"
-	staticTypeKey   = "staticType"
-	addressKey      = "address"
-	pathKey         = "path"
-	authorizedKey   = "authorized"
-	sizeKey         = "size"
-	typeIDKey       = "typeID"
-	restrictionsKey = "restrictions"
-	labelKey        = "label"
-	parametersKey   = "parameters"
-	returnKey       = "return"
+	typeKey          = "type"
+	kindKey          = "kind"
+	valueKey         = "value"
+	keyKey           = "key"
+	nameKey          = "name"
+	fieldsKey        = "fields"
+	initializersKey  = "initializers"
+	idKey            = "id"
+	targetPathKey    = "targetPath"
+	borrowTypeKey    = "borrowType"
+	domainKey        = "domain"
+	identifierKey    = "identifier"
+	staticTypeKey    = "staticType"
+	addressKey       = "address"
+	pathKey          = "path"
+	authorizedKey    = "authorized"
+	sizeKey          = "size"
+	typeIDKey        = "typeID"
+	restrictionsKey  = "restrictions"
+	labelKey         = "label"
+	parametersKey    = "parameters"
+	returnKey        = "return"
+	authorizationKey = "authorization"
+	entitlementsKey  = "entitlements"
 )
 
 var ErrInvalidJSONCadence = errors.New("invalid JSON Cadence structure")
 
+const rootPath = "$"
+
+// DecodingError is returned when a JSON value does not conform to the JSON Cadence specification.
+//
+// Path is the JSONPath of the JSON-Cadence value which failed to decode,
+// e.g. `$.value[1].value.fields[0].value` for the first field of the second element of an optional array.
+//
+type DecodingError struct {
+	Path string
+	Err  error
+}
+
+func (e *DecodingError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodingError) Error() string {
+	if e.Path == rootPath {
+		return fmt.Sprintf("failed to decode value: %s", e.Err)
+	}
+
+	return fmt.Sprintf("failed to decode value at %s: %s", e.Path, e.Err)
+}
+
+// decodeAt calls the given decoding function for the nested JSON value at the given path,
+// relative to the value currently being decoded.
+// The path is prepended to the path of the decoding error, if any.
+//
+func decodeAt(path string, decode func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		panic(prependDecodingErrorPath(r, path))
+	}()
+
+	decode()
+}
+
+// decodeElementsAt calls the given decoding function for each of the given number
+// of nested JSON values, e.g. the elements of an array.
+//
+// The path of the element which failed to decode, if any, is prepended to the path of the decoding error.
+// It is only built on failure, by formatting the given path format with the index of the element.
+//
+func decodeElementsAt(pathFormat string, count int, decode func(index int)) {
+	index := 0
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		panic(prependDecodingErrorPath(r, fmt.Sprintf(pathFormat, index)))
+	}()
+
+	for ; index < count; index++ {
+		decode(index)
+	}
+}
+
+// prependDecodingErrorPath prepends the given path to the path of the recovered decoding error.
+// Recovered values which are not errors are re-panicked.
+//
+func prependDecodingErrorPath(r interface{}, path string) *DecodingError {
+	err, isError := r.(error)
+	if !isError {
+		panic(r)
+	}
+
+	decodingErr, ok := err.(*DecodingError)
+	if !ok {
+		decodingErr = &DecodingError{
+			Err: err,
+		}
+	}
+	decodingErr.Path = path + decodingErr.Path
+
+	return decodingErr
+}
+
 func decodeJSON(v interface{}) cadence.Value {
 	obj := toObject(v)
 
@@ -140,6 +235,8 @@ func decodeJSON(v interface{}) cadence.Value {
 		return decodeBool(valueJSON)
 	case stringTypeStr:
 		return decodeString(valueJSON)
+	case bytesTypeStr:
+		return decodeBytes(valueJSON)
 	case addressTypeStr:
 		return decodeAddress(valueJSON)
 	case intTypeStr:
@@ -204,6 +301,8 @@ func decodeJSON(v interface{}) cadence.Value {
 		return decodeCapability(valueJSON)
 	case enumTypeStr:
 		return decodeEnum(valueJSON)
+	case attachmentTypeStr:
+		return decodeAttachment(valueJSON)
 	}
 
 	panic(ErrInvalidJSONCadence)
@@ -224,7 +323,12 @@ func decodeOptional(valueJSON interface{}) cadence.Optional {
 		return cadence.NewOptional(nil)
 	}
 
-	return cadence.NewOptional(decodeJSON(valueJSON))
+	var value cadence.Value
+	decodeAt(".value", func() {
+		value = decodeJSON(valueJSON)
+	})
+
+	return cadence.NewOptional(value)
 }
 
 func decodeBool(valueJSON interface{}) cadence.Bool {
@@ -239,11 +343,29 @@ func decodeString(valueJSON interface{}) cadence.String {
 	return str
 }
 
+func decodeBytes(valueJSON interface{}) cadence.Bytes {
+	v := toString(valueJSON)
+
+	// must include 0x prefix
+	if len(v) < 2 || v[:2] != "0x" {
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+
+	b, err := hex.DecodeString(v[2:])
+	if err != nil {
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+
+	return cadence.NewBytes(b)
+}
+
 func decodeAddress(valueJSON interface{}) cadence.Address {
 	v := toString(valueJSON)
 
 	// must include 0x prefix
-	if v[:2] != "0x" {
+	if !strings.HasPrefix(v, "0x") {
 		// TODO: improve error message
 		panic(ErrInvalidJSONCadence)
 	}
@@ -491,9 +613,9 @@ func decodeValues(valueJSON interface{}) []cadence.Value {
 
 	values := make([]cadence.Value, len(v))
 
-	for i, val := range v {
-		values[i] = decodeJSON(val)
-	}
+	decodeElementsAt(".value[%d]", len(v), func(i int) {
+		values[i] = decodeJSON(v[i])
+	})
 
 	return values
 }
@@ -507,9 +629,9 @@ func decodeDictionary(valueJSON interface{}) cadence.Dictionary {
 
 	pairs := make([]cadence.KeyValuePair, len(v))
 
-	for i, val := range v {
-		pairs[i] = decodeKeyValuePair(val)
-	}
+	decodeElementsAt(".value[%d]", len(v), func(i int) {
+		pairs[i] = decodeKeyValuePair(v[i])
+	})
 
 	return cadence.NewDictionary(pairs)
 }
@@ -552,12 +674,9 @@ func decodeComposite(valueJSON interface{}) composite {
 	fieldValues := make([]cadence.Value, len(fields))
 	fieldTypes := make([]cadence.Field, len(fields))
 
-	for i, field := range fields {
-		value, fieldType := decodeCompositeField(field)
-
-		fieldValues[i] = value
-		fieldTypes[i] = fieldType
-	}
+	decodeElementsAt(".value.fields[%d]", len(fields), func(i int) {
+		fieldValues[i], fieldTypes[i] = decodeCompositeField(fields[i])
+	})
 
 	return composite{
 		location:            location,
@@ -631,10 +750,25 @@ func decodeEnum(valueJSON interface{}) cadence.Enum {
 	})
 }
 
+func decodeAttachment(valueJSON interface{}) cadence.Attachment {
+	comp := decodeComposite(valueJSON)
+
+	return cadence.NewAttachment(comp.fieldValues).WithType(&cadence.AttachmentType{
+		Location:            comp.location,
+		QualifiedIdentifier: comp.qualifiedIdentifier,
+		Fields:              comp.fieldTypes,
+	})
+}
+
 func decodeLink(valueJSON interface{}) cadence.Link {
 	obj := toObject(valueJSON)
 
-	targetPath, ok := decodeJSON(obj.Get(targetPathKey)).(cadence.Path)
+	var targetPathValue cadence.Value
+	decodeAt(".value."+targetPathKey, func() {
+		targetPathValue = decodeJSON(obj.Get(targetPathKey))
+	})
+
+	targetPath, ok := targetPathValue.(cadence.Path)
 	if !ok {
 		// TODO: improve error message
 		panic(ErrInvalidJSONCadence)
@@ -654,46 +788,46 @@ func decodePath(valueJSON interface{}) cadence.Path {
 	}
 }
 
-func decodeParamType(valueJSON interface{}) cadence.Parameter {
+func decodeParamType(valueJSON interface{}, results typeDecodingResults) cadence.Parameter {
 	obj := toObject(valueJSON)
 	return cadence.Parameter{
 		Label:      toString(obj.Get(labelKey)),
 		Identifier: toString(obj.Get(idKey)),
-		Type:       decodeType(obj.Get(typeKey)),
+		Type:       decodeType(obj.Get(typeKey), results),
 	}
 }
 
-func decodeParamTypes(params []interface{}) []cadence.Parameter {
+func decodeParamTypes(params []interface{}, results typeDecodingResults) []cadence.Parameter {
 	parameters := make([]cadence.Parameter, 0, len(params))
 
 	for _, param := range params {
-		parameters = append(parameters, decodeParamType(param))
+		parameters = append(parameters, decodeParamType(param, results))
 	}
 
 	return parameters
 }
 
-func decodeFieldTypes(fs []interface{}) []cadence.Field {
+func decodeFieldTypes(fs []interface{}, results typeDecodingResults) []cadence.Field {
 	fields := make([]cadence.Field, 0, len(fs))
 
 	for _, field := range fs {
-		fields = append(fields, decodeFieldType(field))
+		fields = append(fields, decodeFieldType(field, results))
 	}
 
 	return fields
 }
 
-func decodeFieldType(valueJSON interface{}) cadence.Field {
+func decodeFieldType(valueJSON interface{}, results typeDecodingResults) cadence.Field {
 	obj := toObject(valueJSON)
 	return cadence.Field{
 		Identifier: toString(obj.Get(idKey)),
-		Type:       decodeType(obj.Get(typeKey)),
+		Type:       decodeType(obj.Get(typeKey), results),
 	}
 }
 
-func decodeFunctionType(returnValue, parametersValue, id interface{}) cadence.Type {
-	parameters := decodeParamTypes(toSlice(parametersValue))
-	returnType := decodeType(returnValue)
+func decodeFunctionType(returnValue, parametersValue, id interface{}, results typeDecodingResults) cadence.Type {
+	parameters := decodeParamTypes(toSlice(parametersValue), results)
+	returnType := decodeType(returnValue, results)
 
 	return cadence.FunctionType{
 		Parameters: parameters,
@@ -701,91 +835,131 @@ func decodeFunctionType(returnValue, parametersValue, id interface{}) cadence.Ty
 	}.WithID(toString(id))
 }
 
-func decodeNominalType(obj jsonObject, kind, typeID string, fs, initializers []interface{}) cadence.Type {
-	fields := decodeFieldTypes(fs)
-	inits := make([][]cadence.Parameter, 0, len(initializers))
-
-	for _, params := range initializers {
-		inits = append(inits, decodeParamTypes(toSlice(params)))
-	}
+func decodeNominalType(
+	obj jsonObject,
+	kind, typeID string,
+	fs, initializers []interface{},
+	results typeDecodingResults,
+) cadence.Type {
 
 	location, id, err := common.DecodeTypeID(typeID)
 	if err != nil {
 		panic(ErrInvalidJSONCadence)
 	}
 
+	// The type is recorded before its fields and initializers are decoded,
+	// as they may refer to the type itself
+
+	var result cadence.Type
+
 	switch kind {
 	case "Struct":
-		return &cadence.StructType{
+		result = &cadence.StructType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializers:        inits,
 		}
 	case "Resource":
-		return &cadence.ResourceType{
+		result = &cadence.ResourceType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializers:        inits,
 		}
 	case "Event":
-		return &cadence.EventType{
+		result = &cadence.EventType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializer:         inits[0],
 		}
 	case "Contract":
-		return &cadence.ContractType{
+		result = &cadence.ContractType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializers:        inits,
 		}
 	case "StructInterface":
-		return &cadence.StructInterfaceType{
+		result = &cadence.StructInterfaceType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializers:        inits,
 		}
 	case "ResourceInterface":
-		return &cadence.ResourceInterfaceType{
+		result = &cadence.ResourceInterfaceType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializers:        inits,
 		}
 	case "ContractInterface":
-		return &cadence.ContractInterfaceType{
+		result = &cadence.ContractInterfaceType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			Fields:              fields,
-			Initializers:        inits,
 		}
 	case "Enum":
-		return &cadence.EnumType{
+		result = &cadence.EnumType{
 			Location:            location,
 			QualifiedIdentifier: id,
-			RawType:             decodeType(obj.Get(typeKey)),
-			Fields:              fields,
-			Initializers:        inits,
 		}
+	case "Attachment":
+		result = &cadence.AttachmentType{
+			Location:            location,
+			QualifiedIdentifier: id,
+		}
+	default:
+		panic(ErrInvalidJSONCadence)
 	}
 
-	panic(ErrInvalidJSONCadence)
+	results[typeID] = result
+	defer delete(results, typeID)
+
+	fields := decodeFieldTypes(fs, results)
+	inits := make([][]cadence.Parameter, 0, len(initializers))
+
+	for _, params := range initializers {
+		inits = append(inits, decodeParamTypes(toSlice(params), results))
+	}
+
+	switch result := result.(type) {
+	case *cadence.StructType:
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.ResourceType:
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.EventType:
+		if len(inits) != 1 {
+			panic(ErrInvalidJSONCadence)
+		}
+		result.Fields = fields
+		result.Initializer = inits[0]
+	case *cadence.ContractType:
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.StructInterfaceType:
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.ResourceInterfaceType:
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.ContractInterfaceType:
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.EnumType:
+		result.RawType = decodeType(obj.Get(typeKey), results)
+		result.Fields = fields
+		result.Initializers = inits
+	case *cadence.AttachmentType:
+		result.BaseType = decodeType(obj.Get(typeKey), results)
+		result.Fields = fields
+		result.Initializers = inits
+	}
+
+	return result
 }
 
 func decodeRestrictedType(
 	typeValue interface{},
 	restrictionsValue []interface{},
 	typeIDValue string,
+	results typeDecodingResults,
 ) cadence.Type {
-	typ := decodeType(typeValue)
+	typ := decodeType(typeValue, results)
 	restrictions := make([]cadence.Type, 0, len(restrictionsValue))
 	for _, restriction := range restrictionsValue {
-		restrictions = append(restrictions, decodeType(restriction))
+		restrictions = append(restrictions, decodeType(restriction, results))
 	}
 
 	return cadence.RestrictedType{
@@ -794,10 +968,25 @@ func decodeRestrictedType(
 	}.WithID(typeIDValue)
 }
 
-func decodeType(valueJSON interface{}) cadence.Type {
+// typeDecodingResults contains the nominal types which are currently being decoded, by type ID.
+//
+// A reference to a nominal type which is currently being decoded is encoded as its type ID.
+//
+type typeDecodingResults map[string]cadence.Type
+
+func decodeType(valueJSON interface{}, results typeDecodingResults) cadence.Type {
 	if valueJSON == "" {
 		return nil
 	}
+
+	if typeID, ok := valueJSON.(string); ok {
+		result, ok := results[typeID]
+		if !ok {
+			panic(ErrInvalidJSONCadence)
+		}
+		return result
+	}
+
 	obj := toObject(valueJSON)
 	kindValue := toString(obj.Get(kindKey))
 
@@ -806,40 +995,45 @@ func decodeType(valueJSON interface{}) cadence.Type {
 		returnValue := obj.Get(returnKey)
 		parametersValue := obj.Get(parametersKey)
 		idValue := obj.Get(typeIDKey)
-		return decodeFunctionType(returnValue, parametersValue, idValue)
+		return decodeFunctionType(returnValue, parametersValue, idValue, results)
 	case "Restriction":
 		restrictionsValue := obj.Get(restrictionsKey)
 		typeIDValue := toString(obj.Get(typeIDKey))
 		typeValue := obj.Get(typeKey)
-		return decodeRestrictedType(typeValue, toSlice(restrictionsValue), typeIDValue)
+		return decodeRestrictedType(typeValue, toSlice(restrictionsValue), typeIDValue, results)
 	case "Optional":
 		return cadence.OptionalType{
-			Type: decodeType(obj.Get(typeKey)),
+			Type: decodeType(obj.Get(typeKey), results),
 		}
 	case "VariableSizedArray":
 		return cadence.VariableSizedArrayType{
-			ElementType: decodeType(obj.Get(typeKey)),
+			ElementType: decodeType(obj.Get(typeKey), results),
 		}
 	case "Capability":
 		return cadence.CapabilityType{
-			BorrowType: decodeType(obj.Get(typeKey)),
+			BorrowType: decodeType(obj.Get(typeKey), results),
 		}
 	case "Dictionary":
 		return cadence.DictionaryType{
-			KeyType:     decodeType(obj.Get(keyKey)),
-			ElementType: decodeType(obj.Get(valueKey)),
+			KeyType:     decodeType(obj.Get(keyKey), results),
+			ElementType: decodeType(obj.Get(valueKey), results),
 		}
 	case "ConstantSizedArray":
 		size := toUInt(obj.Get(sizeKey))
 		return cadence.ConstantSizedArrayType{
-			ElementType: decodeType(obj.Get(typeKey)),
+			ElementType: decodeType(obj.Get(typeKey), results),
 			Size:        size,
 		}
 	case "Reference":
 		auth := toBool(obj.Get(authorizedKey))
+		var authorization *cadence.EntitlementSetAuthorization
+		if authorizationJSON, ok := obj[authorizationKey]; ok {
+			authorization = decodeEntitlementSetAuthorization(authorizationJSON)
+		}
 		return cadence.ReferenceType{
-			Type:       decodeType(obj.Get(typeKey)),
-			Authorized: auth,
+			Type:          decodeType(obj.Get(typeKey), results),
+			Authorized:    auth,
+			Authorization: authorization,
 		}
 	case "Any":
 		return cadence.AnyType{}
@@ -945,7 +1139,7 @@ func decodeType(valueJSON interface{}) cadence.Type {
 		fieldsValue := obj.Get(fieldsKey)
 		typeIDValue := toString(obj.Get(typeIDKey))
 		initValue := obj.Get(initializersKey)
-		return decodeNominalType(obj, kindValue, typeIDValue, toSlice(fieldsValue), toSlice(initValue))
+		return decodeNominalType(obj, kindValue, typeIDValue, toSlice(fieldsValue), toSlice(initValue), results)
 	}
 }
 
@@ -953,23 +1147,35 @@ func decodeTypeValue(valueJSON interface{}) cadence.TypeValue {
 	obj := toObject(valueJSON)
 
 	return cadence.TypeValue{
-		StaticType: decodeType(obj.Get(staticTypeKey)),
+		StaticType: decodeType(obj.Get(staticTypeKey), typeDecodingResults{}),
 	}
 }
 
 func decodeCapability(valueJSON interface{}) cadence.Capability {
 	obj := toObject(valueJSON)
 
-	path, ok := decodeJSON(obj.Get(pathKey)).(cadence.Path)
+	var pathValue cadence.Value
+	decodeAt(".value."+pathKey, func() {
+		pathValue = decodeJSON(obj.Get(pathKey))
+	})
+
+	path, ok := pathValue.(cadence.Path)
 	if !ok {
 		// TODO: improve error message
 		panic(ErrInvalidJSONCadence)
 	}
 
+	// The ID is optional, it is only set for capabilities issued by capability controllers
+	var id cadence.UInt64
+	if idJSON, ok := obj[idKey]; ok {
+		id = decodeUInt64(idJSON)
+	}
+
 	return cadence.Capability{
 		Path:       path,
 		Address:    decodeAddress(obj.Get(addressKey)),
-		BorrowType: decodeType(obj.Get(borrowTypeKey)),
+		BorrowType: decodeType(obj.Get(borrowTypeKey), typeDecodingResults{}),
+		ID:         id,
 	}
 }
 
@@ -977,6 +1183,37 @@ func decodeCapability(valueJSON interface{}) cadence.Capability {
 
 type jsonObject map[string]interface{}
 
+func decodeEntitlementSetAuthorization(valueJSON interface{}) *cadence.EntitlementSetAuthorization {
+	obj := toObject(valueJSON)
+
+	var kind cadence.EntitlementSetKind
+	switch obj.GetString(kindKey) {
+	case "Conjunction":
+		kind = cadence.Conjunction
+	case "Disjunction":
+		kind = cadence.Disjunction
+	default:
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+
+	entitlementsJSON := obj.GetSlice(entitlementsKey)
+	if len(entitlementsJSON) == 0 {
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+
+	entitlements := make([]common.TypeID, len(entitlementsJSON))
+	for i, entitlementJSON := range entitlementsJSON {
+		entitlements[i] = common.TypeID(toString(entitlementJSON))
+	}
+
+	return &cadence.EntitlementSetAuthorization{
+		Entitlements: entitlements,
+		Kind:         kind,
+	}
+}
+
 func (obj jsonObject) Get(key string) interface{} {
 	v, hasKey := obj[key]
 	if !hasKey {
@@ -1004,6 +1241,18 @@ func (obj jsonObject) GetSlice(key string) []interface{} {
 
 func (obj jsonObject) GetValue(key string) cadence.Value {
 	v := obj.Get(key)
+
+	// The path of the value is only built if decoding fails
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		panic(prependDecodingErrorPath(r, "."+key))
+	}()
+
 	return decodeJSON(v)
 }
 
@@ -62,6 +62,33 @@ func MustEncode(value cadence.Value) []byte {
 	return b
 }
 
+// EncodeType returns the JSON-encoded representation of the given type.
+//
+// This function returns an error if the Cadence type cannot be represented as JSON.
+func EncodeType(typ cadence.Type) (result []byte, err error) {
+	// capture panics that occur during type preparation
+	defer func() {
+		if r := recover(); r != nil {
+			// don't recover Go errors
+			goErr, ok := r.(goRuntime.Error)
+			if ok {
+				panic(goErr)
+			}
+
+			panicErr, isError := r.(error)
+			if !isError {
+				panic(r)
+			}
+
+			err = fmt.Errorf("failed to encode type: %w", panicErr)
+		}
+	}()
+
+	preparedType := prepareType(typ, typePreparationResults{})
+
+	return json.Marshal(preparedType)
+}
+
 // NewEncoder initializes an Encoder that will write JSON-encoded bytes to the
 // given io.Writer.
 func NewEncoder(w io.Writer) *Encoder {
@@ -170,9 +197,15 @@ type jsonDictionaryType struct {
 }
 
 type jsonReferenceType struct {
-	Kind       string    `json:"kind"`
-	Type       jsonValue `json:"type"`
-	Authorized bool      `json:"authorized"`
+	Kind          string                           `json:"kind"`
+	Type          jsonValue                        `json:"type"`
+	Authorized    bool                             `json:"authorized"`
+	Authorization *jsonEntitlementSetAuthorization `json:"authorization,omitempty"`
+}
+
+type jsonEntitlementSetAuthorization struct {
+	Kind         string   `json:"kind"`
+	Entitlements []string `json:"entitlements"`
 }
 
 type jsonRestrictedType struct {
@@ -203,6 +236,7 @@ type jsonCapabilityValue struct {
 	Path       jsonValue `json:"path"`
 	Address    string    `json:"address"`
 	BorrowType jsonValue `json:"borrowType"`
+	ID         string    `json:"id,omitempty"`
 }
 
 const (
@@ -210,6 +244,7 @@ const (
 	optionalTypeStr   = "Optional"
 	boolTypeStr       = "Bool"
 	stringTypeStr     = "String"
+	bytesTypeStr      = "Bytes"
 	addressTypeStr    = "Address"
 	intTypeStr        = "Int"
 	int8TypeStr       = "Int8"
@@ -242,6 +277,7 @@ const (
 	typeTypeStr       = "Type"
 	capabilityTypeStr = "Capability"
 	enumTypeStr       = "Enum"
+	attachmentTypeStr = "Attachment"
 )
 
 // prepare traverses the object graph of the provided value and constructs
@@ -256,6 +292,8 @@ func Prepare(v cadence.Value) jsonValue {
 		return prepareBool(x)
 	case cadence.String:
 		return prepareString(x)
+	case cadence.Bytes:
+		return prepareBytes(x)
 	case cadence.Address:
 		return prepareAddress(x)
 	case cadence.Int:
@@ -320,6 +358,8 @@ func Prepare(v cadence.Value) jsonValue {
 		return prepareCapability(x)
 	case cadence.Enum:
 		return prepareEnum(x)
+	case cadence.Attachment:
+		return prepareAttachment(x)
 	default:
 		panic(fmt.Errorf("unsupported value: %T, %v", v, v))
 	}
@@ -356,6 +396,13 @@ func prepareString(v cadence.String) jsonValue {
 	}
 }
 
+func prepareBytes(v cadence.Bytes) jsonValue {
+	return jsonValueObject{
+		Type:  bytesTypeStr,
+		Value: encodeBytes(v),
+	}
+}
+
 func prepareAddress(v cadence.Address) jsonValue {
 	return jsonValueObject{
 		Type:  addressTypeStr,
@@ -552,6 +599,10 @@ func prepareEnum(v cadence.Enum) jsonValue {
 	return prepareComposite(enumTypeStr, v.EnumType.ID(), v.EnumType.Fields, v.Fields)
 }
 
+func prepareAttachment(v cadence.Attachment) jsonValue {
+	return prepareComposite(attachmentTypeStr, v.AttachmentType.ID(), v.AttachmentType.Fields, v.Fields)
+}
+
 func prepareComposite(kind, id string, fieldTypes []cadence.Field, fields []cadence.Value) jsonValue {
 	nonFunctionFieldTypes := make([]cadence.Field, 0)
 
@@ -610,46 +661,96 @@ func preparePath(x cadence.Path) jsonValue {
 	}
 }
 
-func prepareParameterType(parameterType cadence.Parameter) jsonParameterType {
+func prepareParameterType(parameterType cadence.Parameter, results typePreparationResults) jsonParameterType {
 	return jsonParameterType{
 		Label: parameterType.Label,
 		Id:    parameterType.Identifier,
-		Type:  prepareType(parameterType.Type),
+		Type:  prepareType(parameterType.Type, results),
 	}
 }
 
-func prepareFieldType(fieldType cadence.Field) jsonFieldType {
+func prepareFieldType(fieldType cadence.Field, results typePreparationResults) jsonFieldType {
 	return jsonFieldType{
 		Id:   fieldType.Identifier,
-		Type: prepareType(fieldType.Type),
+		Type: prepareType(fieldType.Type, results),
 	}
 }
 
-func prepareFields(fieldTypes []cadence.Field) []jsonFieldType {
+func prepareFields(fieldTypes []cadence.Field, results typePreparationResults) []jsonFieldType {
 	fields := make([]jsonFieldType, 0)
 	for _, field := range fieldTypes {
-		fields = append(fields, prepareFieldType(field))
+		fields = append(fields, prepareFieldType(field, results))
 	}
 	return fields
 }
 
-func prepareParameters(parameterTypes []cadence.Parameter) []jsonParameterType {
+func prepareEntitlementSetAuthorization(
+	authorization *cadence.EntitlementSetAuthorization,
+) *jsonEntitlementSetAuthorization {
+	if authorization == nil {
+		return nil
+	}
+
+	kind := "Conjunction"
+	if authorization.Kind == cadence.Disjunction {
+		kind = "Disjunction"
+	}
+
+	entitlements := make([]string, len(authorization.Entitlements))
+	for i, entitlement := range authorization.Entitlements {
+		entitlements[i] = string(entitlement)
+	}
+
+	return &jsonEntitlementSetAuthorization{
+		Kind:         kind,
+		Entitlements: entitlements,
+	}
+}
+
+func prepareParameters(parameterTypes []cadence.Parameter, results typePreparationResults) []jsonParameterType {
 	parameters := make([]jsonParameterType, 0)
 	for _, param := range parameterTypes {
-		parameters = append(parameters, prepareParameterType(param))
+		parameters = append(parameters, prepareParameterType(param, results))
 	}
 	return parameters
 }
 
-func prepareInitializers(initializerTypes [][]cadence.Parameter) [][]jsonParameterType {
+func prepareInitializers(initializerTypes [][]cadence.Parameter, results typePreparationResults) [][]jsonParameterType {
 	initializers := make([][]jsonParameterType, 0)
 	for _, params := range initializerTypes {
-		initializers = append(initializers, prepareParameters(params))
+		initializers = append(initializers, prepareParameters(params, results))
 	}
 	return initializers
 }
 
-func prepareType(typ cadence.Type) jsonValue {
+// typePreparationResults contains the nominal types which are currently being prepared.
+//
+// Nominal types may be recursive, e.g. a struct may have a field which has the struct's type.
+// A reference to a nominal type which is currently being prepared is encoded as its type ID.
+//
+type typePreparationResults map[cadence.Type]struct{}
+
+func prepareType(typ cadence.Type, results typePreparationResults) jsonValue {
+
+	switch typ.(type) {
+	case *cadence.StructType,
+		*cadence.ResourceType,
+		*cadence.EventType,
+		*cadence.ContractType,
+		*cadence.StructInterfaceType,
+		*cadence.ResourceInterfaceType,
+		*cadence.ContractInterfaceType,
+		*cadence.EnumType,
+		*cadence.AttachmentType:
+
+		if _, ok := results[typ]; ok {
+			return typ.ID()
+		}
+
+		results[typ] = struct{}{}
+		defer delete(results, typ)
+	}
+
 	switch typ := typ.(type) {
 	case cadence.AnyType,
 		cadence.AnyStructType,
@@ -688,6 +789,7 @@ func prepareType(typ cadence.Type) jsonValue {
 		cadence.Fix64Type,
 		cadence.UFix64Type,
 		cadence.BlockType,
+		cadence.BlockHeaderType,
 		cadence.PathType,
 		cadence.CapabilityPathType,
 		cadence.StoragePathType,
@@ -707,117 +809,126 @@ func prepareType(typ cadence.Type) jsonValue {
 	case cadence.OptionalType:
 		return jsonUnaryType{
 			Kind: "Optional",
-			Type: prepareType(typ.Type),
+			Type: prepareType(typ.Type, results),
 		}
 	case cadence.VariableSizedArrayType:
 		return jsonUnaryType{
 			Kind: "VariableSizedArray",
-			Type: prepareType(typ.ElementType),
+			Type: prepareType(typ.ElementType, results),
 		}
 	case cadence.ConstantSizedArrayType:
 		return jsonConstantSizedArrayType{
 			Kind: "ConstantSizedArray",
-			Type: prepareType(typ.ElementType),
+			Type: prepareType(typ.ElementType, results),
 			Size: typ.Size,
 		}
 	case cadence.DictionaryType:
 		return jsonDictionaryType{
 			Kind:      "Dictionary",
-			KeyType:   prepareType(typ.KeyType),
-			ValueType: prepareType(typ.ElementType),
+			KeyType:   prepareType(typ.KeyType, results),
+			ValueType: prepareType(typ.ElementType, results),
 		}
 	case *cadence.StructType:
 		return jsonNominalType{
 			Kind:         "Struct",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
 		}
 	case *cadence.ResourceType:
 		return jsonNominalType{
 			Kind:         "Resource",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
 		}
 	case *cadence.EventType:
 		return jsonNominalType{
 			Kind:         "Event",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: [][]jsonParameterType{prepareParameters(typ.Initializer)},
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: [][]jsonParameterType{prepareParameters(typ.Initializer, results)},
 		}
 	case *cadence.ContractType:
 		return jsonNominalType{
 			Kind:         "Contract",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
 		}
 	case *cadence.StructInterfaceType:
 		return jsonNominalType{
 			Kind:         "StructInterface",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
 		}
 	case *cadence.ResourceInterfaceType:
 		return jsonNominalType{
 			Kind:         "ResourceInterface",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
 		}
 	case *cadence.ContractInterfaceType:
 		return jsonNominalType{
 			Kind:         "ContractInterface",
 			Type:         "",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
 		}
 	case cadence.FunctionType:
 		return jsonFunctionType{
 			Kind:       "Function",
 			TypeID:     typ.ID(),
-			Return:     prepareType(typ.ReturnType),
-			Parameters: prepareParameters(typ.Parameters),
+			Return:     prepareType(typ.ReturnType, results),
+			Parameters: prepareParameters(typ.Parameters, results),
 		}
 	case cadence.ReferenceType:
 		return jsonReferenceType{
-			Kind:       "Reference",
-			Authorized: typ.Authorized,
-			Type:       prepareType(typ.Type),
+			Kind:          "Reference",
+			Authorized:    typ.Authorized,
+			Authorization: prepareEntitlementSetAuthorization(typ.Authorization),
+			Type:          prepareType(typ.Type, results),
 		}
 	case cadence.RestrictedType:
 		restrictions := make([]jsonValue, 0)
 		for _, restriction := range typ.Restrictions {
-			restrictions = append(restrictions, prepareType(restriction))
+			restrictions = append(restrictions, prepareType(restriction, results))
 		}
 		return jsonRestrictedType{
 			Kind:         "Restriction",
 			TypeID:       typ.ID(),
-			Type:         prepareType(typ.Type),
+			Type:         prepareType(typ.Type, results),
 			Restrictions: restrictions,
 		}
 	case cadence.CapabilityType:
 		return jsonUnaryType{
 			Kind: "Capability",
-			Type: prepareType(typ.BorrowType),
+			Type: prepareType(typ.BorrowType, results),
 		}
 	case *cadence.EnumType:
 		return jsonNominalType{
 			Kind:         "Enum",
 			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
-			Fields:       prepareFields(typ.Fields),
-			Initializers: prepareInitializers(typ.Initializers),
-			Type:         prepareType(typ.RawType),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
+			Type:         prepareType(typ.RawType, results),
+		}
+	case *cadence.AttachmentType:
+		return jsonNominalType{
+			Kind:         "Attachment",
+			TypeID:       string(typ.Location.TypeID(typ.QualifiedIdentifier)),
+			Fields:       prepareFields(typ.Fields, results),
+			Initializers: prepareInitializers(typ.Initializers, results),
+			Type:         prepareType(typ.BaseType, results),
 		}
 	case nil:
 		return ""
@@ -830,18 +941,25 @@ func prepareTypeValue(typeValue cadence.TypeValue) jsonValue {
 	return jsonValueObject{
 		Type: typeTypeStr,
 		Value: jsonTypeValue{
-			StaticType: prepareType(typeValue.StaticType),
+			StaticType: prepareType(typeValue.StaticType, typePreparationResults{}),
 		},
 	}
 }
 
 func prepareCapability(capability cadence.Capability) jsonValue {
+	// The ID is only set for capabilities issued by capability controllers
+	var id string
+	if capability.ID != 0 {
+		id = encodeUInt(uint64(capability.ID))
+	}
+
 	return jsonValueObject{
 		Type: capabilityTypeStr,
 		Value: jsonCapabilityValue{
 			Path:       preparePath(capability.Path),
 			Address:    encodeBytes(capability.Address.Bytes()),
-			BorrowType: prepareType(capability.BorrowType),
+			BorrowType: prepareType(capability.BorrowType, typePreparationResults{}),
+			ID:         id,
 		},
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 	"unicode/utf8"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/tests/checker"
 
@@ -105,6 +107,24 @@ func TestEncodeString(t *testing.T) {
 	}...)
 }
 
+func TestEncodeBytes(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Empty",
+			cadence.NewBytes([]byte{}),
+			`{"type":"Bytes","value":"0x"}`,
+		},
+		{
+			"Non-empty",
+			cadence.NewBytes([]byte{1, 2, 0xff}),
+			`{"type":"Bytes","value":"0x0102ff"}`,
+		},
+	}...)
+}
+
 func TestEncodeAddress(t *testing.T) {
 
 	t.Parallel()
@@ -116,6 +136,19 @@ func TestEncodeAddress(t *testing.T) {
 	)
 }
 
+func TestDecodeAddress(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("missing prefix", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := json.Decode([]byte(`{"type":"Address","value":"0000000102030405"}`))
+		require.Error(t, err)
+	})
+}
+
 func TestEncodeInt(t *testing.T) {
 
 	t.Parallel()
@@ -988,6 +1021,34 @@ func TestEncodeContract(t *testing.T) {
 	testAllEncodeAndDecode(t, simpleContract, resourceContract)
 }
 
+func TestEncodeAttachment(t *testing.T) {
+
+	t.Parallel()
+
+	attachmentType := &cadence.AttachmentType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "FooAttachment",
+		Fields: []cadence.Field{
+			{
+				Identifier: "a",
+				Type:       cadence.IntType{},
+			},
+		},
+	}
+
+	simpleAttachment := encodeTest{
+		"Simple",
+		cadence.NewAttachment(
+			[]cadence.Value{
+				cadence.NewInt(1),
+			},
+		).WithType(attachmentType),
+		`{"type":"Attachment","value":{"id":"S.test.FooAttachment","fields":[{"name":"a","value":{"type":"Int","value":"1"}}]}}`,
+	}
+
+	testAllEncodeAndDecode(t, simpleAttachment)
+}
+
 func TestEncodeLink(t *testing.T) {
 
 	t.Parallel()
@@ -1042,6 +1103,7 @@ func TestEncodeSimpleTypes(t *testing.T) {
 		cadence.Fix64Type{},
 		cadence.UFix64Type{},
 		cadence.BlockType{},
+		cadence.BlockHeaderType{},
 		cadence.PathType{},
 		cadence.CapabilityPathType{},
 		cadence.StoragePathType{},
@@ -1406,6 +1468,44 @@ func TestEncodeType(t *testing.T) {
 		)
 	})
 
+	t.Run("with static attachment", func(t *testing.T) {
+
+		testEncodeAndDecode(
+			t,
+			cadence.TypeValue{
+				StaticType: &cadence.AttachmentType{
+					Location:            utils.TestLocation,
+					QualifiedIdentifier: "A",
+					BaseType: &cadence.StructType{
+						Location:            utils.TestLocation,
+						QualifiedIdentifier: "S",
+						Fields:              []cadence.Field{},
+						Initializers:        [][]cadence.Parameter{},
+					},
+					Fields: []cadence.Field{
+						{Identifier: "foo", Type: cadence.IntType{}},
+					},
+					Initializers: [][]cadence.Parameter{
+						{{Label: "foo", Identifier: "bar", Type: cadence.IntType{}}},
+					},
+				},
+			},
+			`{"type":"Type", "value": {"staticType":
+					{"kind": "Attachment",
+					 "type" : {"kind" : "Struct", "typeID" : "S.test.S", "fields" : [], "initializers" : [], "type" : ""},
+					 "typeID" : "S.test.A",
+					 "fields" : [
+						  {"id" : "foo", "type": {"kind" : "Int"} }
+					    ],
+					 "initializers" : [
+						  [{"label" : "foo", "id" : "bar", "type": {"kind" : "Int"}}]
+						]
+					}
+				}
+			}`,
+		)
+	})
+
 	t.Run("with static &int", func(t *testing.T) {
 
 		testEncodeAndDecode(
@@ -1422,6 +1522,26 @@ func TestEncodeType(t *testing.T) {
 
 	})
 
+	t.Run("with static entitled &int", func(t *testing.T) {
+
+		testEncodeAndDecode(
+			t,
+			cadence.TypeValue{
+				StaticType: cadence.ReferenceType{
+					Authorization: &cadence.EntitlementSetAuthorization{
+						Entitlements: []common.TypeID{"S.test.E", "S.test.F"},
+						Kind:         cadence.Disjunction,
+					},
+					Type: cadence.IntType{},
+				},
+			},
+			`{"type":"Type","value":{"staticType":{"kind":"Reference",
+			"type" : {"kind" : "Int"}, "authorized" : false,
+			"authorization" : {"kind" : "Disjunction", "entitlements" : ["S.test.E", "S.test.F"]}}}}`,
+		)
+
+	})
+
 	t.Run("with static function", func(t *testing.T) {
 
 		testEncodeAndDecode(
@@ -1766,6 +1886,89 @@ func TestExportRecursiveType(t *testing.T) {
 
 }
 
+func TestEncodeRecursiveType(t *testing.T) {
+
+	t.Parallel()
+
+	ty := &cadence.StructType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Node",
+		Fields: []cadence.Field{
+			{
+				Identifier: "next",
+			},
+		},
+		Initializers: [][]cadence.Parameter{},
+	}
+
+	ty.Fields[0].Type = cadence.OptionalType{
+		Type: ty,
+	}
+
+	testEncodeAndDecode(
+		t,
+		cadence.TypeValue{
+			StaticType: ty,
+		},
+		`
+          {
+            "type": "Type",
+            "value": {
+              "staticType": {
+                "kind": "Struct",
+                "type": "",
+                "typeID": "S.test.Node",
+                "fields": [
+                  {
+                    "id": "next",
+                    "type": {
+                      "kind": "Optional",
+                      "type": "S.test.Node"
+                    }
+                  }
+                ],
+                "initializers": []
+              }
+            }
+          }
+        `,
+	)
+
+	t.Run("EncodeType", func(t *testing.T) {
+
+		t.Parallel()
+
+		actual, err := json.EncodeType(cadence.VariableSizedArrayType{
+			ElementType: ty,
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t,
+			`
+              {
+                "kind": "VariableSizedArray",
+                "type": {
+                  "kind": "Struct",
+                  "type": "",
+                  "typeID": "S.test.Node",
+                  "fields": [
+                    {
+                      "id": "next",
+                      "type": {
+                        "kind": "Optional",
+                        "type": "S.test.Node"
+                      }
+                    }
+                  ],
+                  "initializers": []
+                }
+              }
+            `,
+			string(actual),
+		)
+	})
+}
+
 func TestEncodePath(t *testing.T) {
 
 	t.Parallel()
@@ -1850,6 +2053,94 @@ func TestDecodeInvalidType(t *testing.T) {
 	})
 }
 
+func TestDecodeErrorPath(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(encodedValue string, expectedPath string) {
+		_, err := json.Decode([]byte(encodedValue))
+		require.Error(t, err)
+
+		var decodingErr *json.DecodingError
+		require.ErrorAs(t, err, &decodingErr)
+		assert.Equal(t, expectedPath, decodingErr.Path)
+		assert.ErrorIs(t, err, json.ErrInvalidJSONCadence)
+	}
+
+	t.Run("root", func(t *testing.T) {
+		t.Parallel()
+
+		test(`{"type":"Int","value":1}`, "$")
+	})
+
+	t.Run("array element", func(t *testing.T) {
+		t.Parallel()
+
+		test(
+			`
+            {
+              "type":"Array",
+              "value":[
+                {"type":"Int","value":"1"},
+                {"type":"Int","value":2}
+              ]
+            }
+            `,
+			"$.value[1]",
+		)
+	})
+
+	t.Run("dictionary key", func(t *testing.T) {
+		t.Parallel()
+
+		test(
+			`
+            {
+              "type":"Dictionary",
+              "value":[
+                {
+                  "key":{"type":"String","value":true},
+                  "value":{"type":"Int","value":"1"}
+                }
+              ]
+            }
+            `,
+			"$.value[0].key",
+		)
+	})
+
+	t.Run("optional composite field", func(t *testing.T) {
+		t.Parallel()
+
+		test(
+			`
+            {
+              "type":"Optional",
+              "value":{
+                "type":"Struct",
+                "value":{
+                  "id":"S.test.Foo",
+                  "fields":[
+                    {"name":"a","value":{"type":"Int","value":"1"}},
+                    {"name":"b","value":{"type":"Bool","value":"true"}}
+                  ]
+                }
+              }
+            }
+            `,
+			"$.value.value.fields[1].value",
+		)
+	})
+
+	t.Run("error message", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := json.Decode([]byte(`{"type":"Optional","value":{"type":"Int","value":1}}`))
+		require.Error(t, err)
+		assert.Equal(t, "failed to decode value at $.value: invalid JSON Cadence structure", err.Error())
+	})
+}
+
 func testEncodeAndDecode(t *testing.T, val cadence.Value, expectedJSON string) {
 	actualJSON := testEncode(t, val, expectedJSON)
 	testDecode(t, actualJSON, val)
@@ -1871,6 +2162,11 @@ func testDecode(t *testing.T, actualJSON string, expectedVal cadence.Value) {
 	require.NoError(t, err)
 
 	assert.Equal(t, expectedVal, decodedVal)
+
+	streamDecodedVal, err := json.NewStreamDecoder(strings.NewReader(actualJSON)).Decode()
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedVal, streamDecodedVal)
 }
 
 var fooResourceType = &cadence.ResourceType{
@@ -0,0 +1,549 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/onflow/cadence"
+)
+
+// DefaultMaxDepth is the default maximum nesting depth
+// of JSON objects and arrays accepted by a StreamDecoder.
+//
+const DefaultMaxDepth = 256
+
+var ErrMaxDepthExceeded = errors.New("maximum depth exceeded")
+var ErrMaxSizeExceeded = errors.New("maximum size exceeded")
+var ErrMaxElementsExceeded = errors.New("maximum number of elements exceeded")
+
+// A StreamDecoder decodes JSON-encoded representations of Cadence values
+// incrementally from an io.Reader.
+//
+// Unlike Decoder, it does not read a whole value into memory before decoding it:
+// The elements of arrays and dictionaries are decoded one by one,
+// and DecodeArray and DecodeDictionary allow processing very large arrays and dictionaries
+// without retaining their elements.
+//
+// The decoder limits the nesting depth, the number of elements of arrays and dictionaries,
+// and optionally the number of bytes read, to protect against malicious payloads.
+//
+// Multiple values may be decoded from the same reader,
+// e.g. a stream of newline-delimited values.
+//
+type StreamDecoder struct {
+	dec         *json.Decoder
+	maxDepth    int
+	maxElements int
+	maxSize     int64
+	depth       int
+}
+
+// StreamDecoderOption is an option for a StreamDecoder.
+//
+type StreamDecoderOption func(*StreamDecoder)
+
+// WithMaxDepth returns a stream decoder option which limits
+// the nesting depth of JSON objects and arrays.
+//
+// The default is DefaultMaxDepth.
+//
+func WithMaxDepth(depth int) StreamDecoderOption {
+	return func(d *StreamDecoder) {
+		d.maxDepth = depth
+	}
+}
+
+// WithMaxElements returns a stream decoder option which limits
+// the number of elements of each array, dictionary, and composite.
+//
+// By default, the number of elements is not limited.
+//
+func WithMaxElements(count int) StreamDecoderOption {
+	return func(d *StreamDecoder) {
+		d.maxElements = count
+	}
+}
+
+// WithMaxSize returns a stream decoder option which limits
+// the total number of bytes read from the reader.
+//
+// By default, the number of bytes is not limited.
+//
+// NOTE: The decoder reads ahead, so the number of bytes read
+// may be larger than the size of the values decoded so far.
+//
+func WithMaxSize(size int64) StreamDecoderOption {
+	return func(d *StreamDecoder) {
+		d.maxSize = size
+	}
+}
+
+// NewStreamDecoder initializes a StreamDecoder that will decode JSON-encoded bytes from the
+// given io.Reader.
+//
+func NewStreamDecoder(r io.Reader, options ...StreamDecoderOption) *StreamDecoder {
+	d := &StreamDecoder{
+		maxDepth: DefaultMaxDepth,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	if d.maxSize > 0 {
+		r = &limitedReader{
+			reader:    r,
+			remaining: d.maxSize,
+		}
+	}
+
+	d.dec = json.NewDecoder(r)
+
+	return d
+}
+
+// Decode reads the next JSON-encoded value from the io.Reader and decodes it to a
+// Cadence value.
+//
+// It returns io.EOF if there are no more values.
+//
+// This function returns an error if the bytes represent JSON that is malformed,
+// does not conform to the JSON Cadence specification, or exceeds the limits of the decoder.
+//
+func (d *StreamDecoder) Decode() (value cadence.Value, err error) {
+	if !d.dec.More() {
+		return nil, d.end()
+	}
+
+	defer d.recoverDecodingError(&err)
+
+	return d.decodeValue(), nil
+}
+
+// DecodeArray reads the next JSON-encoded value from the io.Reader, which must be an array,
+// and calls the given function for each of its elements, in order.
+//
+// The elements are not retained, so arrays of any length can be decoded
+// with a bounded amount of memory, as long as the elements are bounded.
+// If the function returns an error, decoding stops and the error is returned.
+//
+// It returns io.EOF if there are no more values.
+//
+func (d *StreamDecoder) DecodeArray(f func(element cadence.Value) error) (err error) {
+	if !d.dec.More() {
+		return d.end()
+	}
+
+	defer d.recoverDecodingError(&err)
+
+	d.decodeObject(func(d *StreamDecoder, typ string) cadence.Value {
+		if typ != arrayTypeStr {
+			panic(fmt.Errorf("%w: expected array, got %s", ErrInvalidJSONCadence, typ))
+		}
+
+		d.decodeArrayElements(func(element cadence.Value) {
+			err := f(element)
+			if err != nil {
+				panic(callbackError{err})
+			}
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+// DecodeDictionary reads the next JSON-encoded value from the io.Reader, which must be a dictionary,
+// and calls the given function for each of its key-value pairs, in order.
+//
+// The pairs are not retained, so dictionaries of any size can be decoded
+// with a bounded amount of memory, as long as the keys and values are bounded.
+// If the function returns an error, decoding stops and the error is returned.
+//
+// It returns io.EOF if there are no more values.
+//
+func (d *StreamDecoder) DecodeDictionary(f func(pair cadence.KeyValuePair) error) (err error) {
+	if !d.dec.More() {
+		return d.end()
+	}
+
+	defer d.recoverDecodingError(&err)
+
+	d.decodeObject(func(d *StreamDecoder, typ string) cadence.Value {
+		if typ != dictionaryTypeStr {
+			panic(fmt.Errorf("%w: expected dictionary, got %s", ErrInvalidJSONCadence, typ))
+		}
+
+		d.decodeDictionaryPairs(func(pair cadence.KeyValuePair) {
+			err := f(pair)
+			if err != nil {
+				panic(callbackError{err})
+			}
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
+// callbackError wraps errors returned by the functions passed to
+// DecodeArray and DecodeDictionary, so they are returned unwrapped.
+//
+type callbackError struct {
+	err error
+}
+
+// end returns the error for the end of the input:
+// io.EOF if the input ended, or the error of reading the input.
+//
+func (d *StreamDecoder) end() error {
+	_, err := d.dec.Token()
+	if err == nil {
+		return fmt.Errorf("%w: unexpected end of value", ErrInvalidJSONCadence)
+	}
+	return err
+}
+
+func (d *StreamDecoder) recoverDecodingError(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if callbackErr, ok := r.(callbackError); ok {
+		*err = callbackErr.err
+		return
+	}
+
+	panicErr, isError := r.(error)
+	if !isError {
+		panic(r)
+	}
+
+	*err = fmt.Errorf("failed to decode value: %w", panicErr)
+}
+
+func (d *StreamDecoder) token() json.Token {
+	token, err := d.dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		panic(err)
+	}
+	return token
+}
+
+func (d *StreamDecoder) expectDelim(expected json.Delim) {
+	token := d.token()
+	delim, ok := token.(json.Delim)
+	if !ok || delim != expected {
+		panic(fmt.Errorf("%w: expected %s", ErrInvalidJSONCadence, expected))
+	}
+}
+
+func (d *StreamDecoder) enter() {
+	d.depth++
+	if d.depth > d.maxDepth {
+		panic(ErrMaxDepthExceeded)
+	}
+}
+
+func (d *StreamDecoder) leave() {
+	d.depth--
+}
+
+func (d *StreamDecoder) string() string {
+	s, ok := d.token().(string)
+	if !ok {
+		panic(fmt.Errorf("%w: expected string", ErrInvalidJSONCadence))
+	}
+	return s
+}
+
+// decodeValue decodes a JSON-Cadence value object.
+//
+func (d *StreamDecoder) decodeValue() cadence.Value {
+	return d.decodeObject((*StreamDecoder).decodeValueContent)
+}
+
+// decodeObject decodes a JSON-Cadence value object, i.e. an object with
+// a "type" and a "value" key, and decodes the value using the given function.
+//
+// If the value occurs before the type, it is buffered,
+// and decoded once the type is known.
+//
+func (d *StreamDecoder) decodeObject(decodeContent func(d *StreamDecoder, typ string) cadence.Value) cadence.Value {
+	d.expectDelim('{')
+	return d.decodeObjectFields(decodeContent)
+}
+
+// decodeObjectFields decodes the fields of a JSON-Cadence value object,
+// after its opening delimiter was read.
+//
+func (d *StreamDecoder) decodeObjectFields(decodeContent func(d *StreamDecoder, typ string) cadence.Value) cadence.Value {
+	d.enter()
+
+	var typ string
+	var value cadence.Value
+	var buffered json.RawMessage
+	var hasValue bool
+
+	for d.dec.More() {
+		switch d.string() {
+		case typeKey:
+			if typ != "" {
+				panic(fmt.Errorf("%w: duplicate type", ErrInvalidJSONCadence))
+			}
+			typ = d.string()
+
+		case valueKey:
+			if hasValue {
+				panic(fmt.Errorf("%w: duplicate value", ErrInvalidJSONCadence))
+			}
+			hasValue = true
+
+			if typ != "" {
+				value = decodeContent(d, typ)
+			} else {
+				err := d.dec.Decode(&buffered)
+				if err != nil {
+					panic(err)
+				}
+			}
+
+		default:
+			panic(ErrInvalidJSONCadence)
+		}
+	}
+
+	d.expectDelim('}')
+
+	switch {
+	case typ == "":
+		panic(fmt.Errorf("%w: missing type", ErrInvalidJSONCadence))
+
+	case typ == voidTypeStr:
+		// void is a special case, does not have "value" field
+		if hasValue {
+			panic(ErrInvalidJSONCadence)
+		}
+		value = cadence.NewVoid()
+
+	case !hasValue:
+		panic(fmt.Errorf("%w: missing value", ErrInvalidJSONCadence))
+
+	case buffered != nil:
+		value = decodeContent(d.bufferedDecoder(buffered), typ)
+	}
+
+	d.leave()
+
+	return value
+}
+
+// bufferedDecoder returns a decoder for a value which was buffered
+// at the current depth of this decoder.
+//
+func (d *StreamDecoder) bufferedDecoder(data []byte) *StreamDecoder {
+	return &StreamDecoder{
+		dec:         json.NewDecoder(bytes.NewReader(data)),
+		maxDepth:    d.maxDepth,
+		maxElements: d.maxElements,
+		depth:       d.depth,
+	}
+}
+
+// decodeValueContent decodes the value of a JSON-Cadence value object with the given type.
+//
+// Arrays, dictionaries, and optionals are decoded incrementally.
+// All other values are read as a whole, checked against the limits of the decoder,
+// and decoded like by Decoder.
+//
+func (d *StreamDecoder) decodeValueContent(typ string) cadence.Value {
+	switch typ {
+	case arrayTypeStr:
+		values := make([]cadence.Value, 0)
+		d.decodeArrayElements(func(element cadence.Value) {
+			values = append(values, element)
+		})
+		return cadence.NewArray(values)
+
+	case dictionaryTypeStr:
+		pairs := make([]cadence.KeyValuePair, 0)
+		d.decodeDictionaryPairs(func(pair cadence.KeyValuePair) {
+			pairs = append(pairs, pair)
+		})
+		return cadence.NewDictionary(pairs)
+
+	case optionalTypeStr:
+		switch token := d.token().(type) {
+		case nil:
+			return cadence.NewOptional(nil)
+		case json.Delim:
+			if token == '{' {
+				return cadence.NewOptional(d.decodeObjectFields((*StreamDecoder).decodeValueContent))
+			}
+		}
+		panic(fmt.Errorf("%w: invalid optional", ErrInvalidJSONCadence))
+	}
+
+	var valueJSON interface{}
+	err := d.dec.Decode(&valueJSON)
+	if err != nil {
+		panic(err)
+	}
+
+	d.checkLimits(valueJSON, d.depth)
+
+	return decodeJSON(map[string]interface{}{
+		typeKey:  typ,
+		valueKey: valueJSON,
+	})
+}
+
+func (d *StreamDecoder) decodeArrayElements(f func(element cadence.Value)) {
+	d.expectDelim('[')
+	d.enter()
+
+	count := 0
+	for d.dec.More() {
+		count++
+		d.checkElementCount(count)
+
+		f(d.decodeValue())
+	}
+
+	d.expectDelim(']')
+	d.leave()
+}
+
+func (d *StreamDecoder) decodeDictionaryPairs(f func(pair cadence.KeyValuePair)) {
+	d.expectDelim('[')
+	d.enter()
+
+	count := 0
+	for d.dec.More() {
+		count++
+		d.checkElementCount(count)
+
+		f(d.decodeKeyValuePair())
+	}
+
+	d.expectDelim(']')
+	d.leave()
+}
+
+func (d *StreamDecoder) decodeKeyValuePair() cadence.KeyValuePair {
+	d.expectDelim('{')
+	d.enter()
+
+	var key, value cadence.Value
+
+	for d.dec.More() {
+		switch d.string() {
+		case keyKey:
+			if key != nil {
+				panic(fmt.Errorf("%w: duplicate key", ErrInvalidJSONCadence))
+			}
+			key = d.decodeValue()
+
+		case valueKey:
+			if value != nil {
+				panic(fmt.Errorf("%w: duplicate value", ErrInvalidJSONCadence))
+			}
+			value = d.decodeValue()
+
+		default:
+			panic(ErrInvalidJSONCadence)
+		}
+	}
+
+	d.expectDelim('}')
+	d.leave()
+
+	if key == nil || value == nil {
+		panic(ErrInvalidJSONCadence)
+	}
+
+	return cadence.KeyValuePair{
+		Key:   key,
+		Value: value,
+	}
+}
+
+func (d *StreamDecoder) checkElementCount(count int) {
+	if d.maxElements > 0 && count > d.maxElements {
+		panic(ErrMaxElementsExceeded)
+	}
+}
+
+// checkLimits checks that the given JSON value, which occurs at the given depth,
+// does not exceed the limits of the decoder.
+//
+func (d *StreamDecoder) checkLimits(valueJSON interface{}, depth int) {
+	switch valueJSON := valueJSON.(type) {
+	case map[string]interface{}:
+		depth++
+		if depth > d.maxDepth {
+			panic(ErrMaxDepthExceeded)
+		}
+		d.checkElementCount(len(valueJSON))
+		for _, element := range valueJSON {
+			d.checkLimits(element, depth)
+		}
+
+	case []interface{}:
+		depth++
+		if depth > d.maxDepth {
+			panic(ErrMaxDepthExceeded)
+		}
+		d.checkElementCount(len(valueJSON))
+		for _, element := range valueJSON {
+			d.checkLimits(element, depth)
+		}
+	}
+}
+
+// limitedReader reads from a reader until the given number of bytes was read,
+// and then fails with ErrMaxSizeExceeded.
+//
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, ErrMaxSizeExceeded
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,321 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+)
+
+func TestStreamDecoder(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("multiple values", func(t *testing.T) {
+
+		t.Parallel()
+
+		decoder := json.NewStreamDecoder(strings.NewReader(`
+          {"type":"Int","value":"1"}
+          {"type":"Void"}
+          {"type":"Optional","value":null}
+          {"type":"Optional","value":{"type":"String","value":"foo"}}
+        `))
+
+		expected := []cadence.Value{
+			cadence.NewInt(1),
+			cadence.NewVoid(),
+			cadence.NewOptional(nil),
+			cadence.NewOptional(cadence.String("foo")),
+		}
+
+		for _, expectedValue := range expected {
+			value, err := decoder.Decode()
+			require.NoError(t, err)
+			assert.Equal(t, expectedValue, value)
+		}
+
+		_, err := decoder.Decode()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("value before type", func(t *testing.T) {
+
+		t.Parallel()
+
+		decoder := json.NewStreamDecoder(strings.NewReader(`
+          {
+            "value": [
+              {"value": "1", "type": "Int"},
+              {"type": "Bool", "value": true}
+            ],
+            "type": "Array"
+          }
+        `))
+
+		value, err := decoder.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewBool(true),
+			}),
+			value,
+		)
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		decoder := json.NewStreamDecoder(strings.NewReader(`
+          {"type":"Array","value":[
+            {"type":"Int","value":"1"},
+            {"type":"Int","value":"2"},
+            {"type":"Int","value":"3"}
+          ]}
+        `))
+
+		var elements []cadence.Value
+		err := decoder.DecodeArray(func(element cadence.Value) error {
+			elements = append(elements, element)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewInt(2),
+				cadence.NewInt(3),
+			},
+			elements,
+		)
+
+		err = decoder.DecodeArray(func(_ cadence.Value) error {
+			return nil
+		})
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("array, callback error", func(t *testing.T) {
+
+		t.Parallel()
+
+		decoder := json.NewStreamDecoder(strings.NewReader(`
+          {"type":"Array","value":[
+            {"type":"Int","value":"1"},
+            {"type":"Int","value":"2"}
+          ]}
+        `))
+
+		stopErr := errors.New("stop")
+
+		count := 0
+		err := decoder.DecodeArray(func(_ cadence.Value) error {
+			count++
+			return stopErr
+		})
+		assert.Equal(t, stopErr, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("array, not an array", func(t *testing.T) {
+
+		t.Parallel()
+
+		decoder := json.NewStreamDecoder(strings.NewReader(`{"type":"Int","value":"1"}`))
+
+		err := decoder.DecodeArray(func(_ cadence.Value) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, json.ErrInvalidJSONCadence)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		decoder := json.NewStreamDecoder(strings.NewReader(`
+          {"type":"Dictionary","value":[
+            {"key":{"type":"String","value":"a"},"value":{"type":"Int","value":"1"}},
+            {"value":{"type":"Int","value":"2"},"key":{"type":"String","value":"b"}}
+          ]}
+        `))
+
+		var pairs []cadence.KeyValuePair
+		err := decoder.DecodeDictionary(func(pair cadence.KeyValuePair) error {
+			pairs = append(pairs, pair)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]cadence.KeyValuePair{
+				{Key: cadence.String("a"), Value: cadence.NewInt(1)},
+				{Key: cadence.String("b"), Value: cadence.NewInt(2)},
+			},
+			pairs,
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, data := range []string{
+			`{"type":"Int"}`,
+			`{"value":"1"}`,
+			`{"type":"Int","value":"1","foo":1}`,
+			`{"type":"Void","value":null}`,
+			`{"type":"Optional","value":1}`,
+			`{"type":"Array","value":{}}`,
+			`{"type":"Dictionary","value":[{"key":{"type":"Int","value":"1"}}]}`,
+			`{"type":"Array","value":[`,
+			`[]`,
+		} {
+			_, err := json.NewStreamDecoder(strings.NewReader(data)).Decode()
+			assert.Error(t, err, data)
+		}
+	})
+}
+
+func TestStreamDecoderLimits(t *testing.T) {
+
+	t.Parallel()
+
+	nested := func(depth int) string {
+		return strings.Repeat(`{"type":"Optional","value":`, depth) +
+			`null` +
+			strings.Repeat(`}`, depth)
+	}
+
+	t.Run("depth", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := json.NewStreamDecoder(
+			strings.NewReader(nested(10)),
+			json.WithMaxDepth(10),
+		).Decode()
+		require.NoError(t, err)
+
+		_, err = json.NewStreamDecoder(
+			strings.NewReader(nested(11)),
+			json.WithMaxDepth(10),
+		).Decode()
+		assert.ErrorIs(t, err, json.ErrMaxDepthExceeded)
+	})
+
+	t.Run("default depth", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := json.NewStreamDecoder(
+			strings.NewReader(nested(json.DefaultMaxDepth + 1)),
+		).Decode()
+		assert.ErrorIs(t, err, json.ErrMaxDepthExceeded)
+	})
+
+	t.Run("depth, composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		data := `
+          {"type":"Array","value":[
+            {"type":"Struct","value":{
+              "id":"S.test.Foo",
+              "fields":[{"name":"bar","value":{"type":"Int","value":"1"}}]
+            }}
+          ]}
+        `
+
+		_, err := json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxDepth(7),
+		).Decode()
+		require.NoError(t, err)
+
+		_, err = json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxDepth(6),
+		).Decode()
+		assert.ErrorIs(t, err, json.ErrMaxDepthExceeded)
+	})
+
+	t.Run("elements", func(t *testing.T) {
+
+		t.Parallel()
+
+		data := `{"type":"Array","value":[{"type":"Int","value":"1"},{"type":"Int","value":"2"}]}`
+
+		_, err := json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxElements(2),
+		).Decode()
+		require.NoError(t, err)
+
+		_, err = json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxElements(1),
+		).Decode()
+		assert.ErrorIs(t, err, json.ErrMaxElementsExceeded)
+
+		// Elements are rejected before the array is complete
+
+		count := 0
+		err = json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxElements(1),
+		).DecodeArray(func(_ cadence.Value) error {
+			count++
+			return nil
+		})
+		assert.ErrorIs(t, err, json.ErrMaxElementsExceeded)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("size", func(t *testing.T) {
+
+		t.Parallel()
+
+		data := `{"type":"String","value":"` + strings.Repeat("a", 1000) + `"}`
+
+		_, err := json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxSize(int64(len(data))),
+		).Decode()
+		require.NoError(t, err)
+
+		_, err = json.NewStreamDecoder(
+			strings.NewReader(data),
+			json.WithMaxSize(100),
+		).Decode()
+		assert.ErrorIs(t, err, json.ErrMaxSizeExceeded)
+	})
+}
@@ -0,0 +1,231 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/json"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ABIVersion is the version of the JSON format of ABIs.
+//
+const ABIVersion = 1
+
+// ABI is a stable, machine-readable description of the public interface of a checked program,
+// e.g. of a contract, intended for client code generators.
+//
+// It describes the declared types and their public functions,
+// the declared events, and the public global functions.
+// All types are described as their external cadence.Type representation.
+//
+type ABI struct {
+	Location  Location
+	Types     []ABIType
+	Events    []*cadence.EventType
+	Functions []ABIFunction
+}
+
+// ABIType describes a composite or interface type declared in a program.
+//
+type ABIType struct {
+	Type      cadence.Type
+	Functions []ABIFunction
+}
+
+// ABIFunction describes a public function.
+//
+type ABIFunction struct {
+	Identifier string
+	Type       cadence.FunctionType
+}
+
+// NewABI returns the ABI of the given checked program, which has the given location.
+// The types, events, and functions are in declaration order.
+//
+func NewABI(location Location, program *interpreter.Program) *ABI {
+	elaboration := program.Elaboration
+
+	results := map[sema.TypeID]cadence.Type{}
+
+	abi := &ABI{
+		Location: location,
+	}
+
+	for _, compositeType := range elaboration.DeclaredCompositeTypes() {
+		exportedType := ExportType(compositeType, results)
+
+		if compositeType.Kind == common.CompositeKindEvent {
+			abi.Events = append(abi.Events, exportedType.(*cadence.EventType))
+			continue
+		}
+
+		abi.Types = append(abi.Types, ABIType{
+			Type:      exportedType,
+			Functions: abiMemberFunctions(compositeType.Members, results),
+		})
+	}
+
+	for _, interfaceType := range elaboration.DeclaredInterfaceTypes() {
+		abi.Types = append(abi.Types, ABIType{
+			Type:      ExportType(interfaceType, results),
+			Functions: abiMemberFunctions(interfaceType.Members, results),
+		})
+	}
+
+	for _, function := range elaboration.DeclaredGlobalFunctions() {
+		if !isPublicAccess(function.Access) {
+			continue
+		}
+
+		abi.Functions = append(abi.Functions, ABIFunction{
+			Identifier: function.Identifier,
+			Type:       ExportType(function.Type, results).(cadence.FunctionType),
+		})
+	}
+
+	return abi
+}
+
+func abiMemberFunctions(members *sema.StringMemberOrderedMap, results map[sema.TypeID]cadence.Type) []ABIFunction {
+	var functions []ABIFunction
+
+	members.Foreach(func(name string, member *sema.Member) {
+		// Predeclared functions, like `getType`, are available for all types,
+		// so they are not part of the ABI
+
+		if member.DeclarationKind != common.DeclarationKindFunction ||
+			member.Predeclared ||
+			!isPublicAccess(member.Access) {
+
+			return
+		}
+
+		functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+		if !ok {
+			return
+		}
+
+		functions = append(functions, ABIFunction{
+			Identifier: name,
+			Type:       ExportType(functionType, results).(cadence.FunctionType),
+		})
+	})
+
+	return functions
+}
+
+func isPublicAccess(access ast.Access) bool {
+	switch access {
+	case ast.AccessPublic, ast.AccessPublicSettable:
+		return true
+	default:
+		return false
+	}
+}
+
+type jsonABI struct {
+	Version   int               `json:"version"`
+	Location  string            `json:"location"`
+	Types     []jsonABIType     `json:"types"`
+	Events    []json.RawMessage `json:"events"`
+	Functions []jsonABIFunction `json:"functions"`
+}
+
+type jsonABIType struct {
+	Type      json.RawMessage   `json:"type"`
+	Functions []jsonABIFunction `json:"functions"`
+}
+
+type jsonABIFunction struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+// MarshalJSON encodes the ABI as JSON.
+// Types are encoded in the JSON-Cadence type format.
+//
+func (abi *ABI) MarshalJSON() ([]byte, error) {
+	result := jsonABI{
+		Version: ABIVersion,
+		Types:   make([]jsonABIType, 0, len(abi.Types)),
+		Events:  make([]json.RawMessage, 0, len(abi.Events)),
+	}
+
+	if abi.Location != nil {
+		result.Location = string(abi.Location.ID())
+	}
+
+	for _, abiType := range abi.Types {
+		encodedType, err := jsoncdc.EncodeType(abiType.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		functions, err := encodeABIFunctions(abiType.Functions)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Types = append(result.Types, jsonABIType{
+			Type:      encodedType,
+			Functions: functions,
+		})
+	}
+
+	for _, event := range abi.Events {
+		encodedType, err := jsoncdc.EncodeType(event)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Events = append(result.Events, encodedType)
+	}
+
+	functions, err := encodeABIFunctions(abi.Functions)
+	if err != nil {
+		return nil, err
+	}
+	result.Functions = functions
+
+	return json.Marshal(result)
+}
+
+func encodeABIFunctions(functions []ABIFunction) ([]jsonABIFunction, error) {
+	result := make([]jsonABIFunction, 0, len(functions))
+
+	for _, function := range functions {
+		encodedType, err := jsoncdc.EncodeType(function.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, jsonABIFunction{
+			Name: function.Identifier,
+			Type: encodedType,
+		})
+	}
+
+	return result, nil
+}
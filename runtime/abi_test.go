@@ -0,0 +1,240 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeGenerateABI(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      pub contract Test {
+
+          pub event Deposited(amount: UFix64)
+
+          pub resource interface Receiver {
+              pub fun deposit(amount: UFix64)
+          }
+
+          pub resource Vault: Receiver {
+              pub var balance: UFix64
+
+              init() {
+                  self.balance = 0.0
+              }
+
+              pub fun deposit(amount: UFix64) {
+                  self.balance = self.balance + amount
+                  emit Deposited(amount: amount)
+              }
+
+              access(contract) fun reset() {
+                  self.balance = 0.0
+              }
+          }
+
+          pub fun createVault(): @Vault {
+              return <-create Vault()
+          }
+
+          access(account) fun helper() {}
+      }
+    `)
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    "Test",
+	}
+
+	runtimeInterface := &testRuntimeInterface{
+		getAccountContractCode: func(address Address, name string) ([]byte, error) {
+			if address == location.Address && name == location.Name {
+				return contract, nil
+			}
+			return nil, nil
+		},
+	}
+
+	abi, err := runtime.GenerateABI(
+		location,
+		Context{
+			Interface: runtimeInterface,
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, abi.Types, 3)
+
+	contractType, ok := abi.Types[0].Type.(*cadence.ContractType)
+	require.True(t, ok)
+	assert.Equal(t, "Test", contractType.QualifiedIdentifier)
+	require.Len(t, abi.Types[0].Functions, 1)
+	assert.Equal(t, "createVault", abi.Types[0].Functions[0].Identifier)
+
+	vaultType, ok := abi.Types[1].Type.(*cadence.ResourceType)
+	require.True(t, ok)
+	assert.Equal(t, "Test.Vault", vaultType.QualifiedIdentifier)
+	assert.Equal(t,
+		[]ABIFunction{
+			{
+				Identifier: "deposit",
+				Type: cadence.FunctionType{
+					Parameters: []cadence.Parameter{
+						{
+							Label:      "",
+							Identifier: "amount",
+							Type:       cadence.UFix64Type{},
+						},
+					},
+					ReturnType: cadence.VoidType{},
+				}.WithID("((UFix64):Void)"),
+			},
+		},
+		abi.Types[1].Functions,
+	)
+
+	receiverType, ok := abi.Types[2].Type.(*cadence.ResourceInterfaceType)
+	require.True(t, ok)
+	assert.Equal(t, "Test.Receiver", receiverType.QualifiedIdentifier)
+	require.Len(t, abi.Types[2].Functions, 1)
+
+	require.Len(t, abi.Events, 1)
+	assert.Equal(t, "Test.Deposited", abi.Events[0].QualifiedIdentifier)
+
+	assert.Empty(t, abi.Functions)
+
+	encoded, err := json.Marshal(abi)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+          {
+            "version": 1,
+            "location": "A.0000000000000001.Test",
+            "types": [
+              {
+                "type": {
+                  "kind": "Contract",
+                  "type": "",
+                  "typeID": "A.0000000000000001.Test",
+                  "fields": [],
+                  "initializers": []
+                },
+                "functions": [
+                  {
+                    "name": "createVault",
+                    "type": {
+                      "kind": "Function",
+                      "typeID": "(():A.0000000000000001.Test.Vault)",
+                      "parameters": [],
+                      "return": {
+                        "kind": "Resource",
+                        "type": "",
+                        "typeID": "A.0000000000000001.Test.Vault",
+                        "fields": [
+                          {"id": "uuid", "type": {"kind": "UInt64"}},
+                          {"id": "balance", "type": {"kind": "UFix64"}}
+                        ],
+                        "initializers": []
+                      }
+                    }
+                  }
+                ]
+              },
+              {
+                "type": {
+                  "kind": "Resource",
+                  "type": "",
+                  "typeID": "A.0000000000000001.Test.Vault",
+                  "fields": [
+                    {"id": "uuid", "type": {"kind": "UInt64"}},
+                    {"id": "balance", "type": {"kind": "UFix64"}}
+                  ],
+                  "initializers": []
+                },
+                "functions": [
+                  {
+                    "name": "deposit",
+                    "type": {
+                      "kind": "Function",
+                      "typeID": "((UFix64):Void)",
+                      "parameters": [
+                        {"label": "", "id": "amount", "type": {"kind": "UFix64"}}
+                      ],
+                      "return": {"kind": "Void"}
+                    }
+                  }
+                ]
+              },
+              {
+                "type": {
+                  "kind": "ResourceInterface",
+                  "type": "",
+                  "typeID": "A.0000000000000001.Test.Receiver",
+                  "fields": [
+                    {"id": "uuid", "type": {"kind": "UInt64"}}
+                  ],
+                  "initializers": []
+                },
+                "functions": [
+                  {
+                    "name": "deposit",
+                    "type": {
+                      "kind": "Function",
+                      "typeID": "((UFix64):Void)",
+                      "parameters": [
+                        {"label": "", "id": "amount", "type": {"kind": "UFix64"}}
+                      ],
+                      "return": {"kind": "Void"}
+                    }
+                  }
+                ]
+              }
+            ],
+            "events": [
+              {
+                "kind": "Event",
+                "type": "",
+                "typeID": "A.0000000000000001.Test.Deposited",
+                "fields": [
+                  {"id": "amount", "type": {"kind": "UFix64"}}
+                ],
+                "initializers": [[]]
+              }
+            ],
+            "functions": []
+          }
+        `,
+		string(encoded),
+	)
+}
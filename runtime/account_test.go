@@ -1765,3 +1765,259 @@ func TestGetAuthAccount(t *testing.T) {
 		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
 	})
 }
+
+func TestRuntimeAccountLinking(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	var events []cadence.Event
+	var loggedMessages []string
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	// Link the account
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              #allowAccountLinking
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      let capability = signer.linkAccount(/private/account)!
+                      signer.link<&AuthAccount>(/public/account, target: /private/account)
+                      log(capability.check())
+                      log(signer.linkAccount(/private/account) == nil)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"true", "true"}, loggedMessages)
+
+	require.Len(t, events, 1)
+	assert.EqualValues(t, stdlib.AccountLinkedEventType.ID(), events[0].Type().ID())
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.Address(address),
+			cadence.Path{
+				Domain:     "private",
+				Identifier: "account",
+			},
+		},
+		events[0].Fields,
+	)
+
+	// Borrow the linked account
+
+	loggedMessages = nil
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(`
+              pub fun main(): Address {
+                  let account = getAccount(0x1)
+                  let capability = account.getCapability<&AuthAccount>(/public/account)
+                  log(account.getCapability<&Int>(/public/account).borrow() == nil)
+                  return capability.borrow()!.address
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.Address(address), value)
+	assert.Equal(t, []string{"true"}, loggedMessages)
+}
+
+func TestRuntimeCapabilityControllers(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	var events []cadence.Event
+	var loggedMessages []string
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	// Issue and publish a capability
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save(42, to: /storage/answer)
+                      let capability = signer.capabilities.storage.issue<&Int>(/storage/answer)
+                      signer.capabilities.publish(capability, at: /public/answer)
+                      log(capability.id)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1"}, loggedMessages)
+
+	require.Len(t, events, 1)
+	assert.EqualValues(t,
+		stdlib.StorageCapabilityControllerIssuedEventType.ID(),
+		events[0].Type().ID(),
+	)
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.UInt64(1),
+			cadence.Address(address),
+			cadence.TypeValue{
+				StaticType: cadence.ReferenceType{
+					Type: cadence.IntType{},
+				},
+			},
+			cadence.Path{
+				Domain:     "storage",
+				Identifier: "answer",
+			},
+		},
+		events[0].Fields,
+	)
+
+	// Borrow and get the published capability
+
+	script := []byte(`
+      pub fun main(): Capability<&Int>? {
+          let account = getAccount(0x1)
+          log(account.capabilities.borrow<&Int>(/public/answer)!.toString())
+          return account.capabilities.get<&Int>(/public/answer)
+      }
+    `)
+
+	loggedMessages = nil
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`"42"`}, loggedMessages)
+	assert.Equal(t,
+		cadence.NewOptional(
+			cadence.Capability{
+				Address: cadence.Address(address),
+				BorrowType: cadence.ReferenceType{
+					Type: cadence.IntType{},
+				},
+				ID: 1,
+			},
+		),
+		value,
+	)
+
+	// Revoke the capability
+
+	events = nil
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      for controller in signer.capabilities.storage.getControllers(forPath: /storage/answer) {
+                          controller.revoke()
+                      }
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.EqualValues(t,
+		stdlib.StorageCapabilityControllerRevokedEventType.ID(),
+		events[0].Type().ID(),
+	)
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.UInt64(1),
+			cadence.Address(address),
+		},
+		events[0].Fields,
+	)
+
+	value, err = runtime.ExecuteScript(
+		Script{
+			Source: []byte(`
+              pub fun main(): Bool {
+                  return getAccount(0x1).capabilities.borrow<&Int>(/public/answer) == nil
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewBool(true), value)
+}
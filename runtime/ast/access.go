@@ -20,7 +20,11 @@ package ast
 
 import (
 	"encoding/json"
+	"strings"
 
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
 
@@ -29,6 +33,8 @@ import (
 type Access uint
 
 // NOTE: order indicates permissiveness: from least to most permissive!
+// The only exception is AccessEntitlement, which was added last,
+// and is between account and public access, see permissiveness
 
 const (
 	AccessNotSpecified Access = iota
@@ -37,14 +43,26 @@ const (
 	AccessAccount
 	AccessPublic
 	AccessPublicSettable
+	// AccessEntitlement is the access of members which require entitlements,
+	// e.g. `access(E)`. The entitlements are declared by the member declaration
+	AccessEntitlement
 )
 
 func AccessCount() int {
 	return len(_Access_index) - 1
 }
 
+// permissiveness returns the rank of the access, from least to most permissive
+//
+func (a Access) permissiveness() int {
+	if a == AccessEntitlement {
+		return int(AccessAccount)*2 + 1
+	}
+	return int(a) * 2
+}
+
 func (a Access) IsLessPermissiveThan(otherAccess Access) bool {
-	return a < otherAccess
+	return a.permissiveness() < otherAccess.permissiveness()
 }
 
 // TODO: remove.
@@ -77,6 +95,8 @@ func (a Access) Keyword() string {
 		return "access(account)"
 	case AccessContract:
 		return "access(contract)"
+	case AccessEntitlement:
+		return "access(entitlement)"
 	}
 
 	panic(errors.NewUnreachableError())
@@ -96,6 +116,8 @@ func (a Access) Description() string {
 		return "account"
 	case AccessContract:
 		return "contract"
+	case AccessEntitlement:
+		return "entitlement"
 	}
 
 	panic(errors.NewUnreachableError())
@@ -104,3 +126,104 @@ func (a Access) Description() string {
 func (a Access) MarshalJSON() ([]byte, error) {
 	return json.Marshal(a.String())
 }
+
+func (a *Access) UnmarshalJSON(data []byte) error {
+	index, err := common.UnmarshalEnumJSON(data, AccessCount(), func(index int) string {
+		return Access(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*a = Access(index)
+	return nil
+}
+
+// EntitlementSetKind
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=EntitlementSetKind
+
+type EntitlementSetKind uint8
+
+const (
+	// EntitlementSetKindConjunction is the kind of entitlement sets
+	// which require all entitlements, e.g. `E1, E2`
+	EntitlementSetKindConjunction EntitlementSetKind = iota
+	// EntitlementSetKindDisjunction is the kind of entitlement sets
+	// which require one of the entitlements, e.g. `E1 | E2`
+	EntitlementSetKindDisjunction
+)
+
+// Separator returns the separator of the entitlements of a set of this kind
+//
+func (k EntitlementSetKind) Separator() string {
+	switch k {
+	case EntitlementSetKindConjunction:
+		return ","
+	case EntitlementSetKindDisjunction:
+		return " |"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+func (k EntitlementSetKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+func (k *EntitlementSetKind) UnmarshalJSON(data []byte) error {
+	index, err := common.UnmarshalEnumJSON(data, len(_EntitlementSetKind_index) - 1, func(index int) string {
+		return EntitlementSetKind(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*k = EntitlementSetKind(index)
+	return nil
+}
+
+// EntitlementSet is the set of entitlements of an entitlement access modifier,
+// e.g. `access(E1, E2)`, or of an authorized reference type, e.g. `auth(E1 | E2) &T`.
+//
+// The set may also consist of a single entitlement mapping, e.g. `access(M)`.
+//
+type EntitlementSet struct {
+	Kind         EntitlementSetKind
+	Entitlements []*NominalType
+}
+
+func (s *EntitlementSet) String() string {
+	var builder strings.Builder
+	separator := s.Kind.Separator()
+	for i, entitlement := range s.Entitlements {
+		if i > 0 {
+			builder.WriteString(separator)
+			builder.WriteRune(' ')
+		}
+		builder.WriteString(entitlement.String())
+	}
+	return builder.String()
+}
+
+func (s *EntitlementSet) Doc() prettier.Doc {
+	separatorDoc := prettier.Text(s.Kind.Separator())
+
+	var doc prettier.Concat
+	for i, entitlement := range s.Entitlements {
+		if i > 0 {
+			doc = append(
+				doc,
+				separatorDoc,
+				prettier.Space,
+			)
+		}
+		doc = append(doc, entitlement.Doc())
+	}
+	return doc
+}
+
+// EntitlementAccessKeyword returns the access modifier for the given entitlement set,
+// e.g. `access(E1, E2)`
+//
+func EntitlementAccessKeyword(entitlements *EntitlementSet) string {
+	return "access(" + entitlements.String() + ")"
+}
@@ -14,11 +14,12 @@ func _() {
 	_ = x[AccessAccount-3]
 	_ = x[AccessPublic-4]
 	_ = x[AccessPublicSettable-5]
+	_ = x[AccessEntitlement-6]
 }
 
-const _Access_name = "AccessNotSpecifiedAccessPrivateAccessContractAccessAccountAccessPublicAccessPublicSettable"
+const _Access_name = "AccessNotSpecifiedAccessPrivateAccessContractAccessAccountAccessPublicAccessPublicSettableAccessEntitlement"
 
-var _Access_index = [...]uint8{0, 18, 31, 45, 58, 70, 90}
+var _Access_index = [...]uint8{0, 18, 31, 45, 58, 70, 90, 107}
 
 func (i Access) String() string {
 	if i >= Access(len(_Access_index)-1) {
@@ -62,3 +62,25 @@ func (a *Argument) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(a),
 	})
 }
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	type Alias Argument
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(a),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	a.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
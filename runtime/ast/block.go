@@ -64,16 +64,10 @@ func StatementsDoc(statements []Statement) prettier.Doc {
 	var statementsDoc prettier.Concat
 
 	for _, statement := range statements {
-		// TODO: replace once Statement implements Doc
-		hasDoc, ok := statement.(interface{ Doc() prettier.Doc })
-		if !ok {
-			continue
-		}
-
 		statementsDoc = append(
 			statementsDoc,
 			prettier.HardLine{},
-			hasDoc.Doc(),
+			statement.Doc(),
 		)
 	}
 
@@ -91,6 +85,28 @@ func (b *Block) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type Alias Block
+	aux := struct {
+		Statements []json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(b),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	b.Statements, err = unmarshalStatementsJSON(aux.Statements)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // FunctionBlock
 
 type FunctionBlock struct {
@@ -116,6 +132,53 @@ func (b *FunctionBlock) Walk(walkChild func(Element)) {
 	// TODO: post-conditions
 }
 
+var preConditionsKeywordDoc prettier.Doc = prettier.Text("pre")
+var postConditionsKeywordDoc prettier.Doc = prettier.Text("post")
+
+func (b *FunctionBlock) Doc() prettier.Doc {
+	if b.IsEmpty() {
+		return blockEmptyDoc
+	}
+
+	if b.PreConditions.IsEmpty() && b.PostConditions.IsEmpty() {
+		return b.Block.Doc()
+	}
+
+	var bodyDoc prettier.Concat
+
+	if !b.PreConditions.IsEmpty() {
+		bodyDoc = append(
+			bodyDoc,
+			prettier.HardLine{},
+			b.PreConditions.Doc(preConditionsKeywordDoc),
+		)
+	}
+
+	if !b.PostConditions.IsEmpty() {
+		bodyDoc = append(
+			bodyDoc,
+			prettier.HardLine{},
+			b.PostConditions.Doc(postConditionsKeywordDoc),
+		)
+	}
+
+	if b.Block != nil && !b.Block.IsEmpty() {
+		bodyDoc = append(
+			bodyDoc,
+			StatementsDoc(b.Block.Statements),
+		)
+	}
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: bodyDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
+
 func (b *FunctionBlock) MarshalJSON() ([]byte, error) {
 	type Alias FunctionBlock
 	return json.Marshal(&struct {
@@ -145,6 +208,56 @@ type Condition struct {
 	Message Expression
 }
 
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	type Alias Condition
+	aux := struct {
+		Test    json.RawMessage
+		Message json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	c.Test, err = unmarshalExpressionJSON(aux.Test)
+	if err != nil {
+		return err
+	}
+
+	c.Message, err = unmarshalExpressionJSON(aux.Message)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var conditionMessageSeparatorDoc prettier.Doc = prettier.Text(":")
+
+func (c *Condition) Doc() prettier.Doc {
+	doc := c.Test.Doc()
+	if c.Message == nil {
+		return doc
+	}
+
+	return prettier.Group{
+		Doc: prettier.Concat{
+			doc,
+			conditionMessageSeparatorDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					c.Message.Doc(),
+				},
+			},
+		},
+	}
+}
+
 // Conditions
 
 type Conditions []*Condition
@@ -152,3 +265,37 @@ type Conditions []*Condition
 func (c *Conditions) IsEmpty() bool {
 	return c == nil || len(*c) == 0
 }
+
+// Doc returns the document for the conditions block,
+// introduced by the given keyword, i.e. `pre` or `post`
+//
+func (c *Conditions) Doc(keywordDoc prettier.Doc) prettier.Doc {
+	if c.IsEmpty() {
+		return prettier.Concat{
+			keywordDoc,
+			prettier.Space,
+			blockEmptyDoc,
+		}
+	}
+
+	var conditionsDoc prettier.Concat
+
+	for _, condition := range *c {
+		conditionsDoc = append(
+			conditionsDoc,
+			prettier.HardLine{},
+			condition.Doc(),
+		)
+	}
+
+	return prettier.Concat{
+		keywordDoc,
+		prettier.Space,
+		blockStartDoc,
+		prettier.Indent{
+			Doc: conditionsDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
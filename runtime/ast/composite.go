@@ -21,17 +21,21 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
 // CompositeDeclaration
 
-// NOTE: For events, only an empty initializer is declared
+// NOTE: For events, only an empty initializer is declared.
+// Only attachments have a base type, the type they extend.
 
 type CompositeDeclaration struct {
 	Access        Access
 	CompositeKind common.CompositeKind
 	Identifier    Identifier
+	BaseType      *NominalType `json:",omitempty"`
 	Conformances  []*NominalType
 	Members       *Members
 	DocString     string
@@ -84,10 +88,87 @@ func (d *CompositeDeclaration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+var compositeBaseTypeSeparatorDoc prettier.Doc = prettier.Text(" for ")
+var compositeConformanceSeparatorDoc prettier.Doc = prettier.Text(":")
+var compositeConformancesSeparatorDoc prettier.Doc = prettier.Concat{
+	prettier.Text(","),
+	prettier.Line{},
+}
+
+func (d *CompositeDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		prettier.Text(d.CompositeKind.Keyword()),
+		prettier.Space,
+		prettier.Text(d.Identifier.Identifier),
+	}
+
+	// Events are declared with the parameter list of their initializer,
+	// e.g. `event Foo(bar: Int)`
+
+	if d.CompositeKind == common.CompositeKindEvent {
+		var parameterList *ParameterList
+		initializers := d.Members.Initializers()
+		if len(initializers) > 0 {
+			parameterList = initializers[0].FunctionDeclaration.ParameterList
+		}
+
+		return declarationDoc(
+			d.DocString,
+			d.Access,
+			append(
+				doc,
+				functionSignatureDoc(parameterList, nil),
+			),
+		)
+	}
+
+	if d.BaseType != nil {
+		doc = append(
+			doc,
+			compositeBaseTypeSeparatorDoc,
+			d.BaseType.Doc(),
+		)
+	}
+
+	if len(d.Conformances) > 0 {
+		conformanceDocs := make([]prettier.Doc, len(d.Conformances))
+		for i, conformance := range d.Conformances {
+			conformanceDocs[i] = conformance.Doc()
+		}
+
+		doc = append(
+			doc,
+			compositeConformanceSeparatorDoc,
+			prettier.Group{
+				Doc: prettier.Indent{
+					Doc: prettier.Concat{
+						prettier.Line{},
+						prettier.Join(
+							compositeConformancesSeparatorDoc,
+							conformanceDocs...,
+						),
+					},
+				},
+			},
+		)
+	}
+
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		append(
+			doc,
+			prettier.Space,
+			d.Members.Doc(),
+		),
+	)
+}
+
 // FieldDeclaration
 
 type FieldDeclaration struct {
 	Access         Access
+	Entitlements   *EntitlementSet `json:",omitempty"`
 	VariableKind   VariableKind
 	Identifier     Identifier
 	TypeAnnotation *TypeAnnotation
@@ -137,6 +218,33 @@ func (d *FieldDeclaration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (d *FieldDeclaration) Doc() prettier.Doc {
+	var doc prettier.Concat
+
+	keyword := d.VariableKind.Keyword()
+	if keyword != "" {
+		doc = append(
+			doc,
+			prettier.Text(keyword),
+			prettier.Space,
+		)
+	}
+
+	return memberDeclarationDoc(
+		d.DocString,
+		d.Access,
+		d.Entitlements,
+		prettier.Group{
+			Doc: append(
+				doc,
+				prettier.Text(d.Identifier.Identifier),
+				typeSeparatorDoc,
+				d.TypeAnnotation.Doc(),
+			),
+		},
+	)
+}
+
 // EnumCaseDeclaration
 
 type EnumCaseDeclaration struct {
@@ -196,3 +304,35 @@ func (d *EnumCaseDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *EnumCaseDeclaration) UnmarshalJSON(data []byte) error {
+	type Alias EnumCaseDeclaration
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	d.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
+var enumCaseKeywordSpaceDoc prettier.Doc = prettier.Text("case ")
+
+func (d *EnumCaseDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			enumCaseKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+		},
+	)
+}
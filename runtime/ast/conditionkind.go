@@ -21,6 +21,7 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
 
@@ -52,3 +53,14 @@ func (k ConditionKind) Name() string {
 func (k ConditionKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *ConditionKind) UnmarshalJSON(data []byte) error {
+	index, err := common.UnmarshalEnumJSON(data, ConditionKindCount(), func(index int) string {
+		return ConditionKind(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*k = ConditionKind(index)
+	return nil
+}
@@ -18,7 +18,13 @@
 
 package ast
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
 
 type Declaration interface {
 	Element
@@ -28,4 +34,80 @@ type Declaration interface {
 	DeclarationAccess() Access
 	DeclarationMembers() *Members
 	DeclarationDocString() string
+	Doc() prettier.Doc
+}
+
+var docStringLinePrefixDoc prettier.Doc = prettier.Text("///")
+
+const entitlementAccessKeywordDoc = prettier.Text("access")
+
+const docStringBlockStart = "/**"
+const docStringBlockEnd = "*/"
+
+// isBlockDocString returns true if the given doc string
+// is best written as a block comment, i.e. `/** ... */`.
+//
+// Multi-line doc strings which start on the line after the comment start,
+// like most block comments, are written as block comments,
+// unless they contain comment delimiters
+//
+func isBlockDocString(docString string) bool {
+	return strings.HasPrefix(docString, "\n") &&
+		!strings.Contains(docString, "/*") &&
+		!strings.Contains(docString, docStringBlockEnd)
+}
+
+// declarationDoc returns the document for a declaration:
+// the doc string, if any, as line comments,
+// the access modifier, if any, and the given document for the rest of the declaration
+//
+func declarationDoc(docString string, access Access, doc prettier.Doc) prettier.Doc {
+	return memberDeclarationDoc(docString, access, nil, doc)
+}
+
+// memberDeclarationDoc returns the document for a member declaration, like declarationDoc,
+// but the access modifier of members with entitlement access includes the given entitlements
+//
+func memberDeclarationDoc(docString string, access Access, entitlements *EntitlementSet, doc prettier.Doc) prettier.Doc {
+	var result prettier.Concat
+
+	if isBlockDocString(docString) {
+		result = append(
+			result,
+			prettier.Text(docStringBlockStart+docString+docStringBlockEnd),
+			prettier.HardLine{},
+		)
+	} else if docString != "" {
+		for _, line := range strings.Split(docString, "\n") {
+			result = append(
+				result,
+				docStringLinePrefixDoc,
+				prettier.Text(line),
+				prettier.HardLine{},
+			)
+		}
+	}
+
+	if access == AccessEntitlement && entitlements != nil {
+		result = append(
+			result,
+			entitlementAccessKeywordDoc,
+			prettier.Text("("),
+			entitlements.Doc(),
+			prettier.Text(")"),
+			prettier.Space,
+		)
+	} else if access != AccessNotSpecified {
+		result = append(
+			result,
+			prettier.Text(access.Keyword()),
+			prettier.Space,
+		)
+	}
+
+	if len(result) == 0 {
+		return doc
+	}
+
+	return append(result, doc)
 }
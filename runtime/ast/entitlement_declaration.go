@@ -0,0 +1,205 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"encoding/json"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// EntitlementDeclaration
+
+type EntitlementDeclaration struct {
+	Access     Access
+	Identifier Identifier
+	DocString  string
+	Range
+}
+
+var _ Declaration = &EntitlementDeclaration{}
+
+func (d *EntitlementDeclaration) Accept(visitor Visitor) Repr {
+	return visitor.VisitEntitlementDeclaration(d)
+}
+
+func (*EntitlementDeclaration) Walk(_ func(Element)) {
+	// NO-OP
+}
+
+func (*EntitlementDeclaration) isDeclaration() {}
+
+func (d *EntitlementDeclaration) DeclarationIdentifier() *Identifier {
+	return &d.Identifier
+}
+
+func (d *EntitlementDeclaration) DeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindEntitlement
+}
+
+func (d *EntitlementDeclaration) DeclarationAccess() Access {
+	return d.Access
+}
+
+func (d *EntitlementDeclaration) DeclarationMembers() *Members {
+	return nil
+}
+
+func (d *EntitlementDeclaration) DeclarationDocString() string {
+	return d.DocString
+}
+
+func (d *EntitlementDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias EntitlementDeclaration
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "EntitlementDeclaration",
+		Alias: (*Alias)(d),
+	})
+}
+
+const entitlementKeywordSpaceDoc = prettier.Text("entitlement ")
+
+func (d *EntitlementDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			entitlementKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+		},
+	)
+}
+
+// EntitlementMapElement is an association of an entitlement mapping,
+// e.g. `A -> B`: references which have the input entitlement
+// are granted the output entitlement
+//
+type EntitlementMapElement struct {
+	Input  *NominalType
+	Output *NominalType
+}
+
+var entitlementMapElementArrowDoc prettier.Doc = prettier.Text(" -> ")
+
+func (e *EntitlementMapElement) Doc() prettier.Doc {
+	return prettier.Concat{
+		e.Input.Doc(),
+		entitlementMapElementArrowDoc,
+		e.Output.Doc(),
+	}
+}
+
+// EntitlementMappingDeclaration
+
+type EntitlementMappingDeclaration struct {
+	Access       Access
+	Identifier   Identifier
+	Associations []*EntitlementMapElement
+	DocString    string
+	Range
+}
+
+var _ Declaration = &EntitlementMappingDeclaration{}
+
+func (d *EntitlementMappingDeclaration) Accept(visitor Visitor) Repr {
+	return visitor.VisitEntitlementMappingDeclaration(d)
+}
+
+func (*EntitlementMappingDeclaration) Walk(_ func(Element)) {
+	// NO-OP
+}
+
+func (*EntitlementMappingDeclaration) isDeclaration() {}
+
+func (d *EntitlementMappingDeclaration) DeclarationIdentifier() *Identifier {
+	return &d.Identifier
+}
+
+func (d *EntitlementMappingDeclaration) DeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindEntitlementMapping
+}
+
+func (d *EntitlementMappingDeclaration) DeclarationAccess() Access {
+	return d.Access
+}
+
+func (d *EntitlementMappingDeclaration) DeclarationMembers() *Members {
+	return nil
+}
+
+func (d *EntitlementMappingDeclaration) DeclarationDocString() string {
+	return d.DocString
+}
+
+func (d *EntitlementMappingDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias EntitlementMappingDeclaration
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "EntitlementMappingDeclaration",
+		Alias: (*Alias)(d),
+	})
+}
+
+const entitlementMappingKeywordsSpaceDoc = prettier.Text("entitlement mapping ")
+
+func (d *EntitlementMappingDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		entitlementMappingKeywordsSpaceDoc,
+		prettier.Text(d.Identifier.Identifier),
+		prettier.Space,
+	}
+
+	if len(d.Associations) == 0 {
+		doc = append(doc, blockEmptyDoc)
+	} else {
+		var associationsDoc prettier.Concat
+		for i, association := range d.Associations {
+			if i > 0 {
+				associationsDoc = append(associationsDoc, prettier.HardLine{})
+			}
+			associationsDoc = append(associationsDoc, association.Doc())
+		}
+
+		doc = append(
+			doc,
+			blockStartDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.HardLine{},
+					associationsDoc,
+				},
+			},
+			prettier.HardLine{},
+			blockEndDoc,
+		)
+	}
+
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		doc,
+	)
+}
@@ -147,6 +147,21 @@ func (e *NilExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *NilExpression) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		Range
+	}{}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Pos = aux.Range.StartPos
+
+	return nil
+}
+
 // StringExpression
 
 type StringExpression struct {
@@ -247,6 +262,28 @@ func (e *IntegerExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *IntegerExpression) UnmarshalJSON(data []byte) error {
+	type Alias IntegerExpression
+	aux := struct {
+		Value string
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Value, err = unmarshalBigIntJSON(aux.Value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // FixedPointExpression
 
 type FixedPointExpression struct {
@@ -322,6 +359,34 @@ func (e *FixedPointExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *FixedPointExpression) UnmarshalJSON(data []byte) error {
+	type Alias FixedPointExpression
+	aux := struct {
+		UnsignedInteger string
+		Fractional      string
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.UnsignedInteger, err = unmarshalBigIntJSON(aux.UnsignedInteger)
+	if err != nil {
+		return err
+	}
+
+	e.Fractional, err = unmarshalBigIntJSON(aux.Fractional)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ArrayExpression
 
 type ArrayExpression struct {
@@ -391,6 +456,28 @@ func (e *ArrayExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ArrayExpression) UnmarshalJSON(data []byte) error {
+	type Alias ArrayExpression
+	aux := struct {
+		Values []json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Values, err = unmarshalExpressionsJSON(aux.Values)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // DictionaryExpression
 
 type DictionaryExpression struct {
@@ -482,6 +569,34 @@ func (e DictionaryEntry) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *DictionaryEntry) UnmarshalJSON(data []byte) error {
+	type Alias DictionaryEntry
+	aux := struct {
+		Key   json.RawMessage
+		Value json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Key, err = unmarshalExpressionJSON(aux.Key)
+	if err != nil {
+		return err
+	}
+
+	e.Value, err = unmarshalExpressionJSON(aux.Value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var dictionaryKeyValueSeparatorDoc prettier.Doc = prettier.Concat{
 	prettier.Text(":"),
 	prettier.Line{},
@@ -619,10 +734,16 @@ func (e *InvocationExpression) String() string {
 }
 
 func (e *InvocationExpression) Doc() prettier.Doc {
+	return e.doc(postfixTargetDoc(e.InvokedExpression))
+}
+
+// doc returns the document for the invocation,
+// given the document for the invoked expression
+//
+func (e *InvocationExpression) doc(invokedExpressionDoc prettier.Doc) prettier.Doc {
 
 	result := prettier.Concat{
-		// TODO: potentially parenthesize
-		e.InvokedExpression.Doc(),
+		invokedExpressionDoc,
 	}
 
 	if len(e.TypeArguments) > 0 {
@@ -688,6 +809,31 @@ func (e *InvocationExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *InvocationExpression) UnmarshalJSON(data []byte) error {
+	type Alias InvocationExpression
+	aux := struct {
+		InvokedExpression json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.InvokedExpression, err = unmarshalExpressionJSON(aux.InvokedExpression)
+	if err != nil {
+		return err
+	}
+
+	e.EndPos = aux.Range.EndPos
+
+	return nil
+}
+
 // AccessExpression
 
 type AccessExpression interface {
@@ -752,9 +898,21 @@ func (e *MemberExpression) Doc() prettier.Doc {
 	} else {
 		separatorDoc = memberExpressionSeparatorDoc
 	}
+
+	targetDoc := parenthesizedExpressionDoc(e.Expression, precedenceAccess)
+
+	// A decimal integer literal followed by the separator
+	// would be parsed as a fixed-point literal, e.g. `1.toString()`
+
+	if integerExpression, ok := e.Expression.(*IntegerExpression); ok &&
+		integerExpression.Base == 10 &&
+		integerExpression.Value.Sign() >= 0 {
+
+		targetDoc = prettier.WrapParentheses(targetDoc, prettier.SoftLine{})
+	}
+
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		targetDoc,
 		prettier.Group{
 			Doc: prettier.Indent{
 				Doc: prettier.Concat{
@@ -792,6 +950,28 @@ func (e *MemberExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *MemberExpression) UnmarshalJSON(data []byte) error {
+	type Alias MemberExpression
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // IndexExpression
 
 type IndexExpression struct {
@@ -834,8 +1014,7 @@ func (e *IndexExpression) String() string {
 
 func (e *IndexExpression) Doc() prettier.Doc {
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.TargetExpression.Doc(),
+		postfixTargetDoc(e.TargetExpression),
 		prettier.WrapBrackets(
 			e.IndexingExpression.Doc(),
 			prettier.SoftLine{},
@@ -854,6 +1033,34 @@ func (e *IndexExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *IndexExpression) UnmarshalJSON(data []byte) error {
+	type Alias IndexExpression
+	aux := struct {
+		TargetExpression   json.RawMessage
+		IndexingExpression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.TargetExpression, err = unmarshalExpressionJSON(aux.TargetExpression)
+	if err != nil {
+		return err
+	}
+
+	e.IndexingExpression, err = unmarshalExpressionJSON(aux.IndexingExpression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ConditionalExpression
 
 type ConditionalExpression struct {
@@ -900,14 +1107,11 @@ var conditionalExpressionBranchSeparatorDoc prettier.Doc = prettier.Concat{
 }
 
 func (e *ConditionalExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	testDoc := e.Test.Doc()
+	testDoc := parenthesizedExpressionDoc(e.Test, precedenceLogicalOr)
 
-	// TODO: potentially parenthesize
-	thenDoc := e.Then.Doc()
+	thenDoc := parenthesizedExpressionDoc(e.Then, precedenceTernary)
 
-	// TODO: potentially parenthesize
-	elseDoc := e.Else.Doc()
+	elseDoc := parenthesizedExpressionDoc(e.Else, precedenceTernary)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -949,6 +1153,40 @@ func (e *ConditionalExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ConditionalExpression) UnmarshalJSON(data []byte) error {
+	type Alias ConditionalExpression
+	aux := struct {
+		Test json.RawMessage
+		Then json.RawMessage
+		Else json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Test, err = unmarshalExpressionJSON(aux.Test)
+	if err != nil {
+		return err
+	}
+
+	e.Then, err = unmarshalExpressionJSON(aux.Then)
+	if err != nil {
+		return err
+	}
+
+	e.Else, err = unmarshalExpressionJSON(aux.Else)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // UnaryExpression
 
 type UnaryExpression struct {
@@ -986,8 +1224,7 @@ func (e *UnaryExpression) String() string {
 func (e *UnaryExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		prettier.Text(e.Operation.Symbol()),
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		parenthesizedExpressionDoc(e.Expression, precedenceUnaryPrefix),
 	}
 }
 
@@ -1012,6 +1249,31 @@ func (e *UnaryExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *UnaryExpression) UnmarshalJSON(data []byte) error {
+	type Alias UnaryExpression
+	aux := struct {
+		Expression json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // BinaryExpression
 
 type BinaryExpression struct {
@@ -1047,22 +1309,39 @@ func (e *BinaryExpression) String() string {
 }
 
 func (e *BinaryExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	leftDoc := e.Left.Doc()
+	operationPrecedence := e.Operation.precedence()
+
+	// Operands which bind as strongly as the operation
+	// only need to be parenthesized on the side the operation does not group to.
+	// Comparisons are not chained, so their operands are always parenthesized
+
+	leftPrecedence := operationPrecedence
+	rightPrecedence := operationPrecedence + 1
+	switch {
+	case e.Operation.isRightAssociative():
+		leftPrecedence, rightPrecedence = rightPrecedence, leftPrecedence
+	case operationPrecedence == precedenceComparison:
+		leftPrecedence = rightPrecedence
+	}
 
-	// TODO: potentially parenthesize
-	rightDoc := e.Right.Doc()
+	leftDoc := parenthesizedExpressionDoc(e.Left, leftPrecedence)
+
+	rightDoc := parenthesizedExpressionDoc(e.Right, rightPrecedence)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
 			prettier.Group{
 				Doc: leftDoc,
 			},
-			prettier.Line{},
-			prettier.Text(e.Operation.Symbol()),
-			prettier.Space,
-			prettier.Group{
-				Doc: rightDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					prettier.Text(e.Operation.Symbol()),
+					prettier.Space,
+					prettier.Group{
+						Doc: rightDoc,
+					},
+				},
 			},
 		},
 	}
@@ -1089,6 +1368,34 @@ func (e *BinaryExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *BinaryExpression) UnmarshalJSON(data []byte) error {
+	type Alias BinaryExpression
+	aux := struct {
+		Left  json.RawMessage
+		Right json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Left, err = unmarshalExpressionJSON(aux.Left)
+	if err != nil {
+		return err
+	}
+
+	e.Right, err = unmarshalExpressionJSON(aux.Right)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // FunctionExpression
 
 type FunctionExpression struct {
@@ -1124,88 +1431,48 @@ func (e *FunctionExpression) String() string {
 }
 
 var functionExpressionFunKeywordDoc prettier.Doc = prettier.Text("fun ")
-var functionExpressionParameterSeparatorDoc prettier.Doc = prettier.Concat{
-	prettier.Text(","),
-	prettier.Line{},
-}
 
 var typeSeparatorDoc prettier.Doc = prettier.Text(": ")
 var functionExpressionEmptyBlockDoc prettier.Doc = prettier.Text(" {}")
 
 func (e *FunctionExpression) Doc() prettier.Doc {
 
-	signatureDoc := e.parametersDoc()
-
-	if e.ReturnTypeAnnotation != nil &&
-		!IsEmptyType(e.ReturnTypeAnnotation.Type) {
-
-		signatureDoc = prettier.Concat{
-			signatureDoc,
-			typeSeparatorDoc,
-			e.ReturnTypeAnnotation.Doc(),
-		}
-	}
-
 	doc := prettier.Concat{
 		functionExpressionFunKeywordDoc,
-		prettier.Group{
-			Doc: signatureDoc,
-		},
+		functionSignatureDoc(e.ParameterList, e.ReturnTypeAnnotation),
 	}
 
 	if e.FunctionBlock.IsEmpty() {
 		return append(doc, functionExpressionEmptyBlockDoc)
 	} else {
-		// TODO: pre-conditions
-		// TODO: post-conditions
-
-		blockDoc := e.FunctionBlock.Block.Doc()
-
 		return append(
 			doc,
 			prettier.Space,
-			blockDoc,
+			e.FunctionBlock.Doc(),
 		)
 	}
 }
 
-func (e *FunctionExpression) parametersDoc() prettier.Doc {
-
-	if e.ParameterList == nil ||
-		len(e.ParameterList.Parameters) == 0 {
-
-		return prettier.Text("()")
-	}
-
-	parameterDocs := make([]prettier.Doc, 0, len(e.ParameterList.Parameters))
+// functionSignatureDoc returns the document for the parameter list
+// and the return type annotation, if any, of a function
+//
+func functionSignatureDoc(parameterList *ParameterList, returnTypeAnnotation *TypeAnnotation) prettier.Doc {
 
-	for _, parameter := range e.ParameterList.Parameters {
-		var parameterDoc prettier.Concat
+	signatureDoc := parameterList.Doc()
 
-		if parameter.Label != "" {
-			parameterDoc = append(parameterDoc,
-				prettier.Text(parameter.Label),
-				prettier.Space,
-			)
-		}
+	if returnTypeAnnotation != nil &&
+		!IsEmptyType(returnTypeAnnotation.Type) {
 
-		parameterDoc = append(
-			parameterDoc,
-			prettier.Text(parameter.Identifier.Identifier),
+		signatureDoc = prettier.Concat{
+			signatureDoc,
 			typeSeparatorDoc,
-			parameter.TypeAnnotation.Doc(),
-		)
-
-		parameterDocs = append(parameterDocs, parameterDoc)
+			returnTypeAnnotation.Doc(),
+		}
 	}
 
-	return prettier.WrapParentheses(
-		prettier.Join(
-			functionExpressionParameterSeparatorDoc,
-			parameterDocs...,
-		),
-		prettier.SoftLine{},
-	)
+	return prettier.Group{
+		Doc: signatureDoc,
+	}
 }
 
 func (e *FunctionExpression) StartPosition() Position {
@@ -1229,6 +1496,25 @@ func (e *FunctionExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *FunctionExpression) UnmarshalJSON(data []byte) error {
+	type Alias FunctionExpression
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // CastingExpression
 
 type CastingExpression struct {
@@ -1264,18 +1550,21 @@ func (e *CastingExpression) String() string {
 }
 
 func (e *CastingExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	doc := e.Expression.Doc()
+	doc := parenthesizedExpressionDoc(e.Expression, precedenceCasting)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
 			prettier.Group{
 				Doc: doc,
 			},
-			prettier.Line{},
-			prettier.Text(e.Operation.Symbol()),
-			prettier.Line{},
-			e.TypeAnnotation.Doc(),
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					prettier.Text(e.Operation.Symbol()),
+					prettier.Space,
+					e.TypeAnnotation.Doc(),
+				},
+			},
 		},
 	}
 }
@@ -1301,6 +1590,28 @@ func (e *CastingExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *CastingExpression) UnmarshalJSON(data []byte) error {
+	type Alias CastingExpression
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateExpression
 
 type CreateExpression struct {
@@ -1334,10 +1645,16 @@ func (e *CreateExpression) String() string {
 }
 
 func (e *CreateExpression) Doc() prettier.Doc {
+	invocationExpression := e.InvocationExpression
+
+	// The invoked expression is parsed as a nominal type,
+	// which may not span multiple lines
+
 	return prettier.Concat{
 		prettier.Text("create "),
-		// TODO: potentially parenthesize
-		e.InvocationExpression.Doc(),
+		invocationExpression.doc(
+			flattenDoc(invocationExpression.InvokedExpression.Doc()),
+		),
 	}
 }
 
@@ -1362,6 +1679,25 @@ func (e *CreateExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *CreateExpression) UnmarshalJSON(data []byte) error {
+	type Alias CreateExpression
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // DestroyExpression
 
 type DestroyExpression struct {
@@ -1425,6 +1761,136 @@ func (e *DestroyExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *DestroyExpression) UnmarshalJSON(data []byte) error {
+	type Alias DestroyExpression
+	aux := struct {
+		Expression json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
+// AttachExpression
+
+type AttachExpression struct {
+	Base       Expression
+	Attachment *InvocationExpression
+	StartPos   Position `json:"-"`
+}
+
+var _ Expression = &AttachExpression{}
+
+func (*AttachExpression) isExpression() {}
+
+func (*AttachExpression) isIfStatementTest() {}
+
+func (e *AttachExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *AttachExpression) Walk(walkChild func(Element)) {
+	walkChild(e.Attachment)
+	walkChild(e.Base)
+}
+
+func (e *AttachExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitAttachExpression(e)
+}
+
+func (e *AttachExpression) String() string {
+	return fmt.Sprintf(
+		"(attach %s to %s)",
+		e.Attachment,
+		e.Base,
+	)
+}
+
+const attachExpressionKeywordSpaceDoc = prettier.Text("attach ")
+const attachExpressionToKeywordDoc = prettier.Text("to ")
+
+func (e *AttachExpression) Doc() prettier.Doc {
+	attachment := e.Attachment
+
+	// The invoked expression is parsed as a nominal type,
+	// which may not span multiple lines
+
+	return prettier.Concat{
+		attachExpressionKeywordSpaceDoc,
+		attachment.doc(
+			flattenDoc(attachment.InvokedExpression.Doc()),
+		),
+		prettier.Indent{
+			Doc: prettier.Concat{
+				prettier.Line{},
+				attachExpressionToKeywordDoc,
+				e.Base.Doc(),
+			},
+		},
+	}
+}
+
+func (e *AttachExpression) StartPosition() Position {
+	return e.StartPos
+}
+
+func (e *AttachExpression) EndPosition() Position {
+	return e.Base.EndPosition()
+}
+
+func (e *AttachExpression) MarshalJSON() ([]byte, error) {
+	type Alias AttachExpression
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "AttachExpression",
+		Range: NewRangeFromPositioned(e),
+		Alias: (*Alias)(e),
+	})
+}
+
+func (e *AttachExpression) UnmarshalJSON(data []byte) error {
+	type Alias AttachExpression
+	aux := struct {
+		Base json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Base, err = unmarshalExpressionJSON(aux.Base)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // ReferenceExpression
 
 type ReferenceExpression struct {
@@ -1464,8 +1930,8 @@ var referenceExpressionRefOperatorDoc prettier.Doc = prettier.Text("&")
 var referenceExpressionAsOperatorDoc prettier.Doc = prettier.Text("as")
 
 func (e *ReferenceExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	doc := e.Expression.Doc()
+	// The referenced expression is parsed as the left-hand side of a casting expression
+	doc := parenthesizedExpressionDoc(e.Expression, precedenceCasting+1)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1473,10 +1939,14 @@ func (e *ReferenceExpression) Doc() prettier.Doc {
 			prettier.Group{
 				Doc: doc,
 			},
-			prettier.Line{},
-			referenceExpressionAsOperatorDoc,
-			prettier.Line{},
-			e.Type.Doc(),
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					referenceExpressionAsOperatorDoc,
+					prettier.Space,
+					flattenDoc(e.Type.Doc()),
+				},
+			},
 		},
 	}
 }
@@ -1502,6 +1972,37 @@ func (e *ReferenceExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ReferenceExpression) UnmarshalJSON(data []byte) error {
+	type Alias ReferenceExpression
+	aux := struct {
+		Expression json.RawMessage
+		Type       json.RawMessage `json:"TargetType"`
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	e.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // ForceExpression
 
 type ForceExpression struct {
@@ -1535,8 +2036,7 @@ const forceExpressionOperatorDoc = prettier.Text("!")
 
 func (e *ForceExpression) Doc() prettier.Doc {
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		postfixTargetDoc(e.Expression),
 		forceExpressionOperatorDoc,
 	}
 }
@@ -1562,6 +2062,31 @@ func (e *ForceExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ForceExpression) UnmarshalJSON(data []byte) error {
+	type Alias ForceExpression
+	aux := struct {
+		Expression json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	e.EndPos = aux.Range.EndPos
+
+	return nil
+}
+
 // PathExpression
 
 type PathExpression struct {
@@ -1616,3 +2141,22 @@ func (e *PathExpression) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(e),
 	})
 }
+
+func (e *PathExpression) UnmarshalJSON(data []byte) error {
+	type Alias PathExpression
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	e.StartPos = aux.Range.StartPos
+
+	return nil
+}
@@ -96,6 +96,10 @@ type DestroyExtractor interface {
 	ExtractDestroy(extractor *ExpressionExtractor, expression *DestroyExpression) ExpressionExtraction
 }
 
+type AttachExtractor interface {
+	ExtractAttach(extractor *ExpressionExtractor, expression *AttachExpression) ExpressionExtraction
+}
+
 type ReferenceExtractor interface {
 	ExtractReference(extractor *ExpressionExtractor, expression *ReferenceExpression) ExpressionExtraction
 }
@@ -128,6 +132,7 @@ type ExpressionExtractor struct {
 	CastingExtractor     CastingExtractor
 	CreateExtractor      CreateExtractor
 	DestroyExtractor     DestroyExtractor
+	AttachExtractor      AttachExtractor
 	ReferenceExtractor   ReferenceExtractor
 	ForceExtractor       ForceExtractor
 	PathExtractor        PathExtractor
@@ -719,6 +724,52 @@ func (extractor *ExpressionExtractor) ExtractDestroy(expression *DestroyExpressi
 	}
 }
 
+func (extractor *ExpressionExtractor) VisitAttachExpression(expression *AttachExpression) Repr {
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.AttachExtractor != nil {
+		return extractor.AttachExtractor.ExtractAttach(extractor, expression)
+	}
+	return extractor.ExtractAttach(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractAttach(expression *AttachExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite the attachment and base sub-expression
+
+	rewrittenExpressions, extractedExpressions :=
+		extractor.VisitExpressions([]Expression{
+			newExpression.Attachment,
+			newExpression.Base,
+		})
+
+	attachment, ok := rewrittenExpressions[0].(*InvocationExpression)
+	if !ok {
+		// Edge-case:
+		// The rewritten expression returned from the extractor may not be an InvocationExpression,
+		// but an expression of another type.
+		//
+		// Wrap the rewritten expression in an InvocationExpression.
+
+		attachment = &InvocationExpression{
+			InvokedExpression: rewrittenExpressions[0],
+			EndPos:            rewrittenExpressions[0].EndPosition(),
+		}
+	}
+
+	newExpression.Attachment = attachment
+	newExpression.Base = rewrittenExpressions[1]
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitReferenceExpression(expression *ReferenceExpression) Repr {
 	// delegate to child extractor, if any,
 	// or call default implementation
@@ -1027,11 +1027,15 @@ func TestBinaryExpression_Doc(t *testing.T) {
 				prettier.Group{
 					Doc: prettier.Text("42"),
 				},
-				prettier.Line{},
-				prettier.Text("+"),
-				prettier.Space,
-				prettier.Group{
-					Doc: prettier.Text("99"),
+				prettier.Indent{
+					Doc: prettier.Concat{
+						prettier.Line{},
+						prettier.Text("+"),
+						prettier.Space,
+						prettier.Group{
+							Doc: prettier.Text("99"),
+						},
+					},
 				},
 			},
 		},
@@ -1564,12 +1568,16 @@ func TestCastingExpression_Doc(t *testing.T) {
 				prettier.Group{
 					Doc: prettier.Text("42"),
 				},
-				prettier.Line{},
-				prettier.Text("as?"),
-				prettier.Line{},
-				prettier.Concat{
-					prettier.Text("@"),
-					prettier.Text("R"),
+				prettier.Indent{
+					Doc: prettier.Concat{
+						prettier.Line{},
+						prettier.Text("as?"),
+						prettier.Space,
+						prettier.Concat{
+							prettier.Text("@"),
+							prettier.Text("R"),
+						},
+					},
 				},
 			},
 		},
@@ -1792,13 +1800,17 @@ func TestReferenceExpression_Doc(t *testing.T) {
 				prettier.Group{
 					Doc: prettier.Text("42"),
 				},
-				prettier.Line{},
-				prettier.Text("as"),
-				prettier.Line{},
-				prettier.Concat{
-					prettier.Text("auth "),
-					prettier.Text("&"),
-					prettier.Text("Int"),
+				prettier.Indent{
+					Doc: prettier.Concat{
+						prettier.Line{},
+						prettier.Text("as"),
+						prettier.Space,
+						prettier.Concat{
+							prettier.Text("auth "),
+							prettier.Text("&"),
+							prettier.Text("Int"),
+						},
+					},
 				},
 			},
 		},
@@ -21,11 +21,14 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
 type FunctionDeclaration struct {
 	Access               Access
+	Entitlements         *EntitlementSet `json:",omitempty"`
 	Identifier           Identifier
 	ParameterList        *ParameterList
 	ReturnTypeAnnotation *TypeAnnotation
@@ -105,6 +108,55 @@ func (d *FunctionDeclaration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (d *FunctionDeclaration) UnmarshalJSON(data []byte) error {
+	type Alias FunctionDeclaration
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	d.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
+var functionDeclarationFunKeywordSpaceDoc prettier.Doc = prettier.Text("fun ")
+
+func (d *FunctionDeclaration) Doc() prettier.Doc {
+	return memberDeclarationDoc(
+		d.DocString,
+		d.Access,
+		d.Entitlements,
+		prettier.Concat{
+			functionDeclarationFunKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+			functionSignatureDoc(d.ParameterList, d.ReturnTypeAnnotation),
+			functionBodyDoc(d.FunctionBlock),
+		},
+	)
+}
+
+// functionBodyDoc returns the document for the body of a function declaration, if any,
+// preceded by a space
+//
+func functionBodyDoc(functionBlock *FunctionBlock) prettier.Doc {
+	if functionBlock == nil {
+		return prettier.Concat{}
+	}
+
+	return prettier.Concat{
+		prettier.Space,
+		functionBlock.Doc(),
+	}
+}
+
 // SpecialFunctionDeclaration
 
 type SpecialFunctionDeclaration struct {
@@ -151,6 +203,31 @@ func (d *SpecialFunctionDeclaration) DeclarationDocString() string {
 	return d.FunctionDeclaration.DeclarationDocString()
 }
 
+func (d *SpecialFunctionDeclaration) Doc() prettier.Doc {
+	functionDeclaration := d.FunctionDeclaration
+
+	doc := prettier.Concat{
+		prettier.Text(d.Kind.Keywords()),
+	}
+
+	// The execute block of a transaction has no parameter list
+	if d.Kind != common.DeclarationKindExecute {
+		doc = append(
+			doc,
+			functionSignatureDoc(functionDeclaration.ParameterList, nil),
+		)
+	}
+
+	return declarationDoc(
+		functionDeclaration.DocString,
+		functionDeclaration.Access,
+		append(
+			doc,
+			functionBodyDoc(functionDeclaration.FunctionBlock),
+		),
+	)
+}
+
 func (d *SpecialFunctionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias SpecialFunctionDeclaration
 	return json.Marshal(&struct {
@@ -51,3 +51,20 @@ func (i Identifier) MarshalJSON() ([]byte, error) {
 		Range:      NewRangeFromPositioned(i),
 	})
 }
+
+func (i *Identifier) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Identifier string
+		Range
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	i.Identifier = aux.Identifier
+	i.Pos = aux.StartPos
+
+	return nil
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -75,3 +77,74 @@ func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *ImportDeclaration) UnmarshalJSON(data []byte) error {
+	type Alias ImportDeclaration
+	aux := struct {
+		Location json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	d.Location, err = unmarshalLocationJSON(aux.Location)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+const importDeclarationImportKeywordSpaceDoc = prettier.Text("import ")
+const importDeclarationSpaceFromKeywordSpaceDoc = prettier.Text(" from ")
+
+func (d *ImportDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		importDeclarationImportKeywordSpaceDoc,
+	}
+
+	if len(d.Identifiers) > 0 {
+		identifierDocs := make([]prettier.Doc, len(d.Identifiers))
+		for i, identifier := range d.Identifiers {
+			identifierDocs[i] = prettier.Text(identifier.Identifier)
+		}
+
+		doc = append(
+			doc,
+			prettier.Join(
+				prettier.Text(", "),
+				identifierDocs...,
+			),
+			importDeclarationSpaceFromKeywordSpaceDoc,
+		)
+	}
+
+	return append(
+		doc,
+		importLocationDoc(d.Location),
+	)
+}
+
+// importLocationDoc returns the document for the location of an import declaration,
+// in the form it is written in source code
+//
+func importLocationDoc(location common.Location) prettier.Doc {
+	switch location := location.(type) {
+	case common.StringLocation:
+		return prettier.Text(QuoteString(string(location)))
+
+	case common.AddressLocation:
+		return prettier.Text(location.Address.ShortHexWithPrefix())
+
+	case common.IdentifierLocation:
+		return prettier.Text(string(location))
+
+	default:
+		return prettier.Text(location.String())
+	}
+}
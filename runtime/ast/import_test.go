@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -73,3 +74,54 @@ func TestImportDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestImportDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("string location", func(t *testing.T) {
+
+		t.Parallel()
+
+		decl := &ImportDeclaration{
+			Location: common.StringLocation("test"),
+		}
+
+		assert.Equal(t,
+			prettier.Concat{
+				prettier.Text("import "),
+				prettier.Text(`"test"`),
+			},
+			decl.Doc(),
+		)
+	})
+
+	t.Run("address location, identifiers", func(t *testing.T) {
+
+		t.Parallel()
+
+		decl := &ImportDeclaration{
+			Identifiers: []Identifier{
+				{Identifier: "foo"},
+				{Identifier: "bar"},
+			},
+			Location: common.AddressLocation{
+				Address: common.MustBytesToAddress([]byte{0x1}),
+			},
+		}
+
+		assert.Equal(t,
+			prettier.Concat{
+				prettier.Text("import "),
+				prettier.Concat{
+					prettier.Text("foo"),
+					prettier.Text(", "),
+					prettier.Text("bar"),
+				},
+				prettier.Text(" from "),
+				prettier.Text("0x1"),
+			},
+			decl.Doc(),
+		)
+	})
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -80,3 +82,20 @@ func (d *InterfaceDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+var interfaceKeywordSpaceDoc prettier.Doc = prettier.Text("interface ")
+
+func (d *InterfaceDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			prettier.Text(d.CompositeKind.Keyword()),
+			prettier.Space,
+			interfaceKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+			prettier.Space,
+			d.Members.Doc(),
+		},
+	)
+}
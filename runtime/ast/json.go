@@ -0,0 +1,325 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// JSONVersion is the version of the JSON format of programs.
+//
+// The format is documented in docs/ast-json-spec.md.
+// It must be incremented when the format changes in a way
+// which is incompatible with existing decoders.
+//
+const JSONVersion = 1
+
+// jsonElementConstructors maps the JSON type names of elements
+// to functions which construct an empty element of the type,
+// into which the JSON encoding is decoded
+//
+var jsonElementConstructors = map[string]func() interface{}{
+	// Declarations
+	"CompositeDeclaration":          func() interface{} { return &CompositeDeclaration{} },
+	"FieldDeclaration":              func() interface{} { return &FieldDeclaration{} },
+	"EnumCaseDeclaration":           func() interface{} { return &EnumCaseDeclaration{} },
+	"EntitlementDeclaration":        func() interface{} { return &EntitlementDeclaration{} },
+	"EntitlementMappingDeclaration": func() interface{} { return &EntitlementMappingDeclaration{} },
+	"FunctionDeclaration":           func() interface{} { return &FunctionDeclaration{} },
+	"SpecialFunctionDeclaration":    func() interface{} { return &SpecialFunctionDeclaration{} },
+	"ImportDeclaration":             func() interface{} { return &ImportDeclaration{} },
+	"InterfaceDeclaration":          func() interface{} { return &InterfaceDeclaration{} },
+	"PragmaDeclaration":             func() interface{} { return &PragmaDeclaration{} },
+	"TransactionDeclaration":        func() interface{} { return &TransactionDeclaration{} },
+	"VariableDeclaration":           func() interface{} { return &VariableDeclaration{} },
+
+	// Statements
+	"ReturnStatement":     func() interface{} { return &ReturnStatement{} },
+	"BreakStatement":      func() interface{} { return &BreakStatement{} },
+	"ContinueStatement":   func() interface{} { return &ContinueStatement{} },
+	"IfStatement":         func() interface{} { return &IfStatement{} },
+	"WhileStatement":      func() interface{} { return &WhileStatement{} },
+	"ForStatement":        func() interface{} { return &ForStatement{} },
+	"EmitStatement":       func() interface{} { return &EmitStatement{} },
+	"RemoveStatement":     func() interface{} { return &RemoveStatement{} },
+	"AssignmentStatement": func() interface{} { return &AssignmentStatement{} },
+	"SwapStatement":       func() interface{} { return &SwapStatement{} },
+	"ExpressionStatement": func() interface{} { return &ExpressionStatement{} },
+	"SwitchStatement":     func() interface{} { return &SwitchStatement{} },
+
+	// Expressions
+	"BoolExpression":        func() interface{} { return &BoolExpression{} },
+	"NilExpression":         func() interface{} { return &NilExpression{} },
+	"StringExpression":      func() interface{} { return &StringExpression{} },
+	"IntegerExpression":     func() interface{} { return &IntegerExpression{} },
+	"FixedPointExpression":  func() interface{} { return &FixedPointExpression{} },
+	"ArrayExpression":       func() interface{} { return &ArrayExpression{} },
+	"DictionaryExpression":  func() interface{} { return &DictionaryExpression{} },
+	"IdentifierExpression":  func() interface{} { return &IdentifierExpression{} },
+	"InvocationExpression":  func() interface{} { return &InvocationExpression{} },
+	"MemberExpression":      func() interface{} { return &MemberExpression{} },
+	"IndexExpression":       func() interface{} { return &IndexExpression{} },
+	"ConditionalExpression": func() interface{} { return &ConditionalExpression{} },
+	"UnaryExpression":       func() interface{} { return &UnaryExpression{} },
+	"BinaryExpression":      func() interface{} { return &BinaryExpression{} },
+	"FunctionExpression":    func() interface{} { return &FunctionExpression{} },
+	"CastingExpression":     func() interface{} { return &CastingExpression{} },
+	"CreateExpression":      func() interface{} { return &CreateExpression{} },
+	"DestroyExpression":     func() interface{} { return &DestroyExpression{} },
+	"AttachExpression":      func() interface{} { return &AttachExpression{} },
+	"ReferenceExpression":   func() interface{} { return &ReferenceExpression{} },
+	"ForceExpression":       func() interface{} { return &ForceExpression{} },
+	"PathExpression":        func() interface{} { return &PathExpression{} },
+
+	// Types
+	"NominalType":       func() interface{} { return &NominalType{} },
+	"OptionalType":      func() interface{} { return &OptionalType{} },
+	"VariableSizedType": func() interface{} { return &VariableSizedType{} },
+	"ConstantSizedType": func() interface{} { return &ConstantSizedType{} },
+	"DictionaryType":    func() interface{} { return &DictionaryType{} },
+	"FunctionType":      func() interface{} { return &FunctionType{} },
+	"ReferenceType":     func() interface{} { return &ReferenceType{} },
+	"RestrictedType":    func() interface{} { return &RestrictedType{} },
+	"InstantiationType": func() interface{} { return &InstantiationType{} },
+}
+
+func isJSONNull(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) == 0 || bytes.Equal(data, []byte("null"))
+}
+
+// unmarshalElementJSON decodes the JSON encoding of an element,
+// using the type name in its `Type` field to determine the type of the element.
+// It returns nil if the encoded value is null
+//
+func unmarshalElementJSON(data []byte) (interface{}, error) {
+	if isJSONNull(data) {
+		return nil, nil
+	}
+
+	var typed struct {
+		Type string
+	}
+	err := json.Unmarshal(data, &typed)
+	if err != nil {
+		return nil, err
+	}
+
+	constructor, ok := jsonElementConstructors[typed.Type]
+	if !ok {
+		return nil, fmt.Errorf("invalid element type: %q", typed.Type)
+	}
+
+	element := constructor()
+
+	err = json.Unmarshal(data, element)
+	if err != nil {
+		return nil, err
+	}
+
+	return element, nil
+}
+
+func unmarshalExpressionJSON(data []byte) (Expression, error) {
+	element, err := unmarshalElementJSON(data)
+	if err != nil || element == nil {
+		return nil, err
+	}
+
+	expression, ok := element.(Expression)
+	if !ok {
+		return nil, fmt.Errorf("invalid expression: %T", element)
+	}
+
+	return expression, nil
+}
+
+func unmarshalExpressionsJSON(data []json.RawMessage) ([]Expression, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	expressions := make([]Expression, len(data))
+	for i, expressionData := range data {
+		expression, err := unmarshalExpressionJSON(expressionData)
+		if err != nil {
+			return nil, err
+		}
+		expressions[i] = expression
+	}
+
+	return expressions, nil
+}
+
+func unmarshalStatementJSON(data []byte) (Statement, error) {
+	element, err := unmarshalElementJSON(data)
+	if err != nil || element == nil {
+		return nil, err
+	}
+
+	statement, ok := element.(Statement)
+	if !ok {
+		return nil, fmt.Errorf("invalid statement: %T", element)
+	}
+
+	return statement, nil
+}
+
+func unmarshalStatementsJSON(data []json.RawMessage) ([]Statement, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	statements := make([]Statement, len(data))
+	for i, statementData := range data {
+		statement, err := unmarshalStatementJSON(statementData)
+		if err != nil {
+			return nil, err
+		}
+		statements[i] = statement
+	}
+
+	return statements, nil
+}
+
+func unmarshalDeclarationsJSON(data []json.RawMessage) ([]Declaration, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	declarations := make([]Declaration, len(data))
+	for i, declarationData := range data {
+		element, err := unmarshalElementJSON(declarationData)
+		if err != nil {
+			return nil, err
+		}
+
+		declaration, ok := element.(Declaration)
+		if !ok {
+			return nil, fmt.Errorf("invalid declaration: %T", element)
+		}
+
+		declarations[i] = declaration
+	}
+
+	return declarations, nil
+}
+
+func unmarshalTypeJSON(data []byte) (Type, error) {
+	element, err := unmarshalElementJSON(data)
+	if err != nil || element == nil {
+		return nil, err
+	}
+
+	ty, ok := element.(Type)
+	if !ok {
+		return nil, fmt.Errorf("invalid type: %T", element)
+	}
+
+	return ty, nil
+}
+
+func unmarshalBigIntJSON(value string) (*big.Int, error) {
+	result, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %q", value)
+	}
+	return result, nil
+}
+
+func unmarshalIfStatementTestJSON(data []byte) (IfStatementTest, error) {
+	element, err := unmarshalElementJSON(data)
+	if err != nil || element == nil {
+		return nil, err
+	}
+
+	test, ok := element.(IfStatementTest)
+	if !ok {
+		return nil, fmt.Errorf("invalid if-statement test: %T", element)
+	}
+
+	return test, nil
+}
+
+// unmarshalLocationJSON decodes the JSON encoding of a location,
+// as encoded by the MarshalJSON functions of the locations in the common package
+//
+func unmarshalLocationJSON(data []byte) (common.Location, error) {
+	if isJSONNull(data) {
+		return nil, nil
+	}
+
+	var aux struct {
+		Type        string
+		Address     string
+		Name        string
+		Identifier  string
+		String      string
+		Script      string
+		Transaction string
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return nil, err
+	}
+
+	switch aux.Type {
+	case "AddressLocation":
+		address, err := common.HexToAddress(aux.Address)
+		if err != nil {
+			return nil, err
+		}
+		return common.AddressLocation{
+			Address: address,
+			Name:    aux.Name,
+		}, nil
+
+	case "IdentifierLocation":
+		return common.IdentifierLocation(aux.Identifier), nil
+
+	case "StringLocation":
+		return common.StringLocation(aux.String), nil
+
+	case "ScriptLocation":
+		script, err := hex.DecodeString(aux.Script)
+		if err != nil {
+			return nil, err
+		}
+		return common.ScriptLocation(script), nil
+
+	case "TransactionLocation":
+		transaction, err := hex.DecodeString(aux.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		return common.TransactionLocation(transaction), nil
+
+	case "REPLLocation":
+		return common.REPLLocation{}, nil
+	}
+
+	return nil, fmt.Errorf("invalid location type: %q", aux.Type)
+}
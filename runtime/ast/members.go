@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -65,6 +67,14 @@ func (m *Members) EnumCases() []*EnumCaseDeclaration {
 	return m.indices.EnumCases(m.declarations)
 }
 
+func (m *Members) Entitlements() []*EntitlementDeclaration {
+	return m.indices.Entitlements(m.declarations)
+}
+
+func (m *Members) EntitlementMappings() []*EntitlementMappingDeclaration {
+	return m.indices.EntitlementMappings(m.declarations)
+}
+
 func (m *Members) FieldsByIdentifier() map[string]*FieldDeclaration {
 	return m.indices.FieldsByIdentifier(m.declarations)
 }
@@ -120,3 +130,79 @@ func (m *Members) MarshalJSON() ([]byte, error) {
 		Alias:        (*Alias)(m),
 	})
 }
+
+func (m *Members) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Declarations []json.RawMessage
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	m.declarations, err = unmarshalDeclarationsJSON(aux.Declarations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Members) Doc() prettier.Doc {
+	if m == nil || len(m.declarations) == 0 {
+		return blockEmptyDoc
+	}
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: prettier.Concat{
+				prettier.HardLine{},
+				DeclarationsDoc(m.declarations),
+			},
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
+
+// DeclarationsDoc returns the document for the given declarations, each on a separate line.
+//
+// Declarations are separated by an empty line,
+// except for consecutive fields, enum cases, and imports, which are grouped together.
+//
+func DeclarationsDoc(declarations []Declaration) prettier.Doc {
+	var doc prettier.Concat
+
+	for i, declaration := range declarations {
+		if i > 0 {
+			doc = append(doc, prettier.HardLine{})
+			if !isGroupedDeclarationPair(declarations[i-1], declaration) {
+				doc = append(doc, prettier.HardLine{})
+			}
+		}
+
+		doc = append(doc, declaration.Doc())
+	}
+
+	return doc
+}
+
+func isGroupedDeclarationPair(previous, next Declaration) bool {
+	switch previous.(type) {
+	case *FieldDeclaration:
+		_, ok := next.(*FieldDeclaration)
+		return ok && next.DeclarationDocString() == ""
+
+	case *EnumCaseDeclaration:
+		_, ok := next.(*EnumCaseDeclaration)
+		return ok && next.DeclarationDocString() == ""
+
+	case *ImportDeclaration:
+		_, ok := next.(*ImportDeclaration)
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"sync"
+)
+
+// NodeID is the identifier of an element in a program.
+//
+// Node IDs are assigned in depth-first order, starting at 1,
+// so they only depend on the structure of the program:
+// The same program, e.g. parsed again in another process, or decoded from JSON,
+// has the same node IDs. This allows tools to reference elements across process boundaries.
+//
+// The zero value is not a valid node ID.
+//
+type NodeID uint32
+
+const NoNodeID NodeID = 0
+
+// programNodeIDs is a container for the node IDs of a program's elements
+//
+type programNodeIDs struct {
+	once sync.Once
+	// Use `nodeID` instead
+	_ids map[Element]NodeID
+	// Use `node` instead.
+	// Index 0 is unused, as it corresponds to NoNodeID
+	_nodes []Element
+}
+
+func (i *programNodeIDs) nodeID(program *Program, element Element) NodeID {
+	i.once.Do(i.initializer(program))
+	return i._ids[element]
+}
+
+func (i *programNodeIDs) node(program *Program, id NodeID) Element {
+	i.once.Do(i.initializer(program))
+	if id == NoNodeID || int(id) >= len(i._nodes) {
+		return nil
+	}
+	return i._nodes[id]
+}
+
+func (i *programNodeIDs) initializer(program *Program) func() {
+	return func() {
+		i.init(program)
+	}
+}
+
+func (i *programNodeIDs) init(program *Program) {
+
+	i._ids = map[Element]NodeID{}
+	i._nodes = []Element{nil}
+
+	program.Walk(func(element Element) {
+		Inspect(element, func(element Element) bool {
+			if element == nil {
+				return false
+			}
+
+			// An element might occur multiple times in the tree,
+			// only assign an ID to the first occurrence
+
+			if _, ok := i._ids[element]; ok {
+				return false
+			}
+
+			i._ids[element] = NodeID(len(i._nodes))
+			i._nodes = append(i._nodes, element)
+
+			return true
+		})
+	})
+}
@@ -0,0 +1,89 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramNodeIDs(t *testing.T) {
+
+	t.Parallel()
+
+	returnValue := &BinaryExpression{
+		Operation: OperationPlus,
+		Left: &IdentifierExpression{
+			Identifier: Identifier{Identifier: "a"},
+		},
+		Right: &IntegerExpression{
+			PositiveLiteral: "1",
+		},
+	}
+
+	returnStatement := &ReturnStatement{
+		Expression: returnValue,
+	}
+
+	block := &Block{
+		Statements: []Statement{
+			returnStatement,
+		},
+	}
+
+	functionBlock := &FunctionBlock{
+		Block: block,
+	}
+
+	function := &FunctionDeclaration{
+		Identifier:    Identifier{Identifier: "test"},
+		FunctionBlock: functionBlock,
+	}
+
+	variable := &VariableDeclaration{
+		Identifier: Identifier{Identifier: "x"},
+		Value:      &BoolExpression{Value: true},
+	}
+
+	program := NewProgram([]Declaration{function, variable})
+
+	elements := []Element{
+		function,
+		functionBlock,
+		block,
+		returnStatement,
+		returnValue,
+		returnValue.Left,
+		returnValue.Right,
+		variable,
+		variable.Value,
+	}
+
+	for i, element := range elements {
+		id := NodeID(i + 1)
+
+		assert.Equal(t, id, program.NodeID(element))
+		assert.Same(t, element, program.Node(id))
+	}
+
+	assert.Equal(t, NoNodeID, program.NodeID(&BoolExpression{}))
+	assert.Nil(t, program.Node(NoNodeID))
+	assert.Nil(t, program.Node(NodeID(len(elements)+1)))
+}
@@ -21,6 +21,7 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
 
@@ -118,3 +119,14 @@ func (s Operation) Symbol() string {
 func (s Operation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
 }
+
+func (s *Operation) UnmarshalJSON(data []byte) error {
+	index, err := common.UnmarshalEnumJSON(data, OperationCount(), func(index int) string {
+		return Operation(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*s = Operation(index)
+	return nil
+}
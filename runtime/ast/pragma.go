@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -73,3 +75,34 @@ func (d *PragmaDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *PragmaDeclaration) UnmarshalJSON(data []byte) error {
+	type Alias PragmaDeclaration
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	d.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+const pragmaDeclarationSymbolDoc = prettier.Text("#")
+
+func (d *PragmaDeclaration) Doc() prettier.Doc {
+	return prettier.Concat{
+		pragmaDeclarationSymbolDoc,
+		d.Expression.Doc(),
+	}
+}
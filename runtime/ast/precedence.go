@@ -0,0 +1,187 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"github.com/turbolent/prettier"
+)
+
+// precedence is the precedence of an expression,
+// i.e. how strongly it binds its operands.
+// It mirrors the binding powers of the parser.
+//
+type precedence uint
+
+const (
+	precedenceUnknown precedence = iota
+	precedenceTernary
+	precedenceLogicalOr
+	precedenceLogicalAnd
+	precedenceComparison
+	precedenceNilCoalescing
+	precedenceBitwiseOr
+	precedenceBitwiseXor
+	precedenceBitwiseAnd
+	precedenceBitwiseShift
+	precedenceAddition
+	precedenceMultiplication
+	precedenceCasting
+	precedenceUnaryPrefix
+	// postfix operations, i.e. force, member access, indexing, and invocation
+	precedenceAccess
+	precedenceLiteral
+)
+
+func (s Operation) precedence() precedence {
+	switch s {
+	case OperationOr:
+		return precedenceLogicalOr
+	case OperationAnd:
+		return precedenceLogicalAnd
+	case OperationEqual,
+		OperationNotEqual,
+		OperationLess,
+		OperationGreater,
+		OperationLessEqual,
+		OperationGreaterEqual:
+		return precedenceComparison
+	case OperationNilCoalesce:
+		return precedenceNilCoalescing
+	case OperationBitwiseOr:
+		return precedenceBitwiseOr
+	case OperationBitwiseXor:
+		return precedenceBitwiseXor
+	case OperationBitwiseAnd:
+		return precedenceBitwiseAnd
+	case OperationBitwiseLeftShift,
+		OperationBitwiseRightShift:
+		return precedenceBitwiseShift
+	case OperationPlus,
+		OperationMinus:
+		return precedenceAddition
+	case OperationMul,
+		OperationDiv,
+		OperationMod:
+		return precedenceMultiplication
+	case OperationCast,
+		OperationFailableCast,
+		OperationForceCast:
+		return precedenceCasting
+	case OperationNegate,
+		OperationMove:
+		return precedenceUnaryPrefix
+	}
+
+	return precedenceUnknown
+}
+
+// isRightAssociative returns true if the operation groups to the right,
+// e.g. `a ?? b ?? c` is `a ?? (b ?? c)`
+//
+func (s Operation) isRightAssociative() bool {
+	switch s {
+	case OperationOr,
+		OperationAnd,
+		OperationNilCoalesce:
+		return true
+	}
+	return false
+}
+
+func expressionPrecedence(expression Expression) precedence {
+	switch expression := expression.(type) {
+	case *BinaryExpression:
+		return expression.Operation.precedence()
+
+	case *UnaryExpression,
+		*CreateExpression:
+
+		return precedenceUnaryPrefix
+
+	case *CastingExpression:
+		return precedenceCasting
+
+	case *ForceExpression,
+		*MemberExpression,
+		*IndexExpression,
+		*InvocationExpression:
+
+		return precedenceAccess
+
+	case *IntegerExpression:
+		if expression.Value.Sign() < 0 {
+			return precedenceUnaryPrefix
+		}
+		return precedenceLiteral
+
+	case *FixedPointExpression:
+		if expression.Negative {
+			return precedenceUnaryPrefix
+		}
+		return precedenceLiteral
+
+	case *ConditionalExpression,
+		*DestroyExpression,
+		*AttachExpression,
+		*ReferenceExpression,
+		*FunctionExpression:
+
+		// NOTE: the operand of destroy and reference expressions,
+		// the base of attach expressions,
+		// and the body of function expressions extend as far as possible
+
+		return precedenceTernary
+	}
+
+	return precedenceLiteral
+}
+
+// parenthesizedExpressionDoc returns the document for the given subexpression,
+// wrapped in parentheses if it binds less strongly than the given minimum precedence.
+//
+func parenthesizedExpressionDoc(expression Expression, minimum precedence) prettier.Doc {
+	doc := expression.Doc()
+	if expressionPrecedence(expression) >= minimum {
+		return doc
+	}
+
+	return prettier.WrapParentheses(doc, prettier.SoftLine{})
+}
+
+// postfixTargetDoc returns the document for the target of a postfix operation,
+// i.e. a force, invocation, or index expression.
+//
+// The parser does not allow a line break after the first token of the target,
+// if it is followed by such a postfix operation (e.g. `[\n1\n][0]`),
+// so targets which would start with an opening bracket or parenthesis are kept on one line.
+//
+func postfixTargetDoc(expression Expression) prettier.Doc {
+	doc := parenthesizedExpressionDoc(expression, precedenceAccess)
+
+	switch expression.(type) {
+	case *ArrayExpression, *DictionaryExpression:
+		return flattenDoc(doc)
+	}
+
+	if expressionPrecedence(expression) < precedenceAccess {
+		return flattenDoc(doc)
+	}
+
+	return doc
+}
@@ -0,0 +1,63 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"github.com/turbolent/prettier"
+)
+
+// flattenDoc returns the given document with all line breaks except hard ones
+// replaced, like prettier.Doc.Flatten, so the document is laid out on one line.
+//
+// Unlike prettier.Doc.Flatten, it never produces nil documents,
+// so the result can be flattened again, e.g. when it is part of a group
+//
+func flattenDoc(doc prettier.Doc) prettier.Doc {
+	switch doc := doc.(type) {
+	case prettier.Concat:
+		result := make(prettier.Concat, 0, len(doc))
+		for _, child := range doc {
+			if child == nil {
+				continue
+			}
+			if _, ok := child.(prettier.SoftLine); ok {
+				continue
+			}
+			result = append(result, flattenDoc(child))
+		}
+		return result
+
+	case prettier.Group:
+		return flattenDoc(doc.Doc)
+
+	case prettier.Indent:
+		return prettier.Indent{
+			Doc: flattenDoc(doc.Doc),
+		}
+
+	case prettier.SoftLine:
+		return prettier.Concat{}
+
+	case nil:
+		return prettier.Concat{}
+
+	default:
+		return doc.Flatten()
+	}
+}
@@ -20,6 +20,9 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
+
+	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -28,6 +31,10 @@ type Program struct {
 	// all declarations, in the order they are defined
 	declarations []Declaration
 	indices      programIndices
+	nodeIDs      programNodeIDs
+	// synthesizedOrigins are the origins of the synthesized declarations,
+	// which were spliced into the program, see Splice
+	synthesizedOrigins map[Declaration]Range
 }
 
 func NewProgram(declarations []Declaration) *Program {
@@ -93,6 +100,64 @@ func (p *Program) VariableDeclarations() []*VariableDeclaration {
 	return p.indices.variableDeclarations(p.declarations)
 }
 
+func (p *Program) EntitlementDeclarations() []*EntitlementDeclaration {
+	return p.indices.entitlementDeclarations(p.declarations)
+}
+
+func (p *Program) EntitlementMappingDeclarations() []*EntitlementMappingDeclaration {
+	return p.indices.entitlementMappingDeclarations(p.declarations)
+}
+
+// NodeID returns the ID of the given element of the program,
+// or NoNodeID if the element is not part of the program.
+//
+func (p *Program) NodeID(element Element) NodeID {
+	return p.nodeIDs.nodeID(p, element)
+}
+
+// Node returns the element of the program with the given ID,
+// or nil if no element has the ID.
+//
+func (p *Program) Node(id NodeID) Element {
+	return p.nodeIDs.node(p, id)
+}
+
+// Splice returns a new program which has the declarations of this program,
+// and the given synthesized declarations inserted at the given index.
+//
+// The synthesized declarations are transplanted to the given origin (see Transplant),
+// and they are marked as synthesized (see SynthesizedOrigin).
+//
+func (p *Program) Splice(index int, origin Range, synthesized ...Declaration) *Program {
+	declarations := make([]Declaration, 0, len(p.declarations)+len(synthesized))
+	declarations = append(declarations, p.declarations[:index]...)
+	declarations = append(declarations, synthesized...)
+	declarations = append(declarations, p.declarations[index:]...)
+
+	synthesizedOrigins := make(map[Declaration]Range, len(p.synthesizedOrigins)+len(synthesized))
+	for declaration, declarationOrigin := range p.synthesizedOrigins { //nolint:maprangecheck
+		synthesizedOrigins[declaration] = declarationOrigin
+	}
+
+	for _, declaration := range synthesized {
+		Transplant(declaration, origin)
+		synthesizedOrigins[declaration] = origin
+	}
+
+	return &Program{
+		declarations:       declarations,
+		synthesizedOrigins: synthesizedOrigins,
+	}
+}
+
+// SynthesizedOrigin returns the origin of the given declaration and true,
+// if the declaration was synthesized and spliced into the program (see Splice).
+//
+func (p *Program) SynthesizedOrigin(declaration Declaration) (Range, bool) {
+	origin, ok := p.synthesizedOrigins[declaration]
+	return origin, ok
+}
+
 // SoleContractDeclaration returns the sole contract declaration, if any,
 // and if there are no other actionable declarations.
 //
@@ -163,11 +228,47 @@ func (p *Program) MarshalJSON() ([]byte, error) {
 	type Alias Program
 	return json.Marshal(&struct {
 		Type         string
+		Version      int
 		Declarations []Declaration
 		*Alias
 	}{
 		Type:         "Program",
+		Version:      JSONVersion,
 		Declarations: p.declarations,
 		Alias:        (*Alias)(p),
 	})
 }
+
+// UnmarshalJSON decodes a program from its JSON encoding, see MarshalJSON.
+// The encoding must have the current version, see JSONVersion.
+//
+func (p *Program) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Version      int
+		Declarations []json.RawMessage
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	if aux.Version != JSONVersion {
+		return fmt.Errorf(
+			"unsupported program JSON version: expected %d, got %d",
+			JSONVersion,
+			aux.Version,
+		)
+	}
+
+	p.declarations, err = unmarshalDeclarationsJSON(aux.Declarations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Program) Doc() prettier.Doc {
+	return DeclarationsDoc(p.declarations)
+}
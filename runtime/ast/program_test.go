@@ -39,9 +39,91 @@ func TestProgram_MarshalJSON(t *testing.T) {
 		`
         {
             "Type": "Program",
+            "Version": 1,
             "Declarations": []
         }
         `,
 		string(actual),
 	)
 }
+
+func TestProgram_UnmarshalJSON(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		var program Program
+		err := json.Unmarshal(
+			[]byte(`
+              {
+                  "Type": "Program",
+                  "Version": 1,
+                  "Declarations": [
+                      {
+                          "Type": "PragmaDeclaration",
+                          "Expression": {
+                              "Type": "IdentifierExpression",
+                              "Identifier": {
+                                  "Identifier": "foo",
+                                  "StartPos": {"Offset": 1, "Line": 1, "Column": 1},
+                                  "EndPos": {"Offset": 3, "Line": 1, "Column": 3}
+                              },
+                              "StartPos": {"Offset": 1, "Line": 1, "Column": 1},
+                              "EndPos": {"Offset": 3, "Line": 1, "Column": 3}
+                          },
+                          "StartPos": {"Offset": 0, "Line": 1, "Column": 0},
+                          "EndPos": {"Offset": 3, "Line": 1, "Column": 3}
+                      }
+                  ]
+              }
+            `),
+			&program,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]Declaration{
+				&PragmaDeclaration{
+					Expression: &IdentifierExpression{
+						Identifier: Identifier{
+							Identifier: "foo",
+							Pos:        Position{Offset: 1, Line: 1, Column: 1},
+						},
+					},
+					Range: Range{
+						StartPos: Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   Position{Offset: 3, Line: 1, Column: 3},
+					},
+				},
+			},
+			program.Declarations(),
+		)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+
+		t.Parallel()
+
+		var program Program
+		err := json.Unmarshal(
+			[]byte(`{"Type": "Program", "Declarations": []}`),
+			&program,
+		)
+		require.EqualError(t, err, "unsupported program JSON version: expected 1, got 0")
+	})
+
+	t.Run("unknown element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		var program Program
+		err := json.Unmarshal(
+			[]byte(`{"Type": "Program", "Version": 1, "Declarations": [{"Type": "Foo"}]}`),
+			&program,
+		)
+		require.Error(t, err)
+	})
+}
@@ -40,6 +40,10 @@ type programIndices struct {
 	_transactionDeclarations []*TransactionDeclaration
 	// Use `variableDeclarations()` instead
 	_variableDeclarations []*VariableDeclaration
+	// Use `entitlementDeclarations()` instead
+	_entitlementDeclarations []*EntitlementDeclaration
+	// Use `entitlementMappingDeclarations()` instead
+	_entitlementMappingDeclarations []*EntitlementMappingDeclaration
 }
 
 func (i *programIndices) pragmaDeclarations(declarations []Declaration) []*PragmaDeclaration {
@@ -77,6 +81,16 @@ func (i *programIndices) variableDeclarations(declarations []Declaration) []*Var
 	return i._variableDeclarations
 }
 
+func (i *programIndices) entitlementDeclarations(declarations []Declaration) []*EntitlementDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._entitlementDeclarations
+}
+
+func (i *programIndices) entitlementMappingDeclarations(declarations []Declaration) []*EntitlementMappingDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._entitlementMappingDeclarations
+}
+
 func (i *programIndices) initializer(declarations []Declaration) func() {
 	return func() {
 		i.init(declarations)
@@ -93,6 +107,8 @@ func (i *programIndices) init(declarations []Declaration) {
 	i._interfaceDeclarations = make([]*InterfaceDeclaration, 0)
 	i._functionDeclarations = make([]*FunctionDeclaration, 0)
 	i._transactionDeclarations = make([]*TransactionDeclaration, 0)
+	i._entitlementDeclarations = make([]*EntitlementDeclaration, 0)
+	i._entitlementMappingDeclarations = make([]*EntitlementMappingDeclaration, 0)
 
 	for _, declaration := range declarations {
 
@@ -117,6 +133,12 @@ func (i *programIndices) init(declarations []Declaration) {
 
 		case *VariableDeclaration:
 			i._variableDeclarations = append(i._variableDeclarations, declaration)
+
+		case *EntitlementDeclaration:
+			i._entitlementDeclarations = append(i._entitlementDeclarations, declaration)
+
+		case *EntitlementMappingDeclaration:
+			i._entitlementMappingDeclarations = append(i._entitlementMappingDeclarations, declaration)
 		}
 	}
 }
@@ -27,6 +27,7 @@ import (
 type Statement interface {
 	Element
 	isStatement()
+	Doc() prettier.Doc
 }
 
 // ReturnStatement
@@ -76,6 +77,28 @@ func (s *ReturnStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ReturnStatement) UnmarshalJSON(data []byte) error {
+	type Alias ReturnStatement
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // BreakStatement
 
 type BreakStatement struct {
@@ -151,6 +174,7 @@ func (s *ContinueStatement) MarshalJSON() ([]byte, error) {
 type IfStatementTest interface {
 	Element
 	isIfStatementTest()
+	Doc() prettier.Doc
 }
 
 // IfStatement
@@ -193,16 +217,9 @@ const ifStatementIfKeywordSpaceDoc = prettier.Text("if ")
 const ifStatementSpaceElseKeywordSpaceDoc = prettier.Text(" else ")
 
 func (s *IfStatement) Doc() prettier.Doc {
-	var testDoc prettier.Doc
-	// TODO: replace once IfStatementTest implements Doc
-	testWithDoc, ok := s.Test.(interface{ Doc() prettier.Doc })
-	if ok {
-		testDoc = testWithDoc.Doc()
-	}
-
 	doc := prettier.Concat{
 		ifStatementIfKeywordSpaceDoc,
-		testDoc,
+		s.Test.Doc(),
 		prettier.Space,
 		s.Then.Doc(),
 	}
@@ -245,6 +262,36 @@ func (s *IfStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *IfStatement) UnmarshalJSON(data []byte) error {
+	type Alias IfStatement
+	aux := struct {
+		Test json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Test, err = unmarshalIfStatementTestJSON(aux.Test)
+	if err != nil {
+		return err
+	}
+
+	s.StartPos = aux.Range.StartPos
+
+	variableDeclaration, ok := s.Test.(*VariableDeclaration)
+	if ok {
+		variableDeclaration.ParentIfStatement = s
+	}
+
+	return nil
+}
+
 // WhileStatement
 
 type WhileStatement struct {
@@ -300,6 +347,31 @@ func (s *WhileStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *WhileStatement) UnmarshalJSON(data []byte) error {
+	type Alias WhileStatement
+	aux := struct {
+		Test json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Test, err = unmarshalExpressionJSON(aux.Test)
+	if err != nil {
+		return err
+	}
+
+	s.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // ForStatement
 
 type ForStatement struct {
@@ -374,6 +446,31 @@ func (s *ForStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ForStatement) UnmarshalJSON(data []byte) error {
+	type Alias ForStatement
+	aux := struct {
+		Value json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Value, err = unmarshalExpressionJSON(aux.Value)
+	if err != nil {
+		return err
+	}
+
+	s.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // EmitStatement
 
 type EmitStatement struct {
@@ -424,6 +521,103 @@ func (s *EmitStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *EmitStatement) UnmarshalJSON(data []byte) error {
+	type Alias EmitStatement
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
+// RemoveStatement
+
+type RemoveStatement struct {
+	Attachment *NominalType
+	Value      Expression
+	StartPos   Position `json:"-"`
+}
+
+var _ Statement = &RemoveStatement{}
+
+func (*RemoveStatement) isStatement() {}
+
+func (s *RemoveStatement) StartPosition() Position {
+	return s.StartPos
+}
+
+func (s *RemoveStatement) EndPosition() Position {
+	return s.Value.EndPosition()
+}
+
+func (s *RemoveStatement) Accept(visitor Visitor) Repr {
+	return visitor.VisitRemoveStatement(s)
+}
+
+func (s *RemoveStatement) Walk(walkChild func(Element)) {
+	walkChild(s.Value)
+}
+
+const removeStatementKeywordSpaceDoc = prettier.Text("remove ")
+const removeStatementFromKeywordSpaceDoc = prettier.Text(" from ")
+
+func (s *RemoveStatement) Doc() prettier.Doc {
+	return prettier.Concat{
+		removeStatementKeywordSpaceDoc,
+		s.Attachment.Doc(),
+		removeStatementFromKeywordSpaceDoc,
+		s.Value.Doc(),
+	}
+}
+
+func (s *RemoveStatement) MarshalJSON() ([]byte, error) {
+	type Alias RemoveStatement
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "RemoveStatement",
+		Range: NewRangeFromPositioned(s),
+		Alias: (*Alias)(s),
+	})
+}
+
+func (s *RemoveStatement) UnmarshalJSON(data []byte) error {
+	type Alias RemoveStatement
+	aux := struct {
+		Value json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Value, err = unmarshalExpressionJSON(aux.Value)
+	if err != nil {
+		return err
+	}
+
+	s.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // AssignmentStatement
 
 type AssignmentStatement struct {
@@ -461,9 +655,7 @@ func (s *AssignmentStatement) Doc() prettier.Doc {
 			s.Transfer.Doc(),
 			prettier.Space,
 			prettier.Group{
-				Doc: prettier.Indent{
-					Doc: s.Value.Doc(),
-				},
+				Doc: s.Value.Doc(),
 			},
 		},
 	}
@@ -482,6 +674,34 @@ func (s *AssignmentStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *AssignmentStatement) UnmarshalJSON(data []byte) error {
+	type Alias AssignmentStatement
+	aux := struct {
+		Target json.RawMessage
+		Value  json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Target, err = unmarshalExpressionJSON(aux.Target)
+	if err != nil {
+		return err
+	}
+
+	s.Value, err = unmarshalExpressionJSON(aux.Value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SwapStatement
 
 type SwapStatement struct {
@@ -535,6 +755,34 @@ func (s *SwapStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *SwapStatement) UnmarshalJSON(data []byte) error {
+	type Alias SwapStatement
+	aux := struct {
+		Left  json.RawMessage
+		Right json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Left, err = unmarshalExpressionJSON(aux.Left)
+	if err != nil {
+		return err
+	}
+
+	s.Right, err = unmarshalExpressionJSON(aux.Right)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ExpressionStatement
 
 type ExpressionStatement struct {
@@ -578,6 +826,28 @@ func (s *ExpressionStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ExpressionStatement) UnmarshalJSON(data []byte) error {
+	type Alias ExpressionStatement
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SwitchStatement
 
 type SwitchStatement struct {
@@ -649,6 +919,28 @@ func (s *SwitchStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *SwitchStatement) UnmarshalJSON(data []byte) error {
+	type Alias SwitchStatement
+	aux := struct {
+		Expression json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SwitchCase
 
 type SwitchCase struct {
@@ -668,6 +960,34 @@ func (s *SwitchCase) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *SwitchCase) UnmarshalJSON(data []byte) error {
+	type Alias SwitchCase
+	aux := struct {
+		Expression json.RawMessage
+		Statements []json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	s.Expression, err = unmarshalExpressionJSON(aux.Expression)
+	if err != nil {
+		return err
+	}
+
+	s.Statements, err = unmarshalStatementsJSON(aux.Statements)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 const switchCaseKeywordSpaceDoc = prettier.Text("case ")
 const switchCaseColonSymbolDoc = prettier.Text(":")
 const switchCaseDefaultKeywordSpaceDoc = prettier.Text("default:")
@@ -751,9 +751,7 @@ func TestAssignmentStatement_Doc(t *testing.T) {
 				prettier.Text("="),
 				prettier.Text(" "),
 				prettier.Group{
-					Doc: prettier.Indent{
-						Doc: prettier.Text("false"),
-					},
+					Doc: prettier.Text("false"),
 				},
 			},
 		},
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -86,3 +88,87 @@ func (d *TransactionDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+const transactionDeclarationKeywordDoc = prettier.Text("transaction")
+
+func (d *TransactionDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		transactionDeclarationKeywordDoc,
+	}
+
+	if d.ParameterList != nil {
+		doc = append(
+			doc,
+			functionSignatureDoc(d.ParameterList, nil),
+		)
+	}
+
+	// The sections of the transaction are separated by an empty line.
+	// The fields are grouped into one section
+
+	var sectionDocs []prettier.Doc
+
+	if len(d.Fields) > 0 {
+		fieldDocs := make([]prettier.Doc, len(d.Fields))
+		for i, field := range d.Fields {
+			fieldDocs[i] = field.Doc()
+		}
+		sectionDocs = append(
+			sectionDocs,
+			prettier.Join(prettier.HardLine{}, fieldDocs...),
+		)
+	}
+
+	if d.Prepare != nil {
+		sectionDocs = append(sectionDocs, d.Prepare.Doc())
+	}
+
+	if d.PreConditions != nil {
+		sectionDocs = append(
+			sectionDocs,
+			d.PreConditions.Doc(preConditionsKeywordDoc),
+		)
+	}
+
+	if d.Execute != nil {
+		sectionDocs = append(sectionDocs, d.Execute.Doc())
+	}
+
+	if d.PostConditions != nil {
+		sectionDocs = append(
+			sectionDocs,
+			d.PostConditions.Doc(postConditionsKeywordDoc),
+		)
+	}
+
+	doc = append(doc, prettier.Space)
+
+	if len(sectionDocs) == 0 {
+		doc = append(doc, blockEmptyDoc)
+	} else {
+		doc = append(
+			doc,
+			blockStartDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.HardLine{},
+					prettier.Join(
+						prettier.Concat{
+							prettier.HardLine{},
+							prettier.HardLine{},
+						},
+						sectionDocs...,
+					),
+				},
+			},
+			prettier.HardLine{},
+			blockEndDoc,
+		)
+	}
+
+	return declarationDoc(
+		d.DocString,
+		AccessNotSpecified,
+		doc,
+	)
+}
@@ -54,6 +54,25 @@ func (f Transfer) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (f *Transfer) UnmarshalJSON(data []byte) error {
+	type Alias Transfer
+	aux := struct {
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(f),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	f.Pos = aux.Range.StartPos
+
+	return nil
+}
+
 var copyTransferDoc prettier.Doc = prettier.Text("=")
 var moveTransferDoc prettier.Doc = prettier.Text("<-")
 var forceMoveTransferDoc prettier.Doc = prettier.Text("<-!")
@@ -21,6 +21,7 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
 
@@ -64,3 +65,14 @@ func (k TransferOperation) IsMove() bool {
 func (k TransferOperation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *TransferOperation) UnmarshalJSON(data []byte) error {
+	index, err := common.UnmarshalEnumJSON(data, TransferOperationCount(), func(index int) string {
+		return TransferOperation(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*k = TransferOperation(index)
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"reflect"
+)
+
+// Transplant prepares the given synthesized element, e.g. built programmatically
+// or parsed from a code template, to be spliced into a program (see Program.Splice):
+// The positions of the element and all of its children are set to the given origin,
+// e.g. the range of the code which caused the synthesis,
+// so errors reported for the synthesized code point to the origin.
+//
+// The element is modified in-place,
+// so it must not contain elements of other programs.
+//
+func Transplant(element Element, origin Range) {
+	t := transplanter{
+		origin:  origin,
+		visited: map[uintptr]struct{}{},
+	}
+	t.transplant(reflect.ValueOf(element))
+}
+
+var positionType = reflect.TypeOf(Position{})
+var rangeType = reflect.TypeOf(Range{})
+var membersType = reflect.TypeOf(&Members{})
+
+type transplanter struct {
+	origin Range
+	// visited are the pointers which were already transplanted.
+	// Elements may refer to their parents, e.g. the variable declaration of an if-statement
+	visited map[uintptr]struct{}
+}
+
+func (t transplanter) transplant(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+
+		pointer := value.Pointer()
+		if _, ok := t.visited[pointer]; ok {
+			return
+		}
+		t.visited[pointer] = struct{}{}
+
+		// The declarations of members are unexported
+		if value.Type() == membersType {
+			for _, declaration := range value.Interface().(*Members).Declarations() {
+				t.transplant(reflect.ValueOf(declaration))
+			}
+			return
+		}
+
+		t.transplant(value.Elem())
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		t.transplant(value.Elem())
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			t.transplant(value.Index(i))
+		}
+
+	case reflect.Struct:
+		if !value.CanSet() {
+			return
+		}
+
+		switch value.Type() {
+		case rangeType:
+			value.Set(reflect.ValueOf(t.origin))
+			return
+
+		case positionType:
+			value.Set(reflect.ValueOf(t.origin.StartPos))
+			return
+		}
+
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+
+			if field.Type() == positionType &&
+				value.Type().Field(i).Name == "EndPos" {
+
+				field.Set(reflect.ValueOf(t.origin.EndPos))
+				continue
+			}
+
+			t.transplant(field)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestTransplant(t *testing.T) {
+
+	t.Parallel()
+
+	origin := Range{
+		StartPos: Position{Offset: 10, Line: 2, Column: 4},
+		EndPos:   Position{Offset: 20, Line: 2, Column: 14},
+	}
+
+	test := &VariableDeclaration{
+		IsConstant: true,
+		Identifier: Identifier{
+			Identifier: "x",
+			Pos:        Position{Offset: 1, Line: 1, Column: 1},
+		},
+		Transfer: &Transfer{
+			Operation: TransferOperationCopy,
+			Pos:       Position{Offset: 2, Line: 1, Column: 2},
+		},
+		Value: &NilExpression{
+			Pos: Position{Offset: 3, Line: 1, Column: 3},
+		},
+		StartPos: Position{Offset: 1, Line: 1, Column: 1},
+	}
+
+	ifStatement := &IfStatement{
+		Test: test,
+		Then: &Block{
+			Range: Range{
+				StartPos: Position{Offset: 4, Line: 1, Column: 4},
+				EndPos:   Position{Offset: 5, Line: 1, Column: 5},
+			},
+		},
+		StartPos: Position{Offset: 0, Line: 1, Column: 0},
+	}
+
+	// The variable declaration refers to its parent if-statement
+
+	test.ParentIfStatement = ifStatement
+
+	composite := &CompositeDeclaration{
+		CompositeKind: common.CompositeKindStructure,
+		Identifier: Identifier{
+			Identifier: "S",
+			Pos:        Position{Offset: 7, Line: 1, Column: 7},
+		},
+		Members: NewMembers([]Declaration{
+			&FunctionDeclaration{
+				Identifier: Identifier{
+					Identifier: "test",
+					Pos:        Position{Offset: 8, Line: 1, Column: 8},
+				},
+				FunctionBlock: &FunctionBlock{
+					Block: &Block{
+						Statements: []Statement{ifStatement},
+						Range: Range{
+							StartPos: Position{Offset: 9, Line: 1, Column: 9},
+							EndPos:   Position{Offset: 9, Line: 1, Column: 9},
+						},
+					},
+				},
+				StartPos: Position{Offset: 8, Line: 1, Column: 8},
+			},
+		}),
+		Range: Range{
+			StartPos: Position{Offset: 6, Line: 1, Column: 6},
+			EndPos:   Position{Offset: 10, Line: 1, Column: 10},
+		},
+	}
+
+	Transplant(composite, origin)
+
+	assert.Equal(t, origin, NewRangeFromPositioned(composite))
+	assert.Equal(t, origin.StartPos, composite.Identifier.Pos)
+
+	function := composite.Members.Functions()[0]
+	assert.Equal(t, origin.StartPos, function.StartPos)
+	assert.Equal(t, origin, function.FunctionBlock.Block.Range)
+
+	assert.Equal(t, origin.StartPos, ifStatement.StartPos)
+	assert.Equal(t, origin, ifStatement.Then.Range)
+
+	assert.Equal(t, origin.StartPos, test.StartPos)
+	assert.Equal(t, origin.StartPos, test.Identifier.Pos)
+	assert.Equal(t, origin.StartPos, test.Transfer.Pos)
+	assert.Equal(t, origin.StartPos, test.Value.StartPosition())
+}
+
+func TestProgram_Splice(t *testing.T) {
+
+	t.Parallel()
+
+	first := &VariableDeclaration{
+		Identifier: Identifier{
+			Identifier: "a",
+			Pos:        Position{Offset: 4, Line: 1, Column: 4},
+		},
+		Value: &BoolExpression{
+			Value: true,
+			Range: Range{
+				StartPos: Position{Offset: 8, Line: 1, Column: 8},
+				EndPos:   Position{Offset: 11, Line: 1, Column: 11},
+			},
+		},
+		StartPos: Position{Offset: 0, Line: 1, Column: 0},
+	}
+
+	second := &VariableDeclaration{
+		Identifier: Identifier{
+			Identifier: "b",
+			Pos:        Position{Offset: 17, Line: 2, Column: 4},
+		},
+		Value: &BoolExpression{
+			Range: Range{
+				StartPos: Position{Offset: 21, Line: 2, Column: 8},
+				EndPos:   Position{Offset: 25, Line: 2, Column: 12},
+			},
+		},
+		StartPos: Position{Offset: 13, Line: 2, Column: 0},
+	}
+
+	program := NewProgram([]Declaration{first, second})
+
+	synthesized := &VariableDeclaration{
+		Identifier: Identifier{Identifier: "synthesized"},
+		Value:      &NilExpression{},
+	}
+
+	origin := NewRangeFromPositioned(first)
+
+	splicedProgram := program.Splice(1, origin, synthesized)
+
+	// The program is not modified
+
+	assert.Equal(t, []Declaration{first, second}, program.Declarations())
+
+	_, ok := program.SynthesizedOrigin(synthesized)
+	assert.False(t, ok)
+
+	// The spliced program contains the synthesized declaration,
+	// which is transplanted to the origin
+
+	assert.Equal(t,
+		[]Declaration{first, synthesized, second},
+		splicedProgram.Declarations(),
+	)
+
+	assert.Equal(t, origin.StartPos, synthesized.StartPos)
+	assert.Equal(t, origin.StartPos, synthesized.Identifier.Pos)
+	assert.Equal(t, origin.StartPos, synthesized.Value.StartPosition())
+
+	synthesizedOrigin, ok := splicedProgram.SynthesizedOrigin(synthesized)
+	require.True(t, ok)
+	assert.Equal(t, origin, synthesizedOrigin)
+
+	_, ok = splicedProgram.SynthesizedOrigin(first)
+	assert.False(t, ok)
+}
@@ -51,14 +51,21 @@ func (t *TypeAnnotation) EndPosition() Position {
 
 const typeAnnotationResourceSymbolDoc = prettier.Text("@")
 
+// Doc returns the document for the type annotation.
+//
+// Type annotations are kept on one line, as the parser does not support
+// line breaks in all types, e.g. restricted types
+//
 func (t *TypeAnnotation) Doc() prettier.Doc {
+	typeDoc := flattenDoc(t.Type.Doc())
+
 	if !t.IsResource {
-		return t.Type.Doc()
+		return typeDoc
 	}
 
 	return prettier.Concat{
 		typeAnnotationResourceSymbolDoc,
-		t.Type.Doc(),
+		typeDoc,
 	}
 }
 
@@ -73,6 +80,31 @@ func (t *TypeAnnotation) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *TypeAnnotation) UnmarshalJSON(data []byte) error {
+	type Alias TypeAnnotation
+	aux := struct {
+		Type json.RawMessage `json:"AnnotatedType"`
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	t.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 // Type
 
 type Type interface {
@@ -192,6 +224,31 @@ func (t *OptionalType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *OptionalType) UnmarshalJSON(data []byte) error {
+	type Alias OptionalType
+	aux := struct {
+		Type json.RawMessage `json:"ElementType"`
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	t.EndPos = aux.Range.EndPos
+
+	return nil
+}
+
 func (t *OptionalType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckOptionalTypeEquality(t, other)
 }
@@ -239,6 +296,28 @@ func (t *VariableSizedType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *VariableSizedType) UnmarshalJSON(data []byte) error {
+	type Alias VariableSizedType
+	aux := struct {
+		Type json.RawMessage `json:"ElementType"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (t *VariableSizedType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckVariableSizedTypeEquality(t, other)
 }
@@ -288,6 +367,28 @@ func (t *ConstantSizedType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *ConstantSizedType) UnmarshalJSON(data []byte) error {
+	type Alias ConstantSizedType
+	aux := struct {
+		Type json.RawMessage `json:"ElementType"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (t *ConstantSizedType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckConstantSizedTypeEquality(t, other)
 }
@@ -339,6 +440,34 @@ func (t *DictionaryType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *DictionaryType) UnmarshalJSON(data []byte) error {
+	type Alias DictionaryType
+	aux := struct {
+		KeyType   json.RawMessage
+		ValueType json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.KeyType, err = unmarshalTypeJSON(aux.KeyType)
+	if err != nil {
+		return err
+	}
+
+	t.ValueType, err = unmarshalTypeJSON(aux.ValueType)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (t *DictionaryType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckDictionaryTypeEquality(t, other)
 }
@@ -428,8 +557,11 @@ func (t *FunctionType) CheckEqual(other Type, checker TypeEqualityChecker) error
 
 type ReferenceType struct {
 	Authorized bool
-	Type       Type     `json:"ReferencedType"`
-	StartPos   Position `json:"-"`
+	// Authorization is the entitlement set of an entitled reference type, e.g. `auth(E) &T`.
+	// It is nil for unauthorized and fully authorized reference types, e.g. `auth &T`
+	Authorization *EntitlementSet `json:",omitempty"`
+	Type          Type            `json:"ReferencedType"`
+	StartPos      Position        `json:"-"`
 }
 
 var _ Type = &ReferenceType{}
@@ -438,7 +570,11 @@ func (*ReferenceType) isType() {}
 
 func (t *ReferenceType) String() string {
 	var builder strings.Builder
-	if t.Authorized {
+	if t.Authorization != nil {
+		builder.WriteString("auth(")
+		builder.WriteString(t.Authorization.String())
+		builder.WriteString(") ")
+	} else if t.Authorized {
 		builder.WriteString("auth ")
 	}
 	builder.WriteRune('&')
@@ -455,11 +591,21 @@ func (t *ReferenceType) EndPosition() Position {
 }
 
 const referenceTypeAuthKeywordSpaceDoc = prettier.Text("auth ")
+const referenceTypeAuthKeywordDoc = prettier.Text("auth")
 const referenceTypeSymbolDoc = prettier.Text("&")
 
 func (t *ReferenceType) Doc() prettier.Doc {
 	var doc prettier.Concat
-	if t.Authorized {
+	if t.Authorization != nil {
+		doc = append(
+			doc,
+			referenceTypeAuthKeywordDoc,
+			prettier.Text("("),
+			t.Authorization.Doc(),
+			prettier.Text(")"),
+			prettier.Space,
+		)
+	} else if t.Authorized {
 		doc = append(doc, referenceTypeAuthKeywordSpaceDoc)
 	}
 
@@ -483,6 +629,31 @@ func (t *ReferenceType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *ReferenceType) UnmarshalJSON(data []byte) error {
+	type Alias ReferenceType
+	aux := struct {
+		Type json.RawMessage `json:"ReferencedType"`
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	t.StartPos = aux.Range.StartPos
+
+	return nil
+}
+
 func (t *ReferenceType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckReferenceTypeEquality(t, other)
 }
@@ -569,6 +740,28 @@ func (t *RestrictedType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *RestrictedType) UnmarshalJSON(data []byte) error {
+	type Alias RestrictedType
+	aux := struct {
+		Type json.RawMessage `json:"RestrictedType"`
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (t *RestrictedType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckRestrictedTypeEquality(t, other)
 }
@@ -659,6 +852,31 @@ func (t *InstantiationType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *InstantiationType) UnmarshalJSON(data []byte) error {
+	type Alias InstantiationType
+	aux := struct {
+		Type json.RawMessage `json:"InstantiatedType"`
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(t),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	t.Type, err = unmarshalTypeJSON(aux.Type)
+	if err != nil {
+		return err
+	}
+
+	t.EndPos = aux.Range.EndPos
+
+	return nil
+}
+
 func (t *InstantiationType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckInstantiationTypeEquality(t, other)
 }
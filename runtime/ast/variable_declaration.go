@@ -101,31 +101,53 @@ func (d *VariableDeclaration) Doc() prettier.Doc {
 		keywordDoc = letKeywordDoc
 	}
 
-	// TODO: second transfer and value (if any)
+	identifierTypeDoc := prettier.Concat{
+		prettier.Text(d.Identifier.Identifier),
+	}
+
+	if d.TypeAnnotation != nil {
+		identifierTypeDoc = append(
+			identifierTypeDoc,
+			typeSeparatorDoc,
+			d.TypeAnnotation.Doc(),
+		)
+	}
 
-	// TODO: potentially parenthesize
-	valueDoc := d.Value.Doc()
+	valueDoc := prettier.Concat{
+		d.Value.Doc(),
+	}
 
-	return prettier.Group{
-		Doc: prettier.Concat{
-			keywordDoc,
+	if d.SecondTransfer != nil && d.SecondValue != nil {
+		valueDoc = append(
+			valueDoc,
+			prettier.Line{},
+			d.SecondTransfer.Doc(),
 			prettier.Space,
-			prettier.Group{
-				Doc: prettier.Concat{
-					prettier.Text(d.Identifier.Identifier),
-					prettier.Space,
-					// TODO: type annotation, if any
-					d.Transfer.Doc(),
-					prettier.Space,
-					prettier.Group{
-						Doc: prettier.Indent{
+			d.SecondValue.Doc(),
+		)
+	}
+
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Group{
+			Doc: prettier.Concat{
+				keywordDoc,
+				prettier.Space,
+				prettier.Group{
+					Doc: prettier.Concat{
+						identifierTypeDoc,
+						prettier.Space,
+						d.Transfer.Doc(),
+						prettier.Space,
+						prettier.Group{
 							Doc: valueDoc,
 						},
 					},
 				},
 			},
 		},
-	}
+	)
 }
 
 func (d *VariableDeclaration) MarshalJSON() ([]byte, error) {
@@ -140,3 +162,39 @@ func (d *VariableDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *VariableDeclaration) UnmarshalJSON(data []byte) error {
+	type Alias VariableDeclaration
+	aux := struct {
+		Value       json.RawMessage
+		SecondValue json.RawMessage
+		Range
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	d.Value, err = unmarshalExpressionJSON(aux.Value)
+	if err != nil {
+		return err
+	}
+
+	d.SecondValue, err = unmarshalExpressionJSON(aux.SecondValue)
+	if err != nil {
+		return err
+	}
+
+	d.StartPos = aux.Range.StartPos
+
+	castingExpression, ok := d.Value.(*CastingExpression)
+	if ok {
+		castingExpression.ParentVariableDeclaration = d
+	}
+
+	return nil
+}
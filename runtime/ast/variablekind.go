@@ -21,6 +21,7 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
 
@@ -72,3 +73,14 @@ func (k VariableKind) Keyword() string {
 func (k VariableKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *VariableKind) UnmarshalJSON(data []byte) error {
+	index, err := common.UnmarshalEnumJSON(data, VariableKindCount(), func(index int) string {
+		return VariableKind(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*k = VariableKind(index)
+	return nil
+}
@@ -54,6 +54,7 @@ type StatementVisitor interface {
 	VisitWhileStatement(*WhileStatement) Repr
 	VisitForStatement(*ForStatement) Repr
 	VisitEmitStatement(*EmitStatement) Repr
+	VisitRemoveStatement(*RemoveStatement) Repr
 	VisitVariableDeclaration(*VariableDeclaration) Repr
 	VisitAssignmentStatement(*AssignmentStatement) Repr
 	VisitSwapStatement(*SwapStatement) Repr
@@ -79,6 +80,7 @@ type ExpressionVisitor interface {
 	VisitCastingExpression(*CastingExpression) Repr
 	VisitCreateExpression(*CreateExpression) Repr
 	VisitDestroyExpression(*DestroyExpression) Repr
+	VisitAttachExpression(*AttachExpression) Repr
 	VisitReferenceExpression(*ReferenceExpression) Repr
 	VisitForceExpression(*ForceExpression) Repr
 	VisitPathExpression(*PathExpression) Repr
@@ -95,6 +97,8 @@ type Visitor interface {
 	VisitInterfaceDeclaration(*InterfaceDeclaration) Repr
 	VisitFieldDeclaration(*FieldDeclaration) Repr
 	VisitEnumCaseDeclaration(*EnumCaseDeclaration) Repr
+	VisitEntitlementDeclaration(*EntitlementDeclaration) Repr
+	VisitEntitlementMappingDeclaration(*EntitlementMappingDeclaration) Repr
 	VisitPragmaDeclaration(*PragmaDeclaration) Repr
 	VisitImportDeclaration(*ImportDeclaration) Repr
 	VisitTransactionDeclaration(*TransactionDeclaration) Repr
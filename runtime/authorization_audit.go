@@ -0,0 +1,440 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=AuthorizationOperationKind -trimprefix=AuthorizationOperation
+
+// AuthorizationOperationKind is the kind of a privileged operation
+// which a transaction performs with one of its signing accounts.
+//
+type AuthorizationOperationKind uint
+
+const (
+	AuthorizationOperationUnknown AuthorizationOperationKind = iota
+	// AuthorizationOperationSave is a call of `AuthAccount.save`
+	AuthorizationOperationSave
+	// AuthorizationOperationLoad is a call of `AuthAccount.load`
+	AuthorizationOperationLoad
+	// AuthorizationOperationCopy is a call of `AuthAccount.copy`
+	AuthorizationOperationCopy
+	// AuthorizationOperationBorrow is a call of `AuthAccount.borrow`
+	AuthorizationOperationBorrow
+	// AuthorizationOperationType is a call of `AuthAccount.type`
+	AuthorizationOperationType
+	// AuthorizationOperationLink is a call of `AuthAccount.link`
+	AuthorizationOperationLink
+	// AuthorizationOperationUnlink is a call of `AuthAccount.unlink`
+	AuthorizationOperationUnlink
+	// AuthorizationOperationLinkAccount is a call of `AuthAccount.linkAccount`
+	AuthorizationOperationLinkAccount
+	// AuthorizationOperationGetCapability is a call of `AuthAccount.getCapability`,
+	// which may also get capabilities for private paths
+	AuthorizationOperationGetCapability
+	// AuthorizationOperationAddKey is a call of `AuthAccount.keys.add` or `AuthAccount.addPublicKey`
+	AuthorizationOperationAddKey
+	// AuthorizationOperationRevokeKey is a call of `AuthAccount.keys.revoke` or `AuthAccount.removePublicKey`
+	AuthorizationOperationRevokeKey
+	// AuthorizationOperationAddContract is a call of `AuthAccount.contracts.add` or `AuthAccount.contracts.addFrozen`
+	AuthorizationOperationAddContract
+	// AuthorizationOperationUpdateContract is a call of `AuthAccount.contracts.update__experimental`
+	AuthorizationOperationUpdateContract
+	// AuthorizationOperationRemoveContract is a call of `AuthAccount.contracts.remove`
+	AuthorizationOperationRemoveContract
+	// AuthorizationOperationPass is the passing of a signing account as an argument to a function,
+	// which may perform any operation with it
+	AuthorizationOperationPass
+)
+
+var authAccountOperationKinds = map[string]AuthorizationOperationKind{
+	sema.AuthAccountSaveField:            AuthorizationOperationSave,
+	sema.AuthAccountLoadField:            AuthorizationOperationLoad,
+	sema.AuthAccountCopyField:            AuthorizationOperationCopy,
+	sema.AuthAccountBorrowField:          AuthorizationOperationBorrow,
+	sema.AuthAccountTypeField:            AuthorizationOperationType,
+	sema.AuthAccountLinkField:            AuthorizationOperationLink,
+	sema.AuthAccountUnlinkField:          AuthorizationOperationUnlink,
+	sema.AuthAccountLinkAccountField:     AuthorizationOperationLinkAccount,
+	sema.AuthAccountGetCapabilityField:   AuthorizationOperationGetCapability,
+	sema.AuthAccountAddPublicKeyField:    AuthorizationOperationAddKey,
+	sema.AuthAccountRemovePublicKeyField: AuthorizationOperationRevokeKey,
+}
+
+var authAccountKeysOperationKinds = map[string]AuthorizationOperationKind{
+	sema.AccountKeysAddFunctionName:    AuthorizationOperationAddKey,
+	sema.AccountKeysRevokeFunctionName: AuthorizationOperationRevokeKey,
+}
+
+var authAccountContractsOperationKinds = map[string]AuthorizationOperationKind{
+	sema.AuthAccountContractsTypeAddFunctionName:                AuthorizationOperationAddContract,
+	sema.AuthAccountContractsTypeAddFrozenFunctionName:          AuthorizationOperationAddContract,
+	sema.AuthAccountContractsTypeUpdateExperimentalFunctionName: AuthorizationOperationUpdateContract,
+	sema.AuthAccountContractsTypeRemoveFunctionName:             AuthorizationOperationRemoveContract,
+}
+
+// AuthorizationOperation is a privileged operation which a transaction performs
+// with one of its signing accounts.
+//
+// Signer is the name of the `prepare` parameter of the signing account,
+// or empty if the account could not be attributed to a signer statically.
+//
+// Path is the storage path the operation accesses, e.g. `/storage/vault`,
+// and Target is the target path of a link. Both are empty if the operation has no path,
+// or if the path is not a literal.
+//
+type AuthorizationOperation struct {
+	Kind   AuthorizationOperationKind
+	Signer string
+	Path   string
+	Target string
+	ast.Range
+}
+
+func (o AuthorizationOperation) String() string {
+	var builder strings.Builder
+
+	signer := o.Signer
+	if signer == "" {
+		signer = "?"
+	}
+
+	builder.WriteString(signer)
+	builder.WriteString(": ")
+	builder.WriteString(o.Kind.String())
+
+	if o.Path != "" {
+		builder.WriteByte(' ')
+		builder.WriteString(o.Path)
+	}
+
+	if o.Target != "" {
+		builder.WriteString(" -> ")
+		builder.WriteString(o.Target)
+	}
+
+	return builder.String()
+}
+
+// AuthorizationAudit is the result of the authorization audit of a transaction.
+//
+// Signers are the names of the `prepare` parameters of the transaction, in declaration order,
+// and Operations are the privileged operations performed with them, in source order.
+//
+type AuthorizationAudit struct {
+	Signers    []string
+	Operations []AuthorizationOperation
+}
+
+// OperationKinds returns the distinct kinds of operations performed with the given signer,
+// in the order they first occur.
+//
+func (a *AuthorizationAudit) OperationKinds(signer string) []AuthorizationOperationKind {
+	var kinds []AuthorizationOperationKind
+	seen := map[AuthorizationOperationKind]struct{}{}
+
+	for _, operation := range a.Operations {
+		if operation.Signer != signer {
+			continue
+		}
+		if _, ok := seen[operation.Kind]; ok {
+			continue
+		}
+		seen[operation.Kind] = struct{}{}
+		kinds = append(kinds, operation.Kind)
+	}
+
+	return kinds
+}
+
+// String returns the operations of the audit, one per line.
+//
+func (a *AuthorizationAudit) String() string {
+	var builder strings.Builder
+	for _, operation := range a.Operations {
+		builder.WriteString(operation.String())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// AuditTransactionAuthorization statically determines the privileged operations
+// which the transaction declared in the given checked program performs with its signing accounts.
+//
+// Signing accounts are tracked through the `prepare` parameters,
+// through constants and variables declared with them,
+// and through transaction fields assigned with them.
+// Indexing an array of signing accounts is attributed to the array parameter.
+//
+// Operations on accounts which cannot be attributed to a signer,
+// e.g. accounts returned from functions, are still reported, with an empty signer.
+// Passing a signing account to a function is reported as AuthorizationOperationPass,
+// as the function may perform any operation with it.
+//
+// It returns nil if the program does not declare a transaction.
+//
+func AuditTransactionAuthorization(program *interpreter.Program) *AuthorizationAudit {
+	transactions := program.Program.TransactionDeclarations()
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	transaction := transactions[0]
+
+	auditor := &authorizationAuditor{
+		elaboration: program.Elaboration,
+		aliases:     map[string]string{},
+		fields:      map[string]string{},
+		audit:       &AuthorizationAudit{},
+	}
+
+	if transaction.Prepare != nil {
+		parameterList := transaction.Prepare.FunctionDeclaration.ParameterList
+		if parameterList != nil {
+			for _, parameter := range parameterList.Parameters {
+				name := parameter.Identifier.Identifier
+				auditor.audit.Signers = append(auditor.audit.Signers, name)
+				auditor.aliases[name] = name
+			}
+		}
+	}
+
+	ast.Inspect(transaction, auditor.inspect)
+
+	return auditor.audit
+}
+
+type authorizationAuditor struct {
+	elaboration *sema.Elaboration
+	// aliases maps names of constants and variables to the signers they refer to
+	aliases map[string]string
+	// fields maps names of transaction fields to the signers they refer to
+	fields map[string]string
+	audit  *AuthorizationAudit
+}
+
+func (a *authorizationAuditor) inspect(element ast.Element) bool {
+	switch element := element.(type) {
+	case *ast.VariableDeclaration:
+		name := element.Identifier.Identifier
+		if signer := a.signer(element.Value); signer != "" {
+			a.aliases[name] = signer
+		} else {
+			// The declaration may shadow a signer
+			delete(a.aliases, name)
+		}
+
+	case *ast.AssignmentStatement:
+		signer := a.signer(element.Value)
+		if signer == "" {
+			break
+		}
+		switch target := element.Target.(type) {
+		case *ast.IdentifierExpression:
+			a.aliases[target.Identifier.Identifier] = signer
+		case *ast.MemberExpression:
+			if isSelfExpression(target.Expression) {
+				a.fields[target.Identifier.Identifier] = signer
+			}
+		}
+
+	case *ast.InvocationExpression:
+		a.inspectInvocation(element)
+	}
+
+	return true
+}
+
+func (a *authorizationAuditor) inspectInvocation(invocation *ast.InvocationExpression) {
+
+	if memberExpression, ok := invocation.InvokedExpression.(*ast.MemberExpression); ok {
+		a.inspectMemberInvocation(invocation, memberExpression)
+	}
+
+	// Passing a signing account to a function allows the function
+	// to perform any operation with it
+
+	for _, argument := range invocation.Arguments {
+		signer := a.signer(argument.Expression)
+		if signer == "" {
+			continue
+		}
+
+		a.report(AuthorizationOperation{
+			Kind:   AuthorizationOperationPass,
+			Signer: signer,
+			Range:  ast.NewRangeFromPositioned(argument.Expression),
+		})
+	}
+}
+
+func (a *authorizationAuditor) inspectMemberInvocation(
+	invocation *ast.InvocationExpression,
+	memberExpression *ast.MemberExpression,
+) {
+	memberInfo, ok := a.elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok {
+		return
+	}
+
+	name := memberExpression.Identifier.Identifier
+
+	var kind AuthorizationOperationKind
+	var account ast.Expression
+
+	switch unwrapAccessedType(memberInfo.AccessedType) {
+	case sema.AuthAccountType:
+		kind = authAccountOperationKinds[name]
+		account = memberExpression.Expression
+
+	case sema.AuthAccountKeysType:
+		kind = authAccountKeysOperationKinds[name]
+		account = accountOfNestedMember(memberExpression.Expression)
+
+	case sema.AuthAccountContractsType:
+		kind = authAccountContractsOperationKinds[name]
+		account = accountOfNestedMember(memberExpression.Expression)
+	}
+
+	if kind == AuthorizationOperationUnknown {
+		return
+	}
+
+	operation := AuthorizationOperation{
+		Kind:  kind,
+		Range: ast.NewRangeFromPositioned(invocation),
+	}
+
+	if account != nil {
+		operation.Signer = a.signer(account)
+	}
+
+	switch kind {
+	case AuthorizationOperationSave:
+		operation.Path = pathArgument(invocation.Arguments, 1)
+
+	case AuthorizationOperationLoad,
+		AuthorizationOperationCopy,
+		AuthorizationOperationBorrow,
+		AuthorizationOperationType,
+		AuthorizationOperationUnlink,
+		AuthorizationOperationGetCapability:
+
+		operation.Path = pathArgument(invocation.Arguments, 0)
+
+	case AuthorizationOperationLink:
+		operation.Path = pathArgument(invocation.Arguments, 0)
+		operation.Target = pathArgument(invocation.Arguments, 1)
+
+	case AuthorizationOperationLinkAccount:
+		operation.Path = pathArgument(invocation.Arguments, 0)
+	}
+
+	a.report(operation)
+}
+
+func (a *authorizationAuditor) report(operation AuthorizationOperation) {
+	a.audit.Operations = append(a.audit.Operations, operation)
+}
+
+// signer returns the name of the signer the given expression refers to,
+// or empty if the expression does not statically refer to a signing account.
+//
+func (a *authorizationAuditor) signer(expression ast.Expression) string {
+	switch expression := expression.(type) {
+	case *ast.IdentifierExpression:
+		return a.aliases[expression.Identifier.Identifier]
+
+	case *ast.MemberExpression:
+		if isSelfExpression(expression.Expression) {
+			return a.fields[expression.Identifier.Identifier]
+		}
+
+	case *ast.IndexExpression:
+		return a.signer(expression.TargetExpression)
+
+	case *ast.ReferenceExpression:
+		return a.signer(expression.Expression)
+
+	case *ast.CastingExpression:
+		return a.signer(expression.Expression)
+
+	case *ast.ForceExpression:
+		return a.signer(expression.Expression)
+	}
+
+	return ""
+}
+
+// accountOfNestedMember returns the account expression of an access
+// of the `keys` or `contracts` field of an account, e.g. `signer` for `signer.keys`.
+//
+func accountOfNestedMember(expression ast.Expression) ast.Expression {
+	memberExpression, ok := expression.(*ast.MemberExpression)
+	if !ok {
+		return nil
+	}
+
+	switch memberExpression.Identifier.Identifier {
+	case sema.AuthAccountKeysField, sema.AuthAccountContractsField:
+		return memberExpression.Expression
+	}
+
+	return nil
+}
+
+func unwrapAccessedType(ty sema.Type) sema.Type {
+	for {
+		switch unwrapped := ty.(type) {
+		case *sema.OptionalType:
+			ty = unwrapped.Type
+		case *sema.ReferenceType:
+			ty = unwrapped.Type
+		default:
+			return ty
+		}
+	}
+}
+
+func isSelfExpression(expression ast.Expression) bool {
+	identifierExpression, ok := expression.(*ast.IdentifierExpression)
+	return ok && identifierExpression.Identifier.Identifier == sema.SelfIdentifier
+}
+
+// pathArgument returns the literal path of the argument at the given index,
+// or empty if there is no such argument, or it is not a path literal.
+//
+func pathArgument(arguments ast.Arguments, index int) string {
+	if index >= len(arguments) {
+		return ""
+	}
+
+	pathExpression, ok := arguments[index].Expression.(*ast.PathExpression)
+	if !ok {
+		return ""
+	}
+
+	return pathExpression.String()
+}
@@ -0,0 +1,194 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestAuditTransactionAuthorization(t *testing.T) {
+
+	t.Parallel()
+
+	audit := func(t *testing.T, code string) *AuthorizationAudit {
+		runtime := newTestInterpreterRuntime()
+
+		program, err := runtime.ParseAndCheckProgram(
+			[]byte(code),
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		return AuditTransactionAuthorization(program)
+	}
+
+	t.Run("no transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		result := audit(t, `
+          pub fun test() {}
+        `)
+
+		assert.Nil(t, result)
+	})
+
+	t.Run("storage and links", func(t *testing.T) {
+
+		t.Parallel()
+
+		result := audit(t, `
+          pub resource R {}
+
+          transaction {
+              prepare(sender: AuthAccount, receiver: AuthAccount) {
+                  let r <- sender.load<@R>(from: /storage/r)!
+                  receiver.save(<-r, to: /storage/r)
+                  receiver.link<&R>(/public/r, target: /storage/r)
+                  sender.unlink(/public/r)
+                  log(sender.address)
+                  log(receiver.borrow<&R>(from: /storage/r))
+              }
+          }
+        `)
+
+		require.NotNil(t, result)
+		assert.Equal(t, []string{"sender", "receiver"}, result.Signers)
+		assert.Equal(t,
+			`sender: Load /storage/r
+receiver: Save /storage/r
+receiver: Link /public/r -> /storage/r
+sender: Unlink /public/r
+receiver: Borrow /storage/r
+`,
+			result.String(),
+		)
+
+		assert.Equal(t,
+			[]AuthorizationOperationKind{
+				AuthorizationOperationLoad,
+				AuthorizationOperationUnlink,
+			},
+			result.OperationKinds("sender"),
+		)
+	})
+
+	t.Run("keys and contracts", func(t *testing.T) {
+
+		t.Parallel()
+
+		result := audit(t, `
+          transaction(code: [UInt8], publicKey: PublicKey) {
+              prepare(signer: AuthAccount) {
+                  signer.keys.add(
+                      publicKey: publicKey,
+                      hashAlgorithm: HashAlgorithm.SHA3_256,
+                      weight: 1000.0
+                  )
+                  signer.keys.revoke(keyIndex: 0)
+                  signer.keys.get(keyIndex: 1)
+                  signer.contracts.add(name: "A", code: code)
+                  signer.contracts.update__experimental(name: "B", code: code)
+                  signer.contracts.remove(name: "C")
+                  signer.contracts.get(name: "D")
+              }
+          }
+        `)
+
+		require.NotNil(t, result)
+		assert.Equal(t,
+			`signer: AddKey
+signer: RevokeKey
+signer: AddContract
+signer: UpdateContract
+signer: RemoveContract
+`,
+			result.String(),
+		)
+	})
+
+	t.Run("aliases, fields, and arrays", func(t *testing.T) {
+
+		t.Parallel()
+
+		result := audit(t, `
+          pub fun use(_ account: AuthAccount) {}
+
+          transaction {
+              let account: AuthAccount
+
+              prepare(first: AuthAccount, others: [AuthAccount]) {
+                  self.account = first
+                  let other = others[0]
+                  other.copy<Int>(from: /storage/x)
+                  use(first)
+              }
+
+              execute {
+                  self.account.type(at: /storage/y)
+              }
+          }
+        `)
+
+		require.NotNil(t, result)
+		assert.Equal(t,
+			`others: Copy /storage/x
+first: Pass
+first: Type /storage/y
+`,
+			result.String(),
+		)
+	})
+
+	t.Run("shadowed signer", func(t *testing.T) {
+
+		t.Parallel()
+
+		result := audit(t, `
+          pub fun account(): AuthAccount {
+              return panic("")
+          }
+
+          transaction {
+              prepare(signer: AuthAccount) {
+                  let path = /storage/x
+                  signer.load<Int>(from: path)
+                  let signer = account()
+                  signer.load<Int>(from: /storage/y)
+              }
+          }
+        `)
+
+		require.NotNil(t, result)
+		assert.Equal(t,
+			`signer: Load
+?: Load /storage/y
+`,
+			result.String(),
+		)
+	})
+}
@@ -1,3 +1,6 @@
+//go:build !wasm
+// +build !wasm
+
 /*
  * Cadence - The resource-oriented smart contract programming language
  *
@@ -21,6 +24,7 @@ package execute
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -35,6 +39,12 @@ const commandShortContinue = "c"
 const commandLongContinue = "continue"
 const commandShortNext = "n"
 const commandLongNext = "next"
+const commandShortOver = "o"
+const commandLongOver = "over"
+const commandShortOut = "u"
+const commandLongOut = "out"
+const commandShortBreak = "b"
+const commandLongBreak = "break"
 const commandLongExit = "exit"
 const commandShortShow = "s"
 const commandLongShow = "show"
@@ -44,6 +54,9 @@ const commandLongWhere = "where"
 var debuggerCommandSuggestions = []prompt.Suggest{
 	{Text: commandLongContinue, Description: "Continue"},
 	{Text: commandLongNext, Description: "Next / step"},
+	{Text: commandLongOver, Description: "Step over function invocations"},
+	{Text: commandLongOut, Description: "Step out of the current function"},
+	{Text: commandLongBreak, Description: "Add or remove a breakpoint at the given line"},
 	{Text: commandLongWhere, Description: "Location info"},
 	{Text: commandLongShow, Description: "Show variable(s)"},
 	{Text: commandLongExit, Description: "Exit"},
@@ -70,6 +83,36 @@ func (d *InteractiveDebugger) Next() {
 	d.stop = d.debugger.Next()
 }
 
+func (d *InteractiveDebugger) Over() {
+	d.stop = d.debugger.StepOver()
+}
+
+func (d *InteractiveDebugger) Out() {
+	d.stop = d.debugger.StepOut()
+}
+
+// Break toggles the breakpoint at the given line of the current program
+//
+func (d *InteractiveDebugger) Break(arguments []string) {
+	if len(arguments) != 1 {
+		fmt.Println(colorizeError("error: expected line"))
+		return
+	}
+
+	line, err := strconv.Atoi(arguments[0])
+	if err != nil {
+		fmt.Println(colorizeError(fmt.Sprintf("error: invalid line '%s'", arguments[0])))
+		return
+	}
+
+	location := d.stop.Interpreter.Location
+	if d.debugger.HasBreakpoint(location, line) {
+		d.debugger.RemoveBreakpoint(location, line)
+	} else {
+		d.debugger.AddBreakpoint(location, line)
+	}
+}
+
 // Show shows the values for the variables with the given names.
 // If no names are given, lists all non-base variables
 //
@@ -123,6 +166,12 @@ func (d *InteractiveDebugger) Run() {
 			d.Continue()
 		case commandShortNext, commandLongNext:
 			d.Next()
+		case commandShortOver, commandLongOver:
+			d.Over()
+		case commandShortOut, commandLongOut:
+			d.Out()
+		case commandShortBreak, commandLongBreak:
+			d.Break(arguments)
 		case commandShortShow, commandLongShow:
 			d.Show(arguments)
 		case commandShortWhere, commandLongWhere:
@@ -182,4 +231,14 @@ func (d *InteractiveDebugger) Where() {
 		d.stop.Interpreter.Location,
 		d.stop.Statement.StartPosition().Line,
 	)
+
+	callStack := d.debugger.CallStack()
+	for i := len(callStack) - 1; i >= 0; i-- {
+		frame := callStack[i]
+		fmt.Printf(
+			"  called from %s @ %d\n",
+			frame.Interpreter.Location,
+			frame.Invocation.StartPosition().Line,
+		)
+	}
 }
@@ -1,3 +1,6 @@
+//go:build !wasm
+// +build !wasm
+
 /*
  * Cadence - The resource-oriented smart contract programming language
  *
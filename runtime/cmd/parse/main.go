@@ -1,5 +1,5 @@
-//go:build !wasm
-// +build !wasm
+//go:build !js || !wasm
+// +build !js !wasm
 
 /*
  * Cadence - The resource-oriented smart contract programming language
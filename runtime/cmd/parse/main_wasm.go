@@ -1,4 +1,5 @@
-// +build wasm
+//go:build js && wasm
+// +build js,wasm
 
 /*
  * Copyright 2019-2020 Dapper Labs, Inc.
@@ -0,0 +1,141 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command reduce shrinks a Cadence program to a minimal program
+// which still reproduces a given behaviour, e.g. a checker crash.
+//
+// Usage: reduce [-predicate checker-crash|parser-crash|error|command] [-match substring] [-command cmd] [-o output] program.cdc
+//
+// The predicate "error" holds if the parser or checker reports an error with a message
+// that contains the substring given by -match.
+//
+// The predicate "command" runs the given command with the path of a file containing
+// the candidate program as the last argument. It holds if the command exits successfully.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/tests/reduce"
+)
+
+var predicateFlag = flag.String("predicate", "checker-crash", "the behaviour to preserve: checker-crash, parser-crash, error, or command")
+var matchFlag = flag.String("match", "", "the error message substring, for the predicate 'error'")
+var commandFlag = flag.String("command", "", "the command to run, for the predicate 'command'")
+var outputFlag = flag.String("o", "", "the output file. if not provided, the reduced program is printed")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		cmd.ExitWithError("expected exactly one program path")
+	}
+
+	path := args[0]
+
+	code, err := ioutil.ReadFile(path)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	predicate, err := newPredicate(*predicateFlag, *matchFlag, *commandFlag)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	program := string(code)
+
+	if !predicate(program) {
+		cmd.ExitWithError(fmt.Sprintf("predicate '%s' does not hold for %s", *predicateFlag, path))
+	}
+
+	reduced := reduce.Reduce(program, predicate)
+
+	if *outputFlag == "" {
+		fmt.Println(reduced)
+		return
+	}
+
+	err = ioutil.WriteFile(*outputFlag, []byte(reduced), 0644)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+}
+
+func newPredicate(name string, match string, command string) (reduce.Predicate, error) {
+	switch name {
+	case "checker-crash":
+		return reduce.CheckerCrashes, nil
+
+	case "parser-crash":
+		return reduce.ParserCrashes, nil
+
+	case "error":
+		if match == "" {
+			return nil, fmt.Errorf("missing error message substring: use -match")
+		}
+		return reduce.ErrorContains(match), nil
+
+	case "command":
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("missing command: use -command")
+		}
+		return commandPredicate(fields), nil
+
+	default:
+		return nil, fmt.Errorf("unknown predicate: %s", name)
+	}
+}
+
+// commandPredicate returns a predicate which writes the program to a temporary file,
+// and runs the given command with the path of the file as the last argument.
+// The predicate holds if the command exits successfully.
+//
+func commandPredicate(command []string) reduce.Predicate {
+	return func(program string) bool {
+		file, err := ioutil.TempFile("", "reduce-*.cdc")
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			_ = os.Remove(file.Name())
+		}()
+
+		_, err = file.WriteString(program)
+		if err != nil {
+			panic(err)
+		}
+
+		err = file.Close()
+		if err != nil {
+			panic(err)
+		}
+
+		args := append(command[1:len(command):len(command)], file.Name())
+
+		return exec.Command(command[0], args...).Run() == nil
+	}
+}
@@ -106,6 +106,9 @@ func (a Address) ShortHexWithPrefix() string {
 	return fmt.Sprintf("0x%s", strings.TrimLeft(hexString, "0"))
 }
 
+// HexWithPrefix returns the canonical string representation of the address,
+// the zero-padded hex string with the prefix 0x, e.g. 0x0000000000000001.
+//
 func (a Address) HexWithPrefix() string {
 	return fmt.Sprintf("0x%x", [AddressLength]byte(a))
 }
@@ -122,3 +125,15 @@ func HexToAddress(h string) (Address, error) {
 	}
 	return BytesToAddress(b)
 }
+
+// NormalizeAddressHex returns the canonical string representation of the given address hex string.
+// The prefix 0x is optional and leading zeros may be omitted, e.g. 0x1 and 01 are both normalized
+// to 0x0000000000000001.
+//
+func NormalizeAddressHex(h string) (string, error) {
+	address, err := HexToAddress(h)
+	if err != nil {
+		return "", err
+	}
+	return address.HexWithPrefix(), nil
+}
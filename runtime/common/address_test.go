@@ -254,3 +254,20 @@ func TestAddress_HexToAddress(t *testing.T) {
 		assert.Equal(t, expected, address)
 	}
 }
+
+func TestNormalizeAddressHex(t *testing.T) {
+
+	t.Parallel()
+
+	for _, h := range []string{"0x1", "1", "0x01", "0x0000000000000001", "0000000000000001"} {
+		normalized, err := NormalizeAddressHex(h)
+		require.NoError(t, err)
+		assert.Equal(t, "0x0000000000000001", normalized, h)
+	}
+
+	_, err := NormalizeAddressHex("0xg")
+	require.Error(t, err)
+
+	_, err = NormalizeAddressHex("0x010000000000000001")
+	require.Error(t, err)
+}
@@ -14,11 +14,12 @@ func _() {
 	_ = x[CompositeKindContract-3]
 	_ = x[CompositeKindEvent-4]
 	_ = x[CompositeKindEnum-5]
+	_ = x[CompositeKindAttachment-6]
 }
 
-const _CompositeKind_name = "CompositeKindUnknownCompositeKindStructureCompositeKindResourceCompositeKindContractCompositeKindEventCompositeKindEnum"
+const _CompositeKind_name = "CompositeKindUnknownCompositeKindStructureCompositeKindResourceCompositeKindContractCompositeKindEventCompositeKindEnumCompositeKindAttachment"
 
-var _CompositeKind_index = [...]uint8{0, 20, 42, 63, 84, 102, 119}
+var _CompositeKind_index = [...]uint8{0, 20, 42, 63, 84, 102, 119, 142}
 
 func (i CompositeKind) String() string {
 	if i >= CompositeKind(len(_CompositeKind_index)-1) {
@@ -0,0 +1,60 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=ComputationKind
+
+// ComputationKind is the kind of a computation which is metered.
+//
+// Some kinds of computations, like string concatenations, have a cost which depends on their operands.
+// Their intensity is the cost of the computation, e.g. the number of bytes copied.
+//
+type ComputationKind uint
+
+const (
+	ComputationKindUnknown ComputationKind = iota
+	// ComputationKindStringConcatenation is the concatenation of strings.
+	// The intensity is the number of bytes copied
+	ComputationKindStringConcatenation
+	// ComputationKindElementIteration is the iteration over the elements of an array or dictionary
+	// by a built-in function, e.g. when filtering elements with a predicate.
+	// The intensity is the number of elements
+	ComputationKindElementIteration
+	// ComputationKindStatement is the execution of a statement.
+	// The intensity is always 1
+	ComputationKindStatement
+	// ComputationKindLoopIteration is an iteration of a loop.
+	// The intensity is always 1
+	ComputationKindLoopIteration
+	// ComputationKindFunctionInvocation is the invocation of a function by an invocation expression.
+	// The intensity is always 1
+	ComputationKindFunctionInvocation
+	// ComputationKindStorageRead is the read of a value from account storage.
+	// The intensity is always 1
+	ComputationKindStorageRead
+	// ComputationKindStorageWrite is the write or removal of a value in account storage.
+	// The intensity is always 1
+	ComputationKindStorageWrite
+	// ComputationKindValueAllocation is the construction of a new array, dictionary, or composite value.
+	// The intensity is 1, plus the number of elements or fields of the constructed value
+	ComputationKindValueAllocation
+	// ComputationKindHashing is the hashing of data by a hasher, i.e. an update of the hasher.
+	// The intensity is the number of bytes hashed
+	ComputationKindHashing
+)
@@ -56,6 +56,9 @@ const (
 	DeclarationKindPragma
 	DeclarationKindEnum
 	DeclarationKindEnumCase
+	DeclarationKindAttachment
+	DeclarationKindEntitlement
+	DeclarationKindEntitlementMapping
 )
 
 func DeclarationKindCount() int {
@@ -72,7 +75,10 @@ func (k DeclarationKind) IsTypeDeclaration() bool {
 		DeclarationKindResourceInterface,
 		DeclarationKindContractInterface,
 		DeclarationKindTypeParameter,
-		DeclarationKindEnum:
+		DeclarationKindEnum,
+		DeclarationKindAttachment,
+		DeclarationKindEntitlement,
+		DeclarationKindEntitlementMapping:
 
 		return true
 
@@ -135,6 +141,12 @@ func (k DeclarationKind) Name() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "enum case"
+	case DeclarationKindAttachment:
+		return "attachment"
+	case DeclarationKindEntitlement:
+		return "entitlement"
+	case DeclarationKindEntitlementMapping:
+		return "entitlement mapping"
 	case DeclarationKindUnknown:
 		return "unknown"
 	}
@@ -182,6 +194,12 @@ func (k DeclarationKind) Keywords() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "case"
+	case DeclarationKindAttachment:
+		return "attachment"
+	case DeclarationKindEntitlement:
+		return "entitlement"
+	case DeclarationKindEntitlementMapping:
+		return "entitlement mapping"
 	default:
 		return ""
 	}
@@ -190,3 +208,14 @@ func (k DeclarationKind) Keywords() string {
 func (k DeclarationKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *DeclarationKind) UnmarshalJSON(data []byte) error {
+	index, err := UnmarshalEnumJSON(data, DeclarationKindCount(), func(index int) string {
+		return DeclarationKind(index).String()
+	})
+	if err != nil {
+		return err
+	}
+	*k = DeclarationKind(index)
+	return nil
+}
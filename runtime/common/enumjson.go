@@ -0,0 +1,44 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalEnumJSON decodes the JSON encoding of a value of an enum with the given number of values,
+// i.e. the name of the value, as encoded by the MarshalJSON functions of enums.
+// It returns the index of the value with the decoded name.
+//
+func UnmarshalEnumJSON(data []byte, count int, name func(index int) string) (int, error) {
+	var decodedName string
+	err := json.Unmarshal(data, &decodedName)
+	if err != nil {
+		return 0, err
+	}
+
+	for index := 0; index < count; index++ {
+		if name(index) == decodedName {
+			return index, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid enum value: %q", decodedName)
+}
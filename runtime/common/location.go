@@ -138,6 +138,102 @@ func DecodeTypeID(typeID string) (location Location, qualifiedIdentifier string,
 	return decoder(typeID)
 }
 
+// NormalizeTypeID returns the canonical form of the given type ID.
+// For example, the address in a type ID with an address location
+// is zero-padded, i.e. A.01.Foo.Bar is normalized to A.0000000000000001.Foo.Bar.
+//
+// Type IDs without a location, e.g. of built-in types, are returned unchanged.
+//
+func NormalizeTypeID(typeID string) (TypeID, error) {
+	location, qualifiedIdentifier, err := DecodeTypeID(typeID)
+	if err != nil {
+		return "", err
+	}
+
+	return NewTypeIDFromQualifiedName(location, qualifiedIdentifier), nil
+}
+
+// TypeIDsMatch returns true if the given type IDs denote the same type.
+// Invalid type IDs never match.
+//
+func TypeIDsMatch(first, second string) bool {
+	normalizedFirst, err := NormalizeTypeID(first)
+	if err != nil {
+		return false
+	}
+
+	normalizedSecond, err := NormalizeTypeID(second)
+	if err != nil {
+		return false
+	}
+
+	return normalizedFirst == normalizedSecond
+}
+
+// NewEventQualifiedIdentifier returns the qualified identifier of the event
+// with the given name, which is declared in the contract with the given name,
+// e.g. `Auction.BidPlaced`.
+//
+// Events which are not declared in a contract, e.g. built-in events or events declared in a transaction,
+// have no contract name, and the qualified identifier is just the event name.
+//
+func NewEventQualifiedIdentifier(contractName, eventName string) string {
+	if contractName == "" {
+		return eventName
+	}
+
+	return contractName + "." + eventName
+}
+
+// NewEventTypeID returns the type ID of the event with the given name,
+// which is declared in the contract with the given name at the given location,
+// e.g. A.0000000000000001.Auction.BidPlaced.
+//
+func NewEventTypeID(location Location, contractName, eventName string) TypeID {
+	return NewTypeIDFromQualifiedName(
+		location,
+		NewEventQualifiedIdentifier(contractName, eventName),
+	)
+}
+
+// DecodeEventTypeID decodes the given event type ID into the location,
+// the name of the contract which declares the event, and the name of the event.
+// It is the inverse of NewEventTypeID.
+//
+// The contract name is empty if the event is not declared in a contract.
+//
+func DecodeEventTypeID(typeID string) (location Location, contractName string, eventName string, err error) {
+	var qualifiedIdentifier string
+	location, qualifiedIdentifier, err = DecodeTypeID(typeID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	parts := strings.Split(qualifiedIdentifier, ".")
+
+	switch len(parts) {
+	case 1:
+		eventName = parts[0]
+
+	case 2:
+		contractName = parts[0]
+		eventName = parts[1]
+
+		if contractName == "" {
+			return nil, "", "", fmt.Errorf("invalid event type ID %q: missing contract name", typeID)
+		}
+
+	default:
+		return nil, "", "", fmt.Errorf("invalid event type ID %q: invalid qualified identifier", typeID)
+	}
+
+	if eventName == "" {
+		return nil, "", "", fmt.Errorf("invalid event type ID %q: missing event name", typeID)
+	}
+
+	return location, contractName, eventName, nil
+}
+
 // HasImportLocation
 
 type HasImportLocation interface {
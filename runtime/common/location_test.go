@@ -21,6 +21,7 @@ package common
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -153,3 +154,160 @@ func TestLocationsInSameAccount(t *testing.T) {
 		)
 	})
 }
+
+func TestNormalizeTypeID(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("address location", func(t *testing.T) {
+
+		t.Parallel()
+
+		typeID, err := NormalizeTypeID("A.01.Foo.Bar")
+		require.NoError(t, err)
+		assert.Equal(t, TypeID("A.0000000000000001.Foo.Bar"), typeID)
+	})
+
+	t.Run("no location", func(t *testing.T) {
+
+		t.Parallel()
+
+		typeID, err := NormalizeTypeID("Int")
+		require.NoError(t, err)
+		assert.Equal(t, TypeID("Int"), typeID)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := NormalizeTypeID("A.xyz.Foo")
+		require.Error(t, err)
+	})
+}
+
+func TestTypeIDsMatch(t *testing.T) {
+
+	t.Parallel()
+
+	assert.True(t, TypeIDsMatch("A.01.Foo.Bar", "A.0000000000000001.Foo.Bar"))
+	assert.False(t, TypeIDsMatch("A.01.Foo.Bar", "A.02.Foo.Bar"))
+	assert.False(t, TypeIDsMatch("A.xyz.Foo", "A.xyz.Foo"))
+}
+
+func TestNewEventTypeID(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("address location", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := AddressLocation{
+			Address: MustBytesToAddress([]byte{0x1}),
+			Name:    "Auction",
+		}
+
+		assert.Equal(t,
+			TypeID("A.0000000000000001.Auction.BidPlaced"),
+			NewEventTypeID(location, "Auction", "BidPlaced"),
+		)
+	})
+
+	t.Run("no contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := TransactionLocation{0x1}
+
+		assert.Equal(t,
+			location.TypeID("Done"),
+			NewEventTypeID(location, "", "Done"),
+		)
+	})
+
+	t.Run("no location", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			TypeID("Foo.Bar"),
+			NewEventTypeID(nil, "Foo", "Bar"),
+		)
+	})
+}
+
+func TestDecodeEventTypeID(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("address location", func(t *testing.T) {
+
+		t.Parallel()
+
+		location, contractName, eventName, err := DecodeEventTypeID("A.0000000000000001.Auction.BidPlaced")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			AddressLocation{
+				Address: MustBytesToAddress([]byte{0x1}),
+				Name:    "Auction",
+			},
+			location,
+		)
+		assert.Equal(t, "Auction", contractName)
+		assert.Equal(t, "BidPlaced", eventName)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		expectedLocation := StringLocation("test")
+
+		typeID := NewEventTypeID(expectedLocation, "Test", "Foo")
+
+		location, contractName, eventName, err := DecodeEventTypeID(string(typeID))
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedLocation, location)
+		assert.Equal(t, "Test", contractName)
+		assert.Equal(t, "Foo", eventName)
+	})
+
+	t.Run("no contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		location, contractName, eventName, err := DecodeEventTypeID("S.test.Foo")
+		require.NoError(t, err)
+
+		assert.Equal(t, StringLocation("test"), location)
+		assert.Equal(t, "", contractName)
+		assert.Equal(t, "Foo", eventName)
+	})
+
+	t.Run("too many parts", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, _, _, err := DecodeEventTypeID("S.test.Foo.Bar.Baz")
+		require.Error(t, err)
+	})
+
+	t.Run("missing event name", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, _, _, err := DecodeEventTypeID("S.test.Foo.")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid location", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, _, _, err := DecodeEventTypeID("A.xyz.Foo.Bar")
+		require.Error(t, err)
+	})
+}
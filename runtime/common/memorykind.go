@@ -0,0 +1,67 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=MemoryKind
+
+// MemoryKind is the kind of a memory allocation which is metered.
+//
+// The amount of a memory usage is an estimate of the number of bytes allocated.
+//
+type MemoryKind uint
+
+const (
+	MemoryKindUnknown MemoryKind = iota
+	// MemoryKindString is the allocation of a string.
+	// The amount is the number of bytes of the string
+	MemoryKindString
+	// MemoryKindArray is the allocation of an array, or the addition of elements to an array.
+	// The amount is estimated based on the number of elements
+	MemoryKindArray
+	// MemoryKindDictionary is the allocation of a dictionary, or the insertion of new entries into a dictionary.
+	// The amount is estimated based on the number of entries
+	MemoryKindDictionary
+	// MemoryKindComposite is the allocation of a composite value, e.g. a structure or resource.
+	// The amount is estimated based on the number of fields
+	MemoryKindComposite
+	// MemoryKindBigInt is the allocation of an arbitrary-precision integer,
+	// e.g. the result of an arithmetic operation on `Int` or `UInt256` values.
+	// The amount is the number of bytes of the magnitude of the integer
+	MemoryKindBigInt
+	// MemoryKindHasherBuffer is the buffering of data by a hasher
+	// which only hashes the data when it is finalized.
+	// The amount is the number of bytes buffered
+	MemoryKindHasherBuffer
+)
+
+// MemoryUsage is the memory usage of an allocation of the given kind
+//
+type MemoryUsage struct {
+	Kind   MemoryKind
+	Amount uint64
+}
+
+// MemoryGauge meters memory allocations.
+//
+// If MeterMemory returns an error, e.g. because a memory limit was exceeded,
+// the allocation is aborted with the error.
+//
+type MemoryGauge interface {
+	MeterMemory(usage MemoryUsage) error
+}
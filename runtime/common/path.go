@@ -0,0 +1,123 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePath parses the given path string, e.g. `/storage/foo`,
+// into its domain and identifier.
+//
+func ParsePath(path string) (domain PathDomain, identifier string, err error) {
+
+	const errorMessagePrefix = "invalid path"
+
+	newError := func(message string) (PathDomain, string, error) {
+		return PathDomainUnknown, "", fmt.Errorf("%s: %s", errorMessagePrefix, message)
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return newError("missing leading slash")
+	}
+
+	parts := strings.SplitN(path[1:], "/", 2)
+	if len(parts) < 2 {
+		return newError("missing identifier")
+	}
+
+	domain = PathDomainFromIdentifier(parts[0])
+	if domain == PathDomainUnknown {
+		return newError(fmt.Sprintf("unknown domain `%s`", parts[0]))
+	}
+
+	identifier = parts[1]
+	if !isIdentifier(identifier) {
+		return newError(fmt.Sprintf("invalid identifier `%s`", identifier))
+	}
+
+	return domain, identifier, nil
+}
+
+// PathString returns the canonical string representation of the path
+// with the given domain and identifier, e.g. `/storage/foo`.
+//
+func PathString(domain PathDomain, identifier string) string {
+	return fmt.Sprintf("/%s/%s", domain.Identifier(), identifier)
+}
+
+// NormalizePath returns the canonical string representation of the given path string.
+// Leading and trailing whitespace is ignored.
+//
+func NormalizePath(path string) (string, error) {
+	domain, identifier, err := ParsePath(strings.TrimSpace(path))
+	if err != nil {
+		return "", err
+	}
+
+	return PathString(domain, identifier), nil
+}
+
+// PathsMatch returns true if the given path strings denote the same path.
+// Invalid paths never match.
+//
+func PathsMatch(first, second string) bool {
+	normalizedFirst, err := NormalizePath(first)
+	if err != nil {
+		return false
+	}
+
+	normalizedSecond, err := NormalizePath(second)
+	if err != nil {
+		return false
+	}
+
+	return normalizedFirst == normalizedSecond
+}
+
+// isIdentifier returns true if the given string is a valid identifier,
+// i.e. it is not empty, only consists of letters, digits, and underscores,
+// and does not start with a digit.
+//
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_',
+			'a' <= r && r <= 'z',
+			'A' <= r && r <= 'Z':
+
+			continue
+
+		case '0' <= r && r <= '9':
+			if i == 0 {
+				return false
+			}
+
+		default:
+			return false
+		}
+	}
+
+	return true
+}
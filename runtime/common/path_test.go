@@ -0,0 +1,84 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, domain := range AllPathDomains {
+			parsedDomain, identifier, err := ParsePath(PathString(domain, "foo_1"))
+			require.NoError(t, err)
+			assert.Equal(t, domain, parsedDomain)
+			assert.Equal(t, "foo_1", identifier)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, path := range []string{
+			"",
+			"storage/foo",
+			"/storage",
+			"/storage/",
+			"/unknown/foo",
+			"/storage/foo/bar",
+			"/storage/1foo",
+			"/storage/foo bar",
+		} {
+			_, _, err := ParsePath(path)
+			require.Error(t, err, path)
+		}
+	})
+}
+
+func TestNormalizePath(t *testing.T) {
+
+	t.Parallel()
+
+	normalized, err := NormalizePath(" /public/foo\n")
+	require.NoError(t, err)
+	assert.Equal(t, "/public/foo", normalized)
+
+	_, err = NormalizePath("/public")
+	require.Error(t, err)
+}
+
+func TestPathsMatch(t *testing.T) {
+
+	t.Parallel()
+
+	assert.True(t, PathsMatch("/storage/foo", " /storage/foo"))
+	assert.False(t, PathsMatch("/storage/foo", "/public/foo"))
+	assert.False(t, PathsMatch("/storage/foo", "/storage/bar"))
+	assert.False(t, PathsMatch("/invalid/foo", "/invalid/foo"))
+}
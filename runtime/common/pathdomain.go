@@ -31,12 +31,14 @@ const (
 	PathDomainStorage
 	PathDomainPrivate
 	PathDomainPublic
+	PathDomainTransaction
 )
 
 var AllPathDomains = []PathDomain{
 	PathDomainStorage,
 	PathDomainPrivate,
 	PathDomainPublic,
+	PathDomainTransaction,
 }
 
 var AllPathDomainsByIdentifier = map[string]PathDomain{}
@@ -66,7 +68,17 @@ func (i PathDomain) Identifier() string {
 
 	case PathDomainPublic:
 		return "public"
+
+	case PathDomainTransaction:
+		return "transaction"
 	}
 
 	panic(errors.NewUnreachableError())
 }
+
+// IsPersistent returns true if values stored in the domain
+// are persisted beyond the end of the transaction.
+//
+func (i PathDomain) IsPersistent() bool {
+	return i != PathDomainTransaction
+}
@@ -12,11 +12,12 @@ func _() {
 	_ = x[PathDomainStorage-1]
 	_ = x[PathDomainPrivate-2]
 	_ = x[PathDomainPublic-3]
+	_ = x[PathDomainTransaction-4]
 }
 
-const _PathDomain_name = "PathDomainUnknownPathDomainStoragePathDomainPrivatePathDomainPublic"
+const _PathDomain_name = "PathDomainUnknownPathDomainStoragePathDomainPrivatePathDomainPublicPathDomainTransaction"
 
-var _PathDomain_index = [...]uint8{0, 17, 34, 51, 67}
+var _PathDomain_index = [...]uint8{0, 17, 34, 51, 67, 88}
 
 func (i PathDomain) String() string {
 	if i >= PathDomain(len(_PathDomain_index)-1) {
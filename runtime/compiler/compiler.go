@@ -93,6 +93,11 @@ func (compiler *Compiler) VisitEmitStatement(_ *ast.EmitStatement) ast.Repr {
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitRemoveStatement(_ *ast.RemoveStatement) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitSwitchStatement(_ *ast.SwitchStatement) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -250,6 +255,11 @@ func (compiler *Compiler) VisitDestroyExpression(_ *ast.DestroyExpression) ast.R
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitAttachExpression(_ *ast.AttachExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitReferenceExpression(_ *ast.ReferenceExpression) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -377,6 +387,16 @@ func (compiler *Compiler) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclaration) a
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitEntitlementDeclaration(_ *ast.EntitlementDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
+func (compiler *Compiler) VisitEntitlementMappingDeclaration(_ *ast.EntitlementMappingDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func compileBinaryOperation(operation ast.Operation) ir.BinOp {
 	// TODO: add remaining operations
 	switch operation {
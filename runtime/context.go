@@ -21,14 +21,54 @@ package runtime
 import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 type Context struct {
 	Interface         Interface
 	Location          Location
 	PredeclaredValues []ValueDeclaration
-	codes             map[common.LocationID]string
-	programs          map[common.LocationID]*ast.Program
+	// ContractValueCache is an optional cache of contract values,
+	// which is shared between executions. See ContractValueCache
+	ContractValueCache *ContractValueCache
+	// Transcript is an optional transcript, which records what the execution did,
+	// e.g. the functions it called, the storage paths it accessed, and the events it emitted.
+	// See interpreter.Transcript
+	Transcript *interpreter.Transcript
+	// LanguageVersion is the language version programs are checked with.
+	// It determines which breaking changes of the checker are enabled,
+	// so embedders can roll them out at a certain point, e.g. at a certain block height.
+	// The default is the legacy behaviour. See sema.LanguageVersion
+	LanguageVersion sema.LanguageVersion
+	// ReadOnly determines if a script may only read.
+	// If set, ExecuteScript rejects writes, e.g. saving to or loading from account storage,
+	// contract deployments, and account key changes, statically when the script is checked,
+	// and dynamically when imported code is called, with a ReadOnlyViolationError
+	ReadOnly bool
+	// simulation is the hypothetical account state layered over the state of the interface,
+	// if the execution is a simulation. See Runtime.SimulateTransaction
+	simulation   *Simulation
+	codes        map[common.LocationID]string
+	programs     map[common.LocationID]*ast.Program
+	programCache programCache
+}
+
+// programCache records the programs of imported locations,
+// so they do not have to be loaded from the interface again.
+//
+type programCache map[common.LocationID]*interpreter.Program
+
+func (c programCache) copy() programCache {
+	if c == nil {
+		return nil
+	}
+
+	result := make(programCache, len(c))
+	for locationID, program := range c {
+		result[locationID] = program
+	}
+	return result
 }
 
 func (c Context) SetCode(location common.Location, code string) {
@@ -54,3 +94,22 @@ func (c *Context) InitializeCodesAndPrograms() {
 		c.programs = map[common.LocationID]*ast.Program{}
 	}
 }
+
+// copyCodesAndPrograms returns a copy of the context
+// which has copies of the codes and programs.
+//
+func (c Context) copyCodesAndPrograms() Context {
+	result := c
+
+	result.codes = make(map[common.LocationID]string, len(c.codes))
+	for locationID, code := range c.codes {
+		result.codes[locationID] = code
+	}
+
+	result.programs = make(map[common.LocationID]*ast.Program, len(c.programs))
+	for locationID, program := range c.programs {
+		result.programs[locationID] = program
+	}
+
+	return result
+}
@@ -803,3 +803,148 @@ func TestRuntimeImportMultipleContracts(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRuntimeFrozenContract(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	signerAddress := Address{0x1}
+
+	const code = `
+      pub contract Test {
+          pub fun hello(): String {
+              return "Hello"
+          }
+      }
+    `
+
+	const code2 = `
+      pub contract Test {
+          pub fun hello(): String {
+              return "Bye"
+          }
+      }
+    `
+
+	addTx := []byte(fmt.Sprintf(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.addFrozen(name: "Test", code: "%s".decodeHex())
+              }
+          }
+        `,
+		hex.EncodeToString([]byte(code)),
+	))
+
+	updateTx := []byte(fmt.Sprintf(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.update__experimental(name: "Test", code: "%s".decodeHex())
+              }
+          }
+        `,
+		hex.EncodeToString([]byte(code2)),
+	))
+
+	removeTx := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.contracts.remove(name: "Test")
+          }
+      }
+    `)
+
+	var deployedCode []byte
+	var events []cadence.Event
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signerAddress}, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			deployedCode = code
+			return nil
+		},
+		getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+			if name == "Test" {
+				return deployedCode, nil
+			}
+			return nil, nil
+		},
+		removeAccountContractCode: func(_ Address, _ string) error {
+			deployedCode = nil
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: addTx,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte(code), deployedCode)
+	require.Len(t, events, 1)
+
+	t.Run("update", func(t *testing.T) {
+
+		events = nil
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: updateTx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var frozenErr *FrozenContractUpdateError
+		require.ErrorAs(t, err, &frozenErr)
+		assert.Equal(t, "Test", frozenErr.Name)
+		assert.False(t, frozenErr.IsRemoval)
+
+		require.Equal(t, []byte(code), deployedCode)
+		require.Empty(t, events)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+
+		events = nil
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: removeTx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var frozenErr *FrozenContractUpdateError
+		require.ErrorAs(t, err, &frozenErr)
+		assert.True(t, frozenErr.IsRemoval)
+
+		require.Equal(t, []byte(code), deployedCode)
+		require.Empty(t, events)
+	})
+}
@@ -21,6 +21,7 @@ package runtime
 import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -32,6 +33,7 @@ type ContractUpdateValidator struct {
 	rootDecl     ast.Declaration
 	currentDecl  ast.Declaration
 	errors       []error
+	warnings     []error
 }
 
 // ContractUpdateValidator should implement ast.TypeEqualityChecker
@@ -76,6 +78,41 @@ func (validator *ContractUpdateValidator) Validate() error {
 	return nil
 }
 
+// Warnings returns the changes found by `Validate()` which do not prevent the update,
+// but may be unintended, e.g. removed fields, whose stored data becomes inaccessible.
+func (validator *ContractUpdateValidator) Warnings() []error {
+	return validator.warnings
+}
+
+// ValidateContractUpdate checks if the contract or contract interface with the given name
+// can be updated from the old code to the new code, without performing the update.
+//
+// It performs the same validation as the update of a contract, so hosts can use it
+// as a pre-deployment check. It returns the warnings of the validator,
+// and an error if the code cannot be parsed, or if the update is invalid.
+func ValidateContractUpdate(
+	location Location,
+	contractName string,
+	oldCode []byte,
+	newCode []byte,
+) (warnings []error, err error) {
+
+	oldProgram, err := parser2.ParseProgram(string(oldCode))
+	if err != nil {
+		return nil, err
+	}
+
+	newProgram, err := parser2.ParseProgram(string(newCode))
+	if err != nil {
+		return nil, err
+	}
+
+	validator := NewContractUpdateValidator(location, contractName, oldProgram, newProgram)
+	err = validator.Validate()
+
+	return validator.Warnings(), err
+}
+
 func (validator *ContractUpdateValidator) getRootDeclaration(program *ast.Program) ast.Declaration {
 	decl, err := getRootDeclaration(program)
 
@@ -169,6 +206,20 @@ func (validator *ContractUpdateValidator) checkFields(oldDeclaration ast.Declara
 
 		validator.checkField(oldField, newField)
 	}
+
+	newFieldsByIdentifier := newDeclaration.DeclarationMembers().FieldsByIdentifier()
+
+	for _, oldField := range oldDeclaration.DeclarationMembers().Fields() {
+		if newFieldsByIdentifier[oldField.Identifier.Identifier] != nil {
+			continue
+		}
+
+		validator.warn(&RemovedFieldWarning{
+			DeclName:  newDeclaration.DeclarationIdentifier().Identifier,
+			FieldName: oldField.Identifier.Identifier,
+			Range:     ast.NewRangeFromPositioned(newDeclaration.DeclarationIdentifier()),
+		})
+	}
 }
 
 func (validator *ContractUpdateValidator) checkField(oldField *ast.FieldDeclaration, newField *ast.FieldDeclaration) {
@@ -529,6 +580,10 @@ func (validator *ContractUpdateValidator) report(err error) {
 	validator.errors = append(validator.errors, err)
 }
 
+func (validator *ContractUpdateValidator) warn(warning error) {
+	validator.warnings = append(validator.warnings, warning)
+}
+
 func (validator *ContractUpdateValidator) getContractUpdateError() error {
 	return &ContractUpdateError{
 		ContractName: validator.contractName,
@@ -29,6 +29,7 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -1980,3 +1981,102 @@ func TestContractUpdateValidationDisabled(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestValidateContractUpdate(t *testing.T) {
+
+	t.Parallel()
+
+	location := common.AddressLocation{
+		Address: common.Address{0x1},
+		Name:    "Test",
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+          pub contract Test {
+              pub var a: String
+              pub var b: Int
+
+              init() {
+                  self.a = "hello"
+                  self.b = 1
+              }
+          }
+        `
+
+		const newCode = `
+          pub contract Test {
+              pub var a: String
+
+              init() {
+                  self.a = "hello"
+              }
+
+              pub fun test() {}
+          }
+        `
+
+		warnings, err := ValidateContractUpdate(location, "Test", []byte(oldCode), []byte(newCode))
+		require.NoError(t, err)
+
+		require.Len(t, warnings, 1)
+		require.IsType(t, &RemovedFieldWarning{}, warnings[0])
+		assert.Equal(t,
+			"removed field `b` from `Test`",
+			warnings[0].Error(),
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+          pub contract Test {
+              pub enum E: UInt8 {
+                  pub case a
+              }
+
+              pub struct S: I {}
+
+              pub struct interface I {}
+          }
+        `
+
+		const newCode = `
+          pub contract Test {
+              pub enum E: UInt16 {
+                  pub case a
+              }
+
+              pub struct S {}
+
+              pub struct interface I {}
+          }
+        `
+
+		_, err := ValidateContractUpdate(location, "Test", []byte(oldCode), []byte(newCode))
+		require.Error(t, err)
+
+		var updateErr *ContractUpdateError
+		require.ErrorAs(t, err, &updateErr)
+
+		require.Len(t, updateErr.Errors, 2)
+		assert.IsType(t, &ConformanceMismatchError{}, updateErr.Errors[0])
+		assert.IsType(t, &ConformanceCountMismatchError{}, updateErr.Errors[1])
+	})
+
+	t.Run("parsing error", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ValidateContractUpdate(location, "Test", []byte(`pub contract Test {}`), []byte(`pub contract`))
+		require.Error(t, err)
+
+		var parserErr parser2.Error
+		require.ErrorAs(t, err, &parserErr)
+	})
+}
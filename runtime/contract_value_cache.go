@@ -0,0 +1,79 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// ContractValueCache caches contract values loaded from storage,
+// keyed by the location of the contract and the hash of its code.
+//
+// Each execution uses a cache, so a contract value is only loaded once per execution.
+// A cache can also be shared between executions using Context.ContractValueCache.
+//
+// A shared cache must only be used while the state does not change,
+// e.g. for executing multiple scripts against the same block:
+// The cached values refer to the storage of the execution that loaded them,
+// so modifications of the contract values by other executions are not persisted,
+// but are visible to subsequent executions.
+// A shared cache must not be used by concurrent executions.
+//
+type ContractValueCache struct {
+	mutex  sync.Mutex
+	values map[contractValueCacheKey]*interpreter.CompositeValue
+}
+
+type contractValueCacheKey struct {
+	locationID common.LocationID
+	codeHash   [32]byte
+}
+
+func NewContractValueCache() *ContractValueCache {
+	return &ContractValueCache{
+		values: map[contractValueCacheKey]*interpreter.CompositeValue{},
+	}
+}
+
+func (c *ContractValueCache) get(key contractValueCacheKey) *interpreter.CompositeValue {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.values[key]
+}
+
+func (c *ContractValueCache) set(key contractValueCacheKey, value *interpreter.CompositeValue) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[key] = value
+}
+
+// Clear removes all cached contract values,
+// e.g. when the state changed.
+//
+func (c *ContractValueCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values = map[contractValueCacheKey]*interpreter.CompositeValue{}
+}
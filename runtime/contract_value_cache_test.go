@@ -0,0 +1,207 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeContractValueCache(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	addressValue := cadence.BytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      pub contract Test {
+          pub let x: Int
+
+          init() {
+              self.x = 42
+          }
+
+          pub fun getX(): Int {
+              return self.x
+          }
+
+          pub fun getAddress(): Address {
+              return self.account.address
+          }
+      }
+    `)
+
+	script := []byte(`
+      import Test from 0x1
+
+      pub fun main(): [AnyStruct] {
+          return [Test.x, Test.getX(), Test.getAddress()]
+      }
+    `)
+
+	deploy := utils.DeploymentTransaction("Test", contract)
+
+	var accountCode []byte
+	var contractReads int
+
+	onRead := func(_, key, _ []byte) {
+		if string(key) == StorageDomainContract {
+			contractReads++
+		}
+	}
+
+	runtimeInterface := &testRuntimeInterface{
+		storage:         newTestLedger(onRead, nil),
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{Address(addressValue)}, nil
+		},
+		getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: deploy,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	expected := cadence.NewArray([]cadence.Value{
+		cadence.NewInt(42),
+		cadence.NewInt(42),
+		addressValue,
+	})
+
+	executeScript := func(t *testing.T, cache *ContractValueCache) {
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface:          runtimeInterface,
+				Location:           common.ScriptLocation{},
+				ContractValueCache: cache,
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+
+	t.Run("per execution", func(t *testing.T) {
+
+		contractReads = 0
+
+		executeScript(t, nil)
+		require.Equal(t, 1, contractReads)
+
+		executeScript(t, nil)
+		require.Equal(t, 2, contractReads)
+	})
+
+	t.Run("shared", func(t *testing.T) {
+
+		cache := NewContractValueCache()
+
+		contractReads = 0
+
+		executeScript(t, cache)
+		require.Equal(t, 1, contractReads)
+
+		// The contract value is not loaded again
+
+		executeScript(t, cache)
+		require.Equal(t, 1, contractReads)
+
+		// The contract value is loaded again when the code changed
+
+		accountCode = append(accountCode, '\n')
+
+		executeScript(t, cache)
+		require.Equal(t, 2, contractReads)
+
+		// The contract value is loaded again when the cache was cleared
+
+		cache.Clear()
+
+		executeScript(t, cache)
+		require.Equal(t, 3, contractReads)
+	})
+
+	t.Run("update", func(t *testing.T) {
+
+		cache := NewContractValueCache()
+
+		contractReads = 0
+
+		executeScript(t, cache)
+		require.Equal(t, 1, contractReads)
+
+		// Update the contract between the executions
+
+		updatedContract := append(contract[:len(contract):len(contract)], []byte(`
+          // updated
+        `)...)
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.UpdateTransaction("Test", updatedContract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, updatedContract, accountCode)
+
+		// The code hash changed, so the cached contract value is not used
+
+		contractReads = 0
+
+		executeScript(t, cache)
+		require.Equal(t, 1, contractReads)
+
+		executeScript(t, cache)
+		require.Equal(t, 1, contractReads)
+	})
+}
@@ -148,8 +148,12 @@ func ExportType(t sema.Type, results map[sema.TypeID]cadence.Type) cadence.Type
 			return cadence.AnyResourceType{}
 		case sema.BlockType:
 			return cadence.BlockType{}
+		case sema.BlockHeaderType:
+			return cadence.BlockHeaderType{}
 		case sema.StringType:
 			return cadence.StringType{}
+		case sema.BytesType:
+			return cadence.BytesType{}
 		case sema.AccountKeyType:
 			return cadence.AccountKeyType{}
 		case sema.PublicAccountContractsType:
@@ -258,6 +262,14 @@ func exportCompositeType(t *sema.CompositeType, results map[sema.TypeID]cadence.
 			RawType:             ExportType(t.EnumRawType, results),
 		}
 
+	case common.CompositeKindAttachment:
+		result = &cadence.AttachmentType{
+			Location:            t.Location,
+			QualifiedIdentifier: t.QualifiedIdentifier(),
+			Fields:              fields,
+			BaseType:            ExportType(t.BaseType, results),
+		}
+
 	default:
 		panic(fmt.Sprintf("cannot export composite type %v of unknown kind %v", t, t.Kind))
 	}
@@ -376,8 +388,48 @@ func exportReferenceType(t *sema.ReferenceType, results map[sema.TypeID]cadence.
 	convertedType := ExportType(t.Type, results)
 
 	return cadence.ReferenceType{
-		Authorized: t.Authorized,
-		Type:       convertedType,
+		Authorized:    t.Authorized,
+		Authorization: exportEntitlementSetAccess(t.Authorization),
+		Type:          convertedType,
+	}
+}
+
+func exportEntitlementSetAccess(access *sema.EntitlementSetAccess) *cadence.EntitlementSetAuthorization {
+	if access == nil {
+		return nil
+	}
+
+	entitlements := make([]common.TypeID, len(access.Entitlements))
+	for i, entitlement := range access.Entitlements {
+		entitlements[i] = entitlement.ID()
+	}
+
+	kind := cadence.Conjunction
+	if access.SetKind == sema.EntitlementSetKindDisjunction {
+		kind = cadence.Disjunction
+	}
+
+	return &cadence.EntitlementSetAuthorization{
+		Entitlements: entitlements,
+		Kind:         kind,
+	}
+}
+
+func importEntitlementSetAuthorization(
+	authorization *cadence.EntitlementSetAuthorization,
+) *interpreter.EntitlementSetAuthorization {
+	if authorization == nil {
+		return nil
+	}
+
+	setKind := sema.EntitlementSetKindConjunction
+	if authorization.Kind == cadence.Disjunction {
+		setKind = sema.EntitlementSetKindDisjunction
+	}
+
+	return &interpreter.EntitlementSetAuthorization{
+		Entitlements: authorization.Entitlements,
+		SetKind:      setKind,
 	}
 }
 
@@ -417,10 +469,10 @@ func importInterfaceType(t cadence.InterfaceType) interpreter.InterfaceStaticTyp
 }
 
 func importCompositeType(t cadence.CompositeType) interpreter.CompositeStaticType {
-	return interpreter.CompositeStaticType{
-		Location:            t.CompositeTypeLocation(),
-		QualifiedIdentifier: t.CompositeTypeQualifiedIdentifier(),
-	}
+	return interpreter.NewCompositeStaticType(
+		t.CompositeTypeLocation(),
+		t.CompositeTypeQualifiedIdentifier(),
+	)
 }
 
 func ImportType(t cadence.Type) interpreter.StaticType {
@@ -519,7 +571,8 @@ func ImportType(t cadence.Type) interpreter.StaticType {
 		*cadence.ResourceType,
 		*cadence.EventType,
 		*cadence.ContractType,
-		*cadence.EnumType:
+		*cadence.EnumType,
+		*cadence.AttachmentType:
 		return importCompositeType(t.(cadence.CompositeType))
 	case *cadence.StructInterfaceType,
 		*cadence.ResourceInterfaceType,
@@ -527,8 +580,9 @@ func ImportType(t cadence.Type) interpreter.StaticType {
 		return importInterfaceType(t.(cadence.InterfaceType))
 	case cadence.ReferenceType:
 		return interpreter.ReferenceStaticType{
-			Authorized: t.Authorized,
-			Type:       ImportType(t.Type),
+			Authorized:    t.Authorized,
+			Authorization: importEntitlementSetAuthorization(t.Authorization),
+			Type:          ImportType(t.Type),
 		}
 	case cadence.RestrictedType:
 		restrictions := make([]interpreter.InterfaceStaticType, 0, len(t.Restrictions))
@@ -545,6 +599,8 @@ func ImportType(t cadence.Type) interpreter.StaticType {
 		}
 	case cadence.BlockType:
 		return interpreter.PrimitiveStaticTypeBlock
+	case cadence.BlockHeaderType:
+		return interpreter.PrimitiveStaticTypeBlockHeader
 	case cadence.CapabilityPathType:
 		return interpreter.PrimitiveStaticTypeCapabilityPath
 	case cadence.StoragePathType:
@@ -553,9 +609,17 @@ func ImportType(t cadence.Type) interpreter.StaticType {
 		return interpreter.PrimitiveStaticTypePublicPath
 	case cadence.PrivatePathType:
 		return interpreter.PrimitiveStaticTypePrivatePath
+	case cadence.PathType:
+		return interpreter.PrimitiveStaticTypePath
+	case cadence.BytesType:
+		return interpreter.PrimitiveStaticTypeBytes
 	case cadence.CapabilityType:
+		var borrowType interpreter.StaticType
+		if t.BorrowType != nil {
+			borrowType = ImportType(t.BorrowType)
+		}
 		return interpreter.CapabilityStaticType{
-			BorrowType: ImportType(t.BorrowType),
+			BorrowType: borrowType,
 		}
 	case cadence.AccountKeyType:
 		return interpreter.PrimitiveStaticTypeAccountKey
@@ -577,3 +641,166 @@ func ImportType(t cadence.Type) interpreter.StaticType {
 		panic(fmt.Sprintf("cannot export type of type %T", t))
 	}
 }
+
+// ImportSemaType converts an external type to its corresponding sema type.
+//
+// It is the inverse of ExportType.
+// Composite and interface types are resolved using the given functions.
+//
+func ImportSemaType(
+	t cadence.Type,
+	getInterface func(location common.Location, qualifiedIdentifier string) (*sema.InterfaceType, error),
+	getComposite func(location common.Location, qualifiedIdentifier string, typeID common.TypeID) (*sema.CompositeType, error),
+) (
+	sema.Type,
+	error,
+) {
+	switch t := t.(type) {
+	case cadence.OptionalType:
+		ty, err := ImportSemaType(t.Type, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+		return &sema.OptionalType{
+			Type: ty,
+		}, nil
+
+	case cadence.VariableSizedArrayType:
+		ty, err := ImportSemaType(t.ElementType, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+		return &sema.VariableSizedType{
+			Type: ty,
+		}, nil
+
+	case cadence.ConstantSizedArrayType:
+		ty, err := ImportSemaType(t.ElementType, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+		return &sema.ConstantSizedType{
+			Type: ty,
+			Size: int64(t.Size),
+		}, nil
+
+	case cadence.DictionaryType:
+		keyType, err := ImportSemaType(t.KeyType, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+		valueType, err := ImportSemaType(t.ElementType, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+		return &sema.DictionaryType{
+			KeyType:   keyType,
+			ValueType: valueType,
+		}, nil
+
+	case cadence.ReferenceType:
+		if t.Authorization != nil {
+			return nil, fmt.Errorf("cannot import entitled reference type %s", t.ID())
+		}
+
+		ty, err := ImportSemaType(t.Type, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+		return &sema.ReferenceType{
+			Authorized: t.Authorized,
+			Type:       ty,
+		}, nil
+
+	case cadence.RestrictedType:
+		ty, err := ImportSemaType(t.Type, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+
+		restrictions := make([]*sema.InterfaceType, 0, len(t.Restrictions))
+		for _, restriction := range t.Restrictions {
+			restrictionType, err := ImportSemaType(restriction, getInterface, getComposite)
+			if err != nil {
+				return nil, err
+			}
+
+			interfaceType, ok := restrictionType.(*sema.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf(
+					"cannot import restricted type: invalid restriction `%s`",
+					restriction.ID(),
+				)
+			}
+			restrictions = append(restrictions, interfaceType)
+		}
+
+		return &sema.RestrictedType{
+			Type:         ty,
+			Restrictions: restrictions,
+		}, nil
+
+	case cadence.CapabilityType:
+		var borrowType sema.Type
+		if t.BorrowType != nil {
+			var err error
+			borrowType, err = ImportSemaType(t.BorrowType, getInterface, getComposite)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &sema.CapabilityType{
+			BorrowType: borrowType,
+		}, nil
+
+	case cadence.FunctionType:
+		parameters := make([]*sema.Parameter, len(t.Parameters))
+		for i, parameter := range t.Parameters {
+			parameterType, err := ImportSemaType(parameter.Type, getInterface, getComposite)
+			if err != nil {
+				return nil, err
+			}
+			parameters[i] = &sema.Parameter{
+				Label:          parameter.Label,
+				Identifier:     parameter.Identifier,
+				TypeAnnotation: sema.NewTypeAnnotation(parameterType),
+			}
+		}
+
+		returnType, err := ImportSemaType(t.ReturnType, getInterface, getComposite)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sema.FunctionType{
+			Parameters:           parameters,
+			ReturnTypeAnnotation: sema.NewTypeAnnotation(returnType),
+		}, nil
+
+	case cadence.CompositeType:
+		// NOTE: built-in composite types, like AccountKey, have no location.
+		// The given function must resolve them, e.g. using sema.NativeCompositeTypes
+		return getComposite(
+			t.CompositeTypeLocation(),
+			t.CompositeTypeQualifiedIdentifier(),
+			common.TypeID(t.ID()),
+		)
+
+	case cadence.InterfaceType:
+		return getInterface(
+			t.InterfaceTypeLocation(),
+			t.InterfaceTypeQualifiedIdentifier(),
+		)
+
+	case nil:
+		return nil, fmt.Errorf("cannot import missing type")
+
+	default:
+		// All remaining types are primitive types
+		staticType, ok := ImportType(t).(interpreter.PrimitiveStaticType)
+		if !ok {
+			return nil, fmt.Errorf("cannot import type of type %T", t)
+		}
+		return staticType.SemaType(), nil
+	}
+}
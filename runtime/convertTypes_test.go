@@ -19,13 +19,16 @@
 package runtime
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -70,3 +73,163 @@ func TestExportRecursiveType(t *testing.T) {
 		ExportType(ty, map[sema.TypeID]cadence.Type{}),
 	)
 }
+
+func TestImportSemaType(t *testing.T) {
+
+	t.Parallel()
+
+	compositeType := &sema.CompositeType{
+		Location:   utils.TestLocation,
+		Identifier: "S",
+		Kind:       common.CompositeKindStructure,
+		Members:    sema.NewStringMemberOrderedMap(),
+	}
+
+	interfaceType := &sema.InterfaceType{
+		Location:      utils.TestLocation,
+		Identifier:    "I",
+		CompositeKind: common.CompositeKindStructure,
+		Members:       sema.NewStringMemberOrderedMap(),
+	}
+
+	getInterface := func(location common.Location, qualifiedIdentifier string) (*sema.InterfaceType, error) {
+		require.Equal(t, utils.TestLocation, location)
+		require.Equal(t, "I", qualifiedIdentifier)
+		return interfaceType, nil
+	}
+
+	getComposite := func(location common.Location, qualifiedIdentifier string, typeID common.TypeID) (*sema.CompositeType, error) {
+		if location == nil {
+			return sema.NativeCompositeTypes[qualifiedIdentifier], nil
+		}
+		require.Equal(t, utils.TestLocation, location)
+		require.Equal(t, "S", qualifiedIdentifier)
+		require.Equal(t, compositeType.ID(), typeID)
+		return compositeType, nil
+	}
+
+	test := func(t *testing.T, ty sema.Type) {
+		exportedType := ExportType(ty, map[sema.TypeID]cadence.Type{})
+
+		importedType, err := ImportSemaType(exportedType, getInterface, getComposite)
+		require.NoError(t, err)
+
+		assert.True(t,
+			importedType.Equal(ty),
+			"expected %s, got %s",
+			ty.QualifiedString(),
+			importedType.QualifiedString(),
+		)
+	}
+
+	t.Run("primitive", func(t *testing.T) {
+
+		t.Parallel()
+
+		for ty := interpreter.PrimitiveStaticTypeUnknown + 1; ty < interpreter.PrimitiveStaticType_Count; ty++ {
+			if strings.HasPrefix(ty.String(), "PrimitiveStaticType(") {
+				continue
+			}
+
+			semaType := ty.SemaType()
+
+			t.Run(ty.String(), func(t *testing.T) {
+				test(t, semaType)
+			})
+		}
+	})
+
+	for name, ty := range map[string]sema.Type{
+		"optional": &sema.OptionalType{
+			Type: sema.IntType,
+		},
+		"variable-sized array": &sema.VariableSizedType{
+			Type: compositeType,
+		},
+		"constant-sized array": &sema.ConstantSizedType{
+			Type: sema.UInt8Type,
+			Size: 32,
+		},
+		"dictionary": &sema.DictionaryType{
+			KeyType:   sema.StringType,
+			ValueType: compositeType,
+		},
+		"reference": &sema.ReferenceType{
+			Authorized: true,
+			Type:       compositeType,
+		},
+		"restricted": &sema.RestrictedType{
+			Type:         compositeType,
+			Restrictions: []*sema.InterfaceType{interfaceType},
+		},
+		"capability": &sema.CapabilityType{
+			BorrowType: &sema.ReferenceType{
+				Type: interfaceType,
+			},
+		},
+		"function": &sema.FunctionType{
+			Parameters: []*sema.Parameter{
+				{
+					Label:          sema.ArgumentLabelNotRequired,
+					Identifier:     "s",
+					TypeAnnotation: sema.NewTypeAnnotation(compositeType),
+				},
+			},
+			ReturnTypeAnnotation: sema.NewTypeAnnotation(
+				&sema.OptionalType{
+					Type: sema.StringType,
+				},
+			),
+		},
+	} {
+		ty := ty
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			test(t, ty)
+		})
+	}
+
+	t.Run("bytes", func(t *testing.T) {
+
+		t.Parallel()
+
+		importedType, err := ImportSemaType(cadence.BytesType{}, getInterface, getComposite)
+		require.NoError(t, err)
+		assert.Equal(t, sema.BytesType, importedType)
+	})
+
+	t.Run("entitled reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ImportSemaType(
+			cadence.ReferenceType{
+				Authorization: &cadence.EntitlementSetAuthorization{
+					Entitlements: []common.TypeID{"S.test.E"},
+				},
+				Type: cadence.IntType{},
+			},
+			getInterface,
+			getComposite,
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid restriction", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ImportSemaType(
+			cadence.RestrictedType{
+				Type: cadence.AnyStructType{},
+				Restrictions: []cadence.Type{
+					cadence.IntType{},
+				},
+			},
+			getInterface,
+			getComposite,
+		)
+		require.Error(t, err)
+	})
+}
@@ -68,6 +68,8 @@ func exportValueWithInterpreter(
 		return cadence.NewBool(bool(v)), nil
 	case *interpreter.StringValue:
 		return cadence.NewString(v.Str)
+	case *interpreter.BytesValue:
+		return cadence.NewBytes(v.Bytes), nil
 	case *interpreter.ArrayValue:
 		return exportArrayValue(v, inter, seenReferences)
 	case interpreter.IntValue:
@@ -249,6 +251,8 @@ func exportCompositeValue(
 		return cadence.NewContract(fields).WithType(t.(*cadence.ContractType)), nil
 	case common.CompositeKindEnum:
 		return cadence.NewEnum(fields).WithType(t.(*cadence.EnumType)), nil
+	case common.CompositeKindAttachment:
+		return cadence.NewAttachment(fields).WithType(t.(*cadence.AttachmentType)), nil
 	}
 
 	return nil, fmt.Errorf(
@@ -261,6 +265,7 @@ func exportCompositeValue(
 				common.CompositeKindEvent.Name(),
 				common.CompositeKindContract.Name(),
 				common.CompositeKindEnum.Name(),
+				common.CompositeKindAttachment.Name(),
 			},
 			"or",
 		),
@@ -323,6 +328,8 @@ func exportSimpleCompositeValue(
 		return cadence.NewContract(fields).WithType(t.(*cadence.ContractType)), nil
 	case common.CompositeKindEnum:
 		return cadence.NewEnum(fields).WithType(t.(*cadence.EnumType)), nil
+	case common.CompositeKindAttachment:
+		return cadence.NewAttachment(fields).WithType(t.(*cadence.AttachmentType)), nil
 	}
 
 	return nil, fmt.Errorf(
@@ -335,6 +342,7 @@ func exportSimpleCompositeValue(
 				common.CompositeKindEvent.Name(),
 				common.CompositeKindContract.Name(),
 				common.CompositeKindEnum.Name(),
+				common.CompositeKindAttachment.Name(),
 			},
 			"or",
 		),
@@ -413,10 +421,17 @@ func exportCapabilityValue(v *interpreter.CapabilityValue, inter *interpreter.In
 		borrowType = inter.MustConvertStaticToSemaType(v.BorrowType)
 	}
 
+	// ID capabilities are not path-based
+	var path cadence.Path
+	if v.ID == 0 {
+		path = exportPathValue(v.Path)
+	}
+
 	return cadence.Capability{
-		Path:       exportPathValue(v.Path),
+		Path:       path,
 		Address:    cadence.NewAddress(v.Address),
 		BorrowType: ExportType(borrowType, map[sema.TypeID]cadence.Type{}),
+		ID:         cadence.UInt64(v.ID),
 	}
 }
 
@@ -436,6 +451,16 @@ func exportEvent(event exportableEvent, seenReferences seenReferences) (cadence.
 	return cadence.NewEvent(fields).WithType(eventType), nil
 }
 
+// ImportValue converts a Cadence value to a runtime value.
+//
+// Composite values can be imported even if their type is not declared by any program,
+// if the type is provided by the interpreter's composite type handler,
+// see interpreter.WithCompositeTypeHandler.
+//
+func ImportValue(inter *interpreter.Interpreter, value cadence.Value, expectedType sema.Type) (interpreter.Value, error) {
+	return importValue(inter, value, expectedType)
+}
+
 // importValue converts a Cadence value to a runtime value.
 func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedType sema.Type) (interpreter.Value, error) {
 	switch v := value.(type) {
@@ -448,6 +473,11 @@ func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedTy
 	case cadence.String:
 		return interpreter.NewStringValue(string(v)), nil
 	case cadence.Bytes:
+		// Bytes are imported as a byte array ([UInt8]),
+		// unless the Bytes type is expected
+		if expectedType == sema.BytesType {
+			return interpreter.NewBytesValue(v), nil
+		}
 		return interpreter.ByteSliceToByteArrayValue(inter, v), nil
 	case cadence.Address:
 		return interpreter.NewAddressValue(common.Address(v)), nil
@@ -544,6 +574,7 @@ func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedTy
 			v.Path,
 			v.Address,
 			v.BorrowType,
+			v.ID,
 		)
 	}
 
@@ -584,6 +615,7 @@ func importCapability(
 	path cadence.Path,
 	address cadence.Address,
 	borrowType cadence.Type,
+	id cadence.UInt64,
 ) (
 	*interpreter.CapabilityValue,
 	error,
@@ -602,6 +634,7 @@ func importCapability(
 		Path:       importPathValue(path),
 		Address:    interpreter.NewAddressValueFromBytes(address.Bytes()),
 		BorrowType: ImportType(borrowType),
+		ID:         interpreter.UInt64Value(id),
 	}, nil
 
 }
@@ -156,6 +156,11 @@ func TestExportValue(t *testing.T) {
 			value:    interpreter.NewStringValue("foo"),
 			expected: cadence.String("foo"),
 		},
+		{
+			label:    "Bytes",
+			value:    interpreter.NewBytesValue([]byte{1, 2, 3}),
+			expected: cadence.NewBytes([]byte{1, 2, 3}),
+		},
 		{
 			label: "Array empty",
 			valueFactory: func(inter *interpreter.Interpreter) interpreter.Value {
@@ -517,6 +522,12 @@ func TestImportValue(t *testing.T) {
 			expected: interpreter.BoolValue(false),
 			value:    cadence.NewBool(false),
 		},
+		{
+			label:        "Bytes",
+			value:        cadence.NewBytes([]byte{1, 2, 3}),
+			expected:     interpreter.NewBytesValue([]byte{1, 2, 3}),
+			expectedType: sema.BytesType,
+		},
 		{
 			label:    "String empty",
 			value:    cadence.String(""),
@@ -810,6 +821,11 @@ func TestImportRuntimeType(t *testing.T) {
 			actual:   cadence.StringType{},
 			expected: interpreter.PrimitiveStaticTypeString,
 		},
+		{
+			label:    "Bytes",
+			actual:   cadence.BytesType{},
+			expected: interpreter.PrimitiveStaticTypeBytes,
+		},
 		{
 			label:    "Character",
 			actual:   cadence.CharacterType{},
@@ -1066,6 +1082,23 @@ func TestImportRuntimeType(t *testing.T) {
 				Type:       interpreter.PrimitiveStaticTypeInt,
 			},
 		},
+		{
+			label: "Entitled reference",
+			actual: cadence.ReferenceType{
+				Authorization: &cadence.EntitlementSetAuthorization{
+					Entitlements: []common.TypeID{"S.test.E"},
+					Kind:         cadence.Disjunction,
+				},
+				Type: cadence.IntType{},
+			},
+			expected: interpreter.ReferenceStaticType{
+				Authorization: &interpreter.EntitlementSetAuthorization{
+					Entitlements: []common.TypeID{"S.test.E"},
+					SetKind:      sema.EntitlementSetKindDisjunction,
+				},
+				Type: interpreter.PrimitiveStaticTypeInt,
+			},
+		},
 		{
 			label: "Capability",
 			actual: cadence.CapabilityType{
@@ -1081,10 +1114,7 @@ func TestImportRuntimeType(t *testing.T) {
 				Location:            TestLocation,
 				QualifiedIdentifier: "S",
 			},
-			expected: interpreter.CompositeStaticType{
-				Location:            TestLocation,
-				QualifiedIdentifier: "S",
-			},
+			expected: interpreter.NewCompositeStaticType(TestLocation, "S"),
 		},
 		{
 			label: "Resource",
@@ -1092,10 +1122,7 @@ func TestImportRuntimeType(t *testing.T) {
 				Location:            TestLocation,
 				QualifiedIdentifier: "S",
 			},
-			expected: interpreter.CompositeStaticType{
-				Location:            TestLocation,
-				QualifiedIdentifier: "S",
-			},
+			expected: interpreter.NewCompositeStaticType(TestLocation, "S"),
 		},
 		{
 			label: "Contract",
@@ -1103,10 +1130,7 @@ func TestImportRuntimeType(t *testing.T) {
 				Location:            TestLocation,
 				QualifiedIdentifier: "S",
 			},
-			expected: interpreter.CompositeStaticType{
-				Location:            TestLocation,
-				QualifiedIdentifier: "S",
-			},
+			expected: interpreter.NewCompositeStaticType(TestLocation, "S"),
 		},
 		{
 			label: "Event",
@@ -1114,10 +1138,7 @@ func TestImportRuntimeType(t *testing.T) {
 				Location:            TestLocation,
 				QualifiedIdentifier: "S",
 			},
-			expected: interpreter.CompositeStaticType{
-				Location:            TestLocation,
-				QualifiedIdentifier: "S",
-			},
+			expected: interpreter.NewCompositeStaticType(TestLocation, "S"),
 		},
 		{
 			label: "Enum",
@@ -1125,10 +1146,7 @@ func TestImportRuntimeType(t *testing.T) {
 				Location:            TestLocation,
 				QualifiedIdentifier: "S",
 			},
-			expected: interpreter.CompositeStaticType{
-				Location:            TestLocation,
-				QualifiedIdentifier: "S",
-			},
+			expected: interpreter.NewCompositeStaticType(TestLocation, "S"),
 		},
 		{
 			label: "StructInterface",
@@ -1177,10 +1195,7 @@ func TestImportRuntimeType(t *testing.T) {
 					}},
 			},
 			expected: &interpreter.RestrictedStaticType{
-				Type: interpreter.CompositeStaticType{
-					Location:            TestLocation,
-					QualifiedIdentifier: "S",
-				},
+				Type: interpreter.NewCompositeStaticType(TestLocation, "S"),
 				Restrictions: []interpreter.InterfaceStaticType{
 					{
 						Location:            TestLocation,
@@ -1305,6 +1320,52 @@ func TestExportStructValue(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestExportAttachmentValue(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+        pub struct Foo {
+            pub let bar: Int
+
+            init(bar: Int) {
+                self.bar = bar
+            }
+        }
+
+        pub attachment A for Foo {
+            pub let baz: Int
+
+            init(baz: Int) {
+                self.baz = baz
+            }
+        }
+
+        pub fun main(): &A? {
+            let foo = attach A(baz: 1) to Foo(bar: 42)
+            return foo[A]
+        }
+    `
+
+	actual := exportValueFromScript(t, script)
+	expected := cadence.NewOptional(
+		cadence.NewAttachment([]cadence.Value{cadence.NewInt(1)}).
+			WithType(&cadence.AttachmentType{
+				Location:            TestLocation,
+				QualifiedIdentifier: "A",
+				BaseType:            fooStructType,
+				Fields: []cadence.Field{
+					{
+						Identifier: "baz",
+						Type:       cadence.IntType{},
+					},
+				},
+			}),
+	)
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestExportResourceValue(t *testing.T) {
 
 	t.Parallel()
@@ -2155,6 +2216,11 @@ func TestRuntimeArgumentPassing(t *testing.T) {
 			typeSignature: "String",
 			exportedValue: cadence.String("foo"),
 		},
+		{
+			label:         "Bytes",
+			typeSignature: "Bytes",
+			exportedValue: cadence.NewBytes([]byte{1, 2, 3}),
+		},
 		{
 			label:         "Array empty",
 			typeSignature: "[String]",
@@ -3007,6 +3073,44 @@ func TestRuntimeImportExportArrayValue(t *testing.T) {
 		)
 	})
 
+	t.Run("import constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := cadence.NewArray([]cadence.Value{
+			cadence.NewUInt8(1),
+			cadence.NewUInt8(2),
+		})
+
+		inter := newTestInterpreter(t)
+
+		actual, err := importValue(
+			inter,
+			value,
+			&sema.ConstantSizedType{
+				Type: sema.UInt8Type,
+				Size: 2,
+			},
+		)
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.ConstantSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt8,
+					Size: 2,
+				},
+				common.Address{},
+				interpreter.UInt8Value(1),
+				interpreter.UInt8Value(2),
+			),
+			actual,
+		)
+	})
+
 	t.Run("import nested array with broader expected type", func(t *testing.T) {
 
 		t.Parallel()
@@ -4704,3 +4808,101 @@ func TestNestedStructArgPassing(t *testing.T) {
 		require.ErrorAs(t, err, &argErr)
 	})
 }
+
+func TestHostDefinedStructArgPassing(t *testing.T) {
+	t.Parallel()
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Display",
+	}
+
+	displayType := &sema.CompositeType{
+		Location:   location,
+		Identifier: "Display",
+		Kind:       common.CompositeKindStructure,
+		Members:    sema.NewStringMemberOrderedMap(),
+		Fields:     []string{"name"},
+	}
+	displayType.Members.Set(
+		"name",
+		sema.NewPublicConstantFieldMember(
+			displayType,
+			"name",
+			sema.StringType,
+			"",
+		),
+	)
+
+	script := `
+        pub fun main(v: AnyStruct): AnyStruct {
+            return v
+        }
+    `
+
+	argument := cadence.NewStruct([]cadence.Value{
+		cadence.String("foo"),
+	}).WithType(&cadence.StructType{
+		Location:            location,
+		QualifiedIdentifier: "Display",
+		Fields: []cadence.Field{
+			{
+				Identifier: "name",
+				Type:       cadence.StringType{},
+			},
+		},
+	})
+
+	execute := func(rt Runtime) (cadence.Value, error) {
+		storage := newTestLedger(nil, nil)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: storage,
+			decodeArgument: func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+				return json.Decode(b)
+			},
+			getAccountContractCode: func(_ common.Address, _ string) ([]byte, error) {
+				return nil, nil
+			},
+		}
+
+		return rt.ExecuteScript(
+			Script{
+				Source: []byte(script),
+				Arguments: [][]byte{
+					json.MustEncode(argument),
+				},
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  TestLocation,
+			},
+		)
+	}
+
+	t.Run("provided type", func(t *testing.T) {
+
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime(
+			WithCompositeTypeProvider(func(_ common.Location, typeID common.TypeID) *sema.CompositeType {
+				if typeID == displayType.ID() {
+					return displayType
+				}
+				return nil
+			}),
+		)
+
+		value, err := execute(rt)
+		require.NoError(t, err)
+		assert.Equal(t, argument, value)
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(newTestInterpreterRuntime())
+		require.Error(t, err)
+	})
+}
@@ -18,18 +18,54 @@
 
 package runtime
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
 
 // LocationCoverage records coverage information for a location
 //
 type LocationCoverage struct {
+	// LineHits is the number of times the statements at each line were executed.
+	// Lines of statements which were never executed have zero hits,
+	// if the program was inspected, see CoverageReport.InspectProgram
 	LineHits map[int]int `json:"line_hits"`
+	// Statements is the number of statements of the program
+	Statements int `json:"statements"`
 }
 
 func (c *LocationCoverage) AddLineHit(line int) {
 	c.LineHits[line]++
 }
 
+// CoveredLines returns the number of lines which were executed
+//
+func (c *LocationCoverage) CoveredLines() int {
+	coveredLines := 0
+	for _, hits := range c.LineHits { //nolint:maprangecheck
+		if hits > 0 {
+			coveredLines++
+		}
+	}
+	return coveredLines
+}
+
+// Percentage returns the percentage of lines which were executed, e.g. "75.0%"
+//
+func (c *LocationCoverage) Percentage() string {
+	if len(c.LineHits) == 0 {
+		return "100.0%"
+	}
+	return fmt.Sprintf(
+		"%0.1f%%",
+		100*float64(c.CoveredLines())/float64(len(c.LineHits)),
+	)
+}
+
 func NewLocationCoverage() *LocationCoverage {
 	return &LocationCoverage{
 		LineHits: map[int]int{},
@@ -40,20 +76,117 @@ func NewLocationCoverage() *LocationCoverage {
 //
 type CoverageReport struct {
 	Coverage map[common.LocationID]*LocationCoverage `json:"coverage"`
+	// inspected records the locations of the programs which were inspected
+	inspected map[common.LocationID]struct{}
 }
 
-func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+func (r *CoverageReport) locationCoverage(location common.Location) *LocationCoverage {
 	locationID := location.ID()
 	locationCoverage := r.Coverage[locationID]
 	if locationCoverage == nil {
 		locationCoverage = NewLocationCoverage()
 		r.Coverage[locationID] = locationCoverage
 	}
-	locationCoverage.AddLineHit(line)
+	return locationCoverage
+}
+
+func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+	r.locationCoverage(location).AddLineHit(line)
+}
+
+// InspectProgram records the statements of the given program,
+// so that the lines of statements which are never executed are reported.
+//
+// The runtime inspects all programs it parses or loads.
+// Each location is only inspected once.
+//
+func (r *CoverageReport) InspectProgram(location common.Location, program *ast.Program) {
+	locationID := location.ID()
+	if _, ok := r.inspected[locationID]; ok {
+		return
+	}
+	if r.inspected == nil {
+		r.inspected = map[common.LocationID]struct{}{}
+	}
+	r.inspected[locationID] = struct{}{}
+
+	locationCoverage := r.locationCoverage(location)
+
+	addStatements := func(statements []ast.Statement) {
+		for _, statement := range statements {
+			line := statement.StartPosition().Line
+			if _, ok := locationCoverage.LineHits[line]; !ok {
+				locationCoverage.LineHits[line] = 0
+			}
+			locationCoverage.Statements++
+		}
+	}
+
+	ast.Inspect(program, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.Block:
+			addStatements(element.Statements)
+
+		case *ast.SwitchStatement:
+			for _, switchCase := range element.Cases {
+				addStatements(switchCase.Statements)
+			}
+		}
+		return true
+	})
+}
+
+// WriteLCOV writes the report in the LCOV tracefile format,
+// e.g. for tools like `genhtml` or code coverage services.
+//
+// The source file of each record is the location ID.
+// Records are ordered by location ID, and lines are ordered by line number.
+//
+func (r *CoverageReport) WriteLCOV(w io.Writer) error {
+	locationIDs := make([]string, 0, len(r.Coverage))
+	for locationID := range r.Coverage { //nolint:maprangecheck
+		locationIDs = append(locationIDs, string(locationID))
+	}
+	sort.Strings(locationIDs)
+
+	for _, locationID := range locationIDs {
+		locationCoverage := r.Coverage[common.LocationID(locationID)]
+
+		lines := make([]int, 0, len(locationCoverage.LineHits))
+		for line := range locationCoverage.LineHits { //nolint:maprangecheck
+			lines = append(lines, line)
+		}
+		sort.Ints(lines)
+
+		_, err := fmt.Fprintf(w, "TN:\nSF:%s\n", locationID)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			_, err = fmt.Fprintf(w, "DA:%d,%d\n", line, locationCoverage.LineHits[line])
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(
+			w,
+			"LF:%d\nLH:%d\nend_of_record\n",
+			len(lines),
+			locationCoverage.CoveredLines(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func NewCoverageReport() *CoverageReport {
 	return &CoverageReport{
-		Coverage: map[common.LocationID]*LocationCoverage{},
+		Coverage:  map[common.LocationID]*LocationCoverage{},
+		inspected: map[common.LocationID]struct{}{},
 	}
 }
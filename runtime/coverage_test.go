@@ -21,6 +21,7 @@ package runtime
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -101,18 +102,52 @@ func TestRuntimeCoverage(t *testing.T) {
                 "4": 1,
                 "5": 42,
                 "7": 1
-              }
+              },
+              "statements": 4
             },
             "t.00": {
               "line_hits": {
                 "5": 1,
                 "6": 1,
+                "7": 0,
                 "9": 1
-              }
+              },
+              "statements": 4
             }
           }
         }
         `,
 		string(actual),
 	)
+
+	locationCoverage := coverageReport.Coverage["t.00"]
+	assert.Equal(t, 3, locationCoverage.CoveredLines())
+	assert.Equal(t, "75.0%", locationCoverage.Percentage())
+
+	var lcov strings.Builder
+	err = coverageReport.WriteLCOV(&lcov)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`TN:
+SF:S.imported
+DA:3,1
+DA:4,1
+DA:5,42
+DA:7,1
+LF:4
+LH:4
+end_of_record
+TN:
+SF:t.00
+DA:5,1
+DA:6,1
+DA:7,0
+DA:9,1
+LF:4
+LH:3
+end_of_record
+`,
+		lcov.String(),
+	)
 }
@@ -28,6 +28,8 @@ import (
 	"github.com/onflow/cadence/encoding/json"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -112,6 +114,482 @@ func TestRuntimeCrypto_verify(t *testing.T) {
 	assert.True(t, called)
 }
 
+func TestRuntimeCrypto_verifyKeyList(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	test := func(t *testing.T, signatureSet string, expected bool) {
+
+		script := []byte(fmt.Sprintf(
+			`
+              import Crypto
+
+              pub fun main(): Bool {
+                  let keyList = Crypto.KeyList()
+
+                  for key in ["01", "02", "03"] {
+                      keyList.add(
+                          PublicKey(
+                              publicKey: key.decodeHex(),
+                              signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                          ),
+                          hashAlgorithm: HashAlgorithm.SHA3_256,
+                          weight: 0.5
+                      )
+                  }
+
+                  keyList.revoke(keyIndex: 2)
+
+                  return keyList.verify(
+                      signatureSet: %s,
+                      signedData: "0506".decodeHex()
+                  )
+              }
+            `,
+			signatureSet,
+		))
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			verifySignature: func(
+				signature []byte,
+				_ string,
+				_ []byte,
+				publicKey []byte,
+				_ SignatureAlgorithm,
+				_ HashAlgorithm,
+			) (bool, error) {
+				// A signature is valid if it is equal to the public key
+				return assert.ObjectsAreEqual(publicKey, signature), nil
+			},
+		}
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewBool(expected),
+			result,
+		)
+	}
+
+	t.Run("sufficient weight", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 1, signature: "02".decodeHex()),
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex())
+            ]`,
+			true,
+		)
+	})
+
+	t.Run("insufficient weight", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex())
+            ]`,
+			false,
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `[]`, false)
+	})
+
+	t.Run("duplicate signature", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex()),
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex())
+            ]`,
+			false,
+		)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex()),
+                Crypto.KeyListSignature(keyIndex: 1, signature: "01".decodeHex())
+            ]`,
+			false,
+		)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex()),
+                Crypto.KeyListSignature(keyIndex: 2, signature: "03".decodeHex())
+            ]`,
+			false,
+		)
+	})
+
+	t.Run("key index out of bounds", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex()),
+                Crypto.KeyListSignature(keyIndex: 3, signature: "04".decodeHex())
+            ]`,
+			false,
+		)
+	})
+
+	t.Run("negative key index", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`[
+                Crypto.KeyListSignature(keyIndex: 0, signature: "01".decodeHex()),
+                Crypto.KeyListSignature(keyIndex: -1, signature: "01".decodeHex())
+            ]`,
+			false,
+		)
+	})
+}
+
+type testSignatureSchemeRuntimeInterface struct {
+	*testRuntimeInterface
+	isSignatureSchemeSupported func(scheme string) (bool, error)
+	verifySignatureWithScheme  func(
+		scheme string,
+		signature []byte,
+		tag string,
+		signedData []byte,
+		publicKey []byte,
+	) (bool, error)
+}
+
+var _ SignatureSchemeInterface = &testSignatureSchemeRuntimeInterface{}
+
+func (i *testSignatureSchemeRuntimeInterface) IsSignatureSchemeSupported(scheme string) (bool, error) {
+	return i.isSignatureSchemeSupported(scheme)
+}
+
+func (i *testSignatureSchemeRuntimeInterface) VerifySignatureWithScheme(
+	scheme string,
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+) (bool, error) {
+	return i.verifySignatureWithScheme(scheme, signature, tag, signedData, publicKey)
+}
+
+func TestRuntimeCrypto_verifySignatureWithScheme(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	executeScript := func(code string, runtimeInterface Interface) (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+	}
+
+	const script = `
+      import Crypto
+
+      pub fun main(): [Bool] {
+          let supported = Crypto.isSignatureSchemeSupported("Dilithium3")
+          let valid = Crypto.verifySignatureWithScheme(
+              "Dilithium3",
+              publicKey: "0102".decodeHex(),
+              signature: "0304".decodeHex(),
+              signedData: "0506".decodeHex(),
+              domainSeparationTag: "FLOW-V0.0-user"
+          )
+          return [supported, valid]
+      }
+    `
+
+	t.Run("supported", func(t *testing.T) {
+
+		t.Parallel()
+
+		called := false
+
+		runtimeInterface := &testSignatureSchemeRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+			},
+			isSignatureSchemeSupported: func(scheme string) (bool, error) {
+				return scheme == "Dilithium3", nil
+			},
+			verifySignatureWithScheme: func(
+				scheme string,
+				signature []byte,
+				tag string,
+				signedData []byte,
+				publicKey []byte,
+			) (bool, error) {
+				called = true
+				assert.Equal(t, "Dilithium3", scheme)
+				assert.Equal(t, []byte{3, 4}, signature)
+				assert.Equal(t, "FLOW-V0.0-user", tag)
+				assert.Equal(t, []byte{5, 6}, signedData)
+				assert.Equal(t, []byte{1, 2}, publicKey)
+				return true, nil
+			},
+		}
+
+		result, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewBool(true),
+				cadence.NewBool(true),
+			}),
+			result,
+		)
+		assert.True(t, called)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testSignatureSchemeRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+			},
+			isSignatureSchemeSupported: func(_ string) (bool, error) {
+				return false, nil
+			},
+			verifySignatureWithScheme: func(_ string, _ []byte, _ string, _ []byte, _ []byte) (bool, error) {
+				assert.FailNow(t, "unexpected call of VerifySignatureWithScheme")
+				return false, nil
+			},
+		}
+
+		_, err := executeScript(script, runtimeInterface)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.UnsupportedSignatureSchemeError{})
+	})
+
+	t.Run("not implemented", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		result, err := executeScript(
+			`
+              import Crypto
+
+              pub fun main(): Bool {
+                  return Crypto.isSignatureSchemeSupported("Dilithium3")
+              }
+            `,
+			runtimeInterface,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewBool(false), result)
+
+		_, err = executeScript(script, runtimeInterface)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.UnsupportedSignatureSchemeError{})
+	})
+}
+
+func TestRuntimeVerifyAccountProof(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	const script = `
+      pub fun main(keyIndices: [Int], signatures: [[UInt8]]): Bool {
+          return verifyAccountProof(
+              address: 0x1,
+              message: "0102".decodeHex(),
+              keyIndices: keyIndices,
+              signatures: signatures
+          )
+      }
+    `
+
+	// Keys 0 and 1 have half weight, key 2 has full weight but is revoked.
+	// The signature for a key is valid if it consists of the key index.
+
+	accountKeys := []*AccountKey{
+		{KeyIndex: 0, Weight: 500, PublicKey: &PublicKey{PublicKey: []byte{0}}},
+		{KeyIndex: 1, Weight: 500, PublicKey: &PublicKey{PublicKey: []byte{1}}},
+		{KeyIndex: 2, Weight: 1000, IsRevoked: true, PublicKey: &PublicKey{PublicKey: []byte{2}}},
+	}
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getAccountKey: func(address Address, index int) (*AccountKey, error) {
+				assert.Equal(t, Address{0, 0, 0, 0, 0, 0, 0, 0x1}, address)
+				if index >= len(accountKeys) {
+					return nil, nil
+				}
+				return accountKeys[index], nil
+			},
+			verifySignature: func(
+				signature []byte,
+				tag string,
+				signedData []byte,
+				publicKey []byte,
+				_ SignatureAlgorithm,
+				_ HashAlgorithm,
+			) (bool, error) {
+				assert.Equal(t, AccountProofDomainSeparationTag, tag)
+				assert.Equal(t, []byte{1, 2}, signedData)
+				return string(signature) == string(publicKey), nil
+			},
+			decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+				return json.Decode(b)
+			},
+		}
+	}
+
+	type testCase struct {
+		name       string
+		keyIndices []int
+		signatures [][]byte
+		expected   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:       "sufficient weight",
+			keyIndices: []int{0, 1},
+			signatures: [][]byte{{0}, {1}},
+			expected:   true,
+		},
+		{
+			name:       "insufficient weight",
+			keyIndices: []int{0},
+			signatures: [][]byte{{0}},
+			expected:   false,
+		},
+		{
+			name:       "invalid signature",
+			keyIndices: []int{0, 1},
+			signatures: [][]byte{{0}, {0}},
+			expected:   false,
+		},
+		{
+			name:       "revoked key",
+			keyIndices: []int{2},
+			signatures: [][]byte{{2}},
+			expected:   false,
+		},
+		{
+			name:       "duplicate key index",
+			keyIndices: []int{0, 0},
+			signatures: [][]byte{{0}, {0}},
+			expected:   false,
+		},
+		{
+			name:       "missing key",
+			keyIndices: []int{0, 1, 3},
+			signatures: [][]byte{{0}, {1}, {3}},
+			expected:   false,
+		},
+		{
+			name:       "negative key index",
+			keyIndices: []int{-1, 1},
+			signatures: [][]byte{{0}, {1}},
+			expected:   false,
+		},
+		{
+			name:       "mismatched lengths",
+			keyIndices: []int{0, 1},
+			signatures: [][]byte{{0}},
+			expected:   false,
+		},
+		{
+			name:       "empty",
+			keyIndices: []int{},
+			signatures: [][]byte{},
+			expected:   false,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			keyIndices := make([]cadence.Value, len(testCase.keyIndices))
+			for i, keyIndex := range testCase.keyIndices {
+				keyIndices[i] = cadence.NewInt(keyIndex)
+			}
+
+			signatures := make([]cadence.Value, len(testCase.signatures))
+			for i, signature := range testCase.signatures {
+				bytes := make([]cadence.Value, len(signature))
+				for j, b := range signature {
+					bytes[j] = cadence.NewUInt8(b)
+				}
+				signatures[i] = cadence.NewArray(bytes)
+			}
+
+			result, err := runtime.ExecuteScript(
+				Script{
+					Source: []byte(script),
+					Arguments: encodeArgs([]cadence.Value{
+						cadence.NewArray(keyIndices),
+						cadence.NewArray(signatures),
+					}),
+				},
+				Context{
+					Interface: newRuntimeInterface(),
+					Location:  utils.TestLocation,
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, cadence.NewBool(testCase.expected), result)
+		})
+	}
+}
+
 func TestRuntimeHashAlgorithm_hash(t *testing.T) {
 
 	t.Parallel()
@@ -233,6 +711,245 @@ func TestRuntimeHashAlgorithm_hash(t *testing.T) {
 	})
 }
 
+type testHasher struct {
+	update   func(data []byte) error
+	finalize func() ([]byte, error)
+}
+
+var _ Hasher = testHasher{}
+
+func (h testHasher) Update(data []byte) error {
+	return h.update(data)
+}
+
+func (h testHasher) Finalize() ([]byte, error) {
+	return h.finalize()
+}
+
+type testIncrementalHashRuntimeInterface struct {
+	*testRuntimeInterface
+	newHasher func(hashAlgorithm HashAlgorithm) (Hasher, error)
+}
+
+var _ IncrementalHashInterface = &testIncrementalHashRuntimeInterface{}
+
+func (i *testIncrementalHashRuntimeInterface) NewHasher(hashAlgorithm HashAlgorithm) (Hasher, error) {
+	return i.newHasher(hashAlgorithm)
+}
+
+func TestRuntimeHashAlgorithm_newHasher(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	executeScript := func(code string, inter Interface) (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: inter,
+				Location:  utils.TestLocation,
+			},
+		)
+	}
+
+	const script = `
+      pub fun main(): [UInt8] {
+          let hasher = HashAlgorithm.SHA3_256.newHasher()
+          hasher.update([1, 2])
+          hasher.update([])
+          hasher.update([3, 4])
+          return hasher.finalize()
+      }
+    `
+
+	expected := cadence.NewArray([]cadence.Value{
+		cadence.NewUInt8(5),
+		cadence.NewUInt8(6),
+	})
+
+	t.Run("buffered", func(t *testing.T) {
+
+		t.Parallel()
+
+		var hashedData [][]byte
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			hash: func(
+				data []byte,
+				tag string,
+				hashAlgorithm HashAlgorithm,
+			) ([]byte, error) {
+				hashedData = append(hashedData, data)
+				assert.Empty(t, tag)
+				assert.Equal(t, HashAlgorithmSHA3_256, hashAlgorithm)
+				return []byte{5, 6}, nil
+			},
+		}
+
+		result, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+		assert.Equal(t, [][]byte{{1, 2, 3, 4}}, hashedData)
+	})
+
+	t.Run("incremental", func(t *testing.T) {
+
+		t.Parallel()
+
+		var updates [][]byte
+		finalized := false
+
+		runtimeInterface := &testIncrementalHashRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				hash: func(_ []byte, _ string, _ HashAlgorithm) ([]byte, error) {
+					assert.FailNow(t, "unexpected call of Hash")
+					return nil, nil
+				},
+			},
+			newHasher: func(hashAlgorithm HashAlgorithm) (Hasher, error) {
+				assert.Equal(t, HashAlgorithmSHA3_256, hashAlgorithm)
+				return testHasher{
+					update: func(data []byte) error {
+						updates = append(updates, data)
+						return nil
+					},
+					finalize: func() ([]byte, error) {
+						finalized = true
+						return []byte{5, 6}, nil
+					},
+				}, nil
+			},
+		}
+
+		result, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+		assert.Equal(t, [][]byte{{1, 2}, {}, {3, 4}}, updates)
+		assert.True(t, finalized)
+	})
+
+	t.Run("buffered, memory metered", func(t *testing.T) {
+
+		t.Parallel()
+
+		var bufferedMemory uint64
+
+		runtimeInterface := &testMemoryRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				hash: func(_ []byte, _ string, _ HashAlgorithm) ([]byte, error) {
+					return []byte{5, 6}, nil
+				},
+			},
+			meterMemory: func(usage common.MemoryUsage) error {
+				if usage.Kind == common.MemoryKindHasherBuffer {
+					bufferedMemory += usage.Amount
+				}
+				return nil
+			},
+		}
+
+		result, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+		assert.Equal(t, uint64(4), bufferedMemory)
+	})
+
+	t.Run("buffered, memory limit exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testMemoryRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				hash: func(_ []byte, _ string, _ HashAlgorithm) ([]byte, error) {
+					assert.FailNow(t, "unexpected call of Hash")
+					return nil, nil
+				},
+			},
+			memoryLimit: 100_000,
+			meterMemory: func(_ common.MemoryUsage) error {
+				return nil
+			},
+		}
+
+		_, err := executeScript(
+			`
+              pub fun main() {
+                  let data = "00".decodeHex()
+                  let hasher = HashAlgorithm.SHA3_256.newHasher()
+                  while true {
+                      hasher.update(data)
+                  }
+              }
+            `,
+			runtimeInterface,
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &MemoryLimitExceededError{})
+	})
+
+	t.Run("computation metered", func(t *testing.T) {
+
+		t.Parallel()
+
+		var hashedBytes uint
+
+		runtimeInterface := &testComputationMeterRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				hash: func(_ []byte, _ string, _ HashAlgorithm) ([]byte, error) {
+					return []byte{5, 6}, nil
+				},
+			},
+			meterComputation: func(kind common.ComputationKind, intensity uint) error {
+				if kind == common.ComputationKindHashing {
+					hashedBytes += intensity
+				}
+				return nil
+			},
+		}
+
+		result, err := executeScript(script, runtimeInterface)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+		assert.Equal(t, uint(4), hashedBytes)
+	})
+
+	t.Run("update after finalize", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		_, err := executeScript(
+			`
+              pub fun main() {
+                  let hasher = HashAlgorithm.SHA3_256.newHasher()
+                  hasher.finalize()
+                  hasher.update([1])
+              }
+            `,
+			runtimeInterface,
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.HasherFinalizedError{})
+	})
+}
+
 func TestRuntimeHashingAlgorithmExport(t *testing.T) {
 
 	t.Parallel()
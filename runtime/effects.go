@@ -0,0 +1,293 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/format"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// AccountPath is a path in the storage of an account
+//
+type AccountPath struct {
+	Address common.Address
+	Path    interpreter.PathValue
+}
+
+func (p AccountPath) String() string {
+	return fmt.Sprintf("%s%s", p.Address.ShortHexWithPrefix(), p.Path)
+}
+
+// TokenBalanceDelta is the change of the balance of fungible tokens of an account.
+//
+// The delta is a fixed-point number with the scale of UFix64,
+// i.e. a delta of 1.0 tokens is represented as 100000000.
+//
+type TokenBalanceDelta struct {
+	Address common.Address
+	// Token is the location of the token contract, e.g. `A.1654653399040a61.FlowToken`
+	Token common.LocationID
+	Delta *big.Int
+}
+
+func (d TokenBalanceDelta) String() string {
+	integer, fraction := new(big.Int).QuoRem(
+		new(big.Int).Abs(d.Delta),
+		sema.Fix64FactorBig,
+		new(big.Int),
+	)
+
+	sign := "+"
+	if d.Delta.Sign() < 0 {
+		sign = "-"
+	}
+
+	return fmt.Sprintf(
+		"%s %s %s%s.%s",
+		d.Address.ShortHexWithPrefix(),
+		d.Token,
+		sign,
+		integer,
+		format.PadLeft(fraction.String(), '0', sema.Fix64Scale),
+	)
+}
+
+// EffectsSummary summarizes the effects of a transaction,
+// e.g. for wallets to display the consequences of a transaction before it is signed:
+// the storage paths which were written and removed, the capabilities which were linked and unlinked,
+// and the changes of fungible token balances.
+//
+// In-place modifications of stored values, e.g. through references, are not storage writes.
+// Balance changes are derived from the `TokensWithdrawn` and `TokensDeposited` events
+// of the fungible token standard, and only reported for vaults owned by accounts.
+//
+// All effects are ordered by their first occurrence.
+//
+type EffectsSummary struct {
+	StorageWrites        []AccountPath
+	StorageRemovals      []AccountPath
+	CapabilitiesLinked   []AccountPath
+	CapabilitiesUnlinked []AccountPath
+	BalanceDeltas        []TokenBalanceDelta
+}
+
+// SummarizeEffects returns the summary of the effects recorded in the given transcript,
+// and of the given events, i.e. the events emitted by the execution.
+//
+// The transaction can be executed without committing its effects, e.g. by discarding the storage writes
+// of the interface, to summarize the effects before the transaction is signed.
+//
+func SummarizeEffects(transcript *interpreter.Transcript, events []cadence.Event) *EffectsSummary {
+	summary := &EffectsSummary{}
+
+	var storageWrites, storageRemovals, capabilitiesLinked, capabilitiesUnlinked accountPathSet
+
+	for _, entry := range transcript.Entries {
+		accountPath := AccountPath{
+			Address: entry.Address,
+			Path:    entry.Path,
+		}
+
+		isCapabilityPath := entry.Path.Domain != common.PathDomainStorage
+
+		switch entry.Kind {
+		case interpreter.TranscriptEntryKindStorageWrite:
+			if isCapabilityPath {
+				capabilitiesLinked.add(accountPath)
+			} else {
+				storageWrites.add(accountPath)
+			}
+
+		case interpreter.TranscriptEntryKindStorageRemove:
+			if isCapabilityPath {
+				capabilitiesUnlinked.add(accountPath)
+			} else {
+				storageRemovals.add(accountPath)
+			}
+		}
+	}
+
+	summary.StorageWrites = storageWrites.paths
+	summary.StorageRemovals = storageRemovals.paths
+	summary.CapabilitiesLinked = capabilitiesLinked.paths
+	summary.CapabilitiesUnlinked = capabilitiesUnlinked.paths
+	summary.BalanceDeltas = tokenBalanceDeltas(events)
+
+	return summary
+}
+
+// String returns the summary in a human-readable format, one effect per line
+//
+func (s *EffectsSummary) String() string {
+	var builder strings.Builder
+
+	writePaths := func(description string, paths []AccountPath) {
+		for _, path := range paths {
+			builder.WriteString(description)
+			builder.WriteByte(' ')
+			builder.WriteString(path.String())
+			builder.WriteByte('\n')
+		}
+	}
+
+	writePaths("write", s.StorageWrites)
+	writePaths("remove", s.StorageRemovals)
+	writePaths("link", s.CapabilitiesLinked)
+	writePaths("unlink", s.CapabilitiesUnlinked)
+
+	for _, delta := range s.BalanceDeltas {
+		builder.WriteString("balance ")
+		builder.WriteString(delta.String())
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+type accountPathSet struct {
+	paths []AccountPath
+	seen  map[AccountPath]struct{}
+}
+
+func (s *accountPathSet) add(path AccountPath) {
+	if _, ok := s.seen[path]; ok {
+		return
+	}
+	if s.seen == nil {
+		s.seen = map[AccountPath]struct{}{}
+	}
+	s.seen[path] = struct{}{}
+	s.paths = append(s.paths, path)
+}
+
+const tokensWithdrawnEventName = "TokensWithdrawn"
+const tokensDepositedEventName = "TokensDeposited"
+
+type tokenAccount struct {
+	address common.Address
+	token   common.LocationID
+}
+
+// tokenBalanceDeltas returns the balance changes described
+// by the fungible token events in the given events.
+// Accounts with a zero delta are omitted.
+//
+func tokenBalanceDeltas(events []cadence.Event) []TokenBalanceDelta {
+	var order []tokenAccount
+	deltas := map[tokenAccount]*big.Int{}
+
+	for _, event := range events {
+		location, ok := event.EventType.Location.(common.AddressLocation)
+		if !ok {
+			continue
+		}
+
+		var sign int64
+		var addressFieldName string
+
+		switch event.EventType.QualifiedIdentifier {
+		case common.NewEventQualifiedIdentifier(location.Name, tokensWithdrawnEventName):
+			sign = -1
+			addressFieldName = "from"
+		case common.NewEventQualifiedIdentifier(location.Name, tokensDepositedEventName):
+			sign = 1
+			addressFieldName = "to"
+		default:
+			continue
+		}
+
+		amount, address, ok := tokenEventFields(event, addressFieldName)
+		if !ok {
+			continue
+		}
+
+		key := tokenAccount{
+			address: address,
+			token:   location.ID(),
+		}
+
+		delta, ok := deltas[key]
+		if !ok {
+			delta = new(big.Int)
+			deltas[key] = delta
+			order = append(order, key)
+		}
+
+		change := new(big.Int).SetUint64(uint64(amount))
+		change.Mul(change, big.NewInt(sign))
+		delta.Add(delta, change)
+	}
+
+	var result []TokenBalanceDelta
+	for _, key := range order {
+		delta := deltas[key]
+		if delta.Sign() == 0 {
+			continue
+		}
+		result = append(result, TokenBalanceDelta{
+			Address: key.address,
+			Token:   key.token,
+			Delta:   delta,
+		})
+	}
+
+	return result
+}
+
+// tokenEventFields returns the amount and the account address of a fungible token event,
+// i.e. the fields `amount: UFix64` and `from: Address?` or `to: Address?`.
+// It returns false if the event does not have these fields,
+// or if the tokens were not withdrawn from or deposited to a vault owned by an account.
+//
+func tokenEventFields(event cadence.Event, addressFieldName string) (cadence.UFix64, common.Address, bool) {
+	var amount cadence.UFix64
+	var address common.Address
+	var hasAmount, hasAddress bool
+
+	for i, field := range event.EventType.Fields {
+		if i >= len(event.Fields) {
+			break
+		}
+
+		value := event.Fields[i]
+
+		switch field.Identifier {
+		case "amount":
+			amount, hasAmount = value.(cadence.UFix64)
+
+		case addressFieldName:
+			optional, ok := value.(cadence.Optional)
+			if !ok || optional.Value == nil {
+				continue
+			}
+			var addressValue cadence.Address
+			addressValue, hasAddress = optional.Value.(cadence.Address)
+			address = common.Address(addressValue)
+		}
+	}
+
+	return amount, address, hasAmount && hasAddress
+}
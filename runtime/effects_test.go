@@ -0,0 +1,165 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeEffectsSummary(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	contractAddress := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xCA, 0xDE}
+	sender := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+	receiver := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2}
+
+	contract := []byte(`
+      pub contract Token {
+
+          pub event TokensWithdrawn(amount: UFix64, from: Address?)
+          pub event TokensDeposited(amount: UFix64, to: Address?)
+
+          pub resource Vault {
+              pub var balance: UFix64
+
+              init(balance: UFix64) {
+                  self.balance = balance
+              }
+
+              pub fun withdraw(amount: UFix64): @Vault {
+                  self.balance = self.balance - amount
+                  emit TokensWithdrawn(amount: amount, from: self.owner?.address)
+                  return <-create Vault(balance: amount)
+              }
+
+              pub fun deposit(from: @Vault) {
+                  self.balance = self.balance + from.balance
+                  emit TokensDeposited(amount: from.balance, to: self.owner?.address)
+                  destroy from
+              }
+          }
+
+          pub fun createVault(balance: UFix64): @Vault {
+              return <-create Vault(balance: balance)
+          }
+      }
+    `)
+
+	setupTransaction := []byte(`
+      import Token from 0xCADE
+
+      transaction {
+          prepare(sender: AuthAccount) {
+              sender.save(<-Token.createVault(balance: 100.0), to: /storage/vault)
+              sender.link<&Token.Vault>(/public/vault, target: /storage/vault)
+          }
+      }
+    `)
+
+	transferTransaction := []byte(`
+      import Token from 0xCADE
+
+      transaction {
+          prepare(sender: AuthAccount, receiver: AuthAccount) {
+              let vault <- sender.borrow<&Token.Vault>(from: /storage/vault)!
+                  .withdraw(amount: 12.5)
+
+              receiver.save(<-Token.createVault(balance: 0.0), to: /storage/vault)
+              receiver.link<&Token.Vault>(/public/vault, target: /storage/vault)
+              receiver.borrow<&Token.Vault>(from: /storage/vault)!
+                  .deposit(from: <-vault)
+
+              sender.unlink(/public/vault)
+          }
+      }
+    `)
+
+	var accountCode []byte
+	var signers []Address
+	var events []cadence.Event
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return signers, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(transaction []byte, transcript *interpreter.Transcript) {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: transaction,
+			},
+			Context{
+				Interface:  runtimeInterface,
+				Location:   nextTransactionLocation(),
+				Transcript: transcript,
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	signers = []Address{contractAddress}
+	executeTransaction(utils.DeploymentTransaction("Token", contract), nil)
+
+	signers = []Address{sender}
+	executeTransaction(setupTransaction, nil)
+
+	events = nil
+	transcript := &interpreter.Transcript{}
+
+	signers = []Address{sender, receiver}
+	executeTransaction(transferTransaction, transcript)
+
+	summary := SummarizeEffects(transcript, events)
+
+	assert.Equal(t,
+		`write 0x2/storage/vault
+link 0x2/public/vault
+unlink 0x1/public/vault
+balance 0x1 A.000000000000cade.Token -12.50000000
+balance 0x2 A.000000000000cade.Token +12.50000000
+`,
+		summary.String(),
+	)
+}
@@ -0,0 +1,398 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+type testElaborationArtifactRuntimeInterface struct {
+	*testRuntimeInterface
+	getElaborationArtifact func(location Location, codeHash [32]byte) ([]byte, error)
+}
+
+var _ ElaborationArtifactInterface = &testElaborationArtifactRuntimeInterface{}
+
+func (i *testElaborationArtifactRuntimeInterface) GetElaborationArtifact(
+	location Location,
+	codeHash [32]byte,
+) ([]byte, error) {
+	return i.getElaborationArtifact(location, codeHash)
+}
+
+func TestRuntimeElaborationArtifact(t *testing.T) {
+
+	t.Parallel()
+
+	importedLocation := common.IdentifierLocation("imported")
+
+	imported := []byte(`
+      pub struct interface HasValue {
+          pub fun getValue(): Int
+      }
+
+      pub resource Counter {
+          pub var count: Int
+
+          init() {
+              self.count = 0
+          }
+
+          pub fun increment(by amount: Int): Int {
+              pre {
+                  amount > 0: "amount must be positive"
+              }
+              post {
+                  self.count == before(self.count) + amount
+              }
+              self.count = self.count + amount
+              return self.count
+          }
+      }
+
+      pub struct Value: HasValue {
+          pub let value: Int
+
+          init(_ value: Int) {
+              self.value = value
+          }
+
+          pub fun getValue(): Int {
+              return self.value
+          }
+      }
+
+      pub enum Color: UInt8 {
+          pub case red
+          pub case green
+      }
+
+      pub attachment Label for Value {
+          pub let text: String
+
+          init(text: String) {
+              self.text = text
+          }
+
+          pub fun describe(): String {
+              return self.text.concat(base.value.toString())
+          }
+      }
+
+      pub fun createCounter(): @Counter {
+          return <-create Counter()
+      }
+    `)
+
+	script := []byte(`
+      import imported
+
+      pub fun main(): [AnyStruct] {
+          let counter <- createCounter()
+          counter.increment(by: 2)
+          let count = counter.increment(by: 3)
+          destroy counter
+
+          let values: [{HasValue}] = [Value(1), Value(count)]
+          var sum = 0
+          for value in values {
+              sum = sum + value.getValue()
+          }
+
+          let colors: {String: Color} = {"green": Color.green}
+          let optional: Value? = Value(7)
+          let labeled = attach Label(text: "value: ") to Value(8)
+
+          return [
+              sum,
+              colors["green"]?.rawValue,
+              optional?.value,
+              Type<@Counter>().identifier,
+              labeled[Label]?.describe()
+          ]
+      }
+    `)
+
+	scriptLocation := common.ScriptLocation{0x1}
+
+	expected := cadence.NewArray([]cadence.Value{
+		cadence.NewInt(6),
+		cadence.NewOptional(cadence.NewUInt8(1)),
+		cadence.NewOptional(cadence.NewInt(7)),
+		cadence.String("I.imported.Counter"),
+		cadence.NewOptional(cadence.String("value: 8")),
+	})
+
+	getCode := func(location Location) ([]byte, error) {
+		switch location {
+		case importedLocation:
+			return imported, nil
+		default:
+			return nil, fmt.Errorf("unknown location: %s", location)
+		}
+	}
+
+	executeScript := func(runtimeInterface Interface) cadence.Value {
+		runtime := newTestInterpreterRuntime()
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  scriptLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		return value
+	}
+
+	// Check and execute the programs, and encode their elaborations
+
+	var checked []common.LocationID
+
+	baseRuntimeInterface := &testRuntimeInterface{
+		getCode: getCode,
+		programChecked: func(location common.Location, _ time.Duration) {
+			checked = append(checked, location.ID())
+		},
+	}
+
+	value := executeScript(baseRuntimeInterface)
+	assert.Equal(t, expected, value)
+
+	assert.ElementsMatch(t,
+		[]common.LocationID{
+			importedLocation.ID(),
+			scriptLocation.ID(),
+		},
+		checked,
+	)
+
+	// Scripts are not stored, so parse and check the script again
+
+	scriptProgram, err := newTestInterpreterRuntime().ParseAndCheckProgram(
+		script,
+		Context{
+			Interface: &testRuntimeInterface{
+				getCode: getCode,
+			},
+			Location: scriptLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	encodeArtifact := func(code []byte, location Location, program *interpreter.Program) []byte {
+		artifact, err := EncodeElaborationArtifact(code, location, program)
+		require.NoError(t, err)
+		return artifact
+	}
+
+	artifacts := map[common.LocationID][]byte{
+		importedLocation.ID(): encodeArtifact(
+			imported,
+			importedLocation,
+			baseRuntimeInterface.programs[importedLocation.ID()],
+		),
+		scriptLocation.ID(): encodeArtifact(
+			script,
+			scriptLocation,
+			scriptProgram,
+		),
+	}
+
+	newRuntimeInterface := func(
+		getElaborationArtifact func(location Location, codeHash [32]byte) ([]byte, error),
+	) Interface {
+		checked = nil
+
+		return &testElaborationArtifactRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				getCode: getCode,
+				programChecked: func(location common.Location, _ time.Duration) {
+					checked = append(checked, location.ID())
+				},
+			},
+			getElaborationArtifact: getElaborationArtifact,
+		}
+	}
+
+	t.Run("compatible", func(t *testing.T) {
+
+		value := executeScript(
+			newRuntimeInterface(func(location Location, _ [32]byte) ([]byte, error) {
+				return artifacts[location.ID()], nil
+			}),
+		)
+		assert.Equal(t, expected, value)
+
+		// No program was checked
+
+		assert.Empty(t, checked)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+
+		value := executeScript(
+			newRuntimeInterface(func(location Location, _ [32]byte) ([]byte, error) {
+				if location == importedLocation {
+					return nil, nil
+				}
+				return artifacts[location.ID()], nil
+			}),
+		)
+		assert.Equal(t, expected, value)
+
+		assert.Equal(t,
+			[]common.LocationID{
+				importedLocation.ID(),
+			},
+			checked,
+		)
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+
+		// The artifact of the imported program is provided for the script
+
+		value := executeScript(
+			newRuntimeInterface(func(location Location, _ [32]byte) ([]byte, error) {
+				return artifacts[importedLocation.ID()], nil
+			}),
+		)
+		assert.Equal(t, expected, value)
+
+		assert.Equal(t,
+			[]common.LocationID{
+				scriptLocation.ID(),
+			},
+			checked,
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		value := executeScript(
+			newRuntimeInterface(func(location Location, _ [32]byte) ([]byte, error) {
+				return []byte{0x1, 0x2}, nil
+			}),
+		)
+		assert.Equal(t, expected, value)
+
+		assert.ElementsMatch(t,
+			[]common.LocationID{
+				importedLocation.ID(),
+				scriptLocation.ID(),
+			},
+			checked,
+		)
+	})
+
+	t.Run("different language version", func(t *testing.T) {
+
+		// The artifacts were produced with the legacy language version
+
+		runtimeInterface := newRuntimeInterface(func(location Location, _ [32]byte) ([]byte, error) {
+			return artifacts[location.ID()], nil
+		})
+
+		value, err := newTestInterpreterRuntime().ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface:       runtimeInterface,
+				Location:        scriptLocation,
+				LanguageVersion: sema.LanguageVersion1,
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, expected, value)
+
+		assert.ElementsMatch(t,
+			[]common.LocationID{
+				importedLocation.ID(),
+				scriptLocation.ID(),
+			},
+			checked,
+		)
+	})
+}
+
+func TestRuntimeElaborationArtifactVersion(t *testing.T) {
+
+	t.Parallel()
+
+	code := []byte(`
+      pub fun main(): Int {
+          return 42
+      }
+    `)
+
+	location := common.ScriptLocation{0x1}
+
+	program, err := newTestInterpreterRuntime().ParseAndCheckProgram(
+		code,
+		Context{
+			Interface: &testRuntimeInterface{},
+			Location:  location,
+		},
+	)
+	require.NoError(t, err)
+
+	artifact, err := EncodeElaborationArtifact(code, location, program)
+	require.NoError(t, err)
+
+	// Different code
+
+	otherCode := []byte(`
+      pub fun main(): Int {
+          return 43
+      }
+    `)
+
+	codeHash := sha3.Sum256(otherCode)
+
+	_, err = sema.DecodeElaboration(
+		artifact,
+		program.Program,
+		location,
+		codeHash[:],
+		nil,
+		nil,
+		nil,
+	)
+	require.Error(t, err)
+
+	var incompatibleErr *sema.IncompatibleElaborationError
+	require.ErrorAs(t, err, &incompatibleErr)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
@@ -74,6 +75,19 @@ func (e ComputationLimitExceededError) Error() string {
 	)
 }
 
+// MemoryLimitExceededError
+
+type MemoryLimitExceededError struct {
+	Limit uint64
+}
+
+func (e MemoryLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"memory limit exceeded: %d",
+		e.Limit,
+	)
+}
+
 // CallStackLimitExceededError
 
 type CallStackLimitExceededError struct {
@@ -87,6 +101,51 @@ func (e CallStackLimitExceededError) Error() string {
 	)
 }
 
+// CodeSizeLimitExceededError
+
+type CodeSizeLimitExceededError struct {
+	Size  int
+	Limit int
+}
+
+func (e CodeSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"code size limit exceeded: got %d bytes, limit is %d",
+		e.Size,
+		e.Limit,
+	)
+}
+
+// ArgumentCountLimitExceededError
+
+type ArgumentCountLimitExceededError struct {
+	Count int
+	Limit int
+}
+
+func (e ArgumentCountLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"argument count limit exceeded: got %d arguments, limit is %d",
+		e.Count,
+		e.Limit,
+	)
+}
+
+// ArgumentSizeLimitExceededError
+
+type ArgumentSizeLimitExceededError struct {
+	Size  int
+	Limit int
+}
+
+func (e ArgumentSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"argument size limit exceeded: got %d bytes, limit is %d",
+		e.Size,
+		e.Limit,
+	)
+}
+
 // InvalidTransactionCountError
 
 type InvalidTransactionCountError struct {
@@ -124,11 +183,20 @@ func (e InvalidEntryPointParameterCountError) Error() string {
 type InvalidTransactionAuthorizerCountError struct {
 	Expected int
 	Actual   int
+	// AtLeast is true if the transaction accepts a variable number of authorizers,
+	// i.e. Expected is the minimum number of authorizers
+	AtLeast bool
 }
 
 func (e InvalidTransactionAuthorizerCountError) Error() string {
+	expected := "expected"
+	if e.AtLeast {
+		expected = "expected at least"
+	}
+
 	return fmt.Sprintf(
-		"authorizer count mismatch for transaction: expected %d, got %d",
+		"authorizer count mismatch for transaction: %s %d, got %d",
+		expected,
 		e.Expected,
 		e.Actual,
 	)
@@ -153,6 +221,29 @@ func (e *InvalidEntryPointArgumentError) Error() string {
 	)
 }
 
+// ArgumentDecodingError is an error that is reported
+// when an encoded argument cannot be decoded against the expected type.
+//
+// The wrapped error is the error reported by Interface.DecodeArgument,
+// e.g. a json.DecodingError, which reports where in the encoded argument decoding failed.
+//
+type ArgumentDecodingError struct {
+	ExpectedType cadence.Type
+	Err          error
+}
+
+func (e *ArgumentDecodingError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ArgumentDecodingError) Error() string {
+	return fmt.Sprintf(
+		"failed to decode argument of type `%s`: %s",
+		e.ExpectedType.ID(),
+		e.Err.Error(),
+	)
+}
+
 // MalformedValueError
 
 type MalformedValueError struct {
@@ -304,6 +395,24 @@ func (e *ContractRemovalError) Error() string {
 	return fmt.Sprintf("cannot remove contract `%s`", e.Name)
 }
 
+// FrozenContractUpdateError is reported when an update or a removal
+// of a contract or contract interface is attempted,
+// but the contract was deployed as frozen.
+//
+type FrozenContractUpdateError struct {
+	Name      string
+	IsRemoval bool
+	interpreter.LocationRange
+}
+
+func (e *FrozenContractUpdateError) Error() string {
+	operation := "update"
+	if e.IsRemoval {
+		operation = "remove"
+	}
+	return fmt.Sprintf("cannot %s frozen contract `%s`", operation, e.Name)
+}
+
 // InvalidContractDeploymentOriginError
 //
 type InvalidContractDeploymentOriginError struct {
@@ -386,6 +495,26 @@ func (e *ExtraneousFieldError) Error() string {
 	)
 }
 
+// RemovedFieldWarning is reported during a contract update, when an existing field
+// is removed from a composite declaration. Removing fields is allowed,
+// but the data stored in the field becomes inaccessible.
+type RemovedFieldWarning struct {
+	DeclName  string
+	FieldName string
+	ast.Range
+}
+
+func (e *RemovedFieldWarning) Error() string {
+	return fmt.Sprintf("removed field `%s` from `%s`",
+		e.FieldName,
+		e.DeclName,
+	)
+}
+
+func (e *RemovedFieldWarning) SecondaryError() string {
+	return "the data stored in the field becomes inaccessible"
+}
+
 // ContractNotFoundError is reported during a contract update, if no contract can be
 // found in the program.
 type ContractNotFoundError struct {
@@ -483,3 +612,169 @@ func (e *MissingCompositeDeclarationError) Error() string {
 		e.Name,
 	)
 }
+
+// TransactionStorageResourceLossError is returned when a resource is left
+// in the transaction storage domain at the end of a transaction.
+//
+type TransactionStorageResourceLossError struct {
+	Path interpreter.PathValue
+}
+
+func (e *TransactionStorageResourceLossError) Error() string {
+	return fmt.Sprintf(
+		"resource loss: transaction storage path %s still stores a resource at the end of the transaction",
+		e.Path,
+	)
+}
+
+// InvalidNativeFunctionError is reported when a native function
+// cannot be registered, see NewNativeFunctions.
+//
+type InvalidNativeFunctionError struct {
+	Name   string
+	Reason string
+}
+
+func (e *InvalidNativeFunctionError) Error() string {
+	return fmt.Sprintf(
+		"invalid native function `%s`: %s",
+		e.Name,
+		e.Reason,
+	)
+}
+
+// InvalidNativeFunctionResultError is reported when a native function
+// returns a value which is not of its declared return type.
+//
+type InvalidNativeFunctionResultError struct {
+	Name string
+	Err  error
+	interpreter.LocationRange
+}
+
+func (e *InvalidNativeFunctionResultError) Error() string {
+	return fmt.Sprintf(
+		"invalid result of native function `%s`: %s",
+		e.Name,
+		e.Err.Error(),
+	)
+}
+
+func (e *InvalidNativeFunctionResultError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidBlockRangeError is reported when a range of block IDs is requested
+// with a start height after the end height, or an end height after the current block height.
+//
+type InvalidBlockRangeError struct {
+	StartHeight        uint64
+	EndHeight          uint64
+	CurrentBlockHeight uint64
+	interpreter.LocationRange
+}
+
+func (e *InvalidBlockRangeError) Error() string {
+	return fmt.Sprintf(
+		"invalid block range from height %d to height %d: current block height is %d",
+		e.StartHeight,
+		e.EndHeight,
+		e.CurrentBlockHeight,
+	)
+}
+
+// BlockRangeLimitExceededError is reported when a range of block IDs is requested
+// which is longer than the maximum range length.
+//
+type BlockRangeLimitExceededError struct {
+	StartHeight uint64
+	EndHeight   uint64
+	Limit       uint64
+	interpreter.LocationRange
+}
+
+func (e *BlockRangeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"block range limit exceeded: cannot get IDs of blocks from height %d to height %d, at most %d block IDs can be requested at once",
+		e.StartHeight,
+		e.EndHeight,
+		e.Limit,
+	)
+}
+
+// ScheduledCallbacksNotSupportedError is reported when a program schedules a callback,
+// but the environment does not support scheduled callbacks.
+//
+type ScheduledCallbacksNotSupportedError struct {
+	interpreter.LocationRange
+}
+
+func (*ScheduledCallbacksNotSupportedError) Error() string {
+	return "scheduled callbacks are not supported"
+}
+
+// InvalidScheduledCallbackBlockHeightError is reported when a callback is scheduled
+// for a block height which is not after the current block height.
+//
+type InvalidScheduledCallbackBlockHeightError struct {
+	BlockHeight        uint64
+	CurrentBlockHeight uint64
+	interpreter.LocationRange
+}
+
+func (e *InvalidScheduledCallbackBlockHeightError) Error() string {
+	return fmt.Sprintf(
+		"cannot schedule callback for block height %d: must be after current block height %d",
+		e.BlockHeight,
+		e.CurrentBlockHeight,
+	)
+}
+
+// InvalidScheduledCallbackHandlerError is reported when the handler of a scheduled callback
+// cannot be borrowed, or does not have a public function with the required type.
+//
+type InvalidScheduledCallbackHandlerError struct {
+	FunctionName string
+	interpreter.LocationRange
+}
+
+func (e *InvalidScheduledCallbackHandlerError) Error() string {
+	return fmt.Sprintf(
+		"invalid scheduled callback handler: capability must refer to a value with a public function `%s` of type `((UInt64): Void)`",
+		e.FunctionName,
+	)
+}
+
+// SimulationUnsupportedOperationError is reported when a simulated transaction
+// attempts an operation which has effects on accounts outside of their storage,
+// e.g. creating an account, changing contract code, or changing account keys.
+//
+// These effects cannot be layered over the state provided by the interface,
+// so they are rejected instead of being reported to the interface.
+//
+type SimulationUnsupportedOperationError struct {
+	Operation string
+	interpreter.LocationRange
+}
+
+func (e SimulationUnsupportedOperationError) Error() string {
+	return fmt.Sprintf("cannot %s in a simulated transaction", e.Operation)
+}
+
+// ScheduledCallbackNotDueError is reported when a scheduled callback is executed
+// before the block height it was scheduled for.
+//
+type ScheduledCallbackNotDueError struct {
+	ID                 uint64
+	BlockHeight        uint64
+	CurrentBlockHeight uint64
+}
+
+func (e ScheduledCallbackNotDueError) Error() string {
+	return fmt.Sprintf(
+		"scheduled callback %d is not due: scheduled for block height %d, current block height is %d",
+		e.ID,
+		e.BlockHeight,
+		e.CurrentBlockHeight,
+	)
+}
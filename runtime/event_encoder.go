@@ -0,0 +1,58 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// EventEncoder encodes the payloads of emitted events,
+// see EncodedEventInterface.
+//
+type EventEncoder interface {
+	EncodeEvent(event cadence.Event) ([]byte, error)
+}
+
+// EventEncoderFunc is an EventEncoder which encodes events using a function.
+//
+type EventEncoderFunc func(event cadence.Event) ([]byte, error)
+
+var _ EventEncoder = EventEncoderFunc(nil)
+
+func (f EventEncoderFunc) EncodeEvent(event cadence.Event) ([]byte, error) {
+	return f(event)
+}
+
+// JSONCDCEventEncoder encodes event payloads in the JSON-Cadence Data Interchange Format.
+//
+var JSONCDCEventEncoder EventEncoder = EventEncoderFunc(
+	func(event cadence.Event) ([]byte, error) {
+		return jsoncdc.Encode(event)
+	},
+)
+
+// CCFEventEncoder encodes event payloads in the Cadence Compact Format.
+//
+var CCFEventEncoder EventEncoder = EventEncoderFunc(
+	func(event cadence.Event) ([]byte, error) {
+		return ccf.Encode(event)
+	},
+)
@@ -0,0 +1,181 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+type testEncodedEventRuntimeInterface struct {
+	*testRuntimeInterface
+	eventEncoder     EventEncoder
+	emitEncodedEvent func(event cadence.Event, payload []byte) error
+}
+
+var _ EncodedEventInterface = &testEncodedEventRuntimeInterface{}
+
+func (i *testEncodedEventRuntimeInterface) EventEncoder() EventEncoder {
+	return i.eventEncoder
+}
+
+func (i *testEncodedEventRuntimeInterface) EmitEncodedEvent(event cadence.Event, payload []byte) error {
+	return i.emitEncodedEvent(event, payload)
+}
+
+func TestRuntimeEncodedEvents(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              AuthAccount(payer: signer)
+          }
+      }
+    `)
+
+	test := func(
+		t *testing.T,
+		encoder EventEncoder,
+		decode func(payload []byte) (cadence.Value, error),
+	) {
+		runtime := newTestInterpreterRuntime()
+
+		var events []cadence.Event
+		var payloads [][]byte
+
+		runtimeInterface := &testEncodedEventRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				getSigningAccounts: func() ([]Address, error) {
+					return []Address{{42}}, nil
+				},
+				createAccount: func(payer Address) (address Address, err error) {
+					return Address{42}, nil
+				},
+				emitEvent: func(event cadence.Event) error {
+					require.FailNow(t, "unexpected call of EmitEvent")
+					return nil
+				},
+			},
+			eventEncoder: encoder,
+			emitEncodedEvent: func(event cadence.Event, payload []byte) error {
+				events = append(events, event)
+				payloads = append(payloads, payload)
+				return nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		require.Len(t, events, 1)
+		require.Len(t, payloads, 1)
+
+		decoded, err := decode(payloads[0])
+		require.NoError(t, err)
+
+		require.IsType(t, cadence.Event{}, decoded)
+		decodedEvent := decoded.(cadence.Event)
+
+		assert.Equal(t, events[0].EventType.ID(), decodedEvent.EventType.ID())
+		assert.Equal(t, events[0].Fields, decodedEvent.Fields)
+	}
+
+	t.Run("JSON-CDC", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, JSONCDCEventEncoder, jsoncdc.Decode)
+	})
+
+	t.Run("CCF", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, CCFEventEncoder, ccf.Decode)
+	})
+}
+
+func TestRuntimeEncodedEventsEncodingError(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	script := []byte(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              AuthAccount(payer: signer)
+          }
+      }
+    `)
+
+	encodingErr := errors.New("encoding failed")
+
+	runtimeInterface := &testEncodedEventRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{42}}, nil
+			},
+			createAccount: func(payer Address) (address Address, err error) {
+				return Address{42}, nil
+			},
+		},
+		eventEncoder: EventEncoderFunc(func(event cadence.Event) ([]byte, error) {
+			return nil, encodingErr
+		}),
+		emitEncodedEvent: func(event cadence.Event, payload []byte) error {
+			require.FailNow(t, "unexpected call of EmitEncodedEvent")
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.ErrorIs(t, err, encodingErr)
+}
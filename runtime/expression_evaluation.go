@@ -0,0 +1,338 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// ExpressionEvaluationLimits are the limits which are enforced
+// when evaluating an expression using EvaluateExpression.
+//
+// Limits which are zero are replaced with the corresponding default limit
+// in DefaultExpressionEvaluationLimits.
+//
+type ExpressionEvaluationLimits struct {
+	// CodeSizeLimit is the maximum size of the expression's code, in bytes
+	CodeSizeLimit int
+	// ComputationLimit is the maximum computation the evaluation may use,
+	// e.g. the number of statements executed, loop iterations, and function invocations
+	ComputationLimit uint64
+	// MemoryLimit is the maximum estimated number of bytes the evaluation may allocate
+	MemoryLimit uint64
+	// CallStackDepthLimit is the maximum depth of nested function invocations
+	CallStackDepthLimit int
+}
+
+var DefaultExpressionEvaluationLimits = ExpressionEvaluationLimits{
+	CodeSizeLimit:       10_000,
+	ComputationLimit:    10_000,
+	MemoryLimit:         10_000_000,
+	CallStackDepthLimit: 100,
+}
+
+func (limits ExpressionEvaluationLimits) withDefaults() ExpressionEvaluationLimits {
+	if limits.CodeSizeLimit == 0 {
+		limits.CodeSizeLimit = DefaultExpressionEvaluationLimits.CodeSizeLimit
+	}
+	if limits.ComputationLimit == 0 {
+		limits.ComputationLimit = DefaultExpressionEvaluationLimits.ComputationLimit
+	}
+	if limits.MemoryLimit == 0 {
+		limits.MemoryLimit = DefaultExpressionEvaluationLimits.MemoryLimit
+	}
+	if limits.CallStackDepthLimit == 0 {
+		limits.CallStackDepthLimit = DefaultExpressionEvaluationLimits.CallStackDepthLimit
+	}
+	return limits
+}
+
+// ExpressionLocation is the location of expressions evaluated by EvaluateExpression.
+//
+const ExpressionLocation = common.IdentifierLocation("expression")
+
+// expressionEvaluationFunctions are the only functions available to evaluated expressions,
+// in addition to the base values, e.g. the number conversion functions.
+//
+var expressionEvaluationFunctions = stdlib.StandardLibraryFunctions{
+	stdlib.AssertFunction,
+	stdlib.PanicFunction,
+}
+
+// EvaluateExpression parses, checks, and evaluates the given expression,
+// and returns the resulting value.
+//
+// The expression may refer to the given bindings by name.
+// The types of the bindings are the types of the given values,
+// so the values must have types, e.g. arrays must have an array type.
+// Composite values, like structures, are not supported.
+//
+// The evaluation is sandboxed: the expression has no access to storage, accounts,
+// or any other functionality of the environment, and the evaluation is aborted
+// when it exceeds the given limits.
+//
+func EvaluateExpression(
+	code string,
+	bindings map[string]cadence.Value,
+	limits ExpressionEvaluationLimits,
+) (
+	result cadence.Value,
+	err error,
+) {
+	limits = limits.withDefaults()
+
+	codes := map[common.LocationID]string{
+		ExpressionLocation.ID(): code,
+	}
+
+	wrapError := func(err error) error {
+		return Error{
+			Err:      err,
+			Location: ExpressionLocation,
+			Codes:    codes,
+		}
+	}
+
+	if len(code) > limits.CodeSizeLimit {
+		return nil, wrapError(CodeSizeLimitExceededError{
+			Size:  len(code),
+			Limit: limits.CodeSizeLimit,
+		})
+	}
+
+	expression, errs := parser2.ParseExpression(code)
+	if len(errs) > 0 {
+		return nil, wrapError(parser2.Error{
+			Code:   code,
+			Errors: errs,
+		})
+	}
+
+	// NOTE: iterate over the bindings in a deterministic order
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings { //nolint:maprangecheck
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bindingTypes := make(map[string]sema.Type, len(bindings))
+
+	for _, name := range names {
+		for _, function := range expressionEvaluationFunctions {
+			if function.Name == name {
+				return nil, wrapError(fmt.Errorf("invalid binding `%s`: name is reserved", name))
+			}
+		}
+
+		bindingType, err := expressionBindingType(bindings[name])
+		if err != nil {
+			return nil, wrapError(fmt.Errorf("invalid binding `%s`: %w", name, err))
+		}
+		bindingTypes[name] = bindingType
+	}
+
+	// Check the expression
+
+	semaValueDeclarations := expressionEvaluationFunctions.ToSemaValueDeclarations()
+	for _, name := range names {
+		semaValueDeclarations = append(
+			semaValueDeclarations,
+			stdlib.StandardLibraryValue{
+				Name: name,
+				Type: bindingTypes[name],
+				Kind: common.DeclarationKindConstant,
+			},
+		)
+	}
+
+	checker, err := sema.NewChecker(
+		nil,
+		ExpressionLocation,
+		sema.WithPredeclaredValues(semaValueDeclarations),
+		sema.WithAccessCheckMode(sema.AccessCheckModeStrict),
+	)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	checker.VisitExpression(expression, nil)
+
+	checkerErr := checker.CheckerError()
+	if checkerErr != nil {
+		return nil, wrapError(checkerErr)
+	}
+
+	// Evaluate the expression
+
+	interpreterOptions := append(
+		[]interpreter.Option{
+			interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+			interpreter.WithMemoryGauge(
+				&interfaceMemoryGauge{
+					memoryLimit: limits.MemoryLimit,
+				},
+			),
+		},
+		expressionEvaluationLimitOptions(limits)...,
+	)
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		ExpressionLocation,
+		interpreterOptions...,
+	)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	interpreterValueDeclarations := expressionEvaluationFunctions.ToInterpreterValueDeclarations()
+	for _, name := range names {
+		value, err := importValue(inter, bindings[name], bindingTypes[name])
+		if err != nil {
+			return nil, wrapError(fmt.Errorf("invalid binding `%s`: %w", name, err))
+		}
+
+		interpreterValueDeclarations = append(
+			interpreterValueDeclarations,
+			stdlib.StandardLibraryValue{
+				Name: name,
+				Type: bindingTypes[name],
+				ValueFactory: func(_ *interpreter.Interpreter) interpreter.Value {
+					return value
+				},
+				Kind: common.DeclarationKindConstant,
+			},
+		)
+	}
+
+	err = interpreter.WithPredeclaredValues(interpreterValueDeclarations)(inter)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var value interpreter.Value
+
+	func() {
+		defer inter.RecoverErrors(func(internalErr error) {
+			err = internalErr
+		})
+
+		value = expression.Accept(inter).(interpreter.Value)
+	}()
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	result, err = exportValueWithInterpreter(value, inter, seenReferences{})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+// expressionBindingType returns the type of the given binding value.
+// Only values which have a type that is not and does not contain a composite or interface type
+// are supported, as the evaluated expression has no access to any programs.
+//
+func expressionBindingType(value cadence.Value) (sema.Type, error) {
+	if value == nil || value.Type() == nil {
+		return nil, fmt.Errorf("missing value type")
+	}
+
+	return interpreter.ConvertStaticToSemaType(
+		ImportType(value.Type()),
+		func(_ common.Location, qualifiedIdentifier string) (*sema.InterfaceType, error) {
+			return nil, fmt.Errorf("unsupported interface type `%s`", qualifiedIdentifier)
+		},
+		func(_ common.Location, qualifiedIdentifier string, _ common.TypeID) (*sema.CompositeType, error) {
+			return nil, fmt.Errorf("unsupported composite type `%s`", qualifiedIdentifier)
+		},
+		func(typeID common.TypeID) (*sema.EntitlementType, error) {
+			return nil, fmt.Errorf("unsupported entitlement `%s`", typeID)
+		},
+	)
+}
+
+// expressionEvaluationLimitOptions returns the interpreter options
+// which enforce the computation and call stack depth limits of an expression evaluation.
+//
+func expressionEvaluationLimitOptions(limits ExpressionEvaluationLimits) []interpreter.Option {
+
+	var computationUsed uint64
+
+	checkComputationLimit := func(increase uint64) {
+		computationUsed += increase
+
+		if computationUsed <= limits.ComputationLimit {
+			return
+		}
+
+		panic(ComputationLimitExceededError{
+			Limit: limits.ComputationLimit,
+		})
+	}
+
+	callStackDepth := 0
+
+	return []interpreter.Option{
+		interpreter.WithOnStatementHandler(
+			func(_ *interpreter.Interpreter, _ ast.Statement) {
+				checkComputationLimit(1)
+			},
+		),
+		interpreter.WithOnLoopIterationHandler(
+			func(_ *interpreter.Interpreter, _ int) {
+				checkComputationLimit(1)
+			},
+		),
+		interpreter.WithOnFunctionInvocationHandler(
+			func(_ *interpreter.Interpreter, _ int) {
+				callStackDepth++
+				if callStackDepth > limits.CallStackDepthLimit {
+					panic(CallStackLimitExceededError{
+						Limit: uint64(limits.CallStackDepthLimit),
+					})
+				}
+
+				checkComputationLimit(1)
+			},
+		),
+		interpreter.WithOnInvokedFunctionReturnHandler(
+			func(_ *interpreter.Interpreter, _ int) {
+				callStackDepth--
+			},
+		),
+		interpreter.WithOnMeterComputationHandler(
+			func(_ *interpreter.Interpreter, kind common.ComputationKind, intensity uint) {
+				checkComputationLimit(computationIntensityUsage(kind, intensity))
+			},
+		),
+	}
+}
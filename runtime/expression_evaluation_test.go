@@ -0,0 +1,218 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("bindings", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := EvaluateExpression(
+			`amount > limit && owners.contains(owner) ? "allow" : "deny"`,
+			map[string]cadence.Value{
+				"amount": cadence.UFix64(20_00000000),
+				"limit":  cadence.UFix64(10_00000000),
+				"owners": cadence.NewArray([]cadence.Value{
+					cadence.String("alice"),
+					cadence.String("bob"),
+				}).WithType(cadence.VariableSizedArrayType{
+					ElementType: cadence.StringType{},
+				}),
+				"owner": cadence.String("bob"),
+			},
+			ExpressionEvaluationLimits{},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.String("allow"), result)
+	})
+
+	t.Run("function expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := EvaluateExpression(
+			`fun (): Int {
+                var sum = 0
+                for value in values {
+                    sum = sum + value
+                }
+                return sum
+            }()`,
+			map[string]cadence.Value{
+				"values": cadence.NewArray([]cadence.Value{
+					cadence.NewInt(1),
+					cadence.NewInt(2),
+					cadence.NewInt(3),
+				}).WithType(cadence.VariableSizedArrayType{
+					ElementType: cadence.IntType{},
+				}),
+			},
+			ExpressionEvaluationLimits{},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(6), result)
+	})
+
+	t.Run("parsing error", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(`1 +`, nil, ExpressionEvaluationLimits{})
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &parser2.Error{})
+	})
+
+	t.Run("checking error", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(`x + 1`, nil, ExpressionEvaluationLimits{})
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+	})
+
+	t.Run("no environment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`getAccount(0x1).balance`,
+			nil,
+			ExpressionEvaluationLimits{},
+		)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`panic("denied")`,
+			nil,
+			ExpressionEvaluationLimits{},
+		)
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "denied")
+	})
+
+	t.Run("missing binding type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`values.length`,
+			map[string]cadence.Value{
+				"values": cadence.NewArray(nil),
+			},
+			ExpressionEvaluationLimits{},
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("composite binding", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`foo`,
+			map[string]cadence.Value{
+				"foo": cadence.NewStruct([]cadence.Value{cadence.NewInt(42)}).
+					WithType(fooStructType),
+			},
+			ExpressionEvaluationLimits{},
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("code size limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`1 + 2 + 3`,
+			nil,
+			ExpressionEvaluationLimits{
+				CodeSizeLimit: 5,
+			},
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &CodeSizeLimitExceededError{})
+	})
+
+	t.Run("computation limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`fun () {
+                while true {}
+            }()`,
+			nil,
+			ExpressionEvaluationLimits{
+				ComputationLimit: 100,
+			},
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &ComputationLimitExceededError{})
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := EvaluateExpression(
+			`"a".concat(value).concat(value)`,
+			map[string]cadence.Value{
+				"value": cadence.String(make([]byte, 1000)),
+			},
+			ExpressionEvaluationLimits{
+				MemoryLimit: 100,
+			},
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &MemoryLimitExceededError{})
+	})
+}
@@ -0,0 +1,441 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=GasHazardKind -trimprefix=GasHazard
+
+// GasHazardKind is the kind of a code pattern whose computation cost
+// is not bounded statically, and may exceed the computation limit as state grows.
+//
+type GasHazardKind uint
+
+const (
+	GasHazardUnknown GasHazardKind = iota
+	// GasHazardUnboundedLoop is a loop whose number of iterations depends on the size
+	// of a collection which is stored in state or provided by a caller
+	GasHazardUnboundedLoop
+	// GasHazardUnboundedRecursion is a recursive call without an argument
+	// that decreases towards a base case
+	GasHazardUnboundedRecursion
+)
+
+// GasHazard is a code pattern which may exceed the computation limit.
+//
+// Function is the qualified name of the function in which the pattern occurs,
+// e.g. `Collection.getIDs`, or `prepare` and `execute` for transactions.
+//
+// For loops, Source is the expression the number of iterations depends on,
+// e.g. `self.ownedNFTs.keys`. For recursion, Source is the name of the function called.
+//
+type GasHazard struct {
+	Kind     GasHazardKind
+	Function string
+	Source   string
+	ast.Range
+}
+
+func (h GasHazard) String() string {
+	switch h.Kind {
+	case GasHazardUnboundedLoop:
+		return fmt.Sprintf(
+			"%d:%d: %s: loop in `%s` depends on the size of `%s`",
+			h.StartPos.Line,
+			h.StartPos.Column,
+			h.Kind,
+			h.Function,
+			h.Source,
+		)
+
+	case GasHazardUnboundedRecursion:
+		return fmt.Sprintf(
+			"%d:%d: %s: `%s` calls `%s` recursively without a decreasing argument",
+			h.StartPos.Line,
+			h.StartPos.Column,
+			h.Kind,
+			h.Function,
+			h.Source,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%d:%d: %s",
+		h.StartPos.Line,
+		h.StartPos.Column,
+		h.Kind,
+	)
+}
+
+// AnalyzeGasHazards statically determines the code patterns in the given checked program
+// whose computation cost grows with state, and which may therefore eventually exceed
+// the computation limit, in source order.
+//
+// A loop is reported if it iterates over, or its condition depends on the length of,
+// a collection which is stored in a field of a composite, returned by a function of a composite,
+// or passed as a parameter. Loops over array literals and constant-sized arrays are bounded.
+//
+// A call is reported if it is part of a cycle of calls between the functions of the program,
+// unless it is a direct recursive call which passes a parameter decreased by subtraction
+// or division in the parameter's position.
+//
+func AnalyzeGasHazards(program *interpreter.Program) []GasHazard {
+	analyzer := &gasHazardAnalyzer{
+		programFunctions: collectProgramFunctions(program.Program),
+		elaboration:      program.Elaboration,
+	}
+
+	for _, function := range analyzer.functions {
+		analyzer.analyzeLoops(function)
+		analyzer.collectCalls(function)
+	}
+
+	analyzer.analyzeRecursion()
+
+	sort.SliceStable(analyzer.hazards, func(i, j int) bool {
+		return analyzer.hazards[i].StartPos.Compare(analyzer.hazards[j].StartPos) < 0
+	})
+
+	return analyzer.hazards
+}
+
+type analyzedCall struct {
+	callee     string
+	invocation *ast.InvocationExpression
+}
+
+type gasHazardAnalyzer struct {
+	*programFunctions
+	elaboration *sema.Elaboration
+	hazards     []GasHazard
+}
+
+// analyzeLoops reports the loops of the given function which depend on
+// the size of a collection that grows with state.
+//
+// sources maps the names of parameters, constants, and variables
+// to the expressions which determine their sizes.
+//
+func (a *gasHazardAnalyzer) analyzeLoops(function *analyzedFunction) {
+	sources := map[string]string{}
+
+	for _, name := range function.parameterNames() {
+		sources[name] = name
+	}
+
+	ast.Inspect(function.block, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.VariableDeclaration:
+			name := element.Identifier.Identifier
+			if source := a.source(element.Value, sources); source != "" {
+				sources[name] = source
+			} else {
+				// The declaration may shadow a parameter
+				delete(sources, name)
+			}
+
+		case *ast.ForStatement:
+			source := a.source(element.Value, sources)
+			if source == "" {
+				// The loop variable may shadow a parameter
+				delete(sources, element.Identifier.Identifier)
+				break
+			}
+
+			// The elements of the collection may be collections themselves
+			sources[element.Identifier.Identifier] = source
+
+			a.report(GasHazard{
+				Kind:     GasHazardUnboundedLoop,
+				Function: function.name,
+				Source:   source,
+				Range:    ast.NewRangeFromPositioned(element),
+			})
+
+		case *ast.WhileStatement:
+			source := a.lengthSource(element.Test, sources)
+			if source == "" {
+				break
+			}
+
+			a.report(GasHazard{
+				Kind:     GasHazardUnboundedLoop,
+				Function: function.name,
+				Source:   source,
+				Range:    ast.NewRangeFromPositioned(element),
+			})
+		}
+
+		return true
+	})
+}
+
+// lengthSource returns the collection whose length the given loop condition depends on,
+// or empty if the condition does not depend on the length of a collection that grows with state.
+//
+func (a *gasHazardAnalyzer) lengthSource(test ast.Expression, sources map[string]string) string {
+	var result string
+
+	ast.Inspect(test, func(element ast.Element) bool {
+		if result != "" {
+			return false
+		}
+
+		memberExpression, ok := element.(*ast.MemberExpression)
+		if !ok || memberExpression.Identifier.Identifier != "length" {
+			return true
+		}
+
+		result = a.source(memberExpression.Expression, sources)
+		return true
+	})
+
+	return result
+}
+
+// source returns the expression which determines the size of the collection
+// the given expression evaluates to, if that size is not bounded statically,
+// i.e. if it grows with state, or is controlled by the caller.
+//
+// It returns empty if the size is bounded.
+//
+func (a *gasHazardAnalyzer) source(expression ast.Expression, sources map[string]string) string {
+	switch expression := expression.(type) {
+	case *ast.IdentifierExpression:
+		return sources[expression.Identifier.Identifier]
+
+	case *ast.MemberExpression:
+		memberInfo, ok := a.elaboration.MemberExpressionMemberInfos[expression]
+		if !ok {
+			return ""
+		}
+
+		if _, ok := memberInfo.Member.TypeAnnotation.Type.(*sema.ConstantSizedType); ok {
+			return ""
+		}
+
+		// Fields of composites are state, and may grow over time.
+		// Other members, like the keys of a dictionary,
+		// are as large as the accessed value
+
+		if isCompositeOrInterfaceType(unwrapAccessedType(memberInfo.AccessedType)) {
+			return expression.String()
+		}
+
+		if source := a.source(expression.Expression, sources); source != "" {
+			return expression.String()
+		}
+
+	case *ast.InvocationExpression:
+		returnType := a.elaboration.InvocationExpressionReturnTypes[expression]
+		if _, ok := returnType.(*sema.ConstantSizedType); ok {
+			return ""
+		}
+
+		if memberExpression, ok := expression.InvokedExpression.(*ast.MemberExpression); ok {
+			if source := a.source(memberExpression, sources); source != "" {
+				return expression.String()
+			}
+		}
+
+		for _, argument := range expression.Arguments {
+			if source := a.source(argument.Expression, sources); source != "" {
+				return source
+			}
+		}
+
+	case *ast.IndexExpression:
+		return a.source(expression.TargetExpression, sources)
+
+	case *ast.ReferenceExpression:
+		return a.source(expression.Expression, sources)
+
+	case *ast.CastingExpression:
+		return a.source(expression.Expression, sources)
+
+	case *ast.ForceExpression:
+		return a.source(expression.Expression, sources)
+
+	case *ast.BinaryExpression:
+		if source := a.source(expression.Left, sources); source != "" {
+			return source
+		}
+		return a.source(expression.Right, sources)
+
+	case *ast.ConditionalExpression:
+		if source := a.source(expression.Then, sources); source != "" {
+			return source
+		}
+		return a.source(expression.Else, sources)
+	}
+
+	return ""
+}
+
+func isCompositeOrInterfaceType(ty sema.Type) bool {
+	switch ty.(type) {
+	case *sema.CompositeType, *sema.InterfaceType, *sema.RestrictedType:
+		return true
+	}
+	return false
+}
+
+// collectCalls collects the calls of the given function to the functions of the program.
+//
+// Calls are resolved by name: identifiers refer to global functions,
+// `self.f` refers to the function `f` of the enclosing composite,
+// and `C.f` refers to the function `f` of the composite `C`.
+//
+func (a *gasHazardAnalyzer) collectCalls(function *analyzedFunction) {
+	ast.Inspect(function.block, func(element ast.Element) bool {
+		invocation, ok := element.(*ast.InvocationExpression)
+		if !ok {
+			return true
+		}
+
+		var callee string
+
+		switch invoked := invocation.InvokedExpression.(type) {
+		case *ast.IdentifierExpression:
+			callee = invoked.Identifier.Identifier
+
+		case *ast.MemberExpression:
+			target, ok := invoked.Expression.(*ast.IdentifierExpression)
+			if !ok {
+				break
+			}
+
+			name := invoked.Identifier.Identifier
+
+			if target.Identifier.Identifier == sema.SelfIdentifier {
+				callee = qualifiedName(function.composite, name)
+			} else if _, ok := a.compositeNames[target.Identifier.Identifier]; ok {
+				callee = qualifiedName(target.Identifier.Identifier, name)
+			}
+		}
+
+		if _, ok := a.functionsByName[callee]; ok {
+			function.calls = append(function.calls, analyzedCall{
+				callee:     callee,
+				invocation: invocation,
+			})
+		}
+
+		return true
+	})
+}
+
+// analyzeRecursion reports the calls which are part of a cycle of calls.
+//
+func (a *gasHazardAnalyzer) analyzeRecursion() {
+	for _, function := range a.functions {
+		for _, call := range function.calls {
+			if !a.reaches(call.callee, function.name) {
+				continue
+			}
+
+			if call.callee == function.name &&
+				hasDecreasingArgument(call.invocation, function.parameters) {
+
+				continue
+			}
+
+			a.report(GasHazard{
+				Kind:     GasHazardUnboundedRecursion,
+				Function: function.name,
+				Source:   call.callee,
+				Range:    ast.NewRangeFromPositioned(call.invocation),
+			})
+		}
+	}
+}
+
+// reaches returns true if the function with the given name
+// directly or indirectly calls the target function.
+//
+func (a *gasHazardAnalyzer) reaches(name, target string) bool {
+	visited := map[string]struct{}{}
+
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		if name == target {
+			return true
+		}
+		if _, ok := visited[name]; ok {
+			return false
+		}
+		visited[name] = struct{}{}
+
+		for _, call := range a.functionsByName[name].calls {
+			if visit(call.callee) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return visit(name)
+}
+
+// hasDecreasingArgument returns true if the given recursive invocation passes
+// a parameter decreased by subtraction or division in that parameter's position,
+// e.g. `n - 1` for the parameter `n`.
+//
+func hasDecreasingArgument(invocation *ast.InvocationExpression, parameterList *ast.ParameterList) bool {
+	if parameterList == nil {
+		return false
+	}
+
+	parameters := parameterList.Parameters
+
+	for i, argument := range invocation.Arguments {
+		if i >= len(parameters) {
+			break
+		}
+
+		binaryExpression, ok := argument.Expression.(*ast.BinaryExpression)
+		if !ok {
+			continue
+		}
+
+		switch binaryExpression.Operation {
+		case ast.OperationMinus, ast.OperationDiv:
+		default:
+			continue
+		}
+
+		identifierExpression, ok := binaryExpression.Left.(*ast.IdentifierExpression)
+		if ok && identifierExpression.Identifier.Identifier == parameters[i].Identifier.Identifier {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *gasHazardAnalyzer) report(hazard GasHazard) {
+	a.hazards = append(a.hazards, hazard)
+}
@@ -0,0 +1,231 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestAnalyzeGasHazards(t *testing.T) {
+
+	t.Parallel()
+
+	analyze := func(t *testing.T, code string) []string {
+		runtime := newTestInterpreterRuntime()
+
+		program, err := runtime.ParseAndCheckProgram(
+			[]byte(code),
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		var hazards []string
+		for _, hazard := range AnalyzeGasHazards(program) {
+			hazards = append(hazards, hazard.String())
+		}
+		return hazards
+	}
+
+	t.Run("bounded loops", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub fun test(): Int {
+              var sum = 0
+              for x in [1, 2, 3] {
+                  sum = sum + x
+              }
+              let fixed: [Int; 2] = [4, 5]
+              for y in fixed {
+                  sum = sum + y
+              }
+              var i = 0
+              while i < 10 {
+                  i = i + 1
+              }
+              return sum
+          }
+        `)
+
+		assert.Empty(t, hazards)
+	})
+
+	t.Run("loops over state", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub resource Collection {
+              pub let ids: [UInt64]
+              pub let owners: {UInt64: Address}
+
+              init() {
+                  self.ids = []
+                  self.owners = {}
+              }
+
+              pub fun contains(_ id: UInt64): Bool {
+                  for existing in self.ids {
+                      if existing == id {
+                          return true
+                      }
+                  }
+                  return false
+              }
+
+              pub fun count(): Int {
+                  let keys = self.owners.keys
+                  var count = 0
+                  var i = 0
+                  while i < keys.length {
+                      count = count + 1
+                      i = i + 1
+                  }
+                  return count
+              }
+          }
+
+          pub fun total(collection: &Collection): Int {
+              var total = 0
+              for id in collection.ids {
+                  total = total + 1
+              }
+              return total
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"12:18: UnboundedLoop: loop in `Collection.contains` depends on the size of `self.ids`",
+				"24:18: UnboundedLoop: loop in `Collection.count` depends on the size of `self.owners.keys`",
+				"34:14: UnboundedLoop: loop in `total` depends on the size of `collection.ids`",
+			},
+			hazards,
+		)
+	})
+
+	t.Run("loops over parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          transaction(recipients: [Address]) {
+              execute {
+                  for recipient in recipients {
+                      log(recipient)
+                  }
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"4:18: UnboundedLoop: loop in `execute` depends on the size of `recipients`",
+			},
+			hazards,
+		)
+	})
+
+	t.Run("shadowed parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub fun test(values: [Int]) {
+              let values = [1, 2]
+              for value in values {
+                  log(value)
+              }
+          }
+        `)
+
+		assert.Empty(t, hazards)
+	})
+
+	t.Run("decreasing recursion", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub fun factorial(_ n: Int): Int {
+              if n <= 1 {
+                  return 1
+              }
+              return n * factorial(n - 1)
+          }
+
+          pub fun log2(_ n: Int): Int {
+              if n <= 1 {
+                  return 0
+              }
+              return 1 + log2(n / 2)
+          }
+        `)
+
+		assert.Empty(t, hazards)
+	})
+
+	t.Run("recursion without decreasing argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub contract C {
+              pub fun walk(_ n: Int): Int {
+                  if n > 100 {
+                      return n
+                  }
+                  return self.walk(n + 1)
+              }
+          }
+
+          pub fun isEven(_ n: Int): Bool {
+              if n == 0 {
+                  return true
+              }
+              return isOdd(n - 1)
+          }
+
+          pub fun isOdd(_ n: Int): Bool {
+              if n == 0 {
+                  return false
+              }
+              return isEven(n - 1)
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"7:25: UnboundedRecursion: `C.walk` calls `C.walk` recursively without a decreasing argument",
+				"15:21: UnboundedRecursion: `isEven` calls `isOdd` recursively without a decreasing argument",
+				"22:21: UnboundedRecursion: `isOdd` calls `isEven` recursively without a decreasing argument",
+			},
+			hazards,
+		)
+	})
+}
@@ -47,6 +47,9 @@ type Interface interface {
 	//
 	// This is not a caching function!
 	//
+	// Programs which were checked with a different language version than the current one
+	// are checked again, and set again using SetProgram.
+	//
 	GetProgram(Location) (*interpreter.Program, error)
 	// SetProgram sets the program for the given location.
 	SetProgram(Location, *interpreter.Program) error
@@ -94,6 +97,11 @@ type Interface interface {
 	GetCurrentBlockHeight() (uint64, error)
 	// GetBlockAtHeight returns the block at the given height.
 	GetBlockAtHeight(height uint64) (block Block, exists bool, err error)
+	// GetBlockHeaderAtHeight returns the header of the block at the given height.
+	GetBlockHeaderAtHeight(height uint64) (header BlockHeader, exists bool, err error)
+	// GetBlockIDsInRange returns the IDs of the blocks from the given start height
+	// to the given end height (inclusive), ordered by height.
+	GetBlockIDsInRange(startHeight, endHeight uint64) ([]BlockHash, error)
 	// UnsafeRandom returns a random uint64, where the process of random number derivation is not cryptographically
 	// secure.
 	UnsafeRandom() (uint64, error)
@@ -135,6 +143,216 @@ type Interface interface {
 	ResourceOwnerChanged(resource *interpreter.CompositeValue, oldOwner common.Address, newOwner common.Address)
 }
 
+// ValueKey identifies a value in the storage, owned by the given account.
+//
+type ValueKey struct {
+	Owner []byte
+	Key   []byte
+}
+
+// ValueWrite is a write of a value for a key in the storage, owned by the given account.
+//
+type ValueWrite struct {
+	Owner []byte
+	Key   []byte
+	Value []byte
+}
+
+// BatchValueInterface is an optional interface which an Interface may implement
+// to get and set multiple values of the storage in a single call.
+//
+// The storage uses it when it flushes or prefetches many values at once.
+// Before a transaction is executed, only the storage maps of the authorizers
+// which the transaction statically refers to through path literals are prefetched.
+// If the Interface does not implement it, the storage falls back to
+// calling GetValue and SetValue for each value.
+//
+type BatchValueInterface interface {
+	// GetValues gets the values for the given keys in the storage.
+	// The returned values must be in the same order as the given keys.
+	GetValues(keys []ValueKey) (values [][]byte, err error)
+	// SetValues sets the values for the given keys in the storage.
+	// The writes must be performed in the given order.
+	SetValues(writes []ValueWrite) (err error)
+}
+
+// BatchPreloadInterface is an optional interface which an Interface may implement
+// to be informed about the values which will likely be read during an execution.
+//
+// Before a transaction is executed, the runtime determines the values
+// which the transaction statically refers to, e.g. through path literals,
+// and passes them to BatchPreloadValues.
+//
+type BatchPreloadInterface interface {
+	// BatchPreloadValues preloads the values for the given keys in the storage,
+	// e.g. in parallel, so that subsequent reads of them are faster.
+	// Preloading must not have any observable effect.
+	BatchPreloadValues(keys []ValueKey) (err error)
+}
+
+// ElaborationArtifactInterface is an optional interface which an Interface may implement
+// to provide elaborations for programs which were checked ahead of time,
+// e.g. elaborations distributed together with the code of contracts.
+//
+// Elaborations are encoded using EncodeElaborationArtifact.
+// If an encoded elaboration is provided and compatible, the program is not checked.
+// If it is incompatible, e.g. because it was encoded by a different version of Cadence,
+// the program is checked as usual.
+//
+type ElaborationArtifactInterface interface {
+	// GetElaborationArtifact returns the encoded elaboration for the program at the given location,
+	// with the given SHA3-256 hash of its code, or nil if there is none.
+	GetElaborationArtifact(location Location, codeHash [32]byte) ([]byte, error)
+}
+
+// Hasher incrementally hashes data.
+//
+type Hasher interface {
+	// Update adds the given data to the hashed data.
+	Update(data []byte) error
+	// Finalize returns the hash of all added data.
+	Finalize() ([]byte, error)
+}
+
+// IncrementalHashInterface is an optional interface which an Interface may implement
+// to hash data incrementally, i.e. to support hashers created by `HashAlgorithm.newHasher`.
+//
+// If the Interface does not implement it, the data passed to a hasher is buffered,
+// and hashed using Hash when the hasher is finalized.
+//
+type IncrementalHashInterface interface {
+	// NewHasher returns a new hasher for the given hash algorithm.
+	NewHasher(hashAlgorithm HashAlgorithm) (Hasher, error)
+}
+
+// SignatureSchemeInterface is an optional interface which an Interface may implement
+// to provide signature schemes which are not part of the SignatureAlgorithm enum,
+// e.g. to experiment with new curves or post-quantum signature schemes.
+//
+// The signature schemes are identified by name,
+// and can be used through `Crypto.verifySignatureWithScheme`.
+// If the Interface does not implement it, no such signature schemes are supported.
+//
+type SignatureSchemeInterface interface {
+	// IsSignatureSchemeSupported returns true if the signature scheme with the given name is supported.
+	IsSignatureSchemeSupported(scheme string) (bool, error)
+	// VerifySignatureWithScheme returns true if the given signature was produced by signing the given tag + data
+	// using the given public key and the signature scheme with the given name.
+	// It is only called for supported signature schemes.
+	VerifySignatureWithScheme(
+		scheme string,
+		signature []byte,
+		tag string,
+		signedData []byte,
+		publicKey []byte,
+	) (bool, error)
+}
+
+// ScheduledCallback is a callback which is scheduled to be invoked at or after a block height.
+//
+// The callback is invoked by calling the function with the given name
+// on the value the handler capability refers to, with the ID of the callback as the only argument.
+//
+type ScheduledCallback struct {
+	ID           uint64
+	Handler      cadence.Capability
+	FunctionName string
+	BlockHeight  uint64
+	// Fee is the fee paid for the execution of the callback, as a UFix64 fixed-point number
+	Fee uint64
+}
+
+// ScheduledCallbackInterface is an optional interface which an Interface may implement
+// to allow programs to schedule callbacks using `scheduleCallback`.
+//
+// The embedder is responsible for charging the fee of the callback,
+// and for invoking due callbacks using Runtime.ExecuteScheduledCallback.
+// If the Interface does not implement it, callbacks cannot be scheduled.
+//
+type ScheduledCallbackInterface interface {
+	// ScheduleCallback enqueues the given callback and returns its ID.
+	// The ID field of the given callback is not set.
+	ScheduleCallback(callback ScheduledCallback) (id uint64, err error)
+}
+
+// StorageReleaseInterface is an optional interface which an Interface may implement
+// to be informed about the storage which was released during an execution,
+// e.g. to refund storage fees.
+//
+// Storage is released when slabs are removed, e.g. when a value is removed from storage,
+// or when a stored array or dictionary shrinks.
+//
+type StorageReleaseInterface interface {
+	// StorageReleased reports that the given number of bytes of the storage
+	// owned by the given account were released.
+	// It is called for each account when the changes to the storage are committed.
+	StorageReleased(address Address, bytes uint64) error
+}
+
+// StorageUsageDeltaInterface is an optional interface which an Interface may implement
+// to be informed about the change of the storage used by each account during an execution,
+// e.g. to calculate storage fees without querying the storage used by each touched account.
+//
+// The storage used by a register is the length of its key and its value.
+//
+type StorageUsageDeltaInterface interface {
+	// StorageUsageChanged reports that the storage used by the given account
+	// changed by the given number of bytes.
+	// It is called for each account whose storage used changed,
+	// when the changes to the storage are committed.
+	StorageUsageChanged(address Address, delta int64) error
+}
+
+// ComputationMeterInterface is an optional interface which an Interface may implement
+// to meter the computation performed by programs, e.g. to charge fees per kind of computation.
+//
+// All computation is reported, see interpreter.ComputationMeter.
+// If MeterComputation returns an error, the execution is aborted with the error.
+// The computation limit, see GetComputationLimit, is enforced independently.
+//
+// If the Interface does not implement it, only the computation limit is enforced.
+//
+type ComputationMeterInterface interface {
+	MeterComputation(kind common.ComputationKind, intensity uint) error
+}
+
+// MemoryGauge is an optional interface which an Interface may implement
+// to meter the memory allocated by programs, e.g. to charge for memory.
+//
+// Allocations of strings, arrays, dictionaries, composite values, and arbitrary-precision integers
+// are reported, see common.MemoryKind.
+// If MeterMemory returns an error, the execution is aborted with the error.
+//
+type MemoryGauge = common.MemoryGauge
+
+// MemoryLimitInterface is an optional interface which an Interface may implement
+// to limit the memory allocated by programs.
+//
+// If the memory usage reported to the memory gauge exceeds the limit,
+// the execution is aborted with a MemoryLimitExceededError.
+// A limit of 0 means there is no limit.
+// If the Interface does not implement it, memory is not limited.
+//
+type MemoryLimitInterface interface {
+	GetMemoryLimit() uint64
+}
+
+// EncodedEventInterface is an optional interface which an Interface may implement
+// to receive the payloads of emitted events already encoded,
+// e.g. to pass them on to external consumers without re-encoding them.
+//
+// If the Interface implements it, EmitEncodedEvent is called instead of EmitEvent.
+// The payload is encoded using the encoder returned by EventEncoder,
+// e.g. JSONCDCEventEncoder or CCFEventEncoder.
+//
+type EncodedEventInterface interface {
+	// EventEncoder returns the encoder used to encode the payloads of emitted events.
+	EventEncoder() EventEncoder
+	// EmitEncodedEvent is called when an event is emitted by the runtime,
+	// with the event and its encoded payload.
+	EmitEncodedEvent(event cadence.Event, payload []byte) error
+}
+
 type Metrics interface {
 	ProgramParsed(location common.Location, duration time.Duration)
 	ProgramChecked(location common.Location, duration time.Duration)
@@ -35,6 +35,7 @@ var authAccountFieldNames = []string{
 	sema.AuthAccountAddressField,
 	sema.AuthAccountContractsField,
 	sema.AuthAccountKeysField,
+	sema.AuthAccountCapabilitiesField,
 }
 
 // NewAuthAccountValue constructs an auth account value.
@@ -63,8 +64,15 @@ func NewAuthAccountValue(
 
 	var contracts Value
 	var keys Value
+	var capabilities Value
 
 	computedFields := map[string]ComputedField{
+		sema.AuthAccountCapabilitiesField: func(inter *Interpreter, _ func() LocationRange) Value {
+			if capabilities == nil {
+				capabilities = inter.authAccountCapabilitiesValue(address)
+			}
+			return capabilities
+		},
 		sema.AuthAccountContractsField: func(_ *Interpreter, _ func() LocationRange) Value {
 			if contracts == nil {
 				contracts = contractsConstructor()
@@ -107,6 +115,9 @@ func NewAuthAccountValue(
 		sema.AuthAccountLinkField: func(inter *Interpreter, _ func() LocationRange) Value {
 			return inter.authAccountLinkFunction(address)
 		},
+		sema.AuthAccountLinkAccountField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.authAccountLinkAccountFunction(address)
+		},
 		sema.AuthAccountUnlinkField: func(inter *Interpreter, _ func() LocationRange) Value {
 			return inter.authAccountUnlinkFunction(address)
 		},
@@ -146,6 +157,7 @@ var publicAccountFieldNames = []string{
 	sema.PublicAccountAddressField,
 	sema.PublicAccountContractsField,
 	sema.PublicAccountKeysField,
+	sema.PublicAccountCapabilitiesField,
 }
 
 // NewPublicAccountValue constructs a public account value.
@@ -170,8 +182,15 @@ func NewPublicAccountValue(
 
 	var keys Value
 	var contracts Value
+	var capabilities Value
 
 	computedFields := map[string]ComputedField{
+		sema.PublicAccountCapabilitiesField: func(inter *Interpreter, _ func() LocationRange) Value {
+			if capabilities == nil {
+				capabilities = inter.publicAccountCapabilitiesValue(address)
+			}
+			return capabilities
+		},
 		sema.PublicAccountKeysField: func(_ *Interpreter, _ func() LocationRange) Value {
 			if keys == nil {
 				keys = keysConstructor()
@@ -0,0 +1,865 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// CapabilityControllerStorageDomain is the storage domain
+// which stores the capability controllers of an account, keyed by capability ID.
+//
+const CapabilityControllerStorageDomain = "cap_con"
+
+// CapabilityIDStorageDomain is the storage domain
+// which stores the counter used to generate the capability IDs of an account.
+//
+const CapabilityIDStorageDomain = "cap_id"
+
+const capabilityIDCounterStorageKey = "counter"
+
+// PathCapabilityStorageDomain is the storage domain
+// which stores the IDs of the capability controllers of an account,
+// keyed by the identifier of their target storage path.
+//
+const PathCapabilityStorageDomain = "path_cap"
+
+// capabilityIDSetStaticType is the type of the set of capability IDs
+// stored for each target path in the PathCapabilityStorageDomain.
+//
+var capabilityIDSetStaticType = DictionaryStaticType{
+	KeyType:   PrimitiveStaticTypeUInt64,
+	ValueType: PrimitiveStaticTypeBool,
+}
+
+// AuthAccountCapabilities
+
+var authAccountCapabilitiesTypeID = sema.AuthAccountCapabilitiesType.ID()
+var authAccountCapabilitiesStaticType StaticType = PrimitiveStaticTypeAuthAccountCapabilities
+var authAccountCapabilitiesDynamicType DynamicType = CompositeDynamicType{
+	StaticType: sema.AuthAccountCapabilitiesType,
+}
+var authAccountCapabilitiesFieldNames = []string{
+	sema.AuthAccountCapabilitiesTypeStorageField,
+}
+
+// NewAuthAccountCapabilitiesValue constructs an AuthAccount.Capabilities value.
+func NewAuthAccountCapabilitiesValue(
+	address AddressValue,
+	getFunction FunctionValue,
+	borrowFunction FunctionValue,
+	publishFunction FunctionValue,
+	unpublishFunction FunctionValue,
+	storageCapabilitiesConstructor func() Value,
+) Value {
+
+	fields := map[string]Value{
+		sema.AccountCapabilitiesTypeGetFunctionName:           getFunction,
+		sema.AccountCapabilitiesTypeBorrowFunctionName:        borrowFunction,
+		sema.AuthAccountCapabilitiesTypePublishFunctionName:   publishFunction,
+		sema.AuthAccountCapabilitiesTypeUnpublishFunctionName: unpublishFunction,
+	}
+
+	var storageCapabilities Value
+
+	computedFields := map[string]ComputedField{
+		sema.AuthAccountCapabilitiesTypeStorageField: func(_ *Interpreter, _ func() LocationRange) Value {
+			if storageCapabilities == nil {
+				storageCapabilities = storageCapabilitiesConstructor()
+			}
+			return storageCapabilities
+		},
+	}
+
+	var str string
+	stringer := func(_ SeenReferences) string {
+		if str == "" {
+			str = fmt.Sprintf("AuthAccount.Capabilities(%s)", address)
+		}
+		return str
+	}
+
+	return NewSimpleCompositeValue(
+		authAccountCapabilitiesTypeID,
+		authAccountCapabilitiesStaticType,
+		authAccountCapabilitiesDynamicType,
+		authAccountCapabilitiesFieldNames,
+		fields,
+		computedFields,
+		nil,
+		stringer,
+	)
+}
+
+// AuthAccountStorageCapabilities
+
+var authAccountStorageCapabilitiesTypeID = sema.AuthAccountStorageCapabilitiesType.ID()
+var authAccountStorageCapabilitiesStaticType StaticType = PrimitiveStaticTypeAuthAccountStorageCapabilities
+var authAccountStorageCapabilitiesDynamicType DynamicType = CompositeDynamicType{
+	StaticType: sema.AuthAccountStorageCapabilitiesType,
+}
+
+// NewAuthAccountStorageCapabilitiesValue constructs an AuthAccount.StorageCapabilities value.
+func NewAuthAccountStorageCapabilitiesValue(
+	address AddressValue,
+	issueFunction FunctionValue,
+	getControllerFunction FunctionValue,
+	getControllersFunction FunctionValue,
+) Value {
+
+	fields := map[string]Value{
+		sema.AuthAccountStorageCapabilitiesTypeIssueFunctionName:          issueFunction,
+		sema.AuthAccountStorageCapabilitiesTypeGetControllerFunctionName:  getControllerFunction,
+		sema.AuthAccountStorageCapabilitiesTypeGetControllersFunctionName: getControllersFunction,
+	}
+
+	var str string
+	stringer := func(_ SeenReferences) string {
+		if str == "" {
+			str = fmt.Sprintf("AuthAccount.StorageCapabilities(%s)", address)
+		}
+		return str
+	}
+
+	return NewSimpleCompositeValue(
+		authAccountStorageCapabilitiesTypeID,
+		authAccountStorageCapabilitiesStaticType,
+		authAccountStorageCapabilitiesDynamicType,
+		nil,
+		fields,
+		nil,
+		nil,
+		stringer,
+	)
+}
+
+// PublicAccountCapabilities
+
+var publicAccountCapabilitiesTypeID = sema.PublicAccountCapabilitiesType.ID()
+var publicAccountCapabilitiesStaticType StaticType = PrimitiveStaticTypePublicAccountCapabilities
+var publicAccountCapabilitiesDynamicType DynamicType = CompositeDynamicType{
+	StaticType: sema.PublicAccountCapabilitiesType,
+}
+
+// NewPublicAccountCapabilitiesValue constructs a PublicAccount.Capabilities value.
+func NewPublicAccountCapabilitiesValue(
+	address AddressValue,
+	getFunction FunctionValue,
+	borrowFunction FunctionValue,
+) Value {
+
+	fields := map[string]Value{
+		sema.AccountCapabilitiesTypeGetFunctionName:    getFunction,
+		sema.AccountCapabilitiesTypeBorrowFunctionName: borrowFunction,
+	}
+
+	var str string
+	stringer := func(_ SeenReferences) string {
+		if str == "" {
+			str = fmt.Sprintf("PublicAccount.Capabilities(%s)", address)
+		}
+		return str
+	}
+
+	return NewSimpleCompositeValue(
+		publicAccountCapabilitiesTypeID,
+		publicAccountCapabilitiesStaticType,
+		publicAccountCapabilitiesDynamicType,
+		nil,
+		fields,
+		nil,
+		nil,
+		stringer,
+	)
+}
+
+// StorageCapabilityController
+
+var storageCapabilityControllerTypeID = sema.StorageCapabilityControllerType.ID()
+var storageCapabilityControllerStaticType StaticType = PrimitiveStaticTypeStorageCapabilityController
+var storageCapabilityControllerDynamicType DynamicType = CompositeDynamicType{
+	StaticType: sema.StorageCapabilityControllerType,
+}
+var storageCapabilityControllerFieldNames = []string{
+	sema.StorageCapabilityControllerTypeCapabilityIDField,
+	sema.StorageCapabilityControllerTypeBorrowTypeField,
+}
+
+// NewStorageCapabilityControllerValue constructs the first-class StorageCapabilityController value
+// for the given stored capability controller.
+func NewStorageCapabilityControllerValue(
+	controller StorageCapabilityControllerValue,
+	targetFunction FunctionValue,
+	revokeFunction FunctionValue,
+) Value {
+
+	fields := map[string]Value{
+		sema.StorageCapabilityControllerTypeCapabilityIDField: controller.CapabilityID,
+		sema.StorageCapabilityControllerTypeBorrowTypeField: TypeValue{
+			Type: controller.BorrowType,
+		},
+		sema.StorageCapabilityControllerTypeTargetFunctionName: targetFunction,
+		sema.StorageCapabilityControllerTypeRevokeFunctionName: revokeFunction,
+	}
+
+	return NewSimpleCompositeValue(
+		storageCapabilityControllerTypeID,
+		storageCapabilityControllerStaticType,
+		storageCapabilityControllerDynamicType,
+		storageCapabilityControllerFieldNames,
+		fields,
+		nil,
+		nil,
+		controller.RecursiveString,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesValue(addressValue AddressValue) Value {
+	return NewAuthAccountCapabilitiesValue(
+		addressValue,
+		interpreter.accountCapabilitiesGetFunction(addressValue),
+		interpreter.accountCapabilitiesBorrowFunction(addressValue),
+		interpreter.authAccountCapabilitiesPublishFunction(addressValue),
+		interpreter.authAccountCapabilitiesUnpublishFunction(addressValue),
+		func() Value {
+			return NewAuthAccountStorageCapabilitiesValue(
+				addressValue,
+				interpreter.authAccountStorageCapabilitiesIssueFunction(addressValue),
+				interpreter.authAccountStorageCapabilitiesGetControllerFunction(addressValue),
+				interpreter.authAccountStorageCapabilitiesGetControllersFunction(addressValue),
+			)
+		},
+	)
+}
+
+func (interpreter *Interpreter) publicAccountCapabilitiesValue(addressValue AddressValue) Value {
+	return NewPublicAccountCapabilitiesValue(
+		addressValue,
+		interpreter.accountCapabilitiesGetFunction(addressValue),
+		interpreter.accountCapabilitiesBorrowFunction(addressValue),
+	)
+}
+
+func capabilityIDStorageKey(capabilityID UInt64Value) string {
+	return strconv.FormatUint(uint64(capabilityID), 10)
+}
+
+// generateCapabilityID returns a new capability ID for the given account.
+// Capability IDs start at 1, as the ID 0 denotes capabilities created by linking.
+//
+func (interpreter *Interpreter) generateCapabilityID(address common.Address) UInt64Value {
+	var capabilityID UInt64Value
+
+	counter := interpreter.ReadStored(address, CapabilityIDStorageDomain, capabilityIDCounterStorageKey)
+	if counter != nil {
+		var ok bool
+		capabilityID, ok = counter.(UInt64Value)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+	}
+
+	capabilityID++
+
+	interpreter.writeStored(
+		address,
+		CapabilityIDStorageDomain,
+		capabilityIDCounterStorageKey,
+		capabilityID,
+	)
+
+	return capabilityID
+}
+
+// readStorageCapabilityController returns the capability controller
+// for the given capability ID in the given account, if any.
+//
+func (interpreter *Interpreter) readStorageCapabilityController(
+	address common.Address,
+	capabilityID UInt64Value,
+) (
+	StorageCapabilityControllerValue,
+	bool,
+) {
+	value := interpreter.ReadStored(
+		address,
+		CapabilityControllerStorageDomain,
+		capabilityIDStorageKey(capabilityID),
+	)
+	if value == nil {
+		return StorageCapabilityControllerValue{}, false
+	}
+
+	controller, ok := value.(StorageCapabilityControllerValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return controller, true
+}
+
+// readPathCapabilityIDs returns the set of IDs of the capability controllers
+// which target the given storage path, if any.
+//
+func (interpreter *Interpreter) readPathCapabilityIDs(
+	address common.Address,
+	targetPath PathValue,
+) *DictionaryValue {
+	value := interpreter.ReadStored(address, PathCapabilityStorageDomain, targetPath.Identifier)
+	if value == nil {
+		return nil
+	}
+
+	capabilityIDs, ok := value.(*DictionaryValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return capabilityIDs
+}
+
+// recordPathCapabilityController records that the capability controller with the given ID
+// targets the given storage path.
+//
+func (interpreter *Interpreter) recordPathCapabilityController(
+	address common.Address,
+	targetPath PathValue,
+	capabilityID UInt64Value,
+	getLocationRange func() LocationRange,
+) {
+	capabilityIDs := interpreter.readPathCapabilityIDs(address, targetPath)
+	if capabilityIDs == nil {
+		capabilityIDs = NewDictionaryValueWithAddress(
+			interpreter,
+			capabilityIDSetStaticType,
+			address,
+		)
+		interpreter.writeStored(
+			address,
+			PathCapabilityStorageDomain,
+			targetPath.Identifier,
+			capabilityIDs,
+		)
+	}
+
+	capabilityIDs.Insert(interpreter, getLocationRange, capabilityID, BoolValue(true))
+}
+
+// removePathCapabilityController removes the record that the capability controller
+// with the given ID targets the given storage path.
+//
+func (interpreter *Interpreter) removePathCapabilityController(
+	address common.Address,
+	targetPath PathValue,
+	capabilityID UInt64Value,
+	getLocationRange func() LocationRange,
+) {
+	capabilityIDs := interpreter.readPathCapabilityIDs(address, targetPath)
+	if capabilityIDs == nil {
+		return
+	}
+
+	capabilityIDs.Remove(interpreter, getLocationRange, capabilityID)
+
+	if capabilityIDs.Count() == 0 {
+		interpreter.writeStored(address, PathCapabilityStorageDomain, targetPath.Identifier, nil)
+	}
+}
+
+// getStorageCapabilityControllerTarget returns the target of the capability with the given ID,
+// or nil if the capability was revoked or cannot be borrowed with the wanted borrow type.
+//
+func (interpreter *Interpreter) getStorageCapabilityControllerTarget(
+	address common.Address,
+	capabilityID UInt64Value,
+	wantedBorrowType *sema.ReferenceType,
+) (
+	target CapabilityTarget,
+	authorized bool,
+) {
+	controller, ok := interpreter.readStorageCapabilityController(address, capabilityID)
+	if !ok {
+		return nil, false
+	}
+
+	allowedType := interpreter.MustConvertStaticToSemaType(controller.BorrowType)
+
+	if !sema.IsSubType(allowedType, wantedBorrowType) {
+		return nil, false
+	}
+
+	return PathCapabilityTarget(controller.TargetPath), wantedBorrowType.Authorized
+}
+
+func (interpreter *Interpreter) authAccountStorageCapabilitiesIssueFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			invocation.Interpreter.CheckWrite("issue capability", invocation.GetLocationRange)
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			borrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			targetPath, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			capabilityID := interpreter.generateCapabilityID(address)
+
+			borrowStaticType := ConvertSemaToStaticType(borrowType)
+
+			controller := StorageCapabilityControllerValue{
+				BorrowType:   borrowStaticType,
+				CapabilityID: capabilityID,
+				TargetPath:   targetPath,
+			}
+
+			interpreter.writeStored(
+				address,
+				CapabilityControllerStorageDomain,
+				capabilityIDStorageKey(capabilityID),
+				controller,
+			)
+
+			interpreter.recordPathCapabilityController(
+				address,
+				targetPath,
+				capabilityID,
+				invocation.GetLocationRange,
+			)
+
+			onCapabilityControllerIssued := interpreter.onCapabilityControllerIssued
+			if onCapabilityControllerIssued != nil {
+				onCapabilityControllerIssued(
+					interpreter,
+					invocation.GetLocationRange,
+					addressValue,
+					capabilityID,
+					borrowStaticType,
+					targetPath,
+				)
+			}
+
+			return &CapabilityValue{
+				Address:    addressValue,
+				Path:       EmptyPathValue,
+				BorrowType: borrowStaticType,
+				ID:         capabilityID,
+			}
+		},
+		sema.AuthAccountStorageCapabilitiesTypeIssueFunctionType,
+	)
+}
+
+// newStorageCapabilityControllerReference returns a reference
+// to a new first-class value for the given stored capability controller.
+//
+func (interpreter *Interpreter) newStorageCapabilityControllerReference(
+	addressValue AddressValue,
+	controller StorageCapabilityControllerValue,
+) *EphemeralReferenceValue {
+
+	address := addressValue.ToAddress()
+
+	targetFunction := NewHostFunctionValue(
+		func(_ Invocation) Value {
+			return controller.TargetPath
+		},
+		sema.StorageCapabilityControllerTypeTargetFunctionType,
+	)
+
+	revokeFunction := NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			invocation.Interpreter.CheckWrite("revoke capability", invocation.GetLocationRange)
+
+			capabilityID := controller.CapabilityID
+			key := capabilityIDStorageKey(capabilityID)
+
+			// Revoking an already revoked capability has no effect
+
+			if !interpreter.storedValueExists(address, CapabilityControllerStorageDomain, key) {
+				return VoidValue{}
+			}
+
+			interpreter.writeStored(address, CapabilityControllerStorageDomain, key, nil)
+
+			interpreter.removePathCapabilityController(
+				address,
+				controller.TargetPath,
+				capabilityID,
+				invocation.GetLocationRange,
+			)
+
+			onCapabilityControllerRevoked := interpreter.onCapabilityControllerRevoked
+			if onCapabilityControllerRevoked != nil {
+				onCapabilityControllerRevoked(
+					interpreter,
+					invocation.GetLocationRange,
+					addressValue,
+					capabilityID,
+				)
+			}
+
+			return VoidValue{}
+		},
+		sema.StorageCapabilityControllerTypeRevokeFunctionType,
+	)
+
+	return &EphemeralReferenceValue{
+		Value: NewStorageCapabilityControllerValue(
+			controller,
+			targetFunction,
+			revokeFunction,
+		),
+		BorrowedType: sema.StorageCapabilityControllerType,
+	}
+}
+
+func (interpreter *Interpreter) authAccountStorageCapabilitiesGetControllerFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			capabilityID, ok := invocation.Arguments[0].(UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			controller, ok := interpreter.readStorageCapabilityController(address, capabilityID)
+			if !ok {
+				return NilValue{}
+			}
+
+			return NewSomeValueNonCopying(
+				interpreter.newStorageCapabilityControllerReference(addressValue, controller),
+			)
+		},
+		sema.AuthAccountStorageCapabilitiesTypeGetControllerFunctionType,
+	)
+}
+
+var storageCapabilityControllerReferencesArrayStaticType = VariableSizedStaticType{
+	Type: ReferenceStaticType{
+		Type: PrimitiveStaticTypeStorageCapabilityController,
+	},
+}
+
+func (interpreter *Interpreter) authAccountStorageCapabilitiesGetControllersFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			targetPath, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var references []Value
+
+			// Only the controllers which target the path are read
+
+			capabilityIDs := interpreter.readPathCapabilityIDs(address, targetPath)
+			if capabilityIDs != nil {
+				ids := make([]UInt64Value, 0, capabilityIDs.Count())
+
+				capabilityIDs.IterateKeys(func(key Value) (resume bool) {
+					capabilityID, ok := key.(UInt64Value)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					ids = append(ids, capabilityID)
+
+					return true
+				})
+
+				// The set of IDs is not ordered, so sort the controllers by capability ID
+
+				sort.Slice(ids, func(i, j int) bool {
+					return ids[i] < ids[j]
+				})
+
+				references = make([]Value, 0, len(ids))
+
+				for _, capabilityID := range ids {
+					controller, ok := interpreter.readStorageCapabilityController(address, capabilityID)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					references = append(
+						references,
+						interpreter.newStorageCapabilityControllerReference(addressValue, controller),
+					)
+				}
+			}
+
+			return NewArrayValue(
+				interpreter,
+				storageCapabilityControllerReferencesArrayStaticType,
+				common.Address{},
+				references...,
+			)
+		},
+		sema.AuthAccountStorageCapabilitiesTypeGetControllersFunctionType,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesPublishFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			invocation.Interpreter.CheckWrite("publish capability", invocation.GetLocationRange)
+
+			capability, ok := invocation.Arguments[0].(*CapabilityValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			getLocationRange := invocation.GetLocationRange
+
+			if capability.Address != addressValue {
+				panic(CapabilityAddressPublishingError{
+					CapabilityAddress: capability.Address,
+					AccountAddress:    addressValue,
+					LocationRange:     getLocationRange(),
+				})
+			}
+
+			domain := path.Domain.Identifier()
+			identifier := path.Identifier
+
+			// Prevent an overwrite
+
+			if interpreter.storedValueExists(address, domain, identifier) {
+				panic(OverwriteError{
+					Address:       addressValue,
+					Path:          path,
+					LocationRange: getLocationRange(),
+				})
+			}
+
+			value := capability.Transfer(
+				interpreter,
+				getLocationRange,
+				atree.Address(address),
+				true,
+				nil,
+			)
+
+			interpreter.writeStored(address, domain, identifier, value)
+
+			return VoidValue{}
+		},
+		sema.AuthAccountCapabilitiesTypePublishFunctionType,
+	)
+}
+
+func (interpreter *Interpreter) authAccountCapabilitiesUnpublishFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			invocation.Interpreter.CheckWrite("unpublish capability", invocation.GetLocationRange)
+
+			path, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			domain := path.Domain.Identifier()
+			identifier := path.Identifier
+
+			value := interpreter.ReadStored(address, domain, identifier)
+
+			// Only published capabilities can be unpublished, links are left untouched
+
+			capability, ok := value.(*CapabilityValue)
+			if !ok {
+				return NilValue{}
+			}
+
+			result := capability.Transfer(
+				interpreter,
+				invocation.GetLocationRange,
+				atree.Address{},
+				true,
+				nil,
+			)
+
+			interpreter.writeStored(address, domain, identifier, nil)
+
+			return NewSomeValueNonCopying(result)
+		},
+		sema.AuthAccountCapabilitiesTypeUnpublishFunctionType,
+	)
+}
+
+// getPublishedCapability returns the capability published at the given path,
+// with the given borrow type, if it can be borrowed with it.
+//
+func (interpreter *Interpreter) getPublishedCapability(
+	addressValue AddressValue,
+	path PathValue,
+	wantedBorrowType *sema.ReferenceType,
+) *CapabilityValue {
+
+	value := interpreter.ReadStored(
+		addressValue.ToAddress(),
+		path.Domain.Identifier(),
+		path.Identifier,
+	)
+
+	capability, ok := value.(*CapabilityValue)
+	if !ok || capability.BorrowType == nil {
+		return nil
+	}
+
+	borrowType := interpreter.MustConvertStaticToSemaType(capability.BorrowType)
+	if !sema.IsSubType(borrowType, wantedBorrowType) {
+		return nil
+	}
+
+	return &CapabilityValue{
+		Address:    capability.Address,
+		Path:       capability.Path,
+		BorrowType: ConvertSemaToStaticType(wantedBorrowType),
+		ID:         capability.ID,
+	}
+}
+
+func (interpreter *Interpreter) accountCapabilitiesGetFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			path, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			wantedBorrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			capability := interpreter.getPublishedCapability(addressValue, path, wantedBorrowType)
+			if capability == nil {
+				return NilValue{}
+			}
+
+			return NewSomeValueNonCopying(capability)
+		},
+		sema.AccountCapabilitiesTypeGetFunctionType,
+	)
+}
+
+func (interpreter *Interpreter) accountCapabilitiesBorrowFunction(
+	addressValue AddressValue,
+) *HostFunctionValue {
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			path, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			typeParameterPair := invocation.TypeParameterTypes.Oldest()
+			if typeParameterPair == nil {
+				panic(errors.NewUnreachableError())
+			}
+
+			wantedBorrowType, ok := typeParameterPair.Value.(*sema.ReferenceType)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			capability := interpreter.getPublishedCapability(addressValue, path, wantedBorrowType)
+			if capability == nil {
+				return NilValue{}
+			}
+
+			borrowFunction := interpreter.capabilityBorrowFunction(
+				capability.Address,
+				capability.Path,
+				capability.ID,
+				wantedBorrowType,
+			)
+
+			return borrowFunction.invoke(invocation)
+		},
+		sema.AccountCapabilitiesTypeBorrowFunctionType,
+	)
+}
@@ -36,6 +36,7 @@ var authAccountContractsFieldNames []string = nil
 func NewAuthAccountContractsValue(
 	address AddressValue,
 	addFunction FunctionValue,
+	addFrozenFunction FunctionValue,
 	updateFunction FunctionValue,
 	getFunction FunctionValue,
 	removeFunction FunctionValue,
@@ -44,6 +45,7 @@ func NewAuthAccountContractsValue(
 
 	fields := map[string]Value{
 		sema.AuthAccountContractsTypeAddFunctionName:                addFunction,
+		sema.AuthAccountContractsTypeAddFrozenFunctionName:          addFrozenFunction,
 		sema.AuthAccountContractsTypeGetFunctionName:                getFunction,
 		sema.AuthAccountContractsTypeRemoveFunctionName:             removeFunction,
 		sema.AuthAccountContractsTypeUpdateExperimentalFunctionName: updateFunction,
@@ -19,7 +19,9 @@
 package interpreter
 
 import (
+	"math"
 	"math/big"
+	"math/bits"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -73,3 +75,159 @@ func UnsignedBigIntToBigEndianBytes(bigInt *big.Int) []byte {
 		panic(errors.NewUnreachableError())
 	}
 }
+
+// BigEndianBytesToSignedBigInt returns the integer for the given
+// big-endian two's complement representation.
+//
+func BigEndianBytesToSignedBigInt(b []byte) *big.Int {
+	result := new(big.Int).SetBytes(b)
+
+	// Negative if the most significant bit is set
+
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		offset := new(big.Int).Lsh(big.NewInt(1), uint(len(b))*8)
+		result.Sub(result, offset)
+	}
+
+	return result
+}
+
+// BigEndianBytesToUnsignedBigInt returns the integer for the given
+// big-endian unsigned representation.
+//
+func BigEndianBytesToUnsignedBigInt(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
+
+// TruncateBigInt returns the given integer truncated to the given number of bits,
+// i.e. the integer represented by the least significant bits of its two's complement representation.
+//
+func TruncateBigInt(bigInt *big.Int, bitSize uint, signed bool) *big.Int {
+	modulus := new(big.Int).Lsh(big.NewInt(1), bitSize)
+
+	// NOTE: Mod is the Euclidean modulus, so the result is never negative
+
+	result := new(big.Int).Mod(bigInt, modulus)
+
+	if signed && result.Bit(int(bitSize)-1) != 0 {
+		result.Sub(result, modulus)
+	}
+
+	return result
+}
+
+// smallBigInt is a big integer which fits into a single word.
+//
+// The integer and the word are allocated together,
+// so creating the integer only requires a single allocation,
+// instead of one for the integer and one for its words.
+//
+type smallBigInt struct {
+	bigInt big.Int
+	words  [1]big.Word
+}
+
+// newSmallBigIntFromUint64 returns a new big integer for the given value.
+//
+func newSmallBigIntFromUint64(value uint64) *big.Int {
+	// Words are only 32 bits wide on 32-bit platforms
+	if bits.UintSize < 64 {
+		return new(big.Int).SetUint64(value)
+	}
+
+	result := new(smallBigInt)
+	if value != 0 {
+		result.words[0] = big.Word(value)
+		result.bigInt.SetBits(result.words[:])
+	}
+	return &result.bigInt
+}
+
+// newSmallBigIntFromInt64 returns a new big integer for the given value.
+//
+func newSmallBigIntFromInt64(value int64) *big.Int {
+	if value >= 0 {
+		return newSmallBigIntFromUint64(uint64(value))
+	}
+
+	// NOTE: negating math.MinInt64 overflows to math.MinInt64,
+	// but its conversion to uint64 is the correct absolute value
+
+	result := newSmallBigIntFromUint64(uint64(-value))
+	return result.Neg(result)
+}
+
+// The following functions perform the arithmetic operations on int64 and uint64 operands,
+// and report if the result could be determined without overflow.
+// They are used as fast paths for arbitrary precision integers which fit into 64 bits.
+
+func addInt64(a, b int64) (int64, bool) {
+	result := a + b
+	// Overflow occurred if both operands have the same sign,
+	// and the sign of the result differs
+	if (result > a) != (b > 0) {
+		return 0, false
+	}
+	return result, true
+}
+
+func subtractInt64(a, b int64) (int64, bool) {
+	result := a - b
+	if (result < a) != (b > 0) {
+		return 0, false
+	}
+	return result, true
+}
+
+func multiplyInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	result := a * b
+	if (a == -1 && b == math.MinInt64) ||
+		(b == -1 && a == math.MinInt64) ||
+		result/b != a {
+
+		return 0, false
+	}
+	return result, true
+}
+
+// divideInt64Euclidean performs an Euclidean division, like big.Int.Div.
+// The divisor must not be zero.
+//
+func divideInt64Euclidean(a, b int64) (int64, bool) {
+	if a == math.MinInt64 && b == -1 {
+		return 0, false
+	}
+	quotient := a / b
+	if a%b < 0 {
+		if b > 0 {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return quotient, true
+}
+
+// remainderInt64 performs a truncated modulus, like big.Int.Rem.
+// The divisor must not be zero.
+//
+func remainderInt64(a, b int64) int64 {
+	if b == -1 {
+		// Avoid the overflow of math.MinInt64 % -1
+		return 0
+	}
+	return a % b
+}
+
+func addUint64(a, b uint64) (uint64, bool) {
+	result, carry := bits.Add64(a, b, 0)
+	return result, carry == 0
+}
+
+func multiplyUint64(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
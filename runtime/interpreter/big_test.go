@@ -0,0 +1,284 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/runtime/interpreter"
+)
+
+// TestIntSmallValueArithmetic tests that the results of the arithmetic operations
+// on Int and UInt values, which have fast paths for values which fit into 64 bits,
+// are equal to the results of the operations on big integers,
+// especially at the boundaries of the 64-bit ranges.
+//
+func TestIntSmallValueArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	bigMaxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+
+	signedValues := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-1),
+		big.NewInt(2),
+		big.NewInt(-2),
+		big.NewInt(7),
+		big.NewInt(-7),
+		big.NewInt(math.MaxInt32),
+		big.NewInt(math.MinInt32),
+		big.NewInt(math.MaxInt64),
+		big.NewInt(math.MaxInt64 - 1),
+		big.NewInt(math.MinInt64),
+		big.NewInt(math.MinInt64 + 1),
+		new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1)),
+		new(big.Int).Sub(big.NewInt(math.MinInt64), big.NewInt(1)),
+		bigMaxUint64,
+		new(big.Int).Neg(bigMaxUint64),
+	}
+
+	type operation struct {
+		name       string
+		intOp      func(a, b NumberValue) NumberValue
+		bigOp      func(res, a, b *big.Int) *big.Int
+		isDivision bool
+	}
+
+	operations := []operation{
+		{
+			name:  "plus",
+			intOp: NumberValue.Plus,
+			bigOp: (*big.Int).Add,
+		},
+		{
+			name:  "minus",
+			intOp: NumberValue.Minus,
+			bigOp: (*big.Int).Sub,
+		},
+		{
+			name:  "mul",
+			intOp: NumberValue.Mul,
+			bigOp: (*big.Int).Mul,
+		},
+		{
+			name:       "div",
+			intOp:      NumberValue.Div,
+			bigOp:      (*big.Int).Div,
+			isDivision: true,
+		},
+		{
+			name:       "mod",
+			intOp:      NumberValue.Mod,
+			bigOp:      (*big.Int).Rem,
+			isDivision: true,
+		},
+	}
+
+	for _, op := range operations {
+
+		t.Run(fmt.Sprintf("Int %s", op.name), func(t *testing.T) {
+
+			for _, a := range signedValues {
+				for _, b := range signedValues {
+
+					if op.isDivision && b.Sign() == 0 {
+						continue
+					}
+
+					expected := op.bigOp(new(big.Int), a, b)
+
+					result := op.intOp(
+						NewIntValueFromBigInt(a),
+						NewIntValueFromBigInt(b),
+					)
+
+					require.IsType(t, IntValue{}, result)
+					assert.Equal(t,
+						0,
+						expected.Cmp(result.(IntValue).BigInt),
+						"%s %s %s: expected %s, got %s",
+						a, op.name, b, expected, result,
+					)
+				}
+			}
+		})
+
+		t.Run(fmt.Sprintf("UInt %s", op.name), func(t *testing.T) {
+
+			for _, a := range signedValues {
+				if a.Sign() < 0 {
+					continue
+				}
+
+				for _, b := range signedValues {
+					if b.Sign() < 0 ||
+						(op.isDivision && b.Sign() == 0) {
+
+						continue
+					}
+
+					expected := op.bigOp(new(big.Int), a, b)
+
+					f := func() NumberValue {
+						return op.intOp(
+							NewUIntValueFromBigInt(a),
+							NewUIntValueFromBigInt(b),
+						)
+					}
+
+					if expected.Sign() < 0 {
+						assert.PanicsWithValue(t, UnderflowError{}, func() { f() })
+						continue
+					}
+
+					result := f()
+
+					require.IsType(t, UIntValue{}, result)
+					assert.Equal(t,
+						0,
+						expected.Cmp(result.(UIntValue).BigInt),
+						"%s %s %s: expected %s, got %s",
+						a, op.name, b, expected, result,
+					)
+				}
+			}
+		})
+	}
+
+	t.Run("constructors", func(t *testing.T) {
+
+		for _, value := range []int64{0, 1, -1, math.MaxInt64, math.MinInt64} {
+			assert.Equal(t,
+				big.NewInt(value).String(),
+				NewIntValueFromInt64(value).BigInt.String(),
+			)
+		}
+
+		for _, value := range []uint64{0, 1, math.MaxUint64} {
+			assert.Equal(t,
+				new(big.Int).SetUint64(value).String(),
+				NewUIntValueFromUint64(value).BigInt.String(),
+			)
+		}
+	})
+}
+
+// benchmarkBigNumberOperation benchmarks the given operation on Int and UInt values,
+// for values which fit into 64 bits, and values which do not.
+//
+func benchmarkBigNumberOperation(b *testing.B, operation func(a, b NumberValue) NumberValue) {
+
+	large, _ := new(big.Int).SetString("1000000000000000000000000000000", 10)
+
+	for _, benchmark := range []struct {
+		name string
+		a, b NumberValue
+	}{
+		{
+			name: "Int small",
+			a:    NewIntValueFromInt64(1234567),
+			b:    NewIntValueFromInt64(89),
+		},
+		{
+			name: "Int large",
+			a:    NewIntValueFromBigInt(large),
+			b:    NewIntValueFromBigInt(new(big.Int).Sqrt(large)),
+		},
+		{
+			name: "UInt small",
+			a:    NewUIntValueFromUint64(1234567),
+			b:    NewUIntValueFromUint64(89),
+		},
+		{
+			name: "UInt large",
+			a:    NewUIntValueFromBigInt(large),
+			b:    NewUIntValueFromBigInt(new(big.Int).Sqrt(large)),
+		},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				operation(benchmark.a, benchmark.b)
+			}
+		})
+	}
+}
+
+func TestTruncateBigInt(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		value    *big.Int
+		bitSize  uint
+		signed   bool
+		expected *big.Int
+	}
+
+	testCases := []testCase{
+		{big.NewInt(300), 8, false, big.NewInt(44)},
+		{big.NewInt(-1), 8, false, big.NewInt(255)},
+		{big.NewInt(200), 8, true, big.NewInt(-56)},
+		{big.NewInt(-129), 8, true, big.NewInt(127)},
+		{big.NewInt(-128), 8, true, big.NewInt(-128)},
+		{new(big.Int).SetUint64(math.MaxUint64), 64, true, big.NewInt(-1)},
+		{new(big.Int).Lsh(big.NewInt(1), 128), 128, false, big.NewInt(0)},
+	}
+
+	for _, testCase := range testCases {
+		actual := TruncateBigInt(testCase.value, testCase.bitSize, testCase.signed)
+
+		assert.Zero(t,
+			testCase.expected.Cmp(actual),
+			"%s truncated to %d bits (signed: %t)",
+			testCase.value,
+			testCase.bitSize,
+			testCase.signed,
+		)
+	}
+}
+
+func TestBigEndianBytesToBigInt(t *testing.T) {
+
+	t.Parallel()
+
+	for _, value := range []int64{0, 1, -1, 127, 128, -128, -129, math.MaxInt64, math.MinInt64} {
+		bigInt := big.NewInt(value)
+
+		assert.Zero(t,
+			bigInt.Cmp(BigEndianBytesToSignedBigInt(SignedBigIntToBigEndianBytes(bigInt))),
+		)
+
+		if value >= 0 {
+			assert.Zero(t,
+				bigInt.Cmp(BigEndianBytesToUnsignedBigInt(UnsignedBigIntToBigEndianBytes(bigInt))),
+			)
+		}
+	}
+}
@@ -66,3 +66,44 @@ func NewBlockValue(
 		nil,
 	)
 }
+
+// BlockHeader
+
+var blockHeaderDynamicType DynamicType = BlockHeaderDynamicType{}
+var blockHeaderStaticType StaticType = PrimitiveStaticTypeBlockHeader
+var blockHeaderFieldNames = []string{
+	sema.BlockHeaderTypeHeightFieldName,
+	sema.BlockHeaderTypeViewFieldName,
+	sema.BlockHeaderTypeIDFieldName,
+	sema.BlockHeaderTypeParentIDFieldName,
+	sema.BlockHeaderTypeTimestampFieldName,
+}
+var blockHeaderFieldFormatters = map[string]func(Value, SeenReferences) string{
+	sema.BlockHeaderTypeIDFieldName:       blockFieldFormatters[sema.BlockTypeIDFieldName],
+	sema.BlockHeaderTypeParentIDFieldName: blockFieldFormatters[sema.BlockTypeIDFieldName],
+}
+
+func NewBlockHeaderValue(
+	height UInt64Value,
+	view UInt64Value,
+	id *ArrayValue,
+	parentID *ArrayValue,
+	timestamp UFix64Value,
+) *SimpleCompositeValue {
+	return NewSimpleCompositeValue(
+		sema.BlockHeaderType.TypeID,
+		blockHeaderStaticType,
+		blockHeaderDynamicType,
+		blockHeaderFieldNames,
+		map[string]Value{
+			sema.BlockHeaderTypeHeightFieldName:    height,
+			sema.BlockHeaderTypeViewFieldName:      view,
+			sema.BlockHeaderTypeIDFieldName:        id,
+			sema.BlockHeaderTypeParentIDFieldName:  parentID,
+			sema.BlockHeaderTypeTimestampFieldName: timestamp,
+		},
+		nil,
+		blockHeaderFieldFormatters,
+		nil,
+	)
+}
@@ -0,0 +1,183 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// compactIntegerAtreeValue is the compact storage encoding of an element
+// of a constant-sized array of fixed-size integers, e.g. `[UInt8; 32]`.
+//
+// The element is encoded as an untagged CBOR integer, i.e. without the type tag
+// which is otherwise encoded for each element.
+// The element type is instead determined by the static type of the containing array,
+// so the element is only converted to a value when it is read from the array.
+//
+// Bits are the two's complement bits of the integer,
+// i.e. negative integers of signed types are sign-extended to 64 bits.
+//
+type compactIntegerAtreeValue struct {
+	bits     uint64
+	negative bool
+}
+
+var _ atree.Value = compactIntegerAtreeValue{}
+var _ atree.Storable = compactIntegerAtreeValue{}
+
+// isCompactArrayElementType returns true if the elements of arrays with the given type
+// are stored using the compact encoding.
+//
+// Only the elements of constant-sized arrays are stored compactly.
+//
+func isCompactArrayElementType(arrayType ArrayStaticType) bool {
+	constantSizedType, ok := arrayType.(ConstantSizedStaticType)
+	if !ok {
+		return false
+	}
+
+	switch constantSizedType.Type {
+	case PrimitiveStaticTypeInt8,
+		PrimitiveStaticTypeInt16,
+		PrimitiveStaticTypeInt32,
+		PrimitiveStaticTypeInt64,
+		PrimitiveStaticTypeUInt8,
+		PrimitiveStaticTypeUInt16,
+		PrimitiveStaticTypeUInt32,
+		PrimitiveStaticTypeUInt64,
+		PrimitiveStaticTypeWord8,
+		PrimitiveStaticTypeWord16,
+		PrimitiveStaticTypeWord32,
+		PrimitiveStaticTypeWord64:
+
+		return true
+	}
+
+	return false
+}
+
+// newCompactIntegerAtreeValue returns the compact storage encoding of the given integer value
+//
+func newCompactIntegerAtreeValue(value Value) compactIntegerAtreeValue {
+	switch value := value.(type) {
+	case Int8Value:
+		return newSignedCompactIntegerAtreeValue(int64(value))
+	case Int16Value:
+		return newSignedCompactIntegerAtreeValue(int64(value))
+	case Int32Value:
+		return newSignedCompactIntegerAtreeValue(int64(value))
+	case Int64Value:
+		return newSignedCompactIntegerAtreeValue(int64(value))
+	case UInt8Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case UInt16Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case UInt32Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case UInt64Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case Word8Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case Word16Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case Word32Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	case Word64Value:
+		return compactIntegerAtreeValue{bits: uint64(value)}
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+func newSignedCompactIntegerAtreeValue(value int64) compactIntegerAtreeValue {
+	return compactIntegerAtreeValue{
+		bits:     uint64(value),
+		negative: value < 0,
+	}
+}
+
+// value returns the integer value of the given element type
+//
+func (v compactIntegerAtreeValue) value(elementType StaticType) Value {
+	switch elementType {
+	case PrimitiveStaticTypeInt8:
+		return Int8Value(v.bits)
+	case PrimitiveStaticTypeInt16:
+		return Int16Value(v.bits)
+	case PrimitiveStaticTypeInt32:
+		return Int32Value(v.bits)
+	case PrimitiveStaticTypeInt64:
+		return Int64Value(v.bits)
+	case PrimitiveStaticTypeUInt8:
+		return UInt8Value(v.bits)
+	case PrimitiveStaticTypeUInt16:
+		return UInt16Value(v.bits)
+	case PrimitiveStaticTypeUInt32:
+		return UInt32Value(v.bits)
+	case PrimitiveStaticTypeUInt64:
+		return UInt64Value(v.bits)
+	case PrimitiveStaticTypeWord8:
+		return Word8Value(v.bits)
+	case PrimitiveStaticTypeWord16:
+		return Word16Value(v.bits)
+	case PrimitiveStaticTypeWord32:
+		return Word32Value(v.bits)
+	case PrimitiveStaticTypeWord64:
+		return Word64Value(v.bits)
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+func (v compactIntegerAtreeValue) Storable(
+	_ atree.SlabStorage,
+	_ atree.Address,
+	_ uint64,
+) (
+	atree.Storable,
+	error,
+) {
+	return v, nil
+}
+
+// Encode encodes the value as an untagged CBOR integer
+//
+func (v compactIntegerAtreeValue) Encode(e *atree.Encoder) error {
+	if v.negative {
+		return e.CBOR.EncodeInt64(int64(v.bits))
+	}
+	return e.CBOR.EncodeUint64(v.bits)
+}
+
+func (v compactIntegerAtreeValue) ByteSize() uint32 {
+	if v.negative {
+		return getIntCBORSize(int64(v.bits))
+	}
+	return getUintCBORSize(v.bits)
+}
+
+func (v compactIntegerAtreeValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+	return v, nil
+}
+
+func (compactIntegerAtreeValue) ChildStorables() []atree.Storable {
+	return nil
+}
@@ -19,26 +19,92 @@
 package interpreter
 
 import (
+	"sync"
 	"sync/atomic"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// StopReason is the reason why the execution was stopped
+//
+type StopReason uint
+
+const (
+	// StopReasonPause indicates that the execution stopped because a pause was requested
+	StopReasonPause StopReason = iota
+	// StopReasonStep indicates that the execution stopped after a step
+	StopReasonStep
+	// StopReasonBreakpoint indicates that the execution stopped at a breakpoint
+	StopReasonBreakpoint
 )
 
 type Stop struct {
 	Interpreter *Interpreter
 	Statement   ast.Statement
+	Reason      StopReason
+}
+
+// StackFrame is a frame of the call stack, i.e. an invocation of a function
+// by an invocation expression.
+//
+type StackFrame struct {
+	// Interpreter is the interpreter which evaluated the invocation expression
+	Interpreter *Interpreter
+	// Invocation is the invocation expression
+	Invocation *ast.InvocationExpression
+	activation *VariableActivation
+}
+
+// Activation returns the activation at the invocation,
+// i.e. the variables of the caller.
+//
+func (f StackFrame) Activation() *VariableActivation {
+	return f.activation
 }
 
+type breakpoint struct {
+	locationID common.LocationID
+	line       int
+}
+
+type stepMode uint
+
+const (
+	stepModeNone stepMode = iota
+	stepModeInto
+	stepModeOver
+	stepModeOut
+)
+
+// Debugger allows stopping the execution of programs, e.g. at breakpoints,
+// inspecting the variables and the call stack, and stepping through the program.
+//
+// The execution is stopped before a statement is executed.
+// Each stop is sent to the channel returned by Stops,
+// and the execution is blocked until it is continued,
+// e.g. using Continue, or one of the stepping functions.
+//
+// Breakpoints may be added and removed while a program is executed.
+// All other functions must only be called while the execution is stopped.
+//
 type Debugger struct {
-	pauseRequested uint32
-	stops          chan Stop
-	continues      chan struct{}
+	pauseRequested  uint32
+	stopped         uint32
+	stops           chan Stop
+	continues       chan struct{}
+	breakpointsLock sync.RWMutex
+	breakpoints     map[breakpoint]struct{}
+	stepMode        stepMode
+	stepDepth       int
+	callStack       []StackFrame
 }
 
 func NewDebugger() *Debugger {
 	return &Debugger{
-		stops:     make(chan Stop),
-		continues: make(chan struct{}),
+		stops:       make(chan Stop),
+		continues:   make(chan struct{}),
+		breakpoints: map[breakpoint]struct{}{},
 	}
 }
 
@@ -47,20 +113,55 @@ func (d *Debugger) Stops() <-chan Stop {
 }
 
 func (d *Debugger) onStatement(interpreter *Interpreter, statement ast.Statement) {
-	if !d.PauseRequested() {
+	reason, ok := d.stopReason(interpreter, statement)
+	if !ok {
 		return
 	}
 
+	d.resetPauseRequest()
+	d.stepMode = stepModeNone
+
+	atomic.StoreUint32(&d.stopped, 1)
+
 	d.stops <- Stop{
 		Interpreter: interpreter,
 		Statement:   statement,
+		Reason:      reason,
 	}
 
-	d.resetPauseRequest()
-
 	<-d.continues
 }
 
+func (d *Debugger) stopReason(interpreter *Interpreter, statement ast.Statement) (StopReason, bool) {
+	if d.PauseRequested() {
+		return StopReasonPause, true
+	}
+
+	depth := len(d.callStack)
+
+	switch d.stepMode {
+	case stepModeInto:
+		return StopReasonStep, true
+
+	case stepModeOver:
+		if depth <= d.stepDepth {
+			return StopReasonStep, true
+		}
+
+	case stepModeOut:
+		if depth < d.stepDepth {
+			return StopReasonStep, true
+		}
+	}
+
+	location := interpreter.Location
+	if location != nil && d.HasBreakpoint(location, statement.StartPosition().Line) {
+		return StopReasonBreakpoint, true
+	}
+
+	return 0, false
+}
+
 func (d *Debugger) PauseRequested() bool {
 	return atomic.LoadUint32(&d.pauseRequested) == 1
 }
@@ -73,13 +174,15 @@ func (d *Debugger) RequestPause() {
 	atomic.StoreUint32(&d.pauseRequested, 1)
 }
 
+// Continue continues the execution, if it is stopped.
+// It returns false if the execution is not stopped.
+//
 func (d *Debugger) Continue() bool {
-	select {
-	case d.continues <- struct{}{}:
-		return true
-	default:
+	if !atomic.CompareAndSwapUint32(&d.stopped, 1, 0) {
 		return false
 	}
+	d.continues <- struct{}{}
+	return true
 }
 
 func (d *Debugger) Pause() Stop {
@@ -93,6 +196,105 @@ func (d *Debugger) Next() Stop {
 	return <-d.Stops()
 }
 
+// StepInto continues the execution and stops before the next statement,
+// which may be in an invoked function.
+//
+func (d *Debugger) StepInto() Stop {
+	return d.step(stepModeInto)
+}
+
+// StepOver continues the execution and stops before the next statement
+// of the current function, or of a caller, if the current function returns.
+//
+func (d *Debugger) StepOver() Stop {
+	return d.step(stepModeOver)
+}
+
+// StepOut continues the execution and stops before the next statement
+// after the current function returned.
+//
+func (d *Debugger) StepOut() Stop {
+	return d.step(stepModeOut)
+}
+
+func (d *Debugger) step(mode stepMode) Stop {
+	d.stepMode = mode
+	d.stepDepth = len(d.callStack)
+	d.Continue()
+	return <-d.Stops()
+}
+
+// AddBreakpoint adds a breakpoint at the given line of the program at the given location.
+// The execution stops before each statement which starts at the line.
+//
+func (d *Debugger) AddBreakpoint(location common.Location, line int) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	d.breakpoints[breakpoint{
+		locationID: location.ID(),
+		line:       line,
+	}] = struct{}{}
+}
+
+// RemoveBreakpoint removes the breakpoint at the given line of the program at the given location, if any.
+//
+func (d *Debugger) RemoveBreakpoint(location common.Location, line int) {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	delete(d.breakpoints, breakpoint{
+		locationID: location.ID(),
+		line:       line,
+	})
+}
+
+// ClearBreakpoints removes all breakpoints.
+//
+func (d *Debugger) ClearBreakpoints() {
+	d.breakpointsLock.Lock()
+	defer d.breakpointsLock.Unlock()
+
+	d.breakpoints = map[breakpoint]struct{}{}
+}
+
+// HasBreakpoint returns true if there is a breakpoint at the given line of the program at the given location.
+//
+func (d *Debugger) HasBreakpoint(location common.Location, line int) bool {
+	d.breakpointsLock.RLock()
+	defer d.breakpointsLock.RUnlock()
+
+	_, ok := d.breakpoints[breakpoint{
+		locationID: location.ID(),
+		line:       line,
+	}]
+	return ok
+}
+
+func (d *Debugger) pushStackFrame(interpreter *Interpreter, invocation *ast.InvocationExpression) {
+	d.callStack = append(
+		d.callStack,
+		StackFrame{
+			Interpreter: interpreter,
+			Invocation:  invocation,
+			activation:  interpreter.activations.Current(),
+		},
+	)
+}
+
+func (d *Debugger) popStackFrame() {
+	d.callStack = d.callStack[:len(d.callStack)-1]
+}
+
+// CallStack returns the frames of the call stack.
+// The innermost frame, i.e. the invocation of the current function, is last.
+//
+func (d *Debugger) CallStack() []StackFrame {
+	callStack := make([]StackFrame, len(d.callStack))
+	copy(callStack, d.callStack)
+	return callStack
+}
+
 func (d *Debugger) CurrentActivation(interpreter *Interpreter) *VariableActivation {
 	return interpreter.activations.Current()
 }
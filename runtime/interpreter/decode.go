@@ -102,6 +102,22 @@ func (d Decoder) decodeStorable() (atree.Storable, error) {
 		}
 		storable = stringAtreeValue(v)
 
+	// Untagged integers are compactly stored elements of constant-sized arrays
+
+	case cbor.UintType:
+		v, err := d.decoder.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+		storable = compactIntegerAtreeValue{bits: v}
+
+	case cbor.IntType:
+		v, err := d.decoder.DecodeInt64()
+		if err != nil {
+			return nil, err
+		}
+		storable = newSignedCompactIntegerAtreeValue(v)
+
 	case cbor.TagType:
 		var num uint64
 		num, err = d.decoder.DecodeTagNumber()
@@ -128,6 +144,13 @@ func (d Decoder) decodeStorable() (atree.Storable, error) {
 			}
 			storable = d.decodeString(v)
 
+		case CBORTagBytesValue:
+			v, err := d.decoder.DecodeBytes()
+			if err != nil {
+				return nil, err
+			}
+			storable = NewBytesValue(v)
+
 		case CBORTagSomeValue:
 			storable, err = d.decodeSome()
 
@@ -215,6 +238,12 @@ func (d Decoder) decodeStorable() (atree.Storable, error) {
 		case CBORTagLinkValue:
 			storable, err = d.decodeLink()
 
+		case CBORTagAccountLinkValue:
+			storable, err = d.decodeAccountLink()
+
+		case CBORTagStorageCapabilityControllerValue:
+			storable, err = d.decodeStorageCapabilityController()
+
 		case CBORTagTypeValue:
 			storable, err = d.decodeType()
 
@@ -860,7 +889,9 @@ func (d Decoder) decodeCapability() (*CapabilityValue, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	// Capabilities without an ID omit the ID element
+
+	if size != expectedLength && size != encodedPathCapabilityValueLength {
 		return nil, fmt.Errorf(
 			"invalid capability encoding: expected [%d]interface{}, got [%d]interface{}",
 			expectedLength,
@@ -927,13 +958,36 @@ func (d Decoder) decodeCapability() (*CapabilityValue, error) {
 		return nil, fmt.Errorf("invalid capability borrow type encoding: %w", err)
 	}
 
+	// Decode ID at array index encodedCapabilityValueIDFieldKey, if any
+
+	var id UInt64Value
+
+	if size == expectedLength {
+		id, err = d.decodeTaggedUInt64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid capability ID encoding: %w", err)
+		}
+	}
+
 	return &CapabilityValue{
 		Address:    address,
 		Path:       pathValue,
 		BorrowType: borrowType,
+		ID:         id,
 	}, nil
 }
 
+func (d Decoder) decodeTaggedUInt64() (UInt64Value, error) {
+	num, err := d.decoder.DecodeTagNumber()
+	if err != nil {
+		return 0, err
+	}
+	if num != CBORTagUInt64Value {
+		return 0, fmt.Errorf("expected CBOR tag %d, got %d", CBORTagUInt64Value, num)
+	}
+	return d.decodeUInt64()
+}
+
 func (d Decoder) decodeLink() (LinkValue, error) {
 
 	const expectedLength = encodedLinkValueLength
@@ -983,6 +1037,102 @@ func (d Decoder) decodeLink() (LinkValue, error) {
 	}, nil
 }
 
+func (d Decoder) decodeAccountLink() (AccountLinkValue, error) {
+	size, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return AccountLinkValue{}, fmt.Errorf(
+				"invalid account link encoding: expected []interface{}, got %s",
+				e.ActualType.String(),
+			)
+		}
+		return AccountLinkValue{}, err
+	}
+
+	// Skip unknown elements, which might be added in the future
+
+	for i := uint64(0); i < size; i++ {
+		err = d.decoder.Skip()
+		if err != nil {
+			return AccountLinkValue{}, err
+		}
+	}
+
+	return AccountLinkValue{}, nil
+}
+
+func (d Decoder) decodeStorageCapabilityController() (StorageCapabilityControllerValue, error) {
+
+	const expectedLength = encodedStorageCapabilityControllerValueLength
+
+	size, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return StorageCapabilityControllerValue{}, fmt.Errorf(
+				"invalid storage capability controller encoding: expected [%d]interface{}, got %s",
+				expectedLength,
+				e.ActualType.String(),
+			)
+		}
+		return StorageCapabilityControllerValue{}, err
+	}
+
+	if size != expectedLength {
+		return StorageCapabilityControllerValue{}, fmt.Errorf(
+			"invalid storage capability controller encoding: expected [%d]interface{}, got [%d]interface{}",
+			expectedLength,
+			size,
+		)
+	}
+
+	// Decode borrow type at array index encodedStorageCapabilityControllerValueBorrowTypeFieldKey
+	borrowType, err := decodeStaticType(d.decoder)
+	if err != nil {
+		return StorageCapabilityControllerValue{}, fmt.Errorf(
+			"invalid storage capability controller borrow type encoding: %w",
+			err,
+		)
+	}
+
+	// Decode capability ID at array index encodedStorageCapabilityControllerValueCapabilityIDFieldKey
+	capabilityID, err := d.decodeTaggedUInt64()
+	if err != nil {
+		return StorageCapabilityControllerValue{}, fmt.Errorf(
+			"invalid storage capability controller capability ID encoding: %w",
+			err,
+		)
+	}
+
+	// Decode target path at array index encodedStorageCapabilityControllerValueTargetPathFieldKey
+	num, err := d.decoder.DecodeTagNumber()
+	if err != nil {
+		return StorageCapabilityControllerValue{}, fmt.Errorf(
+			"invalid storage capability controller target path encoding: %w",
+			err,
+		)
+	}
+	if num != CBORTagPathValue {
+		return StorageCapabilityControllerValue{}, fmt.Errorf(
+			"invalid storage capability controller target path encoding: expected CBOR tag %d, got %d",
+			CBORTagPathValue,
+			num,
+		)
+	}
+	targetPath, err := d.decodePath()
+	if err != nil {
+		return StorageCapabilityControllerValue{}, fmt.Errorf(
+			"invalid storage capability controller target path encoding: %w",
+			err,
+		)
+	}
+
+	return StorageCapabilityControllerValue{
+		BorrowType:   borrowType,
+		CapabilityID: capabilityID,
+		TargetPath:   targetPath,
+	}, nil
+}
+
 func (d Decoder) decodeType() (TypeValue, error) {
 	const expectedLength = encodedTypeValueTypeLength
 
@@ -1068,6 +1218,9 @@ func decodeStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 	case CBORTagCapabilityStaticType:
 		return decodeCapabilityStaticType(dec)
 
+	case CBORTagInclusiveRangeStaticType:
+		return decodeInclusiveRangeStaticType(dec)
+
 	default:
 		return nil, fmt.Errorf("invalid static type encoding tag: %d", number)
 	}
@@ -1273,6 +1426,7 @@ func decodeConstantSizedStaticType(dec *cbor.StreamDecoder) (StaticType, error)
 
 func decodeReferenceStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 	const expectedLength = encodedReferenceStaticTypeLength
+	const expectedEntitledLength = encodedEntitledReferenceStaticTypeLength
 
 	arraySize, err := dec.DecodeArrayHead()
 
@@ -1287,10 +1441,11 @@ func decodeReferenceStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 		return nil, err
 	}
 
-	if arraySize != expectedLength {
+	if arraySize != expectedLength && arraySize != expectedEntitledLength {
 		return nil, fmt.Errorf(
-			"invalid reference static type encoding: expected [%d]interface{}, got [%d]interface{}",
+			"invalid reference static type encoding: expected [%d]interface{} or [%d]interface{}, got [%d]interface{}",
 			expectedLength,
+			expectedEntitledLength,
 			arraySize,
 		)
 	}
@@ -1316,9 +1471,102 @@ func decodeReferenceStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 		)
 	}
 
+	var authorization *EntitlementSetAuthorization
+	if arraySize == expectedEntitledLength {
+		// Decode authorization at array index encodedReferenceStaticTypeAuthorizationFieldKey
+		authorization, err = decodeEntitlementSetAuthorization(dec)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid reference static type authorization encoding: %w",
+				err,
+			)
+		}
+	}
+
 	return ReferenceStaticType{
-		Authorized: authorized,
-		Type:       staticType,
+		Authorized:    authorized,
+		Authorization: authorization,
+		Type:          staticType,
+	}, nil
+}
+
+func decodeEntitlementSetAuthorization(dec *cbor.StreamDecoder) (*EntitlementSetAuthorization, error) {
+	const expectedLength = encodedEntitlementSetAuthorizationLength
+
+	arraySize, err := dec.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return nil, fmt.Errorf(
+				"invalid entitlement set authorization encoding: expected [%d]interface{}, got %s",
+				expectedLength,
+				e.ActualType.String(),
+			)
+		}
+		return nil, err
+	}
+
+	if arraySize != expectedLength {
+		return nil, fmt.Errorf(
+			"invalid entitlement set authorization encoding: expected [%d]interface{}, got [%d]interface{}",
+			expectedLength,
+			arraySize,
+		)
+	}
+
+	// Decode set kind at array index encodedEntitlementSetAuthorizationSetKindFieldKey
+	setKind, err := dec.DecodeUint64()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return nil, fmt.Errorf(
+				"invalid entitlement set authorization set kind encoding: %s",
+				e.ActualType.String(),
+			)
+		}
+		return nil, err
+	}
+
+	switch sema.EntitlementSetKind(setKind) {
+	case sema.EntitlementSetKindConjunction,
+		sema.EntitlementSetKindDisjunction:
+		break
+	default:
+		return nil, fmt.Errorf(
+			"invalid entitlement set authorization set kind: %d",
+			setKind,
+		)
+	}
+
+	// Decode entitlements at array index encodedEntitlementSetAuthorizationEntitlementsFieldKey
+	entitlementCount, err := dec.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return nil, fmt.Errorf(
+				"invalid entitlement set authorization entitlements encoding: %s",
+				e.ActualType.String(),
+			)
+		}
+		return nil, err
+	}
+
+	if entitlementCount == 0 {
+		return nil, fmt.Errorf("invalid entitlement set authorization: missing entitlements")
+	}
+
+	entitlements := make([]common.TypeID, entitlementCount)
+	for i := 0; i < int(entitlementCount); i++ {
+		entitlement, err := dec.DecodeString()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid entitlement set authorization entitlement encoding: %w",
+				err,
+			)
+		}
+		entitlements[i] = common.TypeID(entitlement)
+	}
+
+	return &EntitlementSetAuthorization{
+		Entitlements: entitlements,
+		SetKind:      sema.EntitlementSetKind(setKind),
 	}, nil
 }
 
@@ -1478,6 +1726,27 @@ func decodeCapabilityStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 	}, nil
 }
 
+func decodeInclusiveRangeStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
+	var elementStaticType StaticType
+
+	// Optional element type can be CBOR nil.
+	err := dec.DecodeNil()
+	if _, ok := err.(*cbor.WrongTypeError); ok {
+		elementStaticType, err = decodeStaticType(dec)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(
+			"invalid inclusive range static type element type encoding: %w",
+			err,
+		)
+	}
+
+	return InclusiveRangeStaticType{
+		ElementType: elementStaticType,
+	}, nil
+}
+
 func decodeCompositeTypeInfo(dec *cbor.StreamDecoder) (atree.TypeInfo, error) {
 
 	length, err := dec.DecodeArrayHead()
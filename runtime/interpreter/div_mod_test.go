@@ -3358,3 +3358,11 @@ func TestNegativeMod(t *testing.T) {
 		}
 	})
 }
+
+func BenchmarkDivBigNumber(b *testing.B) {
+	benchmarkBigNumberOperation(b, NumberValue.Div)
+}
+
+func BenchmarkModBigNumber(b *testing.B) {
+	benchmarkBigNumberOperation(b, NumberValue.Mod)
+}
@@ -32,6 +32,7 @@ type ReferenceDynamicType interface {
 	DynamicType
 	isReferenceType()
 	Authorized() bool
+	Authorization() *sema.EntitlementSetAccess
 	InnerType() DynamicType
 	BorrowedType() sema.Type
 }
@@ -66,6 +67,16 @@ func (StringDynamicType) IsImportable() bool {
 	return sema.StringType.Importable
 }
 
+// BytesDynamicType
+
+type BytesDynamicType struct{}
+
+func (BytesDynamicType) IsDynamicType() {}
+
+func (BytesDynamicType) IsImportable() bool {
+	return sema.BytesType.Importable
+}
+
 // BoolDynamicType
 
 type BoolDynamicType struct{}
@@ -169,9 +180,10 @@ func (t SomeDynamicType) IsImportable() bool {
 // StorageReferenceDynamicType
 
 type StorageReferenceDynamicType struct {
-	authorized   bool
-	innerType    DynamicType
-	borrowedType sema.Type
+	authorized    bool
+	authorization *sema.EntitlementSetAccess
+	innerType     DynamicType
+	borrowedType  sema.Type
 }
 
 func (StorageReferenceDynamicType) IsDynamicType() {}
@@ -182,6 +194,10 @@ func (t StorageReferenceDynamicType) Authorized() bool {
 	return t.authorized
 }
 
+func (t StorageReferenceDynamicType) Authorization() *sema.EntitlementSetAccess {
+	return t.authorization
+}
+
 func (t StorageReferenceDynamicType) InnerType() DynamicType {
 	return t.innerType
 }
@@ -197,9 +213,10 @@ func (StorageReferenceDynamicType) IsImportable() bool {
 // EphemeralReferenceDynamicType
 
 type EphemeralReferenceDynamicType struct {
-	authorized   bool
-	innerType    DynamicType
-	borrowedType sema.Type
+	authorized    bool
+	authorization *sema.EntitlementSetAccess
+	innerType     DynamicType
+	borrowedType  sema.Type
 }
 
 func (EphemeralReferenceDynamicType) IsDynamicType() {}
@@ -210,6 +227,10 @@ func (t EphemeralReferenceDynamicType) Authorized() bool {
 	return t.authorized
 }
 
+func (t EphemeralReferenceDynamicType) Authorization() *sema.EntitlementSetAccess {
+	return t.authorization
+}
+
 func (t EphemeralReferenceDynamicType) InnerType() DynamicType {
 	return t.innerType
 }
@@ -308,6 +329,28 @@ func (BlockDynamicType) IsImportable() bool {
 	return sema.BlockType.Importable
 }
 
+// InclusiveRangeDynamicType
+
+type InclusiveRangeDynamicType struct {
+	ElementType sema.Type
+}
+
+func (InclusiveRangeDynamicType) IsDynamicType() {}
+
+func (InclusiveRangeDynamicType) IsImportable() bool {
+	return false
+}
+
+// BlockHeaderDynamicType
+
+type BlockHeaderDynamicType struct{}
+
+func (BlockHeaderDynamicType) IsDynamicType() {}
+
+func (BlockHeaderDynamicType) IsImportable() bool {
+	return sema.BlockHeaderType.Importable
+}
+
 // UnwrapOptionalDynamicType returns the type if it is not an optional type,
 // or the inner-most type if it is (optional types are repeatedly unwrapped)
 //
@@ -115,7 +115,7 @@ const (
 	CBORTagTypeValue
 	_ // DO *NOT* REPLACE. Previously used for array values
 	CBORTagStringValue
-	_
+	CBORTagBytesValue
 	_
 	_
 	_
@@ -198,8 +198,8 @@ const (
 	CBORTagCapabilityValue
 	_ // DO NOT REPLACE! used to be used for storage references
 	CBORTagLinkValue
-	_
-	_
+	CBORTagAccountLinkValue
+	CBORTagStorageCapabilityControllerValue
 	_
 	_
 	_
@@ -218,6 +218,7 @@ const (
 	CBORTagReferenceStaticType
 	CBORTagRestrictedStaticType
 	CBORTagCapabilityStaticType
+	CBORTagInclusiveRangeStaticType
 )
 
 // CBOREncMode
@@ -262,6 +263,24 @@ func (v *StringValue) Encode(e *atree.Encoder) error {
 	return e.CBOR.EncodeString(v.Str)
 }
 
+// Encode encodes the value as
+//
+// 	cbor.Tag{
+// 		Number: CBORTagBytesValue,
+// 		Content: []byte(v.Bytes)
+// 	}
+//
+func (v *BytesValue) Encode(e *atree.Encoder) error {
+	err := e.CBOR.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, CBORTagBytesValue,
+	})
+	if err != nil {
+		return err
+	}
+	return e.CBOR.EncodeBytes(v.Bytes)
+}
+
 // Encode encodes the value as a CBOR string
 //
 func (v stringAtreeValue) Encode(e *atree.Encoder) error {
@@ -692,12 +711,17 @@ const (
 	// encodedCapabilityValueAddressFieldKey    uint64 = 0
 	// encodedCapabilityValuePathFieldKey       uint64 = 1
 	// encodedCapabilityValueBorrowTypeFieldKey uint64 = 2
+	// encodedCapabilityValueIDFieldKey         uint64 = 3
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCapabilityValueLength MUST be updated when new element is added.
 	// It is used to verify encoded capability length during decoding.
-	encodedCapabilityValueLength = 3
+	encodedCapabilityValueLength = 4
+
+	// encodedPathCapabilityValueLength is the length of encoded capabilities without an ID,
+	// i.e. capabilities created by linking, which omit the ID element.
+	encodedPathCapabilityValueLength = 3
 )
 
 // Encode encodes CapabilityStorable as
@@ -707,15 +731,25 @@ const (
 //					encodedCapabilityValueAddressFieldKey:    AddressValue(v.Address),
 // 					encodedCapabilityValuePathFieldKey:       PathValue(v.Path),
 // 					encodedCapabilityValueBorrowTypeFieldKey: StaticType(v.BorrowType),
+// 					encodedCapabilityValueIDFieldKey:         UInt64Value(v.ID),
 // 				},
 // }
+//
+// The ID is only encoded if it is set,
+// so the encoding of capabilities created by linking is unchanged.
+//
 func (v *CapabilityValue) Encode(e *atree.Encoder) error {
+	// array, 3 or 4 items follow
+	var arrayHead byte = 0x80 | encodedPathCapabilityValueLength
+	if v.ID != 0 {
+		arrayHead = 0x80 | encodedCapabilityValueLength
+	}
+
 	// Encode tag number and array head
 	err := e.CBOR.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagCapabilityValue,
-		// array, 3 items follow
-		0x83,
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -734,7 +768,17 @@ func (v *CapabilityValue) Encode(e *atree.Encoder) error {
 	}
 
 	// Encode borrow type at array index encodedCapabilityValueBorrowTypeFieldKey
-	return EncodeStaticType(e.CBOR, v.BorrowType)
+	err = EncodeStaticType(e.CBOR, v.BorrowType)
+	if err != nil {
+		return err
+	}
+
+	if v.ID == 0 {
+		return nil
+	}
+
+	// Encode ID at array index encodedCapabilityValueIDFieldKey
+	return v.ID.Encode(e)
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -896,6 +940,70 @@ func (v LinkValue) Encode(e *atree.Encoder) error {
 	return EncodeStaticType(e.CBOR, v.Type)
 }
 
+// Encode encodes AccountLinkValue as
+// cbor.Tag{
+//			Number:  CBORTagAccountLinkValue,
+//			Content: []interface{}{},
+// }
+func (AccountLinkValue) Encode(e *atree.Encoder) error {
+	return e.CBOR.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, CBORTagAccountLinkValue,
+		// array, 0 items follow
+		0x80,
+	})
+}
+
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedStorageCapabilityControllerValueBorrowTypeFieldKey   uint64 = 0
+	// encodedStorageCapabilityControllerValueCapabilityIDFieldKey uint64 = 1
+	// encodedStorageCapabilityControllerValueTargetPathFieldKey   uint64 = 2
+
+	// !!! *WARNING* !!!
+	//
+	// encodedStorageCapabilityControllerValueLength MUST be updated when new element is added.
+	// It is used to verify encoded storage capability controller length during decoding.
+	encodedStorageCapabilityControllerValueLength = 3
+)
+
+// Encode encodes StorageCapabilityControllerValue as
+// cbor.Tag{
+//			Number: CBORTagStorageCapabilityControllerValue,
+//			Content: []interface{}{
+//				encodedStorageCapabilityControllerValueBorrowTypeFieldKey:   StaticType(v.BorrowType),
+//				encodedStorageCapabilityControllerValueCapabilityIDFieldKey: UInt64Value(v.CapabilityID),
+//				encodedStorageCapabilityControllerValueTargetPathFieldKey:   PathValue(v.TargetPath),
+//			},
+// }
+func (v StorageCapabilityControllerValue) Encode(e *atree.Encoder) error {
+	// Encode tag number and array head
+	err := e.CBOR.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, CBORTagStorageCapabilityControllerValue,
+		// array, 3 items follow
+		0x83,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Encode borrow type at array index encodedStorageCapabilityControllerValueBorrowTypeFieldKey
+	err = EncodeStaticType(e.CBOR, v.BorrowType)
+	if err != nil {
+		return err
+	}
+
+	// Encode capability ID at array index encodedStorageCapabilityControllerValueCapabilityIDFieldKey
+	err = v.CapabilityID.Encode(e)
+	if err != nil {
+		return err
+	}
+
+	// Encode target path at array index encodedStorageCapabilityControllerValueTargetPathFieldKey
+	return v.TargetPath.Encode(e)
+}
+
 // NOTE: NEVER change, only add/increment; ensure uint64
 const (
 	// encodedTypeValueTypeFieldKey uint64 = 0
@@ -1134,31 +1242,47 @@ func (t ConstantSizedStaticType) Encode(e *cbor.StreamEncoder) error {
 
 // NOTE: NEVER change, only add/increment; ensure uint64
 const (
-	// encodedReferenceStaticTypeAuthorizedFieldKey uint64 = 0
-	// encodedReferenceStaticTypeTypeFieldKey       uint64 = 1
+	// encodedReferenceStaticTypeAuthorizedFieldKey    uint64 = 0
+	// encodedReferenceStaticTypeTypeFieldKey          uint64 = 1
+	// encodedReferenceStaticTypeAuthorizationFieldKey uint64 = 2
 
 	// !!! *WARNING* !!!
 	//
 	// encodedReferenceStaticTypeLength MUST be updated when new element is added.
 	// It is used to verify encoded reference static type length during decoding.
 	encodedReferenceStaticTypeLength = 2
+
+	// encodedEntitledReferenceStaticTypeLength is the length of
+	// the encoding of a reference static type which has an authorization.
+	// Non-entitled reference static types keep the shorter encoding,
+	// so existing encoded values stay unchanged.
+	encodedEntitledReferenceStaticTypeLength = 3
 )
 
 // Encode encodes ReferenceStaticType as
 // cbor.Tag{
 //		Number: CBORTagReferenceStaticType,
 //		Content: cborArray{
-//				encodedReferenceStaticTypeAuthorizedFieldKey: bool(v.Authorized),
-//				encodedReferenceStaticTypeTypeFieldKey:       StaticType(v.Type),
+//				encodedReferenceStaticTypeAuthorizedFieldKey:    bool(v.Authorized),
+//				encodedReferenceStaticTypeTypeFieldKey:          StaticType(v.Type),
+//				encodedReferenceStaticTypeAuthorizationFieldKey: *EntitlementSetAuthorization(v.Authorization),
 //		},
 //	}
+//
+// The authorization element is only encoded if the reference has an authorization.
 func (t ReferenceStaticType) Encode(e *cbor.StreamEncoder) error {
+	// array, 2 items follow
+	var arrayHead byte = 0x82
+	if t.Authorization != nil {
+		// array, 3 items follow
+		arrayHead = 0x83
+	}
+
 	// Encode tag number and array head
 	err := e.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, CBORTagReferenceStaticType,
-		// array, 2 items follow
-		0x82,
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -1169,7 +1293,65 @@ func (t ReferenceStaticType) Encode(e *cbor.StreamEncoder) error {
 		return err
 	}
 	// Encode type at array index encodedReferenceStaticTypeTypeFieldKey
-	return EncodeStaticType(e, t.Type)
+	err = EncodeStaticType(e, t.Type)
+	if err != nil {
+		return err
+	}
+
+	if t.Authorization == nil {
+		return nil
+	}
+
+	// Encode authorization at array index encodedReferenceStaticTypeAuthorizationFieldKey
+	return t.Authorization.Encode(e)
+}
+
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedEntitlementSetAuthorizationSetKindFieldKey      uint64 = 0
+	// encodedEntitlementSetAuthorizationEntitlementsFieldKey uint64 = 1
+
+	// !!! *WARNING* !!!
+	//
+	// encodedEntitlementSetAuthorizationLength MUST be updated when new element is added.
+	// It is used to verify encoded entitlement set authorization length during decoding.
+	encodedEntitlementSetAuthorizationLength = 2
+)
+
+// Encode encodes EntitlementSetAuthorization as
+// cborArray{
+//		encodedEntitlementSetAuthorizationSetKindFieldKey:      uint(v.SetKind),
+//		encodedEntitlementSetAuthorizationEntitlementsFieldKey: []string(v.Entitlements),
+// }
+func (a *EntitlementSetAuthorization) Encode(e *cbor.StreamEncoder) error {
+	// Encode array head
+	err := e.EncodeRawBytes([]byte{
+		// array, 2 items follow
+		0x82,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Encode set kind at array index encodedEntitlementSetAuthorizationSetKindFieldKey
+	err = e.EncodeUint(uint(a.SetKind))
+	if err != nil {
+		return err
+	}
+
+	// Encode entitlements at array index encodedEntitlementSetAuthorizationEntitlementsFieldKey
+	err = e.EncodeArrayHead(uint64(len(a.Entitlements)))
+	if err != nil {
+		return err
+	}
+	for _, entitlement := range a.Entitlements {
+		err = e.EncodeString(string(entitlement))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -1279,6 +1461,22 @@ func (t CapabilityStaticType) Encode(e *cbor.StreamEncoder) error {
 	return EncodeStaticType(e, t.BorrowType)
 }
 
+// Encode encodes InclusiveRangeStaticType as
+// cbor.Tag{
+//		Number:  CBORTagInclusiveRangeStaticType,
+//		Content: StaticType(v.ElementType),
+// }
+func (t InclusiveRangeStaticType) Encode(e *cbor.StreamEncoder) error {
+	err := e.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, CBORTagInclusiveRangeStaticType,
+	})
+	if err != nil {
+		return err
+	}
+	return EncodeStaticType(e, t.ElementType)
+}
+
 func (t FunctionStaticType) Encode(_ *cbor.StreamEncoder) error {
 	return NonStorableStaticTypeError{
 		Type: t,
@@ -253,6 +253,74 @@ func TestEncodeDecodeString(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeBytes(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		expected := NewBytesValue([]byte{})
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: expected,
+				encoded: []byte{
+					// tag
+					0xd8, CBORTagBytesValue,
+
+					// byte string, 0 bytes follow
+					0x40,
+				},
+			})
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		expected := NewBytesValue([]byte{1, 2, 0xff})
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: expected,
+				encoded: []byte{
+					// tag
+					0xd8, CBORTagBytesValue,
+
+					// byte string, 3 bytes follow
+					0x43,
+					// 1, 2, 255
+					0x1, 0x2, 0xff,
+				},
+			},
+		)
+	})
+
+	t.Run("larger than max inline size", func(t *testing.T) {
+
+		t.Parallel()
+
+		maxInlineElementSize := atree.MaxInlineArrayElementSize
+		expected := NewBytesValue(make([]byte, maxInlineElementSize+1))
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:                expected,
+				maxInlineElementSize: maxInlineElementSize,
+				encoded: []byte{
+					// tag
+					0xd8, atree.CBORTagStorageID,
+
+					// storage ID
+					0x50, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x42, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1,
+				},
+			},
+		)
+	})
+}
+
 func TestEncodeDecodeArray(t *testing.T) {
 
 	t.Parallel()
@@ -3179,6 +3247,23 @@ func TestEncodeDecodeCapabilityValue(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeAccountLinkValue(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeDecode(t,
+		encodeDecodeTest{
+			value: AccountLinkValue{},
+			encoded: []byte{
+				// tag
+				0xd8, CBORTagAccountLinkValue,
+				// array, 0 items follow
+				0x80,
+			},
+		},
+	)
+}
+
 func TestEncodeDecodeLinkValue(t *testing.T) {
 
 	t.Parallel()
@@ -3466,6 +3551,53 @@ func TestEncodeDecodeLinkValue(t *testing.T) {
 		)
 	})
 
+	t.Run("reference type, entitled, bool", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := LinkValue{
+			TargetPath: publicPathValue,
+			Type: ReferenceStaticType{
+				Authorization: &EntitlementSetAuthorization{
+					Entitlements: []common.TypeID{"S.test.E"},
+					SetKind:      sema.EntitlementSetKindDisjunction,
+				},
+				Type: PrimitiveStaticTypeBool,
+			},
+		}
+
+		//nolint:gocritic
+		encoded := append(
+			expectedLinkEncodingPrefix[:],
+			// tag
+			0xd8, CBORTagReferenceStaticType,
+			// array, 3 items follow
+			0x83,
+			// false
+			0xf4,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			0x6,
+			// array, 2 items follow
+			0x82,
+			// positive integer 1
+			0x1,
+			// array, 1 item follows
+			0x81,
+			// UTF-8 string, length 8
+			0x68,
+			// S.test.E
+			0x53, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x45,
+		)
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   value,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("dictionary, bool, string", func(t *testing.T) {
 
 		t.Parallel()
@@ -3823,4 +3955,34 @@ func TestEncodeDecodeStaticType(t *testing.T) {
 
 		require.Equal(t, ty, actualType)
 	})
+
+	t.Run("inclusive range", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := InclusiveRangeStaticType{
+			ElementType: PrimitiveStaticTypeInt,
+		}
+
+		encoded := cbor.RawMessage{
+			// tag
+			0xd8, CBORTagInclusiveRangeStaticType,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// positive integer to follow
+			0x18,
+			// int
+			0x24,
+		}
+
+		actualEncoded, err := StaticTypeToBytes(ty)
+		require.NoError(t, err)
+
+		AssertEqualWithDiff(t, encoded, actualEncoded)
+
+		actualType, err := StaticTypeFromBytes(encoded)
+		require.NoError(t, err)
+
+		require.Equal(t, ty, actualType)
+	})
 }
@@ -207,6 +207,58 @@ func (e DivisionByZeroError) Error() string {
 	return "division by zero"
 }
 
+// NegativeSquareRootError
+
+type NegativeSquareRootError struct{}
+
+func (e NegativeSquareRootError) Error() string {
+	return "square root of negative number"
+}
+
+// NumberConversionError is reported when a number value
+// cannot be converted to another number type, because it is outside of its range.
+// The error wraps the underlying OverflowError or UnderflowError.
+//
+type NumberConversionError struct {
+	Err            error
+	Value          Value
+	TargetTypeName string
+	Min            Value
+	Max            Value
+	LocationRange
+}
+
+func (e NumberConversionError) Unwrap() error {
+	return e.Err
+}
+
+func (e NumberConversionError) Error() string {
+	message := fmt.Sprintf(
+		"cannot convert value `%s` to type `%s`: %s",
+		e.Value,
+		e.TargetTypeName,
+		e.Err.Error(),
+	)
+
+	if e.Min == nil && e.Max == nil {
+		return message
+	}
+
+	formatBound := func(bound Value, unbounded string) string {
+		if bound == nil {
+			return unbounded
+		}
+		return bound.String()
+	}
+
+	return fmt.Sprintf(
+		"%s, expected value in range [%s, %s]",
+		message,
+		formatBound(e.Min, "-∞"),
+		formatBound(e.Max, "∞"),
+	)
+}
+
 // InvalidatedResourceError
 
 type InvalidatedResourceError struct {
@@ -241,10 +293,27 @@ func (e ForceNilError) Error() string {
 //
 type ForceCastTypeMismatchError struct {
 	ExpectedType sema.Type
+	// ActualType is optional
+	ActualType sema.Type
 	LocationRange
 }
 
 func (e ForceCastTypeMismatchError) Error() string {
+	if e.ActualType != nil {
+		expected, actual, hint := sema.ErrorMessageExpectedActualTypes(
+			e.ExpectedType,
+			e.ActualType,
+		)
+		if hint != "" {
+			return fmt.Sprintf(
+				"unexpectedly found `%s` while force-casting value to `%s`: %s",
+				actual,
+				expected,
+				hint,
+			)
+		}
+	}
+
 	return fmt.Sprintf(
 		"unexpectedly found non-`%s` while force-casting value",
 		e.ExpectedType.QualifiedString(),
@@ -255,10 +324,27 @@ func (e ForceCastTypeMismatchError) Error() string {
 //
 type TypeMismatchError struct {
 	ExpectedType sema.Type
+	// ActualType is optional
+	ActualType sema.Type
 	LocationRange
 }
 
 func (e TypeMismatchError) Error() string {
+	if e.ActualType != nil {
+		expected, actual, hint := sema.ErrorMessageExpectedActualTypes(
+			e.ExpectedType,
+			e.ActualType,
+		)
+		if hint != "" {
+			return fmt.Sprintf(
+				"type mismatch: expected %s, got %s: %s",
+				expected,
+				actual,
+				hint,
+			)
+		}
+	}
+
 	return fmt.Sprintf(
 		"type mismatch: expected %s",
 		e.ExpectedType.QualifiedString(),
@@ -308,6 +394,22 @@ func (e OverwriteError) Error() string {
 	)
 }
 
+// CapabilityAddressPublishingError
+//
+type CapabilityAddressPublishingError struct {
+	CapabilityAddress AddressValue
+	AccountAddress    AddressValue
+	LocationRange
+}
+
+func (e CapabilityAddressPublishingError) Error() string {
+	return fmt.Sprintf(
+		"cannot publish capability of account %s in account %s",
+		e.CapabilityAddress,
+		e.AccountAddress,
+	)
+}
+
 // CyclicLinkError
 //
 type CyclicLinkError struct {
@@ -328,7 +430,7 @@ func (e CyclicLinkError) Error() string {
 
 	return fmt.Sprintf(
 		"cyclic link in account %s: %s",
-		e.Address.ShortHexWithPrefix(),
+		e.Address.HexWithPrefix(),
 		paths,
 	)
 }
@@ -349,6 +451,16 @@ func (e ArrayIndexOutOfBoundsError) Error() string {
 	)
 }
 
+// ArrayElementNotFoundError
+//
+type ArrayElementNotFoundError struct {
+	LocationRange
+}
+
+func (e ArrayElementNotFoundError) Error() string {
+	return "no element of the array satisfies the predicate"
+}
+
 // ArraySliceIndicesError
 //
 type ArraySliceIndicesError struct {
@@ -377,6 +489,17 @@ func (e InvalidSliceIndexError) Error() string {
 	return fmt.Sprintf("invalid slice index: %d > %d", e.FromIndex, e.UpToIndex)
 }
 
+// InclusiveRangeConstructionError
+//
+type InclusiveRangeConstructionError struct {
+	Message string
+	LocationRange
+}
+
+func (e InclusiveRangeConstructionError) Error() string {
+	return fmt.Sprintf("failed to construct inclusive range: %s", e.Message)
+}
+
 // StringIndexOutOfBoundsError
 //
 type StringIndexOutOfBoundsError struct {
@@ -511,6 +634,20 @@ func (e ResourceConstructionError) Error() string {
 	)
 }
 
+// DuplicateAttachmentError
+//
+type DuplicateAttachmentError struct {
+	AttachmentType *sema.CompositeType
+	LocationRange
+}
+
+func (e DuplicateAttachmentError) Error() string {
+	return fmt.Sprintf(
+		"cannot attach %s: value already has this attachment",
+		e.AttachmentType.QualifiedString(),
+	)
+}
+
 // ContainerMutationError
 //
 type ContainerMutationError struct {
@@ -520,11 +657,20 @@ type ContainerMutationError struct {
 }
 
 func (e ContainerMutationError) Error() string {
-	return fmt.Sprintf(
+	expected, actual, hint := sema.ErrorMessageExpectedActualTypes(
+		e.ExpectedType,
+		e.ActualType,
+	)
+
+	message := fmt.Sprintf(
 		"invalid container update: expected a subtype of '%s', found '%s'",
-		e.ExpectedType.QualifiedString(),
-		e.ActualType.QualifiedString(),
+		expected,
+		actual,
 	)
+	if hint != "" {
+		message += ": " + hint
+	}
+	return message
 }
 
 // NonStorableValueError
@@ -591,3 +737,49 @@ func (e InvalidOperandsError) Error() string {
 		e.RightType.String(),
 	)
 }
+
+// HasherFinalizedError is reported when a hasher is used after it was finalized
+//
+type HasherFinalizedError struct {
+	LocationRange
+}
+
+func (e HasherFinalizedError) Error() string {
+	return "hasher is already finalized"
+}
+
+// UnsupportedSignatureSchemeError is reported when a signature is verified
+// using a signature scheme which is not supported
+//
+type UnsupportedSignatureSchemeError struct {
+	Scheme string
+	LocationRange
+}
+
+func (e UnsupportedSignatureSchemeError) Error() string {
+	return fmt.Sprintf("unsupported signature scheme: %s", e.Scheme)
+}
+
+// InvariantViolationError is reported when invariant checks are enabled
+// and an internal invariant of a value does not hold, e.g. because a value got corrupted
+//
+type InvariantViolationError struct {
+	Message string
+	LocationRange
+}
+
+func (e InvariantViolationError) Error() string {
+	return fmt.Sprintf("internal invariant violated: %s", e.Message)
+}
+
+// ReadOnlyViolationError is reported when read-only enforcement is enabled
+// and the program attempts to write, e.g. to account storage
+//
+type ReadOnlyViolationError struct {
+	Operation string
+	LocationRange
+}
+
+func (e ReadOnlyViolationError) Error() string {
+	return fmt.Sprintf("read-only violation: cannot %s, the program may only read", e.Operation)
+}
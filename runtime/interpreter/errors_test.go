@@ -25,6 +25,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/common"
 	. "github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 func TestOverwriteError_Error(t *testing.T) {
@@ -40,3 +41,44 @@ func TestOverwriteError_Error(t *testing.T) {
 		"failed to save object: path /storage/test in account 0x0000000000000001 already stores an object",
 	)
 }
+
+func TestForceCastTypeMismatchError_Error(t *testing.T) {
+
+	t.Parallel()
+
+	newFooType := func(location common.Location) *sema.CompositeType {
+		return &sema.CompositeType{
+			Location:   location,
+			Identifier: "Foo",
+			Kind:       common.CompositeKindStructure,
+			Members:    sema.NewStringMemberOrderedMap(),
+		}
+	}
+
+	t.Run("different names", func(t *testing.T) {
+
+		t.Parallel()
+
+		require.EqualError(t,
+			ForceCastTypeMismatchError{
+				ExpectedType: sema.IntType,
+				ActualType:   sema.StringType,
+			},
+			"unexpectedly found non-`Int` while force-casting value",
+		)
+	})
+
+	t.Run("same name, different locations", func(t *testing.T) {
+
+		t.Parallel()
+
+		require.EqualError(t,
+			ForceCastTypeMismatchError{
+				ExpectedType: newFooType(common.StringLocation("a")),
+				ActualType:   newFooType(common.StringLocation("b")),
+			},
+			"unexpectedly found `S.b.Foo` while force-casting value to `S.a.Foo`: "+
+				"the types have the same name, but are declared in different locations",
+		)
+	})
+}
@@ -61,7 +61,7 @@ const (
 	HashInputTypeAddress
 	HashInputTypePath
 	HashInputTypeType
-	_
+	HashInputTypeBytes
 	_
 	_
 	_
@@ -0,0 +1,277 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"math/big"
+
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// InclusiveRange
+
+var inclusiveRangeFieldNames = []string{
+	sema.InclusiveRangeTypeStartFieldName,
+	sema.InclusiveRangeTypeEndFieldName,
+	sema.InclusiveRangeTypeStepFieldName,
+}
+
+// NewInclusiveRangeValue constructs an InclusiveRange value
+// with the given start, end, and step, which must all be of the given element type.
+//
+// If the step is nil, it defaults to 1 if start <= end, and -1 otherwise.
+//
+// The range is not materialized: its elements are only computed on demand,
+// see Interpreter.forEachInclusiveRangeElement.
+//
+func NewInclusiveRangeValue(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	start IntegerValue,
+	end IntegerValue,
+	step IntegerValue,
+	elementType sema.Type,
+) *SimpleCompositeValue {
+
+	startBigInt := integerValueToBigInt(start)
+	endBigInt := integerValueToBigInt(end)
+
+	comparison := startBigInt.Cmp(endBigInt)
+
+	if step == nil {
+		if comparison > 0 && !sema.IsSubType(elementType, sema.SignedIntegerType) {
+			panic(InclusiveRangeConstructionError{
+				Message:       "start is greater than end, but the element type is unsigned",
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		var defaultStep int64 = 1
+		if comparison > 0 {
+			defaultStep = -1
+		}
+
+		step = interpreter.convert(
+			NewIntValueFromInt64(defaultStep),
+			sema.IntType,
+			elementType,
+		).(IntegerValue)
+	} else {
+		stepSign := integerValueToBigInt(step).Sign()
+
+		if stepSign == 0 {
+			panic(InclusiveRangeConstructionError{
+				Message:       "step must not be zero",
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		// The step must point from the start towards the end
+
+		if (comparison < 0 && stepSign < 0) ||
+			(comparison > 0 && stepSign > 0) {
+
+			panic(InclusiveRangeConstructionError{
+				Message:       "step does not move from start towards end",
+				LocationRange: getLocationRange(),
+			})
+		}
+	}
+
+	rangeSemaType := &sema.InclusiveRangeType{
+		MemberType: elementType,
+	}
+
+	var rangeValue *SimpleCompositeValue
+
+	containsFunction := NewHostFunctionValue(
+		func(invocation Invocation) Value {
+			element, ok := invocation.Arguments[0].(IntegerValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			return BoolValue(inclusiveRangeContains(rangeValue, element))
+		},
+		sema.InclusiveRangeContainsFunctionType(elementType),
+	)
+
+	rangeValue = NewSimpleCompositeValue(
+		rangeSemaType.ID(),
+		ConvertSemaToStaticType(rangeSemaType),
+		InclusiveRangeDynamicType{
+			ElementType: elementType,
+		},
+		inclusiveRangeFieldNames,
+		map[string]Value{
+			sema.InclusiveRangeTypeStartFieldName:       start,
+			sema.InclusiveRangeTypeEndFieldName:         end,
+			sema.InclusiveRangeTypeStepFieldName:        step,
+			sema.InclusiveRangeTypeContainsFunctionName: containsFunction,
+		},
+		nil,
+		nil,
+		nil,
+	)
+
+	return rangeValue
+}
+
+func integerValueToBigInt(value IntegerValue) *big.Int {
+	// NOTE: copy, as the converted value may share the big integer
+	return new(big.Int).Set(ConvertInt(value).BigInt)
+}
+
+// inclusiveRangeBounds returns the start, end, and step of the given range value
+// as big integers, so they can be compared and stepped through without overflowing
+//
+func inclusiveRangeBounds(rangeValue *SimpleCompositeValue) (start, end, step *big.Int) {
+	getField := func(name string) *big.Int {
+		value, ok := rangeValue.Fields[name].(IntegerValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+		return integerValueToBigInt(value)
+	}
+
+	start = getField(sema.InclusiveRangeTypeStartFieldName)
+	end = getField(sema.InclusiveRangeTypeEndFieldName)
+	step = getField(sema.InclusiveRangeTypeStepFieldName)
+
+	return
+}
+
+func inclusiveRangeContains(rangeValue *SimpleCompositeValue, element IntegerValue) bool {
+	start, end, step := inclusiveRangeBounds(rangeValue)
+
+	elementBigInt := integerValueToBigInt(element)
+
+	lower, upper := start, end
+	if step.Sign() < 0 {
+		lower, upper = end, start
+	}
+
+	if elementBigInt.Cmp(lower) < 0 || elementBigInt.Cmp(upper) > 0 {
+		return false
+	}
+
+	offset := new(big.Int).Sub(elementBigInt, start)
+	return offset.Rem(offset, step).Sign() == 0
+}
+
+// isInclusiveRangeValue returns the given value as a composite value,
+// and true if it is an InclusiveRange value
+//
+func isInclusiveRangeValue(value Value) (*SimpleCompositeValue, bool) {
+	compositeValue, ok := value.(*SimpleCompositeValue)
+	if !ok {
+		return nil, false
+	}
+
+	_, ok = compositeValue.dynamicType.(InclusiveRangeDynamicType)
+	return compositeValue, ok
+}
+
+// forEachInclusiveRangeElement calls the given function for each element of the given range,
+// in order, until the function returns false.
+// The elements are computed one at a time, i.e. the range is never materialized.
+//
+func (interpreter *Interpreter) forEachInclusiveRangeElement(
+	rangeValue *SimpleCompositeValue,
+	f func(element Value) (resume bool),
+) {
+	dynamicType, ok := rangeValue.dynamicType.(InclusiveRangeDynamicType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	elementType := dynamicType.ElementType
+
+	current, end, step := inclusiveRangeBounds(rangeValue)
+
+	ascending := step.Sign() > 0
+
+	for {
+		comparison := current.Cmp(end)
+		if (ascending && comparison > 0) ||
+			(!ascending && comparison < 0) {
+
+			return
+		}
+
+		element := interpreter.convert(
+			NewIntValueFromBigInt(new(big.Int).Set(current)),
+			sema.IntType,
+			elementType,
+		)
+
+		if !f(element) {
+			return
+		}
+
+		current.Add(current, step)
+	}
+}
+
+// inclusiveRangeFunction is the `InclusiveRange` function. It is stateless, hence it can be re-used across interpreters.
+//
+var inclusiveRangeFunction = NewHostFunctionValue(
+	func(invocation Invocation) Value {
+
+		typeParameterPair := invocation.TypeParameterTypes.Oldest()
+		if typeParameterPair == nil {
+			panic(errors.NewUnreachableError())
+		}
+
+		elementType := typeParameterPair.Value
+
+		start, ok := invocation.Arguments[0].(IntegerValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		end, ok := invocation.Arguments[1].(IntegerValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		var step IntegerValue
+		if len(invocation.Arguments) > 2 {
+			step, ok = invocation.Arguments[2].(IntegerValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+		}
+
+		return NewInclusiveRangeValue(
+			invocation.Interpreter,
+			invocation.GetLocationRange,
+			start,
+			end,
+			step,
+			elementType,
+		)
+	},
+	sema.InclusiveRangeConstructorFunctionType,
+)
+
+func defineInclusiveRangeFunction(activation *VariableActivation) {
+	defineBaseValue(activation, sema.InclusiveRangeTypeName, inclusiveRangeFunction)
+}
@@ -0,0 +1,337 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// InspectedValueKind is the kind of an inspected stored value.
+//
+type InspectedValueKind uint8
+
+const (
+	InspectedValueKindUnknown InspectedValueKind = iota
+	InspectedValueKindPrimitive
+	InspectedValueKindOptional
+	InspectedValueKindArray
+	InspectedValueKindDictionary
+	InspectedValueKindComposite
+	InspectedValueKindInvalid
+)
+
+func (k InspectedValueKind) String() string {
+	switch k {
+	case InspectedValueKindPrimitive:
+		return "Primitive"
+	case InspectedValueKindOptional:
+		return "Optional"
+	case InspectedValueKindArray:
+		return "Array"
+	case InspectedValueKindDictionary:
+		return "Dictionary"
+	case InspectedValueKindComposite:
+		return "Composite"
+	case InspectedValueKindInvalid:
+		return "Invalid"
+	default:
+		return "Unknown"
+	}
+}
+
+// InspectedValue is a generic, schema-less representation of a stored value.
+//
+// It is produced by decoding the stored data only,
+// i.e. without the types of the program which originally stored the value,
+// and is intended for the analysis of (potentially broken) storage.
+//
+// Decoding failures do not abort the inspection:
+// The affected value has the kind InspectedValueKindInvalid
+// and its Error field describes the failure.
+//
+type InspectedValue struct {
+	Kind InspectedValueKind
+	// TypeID is the type ID of the static type of the value, if known
+	TypeID string
+	// Value is the string representation of a primitive value
+	Value string
+	// CompositeKind is the kind of a composite value
+	CompositeKind common.CompositeKind
+	// Inner is the inner value of a non-nil optional value
+	Inner *InspectedValue
+	// Fields are the fields of a composite value
+	Fields []InspectedField
+	// Elements are the elements of an array value
+	Elements []InspectedValue
+	// Entries are the entries of a dictionary value
+	Entries []InspectedEntry
+	// Error is the decoding error of an invalid value
+	Error string
+}
+
+// InspectedField is a field of an inspected composite value.
+//
+type InspectedField struct {
+	Name  string
+	Value InspectedValue
+}
+
+// InspectedEntry is an entry of an inspected dictionary value.
+//
+type InspectedEntry struct {
+	Key   InspectedValue
+	Value InspectedValue
+}
+
+func newInvalidInspectedValue(err error) InspectedValue {
+	return InspectedValue{
+		Kind:  InspectedValueKindInvalid,
+		Error: err.Error(),
+	}
+}
+
+// InspectEncodedStorable decodes the given CBOR-encoded storable
+// and inspects it, loading any referenced slabs from the given storage.
+//
+func InspectEncodedStorable(storage atree.SlabStorage, data []byte) InspectedValue {
+	decoder := CBORDecMode.NewByteStreamDecoder(data)
+
+	storable, err := DecodeStorable(decoder, atree.StorageIDUndefined)
+	if err != nil {
+		return newInvalidInspectedValue(err)
+	}
+
+	return InspectStorable(storage, storable)
+}
+
+// InspectSlab inspects the value stored in the slab with the given storage ID.
+//
+func InspectSlab(storage atree.SlabStorage, storageID atree.StorageID) InspectedValue {
+	return InspectStorable(storage, atree.StorageIDStorable(storageID))
+}
+
+// InspectStorable inspects the given storable,
+// loading any referenced slabs from the given storage.
+//
+func InspectStorable(storage atree.SlabStorage, storable atree.Storable) (result InspectedValue) {
+
+	// Broken storage might cause panics in decoding code,
+	// report them as invalid values instead
+
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			result = newInvalidInspectedValue(err)
+		}
+	}()
+
+	if someStorable, ok := storable.(SomeStorable); ok {
+		inner := InspectStorable(storage, someStorable.Storable)
+
+		result = InspectedValue{
+			Kind:  InspectedValueKindOptional,
+			Inner: &inner,
+		}
+		if inner.TypeID != "" {
+			result.TypeID = inner.TypeID + "?"
+		}
+		return result
+	}
+
+	storedValue, err := storable.StoredValue(storage)
+	if err != nil {
+		return newInvalidInspectedValue(err)
+	}
+
+	return inspectStoredValue(storage, storedValue)
+}
+
+func inspectStoredValue(storage atree.SlabStorage, storedValue atree.Value) InspectedValue {
+	switch storedValue := storedValue.(type) {
+	case *atree.Array:
+		return inspectArray(storage, storedValue)
+
+	case *atree.OrderedMap:
+		switch typeInfo := storedValue.Type().(type) {
+		case DictionaryStaticType:
+			return inspectDictionary(storage, storedValue, typeInfo)
+
+		case compositeTypeInfo:
+			return inspectComposite(storage, storedValue, typeInfo)
+
+		default:
+			return newInvalidInspectedValue(
+				fmt.Errorf("invalid ordered map type info: %T", typeInfo),
+			)
+		}
+
+	case NilValue:
+		return InspectedValue{
+			Kind: InspectedValueKindOptional,
+		}
+
+	case *SomeValue:
+		inner := inspectStoredValue(storage, storedValue.Value)
+		result := InspectedValue{
+			Kind:  InspectedValueKindOptional,
+			Inner: &inner,
+		}
+		if inner.TypeID != "" {
+			result.TypeID = inner.TypeID + "?"
+		}
+		return result
+
+	case Value:
+		result := InspectedValue{
+			Kind:  InspectedValueKindPrimitive,
+			Value: storedValue.String(),
+		}
+		if staticType := storedValue.StaticType(); staticType != nil {
+			result.TypeID = staticType.String()
+		}
+		return result
+
+	default:
+		return newInvalidInspectedValue(
+			fmt.Errorf("cannot inspect stored value: %T", storedValue),
+		)
+	}
+}
+
+func inspectArray(storage atree.SlabStorage, array *atree.Array) InspectedValue {
+	result := InspectedValue{
+		Kind: InspectedValueKindArray,
+	}
+
+	arrayType, ok := array.Type().(ArrayStaticType)
+	if ok {
+		result.TypeID = arrayType.String()
+	}
+
+	// Get each element individually,
+	// so a broken element does not prevent inspecting the remaining elements
+
+	count := array.Count()
+	result.Elements = make([]InspectedValue, count)
+
+	for i := uint64(0); i < count; i++ {
+		element, err := array.Get(i)
+		if err != nil {
+			result.Elements[i] = newInvalidInspectedValue(err)
+			continue
+		}
+		// Compactly stored elements can only be converted using the element type of the array
+
+		if compactInteger, ok := element.(compactIntegerAtreeValue); ok && arrayType != nil {
+			result.Elements[i] = inspectStoredValue(storage, compactInteger.value(arrayType.ElementType()))
+			continue
+		}
+
+		result.Elements[i] = InspectStorable(storage, element)
+	}
+
+	return result
+}
+
+func inspectDictionary(
+	storage atree.SlabStorage,
+	dictionary *atree.OrderedMap,
+	staticType DictionaryStaticType,
+) InspectedValue {
+	result := InspectedValue{
+		Kind:   InspectedValueKindDictionary,
+		TypeID: staticType.String(),
+	}
+
+	// NOTE: getting entries individually requires the key comparison and hashing
+	// of the interpreter, so a broken entry stops the iteration,
+	// and the error is reported for the dictionary
+
+	err := dictionary.Iterate(func(key atree.Value, value atree.Value) (resume bool, err error) {
+		result.Entries = append(
+			result.Entries,
+			InspectedEntry{
+				Key:   inspectStoredValue(storage, key),
+				Value: inspectStoredValue(storage, value),
+			},
+		)
+		return true, nil
+	})
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func inspectComposite(
+	storage atree.SlabStorage,
+	dictionary *atree.OrderedMap,
+	typeInfo compositeTypeInfo,
+) InspectedValue {
+	result := InspectedValue{
+		Kind:          InspectedValueKindComposite,
+		TypeID:        string(common.NewTypeIDFromQualifiedName(typeInfo.location, typeInfo.qualifiedIdentifier)),
+		CompositeKind: typeInfo.kind,
+	}
+
+	// Iterate over the field names only and get each field value individually,
+	// so a broken field does not prevent inspecting the remaining fields
+
+	err := dictionary.IterateKeys(func(key atree.Value) (resume bool, err error) {
+		fieldName, ok := key.(stringAtreeValue)
+		if !ok {
+			return false, fmt.Errorf("invalid composite field name: %T", key)
+		}
+
+		var fieldValue InspectedValue
+
+		storable, err := dictionary.Get(
+			stringAtreeComparator,
+			stringAtreeHashInput,
+			fieldName,
+		)
+		if err != nil {
+			fieldValue = newInvalidInspectedValue(err)
+		} else {
+			fieldValue = InspectStorable(storage, storable)
+		}
+
+		result.Fields = append(
+			result.Fields,
+			InspectedField{
+				Name:  string(fieldName),
+				Value: fieldValue,
+			},
+		)
+		return true, nil
+	})
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,215 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	. "github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInspectStorable(t *testing.T) {
+
+	t.Parallel()
+
+	newTestValue := func(t *testing.T) (InMemoryStorage, *CompositeValue) {
+
+		storage := NewInMemoryStorage()
+
+		inter, err := NewInterpreter(
+			nil,
+			common.AddressLocation{},
+			WithStorage(storage),
+		)
+		require.NoError(t, err)
+
+		array := NewArrayValue(
+			inter,
+			VariableSizedStaticType{
+				Type: PrimitiveStaticTypeString,
+			},
+			testOwner,
+			NewStringValue("x"),
+		)
+
+		dictionary := NewDictionaryValueWithAddress(
+			inter,
+			DictionaryStaticType{
+				KeyType: PrimitiveStaticTypeString,
+				ValueType: OptionalStaticType{
+					Type: PrimitiveStaticTypeInt,
+				},
+			},
+			testOwner,
+			NewStringValue("y"),
+			NewSomeValueNonCopying(NewIntValueFromInt64(1)),
+		)
+
+		value := NewCompositeValue(
+			inter,
+			TestLocation,
+			"Test",
+			common.CompositeKindResource,
+			[]CompositeField{
+				{Name: "a", Value: NewIntValueFromInt64(42)},
+				{Name: "b", Value: array},
+				{Name: "c", Value: dictionary},
+				{Name: "d", Value: NilValue{}},
+			},
+			testOwner,
+		)
+
+		return storage, value
+	}
+
+	fieldsByName := func(inspected InspectedValue) map[string]InspectedValue {
+		result := map[string]InspectedValue{}
+		for _, field := range inspected.Fields {
+			result[field.Name] = field.Value
+		}
+		return result
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage, value := newTestValue(t)
+
+		inspected := InspectSlab(storage, value.StorageID())
+
+		assert.Equal(t, InspectedValueKindComposite, inspected.Kind)
+		assert.Equal(t, "S.test.Test", inspected.TypeID)
+		assert.Equal(t, common.CompositeKindResource, inspected.CompositeKind)
+		assert.Empty(t, inspected.Error)
+
+		fields := fieldsByName(inspected)
+		require.Len(t, fields, 4)
+
+		assert.Equal(t,
+			InspectedValue{
+				Kind:   InspectedValueKindPrimitive,
+				TypeID: "Int",
+				Value:  "42",
+			},
+			fields["a"],
+		)
+
+		assert.Equal(t,
+			InspectedValue{
+				Kind:   InspectedValueKindArray,
+				TypeID: "[String]",
+				Elements: []InspectedValue{
+					{
+						Kind:   InspectedValueKindPrimitive,
+						TypeID: "String",
+						Value:  `"x"`,
+					},
+				},
+			},
+			fields["b"],
+		)
+
+		assert.Equal(t,
+			InspectedValue{
+				Kind:   InspectedValueKindDictionary,
+				TypeID: "{String: Int?}",
+				Entries: []InspectedEntry{
+					{
+						Key: InspectedValue{
+							Kind:   InspectedValueKindPrimitive,
+							TypeID: "String",
+							Value:  `"y"`,
+						},
+						Value: InspectedValue{
+							Kind:   InspectedValueKindOptional,
+							TypeID: "Int?",
+							Inner: &InspectedValue{
+								Kind:   InspectedValueKindPrimitive,
+								TypeID: "Int",
+								Value:  "1",
+							},
+						},
+					},
+				},
+			},
+			fields["c"],
+		)
+
+		assert.Equal(t,
+			InspectedValue{
+				Kind: InspectedValueKindOptional,
+			},
+			fields["d"],
+		)
+	})
+
+	t.Run("missing slab", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage, value := newTestValue(t)
+
+		array := value.GetField("b").(*ArrayValue)
+
+		err := storage.Remove(array.StorageID())
+		require.NoError(t, err)
+
+		inspected := InspectSlab(storage, value.StorageID())
+
+		assert.Equal(t, InspectedValueKindComposite, inspected.Kind)
+
+		fields := fieldsByName(inspected)
+		require.Len(t, fields, 4)
+
+		assert.Equal(t, InspectedValueKindPrimitive, fields["a"].Kind)
+		assert.Equal(t, InspectedValueKindInvalid, fields["b"].Kind)
+		assert.NotEmpty(t, fields["b"].Error)
+		assert.Equal(t, InspectedValueKindDictionary, fields["c"].Kind)
+	})
+
+	t.Run("encoded", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := NewInMemoryStorage()
+
+		data, err := atree.Encode(BoolValue(true), CBOREncMode)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			InspectedValue{
+				Kind:   InspectedValueKindPrimitive,
+				TypeID: "Bool",
+				Value:  "true",
+			},
+			InspectEncodedStorable(storage, data),
+		)
+
+		inspected := InspectEncodedStorable(storage, []byte{0xd8, 0xff, 0x00})
+		assert.Equal(t, InspectedValueKindInvalid, inspected.Kind)
+		assert.NotEmpty(t, inspected.Error)
+	})
+}
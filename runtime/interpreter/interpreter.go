@@ -23,8 +23,13 @@ import (
 	goErrors "errors"
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
 	goRuntime "runtime"
+	"strings"
 	"time"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/onflow/atree"
 	"github.com/opentracing/opentracing-go"
@@ -116,6 +121,33 @@ type OnInvokedFunctionReturnFunc func(
 	line int,
 )
 
+// OnMeterComputationFunc is a function that is triggered when a computation is about to be performed,
+// whose cost depends on its operands, e.g. a string concatenation.
+// The intensity is the cost of the computation, e.g. the number of bytes copied.
+//
+type OnMeterComputationFunc func(
+	inter *Interpreter,
+	kind common.ComputationKind,
+	intensity uint,
+)
+
+// ComputationMeter meters the computation performed by the interpreter.
+//
+// All computation is reported to the meter, at the granularity of individual operations:
+// statements, loop iterations, function invocations, reads and writes of account storage,
+// allocations of values, and computations whose cost depends on their operands,
+// like string concatenations. See common.ComputationKind.
+//
+// Computation is reported deterministically: the same program, executed on the same state,
+// reports the same computations in the same order, independent of the host.
+//
+// If MeterComputation returns an error, e.g. because a computation limit was exceeded,
+// the execution is aborted with the error.
+//
+type ComputationMeter interface {
+	MeterComputation(kind common.ComputationKind, intensity uint) error
+}
+
 // OnRecordTraceFunc is a function thats records a trace.
 type OnRecordTraceFunc func(
 	inter *Interpreter,
@@ -150,6 +182,15 @@ type ContractValueHandlerFunc func(
 	invocationRange ast.Range,
 ) *CompositeValue
 
+// CompositeTypeHandlerFunc is a function that provides composite types
+// which are not declared by any program, e.g. types defined by the host environment.
+// It returns nil if it does not provide the type.
+//
+type CompositeTypeHandlerFunc func(
+	location common.Location,
+	typeID common.TypeID,
+) *sema.CompositeType
+
 // ImportLocationHandlerFunc is a function that handles imports of locations.
 //
 type ImportLocationHandlerFunc func(
@@ -165,6 +206,45 @@ type PublicAccountHandlerFunc func(
 	address AddressValue,
 ) Value
 
+// AuthAccountHandlerFunc is a function that handles retrieving an auth account at a given address.
+// The account returned must be of type `AuthAccount`.
+//
+type AuthAccountHandlerFunc func(
+	inter *Interpreter,
+	address AddressValue,
+) Value
+
+// OnAccountLinkedFunc is a function that is triggered when an account is linked.
+//
+type OnAccountLinkedFunc func(
+	inter *Interpreter,
+	getLocationRange func() LocationRange,
+	address AddressValue,
+	path PathValue,
+)
+
+// OnCapabilityControllerIssuedFunc is a function that is triggered
+// when a capability controller is issued.
+//
+type OnCapabilityControllerIssuedFunc func(
+	inter *Interpreter,
+	getLocationRange func() LocationRange,
+	address AddressValue,
+	capabilityID UInt64Value,
+	borrowType StaticType,
+	targetPath PathValue,
+)
+
+// OnCapabilityControllerRevokedFunc is a function that is triggered
+// when a capability controller is revoked.
+//
+type OnCapabilityControllerRevokedFunc func(
+	inter *Interpreter,
+	getLocationRange func() LocationRange,
+	address AddressValue,
+	capabilityID UInt64Value,
+)
+
 // UUIDHandlerFunc is a function that handles the generation of UUIDs.
 type UUIDHandlerFunc func() (uint64, error)
 
@@ -208,6 +288,26 @@ type SignatureVerificationHandlerFunc func(
 	key MemberAccessibleValue,
 ) BoolValue
 
+// SignatureSchemeSupportHandlerFunc is a function that determines
+// if the signature scheme with the given name is supported.
+type SignatureSchemeSupportHandlerFunc func(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	scheme *StringValue,
+) BoolValue
+
+// SignatureSchemeVerificationHandlerFunc is a function that validates a signature,
+// which was produced using the signature scheme with the given name.
+type SignatureSchemeVerificationHandlerFunc func(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	scheme *StringValue,
+	signature *ArrayValue,
+	signedData *ArrayValue,
+	domainSeparationTag *StringValue,
+	publicKey *ArrayValue,
+) BoolValue
+
 // HashHandlerFunc is a function that hashes.
 type HashHandlerFunc func(
 	inter *Interpreter,
@@ -217,6 +317,22 @@ type HashHandlerFunc func(
 	hashAlgorithm MemberAccessibleValue,
 ) *ArrayValue
 
+// Hasher incrementally hashes data, see HasherCreationHandlerFunc.
+//
+type Hasher interface {
+	// Update adds the given data to the hashed data
+	Update(data []byte)
+	// Finalize returns the hash of all added data
+	Finalize() []byte
+}
+
+// HasherCreationHandlerFunc is a function that creates a hasher for the given hash algorithm.
+type HasherCreationHandlerFunc func(
+	inter *Interpreter,
+	getLocationRange func() LocationRange,
+	hashAlgorithm MemberAccessibleValue,
+) Hasher
+
 // ExitHandlerFunc is a function that is called at the end of execution
 type ExitHandlerFunc func() error
 
@@ -290,35 +406,53 @@ type Interpreter struct {
 	Globals                        GlobalVariables
 	allInterpreters                map[common.LocationID]*Interpreter
 	typeCodes                      TypeCodes
+	stringLiterals                 map[*ast.StringExpression]*StringValue
 	Transactions                   []*HostFunctionValue
 	Storage                        Storage
 	onEventEmitted                 OnEventEmittedFunc
 	onStatement                    OnStatementFunc
 	onLoopIteration                OnLoopIterationFunc
 	onFunctionInvocation           OnFunctionInvocationFunc
+	onMeterComputation             OnMeterComputationFunc
+	computationMeter               ComputationMeter
+	memoryGauge                    common.MemoryGauge
+	transcript                     *Transcript
 	onInvokedFunctionReturn        OnInvokedFunctionReturnFunc
 	onRecordTrace                  OnRecordTraceFunc
 	onResourceOwnerChange          OnResourceOwnerChangeFunc
 	injectedCompositeFieldsHandler InjectedCompositeFieldsHandlerFunc
 	contractValueHandler           ContractValueHandlerFunc
 	importLocationHandler          ImportLocationHandlerFunc
+	compositeTypeHandler           CompositeTypeHandlerFunc
 	publicAccountHandler           PublicAccountHandlerFunc
+	authAccountHandler             AuthAccountHandlerFunc
+	onAccountLinked                OnAccountLinkedFunc
+	onCapabilityControllerIssued   OnCapabilityControllerIssuedFunc
+	onCapabilityControllerRevoked  OnCapabilityControllerRevokedFunc
 	uuidHandler                    UUIDHandlerFunc
 	PublicKeyValidationHandler     PublicKeyValidationHandlerFunc
 	SignatureVerificationHandler   SignatureVerificationHandlerFunc
 	BLSVerifyPoPHandler            VerifyBLSPoPHandlerFunc
 	AggregateBLSSignaturesHandler  AggregateBLSSignaturesHandlerFunc
 	AggregateBLSPublicKeysHandler  AggregateBLSPublicKeysHandlerFunc
+	SignatureSchemeSupportHandler  SignatureSchemeSupportHandlerFunc
+	SignatureSchemeVerifier        SignatureSchemeVerificationHandlerFunc
 	HashHandler                    HashHandlerFunc
+	HasherCreationHandler          HasherCreationHandlerFunc
 	ExitHandler                    ExitHandlerFunc
 	interpreted                    bool
 	statement                      ast.Statement
 	debugger                       *Debugger
 	atreeValueValidationEnabled    bool
 	atreeStorageValidationEnabled  bool
+	invariantChecksEnabled         bool
+	readOnlyEnforcementEnabled     bool
 	tracingEnabled                 bool
 	// TODO: ideally this would be a weak map, but Go has no weak references
 	referencedResourceKindedValues ReferencedResourceKindedValues
+	// attachmentBase is the reference to the base value
+	// of the attachment currently being constructed, if any
+	attachmentBase *EphemeralReferenceValue
 }
 
 type Option func(*Interpreter) error
@@ -353,6 +487,46 @@ func WithOnLoopIterationHandler(handler OnLoopIterationFunc) Option {
 	}
 }
 
+// WithOnMeterComputationHandler returns an interpreter option which sets
+// the given function as the computation metering handler.
+//
+func WithOnMeterComputationHandler(handler OnMeterComputationFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnMeterComputationHandler(handler)
+		return nil
+	}
+}
+
+// WithComputationMeter returns an interpreter option which sets
+// the given computation meter.
+//
+func WithComputationMeter(meter ComputationMeter) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetComputationMeter(meter)
+		return nil
+	}
+}
+
+// WithMemoryGauge returns an interpreter option which sets
+// the given memory gauge.
+//
+func WithMemoryGauge(gauge common.MemoryGauge) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetMemoryGauge(gauge)
+		return nil
+	}
+}
+
+// WithTranscript returns an interpreter option which sets
+// the given transcript, which records what the execution does.
+//
+func WithTranscript(transcript *Transcript) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetTranscript(transcript)
+		return nil
+	}
+}
+
 // WithOnFunctionInvocationHandler returns an interpreter option which sets
 // the given function as the function invocation handler.
 //
@@ -454,6 +628,16 @@ func WithImportLocationHandler(handler ImportLocationHandlerFunc) Option {
 	}
 }
 
+// WithCompositeTypeHandler returns an interpreter option which sets the given function
+// as the function that is used to provide composite types which are not declared by any program.
+//
+func WithCompositeTypeHandler(handler CompositeTypeHandlerFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetCompositeTypeHandler(handler)
+		return nil
+	}
+}
+
 // WithPublicAccountHandler returns an interpreter option which sets the given function
 // as the function that is used to handle public accounts.
 //
@@ -464,6 +648,46 @@ func WithPublicAccountHandler(handler PublicAccountHandlerFunc) Option {
 	}
 }
 
+// WithAuthAccountHandler returns an interpreter option which sets the given function
+// as the function that is used to handle auth accounts.
+//
+func WithAuthAccountHandler(handler AuthAccountHandlerFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetAuthAccountHandler(handler)
+		return nil
+	}
+}
+
+// WithOnAccountLinkedHandler returns an interpreter option which sets
+// the given function as the account linked handler.
+//
+func WithOnAccountLinkedHandler(handler OnAccountLinkedFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnAccountLinkedHandler(handler)
+		return nil
+	}
+}
+
+// WithOnCapabilityControllerIssuedHandler returns an interpreter option which sets
+// the given function as the capability controller issued handler.
+//
+func WithOnCapabilityControllerIssuedHandler(handler OnCapabilityControllerIssuedFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnCapabilityControllerIssuedHandler(handler)
+		return nil
+	}
+}
+
+// WithOnCapabilityControllerRevokedHandler returns an interpreter option which sets
+// the given function as the capability controller revoked handler.
+//
+func WithOnCapabilityControllerRevokedHandler(handler OnCapabilityControllerRevokedFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnCapabilityControllerRevokedHandler(handler)
+		return nil
+	}
+}
+
 // WithUUIDHandler returns an interpreter option which sets the given function
 // as the function that is used to generate UUIDs.
 //
@@ -512,6 +736,19 @@ func WithSignatureVerificationHandler(handler SignatureVerificationHandlerFunc)
 	}
 }
 
+// WithSignatureSchemeHandlers returns an interpreter option which sets the given
+// functions as the functions used to handle signature schemes which are identified by name.
+//
+func WithSignatureSchemeHandlers(
+	support SignatureSchemeSupportHandlerFunc,
+	verification SignatureSchemeVerificationHandlerFunc,
+) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetSignatureSchemeHandlers(support, verification)
+		return nil
+	}
+}
+
 // WithHashHandler returns an interpreter option which sets the given
 // function as the function that is used to hash.
 //
@@ -522,6 +759,16 @@ func WithHashHandler(handler HashHandlerFunc) Option {
 	}
 }
 
+// WithHasherCreationHandler returns an interpreter option which sets the given
+// function as the function that is used to create incremental hashers.
+//
+func WithHasherCreationHandler(handler HasherCreationHandlerFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetHasherCreationHandler(handler)
+		return nil
+	}
+}
+
 // WithExitHandler returns an interpreter option which sets the given
 // function as the function that is used when execution is complete.
 //
@@ -562,6 +809,26 @@ func WithAtreeStorageValidationEnabled(enabled bool) Option {
 	}
 }
 
+// WithInvariantChecksEnabled returns an interpreter option which sets
+// the invariant checks option.
+//
+func WithInvariantChecksEnabled(enabled bool) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetInvariantChecksEnabled(enabled)
+		return nil
+	}
+}
+
+// WithReadOnlyEnforcementEnabled returns an interpreter option which sets
+// the read-only enforcement option.
+//
+func WithReadOnlyEnforcementEnabled(enabled bool) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetReadOnlyEnforcementEnabled(enabled)
+		return nil
+	}
+}
+
 // WithTracingEnabled returns an interpreter option which sets
 // the tracing option.
 //
@@ -666,6 +933,152 @@ func (interpreter *Interpreter) SetOnLoopIterationHandler(function OnLoopIterati
 	interpreter.onLoopIteration = function
 }
 
+// SetOnMeterComputationHandler sets the function that is triggered when a computation is about to be performed.
+//
+func (interpreter *Interpreter) SetOnMeterComputationHandler(function OnMeterComputationFunc) {
+	interpreter.onMeterComputation = function
+}
+
+// SetComputationMeter sets the computation meter.
+//
+func (interpreter *Interpreter) SetComputationMeter(meter ComputationMeter) {
+	interpreter.computationMeter = meter
+}
+
+// SetMemoryGauge sets the memory gauge.
+//
+func (interpreter *Interpreter) SetMemoryGauge(gauge common.MemoryGauge) {
+	interpreter.memoryGauge = gauge
+}
+
+// SetTranscript sets the transcript.
+//
+func (interpreter *Interpreter) SetTranscript(transcript *Transcript) {
+	interpreter.transcript = transcript
+}
+
+// meterComputation reports the computation of the given kind and intensity
+// to the computation metering handler and the computation meter, if any.
+//
+func (interpreter *Interpreter) meterComputation(kind common.ComputationKind, intensity uint) {
+	if interpreter.onMeterComputation != nil {
+		interpreter.onMeterComputation(interpreter, kind, intensity)
+	}
+	interpreter.reportComputation(kind, intensity)
+}
+
+// reportComputation reports the computation of the given kind and intensity
+// to the computation meter, if any.
+//
+// Unlike meterComputation, the computation is not reported to the computation metering handler,
+// as e.g. statements are reported to a dedicated handler.
+//
+func (interpreter *Interpreter) reportComputation(kind common.ComputationKind, intensity uint) {
+	if interpreter.computationMeter == nil {
+		return
+	}
+	err := interpreter.computationMeter.MeterComputation(kind, intensity)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// meterMemory reports the memory usage of the given kind and amount
+// to the memory gauge, if any.
+//
+func (interpreter *Interpreter) meterMemory(kind common.MemoryKind, amount uint64) {
+	if interpreter.memoryGauge == nil {
+		return
+	}
+	err := interpreter.memoryGauge.MeterMemory(
+		common.MemoryUsage{
+			Kind:   kind,
+			Amount: amount,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MeterMemory reports the memory usage of the given kind and amount
+// to the memory gauge, if any.
+//
+// It allows host functions to meter memory which is not allocated for values.
+//
+func (interpreter *Interpreter) MeterMemory(kind common.MemoryKind, amount uint64) {
+	interpreter.meterMemory(kind, amount)
+}
+
+// containerBaseMemoryUsage is the estimated number of bytes allocated
+// for an array, dictionary, or composite value, independent of its elements
+//
+const containerBaseMemoryUsage = 64
+
+// containerElementMemoryUsage is the estimated number of bytes allocated
+// for each element of an array, each entry of a dictionary, or each field of a composite value
+//
+const containerElementMemoryUsage = 16
+
+// meterContainerMemory reports the memory usage of an array, dictionary, or composite value
+// with the given number of elements, entries, or fields.
+//
+func (interpreter *Interpreter) meterContainerMemory(kind common.MemoryKind, count int) {
+	interpreter.meterMemory(
+		kind,
+		containerBaseMemoryUsage+uint64(count)*containerElementMemoryUsage,
+	)
+}
+
+// meterContainerGrowthMemory reports the memory usage of the given number of elements or entries
+// which are added to an existing array or dictionary.
+//
+func (interpreter *Interpreter) meterContainerGrowthMemory(kind common.MemoryKind, count int) {
+	interpreter.meterMemory(
+		kind,
+		uint64(count)*containerElementMemoryUsage,
+	)
+}
+
+// meterBigIntMemory reports the memory usage of the given value,
+// if it is an arbitrary-precision integer, and returns the value.
+//
+func (interpreter *Interpreter) meterBigIntMemory(value Value) Value {
+	if interpreter.memoryGauge == nil {
+		return value
+	}
+
+	var bigInt *big.Int
+
+	switch value := value.(type) {
+	case IntValue:
+		bigInt = value.BigInt
+	case UIntValue:
+		bigInt = value.BigInt
+	case Int128Value:
+		bigInt = value.BigInt
+	case Int256Value:
+		bigInt = value.BigInt
+	case UInt128Value:
+		bigInt = value.BigInt
+	case UInt256Value:
+		bigInt = value.BigInt
+	default:
+		return value
+	}
+
+	interpreter.meterMemory(
+		common.MemoryKindBigInt,
+		uint64(len(bigInt.Bits()))*bigIntWordSize,
+	)
+
+	return value
+}
+
+// bigIntWordSize is the number of bytes of a word of the magnitude of a big.Int
+//
+const bigIntWordSize = bits.UintSize / 8
+
 // SetOnFunctionInvocationHandler sets the function that is triggered when a function invocation is about to be executed.
 //
 func (interpreter *Interpreter) SetOnFunctionInvocationHandler(function OnFunctionInvocationFunc) {
@@ -714,12 +1127,45 @@ func (interpreter *Interpreter) SetImportLocationHandler(function ImportLocation
 	interpreter.importLocationHandler = function
 }
 
+// SetCompositeTypeHandler sets the function that is used to provide composite types
+// which are not declared by any program.
+//
+func (interpreter *Interpreter) SetCompositeTypeHandler(function CompositeTypeHandlerFunc) {
+	interpreter.compositeTypeHandler = function
+}
+
 // SetPublicAccountHandler sets the function that is used to handle accounts.
 //
 func (interpreter *Interpreter) SetPublicAccountHandler(function PublicAccountHandlerFunc) {
 	interpreter.publicAccountHandler = function
 }
 
+// SetAuthAccountHandler sets the function that is used to handle auth accounts.
+//
+func (interpreter *Interpreter) SetAuthAccountHandler(function AuthAccountHandlerFunc) {
+	interpreter.authAccountHandler = function
+}
+
+// SetOnAccountLinkedHandler sets the function that is triggered when an account is linked.
+//
+func (interpreter *Interpreter) SetOnAccountLinkedHandler(function OnAccountLinkedFunc) {
+	interpreter.onAccountLinked = function
+}
+
+// SetOnCapabilityControllerIssuedHandler sets the function that is triggered
+// when a capability controller is issued.
+//
+func (interpreter *Interpreter) SetOnCapabilityControllerIssuedHandler(function OnCapabilityControllerIssuedFunc) {
+	interpreter.onCapabilityControllerIssued = function
+}
+
+// SetOnCapabilityControllerRevokedHandler sets the function that is triggered
+// when a capability controller is revoked.
+//
+func (interpreter *Interpreter) SetOnCapabilityControllerRevokedHandler(function OnCapabilityControllerRevokedFunc) {
+	interpreter.onCapabilityControllerRevoked = function
+}
+
 // SetUUIDHandler sets the function that is used to handle the generation of UUIDs.
 //
 func (interpreter *Interpreter) SetUUIDHandler(function UUIDHandlerFunc) {
@@ -750,12 +1196,29 @@ func (interpreter *Interpreter) SetSignatureVerificationHandler(function Signatu
 	interpreter.SignatureVerificationHandler = function
 }
 
+// SetSignatureSchemeHandlers sets the functions that are used to handle
+// signature schemes which are identified by name.
+//
+func (interpreter *Interpreter) SetSignatureSchemeHandlers(
+	support SignatureSchemeSupportHandlerFunc,
+	verification SignatureSchemeVerificationHandlerFunc,
+) {
+	interpreter.SignatureSchemeSupportHandler = support
+	interpreter.SignatureSchemeVerifier = verification
+}
+
 // SetHashHandler sets the function that is used to hash.
 //
 func (interpreter *Interpreter) SetHashHandler(function HashHandlerFunc) {
 	interpreter.HashHandler = function
 }
 
+// SetHasherCreationHandler sets the function that is used to create incremental hashers.
+//
+func (interpreter *Interpreter) SetHasherCreationHandler(function HasherCreationHandlerFunc) {
+	interpreter.HasherCreationHandler = function
+}
+
 // SetExitHandler sets the function that is used to handle end of execution.
 //
 func (interpreter *Interpreter) SetExitHandler(function ExitHandlerFunc) {
@@ -786,6 +1249,57 @@ func (interpreter *Interpreter) SetAtreeStorageValidationEnabled(enabled bool) {
 	interpreter.atreeStorageValidationEnabled = enabled
 }
 
+// SetInvariantChecksEnabled sets the invariant checks option.
+// If enabled, the interpreter checks the invariants of values after transfers
+// and writes to storage, see CheckValueInvariants.
+//
+func (interpreter *Interpreter) SetInvariantChecksEnabled(enabled bool) {
+	interpreter.invariantChecksEnabled = enabled
+}
+
+// SetReadOnlyEnforcementEnabled sets the read-only enforcement option.
+// If enabled, operations which write, e.g. to account storage, fail with a ReadOnlyViolationError,
+// see CheckWrite.
+//
+func (interpreter *Interpreter) SetReadOnlyEnforcementEnabled(enabled bool) {
+	interpreter.readOnlyEnforcementEnabled = enabled
+}
+
+// CheckWrite panics with a ReadOnlyViolationError if read-only enforcement is enabled.
+// It must be called before the given operation performs any write.
+//
+func (interpreter *Interpreter) CheckWrite(operation string, getLocationRange func() LocationRange) {
+	if !interpreter.readOnlyEnforcementEnabled {
+		return
+	}
+
+	var locationRange LocationRange
+	if getLocationRange != nil {
+		locationRange = getLocationRange()
+	}
+
+	panic(ReadOnlyViolationError{
+		Operation:     operation,
+		LocationRange: locationRange,
+	})
+}
+
+// checkStoredValueWrite panics with a ReadOnlyViolationError if read-only enforcement is enabled,
+// and the container with the given address is stored in an account,
+// i.e. if the given operation would modify the account's storage.
+//
+func (interpreter *Interpreter) checkStoredValueWrite(
+	address atree.Address,
+	operation string,
+	getLocationRange func() LocationRange,
+) {
+	if address == (atree.Address{}) {
+		return
+	}
+
+	interpreter.CheckWrite(operation, getLocationRange)
+}
+
 // SetTracingEnabled sets the tracing option.
 //
 func (interpreter *Interpreter) SetTracingEnabled(enabled bool) {
@@ -1629,10 +2143,25 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 					address,
 				)
 
+				// The declared fields are only set when the initializer is invoked,
+				// so their memory usage is reported here
+
+				interpreter.meterMemory(
+					common.MemoryKindComposite,
+					uint64(len(compositeType.Fields))*containerElementMemoryUsage,
+				)
+
 				value.InjectedFields = injectedFields
 				value.Functions = functions
 				value.Destructor = destructorFunction
 
+				if declaration.CompositeKind == common.CompositeKindAttachment {
+					// NOTE: attachments are only constructed in attach expressions,
+					// which provide the base value the attachment is attached to
+
+					value.base = invocation.Interpreter.attachmentBase
+				}
+
 				invocation.Self = value
 
 				if declaration.CompositeKind == common.CompositeKindContract {
@@ -1770,26 +2299,85 @@ func EnumConstructorFunction(
 
 	// Prepare the constructor function which performs a lookup in the lookup table
 
-	constructor := NewHostFunctionValue(
-		func(invocation Invocation) Value {
+	lookup := func(invocation Invocation) Value {
 
-			rawValueArgumentBigEndianBytes := invocation.Arguments[0].(IntegerValue).ToBigEndianBytes()
+		rawValueArgumentBigEndianBytes := invocation.Arguments[0].(IntegerValue).ToBigEndianBytes()
 
-			caseValue, ok := lookupTable[string(rawValueArgumentBigEndianBytes)]
-			if !ok {
-				return NilValue{}
-			}
+		caseValue, ok := lookupTable[string(rawValueArgumentBigEndianBytes)]
+		if !ok {
+			return NilValue{}
+		}
 
-			return NewSomeValueNonCopying(caseValue)
-		},
+		return NewSomeValueNonCopying(caseValue)
+	}
+
+	constructor := NewHostFunctionValue(
+		lookup,
 		sema.EnumConstructorType(enumType),
 	)
 
+	// Declare the synthetic members, unless an enum case has the same name
+
+	if _, ok := nestedVariables[sema.EnumLookupFunctionName]; !ok {
+		nestedVariables[sema.EnumLookupFunctionName] = NewVariableWithValue(
+			NewHostFunctionValue(
+				lookup,
+				sema.EnumLookupFunctionType(enumType),
+			),
+		)
+	}
+
+	if _, ok := nestedVariables[sema.EnumAllCasesFieldName]; !ok {
+		// NOTE: the array is created on each access,
+		// so mutating it does not affect later accesses
+		nestedVariables[sema.EnumAllCasesFieldName] = NewComputedVariable(
+			func() Value {
+				return enumAllCasesValue(inter, getLocationRange, enumType, caseValues)
+			},
+		)
+	}
+
 	constructor.NestedVariables = nestedVariables
 
 	return constructor
 }
 
+func enumAllCasesValue(
+	inter *Interpreter,
+	getLocationRange func() LocationRange,
+	enumType *sema.CompositeType,
+	caseValues []*CompositeValue,
+) *ArrayValue {
+
+	arrayType := VariableSizedStaticType{
+		Type: ConvertSemaToStaticType(enumType),
+	}
+
+	index := 0
+
+	return NewArrayValueWithIterator(
+		inter,
+		arrayType,
+		common.Address{},
+		func() Value {
+			if index >= len(caseValues) {
+				return nil
+			}
+
+			caseValue := caseValues[index]
+			index++
+
+			return caseValue.Transfer(
+				inter,
+				getLocationRange,
+				atree.Address{},
+				false,
+				nil,
+			)
+		},
+	)
+}
+
 func (interpreter *Interpreter) compositeInitializerFunction(
 	compositeDeclaration *ast.CompositeDeclaration,
 	lexicalScope *VariableActivation,
@@ -1976,6 +2564,16 @@ func (interpreter *Interpreter) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclarat
 	panic(errors.NewUnreachableError())
 }
 
+func (interpreter *Interpreter) VisitEntitlementDeclaration(_ *ast.EntitlementDeclaration) ast.Repr {
+	// entitlements are only types, they aren't interpreted
+	panic(errors.NewUnreachableError())
+}
+
+func (interpreter *Interpreter) VisitEntitlementMappingDeclaration(_ *ast.EntitlementMappingDeclaration) ast.Repr {
+	// entitlement mappings are only types, they aren't interpreted
+	panic(errors.NewUnreachableError())
+}
+
 func (interpreter *Interpreter) checkValueTransferTargetType(value Value, targetType sema.Type) bool {
 
 	if targetType == nil {
@@ -2039,6 +2637,8 @@ func (interpreter *Interpreter) transferAndConvert(
 		})
 	}
 
+	interpreter.maybeCheckValueInvariants(result, atree.Address{}, getLocationRange)
+
 	return result
 }
 
@@ -2380,7 +2980,7 @@ func (interpreter *Interpreter) functionConditionsWrapper(
 				}
 
 				if invocation.Self != nil {
-					interpreter.declareVariable(sema.SelfIdentifier, invocation.Self)
+					interpreter.declareSelfVariable(invocation.Self)
 				}
 
 				// NOTE: The `inner` function might be nil.
@@ -2511,20 +3111,36 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithOnStatementHandler(interpreter.onStatement),
 		WithOnLoopIterationHandler(interpreter.onLoopIteration),
 		WithOnFunctionInvocationHandler(interpreter.onFunctionInvocation),
+		WithOnMeterComputationHandler(interpreter.onMeterComputation),
+		WithComputationMeter(interpreter.computationMeter),
+		WithMemoryGauge(interpreter.memoryGauge),
+		WithTranscript(interpreter.transcript),
 		WithOnInvokedFunctionReturnHandler(interpreter.onInvokedFunctionReturn),
 		WithInjectedCompositeFieldsHandler(interpreter.injectedCompositeFieldsHandler),
 		WithContractValueHandler(interpreter.contractValueHandler),
 		WithImportLocationHandler(interpreter.importLocationHandler),
+		WithCompositeTypeHandler(interpreter.compositeTypeHandler),
 		WithUUIDHandler(interpreter.uuidHandler),
 		WithAllInterpreters(interpreter.allInterpreters),
 		WithAtreeValueValidationEnabled(interpreter.atreeValueValidationEnabled),
 		WithAtreeStorageValidationEnabled(interpreter.atreeStorageValidationEnabled),
+		WithInvariantChecksEnabled(interpreter.invariantChecksEnabled),
+		WithReadOnlyEnforcementEnabled(interpreter.readOnlyEnforcementEnabled),
 		withTypeCodes(interpreter.typeCodes),
 		withReferencedResourceKindedValues(interpreter.referencedResourceKindedValues),
 		WithPublicAccountHandler(interpreter.publicAccountHandler),
+		WithAuthAccountHandler(interpreter.authAccountHandler),
+		WithOnAccountLinkedHandler(interpreter.onAccountLinked),
+		WithOnCapabilityControllerIssuedHandler(interpreter.onCapabilityControllerIssued),
+		WithOnCapabilityControllerRevokedHandler(interpreter.onCapabilityControllerRevoked),
 		WithPublicKeyValidationHandler(interpreter.PublicKeyValidationHandler),
 		WithSignatureVerificationHandler(interpreter.SignatureVerificationHandler),
+		WithSignatureSchemeHandlers(
+			interpreter.SignatureSchemeSupportHandler,
+			interpreter.SignatureSchemeVerifier,
+		),
 		WithHashHandler(interpreter.HashHandler),
+		WithHasherCreationHandler(interpreter.HasherCreationHandler),
 		WithBLSCryptoFunctions(
 			interpreter.BLSVerifyPoPHandler,
 			interpreter.AggregateBLSSignaturesHandler,
@@ -2547,11 +3163,36 @@ func (interpreter *Interpreter) NewSubInterpreter(
 	)
 }
 
+// storageOwner returns the address which owns the values
+// stored in the given domain of the given account.
+//
+// Values stored in the transaction domain are not owned by any account,
+// they are shared between all accounts and only exist until the end of the transaction.
+//
+func storageOwner(address common.Address, domain string) common.Address {
+	if domain == common.PathDomainTransaction.Identifier() {
+		return common.Address{}
+	}
+	return address
+}
+
+// containerStorageID returns the storage ID of the given value,
+// if it is a container, e.g. a resource, and false otherwise.
+//
+func containerStorageID(value Value) (atree.StorageID, bool) {
+	container, ok := value.(ReferenceTrackedResourceKindedValue)
+	if !ok {
+		return atree.StorageID{}, false
+	}
+	return container.StorageID(), true
+}
+
 func (interpreter *Interpreter) storedValueExists(
 	storageAddress common.Address,
 	domain string,
 	identifier string,
 ) bool {
+	storageAddress = storageOwner(storageAddress, domain)
 	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
 	return accountStorage.ValueExists(identifier)
 }
@@ -2561,6 +3202,19 @@ func (interpreter *Interpreter) ReadStored(
 	domain string,
 	identifier string,
 ) Value {
+	interpreter.reportComputation(common.ComputationKindStorageRead, 1)
+
+	if interpreter.transcript != nil {
+		interpreter.transcript.recordStorageAccess(
+			interpreter,
+			TranscriptEntryKindStorageRead,
+			storageAddress,
+			domain,
+			identifier,
+		)
+	}
+
+	storageAddress = storageOwner(storageAddress, domain)
 	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
 	return accountStorage.ReadValue(identifier)
 }
@@ -2571,6 +3225,29 @@ func (interpreter *Interpreter) writeStored(
 	identifier string,
 	value Value,
 ) {
+	interpreter.reportComputation(common.ComputationKindStorageWrite, 1)
+
+	// Writes to the transaction domain are not persisted
+
+	if domain != common.PathDomainTransaction.Identifier() {
+		interpreter.CheckWrite("write to storage", nil)
+	}
+
+	if interpreter.transcript != nil {
+		kind := TranscriptEntryKindStorageWrite
+		if value == nil {
+			kind = TranscriptEntryKindStorageRemove
+		}
+		interpreter.transcript.recordStorageAccess(
+			interpreter,
+			kind,
+			storageAddress,
+			domain,
+			identifier,
+		)
+	}
+
+	storageAddress = storageOwner(storageAddress, domain)
 	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
 	accountStorage.WriteValue(interpreter, identifier, value)
 }
@@ -2580,6 +3257,19 @@ type valueConverterDeclaration struct {
 	convert func(Value) Value
 	min     Value
 	max     Value
+	// number is only set for number types
+	number *numberConverterDeclaration
+}
+
+// numberConverterDeclaration describes the representation of the values of a number type
+//
+type numberConverterDeclaration struct {
+	// bitSize is the size of fixed-size number types, in bits, and 0 otherwise
+	bitSize uint
+	signed  bool
+	// fromRaw creates a value of a fixed-point type from its unscaled integer representation.
+	// Values of integer types are created by converting the integer
+	fromRaw func(*big.Int) Value
 }
 
 // It would be nice if return types in Go's function types would be covariant
@@ -2590,6 +3280,9 @@ var converterDeclarations = []valueConverterDeclaration{
 		convert: func(value Value) Value {
 			return ConvertInt(value)
 		},
+		number: &numberConverterDeclaration{
+			signed: true,
+		},
 	},
 	{
 		name: sema.UIntTypeName,
@@ -2597,6 +3290,9 @@ var converterDeclarations = []valueConverterDeclaration{
 			return ConvertUInt(value)
 		},
 		min: NewUIntValueFromBigInt(sema.UIntTypeMin),
+		number: &numberConverterDeclaration{
+			signed: false,
+		},
 	},
 	{
 		name: sema.Int8TypeName,
@@ -2605,6 +3301,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Int8Value(math.MinInt8),
 		max: Int8Value(math.MaxInt8),
+		number: &numberConverterDeclaration{
+			bitSize: 8,
+			signed:  true,
+		},
 	},
 	{
 		name: sema.Int16TypeName,
@@ -2613,6 +3313,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Int16Value(math.MinInt16),
 		max: Int16Value(math.MaxInt16),
+		number: &numberConverterDeclaration{
+			bitSize: 16,
+			signed:  true,
+		},
 	},
 	{
 		name: sema.Int32TypeName,
@@ -2621,6 +3325,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Int32Value(math.MinInt32),
 		max: Int32Value(math.MaxInt32),
+		number: &numberConverterDeclaration{
+			bitSize: 32,
+			signed:  true,
+		},
 	},
 	{
 		name: sema.Int64TypeName,
@@ -2629,6 +3337,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Int64Value(math.MinInt64),
 		max: Int64Value(math.MaxInt64),
+		number: &numberConverterDeclaration{
+			bitSize: 64,
+			signed:  true,
+		},
 	},
 	{
 		name: sema.Int128TypeName,
@@ -2637,6 +3349,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: NewInt128ValueFromBigInt(sema.Int128TypeMinIntBig),
 		max: NewInt128ValueFromBigInt(sema.Int128TypeMaxIntBig),
+		number: &numberConverterDeclaration{
+			bitSize: 128,
+			signed:  true,
+		},
 	},
 	{
 		name: sema.Int256TypeName,
@@ -2645,6 +3361,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: NewInt256ValueFromBigInt(sema.Int256TypeMinIntBig),
 		max: NewInt256ValueFromBigInt(sema.Int256TypeMaxIntBig),
+		number: &numberConverterDeclaration{
+			bitSize: 256,
+			signed:  true,
+		},
 	},
 	{
 		name: sema.UInt8TypeName,
@@ -2653,6 +3373,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: UInt8Value(0),
 		max: UInt8Value(math.MaxUint8),
+		number: &numberConverterDeclaration{
+			bitSize: 8,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.UInt16TypeName,
@@ -2661,6 +3385,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: UInt16Value(0),
 		max: UInt16Value(math.MaxUint16),
+		number: &numberConverterDeclaration{
+			bitSize: 16,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.UInt32TypeName,
@@ -2669,6 +3397,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: UInt32Value(0),
 		max: UInt32Value(math.MaxUint32),
+		number: &numberConverterDeclaration{
+			bitSize: 32,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.UInt64TypeName,
@@ -2677,6 +3409,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: UInt64Value(0),
 		max: UInt64Value(math.MaxUint64),
+		number: &numberConverterDeclaration{
+			bitSize: 64,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.UInt128TypeName,
@@ -2685,6 +3421,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: NewUInt128ValueFromUint64(0),
 		max: NewUInt128ValueFromBigInt(sema.UInt128TypeMaxIntBig),
+		number: &numberConverterDeclaration{
+			bitSize: 128,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.UInt256TypeName,
@@ -2693,6 +3433,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: NewUInt256ValueFromUint64(0),
 		max: NewUInt256ValueFromBigInt(sema.UInt256TypeMaxIntBig),
+		number: &numberConverterDeclaration{
+			bitSize: 256,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.Word8TypeName,
@@ -2701,6 +3445,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Word8Value(0),
 		max: Word8Value(math.MaxUint8),
+		number: &numberConverterDeclaration{
+			bitSize: 8,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.Word16TypeName,
@@ -2709,6 +3457,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Word16Value(0),
 		max: Word16Value(math.MaxUint16),
+		number: &numberConverterDeclaration{
+			bitSize: 16,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.Word32TypeName,
@@ -2717,6 +3469,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Word32Value(0),
 		max: Word32Value(math.MaxUint32),
+		number: &numberConverterDeclaration{
+			bitSize: 32,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.Word64TypeName,
@@ -2725,6 +3481,10 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Word64Value(0),
 		max: Word64Value(math.MaxUint64),
+		number: &numberConverterDeclaration{
+			bitSize: 64,
+			signed:  false,
+		},
 	},
 	{
 		name: sema.Fix64TypeName,
@@ -2733,6 +3493,13 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: Fix64Value(math.MinInt64),
 		max: Fix64Value(math.MaxInt64),
+		number: &numberConverterDeclaration{
+			bitSize: 64,
+			signed:  true,
+			fromRaw: func(value *big.Int) Value {
+				return Fix64Value(value.Int64())
+			},
+		},
 	},
 	{
 		name: sema.UFix64TypeName,
@@ -2741,6 +3508,13 @@ var converterDeclarations = []valueConverterDeclaration{
 		},
 		min: UFix64Value(0),
 		max: UFix64Value(math.MaxUint64),
+		number: &numberConverterDeclaration{
+			bitSize: 64,
+			signed:  false,
+			fromRaw: func(value *big.Int) Value {
+				return UFix64Value(value.Uint64())
+			},
+		},
 	},
 	{
 		name: "Address",
@@ -2991,6 +3765,8 @@ func defineBaseFunctions(activation *VariableActivation) {
 	defineTypeFunction(activation)
 	defineRuntimeTypeConstructorFunctions(activation)
 	defineStringFunction(activation)
+	defineBytesFunction(activation)
+	defineInclusiveRangeFunction(activation)
 }
 
 type converterFunction struct {
@@ -3004,12 +3780,45 @@ var converterFunctionValues = func() []converterFunction {
 
 	converterFuncValues := make([]converterFunction, len(converterDeclarations))
 
+	numberTypes := make(map[string]sema.Type, len(sema.AllNumberTypes))
+	for _, numberType := range sema.AllNumberTypes {
+		numberTypes[numberType.String()] = numberType
+	}
+
 	for index, declaration := range converterDeclarations {
 		// NOTE: declare in loop, as captured in closure below
 		convert := declaration.convert
+		name := declaration.name
+		minValue := declaration.min
+		maxValue := declaration.max
 		converterFunctionValue := NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return convert(invocation.Arguments[0])
+				value := invocation.Arguments[0]
+
+				// Elaborate range errors with the converted value and the target range
+
+				defer func() {
+					r := recover()
+					if r == nil {
+						return
+					}
+
+					switch err := r.(type) {
+					case OverflowError, UnderflowError:
+						panic(NumberConversionError{
+							Err:            err.(error),
+							Value:          value,
+							TargetTypeName: name,
+							Min:            minValue,
+							Max:            maxValue,
+							LocationRange:  invocation.GetLocationRange(),
+						})
+					}
+
+					panic(r)
+				}()
+
+				return convert(value)
 			},
 
 			// Converter functions are not passed around as values.
@@ -3032,6 +3841,22 @@ var converterFunctionValues = func() []converterFunction {
 			addMember(sema.NumberTypeMaxFieldName, declaration.max)
 		}
 
+		if declaration.number != nil {
+			numberType := numberTypes[declaration.name]
+
+			if truncate, ok := truncatingConverters[declaration.name]; ok {
+				addMember(
+					sema.NumberTypeTruncatingFunctionName,
+					newNumberTruncatingFunction(numberType, truncate),
+				)
+			}
+
+			addMember(
+				sema.NumberTypeFromBigEndianBytesFunctionName,
+				newNumberFromBigEndianBytesFunction(numberType, convert, declaration.number),
+			)
+		}
+
 		converterFuncValues[index] = converterFunction{
 			name:      declaration.name,
 			converter: converterFunctionValue,
@@ -3041,6 +3866,100 @@ var converterFunctionValues = func() []converterFunction {
 	return converterFuncValues
 }()
 
+// truncatingConverters are the functions which convert an integer of any size
+// to a fixed-size integer type, wrapping it around if it is outside the bounds of the type.
+// The converters are keyed by the name of the target type.
+//
+var truncatingConverters = func() map[string]func(Value) Value {
+
+	converters := map[string]func(Value) Value{}
+
+	for _, declaration := range converterDeclarations {
+		// NOTE: declare in loop, as captured in closure below
+		convert := declaration.convert
+		number := declaration.number
+
+		// Only fixed-size integer types can be truncated to
+
+		if number == nil || number.bitSize == 0 || number.fromRaw != nil {
+			continue
+		}
+
+		converters[declaration.name] = func(value Value) Value {
+			var bigInt *big.Int
+
+			switch value := value.(type) {
+			case BigNumberValue:
+				bigInt = value.ToBigInt()
+
+			case NumberValue:
+				bigInt = big.NewInt(int64(value.ToInt()))
+
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
+			truncated := TruncateBigInt(bigInt, number.bitSize, number.signed)
+
+			return convert(NewIntValueFromBigInt(truncated))
+		}
+	}
+
+	return converters
+}()
+
+// newNumberTruncatingFunction returns the `truncating` function of a fixed-size integer type
+//
+func newNumberTruncatingFunction(numberType sema.Type, truncate func(Value) Value) *HostFunctionValue {
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+			return truncate(invocation.Arguments[0])
+		},
+		sema.NumberTypeTruncatingFunctionType(numberType),
+	)
+}
+
+// newNumberFromBigEndianBytesFunction returns the `fromBigEndianBytes` function of a number type,
+// which is the inverse of the `toBigEndianBytes` function of its values.
+//
+func newNumberFromBigEndianBytesFunction(
+	numberType sema.Type,
+	convert func(Value) Value,
+	declaration *numberConverterDeclaration,
+) *HostFunctionValue {
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+			bytes, err := ByteArrayValueToByteSlice(invocation.Arguments[0])
+			if err != nil {
+				panic(err)
+			}
+
+			if len(bytes) == 0 ||
+				(declaration.bitSize > 0 && uint(len(bytes))*8 > declaration.bitSize) {
+
+				return NilValue{}
+			}
+
+			var bigInt *big.Int
+			if declaration.signed {
+				bigInt = BigEndianBytesToSignedBigInt(bytes)
+			} else {
+				bigInt = BigEndianBytesToUnsignedBigInt(bytes)
+			}
+
+			var result Value
+			if declaration.fromRaw != nil {
+				result = declaration.fromRaw(bigInt)
+			} else {
+				result = convert(NewIntValueFromBigInt(bigInt))
+			}
+
+			return NewSomeValueNonCopying(result)
+		},
+		sema.NumberTypeFromBigEndianBytesFunctionType(numberType),
+	)
+}
+
 func defineConverterFunctions(activation *VariableActivation) {
 	for _, converterFunc := range converterFunctionValues {
 		defineBaseValue(activation, converterFunc.name, converterFunc.converter)
@@ -3200,19 +4119,146 @@ var stringFunction = func() Value {
 				}
 
 				bytes, _ := ByteArrayValueToByteSlice(argument)
-				return NewStringValue(hex.EncodeToString(bytes))
+				return newMeteredStringValue(invocation.Interpreter, hex.EncodeToString(bytes))
 			},
 			sema.StringTypeEncodeHexFunctionType,
 		),
 	)
 
+	addMember(
+		sema.StringTypeFromUTF8FunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				bytes, err := ByteArrayValueToByteSlice(invocation.Arguments[0])
+				if err != nil {
+					panic(err)
+				}
+
+				if !utf8.Valid(bytes) {
+					return NilValue{}
+				}
+
+				return NewSomeValueNonCopying(
+					newMeteredStringValue(invocation.Interpreter, string(bytes)),
+				)
+			},
+			sema.StringTypeFromUTF8FunctionType,
+		),
+	)
+
+	addMember(
+		sema.StringTypeFromUTF16FunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				argument, ok := invocation.Arguments[0].(*ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				codeUnits := make([]uint16, 0, argument.Count())
+				argument.Iterate(func(element Value) (resume bool) {
+					codeUnit, ok := element.(UInt16Value)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+					codeUnits = append(codeUnits, uint16(codeUnit))
+					return true
+				})
+
+				str, ok := decodeUTF16(codeUnits)
+				if !ok {
+					return NilValue{}
+				}
+
+				return NewSomeValueNonCopying(
+					newMeteredStringValue(invocation.Interpreter, str),
+				)
+			},
+			sema.StringTypeFromUTF16FunctionType,
+		),
+	)
+
+	addMember(
+		sema.StringTypeJoinFunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				argument, argumentOk := invocation.Arguments[0].(*ArrayValue)
+				separator, separatorOk := invocation.Arguments[1].(*StringValue)
+				if !argumentOk || !separatorOk {
+					panic(errors.NewUnreachableError())
+				}
+
+				strs := make([]string, 0, argument.Count())
+				argument.Iterate(func(element Value) (resume bool) {
+					str, ok := element.(*StringValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+					strs = append(strs, str.Str)
+					return true
+				})
+
+				return newMeteredStringValue(
+					invocation.Interpreter,
+					strings.Join(strs, separator.Str),
+				)
+			},
+			sema.StringTypeJoinFunctionType,
+		),
+	)
+
 	return functionValue
 }()
 
+// decodeUTF16 returns the string for the given UTF-16 code units,
+// and false if the code units are not valid UTF-16, i.e. contain unpaired surrogates
+//
+func decodeUTF16(codeUnits []uint16) (string, bool) {
+	runes := make([]rune, 0, len(codeUnits))
+
+	for i := 0; i < len(codeUnits); i++ {
+		r := rune(codeUnits[i])
+
+		if utf16.IsSurrogate(r) {
+			if i+1 >= len(codeUnits) {
+				return "", false
+			}
+
+			r = utf16.DecodeRune(r, rune(codeUnits[i+1]))
+			if r == utf8.RuneError {
+				return "", false
+			}
+
+			i++
+		}
+
+		runes = append(runes, r)
+	}
+
+	return string(runes), true
+}
+
 func defineStringFunction(activation *VariableActivation) {
 	defineBaseValue(activation, sema.StringType.String(), stringFunction)
 }
 
+// bytesFunction is the `Bytes` function. It is stateless, hence it can be re-used across interpreters.
+//
+var bytesFunction = NewHostFunctionValue(
+	func(invocation Invocation) Value {
+		bytes, err := ByteArrayValueToByteSlice(invocation.Arguments[0])
+		if err != nil {
+			panic(err)
+		}
+		return NewBytesValue(bytes)
+	},
+	sema.BytesFunctionType,
+)
+
+func defineBytesFunction(activation *VariableActivation) {
+	defineBaseValue(activation, sema.BytesType.String(), bytesFunction)
+}
+
 // TODO:
 // - FunctionType
 //
@@ -3243,6 +4289,12 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 			return true
 		}
 
+	case BytesDynamicType:
+		switch superType {
+		case sema.AnyStructType, sema.BytesType:
+			return true
+		}
+
 	case BoolDynamicType:
 		switch superType {
 		case sema.AnyStructType, sema.BoolType:
@@ -3374,12 +4426,14 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 			}
 
 			// If the reference value is not authorized,
-			// it may not be downcasted
+			// it may not be downcasted.
+			// Its entitlements must permit the entitlements of the super type
 
 			return sema.IsSubType(
 				&sema.ReferenceType{
-					Authorized: authorized,
-					Type:       typedSubType.BorrowedType(),
+					Authorized:    authorized,
+					Authorization: typedSubType.Authorization(),
+					Type:          typedSubType.BorrowedType(),
 				},
 				typedSuperType,
 			)
@@ -3417,6 +4471,27 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 
 		return superType == sema.AnyStructType
 
+	case InclusiveRangeDynamicType:
+		if typedSuperType, ok := superType.(*sema.InclusiveRangeType); ok {
+
+			// InclusiveRange<T> <: InclusiveRange:
+			// always
+
+			if typedSuperType.MemberType == nil {
+				return true
+			}
+
+			// InclusiveRange<T> <: InclusiveRange<U>:
+			// if T <: U
+
+			return sema.IsSubType(
+				typedSubType.ElementType,
+				typedSuperType.MemberType,
+			)
+		}
+
+		return superType == sema.AnyStructType
+
 	case PublicPathDynamicType:
 		switch superType {
 		case sema.PublicPathType, sema.CapabilityPathType, sema.PathType, sema.AnyStructType:
@@ -3446,6 +4521,12 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 		case sema.AnyStructType, sema.BlockType:
 			return true
 		}
+
+	case BlockHeaderDynamicType:
+		switch superType {
+		case sema.AnyStructType, sema.BlockHeaderType:
+			return true
+		}
 	}
 
 	return false
@@ -3459,6 +4540,8 @@ func (interpreter *Interpreter) authAccountSaveFunction(addressValue AddressValu
 	return NewHostFunctionValue(
 		func(invocation Invocation) Value {
 
+			invocation.Interpreter.CheckWrite("save to storage", invocation.GetLocationRange)
+
 			value := invocation.Arguments[0]
 			path, pathOk := invocation.Arguments[1].(PathValue)
 
@@ -3490,7 +4573,7 @@ func (interpreter *Interpreter) authAccountSaveFunction(addressValue AddressValu
 			value = value.Transfer(
 				interpreter,
 				getLocationRange,
-				atree.Address(address),
+				atree.Address(storageOwner(address, domain)),
 				true,
 				nil,
 			)
@@ -3555,6 +4638,10 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 	return NewHostFunctionValue(
 		func(invocation Invocation) Value {
 
+			if clear {
+				invocation.Interpreter.CheckWrite("load from storage", invocation.GetLocationRange)
+			}
+
 			path, pathOk := invocation.Arguments[0].(PathValue)
 
 			if !pathOk {
@@ -3584,6 +4671,7 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 			if !interpreter.IsSubType(dynamicType, ty) {
 				panic(ForceCastTypeMismatchError{
 					ExpectedType:  ty,
+					ActualType:    interpreter.errorValueType(value),
 					LocationRange: invocation.GetLocationRange(),
 				})
 			}
@@ -3591,6 +4679,8 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 			inter := invocation.Interpreter
 			getLocationRange := invocation.GetLocationRange
 
+			storageID, isContainer := containerStorageID(value)
+
 			// We could also pass remove=true and the storable stored in storage,
 			// but passing remove=false here and writing nil below has the same effect
 			// TODO: potentially refactor and get storable in storage, pass it and remove=true
@@ -3605,7 +4695,17 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 			// Remove the value from storage,
 			// but only if the type check succeeded.
 			if clear {
-				interpreter.writeStored(address, domain, identifier, nil)
+				transferredStorageID, _ := containerStorageID(transferredValue)
+				if isContainer && storageID == transferredStorageID {
+					// The value is already stored at the temporary address,
+					// e.g. in the transaction domain, so the transfer did not copy it.
+					// Only remove the key, as removing the value would invalidate the transferred value
+					interpreter.Storage.
+						GetStorageMap(storageOwner(address, domain), domain).
+						RemoveKey(interpreter, identifier)
+				} else {
+					interpreter.writeStored(address, domain, identifier, nil)
+				}
 			}
 
 			return NewSomeValueNonCopying(transferredValue)
@@ -3644,6 +4744,7 @@ func (interpreter *Interpreter) authAccountBorrowFunction(addressValue AddressVa
 
 			reference := &StorageReferenceValue{
 				Authorized:           referenceType.Authorized,
+				Authorization:        referenceType.Authorization,
 				TargetStorageAddress: address,
 				TargetPath:           path,
 				BorrowedType:         referenceType.Type,
@@ -3675,6 +4776,8 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 	return NewHostFunctionValue(
 		func(invocation Invocation) Value {
 
+			invocation.Interpreter.CheckWrite("link", invocation.GetLocationRange)
+
 			typeParameterPair := invocation.TypeParameterTypes.Oldest()
 			if typeParameterPair == nil {
 				panic(errors.NewUnreachableError())
@@ -3738,6 +4841,73 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 	)
 }
 
+var authAccountReferenceType = &sema.ReferenceType{
+	Type: sema.AuthAccountType,
+}
+
+var authAccountReferenceStaticType = ReferenceStaticType{
+	Authorized: false,
+	Type:       PrimitiveStaticTypeAuthAccount,
+}
+
+func (interpreter *Interpreter) authAccountLinkAccountFunction(addressValue AddressValue) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+
+			invocation.Interpreter.CheckWrite("link account", invocation.GetLocationRange)
+
+			newCapabilityPath, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			newCapabilityDomain := newCapabilityPath.Domain.Identifier()
+			newCapabilityIdentifier := newCapabilityPath.Identifier
+
+			if interpreter.storedValueExists(
+				address,
+				newCapabilityDomain,
+				newCapabilityIdentifier,
+			) {
+				return NilValue{}
+			}
+
+			// Write new value
+
+			interpreter.writeStored(
+				address,
+				newCapabilityDomain,
+				newCapabilityIdentifier,
+				AccountLinkValue{},
+			)
+
+			onAccountLinked := interpreter.onAccountLinked
+			if onAccountLinked != nil {
+				onAccountLinked(
+					interpreter,
+					invocation.GetLocationRange,
+					addressValue,
+					newCapabilityPath,
+				)
+			}
+
+			return NewSomeValueNonCopying(
+				&CapabilityValue{
+					Address:    addressValue,
+					Path:       newCapabilityPath,
+					BorrowType: authAccountReferenceStaticType,
+				},
+			)
+
+		},
+		sema.AuthAccountTypeLinkAccountFunctionType,
+	)
+}
+
 func (interpreter *Interpreter) accountGetLinkTargetFunction(addressValue AddressValue) *HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
@@ -3780,6 +4950,8 @@ func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressVa
 	return NewHostFunctionValue(
 		func(invocation Invocation) Value {
 
+			invocation.Interpreter.CheckWrite("unlink", invocation.GetLocationRange)
+
 			capabilityPath, pathOk := invocation.Arguments[0].(PathValue)
 
 			if !pathOk {
@@ -3802,6 +4974,7 @@ func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressVa
 func (interpreter *Interpreter) capabilityBorrowFunction(
 	addressValue AddressValue,
 	pathValue PathValue,
+	capabilityID UInt64Value,
 	borrowType *sema.ReferenceType,
 ) *HostFunctionValue {
 
@@ -3828,10 +5001,11 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 				panic(errors.NewUnreachableError())
 			}
 
-			targetPath, authorized, err :=
-				interpreter.GetCapabilityFinalTargetPath(
+			target, authorized, err :=
+				interpreter.getCapabilityTarget(
 					address,
 					pathValue,
+					capabilityID,
 					borrowType,
 					invocation.GetLocationRange,
 				)
@@ -3839,12 +5013,35 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 				panic(err)
 			}
 
-			if targetPath == EmptyPathValue {
+			if target == nil {
 				return NilValue{}
 			}
 
+			var targetPath PathValue
+
+			switch target := target.(type) {
+			case AccountCapabilityTarget:
+				return NewSomeValueNonCopying(
+					&EphemeralReferenceValue{
+						Authorized: false,
+						Value: interpreter.authAccountHandler(
+							interpreter,
+							AddressValue(target),
+						),
+						BorrowedType: borrowType.Type,
+					},
+				)
+
+			case PathCapabilityTarget:
+				targetPath = PathValue(target)
+
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
 			reference := &StorageReferenceValue{
 				Authorized:           authorized,
+				Authorization:        borrowType.Authorization,
 				TargetStorageAddress: address,
 				TargetPath:           targetPath,
 				BorrowedType:         borrowType.Type,
@@ -3871,6 +5068,7 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 func (interpreter *Interpreter) capabilityCheckFunction(
 	addressValue AddressValue,
 	pathValue PathValue,
+	capabilityID UInt64Value,
 	borrowType *sema.ReferenceType,
 ) *HostFunctionValue {
 
@@ -3898,10 +5096,11 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 				panic(errors.NewUnreachableError())
 			}
 
-			targetPath, authorized, err :=
-				interpreter.GetCapabilityFinalTargetPath(
+			target, authorized, err :=
+				interpreter.getCapabilityTarget(
 					address,
 					pathValue,
+					capabilityID,
 					borrowType,
 					invocation.GetLocationRange,
 				)
@@ -3909,12 +5108,26 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 				panic(err)
 			}
 
-			if targetPath == EmptyPathValue {
+			if target == nil {
 				return BoolValue(false)
 			}
 
+			var targetPath PathValue
+
+			switch target := target.(type) {
+			case AccountCapabilityTarget:
+				return BoolValue(true)
+
+			case PathCapabilityTarget:
+				targetPath = PathValue(target)
+
+			default:
+				panic(errors.NewUnreachableError())
+			}
+
 			reference := &StorageReferenceValue{
 				Authorized:           authorized,
+				Authorization:        borrowType.Authorization,
 				TargetStorageAddress: address,
 				TargetPath:           targetPath,
 				BorrowedType:         borrowType.Type,
@@ -3934,6 +5147,61 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 	)
 }
 
+// getCapabilityTarget returns the final target of the capability.
+// Capabilities with an ID are resolved through their capability controller,
+// all other capabilities are resolved by following links.
+//
+func (interpreter *Interpreter) getCapabilityTarget(
+	address common.Address,
+	path PathValue,
+	capabilityID UInt64Value,
+	wantedBorrowType *sema.ReferenceType,
+	getLocationRange func() LocationRange,
+) (
+	target CapabilityTarget,
+	authorized bool,
+	err error,
+) {
+	if capabilityID != 0 {
+		target, authorized = interpreter.getStorageCapabilityControllerTarget(
+			address,
+			capabilityID,
+			wantedBorrowType,
+		)
+		return target, authorized, nil
+	}
+
+	return interpreter.GetCapabilityFinalTarget(
+		address,
+		path,
+		wantedBorrowType,
+		getLocationRange,
+	)
+}
+
+// CapabilityTarget is the final target of a capability,
+// i.e. either a path in storage or an account.
+//
+type CapabilityTarget interface {
+	isCapabilityTarget()
+}
+
+// PathCapabilityTarget is a capability target which is a path in storage.
+//
+type PathCapabilityTarget PathValue
+
+func (PathCapabilityTarget) isCapabilityTarget() {}
+
+// AccountCapabilityTarget is a capability target which is an account.
+//
+type AccountCapabilityTarget common.Address
+
+func (AccountCapabilityTarget) isCapabilityTarget() {}
+
+// GetCapabilityFinalTargetPath returns the final target path of the capability
+// at the given address and path, following links.
+// It returns EmptyPathValue if the capability does not target a path in storage.
+//
 func (interpreter *Interpreter) GetCapabilityFinalTargetPath(
 	address common.Address,
 	path PathValue,
@@ -3943,6 +5211,38 @@ func (interpreter *Interpreter) GetCapabilityFinalTargetPath(
 	finalPath PathValue,
 	authorized bool,
 	err error,
+) {
+	target, authorized, err := interpreter.GetCapabilityFinalTarget(
+		address,
+		path,
+		wantedBorrowType,
+		getLocationRange,
+	)
+	if err != nil {
+		return EmptyPathValue, false, err
+	}
+
+	pathTarget, ok := target.(PathCapabilityTarget)
+	if !ok {
+		return EmptyPathValue, false, nil
+	}
+
+	return PathValue(pathTarget), authorized, nil
+}
+
+// GetCapabilityFinalTarget returns the final target of the capability
+// at the given address and path, following links.
+// It returns nil if the capability cannot be borrowed with the wanted borrow type.
+//
+func (interpreter *Interpreter) GetCapabilityFinalTarget(
+	address common.Address,
+	path PathValue,
+	wantedBorrowType *sema.ReferenceType,
+	getLocationRange func() LocationRange,
+) (
+	target CapabilityTarget,
+	authorized bool,
+	err error,
 ) {
 	wantedReferenceType := wantedBorrowType
 
@@ -3953,7 +5253,7 @@ func (interpreter *Interpreter) GetCapabilityFinalTargetPath(
 		// Detect cyclic links
 
 		if _, ok := seenPaths[path]; ok {
-			return EmptyPathValue, false, CyclicLinkError{
+			return nil, false, CyclicLinkError{
 				Address:       address,
 				Paths:         paths,
 				LocationRange: getLocationRange(),
@@ -3969,23 +5269,61 @@ func (interpreter *Interpreter) GetCapabilityFinalTargetPath(
 		)
 
 		if value == nil {
-			return EmptyPathValue, false, nil
+			return nil, false, nil
 		}
 
-		if link, ok := value.(LinkValue); ok {
+		switch value := value.(type) {
+		case LinkValue:
 
-			allowedType := interpreter.MustConvertStaticToSemaType(link.Type)
+			allowedType := interpreter.MustConvertStaticToSemaType(value.Type)
 
 			if !sema.IsSubType(allowedType, wantedBorrowType) {
-				return EmptyPathValue, false, nil
+				return nil, false, nil
 			}
 
-			targetPath := link.TargetPath
+			targetPath := value.TargetPath
 			paths = append(paths, targetPath)
 			path = targetPath
 
-		} else {
-			return path, wantedReferenceType.Authorized, nil
+		case AccountLinkValue:
+
+			if !sema.IsSubType(authAccountReferenceType, wantedBorrowType) {
+				return nil, false, nil
+			}
+
+			return AccountCapabilityTarget(address), false, nil
+
+		case *CapabilityValue:
+
+			// Capabilities published with `AuthAccount.Capabilities.publish`
+			// are resolved through their capability controller,
+			// or, if they were created by linking, through their path
+
+			if value.ID == 0 {
+				if value.BorrowType != nil {
+					allowedType := interpreter.MustConvertStaticToSemaType(value.BorrowType)
+
+					if !sema.IsSubType(allowedType, wantedBorrowType) {
+						return nil, false, nil
+					}
+				}
+
+				targetPath := value.Path
+				paths = append(paths, targetPath)
+				path = targetPath
+
+				continue
+			}
+
+			target, authorized = interpreter.getStorageCapabilityControllerTarget(
+				address,
+				value.ID,
+				wantedBorrowType,
+			)
+			return target, authorized, nil
+
+		default:
+			return PathCapabilityTarget(path), wantedReferenceType.Authorized, nil
 		}
 	}
 }
@@ -3999,6 +5337,7 @@ func (interpreter *Interpreter) ConvertStaticToSemaType(staticType StaticType) (
 		func(location common.Location, qualifiedIdentifier string, typeID common.TypeID) (*sema.CompositeType, error) {
 			return interpreter.GetCompositeType(location, qualifiedIdentifier, typeID)
 		},
+		interpreter.getEntitlementType,
 	)
 }
 
@@ -4062,6 +5401,17 @@ func (interpreter *Interpreter) GetCompositeType(
 }
 
 func (interpreter *Interpreter) getUserCompositeType(location common.Location, typeID common.TypeID) (*sema.CompositeType, error) {
+
+	// Composite types provided by the host take precedence,
+	// as their location might not have a program which could be loaded
+
+	if interpreter.compositeTypeHandler != nil {
+		ty := interpreter.compositeTypeHandler(location, typeID)
+		if ty != nil {
+			return ty, nil
+		}
+	}
+
 	elaboration := interpreter.getElaboration(location)
 	if elaboration == nil {
 		return nil, TypeLoadingError{
@@ -4113,21 +5463,49 @@ func (interpreter *Interpreter) getInterfaceType(location common.Location, quali
 	return ty, nil
 }
 
+func (interpreter *Interpreter) getEntitlementType(typeID common.TypeID) (*sema.EntitlementType, error) {
+	location, _, err := common.DecodeTypeID(string(typeID))
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		return nil, TypeLoadingError{
+			TypeID: typeID,
+		}
+	}
+
+	elaboration := interpreter.getElaboration(location)
+	if elaboration == nil {
+		return nil, TypeLoadingError{
+			TypeID: typeID,
+		}
+	}
+
+	ty := elaboration.EntitlementTypes[typeID]
+	if ty == nil {
+		return nil, TypeLoadingError{
+			TypeID: typeID,
+		}
+	}
+	return ty, nil
+}
+
 func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
-	if interpreter.onLoopIteration == nil {
-		return
+	if interpreter.onLoopIteration != nil {
+		line := pos.StartPosition().Line
+		interpreter.onLoopIteration(interpreter, line)
 	}
 
-	line := pos.StartPosition().Line
-	interpreter.onLoopIteration(interpreter, line)
+	interpreter.reportComputation(common.ComputationKindLoopIteration, 1)
 }
 
 func (interpreter *Interpreter) reportFunctionInvocation(line int) {
-	if interpreter.onFunctionInvocation == nil {
-		return
+	if interpreter.onFunctionInvocation != nil {
+		interpreter.onFunctionInvocation(interpreter, line)
 	}
 
-	interpreter.onFunctionInvocation(interpreter, line)
+	interpreter.reportComputation(common.ComputationKindFunctionInvocation, 1)
 }
 
 func (interpreter *Interpreter) reportInvokedFunctionReturn(line int) {
@@ -4220,11 +5598,29 @@ func (interpreter *Interpreter) ExpectType(
 		}
 		panic(TypeMismatchError{
 			ExpectedType:  expectedType,
+			ActualType:    interpreter.errorValueType(value),
 			LocationRange: locationRange,
 		})
 	}
 }
 
+// errorValueType returns the type of the given value, for use in error messages.
+// It returns nil if the type cannot be determined.
+//
+func (interpreter *Interpreter) errorValueType(value Value) sema.Type {
+	staticType := value.StaticType()
+	if staticType == nil {
+		return nil
+	}
+
+	ty, err := interpreter.ConvertStaticToSemaType(staticType)
+	if err != nil {
+		return nil
+	}
+
+	return ty
+}
+
 func (interpreter *Interpreter) checkContainerMutation(
 	elementType StaticType,
 	element Value,
@@ -4371,6 +5767,16 @@ func (interpreter *Interpreter) ValidateAtreeValue(v atree.Value) {
 	}
 }
 
+// declareSelfVariable declares `self`, and for attachments also `base`.
+//
+func (interpreter *Interpreter) declareSelfVariable(self MemberAccessibleValue) {
+	interpreter.declareVariable(sema.SelfIdentifier, self)
+
+	if compositeValue, ok := self.(*CompositeValue); ok && compositeValue != nil && compositeValue.base != nil {
+		interpreter.declareVariable(sema.BaseIdentifier, compositeValue.base)
+	}
+}
+
 func (interpreter *Interpreter) trackReferencedResourceKindedValue(
 	id atree.StorageID,
 	value ReferenceTrackedResourceKindedValue,
@@ -22,6 +22,8 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/onflow/atree"
+
 	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -105,9 +107,17 @@ func (interpreter *Interpreter) indexExpressionGetterSetter(indexExpression *ast
 //
 func (interpreter *Interpreter) memberExpressionGetterSetter(memberExpression *ast.MemberExpression) getterSetter {
 	target := interpreter.evalExpression(memberExpression.Expression)
+	return interpreter.memberGetterSetter(memberExpression, target)
+}
+
+// memberGetterSetter returns a getter/setter function pair
+// for the target member expression, given the already evaluated target
+//
+func (interpreter *Interpreter) memberGetterSetter(memberExpression *ast.MemberExpression, target Value) getterSetter {
 	identifier := memberExpression.Identifier.Identifier
 	getLocationRange := locationRangeGetter(interpreter.Location, memberExpression)
 	_, isNestedResourceMove := interpreter.Program.Elaboration.IsNestedResourceMoveExpression[memberExpression]
+	mappedType, isMapped := interpreter.Program.Elaboration.MemberExpressionMappedTypes[memberExpression]
 	return getterSetter{
 		target: target,
 		get: func(allowMissing bool) Value {
@@ -139,6 +149,13 @@ func (interpreter *Interpreter) memberExpressionGetterSetter(memberExpression *a
 				})
 			}
 
+			// Accessing a member with mapped entitlement access results in a reference
+			// which is authorized for the image of the accessed reference's authorization
+
+			if isMapped && resultValue != nil {
+				resultValue = reauthorizeMappedMember(resultValue, mappedType)
+			}
+
 			// If the member access is optional chaining, only wrap the result value
 			// in an optional, if it is not already an optional value
 
@@ -191,7 +208,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Plus(right)
+		return interpreter.meterBigIntMemory(left.Plus(right))
 
 	case ast.OperationMinus:
 		left, leftOk := leftValue.(NumberValue)
@@ -199,7 +216,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Minus(right)
+		return interpreter.meterBigIntMemory(left.Minus(right))
 
 	case ast.OperationMod:
 		left, leftOk := leftValue.(NumberValue)
@@ -207,7 +224,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Mod(right)
+		return interpreter.meterBigIntMemory(left.Mod(right))
 
 	case ast.OperationMul:
 		left, leftOk := leftValue.(NumberValue)
@@ -215,7 +232,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Mul(right)
+		return interpreter.meterBigIntMemory(left.Mul(right))
 
 	case ast.OperationDiv:
 		left, leftOk := leftValue.(NumberValue)
@@ -223,7 +240,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Div(right)
+		return interpreter.meterBigIntMemory(left.Div(right))
 
 	case ast.OperationBitwiseOr:
 		left, leftOk := leftValue.(IntegerValue)
@@ -231,7 +248,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseOr(right)
+		return interpreter.meterBigIntMemory(left.BitwiseOr(right))
 
 	case ast.OperationBitwiseXor:
 		left, leftOk := leftValue.(IntegerValue)
@@ -239,7 +256,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseXor(right)
+		return interpreter.meterBigIntMemory(left.BitwiseXor(right))
 
 	case ast.OperationBitwiseAnd:
 		left, leftOk := leftValue.(IntegerValue)
@@ -247,7 +264,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseAnd(right)
+		return interpreter.meterBigIntMemory(left.BitwiseAnd(right))
 
 	case ast.OperationBitwiseLeftShift:
 		left, leftOk := leftValue.(IntegerValue)
@@ -255,7 +272,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseLeftShift(right)
+		return interpreter.meterBigIntMemory(left.BitwiseLeftShift(right))
 
 	case ast.OperationBitwiseRightShift:
 		left, leftOk := leftValue.(IntegerValue)
@@ -263,7 +280,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseRightShift(right)
+		return interpreter.meterBigIntMemory(left.BitwiseRightShift(right))
 
 	case ast.OperationLess:
 		left, leftOk := leftValue.(NumberValue)
@@ -395,7 +412,7 @@ func (interpreter *Interpreter) VisitUnaryExpression(expression *ast.UnaryExpres
 		if !ok {
 			panic(errors.NewUnreachableError())
 		}
-		return integerValue.Negate()
+		return interpreter.meterBigIntMemory(integerValue.Negate())
 
 	case ast.OperationMove:
 		return value
@@ -513,7 +530,20 @@ func (interpreter *Interpreter) VisitFixedPointExpression(expression *ast.FixedP
 }
 
 func (interpreter *Interpreter) VisitStringExpression(expression *ast.StringExpression) ast.Repr {
-	return NewStringValue(expression.Value)
+	// Strings are immutable, so the value of a string literal can be reused
+	// each time the literal is evaluated. This allows values cached in the string,
+	// like its hash input or its length, to be reused, e.g. when the literal
+	// is repeatedly used as the key of a dictionary lookup in a loop.
+
+	value, ok := interpreter.stringLiterals[expression]
+	if !ok {
+		value = newMeteredStringValue(interpreter, expression.Value)
+		if interpreter.stringLiterals == nil {
+			interpreter.stringLiterals = map[*ast.StringExpression]*StringValue{}
+		}
+		interpreter.stringLiterals[expression] = value
+	}
+	return value
 }
 
 func (interpreter *Interpreter) VisitArrayExpression(expression *ast.ArrayExpression) ast.Repr {
@@ -587,7 +617,52 @@ func (interpreter *Interpreter) VisitMemberExpression(expression *ast.MemberExpr
 	return interpreter.memberExpressionGetterSetter(expression).get(allowMissing)
 }
 
+// reauthorizeMappedMember returns the given member value,
+// with the reference it is or contains authorized according to the given mapped type.
+//
+func reauthorizeMappedMember(value Value, mappedType sema.Type) Value {
+	if optionalType, ok := mappedType.(*sema.OptionalType); ok {
+		someValue, ok := value.(*SomeValue)
+		if !ok {
+			return value
+		}
+		return NewSomeValueNonCopying(
+			reauthorizeMappedMember(someValue.Value, optionalType.Type),
+		)
+	}
+
+	referenceType, ok := mappedType.(*sema.ReferenceType)
+	if !ok {
+		return value
+	}
+
+	switch reference := value.(type) {
+	case *EphemeralReferenceValue:
+		return &EphemeralReferenceValue{
+			Authorized:    reference.Authorized,
+			Authorization: referenceType.Authorization,
+			Value:         reference.Value,
+			BorrowedType:  reference.BorrowedType,
+		}
+
+	case *StorageReferenceValue:
+		return &StorageReferenceValue{
+			Authorized:           reference.Authorized,
+			Authorization:        referenceType.Authorization,
+			TargetStorageAddress: reference.TargetStorageAddress,
+			TargetPath:           reference.TargetPath,
+			BorrowedType:         reference.BorrowedType,
+		}
+	}
+
+	return value
+}
+
 func (interpreter *Interpreter) VisitIndexExpression(expression *ast.IndexExpression) ast.Repr {
+	if attachmentType, ok := interpreter.Program.Elaboration.AttachmentAccessTypes[expression]; ok {
+		return interpreter.getAttachment(expression, attachmentType)
+	}
+
 	typedResult, ok := interpreter.evalExpression(expression.TargetExpression).(ValueIndexableValue)
 	if !ok {
 		panic(errors.NewUnreachableError())
@@ -620,7 +695,7 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 	}
 
 	// interpret the invoked expression
-	result := interpreter.evalExpression(invocationExpression.InvokedExpression)
+	result, self := interpreter.evalInvokedExpression(invocationExpression.InvokedExpression)
 
 	// Handle optional chaining on member expression, if any:
 	// - If the member expression is nil, finish execution
@@ -669,8 +744,19 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 
 	interpreter.reportFunctionInvocation(line)
 
+	if interpreter.debugger != nil {
+		interpreter.debugger.pushStackFrame(interpreter, invocationExpression)
+		defer interpreter.debugger.popStackFrame()
+	}
+
+	if interpreter.transcript != nil {
+		interpreter.transcript.recordFunctionCall(interpreter, invocationExpression)
+		defer interpreter.transcript.recordFunctionReturn()
+	}
+
 	resultValue := interpreter.invokeFunctionValue(
 		function,
+		self,
 		arguments,
 		argumentExpressions,
 		argumentTypes,
@@ -690,6 +776,41 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 	return resultValue
 }
 
+// evalInvokedExpression evaluates the invoked expression of an invocation.
+//
+// If the invoked expression is a member expression which accesses a function of a composite value,
+// then the function is resolved directly from the code of the composite type,
+// instead of looking up the member in the fields of the value first,
+// and the function is returned unbound, together with the composite value,
+// which must be used as the self value of the invocation.
+//
+func (interpreter *Interpreter) evalInvokedExpression(expression ast.Expression) (result Value, self *CompositeValue) {
+
+	memberExpression, ok := expression.(*ast.MemberExpression)
+	if !ok || memberExpression.Optional {
+		return interpreter.evalExpression(expression), nil
+	}
+
+	memberInfo := interpreter.Program.Elaboration.MemberExpressionMemberInfos[memberExpression]
+	if memberInfo.Member == nil ||
+		memberInfo.Member.DeclarationKind != common.DeclarationKindFunction {
+
+		return interpreter.evalExpression(expression), nil
+	}
+
+	target := interpreter.evalExpression(memberExpression.Expression)
+
+	if composite, ok := target.(*CompositeValue); ok {
+		function := composite.typeCodeFunction(interpreter, memberExpression.Identifier.Identifier)
+		if function != nil {
+			return function, composite
+		}
+	}
+
+	const allowMissing = false
+	return interpreter.memberGetterSetter(memberExpression, target).get(allowMissing), nil
+}
+
 func (interpreter *Interpreter) visitExpressionsNonCopying(expressions []ast.Expression) []Value {
 	values := make([]Value, 0, len(expressions))
 
@@ -780,6 +901,7 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 				getLocationRange := locationRangeGetter(interpreter.Location, expression.Expression)
 				panic(ForceCastTypeMismatchError{
 					ExpectedType:  expectedType,
+					ActualType:    interpreter.errorValueType(value),
 					LocationRange: getLocationRange(),
 				})
 			}
@@ -813,6 +935,158 @@ func (interpreter *Interpreter) VisitDestroyExpression(expression *ast.DestroyEx
 	return VoidValue{}
 }
 
+func (interpreter *Interpreter) VisitAttachExpression(expression *ast.AttachExpression) ast.Repr {
+
+	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+
+	attachmentType := interpreter.Program.Elaboration.AttachExpressionTypes[expression]
+
+	// The base is moved if it is a resource, or copied if it is a structure
+
+	base, ok := interpreter.evalExpression(expression.Base).
+		Transfer(
+			interpreter,
+			getLocationRange,
+			atree.Address{},
+			false,
+			nil,
+		).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	if base.GetAttachment(attachmentType.ID()) != nil {
+		panic(DuplicateAttachmentError{
+			AttachmentType: attachmentType,
+			LocationRange:  getLocationRange(),
+		})
+	}
+
+	// Construct the attachment, with the base value available in the initializer
+
+	attachment := interpreter.constructAttachment(
+		expression.Attachment,
+		interpreter.attachmentBaseReference(base, attachmentType),
+	)
+
+	base.SetAttachment(interpreter, getLocationRange, attachment)
+
+	return base
+}
+
+func (interpreter *Interpreter) constructAttachment(
+	invocation *ast.InvocationExpression,
+	base *EphemeralReferenceValue,
+) *CompositeValue {
+
+	previousBase := interpreter.attachmentBase
+	interpreter.attachmentBase = base
+	defer func() {
+		interpreter.attachmentBase = previousBase
+	}()
+
+	attachment, ok := interpreter.evalExpression(invocation).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return attachment
+}
+
+// getAttachment evaluates an index expression which accesses an attachment,
+// e.g. `r[A]`, and returns an optional reference to the attachment.
+//
+func (interpreter *Interpreter) getAttachment(
+	expression *ast.IndexExpression,
+	attachmentType *sema.CompositeType,
+) Value {
+	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+
+	target := interpreter.evalExpression(expression.TargetExpression)
+
+	base := interpreter.attachmentBaseValue(target, getLocationRange)
+
+	attachment := base.GetAttachment(attachmentType.ID())
+	if attachment == nil {
+		return NilValue{}
+	}
+
+	interpreter.setAttachmentBase(attachment, base)
+
+	if attachment.IsResourceKinded(interpreter) {
+		interpreter.trackReferencedResourceKindedValue(attachment.StorageID(), attachment)
+	}
+
+	return NewSomeValueNonCopying(
+		&EphemeralReferenceValue{
+			Value:        attachment,
+			BorrowedType: attachmentType,
+		},
+	)
+}
+
+// attachmentBaseValue returns the composite value which the attachments are attached to,
+// dereferencing the given value if it is a reference.
+//
+func (interpreter *Interpreter) attachmentBaseValue(
+	value Value,
+	getLocationRange func() LocationRange,
+) *CompositeValue {
+
+	var referencedValue *Value
+
+	switch value := value.(type) {
+	case *CompositeValue:
+		return value
+
+	case *EphemeralReferenceValue:
+		referencedValue = value.ReferencedValue()
+
+	case *StorageReferenceValue:
+		referencedValue = value.ReferencedValue(interpreter)
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	if referencedValue == nil {
+		panic(DereferenceError{
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	base, ok := (*referencedValue).(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	interpreter.checkResourceNotDestroyed(base, getLocationRange)
+
+	return base
+}
+
+// attachmentBaseReference returns the reference to the given base value,
+// which is available as `base` in the functions of the attachment.
+//
+func (interpreter *Interpreter) attachmentBaseReference(
+	base *CompositeValue,
+	attachmentType *sema.CompositeType,
+) *EphemeralReferenceValue {
+
+	if base.IsResourceKinded(interpreter) {
+		interpreter.trackReferencedResourceKindedValue(base.StorageID(), base)
+	}
+
+	return &EphemeralReferenceValue{
+		Value:        base,
+		BorrowedType: attachmentType.BaseType,
+	}
+}
+
+func (interpreter *Interpreter) setAttachmentBase(attachment *CompositeValue, base *CompositeValue) {
+	attachment.base = interpreter.attachmentBaseReference(base, attachment.semaType(interpreter))
+}
+
 func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *ast.ReferenceExpression) ast.Repr {
 
 	borrowType := interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression]
@@ -824,9 +1098,10 @@ func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *as
 	}
 
 	return &EphemeralReferenceValue{
-		Authorized:   borrowType.Authorized,
-		Value:        result,
-		BorrowedType: borrowType.Type,
+		Authorized:    borrowType.Authorized,
+		Authorization: borrowType.Authorization,
+		Value:         result,
+		BorrowedType:  borrowType.Type,
 	}
 }
 
@@ -43,6 +43,7 @@ func (interpreter *Interpreter) InvokeFunctionValue(
 
 	return interpreter.invokeFunctionValue(
 		function,
+		nil,
 		arguments,
 		nil,
 		argumentTypes,
@@ -52,8 +53,12 @@ func (interpreter *Interpreter) InvokeFunctionValue(
 	), nil
 }
 
+// invokeFunctionValue invokes the given function with the given arguments.
+// If self is given, the function is invoked as a function of the given composite value.
+//
 func (interpreter *Interpreter) invokeFunctionValue(
 	function FunctionValue,
+	self *CompositeValue,
 	arguments []Value,
 	expressions []ast.Expression,
 	argumentTypes []sema.Type,
@@ -104,6 +109,7 @@ func (interpreter *Interpreter) invokeFunctionValue(
 		TypeParameterTypes: typeParameterTypes,
 		GetLocationRange:   getLocationRange,
 		Interpreter:        interpreter,
+		Self:               self,
 	}
 
 	return function.invoke(invocation)
@@ -122,7 +128,7 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 
 	// Make `self` available, if any
 	if invocation.Self != nil {
-		interpreter.declareVariable(sema.SelfIdentifier, invocation.Self)
+		interpreter.declareSelfVariable(invocation.Self)
 	}
 
 	return interpreter.invokeInterpretedFunctionActivated(function, invocation.Arguments)
@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
 
@@ -44,6 +45,8 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface
 		interpreter.onStatement(interpreter, statement)
 	}
 
+	interpreter.reportComputation(common.ComputationKindStatement, 1)
+
 	return statement.Accept(interpreter)
 }
 
@@ -288,6 +291,60 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 	getLocationRange := locationRangeGetter(interpreter.Location, statement)
 
 	value := interpreter.evalExpression(statement.Value)
+
+	var indexVariable *Variable
+	var one = NewIntValueFromInt64(1)
+	if statement.Index != nil {
+		indexVariable = interpreter.declareVariable(
+			statement.Index.Identifier,
+			NewIntValueFromInt64(0),
+		)
+	}
+
+	// executeBody evaluates the body of the loop for the given element.
+	// It returns the result of the loop, if the loop should not be resumed.
+
+	executeBody := func(value Value) (result ast.Repr, resume bool) {
+
+		interpreter.reportLoopIteration(statement)
+
+		variable.SetValue(value)
+
+		result = statement.Block.Accept(interpreter)
+
+		switch result.(type) {
+		case controlBreak:
+			return nil, false
+
+		case controlContinue:
+			// NO-OP
+
+		case functionReturn:
+			return result, false
+		}
+
+		if indexVariable != nil {
+			indexVariable.SetValue(indexVariable.GetValue().(IntValue).Plus(one))
+		}
+
+		return nil, true
+	}
+
+	// Ranges are iterated lazily, without materializing their elements
+
+	if rangeValue, ok := isInclusiveRangeValue(value); ok {
+		var result ast.Repr
+		interpreter.forEachInclusiveRangeElement(
+			rangeValue,
+			func(element Value) bool {
+				var resume bool
+				result, resume = executeBody(element)
+				return resume
+			},
+		)
+		return result
+	}
+
 	transferredValue := value.Transfer(
 		interpreter,
 		getLocationRange,
@@ -296,20 +353,13 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 		nil,
 	)
 
-	iterator, err := transferredValue.(*ArrayValue).array.Iterator()
+	transferredArray := transferredValue.(*ArrayValue)
+
+	iterator, err := transferredArray.array.Iterator()
 	if err != nil {
 		panic(ExternalError{err})
 	}
 
-	var indexVariable *Variable
-	var one = NewIntValueFromInt64(1)
-	if statement.Index != nil {
-		indexVariable = interpreter.declareVariable(
-			statement.Index.Identifier,
-			NewIntValueFromInt64(0),
-		)
-	}
-
 	for {
 		var atreeValue atree.Value
 		atreeValue, err = iterator.Next()
@@ -321,31 +371,45 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 			return nil
 		}
 
-		interpreter.reportLoopIteration(statement)
-
 		// atree.Array iterator returns low-level atree.Value,
 		// convert to high-level interpreter.Value
-		value := MustConvertStoredValue(atreeValue)
+		value := transferredArray.storedElement(atreeValue)
 
-		variable.SetValue(value)
+		result, resume := executeBody(value)
+		if !resume {
+			return result
+		}
+	}
+}
 
-		result := statement.Block.Accept(interpreter)
+func (interpreter *Interpreter) VisitRemoveStatement(statement *ast.RemoveStatement) ast.Repr {
+	value := interpreter.evalExpression(statement.Value)
 
-		switch result.(type) {
-		case controlBreak:
-			return nil
+	attachmentType := interpreter.Program.Elaboration.RemoveStatementAttachmentTypes[statement]
 
-		case controlContinue:
-			// NO-OP
+	getLocationRange := locationRangeGetter(interpreter.Location, statement)
 
-		case functionReturn:
-			return result
-		}
+	base := interpreter.attachmentBaseValue(value, getLocationRange)
 
-		if indexVariable != nil {
-			indexVariable.SetValue(indexVariable.GetValue().(IntValue).Plus(one))
-		}
+	key := attachmentKey(attachmentType.ID())
+
+	// Resource-kinded attachments are destroyed when removed,
+	// all other attachments are just removed
+
+	if !attachmentType.IsResourceType() {
+		base.RemoveField(interpreter, getLocationRange, key)
+		return nil
 	}
+
+	attachment, ok := base.RemoveMember(interpreter, getLocationRange, key).(*CompositeValue)
+	if !ok {
+		return nil
+	}
+
+	interpreter.setAttachmentBase(attachment, base)
+	attachment.Destroy(interpreter, getLocationRange)
+
+	return nil
 }
 
 func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement) ast.Repr {
@@ -369,6 +433,10 @@ func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement)
 		panic(err)
 	}
 
+	if interpreter.transcript != nil {
+		interpreter.transcript.recordEvent(interpreter, statement, event)
+	}
+
 	return nil
 }
 
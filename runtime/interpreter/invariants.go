@@ -0,0 +1,355 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// maybeCheckValueInvariants checks the invariants of the given value,
+// if invariant checks are enabled.
+//
+// The given address is the address which is expected to own the value.
+//
+func (interpreter *Interpreter) maybeCheckValueInvariants(
+	value Value,
+	address atree.Address,
+	getLocationRange func() LocationRange,
+) {
+	if !interpreter.invariantChecksEnabled {
+		return
+	}
+
+	interpreter.CheckValueInvariants(value, address, getLocationRange)
+}
+
+// CheckValueInvariants checks the invariants of the given value and all its nested values.
+//
+// Ownership: All container values (arrays, dictionaries, and composites)
+// must be stored in the account with the given address.
+//
+// Slab references: The slabs of all container values must exist in storage.
+//
+// Type agreement: The types of elements, keys, and fields
+// must be subtypes of the static types declared by the container.
+//
+// The checks have no side effects, e.g. they do not load programs.
+// Types declared in programs which are not loaded yet are not checked.
+//
+// An InvariantViolationError is panicked if an invariant does not hold.
+//
+// The checks are expensive, they should only be enabled in emulator and test environments.
+//
+func (interpreter *Interpreter) CheckValueInvariants(
+	value Value,
+	address atree.Address,
+	getLocationRange func() LocationRange,
+) {
+	checker := valueInvariantsChecker{
+		interpreter:      interpreter,
+		address:          address,
+		getLocationRange: getLocationRange,
+	}
+	checker.check(value)
+}
+
+type valueInvariantsChecker struct {
+	interpreter      *Interpreter
+	address          atree.Address
+	getLocationRange func() LocationRange
+}
+
+func (c valueInvariantsChecker) check(value Value) {
+	switch value := value.(type) {
+	case *SomeValue:
+		c.check(value.Value)
+
+	case *ArrayValue:
+		c.checkStorageID(value, value.StorageID())
+
+		elementType := c.semaType(value.Type.ElementType())
+
+		index := 0
+		value.Iterate(func(element Value) (resume bool) {
+			c.checkType(element, elementType, func() string {
+				return fmt.Sprintf("element %d of array", index)
+			})
+			c.check(element)
+			index++
+			return true
+		})
+
+	case *DictionaryValue:
+		c.checkStorageID(value, value.StorageID())
+
+		keyType := c.semaType(value.Type.KeyType)
+		valueType := c.semaType(value.Type.ValueType)
+
+		value.Iterate(func(key, element Value) (resume bool) {
+			c.checkType(key, keyType, func() string {
+				return "key of dictionary"
+			})
+			c.checkType(element, valueType, func() string {
+				return "value of dictionary"
+			})
+			c.check(key)
+			c.check(element)
+			return true
+		})
+
+	case *CompositeValue:
+		c.checkStorageID(value, value.StorageID())
+
+		compositeType := c.loadedCompositeType(value)
+
+		value.ForEachField(func(fieldName string, fieldValue Value) {
+
+			// Only fields declared by the composite type can be checked,
+			// e.g. the injected fields of contracts are not declared
+
+			if compositeType == nil {
+				c.check(fieldValue)
+				return
+			}
+
+			member, ok := compositeType.Members.Get(fieldName)
+			if ok && member.DeclarationKind == common.DeclarationKindField {
+				c.checkType(fieldValue, member.TypeAnnotation.Type, func() string {
+					return fmt.Sprintf("field `%s` of %s", fieldName, value.TypeID())
+				})
+			}
+
+			c.check(fieldValue)
+		})
+	}
+}
+
+// loadedCompositeType returns the type of the composite value,
+// if the program declaring it is already loaded.
+//
+func (c valueInvariantsChecker) loadedCompositeType(value *CompositeValue) *sema.CompositeType {
+	compositeType, err := c.getLoadedCompositeType(
+		value.Location,
+		value.QualifiedIdentifier,
+		value.TypeID(),
+	)
+	if err != nil {
+		return nil
+	}
+	return compositeType
+}
+
+func (c valueInvariantsChecker) loadedElaboration(location common.Location) *sema.Elaboration {
+	subInterpreter := c.interpreter.allInterpreters[location.ID()]
+	if subInterpreter == nil || subInterpreter.Program == nil {
+		return nil
+	}
+	return subInterpreter.Program.Elaboration
+}
+
+func (c valueInvariantsChecker) getLoadedCompositeType(
+	location common.Location,
+	qualifiedIdentifier string,
+	typeID common.TypeID,
+) (*sema.CompositeType, error) {
+
+	var ty *sema.CompositeType
+	if location == nil {
+		ty = sema.NativeCompositeTypes[qualifiedIdentifier]
+	} else if elaboration := c.loadedElaboration(location); elaboration != nil {
+		ty = elaboration.CompositeTypes[typeID]
+	}
+
+	if ty == nil {
+		return nil, TypeLoadingError{
+			TypeID: typeID,
+		}
+	}
+	return ty, nil
+}
+
+func (c valueInvariantsChecker) getLoadedInterfaceType(
+	location common.Location,
+	qualifiedIdentifier string,
+) (*sema.InterfaceType, error) {
+
+	if location == nil {
+		return nil, &InterfaceMissingLocationError{
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	}
+
+	typeID := location.TypeID(qualifiedIdentifier)
+
+	var ty *sema.InterfaceType
+	if elaboration := c.loadedElaboration(location); elaboration != nil {
+		ty = elaboration.InterfaceTypes[typeID]
+	}
+
+	if ty == nil {
+		return nil, TypeLoadingError{
+			TypeID: typeID,
+		}
+	}
+	return ty, nil
+}
+
+func (c valueInvariantsChecker) getLoadedEntitlementType(typeID common.TypeID) (*sema.EntitlementType, error) {
+	location, _, err := common.DecodeTypeID(string(typeID))
+	if err != nil {
+		return nil, err
+	}
+
+	var ty *sema.EntitlementType
+	if location != nil {
+		if elaboration := c.loadedElaboration(location); elaboration != nil {
+			ty = elaboration.EntitlementTypes[typeID]
+		}
+	}
+
+	if ty == nil {
+		return nil, TypeLoadingError{
+			TypeID: typeID,
+		}
+	}
+	return ty, nil
+}
+
+// semaType converts the static type to a sema type,
+// if all types it refers to are already loaded, and returns nil otherwise.
+//
+func (c valueInvariantsChecker) semaType(staticType StaticType) sema.Type {
+	ty, err := ConvertStaticToSemaType(
+		staticType,
+		c.getLoadedInterfaceType,
+		c.getLoadedCompositeType,
+		c.getLoadedEntitlementType,
+	)
+	if err != nil {
+		return nil
+	}
+	return ty
+}
+
+// checkStorageID checks that the slab of the container value
+// is owned by the expected address, and that it exists in storage.
+//
+func (c valueInvariantsChecker) checkStorageID(value Value, storageID atree.StorageID) {
+
+	if storageID.Address != c.address {
+		panic(InvariantViolationError{
+			Message: fmt.Sprintf(
+				"%s is stored in account %s, expected %s",
+				value.StaticType(),
+				common.Address(storageID.Address).HexWithPrefix(),
+				common.Address(c.address).HexWithPrefix(),
+			),
+			LocationRange: c.getLocationRange(),
+		})
+	}
+
+	_, found, err := c.interpreter.Storage.Retrieve(storageID)
+	if err != nil {
+		panic(ExternalError{err})
+	}
+	if !found {
+		panic(InvariantViolationError{
+			Message: fmt.Sprintf(
+				"slab %s of %s does not exist",
+				storageID,
+				value.StaticType(),
+			),
+			LocationRange: c.getLocationRange(),
+		})
+	}
+}
+
+// checkType checks that the type of the value
+// is a subtype of the given static type.
+//
+// As the value's nested values are checked separately,
+// only the static type of the value is considered,
+// which, unlike the dynamic type, can be determined without loading programs.
+//
+func (c valueInvariantsChecker) checkType(value Value, expectedType sema.Type, describe func() string) {
+	if expectedType == nil {
+		return
+	}
+
+	actualType := c.semaType(value.StaticType())
+	if actualType == nil || sema.IsSubType(actualType, expectedType) {
+		return
+	}
+
+	if isCharacterStringValue(value, expectedType) {
+		return
+	}
+
+	// Function values' static types do not (yet) have parameter and return type information,
+	// so accept any function, like checkValueTransferTargetType
+
+	if _, ok := value.(FunctionValue); ok {
+		switch unwrappedExpectedType := sema.UnwrapOptionalType(expectedType).(type) {
+		case *sema.FunctionType:
+			return
+		default:
+			switch unwrappedExpectedType {
+			case sema.AnyStructType, sema.AnyType:
+				return
+			}
+		}
+	}
+
+	panic(InvariantViolationError{
+		Message: fmt.Sprintf(
+			"%s has type %s, expected %s",
+			describe(),
+			actualType.QualifiedString(),
+			expectedType.QualifiedString(),
+		),
+		LocationRange: c.getLocationRange(),
+	})
+}
+
+// isCharacterStringValue returns true if the value, or the value nested in optionals,
+// is a string value which is expected to be a character.
+// Characters are represented as string values, so their static type is String.
+//
+func isCharacterStringValue(value Value, expectedType sema.Type) bool {
+	for {
+		someValue, ok := value.(*SomeValue)
+		if !ok {
+			break
+		}
+		optionalType, ok := expectedType.(*sema.OptionalType)
+		if !ok {
+			return false
+		}
+		value = someValue.Value
+		expectedType = optionalType.Type
+	}
+
+	_, ok := value.(*StringValue)
+	return ok && expectedType == sema.CharacterType
+}
@@ -0,0 +1,183 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	. "github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestCheckValueInvariants(t *testing.T) {
+
+	t.Parallel()
+
+	intArrayType := VariableSizedStaticType{
+		Type: PrimitiveStaticTypeInt,
+	}
+
+	nestedArrayType := VariableSizedStaticType{
+		Type: intArrayType,
+	}
+
+	address := common.Address{0, 0, 0, 0, 0, 0, 0, 1}
+
+	requireInvariantViolation := func(t *testing.T, f func()) InvariantViolationError {
+		var violation InvariantViolationError
+		func() {
+			defer func() {
+				r := recover()
+				require.IsType(t, InvariantViolationError{}, r)
+				violation = r.(InvariantViolationError)
+			}()
+			f()
+		}()
+		return violation
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		value := NewArrayValue(
+			inter,
+			nestedArrayType,
+			address,
+			NewArrayValue(
+				inter,
+				intArrayType,
+				common.Address{},
+				NewIntValueFromInt64(1),
+			),
+		)
+
+		inter.CheckValueInvariants(value, atree.Address(address), ReturnEmptyLocationRange)
+	})
+
+	t.Run("element type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		// NOTE: NewArrayValue does not check the element types
+		value := NewArrayValue(
+			inter,
+			intArrayType,
+			common.Address{},
+			NewStringValue("a"),
+		)
+
+		violation := requireInvariantViolation(t, func() {
+			inter.CheckValueInvariants(value, atree.Address{}, ReturnEmptyLocationRange)
+		})
+
+		assert.Equal(t,
+			"internal invariant violated: element 0 of array has type String, expected Int",
+			violation.Error(),
+		)
+	})
+
+	t.Run("owner mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		value := NewArrayValue(
+			inter,
+			intArrayType,
+			address,
+		)
+
+		violation := requireInvariantViolation(t, func() {
+			inter.CheckValueInvariants(value, atree.Address{}, ReturnEmptyLocationRange)
+		})
+
+		assert.Equal(t,
+			"internal invariant violated: [Int] is stored in account 0x0000000000000001, "+
+				"expected 0x0000000000000000",
+			violation.Error(),
+		)
+	})
+
+	t.Run("nested owner mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		// NOTE: NewArrayValueWithIterator does not transfer the elements
+
+		element := NewArrayValue(
+			inter,
+			intArrayType,
+			address,
+		)
+
+		elements := []Value{element}
+
+		value := NewArrayValueWithIterator(
+			inter,
+			nestedArrayType,
+			common.Address{},
+			func() Value {
+				if len(elements) == 0 {
+					return nil
+				}
+				element := elements[0]
+				elements = elements[1:]
+				return element
+			},
+		)
+
+		requireInvariantViolation(t, func() {
+			inter.CheckValueInvariants(value, atree.Address{}, ReturnEmptyLocationRange)
+		})
+	})
+
+	t.Run("missing slab", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		value := NewArrayValue(
+			inter,
+			intArrayType,
+			common.Address{},
+		)
+
+		err := inter.Storage.Remove(value.StorageID())
+		require.NoError(t, err)
+
+		violation := requireInvariantViolation(t, func() {
+			inter.CheckValueInvariants(value, atree.Address{}, ReturnEmptyLocationRange)
+		})
+
+		assert.Contains(t, violation.Error(), "does not exist")
+	})
+}
@@ -2595,3 +2595,7 @@ func TestMinusUInt(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkMinusBigNumber(b *testing.B) {
+	benchmarkBigNumberOperation(b, NumberValue.Minus)
+}
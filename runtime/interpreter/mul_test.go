@@ -2970,3 +2970,7 @@ func TestMulInt256(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkMulBigNumber(b *testing.B) {
+	benchmarkBigNumberOperation(b, NumberValue.Mul)
+}
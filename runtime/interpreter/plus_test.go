@@ -2509,3 +2509,7 @@ func TestPlusInt256(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkPlusBigNumber(b *testing.B) {
+	benchmarkBigNumberOperation(b, NumberValue.Plus)
+}
@@ -63,8 +63,8 @@ const (
 	PrimitiveStaticTypeCharacter
 	PrimitiveStaticTypeMetaType
 	PrimitiveStaticTypeBlock
-	_
-	_
+	PrimitiveStaticTypeBytes
+	PrimitiveStaticTypeBlockHeader
 	_
 	_
 	_
@@ -168,6 +168,15 @@ const (
 	PrimitiveStaticTypeAuthAccountKeys
 	PrimitiveStaticTypePublicAccountKeys
 	PrimitiveStaticTypeAccountKey
+	PrimitiveStaticTypeAuthAccountCapabilities
+	PrimitiveStaticTypeAuthAccountStorageCapabilities
+	PrimitiveStaticTypePublicAccountCapabilities
+	PrimitiveStaticTypeStorageCapabilityController
+
+	// !!! *WARNING* !!!
+	// ADD NEW TYPES *BEFORE* THIS WARNING.
+	// DO *NOT* ADD NEW TYPES AFTER THIS LINE!
+	PrimitiveStaticType_Count
 )
 
 func (PrimitiveStaticType) isStaticType() {}
@@ -207,6 +216,12 @@ func (i PrimitiveStaticType) SemaType() sema.Type {
 	case PrimitiveStaticTypeBlock:
 		return sema.BlockType
 
+	case PrimitiveStaticTypeBytes:
+		return sema.BytesType
+
+	case PrimitiveStaticTypeBlockHeader:
+		return sema.BlockHeaderType
+
 	// Number
 
 	case PrimitiveStaticTypeNumber:
@@ -307,6 +322,14 @@ func (i PrimitiveStaticType) SemaType() sema.Type {
 		return sema.PublicAccountKeysType
 	case PrimitiveStaticTypeAccountKey:
 		return sema.AccountKeyType
+	case PrimitiveStaticTypeAuthAccountCapabilities:
+		return sema.AuthAccountCapabilitiesType
+	case PrimitiveStaticTypeAuthAccountStorageCapabilities:
+		return sema.AuthAccountStorageCapabilitiesType
+	case PrimitiveStaticTypePublicAccountCapabilities:
+		return sema.PublicAccountCapabilitiesType
+	case PrimitiveStaticTypeStorageCapabilityController:
+		return sema.StorageCapabilityControllerType
 	default:
 		panic(errors.NewUnreachableError())
 	}
@@ -420,6 +443,8 @@ func ConvertSemaToPrimitiveStaticType(t sema.Type) PrimitiveStaticType {
 		return PrimitiveStaticTypePublicAccount
 	case sema.BlockType:
 		return PrimitiveStaticTypeBlock
+	case sema.BlockHeaderType:
+		return PrimitiveStaticTypeBlockHeader
 	case sema.DeployedContractType:
 		return PrimitiveStaticTypeDeployedContract
 	case sema.AuthAccountContractsType:
@@ -432,8 +457,18 @@ func ConvertSemaToPrimitiveStaticType(t sema.Type) PrimitiveStaticType {
 		return PrimitiveStaticTypePublicAccountKeys
 	case sema.AccountKeyType:
 		return PrimitiveStaticTypeAccountKey
+	case sema.AuthAccountCapabilitiesType:
+		return PrimitiveStaticTypeAuthAccountCapabilities
+	case sema.AuthAccountStorageCapabilitiesType:
+		return PrimitiveStaticTypeAuthAccountStorageCapabilities
+	case sema.PublicAccountCapabilitiesType:
+		return PrimitiveStaticTypePublicAccountCapabilities
+	case sema.StorageCapabilityControllerType:
+		return PrimitiveStaticTypeStorageCapabilityController
 	case sema.StringType:
 		return PrimitiveStaticTypeString
+	case sema.BytesType:
+		return PrimitiveStaticTypeBytes
 	}
 
 	switch t.(type) {
@@ -20,6 +20,8 @@ func _() {
 	_ = x[PrimitiveStaticTypeCharacter-9]
 	_ = x[PrimitiveStaticTypeMetaType-10]
 	_ = x[PrimitiveStaticTypeBlock-11]
+	_ = x[PrimitiveStaticTypeBytes-12]
+	_ = x[PrimitiveStaticTypeBlockHeader-13]
 	_ = x[PrimitiveStaticTypeNumber-18]
 	_ = x[PrimitiveStaticTypeSignedNumber-19]
 	_ = x[PrimitiveStaticTypeInteger-24]
@@ -60,63 +62,73 @@ func _() {
 	_ = x[PrimitiveStaticTypeAuthAccountKeys-95]
 	_ = x[PrimitiveStaticTypePublicAccountKeys-96]
 	_ = x[PrimitiveStaticTypeAccountKey-97]
+	_ = x[PrimitiveStaticTypeAuthAccountCapabilities-98]
+	_ = x[PrimitiveStaticTypeAuthAccountStorageCapabilities-99]
+	_ = x[PrimitiveStaticTypePublicAccountCapabilities-100]
+	_ = x[PrimitiveStaticTypeStorageCapabilityController-101]
 }
 
-const _PrimitiveStaticType_name = "UnknownVoidAnyNeverAnyStructAnyResourceBoolAddressStringCharacterMetaTypeBlockNumberSignedNumberIntegerSignedIntegerFixedPointSignedFixedPointIntInt8Int16Int32Int64Int128Int256UIntUInt8UInt16UInt32UInt64UInt128UInt256Word8Word16Word32Word64Fix64UFix64PathCapabilityStoragePathCapabilityPathPublicPathPrivatePathAuthAccountPublicAccountDeployedContractAuthAccountContractsPublicAccountContractsAuthAccountKeysPublicAccountKeysAccountKey"
+const _PrimitiveStaticType_name = "UnknownVoidAnyNeverAnyStructAnyResourceBoolAddressStringCharacterMetaTypeBlockBytesBlockHeaderNumberSignedNumberIntegerSignedIntegerFixedPointSignedFixedPointIntInt8Int16Int32Int64Int128Int256UIntUInt8UInt16UInt32UInt64UInt128UInt256Word8Word16Word32Word64Fix64UFix64PathCapabilityStoragePathCapabilityPathPublicPathPrivatePathAuthAccountPublicAccountDeployedContractAuthAccountContractsPublicAccountContractsAuthAccountKeysPublicAccountKeysAccountKeyAuthAccountCapabilitiesAuthAccountStorageCapabilitiesPublicAccountCapabilitiesStorageCapabilityController"
 
 var _PrimitiveStaticType_map = map[PrimitiveStaticType]string{
-	0:  _PrimitiveStaticType_name[0:7],
-	1:  _PrimitiveStaticType_name[7:11],
-	2:  _PrimitiveStaticType_name[11:14],
-	3:  _PrimitiveStaticType_name[14:19],
-	4:  _PrimitiveStaticType_name[19:28],
-	5:  _PrimitiveStaticType_name[28:39],
-	6:  _PrimitiveStaticType_name[39:43],
-	7:  _PrimitiveStaticType_name[43:50],
-	8:  _PrimitiveStaticType_name[50:56],
-	9:  _PrimitiveStaticType_name[56:65],
-	10: _PrimitiveStaticType_name[65:73],
-	11: _PrimitiveStaticType_name[73:78],
-	18: _PrimitiveStaticType_name[78:84],
-	19: _PrimitiveStaticType_name[84:96],
-	24: _PrimitiveStaticType_name[96:103],
-	25: _PrimitiveStaticType_name[103:116],
-	30: _PrimitiveStaticType_name[116:126],
-	31: _PrimitiveStaticType_name[126:142],
-	36: _PrimitiveStaticType_name[142:145],
-	37: _PrimitiveStaticType_name[145:149],
-	38: _PrimitiveStaticType_name[149:154],
-	39: _PrimitiveStaticType_name[154:159],
-	40: _PrimitiveStaticType_name[159:164],
-	41: _PrimitiveStaticType_name[164:170],
-	42: _PrimitiveStaticType_name[170:176],
-	44: _PrimitiveStaticType_name[176:180],
-	45: _PrimitiveStaticType_name[180:185],
-	46: _PrimitiveStaticType_name[185:191],
-	47: _PrimitiveStaticType_name[191:197],
-	48: _PrimitiveStaticType_name[197:203],
-	49: _PrimitiveStaticType_name[203:210],
-	50: _PrimitiveStaticType_name[210:217],
-	53: _PrimitiveStaticType_name[217:222],
-	54: _PrimitiveStaticType_name[222:228],
-	55: _PrimitiveStaticType_name[228:234],
-	56: _PrimitiveStaticType_name[234:240],
-	64: _PrimitiveStaticType_name[240:245],
-	72: _PrimitiveStaticType_name[245:251],
-	76: _PrimitiveStaticType_name[251:255],
-	77: _PrimitiveStaticType_name[255:265],
-	78: _PrimitiveStaticType_name[265:276],
-	79: _PrimitiveStaticType_name[276:290],
-	80: _PrimitiveStaticType_name[290:300],
-	81: _PrimitiveStaticType_name[300:311],
-	90: _PrimitiveStaticType_name[311:322],
-	91: _PrimitiveStaticType_name[322:335],
-	92: _PrimitiveStaticType_name[335:351],
-	93: _PrimitiveStaticType_name[351:371],
-	94: _PrimitiveStaticType_name[371:393],
-	95: _PrimitiveStaticType_name[393:408],
-	96: _PrimitiveStaticType_name[408:425],
-	97: _PrimitiveStaticType_name[425:435],
+	0:   _PrimitiveStaticType_name[0:7],
+	1:   _PrimitiveStaticType_name[7:11],
+	2:   _PrimitiveStaticType_name[11:14],
+	3:   _PrimitiveStaticType_name[14:19],
+	4:   _PrimitiveStaticType_name[19:28],
+	5:   _PrimitiveStaticType_name[28:39],
+	6:   _PrimitiveStaticType_name[39:43],
+	7:   _PrimitiveStaticType_name[43:50],
+	8:   _PrimitiveStaticType_name[50:56],
+	9:   _PrimitiveStaticType_name[56:65],
+	10:  _PrimitiveStaticType_name[65:73],
+	11:  _PrimitiveStaticType_name[73:78],
+	12:  _PrimitiveStaticType_name[78:83],
+	13:  _PrimitiveStaticType_name[83:94],
+	18:  _PrimitiveStaticType_name[94:100],
+	19:  _PrimitiveStaticType_name[100:112],
+	24:  _PrimitiveStaticType_name[112:119],
+	25:  _PrimitiveStaticType_name[119:132],
+	30:  _PrimitiveStaticType_name[132:142],
+	31:  _PrimitiveStaticType_name[142:158],
+	36:  _PrimitiveStaticType_name[158:161],
+	37:  _PrimitiveStaticType_name[161:165],
+	38:  _PrimitiveStaticType_name[165:170],
+	39:  _PrimitiveStaticType_name[170:175],
+	40:  _PrimitiveStaticType_name[175:180],
+	41:  _PrimitiveStaticType_name[180:186],
+	42:  _PrimitiveStaticType_name[186:192],
+	44:  _PrimitiveStaticType_name[192:196],
+	45:  _PrimitiveStaticType_name[196:201],
+	46:  _PrimitiveStaticType_name[201:207],
+	47:  _PrimitiveStaticType_name[207:213],
+	48:  _PrimitiveStaticType_name[213:219],
+	49:  _PrimitiveStaticType_name[219:226],
+	50:  _PrimitiveStaticType_name[226:233],
+	53:  _PrimitiveStaticType_name[233:238],
+	54:  _PrimitiveStaticType_name[238:244],
+	55:  _PrimitiveStaticType_name[244:250],
+	56:  _PrimitiveStaticType_name[250:256],
+	64:  _PrimitiveStaticType_name[256:261],
+	72:  _PrimitiveStaticType_name[261:267],
+	76:  _PrimitiveStaticType_name[267:271],
+	77:  _PrimitiveStaticType_name[271:281],
+	78:  _PrimitiveStaticType_name[281:292],
+	79:  _PrimitiveStaticType_name[292:306],
+	80:  _PrimitiveStaticType_name[306:316],
+	81:  _PrimitiveStaticType_name[316:327],
+	90:  _PrimitiveStaticType_name[327:338],
+	91:  _PrimitiveStaticType_name[338:351],
+	92:  _PrimitiveStaticType_name[351:367],
+	93:  _PrimitiveStaticType_name[367:387],
+	94:  _PrimitiveStaticType_name[387:409],
+	95:  _PrimitiveStaticType_name[409:424],
+	96:  _PrimitiveStaticType_name[424:441],
+	97:  _PrimitiveStaticType_name[441:451],
+	98:  _PrimitiveStaticType_name[451:474],
+	99:  _PrimitiveStaticType_name[474:504],
+	100: _PrimitiveStaticType_name[504:529],
+	101: _PrimitiveStaticType_name[529:556],
 }
 
 func (i PrimitiveStaticType) String() string {
@@ -34,3 +34,24 @@ func ProgramFromChecker(checker *sema.Checker) *Program {
 		Elaboration: checker.Elaboration,
 	}
 }
+
+// CompositeTypes returns all composite types declared in the program,
+// including nested composite types, in declaration order.
+//
+func (p *Program) CompositeTypes() []*sema.CompositeType {
+	return p.Elaboration.DeclaredCompositeTypes()
+}
+
+// InterfaceTypes returns all interface types declared in the program,
+// including nested interface types, in declaration order.
+//
+func (p *Program) InterfaceTypes() []*sema.InterfaceType {
+	return p.Elaboration.DeclaredInterfaceTypes()
+}
+
+// GlobalFunctions returns all functions declared at the top-level of the program,
+// in declaration order.
+//
+func (p *Program) GlobalFunctions() []sema.GlobalFunction {
+	return p.Elaboration.DeclaredGlobalFunctions()
+}
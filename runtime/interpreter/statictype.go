@@ -280,7 +280,10 @@ outer:
 
 type ReferenceStaticType struct {
 	Authorized bool
-	Type       StaticType
+	// Authorization is the entitlement set the reference is authorized for,
+	// nil if the reference is not entitled
+	Authorization *EntitlementSetAuthorization
+	Type          StaticType
 }
 
 var _ StaticType = ReferenceStaticType{}
@@ -291,6 +294,8 @@ func (t ReferenceStaticType) String() string {
 	auth := ""
 	if t.Authorized {
 		auth = "auth "
+	} else if t.Authorization != nil {
+		auth = fmt.Sprintf("auth(%s) ", t.Authorization)
 	}
 
 	return fmt.Sprintf("%s&%s", auth, t.Type)
@@ -303,9 +308,71 @@ func (t ReferenceStaticType) Equal(other StaticType) bool {
 	}
 
 	return t.Authorized == otherReferenceType.Authorized &&
+		t.Authorization.Equal(otherReferenceType.Authorization) &&
 		t.Type.Equal(otherReferenceType.Type)
 }
 
+// EntitlementSetAuthorization
+
+type EntitlementSetAuthorization struct {
+	Entitlements []common.TypeID
+	SetKind      sema.EntitlementSetKind
+}
+
+func ConvertSemaEntitlementSetAccessToStaticAuthorization(
+	access *sema.EntitlementSetAccess,
+) *EntitlementSetAuthorization {
+	if access == nil {
+		return nil
+	}
+
+	entitlements := make([]common.TypeID, len(access.Entitlements))
+	for i, entitlement := range access.Entitlements {
+		entitlements[i] = entitlement.ID()
+	}
+
+	return &EntitlementSetAuthorization{
+		Entitlements: entitlements,
+		SetKind:      access.SetKind,
+	}
+}
+
+func (a *EntitlementSetAuthorization) String() string {
+	separator := ", "
+	if a.SetKind == sema.EntitlementSetKindDisjunction {
+		separator = " | "
+	}
+
+	var builder strings.Builder
+	for i, entitlement := range a.Entitlements {
+		if i > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(string(entitlement))
+	}
+	return builder.String()
+}
+
+func (a *EntitlementSetAuthorization) Equal(other *EntitlementSetAuthorization) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+
+	if a.SetKind != other.SetKind ||
+		len(a.Entitlements) != len(other.Entitlements) {
+
+		return false
+	}
+
+	for i, entitlement := range a.Entitlements {
+		if entitlement != other.Entitlements[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // CapabilityStaticType
 
 type CapabilityStaticType struct {
@@ -339,6 +406,39 @@ func (t CapabilityStaticType) Equal(other StaticType) bool {
 	return t.BorrowType.Equal(otherCapabilityType.BorrowType)
 }
 
+// InclusiveRangeStaticType
+
+type InclusiveRangeStaticType struct {
+	ElementType StaticType
+}
+
+var _ StaticType = InclusiveRangeStaticType{}
+
+func (InclusiveRangeStaticType) isStaticType() {}
+
+func (t InclusiveRangeStaticType) String() string {
+	if t.ElementType != nil {
+		return fmt.Sprintf("InclusiveRange<%s>", t.ElementType)
+	}
+	return "InclusiveRange"
+}
+
+func (t InclusiveRangeStaticType) Equal(other StaticType) bool {
+	otherRangeType, ok := other.(InclusiveRangeStaticType)
+	if !ok {
+		return false
+	}
+
+	// The element types must either be both nil,
+	// or they must be equal
+
+	if t.ElementType == nil {
+		return otherRangeType.ElementType == nil
+	}
+
+	return t.ElementType.Equal(otherRangeType.ElementType)
+}
+
 // Conversion
 
 func ConvertSemaToStaticType(t sema.Type) StaticType {
@@ -386,6 +486,13 @@ func ConvertSemaToStaticType(t sema.Type) StaticType {
 		}
 		return result
 
+	case *sema.InclusiveRangeType:
+		result := InclusiveRangeStaticType{}
+		if t.MemberType != nil {
+			result.ElementType = ConvertSemaToStaticType(t.MemberType)
+		}
+		return result
+
 	case *sema.FunctionType:
 		return FunctionStaticType{
 			Type: t,
@@ -426,8 +533,9 @@ func ConvertSemaDictionaryTypeToStaticDictionaryType(t *sema.DictionaryType) Dic
 
 func ConvertSemaReferenceTyoeToStaticReferenceType(t *sema.ReferenceType) ReferenceStaticType {
 	return ReferenceStaticType{
-		Authorized: t.Authorized,
-		Type:       ConvertSemaToStaticType(t.Type),
+		Authorized:    t.Authorized,
+		Authorization: ConvertSemaEntitlementSetAccessToStaticAuthorization(t.Authorization),
+		Type:          ConvertSemaToStaticType(t.Type),
 	}
 }
 
@@ -442,6 +550,7 @@ func ConvertStaticToSemaType(
 	typ StaticType,
 	getInterface func(location common.Location, qualifiedIdentifier string) (*sema.InterfaceType, error),
 	getComposite func(location common.Location, qualifiedIdentifier string, typeID common.TypeID) (*sema.CompositeType, error),
+	getEntitlement func(typeID common.TypeID) (*sema.EntitlementType, error),
 ) (_ sema.Type, err error) {
 	switch t := typ.(type) {
 	case CompositeStaticType:
@@ -451,31 +560,31 @@ func ConvertStaticToSemaType(
 		return getInterface(t.Location, t.QualifiedIdentifier)
 
 	case VariableSizedStaticType:
-		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite)
+		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite, getEntitlement)
 		return &sema.VariableSizedType{
 			Type: ty,
 		}, err
 
 	case ConstantSizedStaticType:
-		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite)
+		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite, getEntitlement)
 		return &sema.ConstantSizedType{
 			Type: ty,
 			Size: t.Size,
 		}, err
 
 	case DictionaryStaticType:
-		keyType, err := ConvertStaticToSemaType(t.KeyType, getInterface, getComposite)
+		keyType, err := ConvertStaticToSemaType(t.KeyType, getInterface, getComposite, getEntitlement)
 		if err != nil {
 			return nil, err
 		}
-		valueType, err := ConvertStaticToSemaType(t.ValueType, getInterface, getComposite)
+		valueType, err := ConvertStaticToSemaType(t.ValueType, getInterface, getComposite, getEntitlement)
 		return &sema.DictionaryType{
 			KeyType:   keyType,
 			ValueType: valueType,
 		}, err
 
 	case OptionalStaticType:
-		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite)
+		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite, getEntitlement)
 		return &sema.OptionalType{
 			Type: ty,
 		}, err
@@ -490,23 +599,36 @@ func ConvertStaticToSemaType(
 			}
 		}
 
-		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite)
+		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite, getEntitlement)
 		return &sema.RestrictedType{
 			Type:         ty,
 			Restrictions: restrictions,
 		}, err
 
 	case ReferenceStaticType:
-		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite)
+		var authorization *sema.EntitlementSetAccess
+		if t.Authorization != nil {
+			entitlements := make([]*sema.EntitlementType, len(t.Authorization.Entitlements))
+			for i, typeID := range t.Authorization.Entitlements {
+				entitlements[i], err = getEntitlement(typeID)
+				if err != nil {
+					return nil, err
+				}
+			}
+			authorization = sema.NewEntitlementSetAccess(entitlements, t.Authorization.SetKind)
+		}
+
+		ty, err := ConvertStaticToSemaType(t.Type, getInterface, getComposite, getEntitlement)
 		return &sema.ReferenceType{
-			Authorized: t.Authorized,
-			Type:       ty,
+			Authorized:    t.Authorized,
+			Authorization: authorization,
+			Type:          ty,
 		}, err
 
 	case CapabilityStaticType:
 		var borrowType sema.Type
 		if t.BorrowType != nil {
-			borrowType, err = ConvertStaticToSemaType(t.BorrowType, getInterface, getComposite)
+			borrowType, err = ConvertStaticToSemaType(t.BorrowType, getInterface, getComposite, getEntitlement)
 			if err != nil {
 				return nil, err
 			}
@@ -516,6 +638,19 @@ func ConvertStaticToSemaType(
 			BorrowType: borrowType,
 		}, nil
 
+	case InclusiveRangeStaticType:
+		var memberType sema.Type
+		if t.ElementType != nil {
+			memberType, err = ConvertStaticToSemaType(t.ElementType, getInterface, getComposite, getEntitlement)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return &sema.InclusiveRangeType{
+			MemberType: memberType,
+		}, nil
+
 	case FunctionStaticType:
 		return t.Type, nil
 
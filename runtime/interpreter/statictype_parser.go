@@ -0,0 +1,444 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+var primitiveStaticTypesByTypeID = func() map[string]PrimitiveStaticType {
+	result := map[string]PrimitiveStaticType{}
+	for ty := PrimitiveStaticTypeUnknown + 1; ty < PrimitiveStaticType_Count; ty++ {
+		// Skip the unused placeholder values
+		if strings.HasPrefix(ty.String(), "PrimitiveStaticType(") {
+			continue
+		}
+		typeID := ty.SemaType().ID()
+		result[string(typeID)] = ty
+	}
+	return result
+}()
+
+// ParseStaticType parses the given type ID, e.g. as produced by `sema.Type.ID`
+// or `StaticType.String`, into a static type.
+//
+// Nested types are supported, e.g. `{String: [A.0000000000000001.Foo.Bar?]}`.
+//
+// Type IDs do not distinguish between composite types and interface types.
+// Nominal types are parsed as composite types, except for the restrictions
+// of restricted types, which are parsed as interface types.
+//
+// Function types are not supported.
+//
+func ParseStaticType(typeID string) (StaticType, error) {
+	parser := &staticTypeParser{
+		input: typeID,
+	}
+
+	staticType, err := parser.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipSpace()
+	if !parser.atEnd() {
+		return nil, parser.errorf("unexpected trailing input")
+	}
+
+	return staticType, nil
+}
+
+type staticTypeParser struct {
+	input  string
+	offset int
+}
+
+func (p *staticTypeParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"cannot parse static type %q at offset %d: %s",
+		p.input,
+		p.offset,
+		fmt.Sprintf(format, args...),
+	)
+}
+
+func (p *staticTypeParser) atEnd() bool {
+	return p.offset >= len(p.input)
+}
+
+func (p *staticTypeParser) current() byte {
+	return p.input[p.offset]
+}
+
+func (p *staticTypeParser) skipSpace() {
+	for !p.atEnd() && p.current() == ' ' {
+		p.offset++
+	}
+}
+
+// accept skips leading spaces and consumes the given character,
+// if it is the next character.
+//
+func (p *staticTypeParser) accept(c byte) bool {
+	p.skipSpace()
+	if p.atEnd() || p.current() != c {
+		return false
+	}
+	p.offset++
+	return true
+}
+
+func (p *staticTypeParser) expect(c byte) error {
+	if !p.accept(c) {
+		return p.errorf("expected %q", c)
+	}
+	return nil
+}
+
+// parseType parses a type, including any optional type suffixes.
+//
+func (p *staticTypeParser) parseType() (StaticType, error) {
+	staticType, err := p.parseNonOptionalType()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept('?') {
+		staticType = OptionalStaticType{
+			Type: staticType,
+		}
+	}
+
+	return staticType, nil
+}
+
+// parseNonOptionalType parses a type without optional type suffixes.
+//
+// NOTE: the type ID `&T?` is parsed as an optional reference type,
+// not as a reference to an optional type.
+//
+func (p *staticTypeParser) parseNonOptionalType() (StaticType, error) {
+	p.skipSpace()
+	if p.atEnd() {
+		return nil, p.errorf("expected type")
+	}
+
+	switch p.current() {
+	case '&':
+		p.offset++
+		return p.parseReferenceType(false)
+
+	case '[':
+		p.offset++
+		return p.parseArrayType()
+
+	case '{':
+		p.offset++
+		return p.parseDictionaryType()
+
+	case '(':
+		return nil, p.errorf("function types are not supported")
+	}
+
+	identifier := p.parseNominalIdentifier()
+	if identifier == "" {
+		return nil, p.errorf("unexpected character %q", p.current())
+	}
+
+	switch identifier {
+	case "auth":
+		if p.accept('(') {
+			authorization, err := p.parseEntitlementSetAuthorization()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect('&'); err != nil {
+				return nil, err
+			}
+			return p.parseEntitledReferenceType(authorization)
+		}
+		if err := p.expect('&'); err != nil {
+			return nil, err
+		}
+		return p.parseReferenceType(true)
+
+	case "Capability":
+		return p.parseCapabilityType()
+
+	case "InclusiveRange":
+		return p.parseInclusiveRangeType()
+	}
+
+	staticType := p.nominalType(identifier)
+
+	if p.accept('{') {
+		return p.parseRestrictedType(staticType)
+	}
+
+	return staticType, nil
+}
+
+func (p *staticTypeParser) parseReferenceType(authorized bool) (StaticType, error) {
+	referencedType, err := p.parseNonOptionalType()
+	if err != nil {
+		return nil, err
+	}
+
+	return ReferenceStaticType{
+		Authorized: authorized,
+		Type:       referencedType,
+	}, nil
+}
+
+func (p *staticTypeParser) parseEntitledReferenceType(authorization *EntitlementSetAuthorization) (StaticType, error) {
+	referencedType, err := p.parseNonOptionalType()
+	if err != nil {
+		return nil, err
+	}
+
+	return ReferenceStaticType{
+		Authorization: authorization,
+		Type:          referencedType,
+	}, nil
+}
+
+// parseEntitlementSetAuthorization parses the entitlements of an entitled reference type,
+// e.g. `A.0000000000000001.E, A.0000000000000001.F)`.
+// The opening parenthesis must already have been consumed.
+//
+func (p *staticTypeParser) parseEntitlementSetAuthorization() (*EntitlementSetAuthorization, error) {
+	authorization := &EntitlementSetAuthorization{
+		SetKind: sema.EntitlementSetKindConjunction,
+	}
+
+	for {
+		p.skipSpace()
+		identifier := p.parseNominalIdentifier()
+		if identifier == "" {
+			return nil, p.errorf("expected entitlement")
+		}
+		authorization.Entitlements = append(
+			authorization.Entitlements,
+			common.TypeID(identifier),
+		)
+
+		if p.accept(')') {
+			return authorization, nil
+		}
+
+		setKind := sema.EntitlementSetKindConjunction
+		if p.accept('|') {
+			setKind = sema.EntitlementSetKindDisjunction
+		} else if err := p.expect(','); err != nil {
+			return nil, err
+		}
+
+		if len(authorization.Entitlements) > 1 && setKind != authorization.SetKind {
+			return nil, p.errorf("cannot mix conjunctive and disjunctive entitlements")
+		}
+		authorization.SetKind = setKind
+	}
+}
+
+func (p *staticTypeParser) parseArrayType() (StaticType, error) {
+	elementType, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.accept(';') {
+		p.skipSpace()
+		start := p.offset
+		for !p.atEnd() && p.current() >= '0' && p.current() <= '9' {
+			p.offset++
+		}
+
+		size, err := strconv.ParseInt(p.input[start:p.offset], 10, 64)
+		if err != nil {
+			p.offset = start
+			return nil, p.errorf("invalid constant-sized array size")
+		}
+
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+
+		return ConstantSizedStaticType{
+			Type: elementType,
+			Size: size,
+		}, nil
+	}
+
+	if err := p.expect(']'); err != nil {
+		return nil, err
+	}
+
+	return VariableSizedStaticType{
+		Type: elementType,
+	}, nil
+}
+
+func (p *staticTypeParser) parseDictionaryType() (StaticType, error) {
+	keyType, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(':'); err != nil {
+		return nil, err
+	}
+
+	valueType, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect('}'); err != nil {
+		return nil, err
+	}
+
+	return DictionaryStaticType{
+		KeyType:   keyType,
+		ValueType: valueType,
+	}, nil
+}
+
+func (p *staticTypeParser) parseCapabilityType() (StaticType, error) {
+	if !p.accept('<') {
+		return CapabilityStaticType{}, nil
+	}
+
+	borrowType, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect('>'); err != nil {
+		return nil, err
+	}
+
+	return CapabilityStaticType{
+		BorrowType: borrowType,
+	}, nil
+}
+
+func (p *staticTypeParser) parseInclusiveRangeType() (StaticType, error) {
+	if !p.accept('<') {
+		return InclusiveRangeStaticType{}, nil
+	}
+
+	elementType, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect('>'); err != nil {
+		return nil, err
+	}
+
+	return InclusiveRangeStaticType{
+		ElementType: elementType,
+	}, nil
+}
+
+func (p *staticTypeParser) parseRestrictedType(restrictedType StaticType) (StaticType, error) {
+	var restrictions []InterfaceStaticType
+
+	if !p.accept('}') {
+		for {
+			p.skipSpace()
+			identifier := p.parseNominalIdentifier()
+			if identifier == "" {
+				return nil, p.errorf("expected restriction")
+			}
+
+			restriction, err := p.interfaceType(identifier)
+			if err != nil {
+				return nil, err
+			}
+
+			restrictions = append(restrictions, restriction)
+
+			if p.accept('}') {
+				break
+			}
+
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &RestrictedStaticType{
+		Type:         restrictedType,
+		Restrictions: restrictions,
+	}, nil
+}
+
+// parseNominalIdentifier parses the type ID of a nominal type,
+// e.g. `Int`, `AuthAccount.Contracts`, or `A.0000000000000001.Foo.Bar`.
+//
+func (p *staticTypeParser) parseNominalIdentifier() string {
+	start := p.offset
+	for !p.atEnd() {
+		c := rune(p.current())
+		if c != '.' && c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			break
+		}
+		p.offset++
+	}
+	return p.input[start:p.offset]
+}
+
+func (p *staticTypeParser) nominalType(typeID string) StaticType {
+	if primitiveStaticType, ok := primitiveStaticTypesByTypeID[typeID]; ok {
+		return primitiveStaticType
+	}
+
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+	if err != nil {
+		// Decoding failed, treat the type as a native composite type
+		location = nil
+		qualifiedIdentifier = typeID
+	}
+
+	return CompositeStaticType{
+		Location:            location,
+		QualifiedIdentifier: qualifiedIdentifier,
+		TypeID:              common.TypeID(typeID),
+	}
+}
+
+func (p *staticTypeParser) interfaceType(typeID string) (InterfaceStaticType, error) {
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+	if err != nil {
+		return InterfaceStaticType{}, p.errorf("invalid restriction %q: %s", typeID, err)
+	}
+
+	return InterfaceStaticType{
+		Location:            location,
+		QualifiedIdentifier: qualifiedIdentifier,
+	}, nil
+}
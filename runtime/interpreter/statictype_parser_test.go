@@ -0,0 +1,207 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	. "github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestParseStaticType(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("primitive types", func(t *testing.T) {
+
+		t.Parallel()
+
+		for ty := PrimitiveStaticTypeUnknown + 1; ty < PrimitiveStaticType_Count; ty++ {
+			if strings.HasPrefix(ty.String(), "PrimitiveStaticType(") {
+				continue
+			}
+
+			// The type ID `Capability` is parsed as a capability static type
+			// without a borrow type, see below
+			if ty == PrimitiveStaticTypeCapability {
+				continue
+			}
+
+			typeID := string(ty.SemaType().ID())
+
+			t.Run(typeID, func(t *testing.T) {
+
+				parsed, err := ParseStaticType(typeID)
+				require.NoError(t, err)
+
+				assert.Equal(t, ty, parsed)
+			})
+		}
+	})
+
+	fooType := CompositeStaticType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Foo",
+		TypeID:              "S.test.Foo",
+	}
+
+	barType := CompositeStaticType{
+		Location:            common.AddressLocation{Address: common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}, Name: "Bar"},
+		QualifiedIdentifier: "Bar.Baz",
+		TypeID:              "A.0000000000000001.Bar.Baz",
+	}
+
+	interfaceType := InterfaceStaticType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "I",
+	}
+
+	tests := map[string]StaticType{
+		"S.test.Foo":                 fooType,
+		"A.0000000000000001.Bar.Baz": barType,
+		"AuthAccount.Contracts":      PrimitiveStaticTypeAuthAccountContracts,
+		"Int?":                       OptionalStaticType{Type: PrimitiveStaticTypeInt},
+		"Int??": OptionalStaticType{
+			Type: OptionalStaticType{Type: PrimitiveStaticTypeInt},
+		},
+		"[S.test.Foo]":   VariableSizedStaticType{Type: fooType},
+		"[Int8;4]":       ConstantSizedStaticType{Type: PrimitiveStaticTypeInt8, Size: 4},
+		"[Int8; 4]":      ConstantSizedStaticType{Type: PrimitiveStaticTypeInt8, Size: 4},
+		"{String:UInt8}": DictionaryStaticType{KeyType: PrimitiveStaticTypeString, ValueType: PrimitiveStaticTypeUInt8},
+		"{String: [A.0000000000000001.Bar.Baz?]}": DictionaryStaticType{
+			KeyType: PrimitiveStaticTypeString,
+			ValueType: VariableSizedStaticType{
+				Type: OptionalStaticType{Type: barType},
+			},
+		},
+		"&S.test.Foo": ReferenceStaticType{Type: fooType},
+		"auth &S.test.Foo?": OptionalStaticType{
+			Type: ReferenceStaticType{Authorized: true, Type: fooType},
+		},
+		"auth(S.test.E, S.test.F) &S.test.Foo": ReferenceStaticType{
+			Authorization: &EntitlementSetAuthorization{
+				Entitlements: []common.TypeID{"S.test.E", "S.test.F"},
+				SetKind:      sema.EntitlementSetKindConjunction,
+			},
+			Type: fooType,
+		},
+		"auth(S.test.E | S.test.F) &S.test.Foo": ReferenceStaticType{
+			Authorization: &EntitlementSetAuthorization{
+				Entitlements: []common.TypeID{"S.test.E", "S.test.F"},
+				SetKind:      sema.EntitlementSetKindDisjunction,
+			},
+			Type: fooType,
+		},
+		"Capability": CapabilityStaticType{},
+		"Capability<&AnyResource{S.test.I}>": CapabilityStaticType{
+			BorrowType: ReferenceStaticType{
+				Type: &RestrictedStaticType{
+					Type:         PrimitiveStaticTypeAnyResource,
+					Restrictions: []InterfaceStaticType{interfaceType},
+				},
+			},
+		},
+		"InclusiveRange": InclusiveRangeStaticType{},
+		"InclusiveRange<UInt8>": InclusiveRangeStaticType{
+			ElementType: PrimitiveStaticTypeUInt8,
+		},
+		"S.test.Foo{S.test.I,S.test.I}": &RestrictedStaticType{
+			Type:         fooType,
+			Restrictions: []InterfaceStaticType{interfaceType, interfaceType},
+		},
+	}
+
+	for typeID, expected := range tests {
+		typeID := typeID
+		expected := expected
+
+		t.Run(typeID, func(t *testing.T) {
+
+			t.Parallel()
+
+			parsed, err := ParseStaticType(typeID)
+			require.NoError(t, err)
+			assert.Equal(t, expected, parsed)
+		})
+	}
+
+	t.Run("round-trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		staticType := DictionaryStaticType{
+			KeyType: PrimitiveStaticTypeAddress,
+			ValueType: CapabilityStaticType{
+				BorrowType: ReferenceStaticType{
+					Authorized: true,
+					Type: &RestrictedStaticType{
+						Type:         fooType,
+						Restrictions: []InterfaceStaticType{interfaceType},
+					},
+				},
+			},
+		}
+
+		parsed, err := ParseStaticType(staticType.String())
+		require.NoError(t, err)
+		assert.Equal(t, staticType, parsed)
+
+		semaType := &sema.DictionaryType{
+			KeyType: &sema.AddressType{},
+			ValueType: &sema.ConstantSizedType{
+				Type: sema.UFix64Type,
+				Size: 2,
+			},
+		}
+
+		parsed, err = ParseStaticType(string(semaType.ID()))
+		require.NoError(t, err)
+		assert.Equal(t, ConvertSemaToStaticType(semaType), parsed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, typeID := range []string{
+			"",
+			"[Int",
+			"[Int;]",
+			"{Int}",
+			"{Int:String",
+			"Capability<Int",
+			"auth Int",
+			"auth() &Int",
+			"auth(S.test.E, S.test.F | S.test.G) &Int",
+			"Int)",
+			"((Int):Void)",
+			"S.test.Foo{}}",
+		} {
+			_, err := ParseStaticType(typeID)
+			assert.Error(t, err, typeID)
+		}
+	})
+}
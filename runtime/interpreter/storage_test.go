@@ -19,6 +19,7 @@
 package interpreter_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/onflow/atree"
@@ -215,6 +216,108 @@ func TestArrayStorage(t *testing.T) {
 
 		require.False(t, bool(storedArray.Contains(nil, nil, element)))
 	})
+
+	t.Run("constant-sized integer array", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := NewInMemoryStorage()
+
+		inter, err := NewInterpreter(
+			nil,
+			common.AddressLocation{},
+			WithStorage(storage),
+		)
+		require.NoError(t, err)
+
+		elements := []Value{
+			Int64Value(math.MinInt64),
+			Int64Value(-1),
+			Int64Value(0),
+			Int64Value(math.MaxInt64),
+		}
+
+		value := NewArrayValue(
+			inter,
+			ConstantSizedStaticType{
+				Type: PrimitiveStaticTypeInt64,
+				Size: int64(len(elements)),
+			},
+			common.Address{},
+			elements...,
+		)
+
+		variableSizedValue := NewArrayValue(
+			inter,
+			VariableSizedStaticType{
+				Type: PrimitiveStaticTypeInt64,
+			},
+			common.Address{},
+			elements...,
+		)
+
+		encodedSlabs, err := storage.BasicSlabStorage.Encode()
+		require.NoError(t, err)
+
+		// The elements of the constant-sized array are stored without type tags
+
+		assert.Less(t,
+			len(encodedSlabs[value.StorageID()]),
+			len(encodedSlabs[variableSizedValue.StorageID()]),
+		)
+
+		// Decode the slabs into a new storage
+
+		decodedStorage := NewInMemoryStorage()
+
+		for storageID, data := range encodedSlabs { //nolint:maprangecheck
+			slab, err := atree.DecodeSlab(
+				storageID,
+				data,
+				CBORDecMode,
+				DecodeStorable,
+				DecodeTypeInfo,
+			)
+			require.NoError(t, err)
+
+			err = decodedStorage.BasicSlabStorage.Store(storageID, slab)
+			require.NoError(t, err)
+		}
+
+		decodedInter, err := NewInterpreter(
+			nil,
+			common.AddressLocation{},
+			WithStorage(decodedStorage),
+		)
+		require.NoError(t, err)
+
+		retrievedStorable, ok, err := decodedStorage.BasicSlabStorage.Retrieve(value.StorageID())
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		storedValue := StoredValue(retrievedStorable, decodedStorage)
+
+		require.IsType(t, storedValue, &ArrayValue{})
+		storedArray := storedValue.(*ArrayValue)
+
+		require.Equal(t, len(elements), storedArray.Count())
+
+		for i, element := range elements {
+			actual := storedArray.Get(decodedInter, ReturnEmptyLocationRange, i)
+			RequireValuesEqual(t, decodedInter, element, actual)
+		}
+
+		// Setting an element keeps the compact encoding
+
+		storedArray.Set(decodedInter, ReturnEmptyLocationRange, 1, Int64Value(-42))
+
+		RequireValuesEqual(
+			t,
+			decodedInter,
+			Int64Value(-42),
+			storedArray.Get(decodedInter, ReturnEmptyLocationRange, 1),
+		)
+	})
 }
 
 func TestDictionaryStorage(t *testing.T) {
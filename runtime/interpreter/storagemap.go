@@ -96,6 +96,31 @@ func (s StorageMap) ReadValue(key string) Value {
 	return StoredValue(storable, s.orderedMap.Storage)
 }
 
+// ValueStorageID returns the storage ID of the slab which stores the value for the given key,
+// without loading the value. It returns false if the key does not exist,
+// or if the value is stored inline in the storage map.
+//
+func (s StorageMap) ValueStorageID(key string) (atree.StorageID, bool) {
+	storable, err := s.orderedMap.Get(
+		stringAtreeComparator,
+		stringAtreeHashInput,
+		stringAtreeValue(key),
+	)
+	if err != nil {
+		if _, ok := err.(*atree.KeyNotFoundError); ok {
+			return atree.StorageIDUndefined, false
+		}
+		panic(ExternalError{err})
+	}
+
+	storageIDStorable, ok := storable.(atree.StorageIDStorable)
+	if !ok {
+		return atree.StorageIDUndefined, false
+	}
+
+	return atree.StorageID(storageIDStorable), true
+}
+
 // WriteValue sets or removes a value in the storage map.
 // If the given value is a SomeValue, the key is updated.
 // If the given value is NilValue, the key is removed.
@@ -122,6 +147,7 @@ func (s StorageMap) setValue(interpreter *Interpreter, key string, value Value)
 		panic(ExternalError{err})
 	}
 	interpreter.maybeValidateAtreeValue(s.orderedMap)
+	interpreter.maybeCheckValueInvariants(value, s.orderedMap.Address(), ReturnEmptyLocationRange)
 
 	if existingStorable != nil {
 		existingValue := StoredValue(existingStorable, interpreter.Storage)
@@ -133,6 +159,28 @@ func (s StorageMap) setValue(interpreter *Interpreter, key string, value Value)
 // removeValue removes a value in the storage map, if it exists.
 //
 func (s StorageMap) removeValue(interpreter *Interpreter, key string) {
+	existingValueStorable := s.removeKey(interpreter, key)
+
+	// Value
+
+	if existingValueStorable != nil {
+		existingValue := StoredValue(existingValueStorable, interpreter.Storage)
+		existingValue.DeepRemove(interpreter)
+		interpreter.RemoveReferencedSlab(existingValueStorable)
+	}
+}
+
+// RemoveKey removes the key from the storage map, if it exists,
+// but keeps the value which the key was mapped to,
+// e.g. because the value was moved out of the storage map without being copied.
+//
+// Returns the storable of the value, if any.
+//
+func (s StorageMap) RemoveKey(interpreter *Interpreter, key string) atree.Storable {
+	return s.removeKey(interpreter, key)
+}
+
+func (s StorageMap) removeKey(interpreter *Interpreter, key string) atree.Storable {
 	existingKeyStorable, existingValueStorable, err := s.orderedMap.Remove(
 		stringAtreeComparator,
 		stringAtreeHashInput,
@@ -140,7 +188,7 @@ func (s StorageMap) removeValue(interpreter *Interpreter, key string) {
 	)
 	if err != nil {
 		if _, ok := err.(*atree.KeyNotFoundError); ok {
-			return
+			return nil
 		}
 		panic(ExternalError{err})
 	}
@@ -152,13 +200,7 @@ func (s StorageMap) removeValue(interpreter *Interpreter, key string) {
 	// and not a Value, so no need to deep remove
 	interpreter.RemoveReferencedSlab(existingKeyStorable)
 
-	// Value
-
-	if existingValueStorable != nil {
-		existingValue := StoredValue(existingValueStorable, interpreter.Storage)
-		existingValue.DeepRemove(interpreter)
-		interpreter.RemoveReferencedSlab(existingValueStorable)
-	}
+	return existingValueStorable
 }
 
 // Iterator returns an iterator (StorageMapIterator),
@@ -0,0 +1,170 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// TranscriptEntryKind is the kind of an entry of a transcript
+//
+type TranscriptEntryKind uint
+
+const (
+	TranscriptEntryKindUnknown TranscriptEntryKind = iota
+	// TranscriptEntryKindFunctionCall is the invocation of a function
+	TranscriptEntryKindFunctionCall
+	// TranscriptEntryKindStorageRead is the read of a value from a storage path
+	TranscriptEntryKindStorageRead
+	// TranscriptEntryKindStorageWrite is the write of a value to a storage path
+	TranscriptEntryKindStorageWrite
+	// TranscriptEntryKindStorageRemove is the removal of a value from a storage path
+	TranscriptEntryKindStorageRemove
+	// TranscriptEntryKindEvent is the emission of an event
+	TranscriptEntryKindEvent
+)
+
+// TranscriptEntry is an entry of a transcript
+//
+type TranscriptEntry struct {
+	Kind TranscriptEntryKind
+	// Depth is the call depth at which the entry was recorded
+	Depth int
+	// Location is the location of the program which was executed
+	Location common.Location
+	// Line is the line of the program which was executed,
+	// or 0 if the line is unknown, e.g. for storage accesses
+	Line int
+	// Function is the invoked expression of a function call, e.g. `vault.withdraw`
+	Function string
+	// Address is the address of the account of a storage access
+	Address common.Address
+	// Path is the path of a storage access
+	Path PathValue
+	// EventType is the type of the emitted event
+	EventType common.TypeID
+	// Event is the string representation of the emitted event
+	Event string
+}
+
+func (e TranscriptEntry) String() string {
+	switch e.Kind {
+	case TranscriptEntryKindFunctionCall:
+		var locationID common.LocationID
+		if e.Location != nil {
+			locationID = e.Location.ID()
+		}
+		return fmt.Sprintf("call %s (%s:%d)", e.Function, locationID, e.Line)
+
+	case TranscriptEntryKindStorageRead:
+		return fmt.Sprintf("read %s %s", e.Address.ShortHexWithPrefix(), e.Path)
+
+	case TranscriptEntryKindStorageWrite:
+		return fmt.Sprintf("write %s %s", e.Address.ShortHexWithPrefix(), e.Path)
+
+	case TranscriptEntryKindStorageRemove:
+		return fmt.Sprintf("remove %s %s", e.Address.ShortHexWithPrefix(), e.Path)
+
+	case TranscriptEntryKindEvent:
+		return fmt.Sprintf("emit %s", e.Event)
+	}
+
+	return "unknown"
+}
+
+// Transcript records what an execution did:
+// the functions it called, the storage paths it read and wrote, and the events it emitted,
+// e.g. for the review of a transaction before it is signed.
+//
+// Storage accesses are only recorded for paths, i.e. the storage, private, and public domains.
+// The transcript is not safe for concurrent use.
+//
+type Transcript struct {
+	Entries []TranscriptEntry
+	depth   int
+}
+
+// String returns the transcript in a human-readable format, one entry per line.
+// Entries are indented by their call depth.
+//
+func (t *Transcript) String() string {
+	var builder strings.Builder
+	for _, entry := range t.Entries {
+		builder.WriteString(strings.Repeat("  ", entry.Depth))
+		builder.WriteString(entry.String())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func (t *Transcript) record(entry TranscriptEntry) {
+	entry.Depth = t.depth
+	t.Entries = append(t.Entries, entry)
+}
+
+func (t *Transcript) recordFunctionCall(interpreter *Interpreter, invocation *ast.InvocationExpression) {
+	t.record(TranscriptEntry{
+		Kind:     TranscriptEntryKindFunctionCall,
+		Location: interpreter.Location,
+		Line:     invocation.StartPosition().Line,
+		Function: invocation.InvokedExpression.String(),
+	})
+	t.depth++
+}
+
+func (t *Transcript) recordFunctionReturn() {
+	t.depth--
+}
+
+func (t *Transcript) recordStorageAccess(
+	interpreter *Interpreter,
+	kind TranscriptEntryKind,
+	address common.Address,
+	domain string,
+	identifier string,
+) {
+	pathDomain := common.PathDomainFromIdentifier(domain)
+	if pathDomain == common.PathDomainUnknown {
+		return
+	}
+
+	t.record(TranscriptEntry{
+		Kind:     kind,
+		Location: interpreter.Location,
+		Address:  address,
+		Path: PathValue{
+			Domain:     pathDomain,
+			Identifier: identifier,
+		},
+	})
+}
+
+func (t *Transcript) recordEvent(interpreter *Interpreter, statement *ast.EmitStatement, event *CompositeValue) {
+	t.record(TranscriptEntry{
+		Kind:      TranscriptEntryKindEvent,
+		Location:  interpreter.Location,
+		Line:      statement.StartPosition().Line,
+		EventType: event.TypeID(),
+		Event:     event.String(),
+	})
+}
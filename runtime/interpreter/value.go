@@ -19,6 +19,7 @@
 package interpreter
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -26,6 +27,8 @@ import (
 	"math/big"
 	"strings"
 	"time"
+	"unicode/utf16"
+	"unsafe"
 
 	"github.com/onflow/atree"
 	"github.com/rivo/uniseg"
@@ -249,7 +252,7 @@ func (v TypeValue) GetMember(interpreter *Interpreter, _ func() LocationRange, n
 		if staticType != nil {
 			typeID = string(interpreter.MustConvertStaticToSemaType(staticType).ID())
 		}
-		return NewStringValue(typeID)
+		return newMeteredStringValue(interpreter, typeID)
 	case "isSubtype":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
@@ -592,6 +595,24 @@ type StringValue struct {
 	// which is initialized lazily and reused/reset in functions
 	// that are based on grapheme clusters
 	graphemes *uniseg.Graphemes
+	// buffer is the buffer the string is stored in,
+	// if the string is the result of a concatenation, see Concat
+	buffer *stringBuffer
+	// hashInput is the cached hash input of the string,
+	// which is initialized lazily, see HashInput
+	hashInput []byte
+}
+
+// stringBuffer is a buffer which is shared by strings created by repeated concatenation.
+//
+// The strings which share the buffer are prefixes of the content of the buffer,
+// and bytes which were written to the buffer are never modified.
+// This allows the concatenation of a string which spans the whole content of its buffer
+// to append to the buffer in place, instead of copying the string,
+// which makes repeated concatenation, e.g. in a loop, linear instead of quadratic.
+//
+type stringBuffer struct {
+	bytes []byte
 }
 
 func NewStringValue(str string) *StringValue {
@@ -602,6 +623,14 @@ func NewStringValue(str string) *StringValue {
 	}
 }
 
+// newMeteredStringValue returns a new string value for a newly allocated string,
+// and reports its memory usage.
+//
+func newMeteredStringValue(interpreter *Interpreter, str string) *StringValue {
+	interpreter.meterMemory(common.MemoryKindString, uint64(len(str)))
+	return NewStringValue(str)
+}
+
 var _ Value = &StringValue{}
 var _ atree.Storable = &StringValue{}
 var _ EquatableValue = &StringValue{}
@@ -656,31 +685,73 @@ func (v *StringValue) Equal(_ *Interpreter, _ func() LocationRange, other Value)
 // HashInput returns a byte slice containing:
 // - HashInputTypeString (1 byte)
 // - string value (n bytes)
-func (v *StringValue) HashInput(_ *Interpreter, _ func() LocationRange, scratch []byte) []byte {
-	length := 1 + len(v.Str)
-	var buffer []byte
-	if length <= len(scratch) {
-		buffer = scratch[:length]
-	} else {
-		buffer = make([]byte, length)
+//
+// The hash input is cached, as strings are immutable,
+// and are e.g. repeatedly used as keys of dictionary lookups.
+//
+func (v *StringValue) HashInput(_ *Interpreter, _ func() LocationRange, _ []byte) []byte {
+	if v.hashInput == nil {
+		buffer := make([]byte, 1+len(v.Str))
+		buffer[0] = byte(HashInputTypeString)
+		copy(buffer[1:], v.Str)
+		v.hashInput = buffer
 	}
-
-	buffer[0] = byte(HashInputTypeString)
-	copy(buffer[1:], v.Str)
-	return buffer
+	return v.hashInput
 }
 
 func (v *StringValue) NormalForm() string {
 	return norm.NFC.String(v.Str)
 }
 
-func (v *StringValue) Concat(other *StringValue) Value {
-	var sb strings.Builder
+// stringBufferMinLength is the minimum length of a string resulting from a concatenation,
+// for which a buffer is used. Shorter strings are copied,
+// as copying them is cheaper than maintaining a buffer.
+//
+const stringBufferMinLength = 256
+
+func (v *StringValue) Concat(interpreter *Interpreter, other *StringValue) Value {
+
+	length := len(v.Str) + len(other.Str)
+
+	if length < stringBufferMinLength {
+		interpreter.meterComputation(common.ComputationKindStringConcatenation, uint(length))
+
+		return newMeteredStringValue(interpreter, v.Str+other.Str)
+	}
+
+	copied := len(other.Str)
+
+	// If this string spans the whole content of its buffer,
+	// the other string can be appended to the buffer in place.
+	// Otherwise, e.g. if the string was already concatenated with another string before,
+	// the string is copied into a new buffer
 
-	sb.WriteString(v.Str)
-	sb.WriteString(other.Str)
+	buffer := v.buffer
+	if buffer == nil || len(buffer.bytes) != len(v.Str) {
+		buffer = &stringBuffer{
+			bytes: make([]byte, 0, length),
+		}
+		buffer.bytes = append(buffer.bytes, v.Str...)
+		copied += len(v.Str)
+	} else if len(buffer.bytes)+len(other.Str) > cap(buffer.bytes) {
+		// Growing the buffer copies its content
+		copied += len(buffer.bytes)
+	}
+
+	interpreter.meterComputation(common.ComputationKindStringConcatenation, uint(copied))
+	interpreter.meterMemory(common.MemoryKindString, uint64(copied))
+
+	buffer.bytes = append(buffer.bytes, other.Str...)
 
-	return NewStringValue(sb.String())
+	// NOTE: the string is not copied, it refers to the bytes of the buffer,
+	// which are never modified once written
+
+	bytes := buffer.bytes
+	str := *(*string)(unsafe.Pointer(&bytes))
+
+	result := NewStringValue(str)
+	result.buffer = buffer
+	return result
 }
 
 func (v *StringValue) Slice(from IntValue, to IntValue, getLocationRange func() LocationRange) Value {
@@ -776,6 +847,9 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 	case "utf8":
 		return ByteSliceToByteArrayValue(interpreter, []byte(v.Str))
 
+	case "utf16":
+		return v.UTF16(interpreter)
+
 	case "concat":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
@@ -783,7 +857,7 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.Concat(otherArray)
+				return v.Concat(invocation.Interpreter, otherArray)
 			},
 			sema.StringTypeConcatFunctionType,
 		)
@@ -812,10 +886,91 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 	case "toLower":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return v.ToLower()
+				return v.ToLower(invocation.Interpreter)
 			},
 			sema.StringTypeToLowerFunctionType,
 		)
+
+	case "toUpper":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.ToUpper(invocation.Interpreter)
+			},
+			sema.StringTypeToUpperFunctionType,
+		)
+
+	case "split":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				separator, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.Split(invocation.Interpreter, separator)
+			},
+			sema.StringTypeSplitFunctionType,
+		)
+
+	case "replaceAll":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				original, originalOk := invocation.Arguments[0].(*StringValue)
+				replacement, replacementOk := invocation.Arguments[1].(*StringValue)
+				if !originalOk || !replacementOk {
+					panic(errors.NewUnreachableError())
+				}
+				return v.ReplaceAll(invocation.Interpreter, original, replacement)
+			},
+			sema.StringTypeReplaceAllFunctionType,
+		)
+
+	case "index":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return NewIntValueFromInt64(int64(v.IndexOf(other)))
+			},
+			sema.StringTypeIndexFunctionType,
+		)
+
+	case "contains":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return BoolValue(v.Contains(other))
+			},
+			sema.StringTypeContainsFunctionType,
+		)
+
+	case "padLeft":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				length, lengthOk := invocation.Arguments[0].(IntValue)
+				character, characterOk := invocation.Arguments[1].(*StringValue)
+				if !lengthOk || !characterOk {
+					panic(errors.NewUnreachableError())
+				}
+				return v.PadLeft(invocation.Interpreter, length.ToInt(), character)
+			},
+			sema.StringTypePadLeftFunctionType,
+		)
+
+	// NOTE: characters are represented as strings,
+	// so the members of characters are also provided here
+
+	case sema.ToStringFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v
+			},
+			sema.ToStringFunctionType,
+		)
 	}
 
 	return nil
@@ -845,8 +1000,160 @@ func (v *StringValue) Length() int {
 	return v.length
 }
 
-func (v *StringValue) ToLower() *StringValue {
-	return NewStringValue(strings.ToLower(v.Str))
+func (v *StringValue) ToLower(interpreter *Interpreter) *StringValue {
+	return newMeteredStringValue(interpreter, strings.ToLower(v.Str))
+}
+
+func (v *StringValue) ToUpper(interpreter *Interpreter) *StringValue {
+	return newMeteredStringValue(interpreter, strings.ToUpper(v.Str))
+}
+
+var UTF16CodeUnitArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.UTF16CodeUnitArrayType)
+
+// UTF16 returns an array of the UTF-16 code units of this string
+//
+func (v *StringValue) UTF16(interpreter *Interpreter) *ArrayValue {
+	codeUnits := utf16.Encode([]rune(v.Str))
+
+	values := make([]Value, len(codeUnits))
+	for i, codeUnit := range codeUnits {
+		values[i] = UInt16Value(codeUnit)
+	}
+
+	return NewArrayValue(
+		interpreter,
+		UTF16CodeUnitArrayStaticType,
+		common.Address{},
+		values...,
+	)
+}
+
+var StringArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.StringArrayType)
+
+// Split returns an array of the substrings of this string which are separated by the given separator.
+// If the separator is empty, the string is split into its characters (grapheme clusters)
+//
+func (v *StringValue) Split(interpreter *Interpreter, separator *StringValue) *ArrayValue {
+	var values []Value
+
+	if len(separator.Str) == 0 {
+		values = make([]Value, 0, v.Length())
+
+		v.prepareGraphemes()
+		for v.graphemes.Next() {
+			values = append(values, NewStringValue(v.graphemes.Str()))
+		}
+	} else {
+		parts := strings.Split(v.Str, separator.Str)
+
+		values = make([]Value, len(parts))
+		for i, part := range parts {
+			values[i] = NewStringValue(part)
+		}
+	}
+
+	interpreter.meterMemory(common.MemoryKindString, uint64(len(v.Str)))
+
+	return NewArrayValue(
+		interpreter,
+		StringArrayStaticType,
+		common.Address{},
+		values...,
+	)
+}
+
+// ReplaceAll returns a new string in which all occurrences of the given original string
+// are replaced with the given replacement string.
+// If the original string is empty, this string is returned unchanged
+//
+func (v *StringValue) ReplaceAll(interpreter *Interpreter, original *StringValue, replacement *StringValue) *StringValue {
+	if len(original.Str) == 0 {
+		return v
+	}
+
+	return newMeteredStringValue(
+		interpreter,
+		strings.ReplaceAll(v.Str, original.Str, replacement.Str),
+	)
+}
+
+// IndexOf returns the index of the character (grapheme cluster) at which
+// the first occurrence of the given string starts, or -1 if this string does not contain it.
+//
+// Only occurrences which start at a character boundary are considered,
+// e.g. "e" does not occur in "e\u{301}" (é)
+//
+func (v *StringValue) IndexOf(other *StringValue) int {
+	if len(other.Str) == 0 {
+		return 0
+	}
+
+	if !strings.Contains(v.Str, other.Str) {
+		return -1
+	}
+
+	v.prepareGraphemes()
+
+	index := 0
+	for v.graphemes.Next() {
+		start, _ := v.graphemes.Positions()
+
+		// The occurrence must also end at a character boundary
+
+		if strings.HasPrefix(v.Str[start:], other.Str) &&
+			isGraphemeBoundary(v.Str, start+len(other.Str)) {
+
+			return index
+		}
+
+		index++
+	}
+
+	return -1
+}
+
+func (v *StringValue) Contains(other *StringValue) bool {
+	return v.IndexOf(other) >= 0
+}
+
+// PadLeft returns a new string of the given length (in characters),
+// which contains this string prefixed with the given character as many times as needed.
+// If this string is already at least as long as the given length, it is returned unchanged
+//
+func (v *StringValue) PadLeft(interpreter *Interpreter, length int, character *StringValue) *StringValue {
+	count := length - v.Length()
+	if count <= 0 {
+		return v
+	}
+
+	interpreter.meterMemory(
+		common.MemoryKindString,
+		uint64(len(character.Str))*uint64(count)+uint64(len(v.Str)),
+	)
+
+	return NewStringValue(strings.Repeat(character.Str, count) + v.Str)
+}
+
+// isGraphemeBoundary returns true if the given byte offset of the given string
+// is the start or end of a grapheme cluster
+//
+func isGraphemeBoundary(str string, offset int) bool {
+	if offset == 0 || offset == len(str) {
+		return true
+	}
+
+	graphemes := uniseg.NewGraphemes(str)
+	for graphemes.Next() {
+		start, end := graphemes.Positions()
+		if start == offset || end == offset {
+			return true
+		}
+		if start > offset {
+			break
+		}
+	}
+
+	return false
 }
 
 func (v *StringValue) Storable(storage atree.SlabStorage, address atree.Address, maxInlineSize uint64) (atree.Storable, error) {
@@ -934,96 +1241,368 @@ func (*StringValue) ConformsToDynamicType(
 	return ok
 }
 
-// ArrayValue
+// BytesValue
 
-type ArrayValue struct {
-	Type             ArrayStaticType
-	semaType         sema.ArrayType
-	array            *atree.Array
-	isDestroyed      bool
-	isResourceKinded *bool
+// BytesValue is an immutable sequence of bytes.
+//
+// The bytes are stored contiguously. Slicing does not copy the bytes,
+// the resulting value shares the underlying storage with the sliced value,
+// which is safe, as the bytes are never modified once written.
+//
+type BytesValue struct {
+	Bytes []byte
 }
 
-func NewArrayValue(
-	interpreter *Interpreter,
-	arrayType ArrayStaticType,
-	address common.Address,
-	values ...Value,
-) *ArrayValue {
+func NewBytesValue(bytes []byte) *BytesValue {
+	return &BytesValue{
+		Bytes: bytes,
+	}
+}
 
-	var index int
-	count := len(values)
+var _ Value = &BytesValue{}
+var _ atree.Storable = &BytesValue{}
+var _ EquatableValue = &BytesValue{}
+var _ HashableValue = &BytesValue{}
+var _ ValueIndexableValue = &BytesValue{}
+var _ MemberAccessibleValue = &BytesValue{}
 
-	return NewArrayValueWithIterator(
-		interpreter,
-		arrayType,
-		address,
-		func() Value {
-			if index >= count {
-				return nil
-			}
+func (*BytesValue) IsValue() {}
 
-			value := values[index]
+func (v *BytesValue) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitBytesValue(interpreter, v)
+}
 
-			index++
+func (*BytesValue) Walk(_ func(Value)) {
+	// NO-OP
+}
 
-			value = value.Transfer(
-				interpreter,
-				// TODO: provide proper location range
-				ReturnEmptyLocationRange,
-				atree.Address(address),
-				true,
-				nil,
-			)
+var bytesDynamicType DynamicType = BytesDynamicType{}
 
-			return value
-		},
-	)
+func (*BytesValue) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
+	return bytesDynamicType
 }
 
-func NewArrayValueWithIterator(
-	interpreter *Interpreter,
-	arrayType ArrayStaticType,
-	address common.Address,
-	values func() Value,
-) *ArrayValue {
-
-	array, err := atree.NewArrayFromBatchData(
-		interpreter.Storage,
-		atree.Address(address),
-		arrayType,
-		func() (atree.Value, error) {
-			return values(), nil
-		},
-	)
-	if err != nil {
-		panic(ExternalError{err})
-	}
+func (*BytesValue) StaticType() StaticType {
+	return PrimitiveStaticTypeBytes
+}
 
-	return &ArrayValue{
-		Type:  arrayType,
-		array: array,
-	}
+func (v *BytesValue) String() string {
+	return format.Bytes(v.Bytes)
 }
 
-var _ Value = &ArrayValue{}
-var _ atree.Value = &ArrayValue{}
-var _ EquatableValue = &ArrayValue{}
-var _ ValueIndexableValue = &ArrayValue{}
-var _ MemberAccessibleValue = &ArrayValue{}
-var _ ReferenceTrackedResourceKindedValue = &ArrayValue{}
+func (v *BytesValue) RecursiveString(_ SeenReferences) string {
+	return v.String()
+}
 
-func (*ArrayValue) IsValue() {}
+func (v *BytesValue) Equal(_ *Interpreter, _ func() LocationRange, other Value) bool {
+	otherBytes, ok := other.(*BytesValue)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(v.Bytes, otherBytes.Bytes)
+}
 
-func (v *ArrayValue) Accept(interpreter *Interpreter, visitor Visitor) {
-	descend := visitor.VisitArrayValue(interpreter, v)
-	if !descend {
-		return
+// HashInput returns a byte slice containing:
+// - HashInputTypeBytes (1 byte)
+// - bytes (n bytes)
+//
+func (v *BytesValue) HashInput(_ *Interpreter, _ func() LocationRange, scratch []byte) []byte {
+	length := 1 + len(v.Bytes)
+	var buffer []byte
+	if length <= len(scratch) {
+		buffer = scratch[:length]
+	} else {
+		buffer = make([]byte, length)
 	}
 
-	v.Walk(func(element Value) {
-		element.Accept(interpreter, visitor)
-	})
+	buffer[0] = byte(HashInputTypeBytes)
+	copy(buffer[1:], v.Bytes)
+	return buffer
+}
+
+// Concat returns the concatenation of this and the other bytes.
+// The bytes of both values are copied into a new buffer.
+//
+func (v *BytesValue) Concat(interpreter *Interpreter, other *BytesValue) *BytesValue {
+	length := len(v.Bytes) + len(other.Bytes)
+
+	interpreter.meterComputation(common.ComputationKindStringConcatenation, uint(length))
+
+	result := make([]byte, length)
+	copy(result, v.Bytes)
+	copy(result[len(v.Bytes):], other.Bytes)
+
+	return NewBytesValue(result)
+}
+
+// Slice returns the bytes in the range [from, upTo).
+// The bytes are not copied.
+//
+func (v *BytesValue) Slice(from IntValue, to IntValue, getLocationRange func() LocationRange) *BytesValue {
+	fromIndex := from.ToInt()
+
+	toIndex := to.ToInt()
+
+	length := len(v.Bytes)
+
+	if fromIndex < 0 || fromIndex > length || toIndex < 0 || toIndex > length {
+		panic(ArraySliceIndicesError{
+			FromIndex:     fromIndex,
+			UpToIndex:     toIndex,
+			Size:          length,
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	if fromIndex > toIndex {
+		panic(InvalidSliceIndexError{
+			FromIndex:     fromIndex,
+			UpToIndex:     toIndex,
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	// NOTE: the capacity of the slice is limited to its length,
+	// so appending to the slice can never overwrite the bytes of the sliced value
+
+	return NewBytesValue(v.Bytes[fromIndex:toIndex:toIndex])
+}
+
+func (v *BytesValue) GetKey(_ *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+	index := key.(NumberValue).ToInt()
+
+	length := len(v.Bytes)
+
+	if index < 0 || index >= length {
+		panic(ArrayIndexOutOfBoundsError{
+			Index:         index,
+			Size:          length,
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	return UInt8Value(v.Bytes[index])
+}
+
+func (*BytesValue) SetKey(_ *Interpreter, _ func() LocationRange, _ Value, _ Value) {
+	panic(errors.NewUnreachableError())
+}
+
+func (*BytesValue) InsertKey(_ *Interpreter, _ func() LocationRange, _ Value, _ Value) {
+	panic(errors.NewUnreachableError())
+}
+
+func (*BytesValue) RemoveKey(_ *Interpreter, _ func() LocationRange, _ Value) Value {
+	panic(errors.NewUnreachableError())
+}
+
+func (v *BytesValue) GetMember(interpreter *Interpreter, _ func() LocationRange, name string) Value {
+	switch name {
+	case "length":
+		return NewIntValueFromInt64(int64(len(v.Bytes)))
+
+	case "concat":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*BytesValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.Concat(invocation.Interpreter, other)
+			},
+			sema.BytesTypeConcatFunctionType,
+		)
+
+	case "slice":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				from, fromOk := invocation.Arguments[0].(IntValue)
+				to, toOk := invocation.Arguments[1].(IntValue)
+				if !fromOk || !toOk {
+					panic(errors.NewUnreachableError())
+				}
+				return v.Slice(from, to, invocation.GetLocationRange)
+			},
+			sema.BytesTypeSliceFunctionType,
+		)
+
+	case "toArray":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return ByteSliceToByteArrayValue(invocation.Interpreter, v.Bytes)
+			},
+			sema.BytesTypeToArrayFunctionType,
+		)
+	}
+
+	return nil
+}
+
+func (*BytesValue) RemoveMember(_ *Interpreter, _ func() LocationRange, _ string) Value {
+	// Bytes have no removable members (fields / functions)
+	panic(errors.NewUnreachableError())
+}
+
+func (*BytesValue) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
+	// Bytes have no settable members (fields / functions)
+	panic(errors.NewUnreachableError())
+}
+
+func (v *BytesValue) Storable(storage atree.SlabStorage, address atree.Address, maxInlineSize uint64) (atree.Storable, error) {
+	return maybeLargeImmutableStorable(v, storage, address, maxInlineSize)
+}
+
+func (*BytesValue) NeedsStoreTo(_ atree.Address) bool {
+	return false
+}
+
+func (*BytesValue) IsResourceKinded(_ *Interpreter) bool {
+	return false
+}
+
+func (v *BytesValue) Transfer(
+	interpreter *Interpreter,
+	_ func() LocationRange,
+	_ atree.Address,
+	remove bool,
+	storable atree.Storable,
+) Value {
+	if remove {
+		interpreter.RemoveReferencedSlab(storable)
+	}
+	return v
+}
+
+func (v *BytesValue) Clone(_ *Interpreter) Value {
+	return NewBytesValue(v.Bytes)
+}
+
+func (*BytesValue) DeepRemove(_ *Interpreter) {
+	// NO-OP
+}
+
+func (v *BytesValue) ByteSize() uint32 {
+	return cborTagSize + getBytesCBORSize(v.Bytes)
+}
+
+func (v *BytesValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+	return v, nil
+}
+
+func (*BytesValue) ChildStorables() []atree.Storable {
+	return nil
+}
+
+func (*BytesValue) ConformsToDynamicType(
+	_ *Interpreter,
+	_ func() LocationRange,
+	dynamicType DynamicType,
+	_ TypeConformanceResults,
+) bool {
+	_, ok := dynamicType.(BytesDynamicType)
+	return ok
+}
+
+// ArrayValue
+
+type ArrayValue struct {
+	Type             ArrayStaticType
+	semaType         sema.ArrayType
+	array            *atree.Array
+	isDestroyed      bool
+	isResourceKinded *bool
+}
+
+func NewArrayValue(
+	interpreter *Interpreter,
+	arrayType ArrayStaticType,
+	address common.Address,
+	values ...Value,
+) *ArrayValue {
+
+	var index int
+	count := len(values)
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		arrayType,
+		address,
+		func() Value {
+			if index >= count {
+				return nil
+			}
+
+			value := values[index]
+
+			index++
+
+			value = value.Transfer(
+				interpreter,
+				// TODO: provide proper location range
+				ReturnEmptyLocationRange,
+				atree.Address(address),
+				true,
+				nil,
+			)
+
+			return value
+		},
+	)
+}
+
+func NewArrayValueWithIterator(
+	interpreter *Interpreter,
+	arrayType ArrayStaticType,
+	address common.Address,
+	values func() Value,
+) *ArrayValue {
+
+	array, err := atree.NewArrayFromBatchData(
+		interpreter.Storage,
+		atree.Address(address),
+		arrayType,
+		func() (atree.Value, error) {
+			value := values()
+			if value == nil {
+				return nil, nil
+			}
+			return arrayElementAtreeValue(arrayType, value), nil
+		},
+	)
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	interpreter.reportComputation(
+		common.ComputationKindValueAllocation,
+		1+uint(array.Count()),
+	)
+	interpreter.meterContainerMemory(common.MemoryKindArray, int(array.Count()))
+
+	return &ArrayValue{
+		Type:  arrayType,
+		array: array,
+	}
+}
+
+var _ Value = &ArrayValue{}
+var _ atree.Value = &ArrayValue{}
+var _ EquatableValue = &ArrayValue{}
+var _ ValueIndexableValue = &ArrayValue{}
+var _ MemberAccessibleValue = &ArrayValue{}
+var _ ReferenceTrackedResourceKindedValue = &ArrayValue{}
+
+func (*ArrayValue) IsValue() {}
+
+func (v *ArrayValue) Accept(interpreter *Interpreter, visitor Visitor) {
+	descend := visitor.VisitArrayValue(interpreter, v)
+	if !descend {
+		return
+	}
+
+	v.Walk(func(element Value) {
+		element.Accept(interpreter, visitor)
+	})
 }
 
 func (v *ArrayValue) Iterate(f func(element Value) (resume bool)) {
@@ -1031,7 +1610,7 @@ func (v *ArrayValue) Iterate(f func(element Value) (resume bool)) {
 		// atree.Array iteration provides low-level atree.Value,
 		// convert to high-level interpreter.Value
 
-		resume = f(MustConvertStoredValue(element))
+		resume = f(v.storedElement(element))
 
 		return resume, nil
 	})
@@ -1040,6 +1619,35 @@ func (v *ArrayValue) Iterate(f func(element Value) (resume bool)) {
 	}
 }
 
+// storedElement converts the given element, which was read from the underlying atree array,
+// to a value. Compactly stored elements are converted using the element type of the array.
+//
+func (v *ArrayValue) storedElement(element atree.Value) Value {
+	if compactInteger, ok := element.(compactIntegerAtreeValue); ok {
+		return compactInteger.value(v.Type.ElementType())
+	}
+	return MustConvertStoredValue(element)
+}
+
+func (v *ArrayValue) storedElementFromStorable(storable atree.Storable, storage atree.SlabStorage) Value {
+	storedValue, err := storable.StoredValue(storage)
+	if err != nil {
+		panic(err)
+	}
+
+	return v.storedElement(storedValue)
+}
+
+// arrayElementAtreeValue returns the atree value which stores the given element
+// in the underlying atree array of an array with the given type.
+//
+func arrayElementAtreeValue(arrayType ArrayStaticType, element Value) atree.Value {
+	if isCompactArrayElementType(arrayType) {
+		return newCompactIntegerAtreeValue(element)
+	}
+	return element
+}
+
 func (v *ArrayValue) Walk(walkChild func(Value)) {
 	v.Iterate(func(element Value) (resume bool) {
 		walkChild(element)
@@ -1112,7 +1720,7 @@ func (v *ArrayValue) Concat(interpreter *Interpreter, getLocationRange func() Lo
 				if atreeValue == nil {
 					first = false
 				} else {
-					value = MustConvertStoredValue(atreeValue)
+					value = v.storedElement(atreeValue)
 				}
 			}
 
@@ -1123,7 +1731,7 @@ func (v *ArrayValue) Concat(interpreter *Interpreter, getLocationRange func() Lo
 				}
 
 				if atreeValue != nil {
-					value = MustConvertStoredValue(atreeValue)
+					value = other.storedElement(atreeValue)
 
 					interpreter.checkContainerMutation(elementType, value, getLocationRange)
 				}
@@ -1179,7 +1787,20 @@ func (v *ArrayValue) Get(interpreter *Interpreter, getLocationRange func() Locat
 		panic(ExternalError{err})
 	}
 
-	return StoredValue(storable, interpreter.Storage)
+	return v.storedElementFromStorable(storable, interpreter.Storage)
+}
+
+// At returns the element at the given index as an optional,
+// or nil if the index is out of bounds.
+//
+func (v *ArrayValue) At(interpreter *Interpreter, getLocationRange func() LocationRange, index int) OptionalValue {
+	if index < 0 || index >= v.Count() {
+		return NilValue{}
+	}
+
+	return NewSomeValueNonCopying(
+		v.Get(interpreter, getLocationRange, index),
+	)
 }
 
 func (v *ArrayValue) SetKey(interpreter *Interpreter, getLocationRange func() LocationRange, key Value, value Value) {
@@ -1201,6 +1822,7 @@ func (v *ArrayValue) Set(interpreter *Interpreter, getLocationRange func() Locat
 	}
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
+	interpreter.checkStoredValueWrite(v.array.Address(), "set array element", getLocationRange)
 
 	element = element.Transfer(
 		interpreter,
@@ -1210,7 +1832,7 @@ func (v *ArrayValue) Set(interpreter *Interpreter, getLocationRange func() Locat
 		nil,
 	)
 
-	existingStorable, err := v.array.Set(uint64(index), element)
+	existingStorable, err := v.array.Set(uint64(index), arrayElementAtreeValue(v.Type, element))
 	if err != nil {
 		v.handleIndexOutOfBoundsError(err, index, getLocationRange)
 
@@ -1218,7 +1840,7 @@ func (v *ArrayValue) Set(interpreter *Interpreter, getLocationRange func() Locat
 	}
 	interpreter.maybeValidateAtreeValue(v.array)
 
-	existingValue := StoredValue(existingStorable, interpreter.Storage)
+	existingValue := v.storedElementFromStorable(existingStorable, interpreter.Storage)
 
 	existingValue.DeepRemove(interpreter)
 
@@ -1244,6 +1866,7 @@ func (v *ArrayValue) RecursiveString(seenReferences SeenReferences) string {
 func (v *ArrayValue) Append(interpreter *Interpreter, getLocationRange func() LocationRange, element Value) {
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
+	interpreter.checkStoredValueWrite(v.array.Address(), "append to array", getLocationRange)
 
 	element = element.Transfer(
 		interpreter,
@@ -1253,7 +1876,9 @@ func (v *ArrayValue) Append(interpreter *Interpreter, getLocationRange func() Lo
 		nil,
 	)
 
-	err := v.array.Append(element)
+	interpreter.meterContainerGrowthMemory(common.MemoryKindArray, 1)
+
+	err := v.array.Append(arrayElementAtreeValue(v.Type, element))
 	if err != nil {
 		panic(ExternalError{err})
 	}
@@ -1285,6 +1910,7 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, getLocationRange func() Lo
 	}
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
+	interpreter.checkStoredValueWrite(v.array.Address(), "insert into array", getLocationRange)
 
 	element = element.Transfer(
 		interpreter,
@@ -1294,13 +1920,15 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, getLocationRange func() Lo
 		nil,
 	)
 
-	err := v.array.Insert(uint64(index), element)
+	err := v.array.Insert(uint64(index), arrayElementAtreeValue(v.Type, element))
 	if err != nil {
 		v.handleIndexOutOfBoundsError(err, index, getLocationRange)
 
 		panic(ExternalError{err})
 	}
 	interpreter.maybeValidateAtreeValue(v.array)
+
+	interpreter.meterContainerGrowthMemory(common.MemoryKindArray, 1)
 }
 
 func (v *ArrayValue) RemoveKey(interpreter *Interpreter, getLocationRange func() LocationRange, key Value) Value {
@@ -1321,6 +1949,8 @@ func (v *ArrayValue) Remove(interpreter *Interpreter, getLocationRange func() Lo
 		})
 	}
 
+	interpreter.checkStoredValueWrite(v.array.Address(), "remove from array", getLocationRange)
+
 	storable, err := v.array.Remove(uint64(index))
 	if err != nil {
 		v.handleIndexOutOfBoundsError(err, index, getLocationRange)
@@ -1329,7 +1959,7 @@ func (v *ArrayValue) Remove(interpreter *Interpreter, getLocationRange func() Lo
 	}
 	interpreter.maybeValidateAtreeValue(v.array)
 
-	value := StoredValue(storable, interpreter.Storage)
+	value := v.storedElementFromStorable(storable, interpreter.Storage)
 
 	return value.Transfer(
 		interpreter,
@@ -1344,9 +1974,178 @@ func (v *ArrayValue) RemoveFirst(interpreter *Interpreter, getLocationRange func
 	return v.Remove(interpreter, getLocationRange, 0)
 }
 
-func (v *ArrayValue) RemoveLast(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
-	return v.Remove(interpreter, getLocationRange, v.Count()-1)
-}
+// RemoveFirstWhere removes the first element which satisfies the given predicate and returns it.
+// If no element satisfies the predicate, the program aborts.
+//
+func (v *ArrayValue) RemoveFirstWhere(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	predicate FunctionValue,
+) Value {
+	elementType := v.SemaType(interpreter).ElementType(false)
+
+	for index := 0; index < v.Count(); index++ {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		element := v.Get(interpreter, getLocationRange, index)
+
+		if v.satisfiesPredicate(interpreter, getLocationRange, predicate, elementType, element) {
+			return v.Remove(interpreter, getLocationRange, index)
+		}
+	}
+
+	panic(ArrayElementNotFoundError{
+		LocationRange: getLocationRange(),
+	})
+}
+
+// RemoveAll removes all elements which satisfy the given predicate,
+// and returns them in a new array.
+//
+// The predicate is called for each element in a single pass over the array,
+// while the array is unchanged. If any element satisfies the predicate,
+// the array is then rebuilt once: All elements are popped, and the remaining elements
+// are appended again with their existing storables, i.e. they are not copied or re-encoded.
+// The array keeps its storage ID, so references to it stay valid.
+//
+func (v *ArrayValue) RemoveAll(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	predicate FunctionValue,
+) Value {
+	elementType := v.SemaType(interpreter).ElementType(false)
+
+	count := v.Count()
+	satisfied := make([]bool, 0, count)
+	removedCount := 0
+
+	v.Iterate(func(element Value) (resume bool) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		result := v.satisfiesPredicate(interpreter, getLocationRange, predicate, elementType, element)
+		if result {
+			removedCount++
+		}
+		satisfied = append(satisfied, result)
+
+		return true
+	})
+
+	resultType := VariableSizedStaticType{
+		Type: v.Type.ElementType(),
+	}
+
+	if removedCount == 0 {
+		return NewArrayValue(interpreter, resultType, common.Address{})
+	}
+
+	interpreter.checkStoredValueWrite(v.array.Address(), "remove from array", getLocationRange)
+
+	// Pop all elements. The elements are popped backward
+
+	storables := make([]atree.Storable, count)
+	index := count
+
+	err := v.array.PopIterate(func(storable atree.Storable) {
+		index--
+		storables[index] = storable
+	})
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	removed := make([]Value, 0, removedCount)
+
+	for index, storable := range storables {
+		if satisfied[index] {
+			value := v.storedElementFromStorable(storable, interpreter.Storage)
+
+			removed = append(
+				removed,
+				value.Transfer(
+					interpreter,
+					getLocationRange,
+					atree.Address{},
+					true,
+					storable,
+				),
+			)
+		} else {
+			err := v.array.Append(existingStorableAtreeValue{storable})
+			if err != nil {
+				panic(ExternalError{err})
+			}
+		}
+	}
+
+	interpreter.maybeValidateAtreeValue(v.array)
+
+	return NewArrayValue(
+		interpreter,
+		resultType,
+		common.Address{},
+		removed...,
+	)
+}
+
+// existingStorableAtreeValue is an atree value for an element which is already stored
+// in the array it is inserted into, e.g. when an array is rebuilt.
+// The existing storable is reused, so the element is not stored again.
+//
+type existingStorableAtreeValue struct {
+	storable atree.Storable
+}
+
+var _ atree.Value = existingStorableAtreeValue{}
+
+func (v existingStorableAtreeValue) Storable(_ atree.SlabStorage, _ atree.Address, _ uint64) (atree.Storable, error) {
+	return v.storable, nil
+}
+
+// satisfiesPredicate returns true if the given element satisfies the given predicate.
+// Resources are passed to the predicate by reference.
+//
+func (v *ArrayValue) satisfiesPredicate(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	predicate FunctionValue,
+	elementType sema.Type,
+	element Value,
+) bool {
+	var argument Value
+	argumentType := elementType
+
+	if elementType.IsResourceType() {
+		argumentType = &sema.ReferenceType{
+			Type: elementType,
+		}
+		argument = &EphemeralReferenceValue{
+			Value:        element,
+			BorrowedType: elementType,
+		}
+	} else {
+		argument = element.Transfer(
+			interpreter,
+			getLocationRange,
+			atree.Address{},
+			false,
+			nil,
+		)
+	}
+
+	result := predicate.invoke(Invocation{
+		Arguments:        []Value{argument},
+		ArgumentTypes:    []sema.Type{argumentType},
+		GetLocationRange: getLocationRange,
+		Interpreter:      interpreter,
+	})
+
+	return bool(result.(BoolValue))
+}
+
+func (v *ArrayValue) RemoveLast(interpreter *Interpreter, getLocationRange func() LocationRange) Value {
+	return v.Remove(interpreter, getLocationRange, v.Count()-1)
+}
 
 func (v *ArrayValue) Contains(interpreter *Interpreter, getLocationRange func() LocationRange, needleValue Value) BoolValue {
 
@@ -1462,9 +2261,22 @@ func (v *ArrayValue) GetMember(inter *Interpreter, _ func() LocationRange, name
 	case "removeFirst":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return v.RemoveFirst(
+				if len(invocation.Arguments) == 0 {
+					return v.RemoveFirst(
+						invocation.Interpreter,
+						invocation.GetLocationRange,
+					)
+				}
+
+				predicate, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.RemoveFirstWhere(
 					invocation.Interpreter,
 					invocation.GetLocationRange,
+					predicate,
 				)
 			},
 			sema.ArrayRemoveFirstFunctionType(
@@ -1472,6 +2284,25 @@ func (v *ArrayValue) GetMember(inter *Interpreter, _ func() LocationRange, name
 			),
 		)
 
+	case "removeAll":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				predicate, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.RemoveAll(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					predicate,
+				)
+			},
+			sema.ArrayRemoveAllFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
+
 	case "removeLast":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
@@ -1499,6 +2330,70 @@ func (v *ArrayValue) GetMember(inter *Interpreter, _ func() LocationRange, name
 			),
 		)
 
+	case "at":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				indexValue := invocation.Arguments[0].(NumberValue)
+
+				// Indices of big number types may not fit into an int,
+				// so check them against the bounds before converting them,
+				// as the conversion would fail with an overflow error
+
+				if bigIndexValue, ok := indexValue.(BigNumberValue); ok {
+					bigIndex := bigIndexValue.ToBigInt()
+					if bigIndex.Sign() < 0 || bigIndex.Cmp(big.NewInt(int64(v.Count()))) >= 0 {
+						return NilValue{}
+					}
+				}
+
+				return v.At(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					indexValue.ToInt(),
+				)
+			},
+			sema.ArrayAtFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
+
+	case "toVariableSized":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.ToVariableSized(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+				)
+			},
+			sema.ArrayToVariableSizedFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
+
+	case "toConstantSized":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				constantSizedType, ok := typeParameterPair.Value.(*sema.ConstantSizedType)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.ToConstantSized(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					ConvertSemaArrayTypeToStaticArrayType(constantSizedType).(ConstantSizedStaticType),
+				)
+			},
+			sema.ArrayToConstantSizedFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
+
 	case "slice":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
@@ -1515,6 +2410,103 @@ func (v *ArrayValue) GetMember(inter *Interpreter, _ func() LocationRange, name
 				v.SemaType(inter).ElementType(false),
 			),
 		)
+
+	case "map":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				transform, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Map(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					transform,
+					ConvertSemaToStaticType(typeParameterPair.Value),
+				)
+			},
+			sema.ArrayMapFunctionType(
+				v.SemaType(inter),
+			),
+		)
+
+	case "filter":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				predicate, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Filter(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					predicate,
+				)
+			},
+			sema.ArrayFilterFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
+
+	case "reduce":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				combine, ok := invocation.Arguments[1].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Reduce(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					invocation.Arguments[0],
+					typeParameterPair.Value,
+					combine,
+				)
+			},
+			sema.ArrayReduceFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
+
+	case "reverse":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Reverse(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+				)
+			},
+			sema.ArrayReverseFunctionType(
+				v.SemaType(inter),
+			),
+		)
+
+	case "firstIndex":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.FirstIndex(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					invocation.Arguments[0],
+				)
+			},
+			sema.ArrayFirstIndexFunctionType(
+				v.SemaType(inter).ElementType(false),
+			),
+		)
 	}
 
 	return nil
@@ -1653,10 +2645,10 @@ func (v *ArrayValue) Transfer(
 					return nil, nil
 				}
 
-				element := MustConvertStoredValue(value).
+				element := v.storedElement(value).
 					Transfer(interpreter, getLocationRange, address, remove, nil)
 
-				return element, nil
+				return arrayElementAtreeValue(v.Type, element), nil
 			},
 		)
 		if err != nil {
@@ -1727,10 +2719,10 @@ func (v *ArrayValue) Clone(interpreter *Interpreter) Value {
 				return nil, nil
 			}
 
-			element := MustConvertStoredValue(value).
+			element := v.storedElement(value).
 				Clone(interpreter)
 
-			return element, nil
+			return arrayElementAtreeValue(v.Type, element), nil
 		},
 	)
 	if err != nil {
@@ -1752,7 +2744,7 @@ func (v *ArrayValue) DeepRemove(interpreter *Interpreter) {
 	storage := v.array.Storage
 
 	err := v.array.PopIterate(func(storable atree.Storable) {
-		value := StoredValue(storable, storage)
+		value := v.storedElementFromStorable(storable, storage)
 		value.DeepRemove(interpreter)
 		interpreter.RemoveReferencedSlab(storable)
 	})
@@ -1790,31 +2782,334 @@ func (v *ArrayValue) IsResourceKinded(interpreter *Interpreter) bool {
 	return *v.isResourceKinded
 }
 
-func (v *ArrayValue) Slice(
+// ToVariableSized returns a new variable-sized array
+// with the elements of the array.
+//
+func (v *ArrayValue) ToVariableSized(
 	interpreter *Interpreter,
-	from IntValue,
-	to IntValue,
 	getLocationRange func() LocationRange,
-) Value {
-	fromIndex := from.ToInt()
-	toIndex := to.ToInt()
-
-	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
-	// atree's Array.RangeIterator function will check the upper bound and report an atree.SliceOutOfBoundsError
+) *ArrayValue {
+	return v.copyWithType(
+		interpreter,
+		getLocationRange,
+		VariableSizedStaticType{
+			Type: v.Type.ElementType(),
+		},
+	)
+}
 
-	if fromIndex < 0 || toIndex < 0 {
-		panic(ArraySliceIndicesError{
-			FromIndex:     fromIndex,
-			UpToIndex:     toIndex,
-			Size:          v.Count(),
-			LocationRange: getLocationRange(),
-		})
+// ToConstantSized returns a new constant-sized array of the given type
+// with the elements of the array, or nil if the number of elements
+// does not match the size of the given type.
+//
+func (v *ArrayValue) ToConstantSized(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	staticType ConstantSizedStaticType,
+) OptionalValue {
+	if int64(v.Count()) != staticType.Size {
+		return NilValue{}
 	}
 
-	iterator, err := v.array.RangeIterator(uint64(fromIndex), uint64(toIndex))
-	if err != nil {
+	return NewSomeValueNonCopying(
+		v.copyWithType(
+			interpreter,
+			getLocationRange,
+			staticType,
+		),
+	)
+}
 
-		switch err.(type) {
+func (v *ArrayValue) copyWithType(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	staticType ArrayStaticType,
+) *ArrayValue {
+
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		staticType,
+		common.Address{},
+		func() Value {
+
+			atreeValue, err := iterator.Next()
+			if err != nil {
+				panic(ExternalError{err})
+			}
+
+			if atreeValue == nil {
+				return nil
+			}
+
+			return v.storedElement(atreeValue).
+				Transfer(
+					interpreter,
+					getLocationRange,
+					atree.Address{},
+					false,
+					nil,
+				)
+		},
+	)
+}
+
+// Map returns a new array which contains the results of calling the given function
+// on each element of the array. The result of a constant-sized array is a constant-sized array.
+//
+func (v *ArrayValue) Map(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	transform FunctionValue,
+	resultElementType StaticType,
+) *ArrayValue {
+
+	argumentTypes := []sema.Type{
+		v.SemaType(interpreter).ElementType(false),
+	}
+
+	var resultType ArrayStaticType
+	switch arrayType := v.Type.(type) {
+	case ConstantSizedStaticType:
+		resultType = ConstantSizedStaticType{
+			Type: resultElementType,
+			Size: arrayType.Size,
+		}
+
+	case VariableSizedStaticType:
+		resultType = VariableSizedStaticType{
+			Type: resultElementType,
+		}
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(ExternalError{err})
+	}
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		resultType,
+		common.Address{},
+		func() Value {
+
+			atreeValue, err := iterator.Next()
+			if err != nil {
+				panic(ExternalError{err})
+			}
+
+			if atreeValue == nil {
+				return nil
+			}
+
+			interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+			element := v.storedElement(atreeValue).
+				Transfer(
+					interpreter,
+					getLocationRange,
+					atree.Address{},
+					false,
+					nil,
+				)
+
+			return transform.invoke(Invocation{
+				Arguments:        []Value{element},
+				ArgumentTypes:    argumentTypes,
+				GetLocationRange: getLocationRange,
+				Interpreter:      interpreter,
+			})
+		},
+	)
+}
+
+// Filter returns a new variable-sized array which contains the elements
+// which satisfy the given predicate.
+//
+func (v *ArrayValue) Filter(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	predicate FunctionValue,
+) *ArrayValue {
+	elementType := v.SemaType(interpreter).ElementType(false)
+
+	var filtered []Value
+
+	v.Iterate(func(element Value) (resume bool) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		if v.satisfiesPredicate(interpreter, getLocationRange, predicate, elementType, element) {
+			filtered = append(
+				filtered,
+				element.Transfer(
+					interpreter,
+					getLocationRange,
+					atree.Address{},
+					false,
+					nil,
+				),
+			)
+		}
+
+		// continue iteration
+		return true
+	})
+
+	return NewArrayValue(
+		interpreter,
+		VariableSizedStaticType{
+			Type: v.Type.ElementType(),
+		},
+		common.Address{},
+		filtered...,
+	)
+}
+
+// Reduce combines the elements of the array, from first to last,
+// by calling the given function with the accumulated result and the next element.
+//
+func (v *ArrayValue) Reduce(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	initial Value,
+	resultType sema.Type,
+	combine FunctionValue,
+) Value {
+
+	argumentTypes := []sema.Type{
+		resultType,
+		v.SemaType(interpreter).ElementType(false),
+	}
+
+	result := initial
+
+	v.Iterate(func(element Value) (resume bool) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		element = element.Transfer(
+			interpreter,
+			getLocationRange,
+			atree.Address{},
+			false,
+			nil,
+		)
+
+		result = combine.invoke(Invocation{
+			Arguments:        []Value{result, element},
+			ArgumentTypes:    argumentTypes,
+			GetLocationRange: getLocationRange,
+			Interpreter:      interpreter,
+		})
+
+		// continue iteration
+		return true
+	})
+
+	return result
+}
+
+// Reverse returns a new array of the same type
+// which contains the elements of the array in reverse order.
+//
+func (v *ArrayValue) Reverse(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+) *ArrayValue {
+
+	index := v.Count() - 1
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		v.Type,
+		common.Address{},
+		func() Value {
+			if index < 0 {
+				return nil
+			}
+
+			interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+			element := v.Get(interpreter, getLocationRange, index)
+			index--
+
+			return element.Transfer(
+				interpreter,
+				getLocationRange,
+				atree.Address{},
+				false,
+				nil,
+			)
+		},
+	)
+}
+
+// FirstIndex returns the index of the first element which is equal to the given value,
+// or nil if the array does not contain the value.
+//
+func (v *ArrayValue) FirstIndex(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	needleValue Value,
+) OptionalValue {
+
+	needleEquatable, ok := needleValue.(EquatableValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	var result OptionalValue = NilValue{}
+
+	index := 0
+	v.Iterate(func(element Value) (resume bool) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		if needleEquatable.Equal(interpreter, getLocationRange, element) {
+			result = NewSomeValueNonCopying(NewIntValueFromInt64(int64(index)))
+			// stop iteration
+			return false
+		}
+
+		index++
+
+		// continue iteration
+		return true
+	})
+
+	return result
+}
+
+func (v *ArrayValue) Slice(
+	interpreter *Interpreter,
+	from IntValue,
+	to IntValue,
+	getLocationRange func() LocationRange,
+) Value {
+	fromIndex := from.ToInt()
+	toIndex := to.ToInt()
+
+	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
+	// atree's Array.RangeIterator function will check the upper bound and report an atree.SliceOutOfBoundsError
+
+	if fromIndex < 0 || toIndex < 0 {
+		panic(ArraySliceIndicesError{
+			FromIndex:     fromIndex,
+			UpToIndex:     toIndex,
+			Size:          v.Count(),
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	iterator, err := v.array.RangeIterator(uint64(fromIndex), uint64(toIndex))
+	if err != nil {
+
+		switch err.(type) {
 		case *atree.SliceOutOfBoundsError:
 			panic(ArraySliceIndicesError{
 				FromIndex:     fromIndex,
@@ -1850,7 +3145,7 @@ func (v *ArrayValue) Slice(
 			}
 
 			if atreeValue != nil {
-				value = MustConvertStoredValue(atreeValue)
+				value = v.storedElement(atreeValue)
 			}
 
 			if value == nil {
@@ -1890,40 +3185,160 @@ type NumberValue interface {
 	ToBigEndianBytes() []byte
 }
 
-func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
-	switch name {
+func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
+	switch name {
+
+	case sema.ToStringFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return newMeteredStringValue(invocation.Interpreter, v.String())
+			},
+			sema.ToStringFunctionType,
+		)
+
+	case sema.ToBigEndianBytesFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return ByteSliceToByteArrayValue(
+					invocation.Interpreter,
+					v.ToBigEndianBytes(),
+				)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					sema.ByteArrayType,
+				),
+			},
+		)
+
+	case sema.IntegerTypeTruncatingConvertFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				truncate, ok := truncatingConverters[typeParameterPair.Value.String()]
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return truncate(v)
+			},
+			sema.IntegerTypeTruncatingConvertFunctionType,
+		)
+
+	case sema.NumericTypeSaturatingAddFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(NumberValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.SaturatingPlus(other)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeSaturatingSubtractFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(NumberValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.SaturatingMinus(other)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeSaturatingMultiplyFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(NumberValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.SaturatingMul(other)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeSaturatingDivideFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(NumberValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.SaturatingDiv(other)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+	}
+
+	return nil
+}
+
+// FixedPointValue is a fixed-point number value
+//
+type FixedPointValue interface {
+	NumberValue
+	Abs() NumberValue
+	Pow(exponent uint64) NumberValue
+	Sqrt() NumberValue
+}
 
-	case sema.ToStringFunctionName:
-		return NewHostFunctionValue(
-			func(invocation Invocation) Value {
-				return NewStringValue(v.String())
-			},
-			sema.ToStringFunctionType,
-		)
+func getFixedPointValueMember(v FixedPointValue, name string, typ sema.Type) Value {
+	switch name {
 
-	case sema.ToBigEndianBytesFunctionName:
+	case sema.FixedPointTypeMinFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return ByteSliceToByteArrayValue(
-					invocation.Interpreter,
-					v.ToBigEndianBytes(),
-				)
+				other, ok := invocation.Arguments[0].(NumberValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				if other.Less(v) {
+					return other
+				}
+				return v
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
-					sema.ByteArrayType,
+					typ,
 				),
 			},
 		)
 
-	case sema.NumericTypeSaturatingAddFunctionName:
+	case sema.FixedPointTypeMaxFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
 				other, ok := invocation.Arguments[0].(NumberValue)
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.SaturatingPlus(other)
+				if other.Greater(v) {
+					return other
+				}
+				return v
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -1932,14 +3347,10 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 			},
 		)
 
-	case sema.NumericTypeSaturatingSubtractFunctionName:
+	case sema.FixedPointTypeAbsFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				other, ok := invocation.Arguments[0].(NumberValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
-				}
-				return v.SaturatingMinus(other)
+				return v.Abs()
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -1948,14 +3359,14 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 			},
 		)
 
-	case sema.NumericTypeSaturatingMultiplyFunctionName:
+	case sema.FixedPointTypePowFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				other, ok := invocation.Arguments[0].(NumberValue)
+				exponent, ok := invocation.Arguments[0].(UInt64Value)
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
-				return v.SaturatingMul(other)
+				return v.Pow(uint64(exponent))
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -1964,14 +3375,10 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 			},
 		)
 
-	case sema.NumericTypeSaturatingDivideFunctionName:
+	case sema.FixedPointTypeSqrtFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				other, ok := invocation.Arguments[0].(NumberValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
-				}
-				return v.SaturatingDiv(other)
+				return v.Sqrt()
 			},
 			&sema.FunctionType{
 				ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -1981,7 +3388,25 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 		)
 	}
 
-	return nil
+	return getNumberValueMember(v, name, typ)
+}
+
+// fixedPointPow computes base^exponent by repeated squaring.
+// Every intermediate product is truncated by the multiplication,
+// and overflows and underflows abort.
+//
+func fixedPointPow(base NumberValue, one NumberValue, exponent uint64) NumberValue {
+	result := one
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			result = result.Mul(base)
+		}
+		exponent >>= 1
+		if exponent > 0 {
+			base = base.Mul(base)
+		}
+	}
+	return result
 }
 
 type IntegerValue interface {
@@ -2008,7 +3433,7 @@ type IntValue struct {
 }
 
 func NewIntValueFromInt64(value int64) IntValue {
-	return NewIntValueFromBigInt(big.NewInt(value))
+	return NewIntValueFromBigInt(newSmallBigIntFromInt64(value))
 }
 
 func NewIntValueFromBigInt(value *big.Int) IntValue {
@@ -2089,6 +3514,13 @@ func (v IntValue) Plus(other NumberValue) NumberValue {
 		})
 	}
 
+	// Fast path: the operands and the result fit into an int64
+	if v.BigInt.IsInt64() && o.BigInt.IsInt64() {
+		if result, ok := addInt64(v.BigInt.Int64(), o.BigInt.Int64()); ok {
+			return NewIntValueFromInt64(result)
+		}
+	}
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	return IntValue{res}
@@ -2119,6 +3551,13 @@ func (v IntValue) Minus(other NumberValue) NumberValue {
 		})
 	}
 
+	// Fast path: the operands and the result fit into an int64
+	if v.BigInt.IsInt64() && o.BigInt.IsInt64() {
+		if result, ok := subtractInt64(v.BigInt.Int64(), o.BigInt.Int64()); ok {
+			return NewIntValueFromInt64(result)
+		}
+	}
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	return IntValue{res}
@@ -2149,11 +3588,17 @@ func (v IntValue) Mod(other NumberValue) NumberValue {
 		})
 	}
 
-	res := new(big.Int)
 	// INT33-C
-	if o.BigInt.Cmp(res) == 0 {
+	if o.BigInt.Sign() == 0 {
 		panic(DivisionByZeroError{})
 	}
+
+	// Fast path: the operands fit into an int64
+	if v.BigInt.IsInt64() && o.BigInt.IsInt64() {
+		return NewIntValueFromInt64(remainderInt64(v.BigInt.Int64(), o.BigInt.Int64()))
+	}
+
+	res := new(big.Int)
 	res.Rem(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
@@ -2168,6 +3613,13 @@ func (v IntValue) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	// Fast path: the operands and the result fit into an int64
+	if v.BigInt.IsInt64() && o.BigInt.IsInt64() {
+		if result, ok := multiplyInt64(v.BigInt.Int64(), o.BigInt.Int64()); ok {
+			return NewIntValueFromInt64(result)
+		}
+	}
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	return IntValue{res}
@@ -2198,11 +3650,19 @@ func (v IntValue) Div(other NumberValue) NumberValue {
 		})
 	}
 
-	res := new(big.Int)
 	// INT33-C
-	if o.BigInt.Cmp(res) == 0 {
+	if o.BigInt.Sign() == 0 {
 		panic(DivisionByZeroError{})
 	}
+
+	// Fast path: the operands and the result fit into an int64
+	if v.BigInt.IsInt64() && o.BigInt.IsInt64() {
+		if result, ok := divideInt64Euclidean(v.BigInt.Int64(), o.BigInt.Int64()); ok {
+			return NewIntValueFromInt64(result)
+		}
+	}
+
+	res := new(big.Int)
 	res.Div(v.BigInt, o.BigInt)
 	return IntValue{res}
 }
@@ -5637,7 +7097,7 @@ type UIntValue struct {
 }
 
 func NewUIntValueFromUint64(value uint64) UIntValue {
-	return NewUIntValueFromBigInt(new(big.Int).SetUint64(value))
+	return NewUIntValueFromBigInt(newSmallBigIntFromUint64(value))
 }
 
 func NewUIntValueFromBigInt(value *big.Int) UIntValue {
@@ -5726,6 +7186,13 @@ func (v UIntValue) Plus(other NumberValue) NumberValue {
 		})
 	}
 
+	// Fast path: the operands and the result fit into an uint64
+	if v.BigInt.IsUint64() && o.BigInt.IsUint64() {
+		if result, ok := addUint64(v.BigInt.Uint64(), o.BigInt.Uint64()); ok {
+			return NewUIntValueFromUint64(result)
+		}
+	}
+
 	res := new(big.Int)
 	res.Add(v.BigInt, o.BigInt)
 	return UIntValue{res}
@@ -5756,6 +7223,16 @@ func (v UIntValue) Minus(other NumberValue) NumberValue {
 		})
 	}
 
+	// Fast path: the operands fit into an uint64
+	if v.BigInt.IsUint64() && o.BigInt.IsUint64() {
+		a, b := v.BigInt.Uint64(), o.BigInt.Uint64()
+		// INT30-C
+		if a < b {
+			panic(UnderflowError{})
+		}
+		return NewUIntValueFromUint64(a - b)
+	}
+
 	res := new(big.Int)
 	res.Sub(v.BigInt, o.BigInt)
 	// INT30-C
@@ -5794,11 +7271,17 @@ func (v UIntValue) Mod(other NumberValue) NumberValue {
 		})
 	}
 
-	res := new(big.Int)
 	// INT33-C
-	if o.BigInt.Cmp(res) == 0 {
+	if o.BigInt.Sign() == 0 {
 		panic(DivisionByZeroError{})
 	}
+
+	// Fast path: the operands fit into an uint64
+	if v.BigInt.IsUint64() && o.BigInt.IsUint64() {
+		return NewUIntValueFromUint64(v.BigInt.Uint64() % o.BigInt.Uint64())
+	}
+
+	res := new(big.Int)
 	res.Rem(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
@@ -5813,6 +7296,13 @@ func (v UIntValue) Mul(other NumberValue) NumberValue {
 		})
 	}
 
+	// Fast path: the operands and the result fit into an uint64
+	if v.BigInt.IsUint64() && o.BigInt.IsUint64() {
+		if result, ok := multiplyUint64(v.BigInt.Uint64(), o.BigInt.Uint64()); ok {
+			return NewUIntValueFromUint64(result)
+		}
+	}
+
 	res := new(big.Int)
 	res.Mul(v.BigInt, o.BigInt)
 	return UIntValue{res}
@@ -5843,11 +7333,17 @@ func (v UIntValue) Div(other NumberValue) NumberValue {
 		})
 	}
 
-	res := new(big.Int)
 	// INT33-C
-	if o.BigInt.Cmp(res) == 0 {
+	if o.BigInt.Sign() == 0 {
 		panic(DivisionByZeroError{})
 	}
+
+	// Fast path: the operands fit into an uint64
+	if v.BigInt.IsUint64() && o.BigInt.IsUint64() {
+		return NewUIntValueFromUint64(v.BigInt.Uint64() / o.BigInt.Uint64())
+	}
+
+	res := new(big.Int)
 	res.Div(v.BigInt, o.BigInt)
 	return UIntValue{res}
 }
@@ -9035,8 +10531,22 @@ func (v Word8Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word8Value) SaturatingPlus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word8Value) SaturatingPlus(other NumberValue) NumberValue {
+	o, ok := other.(Word8Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingAddFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	sum := v + o
+	// INT30-C
+	if sum < v {
+		return Word8Value(math.MaxUint8)
+	}
+	return sum
 }
 
 func (v Word8Value) Minus(other NumberValue) NumberValue {
@@ -9052,8 +10562,23 @@ func (v Word8Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word8Value) SaturatingMinus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word8Value) SaturatingMinus(other NumberValue) NumberValue {
+	o, ok := other.(Word8Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingSubtractFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	diff := v - o
+
+	// INT30-C
+	if diff > v {
+		return Word8Value(0)
+	}
+	return diff
 }
 
 func (v Word8Value) Mod(other NumberValue) NumberValue {
@@ -9085,8 +10610,21 @@ func (v Word8Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word8Value) SaturatingMul(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word8Value) SaturatingMul(other NumberValue) NumberValue {
+	o, ok := other.(Word8Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingMultiplyFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	// INT30-C
+	if (v > 0) && (o > 0) && (v > (math.MaxUint8 / o)) {
+		return Word8Value(math.MaxUint8)
+	}
+	return v * o
 }
 
 func (v Word8Value) Div(other NumberValue) NumberValue {
@@ -9383,8 +10921,22 @@ func (v Word16Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word16Value) SaturatingPlus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word16Value) SaturatingPlus(other NumberValue) NumberValue {
+	o, ok := other.(Word16Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingAddFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	sum := v + o
+	// INT30-C
+	if sum < v {
+		return Word16Value(math.MaxUint16)
+	}
+	return sum
 }
 
 func (v Word16Value) Minus(other NumberValue) NumberValue {
@@ -9400,8 +10952,23 @@ func (v Word16Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word16Value) SaturatingMinus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word16Value) SaturatingMinus(other NumberValue) NumberValue {
+	o, ok := other.(Word16Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingSubtractFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	diff := v - o
+
+	// INT30-C
+	if diff > v {
+		return Word16Value(0)
+	}
+	return diff
 }
 
 func (v Word16Value) Mod(other NumberValue) NumberValue {
@@ -9433,8 +11000,21 @@ func (v Word16Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word16Value) SaturatingMul(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word16Value) SaturatingMul(other NumberValue) NumberValue {
+	o, ok := other.(Word16Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingMultiplyFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	// INT30-C
+	if (v > 0) && (o > 0) && (v > (math.MaxUint16 / o)) {
+		return Word16Value(math.MaxUint16)
+	}
+	return v * o
 }
 
 func (v Word16Value) Div(other NumberValue) NumberValue {
@@ -9734,8 +11314,22 @@ func (v Word32Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word32Value) SaturatingPlus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word32Value) SaturatingPlus(other NumberValue) NumberValue {
+	o, ok := other.(Word32Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingAddFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	sum := v + o
+	// INT30-C
+	if sum < v {
+		return Word32Value(math.MaxUint32)
+	}
+	return sum
 }
 
 func (v Word32Value) Minus(other NumberValue) NumberValue {
@@ -9751,8 +11345,23 @@ func (v Word32Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word32Value) SaturatingMinus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word32Value) SaturatingMinus(other NumberValue) NumberValue {
+	o, ok := other.(Word32Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingSubtractFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	diff := v - o
+
+	// INT30-C
+	if diff > v {
+		return Word32Value(0)
+	}
+	return diff
 }
 
 func (v Word32Value) Mod(other NumberValue) NumberValue {
@@ -9784,8 +11393,21 @@ func (v Word32Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word32Value) SaturatingMul(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word32Value) SaturatingMul(other NumberValue) NumberValue {
+	o, ok := other.(Word32Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingMultiplyFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	// INT30-C
+	if (v > 0) && (o > 0) && (v > (math.MaxUint32 / o)) {
+		return Word32Value(math.MaxUint32)
+	}
+	return v * o
 }
 
 func (v Word32Value) Div(other NumberValue) NumberValue {
@@ -10106,8 +11728,22 @@ func (v Word64Value) Plus(other NumberValue) NumberValue {
 	return v + o
 }
 
-func (v Word64Value) SaturatingPlus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word64Value) SaturatingPlus(other NumberValue) NumberValue {
+	o, ok := other.(Word64Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingAddFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	sum := v + o
+	// INT30-C
+	if sum < v {
+		return Word64Value(math.MaxUint64)
+	}
+	return sum
 }
 
 func (v Word64Value) Minus(other NumberValue) NumberValue {
@@ -10123,8 +11759,23 @@ func (v Word64Value) Minus(other NumberValue) NumberValue {
 	return v - o
 }
 
-func (v Word64Value) SaturatingMinus(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word64Value) SaturatingMinus(other NumberValue) NumberValue {
+	o, ok := other.(Word64Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingSubtractFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	diff := v - o
+
+	// INT30-C
+	if diff > v {
+		return Word64Value(0)
+	}
+	return diff
 }
 
 func (v Word64Value) Mod(other NumberValue) NumberValue {
@@ -10156,8 +11807,21 @@ func (v Word64Value) Mul(other NumberValue) NumberValue {
 	return v * o
 }
 
-func (v Word64Value) SaturatingMul(_ NumberValue) NumberValue {
-	panic(errors.UnreachableError{})
+func (v Word64Value) SaturatingMul(other NumberValue) NumberValue {
+	o, ok := other.(Word64Value)
+	if !ok {
+		panic(InvalidOperandsError{
+			FunctionName: sema.NumericTypeSaturatingMultiplyFunctionName,
+			LeftType:     v.StaticType(),
+			RightType:    other.StaticType(),
+		})
+	}
+
+	// INT30-C
+	if (v > 0) && (o > 0) && (v > (math.MaxUint64 / o)) {
+		return Word64Value(math.MaxUint64)
+	}
+	return v * o
 }
 
 func (v Word64Value) Div(other NumberValue) NumberValue {
@@ -10418,6 +12082,7 @@ func NewFix64ValueWithInteger(integer int64) Fix64Value {
 var _ Value = Fix64Value(0)
 var _ atree.Storable = Fix64Value(0)
 var _ NumberValue = Fix64Value(0)
+var _ FixedPointValue = Fix64Value(0)
 var _ EquatableValue = Fix64Value(0)
 var _ HashableValue = Fix64Value(0)
 var _ MemberAccessibleValue = Fix64Value(0)
@@ -10462,6 +12127,29 @@ func (v Fix64Value) Negate() NumberValue {
 	return -v
 }
 
+func (v Fix64Value) Abs() NumberValue {
+	if v < 0 {
+		return v.Negate()
+	}
+	return v
+}
+
+func (v Fix64Value) Pow(exponent uint64) NumberValue {
+	return fixedPointPow(v, Fix64Value(sema.Fix64Factor), exponent)
+}
+
+func (v Fix64Value) Sqrt() NumberValue {
+	if v < 0 {
+		panic(NegativeSquareRootError{})
+	}
+
+	result := new(big.Int).SetInt64(int64(v))
+	result.Mul(result, sema.Fix64FactorBig)
+	result.Sqrt(result)
+
+	return Fix64Value(result.Int64())
+}
+
 func (v Fix64Value) Plus(other NumberValue) NumberValue {
 	o, ok := other.(Fix64Value)
 	if !ok {
@@ -10763,7 +12451,7 @@ func ConvertFix64(value Value) Fix64Value {
 }
 
 func (v Fix64Value) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
-	return getNumberValueMember(v, name, sema.Fix64Type)
+	return getFixedPointValueMember(v, name, sema.Fix64Type)
 }
 
 func (Fix64Value) RemoveMember(_ *Interpreter, _ func() LocationRange, _ string) Value {
@@ -10858,6 +12546,7 @@ func NewUFix64ValueWithInteger(integer uint64) UFix64Value {
 var _ Value = UFix64Value(0)
 var _ atree.Storable = UFix64Value(0)
 var _ NumberValue = UFix64Value(0)
+var _ FixedPointValue = UFix64Value(0)
 var _ EquatableValue = UFix64Value(0)
 var _ HashableValue = UFix64Value(0)
 var _ MemberAccessibleValue = UFix64Value(0)
@@ -10898,6 +12587,22 @@ func (v UFix64Value) Negate() NumberValue {
 	panic(errors.NewUnreachableError())
 }
 
+func (v UFix64Value) Abs() NumberValue {
+	return v
+}
+
+func (v UFix64Value) Pow(exponent uint64) NumberValue {
+	return fixedPointPow(v, UFix64Value(sema.Fix64Factor), exponent)
+}
+
+func (v UFix64Value) Sqrt() NumberValue {
+	result := new(big.Int).SetUint64(uint64(v))
+	result.Mul(result, sema.Fix64FactorBig)
+	result.Sqrt(result)
+
+	return UFix64Value(result.Uint64())
+}
+
 func (v UFix64Value) Plus(other NumberValue) NumberValue {
 	o, ok := other.(UFix64Value)
 	if !ok {
@@ -11182,7 +12887,7 @@ func ConvertUFix64(value Value) UFix64Value {
 }
 
 func (v UFix64Value) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
-	return getNumberValueMember(v, name, sema.UFix64Type)
+	return getFixedPointValueMember(v, name, sema.UFix64Type)
 }
 
 func (UFix64Value) RemoveMember(_ *Interpreter, _ func() LocationRange, _ string) Value {
@@ -11277,6 +12982,12 @@ type CompositeValue struct {
 	typeID              common.TypeID
 	staticType          StaticType
 	dynamicType         DynamicType
+	// enumHashInput is the cached hash input of an enum value,
+	// which is initialized lazily, see HashInput
+	enumHashInput []byte
+	// base is the reference to the value an attachment is attached to.
+	// It is only set in-memory, when the attachment is accessed
+	base *EphemeralReferenceValue
 }
 
 type ComputedField func(*Interpreter, func() LocationRange) Value
@@ -11316,6 +13027,12 @@ func NewCompositeValue(
 		Kind:                kind,
 	}
 
+	interpreter.reportComputation(
+		common.ComputationKindValueAllocation,
+		1+uint(len(fields)),
+	)
+	interpreter.meterContainerMemory(common.MemoryKindComposite, len(fields))
+
 	for _, field := range fields {
 		v.SetMember(
 			interpreter,
@@ -11343,16 +13060,16 @@ func (v *CompositeValue) Accept(interpreter *Interpreter, visitor Visitor) {
 		return
 	}
 
-	v.ForEachField(func(_ string, value Value) {
+	v.forEachStoredValue(func(_ string, value Value) {
 		value.Accept(interpreter, visitor)
 	})
 }
 
-// Walk iterates over all field values of the composite value.
+// Walk iterates over all field values and attachments of the composite value.
 // It does NOT walk the computed fields and functions!
 //
 func (v *CompositeValue) Walk(walkChild func(Value)) {
-	v.ForEachField(func(_ string, value Value) {
+	v.forEachStoredValue(func(_ string, value Value) {
 		walkChild(value)
 	})
 }
@@ -11415,7 +13132,38 @@ func (v *CompositeValue) Destroy(interpreter *Interpreter, getLocationRange func
 		destructor.invoke(invocation)
 	}
 
+	// Destroy the attachments after the destructor,
+	// so they are still accessible in it
+
+	var attachments []*CompositeValue
+	v.ForEachAttachment(func(attachment *CompositeValue) {
+		attachments = append(attachments, attachment)
+	})
+
+	for _, attachment := range attachments {
+		interpreter.setAttachmentBase(attachment, v)
+		attachment.Destroy(interpreter, getLocationRange)
+	}
+
 	v.isDestroyed = true
+
+	// Also invalidate all other instances of the value which are referenced.
+	// For example, attachments are loaded from their base value on each access,
+	// so references to an attachment refer to a different instance than the destroyed one
+
+	storageID := v.StorageID()
+
+	interpreter.updateReferencedResource(
+		storageID,
+		storageID,
+		func(value ReferenceTrackedResourceKindedValue) {
+			compositeValue, ok := value.(*CompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			compositeValue.isDestroyed = true
+		},
+	)
 }
 
 func (v *CompositeValue) GetMember(interpreter *Interpreter, getLocationRange func() LocationRange, name string) Value {
@@ -11509,6 +13257,23 @@ func (v *CompositeValue) InitializeFunctions(interpreter *Interpreter) {
 	v.Functions = interpreter.typeCodes.CompositeCodes[v.TypeID()].CompositeFunctions
 }
 
+// typeCodeFunction returns the function with the given name
+// declared by the composite type of the value, if any.
+//
+// Unlike GetMember, the fields of the value are not considered,
+// and the function is not bound to the value.
+//
+func (v *CompositeValue) typeCodeFunction(interpreter *Interpreter, name string) FunctionValue {
+	interpreter = v.getInterpreter(interpreter)
+
+	code, ok := interpreter.typeCodes.CompositeCodes[v.TypeID()]
+	if !ok {
+		return nil
+	}
+
+	return code.CompositeFunctions[name]
+}
+
 func (v *CompositeValue) OwnerValue(interpreter *Interpreter, getLocationRange func() LocationRange) OptionalValue {
 	address := v.StorageID().Address
 
@@ -11530,6 +13295,8 @@ func (v *CompositeValue) RemoveMember(
 	name string,
 ) Value {
 
+	interpreter.checkStoredValueWrite(v.dictionary.Address(), "remove field", getLocationRange)
+
 	// No need to clean up storable for passed-in key value,
 	// as atree never calls Storable()
 	existingKeyStorable, existingValueStorable, err := v.dictionary.Remove(
@@ -11571,6 +13338,8 @@ func (v *CompositeValue) SetMember(
 ) {
 	address := v.StorageID().Address
 
+	interpreter.checkStoredValueWrite(address, "set field", getLocationRange)
+
 	value = value.Transfer(
 		interpreter,
 		getLocationRange,
@@ -11712,6 +13481,14 @@ func (v *CompositeValue) Equal(interpreter *Interpreter, getLocationRange func()
 // - hash input of raw value field name (n bytes)
 func (v *CompositeValue) HashInput(interpreter *Interpreter, getLocationRange func() LocationRange, scratch []byte) []byte {
 	if v.Kind == common.CompositeKindEnum {
+
+		// The hash input is cached, as the type and the raw value of an enum case never change,
+		// and determining the raw value requires a lookup of the field
+
+		if v.enumHashInput != nil {
+			return v.enumHashInput
+		}
+
 		typeID := v.TypeID()
 
 		rawValue := v.GetField(sema.EnumRawValueFieldName)
@@ -11719,20 +13496,14 @@ func (v *CompositeValue) HashInput(interpreter *Interpreter, getLocationRange fu
 			HashInput(interpreter, getLocationRange, scratch)
 
 		length := 1 + len(typeID) + len(rawValueHashInput)
-		if length <= len(scratch) {
-			// Copy rawValueHashInput first because
-			// rawValueHashInput and scratch can point to the same underlying scratch buffer
-			copy(scratch[1+len(typeID):], rawValueHashInput)
-
-			scratch[0] = byte(HashInputTypeEnum)
-			copy(scratch[1:], typeID)
-			return scratch[:length]
-		}
 
 		buffer := make([]byte, length)
 		buffer[0] = byte(HashInputTypeEnum)
 		copy(buffer[1:], typeID)
 		copy(buffer[1+len(typeID):], rawValueHashInput)
+
+		v.enumHashInput = buffer
+
 		return buffer
 	}
 
@@ -11770,7 +13541,7 @@ func (v *CompositeValue) ConformsToDynamicType(
 		return false
 	}
 
-	fieldsLen := int(v.dictionary.Count())
+	fieldsLen := v.fieldCount()
 	if v.ComputedFields != nil {
 		fieldsLen += len(v.ComputedFields)
 	}
@@ -11820,7 +13591,7 @@ func (v *CompositeValue) ConformsToDynamicType(
 
 func (v *CompositeValue) IsStorable() bool {
 
-	// Only structures, resources, enums, and contracts can be stored.
+	// Only structures, resources, enums, attachments, and contracts can be stored.
 	// Contracts are not directly storable by programs,
 	// but they are still stored in storage by the interpreter
 
@@ -11828,6 +13599,7 @@ func (v *CompositeValue) IsStorable() bool {
 	case common.CompositeKindStructure,
 		common.CompositeKindResource,
 		common.CompositeKindEnum,
+		common.CompositeKindAttachment,
 		common.CompositeKindContract:
 		break
 	default:
@@ -11850,10 +13622,29 @@ func (v *CompositeValue) NeedsStoreTo(address atree.Address) bool {
 	return address != v.StorageID().Address
 }
 
-func (v *CompositeValue) IsResourceKinded(_ *Interpreter) bool {
+func (v *CompositeValue) IsResourceKinded(interpreter *Interpreter) bool {
+	if v.Kind == common.CompositeKindAttachment {
+		// Attachments are resources if they extend a resource
+		return v.semaType(interpreter).IsResourceType()
+	}
+
 	return v.Kind == common.CompositeKindResource
 }
 
+func (v *CompositeValue) semaType(interpreter *Interpreter) *sema.CompositeType {
+	dynamicType, ok := v.DynamicType(interpreter, SeenReferences{}).(CompositeDynamicType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	compositeType, ok := dynamicType.StaticType.(*sema.CompositeType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return compositeType
+}
+
 func (v *CompositeValue) IsReferenceTrackedResourceKindedValue() {}
 
 func (v *CompositeValue) Transfer(
@@ -12042,6 +13833,25 @@ func (v *CompositeValue) Clone(interpreter *Interpreter) Value {
 	}
 }
 
+// UnboundCopy returns a new composite value which shares the stored fields of this value,
+// but not the functions, injected fields, and nested variables,
+// which are bound by the interpreter that loaded or created the value.
+//
+func (v *CompositeValue) UnboundCopy() *CompositeValue {
+	return &CompositeValue{
+		dictionary:          v.dictionary,
+		Location:            v.Location,
+		QualifiedIdentifier: v.QualifiedIdentifier,
+		Kind:                v.Kind,
+		ComputedFields:      v.ComputedFields,
+		Destructor:          v.Destructor,
+		Stringer:            v.Stringer,
+		isDestroyed:         v.isDestroyed,
+		typeID:              v.typeID,
+		staticType:          v.staticType,
+	}
+}
+
 func (v *CompositeValue) DeepRemove(interpreter *Interpreter) {
 
 	// Remove nested values and storables
@@ -12068,9 +13878,38 @@ func (v *CompositeValue) GetOwner() common.Address {
 }
 
 // ForEachField iterates over all field-name field-value pairs of the composite value.
-// It does NOT iterate over computed fields and functions!
+// It does NOT iterate over computed fields, functions, and attachments!
 //
 func (v *CompositeValue) ForEachField(f func(fieldName string, fieldValue Value)) {
+	v.forEachStoredValue(func(name string, value Value) {
+		if isAttachmentKey(name) {
+			return
+		}
+		f(name, value)
+	})
+}
+
+// ForEachAttachment iterates over all attachments of the composite value.
+//
+func (v *CompositeValue) ForEachAttachment(f func(attachment *CompositeValue)) {
+	v.forEachStoredValue(func(name string, value Value) {
+		if !isAttachmentKey(name) {
+			return
+		}
+
+		attachment, ok := value.(*CompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		f(attachment)
+	})
+}
+
+// forEachStoredValue iterates over all values stored in the composite value,
+// i.e. the fields and the attachments.
+//
+func (v *CompositeValue) forEachStoredValue(f func(name string, value Value)) {
 	err := v.dictionary.Iterate(func(key atree.Value, value atree.Value) (resume bool, err error) {
 		f(
 			string(key.(stringAtreeValue)),
@@ -12083,16 +13922,71 @@ func (v *CompositeValue) ForEachField(f func(fieldName string, fieldValue Value)
 	}
 }
 
+// fieldCount returns the number of stored fields, i.e. excluding attachments.
+//
+func (v *CompositeValue) fieldCount() int {
+	count := 0
+	err := v.dictionary.Iterate(func(key atree.Value, _ atree.Value) (resume bool, err error) {
+		if !isAttachmentKey(string(key.(stringAtreeValue))) {
+			count++
+		}
+		return true, nil
+	})
+	if err != nil {
+		panic(ExternalError{err})
+	}
+	return count
+}
+
+// attachmentKeyPrefix is the prefix of the keys under which attachments
+// are stored in the composite value they are attached to.
+// It is not valid in identifiers, so attachments never clash with fields.
+//
+const attachmentKeyPrefix = "$"
+
+func attachmentKey(typeID common.TypeID) string {
+	return attachmentKeyPrefix + string(typeID)
+}
+
+func isAttachmentKey(name string) bool {
+	return strings.HasPrefix(name, attachmentKeyPrefix)
+}
+
+// GetAttachment returns the attachment with the given type ID,
+// or nil if the composite value has no such attachment.
+//
+func (v *CompositeValue) GetAttachment(typeID common.TypeID) *CompositeValue {
+	attachment, _ := v.GetField(attachmentKey(typeID)).(*CompositeValue)
+	return attachment
+}
+
+// SetAttachment attaches the given attachment to the composite value.
+//
+func (v *CompositeValue) SetAttachment(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	attachment *CompositeValue,
+) {
+	v.SetMember(
+		interpreter,
+		getLocationRange,
+		attachmentKey(attachment.TypeID()),
+		attachment,
+	)
+}
+
 func (v *CompositeValue) StorageID() atree.StorageID {
 	return v.dictionary.StorageID()
 }
 
 func (v *CompositeValue) RemoveField(
 	interpreter *Interpreter,
-	_ func() LocationRange,
+	getLocationRange func() LocationRange,
 	name string,
 ) {
 
+	interpreter.checkStoredValueWrite(v.dictionary.Address(), "remove field", getLocationRange)
+
 	existingKeyStorable, existingValueStorable, err := v.dictionary.Remove(
 		stringAtreeComparator,
 		stringAtreeHashInput,
@@ -12150,7 +14044,20 @@ func NewEnumCaseValue(
 }
 
 // DictionaryValue
-
+//
+// The entries of a dictionary are iterated in a deterministic order,
+// e.g. by Iterate, the `keys` and `values` fields, and when the dictionary is exported.
+//
+// The order is the order of the underlying atree ordered map:
+// The entries are ordered by the digests of their keys,
+// which are seeded with the storage ID of the dictionary.
+// The order therefore does not depend on the order in which entries are inserted or removed,
+// apart from keys with colliding digests, which are ordered by insertion.
+// A transfer, e.g. a move to another account or a copy, may change the order.
+//
+// Programs are executed in consensus, so the order must not change.
+// It is pinned by the tests in tests/interpreter/dictionary_order_test.go.
+//
 type DictionaryValue struct {
 	Type             DictionaryStaticType
 	semaType         *sema.DictionaryType
@@ -12199,6 +14106,14 @@ func NewDictionaryValueWithAddress(
 		dictionary: dictionary,
 	}
 
+	interpreter.reportComputation(
+		common.ComputationKindValueAllocation,
+		1+uint(keysAndValuesCount/2),
+	)
+
+	// The memory usage of the entries is metered when they are inserted
+	interpreter.meterContainerMemory(common.MemoryKindDictionary, 0)
+
 	for i := 0; i < keysAndValuesCount; i += 2 {
 		key := keysAndValues[i]
 		value := keysAndValues[i+1]
@@ -12230,6 +14145,8 @@ func (v *DictionaryValue) Accept(interpreter *Interpreter, visitor Visitor) {
 	})
 }
 
+// Iterate iterates over the entries of the dictionary, in the deterministic order of the dictionary.
+//
 func (v *DictionaryValue) Iterate(f func(key, value Value) (resume bool)) {
 	err := v.dictionary.Iterate(func(key, value atree.Value) (resume bool, err error) {
 		// atree.OrderedMap iteration provides low-level atree.Value,
@@ -12247,6 +14164,36 @@ func (v *DictionaryValue) Iterate(f func(key, value Value) (resume bool)) {
 	}
 }
 
+// IterateKeys iterates over the keys of the dictionary, in the deterministic order of the dictionary.
+// The keys are loaded lazily, i.e. the values are not loaded and the keys are not collected.
+//
+func (v *DictionaryValue) IterateKeys(f func(key Value) (resume bool)) {
+	err := v.dictionary.IterateKeys(func(key atree.Value) (resume bool, err error) {
+		// atree.OrderedMap iteration provides low-level atree.Value,
+		// convert to high-level interpreter.Value
+
+		return f(MustConvertStoredValue(key)), nil
+	})
+	if err != nil {
+		panic(ExternalError{err})
+	}
+}
+
+// IterateValues iterates over the values of the dictionary, in the deterministic order of the dictionary.
+// The values are loaded lazily, i.e. the values are not collected.
+//
+func (v *DictionaryValue) IterateValues(f func(value Value) (resume bool)) {
+	err := v.dictionary.IterateValues(func(value atree.Value) (resume bool, err error) {
+		// atree.OrderedMap iteration provides low-level atree.Value,
+		// convert to high-level interpreter.Value
+
+		return f(MustConvertStoredValue(value)), nil
+	})
+	if err != nil {
+		panic(ExternalError{err})
+	}
+}
+
 func (v *DictionaryValue) Walk(walkChild func(Value)) {
 	v.Iterate(func(key, value Value) (resume bool) {
 		walkChild(key)
@@ -12507,6 +14454,67 @@ func (v *DictionaryValue) GetMember(
 			),
 		)
 
+	case "filter":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				predicate, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Filter(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					predicate,
+				)
+			},
+			sema.DictionaryFilterFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
+	case "forEachKey":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				function, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				v.ForEachKey(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					function,
+				)
+
+				return VoidValue{}
+			},
+			sema.DictionaryForEachKeyFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
+	case "forEachValue":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				function, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				v.ForEachValue(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					function,
+				)
+
+				return VoidValue{}
+			},
+			sema.DictionaryForEachValueFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
 	case "containsKey":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
@@ -12521,23 +14529,137 @@ func (v *DictionaryValue) GetMember(
 			),
 		)
 
-	}
+	}
+
+	return nil
+}
+
+func (*DictionaryValue) RemoveMember(_ *Interpreter, _ func() LocationRange, _ string) Value {
+	// Dictionaries have no removable members (fields / functions)
+	panic(errors.NewUnreachableError())
+}
+
+func (*DictionaryValue) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
+	// Dictionaries have no settable members (fields / functions)
+	panic(errors.NewUnreachableError())
+}
+
+func (v *DictionaryValue) Count() int {
+	return int(v.dictionary.Count())
+}
+
+// Filter returns a new dictionary which contains the key-value pairs
+// which satisfy the given predicate.
+//
+func (v *DictionaryValue) Filter(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	predicate FunctionValue,
+) Value {
+	dictionaryType := v.SemaType(interpreter)
+	argumentTypes := []sema.Type{
+		dictionaryType.KeyType,
+		dictionaryType.ValueType,
+	}
+
+	var keysAndValues []Value
+
+	v.Iterate(func(key, value Value) (resume bool) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		key = key.Transfer(interpreter, getLocationRange, atree.Address{}, false, nil)
+		value = value.Transfer(interpreter, getLocationRange, atree.Address{}, false, nil)
+
+		result := predicate.invoke(Invocation{
+			Arguments:        []Value{key, value},
+			ArgumentTypes:    argumentTypes,
+			GetLocationRange: getLocationRange,
+			Interpreter:      interpreter,
+		})
+
+		if result.(BoolValue) {
+			keysAndValues = append(keysAndValues, key, value)
+		}
 
-	return nil
-}
+		// continue iteration
+		return true
+	})
 
-func (*DictionaryValue) RemoveMember(_ *Interpreter, _ func() LocationRange, _ string) Value {
-	// Dictionaries have no removable members (fields / functions)
-	panic(errors.NewUnreachableError())
+	return NewDictionaryValue(
+		interpreter,
+		v.Type,
+		keysAndValues...,
+	)
 }
 
-func (*DictionaryValue) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
-	// Dictionaries have no settable members (fields / functions)
-	panic(errors.NewUnreachableError())
+// ForEachKey calls the given function for each key of the dictionary,
+// until the function returns false.
+//
+// The keys are iterated lazily, in the order of the underlying atree map,
+// so the remaining entries are not loaded when the function returns false.
+//
+func (v *DictionaryValue) ForEachKey(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	function FunctionValue,
+) {
+	argumentTypes := []sema.Type{
+		v.SemaType(interpreter).KeyType,
+	}
+
+	err := v.dictionary.IterateKeys(func(key atree.Value) (resume bool, err error) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		argument := MustConvertStoredValue(key).
+			Transfer(interpreter, getLocationRange, atree.Address{}, false, nil)
+
+		result := function.invoke(Invocation{
+			Arguments:        []Value{argument},
+			ArgumentTypes:    argumentTypes,
+			GetLocationRange: getLocationRange,
+			Interpreter:      interpreter,
+		})
+
+		return bool(result.(BoolValue)), nil
+	})
+	if err != nil {
+		panic(ExternalError{err})
+	}
 }
 
-func (v *DictionaryValue) Count() int {
-	return int(v.dictionary.Count())
+// ForEachValue calls the given function for each value of the dictionary,
+// until the function returns false.
+//
+// The values are iterated lazily, in the order of the underlying atree map,
+// so the remaining entries are not loaded when the function returns false.
+//
+func (v *DictionaryValue) ForEachValue(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	function FunctionValue,
+) {
+	argumentTypes := []sema.Type{
+		v.SemaType(interpreter).ValueType,
+	}
+
+	err := v.dictionary.IterateValues(func(value atree.Value) (resume bool, err error) {
+		interpreter.meterComputation(common.ComputationKindElementIteration, 1)
+
+		argument := MustConvertStoredValue(value).
+			Transfer(interpreter, getLocationRange, atree.Address{}, false, nil)
+
+		result := function.invoke(Invocation{
+			Arguments:        []Value{argument},
+			ArgumentTypes:    argumentTypes,
+			GetLocationRange: getLocationRange,
+			Interpreter:      interpreter,
+		})
+
+		return bool(result.(BoolValue)), nil
+	})
+	if err != nil {
+		panic(ExternalError{err})
+	}
 }
 
 func (v *DictionaryValue) RemoveKey(
@@ -12554,6 +14676,8 @@ func (v *DictionaryValue) Remove(
 	keyValue Value,
 ) OptionalValue {
 
+	interpreter.checkStoredValueWrite(v.dictionary.Address(), "remove from dictionary", getLocationRange)
+
 	valueComparator := newValueComparator(interpreter, getLocationRange)
 	hashInputProvider := newHashInputProvider(interpreter, getLocationRange)
 
@@ -12613,6 +14737,8 @@ func (v *DictionaryValue) Insert(
 
 	address := v.dictionary.Address()
 
+	interpreter.checkStoredValueWrite(address, "insert into dictionary", getLocationRange)
+
 	keyValue = keyValue.Transfer(
 		interpreter,
 		getLocationRange,
@@ -12646,6 +14772,9 @@ func (v *DictionaryValue) Insert(
 	interpreter.maybeValidateAtreeValue(v.dictionary)
 
 	if existingValueStorable == nil {
+		// The key is a new key, so the dictionary grew by one entry
+		interpreter.meterContainerGrowthMemory(common.MemoryKindDictionary, 1)
+
 		return NilValue{}
 	}
 
@@ -13393,6 +15522,7 @@ func (s SomeStorable) ChildStorables() []atree.Storable {
 
 type StorageReferenceValue struct {
 	Authorized           bool
+	Authorization        *sema.EntitlementSetAccess
 	TargetStorageAddress common.Address
 	TargetPath           PathValue
 	BorrowedType         sema.Type
@@ -13431,9 +15561,10 @@ func (v *StorageReferenceValue) DynamicType(interpreter *Interpreter, seenRefere
 	innerType := (*referencedValue).DynamicType(interpreter, seenReferences)
 
 	return StorageReferenceDynamicType{
-		authorized:   v.Authorized,
-		innerType:    innerType,
-		borrowedType: v.BorrowedType,
+		authorized:    v.Authorized,
+		authorization: v.Authorization,
+		innerType:     innerType,
+		borrowedType:  v.BorrowedType,
 	}
 }
 
@@ -13443,8 +15574,9 @@ func (v *StorageReferenceValue) StaticType() StaticType {
 		borrowedType = ConvertSemaToStaticType(v.BorrowedType)
 	}
 	return ReferenceStaticType{
-		Authorized: v.Authorized,
-		Type:       borrowedType,
+		Authorized:    v.Authorized,
+		Authorization: ConvertSemaEntitlementSetAccessToStaticAuthorization(v.Authorization),
+		Type:          borrowedType,
 	}
 }
 
@@ -13463,6 +15595,7 @@ func (v *StorageReferenceValue) dereference(interpreter *Interpreter, getLocatio
 		if !interpreter.IsSubType(dynamicType, v.BorrowedType) {
 			return nil, ForceCastTypeMismatchError{
 				ExpectedType:  v.BorrowedType,
+				ActualType:    interpreter.errorValueType(referenced),
 				LocationRange: getLocationRange(),
 			}
 		}
@@ -13624,7 +15757,8 @@ func (v *StorageReferenceValue) Equal(_ *Interpreter, _ func() LocationRange, ot
 	if !ok ||
 		v.TargetStorageAddress != otherReference.TargetStorageAddress ||
 		v.TargetPath != otherReference.TargetPath ||
-		v.Authorized != otherReference.Authorized {
+		v.Authorized != otherReference.Authorized ||
+		!v.Authorization.Equal(otherReference.Authorization) {
 
 		return false
 	}
@@ -13645,7 +15779,8 @@ func (v *StorageReferenceValue) ConformsToDynamicType(
 
 	refType, ok := dynamicType.(StorageReferenceDynamicType)
 	if !ok ||
-		refType.authorized != v.Authorized {
+		refType.authorized != v.Authorized ||
+		!refType.authorization.Equal(v.Authorization) {
 
 		return false
 	}
@@ -13703,6 +15838,7 @@ func (v *StorageReferenceValue) Transfer(
 func (v *StorageReferenceValue) Clone(_ *Interpreter) Value {
 	return &StorageReferenceValue{
 		Authorized:           v.Authorized,
+		Authorization:        v.Authorization,
 		TargetStorageAddress: v.TargetStorageAddress,
 		TargetPath:           v.TargetPath,
 		BorrowedType:         v.BorrowedType,
@@ -13716,9 +15852,10 @@ func (*StorageReferenceValue) DeepRemove(_ *Interpreter) {
 // EphemeralReferenceValue
 
 type EphemeralReferenceValue struct {
-	Authorized   bool
-	Value        Value
-	BorrowedType sema.Type
+	Authorized    bool
+	Authorization *sema.EntitlementSetAccess
+	Value         Value
+	BorrowedType  sema.Type
 }
 
 var _ Value = &EphemeralReferenceValue{}
@@ -13767,9 +15904,10 @@ func (v *EphemeralReferenceValue) DynamicType(interpreter *Interpreter, seenRefe
 	innerType := (*referencedValue).DynamicType(interpreter, seenReferences)
 
 	return EphemeralReferenceDynamicType{
-		authorized:   v.Authorized,
-		innerType:    innerType,
-		borrowedType: v.BorrowedType,
+		authorized:    v.Authorized,
+		authorization: v.Authorization,
+		innerType:     innerType,
+		borrowedType:  v.BorrowedType,
 	}
 }
 
@@ -13779,8 +15917,9 @@ func (v *EphemeralReferenceValue) StaticType() StaticType {
 		borrowedType = ConvertSemaToStaticType(v.BorrowedType)
 	}
 	return ReferenceStaticType{
-		Authorized: v.Authorized,
-		Type:       borrowedType,
+		Authorized:    v.Authorized,
+		Authorization: ConvertSemaEntitlementSetAccessToStaticAuthorization(v.Authorization),
+		Type:          borrowedType,
 	}
 }
 
@@ -13946,7 +16085,8 @@ func (v *EphemeralReferenceValue) Equal(_ *Interpreter, _ func() LocationRange,
 	otherReference, ok := other.(*EphemeralReferenceValue)
 	if !ok ||
 		v.Value != otherReference.Value ||
-		v.Authorized != otherReference.Authorized {
+		v.Authorized != otherReference.Authorized ||
+		!v.Authorization.Equal(otherReference.Authorization) {
 
 		return false
 	}
@@ -13967,7 +16107,8 @@ func (v *EphemeralReferenceValue) ConformsToDynamicType(
 
 	refType, ok := dynamicType.(EphemeralReferenceDynamicType)
 	if !ok ||
-		refType.authorized != v.Authorized {
+		refType.authorized != v.Authorized ||
+		!refType.authorization.Equal(v.Authorization) {
 
 		return false
 	}
@@ -14041,9 +16182,10 @@ func (v *EphemeralReferenceValue) Transfer(
 
 func (v *EphemeralReferenceValue) Clone(_ *Interpreter) Value {
 	return &EphemeralReferenceValue{
-		Authorized:   v.Authorized,
-		BorrowedType: v.BorrowedType,
-		Value:        v.Value,
+		Authorized:    v.Authorized,
+		Authorization: v.Authorization,
+		BorrowedType:  v.BorrowedType,
+		Value:         v.Value,
 	}
 }
 
@@ -14151,7 +16293,7 @@ func (v AddressValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 	case sema.ToStringFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return NewStringValue(v.String())
+				return newMeteredStringValue(invocation.Interpreter, v.String())
 			},
 			sema.ToStringFunctionType,
 		)
@@ -14255,6 +16397,7 @@ func accountGetCapabilityFunction(
 			if !invocation.Interpreter.IsSubType(pathDynamicType, pathType) {
 				panic(TypeMismatchError{
 					ExpectedType:  pathType,
+					ActualType:    invocation.Interpreter.errorValueType(path),
 					LocationRange: invocation.GetLocationRange(),
 				})
 			}
@@ -14315,7 +16458,7 @@ var privatePathDynamicType DynamicType = PrivatePathDynamicType{}
 
 func (v PathValue) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
 	switch v.Domain {
-	case common.PathDomainStorage:
+	case common.PathDomainStorage, common.PathDomainTransaction:
 		return storagePathDynamicType
 	case common.PathDomainPublic:
 		return publicPathDynamicType
@@ -14328,7 +16471,7 @@ func (v PathValue) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
 
 func (v PathValue) StaticType() StaticType {
 	switch v.Domain {
-	case common.PathDomainStorage:
+	case common.PathDomainStorage, common.PathDomainTransaction:
 		return PrimitiveStaticTypeStoragePath
 	case common.PathDomainPublic:
 		return PrimitiveStaticTypePublicPath
@@ -14356,7 +16499,7 @@ func (v PathValue) GetMember(_ *Interpreter, _ func() LocationRange, name string
 	case sema.ToStringFunctionName:
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				return NewStringValue(v.String())
+				return newMeteredStringValue(invocation.Interpreter, v.String())
 			},
 			sema.ToStringFunctionType,
 		)
@@ -14387,7 +16530,8 @@ func (v PathValue) ConformsToDynamicType(
 	case PrivatePathDynamicType:
 		return v.Domain == common.PathDomainPrivate
 	case StoragePathDynamicType:
-		return v.Domain == common.PathDomainStorage
+		return v.Domain == common.PathDomainStorage ||
+			v.Domain == common.PathDomainTransaction
 	default:
 		return false
 	}
@@ -14521,6 +16665,9 @@ type CapabilityValue struct {
 	Address    AddressValue
 	Path       PathValue
 	BorrowType StaticType
+	// ID is the identifier of capabilities issued by capability controllers.
+	// It is zero for capabilities created by linking, which target a path.
+	ID UInt64Value
 }
 
 var _ Value = &CapabilityValue{}
@@ -14567,6 +16714,13 @@ func (v *CapabilityValue) RecursiveString(seenReferences SeenReferences) string
 	if v.BorrowType != nil {
 		borrowType = v.BorrowType.String()
 	}
+	if v.ID != 0 {
+		return format.IDCapability(
+			borrowType,
+			v.Address.RecursiveString(seenReferences),
+			v.ID.RecursiveString(seenReferences),
+		)
+	}
 	return format.Capability(
 		borrowType,
 		v.Address.RecursiveString(seenReferences),
@@ -14582,7 +16736,7 @@ func (v *CapabilityValue) GetMember(interpreter *Interpreter, _ func() LocationR
 			// this function will panic already if this conversion fails
 			borrowType, _ = interpreter.MustConvertStaticToSemaType(v.BorrowType).(*sema.ReferenceType)
 		}
-		return interpreter.capabilityBorrowFunction(v.Address, v.Path, borrowType)
+		return interpreter.capabilityBorrowFunction(v.Address, v.Path, v.ID, borrowType)
 
 	case "check":
 		var borrowType *sema.ReferenceType
@@ -14590,10 +16744,13 @@ func (v *CapabilityValue) GetMember(interpreter *Interpreter, _ func() LocationR
 			// this function will panic already if this conversion fails
 			borrowType, _ = interpreter.MustConvertStaticToSemaType(v.BorrowType).(*sema.ReferenceType)
 		}
-		return interpreter.capabilityCheckFunction(v.Address, v.Path, borrowType)
+		return interpreter.capabilityCheckFunction(v.Address, v.Path, v.ID, borrowType)
 
 	case "address":
 		return v.Address
+
+	case "id":
+		return v.ID
 	}
 
 	return nil
@@ -14636,7 +16793,8 @@ func (v *CapabilityValue) Equal(interpreter *Interpreter, getLocationRange func(
 	}
 
 	return otherCapability.Address.Equal(interpreter, getLocationRange, v.Address) &&
-		otherCapability.Path.Equal(interpreter, getLocationRange, v.Path)
+		otherCapability.Path.Equal(interpreter, getLocationRange, v.Path) &&
+		otherCapability.ID == v.ID
 }
 
 func (*CapabilityValue) IsStorable() bool {
@@ -14683,6 +16841,7 @@ func (v *CapabilityValue) Clone(interpreter *Interpreter) Value {
 		Address:    v.Address.Clone(interpreter).(AddressValue),
 		Path:       v.Path.Clone(interpreter).(PathValue),
 		BorrowType: v.BorrowType,
+		ID:         v.ID,
 	}
 }
 
@@ -14822,6 +16981,239 @@ func (v LinkValue) ChildStorables() []atree.Storable {
 	}
 }
 
+// AccountLinkValue is a link which targets the account it is stored in,
+// created by `AuthAccount.linkAccount`.
+//
+type AccountLinkValue struct{}
+
+var _ Value = AccountLinkValue{}
+var _ atree.Value = AccountLinkValue{}
+var _ EquatableValue = AccountLinkValue{}
+
+func (AccountLinkValue) IsValue() {}
+
+func (v AccountLinkValue) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitAccountLinkValue(interpreter, v)
+}
+
+func (AccountLinkValue) Walk(_ func(Value)) {
+	// NO-OP
+}
+
+func (AccountLinkValue) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
+	return nil
+}
+
+func (AccountLinkValue) StaticType() StaticType {
+	return nil
+}
+
+func (v AccountLinkValue) String() string {
+	return v.RecursiveString(SeenReferences{})
+}
+
+func (AccountLinkValue) RecursiveString(_ SeenReferences) string {
+	return "AccountLink()"
+}
+
+func (AccountLinkValue) ConformsToDynamicType(
+	_ *Interpreter,
+	_ func() LocationRange,
+	_ DynamicType,
+	_ TypeConformanceResults,
+) bool {
+	// There is no dynamic type for links,
+	// as they are not first-class values in programs,
+	// but only stored
+	return false
+}
+
+func (AccountLinkValue) Equal(_ *Interpreter, _ func() LocationRange, other Value) bool {
+	_, ok := other.(AccountLinkValue)
+	return ok
+}
+
+func (AccountLinkValue) IsStorable() bool {
+	return true
+}
+
+func (v AccountLinkValue) Storable(_ atree.SlabStorage, _ atree.Address, _ uint64) (atree.Storable, error) {
+	return v, nil
+}
+
+func (AccountLinkValue) NeedsStoreTo(_ atree.Address) bool {
+	return false
+}
+
+func (AccountLinkValue) IsResourceKinded(_ *Interpreter) bool {
+	return false
+}
+
+func (v AccountLinkValue) Transfer(
+	interpreter *Interpreter,
+	_ func() LocationRange,
+	_ atree.Address,
+	remove bool,
+	storable atree.Storable,
+) Value {
+	if remove {
+		interpreter.RemoveReferencedSlab(storable)
+	}
+	return v
+}
+
+func (v AccountLinkValue) Clone(_ *Interpreter) Value {
+	return v
+}
+
+func (AccountLinkValue) DeepRemove(_ *Interpreter) {
+	// NO-OP
+}
+
+func (v AccountLinkValue) ByteSize() uint32 {
+	return mustStorableSize(v)
+}
+
+func (v AccountLinkValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+	return v, nil
+}
+
+func (AccountLinkValue) ChildStorables() []atree.Storable {
+	return nil
+}
+
+// StorageCapabilityControllerValue is the stored state of a capability controller,
+// created by `AuthAccount.StorageCapabilities.issue`.
+// Like links, controllers are not first-class values, but only stored.
+//
+type StorageCapabilityControllerValue struct {
+	BorrowType   StaticType
+	CapabilityID UInt64Value
+	TargetPath   PathValue
+}
+
+var _ Value = StorageCapabilityControllerValue{}
+var _ atree.Value = StorageCapabilityControllerValue{}
+var _ EquatableValue = StorageCapabilityControllerValue{}
+
+func (StorageCapabilityControllerValue) IsValue() {}
+
+func (v StorageCapabilityControllerValue) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitStorageCapabilityControllerValue(interpreter, v)
+}
+
+func (v StorageCapabilityControllerValue) Walk(walkChild func(Value)) {
+	walkChild(v.CapabilityID)
+	walkChild(v.TargetPath)
+}
+
+func (StorageCapabilityControllerValue) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
+	return nil
+}
+
+func (StorageCapabilityControllerValue) StaticType() StaticType {
+	return nil
+}
+
+func (v StorageCapabilityControllerValue) String() string {
+	return v.RecursiveString(SeenReferences{})
+}
+
+func (v StorageCapabilityControllerValue) RecursiveString(seenReferences SeenReferences) string {
+	return format.StorageCapabilityController(
+		v.BorrowType.String(),
+		v.CapabilityID.RecursiveString(seenReferences),
+		v.TargetPath.RecursiveString(seenReferences),
+	)
+}
+
+func (StorageCapabilityControllerValue) ConformsToDynamicType(
+	_ *Interpreter,
+	_ func() LocationRange,
+	_ DynamicType,
+	_ TypeConformanceResults,
+) bool {
+	// There is no dynamic type for capability controllers,
+	// as they are not first-class values in programs,
+	// but only stored
+	return false
+}
+
+func (v StorageCapabilityControllerValue) Equal(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	other Value,
+) bool {
+	otherController, ok := other.(StorageCapabilityControllerValue)
+	if !ok {
+		return false
+	}
+
+	return otherController.CapabilityID == v.CapabilityID &&
+		otherController.TargetPath.Equal(interpreter, getLocationRange, v.TargetPath) &&
+		otherController.BorrowType.Equal(v.BorrowType)
+}
+
+func (StorageCapabilityControllerValue) IsStorable() bool {
+	return true
+}
+
+func (v StorageCapabilityControllerValue) Storable(
+	storage atree.SlabStorage,
+	address atree.Address,
+	maxInlineSize uint64,
+) (atree.Storable, error) {
+	return maybeLargeImmutableStorable(v, storage, address, maxInlineSize)
+}
+
+func (StorageCapabilityControllerValue) NeedsStoreTo(_ atree.Address) bool {
+	return false
+}
+
+func (StorageCapabilityControllerValue) IsResourceKinded(_ *Interpreter) bool {
+	return false
+}
+
+func (v StorageCapabilityControllerValue) Transfer(
+	interpreter *Interpreter,
+	_ func() LocationRange,
+	_ atree.Address,
+	remove bool,
+	storable atree.Storable,
+) Value {
+	if remove {
+		interpreter.RemoveReferencedSlab(storable)
+	}
+	return v
+}
+
+func (v StorageCapabilityControllerValue) Clone(interpreter *Interpreter) Value {
+	return StorageCapabilityControllerValue{
+		BorrowType:   v.BorrowType,
+		CapabilityID: v.CapabilityID,
+		TargetPath:   v.TargetPath.Clone(interpreter).(PathValue),
+	}
+}
+
+func (StorageCapabilityControllerValue) DeepRemove(_ *Interpreter) {
+	// NO-OP
+}
+
+func (v StorageCapabilityControllerValue) ByteSize() uint32 {
+	return mustStorableSize(v)
+}
+
+func (v StorageCapabilityControllerValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+	return v, nil
+}
+
+func (v StorageCapabilityControllerValue) ChildStorables() []atree.Storable {
+	return []atree.Storable{
+		v.CapabilityID,
+		v.TargetPath,
+	}
+}
+
 // NewPublicKeyValue constructs a PublicKey value.
 func NewPublicKeyValue(
 	interpreter *Interpreter,
@@ -14972,3 +17364,66 @@ var publicKeyVerifyPoPFunction = NewHostFunctionValue(
 	},
 	sema.PublicKeyVerifyPoPFunctionType,
 )
+
+// NewHasherValue constructs a Hasher value,
+// which incrementally hashes data using the given hasher.
+//
+// NOTE: Copies of the value share the hasher,
+// i.e. updating or finalizing a copy also affects the original value.
+//
+func NewHasherValue(interpreter *Interpreter, hasher Hasher) *CompositeValue {
+
+	hasherValue := NewCompositeValue(
+		interpreter,
+		sema.HasherType.Location,
+		sema.HasherType.QualifiedIdentifier(),
+		sema.HasherType.Kind,
+		nil,
+		common.Address{},
+	)
+
+	finalized := false
+
+	checkNotFinalized := func(getLocationRange func() LocationRange) {
+		if finalized {
+			panic(HasherFinalizedError{
+				LocationRange: getLocationRange(),
+			})
+		}
+	}
+
+	hasherValue.Functions = map[string]FunctionValue{
+		sema.HasherTypeUpdateFunctionName: NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				checkNotFinalized(invocation.GetLocationRange)
+
+				data, err := ByteArrayValueToByteSlice(invocation.Arguments[0])
+				if err != nil {
+					panic(err)
+				}
+
+				invocation.Interpreter.meterComputation(common.ComputationKindHashing, uint(len(data)))
+
+				hasher.Update(data)
+
+				return VoidValue{}
+			},
+			sema.HasherTypeUpdateFunctionType,
+		),
+		sema.HasherTypeFinalizeFunctionName: NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				checkNotFinalized(invocation.GetLocationRange)
+
+				finalized = true
+
+				return ByteSliceToByteArrayValue(
+					invocation.Interpreter,
+					hasher.Finalize(),
+				)
+			},
+			sema.HasherTypeFinalizeFunctionType,
+		),
+	}
+
+	return hasherValue
+}
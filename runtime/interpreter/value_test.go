@@ -1481,6 +1481,15 @@ func TestGetHashInput(t *testing.T) {
 				testCase.expected,
 				actual,
 			)
+
+			// Hash inputs may be cached, so a repeated request must produce the same result
+
+			actual = testCase.value.HashInput(inter, ReturnEmptyLocationRange, scratch[:])
+
+			assert.Equal(t,
+				testCase.expected,
+				actual,
+			)
 		})
 	}
 
@@ -19,12 +19,16 @@
 package interpreter
 
 type Variable struct {
-	value  Value
-	getter func() Value
+	value    Value
+	getter   func() Value
+	computed bool
 }
 
 func (v *Variable) GetValue() Value {
 	if v.getter != nil {
+		if v.computed {
+			return v.getter()
+		}
 		v.value = v.getter()
 		v.getter = nil
 	}
@@ -33,6 +37,7 @@ func (v *Variable) GetValue() Value {
 
 func (v *Variable) SetValue(value Value) {
 	v.getter = nil
+	v.computed = false
 	v.value = value
 }
 
@@ -47,3 +52,13 @@ func NewVariableWithGetter(getter func() Value) *Variable {
 		getter: getter,
 	}
 }
+
+// NewComputedVariable returns a variable which calls the getter each time the value is read,
+// instead of only once like a variable returned by NewVariableWithGetter.
+//
+func NewComputedVariable(getter func() Value) *Variable {
+	return &Variable{
+		getter:   getter,
+		computed: true,
+	}
+}
@@ -24,6 +24,7 @@ type Visitor interface {
 	VisitVoidValue(interpreter *Interpreter, value VoidValue)
 	VisitBoolValue(interpreter *Interpreter, value BoolValue)
 	VisitStringValue(interpreter *Interpreter, value *StringValue)
+	VisitBytesValue(interpreter *Interpreter, value *BytesValue)
 	VisitArrayValue(interpreter *Interpreter, value *ArrayValue) bool
 	VisitIntValue(interpreter *Interpreter, value IntValue)
 	VisitInt8Value(interpreter *Interpreter, value Int8Value)
@@ -55,51 +56,56 @@ type Visitor interface {
 	VisitPathValue(interpreter *Interpreter, value PathValue)
 	VisitCapabilityValue(interpreter *Interpreter, value *CapabilityValue)
 	VisitLinkValue(interpreter *Interpreter, value LinkValue)
+	VisitAccountLinkValue(interpreter *Interpreter, value AccountLinkValue)
+	VisitStorageCapabilityControllerValue(interpreter *Interpreter, value StorageCapabilityControllerValue)
 	VisitInterpretedFunctionValue(interpreter *Interpreter, value *InterpretedFunctionValue)
 	VisitHostFunctionValue(interpreter *Interpreter, value *HostFunctionValue)
 	VisitBoundFunctionValue(interpreter *Interpreter, value BoundFunctionValue)
 }
 
 type EmptyVisitor struct {
-	SimpleCompositeValueVisitor     func(interpreter *Interpreter, value *SimpleCompositeValue)
-	TypeValueVisitor                func(interpreter *Interpreter, value TypeValue)
-	VoidValueVisitor                func(interpreter *Interpreter, value VoidValue)
-	BoolValueVisitor                func(interpreter *Interpreter, value BoolValue)
-	StringValueVisitor              func(interpreter *Interpreter, value *StringValue)
-	ArrayValueVisitor               func(interpreter *Interpreter, value *ArrayValue) bool
-	IntValueVisitor                 func(interpreter *Interpreter, value IntValue)
-	Int8ValueVisitor                func(interpreter *Interpreter, value Int8Value)
-	Int16ValueVisitor               func(interpreter *Interpreter, value Int16Value)
-	Int32ValueVisitor               func(interpreter *Interpreter, value Int32Value)
-	Int64ValueVisitor               func(interpreter *Interpreter, value Int64Value)
-	Int128ValueVisitor              func(interpreter *Interpreter, value Int128Value)
-	Int256ValueVisitor              func(interpreter *Interpreter, value Int256Value)
-	UIntValueVisitor                func(interpreter *Interpreter, value UIntValue)
-	UInt8ValueVisitor               func(interpreter *Interpreter, value UInt8Value)
-	UInt16ValueVisitor              func(interpreter *Interpreter, value UInt16Value)
-	UInt32ValueVisitor              func(interpreter *Interpreter, value UInt32Value)
-	UInt64ValueVisitor              func(interpreter *Interpreter, value UInt64Value)
-	UInt128ValueVisitor             func(interpreter *Interpreter, value UInt128Value)
-	UInt256ValueVisitor             func(interpreter *Interpreter, value UInt256Value)
-	Word8ValueVisitor               func(interpreter *Interpreter, value Word8Value)
-	Word16ValueVisitor              func(interpreter *Interpreter, value Word16Value)
-	Word32ValueVisitor              func(interpreter *Interpreter, value Word32Value)
-	Word64ValueVisitor              func(interpreter *Interpreter, value Word64Value)
-	Fix64ValueVisitor               func(interpreter *Interpreter, value Fix64Value)
-	UFix64ValueVisitor              func(interpreter *Interpreter, value UFix64Value)
-	CompositeValueVisitor           func(interpreter *Interpreter, value *CompositeValue) bool
-	DictionaryValueVisitor          func(interpreter *Interpreter, value *DictionaryValue) bool
-	NilValueVisitor                 func(interpreter *Interpreter, value NilValue)
-	SomeValueVisitor                func(interpreter *Interpreter, value *SomeValue) bool
-	StorageReferenceValueVisitor    func(interpreter *Interpreter, value *StorageReferenceValue)
-	EphemeralReferenceValueVisitor  func(interpreter *Interpreter, value *EphemeralReferenceValue)
-	AddressValueVisitor             func(interpreter *Interpreter, value AddressValue)
-	PathValueVisitor                func(interpreter *Interpreter, value PathValue)
-	CapabilityValueVisitor          func(interpreter *Interpreter, value *CapabilityValue)
-	LinkValueVisitor                func(interpreter *Interpreter, value LinkValue)
-	InterpretedFunctionValueVisitor func(interpreter *Interpreter, value *InterpretedFunctionValue)
-	HostFunctionValueVisitor        func(interpreter *Interpreter, value *HostFunctionValue)
-	BoundFunctionValueVisitor       func(interpreter *Interpreter, value BoundFunctionValue)
+	SimpleCompositeValueVisitor             func(interpreter *Interpreter, value *SimpleCompositeValue)
+	TypeValueVisitor                        func(interpreter *Interpreter, value TypeValue)
+	VoidValueVisitor                        func(interpreter *Interpreter, value VoidValue)
+	BoolValueVisitor                        func(interpreter *Interpreter, value BoolValue)
+	StringValueVisitor                      func(interpreter *Interpreter, value *StringValue)
+	BytesValueVisitor                       func(interpreter *Interpreter, value *BytesValue)
+	ArrayValueVisitor                       func(interpreter *Interpreter, value *ArrayValue) bool
+	IntValueVisitor                         func(interpreter *Interpreter, value IntValue)
+	Int8ValueVisitor                        func(interpreter *Interpreter, value Int8Value)
+	Int16ValueVisitor                       func(interpreter *Interpreter, value Int16Value)
+	Int32ValueVisitor                       func(interpreter *Interpreter, value Int32Value)
+	Int64ValueVisitor                       func(interpreter *Interpreter, value Int64Value)
+	Int128ValueVisitor                      func(interpreter *Interpreter, value Int128Value)
+	Int256ValueVisitor                      func(interpreter *Interpreter, value Int256Value)
+	UIntValueVisitor                        func(interpreter *Interpreter, value UIntValue)
+	UInt8ValueVisitor                       func(interpreter *Interpreter, value UInt8Value)
+	UInt16ValueVisitor                      func(interpreter *Interpreter, value UInt16Value)
+	UInt32ValueVisitor                      func(interpreter *Interpreter, value UInt32Value)
+	UInt64ValueVisitor                      func(interpreter *Interpreter, value UInt64Value)
+	UInt128ValueVisitor                     func(interpreter *Interpreter, value UInt128Value)
+	UInt256ValueVisitor                     func(interpreter *Interpreter, value UInt256Value)
+	Word8ValueVisitor                       func(interpreter *Interpreter, value Word8Value)
+	Word16ValueVisitor                      func(interpreter *Interpreter, value Word16Value)
+	Word32ValueVisitor                      func(interpreter *Interpreter, value Word32Value)
+	Word64ValueVisitor                      func(interpreter *Interpreter, value Word64Value)
+	Fix64ValueVisitor                       func(interpreter *Interpreter, value Fix64Value)
+	UFix64ValueVisitor                      func(interpreter *Interpreter, value UFix64Value)
+	CompositeValueVisitor                   func(interpreter *Interpreter, value *CompositeValue) bool
+	DictionaryValueVisitor                  func(interpreter *Interpreter, value *DictionaryValue) bool
+	NilValueVisitor                         func(interpreter *Interpreter, value NilValue)
+	SomeValueVisitor                        func(interpreter *Interpreter, value *SomeValue) bool
+	StorageReferenceValueVisitor            func(interpreter *Interpreter, value *StorageReferenceValue)
+	EphemeralReferenceValueVisitor          func(interpreter *Interpreter, value *EphemeralReferenceValue)
+	AddressValueVisitor                     func(interpreter *Interpreter, value AddressValue)
+	PathValueVisitor                        func(interpreter *Interpreter, value PathValue)
+	CapabilityValueVisitor                  func(interpreter *Interpreter, value *CapabilityValue)
+	LinkValueVisitor                        func(interpreter *Interpreter, value LinkValue)
+	AccountLinkValueVisitor                 func(interpreter *Interpreter, value AccountLinkValue)
+	StorageCapabilityControllerValueVisitor func(interpreter *Interpreter, value StorageCapabilityControllerValue)
+	InterpretedFunctionValueVisitor         func(interpreter *Interpreter, value *InterpretedFunctionValue)
+	HostFunctionValueVisitor                func(interpreter *Interpreter, value *HostFunctionValue)
+	BoundFunctionValueVisitor               func(interpreter *Interpreter, value BoundFunctionValue)
 }
 
 var _ Visitor = &EmptyVisitor{}
@@ -139,6 +145,13 @@ func (v EmptyVisitor) VisitStringValue(interpreter *Interpreter, value *StringVa
 	v.StringValueVisitor(interpreter, value)
 }
 
+func (v EmptyVisitor) VisitBytesValue(interpreter *Interpreter, value *BytesValue) {
+	if v.BytesValueVisitor == nil {
+		return
+	}
+	v.BytesValueVisitor(interpreter, value)
+}
+
 func (v EmptyVisitor) VisitArrayValue(interpreter *Interpreter, value *ArrayValue) bool {
 	if v.ArrayValueVisitor == nil {
 		return true
@@ -356,6 +369,23 @@ func (v EmptyVisitor) VisitLinkValue(interpreter *Interpreter, value LinkValue)
 	v.LinkValueVisitor(interpreter, value)
 }
 
+func (v EmptyVisitor) VisitAccountLinkValue(interpreter *Interpreter, value AccountLinkValue) {
+	if v.AccountLinkValueVisitor == nil {
+		return
+	}
+	v.AccountLinkValueVisitor(interpreter, value)
+}
+
+func (v EmptyVisitor) VisitStorageCapabilityControllerValue(
+	interpreter *Interpreter,
+	value StorageCapabilityControllerValue,
+) {
+	if v.StorageCapabilityControllerValueVisitor == nil {
+		return
+	}
+	v.StorageCapabilityControllerValueVisitor(interpreter, value)
+}
+
 func (v EmptyVisitor) VisitInterpretedFunctionValue(interpreter *Interpreter, value *InterpretedFunctionValue) {
 	if v.InterpretedFunctionValueVisitor == nil {
 		return
@@ -0,0 +1,276 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// NativeFunctionsNamespaceName is the name of the built-in namespace
+// through which programs can call the native functions registered by the embedder,
+// e.g. `Native.verifyProof(proof)`
+//
+const NativeFunctionsNamespaceName = "Native"
+
+// NativeFunction is a function implemented by the embedder,
+// which can be called by programs through the built-in `Native` namespace.
+//
+// The function is called with the exported arguments of the invocation,
+// and must return a value of the declared return type,
+// or nil if the function does not return a value.
+//
+type NativeFunction struct {
+	Name      string
+	Type      *sema.FunctionType
+	DocString string
+	Function  func(arguments []cadence.Value) (cadence.Value, error)
+}
+
+// NativeFunctions is a validated set of native functions,
+// which can be registered with a runtime using WithNativeFunctions.
+//
+type NativeFunctions struct {
+	functions     []NativeFunction
+	namespaceType *sema.CompositeType
+}
+
+var nativeFunctionNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// NewNativeFunctions validates the given native functions and returns them as a set.
+//
+// Each function must have a unique name, which is a valid identifier,
+// and a function type without type parameters.
+// The parameter types must be exportable and the return type must be importable (or `Void`),
+// as arguments and results are passed between the program and the embedder as Cadence values.
+//
+func NewNativeFunctions(functions ...NativeFunction) (*NativeFunctions, error) {
+
+	namespaceType := &sema.CompositeType{
+		Identifier: NativeFunctionsNamespaceName,
+		Kind:       common.CompositeKindStructure,
+		Members:    sema.NewStringMemberOrderedMap(),
+	}
+
+	for _, function := range functions {
+		err := validateNativeFunction(function)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := namespaceType.Members.Get(function.Name); ok {
+			return nil, &InvalidNativeFunctionError{
+				Name:   function.Name,
+				Reason: "duplicate name",
+			}
+		}
+
+		namespaceType.Members.Set(
+			function.Name,
+			sema.NewPublicFunctionMember(
+				namespaceType,
+				function.Name,
+				function.Type,
+				function.DocString,
+			),
+		)
+	}
+
+	return &NativeFunctions{
+		functions:     append([]NativeFunction(nil), functions...),
+		namespaceType: namespaceType,
+	}, nil
+}
+
+func validateNativeFunction(function NativeFunction) error {
+
+	invalid := func(reason string) error {
+		return &InvalidNativeFunctionError{
+			Name:   function.Name,
+			Reason: reason,
+		}
+	}
+
+	if !nativeFunctionNameRegexp.MatchString(function.Name) {
+		return invalid("name is not a valid identifier")
+	}
+
+	if function.Function == nil {
+		return invalid("missing implementation")
+	}
+
+	functionType := function.Type
+	if functionType == nil {
+		return invalid("missing type")
+	}
+
+	if len(functionType.TypeParameters) > 0 {
+		return invalid("type parameters are not supported")
+	}
+
+	for _, parameter := range functionType.Parameters {
+		parameterType := parameter.TypeAnnotation.Type
+		if !parameterType.IsExternallyReturnable(map[*sema.Member]bool{}) {
+			return invalid(fmt.Sprintf(
+				"type of parameter `%s` is not exportable: `%s`",
+				parameter.Identifier,
+				parameterType.QualifiedString(),
+			))
+		}
+	}
+
+	returnType := functionType.ReturnTypeAnnotation.Type
+	if returnType != sema.VoidType &&
+		!returnType.IsImportable(map[*sema.Member]bool{}) {
+
+		return invalid(fmt.Sprintf(
+			"return type is not importable: `%s`",
+			returnType.QualifiedString(),
+		))
+	}
+
+	return nil
+}
+
+// valueDeclaration returns the declaration of the `Native` namespace,
+// which has a member for each native function
+//
+func (f *NativeFunctions) valueDeclaration() stdlib.StandardLibraryValue {
+	return stdlib.StandardLibraryValue{
+		Name:         NativeFunctionsNamespaceName,
+		Type:         f.namespaceType,
+		DocString:    "The native functions provided by the environment",
+		ValueFactory: f.newNamespaceValue,
+		Kind:         common.DeclarationKindConstant,
+	}
+}
+
+func (f *NativeFunctions) newNamespaceValue(_ *interpreter.Interpreter) interpreter.Value {
+
+	fieldNames := make([]string, 0, len(f.functions))
+	fields := make(map[string]interpreter.Value, len(f.functions))
+
+	for _, function := range f.functions {
+		fieldNames = append(fieldNames, function.Name)
+		fields[function.Name] = interpreter.NewHostFunctionValue(
+			newNativeHostFunction(function),
+			function.Type,
+		)
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		f.namespaceType.ID(),
+		interpreter.NewCompositeStaticType(nil, NativeFunctionsNamespaceName),
+		interpreter.CompositeDynamicType{
+			StaticType: f.namespaceType,
+		},
+		fieldNames,
+		fields,
+		nil,
+		nil,
+		nil,
+	)
+}
+
+func newNativeHostFunction(function NativeFunction) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		inter := invocation.Interpreter
+
+		arguments := make([]cadence.Value, len(invocation.Arguments))
+		for i, argument := range invocation.Arguments {
+			exportedArgument, err := exportValueWithInterpreter(argument, inter, seenReferences{})
+			if err != nil {
+				panic(err)
+			}
+			arguments[i] = exportedArgument
+		}
+
+		var result cadence.Value
+		var err error
+		wrapPanic(func() {
+			result, err = function.Function(arguments)
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		returnType := function.Type.ReturnTypeAnnotation.Type
+		if returnType == sema.VoidType {
+			return interpreter.VoidValue{}
+		}
+
+		value, err := importNativeFunctionResult(inter, result, returnType)
+		if err != nil {
+			panic(&InvalidNativeFunctionResultError{
+				Name:          function.Name,
+				Err:           err,
+				LocationRange: invocation.GetLocationRange(),
+			})
+		}
+
+		return value
+	}
+}
+
+func importNativeFunctionResult(
+	inter *interpreter.Interpreter,
+	result cadence.Value,
+	returnType sema.Type,
+) (
+	interpreter.Value,
+	error,
+) {
+	if result == nil {
+		return nil, &InvalidValueTypeError{
+			ExpectedType: returnType,
+		}
+	}
+
+	value, err := importValue(inter, result, returnType)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicType := value.DynamicType(inter, interpreter.SeenReferences{})
+
+	if !inter.IsSubType(dynamicType, returnType) {
+		return nil, &InvalidValueTypeError{
+			ExpectedType: returnType,
+		}
+	}
+
+	if !value.ConformsToDynamicType(
+		inter,
+		interpreter.ReturnEmptyLocationRange,
+		dynamicType,
+		interpreter.TypeConformanceResults{},
+	) {
+		return nil, &MalformedValueError{
+			ExpectedType: returnType,
+		}
+	}
+
+	return value, nil
+}
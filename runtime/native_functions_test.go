@@ -0,0 +1,394 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeNativeFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	addFunctionType := &sema.FunctionType{
+		Parameters: []*sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "a",
+				TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "b",
+				TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+	}
+
+	newNativeFunctions := func(t *testing.T, result func(a, b cadence.Int) cadence.Value) *NativeFunctions {
+		functions, err := NewNativeFunctions(
+			NativeFunction{
+				Name: "add",
+				Type: addFunctionType,
+				Function: func(arguments []cadence.Value) (cadence.Value, error) {
+					return result(
+						arguments[0].(cadence.Int),
+						arguments[1].(cadence.Int),
+					), nil
+				},
+			},
+		)
+		require.NoError(t, err)
+		return functions
+	}
+
+	add := func(a, b cadence.Int) cadence.Value {
+		return cadence.NewIntFromBig(
+			a.Big().Add(a.Big(), b.Big()),
+		)
+	}
+
+	executeScript := func(runtime Runtime, code string) (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: &testRuntimeInterface{
+					storage: newTestLedger(nil, nil),
+				},
+				Location: utils.TestLocation,
+			},
+		)
+	}
+
+	t.Run("script", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime(
+			WithNativeFunctions(newNativeFunctions(t, add)),
+		)
+
+		result, err := executeScript(runtime, `
+          pub fun main(): Int {
+              return Native.add(1, 2)
+          }
+        `)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(3), result)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime(
+			WithNativeFunctions(newNativeFunctions(t, add)),
+		)
+
+		_, err := executeScript(runtime, `
+          pub fun main(): Int {
+              return Native.add(1, "2")
+          }
+        `)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime(
+			WithNativeFunctions(newNativeFunctions(t, add)),
+		)
+
+		_, err := executeScript(runtime, `
+          pub fun main(): Int {
+              return Native.sub(1, 2)
+          }
+        `)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		_, err := executeScript(runtime, `
+          pub fun main(): Int {
+              return Native.add(1, 2)
+          }
+        `)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		require.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("invalid result", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime(
+			WithNativeFunctions(newNativeFunctions(t, func(_, _ cadence.Int) cadence.Value {
+				return cadence.String("3")
+			})),
+		)
+
+		_, err := executeScript(runtime, `
+          pub fun main(): Int {
+              return Native.add(1, 2)
+          }
+        `)
+		require.Error(t, err)
+
+		var resultErr *InvalidNativeFunctionResultError
+		require.ErrorAs(t, err, &resultErr)
+		assert.Equal(t, "add", resultErr.Name)
+	})
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime(
+			WithNativeFunctions(newNativeFunctions(t, add)),
+		)
+
+		contract := []byte(`
+          pub contract Test {
+              pub fun double(_ n: Int): Int {
+                  return Native.add(n, n)
+              }
+          }
+        `)
+
+		accountCodes := map[common.LocationID][]byte{}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				return accountCodes[location.ID()], nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location.ID()] = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("Test", contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  pub fun main(): Int {
+                      return Test.double(21)
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(42), result)
+	})
+}
+
+func TestNewNativeFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	voidFunctionType := &sema.FunctionType{
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+	}
+
+	noop := func(_ []cadence.Value) (cadence.Value, error) {
+		return nil, nil
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := NewNativeFunctions(
+			NativeFunction{
+				Name:     "foo",
+				Type:     voidFunctionType,
+				Function: noop,
+			},
+			NativeFunction{
+				Name:     "bar",
+				Type:     voidFunctionType,
+				Function: noop,
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	test := func(name string, function NativeFunction, reason string) {
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := NewNativeFunctions(function)
+			require.Error(t, err)
+
+			var nativeFunctionErr *InvalidNativeFunctionError
+			require.ErrorAs(t, err, &nativeFunctionErr)
+			assert.Equal(t, reason, nativeFunctionErr.Reason)
+		})
+	}
+
+	test(
+		"invalid name",
+		NativeFunction{
+			Name:     "foo.bar",
+			Type:     voidFunctionType,
+			Function: noop,
+		},
+		"name is not a valid identifier",
+	)
+
+	test(
+		"missing type",
+		NativeFunction{
+			Name:     "foo",
+			Function: noop,
+		},
+		"missing type",
+	)
+
+	test(
+		"missing implementation",
+		NativeFunction{
+			Name: "foo",
+			Type: voidFunctionType,
+		},
+		"missing implementation",
+	)
+
+	test(
+		"parameter not exportable",
+		NativeFunction{
+			Name: "foo",
+			Type: &sema.FunctionType{
+				Parameters: []*sema.Parameter{
+					{
+						Identifier:     "f",
+						TypeAnnotation: sema.NewTypeAnnotation(voidFunctionType),
+					},
+				},
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+			},
+			Function: noop,
+		},
+		"type of parameter `f` is not exportable: `((): Void)`",
+	)
+
+	test(
+		"return type not importable",
+		NativeFunction{
+			Name: "foo",
+			Type: &sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.AuthAccountType),
+			},
+			Function: noop,
+		},
+		"return type is not importable: `AuthAccount`",
+	)
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		function := NativeFunction{
+			Name:     "foo",
+			Type:     voidFunctionType,
+			Function: noop,
+		}
+
+		_, err := NewNativeFunctions(function, function)
+		require.Error(t, err)
+
+		var nativeFunctionErr *InvalidNativeFunctionError
+		require.ErrorAs(t, err, &nativeFunctionErr)
+		assert.Equal(t, "duplicate name", nativeFunctionErr.Reason)
+	})
+}
@@ -0,0 +1,416 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=OverflowRiskKind -trimprefix=OverflowRisk
+//go:generate go run golang.org/x/tools/cmd/stringer -type=OverflowRiskSeverity -trimprefix=OverflowRiskSeverity
+
+// OverflowRiskKind is the kind of an arithmetic operation or conversion
+// which may overflow or underflow for some values.
+//
+type OverflowRiskKind uint
+
+const (
+	OverflowRiskUnknown OverflowRiskKind = iota
+	// OverflowRiskMultiplication is a multiplication of a value controlled by a user,
+	// e.g. a balance, which aborts on overflow
+	OverflowRiskMultiplication
+	// OverflowRiskUnsignedSubtraction is a subtraction of a value controlled by a user
+	// from an unsigned value, which aborts on underflow
+	OverflowRiskUnsignedSubtraction
+	// OverflowRiskWrappingArithmetic is an addition, subtraction, or multiplication
+	// of Word values controlled by a user, which silently wraps around
+	OverflowRiskWrappingArithmetic
+	// OverflowRiskNarrowingConversion is a conversion of a value controlled by a user
+	// to a number type with a smaller range, which aborts if the value is out of range
+	OverflowRiskNarrowingConversion
+)
+
+// OverflowRiskSeverity is the severity of an overflow risk.
+//
+type OverflowRiskSeverity uint
+
+const (
+	// OverflowRiskSeverityLow is the severity of operations which abort for some user-controlled values,
+	// but which involve only one such value
+	OverflowRiskSeverityLow OverflowRiskSeverity = iota
+	// OverflowRiskSeverityMedium is the severity of operations which abort for some user-controlled values,
+	// e.g. the multiplication of two balances, and which may be used to block other users
+	OverflowRiskSeverityMedium
+	// OverflowRiskSeverityHigh is the severity of operations which silently produce wrong results
+	// for some user-controlled values
+	OverflowRiskSeverityHigh
+)
+
+// OverflowRisk is an arithmetic operation or conversion on user-controlled values,
+// which is not guarded by a bounds check.
+//
+// Function is the qualified name of the function in which the operation occurs,
+// and Expression is the operation itself.
+//
+type OverflowRisk struct {
+	Kind       OverflowRiskKind
+	Severity   OverflowRiskSeverity
+	Function   string
+	Expression string
+	ast.Range
+}
+
+func (r OverflowRisk) String() string {
+	return fmt.Sprintf(
+		"%d:%d: %s: %s in `%s`: `%s`",
+		r.StartPos.Line,
+		r.StartPos.Column,
+		r.Severity,
+		r.Kind,
+		r.Function,
+		r.Expression,
+	)
+}
+
+// AnalyzeOverflowRisks statically determines the arithmetic operations and number conversions
+// in the given checked program which may overflow or underflow for user-controlled values,
+// in source order.
+//
+// Values are user-controlled if they are parameters, fields of composites, e.g. balances,
+// results of functions of composites, or derived from such values.
+//
+// A value is considered bounds-checked if it is compared with `<`, `<=`, `>`, or `>=`
+// anywhere in the function, e.g. in a pre-condition, and operations on bounds-checked values
+// are not reported.
+//
+func AnalyzeOverflowRisks(program *interpreter.Program) []OverflowRisk {
+	analyzer := &overflowRiskAnalyzer{
+		elaboration: program.Elaboration,
+	}
+
+	for _, function := range collectProgramFunctions(program.Program).functions {
+		analyzer.analyzeFunction(function)
+	}
+
+	sort.SliceStable(analyzer.risks, func(i, j int) bool {
+		return analyzer.risks[i].StartPos.Compare(analyzer.risks[j].StartPos) < 0
+	})
+
+	return analyzer.risks
+}
+
+type overflowRiskAnalyzer struct {
+	elaboration *sema.Elaboration
+	risks       []OverflowRisk
+}
+
+// overflowRiskFunctionAnalysis is the state of the analysis of one function.
+//
+// controlled are the names of the parameters, constants, and variables
+// which hold user-controlled values, and checked are the expressions
+// which are compared somewhere in the function.
+//
+type overflowRiskFunctionAnalysis struct {
+	*overflowRiskAnalyzer
+	function   *analyzedFunction
+	controlled map[string]struct{}
+	checked    map[string]struct{}
+}
+
+func (a *overflowRiskAnalyzer) analyzeFunction(function *analyzedFunction) {
+	analysis := &overflowRiskFunctionAnalysis{
+		overflowRiskAnalyzer: a,
+		function:             function,
+		controlled:           map[string]struct{}{},
+		checked:              map[string]struct{}{},
+	}
+
+	for _, name := range function.parameterNames() {
+		analysis.controlled[name] = struct{}{}
+	}
+
+	ast.Inspect(function.block, analysis.collectChecks)
+
+	// Walking a function block does not walk its conditions
+
+	conditions := []*ast.Conditions{
+		function.block.PreConditions,
+		function.block.PostConditions,
+	}
+	if function.transaction != nil {
+		conditions = append(
+			conditions,
+			function.transaction.PreConditions,
+			function.transaction.PostConditions,
+		)
+	}
+
+	for _, conditions := range conditions {
+		if conditions == nil {
+			continue
+		}
+		for _, condition := range *conditions {
+			ast.Inspect(condition.Test, analysis.collectChecks)
+		}
+	}
+
+	ast.Inspect(function.block, analysis.inspect)
+}
+
+func (a *overflowRiskFunctionAnalysis) collectChecks(element ast.Element) bool {
+	binaryExpression, ok := element.(*ast.BinaryExpression)
+	if !ok {
+		return true
+	}
+
+	switch binaryExpression.Operation {
+	case ast.OperationLess,
+		ast.OperationLessEqual,
+		ast.OperationGreater,
+		ast.OperationGreaterEqual:
+
+		a.checked[binaryExpression.Left.String()] = struct{}{}
+		a.checked[binaryExpression.Right.String()] = struct{}{}
+	}
+
+	return true
+}
+
+func (a *overflowRiskFunctionAnalysis) inspect(element ast.Element) bool {
+	switch element := element.(type) {
+	case *ast.VariableDeclaration:
+		name := element.Identifier.Identifier
+		if a.isControlled(element.Value) {
+			a.controlled[name] = struct{}{}
+		} else {
+			// The declaration may shadow a parameter
+			delete(a.controlled, name)
+		}
+
+	case *ast.AssignmentStatement:
+		target, ok := element.Target.(*ast.IdentifierExpression)
+		if ok && a.isControlled(element.Value) {
+			a.controlled[target.Identifier.Identifier] = struct{}{}
+		}
+
+	case *ast.BinaryExpression:
+		a.inspectBinaryExpression(element)
+
+	case *ast.InvocationExpression:
+		a.inspectConversion(element)
+	}
+
+	return true
+}
+
+func (a *overflowRiskFunctionAnalysis) inspectBinaryExpression(expression *ast.BinaryExpression) {
+	resultType, ok := a.elaboration.BinaryExpressionResultTypes[expression]
+	if !ok {
+		return
+	}
+
+	leftRisky := a.isUnchecked(expression.Left)
+	rightRisky := a.isUnchecked(expression.Right)
+
+	if isWordType(resultType) {
+		switch expression.Operation {
+		case ast.OperationPlus, ast.OperationMinus, ast.OperationMul:
+			if leftRisky || rightRisky {
+				a.report(expression, OverflowRiskWrappingArithmetic, OverflowRiskSeverityHigh)
+			}
+		}
+		return
+	}
+
+	switch expression.Operation {
+	case ast.OperationMul:
+		if leftRisky && rightRisky {
+			a.report(expression, OverflowRiskMultiplication, OverflowRiskSeverityMedium)
+		} else if leftRisky || rightRisky {
+			a.report(expression, OverflowRiskMultiplication, OverflowRiskSeverityLow)
+		}
+
+	case ast.OperationMinus:
+		min, _ := numberTypeRange(resultType)
+		if min != nil && min.Sign() == 0 && rightRisky {
+			a.report(expression, OverflowRiskUnsignedSubtraction, OverflowRiskSeverityLow)
+		}
+	}
+}
+
+// inspectConversion reports the given invocation if it is a conversion
+// of an unchecked, user-controlled value to a number type with a smaller range.
+//
+func (a *overflowRiskFunctionAnalysis) inspectConversion(invocation *ast.InvocationExpression) {
+	if len(invocation.Arguments) != 1 {
+		return
+	}
+
+	identifierExpression, ok := invocation.InvokedExpression.(*ast.IdentifierExpression)
+	if !ok {
+		return
+	}
+
+	targetType := a.elaboration.InvocationExpressionReturnTypes[invocation]
+	if targetType == nil || targetType.String() != identifierExpression.Identifier.Identifier {
+		return
+	}
+
+	argumentTypes := a.elaboration.InvocationExpressionArgumentTypes[invocation]
+	if len(argumentTypes) != 1 {
+		return
+	}
+
+	if !isNarrowingConversion(argumentTypes[0], targetType) ||
+		!a.isUnchecked(invocation.Arguments[0].Expression) {
+
+		return
+	}
+
+	a.report(invocation, OverflowRiskNarrowingConversion, OverflowRiskSeverityMedium)
+}
+
+func (a *overflowRiskFunctionAnalysis) report(
+	expression ast.Expression,
+	kind OverflowRiskKind,
+	severity OverflowRiskSeverity,
+) {
+	a.risks = append(a.risks, OverflowRisk{
+		Kind:       kind,
+		Severity:   severity,
+		Function:   a.function.name,
+		Expression: expression.String(),
+		Range:      ast.NewRangeFromPositioned(expression),
+	})
+}
+
+// isUnchecked returns true if the given expression is user-controlled,
+// and it is not compared anywhere in the function.
+//
+func (a *overflowRiskFunctionAnalysis) isUnchecked(expression ast.Expression) bool {
+	if _, ok := a.checked[expression.String()]; ok {
+		return false
+	}
+	return a.isControlled(expression)
+}
+
+// isControlled returns true if the value of the given expression may be controlled by a user.
+//
+func (a *overflowRiskFunctionAnalysis) isControlled(expression ast.Expression) bool {
+	switch expression := expression.(type) {
+	case *ast.IdentifierExpression:
+		_, ok := a.controlled[expression.Identifier.Identifier]
+		return ok
+
+	case *ast.MemberExpression:
+		memberInfo, ok := a.elaboration.MemberExpressionMemberInfos[expression]
+		if ok && isCompositeOrInterfaceType(unwrapAccessedType(memberInfo.AccessedType)) {
+			return true
+		}
+		return a.isControlled(expression.Expression)
+
+	case *ast.InvocationExpression:
+		if memberExpression, ok := expression.InvokedExpression.(*ast.MemberExpression); ok &&
+			a.isControlled(memberExpression) {
+
+			return true
+		}
+		for _, argument := range expression.Arguments {
+			if a.isControlled(argument.Expression) {
+				return true
+			}
+		}
+
+	case *ast.IndexExpression:
+		return a.isControlled(expression.TargetExpression)
+
+	case *ast.ReferenceExpression:
+		return a.isControlled(expression.Expression)
+
+	case *ast.CastingExpression:
+		return a.isControlled(expression.Expression)
+
+	case *ast.ForceExpression:
+		return a.isControlled(expression.Expression)
+
+	case *ast.UnaryExpression:
+		return a.isControlled(expression.Expression)
+
+	case *ast.BinaryExpression:
+		return a.isControlled(expression.Left) ||
+			a.isControlled(expression.Right)
+
+	case *ast.ConditionalExpression:
+		return a.isControlled(expression.Then) ||
+			a.isControlled(expression.Else)
+	}
+
+	return false
+}
+
+func isWordType(ty sema.Type) bool {
+	switch ty {
+	case sema.Word8Type, sema.Word16Type, sema.Word32Type, sema.Word64Type:
+		return true
+	}
+	return false
+}
+
+// numberTypeRange returns the range of the integer part of the given number type.
+// A nil bound means the range is unbounded in that direction.
+//
+func numberTypeRange(ty sema.Type) (min, max *big.Int) {
+	switch ty := ty.(type) {
+	case *sema.NumericType:
+		return ty.MinInt(), ty.MaxInt()
+	case *sema.FixedPointNumericType:
+		return ty.MinInt(), ty.MaxInt()
+	}
+	return nil, nil
+}
+
+// isNarrowingConversion returns true if the range of the source number type
+// is not contained in the range of the target number type.
+//
+func isNarrowingConversion(sourceType, targetType sema.Type) bool {
+	switch sourceType.(type) {
+	case *sema.NumericType, *sema.FixedPointNumericType:
+	default:
+		return false
+	}
+
+	sourceMin, sourceMax := numberTypeRange(sourceType)
+	targetMin, targetMax := numberTypeRange(targetType)
+
+	if targetMin != nil && (sourceMin == nil || sourceMin.Cmp(targetMin) < 0) {
+		return true
+	}
+
+	if targetMax != nil && (sourceMax == nil || sourceMax.Cmp(targetMax) > 0) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,180 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestAnalyzeOverflowRisks(t *testing.T) {
+
+	t.Parallel()
+
+	analyze := func(t *testing.T, code string) []string {
+		runtime := newTestInterpreterRuntime()
+
+		program, err := runtime.ParseAndCheckProgram(
+			[]byte(code),
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		var risks []string
+		for _, risk := range AnalyzeOverflowRisks(program) {
+			risks = append(risks, risk.String())
+		}
+		return risks
+	}
+
+	t.Run("token math", func(t *testing.T) {
+
+		t.Parallel()
+
+		risks := analyze(t, `
+          pub resource Vault {
+              pub var balance: UFix64
+              pub var price: UFix64
+
+              init() {
+                  self.balance = 0.0
+                  self.price = 1.0
+              }
+
+              pub fun value(): UFix64 {
+                  return self.balance * self.price
+              }
+
+              pub fun withdraw(amount: UFix64) {
+                  self.balance = self.balance - amount
+              }
+
+              pub fun fee(amount: UFix64): UFix64 {
+                  return amount * 0.01
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"12:25: Medium: Multiplication in `Vault.value`: `(self.balance * self.price)`",
+				"16:33: Low: UnsignedSubtraction in `Vault.withdraw`: `(self.balance - amount)`",
+				"20:25: Low: Multiplication in `Vault.fee`: `(amount * 0.01)`",
+			},
+			risks,
+		)
+	})
+
+	t.Run("bounds checks", func(t *testing.T) {
+
+		t.Parallel()
+
+		risks := analyze(t, `
+          pub resource Vault {
+              pub var balance: UFix64
+
+              init() {
+                  self.balance = 0.0
+              }
+
+              pub fun withdraw(amount: UFix64) {
+                  pre {
+                      amount <= self.balance
+                  }
+                  self.balance = self.balance - amount
+              }
+          }
+
+          pub fun scale(x: UInt64, y: UInt64): UInt64 {
+              if x > 1000 || y > 1000 {
+                  panic("too large")
+              }
+              return x * y
+          }
+        `)
+
+		assert.Empty(t, risks)
+	})
+
+	t.Run("literals and locals", func(t *testing.T) {
+
+		t.Parallel()
+
+		risks := analyze(t, `
+          pub fun test(): UInt8 {
+              let x: UInt64 = 2
+              let y = x * 3
+              return UInt8(y - 1)
+          }
+        `)
+
+		assert.Empty(t, risks)
+	})
+
+	t.Run("wrapping arithmetic", func(t *testing.T) {
+
+		t.Parallel()
+
+		risks := analyze(t, `
+          pub fun hash(_ value: Word64): Word64 {
+              return value * 31 + 7
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"3:21: High: WrappingArithmetic in `hash`: `((value * 31) + 7)`",
+				"3:21: High: WrappingArithmetic in `hash`: `(value * 31)`",
+			},
+			risks,
+		)
+	})
+
+	t.Run("narrowing conversions", func(t *testing.T) {
+
+		t.Parallel()
+
+		risks := analyze(t, `
+          transaction(amount: UInt64, small: UInt8) {
+              execute {
+                  let a = UInt8(amount)
+                  let b = UInt64(small)
+                  let c = Int8(small)
+                  let d = UFix64(amount)
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"4:26: Medium: NarrowingConversion in `execute`: `UInt8(amount)`",
+				"6:26: Medium: NarrowingConversion in `execute`: `Int8(small)`",
+				"7:26: Medium: NarrowingConversion in `execute`: `UFix64(amount)`",
+			},
+			risks,
+		)
+	})
+}
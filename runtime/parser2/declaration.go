@@ -47,8 +47,17 @@ func parseDeclarations(p *parser, endTokenType lexer.TokenType) (declarations []
 			return
 
 		default:
-			declaration := parseDeclaration(p, docString)
+			var declaration ast.Declaration
+			recovered := p.parseRecoverable(isDeclarationSynchronizationPoint, func() {
+				declaration = parseDeclaration(p, docString)
+			})
+			if recovered {
+				continue
+			}
 			if declaration == nil {
+				if p.skipUnexpectedToken(isDeclarationSynchronizationPoint) {
+					continue
+				}
 				return
 			}
 
@@ -88,6 +97,11 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordAttachment:
+				if isNextTokenIdentifier(p) {
+					return parseAttachmentDeclaration(p, access, accessPos, docString)
+				}
+
 			case KeywordTransaction:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("invalid access modifier for transaction"))
@@ -100,8 +114,19 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 				}
 				pos := p.current.StartPos
 				accessPos = &pos
-				access = parseAccess(p)
+				access, _ = parseAccess(p)
+				if access == ast.AccessEntitlement {
+					panic(fmt.Errorf("invalid entitlement access modifier for non-member declaration"))
+				}
 				continue
+
+			default:
+				// The `entitlement` keyword is not reserved:
+				// it only introduces an entitlement declaration if an identifier follows
+
+				if p.current.Value == keywordEntitlement && isNextTokenIdentifier(p) {
+					return parseEntitlementOrMappingDeclaration(p, access, accessPos, docString)
+				}
 			}
 		}
 
@@ -109,27 +134,28 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 	}
 }
 
-// parseAccess parses an access modifier
+// parseAccess parses an access modifier.
+// The entitlements are only returned for entitlement access, i.e. ast.AccessEntitlement.
 //
 //     access
 //         : 'priv'
 //         | 'pub' ( '(' 'set' ')' )?
-//         | 'access' '(' ( 'self' | 'contract' | 'account' | 'all' ) ')'
+//         | 'access' '(' ( 'self' | 'contract' | 'account' | 'all' | entitlements ) ')'
 //
-func parseAccess(p *parser) ast.Access {
+func parseAccess(p *parser) (ast.Access, *ast.EntitlementSet) {
 
 	switch p.current.Value {
 	case keywordPriv:
 		// Skip the `priv` keyword
 		p.next()
-		return ast.AccessPrivate
+		return ast.AccessPrivate, nil
 
 	case keywordPub:
 		// Skip the `pub` keyword
 		p.next()
 		p.skipSpaceAndComments(true)
 		if !p.current.Is(lexer.TokenParenOpen) {
-			return ast.AccessPublic
+			return ast.AccessPublic, nil
 		}
 
 		// Skip the opening paren
@@ -157,7 +183,7 @@ func parseAccess(p *parser) ast.Access {
 
 		p.mustOne(lexer.TokenParenClose)
 
-		return ast.AccessPublicSettable
+		return ast.AccessPublicSettable, nil
 
 	case keywordAccess:
 		// Skip the `access` keyword
@@ -200,19 +226,14 @@ func parseAccess(p *parser) ast.Access {
 			access = ast.AccessPrivate
 
 		default:
-			panic(fmt.Errorf(
-				"expected keyword %s, got %q",
-				common.EnumerateWords(
-					[]string{
-						strconv.Quote(keywordAll),
-						strconv.Quote(keywordAccount),
-						strconv.Quote(keywordContract),
-						strconv.Quote(keywordSelf),
-					},
-					"or",
-				),
-				p.current.Value,
-			))
+			// Any other identifier is the first entitlement of an entitlement set,
+			// e.g. `access(E)` or `access(E1, E2)`
+
+			entitlements := parseEntitlementSet(p, lexer.TokenParenClose)
+
+			p.mustOne(lexer.TokenParenClose)
+
+			return ast.AccessEntitlement, entitlements
 		}
 
 		// Skip the keyword
@@ -221,7 +242,7 @@ func parseAccess(p *parser) ast.Access {
 
 		p.mustOne(lexer.TokenParenClose)
 
-		return access
+		return access, nil
 
 	default:
 		panic(errors.NewUnreachableError())
@@ -263,6 +284,7 @@ func parseVariableDeclaration(
 	}
 
 	identifier := tokenToIdentifier(p.current)
+	p.checkSoftKeyword(identifier)
 
 	// Skip the identifier
 	p.next()
@@ -570,6 +592,34 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 	}
 }
 
+// isNextTokenIdentifier checks whether the token to follow is an identifier on the same line.
+// It is used to determine if a contextual keyword, like `attachment`, is used as a keyword,
+// or as an identifier.
+//
+func isNextTokenIdentifier(p *parser) bool {
+	p.startBuffering()
+	defer p.replayBuffered()
+
+	// skip the current token
+	p.next()
+
+	return isIdentifierAhead(p)
+}
+
+// isIdentifierAhead checks whether the current token, ignoring whitespace and comments,
+// is an identifier on the same line.
+// The casting keyword is not considered, as it may follow any expression.
+//
+func isIdentifierAhead(p *parser) bool {
+	p.startBuffering()
+	defer p.replayBuffered()
+
+	p.skipSpaceAndComments(false)
+
+	return p.current.Is(lexer.TokenIdentifier) &&
+		p.current.Value != keywordAs
+}
+
 // isNextTokenCommaOrFrom check whether the token to follow is a comma or a from token.
 func isNextTokenCommaOrFrom(p *parser) bool {
 	p.startBuffering()
@@ -644,6 +694,7 @@ func parseEventDeclaration(
 	}
 
 	identifier := tokenToIdentifier(p.current)
+	p.checkSoftKeyword(identifier)
 	// Skip the identifier
 	p.next()
 
@@ -739,6 +790,7 @@ func parseFieldWithVariableKind(
 	}
 
 	identifier := tokenToIdentifier(p.current)
+	p.checkSoftKeyword(identifier)
 	// Skip the identifier
 	p.next()
 	p.skipSpaceAndComments(true)
@@ -817,6 +869,7 @@ func parseCompositeOrInterfaceDeclaration(
 			continue
 		} else {
 			identifier = tokenToIdentifier(p.current)
+			p.checkSoftKeyword(identifier)
 			// Skip the identifier
 			p.next()
 			break
@@ -884,6 +937,190 @@ func parseCompositeOrInterfaceDeclaration(
 	}
 }
 
+// parseAttachmentDeclaration parses an attachment declaration.
+//
+//     attachmentDeclaration : 'attachment' identifier 'for' nominalType conformances?
+//                             '{' membersAndNestedDeclarations '}'
+//
+func parseAttachmentDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.CompositeDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `attachment` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	identifier := tokenToIdentifier(p.mustOne(lexer.TokenIdentifier))
+	p.checkSoftKeyword(identifier)
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordFor) {
+		panic(fmt.Errorf(
+			"expected keyword %q, got %s",
+			keywordFor,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `for` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	baseTypeToken := p.mustOne(lexer.TokenIdentifier)
+	baseType := parseNominalTypeRemainder(p, baseTypeToken)
+
+	p.skipSpaceAndComments(true)
+
+	var conformances []*ast.NominalType
+
+	if p.current.Is(lexer.TokenColon) {
+		// Skip the colon
+		p.next()
+
+		conformances, _ = parseNominalTypes(p, lexer.TokenBraceOpen)
+
+		if len(conformances) < 1 {
+			panic(fmt.Errorf(
+				"expected at least one conformance after %s",
+				lexer.TokenColon,
+			))
+		}
+	}
+
+	p.skipSpaceAndComments(true)
+
+	p.mustOne(lexer.TokenBraceOpen)
+
+	members := parseMembersAndNestedDeclarations(p, lexer.TokenBraceClose)
+
+	p.skipSpaceAndComments(true)
+
+	endToken := p.mustOne(lexer.TokenBraceClose)
+
+	return &ast.CompositeDeclaration{
+		Access:        access,
+		CompositeKind: common.CompositeKindAttachment,
+		Identifier:    identifier,
+		BaseType:      baseType,
+		Conformances:  conformances,
+		Members:       members,
+		DocString:     docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endToken.EndPos,
+		},
+	}
+}
+
+// parseEntitlementOrMappingDeclaration parses an entitlement declaration,
+// or an entitlement mapping declaration.
+//
+//     entitlementDeclaration : 'entitlement' identifier
+//
+//     entitlementMappingDeclaration : 'entitlement' 'mapping' identifier
+//                                     '{' entitlementMapElement* '}'
+//
+func parseEntitlementOrMappingDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) ast.Declaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `entitlement` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	identifierToken := p.mustOne(lexer.TokenIdentifier)
+
+	// The `mapping` keyword is not reserved:
+	// it only introduces an entitlement mapping declaration if an identifier follows,
+	// otherwise it is the name of the entitlement
+
+	if identifierToken.Value != keywordMapping || !isIdentifierAhead(p) {
+		identifier := tokenToIdentifier(identifierToken)
+		p.checkSoftKeyword(identifier)
+
+		return &ast.EntitlementDeclaration{
+			Access:     access,
+			Identifier: identifier,
+			DocString:  docString,
+			Range: ast.Range{
+				StartPos: startPos,
+				EndPos:   identifier.EndPosition(),
+			},
+		}
+	}
+
+	p.skipSpaceAndComments(true)
+	identifier := tokenToIdentifier(p.mustOne(lexer.TokenIdentifier))
+	p.checkSoftKeyword(identifier)
+
+	p.skipSpaceAndComments(true)
+	p.mustOne(lexer.TokenBraceOpen)
+
+	associations := parseEntitlementMapElements(p)
+
+	endToken := p.mustOne(lexer.TokenBraceClose)
+
+	return &ast.EntitlementMappingDeclaration{
+		Access:       access,
+		Identifier:   identifier,
+		Associations: associations,
+		DocString:    docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endToken.EndPos,
+		},
+	}
+}
+
+// parseEntitlementMapElements parses the associations of an entitlement mapping declaration,
+// up to the closing brace.
+//
+//     entitlementMapElement : nominalType '->' nominalType
+//
+func parseEntitlementMapElements(p *parser) (associations []*ast.EntitlementMapElement) {
+	for {
+		p.skipSpaceAndComments(true)
+
+		if p.current.Is(lexer.TokenBraceClose) {
+			return
+		}
+
+		inputToken := p.mustOne(lexer.TokenIdentifier)
+		input := parseNominalTypeRemainder(p, inputToken)
+
+		p.skipSpaceAndComments(true)
+		p.mustOne(lexer.TokenRightArrow)
+
+		p.skipSpaceAndComments(true)
+		outputToken := p.mustOne(lexer.TokenIdentifier)
+		output := parseNominalTypeRemainder(p, outputToken)
+
+		associations = append(
+			associations,
+			&ast.EntitlementMapElement{
+				Input:  input,
+				Output: output,
+			},
+		)
+	}
+}
+
 // parseMembersAndNestedDeclarations parses composite or interface members,
 // and nested declarations.
 //
@@ -909,8 +1146,17 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 			return ast.NewMembers(declarations)
 
 		default:
-			memberOrNestedDeclaration := parseMemberOrNestedDeclaration(p, docString)
+			var memberOrNestedDeclaration ast.Declaration
+			recovered := p.parseRecoverable(isMemberSynchronizationPoint, func() {
+				memberOrNestedDeclaration = parseMemberOrNestedDeclaration(p, docString)
+			})
+			if recovered {
+				continue
+			}
 			if memberOrNestedDeclaration == nil {
+				if p.skipUnexpectedToken(isMemberSynchronizationPoint) {
+					continue
+				}
 				return ast.NewMembers(declarations)
 			}
 
@@ -927,8 +1173,11 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 //                               | functionDeclaration
 //                               | interfaceDeclaration
 //                               | compositeDeclaration
+//                               | attachmentDeclaration
 //                               | eventDeclaration
 //                               | enumCase
+//                               | entitlementDeclaration
+//                               | entitlementMappingDeclaration
 //
 func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration {
 
@@ -936,6 +1185,17 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 
 	access := ast.AccessNotSpecified
 	var accessPos *ast.Position
+	var entitlements *ast.EntitlementSet
+
+	// Only fields and functions may have entitlement access
+	rejectEntitlementAccess := func(declarationDescription string) {
+		if access == ast.AccessEntitlement {
+			panic(fmt.Errorf(
+				"invalid entitlement access modifier for %s",
+				declarationDescription,
+			))
+		}
+	}
 
 	var previousIdentifierToken *lexer.Token
 
@@ -946,18 +1206,25 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 		case lexer.TokenIdentifier:
 			switch p.current.Value {
 			case keywordLet, keywordVar:
-				return parseFieldWithVariableKind(p, access, accessPos, docString)
+				field := parseFieldWithVariableKind(p, access, accessPos, docString)
+				field.Entitlements = entitlements
+				return field
 
 			case keywordCase:
+				rejectEntitlementAccess(common.DeclarationKindEnumCase.Name())
 				return parseEnumCase(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, docString)
+				function := parseFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, docString)
+				function.Entitlements = entitlements
+				return function
 
 			case keywordEvent:
+				rejectEntitlementAccess(common.DeclarationKindEvent.Name())
 				return parseEventDeclaration(p, access, accessPos, docString)
 
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
+				rejectEntitlementAccess("type declaration")
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
 			case keywordPriv, keywordPub, keywordAccess:
@@ -966,10 +1233,33 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 				}
 				pos := p.current.StartPos
 				accessPos = &pos
-				access = parseAccess(p)
+				access, entitlements = parseAccess(p)
 				continue
 
 			default:
+				// The `attachment` keyword is not reserved:
+				// it only introduces an attachment declaration if an identifier follows,
+				// otherwise it is the name of a field or special function
+
+				if p.current.Value == keywordAttachment &&
+					previousIdentifierToken == nil &&
+					isNextTokenIdentifier(p) {
+
+					rejectEntitlementAccess(common.DeclarationKindAttachment.Name())
+					return parseAttachmentDeclaration(p, access, accessPos, docString)
+				}
+
+				// The `entitlement` keyword is not reserved either:
+				// it only introduces an entitlement declaration if an identifier follows
+
+				if p.current.Value == keywordEntitlement &&
+					previousIdentifierToken == nil &&
+					isNextTokenIdentifier(p) {
+
+					rejectEntitlementAccess("entitlement declaration")
+					return parseEntitlementOrMappingDeclaration(p, access, accessPos, docString)
+				}
+
 				if previousIdentifierToken != nil {
 					panic(fmt.Errorf("unexpected %s", p.current.Type))
 				}
@@ -987,7 +1277,9 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 			}
 
 			identifier := tokenToIdentifier(*previousIdentifierToken)
-			return parseFieldDeclarationWithoutVariableKind(p, access, accessPos, identifier, docString)
+			field := parseFieldDeclarationWithoutVariableKind(p, access, accessPos, identifier, docString)
+			field.Entitlements = entitlements
+			return field
 
 		case lexer.TokenParenOpen:
 			if previousIdentifierToken == nil {
@@ -995,6 +1287,7 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 			}
 
 			identifier := tokenToIdentifier(*previousIdentifierToken)
+			rejectEntitlementAccess("special function")
 			return parseSpecialFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, identifier)
 		}
 
@@ -1114,6 +1407,7 @@ func parseEnumCase(
 	}
 
 	identifier := tokenToIdentifier(p.current)
+	p.checkSoftKeyword(identifier)
 	// Skip the identifier
 	p.next()
 
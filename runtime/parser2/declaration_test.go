@@ -966,7 +966,8 @@ func TestParseAccess(t *testing.T) {
 		return Parse(
 			input,
 			func(p *parser) interface{} {
-				return parseAccess(p)
+				access, _ := parseAccess(p)
+				return access
 			},
 		)
 	}
@@ -1167,23 +1168,15 @@ func TestParseAccess(t *testing.T) {
 		)
 	})
 
-	t.Run("access, invalid inner keyword", func(t *testing.T) {
+	t.Run("access, entitlement", func(t *testing.T) {
 
 		t.Parallel()
 
 		result, errs := parse("access ( foo )")
-		utils.AssertEqualWithDiff(t,
-			[]error{
-				&SyntaxError{
-					Message: "expected keyword \"all\", \"account\", \"contract\", or \"self\", got \"foo\"",
-					Pos:     ast.Position{Offset: 9, Line: 1, Column: 9},
-				},
-			},
-			errs,
-		)
+		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
-			nil,
+			ast.AccessEntitlement,
 			result,
 		)
 	})
@@ -4630,6 +4623,121 @@ func TestParseDestructor(t *testing.T) {
 	)
 }
 
+func TestParseAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no conformances", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub attachment A for R { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					Access:        ast.AccessPublic,
+					CompositeKind: common.CompositeKindAttachment,
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 15, Offset: 15},
+					},
+					BaseType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "R",
+							Pos:        ast.Position{Line: 1, Column: 21, Offset: 21},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 25, Offset: 25},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested, with conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("contract C { attachment A for C.R: I { } }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					CompositeKind: common.CompositeKindContract,
+					Identifier: ast.Identifier{
+						Identifier: "C",
+						Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+					Members: ast.NewMembers(
+						[]ast.Declaration{
+							&ast.CompositeDeclaration{
+								CompositeKind: common.CompositeKindAttachment,
+								Identifier: ast.Identifier{
+									Identifier: "A",
+									Pos:        ast.Position{Line: 1, Column: 24, Offset: 24},
+								},
+								BaseType: &ast.NominalType{
+									Identifier: ast.Identifier{
+										Identifier: "C",
+										Pos:        ast.Position{Line: 1, Column: 30, Offset: 30},
+									},
+									NestedIdentifiers: []ast.Identifier{
+										{
+											Identifier: "R",
+											Pos:        ast.Position{Line: 1, Column: 32, Offset: 32},
+										},
+									},
+								},
+								Conformances: []*ast.NominalType{
+									{
+										Identifier: ast.Identifier{
+											Identifier: "I",
+											Pos:        ast.Position{Line: 1, Column: 35, Offset: 35},
+										},
+									},
+								},
+								Members: &ast.Members{},
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+									EndPos:   ast.Position{Line: 1, Column: 39, Offset: 39},
+								},
+							},
+						},
+					),
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 41, Offset: 41},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing for", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("attachment A R { }")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected keyword \"for\", got identifier",
+					Pos:     ast.Position{Offset: 13, Line: 1, Column: 13},
+				},
+			},
+			errs,
+		)
+	})
+}
+
 func TestParseCompositeDeclarationWithSemicolonSeparatedMembers(t *testing.T) {
 
 	t.Parallel()
@@ -4974,3 +5082,182 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		)
 	})
 }
+
+func TestParseEntitlementDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("basic", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub entitlement E")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.EntitlementDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "E",
+						Pos:        ast.Position{Offset: 16, Line: 1, Column: 16},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 16, Line: 1, Column: 16},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("pub entitlement")
+		require.NotEmpty(t, errs)
+	})
+
+	t.Run("mapping", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("entitlement mapping M { A -> B }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.EntitlementMappingDeclaration{
+					Access: ast.AccessNotSpecified,
+					Identifier: ast.Identifier{
+						Identifier: "M",
+						Pos:        ast.Position{Offset: 20, Line: 1, Column: 20},
+					},
+					Associations: []*ast.EntitlementMapElement{
+						{
+							Input: &ast.NominalType{
+								Identifier: ast.Identifier{
+									Identifier: "A",
+									Pos:        ast.Position{Offset: 24, Line: 1, Column: 24},
+								},
+							},
+							Output: &ast.NominalType{
+								Identifier: ast.Identifier{
+									Identifier: "B",
+									Pos:        ast.Position{Offset: 29, Line: 1, Column: 29},
+								},
+							},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 31, Line: 1, Column: 31},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("entitlement named mapping", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("entitlement mapping")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.EntitlementDeclaration{
+					Access: ast.AccessNotSpecified,
+					Identifier: ast.Identifier{
+						Identifier: "mapping",
+						Pos:        ast.Position{Offset: 12, Line: 1, Column: 12},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 18, Line: 1, Column: 18},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("mapping, missing arrow", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("entitlement mapping M { A B }")
+		require.NotEmpty(t, errs)
+	})
+}
+
+func TestParseEntitledFieldDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	result, errs := ParseDeclarations("struct S { access(E, F) let x: Int }")
+	require.Empty(t, errs)
+
+	utils.AssertEqualWithDiff(t,
+		[]ast.Declaration{
+			&ast.CompositeDeclaration{
+				CompositeKind: common.CompositeKindStructure,
+				Identifier: ast.Identifier{
+					Identifier: "S",
+					Pos:        ast.Position{Offset: 7, Line: 1, Column: 7},
+				},
+				Members: ast.NewMembers(
+					[]ast.Declaration{
+						&ast.FieldDeclaration{
+							Access: ast.AccessEntitlement,
+							Entitlements: &ast.EntitlementSet{
+								Kind: ast.EntitlementSetKindConjunction,
+								Entitlements: []*ast.NominalType{
+									{
+										Identifier: ast.Identifier{
+											Identifier: "E",
+											Pos:        ast.Position{Offset: 18, Line: 1, Column: 18},
+										},
+									},
+									{
+										Identifier: ast.Identifier{
+											Identifier: "F",
+											Pos:        ast.Position{Offset: 21, Line: 1, Column: 21},
+										},
+									},
+								},
+							},
+							VariableKind: ast.VariableKindConstant,
+							Identifier: ast.Identifier{
+								Identifier: "x",
+								Pos:        ast.Position{Offset: 28, Line: 1, Column: 28},
+							},
+							TypeAnnotation: &ast.TypeAnnotation{
+								Type: &ast.NominalType{
+									Identifier: ast.Identifier{
+										Identifier: "Int",
+										Pos:        ast.Position{Offset: 31, Line: 1, Column: 31},
+									},
+								},
+								StartPos: ast.Position{Offset: 31, Line: 1, Column: 31},
+							},
+							Range: ast.Range{
+								StartPos: ast.Position{Offset: 11, Line: 1, Column: 11},
+								EndPos:   ast.Position{Offset: 33, Line: 1, Column: 33},
+							},
+						},
+					},
+				),
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+					EndPos:   ast.Position{Offset: 35, Line: 1, Column: 35},
+				},
+			},
+		},
+		result,
+	)
+}
@@ -58,6 +58,38 @@ type ParseError interface {
 	isParseError()
 }
 
+// Warning is a problem which does not prevent a program from being parsed,
+// but which should be addressed, as it might become an error in the future
+
+type Warning interface {
+	error
+	ast.HasPosition
+	isParseWarning()
+}
+
+// SoftKeywordWarning
+
+type SoftKeywordWarning struct {
+	Keyword string
+	ast.Range
+}
+
+func (*SoftKeywordWarning) isParseWarning() {}
+
+func (e *SoftKeywordWarning) Error() string {
+	return fmt.Sprintf(
+		"`%s` is a soft keyword, which may be reserved in a future version",
+		e.Keyword,
+	)
+}
+
+func (e *SoftKeywordWarning) SecondaryError() string {
+	return fmt.Sprintf(
+		"consider renaming to avoid breakage, e.g. to `%s_`",
+		e.Keyword,
+	)
+}
+
 // SyntaxError
 
 type SyntaxError struct {
@@ -766,11 +766,17 @@ func defineIdentifierExpression() {
 			case keywordFun:
 				return parseFunctionExpression(p, token)
 
-			default:
-				return &ast.IdentifierExpression{
-					Identifier: tokenToIdentifier(token),
+			case keywordAttach:
+				// The `attach` keyword is not reserved:
+				// it only introduces an attach expression if an identifier follows
+				if isIdentifierAhead(p) {
+					return parseAttachExpressionRemainder(p, token)
 				}
 			}
+
+			return &ast.IdentifierExpression{
+				Identifier: tokenToIdentifier(token),
+			}
 		},
 	})
 }
@@ -851,6 +857,35 @@ func parseCreateExpressionRemainder(p *parser, token lexer.Token) *ast.CreateExp
 	}
 }
 
+// parseAttachExpressionRemainder parses an attach expression,
+// after the `attach` keyword.
+//
+//     attachExpression : 'attach' nominalType invocation 'to' expression
+//
+func parseAttachExpressionRemainder(p *parser, token lexer.Token) *ast.AttachExpression {
+	attachment := parseNominalTypeInvocationRemainder(p)
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordTo) {
+		panic(fmt.Errorf(
+			"expected keyword %q, got %s",
+			keywordTo,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `to` keyword
+	p.next()
+
+	base := parseExpression(p, lowestBindingPower)
+
+	return &ast.AttachExpression{
+		Base:       base,
+		Attachment: attachment,
+		StartPos:   token.StartPos,
+	}
+}
+
 // Invocation Expression Grammar:
 //
 //     invocation : '(' ( argument ( ',' argument )* )? ')'
@@ -1353,7 +1388,15 @@ func parseStringLiteral(literal string) (result string, errs []error) {
 //
 func parseStringLiteralContent(s string) (result string, errs []error) {
 
+	// Fast path: the content has no escape sequences and is valid UTF-8,
+	// so the result is the content itself and no copy is needed
+
+	if strings.IndexByte(s, '\\') < 0 && utf8.ValidString(s) {
+		return s, nil
+	}
+
 	var builder strings.Builder
+	builder.Grow(len(s))
 	defer func() {
 		result = builder.String()
 	}()
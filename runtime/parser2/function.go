@@ -151,12 +151,15 @@ func parseParameter(p *parser) *ast.Parameter {
 
 	endPos := typeAnnotation.EndPosition()
 
+	identifier := ast.Identifier{
+		Identifier: parameterName,
+		Pos:        parameterPos,
+	}
+	p.checkSoftKeyword(identifier)
+
 	return &ast.Parameter{
-		Label: argumentLabel,
-		Identifier: ast.Identifier{
-			Identifier: parameterName,
-			Pos:        parameterPos,
-		},
+		Label:          argumentLabel,
+		Identifier:     identifier,
 		TypeAnnotation: typeAnnotation,
 		Range: ast.Range{
 			StartPos: startPos,
@@ -190,6 +193,7 @@ func parseFunctionDeclaration(
 	}
 
 	identifier := tokenToIdentifier(p.current)
+	p.checkSoftKeyword(identifier)
 
 	// Skip the identifier
 	p.next()
@@ -0,0 +1,175 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+// TextEdit is a change of code:
+// The text between the start offset (inclusive) and the end offset (exclusive)
+// is replaced with the new text.
+//
+type TextEdit struct {
+	StartOffset int
+	EndOffset   int
+	Text        string
+}
+
+// Apply returns the given code with the edit applied.
+//
+func (e TextEdit) Apply(code string) (string, error) {
+	if e.StartOffset < 0 ||
+		e.StartOffset > e.EndOffset ||
+		e.EndOffset > len(code) {
+
+		return "", fmt.Errorf(
+			"invalid text edit range: %d to %d, code length is %d",
+			e.StartOffset,
+			e.EndOffset,
+			len(code),
+		)
+	}
+
+	return code[:e.StartOffset] + e.Text + code[e.EndOffset:], nil
+}
+
+// ReparseProgram parses the code which results from applying the given edit to the given previous code,
+// reusing the unaffected declarations of the given program, which must be the result of parsing the previous code.
+//
+// Only the code following the edit, and the declaration directly preceding it, is re-scanned and re-parsed.
+// The declaration directly preceding the edit is re-parsed, because the edit may extend it,
+// e.g. by continuing an expression. All declarations before it are reused as-is.
+//
+// The result is the same as parsing the new code with ParseProgram.
+//
+func ReparseProgram(
+	previous *ast.Program,
+	previousCode string,
+	edit TextEdit,
+) (
+	program *ast.Program,
+	code string,
+	err error,
+) {
+	return reparseProgram(previous, previousCode, edit, false)
+}
+
+// ReparseProgramWithRecovery is like ReparseProgram, but recovers from syntax errors,
+// like ParseProgramWithRecovery.
+//
+// The result is the same as parsing the new code with ParseProgramWithRecovery.
+//
+func ReparseProgramWithRecovery(
+	previous *ast.Program,
+	previousCode string,
+	edit TextEdit,
+) (
+	program *ast.Program,
+	code string,
+	err error,
+) {
+	return reparseProgram(previous, previousCode, edit, true)
+}
+
+func reparseProgram(
+	previous *ast.Program,
+	previousCode string,
+	edit TextEdit,
+	errorRecovery bool,
+) (
+	program *ast.Program,
+	code string,
+	err error,
+) {
+	code, err = edit.Apply(previousCode)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var reused []ast.Declaration
+	if previous != nil {
+		reused = reusableDeclarations(previous.Declarations(), edit.StartOffset)
+	}
+
+	// Start parsing directly after the last reused declaration,
+	// so the trivia before the next declaration, e.g. its docstring, is parsed
+
+	start := ast.Position{Line: 1}
+	if len(reused) > 0 {
+		end := reused[len(reused)-1].EndPosition()
+		start = ast.Position{
+			Offset: end.Offset + 1,
+			Line:   end.Line,
+			Column: end.Column + 1,
+		}
+	}
+
+	tokens := lexer.LexFrom(code, start)
+	defer tokens.Reclaim()
+
+	p := &parser{
+		tokens:        tokens,
+		errorRecovery: errorRecovery,
+	}
+
+	res, errs := parseTokenStream(p, func(p *parser) interface{} {
+		return parseDeclarations(p, lexer.TokenEOF)
+	})
+	if len(errs) > 0 {
+		err = Error{
+			Code:   code,
+			Errors: errs,
+		}
+	}
+
+	if res == nil && !errorRecovery {
+		return nil, code, err
+	}
+
+	declarations := make([]ast.Declaration, 0, len(reused))
+	declarations = append(declarations, reused...)
+	if res != nil {
+		declarations = append(declarations, res.([]ast.Declaration)...)
+	}
+
+	return ast.NewProgram(declarations), code, err
+}
+
+// reusableDeclarations returns the declarations which are not affected by an edit at the given offset,
+// i.e. all declarations which end before the offset, except the last one.
+//
+func reusableDeclarations(declarations []ast.Declaration, editOffset int) []ast.Declaration {
+	count := 0
+	for _, declaration := range declarations {
+		if declaration.EndPosition().Offset >= editOffset {
+			break
+		}
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	return declarations[:count-1]
+}
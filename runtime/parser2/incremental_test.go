@@ -0,0 +1,149 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestReparseProgram(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      /// A is the first function
+      pub fun a(): Int {
+          return 1
+      }
+
+      pub let b = 2
+
+      pub struct C {
+          pub let d: Int
+
+          init() {
+              self.d = 3
+          }
+      }
+
+      pub fun e() {}
+    `
+
+	previous, err := ParseProgram(code)
+	require.NoError(t, err)
+
+	test := func(name string, edit TextEdit) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			expectedCode, err := edit.Apply(code)
+			require.NoError(t, err)
+
+			expected, expectedErr := ParseProgram(expectedCode)
+
+			program, newCode, err := ReparseProgram(previous, code, edit)
+
+			require.Equal(t, expectedCode, newCode)
+			require.Equal(t, expectedErr, err)
+			utils.AssertEqualWithDiff(t, expected, program)
+
+			expected, expectedErr = ParseProgramWithRecovery(expectedCode)
+
+			program, _, err = ReparseProgramWithRecovery(previous, code, edit)
+
+			require.Equal(t, expectedErr, err)
+			utils.AssertEqualWithDiff(t, expected, program)
+		})
+	}
+
+	offset := func(s string) int {
+		for i := 0; i+len(s) <= len(code); i++ {
+			if code[i:i+len(s)] == s {
+				return i
+			}
+		}
+		t.Fatalf("missing %q", s)
+		return -1
+	}
+
+	test("insert at start", TextEdit{
+		StartOffset: 0,
+		EndOffset:   0,
+		Text:        "pub let z = 0\n",
+	})
+
+	test("change docstring", TextEdit{
+		StartOffset: offset("first"),
+		EndOffset:   offset("first") + len("first"),
+		Text:        "initial",
+	})
+
+	test("change struct field", TextEdit{
+		StartOffset: offset("3"),
+		EndOffset:   offset("3") + 1,
+		Text:        "4\n + 5",
+	})
+
+	test("extend previous declaration", TextEdit{
+		StartOffset: offset("2") + 1,
+		EndOffset:   offset("2") + 1,
+		Text:        " * 6",
+	})
+
+	test("insert declaration", TextEdit{
+		StartOffset: offset("pub fun e"),
+		EndOffset:   offset("pub fun e"),
+		Text:        "/// F\n      pub fun f() {}\n      ",
+	})
+
+	test("insert at end", TextEdit{
+		StartOffset: len(code),
+		EndOffset:   len(code),
+		Text:        "pub fun g() {}",
+	})
+
+	test("remove declaration", TextEdit{
+		StartOffset: offset("pub struct C"),
+		EndOffset:   offset("pub fun e"),
+		Text:        "",
+	})
+
+	test("syntax error", TextEdit{
+		StartOffset: offset("pub fun e"),
+		EndOffset:   offset("pub fun e"),
+		Text:        "pub fun (",
+	})
+
+	t.Run("invalid edit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, _, err := ReparseProgram(previous, code, TextEdit{
+			StartOffset: 1,
+			EndOffset:   len(code) + 1,
+		})
+		require.Error(t, err)
+	})
+}
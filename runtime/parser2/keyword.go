@@ -62,4 +62,27 @@ const (
 	keywordSwitch      = "switch"
 	keywordDefault     = "default"
 	keywordEnum        = "enum"
+	keywordAttachment  = "attachment"
+	keywordAttach      = "attach"
+	keywordTo          = "to"
+	keywordRemove      = "remove"
+	keywordEntitlement = "entitlement"
+	keywordMapping     = "mapping"
+	keywordView        = "view"
 )
+
+// softKeywords are keywords which are not reserved, i.e. which can still be used as identifiers,
+// because they are only keywords in certain positions, or will only become keywords in the future.
+//
+// Declaring an identifier with the name of a soft keyword is reported as a warning,
+// so programs can be migrated before the keyword is reserved.
+//
+var softKeywords = map[string]struct{}{
+	keywordAccess:      {},
+	keywordAttachment:  {},
+	keywordAttach:      {},
+	keywordRemove:      {},
+	keywordEntitlement: {},
+	keywordMapping:     {},
+	keywordView:        {},
+}
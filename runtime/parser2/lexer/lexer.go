@@ -20,6 +20,7 @@ package lexer
 
 import (
 	"fmt"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/onflow/cadence/runtime/ast"
@@ -77,14 +78,65 @@ func (l *lexer) Revert(cursor int) {
 	l.cursor = cursor
 }
 
+func (l *lexer) Reclaim() {
+	// Clear the tokens, so the values of the tokens,
+	// e.g. the strings referencing the input, can be garbage collected
+
+	for i := range l.tokens {
+		l.tokens[i] = Token{}
+	}
+
+	*l = lexer{
+		tokens: l.tokens[:0],
+	}
+
+	pool.Put(l)
+}
+
+// pool is a pool of lexers, so the token buffers of the lexers can be reused
+//
+var pool = sync.Pool{
+	New: func() interface{} {
+		return &lexer{}
+	},
+}
+
+// estimatedTokensPerByte is an estimate of the number of tokens for each byte of input,
+// which is used to preallocate the token buffer
+//
+const estimatedTokensPerByte = 0.25
+
 func Lex(input string) TokenStream {
-	l := &lexer{
-		input:         input,
-		startPos:      position{line: 1},
-		endOffset:     0,
-		prevEndOffset: 0,
+	return LexFrom(input, ast.Position{Line: 1})
+}
+
+// LexFrom is like Lex, but starts scanning the input at the given position,
+// which must be the start of a token, e.g. the end of a declaration.
+//
+// It can be used to re-scan the part of an input which follows an unchanged part.
+// The positions of the tokens are positions in the whole input.
+//
+func LexFrom(input string, start ast.Position) TokenStream {
+	l := pool.Get().(*lexer)
+
+	tokens := l.tokens
+	if tokens == nil {
+		remaining := len(input) - start.Offset
+		tokens = make([]Token, 0, int(float64(remaining)*estimatedTokensPerByte)+1)
+	}
+
+	*l = lexer{
+		input:       input,
+		startOffset: start.Offset,
+		startPos: position{
+			line:   start.Line,
+			column: start.Column,
+		},
+		endOffset:     start.Offset,
+		prevEndOffset: start.Offset,
 		current:       EOF,
 		prev:          EOF,
+		tokens:        tokens,
 	}
 	l.run(rootState)
 	return l
@@ -140,7 +192,13 @@ func (l *lexer) next() rune {
 	r := EOF
 	w := 1
 	if endOffset < len(l.input) {
-		r, w = utf8.DecodeRuneInString(l.input[endOffset:])
+		// Fast path: ASCII characters do not need to be decoded
+		b := l.input[endOffset]
+		if b < utf8.RuneSelf {
+			r = rune(b)
+		} else {
+			r, w = utf8.DecodeRuneInString(l.input[endOffset:])
+		}
 	}
 
 	l.endOffset += w
@@ -228,12 +286,17 @@ func (l *lexer) endPos() position {
 
 	endPos := l.startPos
 
-	var w int
-	for offset := startOffset; offset < endOffset-1; offset += w {
-		var r rune
-		r, w = utf8.DecodeRuneInString(l.input[offset:])
+	for offset := startOffset; offset < endOffset-1; {
+		b := l.input[offset]
 
-		if r == '\n' {
+		// Fast path: ASCII characters do not need to be decoded
+		w := 1
+		if b >= utf8.RuneSelf {
+			_, w = utf8.DecodeRuneInString(l.input[offset:])
+		}
+		offset += w
+
+		if b == '\n' {
 			endPos.line++
 			endPos.column = 0
 		} else {
@@ -264,19 +327,21 @@ func (l *lexer) emitError(err error) {
 
 func (l *lexer) scanSpace() (containsNewline bool) {
 	// lookahead is already lexed.
-	// parse more, if any
-	l.acceptWhile(func(r rune) bool {
-		switch r {
+	// parse more, if any.
+	// NOTE: not using acceptWhile, as the closure would need to capture
+	// the result variable and would have to be allocated
+	for {
+		switch l.next() {
 		case ' ', '\t', '\r':
-			return true
+			continue
 		case '\n':
 			containsNewline = true
-			return true
+			continue
 		default:
-			return false
+			l.backupOne()
+			return
 		}
-	})
-	return
+	}
 }
 
 func (l *lexer) scanIdentifier() {
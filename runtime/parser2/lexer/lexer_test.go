@@ -2056,3 +2056,91 @@ func TestRevert(t *testing.T) {
 	)
 
 }
+
+func TestLexReclaim(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "let x = 1 + 2"
+
+	lexAll := func() (tokens []Token) {
+		tokenStream := Lex(code)
+		defer tokenStream.Reclaim()
+
+		for {
+			token := tokenStream.Next()
+			tokens = append(tokens, token)
+			if token.Is(TokenEOF) {
+				return
+			}
+		}
+	}
+
+	first := lexAll()
+
+	// Lexing again must produce the same tokens,
+	// even if a reclaimed lexer is reused
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, lexAll())
+	}
+}
+
+func BenchmarkLex(b *testing.B) {
+
+	const code = `
+      pub contract Test {
+
+          pub resource Vault {
+              pub var balance: UFix64
+
+              init(balance: UFix64) {
+                  self.balance = balance
+              }
+
+              pub fun withdraw(amount: UFix64): @Vault {
+                  self.balance = self.balance - amount
+                  return <-create Vault(balance: amount)
+              }
+          }
+
+          pub fun hello(): String {
+              return "Hello, World!"
+          }
+      }
+    `
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		tokenStream := Lex(code)
+		for {
+			if tokenStream.Next().Is(TokenEOF) {
+				break
+			}
+		}
+		tokenStream.Reclaim()
+	}
+}
+
+func TestLexFrom(t *testing.T) {
+
+	t.Parallel()
+
+	const input = "1\n2 3"
+
+	var expected []Token
+	withTokens(Lex(input), func(tokens []Token) {
+		expected = tokens
+	})
+
+	// Start at the second line: "2 3"
+
+	withTokens(
+		LexFrom(input, ast.Position{Offset: 2, Line: 2, Column: 0}),
+		func(tokens []Token) {
+			utils.AssertEqualWithDiff(t, expected[2:], tokens)
+		},
+	)
+}
@@ -42,7 +42,14 @@ func rootState(l *lexer) stateFn {
 		case '+':
 			l.emitType(TokenPlus)
 		case '-':
-			l.emitType(TokenMinus)
+			r = l.next()
+			switch r {
+			case '>':
+				l.emitType(TokenRightArrow)
+			default:
+				l.backupOne()
+				l.emitType(TokenMinus)
+			}
 		case '*':
 			l.emitType(TokenStar)
 		case '%':
@@ -103,9 +110,9 @@ func rootState(l *lexer) stateFn {
 		case '_':
 			return identifierState
 		case ' ', '\t', '\r':
-			return spaceState(false)
+			return spaceState
 		case '\n':
-			return spaceState(true)
+			return newlineSpaceState
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			return numberState
 		case '"':
@@ -253,21 +260,31 @@ type Space struct {
 	ContainsNewline bool
 }
 
-func spaceState(startIsNewline bool) stateFn {
-	return func(l *lexer) stateFn {
-		containsNewline := l.scanSpace()
-		containsNewline = containsNewline || startIsNewline
-		l.emit(
-			TokenSpace,
-			Space{
-				String:          l.word(),
-				ContainsNewline: containsNewline,
-			},
-			l.startPosition(),
-			true,
-		)
-		return rootState
-	}
+// NOTE: the space states are declared as functions instead of as closures,
+// so no closure has to be allocated for each space
+
+func spaceState(l *lexer) stateFn {
+	l.emitSpace(false)
+	return rootState
+}
+
+func newlineSpaceState(l *lexer) stateFn {
+	l.emitSpace(true)
+	return rootState
+}
+
+func (l *lexer) emitSpace(startIsNewline bool) {
+	containsNewline := l.scanSpace()
+	containsNewline = containsNewline || startIsNewline
+	l.emit(
+		TokenSpace,
+		Space{
+			String:          l.word(),
+			ContainsNewline: containsNewline,
+		},
+		l.startPosition(),
+		true,
+	)
 }
 
 func identifierState(l *lexer) stateFn {
@@ -25,4 +25,7 @@ type TokenStream interface {
 	Revert(cursor int)
 	// Input returns the whole input as source code
 	Input() string
+	// Reclaim allows the token stream to be reused.
+	// The token stream must not be used anymore after calling this function
+	Reclaim()
 }
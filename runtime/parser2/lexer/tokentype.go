@@ -81,6 +81,7 @@ const (
 	TokenAsExclamationMark
 	TokenAsQuestionMark
 	TokenPragma
+	TokenRightArrow
 	// NOTE: not an actual token, must be last item
 	TokenMax
 )
@@ -202,6 +203,8 @@ func (t TokenType) String() string {
 		return `'as?'`
 	case TokenPragma:
 		return `'#'`
+	case TokenRightArrow:
+		return `'->'`
 	default:
 		panic(errors.NewUnreachableError())
 	}
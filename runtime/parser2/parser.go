@@ -44,6 +44,14 @@ type parser struct {
 	backtrackingCursors []int
 	// bufferedErrors are the parsing errors encountered during buffering
 	bufferedErrors [][]error
+	// warnings are the parsing warnings encountered during parsing
+	warnings []Warning
+	// bufferedWarningCounts are the numbers of warnings when buffering started,
+	// so warnings encountered during buffering can be dropped when the buffer is replayed
+	bufferedWarningCounts []int
+	// errorRecovery determines if the parser recovers from syntax errors
+	// and produces a partial result, instead of stopping at the first syntax error
+	errorRecovery bool
 }
 
 // Parse creates a lexer to scan the given input string,
@@ -55,11 +63,15 @@ type parser struct {
 func Parse(input string, parse func(*parser) interface{}) (result interface{}, errors []error) {
 	// create a lexer, which turns the input string into tokens
 	tokens := lexer.Lex(input)
+	defer tokens.Reclaim()
 	return ParseTokenStream(tokens, parse)
 }
 
 func ParseTokenStream(tokens lexer.TokenStream, parse func(*parser) interface{}) (result interface{}, errors []error) {
-	p := &parser{tokens: tokens}
+	return parseTokenStream(&parser{tokens: tokens}, parse)
+}
+
+func parseTokenStream(p *parser, parse func(*parser) interface{}) (result interface{}, errors []error) {
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -163,6 +175,22 @@ func (p *parser) next() {
 func (p *parser) mustOne(tokenType lexer.TokenType) lexer.Token {
 	t := p.current
 	if !t.Is(tokenType) {
+
+		// When recovering from errors, tolerate a missing closing brace at the end of the input,
+		// so the declarations and statements of incomplete code are still part of the result
+
+		if p.errorRecovery &&
+			tokenType == lexer.TokenBraceClose &&
+			t.Is(lexer.TokenEOF) {
+
+			p.report(fmt.Errorf("expected token %s", tokenType))
+
+			return lexer.Token{
+				Type:  tokenType,
+				Range: t.Range,
+			}
+		}
+
 		panic(fmt.Errorf("expected token %s", tokenType))
 	}
 	p.next()
@@ -185,6 +213,9 @@ func (p *parser) startBuffering() {
 
 	// Push an empty slice of errors to the stack
 	p.bufferedErrors = append(p.bufferedErrors, nil)
+
+	// Push the current number of warnings to the stack
+	p.bufferedWarningCounts = append(p.bufferedWarningCounts, len(p.warnings))
 }
 
 func (p *parser) acceptBuffered() {
@@ -214,6 +245,12 @@ func (p *parser) acceptBuffered() {
 			bufferedErrors...,
 		)
 	}
+
+	// Pop the last warning count from the stack
+	// and ignore it, i.e. keep the warnings encountered during buffering
+
+	lastIndex = len(p.bufferedWarningCounts) - 1
+	p.bufferedWarningCounts = p.bufferedWarningCounts[:lastIndex]
 }
 
 func (p *parser) replayBuffered() {
@@ -232,6 +269,27 @@ func (p *parser) replayBuffered() {
 	lastIndex = len(p.bufferedErrors) - 1
 	p.bufferedErrors[lastIndex] = nil
 	p.bufferedErrors = p.bufferedErrors[:lastIndex]
+
+	// Pop the last warning count from the stack
+	// and drop the warnings encountered during buffering,
+	// as the buffered tokens are parsed again
+
+	lastIndex = len(p.bufferedWarningCounts) - 1
+	p.warnings = p.warnings[:p.bufferedWarningCounts[lastIndex]]
+	p.bufferedWarningCounts = p.bufferedWarningCounts[:lastIndex]
+}
+
+// checkSoftKeyword reports a warning if the given declared identifier is a soft keyword.
+//
+func (p *parser) checkSoftKeyword(identifier ast.Identifier) {
+	if _, ok := softKeywords[identifier.Identifier]; !ok {
+		return
+	}
+
+	p.warnings = append(p.warnings, &SoftKeywordWarning{
+		Keyword: identifier.Identifier,
+		Range:   ast.NewRangeFromPositioned(identifier),
+	})
 }
 
 type triviaOptions struct {
@@ -391,15 +449,39 @@ func ParseArgumentList(input string) (arguments ast.Arguments, errors []error) {
 }
 
 func ParseProgram(input string) (program *ast.Program, err error) {
-	return ParseProgramFromTokenStream(lexer.Lex(input))
+	tokens := lexer.Lex(input)
+	defer tokens.Reclaim()
+	return ParseProgramFromTokenStream(tokens)
 }
 
 func ParseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program, err error) {
+	program, _, err = parseProgramFromTokenStream(input)
+	return
+}
+
+// ParseProgramWithWarnings parses the given input into a program, like ParseProgram,
+// and additionally returns the warnings encountered during parsing,
+// e.g. for declarations of identifiers which are soft keywords.
+//
+// Warnings do not prevent the program from being parsed.
+//
+func ParseProgramWithWarnings(input string) (program *ast.Program, warnings []Warning, err error) {
+	tokens := lexer.Lex(input)
+	defer tokens.Reclaim()
+	return parseProgramFromTokenStream(tokens)
+}
+
+func parseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program, warnings []Warning, err error) {
+	p := &parser{tokens: input}
+
 	var res interface{}
 	var errs []error
-	res, errs = ParseTokenStream(input, func(p *parser) interface{} {
+	res, errs = parseTokenStream(p, func(p *parser) interface{} {
 		return parseDeclarations(p, lexer.TokenEOF)
 	})
+
+	warnings = p.warnings
+
 	if len(errs) > 0 {
 		err = Error{
 			Code:   input.Input(),
@@ -418,6 +500,48 @@ func ParseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program,
 	return
 }
 
+// ParseProgramWithRecovery parses the given input into a program,
+// recovering from syntax errors.
+//
+// Unlike ParseProgram, parsing does not stop at the first syntax error:
+// Declarations, members, and statements which cannot be parsed are skipped,
+// and missing closing braces at the end of the input are tolerated.
+//
+// The returned program is never nil and contains all declarations which could be parsed,
+// so it can be used for tooling like completion, even if the input is incomplete.
+// The error contains all syntax errors.
+//
+func ParseProgramWithRecovery(input string) (program *ast.Program, err error) {
+	tokens := lexer.Lex(input)
+	defer tokens.Reclaim()
+
+	p := &parser{
+		tokens:        tokens,
+		errorRecovery: true,
+	}
+
+	var res interface{}
+	var errs []error
+	res, errs = parseTokenStream(p, func(p *parser) interface{} {
+		return parseDeclarations(p, lexer.TokenEOF)
+	})
+	if len(errs) > 0 {
+		err = Error{
+			Code:   input,
+			Errors: errs,
+		}
+	}
+
+	var declarations []ast.Declaration
+	if res != nil {
+		declarations = res.([]ast.Declaration)
+	}
+
+	program = ast.NewProgram(declarations)
+
+	return
+}
+
 func ParseProgramFromFile(filename string) (program *ast.Program, code string, err error) {
 	var data []byte
 	data, err = ioutil.ReadFile(filename)
@@ -19,6 +19,7 @@
 package parser2
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
@@ -524,3 +525,464 @@ func TestParseArgumentList(t *testing.T) {
 	})
 
 }
+
+func TestParseProgramWithRecovery(t *testing.T) {
+
+	t.Parallel()
+
+	declarationIdentifiers := func(declarations []ast.Declaration) []string {
+		identifiers := make([]string, 0, len(declarations))
+		for _, declaration := range declarations {
+			identifiers = append(identifiers, declaration.DeclarationIdentifier().Identifier)
+		}
+		return identifiers
+	}
+
+	errorMessages := func(err error) []string {
+		var messages []string
+		for _, err := range err.(Error).Errors {
+			messages = append(messages, err.Error())
+		}
+		return messages
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          pub fun test(): Int {
+              return 1
+          }
+        `
+
+		program, err := ParseProgramWithRecovery(code)
+		require.NoError(t, err)
+
+		expected, err := ParseProgram(code)
+		require.NoError(t, err)
+
+		utils.AssertEqualWithDiff(t, expected, program)
+	})
+
+	t.Run("declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithRecovery(`
+          pub fun a() {}
+
+          pub fun b( {}
+
+          pub let c: Int = 1
+
+          )
+
+          pub struct d {}
+        `)
+		require.Error(t, err)
+
+		assert.Equal(t,
+			[]string{"a", "c", "d"},
+			declarationIdentifiers(program.Declarations()),
+		)
+
+		assert.Equal(t,
+			[]string{
+				"expected parameter or end of parameter list, got '{'",
+				"unexpected token: ')'",
+			},
+			errorMessages(err),
+		)
+	})
+
+	t.Run("members", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithRecovery(`
+          pub resource R {
+              pub let a: Int
+              pub let b: [Int
+              pub fun c() {}
+          }
+        `)
+		require.Error(t, err)
+
+		compositeDeclarations := program.CompositeDeclarations()
+		require.Len(t, compositeDeclarations, 1)
+
+		members := compositeDeclarations[0].Members
+
+		assert.Equal(t,
+			[]string{"a", "c"},
+			declarationIdentifiers(members.Declarations()),
+		)
+
+		assert.Len(t, errorMessages(err), 1)
+	})
+
+	t.Run("statements", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithRecovery(`
+          pub fun test() {
+              let a = 1
+              let b = (a +
+              let c = 3; let d = * 4; let e = 5
+              if true {
+                  let f = )
+              }
+              return
+          }
+
+          pub fun test2() {}
+        `)
+		require.Error(t, err)
+
+		functionDeclarations := program.FunctionDeclarations()
+		require.Len(t, functionDeclarations, 2)
+
+		statements := functionDeclarations[0].FunctionBlock.Block.Statements
+		require.Len(t, statements, 5)
+
+		assert.IsType(t, &ast.VariableDeclaration{}, statements[0])
+		assert.IsType(t, &ast.VariableDeclaration{}, statements[1])
+		assert.IsType(t, &ast.VariableDeclaration{}, statements[2])
+		assert.IsType(t, &ast.IfStatement{}, statements[3])
+		assert.IsType(t, &ast.ReturnStatement{}, statements[4])
+
+		assert.Equal(t, "a", statements[0].(*ast.VariableDeclaration).Identifier.Identifier)
+		assert.Equal(t, "c", statements[1].(*ast.VariableDeclaration).Identifier.Identifier)
+		assert.Equal(t, "e", statements[2].(*ast.VariableDeclaration).Identifier.Identifier)
+
+		assert.Empty(t, statements[3].(*ast.IfStatement).Then.Statements)
+
+		assert.Len(t, errorMessages(err), 3)
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithRecovery(`
+          pub contract C {
+              pub fun test() {
+                  let a = 1
+                  a.
+        `)
+		require.Error(t, err)
+
+		compositeDeclarations := program.CompositeDeclarations()
+		require.Len(t, compositeDeclarations, 1)
+
+		functionDeclarations := compositeDeclarations[0].Members.Functions()
+		require.Len(t, functionDeclarations, 1)
+
+		statements := functionDeclarations[0].FunctionBlock.Block.Statements
+		require.Len(t, statements, 2)
+
+		assert.Equal(t,
+			[]string{
+				"invalid whitespace after '.'",
+				"expected member name, got EOF",
+				"expected token '}'",
+				"expected token '}'",
+			},
+			errorMessages(err),
+		)
+	})
+
+	t.Run("lexer errors are reported once", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithRecovery(`
+          pub fun test() {
+              let a = (0b + ] 0x
+              let b = 1
+          }
+        `)
+		require.Error(t, err)
+
+		statements := program.FunctionDeclarations()[0].FunctionBlock.Block.Statements
+		require.Len(t, statements, 1)
+
+		assert.Equal(t,
+			[]string{
+				"missing digits",
+				"invalid binary integer literal `0b`: missing digits",
+				"missing digits",
+				"unexpected token in expression: ']'",
+			},
+			errorMessages(err),
+		)
+	})
+
+	t.Run("without recovery", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseProgram(`
+          pub fun a() {
+              let b = (1 +
+              let c = 3
+          }
+        `)
+		require.Error(t, err)
+
+		assert.Len(t, errorMessages(err), 1)
+	})
+}
+
+func TestParseProgramWithWarnings(t *testing.T) {
+
+	t.Parallel()
+
+	warningKeywords := func(warnings []Warning) []string {
+		keywords := make([]string, 0, len(warnings))
+		for _, warning := range warnings {
+			require.IsType(t, &SoftKeywordWarning{}, warning)
+			keywords = append(keywords, warning.(*SoftKeywordWarning).Keyword)
+		}
+		return keywords
+	}
+
+	t.Run("declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, warnings, err := ParseProgramWithWarnings(`
+          let view = 1
+
+          fun attach(remove: Int) {
+              for access, mapping in [1] {}
+          }
+
+          struct attachment {
+              let entitlement: Int
+
+              init() {
+                  self.entitlement = view
+              }
+          }
+        `)
+		require.NoError(t, err)
+		require.NotNil(t, program)
+
+		assert.Equal(t,
+			[]string{
+				"view",
+				"attach",
+				"remove",
+				"access",
+				"mapping",
+				"attachment",
+				"entitlement",
+			},
+			warningKeywords(warnings),
+		)
+
+		assert.Equal(t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 15, Line: 2, Column: 14},
+				EndPos:   ast.Position{Offset: 18, Line: 2, Column: 17},
+			},
+			warnings[0].(*SoftKeywordWarning).Range,
+		)
+	})
+
+	t.Run("no declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, warnings, err := ParseProgramWithWarnings(`
+          fun test(x: Int) {
+              let y = x
+          }
+        `)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("buffering", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Warnings encountered while buffering are dropped when the buffer is replayed,
+		// and kept when it is accepted
+
+		var warnings []Warning
+
+		_, errs := Parse("view access", func(p *parser) interface{} {
+			p.startBuffering()
+			p.checkSoftKeyword(mustIdentifier(p))
+			p.replayBuffered()
+
+			p.startBuffering()
+			p.checkSoftKeyword(mustIdentifier(p))
+			p.acceptBuffered()
+
+			p.mustOne(lexer.TokenSpace)
+			p.checkSoftKeyword(mustIdentifier(p))
+
+			warnings = p.warnings
+			return nil
+		})
+		require.Empty(t, errs)
+
+		assert.Equal(t,
+			[]string{"view", "access"},
+			warningKeywords(warnings),
+		)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, warnings, err := ParseProgramWithWarnings(`
+          let view = 1
+          let
+        `)
+		require.Error(t, err)
+
+		assert.Equal(t,
+			[]string{"view"},
+			warningKeywords(warnings),
+		)
+	})
+}
+
+func TestParseProgramJSONRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      #version("1.0")
+
+      import 0x1
+      import A, B from 0x2
+      import "foo"
+      import Bar
+
+      pub entitlement E
+
+      entitlement mapping M {
+          E -> E
+      }
+
+      pub event Test(a: Int, b: String)
+
+      pub enum Color: UInt8 {
+          pub case red
+          pub case green
+      }
+
+      pub struct interface I {
+          pub let x: Int
+
+          pub fun test(): Int {
+              pre { self.x > 0: "positive" }
+          }
+      }
+
+      pub resource R: I {
+          pub let x: Int
+          priv var y: {String: [Int; 2]}
+
+          init(x: Int) {
+              self.x = x
+              self.y = {}
+          }
+
+          pub fun test(): Int {
+              post { result > 0 }
+              return self.x
+          }
+
+          destroy() {}
+      }
+
+      pub attachment A for R {
+          access(E) fun foo(): &R? {
+              return nil
+          }
+      }
+
+      pub contract C {
+          pub var values: [UInt64]
+
+          init() {
+              self.values = []
+          }
+      }
+
+      pub fun test(_ a: Int, b: @R, c: ((Int): Bool)): @R {
+          let x: Int? = a as? Int
+          var y = -1 + 2 * 3 ?? 4
+          let z <- b
+          y = x! > 1 ? 0x10 : 0.5 as UFix64
+          let array = ["a", "b"][0]
+          let path = /storage/foo
+          let ref = &array as &String
+          let fn = fun (x: Int): Int { return x }
+          let r: @R{I} <- attach A() to <-create R(x: 1)
+          remove A from r
+          z <-> r
+          if let x = x {
+              emit Test(a: x, b: "b")
+          } else if true {
+              destroy r
+          }
+          while y < 10 {
+              y = y + 1
+              continue
+          }
+          for i, element in [1, 2, 3] {
+              break
+          }
+          switch y {
+              case 1:
+                  fn(y)
+              default:
+                  return <-z
+          }
+          return <-z
+      }
+
+      transaction(amount: UFix64) {
+          prepare(signer: AuthAccount) {}
+          pre { amount > 0.0 }
+          execute {}
+      }
+    `
+
+	program, err := ParseProgram(code)
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(program)
+	require.NoError(t, err)
+
+	var decoded ast.Program
+	err = json.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+
+	utils.AssertEqualWithDiff(t, program.Declarations(), decoded.Declarations())
+
+	reencoded, err := json.Marshal(&decoded)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(encoded), string(reencoded))
+
+	// The decoded program has the same node IDs
+
+	for id := ast.NodeID(1); program.Node(id) != nil; id++ {
+		node := program.Node(id)
+		decodedNode := decoded.Node(id)
+
+		utils.AssertEqualWithDiff(t, node, decodedNode)
+		assert.Equal(t, id, decoded.NodeID(decodedNode))
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+// synchronizationPredicate determines if the given token is a synchronization point,
+// i.e. a token at which parsing can resume after a syntax error.
+//
+// It is only called for tokens which are not nested in braces.
+// newLine is true if the token is preceded by a newline.
+//
+type synchronizationPredicate func(token lexer.Token, newLine bool) bool
+
+// isDeclarationSynchronizationPoint returns true if the token starts a line and a declaration.
+//
+func isDeclarationSynchronizationPoint(token lexer.Token, newLine bool) bool {
+	if !newLine {
+		return false
+	}
+
+	switch token.Type {
+	case lexer.TokenPragma:
+		return true
+
+	case lexer.TokenIdentifier:
+		switch token.Value {
+		case keywordLet, keywordVar, keywordFun, keywordImport, keywordEvent,
+			keywordStruct, keywordResource, keywordContract, keywordEnum,
+			KeywordTransaction, keywordPriv, keywordPub, keywordAccess:
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMemberSynchronizationPoint returns true if the token starts a line and
+// a composite or interface member, or a nested declaration.
+//
+func isMemberSynchronizationPoint(token lexer.Token, newLine bool) bool {
+	if !newLine || !token.Is(lexer.TokenIdentifier) {
+		return false
+	}
+
+	switch token.Value {
+	case keywordLet, keywordVar, keywordFun, keywordEvent,
+		keywordStruct, keywordResource, keywordContract, keywordEnum,
+		keywordPriv, keywordPub, keywordAccess,
+		keywordInit, keywordDestroy, keywordPrepare, keywordCase:
+
+		return true
+	}
+
+	return false
+}
+
+// isStatementSynchronizationPoint returns true if the token
+// is a semicolon or starts a new line.
+//
+func isStatementSynchronizationPoint(token lexer.Token, newLine bool) bool {
+	return newLine || token.Is(lexer.TokenSemicolon)
+}
+
+// parseRecoverable calls the given function, which parses an element,
+// e.g. a declaration or a statement.
+//
+// If error recovery is enabled and the function fails,
+// the syntax error is reported and all tokens of the element are skipped,
+// up to the next synchronization point.
+// In that case, true is returned, and the caller should continue with the next element.
+//
+func (p *parser) parseRecoverable(
+	isSynchronizationPoint synchronizationPredicate,
+	parse func(),
+) (recovered bool) {
+	if !p.errorRecovery {
+		parse()
+		return false
+	}
+
+	startCursor := p.tokens.Cursor() - 1
+	backtrackingDepth := len(p.backtrackingCursors)
+	bufferedErrorsDepth := len(p.bufferedErrors)
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err, ok := r.(error)
+		if !ok {
+			err = fmt.Errorf("parser: %v", r)
+		}
+
+		// Abandon any buffering started while parsing the element,
+		// including the errors reported while buffering, which are speculative
+
+		p.backtrackingCursors = p.backtrackingCursors[:backtrackingDepth]
+		p.bufferedErrors = p.bufferedErrors[:bufferedErrorsDepth]
+
+		p.report(err)
+
+		p.synchronize(startCursor, isSynchronizationPoint)
+
+		recovered = true
+	}()
+
+	parse()
+
+	return false
+}
+
+// skipUnexpectedToken reports the current token as unexpected
+// and skips all tokens up to the next synchronization point,
+// if error recovery is enabled.
+//
+// It returns true if the token was skipped,
+// and the caller should continue with the next element.
+//
+func (p *parser) skipUnexpectedToken(isSynchronizationPoint synchronizationPredicate) bool {
+	if !p.errorRecovery || p.current.Is(lexer.TokenEOF) {
+		return false
+	}
+
+	p.report(fmt.Errorf("unexpected token: %s", p.current.Type))
+
+	p.synchronize(p.tokens.Cursor()-1, isSynchronizationPoint)
+
+	return true
+}
+
+// synchronize skips the tokens starting at the given cursor
+// up to the next synchronization point.
+//
+// At least one token is skipped, so parsing always makes progress.
+// The synchronization point is never nested in braces opened by the skipped tokens.
+// Parentheses and brackets are not considered, as they are often unbalanced in incomplete code.
+// A closing brace that closes an enclosing block is always a synchronization point,
+// so a syntax error never affects the enclosing declarations.
+//
+// Lexer errors for tokens which were already read before the syntax error occurred
+// are not reported again.
+//
+func (p *parser) synchronize(startCursor int, isSynchronizationPoint synchronizationPredicate) {
+	failureCursor := p.tokens.Cursor()
+
+	p.tokens.Revert(startCursor)
+
+	braceDepth := 0
+	newLine := false
+	first := true
+
+	for {
+		cursor := p.tokens.Cursor()
+		token := p.tokens.Next()
+
+		switch token.Type {
+		case lexer.TokenError:
+			if cursor >= failureCursor {
+				p.current = token
+				p.report(token.Value.(error))
+			}
+			continue
+
+		case lexer.TokenSpace:
+			space := token.Value.(lexer.Space)
+			if space.ContainsNewline {
+				newLine = true
+			}
+			continue
+
+		case lexer.TokenLineComment, lexer.TokenBlockCommentStart,
+			lexer.TokenBlockCommentContent, lexer.TokenBlockCommentEnd:
+
+			continue
+		}
+
+		p.current = token
+
+		if token.Is(lexer.TokenEOF) {
+			return
+		}
+
+		if !first && braceDepth == 0 {
+			if token.Is(lexer.TokenBraceClose) {
+				return
+			}
+
+			if isSynchronizationPoint(token, newLine) {
+				return
+			}
+		}
+
+		first = false
+		newLine = false
+
+		switch token.Type {
+		case lexer.TokenBraceOpen:
+			braceDepth++
+
+		case lexer.TokenBraceClose:
+			if braceDepth > 0 {
+				braceDepth--
+			}
+		}
+	}
+}
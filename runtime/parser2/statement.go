@@ -42,8 +42,18 @@ func parseStatements(p *parser, isEndToken func(token lexer.Token) bool) (statem
 				return
 			}
 
-			statement := parseStatement(p)
+			var statement ast.Statement
+			recovered := p.parseRecoverable(isStatementSynchronizationPoint, func() {
+				statement = parseStatement(p)
+			})
+			if recovered {
+				sawSemicolon = false
+				continue
+			}
 			if statement == nil {
+				if p.skipUnexpectedToken(isStatementSynchronizationPoint) {
+					continue
+				}
 				return
 			}
 
@@ -95,6 +105,12 @@ func parseStatement(p *parser) ast.Statement {
 			return parseForStatement(p)
 		case keywordEmit:
 			return parseEmitStatement(p)
+		case keywordRemove:
+			// The `remove` keyword is not reserved:
+			// it only introduces a remove statement if an identifier follows
+			if isNextTokenIdentifier(p) {
+				return parseRemoveStatement(p)
+			}
 		case keywordFun:
 			// The `fun` keyword is ambiguous: it either introduces a function expression
 			// or a function declaration, depending on if an identifier follows, or not.
@@ -363,12 +379,15 @@ func parseForStatement(p *parser) *ast.ForStatement {
 		p.next()
 		p.skipSpaceAndComments(true)
 		index = &firstValue
+		p.checkSoftKeyword(*index)
 		identifier = mustIdentifier(p)
 		p.skipSpaceAndComments(true)
 	} else {
 		identifier = firstValue
 	}
 
+	p.checkSoftKeyword(identifier)
+
 	if !p.current.IsString(lexer.TokenIdentifier, keywordIn) {
 		p.report(fmt.Errorf(
 			"expected keyword %q, got %s",
@@ -518,6 +537,42 @@ func parseEmitStatement(p *parser) *ast.EmitStatement {
 	}
 }
 
+// parseRemoveStatement parses a remove statement.
+//
+//     removeStatement : 'remove' nominalType 'from' expression
+//
+func parseRemoveStatement(p *parser) *ast.RemoveStatement {
+
+	startPos := p.current.StartPos
+
+	// Skip the `remove` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	attachmentToken := p.mustOne(lexer.TokenIdentifier)
+	attachment := parseNominalTypeRemainder(p, attachmentToken)
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordFrom) {
+		panic(fmt.Errorf(
+			"expected keyword %q, got %s",
+			keywordFrom,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `from` keyword
+	p.next()
+
+	value := parseExpression(p, lowestBindingPower)
+
+	return &ast.RemoveStatement{
+		Attachment: attachment,
+		Value:      value,
+		StartPos:   startPos,
+	}
+}
+
 func parseSwitchStatement(p *parser) *ast.SwitchStatement {
 
 	startPos := p.current.StartPos
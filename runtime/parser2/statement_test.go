@@ -940,6 +940,66 @@ func TestParseEmit(t *testing.T) {
 	})
 }
 
+func TestParseRemoveStatement(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("remove A from r")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.RemoveStatement{
+					Attachment: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					Value: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "r",
+							Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("invocation of identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("remove()")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.ExpressionStatement{
+					Expression: &ast.InvocationExpression{
+						InvokedExpression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "remove",
+								Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+							},
+						},
+						ArgumentsStartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:            ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseFunctionStatementOrExpression(t *testing.T) {
 
 	t.Parallel()
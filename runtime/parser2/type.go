@@ -155,12 +155,29 @@ func init() {
 			switch token.Value {
 			case keywordAuth:
 				p.skipSpaceAndComments(true)
+
+				// The authorization of entitled reference types is an entitlement set,
+				// e.g. `auth(E1, E2) &T`
+
+				var authorization *ast.EntitlementSet
+				if p.current.Is(lexer.TokenParenOpen) {
+					// Skip the opening paren
+					p.next()
+					p.skipSpaceAndComments(true)
+
+					authorization = parseEntitlementSet(p, lexer.TokenParenClose)
+
+					p.mustOne(lexer.TokenParenClose)
+					p.skipSpaceAndComments(true)
+				}
+
 				p.mustOne(lexer.TokenAmpersand)
 				right := parseType(p, typeLeftBindingPowerReference)
 				return &ast.ReferenceType{
-					Authorized: true,
-					Type:       right,
-					StartPos:   token.StartPos,
+					Authorized:    true,
+					Authorization: authorization,
+					Type:          right,
+					StartPos:      token.StartPos,
 				}
 
 			default:
@@ -569,6 +586,59 @@ func parseNominalTypes(
 	return
 }
 
+// parseEntitlementSet parses a non-empty set of entitlements, up to the given end token,
+// which is not consumed. The entitlements are either separated by commas (conjunction),
+// or by vertical bars (disjunction).
+//
+//     entitlements : nominalType ( ',' nominalType )*
+//                  | nominalType ( '|' nominalType )*
+//
+func parseEntitlementSet(p *parser, endTokenType lexer.TokenType) *ast.EntitlementSet {
+	entitlementSet := &ast.EntitlementSet{
+		Kind: ast.EntitlementSetKindConjunction,
+	}
+
+	for {
+		p.skipSpaceAndComments(true)
+
+		entitlementToken := p.mustOne(lexer.TokenIdentifier)
+		entitlement := parseNominalTypeRemainder(p, entitlementToken)
+		entitlementSet.Entitlements = append(entitlementSet.Entitlements, entitlement)
+
+		p.skipSpaceAndComments(true)
+
+		var kind ast.EntitlementSetKind
+
+		switch p.current.Type {
+		case endTokenType:
+			return entitlementSet
+
+		case lexer.TokenComma:
+			kind = ast.EntitlementSetKindConjunction
+
+		case lexer.TokenVerticalBar:
+			kind = ast.EntitlementSetKindDisjunction
+
+		default:
+			panic(fmt.Errorf(
+				"unexpected token: got %s, expected %s, %s, or %s",
+				p.current.Type,
+				lexer.TokenComma,
+				lexer.TokenVerticalBar,
+				endTokenType,
+			))
+		}
+
+		if len(entitlementSet.Entitlements) > 1 && kind != entitlementSet.Kind {
+			panic(fmt.Errorf("cannot mix conjunctive and disjunctive entitlements"))
+		}
+		entitlementSet.Kind = kind
+
+		// Skip the separator
+		p.next()
+	}
+}
+
 func defineFunctionType() {
 	setTypeNullDenotation(
 		lexer.TokenParenOpen,
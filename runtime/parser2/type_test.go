@@ -263,6 +263,98 @@ func TestParseReferenceType(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("entitled, conjunction", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("auth(E, F) &Int")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.ReferenceType{
+				Authorized: true,
+				Authorization: &ast.EntitlementSet{
+					Kind: ast.EntitlementSetKindConjunction,
+					Entitlements: []*ast.NominalType{
+						{
+							Identifier: ast.Identifier{
+								Identifier: "E",
+								Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+							},
+						},
+						{
+							Identifier: ast.Identifier{
+								Identifier: "F",
+								Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+							},
+						},
+					},
+				},
+				Type: &ast.NominalType{
+					Identifier: ast.Identifier{
+						Identifier: "Int",
+						Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("entitled, disjunction", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("auth(E | F) &Int")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.ReferenceType{
+				Authorized: true,
+				Authorization: &ast.EntitlementSet{
+					Kind: ast.EntitlementSetKindDisjunction,
+					Entitlements: []*ast.NominalType{
+						{
+							Identifier: ast.Identifier{
+								Identifier: "E",
+								Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+							},
+						},
+						{
+							Identifier: ast.Identifier{
+								Identifier: "F",
+								Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+							},
+						},
+					},
+				},
+				Type: &ast.NominalType{
+					Identifier: ast.Identifier{
+						Identifier: "Int",
+						Pos:        ast.Position{Line: 1, Column: 13, Offset: 13},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("entitled, mixed separators", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseType("auth(E, F | G) &Int")
+		require.Len(t, errs, 1)
+
+		require.IsType(t, &SyntaxError{}, errs[0])
+		assert.Equal(t,
+			"cannot mix conjunctive and disjunctive entitlements",
+			errs[0].(*SyntaxError).Message,
+		)
+	})
 }
 
 func TestParseOptionalReferenceType(t *testing.T) {
@@ -0,0 +1,89 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ProgramDeclarations describes the types and functions declared in a checked program,
+// with all types exported as their external cadence.Type representation.
+//
+// It is a stable description of the program, intended for tools,
+// which should not depend on the internals of the elaboration.
+//
+type ProgramDeclarations struct {
+	CompositeTypes []cadence.CompositeType
+	InterfaceTypes []cadence.InterfaceType
+	Functions      []ProgramFunction
+}
+
+// ProgramFunction describes a function declared at the top-level of a program
+//
+type ProgramFunction struct {
+	Identifier string
+	Access     ast.Access
+	Type       cadence.FunctionType
+}
+
+// ExportProgramDeclarations returns the types and functions declared in the given checked program,
+// in declaration order.
+//
+func ExportProgramDeclarations(program *interpreter.Program) ProgramDeclarations {
+	elaboration := program.Elaboration
+
+	results := map[sema.TypeID]cadence.Type{}
+
+	compositeTypes := elaboration.DeclaredCompositeTypes()
+	interfaceTypes := elaboration.DeclaredInterfaceTypes()
+	functions := elaboration.DeclaredGlobalFunctions()
+
+	declarations := ProgramDeclarations{
+		CompositeTypes: make([]cadence.CompositeType, 0, len(compositeTypes)),
+		InterfaceTypes: make([]cadence.InterfaceType, 0, len(interfaceTypes)),
+		Functions:      make([]ProgramFunction, 0, len(functions)),
+	}
+
+	for _, compositeType := range compositeTypes {
+		exportedType := ExportType(compositeType, results).(cadence.CompositeType)
+		declarations.CompositeTypes = append(declarations.CompositeTypes, exportedType)
+	}
+
+	for _, interfaceType := range interfaceTypes {
+		exportedType := ExportType(interfaceType, results).(cadence.InterfaceType)
+		declarations.InterfaceTypes = append(declarations.InterfaceTypes, exportedType)
+	}
+
+	for _, function := range functions {
+		exportedType := ExportType(function.Type, results).(cadence.FunctionType)
+		declarations.Functions = append(
+			declarations.Functions,
+			ProgramFunction{
+				Identifier: function.Identifier,
+				Access:     function.Access,
+				Type:       exportedType,
+			},
+		)
+	}
+
+	return declarations
+}
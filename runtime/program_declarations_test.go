@@ -0,0 +1,151 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestExportProgramDeclarations(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := checker.ParseAndCheck(t, `
+      pub struct interface HasID {
+          pub let id: UInt64
+      }
+
+      pub resource R {}
+
+      pub contract C {
+
+          pub struct S: HasID {
+              pub let id: UInt64
+
+              init(id: UInt64) {
+                  self.id = id
+              }
+          }
+
+          pub event E(id: UInt64)
+      }
+
+      pub fun add(_ a: Int, b: Int): Int {
+          return a + b
+      }
+
+      fun helper() {}
+
+      let x = 1
+    `)
+	require.NoError(t, err)
+
+	program := interpreter.ProgramFromChecker(checker)
+
+	t.Run("elaboration", func(t *testing.T) {
+
+		t.Parallel()
+
+		compositeTypeIDs := make([]string, 0)
+		for _, compositeType := range program.CompositeTypes() {
+			compositeTypeIDs = append(compositeTypeIDs, string(compositeType.ID()))
+		}
+
+		assert.Equal(t,
+			[]string{
+				"S.test.R",
+				"S.test.C",
+				"S.test.C.S",
+				"S.test.C.E",
+			},
+			compositeTypeIDs,
+		)
+
+		interfaceTypes := program.InterfaceTypes()
+		require.Len(t, interfaceTypes, 1)
+		assert.Equal(t, "HasID", interfaceTypes[0].Identifier)
+
+		functions := program.GlobalFunctions()
+		require.Len(t, functions, 2)
+		assert.Equal(t, "add", functions[0].Identifier)
+		assert.Equal(t, ast.AccessPublic, functions[0].Access)
+		assert.Equal(t, "helper", functions[1].Identifier)
+		assert.Equal(t, ast.AccessNotSpecified, functions[1].Access)
+	})
+
+	t.Run("exported", func(t *testing.T) {
+
+		t.Parallel()
+
+		declarations := ExportProgramDeclarations(program)
+
+		require.Len(t, declarations.CompositeTypes, 4)
+
+		assert.IsType(t, &cadence.ResourceType{}, declarations.CompositeTypes[0])
+		assert.IsType(t, &cadence.ContractType{}, declarations.CompositeTypes[1])
+
+		structType, ok := declarations.CompositeTypes[2].(*cadence.StructType)
+		require.True(t, ok)
+		assert.Equal(t, utils.TestLocation, structType.Location)
+		assert.Equal(t, "C.S", structType.QualifiedIdentifier)
+		assert.Equal(t,
+			[]cadence.Field{
+				{
+					Identifier: "id",
+					Type:       cadence.UInt64Type{},
+				},
+			},
+			structType.Fields,
+		)
+
+		assert.IsType(t, &cadence.EventType{}, declarations.CompositeTypes[3])
+
+		require.Len(t, declarations.InterfaceTypes, 1)
+		assert.IsType(t, &cadence.StructInterfaceType{}, declarations.InterfaceTypes[0])
+
+		require.Len(t, declarations.Functions, 2)
+
+		function := declarations.Functions[0]
+		assert.Equal(t, "add", function.Identifier)
+		assert.Equal(t,
+			[]cadence.Parameter{
+				{
+					Label:      "_",
+					Identifier: "a",
+					Type:       cadence.IntType{},
+				},
+				{
+					Identifier: "b",
+					Type:       cadence.IntType{},
+				},
+			},
+			function.Type.Parameters,
+		)
+		assert.Equal(t, cadence.IntType{}, function.Type.ReturnType)
+	})
+}
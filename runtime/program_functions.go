@@ -0,0 +1,157 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+type analyzedFunction struct {
+	name       string
+	composite  string
+	access     ast.Access
+	parameters *ast.ParameterList
+	block      *ast.FunctionBlock
+	// calls are the calls to other functions of the program, collected by the gas hazard analysis
+	calls       []analyzedCall
+	transaction *ast.TransactionDeclaration
+}
+
+// programFunctions are the functions declared in a program, which have a body,
+// i.e. global functions, functions and special functions of composites and interfaces,
+// and the prepare and execute blocks of transactions, in declaration order.
+//
+// Functions are named by their qualified name, e.g. `Collection.deposit`,
+// or `prepare` and `execute` for transactions.
+//
+type programFunctions struct {
+	functions       []*analyzedFunction
+	functionsByName map[string]*analyzedFunction
+	// compositeNames are the qualified identifiers of the declared composites
+	compositeNames map[string]struct{}
+}
+
+func collectProgramFunctions(program *ast.Program) *programFunctions {
+	p := &programFunctions{
+		functionsByName: map[string]*analyzedFunction{},
+		compositeNames:  map[string]struct{}{},
+	}
+
+	p.collectDeclarations("", program.Declarations())
+
+	for _, transaction := range program.TransactionDeclarations() {
+		p.collectTransaction(transaction)
+	}
+
+	return p
+}
+
+func (p *programFunctions) addFunction(function *analyzedFunction) {
+	if function.block == nil {
+		return
+	}
+	p.functions = append(p.functions, function)
+	p.functionsByName[function.name] = function
+}
+
+func (p *programFunctions) collectDeclarations(composite string, declarations []ast.Declaration) {
+	for _, declaration := range declarations {
+		switch declaration := declaration.(type) {
+		case *ast.FunctionDeclaration:
+			p.addFunction(&analyzedFunction{
+				name:       qualifiedName(composite, declaration.Identifier.Identifier),
+				composite:  composite,
+				access:     declaration.Access,
+				parameters: declaration.ParameterList,
+				block:      declaration.FunctionBlock,
+			})
+
+		case *ast.SpecialFunctionDeclaration:
+			functionDeclaration := declaration.FunctionDeclaration
+			p.addFunction(&analyzedFunction{
+				name:       qualifiedName(composite, declaration.Kind.Keywords()),
+				composite:  composite,
+				parameters: functionDeclaration.ParameterList,
+				block:      functionDeclaration.FunctionBlock,
+			})
+
+		case *ast.CompositeDeclaration:
+			name := qualifiedName(composite, declaration.Identifier.Identifier)
+			p.compositeNames[name] = struct{}{}
+			p.collectDeclarations(name, declaration.Members.Declarations())
+
+		case *ast.InterfaceDeclaration:
+			name := qualifiedName(composite, declaration.Identifier.Identifier)
+			p.collectDeclarations(name, declaration.Members.Declarations())
+		}
+	}
+}
+
+func (p *programFunctions) collectTransaction(transaction *ast.TransactionDeclaration) {
+	for _, special := range []*ast.SpecialFunctionDeclaration{
+		transaction.Prepare,
+		transaction.Execute,
+	} {
+		if special == nil {
+			continue
+		}
+
+		p.addFunction(&analyzedFunction{
+			name:        special.Kind.Keywords(),
+			parameters:  special.FunctionDeclaration.ParameterList,
+			block:       special.FunctionDeclaration.FunctionBlock,
+			transaction: transaction,
+		})
+	}
+}
+
+// parameterNames returns the names of the parameters of the function,
+// including the parameters of the transaction for its prepare and execute blocks.
+//
+func (f *analyzedFunction) parameterNames() []string {
+	var names []string
+
+	for _, parameterList := range []*ast.ParameterList{
+		f.transactionParameters(),
+		f.parameters,
+	} {
+		if parameterList == nil {
+			continue
+		}
+		for _, parameter := range parameterList.Parameters {
+			names = append(names, parameter.Identifier.Identifier)
+		}
+	}
+
+	return names
+}
+
+func (f *analyzedFunction) transactionParameters() *ast.ParameterList {
+	if f.transaction == nil {
+		return nil
+	}
+	return f.transaction.ParameterList
+}
+
+func qualifiedName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
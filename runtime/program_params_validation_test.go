@@ -27,6 +27,7 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -971,3 +972,289 @@ func TestRuntimeTransactionParameterTypeValidation(t *testing.T) {
 		assert.Contains(t, err.Error(), "cannot import value of type PublicAccount.Keys")
 	})
 }
+
+func TestRuntimeArgumentLimits(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+        pub fun main(a: Int, b: Int) {}
+    `
+
+	arguments := [][]byte{
+		json.MustEncode(cadence.NewInt(1)),
+		json.MustEncode(cadence.NewInt(2)),
+	}
+
+	executeScript := func(t *testing.T, limits ArgumentLimits, arguments [][]byte) error {
+		rt := newTestInterpreterRuntime(WithArgumentLimits(limits))
+
+		decodeCalled := false
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			decodeArgument: func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+				decodeCalled = true
+				return json.Decode(b)
+			},
+		}
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source:    []byte(script),
+				Arguments: arguments,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+
+		if err != nil {
+			assert.False(t, decodeCalled)
+		}
+
+		return err
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		t.Parallel()
+
+		err := executeScript(
+			t,
+			ArgumentLimits{
+				CountLimit: 2,
+				SizeLimit:  len(arguments[0]) + len(arguments[1]),
+			},
+			arguments,
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("count limit exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		err := executeScript(
+			t,
+			ArgumentLimits{
+				CountLimit: 1,
+			},
+			arguments,
+		)
+		require.Error(t, err)
+
+		var limitErr ArgumentCountLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t,
+			ArgumentCountLimitExceededError{
+				Count: 2,
+				Limit: 1,
+			},
+			limitErr,
+		)
+	})
+
+	t.Run("size limit exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		size := len(arguments[0]) + len(arguments[1])
+
+		err := executeScript(
+			t,
+			ArgumentLimits{
+				SizeLimit: size - 1,
+			},
+			arguments,
+		)
+		require.Error(t, err)
+
+		var limitErr ArgumentSizeLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t,
+			ArgumentSizeLimitExceededError{
+				Size:  size,
+				Limit: size - 1,
+			},
+			limitErr,
+		)
+	})
+}
+
+func TestRuntimeArgumentDecodingError(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+        pub fun main(a: Int, b: [Int]) {}
+    `
+
+	rt := newTestInterpreterRuntime()
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		decodeArgument: func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+			return json.Decode(b)
+		},
+	}
+
+	_, err := rt.ExecuteScript(
+		Script{
+			Source: []byte(script),
+			Arguments: [][]byte{
+				json.MustEncode(cadence.NewInt(1)),
+				[]byte(`{"type":"Array","value":[{"type":"Int","value":"1"},{"type":"Int","value":2}]}`),
+			},
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.Error(t, err)
+
+	var argumentErr *InvalidEntryPointArgumentError
+	require.ErrorAs(t, err, &argumentErr)
+	assert.Equal(t, 1, argumentErr.Index)
+
+	var decodingErr *ArgumentDecodingError
+	require.ErrorAs(t, err, &decodingErr)
+	assert.Equal(t,
+		cadence.VariableSizedArrayType{
+			ElementType: cadence.IntType{},
+		},
+		decodingErr.ExpectedType,
+	)
+
+	var jsonErr *json.DecodingError
+	require.ErrorAs(t, err, &jsonErr)
+	assert.Equal(t, "$.value[1]", jsonErr.Path)
+
+	assert.Equal(t,
+		"invalid argument at index 1: "+
+			"failed to decode argument of type `[Int]`: "+
+			"failed to decode value at $.value[1]: invalid JSON Cadence structure",
+		argumentErr.Error(),
+	)
+}
+
+func TestRuntimeValidateArguments(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      transaction(a: Int, b: [String]) {
+          prepare(signer: AuthAccount) {
+              panic("must not be executed")
+          }
+      }
+    `)
+
+	validArguments := [][]byte{
+		json.MustEncode(cadence.NewInt(1)),
+		json.MustEncode(cadence.NewArray([]cadence.Value{
+			cadence.String("2"),
+		})),
+	}
+
+	validate := func(script []byte, arguments [][]byte) error {
+		rt := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, func(owner, key, value []byte) {
+				require.FailNow(t, "unexpected write to storage")
+			}),
+			getSigningAccounts: func() ([]Address, error) {
+				require.FailNow(t, "unexpected request for signing accounts")
+				return nil, nil
+			},
+			decodeArgument: func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+				return json.Decode(b)
+			},
+		}
+
+		return rt.ValidateArguments(
+			Script{
+				Source:    script,
+				Arguments: arguments,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := validate(script, validArguments)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := validate(script, validArguments[:1])
+		require.Error(t, err)
+
+		var countErr InvalidEntryPointParameterCountError
+		require.ErrorAs(t, err, &countErr)
+	})
+
+	t.Run("not decodable", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := validate(script, [][]byte{
+			validArguments[0],
+			[]byte(`{"type":"Array","value":[{"type":"String"}]}`),
+		})
+		require.Error(t, err)
+
+		var argumentErr *InvalidEntryPointArgumentError
+		require.ErrorAs(t, err, &argumentErr)
+		assert.Equal(t, 1, argumentErr.Index)
+
+		var decodingErr *ArgumentDecodingError
+		require.ErrorAs(t, err, &decodingErr)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := validate(script, [][]byte{
+			json.MustEncode(cadence.String("1")),
+			validArguments[1],
+		})
+		require.Error(t, err)
+
+		var argumentErr *InvalidEntryPointArgumentError
+		require.ErrorAs(t, err, &argumentErr)
+		assert.Equal(t, 0, argumentErr.Index)
+	})
+
+	t.Run("program error", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := validate([]byte(`transaction(a: Int) { prepare() { x } }`), validArguments[:1])
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+	})
+
+	t.Run("not a transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := validate([]byte(`pub fun main() {}`), nil)
+		require.Error(t, err)
+
+		var countErr InvalidTransactionCountError
+		require.ErrorAs(t, err, &countErr)
+	})
+}
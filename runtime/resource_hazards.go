@@ -0,0 +1,382 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=ResourceHazardKind -trimprefix=ResourceHazard
+
+// ResourceHazardKind is the kind of a pattern of resource handling
+// which is known to cause loss of resources, or which allows users to grief others.
+//
+type ResourceHazardKind uint
+
+const (
+	ResourceHazardUnknown ResourceHazardKind = iota
+	// ResourceHazardForcedMove is a forced move (`<-!`) into a dictionary entry with a user-provided key,
+	// which aborts if the entry is occupied, so a user may block the key for others
+	ResourceHazardForcedMove
+	// ResourceHazardUserProvidedStoragePath is a call of `AuthAccount.save` with a user-provided path,
+	// which aborts if the path is occupied
+	ResourceHazardUserProvidedStoragePath
+	// ResourceHazardDestroyDepositedResources is a `destroy` of a field containing resources,
+	// into which anyone may deposit resources through a public function,
+	// so the resources of others are lost
+	ResourceHazardDestroyDepositedResources
+	// ResourceHazardUnrestrictedPublicLink is a link of a public path to a reference to a resource
+	// which is not restricted to interfaces, or which is authorized,
+	// so anyone may call all public functions of the resource, e.g. `withdraw`
+	ResourceHazardUnrestrictedPublicLink
+)
+
+// ResourceHazard is a pattern of resource handling which is known to cause loss of resources,
+// or which allows users to grief others.
+//
+// Function is the qualified name of the function in which the pattern occurs,
+// and Expression is the statement or expression itself.
+//
+type ResourceHazard struct {
+	Kind       ResourceHazardKind
+	Function   string
+	Expression string
+	ast.Range
+}
+
+func (h ResourceHazard) String() string {
+	return fmt.Sprintf(
+		"%d:%d: %s in `%s`: `%s`",
+		h.StartPos.Line,
+		h.StartPos.Column,
+		h.Kind,
+		h.Function,
+		h.Expression,
+	)
+}
+
+// AnalyzeResourceHazards statically determines the patterns of resource handling
+// in the given checked program which are known to cause loss of resources,
+// or which allow users to grief others, in source order.
+//
+// Values are user-provided if they are derived from the parameters of the function.
+// A field of a composite is considered to contain deposited resources
+// if a public function of the composite moves a user-provided resource into it.
+//
+func AnalyzeResourceHazards(program *interpreter.Program) []ResourceHazard {
+	analyzer := &resourceHazardAnalyzer{
+		elaboration:     program.Elaboration,
+		depositedFields: map[string]struct{}{},
+	}
+
+	functions := collectProgramFunctions(program.Program).functions
+
+	analyses := make([]*resourceHazardFunctionAnalysis, 0, len(functions))
+	for _, function := range functions {
+		analyses = append(analyses, analyzer.newFunctionAnalysis(function))
+	}
+
+	for _, analysis := range analyses {
+		if analysis.function.composite == "" {
+			continue
+		}
+
+		switch analysis.function.access {
+		case ast.AccessPublic, ast.AccessPublicSettable:
+			ast.Inspect(analysis.function.block, analysis.collectDeposits)
+		}
+	}
+
+	for _, analysis := range analyses {
+		ast.Inspect(analysis.function.block, analysis.inspect)
+	}
+
+	sort.SliceStable(analyzer.hazards, func(i, j int) bool {
+		return analyzer.hazards[i].StartPos.Compare(analyzer.hazards[j].StartPos) < 0
+	})
+
+	return analyzer.hazards
+}
+
+type resourceHazardAnalyzer struct {
+	elaboration *sema.Elaboration
+	// depositedFields are the qualified names of the fields,
+	// e.g. `Collection.ownedNFTs`, into which public functions deposit resources
+	depositedFields map[string]struct{}
+	hazards         []ResourceHazard
+}
+
+// resourceHazardFunctionAnalysis is the state of the analysis of one function.
+//
+// provided are the names of the parameters, and the constants and variables
+// which are derived from them.
+//
+type resourceHazardFunctionAnalysis struct {
+	*resourceHazardAnalyzer
+	function *analyzedFunction
+	provided map[string]struct{}
+}
+
+func (a *resourceHazardAnalyzer) newFunctionAnalysis(function *analyzedFunction) *resourceHazardFunctionAnalysis {
+	analysis := &resourceHazardFunctionAnalysis{
+		resourceHazardAnalyzer: a,
+		function:               function,
+		provided:               map[string]struct{}{},
+	}
+
+	for _, name := range function.parameterNames() {
+		analysis.provided[name] = struct{}{}
+	}
+
+	ast.Inspect(function.block, analysis.collectProvided)
+
+	return analysis
+}
+
+func (a *resourceHazardFunctionAnalysis) collectProvided(element ast.Element) bool {
+	declaration, ok := element.(*ast.VariableDeclaration)
+	if ok && a.isProvided(declaration.Value) {
+		a.provided[declaration.Identifier.Identifier] = struct{}{}
+	}
+	return true
+}
+
+// collectDeposits collects the fields of the function's composite
+// into which the function moves user-provided resources.
+//
+func (a *resourceHazardFunctionAnalysis) collectDeposits(element ast.Element) bool {
+	switch element := element.(type) {
+	case *ast.AssignmentStatement:
+		if indexExpression, ok := element.Target.(*ast.IndexExpression); ok &&
+			a.isProvided(element.Value) {
+
+			a.recordDeposit(indexExpression.TargetExpression)
+		}
+
+	case *ast.SwapStatement:
+		if indexExpression, ok := element.Left.(*ast.IndexExpression); ok &&
+			a.isProvided(element.Right) {
+
+			a.recordDeposit(indexExpression.TargetExpression)
+		}
+		if indexExpression, ok := element.Right.(*ast.IndexExpression); ok &&
+			a.isProvided(element.Left) {
+
+			a.recordDeposit(indexExpression.TargetExpression)
+		}
+
+	case *ast.InvocationExpression:
+		memberExpression, ok := element.InvokedExpression.(*ast.MemberExpression)
+		if !ok {
+			break
+		}
+
+		// `insert` is a function of both arrays and dictionaries
+
+		switch memberExpression.Identifier.Identifier {
+		case "insert", "append":
+
+			for _, argument := range element.Arguments {
+				if a.isProvided(argument.Expression) {
+					a.recordDeposit(memberExpression.Expression)
+					break
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// recordDeposit records the given expression as a deposited field,
+// if it is a field of the composite (`self.field`) which contains resources.
+//
+func (a *resourceHazardFunctionAnalysis) recordDeposit(expression ast.Expression) {
+	name := a.resourceContainerField(expression)
+	if name == "" {
+		return
+	}
+	a.depositedFields[name] = struct{}{}
+}
+
+// resourceContainerField returns the qualified name of the field
+// if the given expression is a field of the function's composite (`self.field`),
+// and the field is an array or dictionary of resources.
+//
+func (a *resourceHazardFunctionAnalysis) resourceContainerField(expression ast.Expression) string {
+	memberExpression, ok := expression.(*ast.MemberExpression)
+	if !ok || !isSelfExpression(memberExpression.Expression) {
+		return ""
+	}
+
+	memberInfo, ok := a.elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok || memberInfo.Member.DeclarationKind != common.DeclarationKindField {
+		return ""
+	}
+
+	switch fieldType := memberInfo.Member.TypeAnnotation.Type.(type) {
+	case *sema.DictionaryType, *sema.VariableSizedType, *sema.ConstantSizedType:
+		if !fieldType.IsResourceType() {
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	return qualifiedName(a.function.composite, memberExpression.Identifier.Identifier)
+}
+
+func (a *resourceHazardFunctionAnalysis) inspect(element ast.Element) bool {
+	switch element := element.(type) {
+	case *ast.AssignmentStatement:
+		if element.Transfer.Operation != ast.TransferOperationMoveForced {
+			break
+		}
+
+		indexExpression, ok := element.Target.(*ast.IndexExpression)
+		if ok && a.isProvided(indexExpression.IndexingExpression) {
+			a.report(
+				ast.Range{
+					StartPos: indexExpression.TargetExpression.StartPosition(),
+					EndPos:   element.Value.EndPosition(),
+				},
+				fmt.Sprintf("%s %s %s", element.Target, element.Transfer.Operation.Operator(), element.Value),
+				ResourceHazardForcedMove,
+			)
+		}
+
+	case *ast.DestroyExpression:
+		name := a.resourceContainerField(element.Expression)
+		if _, ok := a.depositedFields[name]; ok {
+			a.report(
+				ast.NewRangeFromPositioned(element),
+				element.String(),
+				ResourceHazardDestroyDepositedResources,
+			)
+		}
+
+	case *ast.InvocationExpression:
+		a.inspectInvocation(element)
+	}
+
+	return true
+}
+
+func (a *resourceHazardFunctionAnalysis) inspectInvocation(invocation *ast.InvocationExpression) {
+	memberExpression, ok := invocation.InvokedExpression.(*ast.MemberExpression)
+	if !ok {
+		return
+	}
+
+	memberInfo, ok := a.elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok || unwrapAccessedType(memberInfo.AccessedType) != sema.AuthAccountType {
+		return
+	}
+
+	arguments := invocation.Arguments
+
+	switch memberExpression.Identifier.Identifier {
+	case sema.AuthAccountSaveField:
+		if len(arguments) > 1 && a.isProvided(arguments[1].Expression) {
+			a.report(
+				ast.NewRangeFromPositioned(invocation),
+				invocation.String(),
+				ResourceHazardUserProvidedStoragePath,
+			)
+		}
+
+	case sema.AuthAccountLinkField:
+		if len(arguments) == 0 {
+			return
+		}
+
+		pathExpression, ok := arguments[0].Expression.(*ast.PathExpression)
+		if !ok || pathExpression.Domain.Identifier != common.PathDomainPublic.Identifier() {
+			return
+		}
+
+		typeArguments := a.elaboration.InvocationExpressionTypeArguments[invocation]
+		if typeArguments == nil || typeArguments.Len() == 0 {
+			return
+		}
+
+		if isUnrestrictedResourceReferenceType(typeArguments.Oldest().Value) {
+			a.report(
+				ast.NewRangeFromPositioned(invocation),
+				invocation.String(),
+				ResourceHazardUnrestrictedPublicLink,
+			)
+		}
+	}
+}
+
+// isUnrestrictedResourceReferenceType returns true if the given type is a reference to a resource
+// which is authorized, or which is not restricted to interfaces.
+//
+func isUnrestrictedResourceReferenceType(ty sema.Type) bool {
+	referenceType, ok := ty.(*sema.ReferenceType)
+	if !ok || !referenceType.Type.IsResourceType() {
+		return false
+	}
+
+	if referenceType.Authorized {
+		return true
+	}
+
+	_, ok = referenceType.Type.(*sema.CompositeType)
+	return ok
+}
+
+// isProvided returns true if the given expression refers to a parameter of the function,
+// or a constant or variable derived from one.
+//
+func (a *resourceHazardFunctionAnalysis) isProvided(expression ast.Expression) bool {
+	provided := false
+
+	ast.Inspect(expression, func(element ast.Element) bool {
+		identifierExpression, ok := element.(*ast.IdentifierExpression)
+		if ok {
+			if _, ok := a.provided[identifierExpression.Identifier.Identifier]; ok {
+				provided = true
+			}
+		}
+		return !provided
+	})
+
+	return provided
+}
+
+func (a *resourceHazardFunctionAnalysis) report(
+	hazardRange ast.Range,
+	expression string,
+	kind ResourceHazardKind,
+) {
+	a.hazards = append(a.hazards, ResourceHazard{
+		Kind:       kind,
+		Function:   a.function.name,
+		Expression: expression,
+		Range:      hazardRange,
+	})
+}
@@ -0,0 +1,208 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestAnalyzeResourceHazards(t *testing.T) {
+
+	t.Parallel()
+
+	analyze := func(t *testing.T, code string) []string {
+		runtime := newTestInterpreterRuntime()
+
+		program, err := runtime.ParseAndCheckProgram(
+			[]byte(code),
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		var hazards []string
+		for _, hazard := range AnalyzeResourceHazards(program) {
+			hazards = append(hazards, hazard.String())
+		}
+		return hazards
+	}
+
+	t.Run("collection", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub resource NFT {
+              pub let id: UInt64
+
+              init(id: UInt64) {
+                  self.id = id
+              }
+          }
+
+          pub resource Collection {
+              pub var ownedNFTs: @{UInt64: NFT}
+              priv var escrow: @{UInt64: NFT}
+
+              init() {
+                  self.ownedNFTs <- {}
+                  self.escrow <- {}
+              }
+
+              pub fun deposit(token: @NFT) {
+                  let id = token.id
+                  self.ownedNFTs[id] <-! token
+              }
+
+              pub fun withdraw(id: UInt64): @NFT {
+                  return <- self.ownedNFTs.remove(key: id)!
+              }
+
+              priv fun lock(token: @NFT) {
+                  let old <- self.escrow.insert(key: token.id, <-token)
+                  destroy old
+              }
+
+              destroy() {
+                  destroy self.ownedNFTs
+                  destroy self.escrow
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"21:18: ForcedMove in `Collection.deposit`: `self.ownedNFTs[id] <-! token`",
+				"34:18: DestroyDepositedResources in `Collection.destroy`: `(destroy self.ownedNFTs)`",
+			},
+			hazards,
+		)
+	})
+
+	t.Run("deposit by insert", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub resource R {}
+
+          pub resource Bin {
+              pub var items: @[R]
+
+              init() {
+                  self.items <- []
+              }
+
+              pub fun add(item: @R) {
+                  self.items.append(<-item)
+              }
+
+              destroy() {
+                  destroy self.items
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"16:18: DestroyDepositedResources in `Bin.destroy`: `(destroy self.items)`",
+			},
+			hazards,
+		)
+	})
+
+	t.Run("storage and links", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub resource interface Receiver {
+              pub fun deposit(amount: UFix64)
+          }
+
+          pub resource Vault: Receiver {
+              pub fun deposit(amount: UFix64) {}
+              pub fun withdraw(amount: UFix64) {}
+          }
+
+          transaction(path: StoragePath) {
+              prepare(signer: AuthAccount) {
+                  signer.save(<-create Vault(), to: path)
+                  signer.save(<-create Vault(), to: /storage/vault)
+
+                  signer.link<&Vault>(/public/vault, target: /storage/vault)
+                  signer.link<auth &Vault{Receiver}>(/public/authVault, target: /storage/vault)
+                  signer.link<&Vault{Receiver}>(/public/receiver, target: /storage/vault)
+                  signer.link<&Vault>(/private/vault, target: /storage/vault)
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"13:18: UserProvidedStoragePath in `prepare`: `signer.save(<-(create Vault()), to: path)`",
+				"16:18: UnrestrictedPublicLink in `prepare`: `signer.link<&Vault>(/public/vault, target: /storage/vault)`",
+				"17:18: UnrestrictedPublicLink in `prepare`: `signer.link<auth &Vault{Receiver}>(/public/authVault, target: /storage/vault)`",
+			},
+			hazards,
+		)
+	})
+
+	t.Run("safe", func(t *testing.T) {
+
+		t.Parallel()
+
+		hazards := analyze(t, `
+          pub resource NFT {}
+
+          pub resource Collection {
+              pub var ownedNFTs: @{UInt64: NFT}
+              priv var nextID: UInt64
+
+              init() {
+                  self.ownedNFTs <- {}
+                  self.nextID = 0
+              }
+
+              pub fun mint() {
+                  let old <- self.ownedNFTs.insert(key: self.nextID, <-create NFT())
+                  self.nextID = self.nextID + 1
+                  destroy old
+              }
+
+              pub fun replace(id: UInt64) {
+                  self.ownedNFTs[self.nextID] <-! create NFT()
+              }
+
+              destroy() {
+                  destroy self.ownedNFTs
+              }
+          }
+        `)
+
+		assert.Empty(t, hazards)
+	})
+}
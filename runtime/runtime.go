@@ -23,8 +23,11 @@ import (
 	"fmt"
 	"math"
 	goRuntime "runtime"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/onflow/atree"
 	opentracing "github.com/opentracing/opentracing-go"
 	"golang.org/x/crypto/sha3"
 
@@ -43,6 +46,13 @@ type Script struct {
 	Arguments [][]byte
 }
 
+// ScriptResult is the result of a script executed using Runtime.ExecuteScripts.
+//
+type ScriptResult struct {
+	Value cadence.Value
+	Err   error
+}
+
 type importResolutionResults map[common.LocationID]bool
 
 // Runtime is a runtime capable of executing Cadence.
@@ -53,12 +63,57 @@ type Runtime interface {
 	// or if the execution fails.
 	ExecuteScript(Script, Context) (cadence.Value, error)
 
+	// ExecuteScripts executes the given scripts against the same state.
+	//
+	// Each distinct script program, and each imported program, is only parsed and checked once,
+	// and the scripts are executed concurrently.
+	// The results are returned in the order of the given scripts.
+	//
+	// Changes to storage are not written back,
+	// so the functions of the interface must be safe for concurrent use.
+	ExecuteScripts([]Script, Context) []ScriptResult
+
+	// PrepareScript parses and checks the given script,
+	// so it can be executed repeatedly using ExecutePreparedScript.
+	//
+	// This function returns an error if the program has errors (e.g syntax errors, type errors).
+	PrepareScript(source []byte, context Context) (*PreparedScript, error)
+
+	// ExecutePreparedScript executes the given prepared script with the given arguments,
+	// against the storage of the given context.
+	//
+	// The programs of the script and its imports are not parsed and checked again.
+	//
+	// This function returns an error if the execution fails.
+	ExecutePreparedScript(script *PreparedScript, arguments [][]byte, context Context) (cadence.Value, error)
+
 	// ExecuteTransaction executes the given transaction.
 	//
 	// This function returns an error if the program has errors (e.g syntax errors, type errors),
 	// or if the execution fails.
 	ExecuteTransaction(Script, Context) error
 
+	// ValidateArguments parses and checks the given transaction,
+	// and validates its arguments against the transaction parameters, without executing it.
+	//
+	// The arguments are decoded, and must be importable and conform to the parameter types,
+	// like when the transaction is executed. Authorizers are not validated.
+	// Changes to storage are not written back.
+	//
+	// This function returns an error if the program has errors (e.g syntax errors, type errors),
+	// or if any of the arguments is invalid.
+	ValidateArguments(Script, Context) error
+
+	// SimulateTransaction executes the given transaction against the given hypothetical account state,
+	// which is layered over the state provided by the interface.
+	//
+	// Changes to storage are not written back to the ledger of the interface,
+	// see Simulation.
+	//
+	// This function returns an error if the program has errors (e.g syntax errors, type errors),
+	// or if the execution fails.
+	SimulateTransaction(script Script, simulation *Simulation, context Context) error
+
 	// InvokeContractFunction invokes a contract function with the given arguments.
 	//
 	// This function returns an error if the execution fails.
@@ -72,11 +127,25 @@ type Runtime interface {
 		context Context,
 	) (cadence.Value, error)
 
+	// ExecuteScheduledCallback invokes the given scheduled callback,
+	// which must be due, i.e. its block height must not be after the current block height.
+	//
+	// This function returns an error if the callback is not due,
+	// if its handler is invalid, or if the execution fails.
+	ExecuteScheduledCallback(callback ScheduledCallback, context Context) error
+
 	// ParseAndCheckProgram parses and checks the given code without executing the program.
 	//
 	// This function returns an error if the program contains any syntax or semantic errors.
 	ParseAndCheckProgram(source []byte, context Context) (*interpreter.Program, error)
 
+	// GenerateABI returns the ABI of the program at the given location,
+	// e.g. of a deployed contract.
+	//
+	// This function returns an error if the program cannot be loaded,
+	// or if it contains any syntax or semantic errors.
+	GenerateABI(location Location, context Context) (*ABI, error)
+
 	// SetCoverageReport activates reporting coverage in the given report.
 	// Passing nil disables coverage reporting (default).
 	//
@@ -89,12 +158,34 @@ type Runtime interface {
 	// SetAtreeValidationEnabled configures if atree validation is enabled.
 	SetAtreeValidationEnabled(enabled bool)
 
+	// SetInvariantChecksEnabled configures if the interpreter's internal invariant checks are enabled.
+	// The checks are expensive and should only be enabled in emulator and test environments.
+	SetInvariantChecksEnabled(enabled bool)
+
 	// SetTracingEnabled configures if tracing is enabled.
 	SetTracingEnabled(enabled bool)
 
 	// SetResourceOwnerChangeCallbackEnabled configures if the resource owner change callback is enabled.
 	SetResourceOwnerChangeHandlerEnabled(enabled bool)
 
+	// SetStandardContractsEnabled configures if the standard contracts,
+	// e.g. `FungibleToken`, can be imported by identifier.
+	SetStandardContractsEnabled(enabled bool)
+
+	// SetNativeFunctions configures the native functions,
+	// which programs can call through the built-in `Native` namespace.
+	// Passing nil removes the namespace (default).
+	SetNativeFunctions(functions *NativeFunctions)
+
+	// SetCompositeTypeProvider configures the function which provides composite types
+	// that are not declared by any program, e.g. the types of host-defined argument values.
+	// Passing nil removes the provider (default).
+	SetCompositeTypeProvider(provider CompositeTypeProvider)
+
+	// SetArgumentLimits configures the limits which are enforced
+	// for the arguments of scripts and transactions.
+	SetArgumentLimits(limits ArgumentLimits)
+
 	// ReadStored reads the value stored at the given path
 	//
 	ReadStored(address common.Address, path cadence.Path, context Context) (cadence.Value, error)
@@ -102,6 +193,10 @@ type Runtime interface {
 	// ReadLinked dereferences the path and returns the value stored at the target
 	//
 	ReadLinked(address common.Address, path cadence.Path, context Context) (cadence.Value, error)
+
+	// EstimateStoredSize returns the estimated number of bytes the given value would use in account storage
+	//
+	EstimateStoredSize(value cadence.Value, context Context) (uint64, error)
 }
 
 var typeDeclarations = append(
@@ -112,6 +207,7 @@ var typeDeclarations = append(
 type ImportResolver = func(location common.Location) (program *ast.Program, e error)
 
 var validTopLevelDeclarationsInTransaction = []common.DeclarationKind{
+	common.DeclarationKindPragma,
 	common.DeclarationKindImport,
 	common.DeclarationKindFunction,
 	common.DeclarationKindTransaction,
@@ -158,8 +254,15 @@ type interpreterRuntime struct {
 	coverageReport                    *CoverageReport
 	contractUpdateValidationEnabled   bool
 	atreeValidationEnabled            bool
+	invariantChecksEnabled            bool
 	tracingEnabled                    bool
 	resourceOwnerChangeHandlerEnabled bool
+	standardContractsEnabled          bool
+	nativeFunctions                   *NativeFunctions
+	compositeTypeProvider             CompositeTypeProvider
+	argumentLimits                    ArgumentLimits
+	// testContractEnabled is only set for the runtime of a TestRunner
+	testContractEnabled bool
 }
 
 type Option func(Runtime)
@@ -182,6 +285,15 @@ func WithAtreeValidationEnabled(enabled bool) Option {
 	}
 }
 
+// WithInvariantChecksEnabled returns a runtime option
+// that configures if the interpreter's internal invariant checks are enabled.
+//
+func WithInvariantChecksEnabled(enabled bool) Option {
+	return func(runtime Runtime) {
+		runtime.SetInvariantChecksEnabled(enabled)
+	}
+}
+
 // WithTracingEnabled returns a runtime option
 // that configures if tracing is enabled.
 //
@@ -200,6 +312,72 @@ func WithResourceOwnerChangeCallbackEnabled(enabled bool) Option {
 	}
 }
 
+// WithStandardContractsEnabled returns a runtime option
+// that configures if the standard contracts
+// (`FungibleToken`, `NonFungibleToken`, `ViewResolver`, and `MetadataViews`)
+// can be imported by identifier, e.g. `import FungibleToken`,
+// without being deployed to an account.
+//
+func WithStandardContractsEnabled(enabled bool) Option {
+	return func(runtime Runtime) {
+		runtime.SetStandardContractsEnabled(enabled)
+	}
+}
+
+// WithNativeFunctions returns a runtime option
+// that registers the given native functions,
+// which programs can call through the built-in `Native` namespace,
+// e.g. `Native.verifyProof(proof)`.
+//
+// The functions can be created and validated using NewNativeFunctions.
+//
+func WithNativeFunctions(functions *NativeFunctions) Option {
+	return func(runtime Runtime) {
+		runtime.SetNativeFunctions(functions)
+	}
+}
+
+// CompositeTypeProvider is a function that provides the composite type with the given type ID,
+// if the type is not declared by any program, e.g. because it is defined by the host.
+// It returns nil if it does not provide the type.
+//
+type CompositeTypeProvider func(location common.Location, typeID common.TypeID) *sema.CompositeType
+
+// WithCompositeTypeProvider returns a runtime option
+// that configures the function which provides composite types
+// that are not declared by any program.
+//
+// Values of provided types can be imported, e.g. passed as arguments,
+// even if no program declaring the type is deployed.
+//
+func WithCompositeTypeProvider(provider CompositeTypeProvider) Option {
+	return func(runtime Runtime) {
+		runtime.SetCompositeTypeProvider(provider)
+	}
+}
+
+// ArgumentLimits are the limits which are enforced for the arguments of scripts and transactions.
+// The limits are checked before the arguments are decoded.
+//
+// Limits which are zero are not enforced.
+//
+type ArgumentLimits struct {
+	// CountLimit is the maximum number of arguments
+	CountLimit int
+	// SizeLimit is the maximum total size of the encoded arguments, in bytes
+	SizeLimit int
+}
+
+// WithArgumentLimits returns a runtime option
+// that configures the limits which are enforced
+// for the arguments of scripts and transactions.
+//
+func WithArgumentLimits(limits ArgumentLimits) Option {
+	return func(runtime Runtime) {
+		runtime.SetArgumentLimits(limits)
+	}
+}
+
 // NewInterpreterRuntime returns a interpreter-based version of the Flow runtime.
 func NewInterpreterRuntime(options ...Option) Runtime {
 	runtime := &interpreterRuntime{}
@@ -221,6 +399,10 @@ func (r *interpreterRuntime) SetAtreeValidationEnabled(enabled bool) {
 	r.atreeValidationEnabled = enabled
 }
 
+func (r *interpreterRuntime) SetInvariantChecksEnabled(enabled bool) {
+	r.invariantChecksEnabled = enabled
+}
+
 func (r *interpreterRuntime) SetTracingEnabled(enabled bool) {
 	r.tracingEnabled = enabled
 }
@@ -229,6 +411,62 @@ func (r *interpreterRuntime) SetResourceOwnerChangeHandlerEnabled(enabled bool)
 	r.resourceOwnerChangeHandlerEnabled = enabled
 }
 
+func (r *interpreterRuntime) SetStandardContractsEnabled(enabled bool) {
+	r.standardContractsEnabled = enabled
+}
+
+func (r *interpreterRuntime) SetNativeFunctions(functions *NativeFunctions) {
+	r.nativeFunctions = functions
+}
+
+func (r *interpreterRuntime) SetCompositeTypeProvider(provider CompositeTypeProvider) {
+	r.compositeTypeProvider = provider
+}
+
+func (r *interpreterRuntime) SetArgumentLimits(limits ArgumentLimits) {
+	r.argumentLimits = limits
+}
+
+// withNativeFunctions returns the given standard library values,
+// and the `Native` namespace, if native functions are registered.
+//
+func (r *interpreterRuntime) withNativeFunctions(values stdlib.StandardLibraryValues) stdlib.StandardLibraryValues {
+	if r.nativeFunctions == nil {
+		return values
+	}
+
+	result := make(stdlib.StandardLibraryValues, 0, len(values)+1)
+	result = append(result, values...)
+	return append(result, r.nativeFunctions.valueDeclaration())
+}
+
+// builtinContractChecker returns the checker of the built-in contract at the given location,
+// or nil if the location is not the location of a built-in contract.
+//
+// The Crypto and Time contracts are always available,
+// the standard contracts are only available if they are enabled,
+// and the Test contract is only available when running tests.
+//
+func (r *interpreterRuntime) builtinContractChecker(location common.Location) *sema.Checker {
+	if location == stdlib.CryptoChecker.Location {
+		return stdlib.CryptoChecker
+	}
+
+	if location == stdlib.TimeChecker.Location {
+		return stdlib.TimeChecker
+	}
+
+	if r.testContractEnabled && location == stdlib.TestChecker.Location {
+		return stdlib.TestChecker
+	}
+
+	if r.standardContractsEnabled {
+		return stdlib.StandardContractChecker(location)
+	}
+
+	return nil
+}
+
 func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (cadence.Value, error) {
 	context.InitializeCodesAndPrograms()
 
@@ -237,6 +475,13 @@ func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (cade
 	var checkerOptions []sema.Option
 	var interpreterOptions []interpreter.Option
 
+	if context.ReadOnly {
+		interpreterOptions = append(
+			interpreterOptions,
+			interpreter.WithReadOnlyEnforcementEnabled(true),
+		)
+	}
+
 	functions := r.standardLibraryFunctions(
 		context,
 		storage,
@@ -257,56 +502,29 @@ func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (cade
 		return nil, newError(err, context)
 	}
 
-	functionEntryPointType, err := program.Elaboration.FunctionEntryPointType()
+	functionEntryPointType, err := scriptEntryPointType(program)
 	if err != nil {
 		return nil, newError(err, context)
 	}
 
-	// Ensure the entry point's parameter types are importable
-	if len(functionEntryPointType.Parameters) > 0 {
-		for _, param := range functionEntryPointType.Parameters {
-			if !param.TypeAnnotation.Type.IsImportable(map[*sema.Member]bool{}) {
-				err = &ScriptParameterTypeNotImportableError{
-					Type: param.TypeAnnotation.Type,
-				}
-				return nil, newError(err, context)
-			}
-		}
-	}
-
-	// Ensure the entry point's return type is valid
-	if !functionEntryPointType.ReturnTypeAnnotation.Type.IsExternallyReturnable(map[*sema.Member]bool{}) {
-		err = &InvalidScriptReturnTypeError{
-			Type: functionEntryPointType.ReturnTypeAnnotation.Type,
-		}
-		return nil, newError(err, context)
-	}
-
-	interpret := scriptExecutionFunction(
-		functionEntryPointType.Parameters,
-		script.Arguments,
-		context.Interface,
-	)
-
-	value, inter, err := r.interpret(
+	result, inter, err := r.executeScriptProgram(
 		program,
+		functionEntryPointType,
+		script.Arguments,
 		context,
 		storage,
 		functions,
-		stdlib.BuiltinValues(),
 		interpreterOptions,
 		checkerOptions,
-		interpret,
 	)
 	if err != nil {
 		return nil, newError(err, context)
 	}
 
-	// Export before committing storage
+	// A read-only script did not write, so there is nothing to commit
 
-	result, err := exportValue(value)
-	if err != nil {
-		return nil, newError(err, context)
+	if context.ReadOnly {
+		return result, nil
 	}
 
 	// Write back all stored values, which were actually just cached, back into storage.
@@ -341,107 +559,456 @@ func (r *interpreterRuntime) commitStorage(storage *Storage, inter *interpreter.
 
 type interpretFunc func(inter *interpreter.Interpreter) (interpreter.Value, error)
 
-func scriptExecutionFunction(
-	parameters []*sema.Parameter,
-	arguments [][]byte,
-	runtimeInterface Interface,
-) interpretFunc {
-	return func(inter *interpreter.Interpreter) (value interpreter.Value, err error) {
+func (r *interpreterRuntime) ExecuteScripts(scripts []Script, context Context) []ScriptResult {
+	context.InitializeCodesAndPrograms()
 
-		// Recover internal panics and return them as an error.
-		// For example, the argument validation might attempt to
-		// load contract code for non-existing types
+	results := make([]ScriptResult, len(scripts))
 
-		defer inter.RecoverErrors(func(internalErr error) {
-			err = internalErr
-		})
+	// Parse and check each distinct script program once.
+	//
+	// Checking is performed sequentially, so the imported programs
+	// are only parsed and checked once, and are available to all executions.
 
-		values, err := validateArgumentParams(
-			inter,
-			runtimeInterface,
-			arguments,
-			parameters)
-		if err != nil {
-			return nil, err
+	var checkerOptions []sema.Option
+
+	functions := r.standardLibraryFunctions(
+		context,
+		NewStorage(context.Interface),
+		nil,
+		checkerOptions,
+	)
+
+	type checkedScript struct {
+		program                *interpreter.Program
+		functionEntryPointType *sema.FunctionType
+		context                Context
+		err                    error
+	}
+
+	checkedScripts := map[string]checkedScript{}
+
+	for _, script := range scripts {
+		source := string(script.Source)
+		if _, ok := checkedScripts[source]; ok {
+			continue
 		}
-		return inter.Invoke("main", values...)
+
+		program, err := r.parseAndCheckProgram(
+			script.Source,
+			context,
+			functions,
+			stdlib.BuiltinValues(),
+			checkerOptions,
+			true,
+			importResolutionResults{},
+		)
+		var functionEntryPointType *sema.FunctionType
+		if err == nil {
+			functionEntryPointType, err = scriptEntryPointType(program)
+		}
+
+		// The codes and programs recorded in the context for the script location
+		// are overwritten when the next script is checked, so record them
+
+		checkedScripts[source] = checkedScript{
+			program:                program,
+			functionEntryPointType: functionEntryPointType,
+			context:                context.copyCodesAndPrograms(),
+			err:                    err,
+		}
+	}
+
+	// Execute the scripts concurrently.
+	//
+	// Coverage reports are not safe for concurrent use,
+	// so scripts are executed sequentially when coverage is reported.
+
+	workerCount := goRuntime.GOMAXPROCS(0)
+	if r.coverageReport != nil {
+		workerCount = 1
+	}
+
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workerCount)
+
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			defer wg.Done()
+
+			for index := range indices {
+				script := scripts[index]
+				checked := checkedScripts[string(script.Source)]
+
+				// Each execution gets its own codes and programs,
+				// as they are recorded when imports are resolved
+
+				scriptContext := checked.context.copyCodesAndPrograms()
+
+				// Contract value caches are not safe for concurrent use,
+				// so a shared cache is only used when scripts are executed sequentially
+
+				if workerCount > 1 {
+					scriptContext.ContractValueCache = nil
+				}
+
+				if checked.err != nil {
+					results[index].Err = newError(checked.err, scriptContext)
+					continue
+				}
+
+				results[index] = r.executeCheckedScript(
+					checked.program,
+					checked.functionEntryPointType,
+					script.Arguments,
+					scriptContext,
+				)
+			}
+		}()
 	}
+
+	for index := range scripts {
+		indices <- index
+	}
+	close(indices)
+
+	wg.Wait()
+
+	return results
 }
 
-func (r *interpreterRuntime) interpret(
+func (r *interpreterRuntime) executeCheckedScript(
 	program *interpreter.Program,
+	functionEntryPointType *sema.FunctionType,
+	arguments [][]byte,
 	context Context,
-	storage *Storage,
-	functions stdlib.StandardLibraryFunctions,
-	values stdlib.StandardLibraryValues,
-	interpreterOptions []interpreter.Option,
-	checkerOptions []sema.Option,
-	f interpretFunc,
-) (
-	exportableValue,
-	*interpreter.Interpreter,
-	error,
-) {
+) ScriptResult {
 
-	inter, err := r.newInterpreter(
-		program,
+	storage := NewStorage(context.Interface)
+
+	var checkerOptions []sema.Option
+	var interpreterOptions []interpreter.Option
+
+	functions := r.standardLibraryFunctions(
 		context,
-		functions,
-		values,
 		storage,
 		interpreterOptions,
 		checkerOptions,
 	)
-	if err != nil {
-		return exportableValue{}, nil, err
-	}
 
-	var result interpreter.Value
+	// NOTE: storage is intentionally not committed,
+	// the scripts of a batch are executed against the same state
 
-	reportMetric(
-		func() {
-			err = inter.Interpret()
-			if err != nil || f == nil {
-				return
-			}
-			result, err = f(inter)
-		},
-		context.Interface,
-		func(metrics Metrics, duration time.Duration) {
-			metrics.ProgramInterpreted(context.Location, duration)
-		},
+	value, _, err := r.executeScriptProgram(
+		program,
+		functionEntryPointType,
+		arguments,
+		context,
+		storage,
+		functions,
+		interpreterOptions,
+		checkerOptions,
 	)
-
 	if err != nil {
-		return exportableValue{}, nil, err
+		return ScriptResult{
+			Err: newError(err, context),
+		}
 	}
 
-	var exportedValue exportableValue
-	if f != nil {
-		exportedValue = newExportableValue(result, inter)
+	return ScriptResult{
+		Value: value,
 	}
+}
 
-	if inter.ExitHandler != nil {
-		err = inter.ExitHandler()
-	}
-	return exportedValue, inter, err
+// PreparedScript is a script which has been parsed and checked,
+// and can be executed repeatedly using Runtime.ExecutePreparedScript.
+//
+type PreparedScript struct {
+	program                *interpreter.Program
+	functionEntryPointType *sema.FunctionType
+	// context is the context the script was prepared in,
+	// without an interface
+	context Context
 }
 
-func (r *interpreterRuntime) newAuthAccountValue(
-	addressValue interpreter.AddressValue,
-	context Context,
+func (r *interpreterRuntime) PrepareScript(source []byte, context Context) (*PreparedScript, error) {
+	context.InitializeCodesAndPrograms()
+
+	// Record the imported programs, so they do not have to be processed again
+	context.programCache = programCache{}
+
+	var checkerOptions []sema.Option
+
+	functions := r.standardLibraryFunctions(
+		context,
+		NewStorage(context.Interface),
+		nil,
+		checkerOptions,
+	)
+
+	program, err := r.parseAndCheckProgram(
+		source,
+		context,
+		functions,
+		stdlib.BuiltinValues(),
+		checkerOptions,
+		true,
+		importResolutionResults{},
+	)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	functionEntryPointType, err := scriptEntryPointType(program)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	preparedContext := context.copyCodesAndPrograms()
+	preparedContext.Interface = nil
+
+	return &PreparedScript{
+		program:                program,
+		functionEntryPointType: functionEntryPointType,
+		context:                preparedContext,
+	}, nil
+}
+
+func (r *interpreterRuntime) ExecutePreparedScript(
+	script *PreparedScript,
+	arguments [][]byte,
+	context Context,
+) (cadence.Value, error) {
+
+	// The script is executed in the context it was prepared in,
+	// only the interface is replaced.
+	// Each execution gets its own copies of the codes and programs,
+	// so prepared scripts can be executed concurrently
+
+	scriptContext := script.context.copyCodesAndPrograms()
+	scriptContext.Interface = context.Interface
+	scriptContext.programCache = script.context.programCache.copy()
+	scriptContext.ContractValueCache = context.ContractValueCache
+
+	storage := NewStorage(scriptContext.Interface)
+
+	var checkerOptions []sema.Option
+	var interpreterOptions []interpreter.Option
+
+	functions := r.standardLibraryFunctions(
+		scriptContext,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+
+	result, inter, err := r.executeScriptProgram(
+		script.program,
+		script.functionEntryPointType,
+		arguments,
+		scriptContext,
+		storage,
+		functions,
+		interpreterOptions,
+		checkerOptions,
+	)
+	if err != nil {
+		return nil, newError(err, scriptContext)
+	}
+
+	// Write back all stored values, which were actually just cached, back into storage,
+	// like for non-prepared scripts
+
+	err = r.commitStorage(storage, inter)
+	if err != nil {
+		return nil, newError(err, scriptContext)
+	}
+
+	return result, nil
+}
+
+// scriptEntryPointType returns the type of the entry point of the given script program,
+// and ensures its parameter types are importable and its return type is valid.
+//
+func scriptEntryPointType(program *interpreter.Program) (*sema.FunctionType, error) {
+	functionEntryPointType, err := program.Elaboration.FunctionEntryPointType()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the entry point's parameter types are importable
+	if len(functionEntryPointType.Parameters) > 0 {
+		for _, param := range functionEntryPointType.Parameters {
+			if !param.TypeAnnotation.Type.IsImportable(map[*sema.Member]bool{}) {
+				return nil, &ScriptParameterTypeNotImportableError{
+					Type: param.TypeAnnotation.Type,
+				}
+			}
+		}
+	}
+
+	// Ensure the entry point's return type is valid
+	if !functionEntryPointType.ReturnTypeAnnotation.Type.IsExternallyReturnable(map[*sema.Member]bool{}) {
+		return nil, &InvalidScriptReturnTypeError{
+			Type: functionEntryPointType.ReturnTypeAnnotation.Type,
+		}
+	}
+
+	return functionEntryPointType, nil
+}
+
+// executeScriptProgram executes the given checked script program
+// and exports the result.
+//
+func (r *interpreterRuntime) executeScriptProgram(
+	program *interpreter.Program,
+	functionEntryPointType *sema.FunctionType,
+	arguments [][]byte,
+	context Context,
+	storage *Storage,
+	functions stdlib.StandardLibraryFunctions,
+	interpreterOptions []interpreter.Option,
+	checkerOptions []sema.Option,
+) (
+	cadence.Value,
+	*interpreter.Interpreter,
+	error,
+) {
+	interpret := scriptExecutionFunction(
+		functionEntryPointType.Parameters,
+		arguments,
+		r.argumentLimits,
+		context.Interface,
+	)
+
+	value, inter, err := r.interpret(
+		program,
+		context,
+		storage,
+		functions,
+		stdlib.BuiltinValues(),
+		interpreterOptions,
+		checkerOptions,
+		interpret,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Export before committing storage
+
+	result, err := exportValue(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, inter, nil
+}
+
+func scriptExecutionFunction(
+	parameters []*sema.Parameter,
+	arguments [][]byte,
+	argumentLimits ArgumentLimits,
+	runtimeInterface Interface,
+) interpretFunc {
+	return func(inter *interpreter.Interpreter) (value interpreter.Value, err error) {
+
+		// Recover internal panics and return them as an error.
+		// For example, the argument validation might attempt to
+		// load contract code for non-existing types
+
+		defer inter.RecoverErrors(func(internalErr error) {
+			err = internalErr
+		})
+
+		values, err := validateArgumentParams(
+			inter,
+			runtimeInterface,
+			arguments,
+			argumentLimits,
+			parameters)
+		if err != nil {
+			return nil, err
+		}
+		return inter.Invoke("main", values...)
+	}
+}
+
+func (r *interpreterRuntime) interpret(
+	program *interpreter.Program,
+	context Context,
+	storage *Storage,
+	functions stdlib.StandardLibraryFunctions,
+	values stdlib.StandardLibraryValues,
+	interpreterOptions []interpreter.Option,
+	checkerOptions []sema.Option,
+	f interpretFunc,
+) (
+	exportableValue,
+	*interpreter.Interpreter,
+	error,
+) {
+
+	inter, err := r.newInterpreter(
+		program,
+		context,
+		functions,
+		values,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+	if err != nil {
+		return exportableValue{}, nil, err
+	}
+
+	var result interpreter.Value
+
+	reportMetric(
+		func() {
+			err = inter.Interpret()
+			if err != nil || f == nil {
+				return
+			}
+			result, err = f(inter)
+		},
+		context.Interface,
+		func(metrics Metrics, duration time.Duration) {
+			metrics.ProgramInterpreted(context.Location, duration)
+		},
+	)
+
+	if err != nil {
+		return exportableValue{}, nil, err
+	}
+
+	var exportedValue exportableValue
+	if f != nil {
+		exportedValue = newExportableValue(result, inter)
+	}
+
+	if inter.ExitHandler != nil {
+		err = inter.ExitHandler()
+	}
+	return exportedValue, inter, err
+}
+
+func (r *interpreterRuntime) newAuthAccountValue(
+	addressValue interpreter.AddressValue,
+	context Context,
 	storage *Storage,
 	interpreterOptions []interpreter.Option,
 	checkerOptions []sema.Option,
 ) interpreter.Value {
 	return interpreter.NewAuthAccountValue(
 		addressValue,
-		accountBalanceGetFunction(addressValue, context.Interface),
-		accountAvailableBalanceGetFunction(addressValue, context.Interface),
+		accountBalanceGetFunction(addressValue, context.Interface, context.simulation),
+		accountAvailableBalanceGetFunction(addressValue, context.Interface, context.simulation),
 		storageUsedGetFunction(addressValue, context.Interface, storage),
 		storageCapacityGetFunction(addressValue, context.Interface),
-		r.newAddPublicKeyFunction(addressValue, context.Interface),
-		r.newRemovePublicKeyFunction(addressValue, context.Interface),
+		r.newAddPublicKeyFunction(addressValue, context.Interface, context.simulation),
+		r.newRemovePublicKeyFunction(addressValue, context.Interface, context.simulation),
 		func() interpreter.Value {
 			return r.newAuthAccountContracts(
 				addressValue,
@@ -455,6 +1022,7 @@ func (r *interpreterRuntime) newAuthAccountValue(
 			return r.newAuthAccountKeys(
 				addressValue,
 				context.Interface,
+				context.simulation,
 			)
 		},
 	)
@@ -560,6 +1128,199 @@ func (r *interpreterRuntime) InvokeContractFunction(
 	return exportedValue, nil
 }
 
+func (r *interpreterRuntime) ExecuteScheduledCallback(callback ScheduledCallback, context Context) error {
+	context.InitializeCodesAndPrograms()
+
+	storage := NewStorage(context.Interface)
+
+	var interpreterOptions []interpreter.Option
+	var checkerOptions []sema.Option
+
+	functions := r.standardLibraryFunctions(
+		context,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+
+	_, inter, err := r.interpret(
+		nil,
+		context,
+		storage,
+		functions,
+		stdlib.BuiltinValues(),
+		interpreterOptions,
+		checkerOptions,
+		nil,
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	// ensure the callback is due
+
+	var currentBlockHeight uint64
+	wrapPanic(func() {
+		currentBlockHeight, err = context.Interface.GetCurrentBlockHeight()
+	})
+	if err != nil {
+		return newError(err, context)
+	}
+
+	if currentBlockHeight < callback.BlockHeight {
+		return newError(
+			ScheduledCallbackNotDueError{
+				ID:                 callback.ID,
+				BlockHeight:        callback.BlockHeight,
+				CurrentBlockHeight: currentBlockHeight,
+			},
+			context,
+		)
+	}
+
+	getLocationRange := func() interpreter.LocationRange {
+		return interpreter.LocationRange{
+			Location: context.Location,
+		}
+	}
+
+	capability, err := importCapability(
+		inter,
+		callback.Handler.Path,
+		callback.Handler.Address,
+		callback.Handler.BorrowType,
+		callback.Handler.ID,
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	function, err := scheduledCallbackFunction(
+		inter,
+		capability,
+		callback.FunctionName,
+		getLocationRange,
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	_, err = inter.InvokeFunction(
+		function,
+		interpreter.Invocation{
+			Arguments: []interpreter.Value{
+				interpreter.UInt64Value(callback.ID),
+			},
+			ArgumentTypes: []sema.Type{
+				sema.UInt64Type,
+			},
+			GetLocationRange: getLocationRange,
+			Interpreter:      inter,
+		},
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	// Write back all stored values, which were actually just cached, back into storage
+	err = r.commitStorage(storage, inter)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	return nil
+}
+
+// scheduledCallbackFunctionType is the type of functions which handle scheduled callbacks.
+// They are called with the ID of the callback
+//
+var scheduledCallbackFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "id",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.UInt64Type),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+}
+
+// scheduledCallbackFunction returns the function which handles a scheduled callback,
+// i.e. the function with the given name of the value the given capability refers to.
+//
+// The function must be a public member of the borrow type of the capability,
+// so that the callback cannot call functions which the capability does not grant access to.
+//
+func scheduledCallbackFunction(
+	inter *interpreter.Interpreter,
+	capability *interpreter.CapabilityValue,
+	functionName string,
+	getLocationRange func() interpreter.LocationRange,
+) (
+	interpreter.FunctionValue,
+	error,
+) {
+	invalidHandlerError := &InvalidScheduledCallbackHandlerError{
+		FunctionName:  functionName,
+		LocationRange: getLocationRange(),
+	}
+
+	if capability.BorrowType == nil {
+		return nil, invalidHandlerError
+	}
+
+	borrowType, ok := inter.MustConvertStaticToSemaType(capability.BorrowType).(*sema.ReferenceType)
+	if !ok {
+		return nil, invalidHandlerError
+	}
+
+	memberResolver, ok := borrowType.Type.GetMembers()[functionName]
+	if !ok || memberResolver.Kind != common.DeclarationKindFunction {
+		return nil, invalidHandlerError
+	}
+
+	member := memberResolver.Resolve(functionName, ast.Range{}, func(error) {})
+	if member == nil ||
+		member.Access != ast.AccessPublic ||
+		!member.TypeAnnotation.Type.Equal(scheduledCallbackFunctionType) {
+
+		return nil, invalidHandlerError
+	}
+
+	borrowFunction, ok := capability.GetMember(inter, getLocationRange, "borrow").(interpreter.FunctionValue)
+	if !ok {
+		panic(runtimeErrors.NewUnreachableError())
+	}
+
+	result, err := inter.InvokeFunction(
+		borrowFunction,
+		interpreter.Invocation{
+			GetLocationRange: getLocationRange,
+			Interpreter:      inter,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	someValue, ok := result.(*interpreter.SomeValue)
+	if !ok {
+		return nil, invalidHandlerError
+	}
+
+	reference, ok := someValue.Value.(interpreter.MemberAccessibleValue)
+	if !ok {
+		panic(runtimeErrors.NewUnreachableError())
+	}
+
+	function, ok := reference.GetMember(inter, getLocationRange, functionName).(interpreter.FunctionValue)
+	if !ok {
+		return nil, invalidHandlerError
+	}
+
+	return function, nil
+}
+
 func (r *interpreterRuntime) convertArgument(
 	argument interpreter.Value,
 	argumentType sema.Type,
@@ -585,7 +1346,7 @@ func (r *interpreterRuntime) convertArgument(
 		if addressValue, ok := argument.(interpreter.AddressValue); ok {
 			return r.getPublicAccount(
 				interpreter.NewAddressValue(addressValue.ToAddress()),
-				context.Interface,
+				context,
 				storage,
 			)
 		}
@@ -596,7 +1357,12 @@ func (r *interpreterRuntime) convertArgument(
 func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context) error {
 	context.InitializeCodesAndPrograms()
 
-	storage := NewStorage(context.Interface)
+	var ledger atree.Ledger = context.Interface
+	if context.simulation != nil {
+		ledger = newSimulationLedger(ledger)
+	}
+
+	storage := NewStorage(ledger)
 
 	var interpreterOptions []interpreter.Option
 	var checkerOptions []sema.Option
@@ -633,11 +1399,15 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 	transactionType := transactions[0]
 
 	var authorizers []Address
-	wrapPanic(func() {
-		authorizers, err = context.Interface.GetSigningAccounts()
-	})
-	if err != nil {
-		return newError(err, context)
+	if context.simulation != nil && context.simulation.Signers != nil {
+		authorizers = context.simulation.Signers
+	} else {
+		wrapPanic(func() {
+			authorizers, err = context.Interface.GetSigningAccounts()
+		})
+		if err != nil {
+			return newError(err, context)
+		}
 	}
 	// check parameter count
 
@@ -653,15 +1423,68 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		return newError(err, context)
 	}
 
+	// If the last prepare parameter is an array of accounts,
+	// it receives all authorizers which are not bound to the preceding parameters
+
 	transactionAuthorizerCount := len(transactionType.PrepareParameters)
-	if authorizerCount != transactionAuthorizerCount {
+	hasAuthorizerArray := transactionType.HasAuthorizerArrayParameter()
+	if hasAuthorizerArray {
+		transactionAuthorizerCount--
+	}
+
+	if authorizerCount < transactionAuthorizerCount ||
+		(!hasAuthorizerArray && authorizerCount != transactionAuthorizerCount) {
+
 		err = InvalidTransactionAuthorizerCountError{
 			Expected: transactionAuthorizerCount,
 			Actual:   authorizerCount,
+			AtLeast:  hasAuthorizerArray,
 		}
 		return newError(err, context)
 	}
 
+	// preload the storage maps of the authorizers
+	// which the transaction statically refers to
+
+	if preloader, ok := context.Interface.(BatchPreloadInterface); ok && authorizerCount > 0 {
+		keys := storagePreloadKeys(program.Elaboration, authorizers)
+		if len(keys) > 0 {
+			wrapPanic(func() {
+				err = preloader.BatchPreloadValues(keys)
+			})
+			if err != nil {
+				return newError(err, context)
+			}
+		}
+	}
+
+	// prefetch the storage maps of the authorizers
+	// which the transaction statically refers to,
+	// if the storage can be read in a single batch.
+	// Other storage maps are not read, so they do not become part of the read set
+
+	if _, ok := context.Interface.(BatchValueInterface); ok && authorizerCount > 0 {
+		err = storage.prefetchStorageMaps(referencedStorageMapKeys(program.Elaboration, authorizers))
+		if err != nil {
+			return newError(err, context)
+		}
+	}
+
+	// preload the values of the authorizers
+	// which the transaction statically refers to through path literals
+
+	if preloader, ok := context.Interface.(BatchPreloadInterface); ok && authorizerCount > 0 {
+		keys := storageValuePreloadKeys(storage, program.Elaboration, authorizers)
+		if len(keys) > 0 {
+			wrapPanic(func() {
+				err = preloader.BatchPreloadValues(keys)
+			})
+			if err != nil {
+				return newError(err, context)
+			}
+		}
+	}
+
 	// gather authorizers
 
 	authorizerValues := func(inter *interpreter.Interpreter) []interpreter.Value {
@@ -678,7 +1501,36 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 			)
 		}
 
-		return authorizerValues
+		if !hasAuthorizerArray {
+			return authorizerValues
+		}
+
+		// Pass the remaining authorizers as an array
+
+		authorizerArray := interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeAuthAccount,
+			},
+			common.Address{},
+			authorizerValues[transactionAuthorizerCount:]...,
+		)
+
+		return append(
+			authorizerValues[:transactionAuthorizerCount],
+			authorizerArray,
+		)
+	}
+
+	executeTransaction := r.transactionExecutionFunction(
+		transactionType.Parameters,
+		script.Arguments,
+		context.Interface,
+		authorizerValues,
+	)
+
+	if context.simulation != nil {
+		executeTransaction = context.simulation.storedValuesWritingFunction(executeTransaction)
 	}
 
 	_, inter, err := r.interpret(
@@ -689,17 +1541,19 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		stdlib.BuiltinValues(),
 		interpreterOptions,
 		checkerOptions,
-		r.transactionExecutionFunction(
-			transactionType.Parameters,
-			script.Arguments,
-			context.Interface,
-			authorizerValues,
-		),
+		executeTransaction,
 	)
 	if err != nil {
 		return newError(err, context)
 	}
 
+	// The transaction storage is discarded,
+	// so it must not contain any resources
+	err = storage.checkTransactionStorage(inter)
+	if err != nil {
+		return newError(err, context)
+	}
+
 	// Write back all stored values, which were actually just cached, back into storage
 	err = r.commitStorage(storage, inter)
 	if err != nil {
@@ -709,6 +1563,206 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 	return nil
 }
 
+func (r *interpreterRuntime) SimulateTransaction(script Script, simulation *Simulation, context Context) error {
+	context.simulation = simulation
+	return r.ExecuteTransaction(script, context)
+}
+
+func (r *interpreterRuntime) ValidateArguments(script Script, context Context) error {
+	context.InitializeCodesAndPrograms()
+
+	storage := NewStorage(context.Interface)
+
+	var interpreterOptions []interpreter.Option
+	var checkerOptions []sema.Option
+
+	functions := r.standardLibraryFunctions(
+		context,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+
+	program, err := r.parseAndCheckProgram(
+		script.Source,
+		context,
+		functions,
+		stdlib.BuiltinValues(),
+		checkerOptions,
+		true,
+		importResolutionResults{},
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	transactions := program.Elaboration.TransactionTypes
+	transactionCount := len(transactions)
+	if transactionCount != 1 {
+		err = InvalidTransactionCountError{
+			Count: transactionCount,
+		}
+		return newError(err, context)
+	}
+
+	transactionType := transactions[0]
+
+	// The program is interpreted, so the types it declares are available,
+	// but the transaction is not invoked
+
+	_, _, err = r.interpret(
+		program,
+		context,
+		storage,
+		functions,
+		stdlib.BuiltinValues(),
+		interpreterOptions,
+		checkerOptions,
+		r.argumentValidationFunction(
+			transactionType.Parameters,
+			script.Arguments,
+			context.Interface,
+		),
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	return nil
+}
+
+// referencedStorageMapKeys returns the keys of the storage maps of the given authorizers
+// for the persistent path domains which are referred to by path literals in the given elaboration.
+//
+func referencedStorageMapKeys(elaboration *sema.Elaboration, authorizers []Address) []interpreter.StorageKey {
+
+	domains := map[common.PathDomain]bool{}
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the result is ordered afterwards
+
+	for _, domain := range elaboration.PathExpressionDomains { //nolint:maprangecheck
+		domains[domain] = true
+	}
+
+	var keys []interpreter.StorageKey
+
+	for _, address := range authorizers {
+		for _, domain := range common.AllPathDomains {
+			if !domains[domain] || !domain.IsPersistent() {
+				continue
+			}
+
+			keys = append(
+				keys,
+				interpreter.StorageKey{
+					Address: address,
+					Key:     domain.Identifier(),
+				},
+			)
+		}
+	}
+
+	return keys
+}
+
+// storagePreloadKeys returns the keys of the storage maps of the given authorizers
+// for the path domains which are referred to by path literals in the given elaboration.
+//
+func storagePreloadKeys(elaboration *sema.Elaboration, authorizers []Address) []ValueKey {
+
+	storageKeys := referencedStorageMapKeys(elaboration, authorizers)
+
+	keys := make([]ValueKey, 0, len(storageKeys))
+
+	for _, storageKey := range storageKeys {
+
+		// NOTE: copy the address, the key is a loop variable
+		owner := storageKey.Address
+
+		keys = append(
+			keys,
+			ValueKey{
+				Owner: owner[:],
+				Key:   []byte(storageKey.Key),
+			},
+		)
+	}
+
+	return keys
+}
+
+// storageValuePreloadKeys returns the keys of the slabs which store the values
+// at the storage paths the transaction statically refers to, e.g. `/storage/flowTokenVault`,
+// in the storage maps of the given authorizers.
+//
+// Only the storage maps are read, the values themselves are not loaded.
+// Values which are stored inline in a storage map need no preloading.
+//
+func storageValuePreloadKeys(storage *Storage, elaboration *sema.Elaboration, authorizers []Address) []ValueKey {
+
+	identifiers := map[common.PathDomain]map[string]struct{}{}
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the result is ordered afterwards
+
+	for expression, domain := range elaboration.PathExpressionDomains { //nolint:maprangecheck
+		if !domain.IsPersistent() {
+			continue
+		}
+		domainIdentifiers, ok := identifiers[domain]
+		if !ok {
+			domainIdentifiers = map[string]struct{}{}
+			identifiers[domain] = domainIdentifiers
+		}
+		domainIdentifiers[expression.Identifier.Identifier] = struct{}{}
+	}
+
+	var keys []ValueKey
+
+	for _, address := range authorizers {
+		for _, domain := range common.AllPathDomains {
+			domainIdentifiers, ok := identifiers[domain]
+			if !ok {
+				continue
+			}
+
+			domainIdentifier := domain.Identifier()
+			if !storage.storageMapExists(address, domainIdentifier) {
+				continue
+			}
+
+			storageMap := storage.GetStorageMap(address, domainIdentifier)
+
+			sortedIdentifiers := make([]string, 0, len(domainIdentifiers))
+			for identifier := range domainIdentifiers { //nolint:maprangecheck
+				sortedIdentifiers = append(sortedIdentifiers, identifier)
+			}
+			sort.Strings(sortedIdentifiers)
+
+			for _, identifier := range sortedIdentifiers {
+				storageID, ok := storageMap.ValueStorageID(identifier)
+				if !ok {
+					continue
+				}
+
+				// NOTE: copy the address, the address is a loop variable
+				owner := address
+
+				keys = append(
+					keys,
+					ValueKey{
+						Owner: owner[:],
+						Key:   atree.SlabIndexToLedgerKey(storageID.Index),
+					},
+				)
+			}
+		}
+	}
+
+	return keys
+}
+
 func wrapPanic(f func()) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -747,6 +1801,7 @@ func (r *interpreterRuntime) transactionExecutionFunction(
 			inter,
 			runtimeInterface,
 			arguments,
+			r.argumentLimits,
 			parameters,
 		)
 		if err != nil {
@@ -759,15 +1814,46 @@ func (r *interpreterRuntime) transactionExecutionFunction(
 	}
 }
 
+func (r *interpreterRuntime) argumentValidationFunction(
+	parameters []*sema.Parameter,
+	arguments [][]byte,
+	runtimeInterface Interface,
+) interpretFunc {
+	return func(inter *interpreter.Interpreter) (value interpreter.Value, err error) {
+
+		// Recover internal panics and return them as an error,
+		// see transactionExecutionFunction
+
+		defer inter.RecoverErrors(func(internalErr error) {
+			err = internalErr
+		})
+
+		_, err = validateArgumentParams(
+			inter,
+			runtimeInterface,
+			arguments,
+			r.argumentLimits,
+			parameters,
+		)
+		return nil, err
+	}
+}
+
 func validateArgumentParams(
 	inter *interpreter.Interpreter,
 	runtimeInterface Interface,
 	arguments [][]byte,
+	argumentLimits ArgumentLimits,
 	parameters []*sema.Parameter,
 ) (
 	[]interpreter.Value,
 	error,
 ) {
+	err := checkArgumentLimits(arguments, argumentLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	argumentCount := len(arguments)
 	parameterCount := len(parameters)
 
@@ -799,7 +1885,10 @@ func validateArgumentParams(
 		if err != nil {
 			return nil, &InvalidEntryPointArgumentError{
 				Index: i,
-				Err:   err,
+				Err: &ArgumentDecodingError{
+					ExpectedType: exportedParameterType,
+					Err:          err,
+				},
 			}
 		}
 
@@ -865,6 +1954,34 @@ func validateArgumentParams(
 	return argumentValues, nil
 }
 
+// checkArgumentLimits checks the number and the total size
+// of the given encoded arguments against the given limits.
+//
+func checkArgumentLimits(arguments [][]byte, limits ArgumentLimits) error {
+	if limits.CountLimit > 0 && len(arguments) > limits.CountLimit {
+		return ArgumentCountLimitExceededError{
+			Count: len(arguments),
+			Limit: limits.CountLimit,
+		}
+	}
+
+	if limits.SizeLimit > 0 {
+		size := 0
+		for _, argument := range arguments {
+			size += len(argument)
+		}
+
+		if size > limits.SizeLimit {
+			return ArgumentSizeLimitExceededError{
+				Size:  size,
+				Limit: limits.SizeLimit,
+			}
+		}
+	}
+
+	return nil
+}
+
 func hasValidStaticType(value interpreter.Value) bool {
 	switch value := value.(type) {
 	case *interpreter.ArrayValue:
@@ -913,6 +2030,36 @@ func (r *interpreterRuntime) ParseAndCheckProgram(code []byte, context Context)
 	return program, nil
 }
 
+func (r *interpreterRuntime) GenerateABI(location Location, context Context) (*ABI, error) {
+	context = context.WithLocation(location)
+	context.InitializeCodesAndPrograms()
+
+	storage := NewStorage(context.Interface)
+
+	var interpreterOptions []interpreter.Option
+	var checkerOptions []sema.Option
+
+	functions := r.standardLibraryFunctions(
+		context,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+
+	program, err := r.getProgram(
+		context,
+		functions,
+		stdlib.BuiltinValues(),
+		checkerOptions,
+		importResolutionResults{},
+	)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	return NewABI(location, program), nil
+}
+
 func (r *interpreterRuntime) parseAndCheckProgram(
 	code []byte,
 	context Context,
@@ -952,15 +2099,39 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 		return nil, wrapError(err)
 	}
 
+	if r.coverageReport != nil {
+		r.coverageReport.InspectProgram(context.Location, parse)
+	}
+
 	if storeProgram {
 		context.SetProgram(context.Location, parse)
 	}
 
-	// Check
-
-	elaboration, err := r.check(parse, context, functions, values, checkerOptions, checkedImports)
-	if err != nil {
-		return nil, wrapError(err)
+	// Check, unless an encoded elaboration is available.
+	// Encoded elaborations were not checked for writes,
+	// so always check the program in read-only mode
+
+	var elaboration *sema.Elaboration
+	if !context.ReadOnly {
+		elaboration, err = r.decodeElaborationArtifact(
+			code,
+			parse,
+			context,
+			functions,
+			values,
+			checkerOptions,
+			checkedImports,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if elaboration == nil {
+		elaboration, err = r.check(parse, context, functions, values, checkerOptions, checkedImports)
+		if err != nil {
+			return nil, wrapError(err)
+		}
 	}
 
 	// Return
@@ -982,7 +2153,14 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 	return program, nil
 }
 
-func (r *interpreterRuntime) check(
+// decodeElaborationArtifact returns the elaboration for the given program
+// from an encoded elaboration provided by the interface, if any.
+//
+// It returns nil if the interface does not provide an encoded elaboration,
+// or if the encoded elaboration cannot be used, in which case the program must be checked.
+//
+func (r *interpreterRuntime) decodeElaborationArtifact(
+	code []byte,
 	program *ast.Program,
 	startContext Context,
 	functions stdlib.StandardLibraryFunctions,
@@ -993,14 +2171,124 @@ func (r *interpreterRuntime) check(
 	elaboration *sema.Elaboration,
 	err error,
 ) {
+	artifactInterface, ok := startContext.Interface.(ElaborationArtifactInterface)
+	if !ok {
+		return nil, nil
+	}
+
+	// Additional checker options might influence the result of checking
+
+	if len(checkerOptions) > 0 {
+		return nil, nil
+	}
+
+	codeHash := sha3.Sum256(code)
+
+	var data []byte
+	wrapPanic(func() {
+		data, err = artifactInterface.GetElaborationArtifact(startContext.Location, codeHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	elaboration, err = sema.DecodeElaboration(
+		data,
+		program,
+		startContext.Location,
+		codeHash[:],
+		semaValueDeclarations(startContext, functions, r.withNativeFunctions(values)),
+		typeDeclarations,
+		func(importedLocation common.Location) (*sema.Elaboration, error) {
+			builtinChecker := r.builtinContractChecker(importedLocation)
+			switch {
+			case builtinChecker != nil:
+				return builtinChecker.Elaboration, nil
+
+			default:
+				context := startContext.WithLocation(importedLocation)
+
+				if checkedImports[importedLocation.ID()] {
+					return nil, fmt.Errorf("cyclic import of %s", importedLocation)
+				}
+
+				checkedImports[importedLocation.ID()] = true
+				defer delete(checkedImports, importedLocation.ID())
+
+				program, err := r.getProgram(context, functions, values, checkerOptions, checkedImports)
+				if err != nil {
+					return nil, err
+				}
+
+				return program.Elaboration, nil
+			}
+		},
+	)
+	if err != nil {
+		// The encoded elaboration cannot be used, fall back to checking the program
+		return nil, nil
+	}
+
+	// The encoded elaboration was produced for a different language version,
+	// fall back to checking the program
+
+	if elaboration.LanguageVersion != startContext.LanguageVersion {
+		return nil, nil
+	}
+
+	return elaboration, nil
+}
+
+// EncodeElaborationArtifact encodes the elaboration of the given checked program,
+// so that it can be provided through ElaborationArtifactInterface,
+// and checking the program can be skipped.
+//
+// The given code must be the code of the program,
+// and the program must have been checked with the standard library of the runtime.
+//
+func EncodeElaborationArtifact(code []byte, location Location, program *interpreter.Program) ([]byte, error) {
+	codeHash := sha3.Sum256(code)
+	return sema.EncodeElaboration(
+		program.Program,
+		location,
+		program.Elaboration,
+		codeHash[:],
+	)
+}
+
+func semaValueDeclarations(
+	context Context,
+	functions stdlib.StandardLibraryFunctions,
+	values stdlib.StandardLibraryValues,
+) []sema.ValueDeclaration {
 
 	valueDeclarations := functions.ToSemaValueDeclarations()
 	valueDeclarations = append(valueDeclarations, values.ToSemaValueDeclarations()...)
 
-	for _, predeclaredValue := range startContext.PredeclaredValues {
+	for _, predeclaredValue := range context.PredeclaredValues {
 		valueDeclarations = append(valueDeclarations, predeclaredValue)
 	}
 
+	return valueDeclarations
+}
+
+func (r *interpreterRuntime) check(
+	program *ast.Program,
+	startContext Context,
+	functions stdlib.StandardLibraryFunctions,
+	values stdlib.StandardLibraryValues,
+	checkerOptions []sema.Option,
+	checkedImports importResolutionResults,
+) (
+	elaboration *sema.Elaboration,
+	err error,
+) {
+
+	valueDeclarations := semaValueDeclarations(startContext, functions, r.withNativeFunctions(values))
+
 	checker, err := sema.NewChecker(
 		program,
 		startContext.Location,
@@ -1008,6 +2296,8 @@ func (r *interpreterRuntime) check(
 			[]sema.Option{
 				sema.WithPredeclaredValues(valueDeclarations),
 				sema.WithPredeclaredTypes(typeDeclarations),
+				sema.WithLanguageVersion(startContext.LanguageVersion),
+				sema.WithReadOnlyEnforcementEnabled(startContext.ReadOnly),
 				sema.WithValidTopLevelDeclarationsHandler(validTopLevelDeclarations),
 				sema.WithLocationHandler(
 					func(identifiers []Identifier, location Location) (res []ResolvedLocation, err error) {
@@ -1021,13 +2311,18 @@ func (r *interpreterRuntime) check(
 					func(checker *sema.Checker, importedLocation common.Location, importRange ast.Range) (sema.Import, error) {
 
 						var elaboration *sema.Elaboration
-						switch importedLocation {
-						case stdlib.CryptoChecker.Location:
-							elaboration = stdlib.CryptoChecker.Elaboration
+						builtinChecker := r.builtinContractChecker(importedLocation)
+						switch {
+						case builtinChecker != nil:
+							elaboration = builtinChecker.Elaboration
 
 						default:
 							context := startContext.WithLocation(importedLocation)
 
+							// Imported programs may declare functions which write,
+							// calling them is rejected by the interpreter instead
+							context.ReadOnly = false
+
 							// Check for cyclic imports
 							if checkedImports[importedLocation.ID()] {
 								return nil, &sema.CyclicImportsError{
@@ -1090,7 +2385,7 @@ func (r *interpreterRuntime) newInterpreter(
 ) (*interpreter.Interpreter, error) {
 
 	preDeclaredValues := functions.ToInterpreterValueDeclarations()
-	preDeclaredValues = append(preDeclaredValues, values.ToInterpreterValueDeclarations()...)
+	preDeclaredValues = append(preDeclaredValues, r.withNativeFunctions(values).ToInterpreterValueDeclarations()...)
 
 	for _, predeclaredValue := range context.PredeclaredValues {
 		preDeclaredValues = append(preDeclaredValues, predeclaredValue)
@@ -1150,6 +2445,7 @@ func (r *interpreterRuntime) newInterpreter(
 					compositeType,
 					constructorGenerator,
 					invocationRange,
+					context,
 					storage,
 				)
 			},
@@ -1164,8 +2460,74 @@ func (r *interpreterRuntime) newInterpreter(
 			func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
 				return r.getPublicAccount(
 					address,
-					context.Interface,
+					context,
+					storage,
+				)
+			},
+		),
+		interpreter.WithAuthAccountHandler(
+			func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
+				return r.newAuthAccountValue(
+					address,
+					context,
 					storage,
+					interpreterOptions,
+					checkerOptions,
+				)
+			},
+		),
+		interpreter.WithOnAccountLinkedHandler(
+			func(
+				inter *interpreter.Interpreter,
+				_ func() interpreter.LocationRange,
+				address interpreter.AddressValue,
+				path interpreter.PathValue,
+			) {
+				r.emitAccountEvent(
+					stdlib.AccountLinkedEventType,
+					context.Interface,
+					[]exportableValue{
+						newExportableValue(address, inter),
+						newExportableValue(path, inter),
+					},
+				)
+			},
+		),
+		interpreter.WithOnCapabilityControllerIssuedHandler(
+			func(
+				inter *interpreter.Interpreter,
+				_ func() interpreter.LocationRange,
+				address interpreter.AddressValue,
+				capabilityID interpreter.UInt64Value,
+				borrowType interpreter.StaticType,
+				targetPath interpreter.PathValue,
+			) {
+				r.emitAccountEvent(
+					stdlib.StorageCapabilityControllerIssuedEventType,
+					context.Interface,
+					[]exportableValue{
+						newExportableValue(capabilityID, inter),
+						newExportableValue(address, inter),
+						newExportableValue(interpreter.TypeValue{Type: borrowType}, inter),
+						newExportableValue(targetPath, inter),
+					},
+				)
+			},
+		),
+		interpreter.WithOnCapabilityControllerRevokedHandler(
+			func(
+				inter *interpreter.Interpreter,
+				_ func() interpreter.LocationRange,
+				address interpreter.AddressValue,
+				capabilityID interpreter.UInt64Value,
+			) {
+				r.emitAccountEvent(
+					stdlib.StorageCapabilityControllerRevokedEventType,
+					context.Interface,
+					[]exportableValue{
+						newExportableValue(capabilityID, inter),
+						newExportableValue(address, inter),
+					},
 				)
 			},
 		),
@@ -1244,6 +2606,48 @@ func (r *interpreterRuntime) newInterpreter(
 				)
 			},
 		),
+		interpreter.WithSignatureSchemeHandlers(
+			func(
+				_ *interpreter.Interpreter,
+				_ func() interpreter.LocationRange,
+				scheme *interpreter.StringValue,
+			) interpreter.BoolValue {
+				return isSignatureSchemeSupported(scheme, context.Interface)
+			},
+			func(
+				_ *interpreter.Interpreter,
+				getLocationRange func() interpreter.LocationRange,
+				scheme *interpreter.StringValue,
+				signature *interpreter.ArrayValue,
+				signedData *interpreter.ArrayValue,
+				domainSeparationTag *interpreter.StringValue,
+				publicKey *interpreter.ArrayValue,
+			) interpreter.BoolValue {
+				return verifySignatureWithScheme(
+					getLocationRange,
+					scheme,
+					signature,
+					signedData,
+					domainSeparationTag,
+					publicKey,
+					context.Interface,
+				)
+			},
+		),
+		interpreter.WithHasherCreationHandler(
+			func(
+				inter *interpreter.Interpreter,
+				getLocationRange func() interpreter.LocationRange,
+				hashAlgorithm interpreter.MemberAccessibleValue,
+			) interpreter.Hasher {
+				return newHasher(
+					inter,
+					getLocationRange,
+					hashAlgorithm,
+					context.Interface,
+				)
+			},
+		),
 		interpreter.WithOnRecordTraceHandler(
 			func(intr *interpreter.Interpreter, functionName string, duration time.Duration, logs []opentracing.LogRecord) {
 				context.Interface.RecordTrace(functionName, intr.Location, duration, logs)
@@ -1255,6 +2659,7 @@ func (r *interpreterRuntime) newInterpreter(
 		// and disable storage validation after each value modification.
 		// Instead, storage is validated after commits (if validation is enabled).
 		interpreter.WithAtreeStorageValidationEnabled(false),
+		interpreter.WithInvariantChecksEnabled(r.invariantChecksEnabled),
 		interpreter.WithOnResourceOwnerChangeHandler(r.resourceOwnerChangedHandler(context.Interface)),
 	}
 
@@ -1262,6 +2667,32 @@ func (r *interpreterRuntime) newInterpreter(
 		r.meteringInterpreterOptions(context.Interface)...,
 	)
 
+	if computationMeter, ok := context.Interface.(ComputationMeterInterface); ok {
+		defaultOptions = append(defaultOptions,
+			interpreter.WithComputationMeter(interfaceComputationMeter{computationMeter}),
+		)
+	}
+
+	if context.Transcript != nil {
+		defaultOptions = append(defaultOptions,
+			interpreter.WithTranscript(context.Transcript),
+		)
+	}
+
+	if memoryGauge := newInterfaceMemoryGauge(context.Interface); memoryGauge != nil {
+		defaultOptions = append(defaultOptions,
+			interpreter.WithMemoryGauge(memoryGauge),
+		)
+	}
+
+	if r.compositeTypeProvider != nil {
+		defaultOptions = append(defaultOptions,
+			interpreter.WithCompositeTypeHandler(
+				interpreter.CompositeTypeHandlerFunc(r.compositeTypeProvider),
+			),
+		)
+	}
+
 	return interpreter.NewInterpreter(
 		program,
 		context.Location,
@@ -1280,9 +2711,10 @@ func (r *interpreterRuntime) importLocationHandler(
 ) interpreter.ImportLocationHandlerFunc {
 
 	return func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
-		switch location {
-		case stdlib.CryptoChecker.Location:
-			program := interpreter.ProgramFromChecker(stdlib.CryptoChecker)
+		builtinChecker := r.builtinContractChecker(location)
+		switch {
+		case builtinChecker != nil:
+			program := interpreter.ProgramFromChecker(builtinChecker)
 			subInterpreter, err := inter.NewSubInterpreter(program, location)
 			if err != nil {
 				panic(err)
@@ -1324,6 +2756,21 @@ func (r *interpreterRuntime) getProgram(
 	err error,
 ) {
 
+	if cachedProgram, ok := context.programCache[context.Location.ID()]; ok &&
+		isCheckedWithLanguageVersion(cachedProgram, context.LanguageVersion) {
+
+		context.SetProgram(context.Location, cachedProgram.Program)
+		return cachedProgram, nil
+	}
+
+	if context.programCache != nil {
+		defer func() {
+			if err == nil {
+				context.programCache[context.Location.ID()] = program
+			}
+		}()
+	}
+
 	wrapPanic(func() {
 		program, err = context.Interface.GetProgram(context.Location)
 	})
@@ -1331,6 +2778,14 @@ func (r *interpreterRuntime) getProgram(
 		return nil, err
 	}
 
+	// The host environment may provide a program which was checked with a different language version,
+	// e.g. because it was cached before the language version changed.
+	// In that case, check the program again, and replace it in the host environment
+
+	if program != nil && !isCheckedWithLanguageVersion(program, context.LanguageVersion) {
+		program = nil
+	}
+
 	if program == nil {
 
 		var code []byte
@@ -1353,11 +2808,22 @@ func (r *interpreterRuntime) getProgram(
 		}
 	}
 
+	if r.coverageReport != nil {
+		r.coverageReport.InspectProgram(context.Location, program.Program)
+	}
+
 	context.SetProgram(context.Location, program.Program)
 
 	return program, nil
 }
 
+// isCheckedWithLanguageVersion returns true if the given program was checked with the given language version.
+//
+func isCheckedWithLanguageVersion(program *interpreter.Program, languageVersion sema.LanguageVersion) bool {
+	return program.Elaboration == nil ||
+		program.Elaboration.LanguageVersion == languageVersion
+}
+
 func (r *interpreterRuntime) injectedCompositeFieldsHandler(
 	context Context,
 	storage *Storage,
@@ -1371,8 +2837,8 @@ func (r *interpreterRuntime) injectedCompositeFieldsHandler(
 		compositeKind common.CompositeKind,
 	) map[string]interpreter.Value {
 
-		switch location {
-		case stdlib.CryptoChecker.Location:
+		switch {
+		case r.builtinContractChecker(location) != nil:
 			return nil
 
 		default:
@@ -1479,6 +2945,11 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 				callStackDepth--
 			},
 		),
+		interpreter.WithOnMeterComputationHandler(
+			func(_ *interpreter.Interpreter, kind common.ComputationKind, intensity uint) {
+				checkComputationLimit(computationIntensityUsage(kind, intensity))
+			},
+		),
 		interpreter.WithExitHandler(
 			func() error {
 				return runtimeInterface.SetComputationUsed(computationUsed)
@@ -1487,6 +2958,99 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 	}
 }
 
+// interfaceComputationMeter is an interpreter.ComputationMeter
+// which reports computation to a ComputationMeterInterface.
+//
+type interfaceComputationMeter struct {
+	computationMeter ComputationMeterInterface
+}
+
+var _ interpreter.ComputationMeter = interfaceComputationMeter{}
+
+func (m interfaceComputationMeter) MeterComputation(kind common.ComputationKind, intensity uint) (err error) {
+	wrapPanic(func() {
+		err = m.computationMeter.MeterComputation(kind, intensity)
+	})
+	return
+}
+
+// interfaceMemoryGauge is a common.MemoryGauge which enforces the memory limit
+// of a MemoryLimitInterface, and reports memory usage to a MemoryGauge.
+//
+type interfaceMemoryGauge struct {
+	memoryGauge MemoryGauge
+	memoryLimit uint64
+	memoryUsed  uint64
+}
+
+var _ common.MemoryGauge = &interfaceMemoryGauge{}
+
+// newInterfaceMemoryGauge returns a memory gauge for the given interface,
+// or nil if the interface neither meters nor limits memory.
+//
+func newInterfaceMemoryGauge(runtimeInterface Interface) *interfaceMemoryGauge {
+	memoryGauge, _ := runtimeInterface.(MemoryGauge)
+
+	var memoryLimit uint64
+	if memoryLimitInterface, ok := runtimeInterface.(MemoryLimitInterface); ok {
+		wrapPanic(func() {
+			memoryLimit = memoryLimitInterface.GetMemoryLimit()
+		})
+	}
+
+	if memoryGauge == nil && memoryLimit == 0 {
+		return nil
+	}
+
+	return &interfaceMemoryGauge{
+		memoryGauge: memoryGauge,
+		memoryLimit: memoryLimit,
+	}
+}
+
+func (g *interfaceMemoryGauge) MeterMemory(usage common.MemoryUsage) (err error) {
+	if g.memoryLimit > 0 {
+		g.memoryUsed += usage.Amount
+		if g.memoryUsed > g.memoryLimit {
+			return MemoryLimitExceededError{
+				Limit: g.memoryLimit,
+			}
+		}
+	}
+
+	if g.memoryGauge != nil {
+		wrapPanic(func() {
+			err = g.memoryGauge.MeterMemory(usage)
+		})
+	}
+
+	return
+}
+
+// stringConcatenationBytesPerComputation is the number of bytes copied by string concatenations
+// which are metered as one unit of computation, like one statement.
+//
+const stringConcatenationBytesPerComputation = 100
+
+// hashingBytesPerComputation is the number of bytes hashed by hasher updates
+// which are metered as one unit of computation, like one statement.
+//
+const hashingBytesPerComputation = 100
+
+// computationIntensityUsage returns the computation used
+// by a computation of the given kind and intensity.
+//
+func computationIntensityUsage(kind common.ComputationKind, intensity uint) uint64 {
+	switch kind {
+	case common.ComputationKindStringConcatenation:
+		return uint64(intensity / stringConcatenationBytesPerComputation)
+	case common.ComputationKindHashing:
+		return uint64(intensity / hashingBytesPerComputation)
+	default:
+		return uint64(intensity)
+	}
+}
+
 var getAuthAccountFunctionType = &sema.FunctionType{
 	Parameters: []*sema.Parameter{{
 		Label:          sema.ArgumentLabelNotRequired,
@@ -1503,12 +3067,16 @@ func (r *interpreterRuntime) standardLibraryFunctions(
 	checkerOptions []sema.Option,
 ) stdlib.StandardLibraryFunctions {
 	builtins := stdlib.FlowBuiltInFunctions(stdlib.FlowBuiltinImpls{
-		CreateAccount:   r.newCreateAccountFunction(context, storage, interpreterOptions, checkerOptions),
-		GetAccount:      r.newGetAccountFunction(context.Interface, storage),
-		Log:             r.newLogFunction(context.Interface),
-		GetCurrentBlock: r.newGetCurrentBlockFunction(context.Interface),
-		GetBlock:        r.newGetBlockFunction(context.Interface),
-		UnsafeRandom:    r.newUnsafeRandomFunction(context.Interface),
+		CreateAccount:      r.newCreateAccountFunction(context, storage, interpreterOptions, checkerOptions),
+		GetAccount:         r.newGetAccountFunction(context, storage),
+		Log:                r.newLogFunction(context.Interface),
+		GetCurrentBlock:    r.newGetCurrentBlockFunction(context.Interface),
+		GetBlock:           r.newGetBlockFunction(context.Interface),
+		GetBlockHeader:     r.newGetBlockHeaderFunction(context.Interface),
+		GetBlockIDs:        r.newGetBlockIDsFunction(context.Interface),
+		UnsafeRandom:       r.newUnsafeRandomFunction(context.Interface),
+		VerifyAccountProof: r.newVerifyAccountProofFunction(context.Interface),
+		ScheduleCallback:   r.newScheduleCallbackFunction(context.Interface),
 	})
 
 	switch context.Location.(type) {
@@ -1574,8 +3142,33 @@ func (r *interpreterRuntime) emitEvent(
 	if err != nil {
 		return err
 	}
+	return emitExportedEvent(runtimeInterface, exportedEvent)
+}
+
+// emitExportedEvent emits the given exported event to the runtime interface.
+// If the runtime interface implements EncodedEventInterface,
+// the event is emitted together with its encoded payload.
+func emitExportedEvent(runtimeInterface Interface, event cadence.Event) (err error) {
+	encodedEventInterface, ok := runtimeInterface.(EncodedEventInterface)
+	if !ok {
+		wrapPanic(func() {
+			err = runtimeInterface.EmitEvent(event)
+		})
+		return err
+	}
+
+	var encoder EventEncoder
+	wrapPanic(func() {
+		encoder = encodedEventInterface.EventEncoder()
+	})
+
+	payload, err := encoder.EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
 	wrapPanic(func() {
-		err = runtimeInterface.EmitEvent(exportedEvent)
+		err = encodedEventInterface.EmitEncodedEvent(event, payload)
 	})
 	return err
 }
@@ -1606,9 +3199,7 @@ func (r *interpreterRuntime) emitAccountEvent(
 	if err != nil {
 		panic(err)
 	}
-	wrapPanic(func() {
-		err = runtimeInterface.EmitEvent(exportedEvent)
-	})
+	err = emitExportedEvent(runtimeInterface, exportedEvent)
 	if err != nil {
 		panic(err)
 	}
@@ -1627,6 +3218,9 @@ func (r *interpreterRuntime) newCreateAccountFunction(
 ) interpreter.HostFunction {
 	return func(invocation interpreter.Invocation) interpreter.Value {
 
+		invocation.Interpreter.CheckWrite("create account", invocation.GetLocationRange)
+		context.simulation.checkAccountChange("create account", invocation.GetLocationRange)
+
 		payer := invocation.Arguments[0].(interpreter.MemberAccessibleValue)
 
 		inter := invocation.Interpreter
@@ -1681,12 +3275,17 @@ func (r *interpreterRuntime) newCreateAccountFunction(
 func accountBalanceGetFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) func() interpreter.UFix64Value {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
 	return func() interpreter.UFix64Value {
+		if balance, ok := simulation.balance(address); ok {
+			return interpreter.UFix64Value(balance)
+		}
+
 		var balance uint64
 		var err error
 		wrapPanic(func() {
@@ -1702,12 +3301,17 @@ func accountBalanceGetFunction(
 func accountAvailableBalanceGetFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) func() interpreter.UFix64Value {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
 	address := addressValue.ToAddress()
 
 	return func() interpreter.UFix64Value {
+		if balance, ok := simulation.availableBalance(address); ok {
+			return interpreter.UFix64Value(balance)
+		}
+
 		var balance uint64
 		var err error
 		wrapPanic(func() {
@@ -1771,6 +3375,7 @@ func storageCapacityGetFunction(addressValue interpreter.AddressValue, runtimeIn
 func (r *interpreterRuntime) newAddPublicKeyFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
@@ -1778,6 +3383,9 @@ func (r *interpreterRuntime) newAddPublicKeyFunction(
 
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.CheckWrite("add public key", invocation.GetLocationRange)
+			simulation.checkAccountChange("add public key", invocation.GetLocationRange)
+
 			publicKeyValue := invocation.Arguments[0].(*interpreter.ArrayValue)
 
 			publicKey, err := interpreter.ByteArrayValueToByteSlice(publicKeyValue)
@@ -1812,6 +3420,7 @@ func (r *interpreterRuntime) newAddPublicKeyFunction(
 func (r *interpreterRuntime) newRemovePublicKeyFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
@@ -1819,6 +3428,9 @@ func (r *interpreterRuntime) newRemovePublicKeyFunction(
 
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.CheckWrite("remove public key", invocation.GetLocationRange)
+			simulation.checkAccountChange("remove public key", invocation.GetLocationRange)
+
 			index := invocation.Arguments[0].(interpreter.IntValue)
 
 			var publicKey []byte
@@ -1873,6 +3485,7 @@ func (r *interpreterRuntime) loadContract(
 	compositeType *sema.CompositeType,
 	constructorGenerator func(common.Address) *interpreter.HostFunctionValue,
 	invocationRange ast.Range,
+	context Context,
 	storage *Storage,
 ) *interpreter.CompositeValue {
 
@@ -1886,28 +3499,135 @@ func (r *interpreterRuntime) loadContract(
 		if err != nil {
 			panic(err)
 		}
-		return contract
+		return contract
+
+	case stdlib.TimeChecker.Location:
+		contract, err := stdlib.NewTimeContract(
+			inter,
+			constructorGenerator(common.Address{}),
+			invocationRange,
+		)
+		if err != nil {
+			panic(err)
+		}
+		return contract
+
+	default:
+
+		if r.testContractEnabled && compositeType.Location == stdlib.TestChecker.Location {
+			contract, err := stdlib.NewTestContract(
+				inter,
+				constructorGenerator(common.Address{}),
+				invocationRange,
+			)
+			if err != nil {
+				panic(err)
+			}
+			return contract
+		}
 
-	default:
+		if r.standardContractsEnabled && stdlib.IsStandardContractLocation(compositeType.Location) {
+			contract, err := stdlib.NewStandardContract(
+				inter,
+				compositeType,
+				constructorGenerator(common.Address{}),
+				invocationRange,
+			)
+			if err != nil {
+				panic(err)
+			}
+			return contract
+		}
 
-		var storedValue interpreter.Value
+		var contract *interpreter.CompositeValue
 
 		switch location := compositeType.Location.(type) {
 
 		case common.AddressLocation:
-			storageMap := storage.GetStorageMap(
-				location.Address,
-				StorageDomainContract,
-			)
-			storedValue = storageMap.ReadValue(location.Name)
+			contract = r.loadStoredContract(context, location, storage)
 		}
 
-		if storedValue == nil {
+		if contract == nil {
 			panic(fmt.Errorf("failed to load contract: %s", compositeType.Location))
 		}
 
-		return storedValue.(*interpreter.CompositeValue)
+		return contract
+	}
+}
+
+// loadStoredContract loads the contract value at the given location from storage,
+// or returns nil if no contract is stored.
+//
+// Loaded contract values are cached, keyed by the location and the hash of the code of the contract.
+// The cache of the context is used if it is set, otherwise the cache of the execution's storage.
+//
+func (r *interpreterRuntime) loadStoredContract(
+	context Context,
+	location common.AddressLocation,
+	storage *Storage,
+) *interpreter.CompositeValue {
+
+	cache := context.ContractValueCache
+	if cache == nil {
+		cache = storage.contractValueCache()
+	}
+
+	key, err := r.contractValueCacheKey(context, location)
+	if err != nil {
+		panic(err)
+	}
+
+	// The cached value is not bound to any interpreter.
+	// Return a copy, so the interpreter requesting the value can bind it
+
+	if cachedContract := cache.get(key); cachedContract != nil {
+		return cachedContract.UnboundCopy()
+	}
+
+	storageMap := storage.GetStorageMap(
+		location.Address,
+		StorageDomainContract,
+	)
+	storedValue := storageMap.ReadValue(location.Name)
+	if storedValue == nil {
+		return nil
+	}
+
+	contract := storedValue.(*interpreter.CompositeValue)
+
+	cache.set(key, contract.UnboundCopy())
+
+	return contract
+}
+
+// contractValueCacheKey returns the key for the contract value at the given location.
+// The code of the contract is taken from the context, if it was already loaded,
+// otherwise it is loaded through the interface.
+//
+func (r *interpreterRuntime) contractValueCacheKey(
+	context Context,
+	location common.AddressLocation,
+) (
+	contractValueCacheKey,
+	error,
+) {
+	locationID := location.ID()
+
+	var code []byte
+	if loadedCode, ok := context.codes[locationID]; ok {
+		code = []byte(loadedCode)
+	} else {
+		var err error
+		code, err = r.getCode(context.WithLocation(location))
+		if err != nil {
+			return contractValueCacheKey{}, err
+		}
 	}
+
+	return contractValueCacheKey{
+		locationID: locationID,
+		codeHash:   sha3.Sum256(code),
+	}, nil
 }
 
 func (r *interpreterRuntime) instantiateContract(
@@ -2018,6 +3738,7 @@ func (r *interpreterRuntime) instantiateContract(
 					compositeType,
 					constructorGenerator,
 					invocationRange,
+					context,
 					storage,
 				)
 			},
@@ -2070,12 +3791,12 @@ func (r *interpreterRuntime) newGetAuthAccountFunction(
 	}
 }
 
-func (r *interpreterRuntime) newGetAccountFunction(runtimeInterface Interface, storage *Storage) interpreter.HostFunction {
+func (r *interpreterRuntime) newGetAccountFunction(context Context, storage *Storage) interpreter.HostFunction {
 	return func(invocation interpreter.Invocation) interpreter.Value {
 		accountAddress := invocation.Arguments[0].(interpreter.AddressValue)
 		return r.getPublicAccount(
 			accountAddress,
-			runtimeInterface,
+			context,
 			storage,
 		)
 	}
@@ -2083,14 +3804,17 @@ func (r *interpreterRuntime) newGetAccountFunction(runtimeInterface Interface, s
 
 func (r *interpreterRuntime) getPublicAccount(
 	accountAddress interpreter.AddressValue,
-	runtimeInterface Interface,
+	context Context,
 	storage *Storage,
 ) interpreter.Value {
 
+	runtimeInterface := context.Interface
+	simulation := context.simulation
+
 	return interpreter.NewPublicAccountValue(
 		accountAddress,
-		accountBalanceGetFunction(accountAddress, runtimeInterface),
-		accountAvailableBalanceGetFunction(accountAddress, runtimeInterface),
+		accountBalanceGetFunction(accountAddress, runtimeInterface, simulation),
+		accountAvailableBalanceGetFunction(accountAddress, runtimeInterface, simulation),
 		storageUsedGetFunction(accountAddress, runtimeInterface, storage),
 		storageCapacityGetFunction(accountAddress, runtimeInterface),
 		func() interpreter.Value {
@@ -2113,98 +3837,367 @@ func (r *interpreterRuntime) newLogFunction(runtimeInterface Interface) interpre
 		if err != nil {
 			panic(err)
 		}
-		return interpreter.VoidValue{}
-	}
-}
+		return interpreter.VoidValue{}
+	}
+}
+
+func (r *interpreterRuntime) getCurrentBlockHeight(runtimeInterface Interface) (currentBlockHeight uint64, err error) {
+	wrapPanic(func() {
+		currentBlockHeight, err = runtimeInterface.GetCurrentBlockHeight()
+	})
+	return
+}
+
+func (r *interpreterRuntime) getBlockAtHeight(
+	height uint64,
+	runtimeInterface Interface,
+	inter *interpreter.Interpreter,
+) (
+	interpreter.Value,
+	error,
+) {
+
+	var block Block
+	var exists bool
+	var err error
+
+	wrapPanic(func() {
+		block, exists, err = runtimeInterface.GetBlockAtHeight(height)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	return NewBlockValue(inter, block), nil
+}
+
+func (r *interpreterRuntime) newGetCurrentBlockFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		var height uint64
+		var err error
+		wrapPanic(func() {
+			height, err = r.getCurrentBlockHeight(runtimeInterface)
+		})
+		if err != nil {
+			panic(err)
+		}
+		block, err := r.getBlockAtHeight(
+			height,
+			runtimeInterface,
+			invocation.Interpreter,
+		)
+		if err != nil {
+			panic(err)
+		}
+		return block
+	}
+}
+
+func (r *interpreterRuntime) newGetBlockFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		height := uint64(invocation.Arguments[0].(interpreter.UInt64Value))
+		block, err := r.getBlockAtHeight(
+			height,
+			runtimeInterface,
+			invocation.Interpreter,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		if block == nil {
+			return interpreter.NilValue{}
+		}
+
+		return interpreter.NewSomeValueNonCopying(block)
+	}
+}
+
+func (r *interpreterRuntime) newGetBlockHeaderFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		height := uint64(invocation.Arguments[0].(interpreter.UInt64Value))
+
+		var header BlockHeader
+		var exists bool
+		var err error
+		wrapPanic(func() {
+			header, exists, err = runtimeInterface.GetBlockHeaderAtHeight(height)
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		if !exists {
+			return interpreter.NilValue{}
+		}
+
+		return interpreter.NewSomeValueNonCopying(
+			NewBlockHeaderValue(invocation.Interpreter, header),
+		)
+	}
+}
+
+func (r *interpreterRuntime) newGetBlockIDsFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		startHeight := uint64(invocation.Arguments[0].(interpreter.UInt64Value))
+		endHeight := uint64(invocation.Arguments[1].(interpreter.UInt64Value))
+
+		currentBlockHeight, err := r.getCurrentBlockHeight(runtimeInterface)
+		if err != nil {
+			panic(err)
+		}
+
+		if startHeight > endHeight {
+			panic(&InvalidBlockRangeError{
+				StartHeight:        startHeight,
+				EndHeight:          endHeight,
+				CurrentBlockHeight: currentBlockHeight,
+				LocationRange:      invocation.GetLocationRange(),
+			})
+		}
+
+		// NOTE: check the length of the range before the end height,
+		// the range is too long no matter what the current block height is
+
+		if endHeight-startHeight >= stdlib.MaxBlockIDRangeLength {
+			panic(&BlockRangeLimitExceededError{
+				StartHeight:   startHeight,
+				EndHeight:     endHeight,
+				Limit:         stdlib.MaxBlockIDRangeLength,
+				LocationRange: invocation.GetLocationRange(),
+			})
+		}
+
+		if endHeight > currentBlockHeight {
+			panic(&InvalidBlockRangeError{
+				StartHeight:        startHeight,
+				EndHeight:          endHeight,
+				CurrentBlockHeight: currentBlockHeight,
+				LocationRange:      invocation.GetLocationRange(),
+			})
+		}
+
+		var hashes []BlockHash
+		wrapPanic(func() {
+			hashes, err = runtimeInterface.GetBlockIDsInRange(startHeight, endHeight)
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		inter := invocation.Interpreter
+
+		values := make([]interpreter.Value, len(hashes))
+		for i, hash := range hashes {
+			values[i] = newBlockIDValue(inter, hash)
+		}
+
+		return interpreter.NewArrayValue(
+			inter,
+			blockIDsStaticType,
+			common.Address{},
+			values...,
+		)
+	}
+}
+
+func (r *interpreterRuntime) newUnsafeRandomFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		var rand uint64
+		var err error
+		wrapPanic(func() {
+			rand, err = runtimeInterface.UnsafeRandom()
+		})
+		if err != nil {
+			panic(err)
+		}
+		return interpreter.UInt64Value(rand)
+	}
+}
+
+// AccountKeyWeightThreshold is the total weight of account keys
+// which is required for the signatures of an account to be valid
+//
+const AccountKeyWeightThreshold = 1000
+
+// AccountProofDomainSeparationTag is the domain separation tag of account proofs.
+//
+// The tag is fixed, so signatures produced for other domains, e.g. transactions,
+// cannot be used as account proofs.
+//
+const AccountProofDomainSeparationTag = "FCL-ACCOUNT-PROOF-V0.0"
+
+func (r *interpreterRuntime) newVerifyAccountProofFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		addressValue, addressOk := invocation.Arguments[0].(interpreter.AddressValue)
+		messageValue, messageOk := invocation.Arguments[1].(*interpreter.ArrayValue)
+		keyIndicesValue, keyIndicesOk := invocation.Arguments[2].(*interpreter.ArrayValue)
+		signaturesValue, signaturesOk := invocation.Arguments[3].(*interpreter.ArrayValue)
+
+		if !addressOk || !messageOk || !keyIndicesOk || !signaturesOk {
+			panic(runtimeErrors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+		getLocationRange := invocation.GetLocationRange
+
+		signatureCount := signaturesValue.Count()
+
+		if signatureCount == 0 || keyIndicesValue.Count() != signatureCount {
+			return interpreter.BoolValue(false)
+		}
+
+		address := addressValue.ToAddress()
+
+		message, err := interpreter.ByteArrayValueToByteSlice(messageValue)
+		if err != nil {
+			panic(fmt.Errorf("failed to get message. %w", err))
+		}
+
+		seenKeyIndices := make(map[int]struct{}, signatureCount)
+
+		var totalWeight int
+
+		for i := 0; i < signatureCount; i++ {
+
+			// Ensure the key index is valid and has not been seen yet
+
+			keyIndexValue := keyIndicesValue.Get(inter, getLocationRange, i).(interpreter.IntValue)
+			if !keyIndexValue.BigInt.IsInt64() {
+				return interpreter.BoolValue(false)
+			}
+
+			keyIndex := int(keyIndexValue.BigInt.Int64())
+			if keyIndex < 0 {
+				return interpreter.BoolValue(false)
+			}
+
+			if _, ok := seenKeyIndices[keyIndex]; ok {
+				return interpreter.BoolValue(false)
+			}
+			seenKeyIndices[keyIndex] = struct{}{}
+
+			// Ensure the key exists and is not revoked
+
+			var accountKey *AccountKey
+			wrapPanic(func() {
+				accountKey, err = runtimeInterface.GetAccountKey(address, keyIndex)
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			if accountKey == nil || accountKey.IsRevoked {
+				return interpreter.BoolValue(false)
+			}
+
+			// Ensure the signature is valid
+
+			signature, err := interpreter.ByteArrayValueToByteSlice(
+				signaturesValue.Get(inter, getLocationRange, i),
+			)
+			if err != nil {
+				panic(fmt.Errorf("failed to get signature. %w", err))
+			}
+
+			var valid bool
+			wrapPanic(func() {
+				valid, err = runtimeInterface.VerifySignature(
+					signature,
+					AccountProofDomainSeparationTag,
+					message,
+					accountKey.PublicKey.PublicKey,
+					accountKey.PublicKey.SignAlgo,
+					accountKey.HashAlgo,
+				)
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			if !valid {
+				return interpreter.BoolValue(false)
+			}
+
+			totalWeight += accountKey.Weight
+		}
 
-func (r *interpreterRuntime) getCurrentBlockHeight(runtimeInterface Interface) (currentBlockHeight uint64, err error) {
-	wrapPanic(func() {
-		currentBlockHeight, err = runtimeInterface.GetCurrentBlockHeight()
-	})
-	return
+		return interpreter.BoolValue(totalWeight >= AccountKeyWeightThreshold)
+	}
 }
 
-func (r *interpreterRuntime) getBlockAtHeight(
-	height uint64,
-	runtimeInterface Interface,
-	inter *interpreter.Interpreter,
-) (
-	interpreter.Value,
-	error,
-) {
+func (r *interpreterRuntime) newScheduleCallbackFunction(runtimeInterface Interface) interpreter.HostFunction {
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		capabilityValue, capabilityOk := invocation.Arguments[0].(*interpreter.CapabilityValue)
+		functionNameValue, functionNameOk := invocation.Arguments[1].(*interpreter.StringValue)
+		blockHeightValue, blockHeightOk := invocation.Arguments[2].(interpreter.UInt64Value)
+		feeValue, feeOk := invocation.Arguments[3].(interpreter.UFix64Value)
 
-	var block Block
-	var exists bool
-	var err error
+		if !capabilityOk || !functionNameOk || !blockHeightOk || !feeOk {
+			panic(runtimeErrors.NewUnreachableError())
+		}
 
-	wrapPanic(func() {
-		block, exists, err = runtimeInterface.GetBlockAtHeight(height)
-	})
+		inter := invocation.Interpreter
+		getLocationRange := invocation.GetLocationRange
 
-	if err != nil {
-		return nil, err
-	}
+		scheduledCallbackInterface, ok := runtimeInterface.(ScheduledCallbackInterface)
+		if !ok {
+			panic(&ScheduledCallbacksNotSupportedError{
+				LocationRange: getLocationRange(),
+			})
+		}
 
-	if !exists {
-		return nil, nil
-	}
+		// Ensure the callback is scheduled for a future block
 
-	return NewBlockValue(inter, block), nil
-}
+		blockHeight := uint64(blockHeightValue)
 
-func (r *interpreterRuntime) newGetCurrentBlockFunction(runtimeInterface Interface) interpreter.HostFunction {
-	return func(invocation interpreter.Invocation) interpreter.Value {
-		var height uint64
+		var currentBlockHeight uint64
 		var err error
 		wrapPanic(func() {
-			height, err = r.getCurrentBlockHeight(runtimeInterface)
+			currentBlockHeight, err = runtimeInterface.GetCurrentBlockHeight()
 		})
 		if err != nil {
 			panic(err)
 		}
-		block, err := r.getBlockAtHeight(
-			height,
-			runtimeInterface,
-			invocation.Interpreter,
-		)
-		if err != nil {
-			panic(err)
+
+		if blockHeight <= currentBlockHeight {
+			panic(&InvalidScheduledCallbackBlockHeightError{
+				BlockHeight:        blockHeight,
+				CurrentBlockHeight: currentBlockHeight,
+				LocationRange:      getLocationRange(),
+			})
 		}
-		return block
-	}
-}
 
-func (r *interpreterRuntime) newGetBlockFunction(runtimeInterface Interface) interpreter.HostFunction {
-	return func(invocation interpreter.Invocation) interpreter.Value {
-		height := uint64(invocation.Arguments[0].(interpreter.UInt64Value))
-		block, err := r.getBlockAtHeight(
-			height,
-			runtimeInterface,
-			invocation.Interpreter,
-		)
+		// Ensure the handler can be invoked
+
+		functionName := functionNameValue.Str
+
+		_, err = scheduledCallbackFunction(inter, capabilityValue, functionName, getLocationRange)
 		if err != nil {
 			panic(err)
 		}
 
-		if block == nil {
-			return interpreter.NilValue{}
+		callback := ScheduledCallback{
+			Handler:      exportCapabilityValue(capabilityValue, inter),
+			FunctionName: functionName,
+			BlockHeight:  blockHeight,
+			Fee:          uint64(feeValue),
 		}
 
-		return interpreter.NewSomeValueNonCopying(block)
-	}
-}
-
-func (r *interpreterRuntime) newUnsafeRandomFunction(runtimeInterface Interface) interpreter.HostFunction {
-	return func(invocation interpreter.Invocation) interpreter.Value {
-		var rand uint64
-		var err error
+		var id uint64
 		wrapPanic(func() {
-			rand, err = runtimeInterface.UnsafeRandom()
+			id, err = scheduledCallbackInterface.ScheduleCallback(callback)
 		})
 		if err != nil {
 			panic(err)
 		}
-		return interpreter.UInt64Value(rand)
+
+		return interpreter.UInt64Value(id)
 	}
 }
 
@@ -2224,6 +4217,16 @@ func (r *interpreterRuntime) newAuthAccountContracts(
 			interpreterOptions,
 			checkerOptions,
 			false,
+			false,
+		),
+		r.newAuthAccountContractsChangeFunction(
+			addressValue,
+			context,
+			storage,
+			interpreterOptions,
+			checkerOptions,
+			false,
+			true,
 		),
 		r.newAuthAccountContractsChangeFunction(
 			addressValue,
@@ -2232,6 +4235,7 @@ func (r *interpreterRuntime) newAuthAccountContracts(
 			interpreterOptions,
 			checkerOptions,
 			true,
+			false,
 		),
 		r.newAccountContractsGetFunction(
 			addressValue,
@@ -2240,6 +4244,7 @@ func (r *interpreterRuntime) newAuthAccountContracts(
 		r.newAuthAccountContractsRemoveFunction(
 			addressValue,
 			context.Interface,
+			context.simulation,
 			storage,
 		),
 		r.newAccountContractsGetNamesFunction(
@@ -2252,12 +4257,14 @@ func (r *interpreterRuntime) newAuthAccountContracts(
 func (r *interpreterRuntime) newAuthAccountKeys(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) interpreter.Value {
 	return interpreter.NewAuthAccountKeysValue(
 		addressValue,
 		r.newAccountKeysAddFunction(
 			addressValue,
 			runtimeInterface,
+			simulation,
 		),
 		r.newAccountKeysGetFunction(
 			addressValue,
@@ -2266,12 +4273,14 @@ func (r *interpreterRuntime) newAuthAccountKeys(
 		r.newAccountKeysRevokeFunction(
 			addressValue,
 			runtimeInterface,
+			simulation,
 		),
 	)
 }
 
 // newAuthAccountContractsChangeFunction called when e.g.
-// - adding: `AuthAccount.contracts.add(name: "Foo", code: [...])` (isUpdate = false)
+// - adding: `AuthAccount.contracts.add(name: "Foo", code: [...])` (isUpdate = false, freeze = false)
+// - adding frozen: `AuthAccount.contracts.addFrozen(name: "Foo", code: [...])` (isUpdate = false, freeze = true)
 // - updating: `AuthAccount.contracts.update__experimental(name: "Foo", code: [...])` (isUpdate = true)
 //
 func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
@@ -2281,10 +4290,24 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 	interpreterOptions []interpreter.Option,
 	checkerOptions []sema.Option,
 	isUpdate bool,
+	freeze bool,
 ) *interpreter.HostFunctionValue {
+
+	functionType := sema.AuthAccountContractsTypeAddFunctionType
+	if freeze {
+		functionType = sema.AuthAccountContractsTypeAddFrozenFunctionType
+	}
+
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
 
+			operation := "deploy contract"
+			if isUpdate {
+				operation = "update contract"
+			}
+			invocation.Interpreter.CheckWrite(operation, invocation.GetLocationRange)
+			startContext.simulation.checkAccountChange(operation, invocation.GetLocationRange)
+
 			const requiredArgumentCount = 2
 
 			nameValue := invocation.Arguments[0].(*interpreter.StringValue)
@@ -2323,10 +4346,19 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 					panic(fmt.Errorf(
 						"cannot update non-existing contract with name %q in account %s",
 						nameArgument,
-						address.ShortHexWithPrefix(),
+						address.HexWithPrefix(),
 					))
 				}
 
+				// Ensure the existing contract/contract interface was not deployed as frozen
+
+				if storage.isContractFrozen(address, nameArgument) {
+					panic(&FrozenContractUpdateError{
+						Name:          nameArgument,
+						LocationRange: invocation.GetLocationRange(),
+					})
+				}
+
 			} else {
 				// We are adding a new contract.
 				// Ensure that no contract/contract interface with the given name exists already
@@ -2335,7 +4367,7 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 					panic(fmt.Errorf(
 						"cannot overwrite existing contract with name %q in account %s",
 						nameArgument,
-						address.ShortHexWithPrefix(),
+						address.HexWithPrefix(),
 					))
 				}
 			}
@@ -2527,6 +4559,10 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 				panic(err)
 			}
 
+			if freeze {
+				storage.recordFrozenContract(inter, address, declaredName)
+			}
+
 			codeHashValue := CodeToHashValue(inter, code)
 
 			eventArguments := []exportableValue{
@@ -2555,7 +4591,7 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 				newCodeValue,
 			)
 		},
-		sema.AuthAccountContractsTypeAddFunctionType,
+		functionType,
 	)
 }
 
@@ -2693,6 +4729,7 @@ func (r *interpreterRuntime) newAccountContractsGetFunction(
 func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 	storage *Storage,
 ) *interpreter.HostFunctionValue {
 
@@ -2702,6 +4739,9 @@ func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
 
+			invocation.Interpreter.CheckWrite("remove contract", invocation.GetLocationRange)
+			simulation.checkAccountChange("remove contract", invocation.GetLocationRange)
+
 			inter := invocation.Interpreter
 			nameValue := invocation.Arguments[0].(*interpreter.StringValue)
 
@@ -2726,6 +4766,15 @@ func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 				// NOTE: *DO NOT* call SetProgram – the program removal
 				// should not be effective during the execution, only after
 
+				// Deny removing a contract that was deployed as frozen.
+				if storage.isContractFrozen(address, nameArgument) {
+					panic(&FrozenContractUpdateError{
+						Name:          nameArgument,
+						IsRemoval:     true,
+						LocationRange: invocation.GetLocationRange(),
+					})
+				}
+
 				// Deny removing a contract, if the contract validation is enabled, and
 				// the existing code contains enums.
 				if r.contractUpdateValidationEnabled {
@@ -2919,6 +4968,10 @@ var BlockIDStaticType = interpreter.ConstantSizedStaticType{
 	Size: 32,
 }
 
+var blockIDsStaticType = interpreter.VariableSizedStaticType{
+	Type: BlockIDStaticType,
+}
+
 func NewBlockValue(inter *interpreter.Interpreter, block Block) interpreter.Value {
 
 	// height
@@ -2928,17 +4981,7 @@ func NewBlockValue(inter *interpreter.Interpreter, block Block) interpreter.Valu
 	viewValue := interpreter.UInt64Value(block.View)
 
 	// ID
-	var values = make([]interpreter.Value, sema.BlockIDSize)
-	for i, b := range block.Hash {
-		values[i] = interpreter.UInt8Value(b)
-	}
-
-	idValue := interpreter.NewArrayValue(
-		inter,
-		BlockIDStaticType,
-		common.Address{},
-		values...,
-	)
+	idValue := newBlockIDValue(inter, block.Hash)
 
 	// timestamp
 	// TODO: verify
@@ -2952,9 +4995,50 @@ func NewBlockValue(inter *interpreter.Interpreter, block Block) interpreter.Valu
 	)
 }
 
+func NewBlockHeaderValue(inter *interpreter.Interpreter, header BlockHeader) interpreter.Value {
+
+	// height
+	heightValue := interpreter.UInt64Value(header.Height)
+
+	// view
+	viewValue := interpreter.UInt64Value(header.View)
+
+	// ID
+	idValue := newBlockIDValue(inter, header.Hash)
+
+	// parent ID
+	parentIDValue := newBlockIDValue(inter, header.ParentHash)
+
+	// timestamp
+	timestampValue := interpreter.NewUFix64ValueWithInteger(uint64(time.Unix(0, header.Timestamp).Unix()))
+
+	return interpreter.NewBlockHeaderValue(
+		heightValue,
+		viewValue,
+		idValue,
+		parentIDValue,
+		timestampValue,
+	)
+}
+
+func newBlockIDValue(inter *interpreter.Interpreter, hash BlockHash) *interpreter.ArrayValue {
+	var values = make([]interpreter.Value, sema.BlockIDSize)
+	for i, b := range hash {
+		values[i] = interpreter.UInt8Value(b)
+	}
+
+	return interpreter.NewArrayValue(
+		inter,
+		BlockIDStaticType,
+		common.Address{},
+		values...,
+	)
+}
+
 func (r *interpreterRuntime) newAccountKeysAddFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
@@ -2962,6 +5046,9 @@ func (r *interpreterRuntime) newAccountKeysAddFunction(
 
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.CheckWrite("add account key", invocation.GetLocationRange)
+			simulation.checkAccountChange("add account key", invocation.GetLocationRange)
+
 			publicKeyValue := invocation.Arguments[0].(*interpreter.CompositeValue)
 
 			inter := invocation.Interpreter
@@ -3050,6 +5137,7 @@ func (r *interpreterRuntime) newAccountKeysGetFunction(
 func (r *interpreterRuntime) newAccountKeysRevokeFunction(
 	addressValue interpreter.AddressValue,
 	runtimeInterface Interface,
+	simulation *Simulation,
 ) *interpreter.HostFunctionValue {
 
 	// Converted addresses can be cached and don't have to be recomputed on each function invocation
@@ -3057,6 +5145,9 @@ func (r *interpreterRuntime) newAccountKeysRevokeFunction(
 
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.CheckWrite("revoke account key", invocation.GetLocationRange)
+			simulation.checkAccountChange("revoke account key", invocation.GetLocationRange)
+
 			indexValue := invocation.Arguments[0].(interpreter.IntValue)
 			index := indexValue.ToInt()
 
@@ -3410,6 +5501,81 @@ func verifySignature(
 	return interpreter.BoolValue(valid)
 }
 
+func isSignatureSchemeSupported(
+	schemeValue *interpreter.StringValue,
+	runtimeInterface Interface,
+) interpreter.BoolValue {
+
+	signatureSchemeInterface, ok := runtimeInterface.(SignatureSchemeInterface)
+	if !ok {
+		return false
+	}
+
+	var supported bool
+	var err error
+	wrapPanic(func() {
+		supported, err = signatureSchemeInterface.IsSignatureSchemeSupported(schemeValue.Str)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return interpreter.BoolValue(supported)
+}
+
+func verifySignatureWithScheme(
+	getLocationRange func() interpreter.LocationRange,
+	schemeValue *interpreter.StringValue,
+	signatureValue *interpreter.ArrayValue,
+	signedDataValue *interpreter.ArrayValue,
+	domainSeparationTagValue *interpreter.StringValue,
+	publicKeyValue *interpreter.ArrayValue,
+	runtimeInterface Interface,
+) interpreter.BoolValue {
+
+	scheme := schemeValue.Str
+
+	if !isSignatureSchemeSupported(schemeValue, runtimeInterface) {
+		panic(interpreter.UnsupportedSignatureSchemeError{
+			Scheme:        scheme,
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	signatureSchemeInterface := runtimeInterface.(SignatureSchemeInterface)
+
+	signature, err := interpreter.ByteArrayValueToByteSlice(signatureValue)
+	if err != nil {
+		panic(fmt.Errorf("failed to get signature. %w", err))
+	}
+
+	signedData, err := interpreter.ByteArrayValueToByteSlice(signedDataValue)
+	if err != nil {
+		panic(fmt.Errorf("failed to get signed data. %w", err))
+	}
+
+	publicKey, err := interpreter.ByteArrayValueToByteSlice(publicKeyValue)
+	if err != nil {
+		panic(fmt.Errorf("failed to get public key. %w", err))
+	}
+
+	var valid bool
+	wrapPanic(func() {
+		valid, err = signatureSchemeInterface.VerifySignatureWithScheme(
+			scheme,
+			signature,
+			domainSeparationTagValue.Str,
+			signedData,
+			publicKey,
+		)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return interpreter.BoolValue(valid)
+}
+
 func hash(
 	inter *interpreter.Interpreter,
 	getLocationRange func() interpreter.LocationRange,
@@ -3441,3 +5607,98 @@ func hash(
 
 	return interpreter.ByteSliceToByteArrayValue(inter, result)
 }
+
+func newHasher(
+	inter *interpreter.Interpreter,
+	getLocationRange func() interpreter.LocationRange,
+	hashAlgorithmValue interpreter.Value,
+	runtimeInterface Interface,
+) interpreter.Hasher {
+
+	hashAlgorithm := NewHashAlgorithmFromValue(inter, getLocationRange, hashAlgorithmValue)
+
+	incrementalHashInterface, ok := runtimeInterface.(IncrementalHashInterface)
+	if !ok {
+		return &bufferedHasher{
+			inter:            inter,
+			runtimeInterface: runtimeInterface,
+			hashAlgorithm:    hashAlgorithm,
+		}
+	}
+
+	var hasher Hasher
+	var err error
+	wrapPanic(func() {
+		hasher, err = incrementalHashInterface.NewHasher(hashAlgorithm)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return interfaceHasher{
+		hasher: hasher,
+	}
+}
+
+// interfaceHasher is an interpreter.Hasher which hashes using a Hasher,
+// created by an IncrementalHashInterface.
+//
+type interfaceHasher struct {
+	hasher Hasher
+}
+
+var _ interpreter.Hasher = interfaceHasher{}
+
+func (h interfaceHasher) Update(data []byte) {
+	var err error
+	wrapPanic(func() {
+		err = h.hasher.Update(data)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (h interfaceHasher) Finalize() []byte {
+	var result []byte
+	var err error
+	wrapPanic(func() {
+		result, err = h.hasher.Finalize()
+	})
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// bufferedHasher is an interpreter.Hasher which buffers all data,
+// and hashes it using Interface.Hash when finalized.
+//
+// It is used when the Interface does not implement IncrementalHashInterface.
+// The data is still not held in Cadence values, but the buffered data is metered as memory.
+//
+type bufferedHasher struct {
+	inter            *interpreter.Interpreter
+	runtimeInterface Interface
+	hashAlgorithm    HashAlgorithm
+	data             []byte
+}
+
+var _ interpreter.Hasher = &bufferedHasher{}
+
+func (h *bufferedHasher) Update(data []byte) {
+	h.inter.MeterMemory(common.MemoryKindHasherBuffer, uint64(len(data)))
+	h.data = append(h.data, data...)
+}
+
+func (h *bufferedHasher) Finalize() []byte {
+	var result []byte
+	var err error
+	wrapPanic(func() {
+		result, err = h.runtimeInterface.Hash(h.data, "", h.hashAlgorithm)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -128,6 +128,7 @@ func newTestLedger(
 func newTestInterpreterRuntime(options ...Option) Runtime {
 	rt := NewInterpreterRuntime(options...)
 	rt.SetAtreeValidationEnabled(true)
+	rt.SetInvariantChecksEnabled(true)
 	return rt
 }
 
@@ -373,6 +374,39 @@ func (i *testRuntimeInterface) GetBlockAtHeight(height uint64) (block Block, exi
 	return block, true, nil
 }
 
+func (i *testRuntimeInterface) GetBlockHeaderAtHeight(height uint64) (header BlockHeader, exists bool, err error) {
+	block, exists, err := i.GetBlockAtHeight(height)
+	if err != nil || !exists {
+		return BlockHeader{}, exists, err
+	}
+
+	header = BlockHeader{
+		Block: block,
+	}
+
+	if height > 0 {
+		parent, _, err := i.GetBlockAtHeight(height - 1)
+		if err != nil {
+			return BlockHeader{}, false, err
+		}
+		header.ParentHash = parent.Hash
+	}
+
+	return header, true, nil
+}
+
+func (i *testRuntimeInterface) GetBlockIDsInRange(startHeight, endHeight uint64) ([]BlockHash, error) {
+	var ids []BlockHash
+	for height := startHeight; height <= endHeight; height++ {
+		block, _, err := i.GetBlockAtHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, block.Hash)
+	}
+	return ids, nil
+}
+
 func (i *testRuntimeInterface) UnsafeRandom() (uint64, error) {
 	if i.unsafeRandom == nil {
 		return 0, nil
@@ -638,6 +672,251 @@ func TestRuntimeConcurrentImport(t *testing.T) {
 	//require.Equal(t, concurrency+1, checkCount)
 }
 
+func TestRuntimeExecuteScripts(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	importedScript := []byte(`
+      pub fun answer(): Int {
+          return 42
+      }
+    `)
+
+	answerScript := []byte(`
+      import "imported"
+
+      pub fun main(): Int {
+          return answer()
+      }
+    `)
+
+	addScript := []byte(`
+      import "imported"
+
+      pub fun main(x: Int): Int {
+          return answer() + x
+      }
+    `)
+
+	failingScript := []byte(`
+      pub fun main(): Int {
+          panic("failed")
+      }
+    `)
+
+	invalidScript := []byte(`
+      pub fun main(): Int {
+          return "invalid"
+      }
+    `)
+
+	var checkCount uint64
+	var programsLock sync.RWMutex
+	programs := map[common.LocationID]*interpreter.Program{}
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			switch location {
+			case common.StringLocation("imported"):
+				return importedScript, nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+		decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+			return jsoncdc.Decode(b)
+		},
+		programChecked: func(location common.Location, duration time.Duration) {
+			atomic.AddUint64(&checkCount, 1)
+		},
+		setProgram: func(location Location, program *interpreter.Program) error {
+			programsLock.Lock()
+			defer programsLock.Unlock()
+
+			programs[location.ID()] = program
+
+			return nil
+		},
+		getProgram: func(location Location) (*interpreter.Program, error) {
+			programsLock.RLock()
+			defer programsLock.RUnlock()
+
+			program := programs[location.ID()]
+
+			return program, nil
+		},
+	}
+
+	const count = 10
+
+	var scripts []Script
+	for i := 0; i < count; i++ {
+		scripts = append(scripts,
+			Script{
+				Source: answerScript,
+			},
+			Script{
+				Source: addScript,
+				Arguments: [][]byte{
+					jsoncdc.MustEncode(cadence.NewInt(i)),
+				},
+			},
+		)
+	}
+	scripts = append(scripts,
+		Script{
+			Source: failingScript,
+		},
+		Script{
+			Source: invalidScript,
+		},
+	)
+
+	results := runtime.ExecuteScripts(
+		scripts,
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.Len(t, results, len(scripts))
+
+	for i := 0; i < count; i++ {
+		answerResult := results[i*2]
+		require.NoError(t, answerResult.Err)
+		assert.Equal(t, cadence.NewInt(42), answerResult.Value)
+
+		addResult := results[i*2+1]
+		require.NoError(t, addResult.Err)
+		assert.Equal(t, cadence.NewInt(42+i), addResult.Value)
+	}
+
+	failingResult := results[len(scripts)-2]
+	require.Error(t, failingResult.Err)
+	require.Contains(t, failingResult.Err.Error(), "panic: failed")
+	require.Nil(t, failingResult.Value)
+
+	invalidResult := results[len(scripts)-1]
+	require.Error(t, invalidResult.Err)
+	require.IsType(t, Error{}, invalidResult.Err)
+	require.Contains(t, invalidResult.Err.Error(), `return "invalid"`)
+	require.Nil(t, invalidResult.Value)
+
+	// The imported program and each distinct script program are only checked once
+
+	require.Equal(t, uint64(5), checkCount)
+}
+
+func TestRuntimePreparedScript(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	importedScript := []byte(`
+      pub fun answer(): Int {
+          return 42
+      }
+    `)
+
+	script := []byte(`
+      import "imported"
+
+      pub fun main(x: Int): Int {
+          return answer() + x
+      }
+    `)
+
+	var getCodeCount, checkCount int
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			getCode: func(location Location) (bytes []byte, err error) {
+				getCodeCount++
+
+				switch location {
+				case common.StringLocation("imported"):
+					return importedScript, nil
+				default:
+					return nil, fmt.Errorf("unknown import location: %s", location)
+				}
+			},
+			programChecked: func(location common.Location, duration time.Duration) {
+				checkCount++
+			},
+			decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+				return jsoncdc.Decode(b)
+			},
+		}
+	}
+
+	preparedScript, err := runtime.PrepareScript(
+		script,
+		Context{
+			Interface: newRuntimeInterface(),
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, getCodeCount)
+	require.Equal(t, 2, checkCount)
+
+	for i := 0; i < 3; i++ {
+
+		// Use a new interface for each execution,
+		// which does not have the imported program
+
+		value, err := runtime.ExecutePreparedScript(
+			preparedScript,
+			[][]byte{
+				jsoncdc.MustEncode(cadence.NewInt(i)),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42+i), value)
+	}
+
+	// The programs of the script and the imported program are not processed again
+
+	require.Equal(t, 1, getCodeCount)
+	require.Equal(t, 2, checkCount)
+
+	t.Run("invalid", func(t *testing.T) {
+
+		_, err := runtime.PrepareScript(
+			[]byte(`pub fun main(): Int { return "invalid" }`),
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.IsType(t, Error{}, err)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+
+		_, err := runtime.ExecutePreparedScript(
+			preparedScript,
+			[][]byte{
+				jsoncdc.MustEncode(cadence.String("invalid")),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+			},
+		)
+		require.Error(t, err)
+		assert.IsType(t, &InvalidEntryPointArgumentError{}, errors.Unwrap(err))
+	})
+}
+
 func TestRuntimeProgramSetAndGet(t *testing.T) {
 
 	t.Parallel()
@@ -847,6 +1126,116 @@ func TestRuntimeTransactionWithAccount(t *testing.T) {
 	assert.Equal(t, "0x000000000000002a", loggedMessage)
 }
 
+func TestRuntimeTransactionWithSignerArray(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      transaction(message: String) {
+        prepare(payer: AuthAccount, signers: [AuthAccount]) {
+          log(message)
+          log(payer.address)
+          log(signers.length)
+          for signer in signers {
+            log(signer.address)
+          }
+        }
+      }
+    `)
+
+	test := func(t *testing.T, signers []Address) ([]string, error) {
+
+		runtime := newTestInterpreterRuntime()
+
+		var loggedMessages []string
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return signers, nil
+			},
+			log: func(message string) {
+				loggedMessages = append(loggedMessages, message)
+			},
+			decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+				return jsoncdc.Decode(b)
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+				Arguments: [][]byte{
+					jsoncdc.MustEncode(cadence.String("swap")),
+				},
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+
+		return loggedMessages, err
+	}
+
+	t.Run("multiple", func(t *testing.T) {
+
+		t.Parallel()
+
+		loggedMessages, err := test(t, []Address{{0x1}, {0x2}, {0x3}})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]string{
+				`"swap"`,
+				"0x0100000000000000",
+				"2",
+				"0x0200000000000000",
+				"0x0300000000000000",
+			},
+			loggedMessages,
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		loggedMessages, err := test(t, []Address{{0x1}})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]string{
+				`"swap"`,
+				"0x0100000000000000",
+				"0",
+			},
+			loggedMessages,
+		)
+	})
+
+	t.Run("too few", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := test(t, nil)
+		require.Error(t, err)
+
+		var authorizerCountErr InvalidTransactionAuthorizerCountError
+		require.ErrorAs(t, err, &authorizerCountErr)
+
+		assert.Equal(t,
+			InvalidTransactionAuthorizerCountError{
+				Expected: 1,
+				Actual:   0,
+				AtLeast:  true,
+			},
+			authorizerCountErr,
+		)
+	})
+}
+
 func TestRuntimeTransactionWithArguments(t *testing.T) {
 
 	t.Parallel()
@@ -4396,62 +4785,593 @@ func TestRuntimeBlock(t *testing.T) {
 	)
 }
 
-func TestRuntimeUnsafeRandom(t *testing.T) {
+func TestRuntimeBlockHeader(t *testing.T) {
 
 	t.Parallel()
 
 	runtime := newTestInterpreterRuntime()
 
 	script := []byte(`
-      transaction {
-        prepare() {
-          let rand = unsafeRandom()
-          log(rand)
-        }
+      pub fun main() {
+          let header = getBlockHeader(at: 2)!
+          log(header)
+          log(header.parentID)
+
+          log(getBlockHeader(at: 0)?.parentID)
       }
     `)
 
 	var loggedMessages []string
 
 	runtimeInterface := &testRuntimeInterface{
-		unsafeRandom: func() (uint64, error) {
-			return 7558174677681708339, nil
-		},
 		log: func(message string) {
 			loggedMessages = append(loggedMessages, message)
 		},
 	}
 
-	nextTransactionLocation := newTransactionLocationGenerator()
-
-	err := runtime.ExecuteTransaction(
+	_, err := runtime.ExecuteScript(
 		Script{
 			Source: script,
 		},
 		Context{
 			Interface: runtimeInterface,
-			Location:  nextTransactionLocation(),
+			Location:  common.ScriptLocation{},
 		},
 	)
 	require.NoError(t, err)
 
 	assert.Equal(t,
 		[]string{
-			"7558174677681708339",
+			"BlockHeader(height: 2, view: 2, id: 0x0000000000000000000000000000000000000000000000000000000000000002, parentID: 0x0000000000000000000000000000000000000000000000000000000000000001, timestamp: 2.00000000)",
+			"[0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1]",
+			"[0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0]",
 		},
 		loggedMessages,
 	)
 }
 
-func TestRuntimeTransactionTopLevelDeclarations(t *testing.T) {
+func TestRuntimeBlockIDs(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("transaction with function", func(t *testing.T) {
-		runtime := newTestInterpreterRuntime()
+	runtime := newTestInterpreterRuntime()
 
-		script := []byte(`
-          pub fun test() {}
+	executeScript := func(code string, log func(string)) error {
+		runtimeInterface := &testRuntimeInterface{
+			log: log,
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		return err
+	}
+
+	t.Run("valid range", func(t *testing.T) {
+
+		t.Parallel()
+
+		var loggedMessages []string
+
+		err := executeScript(
+			`
+              pub fun main() {
+                  let ids = getBlockIDs(from: 0, to: 1)
+                  log(ids.length)
+                  log(ids[1])
+                  log(getCurrentBlock().id)
+              }
+            `,
+			func(message string) {
+				loggedMessages = append(loggedMessages, message)
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]string{
+				"2",
+				"[0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1]",
+				"[0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1]",
+			},
+			loggedMessages,
+		)
+	})
+
+	t.Run("start after end", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeScript(
+			`
+              pub fun main() {
+                  getBlockIDs(from: 1, to: 0)
+              }
+            `,
+			nil,
+		)
+		require.Error(t, err)
+
+		var rangeErr *InvalidBlockRangeError
+		require.ErrorAs(t, err, &rangeErr)
+	})
+
+	t.Run("end after current block", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeScript(
+			`
+              pub fun main() {
+                  getBlockIDs(from: 1, to: 2)
+              }
+            `,
+			nil,
+		)
+		require.Error(t, err)
+
+		var rangeErr *InvalidBlockRangeError
+		require.ErrorAs(t, err, &rangeErr)
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := executeScript(
+			`
+              pub fun main() {
+                  getBlockIDs(from: 0, to: 256)
+              }
+            `,
+			nil,
+		)
+		require.Error(t, err)
+
+		var limitErr *BlockRangeLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+	})
+}
+
+func TestRuntimeRevertibleRandom(t *testing.T) {
+
+	t.Parallel()
+
+	// `unsafeRandom` is a deprecated alias for `revertibleRandom`
+
+	for _, name := range []string{"revertibleRandom", "unsafeRandom"} {
+
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			runtime := newTestInterpreterRuntime()
+
+			script := []byte(fmt.Sprintf(
+				`
+                  transaction {
+                    prepare() {
+                      let rand = %s()
+                      log(rand)
+                    }
+                  }
+                `,
+				name,
+			))
+
+			var loggedMessages []string
+
+			runtimeInterface := &testRuntimeInterface{
+				unsafeRandom: func() (uint64, error) {
+					return 7558174677681708339, nil
+				},
+				log: func(message string) {
+					loggedMessages = append(loggedMessages, message)
+				},
+			}
+
+			nextTransactionLocation := newTransactionLocationGenerator()
+
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: script,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t,
+				[]string{
+					"7558174677681708339",
+				},
+				loggedMessages,
+			)
+		})
+	}
+}
+
+func TestRuntimeLanguageVersion(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      transaction {
+        prepare() {
+          let remove = 1
+        }
+      }
+    `)
+
+	execute := func(version sema.LanguageVersion) error {
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface:       runtimeInterface,
+				Location:        nextTransactionLocation(),
+				LanguageVersion: version,
+			},
+		)
+	}
+
+	t.Run("legacy", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(sema.LanguageVersionLegacy)
+		require.NoError(t, err)
+	})
+
+	t.Run("version 1", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(sema.LanguageVersion1)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		assert.IsType(t, &sema.ReservedIdentifierError{}, errs[0])
+	})
+
+	t.Run("cached imported program", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		importedScript := []byte(`
+          pub fun remove(): Int {
+              return 1
+          }
+        `)
+
+		script := []byte(`
+          import "imported"
+
+          pub fun main(): Int {
+              return remove()
+          }
+        `)
+
+		// The program of the imported location is cached by the host environment,
+		// across executions with different language versions
+
+		runtimeInterface := &testRuntimeInterface{
+			getCode: func(location Location) ([]byte, error) {
+				switch location {
+				case common.StringLocation("imported"):
+					return importedScript, nil
+				default:
+					return nil, fmt.Errorf("unknown import location: %s", location)
+				}
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		executeScript := func(version sema.LanguageVersion) (cadence.Value, error) {
+			return runtime.ExecuteScript(
+				Script{
+					Source: script,
+				},
+				Context{
+					Interface:       runtimeInterface,
+					Location:        nextTransactionLocation(),
+					LanguageVersion: version,
+				},
+			)
+		}
+
+		value, err := executeScript(sema.LanguageVersionLegacy)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(1), value)
+
+		// The cached program was checked with the legacy language version,
+		// so it is checked again
+
+		_, err = executeScript(sema.LanguageVersion1)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 2)
+
+		var importedErr *sema.ImportedProgramError
+		require.ErrorAs(t, errs[0], &importedErr)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[1])
+
+		importedErrs := checker.ExpectCheckerErrors(t, importedErr.Err, 1)
+
+		assert.IsType(t, &sema.ReservedIdentifierError{}, importedErrs[0])
+	})
+}
+
+func TestRuntimeReadOnlyScript(t *testing.T) {
+
+	t.Parallel()
+
+	importedScript := []byte(`
+      pub fun write() {
+          getAuthAccount(0x1).save(1, to: /storage/answer)
+      }
+    `)
+
+	execute := func(script string) (cadence.Value, error) {
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getCode: func(location Location) (bytes []byte, err error) {
+				switch location {
+				case common.StringLocation("imported"):
+					return importedScript, nil
+				default:
+					return nil, fmt.Errorf("unknown import location: %s", location)
+				}
+			},
+		}
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+				ReadOnly:  true,
+			},
+		)
+	}
+
+	t.Run("read", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := execute(`
+          pub fun main(): Bool {
+              let account = getAuthAccount(0x1)
+              return account.borrow<&Int>(from: /storage/answer) == nil
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewBool(true), result)
+	})
+
+	t.Run("write", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(`
+          pub fun main() {
+              getAuthAccount(0x1).save(1, to: /storage/answer)
+          }
+        `)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		require.IsType(t, &sema.ReadOnlyViolationError{}, errs[0])
+		assert.Equal(t, "save", errs[0].(*sema.ReadOnlyViolationError).Name)
+	})
+
+	t.Run("write in imported program", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(`
+          import "imported"
+
+          pub fun main() {
+              write()
+          }
+        `)
+		require.Error(t, err)
+
+		var violationErr interpreter.ReadOnlyViolationError
+		require.ErrorAs(t, err, &violationErr)
+
+		assert.Equal(t, "save to storage", violationErr.Operation)
+	})
+}
+
+func TestRuntimeReadOnlyScriptStoredValues(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	contract := []byte(`
+      pub contract Test {
+
+          pub var n: Int
+          pub let values: [Int]
+          pub let dict: {Int: Int}
+
+          init() {
+              self.n = 0
+              self.values = [1]
+              self.dict = {}
+          }
+
+          pub fun increment() {
+              self.n = self.n + 1
+          }
+
+          pub fun append() {
+              self.values.append(1)
+          }
+
+          pub fun removeLast() {
+              self.values.removeLast()
+          }
+
+          pub fun insert() {
+              self.dict[1] = 1
+          }
+
+          pub fun sum(): Int {
+              let values = self.values
+              values.append(self.n)
+              var sum = 0
+              for value in values {
+                  sum = sum + value
+              }
+              return sum
+          }
+      }
+    `)
+
+	deploy := utils.DeploymentTransaction("Test", contract)
+
+	var accountCode []byte
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getCode: func(_ Location) (bytes []byte, err error) {
+			return accountCode, nil
+		},
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: deploy,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	execute := func(function string) (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      import Test from 0x1
+
+                      pub fun main(): AnyStruct {
+                          return Test.%s()
+                      }
+                    `,
+					function,
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+				ReadOnly:  true,
+			},
+		)
+	}
+
+	t.Run("read", func(t *testing.T) {
+
+		// Values which are not stored can be modified
+
+		result, err := execute("sum")
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(1), result)
+	})
+
+	test := func(function string, operation string) {
+
+		t.Run(function, func(t *testing.T) {
+
+			_, err := execute(function)
+			require.Error(t, err)
+
+			var violationErr interpreter.ReadOnlyViolationError
+			require.ErrorAs(t, err, &violationErr)
+
+			assert.Equal(t, operation, violationErr.Operation)
+		})
+	}
+
+	test("increment", "set field")
+	test("append", "append to array")
+	test("removeLast", "remove from array")
+	test("insert", "insert into dictionary")
+}
+
+func TestRuntimeTransactionTopLevelDeclarations(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("transaction with function", func(t *testing.T) {
+		runtime := newTestInterpreterRuntime()
+
+		script := []byte(`
+          pub fun test() {}
 
           transaction {}
         `)
@@ -5256,6 +6176,198 @@ func TestRuntimeComputationLimit(t *testing.T) {
 	}
 }
 
+type testComputationMeterRuntimeInterface struct {
+	*testRuntimeInterface
+	meterComputation func(kind common.ComputationKind, intensity uint) error
+}
+
+var _ ComputationMeterInterface = &testComputationMeterRuntimeInterface{}
+
+func (i *testComputationMeterRuntimeInterface) MeterComputation(kind common.ComputationKind, intensity uint) error {
+	return i.meterComputation(kind, intensity)
+}
+
+func TestRuntimeComputationMeter(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun main(): Int {
+          var sum = 0
+          for value in [1, 2, 3] {
+              sum = sum + value
+          }
+          return sum
+      }
+    `)
+
+	t.Run("metered", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		intensities := map[common.ComputationKind]uint{}
+
+		runtimeInterface := &testComputationMeterRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+			},
+			meterComputation: func(kind common.ComputationKind, intensity uint) error {
+				intensities[kind] += intensity
+				return nil
+			},
+		}
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(6), value)
+
+		assert.Equal(t, uint(3), intensities[common.ComputationKindLoopIteration])
+		assert.Equal(t, uint(6), intensities[common.ComputationKindStatement])
+		assert.Equal(t, uint(4), intensities[common.ComputationKindValueAllocation])
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		meterErr := errors.New("computation fee exceeds balance")
+
+		runtimeInterface := &testComputationMeterRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+			},
+			meterComputation: func(kind common.ComputationKind, _ uint) error {
+				if kind == common.ComputationKindLoopIteration {
+					return meterErr
+				}
+				return nil
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.ErrorIs(t, err, meterErr)
+	})
+}
+
+type testMemoryRuntimeInterface struct {
+	*testRuntimeInterface
+	memoryLimit uint64
+	meterMemory func(usage common.MemoryUsage) error
+}
+
+var _ MemoryGauge = &testMemoryRuntimeInterface{}
+var _ MemoryLimitInterface = &testMemoryRuntimeInterface{}
+
+func (i *testMemoryRuntimeInterface) MeterMemory(usage common.MemoryUsage) error {
+	return i.meterMemory(usage)
+}
+
+func (i *testMemoryRuntimeInterface) GetMemoryLimit() uint64 {
+	return i.memoryLimit
+}
+
+func TestRuntimeMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun main(): Int {
+          var s = "abcdefghij"
+          var i = 0
+          while i < 10 {
+              s = s.concat(s)
+              i = i + 1
+          }
+          return s.length
+      }
+    `)
+
+	execute := func(runtimeInterface Interface) (cadence.Value, error) {
+		runtime := newTestInterpreterRuntime()
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	t.Run("metered", func(t *testing.T) {
+
+		t.Parallel()
+
+		var stringMemory uint64
+
+		value, err := execute(&testMemoryRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+			},
+			meterMemory: func(usage common.MemoryUsage) error {
+				if usage.Kind == common.MemoryKindString {
+					stringMemory += usage.Amount
+				}
+				return nil
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(10240), value)
+		assert.GreaterOrEqual(t, stringMemory, uint64(10240))
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		const memoryLimit = 5000
+
+		_, err := execute(&testMemoryRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+			},
+			memoryLimit: memoryLimit,
+			meterMemory: func(_ common.MemoryUsage) error {
+				return nil
+			},
+		})
+
+		var memoryLimitErr MemoryLimitExceededError
+		require.ErrorAs(t, err, &memoryLimitErr)
+
+		assert.Equal(t,
+			MemoryLimitExceededError{
+				Limit: memoryLimit,
+			},
+			memoryLimitErr,
+		)
+	})
+}
+
 func TestRuntimeMetrics(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,314 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type testScheduledCallbackRuntimeInterface struct {
+	*testRuntimeInterface
+	currentBlockHeight uint64
+	scheduleCallback   func(callback ScheduledCallback) (uint64, error)
+}
+
+var _ ScheduledCallbackInterface = &testScheduledCallbackRuntimeInterface{}
+
+func (i *testScheduledCallbackRuntimeInterface) GetCurrentBlockHeight() (uint64, error) {
+	return i.currentBlockHeight, nil
+}
+
+func (i *testScheduledCallbackRuntimeInterface) ScheduleCallback(callback ScheduledCallback) (uint64, error) {
+	return i.scheduleCallback(callback)
+}
+
+func TestRuntimeScheduledCallback(t *testing.T) {
+
+	t.Parallel()
+
+	address := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	contract := []byte(`
+      pub contract Test {
+
+          pub resource interface Handler {
+              pub fun handle(_ id: UInt64)
+          }
+
+          pub resource Counter: Handler {
+
+              pub var handled: [UInt64]
+
+              init() {
+                  self.handled = []
+              }
+
+              pub fun handle(_ id: UInt64) {
+                  self.handled.append(id)
+                  log(self.handled)
+              }
+
+              pub fun reset() {
+                  self.handled = []
+              }
+          }
+
+          init() {
+              self.account.save(<-create Counter(), to: /storage/counter)
+              self.account.link<&Counter{Handler}>(/public/handler, target: /storage/counter)
+          }
+      }
+    `)
+
+	scheduleTransaction := func(functionName string, blockHeight uint64) []byte {
+		return []byte(fmt.Sprintf(
+			`
+              import Test from 0x01
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      let handler = signer.getCapability<&Test.Counter{Test.Handler}>(/public/handler)
+                      let id = scheduleCallback(
+                          handler: handler,
+                          functionName: %q,
+                          blockHeight: %d,
+                          fee: 0.5
+                      )
+                      log(id)
+                  }
+              }
+            `,
+			functionName,
+			blockHeight,
+		))
+	}
+
+	type testEnvironment struct {
+		runtime                 Runtime
+		runtimeInterface        *testScheduledCallbackRuntimeInterface
+		scheduledCallbacks      []ScheduledCallback
+		loggedMessages          []string
+		nextTransactionLocation func() common.TransactionLocation
+	}
+
+	newTestEnvironment := func(t *testing.T) *testEnvironment {
+
+		env := &testEnvironment{
+			runtime:                 newTestInterpreterRuntime(),
+			nextTransactionLocation: newTransactionLocationGenerator(),
+		}
+
+		var accountCode []byte
+
+		env.runtimeInterface = &testScheduledCallbackRuntimeInterface{
+			testRuntimeInterface: &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				getSigningAccounts: func() ([]Address, error) {
+					return []Address{address}, nil
+				},
+				resolveLocation: singleIdentifierLocationResolver(t),
+				getAccountContractCode: func(_ Address, _ string) ([]byte, error) {
+					return accountCode, nil
+				},
+				updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+					accountCode = code
+					return nil
+				},
+				emitEvent: func(event cadence.Event) error {
+					return nil
+				},
+				log: func(message string) {
+					env.loggedMessages = append(env.loggedMessages, message)
+				},
+			},
+			currentBlockHeight: 1,
+			scheduleCallback: func(callback ScheduledCallback) (uint64, error) {
+				env.scheduledCallbacks = append(env.scheduledCallbacks, callback)
+				return uint64(len(env.scheduledCallbacks)), nil
+			},
+		}
+
+		err := env.runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("Test", contract),
+			},
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		return env
+	}
+
+	t.Run("schedule and execute", func(t *testing.T) {
+
+		t.Parallel()
+
+		env := newTestEnvironment(t)
+
+		err := env.runtime.ExecuteTransaction(
+			Script{
+				Source: scheduleTransaction("handle", 10),
+			},
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		require.Len(t, env.scheduledCallbacks, 1)
+
+		callback := env.scheduledCallbacks[0]
+		assert.Equal(t, "handle", callback.FunctionName)
+		assert.Equal(t, uint64(10), callback.BlockHeight)
+		assert.Equal(t, uint64(50_000_000), callback.Fee)
+		assert.Equal(t, cadence.Address(address), callback.Handler.Address)
+		assert.Equal(t,
+			cadence.Path{
+				Domain:     "public",
+				Identifier: "handler",
+			},
+			callback.Handler.Path,
+		)
+		assert.Equal(t, []string{"1"}, env.loggedMessages)
+
+		callback.ID = 1
+
+		// Not due yet
+
+		env.runtimeInterface.currentBlockHeight = 9
+
+		err = env.runtime.ExecuteScheduledCallback(
+			callback,
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &ScheduledCallbackNotDueError{})
+
+		// Due
+
+		env.runtimeInterface.currentBlockHeight = 10
+
+		err = env.runtime.ExecuteScheduledCallback(
+			callback,
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		// Changes to the handler are written back
+
+		env.runtimeInterface.currentBlockHeight = 11
+
+		err = env.runtime.ExecuteScheduledCallback(
+			callback,
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"1", "[1]", "[1, 1]"}, env.loggedMessages)
+	})
+
+	t.Run("function not granted by capability", func(t *testing.T) {
+
+		t.Parallel()
+
+		env := newTestEnvironment(t)
+
+		err := env.runtime.ExecuteTransaction(
+			Script{
+				Source: scheduleTransaction("reset", 10),
+			},
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var handlerErr *InvalidScheduledCallbackHandlerError
+		require.ErrorAs(t, err, &handlerErr)
+
+		assert.Empty(t, env.scheduledCallbacks)
+	})
+
+	t.Run("past block height", func(t *testing.T) {
+
+		t.Parallel()
+
+		env := newTestEnvironment(t)
+
+		err := env.runtime.ExecuteTransaction(
+			Script{
+				Source: scheduleTransaction("handle", 1),
+			},
+			Context{
+				Interface: env.runtimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var blockHeightErr *InvalidScheduledCallbackBlockHeightError
+		require.ErrorAs(t, err, &blockHeightErr)
+
+		assert.Empty(t, env.scheduledCallbacks)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+
+		t.Parallel()
+
+		env := newTestEnvironment(t)
+
+		err := env.runtime.ExecuteTransaction(
+			Script{
+				Source: scheduleTransaction("handle", 10),
+			},
+			Context{
+				Interface: env.runtimeInterface.testRuntimeInterface,
+				Location:  env.nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var notSupportedErr *ScheduledCallbacksNotSupportedError
+		require.ErrorAs(t, err, &notSupportedErr)
+	})
+}
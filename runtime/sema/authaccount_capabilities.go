@@ -0,0 +1,326 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const AccountCapabilitiesTypeName = "Capabilities"
+const AccountCapabilitiesTypeGetFunctionName = "get"
+const AccountCapabilitiesTypeBorrowFunctionName = "borrow"
+const AuthAccountCapabilitiesTypeStorageField = "storage"
+const AuthAccountCapabilitiesTypePublishFunctionName = "publish"
+const AuthAccountCapabilitiesTypeUnpublishFunctionName = "unpublish"
+
+// AuthAccountCapabilitiesType represents the type `AuthAccount.Capabilities`
+//
+var AuthAccountCapabilitiesType = func() *CompositeType {
+
+	authAccountCapabilitiesType := &CompositeType{
+		Identifier: AccountCapabilitiesTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewPublicConstantFieldMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeStorageField,
+			AuthAccountStorageCapabilitiesType,
+			authAccountCapabilitiesTypeStorageFieldDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AccountCapabilitiesTypeGetFunctionName,
+			AccountCapabilitiesTypeGetFunctionType,
+			accountCapabilitiesTypeGetFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AccountCapabilitiesTypeBorrowFunctionName,
+			AccountCapabilitiesTypeBorrowFunctionType,
+			accountCapabilitiesTypeBorrowFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypePublishFunctionName,
+			AuthAccountCapabilitiesTypePublishFunctionType,
+			authAccountCapabilitiesTypePublishFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountCapabilitiesType,
+			AuthAccountCapabilitiesTypeUnpublishFunctionName,
+			AuthAccountCapabilitiesTypeUnpublishFunctionType,
+			authAccountCapabilitiesTypeUnpublishFunctionDocString,
+		),
+	}
+
+	authAccountCapabilitiesType.Members = GetMembersAsMap(members)
+	authAccountCapabilitiesType.Fields = getFieldNames(members)
+	return authAccountCapabilitiesType
+}()
+
+const AuthAccountStorageCapabilitiesTypeName = "StorageCapabilities"
+const AuthAccountStorageCapabilitiesTypeIssueFunctionName = "issue"
+const AuthAccountStorageCapabilitiesTypeGetControllerFunctionName = "getController"
+const AuthAccountStorageCapabilitiesTypeGetControllersFunctionName = "getControllers"
+
+// AuthAccountStorageCapabilitiesType represents the type `AuthAccount.StorageCapabilities`
+//
+var AuthAccountStorageCapabilitiesType = func() *CompositeType {
+
+	authAccountStorageCapabilitiesType := &CompositeType{
+		Identifier: AuthAccountStorageCapabilitiesTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewPublicFunctionMember(
+			authAccountStorageCapabilitiesType,
+			AuthAccountStorageCapabilitiesTypeIssueFunctionName,
+			AuthAccountStorageCapabilitiesTypeIssueFunctionType,
+			authAccountStorageCapabilitiesTypeIssueFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountStorageCapabilitiesType,
+			AuthAccountStorageCapabilitiesTypeGetControllerFunctionName,
+			AuthAccountStorageCapabilitiesTypeGetControllerFunctionType,
+			authAccountStorageCapabilitiesTypeGetControllerFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountStorageCapabilitiesType,
+			AuthAccountStorageCapabilitiesTypeGetControllersFunctionName,
+			AuthAccountStorageCapabilitiesTypeGetControllersFunctionType,
+			authAccountStorageCapabilitiesTypeGetControllersFunctionDocString,
+		),
+	}
+
+	authAccountStorageCapabilitiesType.Members = GetMembersAsMap(members)
+	authAccountStorageCapabilitiesType.Fields = getFieldNames(members)
+	return authAccountStorageCapabilitiesType
+}()
+
+func init() {
+	// Set the container types after initializing the capabilities types, to avoid initializing loop.
+	AuthAccountCapabilitiesType.SetContainerType(AuthAccountType)
+	AuthAccountStorageCapabilitiesType.SetContainerType(AuthAccountType)
+}
+
+const authAccountCapabilitiesTypeStorageFieldDocString = `
+The storage capabilities of the account
+`
+
+var AccountCapabilitiesTypeGetFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "path",
+				TypeAnnotation: NewTypeAnnotation(PublicPathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &CapabilityType{
+					BorrowType: &GenericType{
+						TypeParameter: typeParameter,
+					},
+				},
+			},
+		),
+	}
+}()
+
+const accountCapabilitiesTypeGetFunctionDocString = `
+Returns the capability published at the given public path,
+or nil if no capability is published at the path,
+or if the capability cannot be borrowed with the given type
+`
+
+var AccountCapabilitiesTypeBorrowFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "path",
+				TypeAnnotation: NewTypeAnnotation(PublicPathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &GenericType{
+					TypeParameter: typeParameter,
+				},
+			},
+		),
+	}
+}()
+
+const accountCapabilitiesTypeBorrowFunctionDocString = `
+Borrows the capability published at the given public path.
+
+Returns nil if no capability is published at the path,
+or if the capability cannot be borrowed with the given type.
+This is a shorthand for getting the capability and borrowing it
+`
+
+var AuthAccountCapabilitiesTypePublishFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "capability",
+			TypeAnnotation: NewTypeAnnotation(&CapabilityType{}),
+		},
+		{
+			Label:          "at",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(PublicPathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
+
+const authAccountCapabilitiesTypePublishFunctionDocString = `
+Publishes the given capability at the given public path.
+
+The capability must have been issued by this account.
+If there is already a value stored under the given path, the program aborts
+`
+
+var AuthAccountCapabilitiesTypeUnpublishFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(PublicPathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: &CapabilityType{},
+		},
+	),
+}
+
+const authAccountCapabilitiesTypeUnpublishFunctionDocString = `
+Unpublishes the capability published at the given public path.
+
+Returns the capability if one was published at the path, or nil otherwise
+`
+
+var AuthAccountStorageCapabilitiesTypeIssueFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		TypeBound: &ReferenceType{
+			Type: AnyType,
+		},
+		Name: "T",
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "path",
+				TypeAnnotation: NewTypeAnnotation(StoragePathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&CapabilityType{
+				BorrowType: &GenericType{
+					TypeParameter: typeParameter,
+				},
+			},
+		),
+	}
+}()
+
+const authAccountStorageCapabilitiesTypeIssueFunctionDocString = `
+Issues a new capability for the given storage path, with a new capability controller.
+
+The given type defines how the capability can be borrowed.
+Like links, the target path is not required to store a value when the capability is issued
+`
+
+var AuthAccountStorageCapabilitiesTypeGetControllerFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          "byCapabilityID",
+			Identifier:     "capabilityID",
+			TypeAnnotation: NewTypeAnnotation(UInt64Type),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: StorageCapabilityControllerReferenceType,
+		},
+	),
+}
+
+const authAccountStorageCapabilitiesTypeGetControllerFunctionDocString = `
+Returns the storage capability controller for the capability with the given ID,
+or nil if there is no such controller, e.g. because the capability was revoked
+`
+
+var AuthAccountStorageCapabilitiesTypeGetControllersFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          "forPath",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(StoragePathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: StorageCapabilityControllerReferenceType,
+		},
+	),
+}
+
+const authAccountStorageCapabilitiesTypeGetControllersFunctionDocString = `
+Returns the storage capability controllers of all capabilities which target the given storage path,
+ordered by capability ID
+`
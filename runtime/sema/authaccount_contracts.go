@@ -24,6 +24,7 @@ import (
 
 const AuthAccountContractsTypeName = "Contracts"
 const AuthAccountContractsTypeAddFunctionName = "add"
+const AuthAccountContractsTypeAddFrozenFunctionName = "addFrozen"
 const AuthAccountContractsTypeGetFunctionName = "get"
 const AuthAccountContractsTypeRemoveFunctionName = "remove"
 const AuthAccountContractsTypeUpdateExperimentalFunctionName = "update__experimental"
@@ -46,6 +47,12 @@ var AuthAccountContractsType = func() *CompositeType {
 			AuthAccountContractsTypeAddFunctionType,
 			authAccountContractsTypeAddFunctionDocString,
 		),
+		NewPublicFunctionMember(
+			authAccountContractsType,
+			AuthAccountContractsTypeAddFrozenFunctionName,
+			AuthAccountContractsTypeAddFrozenFunctionType,
+			authAccountContractsTypeAddFrozenFunctionDocString,
+		),
 		NewPublicFunctionMember(
 			authAccountContractsType,
 			AuthAccountContractsTypeUpdateExperimentalFunctionName,
@@ -123,6 +130,40 @@ var AuthAccountContractsTypeAddFunctionType = &FunctionType{
 	RequiredArgumentCount: RequiredArgumentCount(2),
 }
 
+const authAccountContractsTypeAddFrozenFunctionDocString = `
+Adds the given contract to the account, and marks it as frozen.
+
+A frozen contract/contract interface can never be updated or removed.
+The runtime records the flag when the contract is deployed,
+and rejects all subsequent calls to ` + "`update__experimental`" + ` and ` + "`remove`" + `.
+
+The parameters and failure conditions are the same as for ` + "`add`" + `.
+
+Returns the deployed contract.
+`
+
+var AuthAccountContractsTypeAddFrozenFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier: "name",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+		{
+			Identifier: "code",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		DeployedContractType,
+	),
+	// additional arguments are passed to the contract initializer
+	RequiredArgumentCount: RequiredArgumentCount(2),
+}
+
 const authAccountContractsTypeUpdateExperimentalFunctionDocString = `
 **Experimental**
 
@@ -37,10 +37,12 @@ const AuthAccountCopyField = "copy"
 const AuthAccountBorrowField = "borrow"
 const AuthAccountLinkField = "link"
 const AuthAccountUnlinkField = "unlink"
+const AuthAccountLinkAccountField = "linkAccount"
 const AuthAccountGetCapabilityField = "getCapability"
 const AuthAccountGetLinkTargetField = "getLinkTarget"
 const AuthAccountContractsField = "contracts"
 const AuthAccountKeysField = "keys"
+const AuthAccountCapabilitiesField = "capabilities"
 
 // AuthAccountType represents the authorized access to an account.
 // Access to an AuthAccount means having full access to its storage, public keys, and code.
@@ -58,10 +60,14 @@ var AuthAccountType = func() *CompositeType {
 			nestedTypes := NewStringTypeOrderedMap()
 			nestedTypes.Set(AuthAccountContractsTypeName, AuthAccountContractsType)
 			nestedTypes.Set(AccountKeysTypeName, AuthAccountKeysType)
+			nestedTypes.Set(AccountCapabilitiesTypeName, AuthAccountCapabilitiesType)
+			nestedTypes.Set(AuthAccountStorageCapabilitiesTypeName, AuthAccountStorageCapabilitiesType)
 			return nestedTypes
 		}(),
 	}
 
+	AuthAccountTypeLinkAccountFunctionType = newAuthAccountTypeLinkAccountFunctionType(authAccountType)
+
 	var members = []*Member{
 		NewPublicConstantFieldMember(
 			authAccountType,
@@ -147,6 +153,12 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountTypeUnlinkFunctionType,
 			authAccountTypeUnlinkFunctionDocString,
 		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountLinkAccountField,
+			AuthAccountTypeLinkAccountFunctionType,
+			authAccountTypeLinkAccountFunctionDocString,
+		),
 		NewPublicFunctionMember(
 			authAccountType,
 			AuthAccountGetCapabilityField,
@@ -171,6 +183,12 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountKeysType,
 			accountTypeKeysFieldDocString,
 		),
+		NewPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountCapabilitiesField,
+			AuthAccountCapabilitiesType,
+			accountTypeCapabilitiesFieldDocString,
+		),
 	}
 
 	authAccountType.Members = GetMembersAsMap(members)
@@ -456,6 +474,43 @@ The link function does **not** check if the target path is valid/exists at the t
 The link is latent. The target value might be stored after the link is created, and the target value might be moved out after the link has been created.
 `
 
+// AuthAccountTypeLinkAccountFunctionType is the type of the function `AuthAccount.linkAccount`.
+// It is initialized together with AuthAccountType, as it refers to it.
+//
+var AuthAccountTypeLinkAccountFunctionType *FunctionType
+
+func newAuthAccountTypeLinkAccountFunctionType(authAccountType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "newCapabilityPath",
+				TypeAnnotation: NewTypeAnnotation(PrivatePathType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &CapabilityType{
+					BorrowType: &ReferenceType{
+						Type: authAccountType,
+					},
+				},
+			},
+		),
+	}
+}
+
+const authAccountTypeLinkAccountFunctionDocString = `
+Creates a capability at the given private path which targets this account.
+The capability can be borrowed as a reference to this account, i.e. as ` + "`&AuthAccount`" + `,
+which gives full access to the account.
+
+Returns nil if a link for the given capability path already exists, or the newly created capability if not.
+
+Account linking must be explicitly allowed by the program which links the account,
+using the ` + "`#allowAccountLinking`" + ` pragma.
+`
+
 var AuthAccountTypeUnlinkFunctionType = &FunctionType{
 	Parameters: []*Parameter{
 		{
@@ -639,6 +694,10 @@ const accountTypeKeysFieldDocString = `
 The keys associated with the account
 `
 
+const accountTypeCapabilitiesFieldDocString = `
+The capabilities of the account
+`
+
 const authAccountKeysTypeAddFunctionDocString = `
 Adds the given key to the keys list of the account.
 `
@@ -66,13 +66,19 @@ func (e *BeforeExtractor) ExtractInvocation(
 			// create a fresh identifier which has the rewritten argument
 			// as its initial value
 
-			newIdentifier := ast.Identifier{
-				Identifier: extractor.FreshIdentifier(),
-			}
 			newExpression := &ast.IdentifierExpression{
-				Identifier: newIdentifier,
+				Identifier: ast.Identifier{
+					Identifier: extractor.FreshIdentifier(),
+				},
 			}
 
+			// the new identifier is synthesized,
+			// so let it point to the invocation of `before`
+
+			ast.Transplant(newExpression, ast.NewRangeFromPositioned(expression))
+
+			newIdentifier := newExpression.Identifier
+
 			extractedExpressions = append(extractedExpressions,
 				ast.ExtractedExpression{
 					Identifier: newIdentifier,
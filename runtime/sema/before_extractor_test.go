@@ -40,11 +40,15 @@ func TestBeforeExtractor(t *testing.T) {
 
 	extractor := NewBeforeExtractor(nil)
 
+	// The synthesized identifiers point to the invocations of `before`
+
 	identifier1 := ast.Identifier{
 		Identifier: extractor.ExpressionExtractor.FormatIdentifier(0),
+		Pos:        ast.Position{Offset: 20, Line: 2, Column: 19},
 	}
 	identifier2 := ast.Identifier{
 		Identifier: extractor.ExpressionExtractor.FormatIdentifier(1),
+		Pos:        ast.Position{Offset: 9, Line: 2, Column: 8},
 	}
 
 	result := extractor.ExtractBefore(expression)
@@ -0,0 +1,132 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// BlockHeaderType represents the type `BlockHeader`
+//
+var BlockHeaderType = &SimpleType{
+	Name:                 "BlockHeader",
+	QualifiedName:        "BlockHeader",
+	TypeID:               "BlockHeader",
+	tag:                  BlockHeaderTypeTag,
+	IsInvalid:            false,
+	IsResource:           false,
+	Storable:             false,
+	Equatable:            false,
+	ExternallyReturnable: false,
+	Importable:           false,
+	Members: func(t *SimpleType) map[string]MemberResolver {
+		return map[string]MemberResolver{
+			BlockHeaderTypeHeightFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UInt64Type,
+						blockHeaderTypeHeightFieldDocString,
+					)
+				},
+			},
+			BlockHeaderTypeViewFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UInt64Type,
+						blockHeaderTypeViewFieldDocString,
+					)
+				},
+			},
+			BlockHeaderTypeTimestampFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UFix64Type,
+						blockHeaderTypeTimestampFieldDocString,
+					)
+				},
+			},
+			BlockHeaderTypeIDFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						blockIDFieldType,
+						blockHeaderTypeIDFieldDocString,
+					)
+				},
+			},
+			BlockHeaderTypeParentIDFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						blockIDFieldType,
+						blockHeaderTypeParentIDFieldDocString,
+					)
+				},
+			},
+		}
+	},
+}
+
+const BlockHeaderTypeHeightFieldName = BlockTypeHeightFieldName
+
+const blockHeaderTypeHeightFieldDocString = `
+The height of the block
+`
+
+const BlockHeaderTypeViewFieldName = BlockTypeViewFieldName
+
+const blockHeaderTypeViewFieldDocString = `
+The view of the block
+`
+
+const BlockHeaderTypeTimestampFieldName = BlockTypeTimestampFieldName
+
+const blockHeaderTypeTimestampFieldDocString = `
+The timestamp of the block, as claimed by the proposer of the block
+`
+
+const BlockHeaderTypeIDFieldName = BlockTypeIDFieldName
+
+const blockHeaderTypeIDFieldDocString = `
+The ID of the block.
+
+It is essentially the hash of the block
+`
+
+const BlockHeaderTypeParentIDFieldName = "parentID"
+
+const blockHeaderTypeParentIDFieldDocString = `
+The ID of the parent block, i.e. the block at the previous height.
+
+Together with the IDs of other blocks, it can be used to verify that blocks are part of the chain
+`
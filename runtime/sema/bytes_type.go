@@ -0,0 +1,189 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// BytesType represents the type of immutable sequences of bytes.
+//
+// Unlike byte arrays (`[UInt8]`), the bytes are stored contiguously,
+// instead of as individual elements.
+//
+var BytesType = &SimpleType{
+	Name:                 "Bytes",
+	QualifiedName:        "Bytes",
+	TypeID:               "Bytes",
+	tag:                  BytesTypeTag,
+	IsInvalid:            false,
+	IsResource:           false,
+	Storable:             true,
+	Equatable:            true,
+	ExternallyReturnable: true,
+	Importable:           true,
+	ValueIndexingInfo: ValueIndexingInfo{
+		IsValueIndexableType:          true,
+		AllowsValueIndexingAssignment: false,
+		ElementType: func(_ bool) Type {
+			return UInt8Type
+		},
+		IndexingType: IntegerType,
+	},
+}
+
+func init() {
+	BytesType.Members = func(t *SimpleType) map[string]MemberResolver {
+		return map[string]MemberResolver{
+			"length": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						IntType,
+						bytesTypeLengthFieldDocString,
+					)
+				},
+			},
+			"concat": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						BytesTypeConcatFunctionType,
+						bytesTypeConcatFunctionDocString,
+					)
+				},
+			},
+			"slice": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						BytesTypeSliceFunctionType,
+						bytesTypeSliceFunctionDocString,
+					)
+				},
+			},
+			"toArray": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						BytesTypeToArrayFunctionType,
+						bytesTypeToArrayFunctionDocString,
+					)
+				},
+			},
+		}
+	}
+}
+
+const bytesTypeLengthFieldDocString = `
+The number of bytes
+`
+
+var BytesTypeConcatFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "other",
+			TypeAnnotation: NewTypeAnnotation(BytesType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BytesType,
+	),
+}
+
+const bytesTypeConcatFunctionDocString = `
+Returns new bytes which contain the given bytes appended to the end of the original bytes, but does not modify the original bytes
+`
+
+var BytesTypeSliceFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "from",
+			TypeAnnotation: NewTypeAnnotation(IntType),
+		},
+		{
+			Identifier:     "upTo",
+			TypeAnnotation: NewTypeAnnotation(IntType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BytesType,
+	),
+}
+
+const bytesTypeSliceFunctionDocString = `
+Returns the slice of the bytes from start index ` + "`from`" + ` up to, but not including, the end index ` + "`upTo`" + `.
+
+The slice shares the bytes of the original bytes, so slicing does not copy any bytes.
+If either of the parameters are out of the bounds of the bytes, or the indices are invalid (` + "`from > upTo`" + `), then the function will fail
+`
+
+var BytesTypeToArrayFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(ByteArrayType),
+}
+
+const bytesTypeToArrayFunctionDocString = `
+Returns a new byte array containing the bytes
+`
+
+var BytesFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "bytes",
+			TypeAnnotation: NewTypeAnnotation(ByteArrayType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BytesType,
+	),
+}
+
+func init() {
+
+	// Declare a conversion function for the bytes type
+
+	typeName := BytesType.String()
+
+	// Check that the function is not accidentally redeclared
+
+	if BaseValueActivation.Find(typeName) != nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	BaseValueActivation.Set(
+		typeName,
+		baseFunctionVariable(
+			typeName,
+			BytesFunctionType,
+			"Creates bytes from the given byte array",
+		),
+	)
+}
@@ -18,6 +18,11 @@
 
 package sema
 
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
 // CharacterType represents the character type
 //
 var CharacterType = &SimpleType{
@@ -32,3 +37,55 @@ var CharacterType = &SimpleType{
 	ExternallyReturnable: true,
 	Importable:           true,
 }
+
+func init() {
+	CharacterType.Members = func(t *SimpleType) map[string]MemberResolver {
+		return map[string]MemberResolver{
+			"utf8": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						ByteArrayType,
+						characterTypeUtf8FieldDocString,
+					)
+				},
+			},
+			"utf16": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UTF16CodeUnitArrayType,
+						characterTypeUtf16FieldDocString,
+					)
+				},
+			},
+			"toString": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						ToStringFunctionType,
+						characterTypeToStringFunctionDocString,
+					)
+				},
+			},
+		}
+	}
+}
+
+const characterTypeUtf8FieldDocString = `
+The byte array of the UTF-8 encoding of the character
+`
+
+const characterTypeUtf16FieldDocString = `
+The array of the UTF-16 code units of the character
+`
+
+const characterTypeToStringFunctionDocString = `
+Returns the string which contains only the character
+`
@@ -0,0 +1,230 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func (checker *Checker) VisitAttachExpression(expression *ast.AttachExpression) ast.Repr {
+
+	attachmentType := checker.visitAttachmentConstruction(expression.Attachment)
+
+	if attachmentType == nil {
+		checker.VisitExpression(expression.Base, nil)
+		return InvalidType
+	}
+
+	checker.Elaboration.AttachExpressionTypes[expression] = attachmentType
+
+	// The attachment is attached to the base value, which is returned:
+	// if the base is a resource, it must be moved
+
+	baseType := checker.VisitExpression(expression.Base, attachmentType.BaseType)
+
+	checker.checkResourceMoveOperation(expression.Base, baseType)
+
+	return baseType
+}
+
+// visitAttachmentConstruction checks the invocation of the attachment constructor
+// of an attach expression, and returns the attachment type, if valid.
+//
+func (checker *Checker) visitAttachmentConstruction(invocation *ast.InvocationExpression) *CompositeType {
+	inAttach := checker.inAttach
+	checker.inAttach = true
+	defer func() {
+		checker.inAttach = inAttach
+	}()
+
+	ty := checker.VisitExpression(invocation, nil)
+
+	if ty.IsInvalidType() {
+		return nil
+	}
+
+	compositeType, ok := ty.(*CompositeType)
+	if !ok || compositeType.Kind != common.CompositeKindAttachment {
+		checker.report(
+			&NotAnAttachmentError{
+				Type:  ty,
+				Range: ast.NewRangeFromPositioned(invocation),
+			},
+		)
+
+		return nil
+	}
+
+	if compositeType.BaseType == nil || compositeType.BaseType.IsInvalidType() {
+		return nil
+	}
+
+	return compositeType
+}
+
+func (checker *Checker) VisitRemoveStatement(statement *ast.RemoveStatement) ast.Repr {
+
+	valueType := checker.VisitExpression(statement.Value, nil)
+
+	attachmentType := checker.convertAttachmentType(statement.Attachment)
+
+	if attachmentType == nil || valueType.IsInvalidType() {
+		return nil
+	}
+
+	checker.Elaboration.RemoveStatementAttachmentTypes[statement] = attachmentType
+
+	checker.checkAttachmentBaseType(
+		attachmentType,
+		valueType,
+		statement.Value,
+	)
+
+	return nil
+}
+
+// convertAttachmentType converts the given type, and reports an error
+// if it is not an attachment type.
+//
+func (checker *Checker) convertAttachmentType(t ast.Type) *CompositeType {
+	ty := checker.ConvertType(t)
+
+	if ty.IsInvalidType() {
+		return nil
+	}
+
+	compositeType, ok := ty.(*CompositeType)
+	if !ok || compositeType.Kind != common.CompositeKindAttachment {
+		checker.report(
+			&NotAnAttachmentError{
+				Type:  ty,
+				Range: ast.NewRangeFromPositioned(t),
+			},
+		)
+
+		return nil
+	}
+
+	if compositeType.BaseType == nil || compositeType.BaseType.IsInvalidType() {
+		return nil
+	}
+
+	return compositeType
+}
+
+// checkAttachmentBaseType checks that the given value type,
+// or the type it references, is the base type of the given attachment type.
+//
+func (checker *Checker) checkAttachmentBaseType(
+	attachmentType *CompositeType,
+	valueType Type,
+	valueExpression ast.Expression,
+) {
+	baseType := valueType
+	if referenceType, ok := baseType.(*ReferenceType); ok {
+		baseType = referenceType.Type
+	}
+
+	if baseType.Equal(attachmentType.BaseType) {
+		return
+	}
+
+	checker.report(
+		&InvalidAttachmentAccessError{
+			AttachmentType: attachmentType,
+			BaseType:       valueType,
+			Range:          ast.NewRangeFromPositioned(valueExpression),
+		},
+	)
+}
+
+// isAttachmentAccess returns true if the given index expression accesses an attachment,
+// i.e. the indexed value is a structure or resource, or a reference to one.
+// Such values are not indexable otherwise.
+//
+func isAttachmentAccess(targetType Type) bool {
+	if referenceType, ok := targetType.(*ReferenceType); ok {
+		targetType = referenceType.Type
+	}
+
+	compositeType, ok := targetType.(*CompositeType)
+	if !ok {
+		return false
+	}
+
+	switch compositeType.Kind {
+	case common.CompositeKindStructure,
+		common.CompositeKindResource:
+
+		return true
+	}
+
+	return false
+}
+
+// visitAttachmentAccess checks an index expression which accesses an attachment,
+// e.g. `r[A]`, and returns the type of the access, an optional reference to the attachment.
+//
+func (checker *Checker) visitAttachmentAccess(
+	indexExpression *ast.IndexExpression,
+	targetType Type,
+	isAssignment bool,
+) Type {
+
+	if isAssignment {
+		checker.report(
+			&NotIndexingAssignableTypeError{
+				Type:  targetType,
+				Range: ast.NewRangeFromPositioned(indexExpression.TargetExpression),
+			},
+		)
+	}
+
+	indexingType := ast.ExpressionAsType(indexExpression.IndexingExpression)
+	if indexingType == nil {
+		checker.report(
+			&NotIndexableTypeError{
+				Type:  targetType,
+				Range: ast.NewRangeFromPositioned(indexExpression.TargetExpression),
+			},
+		)
+
+		return InvalidType
+	}
+
+	attachmentType := checker.convertAttachmentType(indexingType)
+	if attachmentType == nil {
+		return InvalidType
+	}
+
+	checker.Elaboration.AttachmentAccessTypes[indexExpression] = attachmentType
+
+	checker.checkAttachmentBaseType(
+		attachmentType,
+		targetType,
+		indexExpression.TargetExpression,
+	)
+
+	return &OptionalType{
+		Type: &ReferenceType{
+			Type: attachmentType,
+		},
+	}
+}
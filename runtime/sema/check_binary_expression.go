@@ -122,12 +122,18 @@ func (checker *Checker) VisitBinaryExpression(expression *ast.BinaryExpression)
 			BinaryOperationKindNonEqualityComparison,
 			BinaryOperationKindBitwise:
 
-			return checker.checkBinaryExpressionArithmeticOrNonEqualityComparisonOrBitwise(
+			resultType := checker.checkBinaryExpressionArithmeticOrNonEqualityComparisonOrBitwise(
 				expression, operation, operationKind,
 				leftType, rightType,
 				leftIsInvalid, rightIsInvalid, anyInvalid,
 			)
 
+			if operationKind == BinaryOperationKindArithmetic {
+				checker.Elaboration.BinaryExpressionResultTypes[expression] = resultType
+			}
+
+			return resultType
+
 		case BinaryOperationKindEquality:
 			return checker.checkBinaryExpressionEquality(
 				expression, operation, operationKind,
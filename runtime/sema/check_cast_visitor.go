@@ -183,6 +183,10 @@ func (d *CheckCastVisitor) VisitDestroyExpression(_ *ast.DestroyExpression) ast.
 	return d.isTypeRedundant(d.exprInferredType, d.targetType)
 }
 
+func (d *CheckCastVisitor) VisitAttachExpression(_ *ast.AttachExpression) ast.Repr {
+	return d.isTypeRedundant(d.exprInferredType, d.targetType)
+}
+
 func (d *CheckCastVisitor) VisitReferenceExpression(_ *ast.ReferenceExpression) ast.Repr {
 	return d.isTypeRedundant(d.exprInferredType, d.targetType)
 }
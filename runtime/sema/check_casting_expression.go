@@ -152,7 +152,7 @@ func (checker *Checker) VisitCastingExpression(expression *ast.CastingExpression
 		}
 
 		if expression.Operation == ast.OperationFailableCast {
-			return &OptionalType{Type: rightHandType}
+			return NewOptionalType(rightHandType)
 		}
 
 		return rightHandType
@@ -213,6 +213,12 @@ func FailableCastCanSucceed(subType, superType Type) bool {
 				return false
 			}
 
+			// The holder of an entitled reference may not gain more entitlements
+
+			if !typedSubType.Authorization.PermitsAccess(typedSuperType.Authorization) {
+				return false
+			}
+
 			// A failable cast from an unauthorized reference type
 			// to an unauthorized reference type
 			// has the same semantics as a static/non-failable cast
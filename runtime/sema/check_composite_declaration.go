@@ -19,6 +19,8 @@
 package sema
 
 import (
+	"math/big"
+
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
@@ -136,7 +138,7 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 
 	checker.checkResourceFieldNesting(
 		compositeType.Members,
-		compositeType.Kind,
+		compositeType.effectiveKind(),
 		fieldPositionGetter,
 	)
 
@@ -197,6 +199,14 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 	for _, nestedComposite := range declaration.Members.Composites() {
 		nestedComposite.Accept(checker)
 	}
+
+	for _, nestedEntitlement := range declaration.Members.Entitlements() {
+		nestedEntitlement.Accept(checker)
+	}
+
+	for _, nestedEntitlementMapping := range declaration.Members.EntitlementMappings() {
+		nestedEntitlementMapping.Accept(checker)
+	}
 }
 
 // declareCompositeNestedTypes declares the types nested in a composite,
@@ -345,7 +355,8 @@ func (checker *Checker) declareNestedDeclarations(
 			case common.CompositeKindResource,
 				common.CompositeKindStructure,
 				common.CompositeKindEvent,
-				common.CompositeKindEnum:
+				common.CompositeKindEnum,
+				common.CompositeKindAttachment:
 				break
 
 			default:
@@ -424,6 +435,8 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		Members:     NewStringMemberOrderedMap(),
 	}
 
+	checker.checkDeclarationIdentifier(identifier)
+
 	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
 		identifier:               identifier,
 		ty:                       compositeType,
@@ -485,6 +498,14 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		nestedCompositeType.SetContainerType(compositeType)
 	}
 
+	checker.declareNestedEntitlementDeclarations(
+		compositeType,
+		declaration.CompositeKind,
+		declaration.DeclarationKind(),
+		declaration.Members,
+		nestedDeclarations,
+	)
+
 	return compositeType
 }
 
@@ -504,6 +525,14 @@ func (checker *Checker) declareCompositeMembersAndValue(
 		panic(errors.NewUnreachableError())
 	}
 
+	// Resolve the base type of attachments.
+	// NOTE: perform after all composite types are declared,
+	// as the base type may be declared after the attachment
+
+	if declaration.CompositeKind == common.CompositeKindAttachment {
+		compositeType.BaseType = checker.attachmentBaseType(declaration)
+	}
+
 	declarationMembers := NewStringMemberOrderedMap()
 
 	(func() {
@@ -681,6 +710,38 @@ func (checker *Checker) declareCompositeMembersAndValue(
 	}
 }
 
+// attachmentBaseType returns the type extended by the given attachment declaration.
+// Only structures and resources may be extended.
+//
+func (checker *Checker) attachmentBaseType(declaration *ast.CompositeDeclaration) Type {
+	if declaration.BaseType == nil {
+		return InvalidType
+	}
+
+	baseType := checker.ConvertType(declaration.BaseType)
+	if baseType.IsInvalidType() {
+		return baseType
+	}
+
+	if compositeType, ok := baseType.(*CompositeType); ok {
+		switch compositeType.Kind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			return compositeType
+		}
+	}
+
+	checker.report(
+		&InvalidAttachmentBaseTypeError{
+			Type:  baseType,
+			Range: ast.NewRangeFromPositioned(declaration.BaseType),
+		},
+	)
+
+	return InvalidType
+}
+
 func (checker *Checker) declareCompositeConstructor(
 	declaration *ast.CompositeDeclaration,
 	constructorType *FunctionType,
@@ -783,6 +844,8 @@ func (checker *Checker) declareEnumConstructor(
 		}
 	}
 
+	AddEnumConstructorMembers(constructorType, compositeType)
+
 	if checker.positionInfoEnabled {
 		checker.memberOrigins[constructorType] = constructorOrigins
 	}
@@ -819,6 +882,61 @@ func EnumConstructorType(compositeType *CompositeType) *FunctionType {
 	}
 }
 
+// EnumLookupFunctionType returns the type of the `lookup` function of the given enum type,
+// which returns the enum case with the given raw value, if any.
+//
+func EnumLookupFunctionType(compositeType *CompositeType) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier:     EnumRawValueFieldName,
+				TypeAnnotation: NewTypeAnnotation(compositeType.EnumRawType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: compositeType,
+			},
+		),
+	}
+}
+
+// AddEnumConstructorMembers declares the synthetic members of the enum constructor:
+// the `allCases` field and the `lookup` function.
+//
+// Enum cases take precedence, so the members must be added after the cases were declared.
+//
+func AddEnumConstructorMembers(constructorType *FunctionType, compositeType *CompositeType) {
+
+	members := constructorType.Members
+
+	if _, ok := members.Get(EnumAllCasesFieldName); !ok {
+		members.Set(
+			EnumAllCasesFieldName,
+			NewPublicConstantFieldMember(
+				constructorType,
+				EnumAllCasesFieldName,
+				&VariableSizedType{
+					Type: compositeType,
+				},
+				enumAllCasesFieldDocString,
+			),
+		)
+	}
+
+	if _, ok := members.Get(EnumLookupFunctionName); !ok {
+		members.Set(
+			EnumLookupFunctionName,
+			NewPublicFunctionMember(
+				constructorType,
+				EnumLookupFunctionName,
+				EnumLookupFunctionType(compositeType),
+				enumLookupFunctionDocString,
+			),
+		)
+	}
+}
+
 // checkMemberStorability check that all fields have a type that is storable.
 //
 func (checker *Checker) checkMemberStorability(members *StringMemberOrderedMap) {
@@ -945,8 +1063,11 @@ func (checker *Checker) enumRawType(declaration *ast.CompositeDeclaration) Type
 	conformance := declaration.Conformances[0]
 	rawType := checker.ConvertType(conformance)
 
-	if !rawType.IsInvalidType() &&
-		!IsSameTypeKind(rawType, IntegerType) {
+	if rawType.IsInvalidType() {
+		return rawType
+	}
+
+	if !IsSameTypeKind(rawType, IntegerType) {
 
 		checker.report(
 			&InvalidEnumRawTypeError{
@@ -954,6 +1075,31 @@ func (checker *Checker) enumRawType(declaration *ast.CompositeDeclaration) Type
 				Range: ast.NewRangeFromPositioned(conformance),
 			},
 		)
+
+		return rawType
+	}
+
+	// The raw value of each case is its index,
+	// so the raw type must be able to represent the index of the last case.
+	// Arbitrary-precision raw types, i.e. `Int` and `UInt`, have no maximum
+
+	if rangedType, ok := rawType.(IntegerRangedType); ok {
+		maxInt := rangedType.MaxInt()
+		caseCount := len(declaration.Members.EnumCases())
+
+		if maxInt != nil &&
+			caseCount > 0 &&
+			big.NewInt(int64(caseCount-1)).Cmp(maxInt) > 0 {
+
+			checker.report(
+				&EnumCaseCountExceedsRawTypeError{
+					RawType:      rawType,
+					CaseCount:    caseCount,
+					MaxCaseCount: new(big.Int).Add(maxInt, big.NewInt(1)),
+					Range:        ast.NewRangeFromPositioned(conformance),
+				},
+			)
+		}
 	}
 
 	return rawType
@@ -1176,6 +1322,17 @@ func (checker *Checker) memberSatisfied(compositeMember, interfaceMember *Member
 	effectiveInterfaceMemberAccess := checker.effectiveInterfaceMemberAccess(interfaceMember.Access)
 	effectiveCompositeMemberAccess := checker.effectiveCompositeMemberAccess(compositeMember.Access)
 
+	// Members with entitlement access must require the same entitlements.
+	// NOTE: invalid entitlement access was already reported
+
+	if effectiveInterfaceMemberAccess == ast.AccessEntitlement &&
+		effectiveCompositeMemberAccess == ast.AccessEntitlement &&
+		interfaceMember.EntitlementAccess != nil &&
+		compositeMember.EntitlementAccess != nil {
+
+		return interfaceMember.EntitlementAccess.Equal(compositeMember.EntitlementAccess)
+	}
+
 	return !effectiveCompositeMemberAccess.IsLessPermissiveThan(effectiveInterfaceMemberAccess)
 }
 
@@ -1417,7 +1574,9 @@ func (checker *Checker) defaultMembersAndOrigins(
 
 		fieldNames = append(fieldNames, identifier)
 
-		fieldTypeAnnotation := checker.ConvertTypeAnnotation(field.TypeAnnotation)
+		entitlementAccess := checker.convertEntitlementAccess(field.Access, field.Entitlements)
+
+		fieldTypeAnnotation := checker.convertFieldTypeAnnotation(field, entitlementAccess)
 		checker.checkTypeAnnotation(fieldTypeAnnotation, field.TypeAnnotation)
 
 		const declarationKind = common.DeclarationKindField
@@ -1440,13 +1599,14 @@ func (checker *Checker) defaultMembersAndOrigins(
 		members.Set(
 			identifier,
 			&Member{
-				ContainerType:   containerType,
-				Access:          field.Access,
-				Identifier:      field.Identifier,
-				DeclarationKind: declarationKind,
-				TypeAnnotation:  fieldTypeAnnotation,
-				VariableKind:    field.VariableKind,
-				DocString:       field.DocString,
+				ContainerType:     containerType,
+				Access:            field.Access,
+				EntitlementAccess: entitlementAccess,
+				Identifier:        field.Identifier,
+				DeclarationKind:   declarationKind,
+				TypeAnnotation:    fieldTypeAnnotation,
+				VariableKind:      field.VariableKind,
+				DocString:         field.DocString,
 			})
 
 		if checker.positionInfoEnabled && origins != nil {
@@ -1486,6 +1646,20 @@ func (checker *Checker) defaultMembersAndOrigins(
 
 		const declarationKind = common.DeclarationKindFunction
 
+		entitlementAccess := checker.convertEntitlementAccess(function.Access, function.Entitlements)
+
+		// Mapped entitlement access is only supported for fields
+
+		if _, ok := entitlementAccess.(EntitlementMapAccess); ok {
+			checker.report(
+				&InvalidMappedEntitlementMemberError{
+					Range: ast.NewRangeFromPositioned(function.Identifier),
+				},
+			)
+
+			entitlementAccess = nil
+		}
+
 		effectiveAccess := checker.effectiveMemberAccess(function.Access, containerKind)
 
 		if requireNonPrivateMemberAccess &&
@@ -1504,14 +1678,15 @@ func (checker *Checker) defaultMembersAndOrigins(
 		members.Set(
 			identifier,
 			&Member{
-				ContainerType:   containerType,
-				Access:          function.Access,
-				Identifier:      function.Identifier,
-				DeclarationKind: declarationKind,
-				TypeAnnotation:  fieldTypeAnnotation,
-				VariableKind:    ast.VariableKindConstant,
-				ArgumentLabels:  argumentLabels,
-				DocString:       function.DocString,
+				ContainerType:     containerType,
+				Access:            function.Access,
+				EntitlementAccess: entitlementAccess,
+				Identifier:        function.Identifier,
+				DeclarationKind:   declarationKind,
+				TypeAnnotation:    fieldTypeAnnotation,
+				VariableKind:      ast.VariableKindConstant,
+				ArgumentLabels:    argumentLabels,
+				DocString:         function.DocString,
 			})
 
 		if checker.positionInfoEnabled && origins != nil {
@@ -1574,6 +1749,16 @@ const enumRawValueFieldDocString = `
 The raw value of the enum case
 `
 
+const EnumAllCasesFieldName = "allCases"
+const enumAllCasesFieldDocString = `
+All cases of the enum, in declaration order
+`
+
+const EnumLookupFunctionName = "lookup"
+const enumLookupFunctionDocString = `
+Returns the enum case with the given raw value, if any, or nil if no such case exists
+`
+
 func (checker *Checker) enumMembersAndOrigins(
 	allMembers *ast.Members,
 	containerType *CompositeType,
@@ -1843,6 +2028,37 @@ func (checker *Checker) declareSelfValue(selfType Type, selfDocString string) {
 	if checker.positionInfoEnabled {
 		checker.recordVariableDeclarationOccurrence(SelfIdentifier, self)
 	}
+
+	// Attachments may access the value they are attached to,
+	// through a reference named `base`
+
+	if compositeType, ok := selfType.(*CompositeType); ok &&
+		compositeType.Kind == common.CompositeKindAttachment {
+
+		checker.declareBaseValue(compositeType.BaseType, depth)
+	}
+}
+
+func (checker *Checker) declareBaseValue(baseType Type, depth int) {
+	if baseType == nil {
+		baseType = InvalidType
+	}
+
+	base := &Variable{
+		Identifier:      BaseIdentifier,
+		Access:          ast.AccessPublic,
+		DeclarationKind: common.DeclarationKindConstant,
+		Type: &ReferenceType{
+			Type: baseType,
+		},
+		IsConstant:      true,
+		ActivationDepth: depth,
+		Pos:             nil,
+	}
+	checker.valueActivations.Set(BaseIdentifier, base)
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(BaseIdentifier, base)
+	}
 }
 
 // checkNestedIdentifiers checks that nested identifiers, i.e. fields, functions,
@@ -2069,7 +2285,7 @@ func (checker *Checker) checkDestructor(
 //
 func (checker *Checker) checkCompositeResourceInvalidated(containerType Type) {
 	compositeType, isComposite := containerType.(*CompositeType)
-	if !isComposite || compositeType.Kind != common.CompositeKindResource {
+	if !isComposite || !compositeType.IsResourceType() {
 		return
 	}
 
@@ -111,7 +111,7 @@ func IsValidDictionaryKeyType(keyType Type) bool {
 		return keyType.Kind == common.CompositeKindEnum
 	default:
 		switch keyType {
-		case NeverType, BoolType, CharacterType, StringType, MetaType:
+		case NeverType, BoolType, CharacterType, StringType, MetaType, BytesType:
 			return true
 		default:
 			return IsSameTypeKind(keyType, NumberType) ||
@@ -0,0 +1,482 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// VisitEntitlementDeclaration checks the entitlement declaration.
+//
+// NOTE: The entitlement type was already declared in `declareEntitlementType`.
+//
+func (checker *Checker) VisitEntitlementDeclaration(declaration *ast.EntitlementDeclaration) ast.Repr {
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	return nil
+}
+
+// VisitEntitlementMappingDeclaration checks the entitlement mapping declaration.
+//
+// NOTE: The entitlement mapping type was already declared in `declareEntitlementMapType`,
+// and its relations were declared in `declareEntitlementMappingRelations`.
+//
+func (checker *Checker) VisitEntitlementMappingDeclaration(declaration *ast.EntitlementMappingDeclaration) ast.Repr {
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	return nil
+}
+
+// declareEntitlementType declares the type for the given entitlement declaration
+// and records it in the elaboration.
+//
+func (checker *Checker) declareEntitlementType(declaration *ast.EntitlementDeclaration) *EntitlementType {
+	identifier := declaration.Identifier
+
+	entitlementType := NewEntitlementType(checker.Location, identifier.Identifier)
+
+	checker.checkDeclarationIdentifier(identifier)
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               identifier,
+		ty:                       entitlementType,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: false,
+	})
+	checker.report(err)
+
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(
+			identifier.Identifier,
+			variable,
+		)
+	}
+
+	checker.Elaboration.EntitlementDeclarationTypes[declaration] = entitlementType
+
+	return entitlementType
+}
+
+// declareEntitlementMapType declares the type for the given entitlement mapping declaration
+// and records it in the elaboration.
+//
+// NOTE: The function does *not* declare the relations of the mapping,
+// as they may refer to entitlements which are declared later.
+//
+// See `declareEntitlementMappingRelations` for the declaration of the relations.
+//
+func (checker *Checker) declareEntitlementMapType(declaration *ast.EntitlementMappingDeclaration) *EntitlementMapType {
+	identifier := declaration.Identifier
+
+	entitlementMapType := NewEntitlementMapType(checker.Location, identifier.Identifier)
+
+	checker.checkDeclarationIdentifier(identifier)
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               identifier,
+		ty:                       entitlementMapType,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: false,
+	})
+	checker.report(err)
+
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(
+			identifier.Identifier,
+			variable,
+		)
+	}
+
+	checker.Elaboration.EntitlementMappingDeclarationTypes[declaration] = entitlementMapType
+
+	return entitlementMapType
+}
+
+// declareEntitlementMappingRelations declares the relations
+// of the type of the given entitlement mapping declaration.
+//
+// NOTE: This function assumes that the entitlement mapping type was previously declared using
+// `declareEntitlementMapType` and exists in `checker.Elaboration.EntitlementMappingDeclarationTypes`.
+//
+func (checker *Checker) declareEntitlementMappingRelations(declaration *ast.EntitlementMappingDeclaration) {
+	entitlementMapType := checker.Elaboration.EntitlementMappingDeclarationTypes[declaration]
+	if entitlementMapType == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	relations := make([]EntitlementRelation, 0, len(declaration.Associations))
+
+	for _, association := range declaration.Associations {
+		input := checker.convertMappedEntitlement(association.Input)
+		output := checker.convertMappedEntitlement(association.Output)
+
+		if input == nil || output == nil {
+			continue
+		}
+
+		relations = append(
+			relations,
+			EntitlementRelation{
+				Input:  input,
+				Output: output,
+			},
+		)
+	}
+
+	entitlementMapType.Relations = relations
+}
+
+func (checker *Checker) convertMappedEntitlement(t *ast.NominalType) *EntitlementType {
+	ty := checker.convertNominalType(t)
+
+	entitlementType, ok := ty.(*EntitlementType)
+	if !ok {
+		if !ty.IsInvalidType() {
+			checker.report(
+				&InvalidNonEntitlementTypeInMapError{
+					Range: ast.NewRangeFromPositioned(t),
+				},
+			)
+		}
+		return nil
+	}
+
+	return entitlementType
+}
+
+// declareNestedEntitlementMappingRelations declares the relations of the entitlement mappings
+// which are nested in the given members, while the given nested types are in scope.
+//
+func (checker *Checker) declareNestedEntitlementMappingRelations(
+	members *ast.Members,
+	getEndPosition func() ast.Position,
+	declareNestedTypes func(),
+) {
+	nestedEntitlementMappingDeclarations := members.EntitlementMappings()
+	if len(nestedEntitlementMappingDeclarations) == 0 {
+		return
+	}
+
+	checker.typeActivations.Enter()
+	defer checker.typeActivations.Leave(getEndPosition)
+
+	declareNestedTypes()
+
+	for _, nestedDeclaration := range nestedEntitlementMappingDeclarations {
+		checker.declareEntitlementMappingRelations(nestedDeclaration)
+	}
+}
+
+// declareNestedEntitlementDeclarations declares the types for the given entitlement declarations
+// and entitlement mapping declarations, which are nested in the given container type.
+//
+// Only contracts and contract interfaces support nested entitlement declarations.
+//
+func (checker *Checker) declareNestedEntitlementDeclarations(
+	containerType ContainerType,
+	containerCompositeKind common.CompositeKind,
+	containerDeclarationKind common.DeclarationKind,
+	members *ast.Members,
+	nestedDeclarations map[string]ast.Declaration,
+) {
+	declareNested := func(nestedDeclaration ast.Declaration, nestedType ContainedType) {
+		identifier := nestedDeclaration.DeclarationIdentifier()
+
+		if containerCompositeKind != common.CompositeKindContract {
+			checker.report(
+				&InvalidNestedDeclarationError{
+					NestedDeclarationKind:    nestedDeclaration.DeclarationKind(),
+					ContainerDeclarationKind: containerDeclarationKind,
+					Range:                    ast.NewRangeFromPositioned(identifier),
+				},
+			)
+
+			// NOTE: don't return, so nested types are still declared
+		}
+
+		if _, exists := nestedDeclarations[identifier.Identifier]; !exists {
+			nestedDeclarations[identifier.Identifier] = nestedDeclaration
+		}
+
+		containerType.GetNestedTypes().Set(identifier.Identifier, nestedType)
+		nestedType.SetContainerType(containerType)
+	}
+
+	for _, nestedDeclaration := range members.Entitlements() {
+		nestedType := checker.declareEntitlementType(nestedDeclaration)
+		declareNested(nestedDeclaration, nestedType)
+	}
+
+	for _, nestedDeclaration := range members.EntitlementMappings() {
+		nestedType := checker.declareEntitlementMapType(nestedDeclaration)
+		declareNested(nestedDeclaration, nestedType)
+	}
+}
+
+// convertEntitlementAccess converts the entitlements of a member's entitlement access modifier.
+//
+// The result is nil if the access is not an entitlement access, or if it is invalid.
+//
+func (checker *Checker) convertEntitlementAccess(access ast.Access, entitlements *ast.EntitlementSet) EntitlementAccess {
+	if access != ast.AccessEntitlement || entitlements == nil {
+		return nil
+	}
+
+	entitlementTypes := make([]*EntitlementType, 0, len(entitlements.Entitlements))
+
+	for _, entitlement := range entitlements.Entitlements {
+		ty := checker.convertNominalType(entitlement)
+
+		switch ty := ty.(type) {
+		case *EntitlementType:
+			entitlementTypes = append(entitlementTypes, ty)
+
+		case *EntitlementMapType:
+			if len(entitlements.Entitlements) > 1 {
+				checker.report(
+					&InvalidMultipleMappedEntitlementError{
+						Range: ast.NewRangeFromPositioned(entitlement),
+					},
+				)
+				return nil
+			}
+
+			return EntitlementMapAccess{
+				Type: ty,
+			}
+
+		default:
+			if !ty.IsInvalidType() {
+				checker.report(
+					&InvalidNonEntitlementAccessError{
+						Range: ast.NewRangeFromPositioned(entitlement),
+					},
+				)
+			}
+			return nil
+		}
+	}
+
+	return NewEntitlementSetAccess(
+		entitlementTypes,
+		convertEntitlementSetKind(entitlements.Kind),
+	)
+}
+
+// convertReferenceAuthorization converts the authorization of an entitled reference type.
+//
+// An entitlement mapping may only be used as the authorization of the type of a field
+// which has the same mapped entitlement access. The authorization is then the codomain of the mapping.
+//
+// The result is nil, i.e. the reference is unauthorized, if the authorization is invalid.
+//
+func (checker *Checker) convertReferenceAuthorization(authorization *ast.EntitlementSet) *EntitlementSetAccess {
+	entitlementTypes := make([]*EntitlementType, 0, len(authorization.Entitlements))
+
+	for _, entitlement := range authorization.Entitlements {
+		ty := checker.convertNominalType(entitlement)
+
+		switch ty := ty.(type) {
+		case *EntitlementType:
+			entitlementTypes = append(entitlementTypes, ty)
+
+		case *EntitlementMapType:
+			if len(authorization.Entitlements) > 1 {
+				checker.report(
+					&InvalidMultipleMappedEntitlementError{
+						Range: ast.NewRangeFromPositioned(entitlement),
+					},
+				)
+				return nil
+			}
+
+			if checker.entitlementMapInScope == nil ||
+				!checker.entitlementMapInScope.Equal(ty) {
+
+				checker.report(
+					&InvalidMappedAuthorizationOutsideOfFieldError{
+						Map:   ty,
+						Range: ast.NewRangeFromPositioned(entitlement),
+					},
+				)
+				return nil
+			}
+
+			return ty.Codomain()
+
+		default:
+			if !ty.IsInvalidType() {
+				checker.report(
+					&InvalidNonEntitlementAuthorizationError{
+						Range: ast.NewRangeFromPositioned(entitlement),
+					},
+				)
+			}
+			return nil
+		}
+	}
+
+	return NewEntitlementSetAccess(
+		entitlementTypes,
+		convertEntitlementSetKind(authorization.Kind),
+	)
+}
+
+func convertEntitlementSetKind(kind ast.EntitlementSetKind) EntitlementSetKind {
+	switch kind {
+	case ast.EntitlementSetKindConjunction:
+		return EntitlementSetKindConjunction
+	case ast.EntitlementSetKindDisjunction:
+		return EntitlementSetKindDisjunction
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// convertFieldTypeAnnotation converts the type annotation of the given field.
+//
+// The type of a field with mapped entitlement access must be a reference type,
+// or an optional reference type, which is authorized with the same entitlement mapping.
+//
+func (checker *Checker) convertFieldTypeAnnotation(
+	field *ast.FieldDeclaration,
+	entitlementAccess EntitlementAccess,
+) *TypeAnnotation {
+
+	mapAccess, ok := entitlementAccess.(EntitlementMapAccess)
+	if !ok {
+		return checker.ConvertTypeAnnotation(field.TypeAnnotation)
+	}
+
+	previousEntitlementMapInScope := checker.entitlementMapInScope
+	checker.entitlementMapInScope = mapAccess.Type
+	defer func() {
+		checker.entitlementMapInScope = previousEntitlementMapInScope
+	}()
+
+	typeAnnotation := checker.ConvertTypeAnnotation(field.TypeAnnotation)
+
+	fieldType := typeAnnotation.Type
+	if !fieldType.IsInvalidType() {
+
+		if optionalType, ok := fieldType.(*OptionalType); ok {
+			fieldType = optionalType.Type
+		}
+
+		referenceType, ok := fieldType.(*ReferenceType)
+		if !ok ||
+			referenceType.Authorized ||
+			!referenceType.Authorization.Equal(mapAccess.Type.Codomain()) {
+
+			checker.report(
+				&InvalidMappedEntitlementMemberError{
+					Range: ast.NewRangeFromPositioned(field.TypeAnnotation),
+				},
+			)
+		}
+	}
+
+	return typeAnnotation
+}
+
+// checkMemberEntitlementAccess checks that the given member, which is accessed
+// through a value of the given type, can be accessed with the entitlements of the value, if any.
+//
+// Members with entitlement access can be accessed on owned values,
+// through references with full authorization (`auth &T`),
+// and through entitled references which guarantee the required entitlements.
+//
+// For members with mapped entitlement access, the resulting type is recorded in the elaboration,
+// as the authorization of the resulting reference is the image of the reference's authorization.
+//
+func (checker *Checker) checkMemberEntitlementAccess(
+	expression *ast.MemberExpression,
+	accessedType Type,
+	member *Member,
+) {
+	if member.EntitlementAccess == nil ||
+		checker.containerTypes[member.ContainerType] {
+
+		return
+	}
+
+	if optionalType, ok := accessedType.(*OptionalType); ok && expression.Optional {
+		accessedType = optionalType.Type
+	}
+
+	referenceType, ok := accessedType.(*ReferenceType)
+	if !ok || referenceType.Authorized {
+		return
+	}
+
+	switch access := member.EntitlementAccess.(type) {
+	case *EntitlementSetAccess:
+		if !referenceType.Authorization.PermitsAccess(access) {
+			checker.report(
+				&InsufficientEntitlementsError{
+					Name:            member.Identifier.Identifier,
+					DeclarationKind: member.DeclarationKind,
+					RequiredAccess:  access,
+					PossessedAccess: referenceType.Authorization,
+					Range:           ast.NewRangeFromPositioned(expression),
+				},
+			)
+		}
+
+	case EntitlementMapAccess:
+		image := access.Type.Image(referenceType.Authorization)
+
+		checker.Elaboration.MemberExpressionMappedTypes[expression] =
+			mappedMemberType(member.TypeAnnotation.Type, image)
+	}
+}
+
+// mappedMemberType returns the given type of a member with mapped entitlement access,
+// with the given authorization
+//
+func mappedMemberType(memberType Type, authorization *EntitlementSetAccess) Type {
+	switch memberType := memberType.(type) {
+	case *OptionalType:
+		return NewOptionalType(mappedMemberType(memberType.Type, authorization))
+
+	case *ReferenceType:
+		return &ReferenceType{
+			Authorization: authorization,
+			Type:          memberType.Type,
+		}
+	}
+
+	return memberType
+}
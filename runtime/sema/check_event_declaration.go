@@ -92,7 +92,7 @@ func IsValidEventParameterType(t Type, results map[*Member]bool) bool {
 
 	default:
 		switch t {
-		case MetaType, BoolType, CharacterType, StringType:
+		case MetaType, BoolType, CharacterType, StringType, BytesType:
 			return true
 		}
 
@@ -30,6 +30,29 @@ func (checker *Checker) VisitIdentifierExpression(expression *ast.IdentifierExpr
 		return InvalidType
 	}
 
+	if variable.Deprecation != nil {
+		// Starting with language version 1,
+		// deprecated declarations may no longer be used
+
+		if checker.languageVersion >= LanguageVersion1 {
+			checker.report(
+				&DeprecatedDeclarationError{
+					Name:        identifier.Identifier,
+					Deprecation: variable.Deprecation,
+					Range:       ast.NewRangeFromPositioned(expression),
+				},
+			)
+		} else {
+			checker.hint(
+				&DeprecatedDeclarationHint{
+					Name:        identifier.Identifier,
+					Deprecation: variable.Deprecation,
+					Range:       ast.NewRangeFromPositioned(expression),
+				},
+			)
+		}
+	}
+
 	valueType := variable.Type
 
 	if valueType.IsResourceType() {
@@ -251,6 +274,17 @@ func (checker *Checker) visitIndexExpression(
 		return InvalidType
 	}
 
+	// Attachments of structures and resources are accessed by indexing
+	// with the attachment type, e.g. `r[A]`
+
+	if isAttachmentAccess(targetType) {
+		return checker.visitAttachmentAccess(
+			indexExpression,
+			targetType,
+			isAssignment,
+		)
+	}
+
 	// Check if the type instance is actually indexable. For most types (e.g. arrays and dictionaries)
 	// this is known statically (in the sense of this host language (Go), not the implemented language),
 	// i.e. a Go type switch would be sufficient.
@@ -30,7 +30,7 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 
 	valueExpression := statement.Value
 
-	// iterations are only supported for non-resource arrays.
+	// iterations are only supported for non-resource arrays and ranges.
 	// Hence, if the array is empty and no context type is available,
 	// then default it to [AnyStruct].
 	var expectedType Type
@@ -60,10 +60,12 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 			)
 		} else if arrayType, ok := valueType.(ArrayType); ok {
 			elementType = arrayType.ElementType(false)
+		} else if rangeType, ok := valueType.(*InclusiveRangeType); ok {
+			elementType = rangeType.EffectiveMemberType()
 		} else {
 			checker.report(
 				&TypeMismatchWithDescriptionError{
-					ExpectedTypeDescription: "array",
+					ExpectedTypeDescription: "array or range",
 					ActualType:              valueType,
 					Range:                   ast.NewRangeFromPositioned(valueExpression),
 				},
@@ -71,6 +73,8 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 		}
 	}
 
+	checker.checkDeclarationIdentifier(statement.Identifier)
+
 	identifier := statement.Identifier.Identifier
 
 	variable, err := checker.valueActivations.Declare(variableDeclaration{
@@ -88,6 +92,8 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 	}
 
 	if statement.Index != nil {
+		checker.checkDeclarationIdentifier(*statement.Index)
+
 		index := statement.Index.Identifier
 		indexVariable, err := checker.valueActivations.Declare(variableDeclaration{
 			identifier:               index,
@@ -91,6 +91,8 @@ func (checker *Checker) declareFunctionDeclaration(
 	declaration *ast.FunctionDeclaration,
 	functionType *FunctionType,
 ) {
+	checker.checkDeclarationIdentifier(declaration.Identifier)
+
 	argumentLabels := declaration.ParameterList.EffectiveArgumentLabels()
 
 	_, err := checker.valueActivations.Declare(variableDeclaration{
@@ -281,6 +283,8 @@ func (checker *Checker) declareParameters(
 	for i, parameter := range parameterList.Parameters {
 		identifier := parameter.Identifier
 
+		checker.checkDeclarationIdentifier(identifier)
+
 		// check if variable with this identifier is already declared in the current scope
 		existingVariable := checker.valueActivations.Find(identifier.Identifier)
 		if existingVariable != nil && existingVariable.ActivationDepth == depth {
@@ -120,6 +120,14 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 		checker.visitCompositeDeclaration(nestedComposite, kind)
 	}
 
+	for _, nestedEntitlement := range declaration.Members.Entitlements() {
+		nestedEntitlement.Accept(checker)
+	}
+
+	for _, nestedEntitlementMapping := range declaration.Members.EntitlementMappings() {
+		nestedEntitlementMapping.Accept(checker)
+	}
+
 	return nil
 }
 
@@ -218,6 +226,8 @@ func (checker *Checker) declareInterfaceType(declaration *ast.InterfaceDeclarati
 		Members:       NewStringMemberOrderedMap(),
 	}
 
+	checker.checkDeclarationIdentifier(identifier)
+
 	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
 		identifier:               identifier,
 		ty:                       interfaceType,
@@ -274,6 +284,14 @@ func (checker *Checker) declareInterfaceType(declaration *ast.InterfaceDeclarati
 		nestedCompositeType.SetContainerType(interfaceType)
 	}
 
+	checker.declareNestedEntitlementDeclarations(
+		interfaceType,
+		declaration.CompositeKind,
+		declaration.DeclarationKind(),
+		declaration.Members,
+		nestedDeclarations,
+	)
+
 	return interfaceType
 }
 
@@ -49,6 +49,12 @@ func (checker *Checker) checkInvocationExpression(invocationExpression *ast.Invo
 		checker.inCreate = inCreate
 	}()
 
+	inAttach := checker.inAttach
+	checker.inAttach = false
+	defer func() {
+		checker.inAttach = inAttach
+	}()
+
 	inInvocation := checker.inInvocation
 	checker.inInvocation = true
 	defer func() {
@@ -175,6 +181,7 @@ func (checker *Checker) checkInvocationExpression(invocationExpression *ast.Invo
 		functionType,
 		returnType,
 		inCreate,
+		inAttach,
 	)
 
 	checker.checkMemberInvocationResourceInvalidation(invokedExpression)
@@ -249,17 +256,36 @@ func (checker *Checker) checkConstructorInvocationWithResourceResult(
 	functionType *FunctionType,
 	returnType Type,
 	inCreate bool,
+	inAttach bool,
 ) {
 	if !functionType.IsConstructor {
 		return
 	}
 
+	compositeReturnType, ok := returnType.(*CompositeType)
+	if !ok {
+		return
+	}
+
+	// Attachments can only be constructed in attach expressions,
+	// even if they extend a resource
+
+	if compositeReturnType.Kind == common.CompositeKindAttachment {
+		if !inAttach {
+			checker.report(
+				&InvalidAttachmentConstructionError{
+					Range: ast.NewRangeFromPositioned(invocationExpression),
+				},
+			)
+		}
+
+		return
+	}
+
 	// NOTE: not using `isResourceType`,
 	// as only direct resource types can be constructed
 
-	if compositeReturnType, ok := returnType.(*CompositeType); !ok ||
-		compositeReturnType.Kind != common.CompositeKindResource {
-
+	if compositeReturnType.Kind != common.CompositeKindResource {
 		return
 	}
 
@@ -477,6 +503,14 @@ func (checker *Checker) checkInvocation(
 		invocationExpression,
 	)
 
+	// The invokable type might have special checks for the type arguments
+
+	functionType.CheckTypeArguments(
+		checker,
+		typeArguments,
+		ast.NewRangeFromPositioned(invocationExpression),
+	)
+
 	// Save types in the elaboration
 
 	checker.Elaboration.InvocationExpressionTypeArguments[invocationExpression] = typeArguments
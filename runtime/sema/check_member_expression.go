@@ -84,12 +84,19 @@ func (checker *Checker) VisitMemberExpression(expression *ast.MemberExpression)
 
 	memberType := member.TypeAnnotation.Type
 
+	// The type of a member with mapped entitlement access
+	// might have been mapped to the authorization of the accessed reference
+
+	if mappedType, ok := checker.Elaboration.MemberExpressionMappedTypes[expression]; ok {
+		memberType = mappedType
+	}
+
 	// If the member access is optional chaining, only wrap the result value
 	// in an optional, if it is not already an optional value
 
 	if isOptional {
 		if _, ok := memberType.(*OptionalType); !ok {
-			return &OptionalType{Type: memberType}
+			return NewOptionalType(memberType)
 		}
 	}
 
@@ -162,7 +169,7 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression) (accessedT
 			return
 		}
 		targetRange := ast.NewRangeFromPositioned(expression.Expression)
-		member = resolver.Resolve(identifier, targetRange, checker.report)
+		member = resolver.Resolve(identifier, targetRange, checker.reportFunc)
 	}
 
 	// Get the member from the accessed value based
@@ -246,6 +253,41 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression) (accessedT
 			)
 		}
 
+		// Check that the entitlements of the accessed reference, if any, permit the access
+
+		checker.checkMemberEntitlementAccess(expression, accessedType, member)
+
+		// Check that linking accounts is explicitly allowed by the program
+
+		if member.ContainerType == AuthAccountType &&
+			identifier == AuthAccountLinkAccountField &&
+			!checker.isAccountLinkingAllowed() {
+
+			checker.report(
+				&AccountLinkingNotAllowedError{
+					Range: ast.Range{
+						StartPos: identifierStartPosition,
+						EndPos:   identifierEndPosition,
+					},
+				},
+			)
+		}
+
+		// Check that the member does not write, if the program may only read
+
+		if checker.readOnlyEnforcementEnabled && isWritingMember(member) {
+			checker.report(
+				&ReadOnlyViolationError{
+					ContainerType: member.ContainerType,
+					Name:          identifier,
+					Range: ast.Range{
+						StartPos: identifierStartPosition,
+						EndPos:   identifierEndPosition,
+					},
+				},
+			)
+		}
+
 		// Check that the member access is not to a function of resource type
 		// outside of an invocation of it.
 		//
@@ -267,6 +309,50 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression) (accessedT
 	return accessedType, member, isOptional
 }
 
+// writingMembers are the names of the members which write, e.g. to account storage,
+// by their container type. See WithReadOnlyEnforcementEnabled
+//
+var writingMembers = map[Type]map[string]struct{}{
+	AuthAccountType: {
+		AuthAccountSaveField:            {},
+		AuthAccountLoadField:            {},
+		AuthAccountLinkField:            {},
+		AuthAccountUnlinkField:          {},
+		AuthAccountLinkAccountField:     {},
+		AuthAccountAddPublicKeyField:    {},
+		AuthAccountRemovePublicKeyField: {},
+	},
+	AuthAccountContractsType: {
+		AuthAccountContractsTypeAddFunctionName:                {},
+		AuthAccountContractsTypeAddFrozenFunctionName:          {},
+		AuthAccountContractsTypeUpdateExperimentalFunctionName: {},
+		AuthAccountContractsTypeRemoveFunctionName:             {},
+	},
+	AuthAccountKeysType: {
+		AccountKeysAddFunctionName:    {},
+		AccountKeysRevokeFunctionName: {},
+	},
+	AuthAccountCapabilitiesType: {
+		AuthAccountCapabilitiesTypePublishFunctionName:   {},
+		AuthAccountCapabilitiesTypeUnpublishFunctionName: {},
+	},
+	AuthAccountStorageCapabilitiesType: {
+		AuthAccountStorageCapabilitiesTypeIssueFunctionName: {},
+	},
+	StorageCapabilityControllerType: {
+		StorageCapabilityControllerTypeRevokeFunctionName: {},
+	},
+}
+
+func isWritingMember(member *Member) bool {
+	names, ok := writingMembers[member.ContainerType]
+	if !ok {
+		return false
+	}
+	_, ok = names[member.Identifier.Identifier]
+	return ok
+}
+
 // isReadableMember returns true if the given member can be read from
 // in the current location of the checker
 //
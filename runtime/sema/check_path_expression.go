@@ -40,6 +40,11 @@ func (checker *Checker) VisitPathExpression(expression *ast.PathExpression) ast.
 
 	checker.report(err)
 
+	if err == nil {
+		domain := common.PathDomainFromIdentifier(expression.Domain.Identifier)
+		checker.Elaboration.PathExpressionDomains[expression] = domain
+	}
+
 	return ty
 }
 
@@ -65,7 +70,7 @@ func CheckPathLiteral(domainString, identifier string, domainRangeThunk, idRange
 	}
 
 	switch domain {
-	case common.PathDomainStorage:
+	case common.PathDomainStorage, common.PathDomainTransaction:
 		return StoragePathType, nil
 	case common.PathDomainPublic:
 		return PublicPathType, nil
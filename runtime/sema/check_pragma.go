@@ -23,6 +23,12 @@ import "github.com/onflow/cadence/runtime/ast"
 func (checker *Checker) VisitPragmaDeclaration(p *ast.PragmaDeclaration) ast.Repr {
 
 	invocPragma, isInvocPragma := p.Expression.(*ast.InvocationExpression)
+
+	if isInvocPragma && isStaticAssertPragma(invocPragma) {
+		checker.checkStaticAssertPragma(invocPragma)
+		return nil
+	}
+
 	var isIdentPragma bool
 	if !isInvocPragma {
 		_, isIdentPragma = p.Expression.(*ast.IdentifierExpression)
@@ -58,3 +64,102 @@ func (checker *Checker) VisitPragmaDeclaration(p *ast.PragmaDeclaration) ast.Rep
 
 	return nil
 }
+
+// AllowAccountLinkingPragma is the identifier of the pragma
+// which allows the program to link accounts, i.e. to call `AuthAccount.linkAccount`
+//
+const AllowAccountLinkingPragma = "allowAccountLinking"
+
+// isAccountLinkingAllowed returns true if the program declares the pragma
+// which allows linking accounts
+//
+func (checker *Checker) isAccountLinkingAllowed() bool {
+	for _, pragma := range checker.Program.PragmaDeclarations() {
+		identifierExpression, ok := pragma.Expression.(*ast.IdentifierExpression)
+		if ok && identifierExpression.Identifier.Identifier == AllowAccountLinkingPragma {
+			return true
+		}
+	}
+	return false
+}
+
+// StaticAssertPragma is the identifier of the pragma
+// which asserts a condition when the program is checked, e.g. `#assert(E.allCases.length == 3, "message")`
+//
+const StaticAssertPragma = "assert"
+
+func isStaticAssertPragma(invocation *ast.InvocationExpression) bool {
+	identifierExpression, ok := invocation.InvokedExpression.(*ast.IdentifierExpression)
+	return ok && identifierExpression.Identifier.Identifier == StaticAssertPragma
+}
+
+// checkStaticAssertPragma checks the arguments of the static assertion pragma,
+// evaluates the condition and reports an error if it does not hold.
+//
+// The condition must be a constant boolean expression,
+// and the optional message must be a string literal.
+//
+func (checker *Checker) checkStaticAssertPragma(invocation *ast.InvocationExpression) {
+
+	arguments := invocation.Arguments
+	argumentCount := len(arguments)
+
+	if len(invocation.TypeArguments) > 0 ||
+		argumentCount < 1 ||
+		argumentCount > 2 {
+
+		checker.report(&InvalidPragmaError{
+			Message: "`#assert` requires a condition and an optional message",
+			Range:   ast.NewRangeFromPositioned(invocation),
+		})
+		return
+	}
+
+	for _, argument := range arguments {
+		if argument.Label != "" {
+			checker.report(&InvalidPragmaError{
+				Message: "`#assert` arguments must not have labels",
+				Range:   ast.NewRangeFromPositioned(argument.Expression),
+			})
+			return
+		}
+	}
+
+	var message string
+	if argumentCount > 1 {
+		messageExpression, ok := arguments[1].Expression.(*ast.StringExpression)
+		if !ok {
+			checker.report(&InvalidPragmaError{
+				Message: "`#assert` message must be a string literal",
+				Range:   ast.NewRangeFromPositioned(arguments[1].Expression),
+			})
+			return
+		}
+		message = messageExpression.Value
+	}
+
+	condition := arguments[0].Expression
+
+	// Only evaluate the condition if it is well-typed
+
+	errorCount := len(checker.errors)
+
+	checker.VisitExpression(condition, BoolType)
+
+	if len(checker.errors) > errorCount {
+		return
+	}
+
+	value, err := checker.evaluateConstantExpression(condition)
+	if err != nil {
+		checker.report(err)
+		return
+	}
+
+	if value != true {
+		checker.report(&StaticAssertionFailedError{
+			Message: message,
+			Range:   ast.NewRangeFromPositioned(condition),
+		})
+	}
+}
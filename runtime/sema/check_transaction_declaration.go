@@ -199,14 +199,21 @@ func (checker *Checker) visitTransactionPrepareFunction(
 }
 
 // checkTransactionPrepareFunctionParameters checks that the parameters are each of type Account.
+// The last parameter may also be an array of accounts, which receives all remaining signers.
 //
 func (checker *Checker) checkTransactionPrepareFunctionParameters(
 	parameterList *ast.ParameterList,
 	parameters []*Parameter,
 ) {
+	lastIndex := len(parameterList.Parameters) - 1
+
 	for i, parameter := range parameterList.Parameters {
 		parameterType := parameters[i].TypeAnnotation.Type
 
+		if i == lastIndex && IsAuthAccountArrayType(parameterType) {
+			continue
+		}
+
 		if !parameterType.IsInvalidType() &&
 			!IsSameTypeKind(parameterType, AuthAccountType) {
 
@@ -51,9 +51,7 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 		// If the variable declaration is an optional binding (`if let`),
 		// then the value is expected to be an optional of the declaration's type.
 		if isOptionalBinding {
-			expectedValueType = &OptionalType{
-				Type: declarationType,
-			}
+			expectedValueType = NewOptionalType(declarationType)
 		} else {
 			expectedValueType = declarationType
 		}
@@ -173,6 +171,8 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 
 	// Finally, declare the variable in the current value activation
 
+	checker.checkDeclarationIdentifier(declaration.Identifier)
+
 	identifier := declaration.Identifier.Identifier
 
 	variable, err := checker.valueActivations.Declare(variableDeclaration{
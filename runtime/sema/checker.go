@@ -32,6 +32,7 @@ import (
 
 const ArgumentLabelNotRequired = "_"
 const SelfIdentifier = "self"
+const BaseIdentifier = "base"
 const BeforeIdentifier = "before"
 const ResultIdentifier = "result"
 
@@ -78,6 +79,8 @@ type ImportHandlerFunc func(checker *Checker, importedLocation common.Location,
 
 type MemberAccountAccessHandlerFunc func(checker *Checker, memberLocation common.Location) bool
 
+type CompositeMembersHandlerFunc func(compositeType *CompositeType) []*Member
+
 // Checker
 
 type Checker struct {
@@ -86,6 +89,7 @@ type Checker struct {
 	PredeclaredValues                  []ValueDeclaration
 	PredeclaredTypes                   []TypeDeclaration
 	accessCheckMode                    AccessCheckMode
+	languageVersion                    LanguageVersion
 	errors                             []error
 	hints                              []Hint
 	valueActivations                   *VariableActivations
@@ -103,6 +107,7 @@ type Checker struct {
 	FunctionInvocations                *FunctionInvocations
 	isChecked                          bool
 	inCreate                           bool
+	inAttach                           bool
 	inInvocation                       bool
 	inAssignment                       bool
 	allowSelfResourceFieldInvalidation bool
@@ -115,7 +120,15 @@ type Checker struct {
 	checkHandler                       CheckHandlerFunc
 	expectedType                       Type
 	memberAccountAccessHandler         MemberAccountAccessHandlerFunc
+	compositeMembersHandler            CompositeMembersHandlerFunc
 	lintEnabled                        bool
+	readOnlyEnforcementEnabled         bool
+	// entitlementMapInScope is the entitlement mapping of the field
+	// whose type is currently converted, if any
+	entitlementMapInScope *EntitlementMapType
+	// reportFunc is the method value of report.
+	// It is cached, as creating it allocates
+	reportFunc func(error)
 }
 
 type Option func(*Checker) error
@@ -162,6 +175,16 @@ func WithAccessCheckMode(mode AccessCheckMode) Option {
 	}
 }
 
+// WithLanguageVersion returns a checker option which sets
+// the language version, which determines which breaking changes are enabled.
+//
+func WithLanguageVersion(version LanguageVersion) Option {
+	return func(checker *Checker) error {
+		checker.languageVersion = version
+		return nil
+	}
+}
+
 // WithValidTopLevelDeclarationsHandler returns a checker option which sets
 // the given handler as function which is used to determine
 // the slice of declaration kinds which are valid at the top-level
@@ -194,6 +217,20 @@ func WithLocationHandler(handler LocationHandlerFunc) Option {
 	}
 }
 
+// WithCompositeMembersHandler returns a checker option which sets
+// the given handler as function which is used to declare additional members
+// of composite types, which are implemented natively.
+//
+// The handler is called when the members of a composite type are declared,
+// after its nested types were declared.
+//
+func WithCompositeMembersHandler(handler CompositeMembersHandlerFunc) Option {
+	return func(checker *Checker) error {
+		checker.compositeMembersHandler = handler
+		return nil
+	}
+}
+
 // WithImportHandler returns a checker option which sets
 // the given handler as function which is used to resolve unresolved imports.
 //
@@ -215,6 +252,19 @@ func WithMemberAccountAccessHandler(handler MemberAccountAccessHandlerFunc) Opti
 	}
 }
 
+// WithReadOnlyEnforcementEnabled returns a checker option which enables/disables
+// if the program may only read, i.e. if accesses of members which write,
+// e.g. to account storage, are rejected. See ReadOnlyViolationError.
+//
+// The option only applies to the checked program, it is not inherited by sub-checkers.
+//
+func WithReadOnlyEnforcementEnabled(enabled bool) Option {
+	return func(checker *Checker) error {
+		checker.readOnlyEnforcementEnabled = enabled
+		return nil
+	}
+}
+
 // WithPositionInfoEnabled returns a checker option which enables/disables
 // if position info recoding is enabled.
 //
@@ -271,7 +321,9 @@ func NewChecker(program *ast.Program, location common.Location, options ...Optio
 		Elaboration:         NewElaboration(),
 	}
 
-	checker.beforeExtractor = NewBeforeExtractor(checker.report)
+	checker.reportFunc = checker.report
+
+	checker.beforeExtractor = NewBeforeExtractor(checker.reportFunc)
 
 	for _, option := range options {
 		err := option(checker)
@@ -280,6 +332,8 @@ func NewChecker(program *ast.Program, location common.Location, options ...Optio
 		}
 	}
 
+	checker.Elaboration.LanguageVersion = checker.languageVersion
+
 	err := checker.CheckerError()
 	if err != nil {
 		return nil, err
@@ -295,6 +349,7 @@ func (checker *Checker) SubChecker(program *ast.Program, location common.Locatio
 		WithPredeclaredValues(checker.PredeclaredValues),
 		WithPredeclaredTypes(checker.PredeclaredTypes),
 		WithAccessCheckMode(checker.accessCheckMode),
+		WithLanguageVersion(checker.languageVersion),
 		WithValidTopLevelDeclarationsHandler(checker.validTopLevelDeclarationsHandler),
 		WithCheckHandler(checker.checkHandler),
 		WithImportHandler(checker.importHandler),
@@ -302,6 +357,23 @@ func (checker *Checker) SubChecker(program *ast.Program, location common.Locatio
 	)
 }
 
+// checkDeclarationIdentifier checks that the given identifier
+// may be used as the name of a declaration in the checker's language version.
+//
+func (checker *Checker) checkDeclarationIdentifier(identifier ast.Identifier) {
+	if !checker.languageVersion.IsReservedIdentifier(identifier.Identifier) {
+		return
+	}
+
+	checker.report(
+		&ReservedIdentifierError{
+			Name:            identifier.Identifier,
+			LanguageVersion: checker.languageVersion,
+			Pos:             identifier.Pos,
+		},
+	)
+}
+
 func (checker *Checker) declareValue(declaration ValueDeclaration) *Variable {
 
 	if !declaration.ValueDeclarationAvailable(checker.Location) {
@@ -322,6 +394,9 @@ func (checker *Checker) declareValue(declaration ValueDeclaration) *Variable {
 		allowOuterScopeShadowing: false,
 	})
 	checker.report(err)
+	if deprecatable, ok := declaration.(DeprecatableValueDeclaration); ok {
+		variable.Deprecation = deprecatable.ValueDeclarationDeprecation()
+	}
 	if checker.positionInfoEnabled {
 		checker.recordVariableDeclarationOccurrence(name, variable)
 	}
@@ -453,11 +528,25 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 			checker.Elaboration.InterfaceTypes[typedType.ID()] = typedType
 		case *CompositeType:
 			checker.Elaboration.CompositeTypes[typedType.ID()] = typedType
+		case *EntitlementType:
+			checker.Elaboration.EntitlementTypes[typedType.ID()] = typedType
+		case *EntitlementMapType:
+			checker.Elaboration.EntitlementMapTypes[typedType.ID()] = typedType
 		default:
 			panic(errors.NewUnreachableError())
 		}
 	}
 
+	for _, declaration := range program.EntitlementDeclarations() {
+		entitlementType := checker.declareEntitlementType(declaration)
+		registerInElaboration(entitlementType)
+	}
+
+	for _, declaration := range program.EntitlementMappingDeclarations() {
+		entitlementMapType := checker.declareEntitlementMapType(declaration)
+		registerInElaboration(entitlementMapType)
+	}
+
 	for _, declaration := range program.InterfaceDeclarations() {
 		interfaceType := checker.declareInterfaceType(declaration)
 
@@ -476,6 +565,33 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		VisitThisAndNested(compositeType, registerInElaboration)
 	}
 
+	// Declare entitlement mappings' relations,
+	// before any members, as the types of fields with mapped entitlement access depend on them
+
+	for _, declaration := range program.EntitlementMappingDeclarations() {
+		checker.declareEntitlementMappingRelations(declaration)
+	}
+
+	for _, declaration := range program.InterfaceDeclarations() {
+		checker.declareNestedEntitlementMappingRelations(
+			declaration.Members,
+			declaration.EndPosition,
+			func() {
+				checker.declareInterfaceNestedTypes(declaration)
+			},
+		)
+	}
+
+	for _, declaration := range program.CompositeDeclarations() {
+		checker.declareNestedEntitlementMappingRelations(
+			declaration.Members,
+			declaration.EndPosition,
+			func() {
+				checker.declareCompositeNestedTypes(declaration, ContainerKindComposite, false)
+			},
+		)
+	}
+
 	// Declare interfaces' and composites' members
 
 	for _, declaration := range program.InterfaceDeclarations() {
@@ -691,6 +807,7 @@ func CheckIntegerLiteral(expression *ast.IntegerExpression, targetType Type, rep
 				ExpectedType:   targetType,
 				ExpectedMinInt: minInt,
 				ExpectedMaxInt: maxInt,
+				Literal:        expression.String(),
 				Range:          ast.NewRangeFromPositioned(expression),
 			})
 		}
@@ -745,6 +862,7 @@ func CheckFixedPointLiteral(expression *ast.FixedPointExpression, targetType Typ
 					ExpectedMinFractional: minFractional,
 					ExpectedMaxInt:        maxInt,
 					ExpectedMaxFractional: maxFractional,
+					Literal:               expression.String(),
 					Range:                 ast.NewRangeFromPositioned(expression),
 				})
 			}
@@ -768,6 +886,7 @@ func CheckFixedPointLiteral(expression *ast.FixedPointExpression, targetType Typ
 					ExpectedType:   targetType,
 					ExpectedMinInt: minInt,
 					ExpectedMaxInt: maxInt,
+					Literal:        expression.String(),
 					Range:          ast.NewRangeFromPositioned(expression),
 				})
 			}
@@ -1172,10 +1291,16 @@ func (checker *Checker) convertRestrictedType(t *ast.RestrictedType) Type {
 func (checker *Checker) convertReferenceType(t *ast.ReferenceType) Type {
 	ty := checker.ConvertType(t.Type)
 
-	return &ReferenceType{
-		Authorized: t.Authorized,
-		Type:       ty,
+	// Entitled reference types are not authorized, i.e. they do not have full authorization
+
+	if t.Authorization != nil {
+		return &ReferenceType{
+			Authorization: checker.convertReferenceAuthorization(t.Authorization),
+			Type:          ty,
+		}
 	}
+
+	return NewReferenceType(t.Authorized, ty)
 }
 
 func (checker *Checker) convertDictionaryType(t *ast.DictionaryType) Type {
@@ -1199,9 +1324,7 @@ func (checker *Checker) convertDictionaryType(t *ast.DictionaryType) Type {
 
 func (checker *Checker) convertOptionalType(t *ast.OptionalType) Type {
 	ty := checker.ConvertType(t.Type)
-	return &OptionalType{
-		Type: ty,
-	}
+	return NewOptionalType(ty)
 }
 
 // convertFunctionType converts the given AST function type into a sema function type.
@@ -1276,9 +1399,7 @@ func (checker *Checker) convertConstantSizedType(t *ast.ConstantSizedType) Type
 
 func (checker *Checker) convertVariableSizedType(t *ast.VariableSizedType) Type {
 	elementType := checker.ConvertType(t.Type)
-	return &VariableSizedType{
-		Type: elementType,
-	}
+	return NewVariableSizedType(elementType)
 }
 
 func (checker *Checker) findAndCheckTypeVariable(identifier ast.Identifier, recordOccurrence bool) *Variable {
@@ -1866,7 +1987,8 @@ func (checker *Checker) checkDeclarationAccessModifier(
 			}
 
 		case ast.AccessContract,
-			ast.AccessAccount:
+			ast.AccessAccount,
+			ast.AccessEntitlement:
 
 			// Type declarations must be public for now
 
@@ -1948,13 +2070,15 @@ func (checker *Checker) isReadableAccess(access ast.Access) bool {
 		AccessCheckModeNotSpecifiedRestricted:
 
 		return access == ast.AccessPublic ||
-			access == ast.AccessPublicSettable
+			access == ast.AccessPublicSettable ||
+			access == ast.AccessEntitlement
 
 	case AccessCheckModeNotSpecifiedUnrestricted:
 
 		return access == ast.AccessNotSpecified ||
 			access == ast.AccessPublic ||
-			access == ast.AccessPublicSettable
+			access == ast.AccessPublicSettable ||
+			access == ast.AccessEntitlement
 
 	case AccessCheckModeNone:
 		return true
@@ -2105,6 +2229,17 @@ func (checker *Checker) predeclaredMembers(containerType Type) []*Member {
 		}
 	}
 
+	// Composite types may have additional members which are implemented natively
+
+	if compositeType, ok := containerType.(*CompositeType); ok &&
+		checker.compositeMembersHandler != nil {
+
+		predeclaredMembers = append(
+			predeclaredMembers,
+			checker.compositeMembersHandler(compositeType)...,
+		)
+	}
+
 	return predeclaredMembers
 }
 
@@ -2143,6 +2278,14 @@ func (checker *Checker) checkVariableMove(expression ast.Expression) {
 }
 
 func (checker *Checker) rewritePostConditions(postConditions []*ast.Condition) PostConditionsRewrite {
+	return rewritePostConditions(checker.beforeExtractor, postConditions)
+}
+
+// rewritePostConditions rewrites the given post-conditions,
+// extracting `before` expressions into variable declarations
+// using the given extractor.
+//
+func rewritePostConditions(beforeExtractor *BeforeExtractor, postConditions []*ast.Condition) PostConditionsRewrite {
 
 	var beforeStatements []ast.Statement
 	rewrittenPostConditions := make([]*ast.Condition, len(postConditions))
@@ -2152,14 +2295,14 @@ func (checker *Checker) rewritePostConditions(postConditions []*ast.Condition) P
 		// copy condition and set expression to rewritten one
 		newPostCondition := *postCondition
 
-		testExtraction := checker.beforeExtractor.ExtractBefore(postCondition.Test)
+		testExtraction := beforeExtractor.ExtractBefore(postCondition.Test)
 
 		extractedExpressions := testExtraction.ExtractedExpressions
 
 		newPostCondition.Test = testExtraction.RewrittenExpression
 
 		if postCondition.Message != nil {
-			messageExtraction := checker.beforeExtractor.ExtractBefore(postCondition.Message)
+			messageExtraction := beforeExtractor.ExtractBefore(postCondition.Message)
 
 			newPostCondition.Message = messageExtraction.RewrittenExpression
 
@@ -2179,9 +2322,18 @@ func (checker *Checker) rewritePostConditions(postConditions []*ast.Condition) P
 				Transfer: &ast.Transfer{
 					Operation: ast.TransferOperationCopy,
 				},
-				Value: extractedExpression.Expression,
 			}
 
+			// The declaration is synthesized, so let it point to the extracted expression.
+			// NOTE: transplant before setting the value, which is not synthesized
+
+			ast.Transplant(
+				variableDeclaration,
+				ast.NewRangeFromPositioned(extractedExpression.Expression),
+			)
+
+			variableDeclaration.Value = extractedExpression.Expression
+
 			beforeStatements = append(beforeStatements,
 				variableDeclaration,
 			)
@@ -2212,6 +2364,13 @@ func (checker *Checker) checkTypeAnnotation(typeAnnotation *TypeAnnotation, pos
 				Range: ast.NewRangeFromPositioned(pos),
 			},
 		)
+
+	case TypeAnnotationStateDirectEntitlementTypeAnnotation:
+		checker.report(
+			&DirectEntitlementAnnotationError{
+				Range: ast.NewRangeFromPositioned(pos),
+			},
+		)
 	}
 
 	checker.checkInvalidInterfaceAsType(typeAnnotation.Type, pos)
@@ -2495,7 +2654,5 @@ func wrapWithOptionalIfNotNil(typ Type) Type {
 		return nil
 	}
 
-	return &OptionalType{
-		Type: typ,
-	}
+	return NewOptionalType(typ)
 }
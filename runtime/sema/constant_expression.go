@@ -0,0 +1,295 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"math/big"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// constantValue is the result of evaluating a constant expression.
+// It is either a bool, an integer (*big.Int), a string,
+// or a constantArray.
+//
+type constantValue interface{}
+
+// constantArray is an array which has a length known to the checker,
+// but which elements are not necessarily constant.
+//
+type constantArray struct {
+	length int
+}
+
+// evaluateConstantExpression evaluates the given expression,
+// which must have been checked already.
+//
+// Integer arithmetic is performed with arbitrary precision.
+//
+// If the expression cannot be evaluated, an error is returned.
+//
+func (checker *Checker) evaluateConstantExpression(expression ast.Expression) (constantValue, error) {
+
+	switch expression := expression.(type) {
+	case *ast.BoolExpression:
+		return expression.Value, nil
+
+	case *ast.IntegerExpression:
+		return new(big.Int).Set(expression.Value), nil
+
+	case *ast.StringExpression:
+		return expression.Value, nil
+
+	case *ast.ArrayExpression:
+		return constantArray{
+			length: len(expression.Values),
+		}, nil
+
+	case *ast.UnaryExpression:
+		return checker.evaluateConstantUnaryExpression(expression)
+
+	case *ast.BinaryExpression:
+		return checker.evaluateConstantBinaryExpression(expression)
+
+	case *ast.MemberExpression:
+		return checker.evaluateConstantMemberExpression(expression)
+	}
+
+	return nil, &NonConstantExpressionError{
+		Range: ast.NewRangeFromPositioned(expression),
+	}
+}
+
+func (checker *Checker) evaluateConstantUnaryExpression(expression *ast.UnaryExpression) (constantValue, error) {
+
+	value, err := checker.evaluateConstantExpression(expression.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expression.Operation {
+	case ast.OperationNegate:
+		if value, ok := value.(bool); ok {
+			return !value, nil
+		}
+
+	case ast.OperationMinus:
+		if value, ok := value.(*big.Int); ok {
+			return new(big.Int).Neg(value), nil
+		}
+	}
+
+	return nil, &NonConstantExpressionError{
+		Range: ast.NewRangeFromPositioned(expression),
+	}
+}
+
+func (checker *Checker) evaluateConstantBinaryExpression(expression *ast.BinaryExpression) (constantValue, error) {
+
+	left, err := checker.evaluateConstantExpression(expression.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit the logical operators, like the interpreter
+
+	if leftBool, ok := left.(bool); ok {
+		switch expression.Operation {
+		case ast.OperationOr:
+			if leftBool {
+				return true, nil
+			}
+		case ast.OperationAnd:
+			if !leftBool {
+				return false, nil
+			}
+		}
+	}
+
+	right, err := checker.evaluateConstantExpression(expression.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch left := left.(type) {
+	case bool:
+		if right, ok := right.(bool); ok {
+			switch expression.Operation {
+			case ast.OperationOr, ast.OperationAnd:
+				return right, nil
+			case ast.OperationEqual:
+				return left == right, nil
+			case ast.OperationNotEqual:
+				return left != right, nil
+			}
+		}
+
+	case string:
+		if right, ok := right.(string); ok {
+			switch expression.Operation {
+			case ast.OperationEqual:
+				return left == right, nil
+			case ast.OperationNotEqual:
+				return left != right, nil
+			}
+		}
+
+	case *big.Int:
+		if right, ok := right.(*big.Int); ok {
+			return evaluateConstantIntegerOperation(expression, left, right)
+		}
+	}
+
+	return nil, &NonConstantExpressionError{
+		Range: ast.NewRangeFromPositioned(expression),
+	}
+}
+
+func evaluateConstantIntegerOperation(
+	expression *ast.BinaryExpression,
+	left, right *big.Int,
+) (constantValue, error) {
+
+	switch expression.Operation {
+	case ast.OperationEqual:
+		return left.Cmp(right) == 0, nil
+	case ast.OperationNotEqual:
+		return left.Cmp(right) != 0, nil
+	case ast.OperationLess:
+		return left.Cmp(right) < 0, nil
+	case ast.OperationLessEqual:
+		return left.Cmp(right) <= 0, nil
+	case ast.OperationGreater:
+		return left.Cmp(right) > 0, nil
+	case ast.OperationGreaterEqual:
+		return left.Cmp(right) >= 0, nil
+	case ast.OperationPlus:
+		return new(big.Int).Add(left, right), nil
+	case ast.OperationMinus:
+		return new(big.Int).Sub(left, right), nil
+	case ast.OperationMul:
+		return new(big.Int).Mul(left, right), nil
+
+	case ast.OperationDiv, ast.OperationMod:
+		if right.Sign() == 0 {
+			return nil, &ConstantDivisionByZeroError{
+				Range: ast.NewRangeFromPositioned(expression),
+			}
+		}
+
+		// NOTE: truncated division, like the interpreter
+		if expression.Operation == ast.OperationDiv {
+			return new(big.Int).Quo(left, right), nil
+		}
+		return new(big.Int).Rem(left, right), nil
+	}
+
+	return nil, &NonConstantExpressionError{
+		Range: ast.NewRangeFromPositioned(expression),
+	}
+}
+
+func (checker *Checker) evaluateConstantMemberExpression(expression *ast.MemberExpression) (constantValue, error) {
+
+	if !expression.Optional {
+
+		switch expression.Identifier.Identifier {
+		case "length":
+			value, err := checker.evaluateConstantExpression(expression.Expression)
+			if err != nil {
+				return nil, err
+			}
+
+			switch value := value.(type) {
+			case constantArray:
+				return big.NewInt(int64(value.length)), nil
+			case string:
+				return big.NewInt(int64(uniseg.GraphemeClusterCount(value))), nil
+			}
+
+		case EnumAllCasesFieldName:
+			caseCount, ok := checker.enumCaseCount(expression.Expression)
+			if ok {
+				return constantArray{
+					length: caseCount,
+				}, nil
+			}
+		}
+	}
+
+	return nil, &NonConstantExpressionError{
+		Range: ast.NewRangeFromPositioned(expression),
+	}
+}
+
+// enumCaseCount returns the number of cases of the enum,
+// if the given expression refers to an enum constructor
+// which has the synthetic `allCases` member.
+//
+func (checker *Checker) enumCaseCount(expression ast.Expression) (int, bool) {
+
+	identifierExpression, ok := expression.(*ast.IdentifierExpression)
+	if !ok {
+		return 0, false
+	}
+
+	variable := checker.valueActivations.Find(identifierExpression.Identifier.Identifier)
+	if variable == nil {
+		return 0, false
+	}
+
+	constructorType, ok := variable.Type.(*FunctionType)
+	if !ok || !constructorType.IsConstructor || constructorType.Members == nil {
+		return 0, false
+	}
+
+	optionalType, ok := constructorType.ReturnTypeAnnotation.Type.(*OptionalType)
+	if !ok {
+		return 0, false
+	}
+
+	enumType, ok := optionalType.Type.(*CompositeType)
+	if !ok || enumType.Kind != common.CompositeKindEnum {
+		return 0, false
+	}
+
+	// An enum case might be named like the synthetic member
+
+	allCasesMember, ok := constructorType.Members.Get(EnumAllCasesFieldName)
+	if !ok {
+		return 0, false
+	}
+	if _, ok := allCasesMember.TypeAnnotation.Type.(*VariableSizedType); !ok {
+		return 0, false
+	}
+
+	caseCount := 0
+	constructorType.Members.Foreach(func(_ string, member *Member) {
+		if member.DeclarationKind == common.DeclarationKindField &&
+			member.TypeAnnotation.Type == enumType {
+
+			caseCount++
+		}
+	})
+
+	return caseCount, true
+}
@@ -152,6 +152,18 @@ const HashAlgorithmTypeHashWithTagFunctionDocString = `
 Returns the hash of the given data and tag
 `
 
+const HashAlgorithmTypeNewHasherFunctionName = "newHasher"
+
+var HashAlgorithmTypeNewHasherFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		HasherType,
+	),
+}
+
+const HashAlgorithmTypeNewHasherFunctionDocString = `
+Returns a new hasher, which hashes data incrementally
+`
+
 var HashAlgorithmType = newNativeEnumType(
 	HashAlgorithmTypeName,
 	UInt8Type,
@@ -169,6 +181,12 @@ var HashAlgorithmType = newNativeEnumType(
 				HashAlgorithmTypeHashWithTagFunctionType,
 				HashAlgorithmTypeHashWithTagFunctionDocString,
 			),
+			NewPublicFunctionMember(
+				enumType,
+				HashAlgorithmTypeNewHasherFunctionName,
+				HashAlgorithmTypeNewHasherFunctionType,
+				HashAlgorithmTypeNewHasherFunctionDocString,
+			),
 		}
 	},
 )
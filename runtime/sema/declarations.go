@@ -34,6 +34,26 @@ type ValueDeclaration interface {
 	ValueDeclarationAvailable(common.Location) bool
 }
 
+// Deprecation describes why a declaration is deprecated,
+// and what should be used instead.
+//
+type Deprecation struct {
+	// Replacement is the optional name of the declaration which should be used instead
+	Replacement string
+	// Message is an optional explanation
+	Message string
+}
+
+// DeprecatableValueDeclaration is a value declaration which may be deprecated.
+// Uses of a deprecated value declaration are still valid, but are reported as hints.
+//
+type DeprecatableValueDeclaration interface {
+	ValueDeclaration
+	// ValueDeclarationDeprecation returns the deprecation of the declaration,
+	// or nil if the declaration is not deprecated
+	ValueDeclarationDeprecation() *Deprecation
+}
+
 type TypeDeclaration interface {
 	TypeDeclarationName() string
 	TypeDeclarationType() Type
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 )
 
 type MemberInfo struct {
@@ -72,6 +73,9 @@ type Elaboration struct {
 	InterfaceNestedDeclarations         map[*ast.InterfaceDeclaration]map[string]ast.Declaration
 	PostConditionsRewrite               map[*ast.Conditions]PostConditionsRewrite
 	EmitStatementEventTypes             map[*ast.EmitStatement]*CompositeType
+	AttachExpressionTypes               map[*ast.AttachExpression]*CompositeType
+	RemoveStatementAttachmentTypes      map[*ast.RemoveStatement]*CompositeType
+	AttachmentAccessTypes               map[*ast.IndexExpression]*CompositeType
 	CompositeTypes                      map[TypeID]*CompositeType
 	InterfaceTypes                      map[TypeID]*InterfaceType
 	IdentifierInInvocationTypes         map[*ast.IdentifierExpression]Type
@@ -83,6 +87,16 @@ type Elaboration struct {
 	EffectivePredeclaredTypes           map[string]TypeDeclaration
 	isChecking                          bool
 	ReferenceExpressionBorrowTypes      map[*ast.ReferenceExpression]*ReferenceType
+	PathExpressionDomains               map[*ast.PathExpression]common.PathDomain
+	EntitlementDeclarationTypes         map[*ast.EntitlementDeclaration]*EntitlementType
+	EntitlementMappingDeclarationTypes  map[*ast.EntitlementMappingDeclaration]*EntitlementMapType
+	EntitlementTypes                    map[TypeID]*EntitlementType
+	EntitlementMapTypes                 map[TypeID]*EntitlementMapType
+	// MemberExpressionMappedTypes are the types of members with mapped entitlement access,
+	// which are accessed through an entitled or unauthorized reference
+	MemberExpressionMappedTypes map[*ast.MemberExpression]Type
+	// LanguageVersion is the language version the program was checked with
+	LanguageVersion LanguageVersion
 }
 
 func NewElaboration() *Elaboration {
@@ -126,6 +140,9 @@ func NewElaboration() *Elaboration {
 		InterfaceNestedDeclarations:         map[*ast.InterfaceDeclaration]map[string]ast.Declaration{},
 		PostConditionsRewrite:               map[*ast.Conditions]PostConditionsRewrite{},
 		EmitStatementEventTypes:             map[*ast.EmitStatement]*CompositeType{},
+		AttachExpressionTypes:               map[*ast.AttachExpression]*CompositeType{},
+		RemoveStatementAttachmentTypes:      map[*ast.RemoveStatement]*CompositeType{},
+		AttachmentAccessTypes:               map[*ast.IndexExpression]*CompositeType{},
 		CompositeTypes:                      map[TypeID]*CompositeType{},
 		InterfaceTypes:                      map[TypeID]*InterfaceType{},
 		IdentifierInInvocationTypes:         map[*ast.IdentifierExpression]Type{},
@@ -135,6 +152,12 @@ func NewElaboration() *Elaboration {
 		EffectivePredeclaredValues:          map[string]ValueDeclaration{},
 		EffectivePredeclaredTypes:           map[string]TypeDeclaration{},
 		ReferenceExpressionBorrowTypes:      map[*ast.ReferenceExpression]*ReferenceType{},
+		PathExpressionDomains:               map[*ast.PathExpression]common.PathDomain{},
+		EntitlementDeclarationTypes:         map[*ast.EntitlementDeclaration]*EntitlementType{},
+		EntitlementMappingDeclarationTypes:  map[*ast.EntitlementMappingDeclaration]*EntitlementMapType{},
+		EntitlementTypes:                    map[TypeID]*EntitlementType{},
+		EntitlementMapTypes:                 map[TypeID]*EntitlementMapType{},
+		MemberExpressionMappedTypes:         map[*ast.MemberExpression]Type{},
 	}
 }
 
@@ -172,3 +195,83 @@ func (e *Elaboration) FunctionEntryPointType() (*FunctionType, error) {
 
 	return functionType, nil
 }
+
+// DeclaredCompositeTypes returns all composite types declared in the program,
+// including nested composite types, in declaration order.
+//
+// Imported and predeclared types are not included.
+//
+func (e *Elaboration) DeclaredCompositeTypes() []*CompositeType {
+	var result []*CompositeType
+
+	e.GlobalTypes.Foreach(func(_ string, variable *Variable) {
+		VisitThisAndNested(variable.Type, func(ty Type) {
+			if compositeType, ok := ty.(*CompositeType); ok {
+				result = append(result, compositeType)
+			}
+		})
+	})
+
+	return result
+}
+
+// DeclaredInterfaceTypes returns all interface types declared in the program,
+// including nested interface types, in declaration order.
+//
+// Imported and predeclared types are not included.
+//
+func (e *Elaboration) DeclaredInterfaceTypes() []*InterfaceType {
+	var result []*InterfaceType
+
+	e.GlobalTypes.Foreach(func(_ string, variable *Variable) {
+		VisitThisAndNested(variable.Type, func(ty Type) {
+			if interfaceType, ok := ty.(*InterfaceType); ok {
+				result = append(result, interfaceType)
+			}
+		})
+	})
+
+	return result
+}
+
+// GlobalFunction is a function declared at the top-level of a program
+//
+type GlobalFunction struct {
+	Identifier string
+	Access     ast.Access
+	Type       *FunctionType
+}
+
+// DeclaredGlobalFunctions returns all functions declared at the top-level of the program,
+// in declaration order.
+//
+// Imported and predeclared functions are not included.
+//
+func (e *Elaboration) DeclaredGlobalFunctions() []GlobalFunction {
+	var result []GlobalFunction
+
+	e.GlobalValues.Foreach(func(name string, variable *Variable) {
+		if variable.DeclarationKind != common.DeclarationKindFunction ||
+			variable.IsBaseValue {
+
+			return
+		}
+
+		if _, ok := e.EffectivePredeclaredValues[name]; ok {
+			return
+		}
+
+		functionType, ok := variable.Type.(*FunctionType)
+		if !ok {
+			return
+		}
+
+		result = append(result, GlobalFunction{
+			Identifier: name,
+			Access:     variable.Access,
+			Type:       functionType,
+		})
+	})
+
+	return result
+}
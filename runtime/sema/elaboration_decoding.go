@@ -0,0 +1,820 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// DecodeElaboration decodes an elaboration which was encoded using EncodeElaboration.
+//
+// The given program must be the result of parsing the code for which the elaboration was encoded,
+// and the given code hash must match the code hash given when encoding.
+// The given predeclared values and types must match the ones used when checking the program.
+//
+// The import handler is called to get the elaborations of imported programs,
+// which declare the imported types.
+//
+// If the encoded elaboration cannot be used for the program, an IncompatibleElaborationError is returned.
+// In that case, the program should be checked instead.
+//
+func DecodeElaboration(
+	data []byte,
+	program *ast.Program,
+	location common.Location,
+	codeHash []byte,
+	predeclaredValues []ValueDeclaration,
+	predeclaredTypes []TypeDeclaration,
+	importHandler func(location common.Location) (*Elaboration, error),
+) (
+	elaboration *Elaboration,
+	err error,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			var codecErr error
+			switch r := r.(type) {
+			case elaborationCodecError:
+				codecErr = r.err
+			case error:
+				codecErr = r
+			default:
+				codecErr = fmt.Errorf("%v", r)
+			}
+			elaboration = nil
+			err = fmt.Errorf("cannot decode elaboration: %w", codecErr)
+		}
+	}()
+
+	var encoded encodedElaboration
+	err = cbor.Unmarshal(data, &encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if encoded.Version != ElaborationEncodingVersion {
+		return nil, &IncompatibleElaborationError{
+			Message: fmt.Sprintf(
+				"version mismatch: expected %d, got %d",
+				ElaborationEncodingVersion,
+				encoded.Version,
+			),
+		}
+	}
+
+	initializeBuiltinTypes()
+
+	if !bytes.Equal(encoded.Fingerprint, builtinTypesFingerprint) {
+		return nil, &IncompatibleElaborationError{
+			Message: "built-in types mismatch",
+		}
+	}
+
+	if !bytes.Equal(encoded.CodeHash, codeHash) {
+		return nil, &IncompatibleElaborationError{
+			Message: "code hash mismatch",
+		}
+	}
+
+	decoder := &elaborationDecoder{
+		encoded:       &encoded,
+		location:      location,
+		importHandler: importHandler,
+		elaboration:   NewElaboration(),
+		types:         make([]Type, len(encoded.Types)),
+	}
+
+	err = decoder.decodePredeclarations(predeclaredValues, predeclaredTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder.decode(program)
+
+	decoder.elaboration.LanguageVersion = encoded.LanguageVersion
+
+	return decoder.elaboration, nil
+}
+
+type elaborationDecoder struct {
+	encoded          *encodedElaboration
+	location         common.Location
+	importHandler    func(location common.Location) (*Elaboration, error)
+	elaboration      *Elaboration
+	predeclaredTypes map[TypeID]Type
+	elements         *elaborationElements
+	types            []Type
+}
+
+func (d *elaborationDecoder) decodePredeclarations(
+	predeclaredValues []ValueDeclaration,
+	predeclaredTypes []TypeDeclaration,
+) error {
+
+	elaboration := d.elaboration
+
+	// Only the predeclared values and types which were effective when checking
+	// are effective for the decoded elaboration, and they must not have changed
+
+	valueDeclarations := map[string]ValueDeclaration{}
+	for _, declaration := range predeclaredValues {
+		valueDeclarations[declaration.ValueDeclarationName()] = declaration
+	}
+
+	for _, encodedValue := range d.encoded.PredeclaredValues {
+		declaration, ok := valueDeclarations[encodedValue.Name]
+		if !ok || string(declaration.ValueDeclarationType().ID()) != encodedValue.TypeID {
+			return &IncompatibleElaborationError{
+				Message: fmt.Sprintf("predeclared value mismatch: %s", encodedValue.Name),
+			}
+		}
+		elaboration.EffectivePredeclaredValues[encodedValue.Name] = declaration
+	}
+
+	typeDeclarations := map[string]TypeDeclaration{}
+	for _, declaration := range predeclaredTypes {
+		typeDeclarations[declaration.TypeDeclarationName()] = declaration
+	}
+
+	effectiveTypeDeclarations := make([]TypeDeclaration, 0, len(d.encoded.PredeclaredTypes))
+
+	for _, encodedType := range d.encoded.PredeclaredTypes {
+		declaration, ok := typeDeclarations[encodedType.Name]
+		if !ok || string(declaration.TypeDeclarationType().ID()) != encodedType.TypeID {
+			return &IncompatibleElaborationError{
+				Message: fmt.Sprintf("predeclared type mismatch: %s", encodedType.Name),
+			}
+		}
+		elaboration.EffectivePredeclaredTypes[encodedType.Name] = declaration
+		effectiveTypeDeclarations = append(effectiveTypeDeclarations, declaration)
+	}
+
+	d.predeclaredTypes = predeclaredTypesByID(effectiveTypeDeclarations)
+
+	return nil
+}
+
+func (d *elaborationDecoder) decode(program *ast.Program) {
+
+	elaboration := d.elaboration
+	encoded := d.encoded
+
+	// Index the elements of the program.
+	// The post-conditions rewrites are not part of the program,
+	// so perform them again, in the same order
+
+	rewrites := map[int]struct{}{}
+	for _, index := range encoded.Rewrites {
+		rewrites[index] = struct{}{}
+	}
+
+	beforeExtractor := NewBeforeExtractor(func(err error) {
+		panicElaborationCodecError("%w", err)
+	})
+
+	d.elements = newElaborationElements(
+		program,
+		func(conditions *ast.Conditions, index int) (PostConditionsRewrite, bool) {
+			if _, ok := rewrites[index]; !ok {
+				return PostConditionsRewrite{}, false
+			}
+			delete(rewrites, index)
+
+			rewrite := rewritePostConditions(beforeExtractor, *conditions)
+			elaboration.PostConditionsRewrite[conditions] = rewrite
+			return rewrite, true
+		},
+	)
+
+	if len(rewrites) > 0 {
+		panicElaborationCodecError("post-conditions rewrite for unknown conditions")
+	}
+
+	// Generically encoded fields, which are keyed by program elements
+
+	elaborationValue := reflect.ValueOf(elaboration).Elem()
+	elaborationType := elaborationValue.Type()
+
+	for _, encodedField := range encoded.Fields {
+		field, ok := elaborationType.FieldByName(encodedField.Name)
+		if !ok {
+			panicElaborationCodecError("unknown elaboration field: %s", encodedField.Name)
+		}
+
+		if _, ok := specialElaborationFields[field.Name]; ok {
+			panicElaborationCodecError("unsupported elaboration field: %s", field.Name)
+		}
+
+		valueKind := elaborationFieldValueKind(field)
+		if valueKind == elaborationValueKindUnknown {
+			panicElaborationCodecError("unsupported elaboration field: %s", field.Name)
+		}
+
+		fieldValue := elaborationValue.FieldByIndex(field.Index)
+
+		d.decodeEntries(fieldValue, encodedField.Entries, valueKind)
+	}
+
+	// Global values and types
+
+	d.decodeVariables(elaboration.GlobalValues, encoded.GlobalValues)
+	d.decodeVariables(elaboration.GlobalTypes, encoded.GlobalTypes)
+
+	// Transaction, composite, and interface types
+
+	for _, index := range encoded.TransactionTypes {
+		transactionType, ok := d.decodeType(index).(*TransactionType)
+		if !ok {
+			panicElaborationCodecError("invalid transaction type")
+		}
+		elaboration.TransactionTypes = append(elaboration.TransactionTypes, transactionType)
+	}
+
+	for _, index := range encoded.CompositeTypes {
+		compositeType, ok := d.decodeType(index).(*CompositeType)
+		if !ok {
+			panicElaborationCodecError("invalid composite type")
+		}
+		elaboration.CompositeTypes[compositeType.ID()] = compositeType
+	}
+
+	for _, index := range encoded.InterfaceTypes {
+		interfaceType, ok := d.decodeType(index).(*InterfaceType)
+		if !ok {
+			panicElaborationCodecError("invalid interface type")
+		}
+		elaboration.InterfaceTypes[interfaceType.ID()] = interfaceType
+	}
+
+	// The mappings from types to declarations are the inverses
+	// of the mappings from declarations to types
+
+	for declaration, compositeType := range elaboration.CompositeDeclarationTypes { //nolint:maprangecheck
+		elaboration.CompositeTypeDeclarations[compositeType] = declaration
+	}
+
+	for declaration, interfaceType := range elaboration.InterfaceDeclarationTypes { //nolint:maprangecheck
+		elaboration.InterfaceTypeDeclarations[interfaceType] = declaration
+	}
+}
+
+func (d *elaborationDecoder) decodeEntries(
+	fieldValue reflect.Value,
+	entries []encodedEntry,
+	valueKind elaborationValueKind,
+) {
+	keyType := fieldValue.Type().Key()
+	valueType := fieldValue.Type().Elem()
+
+	for _, entry := range entries {
+		element, err := d.elements.element(entry.Element)
+		if err != nil {
+			panicElaborationCodecError("%w", err)
+		}
+
+		key := reflect.ValueOf(element)
+		if !key.Type().AssignableTo(keyType) {
+			panicElaborationCodecError(
+				"invalid element for %s: %T",
+				keyType,
+				element,
+			)
+		}
+
+		value := d.decodeEntryValue(element, entry, valueKind, valueType)
+
+		fieldValue.SetMapIndex(key, value)
+	}
+}
+
+func (d *elaborationDecoder) decodeEntryValue(
+	element interface{},
+	entry encodedEntry,
+	valueKind elaborationValueKind,
+	valueType reflect.Type,
+) reflect.Value {
+
+	var value interface{}
+
+	switch valueKind {
+	case elaborationValueKindNone:
+		return reflect.Zero(valueType)
+
+	case elaborationValueKindType:
+		ty := d.decodeType(entry.Type)
+		if ty == nil {
+			return reflect.Zero(valueType)
+		}
+		value = ty
+
+	case elaborationValueKindTypes:
+		value = d.decodeTypes(entry.Types)
+
+	case elaborationValueKindMemberInfo:
+		value = d.decodeMemberInfo(element, entry.MemberInfo)
+
+	case elaborationValueKindTypeArguments:
+		typeArguments := NewTypeParameterTypeOrderedMap()
+		for _, typeArgument := range entry.TypeArgs {
+			typeArguments.Set(
+				d.decodeTypeParameter(typeArgument.TypeParameter),
+				d.decodeType(typeArgument.Type),
+			)
+		}
+		value = typeArguments
+
+	case elaborationValueKindDictionaryEntryTypes:
+		if len(entry.Types)%2 != 0 {
+			panicElaborationCodecError("invalid dictionary entry types")
+		}
+		entryTypes := make([]DictionaryEntryType, len(entry.Types)/2)
+		for i := range entryTypes {
+			entryTypes[i] = DictionaryEntryType{
+				KeyType:   d.decodeType(entry.Types[i*2]),
+				ValueType: d.decodeType(entry.Types[i*2+1]),
+			}
+		}
+		value = entryTypes
+
+	case elaborationValueKindDeclarations:
+		declarations := make(map[string]ast.Declaration, len(entry.Declarations))
+		for _, encodedDeclaration := range entry.Declarations {
+			element, err := d.elements.element(encodedDeclaration.Element)
+			if err != nil {
+				panicElaborationCodecError("%w", err)
+			}
+			declaration, ok := element.(ast.Declaration)
+			if !ok {
+				panicElaborationCodecError("invalid declaration: %T", element)
+			}
+			declarations[encodedDeclaration.Name] = declaration
+		}
+		value = declarations
+
+	case elaborationValueKindResolvedLocations:
+		resolvedLocations := make([]ResolvedLocation, len(entry.Locations))
+		for i, encodedResolvedLocation := range entry.Locations {
+			location, _, err := common.DecodeTypeID(encodedResolvedLocation.Location)
+			if err != nil {
+				panicElaborationCodecError("%w", err)
+			}
+
+			var identifiers []ast.Identifier
+			for _, identifier := range encodedResolvedLocation.Identifiers {
+				identifiers = append(identifiers, decodeIdentifier(identifier))
+			}
+
+			resolvedLocations[i] = ResolvedLocation{
+				Location:    location,
+				Identifiers: identifiers,
+			}
+		}
+		value = resolvedLocations
+
+	case elaborationValueKindUint:
+		result := reflect.New(valueType).Elem()
+		result.SetUint(entry.Value)
+		return result
+
+	default:
+		panicElaborationCodecError("unsupported value kind: %d", valueKind)
+	}
+
+	result := reflect.ValueOf(value)
+	if !result.Type().AssignableTo(valueType) {
+		panicElaborationCodecError("invalid value for %s: %T", valueType, value)
+	}
+	return result
+}
+
+func (d *elaborationDecoder) decodeMemberInfo(element interface{}, encoded *encodedMemberInfo) MemberInfo {
+	if encoded == nil {
+		panicElaborationCodecError("missing member info")
+	}
+
+	memberInfo := MemberInfo{
+		AccessedType: d.decodeType(encoded.AccessedType),
+		IsOptional:   encoded.IsOptional,
+	}
+
+	if !encoded.HasMember {
+		return memberInfo
+	}
+
+	// Resolve the member again, like the checker does
+
+	memberExpression, ok := element.(*ast.MemberExpression)
+	if !ok {
+		panicElaborationCodecError("invalid member expression: %T", element)
+	}
+
+	ty := memberInfo.AccessedType
+	if encoded.IsOptional {
+		optionalType, ok := ty.(*OptionalType)
+		if !ok {
+			panicElaborationCodecError("invalid optional accessed type: %s", ty)
+		}
+		ty = optionalType.Type
+	}
+
+	identifier := memberExpression.Identifier.Identifier
+
+	resolver, ok := ty.GetMembers()[identifier]
+	if !ok {
+		panicElaborationCodecError("unknown member: %s.%s", ty, identifier)
+	}
+
+	memberInfo.Member = resolver.Resolve(
+		identifier,
+		ast.NewRangeFromPositioned(memberExpression.Expression),
+		func(err error) {
+			panicElaborationCodecError("%w", err)
+		},
+	)
+	if memberInfo.Member == nil {
+		panicElaborationCodecError("unknown member: %s.%s", ty, identifier)
+	}
+
+	return memberInfo
+}
+
+func (d *elaborationDecoder) decodeVariables(variables *StringVariableOrderedMap, encoded []encodedVariable) {
+	for _, encodedVariable := range encoded {
+		variable := &Variable{
+			Identifier:      encodedVariable.Identifier,
+			DeclarationKind: encodedVariable.DeclarationKind,
+			Type:            d.decodeType(encodedVariable.Type),
+			Access:          encodedVariable.Access,
+			IsConstant:      encodedVariable.IsConstant,
+			IsBaseValue:     encodedVariable.IsBaseValue,
+			ActivationDepth: encodedVariable.ActivationDepth,
+			ArgumentLabels:  encodedVariable.ArgumentLabels,
+			DocString:       encodedVariable.DocString,
+		}
+
+		if encodedVariable.Pos != nil {
+			pos := decodePosition(*encodedVariable.Pos)
+			variable.Pos = &pos
+		}
+
+		variables.Set(variable.Identifier, variable)
+	}
+}
+
+func decodePosition(position encodedPosition) ast.Position {
+	return ast.Position{
+		Offset: position.Offset,
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+func decodeIdentifier(identifier encodedIdentifier) ast.Identifier {
+	return ast.Identifier{
+		Identifier: identifier.Identifier,
+		Pos:        decodePosition(identifier.Pos),
+	}
+}
+
+func (d *elaborationDecoder) decodeTypes(indices []int) []Type {
+	if indices == nil {
+		return nil
+	}
+	result := make([]Type, len(indices))
+	for i, index := range indices {
+		result[i] = d.decodeType(index)
+	}
+	return result
+}
+
+func (d *elaborationDecoder) decodeTypeAnnotation(encoded encodedTypeAnnotation) *TypeAnnotation {
+	return &TypeAnnotation{
+		IsResource: encoded.IsResource,
+		Type:       d.decodeType(encoded.Type),
+	}
+}
+
+func (d *elaborationDecoder) decodeTypeParameter(encoded encodedTypeParameter) *TypeParameter {
+	return &TypeParameter{
+		Name:      encoded.Name,
+		TypeBound: d.decodeType(encoded.TypeBound),
+		Optional:  encoded.Optional,
+	}
+}
+
+func (d *elaborationDecoder) decodeParameters(encoded []encodedParameter) []*Parameter {
+	if encoded == nil {
+		return nil
+	}
+	result := make([]*Parameter, len(encoded))
+	for i, encodedParameter := range encoded {
+		result[i] = &Parameter{
+			Label:          encodedParameter.Label,
+			Identifier:     encodedParameter.Identifier,
+			TypeAnnotation: d.decodeTypeAnnotation(encodedParameter.TypeAnnotation),
+		}
+	}
+	return result
+}
+
+func (d *elaborationDecoder) decodeMembers(encoded []encodedMember) *StringMemberOrderedMap {
+	members := NewStringMemberOrderedMap()
+	for _, encodedMember := range encoded {
+		members.Set(
+			encodedMember.Name,
+			&Member{
+				ContainerType:         d.decodeType(encodedMember.ContainerType),
+				Access:                encodedMember.Access,
+				Identifier:            decodeIdentifier(encodedMember.Identifier),
+				TypeAnnotation:        d.decodeTypeAnnotation(encodedMember.TypeAnnotation),
+				DeclarationKind:       encodedMember.DeclarationKind,
+				VariableKind:          encodedMember.VariableKind,
+				ArgumentLabels:        encodedMember.ArgumentLabels,
+				Predeclared:           encodedMember.Predeclared,
+				IgnoreInSerialization: encodedMember.IgnoreInSerialization,
+				DocString:             encodedMember.DocString,
+			},
+		)
+	}
+	return members
+}
+
+func (d *elaborationDecoder) decodeNestedTypes(encoded []encodedNamedType) *StringTypeOrderedMap {
+	nestedTypes := NewStringTypeOrderedMap()
+	for _, encodedNestedType := range encoded {
+		nestedTypes.Set(
+			encodedNestedType.Name,
+			d.decodeType(encodedNestedType.Type),
+		)
+	}
+	return nestedTypes
+}
+
+// decodeType returns the type for the given reference,
+// decoding it if it was not decoded yet.
+//
+func (d *elaborationDecoder) decodeType(index int) Type {
+	if index == 0 {
+		return nil
+	}
+
+	if index < 0 || index > len(d.encoded.Types) {
+		panicElaborationCodecError("invalid type index: %d", index)
+	}
+
+	ty := d.types[index-1]
+	if ty != nil {
+		return ty
+	}
+
+	encoded := &d.encoded.Types[index-1]
+
+	switch encoded.Kind {
+	case encodedTypeKindBuiltin:
+		ty = d.decodeBuiltinType(encoded.ID)
+
+	case encodedTypeKindPredeclared:
+		var ok bool
+		ty, ok = d.predeclaredTypes[TypeID(encoded.ID)]
+		if !ok {
+			panicElaborationCodecError("unknown predeclared type: %s", encoded.ID)
+		}
+
+	case encodedTypeKindImported:
+		ty = d.decodeImportedType(encoded.ID)
+
+	case encodedTypeKindOptional:
+		ty = &OptionalType{
+			Type: d.decodeType(encoded.Type),
+		}
+
+	case encodedTypeKindVariableSized:
+		ty = &VariableSizedType{
+			Type: d.decodeType(encoded.Type),
+		}
+
+	case encodedTypeKindConstantSized:
+		ty = &ConstantSizedType{
+			Type: d.decodeType(encoded.Type),
+			Size: encoded.Size,
+		}
+
+	case encodedTypeKindDictionary:
+		ty = &DictionaryType{
+			KeyType:   d.decodeType(encoded.Type),
+			ValueType: d.decodeType(encoded.ValueType),
+		}
+
+	case encodedTypeKindReference:
+		ty = &ReferenceType{
+			Type:       d.decodeType(encoded.Type),
+			Authorized: encoded.Authorized,
+		}
+
+	case encodedTypeKindRestricted:
+		ty = &RestrictedType{
+			Type:         d.decodeType(encoded.Type),
+			Restrictions: d.decodeInterfaceTypes(encoded.Types),
+		}
+
+	case encodedTypeKindCapability:
+		ty = &CapabilityType{
+			BorrowType: d.decodeType(encoded.Type),
+		}
+
+	case encodedTypeKindInclusiveRange:
+		ty = &InclusiveRangeType{
+			MemberType: d.decodeType(encoded.Type),
+		}
+
+	case encodedTypeKindGeneric:
+		if encoded.TypeParameter == nil {
+			panicElaborationCodecError("missing type parameter")
+		}
+		ty = &GenericType{
+			TypeParameter: d.decodeTypeParameter(*encoded.TypeParameter),
+		}
+
+	// Function, transaction, composite, and interface types may refer to themselves,
+	// so they are recorded before they are filled in
+
+	case encodedTypeKindFunction:
+		functionType := &FunctionType{}
+		d.types[index-1] = functionType
+		d.decodeFunctionType(functionType, encoded)
+		return functionType
+
+	case encodedTypeKindTransaction:
+		transactionType := &TransactionType{}
+		d.types[index-1] = transactionType
+		transactionType.Members = d.decodeMembers(encoded.Members)
+		transactionType.Fields = encoded.Fields
+		transactionType.Parameters = d.decodeParameters(encoded.Parameters)
+		transactionType.PrepareParameters = d.decodeParameters(encoded.PrepareParameters)
+		return transactionType
+
+	case encodedTypeKindComposite:
+		compositeType := &CompositeType{}
+		d.types[index-1] = compositeType
+		d.decodeCompositeType(compositeType, encoded)
+		return compositeType
+
+	case encodedTypeKindInterface:
+		interfaceType := &InterfaceType{}
+		d.types[index-1] = interfaceType
+		d.decodeInterfaceType(interfaceType, encoded)
+		return interfaceType
+
+	default:
+		panicElaborationCodecError("unsupported type kind: %d", encoded.Kind)
+	}
+
+	d.types[index-1] = ty
+
+	return ty
+}
+
+func (d *elaborationDecoder) decodeBuiltinType(typeID string) Type {
+	ty, ok := builtinTypes[TypeID(typeID)]
+	if ok {
+		return ty
+	}
+
+	// Address types are not registered as built-in types,
+	// as they are created for each address type
+
+	if typeID == string((&AddressType{}).ID()) {
+		return &AddressType{}
+	}
+
+	panicElaborationCodecError("unknown built-in type: %s", typeID)
+	return nil
+}
+
+func (d *elaborationDecoder) decodeImportedType(typeID string) Type {
+	location, _, err := common.DecodeTypeID(typeID)
+	if err != nil {
+		panicElaborationCodecError("%w", err)
+	}
+
+	if d.importHandler == nil {
+		panicElaborationCodecError("cannot import type: %s", typeID)
+	}
+
+	importedElaboration, err := d.importHandler(location)
+	if err != nil {
+		panicElaborationCodecError("%w", err)
+	}
+
+	if compositeType, ok := importedElaboration.CompositeTypes[TypeID(typeID)]; ok {
+		return compositeType
+	}
+
+	if interfaceType, ok := importedElaboration.InterfaceTypes[TypeID(typeID)]; ok {
+		return interfaceType
+	}
+
+	panicElaborationCodecError("unknown imported type: %s", typeID)
+	return nil
+}
+
+func (d *elaborationDecoder) decodeInterfaceTypes(indices []int) []*InterfaceType {
+	if indices == nil {
+		return nil
+	}
+	result := make([]*InterfaceType, len(indices))
+	for i, index := range indices {
+		interfaceType, ok := d.decodeType(index).(*InterfaceType)
+		if !ok {
+			panicElaborationCodecError("invalid interface type")
+		}
+		result[i] = interfaceType
+	}
+	return result
+}
+
+func (d *elaborationDecoder) decodeFunctionType(functionType *FunctionType, encoded *encodedType) {
+	for _, typeParameter := range encoded.TypeParameters {
+		functionType.TypeParameters = append(
+			functionType.TypeParameters,
+			d.decodeTypeParameter(typeParameter),
+		)
+	}
+
+	functionType.IsConstructor = encoded.IsConstructor
+	functionType.Parameters = d.decodeParameters(encoded.Parameters)
+
+	if encoded.ReturnTypeAnnotation != nil {
+		functionType.ReturnTypeAnnotation = d.decodeTypeAnnotation(*encoded.ReturnTypeAnnotation)
+	}
+
+	functionType.RequiredArgumentCount = encoded.RequiredArgumentCount
+
+	if encoded.HasMembers {
+		functionType.Members = d.decodeMembers(encoded.Members)
+	}
+}
+
+func (d *elaborationDecoder) decodeCompositeType(compositeType *CompositeType, encoded *encodedType) {
+	compositeType.Location = d.location
+	compositeType.Identifier = encoded.Identifier
+	compositeType.Kind = encoded.CompositeKind
+	compositeType.ExplicitInterfaceConformances = d.decodeInterfaceTypes(encoded.Types)
+
+	for _, index := range encoded.ImplicitConformances {
+		conformance, ok := d.decodeType(index).(*CompositeType)
+		if !ok {
+			panicElaborationCodecError("invalid composite type")
+		}
+		compositeType.ImplicitTypeRequirementConformances = append(
+			compositeType.ImplicitTypeRequirementConformances,
+			conformance,
+		)
+	}
+
+	if encoded.HasMembers {
+		compositeType.Members = d.decodeMembers(encoded.Members)
+	}
+	compositeType.Fields = encoded.Fields
+	compositeType.ConstructorParameters = d.decodeParameters(encoded.Parameters)
+	compositeType.nestedTypes = d.decodeNestedTypes(encoded.NestedTypes)
+	compositeType.containerType = d.decodeType(encoded.ContainerType)
+	compositeType.EnumRawType = d.decodeType(encoded.EnumRawType)
+	compositeType.BaseType = d.decodeType(encoded.BaseType)
+}
+
+func (d *elaborationDecoder) decodeInterfaceType(interfaceType *InterfaceType, encoded *encodedType) {
+	interfaceType.Location = d.location
+	interfaceType.Identifier = encoded.Identifier
+	interfaceType.CompositeKind = encoded.CompositeKind
+	if encoded.HasMembers {
+		interfaceType.Members = d.decodeMembers(encoded.Members)
+	}
+	interfaceType.Fields = encoded.Fields
+	interfaceType.InitializerParameters = d.decodeParameters(encoded.Parameters)
+	interfaceType.nestedTypes = d.decodeNestedTypes(encoded.NestedTypes)
+	interfaceType.containerType = d.decodeType(encoded.ContainerType)
+}
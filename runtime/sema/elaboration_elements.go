@@ -0,0 +1,351 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// elaborationElements assigns an index to each element of a program
+// which may be referred to by an elaboration, in a deterministic order.
+//
+// The elements include the nodes of the post-conditions rewrites,
+// which are produced by the checker and are not part of the program.
+//
+type elaborationElements struct {
+	elements []interface{}
+	indices  map[interface{}]int
+	// rewrite returns the post-conditions rewrite for the given conditions, if any.
+	rewrite func(conditions *ast.Conditions, index int) (PostConditionsRewrite, bool)
+}
+
+func newElaborationElements(
+	program *ast.Program,
+	rewrite func(conditions *ast.Conditions, index int) (PostConditionsRewrite, bool),
+) *elaborationElements {
+	elements := &elaborationElements{
+		indices: map[interface{}]int{},
+		rewrite: rewrite,
+	}
+	for _, declaration := range program.Declarations() {
+		elements.walkDeclaration(declaration)
+	}
+	return elements
+}
+
+// add records the given element and returns true,
+// or returns false if the element was already recorded before.
+//
+func (e *elaborationElements) add(element interface{}) bool {
+	if _, ok := e.indices[element]; ok {
+		return false
+	}
+	e.indices[element] = len(e.elements)
+	e.elements = append(e.elements, element)
+	return true
+}
+
+func (e *elaborationElements) walkDeclaration(declaration ast.Declaration) {
+	if !e.add(declaration) {
+		return
+	}
+
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		e.walkDeclarations(declaration.Members.Declarations())
+
+	case *ast.InterfaceDeclaration:
+		e.walkDeclarations(declaration.Members.Declarations())
+
+	case *ast.FunctionDeclaration:
+		e.walkFunctionBlock(declaration.FunctionBlock)
+
+	case *ast.SpecialFunctionDeclaration:
+		e.walkDeclaration(declaration.FunctionDeclaration)
+
+	case *ast.TransactionDeclaration:
+		for _, field := range declaration.Fields {
+			e.walkDeclaration(field)
+		}
+		if declaration.Prepare != nil {
+			e.walkDeclaration(declaration.Prepare)
+		}
+		e.walkConditions(declaration.PreConditions)
+		if declaration.Execute != nil {
+			e.walkDeclaration(declaration.Execute)
+		}
+		e.walkConditions(declaration.PostConditions)
+
+	case *ast.VariableDeclaration:
+		e.walkExpression(declaration.Value)
+		e.walkExpression(declaration.SecondValue)
+
+	case *ast.PragmaDeclaration:
+		e.walkExpression(declaration.Expression)
+
+	case *ast.FieldDeclaration,
+		*ast.EnumCaseDeclaration,
+		*ast.ImportDeclaration,
+		*ast.EntitlementDeclaration,
+		*ast.EntitlementMappingDeclaration:
+
+		break
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+func (e *elaborationElements) walkDeclarations(declarations []ast.Declaration) {
+	for _, declaration := range declarations {
+		e.walkDeclaration(declaration)
+	}
+}
+
+func (e *elaborationElements) walkFunctionBlock(functionBlock *ast.FunctionBlock) {
+	if functionBlock == nil || !e.add(functionBlock) {
+		return
+	}
+
+	e.walkConditions(functionBlock.PreConditions)
+	e.walkBlock(functionBlock.Block)
+	e.walkConditions(functionBlock.PostConditions)
+}
+
+func (e *elaborationElements) walkConditions(conditions *ast.Conditions) {
+	if conditions == nil || !e.add(conditions) {
+		return
+	}
+
+	index := e.indices[conditions]
+
+	for _, condition := range *conditions {
+		e.walkCondition(condition)
+	}
+
+	rewrite, ok := e.rewrite(conditions, index)
+	if !ok {
+		return
+	}
+
+	e.walkStatements(rewrite.BeforeStatements)
+
+	for _, condition := range rewrite.RewrittenPostConditions {
+		e.walkCondition(condition)
+	}
+}
+
+func (e *elaborationElements) walkCondition(condition *ast.Condition) {
+	if !e.add(condition) {
+		return
+	}
+
+	e.walkExpression(condition.Test)
+	e.walkExpression(condition.Message)
+}
+
+func (e *elaborationElements) walkBlock(block *ast.Block) {
+	if block == nil || !e.add(block) {
+		return
+	}
+
+	e.walkStatements(block.Statements)
+}
+
+func (e *elaborationElements) walkStatements(statements []ast.Statement) {
+	for _, statement := range statements {
+		e.walkStatement(statement)
+	}
+}
+
+func (e *elaborationElements) walkStatement(statement ast.Statement) {
+	if declaration, ok := statement.(ast.Declaration); ok {
+		e.walkDeclaration(declaration)
+		return
+	}
+
+	if !e.add(statement) {
+		return
+	}
+
+	switch statement := statement.(type) {
+	case *ast.ReturnStatement:
+		e.walkExpression(statement.Expression)
+
+	case *ast.BreakStatement,
+		*ast.ContinueStatement:
+
+		break
+
+	case *ast.IfStatement:
+		switch test := statement.Test.(type) {
+		case ast.Expression:
+			e.walkExpression(test)
+		case *ast.VariableDeclaration:
+			e.walkDeclaration(test)
+		default:
+			panic(errors.NewUnreachableError())
+		}
+		e.walkBlock(statement.Then)
+		e.walkBlock(statement.Else)
+
+	case *ast.WhileStatement:
+		e.walkExpression(statement.Test)
+		e.walkBlock(statement.Block)
+
+	case *ast.ForStatement:
+		e.walkExpression(statement.Value)
+		e.walkBlock(statement.Block)
+
+	case *ast.EmitStatement:
+		e.walkExpression(statement.InvocationExpression)
+
+	case *ast.RemoveStatement:
+		e.walkExpression(statement.Value)
+
+	case *ast.AssignmentStatement:
+		e.walkExpression(statement.Target)
+		e.walkExpression(statement.Value)
+
+	case *ast.SwapStatement:
+		e.walkExpression(statement.Left)
+		e.walkExpression(statement.Right)
+
+	case *ast.ExpressionStatement:
+		e.walkExpression(statement.Expression)
+
+	case *ast.SwitchStatement:
+		e.walkExpression(statement.Expression)
+		for _, switchCase := range statement.Cases {
+			if !e.add(switchCase) {
+				continue
+			}
+			e.walkExpression(switchCase.Expression)
+			e.walkStatements(switchCase.Statements)
+		}
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+func (e *elaborationElements) walkExpressions(expressions []ast.Expression) {
+	for _, expression := range expressions {
+		e.walkExpression(expression)
+	}
+}
+
+func (e *elaborationElements) walkExpression(expression ast.Expression) {
+	if expression == nil || !e.add(expression) {
+		return
+	}
+
+	switch expression := expression.(type) {
+	case *ast.BoolExpression,
+		*ast.NilExpression,
+		*ast.StringExpression,
+		*ast.IntegerExpression,
+		*ast.FixedPointExpression,
+		*ast.IdentifierExpression,
+		*ast.PathExpression:
+
+		break
+
+	case *ast.ArrayExpression:
+		e.walkExpressions(expression.Values)
+
+	case *ast.DictionaryExpression:
+		for _, entry := range expression.Entries {
+			e.walkExpression(entry.Key)
+			e.walkExpression(entry.Value)
+		}
+
+	case *ast.InvocationExpression:
+		e.walkExpression(expression.InvokedExpression)
+		for _, argument := range expression.Arguments {
+			e.walkExpression(argument.Expression)
+		}
+
+	case *ast.MemberExpression:
+		e.walkExpression(expression.Expression)
+
+	case *ast.IndexExpression:
+		e.walkExpression(expression.TargetExpression)
+		e.walkExpression(expression.IndexingExpression)
+
+	case *ast.ConditionalExpression:
+		e.walkExpression(expression.Test)
+		e.walkExpression(expression.Then)
+		e.walkExpression(expression.Else)
+
+	case *ast.UnaryExpression:
+		e.walkExpression(expression.Expression)
+
+	case *ast.BinaryExpression:
+		e.walkExpression(expression.Left)
+		e.walkExpression(expression.Right)
+
+	case *ast.FunctionExpression:
+		e.walkFunctionBlock(expression.FunctionBlock)
+
+	case *ast.CastingExpression:
+		e.walkExpression(expression.Expression)
+
+	case *ast.CreateExpression:
+		e.walkExpression(expression.InvocationExpression)
+
+	case *ast.DestroyExpression:
+		e.walkExpression(expression.Expression)
+
+	case *ast.AttachExpression:
+		e.walkExpression(expression.Base)
+		e.walkExpression(expression.Attachment)
+
+	case *ast.ReferenceExpression:
+		e.walkExpression(expression.Expression)
+
+	case *ast.ForceExpression:
+		e.walkExpression(expression.Expression)
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+}
+
+// index returns the index of the given element.
+//
+func (e *elaborationElements) index(element interface{}) (int, error) {
+	index, ok := e.indices[element]
+	if !ok {
+		return 0, fmt.Errorf("element is not part of the program: %T", element)
+	}
+	return index, nil
+}
+
+// element returns the element with the given index.
+//
+func (e *elaborationElements) element(index int) (interface{}, error) {
+	if index < 0 || index >= len(e.elements) {
+		return nil, fmt.Errorf("invalid element index: %d", index)
+	}
+	return e.elements[index], nil
+}
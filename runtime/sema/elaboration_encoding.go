@@ -0,0 +1,1196 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// ElaborationEncodingVersion is the version of the encoding of elaborations.
+//
+// It must be incremented whenever the encoding changes,
+// or the elaboration produced by the checker changes in an incompatible way.
+//
+const ElaborationEncodingVersion = 1
+
+// IncompatibleElaborationError is returned when an encoded elaboration
+// cannot be used for a program, e.g. because it was produced by a different version
+// of the checker, with different built-in types, or for different code.
+//
+type IncompatibleElaborationError struct {
+	Message string
+}
+
+func (e *IncompatibleElaborationError) Error() string {
+	return fmt.Sprintf("incompatible elaboration: %s", e.Message)
+}
+
+// elaborationCodecError wraps errors which abort the encoding or decoding of an elaboration.
+//
+type elaborationCodecError struct {
+	err error
+}
+
+func panicElaborationCodecError(format string, a ...interface{}) {
+	panic(elaborationCodecError{
+		err: fmt.Errorf(format, a...),
+	})
+}
+
+// encodedTypeKind is the kind of an encoded type
+//
+type encodedTypeKind uint
+
+const (
+	encodedTypeKindUnknown encodedTypeKind = iota
+	// a built-in type, referred to by type ID
+	encodedTypeKindBuiltin
+	// a predeclared type, referred to by type ID
+	encodedTypeKindPredeclared
+	// a composite or interface type declared in an imported program, referred to by type ID
+	encodedTypeKindImported
+	encodedTypeKindOptional
+	encodedTypeKindVariableSized
+	encodedTypeKindConstantSized
+	encodedTypeKindDictionary
+	encodedTypeKindReference
+	encodedTypeKindRestricted
+	encodedTypeKindCapability
+	encodedTypeKindFunction
+	encodedTypeKindGeneric
+	encodedTypeKindComposite
+	encodedTypeKindInterface
+	encodedTypeKindTransaction
+	encodedTypeKindInclusiveRange
+)
+
+// NOTE: Types are referred to by their index in the type table, plus one.
+// The zero value refers to no type (nil)
+
+type encodedElaboration struct {
+	Version           uint64
+	Fingerprint       []byte
+	CodeHash          []byte
+	LanguageVersion   LanguageVersion         `cbor:",omitempty"`
+	PredeclaredValues []encodedPredeclaration `cbor:",omitempty"`
+	PredeclaredTypes  []encodedPredeclaration `cbor:",omitempty"`
+	Types             []encodedType           `cbor:",omitempty"`
+	Rewrites          []int                   `cbor:",omitempty"`
+	Fields            []encodedField          `cbor:",omitempty"`
+	GlobalValues      []encodedVariable       `cbor:",omitempty"`
+	GlobalTypes       []encodedVariable       `cbor:",omitempty"`
+	TransactionTypes  []int                   `cbor:",omitempty"`
+	CompositeTypes    []int                   `cbor:",omitempty"`
+	InterfaceTypes    []int                   `cbor:",omitempty"`
+}
+
+type encodedPredeclaration struct {
+	Name   string
+	TypeID string
+}
+
+type encodedPosition struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+type encodedIdentifier struct {
+	Identifier string
+	Pos        encodedPosition
+}
+
+type encodedTypeAnnotation struct {
+	IsResource bool `cbor:",omitempty"`
+	Type       int
+}
+
+type encodedTypeParameter struct {
+	Name      string
+	TypeBound int  `cbor:",omitempty"`
+	Optional  bool `cbor:",omitempty"`
+}
+
+type encodedParameter struct {
+	Label          string `cbor:",omitempty"`
+	Identifier     string
+	TypeAnnotation encodedTypeAnnotation
+}
+
+type encodedMember struct {
+	Name                  string
+	ContainerType         int
+	Access                ast.Access
+	Identifier            encodedIdentifier
+	TypeAnnotation        encodedTypeAnnotation
+	DeclarationKind       common.DeclarationKind
+	VariableKind          ast.VariableKind
+	ArgumentLabels        []string `cbor:",omitempty"`
+	Predeclared           bool     `cbor:",omitempty"`
+	IgnoreInSerialization bool     `cbor:",omitempty"`
+	DocString             string   `cbor:",omitempty"`
+}
+
+type encodedNamedType struct {
+	Name string
+	Type int
+}
+
+type encodedType struct {
+	Kind                  encodedTypeKind
+	ID                    string                 `cbor:",omitempty"`
+	Identifier            string                 `cbor:",omitempty"`
+	CompositeKind         common.CompositeKind   `cbor:",omitempty"`
+	Type                  int                    `cbor:",omitempty"`
+	ValueType             int                    `cbor:",omitempty"`
+	Size                  int64                  `cbor:",omitempty"`
+	Authorized            bool                   `cbor:",omitempty"`
+	Types                 []int                  `cbor:",omitempty"`
+	ImplicitConformances  []int                  `cbor:",omitempty"`
+	TypeParameter         *encodedTypeParameter  `cbor:",omitempty"`
+	TypeParameters        []encodedTypeParameter `cbor:",omitempty"`
+	Parameters            []encodedParameter     `cbor:",omitempty"`
+	PrepareParameters     []encodedParameter     `cbor:",omitempty"`
+	ReturnTypeAnnotation  *encodedTypeAnnotation `cbor:",omitempty"`
+	RequiredArgumentCount *int                   `cbor:",omitempty"`
+	IsConstructor         bool                   `cbor:",omitempty"`
+	HasMembers            bool                   `cbor:",omitempty"`
+	Members               []encodedMember        `cbor:",omitempty"`
+	Fields                []string               `cbor:",omitempty"`
+	NestedTypes           []encodedNamedType     `cbor:",omitempty"`
+	ContainerType         int                    `cbor:",omitempty"`
+	EnumRawType           int                    `cbor:",omitempty"`
+	BaseType              int                    `cbor:",omitempty"`
+}
+
+type encodedVariable struct {
+	Identifier      string
+	DeclarationKind common.DeclarationKind
+	Type            int
+	Access          ast.Access
+	IsConstant      bool             `cbor:",omitempty"`
+	IsBaseValue     bool             `cbor:",omitempty"`
+	ActivationDepth int              `cbor:",omitempty"`
+	ArgumentLabels  []string         `cbor:",omitempty"`
+	Pos             *encodedPosition `cbor:",omitempty"`
+	DocString       string           `cbor:",omitempty"`
+}
+
+type encodedMemberInfo struct {
+	AccessedType int
+	IsOptional   bool `cbor:",omitempty"`
+	// HasMember indicates if the member was resolved.
+	// The member is resolved again when decoding, using the member expression's identifier
+	HasMember bool `cbor:",omitempty"`
+}
+
+type encodedTypeArgument struct {
+	TypeParameter encodedTypeParameter
+	Type          int
+}
+
+type encodedNamedElement struct {
+	Name    string
+	Element int
+}
+
+type encodedResolvedLocation struct {
+	Location    string
+	Identifiers []encodedIdentifier `cbor:",omitempty"`
+}
+
+// encodedEntry is an entry of an elaboration map which is keyed by a program element.
+// Only the field which corresponds to the type of the map's values is set
+//
+type encodedEntry struct {
+	Element      int
+	Type         int                       `cbor:",omitempty"`
+	Types        []int                     `cbor:",omitempty"`
+	MemberInfo   *encodedMemberInfo        `cbor:",omitempty"`
+	TypeArgs     []encodedTypeArgument     `cbor:",omitempty"`
+	Declarations []encodedNamedElement     `cbor:",omitempty"`
+	Locations    []encodedResolvedLocation `cbor:",omitempty"`
+	Value        uint64                    `cbor:",omitempty"`
+}
+
+type encodedField struct {
+	Name    string
+	Entries []encodedEntry
+}
+
+// elaborationValueKind is the kind of the values of an elaboration map
+//
+type elaborationValueKind uint
+
+const (
+	elaborationValueKindUnknown elaborationValueKind = iota
+	elaborationValueKindNone
+	elaborationValueKindType
+	elaborationValueKindTypes
+	elaborationValueKindMemberInfo
+	elaborationValueKindTypeArguments
+	elaborationValueKindDictionaryEntryTypes
+	elaborationValueKindDeclarations
+	elaborationValueKindResolvedLocations
+	elaborationValueKindUint
+)
+
+var typeReflectType = reflect.TypeOf((*Type)(nil)).Elem()
+var astElementReflectType = reflect.TypeOf((*ast.Element)(nil)).Elem()
+var conditionsReflectType = reflect.TypeOf((*ast.Conditions)(nil))
+
+// elaborationFieldValueKind returns the kind of the values of the given elaboration field,
+// if the field is a map which is keyed by a program element and which is encoded generically.
+//
+func elaborationFieldValueKind(field reflect.StructField) elaborationValueKind {
+	fieldType := field.Type
+	if fieldType.Kind() != reflect.Map {
+		return elaborationValueKindUnknown
+	}
+
+	keyType := fieldType.Key()
+	if !keyType.Implements(astElementReflectType) {
+		return elaborationValueKindUnknown
+	}
+
+	valueType := fieldType.Elem()
+
+	switch {
+	case valueType.Implements(typeReflectType):
+		return elaborationValueKindType
+
+	case valueType == reflect.TypeOf([]Type(nil)):
+		return elaborationValueKindTypes
+
+	case valueType == reflect.TypeOf(MemberInfo{}):
+		return elaborationValueKindMemberInfo
+
+	case valueType == reflect.TypeOf((*TypeParameterTypeOrderedMap)(nil)):
+		return elaborationValueKindTypeArguments
+
+	case valueType == reflect.TypeOf([]DictionaryEntryType(nil)):
+		return elaborationValueKindDictionaryEntryTypes
+
+	case valueType == reflect.TypeOf(map[string]ast.Declaration(nil)):
+		return elaborationValueKindDeclarations
+
+	case valueType == reflect.TypeOf([]ResolvedLocation(nil)):
+		return elaborationValueKindResolvedLocations
+
+	case valueType == reflect.TypeOf(struct{}{}):
+		return elaborationValueKindNone
+
+	case valueType.Kind() == reflect.Uint8:
+		return elaborationValueKindUint
+	}
+
+	return elaborationValueKindUnknown
+}
+
+// specialElaborationFields are the fields of the elaboration
+// which are not encoded generically
+//
+var specialElaborationFields = map[string]struct{}{
+	"lock":                       {},
+	"isChecking":                 {},
+	"CompositeTypeDeclarations":  {},
+	"InterfaceTypeDeclarations":  {},
+	"PostConditionsRewrite":      {},
+	"CompositeTypes":             {},
+	"InterfaceTypes":             {},
+	"GlobalValues":               {},
+	"GlobalTypes":                {},
+	"TransactionTypes":           {},
+	"EffectivePredeclaredValues": {},
+	"EffectivePredeclaredTypes":  {},
+	"EntitlementTypes":           {},
+	"EntitlementMapTypes":        {},
+	"LanguageVersion":            {},
+}
+
+// builtinTypes are all types which are available in all programs,
+// and which are encoded by their type ID
+//
+var builtinTypes map[TypeID]Type
+var builtinTypeIDs map[Type]TypeID
+var builtinTypesFingerprint []byte
+var builtinTypesOnce sync.Once
+
+func initializeBuiltinTypes() {
+	builtinTypesOnce.Do(func() {
+		builtinTypes = map[TypeID]Type{}
+		builtinTypeIDs = map[Type]TypeID{}
+
+		addType := func(ty Type) {
+			VisitThisAndNested(ty, func(ty Type) {
+				typeID := ty.ID()
+				builtinTypes[typeID] = ty
+				builtinTypeIDs[ty] = typeID
+			})
+		}
+
+		_ = BaseTypeActivation.ForEach(func(_ string, variable *Variable) error {
+			switch variable.Type.(type) {
+			case *CapabilityType, *InclusiveRangeType:
+				return nil
+			}
+			addType(variable.Type)
+			return nil
+		})
+
+		for _, ty := range NativeCompositeTypes { //nolint:maprangecheck
+			addType(ty)
+		}
+
+		addType(AnyType)
+		addType(StorableType)
+
+		builtinTypesFingerprint = typesFingerprint(builtinTypes)
+	})
+}
+
+// typesFingerprint returns a fingerprint of the given types,
+// which includes the type IDs, and the names and kinds of their members.
+//
+func typesFingerprint(types map[TypeID]Type) []byte {
+	typeIDs := make([]string, 0, len(types))
+	for typeID := range types { //nolint:maprangecheck
+		typeIDs = append(typeIDs, string(typeID))
+	}
+	sort.Strings(typeIDs)
+
+	hasher := sha256.New()
+
+	writeString := func(s string) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(s)))
+		_, _ = hasher.Write(length[:])
+		_, _ = hasher.Write([]byte(s))
+	}
+
+	writeString(fmt.Sprint(ElaborationEncodingVersion))
+
+	for _, typeID := range typeIDs {
+		writeString(typeID)
+
+		members := types[TypeID(typeID)].GetMembers()
+		names := make([]string, 0, len(members))
+		for name := range members { //nolint:maprangecheck
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			writeString(name)
+			writeString(members[name].Kind.String())
+		}
+	}
+
+	return hasher.Sum(nil)
+}
+
+// predeclaredTypesByID returns the types of the given type declarations, including nested types, by type ID.
+//
+func predeclaredTypesByID(declarations []TypeDeclaration) map[TypeID]Type {
+	types := map[TypeID]Type{}
+	for _, declaration := range declarations {
+		VisitThisAndNested(declaration.TypeDeclarationType(), func(ty Type) {
+			types[ty.ID()] = ty
+		})
+	}
+	return types
+}
+
+// predeclaredTypeIDs returns the type IDs of the given types, by type.
+//
+func predeclaredTypeIDs(types map[TypeID]Type) map[Type]TypeID {
+	typeIDs := make(map[Type]TypeID, len(types))
+	for typeID, ty := range types { //nolint:maprangecheck
+		typeIDs[ty] = typeID
+	}
+	return typeIDs
+}
+
+func encodeLocation(location common.Location) string {
+	if location == nil {
+		return ""
+	}
+
+	// The location is encoded as a type ID,
+	// so it can be decoded using the registered type ID decoders.
+	// The qualified identifier of address locations is their name
+
+	qualifiedIdentifier := "_"
+	if addressLocation, ok := location.(common.AddressLocation); ok {
+		qualifiedIdentifier = addressLocation.Name
+	}
+
+	return string(location.TypeID(qualifiedIdentifier))
+}
+
+func encodePosition(position ast.Position) encodedPosition {
+	return encodedPosition{
+		Offset: position.Offset,
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+func encodeIdentifier(identifier ast.Identifier) encodedIdentifier {
+	return encodedIdentifier{
+		Identifier: identifier.Identifier,
+		Pos:        encodePosition(identifier.Pos),
+	}
+}
+
+// EncodeElaboration encodes the given elaboration of the given program,
+// which was checked for the given location.
+//
+// The encoded elaboration can be decoded using DecodeElaboration,
+// which allows skipping the checking of the program.
+//
+// The given code hash identifies the code of the program,
+// and is verified when the elaboration is decoded.
+//
+func EncodeElaboration(
+	program *ast.Program,
+	location common.Location,
+	elaboration *Elaboration,
+	codeHash []byte,
+) (
+	data []byte,
+	err error,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			codecError, ok := r.(elaborationCodecError)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("cannot encode elaboration: %w", codecError.err)
+		}
+	}()
+
+	if elaboration.IsChecking() {
+		panicElaborationCodecError("elaboration is still being checked")
+	}
+
+	initializeBuiltinTypes()
+
+	predeclaredTypeDeclarations := make([]TypeDeclaration, 0, len(elaboration.EffectivePredeclaredTypes))
+	for _, declaration := range elaboration.EffectivePredeclaredTypes { //nolint:maprangecheck
+		predeclaredTypeDeclarations = append(predeclaredTypeDeclarations, declaration)
+	}
+
+	encoder := &elaborationEncoder{
+		location:           location,
+		elaboration:        elaboration,
+		predeclaredTypeIDs: predeclaredTypeIDs(predeclaredTypesByID(predeclaredTypeDeclarations)),
+		typeIndices:        map[Type]int{},
+	}
+
+	encoded := encoder.encode(program, codeHash)
+
+	var buffer bytes.Buffer
+	err = cbor.NewEncoder(&buffer).Encode(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+type elaborationEncoder struct {
+	location           common.Location
+	elaboration        *Elaboration
+	predeclaredTypeIDs map[Type]TypeID
+	elements           *elaborationElements
+	types              []encodedType
+	typeIndices        map[Type]int
+}
+
+func (e *elaborationEncoder) encode(program *ast.Program, codeHash []byte) *encodedElaboration {
+
+	elaboration := e.elaboration
+
+	encoded := &encodedElaboration{
+		Version:         ElaborationEncodingVersion,
+		Fingerprint:     builtinTypesFingerprint,
+		CodeHash:        codeHash,
+		LanguageVersion: elaboration.LanguageVersion,
+	}
+
+	e.elements = newElaborationElements(
+		program,
+		func(conditions *ast.Conditions, index int) (PostConditionsRewrite, bool) {
+			rewrite, ok := elaboration.PostConditionsRewrite[conditions]
+			if ok {
+				encoded.Rewrites = append(encoded.Rewrites, index)
+			}
+			return rewrite, ok
+		},
+	)
+
+	if len(encoded.Rewrites) != len(elaboration.PostConditionsRewrite) {
+		panicElaborationCodecError("post-conditions rewrite for conditions which are not part of the program")
+	}
+
+	// Entitlements are not supported yet
+
+	if len(elaboration.EntitlementTypes) > 0 ||
+		len(elaboration.EntitlementMapTypes) > 0 {
+
+		panicElaborationCodecError("unsupported entitlement declarations")
+	}
+
+	// Predeclarations
+
+	encoded.PredeclaredValues = encodePredeclarations(
+		len(elaboration.EffectivePredeclaredValues),
+		func(f func(name string, ty Type)) {
+			for name, declaration := range elaboration.EffectivePredeclaredValues { //nolint:maprangecheck
+				f(name, declaration.ValueDeclarationType())
+			}
+		},
+	)
+
+	encoded.PredeclaredTypes = encodePredeclarations(
+		len(elaboration.EffectivePredeclaredTypes),
+		func(f func(name string, ty Type)) {
+			for name, declaration := range elaboration.EffectivePredeclaredTypes { //nolint:maprangecheck
+				f(name, declaration.TypeDeclarationType())
+			}
+		},
+	)
+
+	// Generically encoded fields, which are keyed by program elements
+
+	elaborationValue := reflect.ValueOf(elaboration).Elem()
+	elaborationType := elaborationValue.Type()
+
+	for i := 0; i < elaborationType.NumField(); i++ {
+		field := elaborationType.Field(i)
+
+		if _, ok := specialElaborationFields[field.Name]; ok {
+			continue
+		}
+
+		valueKind := elaborationFieldValueKind(field)
+		if valueKind == elaborationValueKindUnknown {
+			panicElaborationCodecError("unsupported elaboration field: %s", field.Name)
+		}
+
+		fieldValue := elaborationValue.Field(i)
+		if fieldValue.Len() == 0 {
+			continue
+		}
+
+		encoded.Fields = append(
+			encoded.Fields,
+			encodedField{
+				Name:    field.Name,
+				Entries: e.encodeEntries(fieldValue, valueKind),
+			},
+		)
+	}
+
+	// Global values and types
+
+	encoded.GlobalValues = e.encodeVariables(elaboration.GlobalValues)
+	encoded.GlobalTypes = e.encodeVariables(elaboration.GlobalTypes)
+
+	// Transaction, composite, and interface types
+
+	for _, transactionType := range elaboration.TransactionTypes {
+		encoded.TransactionTypes = append(
+			encoded.TransactionTypes,
+			e.encodeType(transactionType),
+		)
+	}
+
+	encoded.CompositeTypes = e.encodeTypeMap(
+		len(elaboration.CompositeTypes),
+		func(f func(ty Type)) {
+			for _, ty := range elaboration.CompositeTypes { //nolint:maprangecheck
+				f(ty)
+			}
+		},
+	)
+
+	encoded.InterfaceTypes = e.encodeTypeMap(
+		len(elaboration.InterfaceTypes),
+		func(f func(ty Type)) {
+			for _, ty := range elaboration.InterfaceTypes { //nolint:maprangecheck
+				f(ty)
+			}
+		},
+	)
+
+	encoded.Types = e.types
+
+	return encoded
+}
+
+func encodePredeclarations(count int, forEach func(f func(name string, ty Type))) []encodedPredeclaration {
+	if count == 0 {
+		return nil
+	}
+
+	result := make([]encodedPredeclaration, 0, count)
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the result is sorted afterwards
+
+	forEach(func(name string, ty Type) {
+		result = append(
+			result,
+			encodedPredeclaration{
+				Name:   name,
+				TypeID: string(ty.ID()),
+			},
+		)
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// encodeTypeMap encodes the types of a map keyed by type ID.
+// The types are encoded in the order of their type IDs.
+//
+func (e *elaborationEncoder) encodeTypeMap(count int, forEach func(f func(ty Type))) []int {
+	if count == 0 {
+		return nil
+	}
+
+	types := make([]Type, 0, count)
+
+	forEach(func(ty Type) {
+		types = append(types, ty)
+	})
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].ID() < types[j].ID()
+	})
+
+	result := make([]int, len(types))
+	for i, ty := range types {
+		result[i] = e.encodeType(ty)
+	}
+	return result
+}
+
+func (e *elaborationEncoder) encodeEntries(fieldValue reflect.Value, valueKind elaborationValueKind) []encodedEntry {
+
+	entries := make([]encodedEntry, 0, fieldValue.Len())
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if the result is sorted afterwards.
+	// The encoding of the values is performed after sorting,
+	// as it has side effects (the types are added to the type table)
+
+	iterator := fieldValue.MapRange()
+	values := map[int]reflect.Value{}
+
+	for iterator.Next() {
+		element := iterator.Key().Interface()
+
+		index, err := e.elements.index(element)
+		if err != nil {
+			panicElaborationCodecError("%w", err)
+		}
+
+		values[index] = iterator.Value()
+		entries = append(entries, encodedEntry{Element: index})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Element < entries[j].Element
+	})
+
+	for i := range entries {
+		entry := &entries[i]
+		value := values[entry.Element]
+		e.encodeEntryValue(entry, value, valueKind)
+	}
+
+	return entries
+}
+
+func (e *elaborationEncoder) encodeEntryValue(entry *encodedEntry, value reflect.Value, valueKind elaborationValueKind) {
+	switch valueKind {
+	case elaborationValueKindNone:
+		break
+
+	case elaborationValueKindType:
+		var ty Type
+		if !value.IsNil() {
+			ty = value.Interface().(Type)
+		}
+		entry.Type = e.encodeType(ty)
+
+	case elaborationValueKindTypes:
+		entry.Types = e.encodeTypes(value.Interface().([]Type))
+
+	case elaborationValueKindMemberInfo:
+		memberInfo := value.Interface().(MemberInfo)
+		encodedMemberInfo := &encodedMemberInfo{
+			AccessedType: e.encodeType(memberInfo.AccessedType),
+			IsOptional:   memberInfo.IsOptional,
+		}
+		encodedMemberInfo.HasMember = memberInfo.Member != nil
+		entry.MemberInfo = encodedMemberInfo
+
+	case elaborationValueKindTypeArguments:
+		typeArguments := value.Interface().(*TypeParameterTypeOrderedMap)
+		if typeArguments == nil {
+			break
+		}
+		typeArguments.Foreach(func(typeParameter *TypeParameter, ty Type) {
+			entry.TypeArgs = append(
+				entry.TypeArgs,
+				encodedTypeArgument{
+					TypeParameter: e.encodeTypeParameter(typeParameter),
+					Type:          e.encodeType(ty),
+				},
+			)
+		})
+
+	case elaborationValueKindDictionaryEntryTypes:
+		for _, entryType := range value.Interface().([]DictionaryEntryType) {
+			entry.Types = append(
+				entry.Types,
+				e.encodeType(entryType.KeyType),
+				e.encodeType(entryType.ValueType),
+			)
+		}
+
+	case elaborationValueKindDeclarations:
+		declarations := value.Interface().(map[string]ast.Declaration)
+		for name, declaration := range declarations { //nolint:maprangecheck
+			index, err := e.elements.index(declaration)
+			if err != nil {
+				panicElaborationCodecError("%w", err)
+			}
+			entry.Declarations = append(
+				entry.Declarations,
+				encodedNamedElement{
+					Name:    name,
+					Element: index,
+				},
+			)
+		}
+		sort.Slice(entry.Declarations, func(i, j int) bool {
+			return entry.Declarations[i].Name < entry.Declarations[j].Name
+		})
+
+	case elaborationValueKindResolvedLocations:
+		for _, resolvedLocation := range value.Interface().([]ResolvedLocation) {
+			encodedResolvedLocation := encodedResolvedLocation{
+				Location: encodeLocation(resolvedLocation.Location),
+			}
+			for _, identifier := range resolvedLocation.Identifiers {
+				encodedResolvedLocation.Identifiers = append(
+					encodedResolvedLocation.Identifiers,
+					encodeIdentifier(identifier),
+				)
+			}
+			entry.Locations = append(entry.Locations, encodedResolvedLocation)
+		}
+
+	case elaborationValueKindUint:
+		entry.Value = value.Uint()
+
+	default:
+		panicElaborationCodecError("unsupported value kind: %d", valueKind)
+	}
+}
+
+func (e *elaborationEncoder) encodeVariables(variables *StringVariableOrderedMap) []encodedVariable {
+	var result []encodedVariable
+
+	variables.Foreach(func(name string, variable *Variable) {
+		if name != variable.Identifier {
+			panicElaborationCodecError("variable name mismatch: %s != %s", name, variable.Identifier)
+		}
+
+		encoded := encodedVariable{
+			Identifier:      variable.Identifier,
+			DeclarationKind: variable.DeclarationKind,
+			Type:            e.encodeType(variable.Type),
+			Access:          variable.Access,
+			IsConstant:      variable.IsConstant,
+			IsBaseValue:     variable.IsBaseValue,
+			ActivationDepth: variable.ActivationDepth,
+			ArgumentLabels:  variable.ArgumentLabels,
+			DocString:       variable.DocString,
+		}
+
+		if variable.Pos != nil {
+			pos := encodePosition(*variable.Pos)
+			encoded.Pos = &pos
+		}
+
+		result = append(result, encoded)
+	})
+
+	return result
+}
+
+func (e *elaborationEncoder) encodeTypes(types []Type) []int {
+	if types == nil {
+		return nil
+	}
+	result := make([]int, len(types))
+	for i, ty := range types {
+		result[i] = e.encodeType(ty)
+	}
+	return result
+}
+
+func (e *elaborationEncoder) encodeTypeAnnotation(typeAnnotation *TypeAnnotation) encodedTypeAnnotation {
+	if typeAnnotation == nil {
+		return encodedTypeAnnotation{}
+	}
+	return encodedTypeAnnotation{
+		IsResource: typeAnnotation.IsResource,
+		Type:       e.encodeType(typeAnnotation.Type),
+	}
+}
+
+func (e *elaborationEncoder) encodeTypeParameter(typeParameter *TypeParameter) encodedTypeParameter {
+	return encodedTypeParameter{
+		Name:      typeParameter.Name,
+		TypeBound: e.encodeType(typeParameter.TypeBound),
+		Optional:  typeParameter.Optional,
+	}
+}
+
+func (e *elaborationEncoder) encodeParameters(parameters []*Parameter) []encodedParameter {
+	if parameters == nil {
+		return nil
+	}
+	result := make([]encodedParameter, len(parameters))
+	for i, parameter := range parameters {
+		result[i] = encodedParameter{
+			Label:          parameter.Label,
+			Identifier:     parameter.Identifier,
+			TypeAnnotation: e.encodeTypeAnnotation(parameter.TypeAnnotation),
+		}
+	}
+	return result
+}
+
+func (e *elaborationEncoder) encodeMembers(members *StringMemberOrderedMap) []encodedMember {
+	if members == nil {
+		return nil
+	}
+
+	var result []encodedMember
+
+	members.Foreach(func(name string, member *Member) {
+		if member.EntitlementAccess != nil {
+			panicElaborationCodecError("unsupported entitlement access of member: %s", name)
+		}
+
+		result = append(
+			result,
+			encodedMember{
+				Name:                  name,
+				ContainerType:         e.encodeType(member.ContainerType),
+				Access:                member.Access,
+				Identifier:            encodeIdentifier(member.Identifier),
+				TypeAnnotation:        e.encodeTypeAnnotation(member.TypeAnnotation),
+				DeclarationKind:       member.DeclarationKind,
+				VariableKind:          member.VariableKind,
+				ArgumentLabels:        member.ArgumentLabels,
+				Predeclared:           member.Predeclared,
+				IgnoreInSerialization: member.IgnoreInSerialization,
+				DocString:             member.DocString,
+			},
+		)
+	})
+
+	return result
+}
+
+func (e *elaborationEncoder) encodeNestedTypes(nestedTypes *StringTypeOrderedMap) []encodedNamedType {
+	if nestedTypes == nil {
+		return nil
+	}
+
+	var result []encodedNamedType
+
+	nestedTypes.Foreach(func(name string, nestedType Type) {
+		result = append(
+			result,
+			encodedNamedType{
+				Name: name,
+				Type: e.encodeType(nestedType),
+			},
+		)
+	})
+
+	return result
+}
+
+func (e *elaborationEncoder) isLocal(location common.Location) bool {
+	return location != nil && common.LocationsMatch(location, e.location)
+}
+
+// encodeType adds the given type to the type table, if it was not added yet,
+// and returns the reference to it.
+//
+func (e *elaborationEncoder) encodeType(ty Type) int {
+	if ty == nil {
+		return 0
+	}
+
+	if index, ok := e.typeIndices[ty]; ok {
+		return index
+	}
+
+	// Reserve the entry before encoding the type,
+	// as the type might refer to itself, e.g. through its members
+
+	e.types = append(e.types, encodedType{})
+	index := len(e.types)
+	e.typeIndices[ty] = index
+
+	// NOTE: do not hold a pointer to the entry while encoding,
+	// as the type table may grow
+
+	e.types[index-1] = e.encodeTypeEntry(ty)
+
+	return index
+}
+
+func (e *elaborationEncoder) encodeTypeEntry(ty Type) encodedType {
+
+	// Built-in and predeclared types are referred to by their type ID
+
+	if typeID, ok := builtinTypeIDs[ty]; ok {
+		return encodedType{
+			Kind: encodedTypeKindBuiltin,
+			ID:   string(typeID),
+		}
+	}
+
+	if typeID, ok := e.predeclaredTypeIDs[ty]; ok {
+		return encodedType{
+			Kind: encodedTypeKindPredeclared,
+			ID:   string(typeID),
+		}
+	}
+
+	switch ty := ty.(type) {
+	case *AddressType:
+		return encodedType{
+			Kind: encodedTypeKindBuiltin,
+			ID:   string(ty.ID()),
+		}
+
+	case *OptionalType:
+		return encodedType{
+			Kind: encodedTypeKindOptional,
+			Type: e.encodeType(ty.Type),
+		}
+
+	case *VariableSizedType:
+		return encodedType{
+			Kind: encodedTypeKindVariableSized,
+			Type: e.encodeType(ty.Type),
+		}
+
+	case *ConstantSizedType:
+		return encodedType{
+			Kind: encodedTypeKindConstantSized,
+			Type: e.encodeType(ty.Type),
+			Size: ty.Size,
+		}
+
+	case *DictionaryType:
+		return encodedType{
+			Kind:      encodedTypeKindDictionary,
+			Type:      e.encodeType(ty.KeyType),
+			ValueType: e.encodeType(ty.ValueType),
+		}
+
+	case *ReferenceType:
+		if ty.Authorization != nil {
+			panicElaborationCodecError("unsupported entitled reference type: %s", ty.ID())
+		}
+		return encodedType{
+			Kind:       encodedTypeKindReference,
+			Type:       e.encodeType(ty.Type),
+			Authorized: ty.Authorized,
+		}
+
+	case *RestrictedType:
+		restrictions := make([]int, len(ty.Restrictions))
+		for i, restriction := range ty.Restrictions {
+			restrictions[i] = e.encodeType(restriction)
+		}
+		return encodedType{
+			Kind:  encodedTypeKindRestricted,
+			Type:  e.encodeType(ty.Type),
+			Types: restrictions,
+		}
+
+	case *CapabilityType:
+		return encodedType{
+			Kind: encodedTypeKindCapability,
+			Type: e.encodeType(ty.BorrowType),
+		}
+
+	case *InclusiveRangeType:
+		return encodedType{
+			Kind: encodedTypeKindInclusiveRange,
+			Type: e.encodeType(ty.MemberType),
+		}
+
+	case *GenericType:
+		typeParameter := e.encodeTypeParameter(ty.TypeParameter)
+		return encodedType{
+			Kind:          encodedTypeKindGeneric,
+			TypeParameter: &typeParameter,
+		}
+
+	case *FunctionType:
+		return e.encodeFunctionType(ty)
+
+	case *TransactionType:
+		return encodedType{
+			Kind:              encodedTypeKindTransaction,
+			Members:           e.encodeMembers(ty.Members),
+			Fields:            ty.Fields,
+			Parameters:        e.encodeParameters(ty.Parameters),
+			PrepareParameters: e.encodeParameters(ty.PrepareParameters),
+		}
+
+	case *CompositeType:
+		if !e.isLocal(ty.Location) {
+			return e.encodeImportedType(ty, ty.Location)
+		}
+		return e.encodeCompositeType(ty)
+
+	case *InterfaceType:
+		if !e.isLocal(ty.Location) {
+			return e.encodeImportedType(ty, ty.Location)
+		}
+		return e.encodeInterfaceType(ty)
+	}
+
+	panicElaborationCodecError("unsupported type: %T", ty)
+	return encodedType{}
+}
+
+func (e *elaborationEncoder) encodeImportedType(ty Type, location common.Location) encodedType {
+	if location == nil {
+		panicElaborationCodecError("unsupported type without location: %s", ty.ID())
+	}
+
+	return encodedType{
+		Kind: encodedTypeKindImported,
+		ID:   string(ty.ID()),
+	}
+}
+
+func (e *elaborationEncoder) encodeFunctionType(ty *FunctionType) encodedType {
+	var typeParameters []encodedTypeParameter
+	for _, typeParameter := range ty.TypeParameters {
+		typeParameters = append(typeParameters, e.encodeTypeParameter(typeParameter))
+	}
+
+	encoded := encodedType{
+		Kind:                  encodedTypeKindFunction,
+		IsConstructor:         ty.IsConstructor,
+		TypeParameters:        typeParameters,
+		Parameters:            e.encodeParameters(ty.Parameters),
+		RequiredArgumentCount: ty.RequiredArgumentCount,
+	}
+
+	if ty.ReturnTypeAnnotation != nil {
+		returnTypeAnnotation := e.encodeTypeAnnotation(ty.ReturnTypeAnnotation)
+		encoded.ReturnTypeAnnotation = &returnTypeAnnotation
+	}
+
+	if ty.Members != nil {
+		encoded.HasMembers = true
+		encoded.Members = e.encodeMembers(ty.Members)
+	}
+
+	return encoded
+}
+
+func (e *elaborationEncoder) encodeCompositeType(ty *CompositeType) encodedType {
+	conformances := make([]int, len(ty.ExplicitInterfaceConformances))
+	for i, conformance := range ty.ExplicitInterfaceConformances {
+		conformances[i] = e.encodeType(conformance)
+	}
+
+	implicitConformances := make([]int, len(ty.ImplicitTypeRequirementConformances))
+	for i, conformance := range ty.ImplicitTypeRequirementConformances {
+		implicitConformances[i] = e.encodeType(conformance)
+	}
+
+	return encodedType{
+		Kind:                 encodedTypeKindComposite,
+		Identifier:           ty.Identifier,
+		CompositeKind:        ty.Kind,
+		Types:                conformances,
+		ImplicitConformances: implicitConformances,
+		HasMembers:           ty.Members != nil,
+		Members:              e.encodeMembers(ty.Members),
+		Fields:               ty.Fields,
+		Parameters:           e.encodeParameters(ty.ConstructorParameters),
+		NestedTypes:          e.encodeNestedTypes(ty.nestedTypes),
+		ContainerType:        e.encodeType(ty.containerType),
+		EnumRawType:          e.encodeType(ty.EnumRawType),
+		BaseType:             e.encodeType(ty.BaseType),
+	}
+}
+
+func (e *elaborationEncoder) encodeInterfaceType(ty *InterfaceType) encodedType {
+	return encodedType{
+		Kind:          encodedTypeKindInterface,
+		Identifier:    ty.Identifier,
+		CompositeKind: ty.CompositeKind,
+		HasMembers:    ty.Members != nil,
+		Members:       e.encodeMembers(ty.Members),
+		Fields:        ty.Fields,
+		Parameters:    e.encodeParameters(ty.InitializerParameters),
+		NestedTypes:   e.encodeNestedTypes(ty.nestedTypes),
+		ContainerType: e.encodeType(ty.containerType),
+	}
+}
@@ -0,0 +1,534 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// EntitlementType is the type of an entitlement, declared with an entitlement declaration.
+//
+// Entitlements are not value types: they may only be used in access modifiers
+// and in the authorization of reference types.
+//
+type EntitlementType struct {
+	Location      common.Location
+	Identifier    string
+	containerType Type
+}
+
+var _ ContainedType = &EntitlementType{}
+var _ LocatedType = &EntitlementType{}
+
+func NewEntitlementType(location common.Location, identifier string) *EntitlementType {
+	return &EntitlementType{
+		Location:   location,
+		Identifier: identifier,
+	}
+}
+
+func (*EntitlementType) IsType() {}
+
+func (*EntitlementType) Tag() TypeTag {
+	return InvalidTypeTag
+}
+
+func (t *EntitlementType) String() string {
+	return t.Identifier
+}
+
+func (t *EntitlementType) QualifiedString() string {
+	return t.QualifiedIdentifier()
+}
+
+func (t *EntitlementType) GetContainerType() Type {
+	return t.containerType
+}
+
+func (t *EntitlementType) SetContainerType(containerType Type) {
+	t.containerType = containerType
+}
+
+func (t *EntitlementType) GetLocation() common.Location {
+	return t.Location
+}
+
+func (t *EntitlementType) QualifiedIdentifier() string {
+	return qualifiedIdentifier(t.Identifier, t.containerType)
+}
+
+func (t *EntitlementType) ID() TypeID {
+	identifier := t.QualifiedIdentifier()
+	if t.Location == nil {
+		return TypeID(identifier)
+	}
+	return t.Location.TypeID(identifier)
+}
+
+func (t *EntitlementType) Equal(other Type) bool {
+	otherEntitlement, ok := other.(*EntitlementType)
+	if !ok {
+		return false
+	}
+
+	return otherEntitlement.ID() == t.ID()
+}
+
+func (*EntitlementType) IsResourceType() bool {
+	return false
+}
+
+func (*EntitlementType) IsInvalidType() bool {
+	return false
+}
+
+func (*EntitlementType) IsStorable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*EntitlementType) IsExternallyReturnable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*EntitlementType) IsImportable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*EntitlementType) IsEquatable() bool {
+	return false
+}
+
+func (*EntitlementType) TypeAnnotationState() TypeAnnotationState {
+	return TypeAnnotationStateDirectEntitlementTypeAnnotation
+}
+
+func (t *EntitlementType) RewriteWithRestrictedTypes() (Type, bool) {
+	return t, false
+}
+
+func (*EntitlementType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
+	return false
+}
+
+func (t *EntitlementType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
+	return t
+}
+
+func (*EntitlementType) GetMembers() map[string]MemberResolver {
+	return map[string]MemberResolver{}
+}
+
+// EntitlementRelation is an association of an entitlement mapping:
+// the input entitlement is mapped to the output entitlement.
+//
+type EntitlementRelation struct {
+	Input  *EntitlementType
+	Output *EntitlementType
+}
+
+// EntitlementMapType is the type of an entitlement mapping,
+// declared with an entitlement mapping declaration.
+//
+// Like entitlements, entitlement mappings are not value types:
+// they may only be used in the access modifier of fields,
+// and in the authorization of the reference type of such fields.
+//
+type EntitlementMapType struct {
+	Location      common.Location
+	Identifier    string
+	Relations     []EntitlementRelation
+	containerType Type
+}
+
+var _ ContainedType = &EntitlementMapType{}
+var _ LocatedType = &EntitlementMapType{}
+
+func NewEntitlementMapType(location common.Location, identifier string) *EntitlementMapType {
+	return &EntitlementMapType{
+		Location:   location,
+		Identifier: identifier,
+	}
+}
+
+func (*EntitlementMapType) IsType() {}
+
+func (*EntitlementMapType) Tag() TypeTag {
+	return InvalidTypeTag
+}
+
+func (t *EntitlementMapType) String() string {
+	return t.Identifier
+}
+
+func (t *EntitlementMapType) QualifiedString() string {
+	return t.QualifiedIdentifier()
+}
+
+func (t *EntitlementMapType) GetContainerType() Type {
+	return t.containerType
+}
+
+func (t *EntitlementMapType) SetContainerType(containerType Type) {
+	t.containerType = containerType
+}
+
+func (t *EntitlementMapType) GetLocation() common.Location {
+	return t.Location
+}
+
+func (t *EntitlementMapType) QualifiedIdentifier() string {
+	return qualifiedIdentifier(t.Identifier, t.containerType)
+}
+
+func (t *EntitlementMapType) ID() TypeID {
+	identifier := t.QualifiedIdentifier()
+	if t.Location == nil {
+		return TypeID(identifier)
+	}
+	return t.Location.TypeID(identifier)
+}
+
+func (t *EntitlementMapType) Equal(other Type) bool {
+	otherEntitlementMap, ok := other.(*EntitlementMapType)
+	if !ok {
+		return false
+	}
+
+	return otherEntitlementMap.ID() == t.ID()
+}
+
+func (*EntitlementMapType) IsResourceType() bool {
+	return false
+}
+
+func (*EntitlementMapType) IsInvalidType() bool {
+	return false
+}
+
+func (*EntitlementMapType) IsStorable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*EntitlementMapType) IsExternallyReturnable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*EntitlementMapType) IsImportable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*EntitlementMapType) IsEquatable() bool {
+	return false
+}
+
+func (*EntitlementMapType) TypeAnnotationState() TypeAnnotationState {
+	return TypeAnnotationStateDirectEntitlementTypeAnnotation
+}
+
+func (t *EntitlementMapType) RewriteWithRestrictedTypes() (Type, bool) {
+	return t, false
+}
+
+func (*EntitlementMapType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
+	return false
+}
+
+func (t *EntitlementMapType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
+	return t
+}
+
+func (*EntitlementMapType) GetMembers() map[string]MemberResolver {
+	return map[string]MemberResolver{}
+}
+
+// Codomain returns the authorization which contains all outputs of the mapping,
+// or nil if the mapping has no relations.
+//
+func (t *EntitlementMapType) Codomain() *EntitlementSetAccess {
+	outputs := make([]*EntitlementType, 0, len(t.Relations))
+	for _, relation := range t.Relations {
+		outputs = append(outputs, relation.Output)
+	}
+
+	return NewEntitlementSetAccess(outputs, EntitlementSetKindConjunction)
+}
+
+// Image returns the authorization which results from mapping the given authorization.
+//
+// The image of a conjunction is the conjunction of all outputs of its entitlements.
+// The image of a disjunction is the disjunction of all outputs of its entitlements,
+// or no authorization if any of its entitlements has no output.
+// The image of no authorization is no authorization (nil).
+//
+func (t *EntitlementMapType) Image(access *EntitlementSetAccess) *EntitlementSetAccess {
+	if access == nil {
+		return nil
+	}
+
+	var outputs []*EntitlementType
+	for _, entitlement := range access.Entitlements {
+		var mapped bool
+		for _, relation := range t.Relations {
+			if relation.Input.Equal(entitlement) {
+				outputs = append(outputs, relation.Output)
+				mapped = true
+			}
+		}
+
+		if !mapped && access.SetKind == EntitlementSetKindDisjunction {
+			return nil
+		}
+	}
+
+	return NewEntitlementSetAccess(outputs, access.SetKind)
+}
+
+// EntitlementSetKind is the kind of an entitlement set
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=EntitlementSetKind
+
+type EntitlementSetKind uint8
+
+const (
+	// EntitlementSetKindConjunction requires all entitlements of the set
+	EntitlementSetKindConjunction EntitlementSetKind = iota
+	// EntitlementSetKindDisjunction requires any entitlement of the set
+	EntitlementSetKindDisjunction
+)
+
+func (k EntitlementSetKind) separator() string {
+	switch k {
+	case EntitlementSetKindConjunction:
+		return ", "
+	case EntitlementSetKindDisjunction:
+		return " | "
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// EntitlementAccess is the access of a member which requires entitlements:
+// either a set of entitlements (EntitlementSetAccess),
+// or an entitlement mapping (EntitlementMapAccess).
+//
+type EntitlementAccess interface {
+	isEntitlementAccess()
+	String() string
+	Equal(other EntitlementAccess) bool
+}
+
+// EntitlementSetAccess is a set of entitlements.
+//
+// It is both the authorization of a reference type,
+// and the access of a member which requires the entitlements.
+//
+type EntitlementSetAccess struct {
+	// Entitlements are the entitlements of the set, ordered by type ID
+	Entitlements []*EntitlementType
+	SetKind      EntitlementSetKind
+}
+
+var _ EntitlementAccess = &EntitlementSetAccess{}
+
+// NewEntitlementSetAccess returns the set of the given entitlements,
+// without duplicates and ordered by type ID, or nil if no entitlements are given.
+//
+func NewEntitlementSetAccess(entitlements []*EntitlementType, setKind EntitlementSetKind) *EntitlementSetAccess {
+	if len(entitlements) == 0 {
+		return nil
+	}
+
+	access := &EntitlementSetAccess{
+		Entitlements: make([]*EntitlementType, 0, len(entitlements)),
+		SetKind:      setKind,
+	}
+
+	for _, entitlement := range entitlements {
+		if !access.contains(entitlement) {
+			access.Entitlements = append(access.Entitlements, entitlement)
+		}
+	}
+
+	sort.Slice(access.Entitlements, func(i, j int) bool {
+		return access.Entitlements[i].ID() < access.Entitlements[j].ID()
+	})
+
+	return access
+}
+
+func (*EntitlementSetAccess) isEntitlementAccess() {}
+
+func (a *EntitlementSetAccess) string(typeFormatter func(Type) string) string {
+	var builder strings.Builder
+	separator := a.SetKind.separator()
+	for i, entitlement := range a.Entitlements {
+		if i > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(typeFormatter(entitlement))
+	}
+	return builder.String()
+}
+
+func (a *EntitlementSetAccess) String() string {
+	return a.string(func(ty Type) string {
+		return ty.String()
+	})
+}
+
+func (a *EntitlementSetAccess) QualifiedString() string {
+	return a.string(func(ty Type) string {
+		return ty.QualifiedString()
+	})
+}
+
+func (a *EntitlementSetAccess) Equal(other EntitlementAccess) bool {
+	otherSet, ok := other.(*EntitlementSetAccess)
+	if !ok {
+		return false
+	}
+
+	if a == nil || otherSet == nil {
+		return a == otherSet
+	}
+
+	if a.SetKind != otherSet.SetKind ||
+		len(a.Entitlements) != len(otherSet.Entitlements) {
+
+		return false
+	}
+
+	for i, entitlement := range a.Entitlements {
+		if !entitlement.Equal(otherSet.Entitlements[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *EntitlementSetAccess) contains(entitlement *EntitlementType) bool {
+	for _, element := range a.Entitlements {
+		if element.Equal(entitlement) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermitsAccess returns true if the possessor of this authorization
+// is guaranteed to possess the given required authorization.
+//
+// A nil receiver represents no authorization, and a nil required authorization
+// is always permitted.
+//
+func (a *EntitlementSetAccess) PermitsAccess(required *EntitlementSetAccess) bool {
+	if required == nil {
+		return true
+	}
+
+	if a == nil {
+		return false
+	}
+
+	switch required.SetKind {
+	case EntitlementSetKindConjunction:
+		switch a.SetKind {
+		case EntitlementSetKindConjunction:
+			// All required entitlements must be possessed
+
+			for _, entitlement := range required.Entitlements {
+				if !a.contains(entitlement) {
+					return false
+				}
+			}
+			return true
+
+		case EntitlementSetKindDisjunction:
+			// Any of the possessed entitlements might be the actual one,
+			// so all of them must be the one and only required entitlement
+
+			for _, entitlement := range required.Entitlements {
+				for _, possessed := range a.Entitlements {
+					if !possessed.Equal(entitlement) {
+						return false
+					}
+				}
+			}
+			return true
+		}
+
+	case EntitlementSetKindDisjunction:
+		switch a.SetKind {
+		case EntitlementSetKindConjunction:
+			// Any of the required entitlements must be possessed
+
+			for _, entitlement := range required.Entitlements {
+				if a.contains(entitlement) {
+					return true
+				}
+			}
+			return false
+
+		case EntitlementSetKindDisjunction:
+			// Any of the possessed entitlements might be the actual one,
+			// so all of them must be required
+
+			for _, possessed := range a.Entitlements {
+				if !required.contains(possessed) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// EntitlementMapAccess is the access of a field which is mapped
+// through an entitlement mapping: the authorization of a reference
+// to the field's value is the image of the authorization
+// of the reference through which the field is accessed.
+//
+type EntitlementMapAccess struct {
+	Type *EntitlementMapType
+}
+
+var _ EntitlementAccess = EntitlementMapAccess{}
+
+func (EntitlementMapAccess) isEntitlementAccess() {}
+
+func (a EntitlementMapAccess) String() string {
+	return a.Type.String()
+}
+
+func (a EntitlementMapAccess) Equal(other EntitlementAccess) bool {
+	otherMap, ok := other.(EntitlementMapAccess)
+	if !ok {
+		return false
+	}
+
+	return a.Type.Equal(otherMap.Type)
+}
@@ -217,6 +217,36 @@ func (e *AssignmentToConstantError) Error() string {
 
 func (*AssignmentToConstantError) isSemanticError() {}
 
+// ErrorMessageExpectedActualTypes returns the descriptions of the given expected and actual types,
+// and a hint, for use in error messages about mismatched types.
+//
+// Usually the descriptions are the qualified strings of the types, and the hint is empty.
+// However, if the qualified strings of the types are identical,
+// a message like "expected `Foo`, got `Foo`" would be confusing.
+// In that case the descriptions are the full type IDs,
+// and the hint explains why the identical-looking types are different.
+//
+func ErrorMessageExpectedActualTypes(expectedType Type, actualType Type) (expected string, actual string, hint string) {
+	expected = expectedType.QualifiedString()
+	actual = actualType.QualifiedString()
+
+	if expected != actual {
+		return expected, actual, ""
+	}
+
+	expected = string(expectedType.ID())
+	actual = string(actualType.ID())
+
+	if expected != actual {
+		hint = "the types have the same name, but are declared in different locations"
+	} else {
+		hint = "the types have the same type ID, but different definitions. " +
+			"A stale cached program of an updated contract might still be in use"
+	}
+
+	return expected, actual, hint
+}
+
 // TypeMismatchError
 
 type TypeMismatchError struct {
@@ -233,11 +263,20 @@ func (e *TypeMismatchError) Error() string {
 func (*TypeMismatchError) isSemanticError() {}
 
 func (e *TypeMismatchError) SecondaryError() string {
-	return fmt.Sprintf(
+	expected, actual, hint := ErrorMessageExpectedActualTypes(
+		e.ExpectedType,
+		e.ActualType,
+	)
+
+	message := fmt.Sprintf(
 		"expected `%s`, got `%s`",
-		e.ExpectedType.QualifiedString(),
-		e.ActualType.QualifiedString(),
+		expected,
+		actual,
 	)
+	if hint != "" {
+		message += ": " + hint
+	}
+	return message
 }
 
 // TypeMismatchWithDescriptionError
@@ -590,6 +629,89 @@ func (e *InvalidNameError) EndPosition() ast.Position {
 	return e.Pos.Shifted(length - 1)
 }
 
+// ReservedIdentifierError
+
+type ReservedIdentifierError struct {
+	Name            string
+	LanguageVersion LanguageVersion
+	Pos             ast.Position
+}
+
+func (e *ReservedIdentifierError) Error() string {
+	return fmt.Sprintf("`%s` is a reserved identifier", e.Name)
+}
+
+func (e *ReservedIdentifierError) SecondaryError() string {
+	return fmt.Sprintf(
+		"it may not be used as a name in language version %s",
+		e.LanguageVersion,
+	)
+}
+
+func (*ReservedIdentifierError) isSemanticError() {}
+
+func (e *ReservedIdentifierError) StartPosition() ast.Position {
+	return e.Pos
+}
+
+func (e *ReservedIdentifierError) EndPosition() ast.Position {
+	length := len(e.Name)
+	return e.Pos.Shifted(length - 1)
+}
+
+// ReadOnlyViolationError
+
+type ReadOnlyViolationError struct {
+	ContainerType Type
+	Name          string
+	ast.Range
+}
+
+func (e *ReadOnlyViolationError) Error() string {
+	return fmt.Sprintf(
+		"cannot access `%s` of type `%s`: the program may only read",
+		e.Name,
+		e.ContainerType.QualifiedString(),
+	)
+}
+
+func (e *ReadOnlyViolationError) SecondaryError() string {
+	return "the member writes, e.g. to account storage"
+}
+
+func (*ReadOnlyViolationError) isSemanticError() {}
+
+// DeprecatedDeclarationError
+
+type DeprecatedDeclarationError struct {
+	Name        string
+	Deprecation *Deprecation
+	ast.Range
+}
+
+func (e *DeprecatedDeclarationError) Error() string {
+	return fmt.Sprintf("`%s` is deprecated and may no longer be used", e.Name)
+}
+
+func (e *DeprecatedDeclarationError) SecondaryError() string {
+	var secondaryError string
+
+	if e.Deprecation.Replacement != "" {
+		secondaryError = fmt.Sprintf("consider replacing with `%s`", e.Deprecation.Replacement)
+	}
+
+	if e.Deprecation.Message != "" {
+		if secondaryError != "" {
+			secondaryError += ": "
+		}
+		secondaryError += e.Deprecation.Message
+	}
+
+	return secondaryError
+}
+
+func (*DeprecatedDeclarationError) isSemanticError() {}
+
 // UnknownSpecialFunctionError
 
 type UnknownSpecialFunctionError struct {
@@ -877,6 +999,26 @@ func (e *InvalidEnumRawTypeError) Error() string {
 
 func (*InvalidEnumRawTypeError) isSemanticError() {}
 
+// EnumCaseCountExceedsRawTypeError
+
+type EnumCaseCountExceedsRawTypeError struct {
+	RawType      Type
+	CaseCount    int
+	MaxCaseCount *big.Int
+	ast.Range
+}
+
+func (e *EnumCaseCountExceedsRawTypeError) Error() string {
+	return fmt.Sprintf(
+		"too many enum cases for raw type `%s`: got %d, expected at most %s",
+		e.RawType.QualifiedString(),
+		e.CaseCount,
+		e.MaxCaseCount,
+	)
+}
+
+func (*EnumCaseCountExceedsRawTypeError) isSemanticError() {}
+
 // MissingEnumRawTypeError
 
 type MissingEnumRawTypeError struct {
@@ -1189,22 +1331,36 @@ type InvalidIntegerLiteralRangeError struct {
 	ExpectedType   Type
 	ExpectedMinInt *big.Int
 	ExpectedMaxInt *big.Int
+	Literal        string
 	ast.Range
 }
 
 func (e *InvalidIntegerLiteralRangeError) Error() string {
-	return "integer literal out of range"
+	if e.Literal == "" {
+		return "integer literal out of range"
+	}
+	return fmt.Sprintf("integer literal out of range: `%s`", e.Literal)
 }
 
 func (e *InvalidIntegerLiteralRangeError) SecondaryError() string {
 	return fmt.Sprintf(
 		"expected `%s`, in range [%s, %s]",
 		e.ExpectedType.QualifiedString(),
-		e.ExpectedMinInt,
-		e.ExpectedMaxInt,
+		formatIntegerBound(e.ExpectedMinInt, "-∞"),
+		formatIntegerBound(e.ExpectedMaxInt, "∞"),
 	)
 }
 
+// formatIntegerBound formats the bound of an integer range,
+// or returns the given string if the range is unbounded.
+//
+func formatIntegerBound(bound *big.Int, unbounded string) string {
+	if bound == nil {
+		return unbounded
+	}
+	return bound.String()
+}
+
 func (*InvalidIntegerLiteralRangeError) isSemanticError() {}
 
 // InvalidAddressLiteralError
@@ -1227,24 +1383,38 @@ type InvalidFixedPointLiteralRangeError struct {
 	ExpectedMinFractional *big.Int
 	ExpectedMaxInt        *big.Int
 	ExpectedMaxFractional *big.Int
+	Literal               string
 	ast.Range
 }
 
 func (e *InvalidFixedPointLiteralRangeError) Error() string {
-	return "fixed-point literal out of range"
+	if e.Literal == "" {
+		return "fixed-point literal out of range"
+	}
+	return fmt.Sprintf("fixed-point literal out of range: `%s`", e.Literal)
 }
 
 func (e *InvalidFixedPointLiteralRangeError) SecondaryError() string {
+	var scale uint
+	if fractionalRangedType, ok := e.ExpectedType.(FractionalRangedType); ok {
+		scale = fractionalRangedType.Scale()
+	}
+
 	return fmt.Sprintf(
-		"expected `%s`, in range [%s.%s, %s.%s]",
+		"expected `%s`, in range [%s, %s]",
 		e.ExpectedType.QualifiedString(),
-		e.ExpectedMinInt,
-		e.ExpectedMinFractional,
-		e.ExpectedMaxInt,
-		e.ExpectedMaxFractional,
+		formatFixedPointBound(e.ExpectedMinInt, e.ExpectedMinFractional, scale),
+		formatFixedPointBound(e.ExpectedMaxInt, e.ExpectedMaxFractional, scale),
 	)
 }
 
+// formatFixedPointBound formats the bound of a fixed-point range,
+// padding the fractional part with leading zeros to the given scale.
+//
+func formatFixedPointBound(integer *big.Int, fractional *big.Int, scale uint) string {
+	return fmt.Sprintf("%s.%0*s", integer, scale, fractional)
+}
+
 func (*InvalidFixedPointLiteralRangeError) isSemanticError() {}
 
 // InvalidFixedPointLiteralScaleError
@@ -2374,6 +2544,13 @@ func (e *InvalidTransactionPrepareParameterTypeError) Error() string {
 
 func (*InvalidTransactionPrepareParameterTypeError) isSemanticError() {}
 
+func (*InvalidTransactionPrepareParameterTypeError) SecondaryError() string {
+	return fmt.Sprintf(
+		"the last parameter may also be of type `[%s]` to accept a variable number of signers",
+		AuthAccountType,
+	)
+}
+
 // InvalidNestedDeclarationError
 
 type InvalidNestedDeclarationError struct {
@@ -2754,6 +2931,24 @@ func (e *InvalidTypeArgumentCountError) SecondaryError() string {
 
 func (e *InvalidTypeArgumentCountError) isSemanticError() {}
 
+// InvalidTypeArgumentError
+
+type InvalidTypeArgumentError struct {
+	TypeArgumentName string
+	Details          string
+	ast.Range
+}
+
+func (e *InvalidTypeArgumentError) Error() string {
+	return fmt.Sprintf("invalid type argument for type parameter `%s`", e.TypeArgumentName)
+}
+
+func (e *InvalidTypeArgumentError) SecondaryError() string {
+	return e.Details
+}
+
+func (e *InvalidTypeArgumentError) isSemanticError() {}
+
 // TypeParameterTypeInferenceError
 
 type TypeParameterTypeInferenceError struct {
@@ -2844,12 +3039,21 @@ func (e *TypeParameterTypeMismatchError) Error() string {
 func (*TypeParameterTypeMismatchError) isSemanticError() {}
 
 func (e *TypeParameterTypeMismatchError) SecondaryError() string {
-	return fmt.Sprintf(
+	expected, actual, hint := ErrorMessageExpectedActualTypes(
+		e.ExpectedType,
+		e.ActualType,
+	)
+
+	message := fmt.Sprintf(
 		"type parameter %s is bound to `%s`, but got `%s` here",
 		e.TypeParameter.Name,
-		e.ExpectedType.QualifiedString(),
-		e.ActualType.QualifiedString(),
+		expected,
+		actual,
 	)
+	if hint != "" {
+		message += ": " + hint
+	}
+	return message
 }
 
 // TypeMismatchWithDescriptionError
@@ -2966,3 +3170,264 @@ func (e *InvalidEntryPointTypeError) Error() string {
 		e.Type.QualifiedString(),
 	)
 }
+
+// AccountLinkingNotAllowedError
+
+type AccountLinkingNotAllowedError struct {
+	ast.Range
+}
+
+func (e *AccountLinkingNotAllowedError) isSemanticError() {}
+
+func (e *AccountLinkingNotAllowedError) Error() string {
+	return "account linking is not allowed"
+}
+
+func (e *AccountLinkingNotAllowedError) SecondaryError() string {
+	return fmt.Sprintf(
+		"add the pragma `#%s` to the program to allow linking accounts",
+		AllowAccountLinkingPragma,
+	)
+}
+
+// StaticAssertionFailedError
+
+type StaticAssertionFailedError struct {
+	Message string
+	ast.Range
+}
+
+func (e *StaticAssertionFailedError) isSemanticError() {}
+
+func (e *StaticAssertionFailedError) Error() string {
+	if e.Message == "" {
+		return "static assertion failed"
+	}
+	return fmt.Sprintf("static assertion failed: %s", e.Message)
+}
+
+// NonConstantExpressionError
+
+type NonConstantExpressionError struct {
+	ast.Range
+}
+
+func (e *NonConstantExpressionError) isSemanticError() {}
+
+func (e *NonConstantExpressionError) Error() string {
+	return "expression is not a constant expression"
+}
+
+func (e *NonConstantExpressionError) SecondaryError() string {
+	return "only literals, operators, the length of array and string literals, " +
+		"and the number of enum cases (`E.allCases.length`) can be evaluated by the checker"
+}
+
+// ConstantDivisionByZeroError
+
+type ConstantDivisionByZeroError struct {
+	ast.Range
+}
+
+func (e *ConstantDivisionByZeroError) isSemanticError() {}
+
+func (e *ConstantDivisionByZeroError) Error() string {
+	return "division by zero in constant expression"
+}
+
+// InvalidAttachmentBaseTypeError
+
+type InvalidAttachmentBaseTypeError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *InvalidAttachmentBaseTypeError) isSemanticError() {}
+
+func (e *InvalidAttachmentBaseTypeError) Error() string {
+	return fmt.Sprintf(
+		"cannot declare attachment for type: %s",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *InvalidAttachmentBaseTypeError) SecondaryError() string {
+	return "only structures and resources can be extended with attachments"
+}
+
+// NotAnAttachmentError
+
+type NotAnAttachmentError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *NotAnAttachmentError) isSemanticError() {}
+
+func (e *NotAnAttachmentError) Error() string {
+	return fmt.Sprintf(
+		"not an attachment: %s",
+		e.Type.QualifiedString(),
+	)
+}
+
+// InvalidAttachmentConstructionError
+
+type InvalidAttachmentConstructionError struct {
+	ast.Range
+}
+
+func (e *InvalidAttachmentConstructionError) isSemanticError() {}
+
+func (e *InvalidAttachmentConstructionError) Error() string {
+	return "cannot construct attachment outside of an `attach` expression"
+}
+
+// InvalidAttachmentAccessError
+
+type InvalidAttachmentAccessError struct {
+	AttachmentType *CompositeType
+	BaseType       Type
+	ast.Range
+}
+
+func (e *InvalidAttachmentAccessError) isSemanticError() {}
+
+func (e *InvalidAttachmentAccessError) Error() string {
+	return fmt.Sprintf(
+		"cannot access attachment `%s` on type `%s`",
+		e.AttachmentType.QualifiedString(),
+		e.BaseType.QualifiedString(),
+	)
+}
+
+func (e *InvalidAttachmentAccessError) SecondaryError() string {
+	return fmt.Sprintf(
+		"the attachment extends `%s`",
+		e.AttachmentType.BaseType.QualifiedString(),
+	)
+}
+
+// InvalidNonEntitlementAccessError
+
+type InvalidNonEntitlementAccessError struct {
+	ast.Range
+}
+
+func (e *InvalidNonEntitlementAccessError) isSemanticError() {}
+
+func (e *InvalidNonEntitlementAccessError) Error() string {
+	return "only entitlements may be used in access modifiers"
+}
+
+// InvalidNonEntitlementAuthorizationError
+
+type InvalidNonEntitlementAuthorizationError struct {
+	ast.Range
+}
+
+func (e *InvalidNonEntitlementAuthorizationError) isSemanticError() {}
+
+func (e *InvalidNonEntitlementAuthorizationError) Error() string {
+	return "only entitlements may be used in the authorization of a reference type"
+}
+
+// DirectEntitlementAnnotationError
+
+type DirectEntitlementAnnotationError struct {
+	ast.Range
+}
+
+func (e *DirectEntitlementAnnotationError) isSemanticError() {}
+
+func (e *DirectEntitlementAnnotationError) Error() string {
+	return "cannot use an entitlement type outside of an `access` modifier or `auth` authorization"
+}
+
+// InvalidNonEntitlementTypeInMapError
+
+type InvalidNonEntitlementTypeInMapError struct {
+	ast.Range
+}
+
+func (e *InvalidNonEntitlementTypeInMapError) isSemanticError() {}
+
+func (e *InvalidNonEntitlementTypeInMapError) Error() string {
+	return "cannot use a non-entitlement type in an entitlement mapping"
+}
+
+// InvalidMultipleMappedEntitlementError
+
+type InvalidMultipleMappedEntitlementError struct {
+	ast.Range
+}
+
+func (e *InvalidMultipleMappedEntitlementError) isSemanticError() {}
+
+func (e *InvalidMultipleMappedEntitlementError) Error() string {
+	return "entitlement mappings cannot be combined with other entitlements"
+}
+
+// InvalidMappedAuthorizationOutsideOfFieldError
+
+type InvalidMappedAuthorizationOutsideOfFieldError struct {
+	Map *EntitlementMapType
+	ast.Range
+}
+
+func (e *InvalidMappedAuthorizationOutsideOfFieldError) isSemanticError() {}
+
+func (e *InvalidMappedAuthorizationOutsideOfFieldError) Error() string {
+	return fmt.Sprintf(
+		"cannot use mapped entitlement authorization `%s` outside of a field with `access(%[1]s)`",
+		e.Map.QualifiedString(),
+	)
+}
+
+// InvalidMappedEntitlementMemberError
+
+type InvalidMappedEntitlementMemberError struct {
+	ast.Range
+}
+
+func (e *InvalidMappedEntitlementMemberError) isSemanticError() {}
+
+func (e *InvalidMappedEntitlementMemberError) Error() string {
+	return "mapped entitlement access modifiers may only be used for fields"
+}
+
+func (e *InvalidMappedEntitlementMemberError) SecondaryError() string {
+	return "the type of the field must be a reference type, or an optional reference type, " +
+		"which is authorized with the same mapped entitlement"
+}
+
+// InsufficientEntitlementsError
+
+type InsufficientEntitlementsError struct {
+	Name            string
+	DeclarationKind common.DeclarationKind
+	RequiredAccess  *EntitlementSetAccess
+	PossessedAccess *EntitlementSetAccess
+	ast.Range
+}
+
+func (e *InsufficientEntitlementsError) isSemanticError() {}
+
+func (e *InsufficientEntitlementsError) Error() string {
+	return fmt.Sprintf(
+		"cannot access `%s`: %s requires `%s` authorization",
+		e.Name,
+		e.DeclarationKind.Name(),
+		e.RequiredAccess.QualifiedString(),
+	)
+}
+
+func (e *InsufficientEntitlementsError) SecondaryError() string {
+	if e.PossessedAccess == nil {
+		return "the reference is not authorized"
+	}
+	return fmt.Sprintf(
+		"the reference only has `%s` authorization",
+		e.PossessedAccess.QualifiedString(),
+	)
+}
@@ -0,0 +1,138 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestErrorMessageExpectedActualTypes(t *testing.T) {
+
+	t.Parallel()
+
+	newFooType := func(location common.Location, kind common.CompositeKind) *CompositeType {
+		return &CompositeType{
+			Location:   location,
+			Identifier: "Foo",
+			Kind:       kind,
+			Members:    NewStringMemberOrderedMap(),
+		}
+	}
+
+	t.Run("different names", func(t *testing.T) {
+
+		t.Parallel()
+
+		expected, actual, hint := ErrorMessageExpectedActualTypes(IntType, StringType)
+		assert.Equal(t, "Int", expected)
+		assert.Equal(t, "String", actual)
+		assert.Empty(t, hint)
+	})
+
+	t.Run("same name, different locations", func(t *testing.T) {
+
+		t.Parallel()
+
+		expectedType := &OptionalType{
+			Type: newFooType(common.StringLocation("a"), common.CompositeKindStructure),
+		}
+		actualType := &OptionalType{
+			Type: newFooType(common.StringLocation("b"), common.CompositeKindStructure),
+		}
+
+		expected, actual, hint := ErrorMessageExpectedActualTypes(expectedType, actualType)
+		assert.Equal(t, "S.a.Foo?", expected)
+		assert.Equal(t, "S.b.Foo?", actual)
+		assert.Contains(t, hint, "different locations")
+	})
+
+	t.Run("same type ID, different definitions", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := common.StringLocation("a")
+
+		expectedType := newFooType(location, common.CompositeKindStructure)
+		actualType := newFooType(location, common.CompositeKindResource)
+
+		expected, actual, hint := ErrorMessageExpectedActualTypes(expectedType, actualType)
+		assert.Equal(t, "S.a.Foo", expected)
+		assert.Equal(t, "S.a.Foo", actual)
+		assert.Contains(t, hint, "stale cached program")
+	})
+}
+
+func TestInvalidLiteralRangeErrorMessages(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("integer", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := &InvalidIntegerLiteralRangeError{
+			ExpectedType:   UInt8Type,
+			ExpectedMinInt: UInt8TypeMinInt,
+			ExpectedMaxInt: UInt8TypeMaxInt,
+			Literal:        "300",
+		}
+
+		assert.Equal(t, "integer literal out of range: `300`", err.Error())
+		assert.Equal(t, "expected `UInt8`, in range [0, 255]", err.SecondaryError())
+	})
+
+	t.Run("integer, unbounded", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := &InvalidIntegerLiteralRangeError{
+			ExpectedType:   UIntType,
+			ExpectedMinInt: big.NewInt(0),
+			Literal:        "-1",
+		}
+
+		assert.Equal(t, "integer literal out of range: `-1`", err.Error())
+		assert.Equal(t, "expected `UInt`, in range [0, ∞]", err.SecondaryError())
+	})
+
+	t.Run("fixed-point", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := &InvalidFixedPointLiteralRangeError{
+			ExpectedType:          UFix64Type,
+			ExpectedMinInt:        UFix64TypeMinIntBig,
+			ExpectedMinFractional: UFix64TypeMinFractionalBig,
+			ExpectedMaxInt:        UFix64TypeMaxIntBig,
+			ExpectedMaxFractional: UFix64TypeMaxFractionalBig,
+			Literal:               "184467440738.0",
+		}
+
+		assert.Equal(t, "fixed-point literal out of range: `184467440738.0`", err.Error())
+		assert.Equal(t,
+			"expected `UFix64`, in range [0.00000000, 184467440737.09551615]",
+			err.SecondaryError(),
+		)
+	})
+}
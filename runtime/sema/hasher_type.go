@@ -0,0 +1,92 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const HasherTypeName = "Hasher"
+
+const HasherTypeUpdateFunctionName = "update"
+
+var HasherTypeUpdateFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "data",
+			TypeAnnotation: NewTypeAnnotation(ByteArrayType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		VoidType,
+	),
+}
+
+const hasherTypeUpdateFunctionDocString = `
+Adds the given data to the data to be hashed.
+
+Fails if the hasher was already finalized
+`
+
+const HasherTypeFinalizeFunctionName = "finalize"
+
+var HasherTypeFinalizeFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		ByteArrayType,
+	),
+}
+
+const hasherTypeFinalizeFunctionDocString = `
+Returns the hash of all data added to the hasher.
+
+Fails if the hasher was already finalized
+`
+
+// HasherType represents an incremental hasher,
+// which is created using the newHasher function of a hash algorithm.
+//
+var HasherType = func() *CompositeType {
+
+	hasherType := &CompositeType{
+		Identifier: HasherTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewPublicFunctionMember(
+			hasherType,
+			HasherTypeUpdateFunctionName,
+			HasherTypeUpdateFunctionType,
+			hasherTypeUpdateFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			hasherType,
+			HasherTypeFinalizeFunctionName,
+			HasherTypeFinalizeFunctionType,
+			hasherTypeFinalizeFunctionDocString,
+		),
+	}
+
+	hasherType.Members = GetMembersAsMap(members)
+	hasherType.Fields = getFieldNames(members)
+
+	return hasherType
+}()
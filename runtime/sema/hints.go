@@ -116,3 +116,27 @@ func (h *UnnecessaryCastHint) Hint() string {
 }
 
 func (*UnnecessaryCastHint) isHint() {}
+
+// DeprecatedDeclarationHint
+
+type DeprecatedDeclarationHint struct {
+	Name        string
+	Deprecation *Deprecation
+	ast.Range
+}
+
+func (h *DeprecatedDeclarationHint) Hint() string {
+	hint := fmt.Sprintf("`%s` is deprecated", h.Name)
+
+	if h.Deprecation.Replacement != "" {
+		hint += fmt.Sprintf(", consider replacing with `%s`", h.Deprecation.Replacement)
+	}
+
+	if h.Deprecation.Message != "" {
+		hint += fmt.Sprintf(": %s", h.Deprecation.Message)
+	}
+
+	return hint
+}
+
+func (*DeprecatedDeclarationHint) isHint() {}
@@ -0,0 +1,387 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+const InclusiveRangeTypeName = "InclusiveRange"
+
+const InclusiveRangeTypeStartFieldName = "start"
+const InclusiveRangeTypeEndFieldName = "end"
+const InclusiveRangeTypeStepFieldName = "step"
+const InclusiveRangeTypeContainsFunctionName = "contains"
+
+// InclusiveRangeType is the type of ranges of integers, `InclusiveRange<T>`,
+// which contain all integers from a start integer up to and including an end integer,
+// with a certain step between them.
+//
+// The unparameterized type `InclusiveRange` is the super-type of all inclusive range types.
+//
+type InclusiveRangeType struct {
+	MemberType          Type
+	memberResolvers     map[string]MemberResolver
+	memberResolversOnce sync.Once
+}
+
+var _ Type = &InclusiveRangeType{}
+var _ ParameterizedType = &InclusiveRangeType{}
+
+func (*InclusiveRangeType) IsType() {}
+
+func (t *InclusiveRangeType) Tag() TypeTag {
+	return InclusiveRangeTypeTag
+}
+
+func (t *InclusiveRangeType) string(typeFormatter func(Type) string) string {
+	var builder strings.Builder
+	builder.WriteString(InclusiveRangeTypeName)
+	if t.MemberType != nil {
+		builder.WriteRune('<')
+		builder.WriteString(typeFormatter(t.MemberType))
+		builder.WriteRune('>')
+	}
+	return builder.String()
+}
+
+func (t *InclusiveRangeType) String() string {
+	return t.string(func(t Type) string {
+		return t.String()
+	})
+}
+
+func (t *InclusiveRangeType) QualifiedString() string {
+	return t.string(func(t Type) string {
+		return t.QualifiedString()
+	})
+}
+
+func (t *InclusiveRangeType) ID() TypeID {
+	return TypeID(t.string(func(t Type) string {
+		return string(t.ID())
+	}))
+}
+
+func (t *InclusiveRangeType) Equal(other Type) bool {
+	otherRange, ok := other.(*InclusiveRangeType)
+	if !ok {
+		return false
+	}
+	if otherRange.MemberType == nil {
+		return t.MemberType == nil
+	}
+	return otherRange.MemberType.Equal(t.MemberType)
+}
+
+func (*InclusiveRangeType) IsResourceType() bool {
+	return false
+}
+
+func (t *InclusiveRangeType) IsInvalidType() bool {
+	if t.MemberType == nil {
+		return false
+	}
+	return t.MemberType.IsInvalidType()
+}
+
+func (t *InclusiveRangeType) TypeAnnotationState() TypeAnnotationState {
+	if t.MemberType == nil {
+		return TypeAnnotationStateValid
+	}
+	return t.MemberType.TypeAnnotationState()
+}
+
+// IsStorable returns false: inclusive ranges are computed on demand,
+// and are not stored
+//
+func (*InclusiveRangeType) IsStorable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*InclusiveRangeType) IsExternallyReturnable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*InclusiveRangeType) IsImportable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*InclusiveRangeType) IsEquatable() bool {
+	return false
+}
+
+func (t *InclusiveRangeType) RewriteWithRestrictedTypes() (Type, bool) {
+	return t, false
+}
+
+func (t *InclusiveRangeType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) bool {
+	otherRange, ok := other.(*InclusiveRangeType)
+	if !ok {
+		return false
+	}
+
+	if t.MemberType == nil || otherRange.MemberType == nil {
+		return false
+	}
+
+	return t.MemberType.Unify(otherRange.MemberType, typeParameters, report, outerRange)
+}
+
+func (t *InclusiveRangeType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	if t.MemberType == nil {
+		return t
+	}
+
+	resolvedMemberType := t.MemberType.Resolve(typeArguments)
+	if resolvedMemberType == nil {
+		return nil
+	}
+
+	return &InclusiveRangeType{
+		MemberType: resolvedMemberType,
+	}
+}
+
+var inclusiveRangeTypeParameter = &TypeParameter{
+	Name:      "T",
+	TypeBound: IntegerType,
+}
+
+func (*InclusiveRangeType) TypeParameters() []*TypeParameter {
+	return []*TypeParameter{
+		inclusiveRangeTypeParameter,
+	}
+}
+
+func (*InclusiveRangeType) Instantiate(typeArguments []Type, _ func(err error)) Type {
+	memberType := typeArguments[0]
+	return &InclusiveRangeType{
+		MemberType: memberType,
+	}
+}
+
+func (t *InclusiveRangeType) BaseType() Type {
+	if t.MemberType == nil {
+		return nil
+	}
+	return &InclusiveRangeType{}
+}
+
+func (t *InclusiveRangeType) TypeArguments() []Type {
+	return []Type{
+		t.EffectiveMemberType(),
+	}
+}
+
+// EffectiveMemberType returns the type of the integers in the range.
+// For the unparameterized type `InclusiveRange`, it is `Integer`.
+//
+func (t *InclusiveRangeType) EffectiveMemberType() Type {
+	if t.MemberType == nil {
+		return IntegerType
+	}
+	return t.MemberType
+}
+
+const inclusiveRangeTypeStartFieldDocString = `
+The start of the range
+`
+
+const inclusiveRangeTypeEndFieldDocString = `
+The end of the range, which is included in the range
+`
+
+const inclusiveRangeTypeStepFieldDocString = `
+The step between the integers of the range
+`
+
+const inclusiveRangeTypeContainsFunctionDocString = `
+Returns true if the given integer is in the range
+`
+
+func InclusiveRangeContainsFunctionType(memberType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(memberType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+	}
+}
+
+func (t *InclusiveRangeType) GetMembers() map[string]MemberResolver {
+	t.initializeMemberResolvers()
+	return t.memberResolvers
+}
+
+func (t *InclusiveRangeType) initializeMemberResolvers() {
+	t.memberResolversOnce.Do(func() {
+
+		memberType := t.EffectiveMemberType()
+
+		newField := func(docString string) MemberResolver {
+			return MemberResolver{
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						memberType,
+						docString,
+					)
+				},
+			}
+		}
+
+		t.memberResolvers = withBuiltinMembers(t, map[string]MemberResolver{
+			InclusiveRangeTypeStartFieldName: newField(inclusiveRangeTypeStartFieldDocString),
+			InclusiveRangeTypeEndFieldName:   newField(inclusiveRangeTypeEndFieldDocString),
+			InclusiveRangeTypeStepFieldName:  newField(inclusiveRangeTypeStepFieldDocString),
+			InclusiveRangeTypeContainsFunctionName: {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						InclusiveRangeContainsFunctionType(memberType),
+						inclusiveRangeTypeContainsFunctionDocString,
+					)
+				},
+			},
+		})
+	})
+}
+
+// InclusiveRangeConstructorFunctionType is the type of the `InclusiveRange` function,
+// which constructs a new range from a start integer, an end integer, and an optional step.
+//
+var InclusiveRangeConstructorFunctionType = func() *FunctionType {
+
+	typeParameter := &TypeParameter{
+		Name:      "T",
+		TypeBound: IntegerType,
+	}
+
+	memberType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     InclusiveRangeTypeStartFieldName,
+				TypeAnnotation: NewTypeAnnotation(memberType),
+			},
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     InclusiveRangeTypeEndFieldName,
+				TypeAnnotation: NewTypeAnnotation(memberType),
+			},
+			{
+				Identifier:     InclusiveRangeTypeStepFieldName,
+				TypeAnnotation: NewTypeAnnotation(memberType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&InclusiveRangeType{
+				MemberType: memberType,
+			},
+		),
+		// The step is optional
+		RequiredArgumentCount: RequiredArgumentCount(2),
+		TypeArgumentsCheck: func(
+			checker *Checker,
+			typeArguments *TypeParameterTypeOrderedMap,
+			invocationRange ast.Range,
+		) {
+			typeArgument, ok := typeArguments.Get(typeParameter)
+			if !ok || typeArgument == nil || typeArgument.IsInvalidType() ||
+				!IsSubType(typeArgument, IntegerType) {
+
+				// Invalid, already reported elsewhere
+				return
+			}
+
+			// The bounds must be of a concrete integer type,
+			// so they can be compared and stepped through
+
+			switch typeArgument {
+			case IntegerType, SignedIntegerType:
+				checker.report(
+					&InvalidTypeArgumentError{
+						TypeArgumentName: typeParameter.Name,
+						Details: fmt.Sprintf(
+							"expected concrete integer type, got `%s`",
+							typeArgument.QualifiedString(),
+						),
+						Range: invocationRange,
+					},
+				)
+			}
+		},
+	}
+}()
+
+const inclusiveRangeConstructorFunctionDocString = `
+Creates a new range which contains all integers from the given start up to and including the given end,
+with the given step between them.
+
+If no step is given, it defaults to 1 if the start is less than or equal to the end, and -1 otherwise.
+`
+
+func init() {
+
+	// Declare the constructor function for the inclusive range type
+
+	typeName := InclusiveRangeTypeName
+
+	// Check that the function is not accidentally redeclared
+
+	if BaseValueActivation.Find(typeName) != nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	BaseValueActivation.Set(
+		typeName,
+		baseFunctionVariable(
+			typeName,
+			InclusiveRangeConstructorFunctionType,
+			inclusiveRangeConstructorFunctionDocString,
+		),
+	)
+}
@@ -0,0 +1,162 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"sync"
+)
+
+// Types are immutable once constructed,
+// so the frequently constructed optional, reference, and variable-sized array types
+// of primitive types are interned, i.e. only constructed once and then shared.
+//
+// This avoids allocations when checking programs,
+// and also allows the lazily initialized members of the types to be shared.
+//
+// Only types with primitive inner types are interned,
+// as there is only a small, fixed number of them.
+
+// isInternableType returns true if the given type is a primitive type.
+//
+// NOTE: composed types, e.g. optional types, must not be considered internable,
+// even if they were interned: their values might not be unique
+//
+func isInternableType(ty Type) bool {
+	switch ty.(type) {
+	case *SimpleType, *NumericType, *FixedPointNumericType:
+		return true
+	default:
+		return false
+	}
+}
+
+type internedTypes struct {
+	lock               sync.RWMutex
+	optionalTypes      map[Type]*OptionalType
+	variableSizedTypes map[Type]*VariableSizedType
+	referenceTypes     map[Type]*ReferenceType
+	authReferenceTypes map[Type]*ReferenceType
+}
+
+var interned = &internedTypes{
+	optionalTypes:      map[Type]*OptionalType{},
+	variableSizedTypes: map[Type]*VariableSizedType{},
+	referenceTypes:     map[Type]*ReferenceType{},
+	authReferenceTypes: map[Type]*ReferenceType{},
+}
+
+// NewOptionalType returns an optional type with the given inner type.
+//
+// Optional types of primitive types are interned.
+//
+func NewOptionalType(ty Type) *OptionalType {
+	if !isInternableType(ty) {
+		return &OptionalType{
+			Type: ty,
+		}
+	}
+
+	interned.lock.RLock()
+	result, ok := interned.optionalTypes[ty]
+	interned.lock.RUnlock()
+	if ok {
+		return result
+	}
+
+	interned.lock.Lock()
+	defer interned.lock.Unlock()
+
+	result, ok = interned.optionalTypes[ty]
+	if !ok {
+		result = &OptionalType{
+			Type: ty,
+		}
+		interned.optionalTypes[ty] = result
+	}
+	return result
+}
+
+// NewVariableSizedType returns a variable-sized array type with the given element type.
+//
+// Variable-sized array types of primitive types are interned.
+//
+func NewVariableSizedType(ty Type) *VariableSizedType {
+	if !isInternableType(ty) {
+		return &VariableSizedType{
+			Type: ty,
+		}
+	}
+
+	interned.lock.RLock()
+	result, ok := interned.variableSizedTypes[ty]
+	interned.lock.RUnlock()
+	if ok {
+		return result
+	}
+
+	interned.lock.Lock()
+	defer interned.lock.Unlock()
+
+	result, ok = interned.variableSizedTypes[ty]
+	if !ok {
+		result = &VariableSizedType{
+			Type: ty,
+		}
+		interned.variableSizedTypes[ty] = result
+	}
+	return result
+}
+
+// NewReferenceType returns a reference type with the given referenced type.
+//
+// Reference types of primitive types are interned.
+//
+func NewReferenceType(authorized bool, ty Type) *ReferenceType {
+	if !isInternableType(ty) {
+		return &ReferenceType{
+			Authorized: authorized,
+			Type:       ty,
+		}
+	}
+
+	referenceTypes := interned.referenceTypes
+	if authorized {
+		referenceTypes = interned.authReferenceTypes
+	}
+
+	interned.lock.RLock()
+	result, ok := referenceTypes[ty]
+	interned.lock.RUnlock()
+	if ok {
+		return result
+	}
+
+	interned.lock.Lock()
+	defer interned.lock.Unlock()
+
+	result, ok = referenceTypes[ty]
+	if !ok {
+		result = &ReferenceType{
+			Authorized: authorized,
+			Type:       ty,
+		}
+		referenceTypes[ty] = result
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=LanguageVersion
+
+// LanguageVersion is the version of the language semantics enforced by the checker.
+//
+// Breaking changes to the checker, like new reserved identifiers or stricter rules,
+// are only enabled starting with a certain language version,
+// so embedders can roll them out at a certain point, e.g. at a certain block height.
+//
+type LanguageVersion uint
+
+const (
+	// LanguageVersionLegacy is the original behaviour of the checker.
+	// It is the default
+	LanguageVersionLegacy LanguageVersion = iota
+	// LanguageVersion1 reserves the identifiers of contextual keywords,
+	// and rejects uses of deprecated declarations
+	LanguageVersion1
+)
+
+// LanguageVersionLatest is the latest language version
+//
+const LanguageVersionLatest = LanguageVersion1
+
+var LanguageVersions = []LanguageVersion{
+	LanguageVersionLegacy,
+	LanguageVersion1,
+}
+
+// languageVersion1ReservedIdentifiers are the identifiers
+// which can not be used as names of declarations,
+// starting with language version 1.
+//
+// They are keywords which are only recognized in certain contexts,
+// so they were allowed as names before.
+//
+var languageVersion1ReservedIdentifiers = map[string]struct{}{
+	"access":      {},
+	"attach":      {},
+	"attachment":  {},
+	"entitlement": {},
+	"mapping":     {},
+	"remove":      {},
+}
+
+// IsReservedIdentifier returns true if the given identifier
+// can not be used as the name of a declaration in this language version.
+//
+func (v LanguageVersion) IsReservedIdentifier(identifier string) bool {
+	if v < LanguageVersion1 {
+		return false
+	}
+	_, ok := languageVersion1ReservedIdentifiers[identifier]
+	return ok
+}
@@ -0,0 +1,58 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// PublicAccountCapabilitiesType represents the type `PublicAccount.Capabilities`
+//
+var PublicAccountCapabilitiesType = func() *CompositeType {
+
+	publicAccountCapabilitiesType := &CompositeType{
+		Identifier: AccountCapabilitiesTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewPublicFunctionMember(
+			publicAccountCapabilitiesType,
+			AccountCapabilitiesTypeGetFunctionName,
+			AccountCapabilitiesTypeGetFunctionType,
+			accountCapabilitiesTypeGetFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			publicAccountCapabilitiesType,
+			AccountCapabilitiesTypeBorrowFunctionName,
+			AccountCapabilitiesTypeBorrowFunctionType,
+			accountCapabilitiesTypeBorrowFunctionDocString,
+		),
+	}
+
+	publicAccountCapabilitiesType.Members = GetMembersAsMap(members)
+	publicAccountCapabilitiesType.Fields = getFieldNames(members)
+	return publicAccountCapabilitiesType
+}()
+
+func init() {
+	// Set the container type after initializing the `PublicAccountCapabilitiesType`, to avoid initializing loop.
+	PublicAccountCapabilitiesType.SetContainerType(PublicAccountType)
+}
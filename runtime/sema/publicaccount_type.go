@@ -32,6 +32,7 @@ const PublicAccountGetCapabilityField = "getCapability"
 const PublicAccountGetTargetLinkField = "getLinkTarget"
 const PublicAccountKeysField = "keys"
 const PublicAccountContractsField = "contracts"
+const PublicAccountCapabilitiesField = "capabilities"
 
 // PublicAccountType represents the publicly accessible portion of an account.
 //
@@ -47,6 +48,7 @@ var PublicAccountType = func() *CompositeType {
 			nestedTypes := NewStringTypeOrderedMap()
 			nestedTypes.Set(AccountKeysTypeName, PublicAccountKeysType)
 			nestedTypes.Set(PublicAccountContractsTypeName, PublicAccountContractsType)
+			nestedTypes.Set(AccountCapabilitiesTypeName, PublicAccountCapabilitiesType)
 			return nestedTypes
 		}(),
 	}
@@ -106,6 +108,12 @@ var PublicAccountType = func() *CompositeType {
 			PublicAccountContractsType,
 			accountTypeContractsFieldDocString,
 		),
+		NewPublicConstantFieldMember(
+			publicAccountType,
+			PublicAccountCapabilitiesField,
+			PublicAccountCapabilitiesType,
+			accountTypeCapabilitiesFieldDocString,
+		),
 	}
 
 	publicAccountType.Members = GetMembersAsMap(members)
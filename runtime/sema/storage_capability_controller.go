@@ -0,0 +1,107 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+const StorageCapabilityControllerTypeName = "StorageCapabilityController"
+const StorageCapabilityControllerTypeCapabilityIDField = "capabilityID"
+const StorageCapabilityControllerTypeBorrowTypeField = "borrowType"
+const StorageCapabilityControllerTypeTargetFunctionName = "target"
+const StorageCapabilityControllerTypeRevokeFunctionName = "revoke"
+
+// StorageCapabilityControllerType represents the type `StorageCapabilityController`,
+// which manages a capability issued for a path in account storage.
+//
+var StorageCapabilityControllerType = func() *CompositeType {
+
+	storageCapabilityControllerType := &CompositeType{
+		Identifier: StorageCapabilityControllerTypeName,
+		Kind:       common.CompositeKindStructure,
+		importable: false,
+	}
+
+	var members = []*Member{
+		NewPublicConstantFieldMember(
+			storageCapabilityControllerType,
+			StorageCapabilityControllerTypeCapabilityIDField,
+			UInt64Type,
+			storageCapabilityControllerTypeCapabilityIDFieldDocString,
+		),
+		NewPublicConstantFieldMember(
+			storageCapabilityControllerType,
+			StorageCapabilityControllerTypeBorrowTypeField,
+			MetaType,
+			storageCapabilityControllerTypeBorrowTypeFieldDocString,
+		),
+		NewPublicFunctionMember(
+			storageCapabilityControllerType,
+			StorageCapabilityControllerTypeTargetFunctionName,
+			StorageCapabilityControllerTypeTargetFunctionType,
+			storageCapabilityControllerTypeTargetFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			storageCapabilityControllerType,
+			StorageCapabilityControllerTypeRevokeFunctionName,
+			StorageCapabilityControllerTypeRevokeFunctionType,
+			storageCapabilityControllerTypeRevokeFunctionDocString,
+		),
+	}
+
+	storageCapabilityControllerType.Members = GetMembersAsMap(members)
+	storageCapabilityControllerType.Fields = getFieldNames(members)
+	return storageCapabilityControllerType
+}()
+
+// StorageCapabilityControllerReferenceType is the type of the references
+// to capability controllers returned by `AuthAccount.StorageCapabilities`.
+//
+var StorageCapabilityControllerReferenceType = &ReferenceType{
+	Type: StorageCapabilityControllerType,
+}
+
+const storageCapabilityControllerTypeCapabilityIDFieldDocString = `
+The identifier of the controlled capability.
+All copies of a capability have the same ID.
+`
+
+const storageCapabilityControllerTypeBorrowTypeFieldDocString = `
+The type of the controlled capability, i.e. the T in ` + "`Capability<T>`" + `.
+`
+
+var StorageCapabilityControllerTypeTargetFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(StoragePathType),
+}
+
+const storageCapabilityControllerTypeTargetFunctionDocString = `
+Returns the targeted storage path of the controlled capability.
+`
+
+var StorageCapabilityControllerTypeRevokeFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+}
+
+const storageCapabilityControllerTypeRevokeFunctionDocString = `
+Revokes the controlled capability.
+
+All copies of the capability can no longer be borrowed or checked,
+and the controller can no longer be retrieved from the account.
+`
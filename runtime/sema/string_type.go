@@ -28,6 +28,21 @@ const StringTypeEncodeHexFunctionDocString = `
 Returns a hexadecimal string for the given byte array
 `
 
+const StringTypeFromUTF8FunctionName = "fromUTF8"
+const StringTypeFromUTF8FunctionDocString = `
+Returns the string for the given UTF-8 encoded bytes, or nil if the bytes are not valid UTF-8
+`
+
+const StringTypeFromUTF16FunctionName = "fromUTF16"
+const StringTypeFromUTF16FunctionDocString = `
+Returns the string for the given UTF-16 code units, or nil if the code units are not valid UTF-16
+`
+
+const StringTypeJoinFunctionName = "join"
+const StringTypeJoinFunctionDocString = `
+Returns a string which contains the given strings, with the given separator between each of them
+`
+
 // StringType represents the string type
 //
 var StringType = &SimpleType{
@@ -120,6 +135,83 @@ func init() {
 					)
 				},
 			},
+			"toUpper": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeToUpperFunctionType,
+						stringTypeToUpperFunctionDocString,
+					)
+				},
+			},
+			"utf16": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UTF16CodeUnitArrayType,
+						stringTypeUtf16FieldDocString,
+					)
+				},
+			},
+			"split": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeSplitFunctionType,
+						stringTypeSplitFunctionDocString,
+					)
+				},
+			},
+			"replaceAll": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeReplaceAllFunctionType,
+						stringTypeReplaceAllFunctionDocString,
+					)
+				},
+			},
+			"index": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeIndexFunctionType,
+						stringTypeIndexFunctionDocString,
+					)
+				},
+			},
+			"contains": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeContainsFunctionType,
+						stringTypeContainsFunctionDocString,
+					)
+				},
+			},
+			"padLeft": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypePadLeftFunctionType,
+						stringTypePadLeftFunctionDocString,
+					)
+				},
+			},
 		}
 	}
 }
@@ -195,3 +287,165 @@ var StringTypeToLowerFunctionType = &FunctionType{
 const stringTypeToLowerFunctionDocString = `
 Returns the string with upper case letters replaced with lowercase
 `
+
+var StringTypeToUpperFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeToUpperFunctionDocString = `
+Returns the string with lower case letters replaced with uppercase
+`
+
+var UTF16CodeUnitArrayType = &VariableSizedType{
+	Type: UInt16Type,
+}
+
+const stringTypeUtf16FieldDocString = `
+The array of the UTF-16 code units of the string
+`
+
+var StringArrayType = &VariableSizedType{
+	Type: StringType,
+}
+
+var StringTypeSplitFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringArrayType,
+	),
+}
+
+const stringTypeSplitFunctionDocString = `
+Returns the substrings of the string which are separated by the given separator.
+
+If the separator is empty, the string is split into its characters
+`
+
+var StringTypeReplaceAllFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "of",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+		{
+			Identifier:     "with",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+const stringTypeReplaceAllFunctionDocString = `
+Returns a new string in which all occurrences of the string ` + "`of`" + ` are replaced with the string ` + "`with`" + `.
+
+If ` + "`of`" + ` is empty, the string is returned unchanged
+`
+
+var StringTypeIndexFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "of",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		IntType,
+	),
+}
+
+const stringTypeIndexFunctionDocString = `
+Returns the index of the character at which the first occurrence of the given string starts, or -1 if the string does not contain the given string
+`
+
+var StringTypeContainsFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "other",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BoolType,
+	),
+}
+
+const stringTypeContainsFunctionDocString = `
+Returns true if the string contains the given string
+`
+
+var StringTypePadLeftFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "toLength",
+			TypeAnnotation: NewTypeAnnotation(IntType),
+		},
+		{
+			Identifier:     "with",
+			TypeAnnotation: NewTypeAnnotation(CharacterType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+const stringTypePadLeftFunctionDocString = `
+Returns a new string of the given length, which contains the string prefixed with the given character as many times as needed.
+
+If the string is already at least as long as the given length, the string is returned unchanged
+`
+
+var StringTypeFromUTF8FunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "bytes",
+			TypeAnnotation: NewTypeAnnotation(ByteArrayType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: StringType,
+		},
+	),
+}
+
+var StringTypeFromUTF16FunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "codeUnits",
+			TypeAnnotation: NewTypeAnnotation(UTF16CodeUnitArrayType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: StringType,
+		},
+	),
+}
+
+var StringTypeJoinFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "strings",
+			TypeAnnotation: NewTypeAnnotation(StringArrayType),
+		},
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
@@ -186,6 +186,30 @@ type MemberResolver struct {
 	Resolve func(identifier string, targetRange ast.Range, report func(error)) *Member
 }
 
+// newCachedMemberResolver returns a member resolver
+// which only resolves the member once, and returns the same member for all further resolutions.
+//
+// NOTE: the given resolve function must not report errors,
+// and must not depend on the target range
+//
+func newCachedMemberResolver(
+	kind common.DeclarationKind,
+	resolve func(identifier string) *Member,
+) MemberResolver {
+	var once sync.Once
+	var member *Member
+
+	return MemberResolver{
+		Kind: kind,
+		Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+			once.Do(func() {
+				member = resolve(identifier)
+			})
+			return member
+		},
+	}
+}
+
 // ContainedType is a type which might have a container type
 //
 type ContainedType interface {
@@ -376,9 +400,9 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 
 	// All types have a predeclared member `fun isInstance(_ type: Type): Bool`
 
-	members[IsInstanceFunctionName] = MemberResolver{
-		Kind: common.DeclarationKindFunction,
-		Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+	members[IsInstanceFunctionName] = newCachedMemberResolver(
+		common.DeclarationKindFunction,
+		func(identifier string) *Member {
 			return NewPublicFunctionMember(
 				ty,
 				identifier,
@@ -386,13 +410,13 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 				isInstanceFunctionDocString,
 			)
 		},
-	}
+	)
 
 	// All types have a predeclared member `fun getType(): Type`
 
-	members[GetTypeFunctionName] = MemberResolver{
-		Kind: common.DeclarationKindFunction,
-		Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+	members[GetTypeFunctionName] = newCachedMemberResolver(
+		common.DeclarationKindFunction,
+		func(identifier string) *Member {
 			return NewPublicFunctionMember(
 				ty,
 				identifier,
@@ -400,15 +424,15 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 				getTypeFunctionDocString,
 			)
 		},
-	}
+	)
 
 	// All number types, addresses, and path types have a `toString` function
 
 	if IsSubType(ty, NumberType) || IsSubType(ty, &AddressType{}) || IsSubType(ty, PathType) {
 
-		members[ToStringFunctionName] = MemberResolver{
-			Kind: common.DeclarationKindFunction,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		members[ToStringFunctionName] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
 				return NewPublicFunctionMember(
 					ty,
 					identifier,
@@ -416,16 +440,16 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 					toStringFunctionDocString,
 				)
 			},
-		}
+		)
 	}
 
 	// All number types have a `toBigEndianBytes` function
 
 	if IsSubType(ty, NumberType) {
 
-		members[ToBigEndianBytesFunctionName] = MemberResolver{
-			Kind: common.DeclarationKindFunction,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		members[ToBigEndianBytesFunctionName] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
 				return NewPublicFunctionMember(
 					ty,
 					identifier,
@@ -433,7 +457,7 @@ func withBuiltinMembers(ty Type, members map[string]MemberResolver) map[string]M
 					toBigEndianBytesFunctionDocString,
 				)
 			},
-		}
+		)
 	}
 
 	return members
@@ -545,9 +569,7 @@ func (t *OptionalType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type
 		return nil
 	}
 
-	return &OptionalType{
-		Type: newInnerType,
-	}
+	return NewOptionalType(newInnerType)
 }
 
 const optionalTypeMapFunctionDocString = `
@@ -791,6 +813,61 @@ const numericTypeSaturatingDivideFunctionDocString = `
 self / other, saturating at the numeric bounds instead of overflowing.
 `
 
+const IntegerTypeTruncatingConvertFunctionName = "truncatingConvert"
+const integerTypeTruncatingConvertFunctionDocString = `
+Converts self to the given fixed-size integer type, truncating it to the size of the type.
+
+Only the least significant bits of the two's complement representation of self are kept,
+so the result wraps around instead of aborting if self is outside the bounds of the type.
+`
+
+var integerTypeTruncatingConvertTypeParameter = &TypeParameter{
+	Name:      "T",
+	TypeBound: IntegerType,
+}
+
+var IntegerTypeTruncatingConvertFunctionType = &FunctionType{
+	TypeParameters: []*TypeParameter{
+		integerTypeTruncatingConvertTypeParameter,
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&GenericType{
+			TypeParameter: integerTypeTruncatingConvertTypeParameter,
+		},
+	),
+	TypeArgumentsCheck: func(
+		checker *Checker,
+		typeArguments *TypeParameterTypeOrderedMap,
+		invocationRange ast.Range,
+	) {
+		typeArgument, ok := typeArguments.Get(integerTypeTruncatingConvertTypeParameter)
+		if !ok || typeArgument == nil || typeArgument.IsInvalidType() ||
+			!IsSubType(typeArgument, IntegerType) {
+
+			// Invalid, already reported elsewhere
+			return
+		}
+
+		// Only fixed-size integer types can be truncated to
+
+		numericType, ok := typeArgument.(*NumericType)
+		if ok && numericType.minInt != nil && numericType.maxInt != nil {
+			return
+		}
+
+		checker.report(
+			&InvalidTypeArgumentError{
+				TypeArgumentName: integerTypeTruncatingConvertTypeParameter.Name,
+				Details: fmt.Sprintf(
+					"expected fixed-size integer type, got `%s`",
+					typeArgument.QualifiedString(),
+				),
+				Range: invocationRange,
+			},
+		)
+	},
+}
+
 func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[string]MemberResolver) {
 
 	arithmeticFunctionType := &FunctionType{
@@ -842,6 +919,101 @@ func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[st
 	}
 }
 
+const FixedPointTypeMinFunctionName = "min"
+const fixedPointTypeMinFunctionDocString = `
+Returns the smaller of self and other.
+`
+
+const FixedPointTypeMaxFunctionName = "max"
+const fixedPointTypeMaxFunctionDocString = `
+Returns the greater of self and other.
+`
+
+const FixedPointTypeAbsFunctionName = "abs"
+const fixedPointTypeAbsFunctionDocString = `
+Returns the absolute value of self.
+Aborts with an overflow if the absolute value is not representable, i.e. for the minimum value of a signed type.
+`
+
+const FixedPointTypePowFunctionName = "pow"
+const fixedPointTypePowFunctionDocString = `
+Returns self raised to the power of the given exponent.
+The result is computed by repeated squaring, and every intermediate product is truncated like a multiplication.
+Aborts with an overflow or underflow if an intermediate product exceeds the numeric bounds.
+`
+
+const FixedPointTypeSqrtFunctionName = "sqrt"
+const fixedPointTypeSqrtFunctionDocString = `
+Returns the square root of self, truncated to the scale of the type.
+Aborts if self is negative. The result is always in range, so the function never overflows.
+`
+
+func addFixedPointMathFunctions(t Type, members map[string]MemberResolver) {
+
+	addFunction := func(name string, functionType *FunctionType, docString string) {
+		members[name] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+				return NewPublicFunctionMember(t, name, functionType, docString)
+			},
+		}
+	}
+
+	binaryFunctionType := &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "other",
+				TypeAnnotation: NewTypeAnnotation(t),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(t),
+	}
+
+	unaryFunctionType := &FunctionType{
+		ReturnTypeAnnotation: NewTypeAnnotation(t),
+	}
+
+	addFunction(
+		FixedPointTypeMinFunctionName,
+		binaryFunctionType,
+		fixedPointTypeMinFunctionDocString,
+	)
+
+	addFunction(
+		FixedPointTypeMaxFunctionName,
+		binaryFunctionType,
+		fixedPointTypeMaxFunctionDocString,
+	)
+
+	addFunction(
+		FixedPointTypeAbsFunctionName,
+		unaryFunctionType,
+		fixedPointTypeAbsFunctionDocString,
+	)
+
+	addFunction(
+		FixedPointTypePowFunctionName,
+		&FunctionType{
+			Parameters: []*Parameter{
+				{
+					Label:          ArgumentLabelNotRequired,
+					Identifier:     "exponent",
+					TypeAnnotation: NewTypeAnnotation(UInt64Type),
+				},
+			},
+			ReturnTypeAnnotation: NewTypeAnnotation(t),
+		},
+		fixedPointTypePowFunctionDocString,
+	)
+
+	addFunction(
+		FixedPointTypeSqrtFunctionName,
+		unaryFunctionType,
+		fixedPointTypeSqrtFunctionDocString,
+	)
+}
+
 // NumericType represent all the types in the integer range
 // and non-fractional ranged types.
 //
@@ -999,6 +1171,22 @@ func (t *NumericType) initializeMemberResolvers() {
 
 		addSaturatingArithmeticFunctions(t, members)
 
+		// All integer types have a `truncatingConvert` function
+
+		if IsSubType(t, IntegerType) {
+			members[IntegerTypeTruncatingConvertFunctionName] = MemberResolver{
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						IntegerTypeTruncatingConvertFunctionType,
+						integerTypeTruncatingConvertFunctionDocString,
+					)
+				},
+			}
+		}
+
 		t.memberResolvers = withBuiltinMembers(t, members)
 	})
 }
@@ -1190,6 +1378,7 @@ func (t *FixedPointNumericType) initializeMemberResolvers() {
 		members := map[string]MemberResolver{}
 
 		addSaturatingArithmeticFunctions(t, members)
+		addFixedPointMathFunctions(t, members)
 
 		t.memberResolvers = withBuiltinMembers(t, members)
 	})
@@ -1337,25 +1526,37 @@ var (
 	// which does NOT check for overflow and underflow
 	Word8Type = NewNumericType(Word8TypeName).
 			WithTag(Word8TypeTag).
-			WithIntRange(Word8TypeMinInt, Word8TypeMaxInt)
+			WithIntRange(Word8TypeMinInt, Word8TypeMaxInt).
+			WithSaturatingAdd().
+			WithSaturatingSubtract().
+			WithSaturatingMultiply()
 
 	// Word16Type represents the 16-bit unsigned integer type `Word16`
 	// which does NOT check for overflow and underflow
 	Word16Type = NewNumericType(Word16TypeName).
 			WithTag(Word16TypeTag).
-			WithIntRange(Word16TypeMinInt, Word16TypeMaxInt)
+			WithIntRange(Word16TypeMinInt, Word16TypeMaxInt).
+			WithSaturatingAdd().
+			WithSaturatingSubtract().
+			WithSaturatingMultiply()
 
 	// Word32Type represents the 32-bit unsigned integer type `Word32`
 	// which does NOT check for overflow and underflow
 	Word32Type = NewNumericType(Word32TypeName).
 			WithTag(Word32TypeTag).
-			WithIntRange(Word32TypeMinInt, Word32TypeMaxInt)
+			WithIntRange(Word32TypeMinInt, Word32TypeMaxInt).
+			WithSaturatingAdd().
+			WithSaturatingSubtract().
+			WithSaturatingMultiply()
 
 	// Word64Type represents the 64-bit unsigned integer type `Word64`
 	// which does NOT check for overflow and underflow
 	Word64Type = NewNumericType(Word64TypeName).
 			WithTag(Word64TypeTag).
-			WithIntRange(Word64TypeMinInt, Word64TypeMaxInt)
+			WithIntRange(Word64TypeMinInt, Word64TypeMaxInt).
+			WithSaturatingAdd().
+			WithSaturatingSubtract().
+			WithSaturatingMultiply()
 
 	// FixedPointType represents the super-type of all fixed-point types
 	FixedPointType = NewNumericType(FixedPointTypeName).
@@ -1515,6 +1716,19 @@ const arrayTypeContainsFunctionDocString = `
 Returns true if the given object is in the array
 `
 
+const arrayTypeAtFunctionDocString = `
+Returns the element at the given index of the array, or nil if the index is out of bounds
+`
+
+const arrayTypeToVariableSizedFunctionDocString = `
+Returns a new variable-sized array with the elements of the constant-sized array
+`
+
+const arrayTypeToConstantSizedFunctionDocString = `
+Returns a new constant-sized array of the given type with the elements of the variable-sized array,
+or nil if the number of elements does not match the size of the given type
+`
+
 const arrayTypeLengthFieldDocString = `
 Returns the number of elements in the array
 `
@@ -1552,7 +1766,47 @@ If the index is outside the bounds, the program aborts
 const arrayTypeRemoveFirstFunctionDocString = `
 Removes the first element from the array and returns it.
 
-The array must not be empty. If the array is empty, the program aborts
+The array must not be empty. If the array is empty, the program aborts.
+
+If a predicate is given, the first element which satisfies the predicate is removed and returned.
+If no element satisfies the predicate, the program aborts
+`
+
+const arrayTypeRemoveAllFunctionDocString = `
+Removes all elements from the array which satisfy the given predicate, and returns them in a new array.
+
+The elements which do not satisfy the predicate keep their order
+`
+
+const arrayTypeMapFunctionDocString = `
+Returns a new variable-sized array which contains the results of calling the given function on each element of the array.
+For constant-sized arrays, the result is a constant-sized array of the same size.
+
+The array is not modified
+`
+
+const arrayTypeFilterFunctionDocString = `
+Returns a new variable-sized array which contains the elements of the array which satisfy the given predicate, in order.
+
+The array is not modified
+`
+
+const arrayTypeReduceFunctionDocString = `
+Combines the elements of the array, from first to last, by repeatedly calling the given function
+with the accumulated result, starting with the given initial value, and the next element.
+
+Returns the final accumulated result, or the initial value if the array is empty
+`
+
+const arrayTypeReverseFunctionDocString = `
+Returns a new array which contains the elements of the array in reverse order.
+
+The array is not modified
+`
+
+const arrayTypeFirstIndexFunctionDocString = `
+Returns the index of the first element of the array which is equal to the given element,
+or nil if the array does not contain the element
 `
 
 const arrayTypeRemoveLastFunctionDocString = `
@@ -1610,9 +1864,35 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
-		"length": {
-			Kind: common.DeclarationKindField,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		"at": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				// Resources cannot be returned from an array without moving them out of it
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayAtFunctionType(elementType),
+					arrayTypeAtFunctionDocString,
+				)
+			},
+		},
+		"length": newCachedMemberResolver(
+			common.DeclarationKindField,
+			func(identifier string) *Member {
 				return NewPublicConstantFieldMember(
 					arrayType,
 					identifier,
@@ -1620,27 +1900,49 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 					arrayTypeLengthFieldDocString,
 				)
 			},
-		},
-	}
-
-	// TODO: maybe still return members but report a helpful error?
-
-	if _, ok := arrayType.(*VariableSizedType); ok {
-
-		members["append"] = MemberResolver{
+		),
+		"firstIndex": {
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
 				elementType := arrayType.ElementType(false)
+
+				// Like for the `contains` function, it is impossible for the element to be in the array
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				if !elementType.IsEquatable() {
+					report(
+						&NotEquatableTypeError{
+							Type:  elementType,
+							Range: targetRange,
+						},
+					)
+				}
+
 				return NewPublicFunctionMember(
 					arrayType,
 					identifier,
-					ArrayAppendFunctionType(elementType),
-					arrayTypeAppendFunctionDocString,
+					ArrayFirstIndexFunctionType(elementType),
+					arrayTypeFirstIndexFunctionDocString,
 				)
 			},
-		}
+		},
+	}
 
-		members["appendAll"] = MemberResolver{
+	// The following functions copy elements into new arrays or pass them to functions,
+	// so they are not available for arrays of resources
+
+	addNonResourceFunction := func(name string, functionType func() *FunctionType, docString string) {
+		members[name] = MemberResolver{
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
 
@@ -1659,18 +1961,74 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				return NewPublicFunctionMember(
 					arrayType,
 					identifier,
-					ArrayAppendAllFunctionType(arrayType),
-					arrayTypeAppendAllFunctionDocString,
+					functionType(),
+					docString,
 				)
 			},
 		}
+	}
 
-		members["concat"] = MemberResolver{
+	addNonResourceFunction(
+		"slice",
+		func() *FunctionType {
+			return ArraySliceFunctionType(arrayType.ElementType(false))
+		},
+		arrayTypeSliceFunctionDocString,
+	)
+
+	addNonResourceFunction(
+		"map",
+		func() *FunctionType {
+			return ArrayMapFunctionType(arrayType)
+		},
+		arrayTypeMapFunctionDocString,
+	)
+
+	addNonResourceFunction(
+		"filter",
+		func() *FunctionType {
+			return ArrayFilterFunctionType(arrayType.ElementType(false))
+		},
+		arrayTypeFilterFunctionDocString,
+	)
+
+	addNonResourceFunction(
+		"reduce",
+		func() *FunctionType {
+			return ArrayReduceFunctionType(arrayType.ElementType(false))
+		},
+		arrayTypeReduceFunctionDocString,
+	)
+
+	addNonResourceFunction(
+		"reverse",
+		func() *FunctionType {
+			return ArrayReverseFunctionType(arrayType)
+		},
+		arrayTypeReverseFunctionDocString,
+	)
+
+	// TODO: maybe still return members but report a helpful error?
+
+	if _, ok := arrayType.(*VariableSizedType); ok {
+
+		members["append"] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
+				elementType := arrayType.ElementType(false)
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayAppendFunctionType(elementType),
+					arrayTypeAppendFunctionDocString,
+				)
+			},
+		)
+
+		members["appendAll"] = MemberResolver{
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
 
-				// TODO: maybe allow for resource element type
-
 				elementType := arrayType.ElementType(false)
 
 				if elementType.IsResourceType() {
@@ -1686,16 +2044,18 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				return NewPublicFunctionMember(
 					arrayType,
 					identifier,
-					ArrayConcatFunctionType(arrayType),
-					arrayTypeConcatFunctionDocString,
+					ArrayAppendAllFunctionType(arrayType),
+					arrayTypeAppendAllFunctionDocString,
 				)
 			},
 		}
 
-		members["slice"] = MemberResolver{
+		members["concat"] = MemberResolver{
 			Kind: common.DeclarationKindFunction,
 			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
 
+				// TODO: maybe allow for resource element type
+
 				elementType := arrayType.ElementType(false)
 
 				if elementType.IsResourceType() {
@@ -1711,15 +2071,15 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				return NewPublicFunctionMember(
 					arrayType,
 					identifier,
-					ArraySliceFunctionType(elementType),
-					arrayTypeSliceFunctionDocString,
+					ArrayConcatFunctionType(arrayType),
+					arrayTypeConcatFunctionDocString,
 				)
 			},
 		}
 
-		members["insert"] = MemberResolver{
-			Kind: common.DeclarationKindFunction,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		members["insert"] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
 
 				elementType := arrayType.ElementType(false)
 
@@ -1730,11 +2090,11 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 					arrayTypeInsertFunctionDocString,
 				)
 			},
-		}
+		)
 
-		members["remove"] = MemberResolver{
-			Kind: common.DeclarationKindFunction,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		members["remove"] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
 
 				elementType := arrayType.ElementType(false)
 
@@ -1745,11 +2105,11 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 					arrayTypeRemoveFunctionDocString,
 				)
 			},
-		}
+		)
 
-		members["removeFirst"] = MemberResolver{
-			Kind: common.DeclarationKindFunction,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		members["removeFirst"] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
 
 				elementType := arrayType.ElementType(false)
 
@@ -1761,11 +2121,26 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 					arrayTypeRemoveFirstFunctionDocString,
 				)
 			},
-		}
+		)
 
-		members["removeLast"] = MemberResolver{
-			Kind: common.DeclarationKindFunction,
-			Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+		members["removeAll"] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayRemoveAllFunctionType(elementType),
+					arrayTypeRemoveAllFunctionDocString,
+				)
+			},
+		)
+
+		members["removeLast"] = newCachedMemberResolver(
+			common.DeclarationKindFunction,
+			func(identifier string) *Member {
 
 				elementType := arrayType.ElementType(false)
 
@@ -1776,6 +2151,63 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 					arrayTypeRemoveLastFunctionDocString,
 				)
 			},
+		)
+
+		members["toConstantSized"] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				// Resources cannot be copied into a new array
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayToConstantSizedFunctionType(elementType),
+					arrayTypeToConstantSizedFunctionDocString,
+				)
+			},
+		}
+	}
+
+	if _, ok := arrayType.(*ConstantSizedType); ok {
+
+		members["toVariableSized"] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				// Resources cannot be copied into a new array
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayToVariableSizedFunctionType(elementType),
+					arrayTypeToVariableSizedFunctionDocString,
+				)
+			},
 		}
 	}
 
@@ -1792,9 +2224,62 @@ func ArrayRemoveLastFunctionType(elementType Type) *FunctionType {
 
 func ArrayRemoveFirstFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          "where",
+				Identifier:     "predicate",
+				TypeAnnotation: NewTypeAnnotation(ArrayPredicateFunctionType(elementType)),
+			},
+		},
 		ReturnTypeAnnotation: NewTypeAnnotation(
 			elementType,
 		),
+		RequiredArgumentCount: RequiredArgumentCount(0),
+	}
+}
+
+func ArrayRemoveAllFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          "where",
+				Identifier:     "predicate",
+				TypeAnnotation: NewTypeAnnotation(ArrayPredicateFunctionType(elementType)),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&VariableSizedType{
+				Type: elementType,
+			},
+		),
+	}
+}
+
+// ArrayPredicateFunctionType returns the type of a predicate function
+// for elements of the given type.
+//
+// Resources are passed to the predicate by reference,
+// as they must not be moved out of the array.
+//
+func ArrayPredicateFunctionType(elementType Type) *FunctionType {
+	parameterType := elementType
+	if elementType.IsResourceType() {
+		parameterType = &ReferenceType{
+			Type: elementType,
+		}
+	}
+
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(parameterType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			BoolType,
+		),
 	}
 }
 
@@ -1860,6 +2345,23 @@ func ArrayContainsFunctionType(elementType Type) *FunctionType {
 	}
 }
 
+func ArrayAtFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "index",
+				TypeAnnotation: NewTypeAnnotation(IntegerType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: elementType,
+			},
+		),
+	}
+}
+
 func ArrayAppendAllFunctionType(arrayType Type) *FunctionType {
 	return &FunctionType{
 		Parameters: []*Parameter{
@@ -1886,23 +2388,225 @@ func ArrayAppendFunctionType(elementType Type) *FunctionType {
 			VoidType,
 		),
 	}
-}
+}
+
+func ArraySliceFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier:     "from",
+				TypeAnnotation: NewTypeAnnotation(IntType),
+			},
+			{
+				Identifier:     "upTo",
+				TypeAnnotation: NewTypeAnnotation(IntType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+			Type: elementType,
+		}),
+	}
+}
+
+// ArrayMapFunctionType returns the type of the function which transforms
+// each element of an array of the given type.
+//
+// The result of a constant-sized array is a constant-sized array of the same size.
+//
+func ArrayMapFunctionType(arrayType ArrayType) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name:      "U",
+		TypeBound: AnyStructType,
+	}
+
+	resultElementType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	var resultType Type
+	if constantSizedType, ok := arrayType.(*ConstantSizedType); ok {
+		resultType = &ConstantSizedType{
+			Type: resultElementType,
+			Size: constantSizedType.Size,
+		}
+	} else {
+		resultType = &VariableSizedType{
+			Type: resultElementType,
+		}
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "transform",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(arrayType.ElementType(false)),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(resultElementType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+	}
+}
+
+func ArrayFilterFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "predicate",
+				TypeAnnotation: NewTypeAnnotation(ArrayPredicateFunctionType(elementType)),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&VariableSizedType{
+				Type: elementType,
+			},
+		),
+	}
+}
+
+func ArrayReduceFunctionType(elementType Type) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name:      "U",
+		TypeBound: AnyStructType,
+	}
+
+	resultType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Identifier:     "initial",
+				TypeAnnotation: NewTypeAnnotation(resultType),
+			},
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "combine",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "accumulator",
+								TypeAnnotation: NewTypeAnnotation(resultType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+	}
+}
+
+func ArrayReverseFunctionType(arrayType ArrayType) *FunctionType {
+	return &FunctionType{
+		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
+	}
+}
+
+func ArrayFirstIndexFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier:     "of",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: IntType,
+			},
+		),
+	}
+}
+
+func ArrayToVariableSizedFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&VariableSizedType{
+				Type: elementType,
+			},
+		),
+	}
+}
+
+// ArrayToConstantSizedFunctionType returns the type of the function
+// which converts a variable-sized array with the given element type
+// to the constant-sized array type given as the type argument.
+//
+// The type argument must be a constant-sized array type
+// with the same element type.
+//
+func ArrayToConstantSizedFunctionType(elementType Type) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name:      "T",
+		TypeBound: AnyStructType,
+	}
 
-func ArraySliceFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
-		Parameters: []*Parameter{
-			{
-				Identifier:     "from",
-				TypeAnnotation: NewTypeAnnotation(IntType),
-			},
-			{
-				Identifier:     "upTo",
-				TypeAnnotation: NewTypeAnnotation(IntType),
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: &GenericType{
+					TypeParameter: typeParameter,
+				},
 			},
+		),
+		TypeArgumentsCheck: func(
+			checker *Checker,
+			typeArguments *TypeParameterTypeOrderedMap,
+			invocationRange ast.Range,
+		) {
+			typeArgument, ok := typeArguments.Get(typeParameter)
+			if !ok || typeArgument == nil || typeArgument.IsInvalidType() {
+				// Invalid, already reported elsewhere
+				return
+			}
+
+			constantSizedType, ok := typeArgument.(*ConstantSizedType)
+			if ok && constantSizedType.Type.Equal(elementType) {
+				return
+			}
+
+			checker.report(
+				&InvalidTypeArgumentError{
+					TypeArgumentName: typeParameter.Name,
+					Details: fmt.Sprintf(
+						"expected constant-sized array type with element type `%s`, got `%s`",
+						elementType.QualifiedString(),
+						typeArgument.QualifiedString(),
+					),
+					Range: invocationRange,
+				},
+			)
 		},
-		ReturnTypeAnnotation: NewTypeAnnotation(&VariableSizedType{
-			Type: elementType,
-		}),
 	}
 }
 
@@ -2355,6 +3059,7 @@ type FunctionType struct {
 	ReturnTypeAnnotation     *TypeAnnotation
 	RequiredArgumentCount    *int
 	ArgumentExpressionsCheck ArgumentExpressionsCheck
+	TypeArgumentsCheck       TypeArgumentsCheck
 	Members                  *StringMemberOrderedMap
 }
 
@@ -2379,6 +3084,17 @@ func (t *FunctionType) CheckArgumentExpressions(
 	t.ArgumentExpressionsCheck(checker, argumentExpressions, invocationRange)
 }
 
+func (t *FunctionType) CheckTypeArguments(
+	checker *Checker,
+	typeArguments *TypeParameterTypeOrderedMap,
+	invocationRange ast.Range,
+) {
+	if t.TypeArgumentsCheck == nil {
+		return
+	}
+	t.TypeArgumentsCheck(checker, typeArguments, invocationRange)
+}
+
 func (t *FunctionType) String() string {
 
 	typeParameters := make([]string, len(t.TypeParameters))
@@ -2802,6 +3518,12 @@ type ArgumentExpressionsCheck func(
 	invocationRange ast.Range,
 )
 
+type TypeArgumentsCheck func(
+	checker *Checker,
+	typeArguments *TypeParameterTypeOrderedMap,
+	invocationRange ast.Range,
+)
+
 // BaseTypeActivation is the base activation that contains
 // the types available in programs
 //
@@ -2821,6 +3543,7 @@ func init() {
 		BoolType,
 		CharacterType,
 		StringType,
+		BytesType,
 		&AddressType{},
 		AuthAccountType,
 		PublicAccountType,
@@ -2830,12 +3553,16 @@ func init() {
 		PrivatePathType,
 		PublicPathType,
 		&CapabilityType{},
+		&InclusiveRangeType{},
 		DeployedContractType,
 		BlockType,
+		BlockHeaderType,
 		AccountKeyType,
 		PublicKeyType,
 		SignatureAlgorithmType,
 		HashAlgorithmType,
+		HasherType,
+		StorageCapabilityControllerType,
 	)
 
 	for _, ty := range types {
@@ -2947,6 +3674,50 @@ const numberTypeMaxFieldDocString = `The maximum integer of this type`
 const fixedPointNumberTypeMinFieldDocString = `The minimum fixed-point value of this type`
 const fixedPointNumberTypeMaxFieldDocString = `The maximum fixed-point value of this type`
 
+const NumberTypeTruncatingFunctionName = "truncating"
+const numberTypeTruncatingFunctionDocString = `
+Converts the given integer to this type, truncating it to the size of this type.
+
+Only the least significant bits of the two's complement representation of the integer are kept,
+so the result wraps around if the integer is outside the bounds of this type
+`
+
+func NumberTypeTruncatingFunctionType(numberType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "value",
+				TypeAnnotation: NewTypeAnnotation(IntegerType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(numberType),
+	}
+}
+
+const NumberTypeFromBigEndianBytesFunctionName = "fromBigEndianBytes"
+const numberTypeFromBigEndianBytesFunctionDocString = `
+Returns the number for the given big-endian byte representation, as returned by toBigEndianBytes,
+or nil if the bytes are empty or do not fit into this type
+`
+
+func NumberTypeFromBigEndianBytesFunctionType(numberType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "bytes",
+				TypeAnnotation: NewTypeAnnotation(ByteArrayType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: numberType,
+			},
+		),
+	}
+}
+
 const numberConversionFunctionDocStringSuffix = `
 The value must be within the bounds of this type.
 If a value is passed that is outside the bounds, the program aborts.`
@@ -3016,6 +3787,17 @@ func init() {
 					))
 				}
 
+				// Only fixed-size integer types can be truncated to
+
+				if numberType.minInt != nil && numberType.maxInt != nil {
+					addMember(NewPublicFunctionMember(
+						functionType,
+						NumberTypeTruncatingFunctionName,
+						NumberTypeTruncatingFunctionType(numberType),
+						numberTypeTruncatingFunctionDocString,
+					))
+				}
+
 			case *FixedPointNumericType:
 				if numberType.minInt != nil {
 					// If a minimum integer is set, a minimum fractional must be set
@@ -3046,6 +3828,13 @@ func init() {
 				}
 			}
 
+			addMember(NewPublicFunctionMember(
+				functionType,
+				NumberTypeFromBigEndianBytesFunctionName,
+				NumberTypeFromBigEndianBytesFunctionType(numberType),
+				numberTypeFromBigEndianBytesFunctionDocString,
+			))
+
 			BaseValueActivation.Set(
 				typeName,
 				baseFunctionVariable(
@@ -3188,6 +3977,27 @@ func init() {
 		StringTypeEncodeHexFunctionDocString,
 	))
 
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeFromUTF8FunctionName,
+		StringTypeFromUTF8FunctionType,
+		StringTypeFromUTF8FunctionDocString,
+	))
+
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeFromUTF16FunctionName,
+		StringTypeFromUTF16FunctionType,
+		StringTypeFromUTF16FunctionDocString,
+	))
+
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeJoinFunctionName,
+		StringTypeJoinFunctionType,
+		StringTypeJoinFunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
@@ -3426,7 +4236,10 @@ type CompositeType struct {
 	nestedTypes           *StringTypeOrderedMap
 	containerType         Type
 	EnumRawType           Type
-	hasComputedMembers    bool
+	// BaseType is the type extended by the attachment.
+	// Only applicable for attachments.
+	BaseType           Type
+	hasComputedMembers bool
 
 	// Only applicable for native composite types.
 	importable bool
@@ -3565,7 +4378,22 @@ func (t *CompositeType) GetMembers() map[string]MemberResolver {
 }
 
 func (t *CompositeType) IsResourceType() bool {
-	return t.Kind == common.CompositeKindResource
+	return t.effectiveKind() == common.CompositeKindResource
+}
+
+// effectiveKind returns the kind which determines the semantics of the composite.
+// Attachments are resources if they extend a resource, and structures otherwise.
+//
+func (t *CompositeType) effectiveKind() common.CompositeKind {
+	if t.Kind != common.CompositeKindAttachment {
+		return t.Kind
+	}
+
+	if t.BaseType != nil && t.BaseType.IsResourceType() {
+		return common.CompositeKindResource
+	}
+
+	return common.CompositeKindStructure
 }
 
 func (*CompositeType) IsInvalidType() bool {
@@ -3577,12 +4405,13 @@ func (t *CompositeType) IsStorable(results map[*Member]bool) bool {
 		return false
 	}
 
-	// Only structures, resources, and enums can be stored
+	// Only structures, resources, enums, and attachments can be stored
 
 	switch t.Kind {
 	case common.CompositeKindStructure,
 		common.CompositeKindResource,
-		common.CompositeKindEnum:
+		common.CompositeKindEnum,
+		common.CompositeKindAttachment:
 		break
 	default:
 		return false
@@ -3634,12 +4463,13 @@ func (t *CompositeType) IsImportable(results map[*Member]bool) bool {
 }
 
 func (t *CompositeType) IsExternallyReturnable(results map[*Member]bool) bool {
-	// Only structures, resources, and enums can be stored
+	// Only structures, resources, enums, and attachments can be stored
 
 	switch t.Kind {
 	case common.CompositeKindStructure,
 		common.CompositeKindResource,
-		common.CompositeKindEnum:
+		common.CompositeKindEnum,
+		common.CompositeKindAttachment:
 		break
 	default:
 		return false
@@ -3760,10 +4590,12 @@ func (t *CompositeType) initializeMemberResolvers() {
 // Member
 
 type Member struct {
-	ContainerType  Type
-	Access         ast.Access
-	Identifier     ast.Identifier
-	TypeAnnotation *TypeAnnotation
+	ContainerType Type
+	Access        ast.Access
+	// EntitlementAccess is the access of a member with entitlement access
+	EntitlementAccess EntitlementAccess
+	Identifier        ast.Identifier
+	TypeAnnotation    *TypeAnnotation
 	// TODO: replace with dedicated MemberKind enum
 	DeclarationKind common.DeclarationKind
 	VariableKind    ast.VariableKind
@@ -4252,6 +5084,24 @@ Inserts the given value into the dictionary under the given key.
 Returns the previous value as an optional if the dictionary contained the key, or nil if the dictionary did not contain the key
 `
 
+const dictionaryTypeFilterFunctionDocString = `
+Returns a new dictionary which contains the key-value pairs of the dictionary which satisfy the given predicate.
+
+The dictionary is not modified
+`
+
+const dictionaryTypeForEachKeyFunctionDocString = `
+Calls the given function for each key of the dictionary, in the deterministic iteration order of the dictionary.
+
+Iteration stops if the function returns false
+`
+
+const dictionaryTypeForEachValueFunctionDocString = `
+Calls the given function for each value of the dictionary, in the deterministic iteration order of the dictionary.
+
+Iteration stops if the function returns false
+`
+
 const dictionaryTypeRemoveFunctionDocString = `
 Removes the value for the given key from the dictionary.
 
@@ -4267,9 +5117,9 @@ func (t *DictionaryType) initializeMemberResolvers() {
 	t.memberResolversOnce.Do(func() {
 
 		t.memberResolvers = withBuiltinMembers(t, map[string]MemberResolver{
-			"containsKey": {
-				Kind: common.DeclarationKindFunction,
-				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+			"containsKey": newCachedMemberResolver(
+				common.DeclarationKindFunction,
+				func(identifier string) *Member {
 
 					return NewPublicFunctionMember(
 						t,
@@ -4278,10 +5128,10 @@ func (t *DictionaryType) initializeMemberResolvers() {
 						dictionaryTypeContainsKeyFunctionDocString,
 					)
 				},
-			},
-			"length": {
-				Kind: common.DeclarationKindField,
-				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+			),
+			"length": newCachedMemberResolver(
+				common.DeclarationKindField,
+				func(identifier string) *Member {
 					return NewPublicConstantFieldMember(
 						t,
 						identifier,
@@ -4289,7 +5139,7 @@ func (t *DictionaryType) initializeMemberResolvers() {
 						dictionaryTypeLengthFieldDocString,
 					)
 				},
-			},
+			),
 			"keys": {
 				Kind: common.DeclarationKindField,
 				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
@@ -4336,30 +5186,160 @@ func (t *DictionaryType) initializeMemberResolvers() {
 					)
 				},
 			},
-			"insert": {
-				Kind: common.DeclarationKindFunction,
-				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+			"insert": newCachedMemberResolver(
+				common.DeclarationKindFunction,
+				func(identifier string) *Member {
 					return NewPublicFunctionMember(t,
 						identifier,
 						DictionaryInsertFunctionType(t),
 						dictionaryTypeInsertFunctionDocString,
 					)
 				},
-			},
-			"remove": {
-				Kind: common.DeclarationKindFunction,
-				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+			),
+			"remove": newCachedMemberResolver(
+				common.DeclarationKindFunction,
+				func(identifier string) *Member {
 					return NewPublicFunctionMember(t,
 						identifier,
 						DictionaryRemoveFunctionType(t),
 						dictionaryTypeRemoveFunctionDocString,
 					)
 				},
+			),
+			"filter": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+					if t.ValueType.IsResourceType() {
+						report(
+							&InvalidResourceDictionaryMemberError{
+								Name:            identifier,
+								DeclarationKind: common.DeclarationKindFunction,
+								Range:           targetRange,
+							},
+						)
+					}
+
+					return NewPublicFunctionMember(t,
+						identifier,
+						DictionaryFilterFunctionType(t),
+						dictionaryTypeFilterFunctionDocString,
+					)
+				},
+			},
+			"forEachKey": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+					if t.KeyType.IsResourceType() {
+						report(
+							&InvalidResourceDictionaryMemberError{
+								Name:            identifier,
+								DeclarationKind: common.DeclarationKindFunction,
+								Range:           targetRange,
+							},
+						)
+					}
+
+					return NewPublicFunctionMember(t,
+						identifier,
+						DictionaryForEachKeyFunctionType(t),
+						dictionaryTypeForEachKeyFunctionDocString,
+					)
+				},
+			},
+			"forEachValue": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+					if t.ValueType.IsResourceType() {
+						report(
+							&InvalidResourceDictionaryMemberError{
+								Name:            identifier,
+								DeclarationKind: common.DeclarationKindFunction,
+								Range:           targetRange,
+							},
+						)
+					}
+
+					return NewPublicFunctionMember(t,
+						identifier,
+						DictionaryForEachValueFunctionType(t),
+						dictionaryTypeForEachValueFunctionDocString,
+					)
+				},
 			},
 		})
 	})
 }
 
+func DictionaryFilterFunctionType(t *DictionaryType) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "predicate",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "key",
+								TypeAnnotation: NewTypeAnnotation(t.KeyType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "value",
+								TypeAnnotation: NewTypeAnnotation(t.ValueType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(t),
+	}
+}
+
+func DictionaryForEachKeyFunctionType(t *DictionaryType) *FunctionType {
+	return dictionaryForEachFunctionType("key", t.KeyType)
+}
+
+func DictionaryForEachValueFunctionType(t *DictionaryType) *FunctionType {
+	return dictionaryForEachFunctionType("value", t.ValueType)
+}
+
+func dictionaryForEachFunctionType(identifier string, elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "function",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     identifier,
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			VoidType,
+		),
+	}
+}
+
 func DictionaryContainsKeyFunctionType(t *DictionaryType) *FunctionType {
 	return &FunctionType{
 		Parameters: []*Parameter{
@@ -4417,7 +5397,7 @@ func (*DictionaryType) isValueIndexableType() bool {
 }
 
 func (t *DictionaryType) ElementType(_ bool) Type {
-	return &OptionalType{Type: t.ValueType}
+	return NewOptionalType(t.ValueType)
 }
 
 func (*DictionaryType) AllowsValueIndexingAssignment() bool {
@@ -4470,7 +5450,9 @@ func (t *DictionaryType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Typ
 // ReferenceType represents the reference to a value
 type ReferenceType struct {
 	Authorized bool
-	Type       Type
+	// Authorization is the set of entitlements of an entitled reference, if any
+	Authorization *EntitlementSetAccess
+	Type          Type
 }
 
 func (*ReferenceType) IsType() {}
@@ -4486,6 +5468,10 @@ func (t *ReferenceType) string(typeFormatter func(Type) string) string {
 	var builder strings.Builder
 	if t.Authorized {
 		builder.WriteString("auth ")
+	} else if t.Authorization != nil {
+		builder.WriteString("auth(")
+		builder.WriteString(t.Authorization.string(typeFormatter))
+		builder.WriteString(") ")
 	}
 	builder.WriteRune('&')
 	builder.WriteString(typeFormatter(t.Type))
@@ -4518,7 +5504,9 @@ func (t *ReferenceType) Equal(other Type) bool {
 		return false
 	}
 
-	if t.Authorized != otherReference.Authorized {
+	if t.Authorized != otherReference.Authorized ||
+		!t.Authorization.Equal(otherReference.Authorization) {
+
 		return false
 	}
 
@@ -4557,8 +5545,9 @@ func (t *ReferenceType) RewriteWithRestrictedTypes() (Type, bool) {
 	rewrittenType, rewritten := t.Type.RewriteWithRestrictedTypes()
 	if rewritten {
 		return &ReferenceType{
-			Authorized: t.Authorized,
-			Type:       rewrittenType,
+			Authorized:    t.Authorized,
+			Authorization: t.Authorization,
+			Type:          rewrittenType,
 		}, true
 	} else {
 		return t, false
@@ -4935,6 +5924,23 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			return false
 		}
 
+		// An entitled reference type `auth(E) &T` is only a subtype
+		// of an entitled reference type `auth(F) &U`,
+		// if the entitlements `E` guarantee the entitlements `F`.
+		//
+		// Otherwise, entitled reference types behave like unauthorized reference types.
+
+		if !typedSubType.Authorization.PermitsAccess(typedSuperType.Authorization) {
+			return false
+		}
+
+		// The references only differ in their authorization,
+		// which was already determined to be sufficient
+
+		if typedSubType.Type.Equal(typedSuperType.Type) {
+			return true
+		}
+
 		switch typedInnerSuperType := typedSuperType.Type.(type) {
 		case *RestrictedType:
 
@@ -5436,6 +6442,26 @@ func (t *TransactionType) EntryPointFunctionType() *FunctionType {
 	}
 }
 
+// HasAuthorizerArrayParameter returns true if the last prepare parameter
+// is an array of accounts, i.e. if the transaction accepts a variable number of signers.
+//
+func (t *TransactionType) HasAuthorizerArrayParameter() bool {
+	count := len(t.PrepareParameters)
+	if count == 0 {
+		return false
+	}
+
+	lastParameterType := t.PrepareParameters[count-1].TypeAnnotation.Type
+	return IsAuthAccountArrayType(lastParameterType)
+}
+
+// IsAuthAccountArrayType returns true if the given type is `[AuthAccount]`.
+//
+func IsAuthAccountArrayType(ty Type) bool {
+	arrayType, ok := ty.(*VariableSizedType)
+	return ok && IsSameTypeKind(arrayType.Type, AuthAccountType)
+}
+
 func (t *TransactionType) PrepareFunctionType() *FunctionType {
 	return &FunctionType{
 		IsConstructor:        true,
@@ -5988,6 +7014,11 @@ const addressTypeCheckFunctionDocString = `
 The address of the capability
 `
 
+const capabilityTypeIDFieldDocString = `
+The ID of the capability.
+It is zero for capabilities created by linking
+`
+
 func (t *CapabilityType) GetMembers() map[string]MemberResolver {
 	t.initializeMemberResolvers()
 	return t.memberResolvers
@@ -6029,6 +7060,17 @@ func (t *CapabilityType) initializeMemberResolvers() {
 					)
 				},
 			},
+			"id": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						UInt64Type,
+						capabilityTypeIDFieldDocString,
+					)
+				},
+			},
 		})
 	})
 }
@@ -6040,6 +7082,7 @@ func init() {
 		AccountKeyType,
 		PublicKeyType,
 		HashAlgorithmType,
+		HasherType,
 		SignatureAlgorithmType,
 		AuthAccountType,
 		AuthAccountKeysType,
@@ -6047,6 +7090,10 @@ func init() {
 		PublicAccountType,
 		PublicAccountKeysType,
 		PublicAccountContractsType,
+		AuthAccountCapabilitiesType,
+		AuthAccountStorageCapabilitiesType,
+		PublicAccountCapabilitiesType,
+		StorageCapabilityControllerType,
 	}
 
 	for _, semaType := range types {
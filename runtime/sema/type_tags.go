@@ -219,6 +219,9 @@ const (
 	capabilityTypeMask uint64 = 1 << iota
 	restrictedTypeMask
 	transactionTypeMask
+	bytesTypeMask
+	blockHeaderTypeMask
+	inclusiveRangeTypeMask
 
 	invalidTypeMask
 )
@@ -332,6 +335,10 @@ var (
 	CapabilityTypeTag  = newTypeTagFromUpperMask(capabilityTypeMask)
 	InvalidTypeTag     = newTypeTagFromUpperMask(invalidTypeMask)
 	TransactionTypeTag = newTypeTagFromUpperMask(transactionTypeMask)
+	BytesTypeTag       = newTypeTagFromUpperMask(bytesTypeMask)
+	BlockHeaderTypeTag = newTypeTagFromUpperMask(blockHeaderTypeMask)
+
+	InclusiveRangeTypeTag = newTypeTagFromUpperMask(inclusiveRangeTypeMask)
 
 	// AnyStructTypeTag only includes the types that are pre-known
 	// to belong to AnyStruct type. This is more of an optimization.
@@ -353,7 +360,10 @@ var (
 				Or(BlockTypeTag).
 				Or(DeployedContractTypeTag).
 				Or(CapabilityTypeTag).
-				Or(FunctionTypeTag)
+				Or(FunctionTypeTag).
+				Or(BytesTypeTag).
+				Or(BlockHeaderTypeTag).
+				Or(InclusiveRangeTypeTag)
 
 	AnyResourceTypeTag = newTypeTagFromLowerMask(anyResourceTypeMask)
 
@@ -614,11 +624,16 @@ func findSuperTypeFromUpperMask(joinedTypeTag TypeTag, types []Type) Type {
 
 	case invalidTypeMask:
 		return InvalidType
+	case bytesTypeMask:
+		return BytesType
+	case blockHeaderTypeMask:
+		return BlockHeaderType
 
 	// All derived types goes here.
 	case capabilityTypeMask,
 		restrictedTypeMask,
-		transactionTypeMask:
+		transactionTypeMask,
+		inclusiveRangeTypeMask:
 		return getSuperTypeOfDerivedTypes(types)
 	default:
 		return nil
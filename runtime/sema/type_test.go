@@ -1677,3 +1677,138 @@ func TestTypeInclusions(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestInternedTypes(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("primitive", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Same(t, NewOptionalType(IntType), NewOptionalType(IntType))
+		assert.Same(t, NewVariableSizedType(StringType), NewVariableSizedType(StringType))
+		assert.Same(t, NewReferenceType(false, UFix64Type), NewReferenceType(false, UFix64Type))
+
+		assert.NotSame(t, NewReferenceType(false, IntType), NewReferenceType(true, IntType))
+		assert.True(t, NewReferenceType(true, IntType).Authorized)
+
+		assert.True(t, NewOptionalType(IntType).Equal(&OptionalType{Type: IntType}))
+	})
+
+	t.Run("non-primitive", func(t *testing.T) {
+
+		t.Parallel()
+
+		innerType := &VariableSizedType{Type: IntType}
+
+		assert.NotSame(t, NewOptionalType(innerType), NewOptionalType(innerType))
+		assert.NotSame(t, NewVariableSizedType(innerType), NewVariableSizedType(innerType))
+		assert.NotSame(t, NewReferenceType(false, innerType), NewReferenceType(false, innerType))
+
+		assert.True(t, NewOptionalType(innerType).Equal(&OptionalType{Type: innerType}))
+	})
+}
+
+func TestCachedMemberResolution(t *testing.T) {
+
+	t.Parallel()
+
+	resolve := func(ty Type, name string) *Member {
+		resolver, ok := ty.GetMembers()[name]
+		require.True(t, ok)
+		return resolver.Resolve(name, ast.Range{}, func(err error) {
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := &CompositeType{
+			Kind:       common.CompositeKindStructure,
+			Identifier: "S",
+			Location:   common.StringLocation("a"),
+			Fields:     []string{},
+			Members:    NewStringMemberOrderedMap(),
+		}
+
+		assert.Same(t, resolve(ty, GetTypeFunctionName), resolve(ty, GetTypeFunctionName))
+		assert.Same(t, resolve(ty, IsInstanceFunctionName), resolve(ty, IsInstanceFunctionName))
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := NewVariableSizedType(IntType)
+
+		assert.Same(t, resolve(ty, "length"), resolve(ty, "length"))
+		assert.Same(t, resolve(ty, "append"), resolve(ty, "append"))
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := &DictionaryType{
+			KeyType:   StringType,
+			ValueType: IntType,
+		}
+
+		assert.Same(t, resolve(ty, "length"), resolve(ty, "length"))
+		assert.Same(t, resolve(ty, "insert"), resolve(ty, "insert"))
+	})
+}
+
+func BenchmarkNewOptionalType(b *testing.B) {
+
+	b.Run("interned", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			NewOptionalType(IntType)
+		}
+	})
+
+	b.Run("not interned", func(b *testing.B) {
+		innerType := &VariableSizedType{Type: IntType}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			NewOptionalType(innerType)
+		}
+	})
+}
+
+func BenchmarkMemberResolution(b *testing.B) {
+
+	benchmark := func(b *testing.B, ty Type, name string) {
+		resolver := ty.GetMembers()[name]
+		report := func(error) {}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			resolver.Resolve(name, ast.Range{}, report)
+		}
+	}
+
+	b.Run("composite", func(b *testing.B) {
+		ty := &CompositeType{
+			Kind:       common.CompositeKindStructure,
+			Identifier: "S",
+			Location:   common.StringLocation("a"),
+			Fields:     []string{},
+			Members:    NewStringMemberOrderedMap(),
+		}
+
+		benchmark(b, ty, GetTypeFunctionName)
+	})
+
+	b.Run("array", func(b *testing.B) {
+		benchmark(b, NewVariableSizedType(IntType), "append")
+	})
+}
@@ -43,4 +43,6 @@ type Variable struct {
 	Pos *ast.Position
 	// DocString is the optional docstring
 	DocString string
+	// Deprecation is set if the variable is deprecated
+	Deprecation *Deprecation
 }
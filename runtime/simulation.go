@@ -0,0 +1,218 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// Simulation is hypothetical account state, which is layered over the state provided by the interface
+// for a single execution of a transaction, see Runtime.SimulateTransaction.
+// It allows determining how a transaction would behave in a different state,
+// e.g. if it would succeed if its authorizers were funded.
+//
+// All writes to storage are recorded in a ledger layered over the ledger of the interface,
+// which is discarded after the execution.
+// Other effects on accounts, i.e. creating accounts, changing contract code, and changing account keys,
+// are rejected with a SimulationUnsupportedOperationError.
+// Emitted events and log messages are still reported to the interface.
+//
+type Simulation struct {
+	// Signers are the signing accounts of the transaction.
+	// If nil, the signing accounts are requested from the interface
+	Signers []Address
+	// Balances are the balances of accounts, which override the balances provided by the interface
+	Balances map[Address]uint64
+	// AvailableBalances are the available balances of accounts,
+	// which override the available balances provided by the interface
+	AvailableBalances map[Address]uint64
+	// StoredValues are values in the storage domain of accounts, keyed by path identifier,
+	// which are written to storage before the transaction is executed,
+	// replacing the values stored in the ledger of the interface
+	StoredValues map[Address]map[string]cadence.Value
+}
+
+func (s *Simulation) balance(address Address) (uint64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	balance, ok := s.Balances[address]
+	return balance, ok
+}
+
+func (s *Simulation) availableBalance(address Address) (uint64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	balance, ok := s.AvailableBalances[address]
+	return balance, ok
+}
+
+// checkAccountChange panics with a SimulationUnsupportedOperationError
+// if the execution is a simulation, as the given operation would change an account outside of its storage
+//
+func (s *Simulation) checkAccountChange(operation string, getLocationRange func() interpreter.LocationRange) {
+	if s == nil {
+		return
+	}
+
+	panic(SimulationUnsupportedOperationError{
+		Operation:     operation,
+		LocationRange: getLocationRange(),
+	})
+}
+
+// storedValuesWritingFunction returns a function which writes the stored values of the simulation,
+// in a deterministic order, and then calls the given function
+//
+func (s *Simulation) storedValuesWritingFunction(f interpretFunc) interpretFunc {
+	return func(inter *interpreter.Interpreter) (value interpreter.Value, err error) {
+
+		err = s.writeStoredValues(inter)
+		if err != nil {
+			return nil, err
+		}
+
+		return f(inter)
+	}
+}
+
+func (s *Simulation) writeStoredValues(inter *interpreter.Interpreter) (err error) {
+
+	// Recover internal panics and return them as an error.
+	// For example, importing a value might attempt to
+	// load contract code for non-existing types
+
+	defer inter.RecoverErrors(func(internalErr error) {
+		err = internalErr
+	})
+
+	addresses := make([]Address, 0, len(s.StoredValues))
+	for address := range s.StoredValues {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	domain := common.PathDomainStorage.Identifier()
+
+	for _, address := range addresses {
+		values := s.StoredValues[address]
+
+		identifiers := make([]string, 0, len(values))
+		for identifier := range values {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+
+		storageMap := inter.Storage.GetStorageMap(address, domain)
+
+		for _, identifier := range identifiers {
+			value, err := importValue(inter, values[identifier], nil)
+			if err != nil {
+				return err
+			}
+
+			value = value.Transfer(
+				inter,
+				interpreter.ReturnEmptyLocationRange,
+				atree.Address(address),
+				true,
+				nil,
+			)
+
+			storageMap.WriteValue(inter, identifier, value)
+		}
+	}
+
+	return nil
+}
+
+// simulationLedger is a ledger which is layered over another ledger.
+// Reads are served from the written values, and fall back to the underlying ledger.
+// Writes are only recorded, and never written to the underlying ledger.
+//
+type simulationLedger struct {
+	ledger atree.Ledger
+	values map[simulationLedgerKey][]byte
+	// storageIndices are the last storage indices allocated for each account.
+	// Allocating a storage index from the underlying ledger would write to it,
+	// so storage indices are allocated from the end of the index space instead,
+	// where they do not collide with the indices allocated by the underlying ledger
+	storageIndices map[string]uint64
+}
+
+var _ atree.Ledger = &simulationLedger{}
+
+type simulationLedgerKey struct {
+	owner string
+	key   string
+}
+
+func newSimulationLedger(ledger atree.Ledger) *simulationLedger {
+	return &simulationLedger{
+		ledger:         ledger,
+		values:         map[simulationLedgerKey][]byte{},
+		storageIndices: map[string]uint64{},
+	}
+}
+
+func (l *simulationLedger) GetValue(owner, key []byte) (value []byte, err error) {
+	value, ok := l.values[simulationLedgerKey{string(owner), string(key)}]
+	if ok {
+		return value, nil
+	}
+	return l.ledger.GetValue(owner, key)
+}
+
+func (l *simulationLedger) SetValue(owner, key, value []byte) (err error) {
+	l.values[simulationLedgerKey{string(owner), string(key)}] = value
+	return nil
+}
+
+func (l *simulationLedger) ValueExists(owner, key []byte) (exists bool, err error) {
+	value, ok := l.values[simulationLedgerKey{string(owner), string(key)}]
+	if ok {
+		return len(value) > 0, nil
+	}
+	return l.ledger.ValueExists(owner, key)
+}
+
+func (l *simulationLedger) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	index, ok := l.storageIndices[string(owner)]
+	if !ok {
+		index = ^uint64(0)
+	} else {
+		index--
+	}
+	l.storageIndices[string(owner)] = index
+
+	var result atree.StorageIndex
+	binary.BigEndian.PutUint64(result[:], index)
+	return result, nil
+}
@@ -0,0 +1,294 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeSimulateTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	newRuntimeInterface := func(ledger testLedger, loggedMessages *[]string) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address1}, nil
+			},
+			getAccountBalance: func(_ Address) (uint64, error) {
+				return 0, nil
+			},
+			log: func(message string) {
+				*loggedMessages = append(*loggedMessages, message)
+			},
+		}
+	}
+
+	t.Run("balances", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		script := []byte(`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  if signer.balance < 10.0 {
+                      panic("insufficient balance")
+                  }
+                  log(signer.balance)
+              }
+          }
+        `)
+
+		var loggedMessages []string
+
+		runtimeInterface := newRuntimeInterface(newTestLedger(nil, nil), &loggedMessages)
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.ErrorContains(t, err, "insufficient balance")
+
+		err = runtime.SimulateTransaction(
+			Script{
+				Source: script,
+			},
+			&Simulation{
+				Balances: map[Address]uint64{
+					address1: 20_00000000,
+				},
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"20.00000000"}, loggedMessages)
+	})
+
+	t.Run("signers", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		script := []byte(`
+          transaction {
+              prepare(signer1: AuthAccount, signer2: AuthAccount) {
+                  log(signer1.address)
+                  log(signer2.address)
+              }
+          }
+        `)
+
+		var loggedMessages []string
+
+		runtimeInterface := newRuntimeInterface(newTestLedger(nil, nil), &loggedMessages)
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.SimulateTransaction(
+			Script{
+				Source: script,
+			},
+			&Simulation{
+				Signers: []Address{address1, address2},
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"0x0000000000000001", "0x0000000000000002"}, loggedMessages)
+	})
+
+	t.Run("storage", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		var loggedMessages []string
+
+		ledger := newTestLedger(nil, nil)
+
+		runtimeInterface := newRuntimeInterface(ledger, &loggedMessages)
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save(1, to: /storage/answer)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		storedValues := make(map[string][]byte, len(ledger.storedValues))
+		for key, value := range ledger.storedValues {
+			storedValues[key] = value
+		}
+
+		script := []byte(`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  log(signer.load<Int>(from: /storage/answer))
+                  signer.save("simulated", to: /storage/other)
+              }
+          }
+        `)
+
+		err = runtime.SimulateTransaction(
+			Script{
+				Source: script,
+			},
+			&Simulation{
+				StoredValues: map[Address]map[string]cadence.Value{
+					address1: {
+						"answer": cadence.NewInt(42),
+					},
+				},
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		// The ledger of the interface is unchanged
+
+		assert.Equal(t, storedValues, ledger.storedValues)
+
+		// The transaction sees the real state again
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"42", "1"}, loggedMessages)
+	})
+
+	t.Run("account changes", func(t *testing.T) {
+
+		t.Parallel()
+
+		test := func(operation string, prepare string) {
+
+			t.Run(operation, func(t *testing.T) {
+
+				t.Parallel()
+
+				runtime := newTestInterpreterRuntime()
+
+				var loggedMessages []string
+
+				runtimeInterface := newRuntimeInterface(newTestLedger(nil, nil), &loggedMessages)
+
+				nextTransactionLocation := newTransactionLocationGenerator()
+
+				err := runtime.SimulateTransaction(
+					Script{
+						Source: []byte(fmt.Sprintf(
+							`
+                              transaction {
+                                  prepare(signer: AuthAccount) {
+                                      %s
+                                  }
+                              }
+                            `,
+							prepare,
+						)),
+					},
+					&Simulation{},
+					Context{
+						Interface: runtimeInterface,
+						Location:  nextTransactionLocation(),
+					},
+				)
+				require.Error(t, err)
+
+				var unsupportedErr SimulationUnsupportedOperationError
+				require.ErrorAs(t, err, &unsupportedErr)
+				assert.Equal(t, operation, unsupportedErr.Operation)
+			})
+		}
+
+		test("create account", `AuthAccount(payer: signer)`)
+		test("deploy contract", `signer.contracts.add(name: "C", code: "pub contract C {}".utf8)`)
+		test("update contract", `signer.contracts.update__experimental(name: "C", code: "pub contract C {}".utf8)`)
+		test("remove contract", `signer.contracts.remove(name: "C")`)
+		test("add public key", `signer.addPublicKey([1, 2, 3])`)
+		test("remove public key", `signer.removePublicKey(0)`)
+		test(
+			"add account key",
+			`
+              signer.keys.add(
+                  publicKey: PublicKey(
+                      publicKey: "0102".decodeHex(),
+                      signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                  ),
+                  hashAlgorithm: HashAlgorithm.SHA3_256,
+                  weight: 100.0
+              )
+            `,
+		)
+		test("revoke account key", `signer.keys.revoke(keyIndex: 0)`)
+	})
+}
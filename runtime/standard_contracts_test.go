@@ -0,0 +1,435 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeStandardContracts(t *testing.T) {
+
+	t.Parallel()
+
+	contract := []byte(`
+      import FungibleToken
+
+      pub contract ExampleToken: FungibleToken {
+
+          pub var totalSupply: UFix64
+
+          pub event TokensInitialized(initialSupply: UFix64)
+          pub event TokensWithdrawn(amount: UFix64, from: Address?)
+          pub event TokensDeposited(amount: UFix64, to: Address?)
+
+          pub resource Vault: FungibleToken.Provider, FungibleToken.Receiver, FungibleToken.Balance {
+
+              pub var balance: UFix64
+
+              init(balance: UFix64) {
+                  self.balance = balance
+              }
+
+              pub fun withdraw(amount: UFix64): @FungibleToken.Vault {
+                  self.balance = self.balance - amount
+                  return <-create Vault(balance: amount)
+              }
+
+              pub fun deposit(from: @FungibleToken.Vault) {
+                  let vault <- from as! @ExampleToken.Vault
+                  self.balance = self.balance + vault.balance
+                  vault.balance = 0.0
+                  destroy vault
+              }
+          }
+
+          pub fun createEmptyVault(): @FungibleToken.Vault {
+              return <-create Vault(balance: 0.0)
+          }
+
+          init() {
+              self.totalSupply = 0.0
+          }
+      }
+    `)
+
+	script := []byte(`
+      import ExampleToken from 0x1
+      import MetadataViews
+
+      pub fun main(): [String] {
+          let vault <- ExampleToken.createEmptyVault()
+          let balance = vault.balance
+          destroy vault
+
+          let file = MetadataViews.IPFSFile(cid: "abc", path: "1.png")
+          return [balance.toString(), file.uri()]
+      }
+    `)
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		accountCodes := map[common.LocationID][]byte{}
+
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			resolveLocation: func(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+				if _, ok := location.(common.AddressLocation); ok {
+					return singleIdentifierLocationResolver(t)(identifiers, location)
+				}
+				return []ResolvedLocation{
+					{
+						Location:    location,
+						Identifiers: identifiers,
+					},
+				}, nil
+			},
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				return accountCodes[location.ID()], nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location.ID()] = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime(WithStandardContractsEnabled(true))
+
+		runtimeInterface := newRuntimeInterface()
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("ExampleToken", contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.String("0.00000000"),
+				cadence.String("ipfs://abc/1.png"),
+			}),
+			value,
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := newRuntimeInterface()
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("ExampleToken", contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot find type in this scope: `FungibleToken`")
+	})
+}
+
+func TestRuntimeViewResolver(t *testing.T) {
+
+	t.Parallel()
+
+	const resolvers = `
+      import ViewResolver
+      import MetadataViews
+
+      pub resource Item: ViewResolver.Resolver {
+
+          access(self) let views: ViewResolver.Views
+
+          init() {
+              self.views = ViewResolver.Views()
+              self.views.add(
+                  MetadataViews.Display(
+                      name: "Item",
+                      description: "An item",
+                      thumbnail: MetadataViews.HTTPFile(url: "https://example.com/item.png")
+                  )
+              )
+              self.views.add(MetadataViews.Serial(42))
+          }
+
+          pub fun getViews(): [Type] {
+              return self.views.getViews()
+          }
+
+          pub fun resolveView(_ view: Type): AnyStruct? {
+              return self.views.resolveView(view)
+          }
+      }
+
+      pub resource InvalidItem: ViewResolver.Resolver {
+
+          pub fun getViews(): [Type] {
+              return [Type<MetadataViews.Display>()]
+          }
+
+          pub fun resolveView(_ view: Type): AnyStruct? {
+              return "not a display"
+          }
+      }
+    `
+
+	runtime := newTestInterpreterRuntime(WithStandardContractsEnabled(true))
+
+	t.Run("resolve", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(resolvers + `
+          pub fun main(): [AnyStruct] {
+              let item <- create Item()
+              let resolver = &item as &AnyResource{ViewResolver.Resolver}
+
+              let display = MetadataViews.getDisplay(resolver)!
+              let serial = MetadataViews.getSerial(resolver)!
+              let editions = MetadataViews.getEditions(resolver)
+
+              destroy item
+
+              return [display.name, display.thumbnail.uri(), serial.number, editions == nil]
+          }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.String("Item"),
+				cadence.String("https://example.com/item.png"),
+				cadence.NewUInt64(42),
+				cadence.NewBool(true),
+			}),
+			value,
+		)
+	})
+
+	t.Run("resolve typed view", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(resolvers + `
+          pub fun main(): [AnyStruct] {
+              let item <- create Item()
+              let resolver = &item as &AnyResource{ViewResolver.Resolver}
+
+              let display: MetadataViews.Display = ViewResolver.resolve<MetadataViews.Display>(resolver)!
+              let royalties: MetadataViews.Royalties? = ViewResolver.resolve<MetadataViews.Royalties>(resolver)
+
+              destroy item
+
+              return [display.name, royalties == nil]
+          }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.String("Item"),
+				cadence.NewBool(true),
+			}),
+			value,
+		)
+	})
+
+	t.Run("resolve typed view, invalid result type", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(resolvers + `
+          pub fun main() {
+              let item <- create Item()
+              let resolver = &item as &AnyResource{ViewResolver.Resolver}
+              let serial: MetadataViews.Serial? = ViewResolver.resolve<MetadataViews.Display>(resolver)
+              destroy item
+          }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resolve typed view, resource type", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(resolvers + `
+          pub fun main() {
+              let item <- create Item()
+              let resolver = &item as &AnyResource{ViewResolver.Resolver}
+              let view <- ViewResolver.resolve<@Item>(resolver)
+              destroy view
+              destroy item
+          }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checker.ExpectCheckerErrors(t, checkerErr, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid view type", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(resolvers + `
+          pub fun main() {
+              let item <- create InvalidItem()
+              let resolver = &item as &AnyResource{ViewResolver.Resolver}
+              MetadataViews.getDisplay(resolver)
+              destroy item
+          }
+        `)
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "The resolved view must be of the requested type")
+	})
+}
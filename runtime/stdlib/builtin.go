@@ -121,6 +121,9 @@ var BuiltinFunctions = StandardLibraryFunctions{
 	CreatePublicKeyFunction,
 	AggregateBLSSignaturesFunction,
 	AggregateBLSPublicKeysFunction,
+	VerifyKeyListSignaturesFunction,
+	IsCustomSignatureSchemeSupportedFunction,
+	VerifyCustomSignatureFunction,
 }
 
 // LogFunction
@@ -362,6 +365,7 @@ func NewSignatureAlgorithmCase(inter *interpreter.Interpreter, rawValue uint8) *
 var hashAlgorithmFunctions = map[string]interpreter.FunctionValue{
 	sema.HashAlgorithmTypeHashFunctionName:        hashAlgorithmHashFunction,
 	sema.HashAlgorithmTypeHashWithTagFunctionName: hashAlgorithmHashWithTagFunction,
+	sema.HashAlgorithmTypeNewHasherFunctionName:   hashAlgorithmNewHasherFunction,
 }
 
 func NewHashAlgorithmCase(inter *interpreter.Interpreter, rawValue uint8) *interpreter.CompositeValue {
@@ -426,6 +430,31 @@ var hashAlgorithmHashWithTagFunction = interpreter.NewHostFunctionValue(
 	sema.HashAlgorithmTypeHashWithTagFunctionType,
 )
 
+var hashAlgorithmNewHasherFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		hashAlgoValue := invocation.Self
+
+		inter := invocation.Interpreter
+
+		getLocationRange := invocation.GetLocationRange
+
+		inter.ExpectType(
+			hashAlgoValue,
+			sema.HashAlgorithmType,
+			getLocationRange,
+		)
+
+		hasher := inter.HasherCreationHandler(
+			inter,
+			getLocationRange,
+			hashAlgoValue,
+		)
+
+		return interpreter.NewHasherValue(inter, hasher)
+	},
+	sema.HashAlgorithmTypeNewHasherFunctionType,
+)
+
 func cryptoAlgorithmEnumConstructorType(
 	enumType *sema.CompositeType,
 	enumCases []sema.CryptoAlgorithm,
@@ -457,6 +486,8 @@ func cryptoAlgorithmEnumConstructorType(
 		Members: sema.GetMembersAsMap(members),
 	}
 
+	sema.AddEnumConstructorMembers(constructorType, enumType)
+
 	return constructorType
 }
 
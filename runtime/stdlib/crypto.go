@@ -28,6 +28,10 @@ import (
 	"github.com/onflow/cadence/runtime/stdlib/contracts"
 )
 
+// CryptoContractLocation is the location of the built-in Crypto contract
+//
+var CryptoContractLocation = common.IdentifierLocation("Crypto")
+
 var CryptoChecker = func() *sema.Checker {
 
 	program, err := parser2.ParseProgram(contracts.Crypto)
@@ -35,12 +39,10 @@ var CryptoChecker = func() *sema.Checker {
 		panic(err)
 	}
 
-	location := common.IdentifierLocation("Crypto")
-
 	var checker *sema.Checker
 	checker, err = sema.NewChecker(
 		program,
-		location,
+		CryptoContractLocation,
 		sema.WithPredeclaredValues(BuiltinFunctions.ToSemaValueDeclarations()),
 		sema.WithPredeclaredTypes(BuiltinTypes.ToTypeDeclarations()),
 	)
@@ -95,3 +97,312 @@ func NewCryptoContract(
 
 	return compositeValue, nil
 }
+
+// VerifyKeyListSignaturesFunction is the native implementation of `Crypto.KeyList.verify`.
+//
+// It is only available in the Crypto contract.
+//
+// The signatures are valid if all signatures were produced by distinct, non-revoked keys,
+// and the total weight of the keys is at least 1.0.
+//
+var VerifyKeyListSignaturesFunction = func() StandardLibraryFunction {
+	function := NewStandardLibraryFunction(
+		"verifyKeyListSignatures",
+		&sema.FunctionType{
+			Parameters: []*sema.Parameter{
+				{
+					Label:      sema.ArgumentLabelNotRequired,
+					Identifier: "entries",
+					TypeAnnotation: sema.NewTypeAnnotation(
+						&sema.ReferenceType{
+							Type: &sema.VariableSizedType{
+								Type: sema.AnyStructType,
+							},
+						},
+					),
+				},
+				{
+					Identifier: "signatureSet",
+					TypeAnnotation: sema.NewTypeAnnotation(
+						&sema.VariableSizedType{
+							Type: sema.AnyStructType,
+						},
+					),
+				},
+				{
+					Identifier:     "signedData",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+				},
+				{
+					Identifier:     "domainSeparationTag",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+				},
+			},
+			ReturnTypeAnnotation: sema.NewTypeAnnotation(
+				sema.BoolType,
+			),
+		},
+		verifyKeyListSignaturesFunctionDocString,
+		verifyKeyListSignatures,
+	)
+
+	function.Available = func(location common.Location) bool {
+		return location == CryptoContractLocation
+	}
+
+	return function
+}()
+
+const verifyKeyListSignaturesFunctionDocString = `
+Returns true if the given signatures of the given key list entries are valid for the given signed data
+`
+
+const (
+	keyListEntryPublicKeyField     = "publicKey"
+	keyListEntryHashAlgorithmField = "hashAlgorithm"
+	keyListEntryWeightField        = "weight"
+	keyListEntryIsRevokedField     = "isRevoked"
+	keyListSignatureKeyIndexField  = "keyIndex"
+	keyListSignatureSignatureField = "signature"
+)
+
+var keyListWeightThreshold = interpreter.NewUFix64ValueWithInteger(1)
+
+func verifyKeyListSignatures(invocation interpreter.Invocation) interpreter.Value {
+	entriesReference, ok := invocation.Arguments[0].(*interpreter.EphemeralReferenceValue)
+	if !ok {
+		panic(errors2.NewUnreachableError())
+	}
+
+	entries, ok := entriesReference.Value.(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors2.NewUnreachableError())
+	}
+
+	signatureSet, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors2.NewUnreachableError())
+	}
+
+	signedData, ok := invocation.Arguments[2].(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors2.NewUnreachableError())
+	}
+
+	domainSeparationTag, ok := invocation.Arguments[3].(*interpreter.StringValue)
+	if !ok {
+		panic(errors2.NewUnreachableError())
+	}
+
+	inter := invocation.Interpreter
+	getLocationRange := invocation.GetLocationRange
+
+	entryCount := entries.Count()
+
+	seenKeyIndices := make(map[int]struct{}, signatureSet.Count())
+
+	var validWeights interpreter.NumberValue = interpreter.UFix64Value(0)
+
+	valid := true
+
+	signatureSet.Iterate(func(element interpreter.Value) (resume bool) {
+		signature := element.(*interpreter.CompositeValue)
+
+		// Ensure the key index is valid
+
+		keyIndexValue := signature.GetField(keyListSignatureKeyIndexField).(interpreter.IntValue)
+		if !keyIndexValue.BigInt.IsInt64() {
+			valid = false
+			return false
+		}
+
+		keyIndex := int(keyIndexValue.BigInt.Int64())
+		if keyIndex < 0 || keyIndex >= entryCount {
+			valid = false
+			return false
+		}
+
+		// Ensure this key index has not already been seen,
+		// i.e. there are no duplicate signatures for the same key
+
+		if _, ok := seenKeyIndices[keyIndex]; ok {
+			valid = false
+			return false
+		}
+
+		seenKeyIndices[keyIndex] = struct{}{}
+
+		// Ensure the key is not revoked
+
+		entry := entries.Get(inter, getLocationRange, keyIndex).(*interpreter.CompositeValue)
+
+		if entry.GetField(keyListEntryIsRevokedField).(interpreter.BoolValue) {
+			valid = false
+			return false
+		}
+
+		// Ensure the signature is valid
+
+		publicKey := entry.GetField(keyListEntryPublicKeyField).(*interpreter.CompositeValue)
+		hashAlgorithm := entry.GetField(keyListEntryHashAlgorithmField).(*interpreter.CompositeValue)
+		signatureValue := signature.GetField(keyListSignatureSignatureField).(*interpreter.ArrayValue)
+
+		signatureValid := inter.SignatureVerificationHandler(
+			inter,
+			getLocationRange,
+			signatureValue,
+			signedData,
+			domainSeparationTag,
+			hashAlgorithm,
+			publicKey,
+		)
+		if !signatureValid {
+			valid = false
+			return false
+		}
+
+		weight := entry.GetField(keyListEntryWeightField).(interpreter.UFix64Value)
+		validWeights = validWeights.Plus(weight)
+
+		return true
+	})
+
+	if !valid {
+		return interpreter.BoolValue(false)
+	}
+
+	return validWeights.GreaterEqual(keyListWeightThreshold)
+}
+
+// IsCustomSignatureSchemeSupportedFunction is the native implementation of
+// `Crypto.isSignatureSchemeSupported`.
+//
+// It is only available in the Crypto contract.
+//
+var IsCustomSignatureSchemeSupportedFunction = func() StandardLibraryFunction {
+	function := NewStandardLibraryFunction(
+		"isCustomSignatureSchemeSupported",
+		&sema.FunctionType{
+			Parameters: []*sema.Parameter{
+				{
+					Label:          sema.ArgumentLabelNotRequired,
+					Identifier:     "scheme",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+				},
+			},
+			ReturnTypeAnnotation: sema.NewTypeAnnotation(
+				sema.BoolType,
+			),
+		},
+		isCustomSignatureSchemeSupportedFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			scheme, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors2.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			handler := inter.SignatureSchemeSupportHandler
+			if handler == nil {
+				return interpreter.BoolValue(false)
+			}
+
+			return handler(inter, invocation.GetLocationRange, scheme)
+		},
+	)
+
+	function.Available = func(location common.Location) bool {
+		return location == CryptoContractLocation
+	}
+
+	return function
+}()
+
+const isCustomSignatureSchemeSupportedFunctionDocString = `
+Returns true if the signature scheme with the given name is supported
+`
+
+// VerifyCustomSignatureFunction is the native implementation of
+// `Crypto.verifySignatureWithScheme`.
+//
+// It is only available in the Crypto contract.
+//
+var VerifyCustomSignatureFunction = func() StandardLibraryFunction {
+	function := NewStandardLibraryFunction(
+		"verifyCustomSignature",
+		&sema.FunctionType{
+			Parameters: []*sema.Parameter{
+				{
+					Label:          sema.ArgumentLabelNotRequired,
+					Identifier:     "scheme",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+				},
+				{
+					Identifier:     "publicKey",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+				},
+				{
+					Identifier:     "signature",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+				},
+				{
+					Identifier:     "signedData",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+				},
+				{
+					Identifier:     "domainSeparationTag",
+					TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+				},
+			},
+			ReturnTypeAnnotation: sema.NewTypeAnnotation(
+				sema.BoolType,
+			),
+		},
+		verifyCustomSignatureFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			scheme, schemeOk := invocation.Arguments[0].(*interpreter.StringValue)
+			publicKey, publicKeyOk := invocation.Arguments[1].(*interpreter.ArrayValue)
+			signature, signatureOk := invocation.Arguments[2].(*interpreter.ArrayValue)
+			signedData, signedDataOk := invocation.Arguments[3].(*interpreter.ArrayValue)
+			domainSeparationTag, tagOk := invocation.Arguments[4].(*interpreter.StringValue)
+
+			if !schemeOk || !publicKeyOk || !signatureOk || !signedDataOk || !tagOk {
+				panic(errors2.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			getLocationRange := invocation.GetLocationRange
+
+			handler := inter.SignatureSchemeVerifier
+			if handler == nil {
+				panic(interpreter.UnsupportedSignatureSchemeError{
+					Scheme:        scheme.Str,
+					LocationRange: getLocationRange(),
+				})
+			}
+
+			return handler(
+				inter,
+				getLocationRange,
+				scheme,
+				signature,
+				signedData,
+				domainSeparationTag,
+				publicKey,
+			)
+		},
+	)
+
+	function.Available = func(location common.Location) bool {
+		return location == CryptoContractLocation
+	}
+
+	return function
+}()
+
+const verifyCustomSignatureFunctionDocString = `
+Returns true if the given signature was produced by signing the given tag and data
+using the given public key and the signature scheme with the given name
+`
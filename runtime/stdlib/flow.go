@@ -114,15 +114,154 @@ var getBlockFunctionType = &sema.FunctionType{
 	),
 }
 
-const unsafeRandomFunctionDocString = `
+const getBlockHeaderFunctionDocString = `
+Returns the header of the block at the given height. If the given block does not exist the function returns nil
+`
+
+var getBlockHeaderFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      "at",
+			Identifier: "height",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.UInt64Type,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.OptionalType{
+			Type: sema.BlockHeaderType,
+		},
+	),
+}
+
+// MaxBlockIDRangeLength is the maximum number of block IDs
+// which can be requested at once using `getBlockIDs`
+//
+const MaxBlockIDRangeLength = 256
+
+const getBlockIDsFunctionDocString = `
+Returns the IDs of the blocks from the given start height to the given end height (inclusive), ordered by height.
+
+The end height must not be after the current block height, and at most 256 block IDs can be requested at once
+`
+
+var getBlockIDsFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:      "from",
+			Identifier: "startHeight",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.UInt64Type,
+			),
+		},
+		{
+			Label:      "to",
+			Identifier: "endHeight",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.UInt64Type,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.VariableSizedType{
+			Type: &sema.ConstantSizedType{
+				Type: sema.UInt8Type,
+				Size: sema.BlockIDSize,
+			},
+		},
+	),
+}
+
+const revertibleRandomFunctionDocString = `
 Returns a pseudo-random number.
 
 NOTE: The use of this function is unsafe if not used correctly.
+The transaction which calls this function may be reverted,
+for example if the result is unfavourable.
 
 Follow best practices to prevent security issues when using this function
 `
 
-var unsafeRandomFunctionType = &sema.FunctionType{
+var revertibleRandomFunctionType = &sema.FunctionType{
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.UInt64Type,
+	),
+}
+
+const verifyAccountProofFunctionDocString = `
+Returns true if the given message was signed by the account with the given address.
+
+Each signature must have been produced by signing the given message with the account-proof domain separation tag,
+using the account key with the key index at the same position in the given list of key indices.
+
+The signatures are valid if all keys exist, are not revoked, are distinct,
+and their total weight is at least 1000, i.e. the weight required to sign a transaction
+`
+
+var verifyAccountProofFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Identifier: "address",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.AddressType{},
+			),
+		},
+		{
+			Identifier:     "message",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.ByteArrayType),
+		},
+		{
+			Identifier: "keyIndices",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.VariableSizedType{
+					Type: sema.IntType,
+				},
+			),
+		},
+		{
+			Identifier: "signatures",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.VariableSizedType{
+					Type: sema.ByteArrayType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.BoolType,
+	),
+}
+
+const scheduleCallbackFunctionDocString = `
+Schedules a callback to be invoked at or after the given block height, and returns the ID of the callback.
+
+The callback is invoked by calling the public function with the given name
+on the value the given capability refers to, with the ID of the callback as the only argument,
+i.e. the function must have the type ` + "`((UInt64): Void)`" + `.
+
+The given fee is paid for the execution of the callback
+`
+
+var scheduleCallbackFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Identifier:     "handler",
+			TypeAnnotation: sema.NewTypeAnnotation(&sema.CapabilityType{}),
+		},
+		{
+			Identifier:     "functionName",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+		{
+			Identifier:     "blockHeight",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.UInt64Type),
+		},
+		{
+			Identifier:     "fee",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.UFix64Type),
+		},
+	},
 	ReturnTypeAnnotation: sema.NewTypeAnnotation(
 		sema.UInt64Type,
 	),
@@ -131,17 +270,48 @@ var unsafeRandomFunctionType = &sema.FunctionType{
 // FlowBuiltinImpls defines the set of functions needed to implement the Flow
 // built-in functions.
 type FlowBuiltinImpls struct {
-	CreateAccount   interpreter.HostFunction
-	GetAccount      interpreter.HostFunction
-	Log             interpreter.HostFunction
-	GetCurrentBlock interpreter.HostFunction
-	GetBlock        interpreter.HostFunction
-	UnsafeRandom    interpreter.HostFunction
+	CreateAccount      interpreter.HostFunction
+	GetAccount         interpreter.HostFunction
+	Log                interpreter.HostFunction
+	GetCurrentBlock    interpreter.HostFunction
+	GetBlock           interpreter.HostFunction
+	GetBlockHeader     interpreter.HostFunction
+	GetBlockIDs        interpreter.HostFunction
+	UnsafeRandom       interpreter.HostFunction
+	VerifyAccountProof interpreter.HostFunction
+	ScheduleCallback   interpreter.HostFunction
+}
+
+// DefaultFlowBuiltinAliases are the aliases for the Flow built-in functions
+// which are declared by FlowBuiltInFunctions.
+//
+// Renamed functions are kept available under their old name as deprecated aliases,
+// so existing programs keep working during the deprecation period.
+//
+var DefaultFlowBuiltinAliases = []StandardLibraryFunctionAlias{
+	{
+		Name:       "unsafeRandom",
+		Target:     "revertibleRandom",
+		Deprecated: true,
+	},
 }
 
 // FlowBuiltInFunctions returns a list of standard library functions, bound to
-// the provided implementation.
+// the provided implementation, including the default aliases.
 func FlowBuiltInFunctions(impls FlowBuiltinImpls) StandardLibraryFunctions {
+	return FlowBuiltInFunctionsWithAliases(impls, DefaultFlowBuiltinAliases...)
+}
+
+// FlowBuiltInFunctionsWithAliases returns a list of standard library functions, bound to
+// the provided implementation, including the given aliases.
+func FlowBuiltInFunctionsWithAliases(
+	impls FlowBuiltinImpls,
+	aliases ...StandardLibraryFunctionAlias,
+) StandardLibraryFunctions {
+	return flowBuiltInFunctions(impls).WithAliases(aliases...)
+}
+
+func flowBuiltInFunctions(impls FlowBuiltinImpls) StandardLibraryFunctions {
 	return StandardLibraryFunctions{
 		NewStandardLibraryFunction(
 			"AuthAccount",
@@ -174,11 +344,35 @@ func FlowBuiltInFunctions(impls FlowBuiltinImpls) StandardLibraryFunctions {
 			impls.GetBlock,
 		),
 		NewStandardLibraryFunction(
-			"unsafeRandom",
-			unsafeRandomFunctionType,
-			unsafeRandomFunctionDocString,
+			"getBlockHeader",
+			getBlockHeaderFunctionType,
+			getBlockHeaderFunctionDocString,
+			impls.GetBlockHeader,
+		),
+		NewStandardLibraryFunction(
+			"getBlockIDs",
+			getBlockIDsFunctionType,
+			getBlockIDsFunctionDocString,
+			impls.GetBlockIDs,
+		),
+		NewStandardLibraryFunction(
+			"revertibleRandom",
+			revertibleRandomFunctionType,
+			revertibleRandomFunctionDocString,
 			impls.UnsafeRandom,
 		),
+		NewStandardLibraryFunction(
+			"verifyAccountProof",
+			verifyAccountProofFunctionType,
+			verifyAccountProofFunctionDocString,
+			impls.VerifyAccountProof,
+		),
+		NewStandardLibraryFunction(
+			"scheduleCallback",
+			scheduleCallbackFunctionType,
+			scheduleCallbackFunctionDocString,
+			impls.ScheduleCallback,
+		),
 	}
 }
 
@@ -197,9 +391,21 @@ func DefaultFlowBuiltinImpls() FlowBuiltinImpls {
 		GetBlock: func(invocation interpreter.Invocation) interpreter.Value {
 			panic(fmt.Errorf("cannot get blocks"))
 		},
+		GetBlockHeader: func(invocation interpreter.Invocation) interpreter.Value {
+			panic(fmt.Errorf("cannot get blocks"))
+		},
+		GetBlockIDs: func(invocation interpreter.Invocation) interpreter.Value {
+			panic(fmt.Errorf("cannot get blocks"))
+		},
 		UnsafeRandom: func(invocation interpreter.Invocation) interpreter.Value {
 			return interpreter.UInt64Value(rand.Uint64())
 		},
+		VerifyAccountProof: func(invocation interpreter.Invocation) interpreter.Value {
+			panic(fmt.Errorf("cannot verify account proofs"))
+		},
+		ScheduleCallback: func(invocation interpreter.Invocation) interpreter.Value {
+			panic(fmt.Errorf("cannot schedule callbacks"))
+		},
 	}
 }
 
@@ -288,6 +494,32 @@ func decodeFlowLocationTypeID(typeID string) (FlowLocation, string, error) {
 
 // built-in event types
 
+// Identifiers of the built-in events
+const (
+	AccountCreatedEventIdentifier                     = "AccountCreated"
+	AccountKeyAddedEventIdentifier                    = "AccountKeyAdded"
+	AccountKeyRemovedEventIdentifier                  = "AccountKeyRemoved"
+	AccountContractAddedEventIdentifier               = "AccountContractAdded"
+	AccountContractUpdatedEventIdentifier             = "AccountContractUpdated"
+	AccountContractRemovedEventIdentifier             = "AccountContractRemoved"
+	AccountLinkedEventIdentifier                      = "AccountLinked"
+	StorageCapabilityControllerIssuedEventIdentifier  = "StorageCapabilityControllerIssued"
+	StorageCapabilityControllerRevokedEventIdentifier = "StorageCapabilityControllerRevoked"
+)
+
+// Type IDs of the built-in events, e.g. `flow.AccountCreated`
+const (
+	AccountCreatedEventTypeID                     common.TypeID = FlowLocationPrefix + "." + AccountCreatedEventIdentifier
+	AccountKeyAddedEventTypeID                    common.TypeID = FlowLocationPrefix + "." + AccountKeyAddedEventIdentifier
+	AccountKeyRemovedEventTypeID                  common.TypeID = FlowLocationPrefix + "." + AccountKeyRemovedEventIdentifier
+	AccountContractAddedEventTypeID               common.TypeID = FlowLocationPrefix + "." + AccountContractAddedEventIdentifier
+	AccountContractUpdatedEventTypeID             common.TypeID = FlowLocationPrefix + "." + AccountContractUpdatedEventIdentifier
+	AccountContractRemovedEventTypeID             common.TypeID = FlowLocationPrefix + "." + AccountContractRemovedEventIdentifier
+	AccountLinkedEventTypeID                      common.TypeID = FlowLocationPrefix + "." + AccountLinkedEventIdentifier
+	StorageCapabilityControllerIssuedEventTypeID  common.TypeID = FlowLocationPrefix + "." + StorageCapabilityControllerIssuedEventIdentifier
+	StorageCapabilityControllerRevokedEventTypeID common.TypeID = FlowLocationPrefix + "." + StorageCapabilityControllerRevokedEventIdentifier
+)
+
 func newFlowEventType(identifier string, parameters ...*sema.Parameter) *sema.CompositeType {
 
 	eventType := &sema.CompositeType{
@@ -362,41 +594,81 @@ var AccountEventContractParameter = &sema.Parameter{
 }
 
 var AccountCreatedEventType = newFlowEventType(
-	"AccountCreated",
+	AccountCreatedEventIdentifier,
 	AccountEventAddressParameter,
 )
 
 var AccountKeyAddedEventType = newFlowEventType(
-	"AccountKeyAdded",
+	AccountKeyAddedEventIdentifier,
 	AccountEventAddressParameter,
 	AccountEventPublicKeyParameter,
 )
 
 var AccountKeyRemovedEventType = newFlowEventType(
-	"AccountKeyRemoved",
+	AccountKeyRemovedEventIdentifier,
 	AccountEventAddressParameter,
 	AccountEventPublicKeyParameter,
 )
 
 var AccountContractAddedEventType = newFlowEventType(
-	"AccountContractAdded",
+	AccountContractAddedEventIdentifier,
 	AccountEventAddressParameter,
 	AccountEventCodeHashParameter,
 	AccountEventContractParameter,
 )
 
 var AccountContractUpdatedEventType = newFlowEventType(
-	"AccountContractUpdated",
+	AccountContractUpdatedEventIdentifier,
 	AccountEventAddressParameter,
 	AccountEventCodeHashParameter,
 	AccountEventContractParameter,
 )
 
 var AccountContractRemovedEventType = newFlowEventType(
-	"AccountContractRemoved",
+	AccountContractRemovedEventIdentifier,
 	AccountEventAddressParameter,
 	AccountEventCodeHashParameter,
 	AccountEventContractParameter,
 )
 
+var AccountEventPathParameter = &sema.Parameter{
+	Identifier:     "path",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.PrivatePathType),
+}
+
+var AccountLinkedEventType = newFlowEventType(
+	AccountLinkedEventIdentifier,
+	AccountEventAddressParameter,
+	AccountEventPathParameter,
+)
+
+var AccountEventCapabilityIDParameter = &sema.Parameter{
+	Identifier:     "id",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.UInt64Type),
+}
+
+var AccountEventBorrowTypeParameter = &sema.Parameter{
+	Identifier:     "type",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.MetaType),
+}
+
+var AccountEventStoragePathParameter = &sema.Parameter{
+	Identifier:     "path",
+	TypeAnnotation: sema.NewTypeAnnotation(sema.StoragePathType),
+}
+
+var StorageCapabilityControllerIssuedEventType = newFlowEventType(
+	StorageCapabilityControllerIssuedEventIdentifier,
+	AccountEventCapabilityIDParameter,
+	AccountEventAddressParameter,
+	AccountEventBorrowTypeParameter,
+	AccountEventStoragePathParameter,
+)
+
+var StorageCapabilityControllerRevokedEventType = newFlowEventType(
+	StorageCapabilityControllerRevokedEventIdentifier,
+	AccountEventCapabilityIDParameter,
+	AccountEventAddressParameter,
+)
+
 var FlowBuiltInTypes StandardLibraryTypes
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -37,11 +38,93 @@ func TestFlowEventTypeIDs(t *testing.T) {
 		AccountContractAddedEventType,
 		AccountContractUpdatedEventType,
 		AccountContractRemovedEventType,
+		AccountLinkedEventType,
+		StorageCapabilityControllerIssuedEventType,
+		StorageCapabilityControllerRevokedEventType,
 	} {
 		assert.True(t, strings.HasPrefix(string(ty.ID()), "flow"))
 	}
 }
 
+func TestFlowEventTypeIDConstants(t *testing.T) {
+
+	t.Parallel()
+
+	for expectedTypeID, ty := range map[common.TypeID]*sema.CompositeType{
+		AccountCreatedEventTypeID:                     AccountCreatedEventType,
+		AccountKeyAddedEventTypeID:                    AccountKeyAddedEventType,
+		AccountKeyRemovedEventTypeID:                  AccountKeyRemovedEventType,
+		AccountContractAddedEventTypeID:               AccountContractAddedEventType,
+		AccountContractUpdatedEventTypeID:             AccountContractUpdatedEventType,
+		AccountContractRemovedEventTypeID:             AccountContractRemovedEventType,
+		AccountLinkedEventTypeID:                      AccountLinkedEventType,
+		StorageCapabilityControllerIssuedEventTypeID:  StorageCapabilityControllerIssuedEventType,
+		StorageCapabilityControllerRevokedEventTypeID: StorageCapabilityControllerRevokedEventType,
+	} {
+		assert.Equal(t, expectedTypeID, ty.ID())
+		assert.Equal(t, expectedTypeID, common.NewEventTypeID(FlowLocation{}, "", ty.Identifier))
+
+		location, contractName, eventName, err := common.DecodeEventTypeID(string(expectedTypeID))
+		require.NoError(t, err)
+
+		assert.Equal(t, FlowLocation{}, location)
+		assert.Equal(t, "", contractName)
+		assert.Equal(t, ty.Identifier, eventName)
+	}
+}
+
+func TestFlowBuiltInFunctionAliases(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+
+		t.Parallel()
+
+		functions := FlowBuiltInFunctions(DefaultFlowBuiltinImpls())
+
+		revertibleRandom, ok := functions.find("revertibleRandom")
+		require.True(t, ok)
+		assert.Nil(t, revertibleRandom.Deprecation)
+
+		unsafeRandom, ok := functions.find("unsafeRandom")
+		require.True(t, ok)
+		assert.Equal(t,
+			&sema.Deprecation{
+				Replacement: "revertibleRandom",
+			},
+			unsafeRandom.Deprecation,
+		)
+		assert.Same(t, revertibleRandom.Function, unsafeRandom.Function)
+		assert.Same(t, revertibleRandom.Type, unsafeRandom.Type)
+	})
+
+	t.Run("without aliases", func(t *testing.T) {
+
+		t.Parallel()
+
+		functions := FlowBuiltInFunctionsWithAliases(DefaultFlowBuiltinImpls())
+
+		_, ok := functions.find("unsafeRandom")
+		assert.False(t, ok)
+	})
+
+	t.Run("undeclared target", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			FlowBuiltInFunctionsWithAliases(
+				DefaultFlowBuiltinImpls(),
+				StandardLibraryFunctionAlias{
+					Name:   "foo",
+					Target: "bar",
+				},
+			)
+		})
+	})
+}
+
 func TestFlowLocation_MarshalJSON(t *testing.T) {
 
 	t.Parallel()
@@ -36,6 +36,8 @@ type StandardLibraryFunction struct {
 	Function       *interpreter.HostFunctionValue
 	ArgumentLabels []string
 	Available      func(common.Location) bool
+	// Deprecation is set if the function is deprecated
+	Deprecation *sema.Deprecation
 }
 
 func (f StandardLibraryFunction) ValueDeclarationName() string {
@@ -77,6 +79,10 @@ func (f StandardLibraryFunction) ValueDeclarationArgumentLabels() []string {
 	return f.ArgumentLabels
 }
 
+func (f StandardLibraryFunction) ValueDeclarationDeprecation() *sema.Deprecation {
+	return f.Deprecation
+}
+
 func NewStandardLibraryFunction(
 	name string,
 	functionType *sema.FunctionType,
@@ -107,6 +113,72 @@ func NewStandardLibraryFunction(
 
 type StandardLibraryFunctions []StandardLibraryFunction
 
+// StandardLibraryFunctionAlias declares an alternative name for a standard library function.
+//
+// Aliases allow built-in functions to be renamed without breaking existing programs:
+// the function is declared under its new name, and the old name is kept as a deprecated alias.
+// Uses of a deprecated alias are still valid, but are reported as hints by the checker.
+//
+type StandardLibraryFunctionAlias struct {
+	// Name is the alternative name
+	Name string
+	// Target is the name of the aliased function
+	Target string
+	// Deprecated indicates if uses of the alias should be reported
+	Deprecated bool
+	// Message is an optional explanation of the deprecation
+	Message string
+}
+
+// WithAliases returns the functions, followed by a function for each of the given aliases.
+// An alias shares the type and the implementation of the aliased function.
+//
+// It panics if an alias refers to a function which is not declared.
+//
+func (functions StandardLibraryFunctions) WithAliases(aliases ...StandardLibraryFunctionAlias) StandardLibraryFunctions {
+	if len(aliases) == 0 {
+		return functions
+	}
+
+	result := make(StandardLibraryFunctions, 0, len(functions)+len(aliases))
+	result = append(result, functions...)
+
+	for _, alias := range aliases {
+		target, ok := functions.find(alias.Target)
+		if !ok {
+			panic(fmt.Errorf(
+				"cannot declare alias %s for undeclared standard library function: %s",
+				alias.Name,
+				alias.Target,
+			))
+		}
+
+		aliasFunction := target
+		aliasFunction.Name = alias.Name
+		aliasFunction.Deprecation = nil
+
+		if alias.Deprecated {
+			aliasFunction.Deprecation = &sema.Deprecation{
+				Replacement: alias.Target,
+				Message:     alias.Message,
+			}
+		}
+
+		result = append(result, aliasFunction)
+	}
+
+	return result
+}
+
+func (functions StandardLibraryFunctions) find(name string) (StandardLibraryFunction, bool) {
+	for _, function := range functions {
+		if function.Name == name {
+			return function, true
+		}
+	}
+	return StandardLibraryFunction{}, false
+}
+
 func (functions StandardLibraryFunctions) ToSemaValueDeclarations() []sema.ValueDeclaration {
 	valueDeclarations := make([]sema.ValueDeclaration, len(functions))
 	for i, function := range functions {
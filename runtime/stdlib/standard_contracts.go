@@ -0,0 +1,175 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib/contracts"
+)
+
+// The locations of the standard contracts.
+//
+// The standard contracts are the canonical definitions of the token standards,
+// which can be imported by identifier, e.g. `import FungibleToken`,
+// if the embedder enabled them.
+//
+var (
+	FungibleTokenLocation    = common.IdentifierLocation("FungibleToken")
+	NonFungibleTokenLocation = common.IdentifierLocation("NonFungibleToken")
+	ViewResolverLocation     = common.IdentifierLocation("ViewResolver")
+	MetadataViewsLocation    = common.IdentifierLocation("MetadataViews")
+)
+
+var standardContractCodes = map[common.IdentifierLocation]string{
+	FungibleTokenLocation:    contracts.FungibleToken,
+	NonFungibleTokenLocation: contracts.NonFungibleToken,
+	ViewResolverLocation:     contracts.ViewResolver,
+	MetadataViewsLocation:    contracts.MetadataViews,
+}
+
+// standardContractLocations are the locations of the standard contracts,
+// in an order in which each contract only imports contracts before it
+//
+var standardContractLocations = []common.IdentifierLocation{
+	FungibleTokenLocation,
+	NonFungibleTokenLocation,
+	ViewResolverLocation,
+	MetadataViewsLocation,
+}
+
+var standardContractCheckers map[common.IdentifierLocation]*sema.Checker
+var standardContractCheckersOnce sync.Once
+
+func checkStandardContracts() {
+	checkers := make(map[common.IdentifierLocation]*sema.Checker, len(standardContractLocations))
+
+	for _, location := range standardContractLocations {
+
+		program, err := parser2.ParseProgram(standardContractCodes[location])
+		if err != nil {
+			panic(err)
+		}
+
+		var checker *sema.Checker
+		checker, err = sema.NewChecker(
+			program,
+			location,
+			sema.WithPredeclaredValues(BuiltinFunctions.ToSemaValueDeclarations()),
+			sema.WithPredeclaredTypes(BuiltinTypes.ToTypeDeclarations()),
+			sema.WithCompositeMembersHandler(viewResolverMembers),
+			sema.WithImportHandler(
+				func(_ *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+					identifierLocation, ok := importedLocation.(common.IdentifierLocation)
+					if !ok {
+						return nil, fmt.Errorf("cannot import %s into standard contract", importedLocation)
+					}
+					importedChecker, ok := checkers[identifierLocation]
+					if !ok {
+						return nil, fmt.Errorf("cannot import %s into standard contract", importedLocation)
+					}
+					return sema.ElaborationImport{
+						Elaboration: importedChecker.Elaboration,
+					}, nil
+				},
+			),
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		err = checker.Check()
+		if err != nil {
+			panic(err)
+		}
+
+		checkers[location] = checker
+	}
+
+	standardContractCheckers = checkers
+}
+
+// StandardContractChecker returns the checker of the standard contract at the given location,
+// or nil if the location is not the location of a standard contract.
+//
+// The standard contracts are parsed and checked when a checker is requested for the first time.
+//
+func StandardContractChecker(location common.Location) *sema.Checker {
+	identifierLocation, ok := location.(common.IdentifierLocation)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := standardContractCodes[identifierLocation]; !ok {
+		return nil
+	}
+
+	standardContractCheckersOnce.Do(checkStandardContracts)
+
+	return standardContractCheckers[identifierLocation]
+}
+
+// IsStandardContractLocation returns true if the given location is the location of a standard contract
+//
+func IsStandardContractLocation(location common.Location) bool {
+	identifierLocation, ok := location.(common.IdentifierLocation)
+	if !ok {
+		return false
+	}
+	_, ok = standardContractCodes[identifierLocation]
+	return ok
+}
+
+// NewStandardContract creates the value of a standard contract of the given type using the given constructor.
+// The standard contracts have no initializer parameters.
+//
+func NewStandardContract(
+	inter *interpreter.Interpreter,
+	contractType *sema.CompositeType,
+	constructor interpreter.FunctionValue,
+	invocationRange ast.Range,
+) (
+	*interpreter.CompositeValue,
+	error,
+) {
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		nil,
+		nil,
+		nil,
+		invocationRange,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := value.(*interpreter.CompositeValue)
+
+	if contract.Location == ViewResolverLocation {
+		injectViewResolverFunctions(inter, contract, contractType)
+	}
+
+	return contract, nil
+}
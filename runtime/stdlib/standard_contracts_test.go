@@ -0,0 +1,43 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestStandardContracts(t *testing.T) {
+
+	t.Parallel()
+
+	for _, location := range standardContractLocations {
+		checker := StandardContractChecker(location)
+		require.IsType(t, &sema.Checker{}, checker)
+		assert.True(t, IsStandardContractLocation(location))
+	}
+
+	assert.Nil(t, StandardContractChecker(common.IdentifierLocation("Unknown")))
+	assert.False(t, IsStandardContractLocation(CryptoChecker.Location))
+}
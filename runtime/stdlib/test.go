@@ -0,0 +1,420 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib/contracts"
+)
+
+// TestContractLocation is the location of the built-in Test contract,
+// which provides assertions and a simulated blockchain for tests written in Cadence.
+//
+// The contract is only available when running tests with a test runner
+//
+var TestContractLocation = common.IdentifierLocation("Test")
+
+// TestFramework is the simulated blockchain used by the Test contract.
+//
+// Failures of deployments, transactions, and scripts are returned as errors,
+// and are reported to the test as error messages.
+// Failed assertions are reported as AssertionError.
+//
+type TestFramework interface {
+	CreateAccount() (common.Address, error)
+
+	DeployContract(
+		inter *interpreter.Interpreter,
+		name string,
+		code string,
+		account common.Address,
+		arguments []interpreter.Value,
+	) error
+
+	ExecuteTransaction(
+		inter *interpreter.Interpreter,
+		code string,
+		signers []common.Address,
+		arguments []interpreter.Value,
+	) error
+
+	ExecuteScript(
+		inter *interpreter.Interpreter,
+		code string,
+		arguments []interpreter.Value,
+	) (interpreter.Value, error)
+}
+
+func isTestContractLocation(location common.Location) bool {
+	return location == TestContractLocation
+}
+
+var testArgumentsType = &sema.VariableSizedType{
+	Type: sema.AnyStructType,
+}
+
+var testErrorMessageType = &sema.OptionalType{
+	Type: sema.StringType,
+}
+
+// NewTestContractFunctions returns the native functions of the Test contract,
+// which use the given framework.
+//
+// The functions are only available in the Test contract.
+//
+func NewTestContractFunctions(framework TestFramework) StandardLibraryFunctions {
+	functions := StandardLibraryFunctions{
+		NewStandardLibraryFunction(
+			"testAssert",
+			&sema.FunctionType{
+				Parameters: []*sema.Parameter{
+					{
+						Label:          sema.ArgumentLabelNotRequired,
+						Identifier:     "condition",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.BoolType),
+					},
+					{
+						Label:          sema.ArgumentLabelNotRequired,
+						Identifier:     "message",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+					},
+				},
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+			},
+			"",
+			testAssert,
+		),
+		NewStandardLibraryFunction(
+			"testAssertEqual",
+			&sema.FunctionType{
+				Parameters: []*sema.Parameter{
+					{
+						Label:          sema.ArgumentLabelNotRequired,
+						Identifier:     "expected",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+					},
+					{
+						Label:          sema.ArgumentLabelNotRequired,
+						Identifier:     "actual",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+					},
+				},
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+			},
+			"",
+			testAssertEqual,
+		),
+		NewStandardLibraryFunction(
+			"testCreateAccount",
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
+			},
+			"",
+			func(invocation interpreter.Invocation) interpreter.Value {
+				address, err := framework.CreateAccount()
+				if err != nil {
+					panic(err)
+				}
+				return interpreter.NewAddressValue(address)
+			},
+		),
+		NewStandardLibraryFunction(
+			"testDeployContract",
+			&sema.FunctionType{
+				Parameters: []*sema.Parameter{
+					{
+						Identifier:     "name",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+					},
+					{
+						Identifier:     "code",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+					},
+					{
+						Identifier:     "account",
+						TypeAnnotation: sema.NewTypeAnnotation(&sema.AddressType{}),
+					},
+					{
+						Identifier:     "arguments",
+						TypeAnnotation: sema.NewTypeAnnotation(testArgumentsType),
+					},
+				},
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(testErrorMessageType),
+			},
+			"",
+			func(invocation interpreter.Invocation) interpreter.Value {
+				name := testStringArgument(invocation, 0)
+				code := testStringArgument(invocation, 1)
+
+				account, ok := invocation.Arguments[2].(interpreter.AddressValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				arguments := testArrayArgument(invocation, 3)
+
+				err := framework.DeployContract(
+					invocation.Interpreter,
+					name,
+					code,
+					common.Address(account),
+					arguments,
+				)
+				return testErrorMessage(err)
+			},
+		),
+		NewStandardLibraryFunction(
+			"testExecuteTransaction",
+			&sema.FunctionType{
+				Parameters: []*sema.Parameter{
+					{
+						Label:          sema.ArgumentLabelNotRequired,
+						Identifier:     "code",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+					},
+					{
+						Identifier: "signers",
+						TypeAnnotation: sema.NewTypeAnnotation(
+							&sema.VariableSizedType{
+								Type: &sema.AddressType{},
+							},
+						),
+					},
+					{
+						Identifier:     "arguments",
+						TypeAnnotation: sema.NewTypeAnnotation(testArgumentsType),
+					},
+				},
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(testErrorMessageType),
+			},
+			"",
+			func(invocation interpreter.Invocation) interpreter.Value {
+				code := testStringArgument(invocation, 0)
+
+				signerValues := testArrayArgument(invocation, 1)
+				signers := make([]common.Address, len(signerValues))
+				for i, signerValue := range signerValues {
+					signer, ok := signerValue.(interpreter.AddressValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+					signers[i] = common.Address(signer)
+				}
+
+				arguments := testArrayArgument(invocation, 2)
+
+				err := framework.ExecuteTransaction(
+					invocation.Interpreter,
+					code,
+					signers,
+					arguments,
+				)
+				return testErrorMessage(err)
+			},
+		),
+		NewStandardLibraryFunction(
+			"testExecuteScript",
+			&sema.FunctionType{
+				Parameters: []*sema.Parameter{
+					{
+						Label:          sema.ArgumentLabelNotRequired,
+						Identifier:     "code",
+						TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+					},
+					{
+						Identifier:     "arguments",
+						TypeAnnotation: sema.NewTypeAnnotation(testArgumentsType),
+					},
+				},
+				// The result is the pair of the return value and the error message
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					&sema.VariableSizedType{
+						Type: &sema.OptionalType{
+							Type: sema.AnyStructType,
+						},
+					},
+				),
+			},
+			"",
+			func(invocation interpreter.Invocation) interpreter.Value {
+				code := testStringArgument(invocation, 0)
+				arguments := testArrayArgument(invocation, 1)
+
+				inter := invocation.Interpreter
+
+				value, err := framework.ExecuteScript(inter, code, arguments)
+
+				var returnValue interpreter.OptionalValue = interpreter.NilValue{}
+				if err == nil && value != nil {
+					returnValue = interpreter.NewSomeValueNonCopying(value)
+				}
+
+				return interpreter.NewArrayValue(
+					inter,
+					interpreter.VariableSizedStaticType{
+						Type: interpreter.OptionalStaticType{
+							Type: interpreter.PrimitiveStaticTypeAnyStruct,
+						},
+					},
+					common.Address{},
+					returnValue,
+					testErrorMessage(err),
+				)
+			},
+		),
+	}
+
+	for i := range functions {
+		functions[i].Available = isTestContractLocation
+	}
+
+	return functions
+}
+
+func testAssert(invocation interpreter.Invocation) interpreter.Value {
+	condition, ok := invocation.Arguments[0].(interpreter.BoolValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	if !condition {
+		panic(AssertionError{
+			Message:       testStringArgument(invocation, 1),
+			LocationRange: invocation.GetLocationRange(),
+		})
+	}
+
+	return interpreter.VoidValue{}
+}
+
+func testAssertEqual(invocation interpreter.Invocation) interpreter.Value {
+	expected := invocation.Arguments[0]
+	actual := invocation.Arguments[1]
+
+	equatableExpected, ok := expected.(interpreter.EquatableValue)
+	if !ok || !equatableExpected.Equal(invocation.Interpreter, invocation.GetLocationRange, actual) {
+		panic(AssertionError{
+			Message: fmt.Sprintf(
+				"not equal: expected: %s, actual: %s",
+				expected,
+				actual,
+			),
+			LocationRange: invocation.GetLocationRange(),
+		})
+	}
+
+	return interpreter.VoidValue{}
+}
+
+func testStringArgument(invocation interpreter.Invocation, index int) string {
+	value, ok := invocation.Arguments[index].(*interpreter.StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return value.Str
+}
+
+func testArrayArgument(invocation interpreter.Invocation, index int) []interpreter.Value {
+	array, ok := invocation.Arguments[index].(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	values := make([]interpreter.Value, 0, array.Count())
+	array.Iterate(func(element interpreter.Value) (resume bool) {
+		values = append(values, element)
+		return true
+	})
+	return values
+}
+
+func testErrorMessage(err error) interpreter.OptionalValue {
+	if err == nil {
+		return interpreter.NilValue{}
+	}
+	return interpreter.NewSomeValueNonCopying(
+		interpreter.NewStringValue(err.Error()),
+	)
+}
+
+// TestChecker is the checker of the built-in Test contract
+//
+var TestChecker = func() *sema.Checker {
+
+	program, err := parser2.ParseProgram(contracts.Test)
+	if err != nil {
+		panic(err)
+	}
+
+	// The functions are only needed for their types
+
+	functions := append(
+		NewTestContractFunctions(nil),
+		BuiltinFunctions...,
+	)
+
+	var checker *sema.Checker
+	checker, err = sema.NewChecker(
+		program,
+		TestContractLocation,
+		sema.WithPredeclaredValues(functions.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(BuiltinTypes.ToTypeDeclarations()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = checker.Check()
+	if err != nil {
+		panic(err)
+	}
+
+	return checker
+}()
+
+// NewTestContract creates the value of the Test contract using the given constructor
+//
+func NewTestContract(
+	inter *interpreter.Interpreter,
+	constructor interpreter.FunctionValue,
+	invocationRange ast.Range,
+) (
+	*interpreter.CompositeValue,
+	error,
+) {
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		nil,
+		nil,
+		nil,
+		invocationRange,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*interpreter.CompositeValue), nil
+}
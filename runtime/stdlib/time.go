@@ -0,0 +1,83 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib/contracts"
+)
+
+// TimeContractLocation is the location of the built-in Time contract,
+// which provides the types `Time.Timestamp` and `Time.Duration`
+//
+var TimeContractLocation = common.IdentifierLocation("Time")
+
+var TimeChecker = func() *sema.Checker {
+
+	program, err := parser2.ParseProgram(contracts.Time)
+	if err != nil {
+		panic(err)
+	}
+
+	var checker *sema.Checker
+	checker, err = sema.NewChecker(
+		program,
+		TimeContractLocation,
+		sema.WithPredeclaredValues(BuiltinFunctions.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(BuiltinTypes.ToTypeDeclarations()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = checker.Check()
+	if err != nil {
+		panic(err)
+	}
+
+	return checker
+}()
+
+// NewTimeContract creates the value of the Time contract using the given constructor
+//
+func NewTimeContract(
+	inter *interpreter.Interpreter,
+	constructor interpreter.FunctionValue,
+	invocationRange ast.Range,
+) (
+	*interpreter.CompositeValue,
+	error,
+) {
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		nil,
+		nil,
+		nil,
+		invocationRange,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*interpreter.CompositeValue), nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+const viewResolverContractName = "ViewResolver"
+const viewResolverResolverTypeName = "Resolver"
+const viewResolverResolveViewFunctionName = "resolveView"
+
+// ViewResolverResolveFunctionName is the name of the natively implemented function
+// `ViewResolver.resolve<T: AnyStruct>(_ resolver: &AnyResource{ViewResolver.Resolver}): T?`.
+//
+// Cadence functions cannot have type parameters, so the function is declared
+// as an additional member of the ViewResolver contract type.
+//
+const ViewResolverResolveFunctionName = "resolve"
+
+const viewResolverResolveFunctionDocString = `
+Returns the view of type T of the given resolver, or nil if the resolver does not support it.
+
+The view is statically typed, so it does not have to be cast,
+and the view is resolved directly, without building the list of the resolver's views
+`
+
+// viewResolverMembers declares the natively implemented members of the ViewResolver contract type.
+// It is used as the composite members handler when checking the ViewResolver contract.
+//
+func viewResolverMembers(compositeType *sema.CompositeType) []*sema.Member {
+	if compositeType.Location != ViewResolverLocation ||
+		compositeType.QualifiedIdentifier() != viewResolverContractName {
+
+		return nil
+	}
+
+	nestedType, ok := compositeType.GetNestedTypes().Get(viewResolverResolverTypeName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	resolverType, ok := nestedType.(*sema.InterfaceType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	typeParameter := &sema.TypeParameter{
+		Name:      "T",
+		TypeBound: sema.AnyStructType,
+	}
+
+	functionType := &sema.FunctionType{
+		TypeParameters: []*sema.TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*sema.Parameter{
+			{
+				Label:      sema.ArgumentLabelNotRequired,
+				Identifier: "resolver",
+				TypeAnnotation: sema.NewTypeAnnotation(
+					&sema.ReferenceType{
+						Type: &sema.RestrictedType{
+							Type: sema.AnyResourceType,
+							Restrictions: []*sema.InterfaceType{
+								resolverType,
+							},
+						},
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			&sema.OptionalType{
+				Type: &sema.GenericType{
+					TypeParameter: typeParameter,
+				},
+			},
+		),
+	}
+
+	return []*sema.Member{
+		sema.NewPublicFunctionMember(
+			compositeType,
+			ViewResolverResolveFunctionName,
+			functionType,
+			viewResolverResolveFunctionDocString,
+		),
+	}
+}
+
+// injectViewResolverFunctions adds the natively implemented functions
+// to the given value of the ViewResolver contract.
+//
+func injectViewResolverFunctions(
+	inter *interpreter.Interpreter,
+	contract *interpreter.CompositeValue,
+	contractType *sema.CompositeType,
+) {
+	member, ok := contractType.Members.Get(ViewResolverResolveFunctionName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// NOTE: the functions of the type are shared by all values of the type,
+	// so they must not be modified
+
+	contract.InitializeFunctions(inter)
+
+	functions := make(map[string]interpreter.FunctionValue, len(contract.Functions)+1)
+	for name, function := range contract.Functions { //nolint:maprangecheck
+		functions[name] = function
+	}
+	functions[ViewResolverResolveFunctionName] = interpreter.NewHostFunctionValue(
+		resolveView,
+		functionType,
+	)
+
+	contract.Functions = functions
+}
+
+// resolveView is the native implementation of `ViewResolver.resolve`.
+//
+// It calls the resolver's `resolveView` function with the requested view type,
+// and returns the view if it has the requested type.
+//
+func resolveView(invocation interpreter.Invocation) interpreter.Value {
+	inter := invocation.Interpreter
+	getLocationRange := invocation.GetLocationRange
+
+	resolver, ok := invocation.Arguments[0].(interpreter.MemberAccessibleValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	typeParameterPair := invocation.TypeParameterTypes.Oldest()
+	if typeParameterPair == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	viewType := typeParameterPair.Value
+
+	resolveViewFunction, ok := resolver.GetMember(
+		inter,
+		getLocationRange,
+		viewResolverResolveViewFunctionName,
+	).(interpreter.FunctionValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	metaTypes := []sema.Type{sema.MetaType}
+
+	result, err := inter.InvokeFunctionValue(
+		resolveViewFunction,
+		[]interpreter.Value{
+			interpreter.TypeValue{
+				Type: interpreter.ConvertSemaToStaticType(viewType),
+			},
+		},
+		metaTypes,
+		metaTypes,
+		getLocationRange().Range,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	someValue, ok := result.(*interpreter.SomeValue)
+	if !ok {
+		return interpreter.NilValue{}
+	}
+
+	// The post-condition of the resolver's resolveView function already ensures
+	// that the view has the requested type, but the static type of the result depends on it
+
+	view := someValue.Value
+	if !inter.IsSubType(view.DynamicType(inter, interpreter.SeenReferences{}), viewType) {
+		return interpreter.NilValue{}
+	}
+
+	return someValue
+}
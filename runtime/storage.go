@@ -31,21 +31,47 @@ import (
 
 const StorageDomainContract = "contract"
 
+// StorageDomainFrozenContract is the storage domain
+// which records the names of the contracts that were deployed as frozen,
+// i.e. that can neither be updated nor removed.
+//
+const StorageDomainFrozenContract = "frozen_contract"
+
 type Storage struct {
 	*atree.PersistentSlabStorage
 	writes          map[interpreter.StorageKey]atree.StorageIndex
 	storageMaps     map[interpreter.StorageKey]*interpreter.StorageMap
 	contractUpdates map[interpreter.StorageKey]*interpreter.CompositeValue
-	Ledger          atree.Ledger
+	contractValues  *ContractValueCache
+	// prefetched contains the prefetched registers of storage maps
+	// which were not loaded yet
+	prefetched map[interpreter.StorageKey][]byte
+	// transactionStorageMap is the storage map of the transaction domain,
+	// which is temporary and never written to the ledger
+	transactionStorageMap *interpreter.StorageMap
+	// usageTracking tracks the storage released by removed slabs and the change of the storage used,
+	// if the ledger implements StorageReleaseInterface or StorageUsageDeltaInterface
+	usageTracking *usageTrackingBaseStorage
+	Ledger        atree.Ledger
 }
 
 var _ atree.SlabStorage = &Storage{}
 var _ interpreter.Storage = &Storage{}
 
 func NewStorage(ledger atree.Ledger) *Storage {
-	ledgerStorage := atree.NewLedgerBaseStorage(ledger)
+	var baseStorage atree.BaseStorage
+	var usageTracking *usageTrackingBaseStorage
+	_, tracksRelease := ledger.(StorageReleaseInterface)
+	_, tracksUsage := ledger.(StorageUsageDeltaInterface)
+	if tracksRelease || tracksUsage {
+		usageTracking = newUsageTrackingBaseStorage(ledger)
+		baseStorage = usageTracking
+	} else {
+		baseStorage = atree.NewLedgerBaseStorage(ledger)
+	}
+
 	persistentSlabStorage := atree.NewPersistentSlabStorage(
-		ledgerStorage,
+		baseStorage,
 		interpreter.CBOREncMode,
 		interpreter.CBORDecMode,
 		interpreter.DecodeStorable,
@@ -57,12 +83,26 @@ func NewStorage(ledger atree.Ledger) *Storage {
 		writes:                map[interpreter.StorageKey]atree.StorageIndex{},
 		storageMaps:           map[interpreter.StorageKey]*interpreter.StorageMap{},
 		contractUpdates:       map[interpreter.StorageKey]*interpreter.CompositeValue{},
+		usageTracking:         usageTracking,
+	}
+}
+
+// contractValueCache returns the cache of the contract values loaded in this storage.
+//
+func (s *Storage) contractValueCache() *ContractValueCache {
+	if s.contractValues == nil {
+		s.contractValues = NewContractValueCache()
 	}
+	return s.contractValues
 }
 
 const storageIndexLength = 8
 
 func (s *Storage) GetStorageMap(address common.Address, domain string) (storageMap *interpreter.StorageMap) {
+	if domain == common.PathDomainTransaction.Identifier() {
+		return s.getTransactionStorageMap()
+	}
+
 	key := interpreter.StorageKey{
 		Address: address,
 		Key:     domain,
@@ -71,15 +111,20 @@ func (s *Storage) GetStorageMap(address common.Address, domain string) (storageM
 	storageMap = s.storageMaps[key]
 	if storageMap == nil {
 
-		// Load data through the runtime interface
+		// Load data through the runtime interface,
+		// unless it was already prefetched
 
-		var data []byte
-		var err error
-		wrapPanic(func() {
-			data, err = s.Ledger.GetValue(key.Address[:], []byte(key.Key))
-		})
-		if err != nil {
-			panic(err)
+		data, ok := s.prefetched[key]
+		if ok {
+			delete(s.prefetched, key)
+		} else {
+			var err error
+			wrapPanic(func() {
+				data, err = s.Ledger.GetValue(key.Address[:], []byte(key.Key))
+			})
+			if err != nil {
+				panic(err)
+			}
 		}
 
 		dataLength := len(data)
@@ -110,6 +155,183 @@ func (s *Storage) GetStorageMap(address common.Address, domain string) (storageM
 	return storageMap
 }
 
+// getTransactionStorageMap returns the storage map of the transaction domain.
+// The storage map and all values stored in it have a temporary address,
+// so they are not written to the ledger when the storage is committed.
+//
+func (s *Storage) getTransactionStorageMap() *interpreter.StorageMap {
+	if s.transactionStorageMap == nil {
+		s.transactionStorageMap = interpreter.NewStorageMap(s, atree.AddressUndefined)
+	}
+	return s.transactionStorageMap
+}
+
+// checkTransactionStorage checks that no resources are left in the transaction domain,
+// as they would be lost when the transaction storage is discarded.
+//
+func (s *Storage) checkTransactionStorage(inter *interpreter.Interpreter) error {
+	if s.transactionStorageMap == nil {
+		return nil
+	}
+
+	iterator := s.transactionStorageMap.Iterator()
+	for {
+		identifier, value := iterator.Next()
+		if value == nil {
+			return nil
+		}
+
+		if value.IsResourceKinded(inter) {
+			return &TransactionStorageResourceLossError{
+				Path: interpreter.PathValue{
+					Domain:     common.PathDomainTransaction,
+					Identifier: identifier,
+				},
+			}
+		}
+	}
+}
+
+// storageMapExists returns true if a storage map for the given domain
+// was already loaded or exists in the underlying ledger.
+// In contrast to GetStorageMap, it does not create a new storage map.
+//
+func (s *Storage) storageMapExists(address common.Address, domain string) bool {
+	key := interpreter.StorageKey{
+		Address: address,
+		Key:     domain,
+	}
+
+	if _, ok := s.storageMaps[key]; ok {
+		return true
+	}
+
+	if data, ok := s.prefetched[key]; ok {
+		return len(data) > 0
+	}
+
+	var exists bool
+	var err error
+	wrapPanic(func() {
+		exists, err = s.Ledger.ValueExists(key.Address[:], []byte(key.Key))
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return exists
+}
+
+// prefetchStorageMaps reads the registers of the storage maps with the given keys
+// which were not loaded or prefetched yet, in a single batch if the ledger supports it.
+// The storage maps are only loaded when they are first used.
+//
+func (s *Storage) prefetchStorageMaps(keys []interpreter.StorageKey) error {
+
+	var valueKeys []ValueKey
+	var storageKeys []interpreter.StorageKey
+
+	for _, key := range keys {
+		if _, ok := s.storageMaps[key]; ok {
+			continue
+		}
+		if _, ok := s.prefetched[key]; ok {
+			continue
+		}
+
+		// NOTE: copy the address, the key is a loop variable
+		address := key.Address
+
+		valueKeys = append(
+			valueKeys,
+			ValueKey{
+				Owner: address[:],
+				Key:   []byte(key.Key),
+			},
+		)
+		storageKeys = append(storageKeys, key)
+	}
+
+	if len(valueKeys) == 0 {
+		return nil
+	}
+
+	values, err := getValues(s.Ledger, valueKeys)
+	if err != nil {
+		return err
+	}
+
+	if s.prefetched == nil {
+		s.prefetched = make(map[interpreter.StorageKey][]byte, len(storageKeys))
+	}
+
+	for i, key := range storageKeys {
+		s.prefetched[key] = values[i]
+	}
+
+	return nil
+}
+
+// getValues gets the values for the given keys from the ledger,
+// in a single call if the ledger implements BatchValueInterface,
+// or one by one otherwise.
+//
+func getValues(ledger atree.Ledger, keys []ValueKey) (values [][]byte, err error) {
+	if batchLedger, ok := ledger.(BatchValueInterface); ok {
+		wrapPanic(func() {
+			values, err = batchLedger.GetValues(keys)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(keys) {
+			return nil, fmt.Errorf(
+				"invalid number of values: expected %d, got %d",
+				len(keys),
+				len(values),
+			)
+		}
+		return values, nil
+	}
+
+	values = make([][]byte, len(keys))
+
+	for i, key := range keys {
+		wrapPanic(func() {
+			values[i], err = ledger.GetValue(key.Owner, key.Key)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// setValues sets the values of the given writes in the ledger, in order,
+// in a single call if the ledger implements BatchValueInterface,
+// or one by one otherwise.
+//
+func setValues(ledger atree.Ledger, writes []ValueWrite) (err error) {
+	if batchLedger, ok := ledger.(BatchValueInterface); ok {
+		wrapPanic(func() {
+			err = batchLedger.SetValues(writes)
+		})
+		return err
+	}
+
+	for _, write := range writes {
+		wrapPanic(func() {
+			err = ledger.SetValue(write.Owner, write.Key, write.Value)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Storage) loadExistingStorageMap(address atree.Address, storageIndex atree.StorageIndex) *interpreter.StorageMap {
 
 	storageID := atree.StorageID{
@@ -151,6 +373,30 @@ func (s *Storage) recordContractUpdate(
 	s.contractUpdates[key] = contractValue
 }
 
+// isContractFrozen returns true if the contract with the given name
+// was deployed as frozen.
+//
+func (s *Storage) isContractFrozen(address common.Address, name string) bool {
+	if !s.storageMapExists(address, StorageDomainFrozenContract) {
+		return false
+	}
+
+	storageMap := s.GetStorageMap(address, StorageDomainFrozenContract)
+	return storageMap.ValueExists(name)
+}
+
+// recordFrozenContract records that the contract with the given name
+// was deployed as frozen.
+//
+func (s *Storage) recordFrozenContract(
+	inter *interpreter.Interpreter,
+	address common.Address,
+	name string,
+) {
+	storageMap := s.GetStorageMap(address, StorageDomainFrozenContract)
+	storageMap.WriteValue(inter, name, interpreter.BoolValue(true))
+}
+
 type ContractUpdate struct {
 	Key           interpreter.StorageKey
 	ContractValue *interpreter.CompositeValue
@@ -270,31 +516,58 @@ func (s *Storage) Commit(inter *interpreter.Interpreter, commitContractUpdates b
 
 	// Write account storage entries in order
 
-	// NOTE: Important: do not use a for-range loop,
-	// as the introduced variable will be overridden on each loop iteration,
-	// leading to the slices created in the loop body being backed by the same data
-	for i := 0; i < len(writes); i++ {
-		write := writes[i]
+	if len(writes) > 0 {
 
-		var err error
-		wrapPanic(func() {
-			err = s.Ledger.SetValue(
-				write.storageKey.Address[:],
-				[]byte(write.storageKey.Key),
-				write.storageIndex[:],
-			)
-		})
+		valueWrites := make([]ValueWrite, len(writes))
+
+		// NOTE: Important: do not use a for-range loop,
+		// as the introduced variable will be overridden on each loop iteration,
+		// leading to the slices created in the loop body being backed by the same data
+		for i := 0; i < len(writes); i++ {
+			write := writes[i]
+
+			valueWrites[i] = ValueWrite{
+				Owner: write.storageKey.Address[:],
+				Key:   []byte(write.storageKey.Key),
+				Value: write.storageIndex[:],
+			}
+		}
+
+		err := setValues(s.Ledger, valueWrites)
 		if err != nil {
 			return err
 		}
 
-		delete(s.writes, write.storageKey)
+		for _, write := range writes {
+			delete(s.writes, write.storageKey)
+
+			// The storage index of a storage map is only written when the storage map is created
+
+			if s.usageTracking != nil {
+				s.usageTracking.recordRegisterCreated(
+					atree.Address(write.storageKey.Address),
+					len(write.storageKey.Key),
+					len(write.storageIndex),
+				)
+			}
+		}
 	}
 
 	// Commit the underlying slab storage's writes
 
 	// TODO: report encoding metric for all encoded slabs
-	return s.PersistentSlabStorage.FastCommit(runtime.NumCPU())
+	err := s.PersistentSlabStorage.FastCommit(runtime.NumCPU())
+	if err != nil {
+		return err
+	}
+
+	// Report the storage released by removed slabs, and the change of the storage used
+
+	if s.usageTracking != nil {
+		return s.usageTracking.report(s.Ledger)
+	}
+
+	return nil
 }
 
 func (s *Storage) CheckHealth() error {
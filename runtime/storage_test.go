@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/onflow/atree"
@@ -2928,3 +2929,807 @@ func TestRuntimeReferenceOwnerAccess(t *testing.T) {
 		)
 	})
 }
+
+type testBatchRuntimeInterface struct {
+	*testRuntimeInterface
+	getValues func(keys []ValueKey) ([][]byte, error)
+	setValues func(writes []ValueWrite) error
+}
+
+var _ BatchValueInterface = &testBatchRuntimeInterface{}
+
+func (i *testBatchRuntimeInterface) GetValues(keys []ValueKey) ([][]byte, error) {
+	return i.getValues(keys)
+}
+
+func (i *testBatchRuntimeInterface) SetValues(writes []ValueWrite) error {
+	return i.setValues(writes)
+}
+
+func TestRuntimeStorageBatchedValues(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	var singleReads []string
+	var singleWrites []string
+
+	ledger := newTestLedger(
+		func(_, key, _ []byte) {
+			singleReads = append(singleReads, string(key))
+		},
+		func(_, key, _ []byte) {
+			singleWrites = append(singleWrites, string(key))
+		},
+	)
+
+	var batchedReads [][]string
+	var batchedWrites [][]string
+
+	runtimeInterface := &testBatchRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+		},
+		getValues: func(keys []ValueKey) ([][]byte, error) {
+			values := make([][]byte, len(keys))
+			var batch []string
+			for i, key := range keys {
+				values[i] = ledger.storedValues[string(key.Owner)+"|"+string(key.Key)]
+				batch = append(batch, string(key.Key))
+			}
+			batchedReads = append(batchedReads, batch)
+			return values, nil
+		},
+		setValues: func(writes []ValueWrite) error {
+			var batch []string
+			for _, write := range writes {
+				ledger.storedValues[string(write.Owner)+"|"+string(write.Key)] = write.Value
+				batch = append(batch, string(write.Key))
+			}
+			batchedWrites = append(batchedWrites, batch)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(code string) {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(1, to: /storage/one)
+              signer.link<&Int>(/public/one, target: /storage/one)
+          }
+      }
+    `)
+
+	// The storage maps of the signer which the transaction refers to are prefetched in a single batch,
+	// and the storage indices of the new storage maps are written in a single batch
+
+	assert.Equal(t,
+		[][]string{{"storage", "public"}},
+		batchedReads,
+	)
+	assert.Equal(t,
+		[][]string{{"public", "storage"}},
+		batchedWrites,
+	)
+
+	// Only the storage map slabs are written individually, by the slab storage
+
+	for _, key := range singleWrites {
+		assert.Equal(t, byte('$'), key[0])
+	}
+	assert.NotContains(t, singleReads, "storage")
+	assert.NotContains(t, singleReads, "public")
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let one = signer.load<Int>(from: /storage/one)!
+              assert(one == 1)
+              assert(signer.getCapability<&Int>(/public/one).borrow() == nil)
+          }
+      }
+    `)
+
+	assert.Equal(t,
+		[][]string{
+			{"storage", "public"},
+			{"storage", "public"},
+		},
+		batchedReads,
+	)
+	assert.Len(t, batchedWrites, 1)
+
+	// The storage maps of domains which the transaction does not refer to are not read
+
+	batchedReads = nil
+	singleReads = nil
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(2, to: /storage/two)
+          }
+      }
+    `)
+
+	assert.Equal(t,
+		[][]string{{"storage"}},
+		batchedReads,
+	)
+	assert.NotContains(t, singleReads, "public")
+	assert.NotContains(t, singleReads, "private")
+}
+
+func TestRuntimeStorageUnbatchedValues(t *testing.T) {
+
+	t.Parallel()
+
+	var writes []string
+
+	ledger := newTestLedger(
+		nil,
+		func(_, key, _ []byte) {
+			writes = append(writes, string(key))
+		},
+	)
+
+	storage := NewStorage(ledger)
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	keys := []interpreter.StorageKey{
+		{Address: address, Key: "b"},
+		{Address: address, Key: "a"},
+	}
+
+	// Prefetching falls back to reading the values individually
+
+	err := storage.prefetchStorageMaps(keys)
+	require.NoError(t, err)
+
+	require.False(t, storage.storageMapExists(address, "a"))
+
+	for _, key := range keys {
+		storage.GetStorageMap(key.Address, key.Key)
+	}
+
+	inter := newTestInterpreter(t)
+
+	// Writing falls back to writing the values individually
+
+	const commitContractUpdates = true
+	err = storage.Commit(inter, commitContractUpdates)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, len(writes), 2)
+	assert.Equal(t, []string{"a", "b"}, writes[:2])
+}
+
+type testPreloadRuntimeInterface struct {
+	*testRuntimeInterface
+	batchPreloadValues func(keys []ValueKey) error
+}
+
+var _ BatchPreloadInterface = &testPreloadRuntimeInterface{}
+
+func (i *testPreloadRuntimeInterface) BatchPreloadValues(keys []ValueKey) error {
+	return i.batchPreloadValues(keys)
+}
+
+func TestRuntimeStorageBatchPreloadValues(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	type preload struct {
+		owner common.Address
+		key   string
+	}
+
+	var preloads [][]preload
+
+	runtimeInterface := &testPreloadRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address1, address2}, nil
+			},
+		},
+		batchPreloadValues: func(keys []ValueKey) error {
+			var batch []preload
+			for _, key := range keys {
+				batch = append(batch, preload{
+					owner: common.MustBytesToAddress(key.Owner),
+					key:   string(key.Key),
+				})
+			}
+			preloads = append(preloads, batch)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(code string) {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	executeTransaction(`
+      transaction {
+          prepare(signer1: AuthAccount, signer2: AuthAccount) {
+              signer1.save(1, to: /storage/one)
+              signer1.save([1], to: /storage/array)
+              signer2.link<&Int>(/public/one, target: /storage/one)
+          }
+      }
+    `)
+
+	assert.Equal(t,
+		[][]preload{
+			{
+				{owner: address1, key: "storage"},
+				{owner: address1, key: "public"},
+				{owner: address2, key: "storage"},
+				{owner: address2, key: "public"},
+			},
+		},
+		preloads,
+	)
+
+	// A transaction without path literals does not preload anything
+
+	executeTransaction(`
+      transaction {
+          prepare(signer1: AuthAccount, signer2: AuthAccount) {}
+      }
+    `)
+
+	assert.Len(t, preloads, 1)
+
+	// A transaction with path literals referring to existing values
+	// also preloads the slabs of the values which are not stored inline
+
+	executeTransaction(`
+      transaction {
+          prepare(signer1: AuthAccount, signer2: AuthAccount) {
+              signer1.borrow<&Int>(from: /storage/one)
+              signer1.borrow<&[Int]>(from: /storage/array)
+              signer1.borrow<&Int>(from: /storage/missing)
+          }
+      }
+    `)
+
+	require.Len(t, preloads, 3)
+
+	assert.Equal(t,
+		[]preload{
+			{owner: address1, key: "storage"},
+			{owner: address2, key: "storage"},
+		},
+		preloads[1],
+	)
+
+	require.Len(t, preloads[2], 1)
+	assert.Equal(t, address1, preloads[2][0].owner)
+	assert.True(t, strings.HasPrefix(preloads[2][0].key, "$"))
+}
+
+func TestRuntimeTransactionStorage(t *testing.T) {
+
+	t.Parallel()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	t.Run("shared between signers and blocks, not persisted", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		var writtenKeys []string
+
+		ledger := newTestLedger(
+			nil,
+			func(owner, key, value []byte) {
+				assert.NotEqual(t, common.Address{}, common.MustBytesToAddress(owner))
+				writtenKeys = append(writtenKeys, string(key))
+			},
+		)
+
+		var loggedMessages []string
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address1, address2}, nil
+			},
+			log: func(message string) {
+				loggedMessages = append(loggedMessages, message)
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+
+                      let signer: AuthAccount
+
+                      prepare(signer1: AuthAccount, signer2: AuthAccount) {
+                          self.signer = signer1
+
+                          signer1.save([signer1.address], to: /transaction/addresses)
+
+                          let addresses = signer2.load<[Address]>(from: /transaction/addresses)!
+                          addresses.append(signer2.address)
+                          signer2.save(addresses, to: /transaction/addresses)
+
+                          signer2.save(1, to: /storage/one)
+                      }
+
+                      execute {
+                          let addresses = self.signer
+                              .borrow<&[Address]>(from: /transaction/addresses)!
+                          log(addresses.length)
+                          log(addresses[1])
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"2", "0x0000000000000002"}, loggedMessages)
+		assert.NotContains(t, writtenKeys, common.PathDomainTransaction.Identifier())
+		assert.Contains(t, writtenKeys, common.PathDomainStorage.Identifier())
+
+		// The values are discarded at the end of the transaction
+
+		loggedMessages = nil
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer1: AuthAccount, signer2: AuthAccount) {
+                          log(signer1.copy<[Address]>(from: /transaction/addresses))
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"nil"}, loggedMessages)
+	})
+
+	t.Run("resources", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		contract := []byte(`
+          pub contract Test {
+
+              pub resource R {}
+
+              pub fun createR(): @R {
+                  return <-create R()
+              }
+          }
+        `)
+
+		accountCodes := map[common.LocationID][]byte{}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address1}, nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location.ID()] = code
+				return nil
+			},
+			getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				code = accountCodes[location.ID()]
+				return code, nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction("Test", contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		// Moving the resource out of the transaction storage is valid
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  transaction {
+
+                      let signer: AuthAccount
+
+                      prepare(signer: AuthAccount) {
+                          self.signer = signer
+                          signer.save(<-Test.createR(), to: /transaction/r)
+                      }
+
+                      execute {
+                          self.signer.save(
+                              <-self.signer.load<@Test.R>(from: /transaction/r)!,
+                              to: /storage/r
+                          )
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		// Leaving the resource in the transaction storage is invalid
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save(<-Test.createR(), to: /transaction/r)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var resourceLossErr *TransactionStorageResourceLossError
+		require.ErrorAs(t, err, &resourceLossErr)
+		assert.Equal(t, "r", resourceLossErr.Path.Identifier)
+	})
+}
+
+type testStorageReleaseRuntimeInterface struct {
+	*testRuntimeInterface
+	storageReleased func(address Address, bytes uint64) error
+}
+
+var _ StorageReleaseInterface = &testStorageReleaseRuntimeInterface{}
+
+func (i *testStorageReleaseRuntimeInterface) StorageReleased(address Address, bytes uint64) error {
+	return i.storageReleased(address, bytes)
+}
+
+func TestRuntimeStorageReleased(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	// Track the sizes of the slab registers,
+	// and the total size of the slab registers which are removed
+
+	slabSizes := map[string]int{}
+	var removedSlabBytes uint64
+
+	onWrite := func(owner, key, value []byte) {
+		if len(key) == 0 || key[0] != '$' {
+			return
+		}
+
+		storageKey := string(owner) + string(key)
+
+		if len(value) == 0 {
+			removedSlabBytes += uint64(slabSizes[storageKey])
+			delete(slabSizes, storageKey)
+		} else {
+			slabSizes[storageKey] = len(value)
+		}
+	}
+
+	type release struct {
+		address Address
+		bytes   uint64
+	}
+
+	var releases []release
+
+	runtimeInterface := &testStorageReleaseRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, onWrite),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+		},
+		storageReleased: func(address Address, bytes uint64) error {
+			releases = append(releases, release{address, bytes})
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(code string) {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	// Storing values does not release storage
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let values: [String] = []
+              var i = 0
+              while i < 100 {
+                  values.append("value number ".concat(i.toString()))
+                  i = i + 1
+              }
+              signer.save(values, to: /storage/values)
+          }
+      }
+    `)
+
+	require.Empty(t, releases)
+	require.Zero(t, removedSlabBytes)
+
+	// Removing values releases storage
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.load<[String]>(from: /storage/values)
+          }
+      }
+    `)
+
+	require.NotZero(t, removedSlabBytes)
+
+	assert.Equal(t,
+		[]release{
+			{
+				address: address,
+				bytes:   removedSlabBytes,
+			},
+		},
+		releases,
+	)
+}
+
+type testStorageUsageDeltaRuntimeInterface struct {
+	*testRuntimeInterface
+	storageUsageChanged func(address Address, delta int64) error
+}
+
+var _ StorageUsageDeltaInterface = &testStorageUsageDeltaRuntimeInterface{}
+
+func (i *testStorageUsageDeltaRuntimeInterface) StorageUsageChanged(address Address, delta int64) error {
+	return i.storageUsageChanged(address, delta)
+}
+
+func TestRuntimeStorageUsageDelta(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	// Track the storage used by all registers of the account,
+	// i.e. the length of the key and the value of each register
+
+	registerSizes := map[string]int64{}
+	var storageUsed int64
+
+	onWrite := func(owner, key, value []byte) {
+		require.Equal(t, address[:], owner)
+
+		storageKey := string(key)
+
+		storageUsed -= registerSizes[storageKey]
+
+		if len(value) == 0 {
+			delete(registerSizes, storageKey)
+		} else {
+			size := int64(len(key) + len(value))
+			registerSizes[storageKey] = size
+			storageUsed += size
+		}
+	}
+
+	var deltas []int64
+
+	runtimeInterface := &testStorageUsageDeltaRuntimeInterface{
+		testRuntimeInterface: &testRuntimeInterface{
+			storage: newTestLedger(nil, onWrite),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+		},
+		storageUsageChanged: func(changedAddress Address, delta int64) error {
+			assert.Equal(t, address, changedAddress)
+			deltas = append(deltas, delta)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	test := func(code string, expectedChange bool) {
+
+		deltas = nil
+		previousStorageUsed := storageUsed
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		if expectedChange {
+			require.Len(t, deltas, 1)
+			assert.NotZero(t, deltas[0])
+			assert.Equal(t, storageUsed-previousStorageUsed, deltas[0])
+		} else {
+			assert.Empty(t, deltas)
+			assert.Equal(t, previousStorageUsed, storageUsed)
+		}
+	}
+
+	// Creating the storage map and storing a value increases the storage used
+
+	test(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  let values: [String] = []
+                  var i = 0
+                  while i < 100 {
+                      values.append("value number ".concat(i.toString()))
+                      i = i + 1
+                  }
+                  signer.save(values, to: /storage/values)
+              }
+          }
+        `,
+		true,
+	)
+
+	// Updating a value changes the storage used
+
+	test(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  let values = signer.borrow<&[String]>(from: /storage/values)!
+                  var i = 0
+                  while i < 50 {
+                      values.append("another value number ".concat(i.toString()))
+                      i = i + 1
+                  }
+              }
+          }
+        `,
+		true,
+	)
+
+	// Reading a value does not change the storage used
+
+	test(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.borrow<&[String]>(from: /storage/values)!.length
+              }
+          }
+        `,
+		false,
+	)
+
+	// Removing a value decreases the storage used
+
+	test(
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.load<[String]>(from: /storage/values)
+              }
+          }
+        `,
+		true,
+	)
+
+	assert.Negative(t, deltas[0])
+}
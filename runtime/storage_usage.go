@@ -0,0 +1,235 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// usageTrackingBaseStorage is a base storage which tracks, per account,
+// the number of bytes of storage which are released by removed slabs,
+// and the change of the storage used by slabs.
+//
+// It is only used if the ledger implements StorageReleaseInterface or StorageUsageDeltaInterface.
+//
+type usageTrackingBaseStorage struct {
+	*atree.LedgerBaseStorage
+	// sizes are the sizes of the slabs which were retrieved from or stored in the ledger
+	sizes map[atree.StorageID]int
+	// released are the numbers of bytes released per account
+	released map[atree.Address]uint64
+	// deltas are the changes of the storage used per account
+	deltas map[atree.Address]int64
+}
+
+var _ atree.BaseStorage = &usageTrackingBaseStorage{}
+
+func newUsageTrackingBaseStorage(ledger atree.Ledger) *usageTrackingBaseStorage {
+	return &usageTrackingBaseStorage{
+		LedgerBaseStorage: atree.NewLedgerBaseStorage(ledger),
+		sizes:             map[atree.StorageID]int{},
+		released:          map[atree.Address]uint64{},
+		deltas:            map[atree.Address]int64{},
+	}
+}
+
+// slabRegisterKeyLength is the length of the key of a register which stores a slab
+//
+const slabRegisterKeyLength = 1 + len(atree.StorageIndex{})
+
+// registerSize returns the storage used by a register,
+// i.e. the length of its key and value, or 0 if the register does not exist
+//
+func registerSize(keyLength int, valueLength int) int64 {
+	if valueLength == 0 {
+		return 0
+	}
+	return int64(keyLength + valueLength)
+}
+
+func (s *usageTrackingBaseStorage) GenerateStorageID(address atree.Address) (atree.StorageID, error) {
+	id, err := s.LedgerBaseStorage.GenerateStorageID(address)
+	if err != nil {
+		return atree.StorageID{}, err
+	}
+
+	// The slab for the newly allocated storage index does not exist yet
+	s.sizes[id] = 0
+
+	return id, nil
+}
+
+func (s *usageTrackingBaseStorage) Retrieve(id atree.StorageID) ([]byte, bool, error) {
+	data, ok, err := s.LedgerBaseStorage.Retrieve(id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.sizes[id] = len(data)
+
+	return data, ok, nil
+}
+
+// size returns the size of the slab with the given ID which is currently stored in the ledger.
+//
+// Slabs are usually loaded or created before they are updated or removed.
+// If the size of the slab is unknown, it is read from the ledger.
+//
+func (s *usageTrackingBaseStorage) size(id atree.StorageID) (int, error) {
+	size, ok := s.sizes[id]
+	if ok {
+		return size, nil
+	}
+
+	data, _, err := s.LedgerBaseStorage.Retrieve(id)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+func (s *usageTrackingBaseStorage) Store(id atree.StorageID, data []byte) error {
+	oldSize, err := s.size(id)
+	if err != nil {
+		return err
+	}
+
+	err = s.LedgerBaseStorage.Store(id, data)
+	if err != nil {
+		return err
+	}
+
+	s.sizes[id] = len(data)
+
+	s.deltas[id.Address] += registerSize(slabRegisterKeyLength, len(data)) -
+		registerSize(slabRegisterKeyLength, oldSize)
+
+	return nil
+}
+
+func (s *usageTrackingBaseStorage) Remove(id atree.StorageID) error {
+
+	// Slabs which were created and removed before they were ever stored don't exist
+
+	oldSize, err := s.size(id)
+	if err != nil {
+		return err
+	}
+
+	err = s.LedgerBaseStorage.Remove(id)
+	if err != nil {
+		return err
+	}
+
+	delete(s.sizes, id)
+
+	if oldSize > 0 {
+		s.released[id.Address] += uint64(oldSize)
+		s.deltas[id.Address] -= registerSize(slabRegisterKeyLength, oldSize)
+	}
+
+	return nil
+}
+
+// recordRegisterCreated records the creation of a register which is not a slab,
+// e.g. the register which stores the storage index of a storage map.
+//
+func (s *usageTrackingBaseStorage) recordRegisterCreated(address atree.Address, keyLength int, valueLength int) {
+	s.deltas[address] += registerSize(keyLength, valueLength)
+}
+
+// sortedAddresses returns the addresses of the given map, in lexicographic order.
+//
+func sortedAddresses(addresses map[atree.Address]struct{}) []atree.Address {
+	result := make([]atree.Address, 0, len(addresses))
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the keys are sorted afterwards
+
+	for address := range addresses { //nolint:maprangecheck
+		result = append(result, address)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i][:], result[j][:]) < 0
+	})
+
+	return result
+}
+
+// report reports the released bytes and the storage usage changes of each account
+// to the ledger, if it implements the corresponding interfaces,
+// in the order of the addresses, and resets them.
+//
+func (s *usageTrackingBaseStorage) report(ledger atree.Ledger) (err error) {
+
+	storageReleaseInterface, ok := ledger.(StorageReleaseInterface)
+	if ok && len(s.released) > 0 {
+
+		addresses := make(map[atree.Address]struct{}, len(s.released))
+		for address := range s.released { //nolint:maprangecheck
+			addresses[address] = struct{}{}
+		}
+
+		for _, address := range sortedAddresses(addresses) {
+			released := s.released[address]
+
+			wrapPanic(func() {
+				err = storageReleaseInterface.StorageReleased(common.Address(address), released)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	s.released = map[atree.Address]uint64{}
+
+	storageUsageDeltaInterface, ok := ledger.(StorageUsageDeltaInterface)
+	if ok && len(s.deltas) > 0 {
+
+		addresses := make(map[atree.Address]struct{}, len(s.deltas))
+		for address, delta := range s.deltas { //nolint:maprangecheck
+			if delta != 0 {
+				addresses[address] = struct{}{}
+			}
+		}
+
+		for _, address := range sortedAddresses(addresses) {
+			delta := s.deltas[address]
+
+			wrapPanic(func() {
+				err = storageUsageDeltaInterface.StorageUsageChanged(common.Address(address), delta)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	s.deltas = map[atree.Address]int64{}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// storedSizeEstimationAddress is the address of the account
+// the value is stored in when its stored size is estimated.
+// The address is arbitrary, as the value is only stored in a temporary ledger.
+//
+var storedSizeEstimationAddress = common.Address{0x1}
+
+// EstimateStoredSize returns the estimated number of bytes the given value
+// would use in account storage, i.e. the size of the value as it is encoded
+// by the interpreter, including the registers of all slabs the value is split into.
+//
+// The value is imported and stored in a temporary in-memory ledger,
+// so the ledger of the given context's interface is never written.
+// The interface is only used to load the programs declaring the types of composite values.
+//
+func (r *interpreterRuntime) EstimateStoredSize(value cadence.Value, context Context) (uint64, error) {
+	context.InitializeCodesAndPrograms()
+
+	ledger := newStoredSizeLedger()
+	storage := NewStorage(ledger)
+
+	var size uint64
+
+	_, _, err := r.interpret(
+		nil,
+		context,
+		storage,
+		nil,
+		nil,
+		nil,
+		nil,
+		func(inter *interpreter.Interpreter) (interpreter.Value, error) {
+			// Import the value with its type, if any,
+			// e.g. so that empty arrays can be imported
+
+			var expectedType sema.Type
+			if value.Type() != nil {
+				var err error
+				expectedType, err = inter.ConvertStaticToSemaType(ImportType(value.Type()))
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			importedValue, err := importValue(inter, value, expectedType)
+			if err != nil {
+				return nil, err
+			}
+
+			size, err = storedSize(inter, storage, ledger, importedValue)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return 0, newError(err, context)
+	}
+
+	return size, nil
+}
+
+// storedSize stores the given value in the given storage,
+// and returns the size of the storable of the value, as it would be stored inline in a storage map,
+// plus the size of all registers written for the slabs of the value.
+//
+func storedSize(
+	inter *interpreter.Interpreter,
+	storage *Storage,
+	ledger *storedSizeLedger,
+	value interpreter.Value,
+) (uint64, error) {
+
+	address := atree.Address(storedSizeEstimationAddress)
+
+	value = value.Transfer(
+		inter,
+		interpreter.ReturnEmptyLocationRange,
+		address,
+		true,
+		nil,
+	)
+
+	storable, err := value.Storable(storage, address, atree.MaxInlineMapKeyOrValueSize)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := interpreter.StorableSize(storable)
+	if err != nil {
+		return 0, err
+	}
+
+	err = storage.PersistentSlabStorage.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(size) + ledger.usedBytes(storedSizeEstimationAddress), nil
+}
+
+// storedSizeLedger is the temporary in-memory ledger used for the estimation of stored sizes.
+//
+type storedSizeLedger struct {
+	values         map[string][]byte
+	storageIndices map[string]atree.StorageIndex
+}
+
+var _ atree.Ledger = &storedSizeLedger{}
+
+func newStoredSizeLedger() *storedSizeLedger {
+	return &storedSizeLedger{
+		values:         map[string][]byte{},
+		storageIndices: map[string]atree.StorageIndex{},
+	}
+}
+
+func storedSizeLedgerKey(owner, key []byte) string {
+	return string(owner) + "|" + string(key)
+}
+
+func (l *storedSizeLedger) GetValue(owner, key []byte) ([]byte, error) {
+	return l.values[storedSizeLedgerKey(owner, key)], nil
+}
+
+func (l *storedSizeLedger) SetValue(owner, key, value []byte) error {
+	l.values[storedSizeLedgerKey(owner, key)] = value
+	return nil
+}
+
+func (l *storedSizeLedger) ValueExists(owner, key []byte) (bool, error) {
+	return len(l.values[storedSizeLedgerKey(owner, key)]) > 0, nil
+}
+
+func (l *storedSizeLedger) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	index := l.storageIndices[string(owner)].Next()
+	l.storageIndices[string(owner)] = index
+	return index, nil
+}
+
+// usedBytes returns the number of bytes used by the registers of the given account,
+// i.e. the sum of the lengths of their keys and values.
+//
+func (l *storedSizeLedger) usedBytes(address common.Address) uint64 {
+	prefix := string(address[:]) + "|"
+
+	var size uint64
+
+	// NOTE: ranging over the map is safe (deterministic),
+	// as the sum is independent of the iteration order
+
+	for key, value := range l.values { //nolint:maprangecheck
+		if len(key) < len(prefix) || key[:len(prefix)] != prefix {
+			continue
+		}
+
+		size += uint64(registerSize(len(key)-len(prefix), len(value)))
+	}
+
+	return size
+}
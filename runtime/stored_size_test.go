@@ -0,0 +1,171 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeEstimateStoredSize(t *testing.T) {
+
+	t.Parallel()
+
+	estimate := func(t *testing.T, runtimeInterface *testRuntimeInterface, value cadence.Value) uint64 {
+		runtime := newTestInterpreterRuntime()
+
+		size, err := runtime.EstimateStoredSize(
+			value,
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		return size
+	}
+
+	newInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(
+				func(_, _, _ []byte) {
+					assert.FailNow(t, "unexpected read")
+				},
+				func(_, _, _ []byte) {
+					assert.FailNow(t, "unexpected write")
+				},
+			),
+		}
+	}
+
+	t.Run("small values", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := newInterface()
+
+		assert.Equal(t, uint64(1), estimate(t, runtimeInterface, cadence.NewBool(true)))
+		assert.Equal(t, uint64(5), estimate(t, runtimeInterface, cadence.NewInt(42)))
+		assert.Equal(t, uint64(8), estimate(t, runtimeInterface, cadence.String("hello")))
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := newInterface()
+
+		newArray := func(count int) cadence.Array {
+			values := make([]cadence.Value, count)
+			for i := 0; i < count; i++ {
+				values[i] = cadence.NewUInt64(uint64(i))
+			}
+			return cadence.NewArray(values).
+				WithType(cadence.VariableSizedArrayType{
+					ElementType: cadence.UInt64Type{},
+				})
+		}
+
+		emptySize := estimate(t, runtimeInterface, newArray(0))
+		smallSize := estimate(t, runtimeInterface, newArray(10))
+		largeSize := estimate(t, runtimeInterface, newArray(1000))
+
+		assert.Equal(t, uint64(42), emptySize)
+		assert.Equal(t, uint64(72), smallSize)
+		// The large array is split into several slabs
+		assert.Equal(t, uint64(5031), largeSize)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		runtimeInterface := newInterface()
+		runtimeInterface.getAccountContractCode = func(_ Address, _ string) ([]byte, error) {
+			return []byte(`
+              pub contract C {
+
+                  pub struct S {
+                      pub let value: Int
+
+                      init(value: Int) {
+                          self.value = value
+                      }
+                  }
+              }
+            `), nil
+		}
+
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewInt(42),
+		}).WithType(&cadence.StructType{
+			Location: common.AddressLocation{
+				Address: address,
+				Name:    "C",
+			},
+			QualifiedIdentifier: "C.S",
+			Fields: []cadence.Field{
+				{
+					Identifier: "value",
+					Type:       cadence.IntType{},
+				},
+			},
+		})
+
+		assert.Equal(t, uint64(98), estimate(t, runtimeInterface, value))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface := newInterface()
+		runtimeInterface.getAccountContractCode = func(_ Address, _ string) ([]byte, error) {
+			return nil, nil
+		}
+
+		value := cadence.NewStruct([]cadence.Value{}).
+			WithType(&cadence.StructType{
+				Location: common.AddressLocation{
+					Address: common.MustBytesToAddress([]byte{0x1}),
+					Name:    "C",
+				},
+				QualifiedIdentifier: "C.S",
+			})
+
+		_, err := runtime.EstimateStoredSize(
+			value,
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,289 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// TestBackend is the blockchain which is simulated for tests run by a TestRunner.
+//
+// Tests interact with it through the functions of the built-in Test contract.
+//
+type TestBackend interface {
+	// CreateAccount creates a new account
+	CreateAccount() (Address, error)
+	// DeployContract deploys the contract with the given name and code to the given account,
+	// passing the given arguments to the contract's initializer
+	DeployContract(account Address, name string, code []byte, arguments []cadence.Value) error
+	// ExecuteTransaction executes the given transaction, signed by the given accounts
+	ExecuteTransaction(code []byte, signers []Address, arguments []cadence.Value) error
+	// ExecuteScript executes the given script and returns its result
+	ExecuteScript(code []byte, arguments []cadence.Value) (cadence.Value, error)
+}
+
+// TestFunctionPrefix is the prefix of the names of test functions
+//
+const TestFunctionPrefix = "test"
+
+// TestSetupFunctionName is the name of the optional function
+// which is invoked once, before all test functions
+//
+const TestSetupFunctionName = "setup"
+
+// TestResult is the result of running a test function
+//
+type TestResult struct {
+	Name string
+	// Error is the error which failed the test, or nil if the test succeeded
+	Error error
+}
+
+func (r TestResult) Succeeded() bool {
+	return r.Error == nil
+}
+
+// TestRunner runs tests written in Cadence.
+//
+// Tests are scripts which import the built-in Test contract,
+// and declare test functions: top-level functions with a name starting with TestFunctionPrefix,
+// which have no parameters and no return value.
+//
+type TestRunner struct {
+	runtime *interpreterRuntime
+	backend TestBackend
+}
+
+// NewTestRunner returns a new test runner which simulates the blockchain using the given backend.
+//
+func NewTestRunner(backend TestBackend, options ...Option) *TestRunner {
+	runtime := &interpreterRuntime{
+		testContractEnabled: true,
+	}
+	for _, option := range options {
+		option(runtime)
+	}
+
+	return &TestRunner{
+		runtime: runtime,
+		backend: backend,
+	}
+}
+
+// TestFunctionNames returns the names of the test functions declared in the given checked program,
+// in declaration order.
+//
+func TestFunctionNames(program *interpreter.Program) []string {
+	var names []string
+
+	for _, function := range program.Elaboration.DeclaredGlobalFunctions() {
+		if !strings.HasPrefix(function.Identifier, TestFunctionPrefix) {
+			continue
+		}
+
+		functionType := function.Type
+		if len(functionType.Parameters) > 0 ||
+			functionType.ReturnTypeAnnotation.Type != sema.VoidType {
+
+			continue
+		}
+
+		names = append(names, function.Identifier)
+	}
+
+	return names
+}
+
+// RunTests runs all test functions of the given test script, in declaration order.
+//
+// The context is the environment of the test script itself,
+// e.g. its location, and how its imports are resolved and its logs are reported.
+//
+// A failing test does not prevent the remaining tests from being run.
+// An error is only returned if the script is invalid, or if the setup function fails.
+//
+func (r *TestRunner) RunTests(source []byte, context Context) ([]TestResult, error) {
+	context.InitializeCodesAndPrograms()
+
+	storage := NewStorage(context.Interface)
+
+	var checkerOptions []sema.Option
+	var interpreterOptions []interpreter.Option
+
+	functions := r.runtime.standardLibraryFunctions(
+		context,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+	functions = append(
+		functions,
+		stdlib.NewTestContractFunctions(testFramework{backend: r.backend})...,
+	)
+
+	program, err := r.runtime.parseAndCheckProgram(
+		source,
+		context,
+		functions,
+		stdlib.BuiltinValues(),
+		checkerOptions,
+		true,
+		importResolutionResults{},
+	)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	names := TestFunctionNames(program)
+	results := make([]TestResult, 0, len(names))
+
+	_, hasSetup := program.Elaboration.GlobalValues.Get(TestSetupFunctionName)
+
+	_, _, err = r.runtime.interpret(
+		program,
+		context,
+		storage,
+		functions,
+		stdlib.BuiltinValues(),
+		interpreterOptions,
+		checkerOptions,
+		func(inter *interpreter.Interpreter) (interpreter.Value, error) {
+			if hasSetup {
+				_, err := inter.Invoke(TestSetupFunctionName)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for _, name := range names {
+				result := TestResult{
+					Name: name,
+				}
+
+				_, err := inter.Invoke(name)
+				if err != nil {
+					result.Error = newError(err, context)
+				}
+
+				results = append(results, result)
+			}
+
+			return nil, nil
+		},
+	)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	return results, nil
+}
+
+// testFramework implements the simulated blockchain of the Test contract using a TestBackend,
+// converting between the values of the test and the values of the backend
+//
+type testFramework struct {
+	backend TestBackend
+}
+
+var _ stdlib.TestFramework = testFramework{}
+
+func (f testFramework) CreateAccount() (address common.Address, err error) {
+	wrapPanic(func() {
+		address, err = f.backend.CreateAccount()
+	})
+	return
+}
+
+func (f testFramework) DeployContract(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account common.Address,
+	arguments []interpreter.Value,
+) (err error) {
+	exportedArguments, err := exportTestArguments(inter, arguments)
+	if err != nil {
+		return err
+	}
+
+	wrapPanic(func() {
+		err = f.backend.DeployContract(account, name, []byte(code), exportedArguments)
+	})
+	return
+}
+
+func (f testFramework) ExecuteTransaction(
+	inter *interpreter.Interpreter,
+	code string,
+	signers []common.Address,
+	arguments []interpreter.Value,
+) (err error) {
+	exportedArguments, err := exportTestArguments(inter, arguments)
+	if err != nil {
+		return err
+	}
+
+	wrapPanic(func() {
+		err = f.backend.ExecuteTransaction([]byte(code), signers, exportedArguments)
+	})
+	return
+}
+
+func (f testFramework) ExecuteScript(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+) (interpreter.Value, error) {
+	exportedArguments, err := exportTestArguments(inter, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	var result cadence.Value
+	wrapPanic(func() {
+		result, err = f.backend.ExecuteScript([]byte(code), exportedArguments)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return importValue(inter, result, sema.AnyStructType)
+}
+
+func exportTestArguments(inter *interpreter.Interpreter, arguments []interpreter.Value) ([]cadence.Value, error) {
+	exportedArguments := make([]cadence.Value, len(arguments))
+	for i, argument := range arguments {
+		exportedArgument, err := ExportValue(argument, inter)
+		if err != nil {
+			return nil, err
+		}
+		exportedArguments[i] = exportedArgument
+	}
+	return exportedArguments, nil
+}
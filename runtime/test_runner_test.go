@@ -0,0 +1,256 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+type testTestBackend struct {
+	accounts     []Address
+	deployments  []string
+	transactions [][]Address
+	arguments    [][]cadence.Value
+}
+
+var _ TestBackend = &testTestBackend{}
+
+func (b *testTestBackend) CreateAccount() (Address, error) {
+	address := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, byte(len(b.accounts) + 1)}
+	b.accounts = append(b.accounts, address)
+	return address, nil
+}
+
+func (b *testTestBackend) DeployContract(account Address, name string, _ []byte, arguments []cadence.Value) error {
+	b.deployments = append(b.deployments, account.ShortHexWithPrefix()+"."+name)
+	b.arguments = append(b.arguments, arguments)
+	return nil
+}
+
+func (b *testTestBackend) ExecuteTransaction(code []byte, signers []Address, arguments []cadence.Value) error {
+	if string(code) == "fail" {
+		return errors.New("transaction failed")
+	}
+	b.transactions = append(b.transactions, signers)
+	b.arguments = append(b.arguments, arguments)
+	return nil
+}
+
+func (b *testTestBackend) ExecuteScript(code []byte, arguments []cadence.Value) (cadence.Value, error) {
+	if string(code) == "fail" {
+		return nil, errors.New("script failed")
+	}
+	b.arguments = append(b.arguments, arguments)
+	return cadence.NewInt(42), nil
+}
+
+func TestRuntimeTestRunner(t *testing.T) {
+
+	t.Parallel()
+
+	runTests := func(t *testing.T, backend TestBackend, code string) ([]TestResult, error) {
+		runner := NewTestRunner(backend)
+
+		return runner.RunTests(
+			[]byte(code),
+			Context{
+				Interface: &testRuntimeInterface{},
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	t.Run("discovery and assertions", func(t *testing.T) {
+
+		t.Parallel()
+
+		results, err := runTests(t, &testTestBackend{}, `
+          import Test
+
+          pub fun testAssert() {
+              Test.assert(1 < 2, message: "less")
+          }
+
+          pub fun testAssertFailure() {
+              Test.assert(2 < 1, message: "not less")
+          }
+
+          pub fun testAssertEqualFailure() {
+              Test.assertEqual([1, 2], [1, 3])
+          }
+
+          pub fun testFail() {
+              Test.fail("failed")
+          }
+
+          pub fun helper() {
+              Test.fail("not a test")
+          }
+
+          pub fun testWithParameter(_ x: Int) {
+              Test.fail("not a test")
+          }
+        `)
+		require.NoError(t, err)
+
+		require.Len(t, results, 4)
+
+		assert.Equal(t, "testAssert", results[0].Name)
+		assert.True(t, results[0].Succeeded())
+
+		assert.Equal(t, "testAssertFailure", results[1].Name)
+		require.Error(t, results[1].Error)
+		assert.Contains(t, results[1].Error.Error(), "assertion failed: not less")
+
+		assert.Equal(t, "testAssertEqualFailure", results[2].Name)
+		require.Error(t, results[2].Error)
+		assert.Contains(t,
+			results[2].Error.Error(),
+			"assertion failed: not equal: expected: [1, 2], actual: [1, 3]",
+		)
+
+		assert.Equal(t, "testFail", results[3].Name)
+		require.Error(t, results[3].Error)
+		assert.Contains(t, results[3].Error.Error(), "assertion failed: failed")
+	})
+
+	t.Run("backend", func(t *testing.T) {
+
+		t.Parallel()
+
+		backend := &testTestBackend{}
+
+		results, err := runTests(t, backend, `
+          import Test
+
+          pub var account: Test.Account? = nil
+
+          pub fun setup() {
+              let created = Test.createAccount()
+              let error = Test.deployContract(
+                  name: "C",
+                  code: "pub contract C {}",
+                  account: created,
+                  arguments: ["x"]
+              )
+              Test.assert(error == nil, message: "deployment failed")
+              account = created
+          }
+
+          pub fun testTransaction() {
+              let result = Test.executeTransaction(
+                  "transaction {}",
+                  signers: [account!, Test.createAccount()],
+                  arguments: [1, true]
+              )
+              Test.assert(result.succeeded(), message: "transaction failed")
+
+              let failure = Test.executeTransaction("fail", signers: [], arguments: [])
+              Test.assertEqual("transaction failed", failure.error!)
+          }
+
+          pub fun testScript() {
+              let result = Test.executeScript("script", arguments: [/public/x])
+              Test.assert(result.succeeded(), message: "script failed")
+              Test.assertEqual(42, result.returnValue!)
+
+              let failure = Test.executeScript("fail", arguments: [])
+              Test.assertEqual(nil, failure.returnValue)
+              Test.assertEqual("script failed", failure.error!)
+          }
+        `)
+		require.NoError(t, err)
+
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.NoError(t, result.Error, result.Name)
+		}
+
+		assert.Equal(t, []string{"0x1.C"}, backend.deployments)
+		assert.Equal(t,
+			[][]Address{
+				{
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1},
+					{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2},
+				},
+			},
+			backend.transactions,
+		)
+		assert.Equal(t,
+			[][]cadence.Value{
+				{cadence.String("x")},
+				{cadence.NewInt(1), cadence.NewBool(true)},
+				{cadence.Path{Domain: "public", Identifier: "x"}},
+			},
+			backend.arguments,
+		)
+	})
+
+	t.Run("failing setup", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := runTests(t, &testTestBackend{}, `
+          import Test
+
+          pub fun setup() {
+              Test.fail("setup failed")
+          }
+
+          pub fun testNothing() {}
+        `)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "assertion failed: setup failed")
+	})
+
+	t.Run("not available outside of tests", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  import Test
+
+                  pub fun main() {
+                      Test.fail("not a test")
+                  }
+                `),
+			},
+			Context{
+				Interface: &testRuntimeInterface{
+					getCode: func(_ Location) ([]byte, error) {
+						return nil, nil
+					},
+				},
+				Location: common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+	})
+}
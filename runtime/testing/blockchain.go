@@ -0,0 +1,148 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Blockchain is an in-memory blockchain, which executes transactions and scripts
+// using a runtime and an in-memory interface.
+//
+// It can be used as the backend of a runtime.TestRunner.
+//
+type Blockchain struct {
+	Interface *InMemoryInterface
+	runtime   runtime.Runtime
+}
+
+var _ runtime.TestBackend = &Blockchain{}
+
+// NewBlockchain returns a new in-memory blockchain without any accounts,
+// which executes transactions and scripts using a runtime with the given options.
+//
+func NewBlockchain(options ...runtime.Option) *Blockchain {
+	return &Blockchain{
+		Interface: NewInMemoryInterface(),
+		runtime:   runtime.NewInterpreterRuntime(options...),
+	}
+}
+
+func (b *Blockchain) CreateAccount() (runtime.Address, error) {
+	return b.Interface.CreateAccount(common.Address{})
+}
+
+// DeployContract deploys the contract with the given name and code to the given account,
+// using a transaction signed by the account.
+//
+// The types of the arguments must be expressible in the transaction,
+// e.g. arguments may not be values of composite types.
+//
+func (b *Blockchain) DeployContract(
+	account runtime.Address,
+	name string,
+	code []byte,
+	arguments []cadence.Value,
+) error {
+	parameters := make([]string, len(arguments))
+	argumentNames := make([]string, len(arguments))
+
+	for i, argument := range arguments {
+		argumentName := fmt.Sprintf("arg%d", i)
+		argumentNames[i] = ", " + argumentName
+		parameters[i] = fmt.Sprintf("%s: %s", argumentName, argument.Type().ID())
+	}
+
+	transaction := fmt.Sprintf(
+		`
+          transaction(%s) {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.add(name: "%s", code: "%s".decodeHex()%s)
+              }
+          }
+        `,
+		strings.Join(parameters, ", "),
+		name,
+		hex.EncodeToString(code),
+		strings.Join(argumentNames, ""),
+	)
+
+	return b.ExecuteTransaction([]byte(transaction), []runtime.Address{account}, arguments)
+}
+
+func (b *Blockchain) ExecuteTransaction(
+	code []byte,
+	signers []runtime.Address,
+	arguments []cadence.Value,
+) error {
+	encodedArguments, err := encodeArguments(arguments)
+	if err != nil {
+		return err
+	}
+
+	b.Interface.SetSigningAccounts(signers...)
+
+	return b.runtime.ExecuteTransaction(
+		runtime.Script{
+			Source:    code,
+			Arguments: encodedArguments,
+		},
+		runtime.Context{
+			Interface: b.Interface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+}
+
+func (b *Blockchain) ExecuteScript(code []byte, arguments []cadence.Value) (cadence.Value, error) {
+	encodedArguments, err := encodeArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.runtime.ExecuteScript(
+		runtime.Script{
+			Source:    code,
+			Arguments: encodedArguments,
+		},
+		runtime.Context{
+			Interface: b.Interface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+}
+
+func encodeArguments(arguments []cadence.Value) ([][]byte, error) {
+	encodedArguments := make([][]byte, len(arguments))
+	for i, argument := range arguments {
+		encodedArgument, err := jsoncdc.Encode(argument)
+		if err != nil {
+			return nil, err
+		}
+		encodedArguments[i] = encodedArgument
+	}
+	return encodedArguments, nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	runtimetesting "github.com/onflow/cadence/runtime/testing"
+)
+
+func TestBlockchainTestRunner(t *testing.T) {
+
+	t.Parallel()
+
+	runner := runtime.NewTestRunner(runtimetesting.NewBlockchain())
+
+	const tests = `
+      import Test
+
+      pub let counterCode = "pub contract Counter { pub var count: Int; init(count: Int) { self.count = count }; pub fun increment() { self.count = self.count + 1 } }"
+
+      pub var account: Test.Account? = nil
+
+      pub fun setup() {
+          let created = Test.createAccount()
+          let error = Test.deployContract(
+              name: "Counter",
+              code: counterCode,
+              account: created,
+              arguments: [41]
+          )
+          Test.assertEqual(nil, error)
+          account = created
+      }
+
+      pub fun getCount(): Int {
+          let address = account!.address
+          let scriptResult = Test.executeScript(
+              "import Counter from ".concat(address.toString()).concat(" pub fun main(): Int { return Counter.count }"),
+              arguments: []
+          )
+          Test.assert(scriptResult.succeeded(), message: scriptResult.error ?? "")
+          return scriptResult.returnValue! as! Int
+      }
+
+      pub fun testIncrement() {
+          let address = account!.address
+          let result = Test.executeTransaction(
+              "import Counter from ".concat(address.toString()).concat(" transaction { prepare(signer: AuthAccount) { Counter.increment() } }"),
+              signers: [account!],
+              arguments: []
+          )
+          Test.assert(result.succeeded(), message: result.error ?? "")
+          Test.assertEqual(42, getCount())
+      }
+
+      pub fun testFailingTransaction() {
+          let result = Test.executeTransaction(
+              "transaction { prepare(signer: AuthAccount) { panic(\"no\") } }",
+              signers: [account!],
+              arguments: []
+          )
+          Test.assert(!result.succeeded(), message: "transaction succeeded")
+      }
+
+      pub fun testWrongCount() {
+          Test.assertEqual(0, getCount())
+      }
+    `
+
+	results, err := runner.RunTests(
+		[]byte(tests),
+		runtime.Context{
+			Interface: runtimetesting.NewInMemoryInterface(),
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "testIncrement", results[0].Name)
+	assert.NoError(t, results[0].Error)
+
+	assert.Equal(t, "testFailingTransaction", results[1].Name)
+	assert.NoError(t, results[1].Error)
+
+	assert.Equal(t, "testWrongCount", results[2].Name)
+	require.Error(t, results[2].Error)
+	assert.Contains(t, results[2].Error.Error(), "not equal: expected: 0, actual: 42")
+}
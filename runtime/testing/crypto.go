@@ -0,0 +1,120 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence/runtime"
+)
+
+// proofOfPossessionTag is the tag of fake proofs of possession
+//
+const proofOfPossessionTag = "PROOF-OF-POSSESSION"
+
+// FakeSignature returns the fake signature of the given tag and data, for the given public key.
+//
+// The fake verifier of InMemoryInterface accepts a signature if and only if
+// it is the fake signature of the signed tag and data, for the given public key.
+// The signature and hash algorithms are ignored.
+//
+// Fake signatures provide no security at all: they can be produced without the private key.
+//
+func FakeSignature(tag string, signedData []byte, publicKey []byte) []byte {
+	hasher := sha3.New256()
+	_, _ = hasher.Write(publicKey)
+	_, _ = hasher.Write([]byte(tag))
+	_, _ = hasher.Write(signedData)
+	return hasher.Sum(nil)
+}
+
+// FakeProofOfPossession returns the fake proof of possession for the given BLS public key.
+//
+func FakeProofOfPossession(publicKey []byte) []byte {
+	return FakeSignature(proofOfPossessionTag, publicKey, publicKey)
+}
+
+func (i *InMemoryInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	_ runtime.SignatureAlgorithm,
+	_ runtime.HashAlgorithm,
+) (bool, error) {
+	return bytes.Equal(signature, FakeSignature(tag, signedData, publicKey)), nil
+}
+
+// ValidatePublicKey considers all non-empty public keys valid.
+//
+func (i *InMemoryInterface) ValidatePublicKey(key *runtime.PublicKey) (bool, error) {
+	return len(key.PublicKey) > 0, nil
+}
+
+func (i *InMemoryInterface) BLSVerifyPOP(publicKey *runtime.PublicKey, proof []byte) (bool, error) {
+	return bytes.Equal(proof, FakeProofOfPossession(publicKey.PublicKey)), nil
+}
+
+func (i *InMemoryInterface) AggregateBLSSignatures(_ [][]byte) ([]byte, error) {
+	return nil, fmt.Errorf("aggregation of BLS signatures is not supported")
+}
+
+func (i *InMemoryInterface) AggregateBLSPublicKeys(_ []*runtime.PublicKey) (*runtime.PublicKey, error) {
+	return nil, fmt.Errorf("aggregation of BLS public keys is not supported")
+}
+
+// Hash hashes the given data using the given hash algorithm.
+// If a tag is given, it is prepended to the data.
+//
+// KMAC128 is not supported.
+//
+func (i *InMemoryInterface) Hash(data []byte, tag string, hashAlgorithm runtime.HashAlgorithm) ([]byte, error) {
+	data = append([]byte(tag), data...)
+
+	switch hashAlgorithm {
+	case runtime.HashAlgorithmSHA2_256:
+		digest := sha256.Sum256(data)
+		return digest[:], nil
+
+	case runtime.HashAlgorithmSHA2_384:
+		digest := sha512.Sum384(data)
+		return digest[:], nil
+
+	case runtime.HashAlgorithmSHA3_256:
+		digest := sha3.Sum256(data)
+		return digest[:], nil
+
+	case runtime.HashAlgorithmSHA3_384:
+		digest := sha3.Sum384(data)
+		return digest[:], nil
+
+	case runtime.HashAlgorithmKECCAK_256:
+		hasher := sha3.NewLegacyKeccak256()
+		_, _ = hasher.Write(data)
+		return hasher.Sum(nil), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", hashAlgorithm.Name())
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// EventFilter reports if an event should be included
+//
+type EventFilter func(event cadence.Event) bool
+
+// EventTypeFilter returns an event filter which includes events of the given type,
+// e.g. `A.0000000000000001.Auction.BidPlaced`, or `flow.AccountCreated`.
+//
+func EventTypeFilter(typeID string) EventFilter {
+	return func(event cadence.Event) bool {
+		return event.EventType.ID() == typeID
+	}
+}
+
+// EventAddressFilter returns an event filter which includes events
+// which are declared in contracts deployed to the given account.
+//
+func EventAddressFilter(address common.Address) EventFilter {
+	return func(event cadence.Event) bool {
+		location, ok := event.EventType.Location.(common.AddressLocation)
+		return ok && location.Address == address
+	}
+}
+
+func matchesEventFilters(event cadence.Event, filters []EventFilter) bool {
+	for _, filter := range filters {
+		if !filter(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterEvents returns the given events which are included by all given filters,
+// in the order they were emitted.
+//
+func FilterEvents(events []cadence.Event, filters ...EventFilter) []cadence.Event {
+	var result []cadence.Event
+	for _, event := range events {
+		if matchesEventFilters(event, filters) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// EventTypeIDs returns the type IDs of the given events, in order.
+//
+// It can be used to assert that exactly the expected events were emitted, in the expected order.
+//
+func EventTypeIDs(events []cadence.Event) []string {
+	typeIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		typeIDs = append(typeIDs, event.EventType.ID())
+	}
+	return typeIDs
+}
+
+type eventSubscription struct {
+	filters []EventFilter
+	handler func(event cadence.Event)
+}
+
+// EventsMatching returns the emitted events which are included by all given filters,
+// in the order they were emitted.
+//
+func (i *InMemoryInterface) EventsMatching(filters ...EventFilter) []cadence.Event {
+	return FilterEvents(i.events, filters...)
+}
+
+// SubscribeEvents calls the given handler for each subsequently emitted event
+// which is included by all given filters.
+//
+// Handlers are called in the order they were subscribed.
+// The returned function cancels the subscription.
+//
+func (i *InMemoryInterface) SubscribeEvents(handler func(event cadence.Event), filters ...EventFilter) (unsubscribe func()) {
+	subscription := &eventSubscription{
+		filters: filters,
+		handler: handler,
+	}
+
+	i.eventSubscriptions = append(i.eventSubscriptions, subscription)
+
+	return func() {
+		for index, other := range i.eventSubscriptions {
+			if other == subscription {
+				i.eventSubscriptions = append(
+					i.eventSubscriptions[:index:index],
+					i.eventSubscriptions[index+1:]...,
+				)
+				return
+			}
+		}
+	}
+}
+
+func (i *InMemoryInterface) notifyEventSubscriptions(event cadence.Event) {
+	for _, subscription := range i.eventSubscriptions {
+		if matchesEventFilters(event, subscription.filters) {
+			subscription.handler(event)
+		}
+	}
+}
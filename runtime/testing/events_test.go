@@ -0,0 +1,148 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	runtimetesting "github.com/onflow/cadence/runtime/testing"
+)
+
+func newTestEvent(location common.Location, qualifiedIdentifier string, fields ...cadence.Value) cadence.Event {
+	return cadence.NewEvent(fields).
+		WithType(&cadence.EventType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		})
+}
+
+func TestInMemoryInterfaceEvents(t *testing.T) {
+
+	t.Parallel()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	bidPlaced := newTestEvent(
+		common.AddressLocation{Address: address1, Name: "Auction"},
+		"Auction.BidPlaced",
+		cadence.NewInt(1),
+	)
+	auctionEnded := newTestEvent(
+		common.AddressLocation{Address: address1, Name: "Auction"},
+		"Auction.Ended",
+	)
+	deposited := newTestEvent(
+		common.AddressLocation{Address: address2, Name: "Token"},
+		"Token.Deposited",
+	)
+	accountCreated := newTestEvent(nil, "flow.AccountCreated")
+
+	t.Run("filter", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+		for _, event := range []cadence.Event{accountCreated, bidPlaced, deposited, auctionEnded} {
+			err := runtimeInterface.EmitEvent(event)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t,
+			[]string{
+				"A.0000000000000001.Auction.BidPlaced",
+				"A.0000000000000001.Auction.Ended",
+			},
+			runtimetesting.EventTypeIDs(
+				runtimeInterface.EventsMatching(
+					runtimetesting.EventAddressFilter(address1),
+				),
+			),
+		)
+
+		assert.Equal(t,
+			[]cadence.Event{bidPlaced},
+			runtimeInterface.EventsMatching(
+				runtimetesting.EventAddressFilter(address1),
+				runtimetesting.EventTypeFilter("A.0000000000000001.Auction.BidPlaced"),
+			),
+		)
+
+		assert.Equal(t,
+			[]cadence.Event{accountCreated},
+			runtimeInterface.EventsMatching(
+				runtimetesting.EventTypeFilter("flow.AccountCreated"),
+			),
+		)
+
+		assert.Empty(t,
+			runtimeInterface.EventsMatching(
+				runtimetesting.EventAddressFilter(address2),
+				runtimetesting.EventTypeFilter("flow.AccountCreated"),
+			),
+		)
+
+		assert.Equal(t,
+			runtimeInterface.Events(),
+			runtimeInterface.EventsMatching(),
+		)
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+		var all, auction []cadence.Event
+
+		unsubscribeAll := runtimeInterface.SubscribeEvents(
+			func(event cadence.Event) {
+				all = append(all, event)
+			},
+		)
+
+		runtimeInterface.SubscribeEvents(
+			func(event cadence.Event) {
+				auction = append(auction, event)
+			},
+			runtimetesting.EventAddressFilter(address1),
+		)
+
+		err := runtimeInterface.EmitEvent(bidPlaced)
+		require.NoError(t, err)
+
+		err = runtimeInterface.EmitEvent(deposited)
+		require.NoError(t, err)
+
+		unsubscribeAll()
+
+		err = runtimeInterface.EmitEvent(auctionEnded)
+		require.NoError(t, err)
+
+		assert.Equal(t, []cadence.Event{bidPlaced, deposited}, all)
+		assert.Equal(t, []cadence.Event{bidPlaced, auctionEnded}, auction)
+	})
+}
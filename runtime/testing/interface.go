@@ -0,0 +1,699 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package testing provides a complete, in-memory implementation of runtime.Interface,
+// which can be used to execute scripts and transactions in tests and examples.
+//
+// The implementation keeps all state in memory: the storage ledger, accounts,
+// account keys, contract code, programs, logs, events, and blocks.
+// Signatures are verified by a fake verifier, see FakeSignature.
+package testing
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onflow/atree"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// DefaultStorageCapacity is the storage capacity of each account, in bytes
+//
+const DefaultStorageCapacity = 100_000_000
+
+// GenesisBlockTimestamp is the timestamp of the block at height 0, in nanoseconds since the Unix epoch
+//
+var GenesisBlockTimestamp = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+
+// DefaultBlockInterval is the default duration between two blocks produced by AdvanceBlocks
+//
+const DefaultBlockInterval = time.Second
+
+type account struct {
+	keys        []*runtime.AccountKey
+	encodedKeys [][]byte
+	contracts   map[string][]byte
+	balance     uint64
+}
+
+// InMemoryInterface is an in-memory implementation of runtime.Interface.
+//
+// It is not safe for concurrent use.
+//
+type InMemoryInterface struct {
+	storedValues       map[string][]byte
+	storageIndices     map[string]uint64
+	accounts           map[common.Address]*account
+	addressCounter     uint64
+	codes              map[common.LocationID][]byte
+	programs           map[common.LocationID]*interpreter.Program
+	signingAccounts    []common.Address
+	logs               []string
+	events             []cadence.Event
+	eventSubscriptions []*eventSubscription
+	uuid               uint64
+	computationLimit   uint64
+	computationUsed    uint64
+	blocks             []runtime.Block
+	blockInterval      time.Duration
+	random             *rand.Rand
+	storageCapacity    uint64
+}
+
+var _ runtime.Interface = &InMemoryInterface{}
+
+// NewInMemoryInterface returns a new in-memory interface without any accounts.
+//
+// The current block is the block at height 0, with timestamp GenesisBlockTimestamp.
+//
+func NewInMemoryInterface() *InMemoryInterface {
+	return &InMemoryInterface{
+		storedValues:    map[string][]byte{},
+		storageIndices:  map[string]uint64{},
+		accounts:        map[common.Address]*account{},
+		codes:           map[common.LocationID][]byte{},
+		programs:        map[common.LocationID]*interpreter.Program{},
+		random:          rand.New(rand.NewSource(0)),
+		storageCapacity: DefaultStorageCapacity,
+		blocks:          []runtime.Block{NewBlock(0, GenesisBlockTimestamp)},
+		blockInterval:   DefaultBlockInterval,
+	}
+}
+
+// Configuration and inspection
+
+// SetSigningAccounts sets the accounts which sign subsequently executed transactions.
+//
+func (i *InMemoryInterface) SetSigningAccounts(addresses ...common.Address) {
+	i.signingAccounts = addresses
+}
+
+// SetCode sets the code for the given location, e.g. for a file location which is imported.
+//
+// The code of contracts deployed to accounts is available without setting it.
+//
+func (i *InMemoryInterface) SetCode(location common.Location, code []byte) {
+	i.codes[location.ID()] = code
+}
+
+// SetComputationLimit sets the computation limit. A limit of 0 means there is no limit.
+//
+func (i *InMemoryInterface) SetComputationLimit(limit uint64) {
+	i.computationLimit = limit
+}
+
+// ComputationUsed returns the computation used, as last reported by the runtime.
+//
+func (i *InMemoryInterface) ComputationUsed() uint64 {
+	return i.computationUsed
+}
+
+// SetAccountBalance sets the balance of the given account.
+//
+func (i *InMemoryInterface) SetAccountBalance(address common.Address, balance uint64) error {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	account.balance = balance
+	return nil
+}
+
+// SetStorageCapacity sets the storage capacity of all accounts, in bytes.
+//
+func (i *InMemoryInterface) SetStorageCapacity(capacity uint64) {
+	i.storageCapacity = capacity
+}
+
+// Logs returns all logged messages, in the order they were logged.
+//
+func (i *InMemoryInterface) Logs() []string {
+	return i.logs
+}
+
+// Events returns all emitted events, in the order they were emitted.
+//
+func (i *InMemoryInterface) Events() []cadence.Event {
+	return i.events
+}
+
+// Reset clears the logged messages and emitted events.
+//
+func (i *InMemoryInterface) Reset() {
+	i.logs = nil
+	i.events = nil
+}
+
+// AccountExists returns true if the account with the given address was created.
+//
+func (i *InMemoryInterface) AccountExists(address common.Address) bool {
+	_, ok := i.accounts[address]
+	return ok
+}
+
+func (i *InMemoryInterface) account(address common.Address) (*account, error) {
+	account, ok := i.accounts[address]
+	if !ok {
+		return nil, fmt.Errorf("account %s does not exist", address.HexWithPrefix())
+	}
+	return account, nil
+}
+
+// Locations and programs
+
+func (i *InMemoryInterface) ResolveLocation(
+	identifiers []runtime.Identifier,
+	location runtime.Location,
+) (
+	[]runtime.ResolvedLocation,
+	error,
+) {
+	addressLocation, ok := location.(common.AddressLocation)
+
+	// Only address locations without a name need to be resolved
+
+	if !ok || addressLocation.Name != "" {
+		return []runtime.ResolvedLocation{
+			{
+				Location:    location,
+				Identifiers: identifiers,
+			},
+		}, nil
+	}
+
+	// If no identifiers are given, import all contracts of the account
+
+	if len(identifiers) == 0 {
+		names, err := i.GetAccountContractNames(addressLocation.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			identifiers = append(
+				identifiers,
+				runtime.Identifier{
+					Identifier: name,
+				},
+			)
+		}
+	}
+
+	resolvedLocations := make([]runtime.ResolvedLocation, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		resolvedLocations = append(
+			resolvedLocations,
+			runtime.ResolvedLocation{
+				Location: common.AddressLocation{
+					Address: addressLocation.Address,
+					Name:    identifier.Identifier,
+				},
+				Identifiers: []runtime.Identifier{identifier},
+			},
+		)
+	}
+
+	return resolvedLocations, nil
+}
+
+func (i *InMemoryInterface) GetCode(location runtime.Location) ([]byte, error) {
+	if code, ok := i.codes[location.ID()]; ok {
+		return code, nil
+	}
+
+	if addressLocation, ok := location.(common.AddressLocation); ok {
+		return i.GetAccountContractCode(addressLocation.Address, addressLocation.Name)
+	}
+
+	return nil, nil
+}
+
+func (i *InMemoryInterface) GetProgram(location runtime.Location) (*interpreter.Program, error) {
+	return i.programs[location.ID()], nil
+}
+
+func (i *InMemoryInterface) SetProgram(location runtime.Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+// Storage
+
+func storageKey(owner, key []byte) string {
+	return strings.Join([]string{string(owner), string(key)}, "|")
+}
+
+func (i *InMemoryInterface) GetValue(owner, key []byte) (value []byte, err error) {
+	return i.storedValues[storageKey(owner, key)], nil
+}
+
+func (i *InMemoryInterface) SetValue(owner, key, value []byte) (err error) {
+	storageKey := storageKey(owner, key)
+	if len(value) == 0 {
+		delete(i.storedValues, storageKey)
+	} else {
+		i.storedValues[storageKey] = value
+	}
+	return nil
+}
+
+func (i *InMemoryInterface) ValueExists(owner, key []byte) (exists bool, err error) {
+	return len(i.storedValues[storageKey(owner, key)]) > 0, nil
+}
+
+func (i *InMemoryInterface) AllocateStorageIndex(owner []byte) (result atree.StorageIndex, err error) {
+	index := i.storageIndices[string(owner)] + 1
+	i.storageIndices[string(owner)] = index
+	binary.BigEndian.PutUint64(result[:], index)
+	return
+}
+
+// GetStorageUsed returns the storage used by the given account,
+// i.e. the sum of the lengths of the keys and values of all registers it owns.
+//
+func (i *InMemoryInterface) GetStorageUsed(address runtime.Address) (value uint64, err error) {
+	prefix := string(address[:]) + "|"
+	for storageKey, storedValue := range i.storedValues {
+		if strings.HasPrefix(storageKey, prefix) {
+			key := storageKey[len(prefix):]
+			value += uint64(len(key) + len(storedValue))
+		}
+	}
+	return value, nil
+}
+
+func (i *InMemoryInterface) GetStorageCapacity(_ runtime.Address) (value uint64, err error) {
+	return i.storageCapacity, nil
+}
+
+// Accounts
+
+// CreateAccount creates a new account.
+// Addresses are assigned sequentially, starting at 0x1.
+//
+func (i *InMemoryInterface) CreateAccount(_ runtime.Address) (address runtime.Address, err error) {
+	i.addressCounter++
+	binary.BigEndian.PutUint64(address[:], i.addressCounter)
+
+	i.accounts[address] = &account{
+		contracts: map[string][]byte{},
+	}
+
+	return address, nil
+}
+
+func (i *InMemoryInterface) GetSigningAccounts() ([]runtime.Address, error) {
+	return i.signingAccounts, nil
+}
+
+func (i *InMemoryInterface) GetAccountBalance(address common.Address) (value uint64, err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return 0, err
+	}
+	return account.balance, nil
+}
+
+func (i *InMemoryInterface) GetAccountAvailableBalance(address common.Address) (value uint64, err error) {
+	return i.GetAccountBalance(address)
+}
+
+// Account keys
+
+func (i *InMemoryInterface) AddEncodedAccountKey(address runtime.Address, publicKey []byte) error {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	account.encodedKeys = append(account.encodedKeys, publicKey)
+	return nil
+}
+
+// RevokeEncodedAccountKey removes the encoded key with the given index from the account.
+// The indices of the other keys are unaffected.
+//
+func (i *InMemoryInterface) RevokeEncodedAccountKey(address runtime.Address, index int) (publicKey []byte, err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(account.encodedKeys) {
+		return nil, nil
+	}
+	publicKey = account.encodedKeys[index]
+	account.encodedKeys[index] = nil
+	return publicKey, nil
+}
+
+func (i *InMemoryInterface) AddAccountKey(
+	address runtime.Address,
+	publicKey *runtime.PublicKey,
+	hashAlgo runtime.HashAlgorithm,
+	weight int,
+) (
+	*runtime.AccountKey,
+	error,
+) {
+	account, err := i.account(address)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &runtime.AccountKey{
+		KeyIndex:  len(account.keys),
+		PublicKey: publicKey,
+		HashAlgo:  hashAlgo,
+		Weight:    weight,
+	}
+	account.keys = append(account.keys, key)
+
+	return key, nil
+}
+
+// GetAccountKey returns the key with the given index, or nil if the account has no such key.
+//
+func (i *InMemoryInterface) GetAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	account, err := i.account(address)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(account.keys) {
+		return nil, nil
+	}
+	return account.keys[index], nil
+}
+
+// RevokeAccountKey marks the key with the given index as revoked,
+// and returns it, or nil if the account has no such key.
+//
+func (i *InMemoryInterface) RevokeAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	key, err := i.GetAccountKey(address, index)
+	if err != nil || key == nil {
+		return nil, err
+	}
+	key.IsRevoked = true
+	return key, nil
+}
+
+// Contracts
+
+func (i *InMemoryInterface) UpdateAccountContractCode(address runtime.Address, name string, code []byte) (err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	account.contracts[name] = code
+	return nil
+}
+
+func (i *InMemoryInterface) GetAccountContractCode(address runtime.Address, name string) (code []byte, err error) {
+	account, ok := i.accounts[address]
+	if !ok {
+		return nil, nil
+	}
+	return account.contracts[name], nil
+}
+
+func (i *InMemoryInterface) RemoveAccountContractCode(address runtime.Address, name string) (err error) {
+	account, err := i.account(address)
+	if err != nil {
+		return err
+	}
+	delete(account.contracts, name)
+	return nil
+}
+
+// GetAccountContractNames returns the names of all contracts deployed to the account, in sorted order.
+//
+func (i *InMemoryInterface) GetAccountContractNames(address runtime.Address) ([]string, error) {
+	account, ok := i.accounts[address]
+	if !ok {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(account.contracts))
+	for name := range account.contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Logs, events, and tracing
+
+func (i *InMemoryInterface) ProgramLog(message string) error {
+	i.logs = append(i.logs, message)
+	return nil
+}
+
+func (i *InMemoryInterface) EmitEvent(event cadence.Event) error {
+	i.events = append(i.events, event)
+	i.notifyEventSubscriptions(event)
+	return nil
+}
+
+func (i *InMemoryInterface) ImplementationDebugLog(_ string) error {
+	return nil
+}
+
+func (i *InMemoryInterface) RecordTrace(
+	_ string,
+	_ common.Location,
+	_ time.Duration,
+	_ []opentracing.LogRecord,
+) {
+	// NO-OP
+}
+
+func (i *InMemoryInterface) ResourceOwnerChanged(
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP
+}
+
+// Execution
+
+// GenerateUUID returns sequential UUIDs, starting at 1.
+//
+func (i *InMemoryInterface) GenerateUUID() (uint64, error) {
+	i.uuid++
+	return i.uuid, nil
+}
+
+func (i *InMemoryInterface) GetComputationLimit() uint64 {
+	return i.computationLimit
+}
+
+func (i *InMemoryInterface) SetComputationUsed(used uint64) error {
+	i.computationUsed = used
+	return nil
+}
+
+// DecodeArgument decodes the given JSON-CDC encoded argument.
+//
+func (i *InMemoryInterface) DecodeArgument(argument []byte, _ cadence.Type) (cadence.Value, error) {
+	return jsoncdc.Decode(argument)
+}
+
+// UnsafeRandom returns pseudo-random numbers from a deterministic source.
+//
+func (i *InMemoryInterface) UnsafeRandom() (uint64, error) {
+	return i.random.Uint64(), nil
+}
+
+// Blocks
+
+// NewBlock returns a block at the given height, with the given timestamp,
+// in nanoseconds since the Unix epoch.
+//
+// The view of the block is its height, and the hash of the block is the SHA3-256 hash of its height.
+//
+func NewBlock(height uint64, timestamp int64) runtime.Block {
+	var encodedHeight [8]byte
+	binary.BigEndian.PutUint64(encodedHeight[:], height)
+
+	return runtime.Block{
+		Height:    height,
+		View:      height,
+		Hash:      sha3.Sum256(encodedHeight[:]),
+		Timestamp: timestamp,
+	}
+}
+
+// CurrentBlock returns the current block, i.e. the latest block.
+//
+func (i *InMemoryInterface) CurrentBlock() runtime.Block {
+	return i.blocks[len(i.blocks)-1]
+}
+
+// SetBlockInterval sets the duration between two blocks produced by AdvanceBlocks.
+//
+func (i *InMemoryInterface) SetBlockInterval(interval time.Duration) {
+	i.blockInterval = interval
+}
+
+// AdvanceBlocks produces the given number of blocks.
+//
+// The timestamp of each block is the timestamp of the previous block,
+// advanced by the block interval, see SetBlockInterval.
+//
+func (i *InMemoryInterface) AdvanceBlocks(count uint64) {
+	for ; count > 0; count-- {
+		i.advanceBlock(i.blockInterval)
+	}
+}
+
+// AdvanceTime produces a single block, with the timestamp of the current block advanced by the given duration.
+//
+// AdvanceTime can be used to test time-dependent code, e.g. vesting schedules,
+// without producing a block for each interval.
+//
+func (i *InMemoryInterface) AdvanceTime(duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("invalid duration %s: time must advance", duration)
+	}
+	i.advanceBlock(duration)
+	return nil
+}
+
+func (i *InMemoryInterface) advanceBlock(duration time.Duration) {
+	current := i.CurrentBlock()
+	i.blocks = append(
+		i.blocks,
+		NewBlock(
+			current.Height+1,
+			current.Timestamp+int64(duration),
+		),
+	)
+}
+
+// SetBlocks replaces the block history with the given blocks.
+// The last block becomes the current block.
+//
+// The blocks must have consecutive heights and must not go back in time.
+// The first block does not have to be at height 0:
+// blocks below the first block do not exist.
+//
+func (i *InMemoryInterface) SetBlocks(blocks ...runtime.Block) error {
+	if len(blocks) == 0 {
+		return fmt.Errorf("missing blocks: at least one block is required")
+	}
+
+	for index := 1; index < len(blocks); index++ {
+		previous := blocks[index-1]
+		block := blocks[index]
+
+		if block.Height != previous.Height+1 {
+			return fmt.Errorf(
+				"invalid height of block %d: expected %d, got %d",
+				index,
+				previous.Height+1,
+				block.Height,
+			)
+		}
+
+		if block.Timestamp < previous.Timestamp {
+			return fmt.Errorf(
+				"invalid timestamp of block at height %d: before timestamp of previous block",
+				block.Height,
+			)
+		}
+	}
+
+	i.blocks = append([]runtime.Block(nil), blocks...)
+	return nil
+}
+
+func (i *InMemoryInterface) GetCurrentBlockHeight() (uint64, error) {
+	return i.CurrentBlock().Height, nil
+}
+
+// GetBlockAtHeight returns the block at the given height,
+// if it is part of the block history, see AdvanceBlocks, AdvanceTime, and SetBlocks.
+//
+func (i *InMemoryInterface) GetBlockAtHeight(height uint64) (block runtime.Block, exists bool, err error) {
+	first := i.blocks[0]
+	if height < first.Height || height > i.CurrentBlock().Height {
+		return runtime.Block{}, false, nil
+	}
+
+	return i.blocks[height-first.Height], true, nil
+}
+
+// GetBlockHeaderAtHeight returns the header of the block at the given height,
+// if it is part of the block history, see GetBlockAtHeight.
+//
+// The parent hash of the first block of the block history is zero.
+//
+func (i *InMemoryInterface) GetBlockHeaderAtHeight(height uint64) (header runtime.BlockHeader, exists bool, err error) {
+	block, exists, err := i.GetBlockAtHeight(height)
+	if err != nil || !exists {
+		return runtime.BlockHeader{}, exists, err
+	}
+
+	header = runtime.BlockHeader{
+		Block: block,
+	}
+
+	if height > i.blocks[0].Height {
+		header.ParentHash = i.blocks[height-1-i.blocks[0].Height].Hash
+	}
+
+	return header, true, nil
+}
+
+// GetBlockIDsInRange returns the IDs of the blocks from the given start height
+// to the given end height (inclusive).
+// All blocks in the range must be part of the block history, see GetBlockAtHeight.
+//
+func (i *InMemoryInterface) GetBlockIDsInRange(startHeight, endHeight uint64) ([]runtime.BlockHash, error) {
+	first := i.blocks[0]
+	if startHeight > endHeight ||
+		startHeight < first.Height ||
+		endHeight > i.CurrentBlock().Height {
+
+		return nil, fmt.Errorf(
+			"invalid block range: blocks from height %d to height %d are not part of the block history",
+			startHeight,
+			endHeight,
+		)
+	}
+
+	blocks := i.blocks[startHeight-first.Height : endHeight-first.Height+1]
+
+	ids := make([]runtime.BlockHash, len(blocks))
+	for index, block := range blocks {
+		ids[index] = block.Hash
+	}
+
+	return ids, nil
+}
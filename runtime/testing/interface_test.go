@@ -0,0 +1,520 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testing_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/stdlib"
+	runtimetesting "github.com/onflow/cadence/runtime/testing"
+)
+
+func executeTransaction(
+	t *testing.T,
+	rt runtime.Runtime,
+	runtimeInterface *runtimetesting.InMemoryInterface,
+	code string,
+	arguments ...cadence.Value,
+) {
+	encodedArguments := make([][]byte, 0, len(arguments))
+	for _, argument := range arguments {
+		encodedArgument, err := jsoncdc.Encode(argument)
+		require.NoError(t, err)
+		encodedArguments = append(encodedArguments, encodedArgument)
+	}
+
+	err := rt.ExecuteTransaction(
+		runtime.Script{
+			Source:    []byte(code),
+			Arguments: encodedArguments,
+		},
+		runtime.Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+}
+
+func executeScript(
+	t *testing.T,
+	rt runtime.Runtime,
+	runtimeInterface *runtimetesting.InMemoryInterface,
+	code string,
+) cadence.Value {
+	value, err := rt.ExecuteScript(
+		runtime.Script{
+			Source: []byte(code),
+		},
+		runtime.Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+	return value
+}
+
+func TestInMemoryInterfaceContracts(t *testing.T) {
+
+	t.Parallel()
+
+	rt := runtime.NewInterpreterRuntime()
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	address, err := runtimeInterface.CreateAccount(common.Address{})
+	require.NoError(t, err)
+	assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+
+	runtimeInterface.SetSigningAccounts(address)
+
+	const contract = `
+      pub contract Counter {
+
+          pub event Incremented(count: Int)
+
+          pub var count: Int
+
+          pub fun increment() {
+              self.count = self.count + 1
+              emit Incremented(count: self.count)
+          }
+
+          init() {
+              self.count = 0
+          }
+      }
+    `
+
+	executeTransaction(t, rt, runtimeInterface,
+		`
+          transaction(code: String) {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.add(name: "Counter", code: code.decodeHex())
+              }
+          }
+        `,
+		cadence.String(hex.EncodeToString([]byte(contract))),
+	)
+
+	names, err := runtimeInterface.GetAccountContractNames(address)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Counter"}, names)
+
+	transaction := fmt.Sprintf(
+		`
+          import Counter from %s
+
+          transaction {
+              execute {
+                  Counter.increment()
+                  log(Counter.count)
+              }
+          }
+        `,
+		address.ShortHexWithPrefix(),
+	)
+
+	runtimeInterface.SetSigningAccounts()
+
+	executeTransaction(t, rt, runtimeInterface, transaction)
+	executeTransaction(t, rt, runtimeInterface, transaction)
+
+	assert.Equal(t, []string{"1", "2"}, runtimeInterface.Logs())
+
+	events := runtimeInterface.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, string(stdlib.AccountContractAddedEventTypeID), events[0].EventType.ID())
+	assert.Equal(t,
+		string(common.NewEventTypeID(common.AddressLocation{Address: address, Name: "Counter"}, "Counter", "Incremented")),
+		events[2].EventType.ID(),
+	)
+	assert.Equal(t, cadence.NewInt(2), events[2].Fields[0])
+
+	value := executeScript(t, rt, runtimeInterface,
+		fmt.Sprintf(
+			`
+              import Counter from %s
+
+              pub fun main(): Int {
+                  return Counter.count
+              }
+            `,
+			address.ShortHexWithPrefix(),
+		),
+	)
+	assert.Equal(t, cadence.NewInt(2), value)
+
+	runtimeInterface.Reset()
+	assert.Empty(t, runtimeInterface.Logs())
+	assert.Empty(t, runtimeInterface.Events())
+}
+
+func TestInMemoryInterfaceStorage(t *testing.T) {
+
+	t.Parallel()
+
+	rt := runtime.NewInterpreterRuntime()
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	address, err := runtimeInterface.CreateAccount(common.Address{})
+	require.NoError(t, err)
+
+	storageUsed, err := runtimeInterface.GetStorageUsed(address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), storageUsed)
+
+	runtimeInterface.SetSigningAccounts(address)
+
+	executeTransaction(t, rt, runtimeInterface, `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(["a", "b"], to: /storage/values)
+          }
+      }
+    `)
+
+	storageUsed, err = runtimeInterface.GetStorageUsed(address)
+	require.NoError(t, err)
+	assert.Greater(t, storageUsed, uint64(0))
+
+	value := executeScript(t, rt, runtimeInterface,
+		fmt.Sprintf(
+			`
+              pub fun main(): Int {
+                  return getAuthAccount(%s).load<[String]>(from: /storage/values)!.length
+              }
+            `,
+			address.ShortHexWithPrefix(),
+		),
+	)
+	assert.Equal(t, cadence.NewInt(2), value)
+}
+
+func TestInMemoryInterfaceKeys(t *testing.T) {
+
+	t.Parallel()
+
+	rt := runtime.NewInterpreterRuntime()
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	address, err := runtimeInterface.CreateAccount(common.Address{})
+	require.NoError(t, err)
+
+	runtimeInterface.SetSigningAccounts(address)
+
+	publicKey := []byte{1, 2, 3, 4}
+
+	executeTransaction(t, rt, runtimeInterface,
+		`
+          transaction(publicKey: [UInt8]) {
+              prepare(signer: AuthAccount) {
+                  signer.keys.add(
+                      publicKey: PublicKey(
+                          publicKey: publicKey,
+                          signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+                      ),
+                      hashAlgorithm: HashAlgorithm.SHA3_256,
+                      weight: 1000.0
+                  )
+              }
+          }
+        `,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewUInt8(1),
+			cadence.NewUInt8(2),
+			cadence.NewUInt8(3),
+			cadence.NewUInt8(4),
+		}),
+	)
+
+	key, err := runtimeInterface.GetAccountKey(address, 0)
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.Equal(t, publicKey, key.PublicKey.PublicKey)
+	assert.Equal(t, runtime.HashAlgorithmSHA3_256, key.HashAlgo)
+	assert.False(t, key.IsRevoked)
+
+	signedData := []byte("hello")
+	signature := runtimetesting.FakeSignature("FLOW-V0.0-user", signedData, publicKey)
+
+	verify := func(signature []byte) cadence.Value {
+		return executeScript(t, rt, runtimeInterface,
+			fmt.Sprintf(
+				`
+                  pub fun main(): Bool {
+                      let key = getAccount(%s).keys.get(keyIndex: 0)!
+                      return key.publicKey.verify(
+                          signature: "%x".decodeHex(),
+                          signedData: "%x".decodeHex(),
+                          domainSeparationTag: "FLOW-V0.0-user",
+                          hashAlgorithm: key.hashAlgorithm
+                      )
+                  }
+                `,
+				address.ShortHexWithPrefix(),
+				signature,
+				signedData,
+			),
+		)
+	}
+
+	assert.Equal(t, cadence.NewBool(true), verify(signature))
+	assert.Equal(t, cadence.NewBool(false), verify([]byte{1}))
+
+	revokedKey, err := runtimeInterface.RevokeAccountKey(address, 0)
+	require.NoError(t, err)
+	assert.True(t, revokedKey.IsRevoked)
+
+	missingKey, err := runtimeInterface.GetAccountKey(address, 1)
+	require.NoError(t, err)
+	assert.Nil(t, missingKey)
+}
+
+func TestInMemoryInterfaceHashAndBlocks(t *testing.T) {
+
+	t.Parallel()
+
+	rt := runtime.NewInterpreterRuntime()
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	value := executeScript(t, rt, runtimeInterface, `
+      pub fun main(): [AnyStruct] {
+          let block = getCurrentBlock()
+          return [
+              HashAlgorithm.SHA3_256.hash([1, 2, 3]),
+              block.height,
+              block.timestamp
+          ]
+      }
+    `)
+
+	expectedDigest := sha3.Sum256([]byte{1, 2, 3})
+	expectedDigestValues := make([]cadence.Value, 0, len(expectedDigest))
+	for _, b := range expectedDigest {
+		expectedDigestValues = append(expectedDigestValues, cadence.NewUInt8(b))
+	}
+
+	values := value.(cadence.Array).Values
+	require.Len(t, values, 3)
+
+	assert.Equal(t, cadence.NewArray(expectedDigestValues), values[0].(cadence.Array))
+	assert.Equal(t, cadence.NewUInt64(0), values[1])
+
+	expectedTimestamp, err := cadence.NewUFix64("1577836800.0")
+	require.NoError(t, err)
+	assert.Equal(t, expectedTimestamp, values[2])
+
+	_, exists, err := runtimeInterface.GetBlockAtHeight(1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestInMemoryInterfaceAdvanceBlocks(t *testing.T) {
+
+	t.Parallel()
+
+	rt := runtime.NewInterpreterRuntime()
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	const script = `
+      pub fun main(): [AnyStruct] {
+          let block = getCurrentBlock()
+          return [
+              block.height,
+              block.timestamp,
+              getBlock(at: 2)?.timestamp
+          ]
+      }
+    `
+
+	runtimeInterface.AdvanceBlocks(3)
+
+	err := runtimeInterface.AdvanceTime(time.Hour)
+	require.NoError(t, err)
+
+	value := executeScript(t, rt, runtimeInterface, script)
+
+	expectedTimestamp, err := cadence.NewUFix64("1577840403.0")
+	require.NoError(t, err)
+
+	expectedPreviousTimestamp, err := cadence.NewUFix64("1577836802.0")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewUInt64(4),
+			expectedTimestamp,
+			cadence.NewOptional(expectedPreviousTimestamp),
+		}),
+		value,
+	)
+
+	err = runtimeInterface.AdvanceTime(0)
+	require.Error(t, err)
+
+	assert.Equal(t, uint64(4), runtimeInterface.CurrentBlock().Height)
+}
+
+func TestInMemoryInterfaceSetBlocks(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+		timestamp := runtimetesting.GenesisBlockTimestamp
+
+		err := runtimeInterface.SetBlocks(
+			runtimetesting.NewBlock(10, timestamp),
+			runtimetesting.NewBlock(11, timestamp+int64(time.Minute)),
+		)
+		require.NoError(t, err)
+
+		height, err := runtimeInterface.GetCurrentBlockHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), height)
+
+		_, exists, err := runtimeInterface.GetBlockAtHeight(9)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		block, exists, err := runtimeInterface.GetBlockAtHeight(10)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, runtimetesting.NewBlock(10, timestamp), block)
+
+		runtimeInterface.SetBlockInterval(time.Minute)
+		runtimeInterface.AdvanceBlocks(1)
+
+		assert.Equal(t,
+			runtimetesting.NewBlock(12, timestamp+int64(2*time.Minute)),
+			runtimeInterface.CurrentBlock(),
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+		timestamp := runtimetesting.GenesisBlockTimestamp
+
+		err := runtimeInterface.SetBlocks()
+		require.Error(t, err)
+
+		err = runtimeInterface.SetBlocks(
+			runtimetesting.NewBlock(1, timestamp),
+			runtimetesting.NewBlock(3, timestamp),
+		)
+		require.Error(t, err)
+
+		err = runtimeInterface.SetBlocks(
+			runtimetesting.NewBlock(1, timestamp),
+			runtimetesting.NewBlock(2, timestamp-1),
+		)
+		require.Error(t, err)
+
+		assert.Equal(t,
+			runtimetesting.NewBlock(0, timestamp),
+			runtimeInterface.CurrentBlock(),
+		)
+	})
+}
+
+func TestInMemoryInterfaceBlockHeadersAndIDs(t *testing.T) {
+
+	t.Parallel()
+
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	timestamp := runtimetesting.GenesisBlockTimestamp
+
+	first := runtimetesting.NewBlock(10, timestamp)
+	second := runtimetesting.NewBlock(11, timestamp+int64(time.Minute))
+	third := runtimetesting.NewBlock(12, timestamp+int64(2*time.Minute))
+
+	err := runtimeInterface.SetBlocks(first, second, third)
+	require.NoError(t, err)
+
+	header, exists, err := runtimeInterface.GetBlockHeaderAtHeight(10)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, runtime.BlockHeader{Block: first}, header)
+
+	header, exists, err = runtimeInterface.GetBlockHeaderAtHeight(12)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t,
+		runtime.BlockHeader{
+			Block:      third,
+			ParentHash: second.Hash,
+		},
+		header,
+	)
+
+	_, exists, err = runtimeInterface.GetBlockHeaderAtHeight(13)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	ids, err := runtimeInterface.GetBlockIDsInRange(11, 12)
+	require.NoError(t, err)
+	assert.Equal(t,
+		[]runtime.BlockHash{second.Hash, third.Hash},
+		ids,
+	)
+
+	_, err = runtimeInterface.GetBlockIDsInRange(9, 12)
+	require.Error(t, err)
+
+	_, err = runtimeInterface.GetBlockIDsInRange(12, 11)
+	require.Error(t, err)
+}
+
+func TestInMemoryInterfaceMissingAccount(t *testing.T) {
+
+	t.Parallel()
+
+	runtimeInterface := runtimetesting.NewInMemoryInterface()
+
+	address := common.MustBytesToAddress([]byte{0x42})
+
+	assert.False(t, runtimeInterface.AccountExists(address))
+
+	_, err := runtimeInterface.GetAccountBalance(address)
+	require.EqualError(t, err, "account 0x0000000000000042 does not exist")
+
+	err = runtimeInterface.UpdateAccountContractCode(address, "C", []byte("pub contract C {}"))
+	require.Error(t, err)
+}
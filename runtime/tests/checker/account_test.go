@@ -53,6 +53,14 @@ func ParseAndCheckAccount(t *testing.T, code string) (*sema.Checker, error) {
 	)
 }
 
+// isStorageDomain returns true if values can be stored in the given path domain,
+// i.e. if paths in the domain are storage paths.
+//
+func isStorageDomain(domain common.PathDomain) bool {
+	return domain == common.PathDomainStorage ||
+		domain == common.PathDomainTransaction
+}
+
 func TestCheckAccount_save(t *testing.T) {
 
 	t.Parallel()
@@ -88,7 +96,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				require.NoError(t, err)
 			} else {
 				errs := ExpectCheckerErrors(t, err, 1)
@@ -115,7 +123,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				require.NoError(t, err)
 			} else {
 				errs := ExpectCheckerErrors(t, err, 1)
@@ -156,7 +164,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				require.NoError(t, err)
 			} else {
 				errs := ExpectCheckerErrors(t, err, 1)
@@ -183,7 +191,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				require.NoError(t, err)
 			} else {
 				errs := ExpectCheckerErrors(t, err, 1)
@@ -226,7 +234,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 
 				errs := ExpectCheckerErrors(t, err, 2)
 
@@ -261,7 +269,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 
 				errs := ExpectCheckerErrors(t, err, 2)
 
@@ -309,7 +317,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				errs := ExpectCheckerErrors(t, err, 1)
 
 				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
@@ -340,7 +348,7 @@ func TestCheckAccount_save(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				errs := ExpectCheckerErrors(t, err, 1)
 
 				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
@@ -379,7 +387,7 @@ func TestCheckAccount_typeAt(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 
 				require.NoError(t, err)
 
@@ -429,7 +437,7 @@ func TestCheckAccount_load(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				errs := ExpectCheckerErrors(t, err, 1)
 
 				require.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
@@ -469,7 +477,7 @@ func TestCheckAccount_load(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 
 					require.NoError(t, err)
 
@@ -505,7 +513,7 @@ func TestCheckAccount_load(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 
 					require.NoError(t, err)
 
@@ -559,7 +567,7 @@ func TestCheckAccount_copy(t *testing.T) {
 				),
 			)
 
-			if domain == common.PathDomainStorage {
+			if isStorageDomain(domain) {
 				errs := ExpectCheckerErrors(t, err, 1)
 
 				require.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
@@ -599,7 +607,7 @@ func TestCheckAccount_copy(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 					require.NoError(t, err)
 
 					sType := RequireGlobalType(t, checker.Elaboration, "S")
@@ -634,7 +642,7 @@ func TestCheckAccount_copy(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 					errs := ExpectCheckerErrors(t, err, 1)
 
 					require.IsType(t, &sema.TypeMismatchError{}, errs[0])
@@ -683,7 +691,7 @@ func TestCheckAccount_borrow(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 					errs := ExpectCheckerErrors(t, err, 1)
 
 					require.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
@@ -709,7 +717,7 @@ func TestCheckAccount_borrow(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 					errs := ExpectCheckerErrors(t, err, 1)
 
 					require.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
@@ -757,7 +765,7 @@ func TestCheckAccount_borrow(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 
 					require.NoError(t, err)
 
@@ -796,7 +804,7 @@ func TestCheckAccount_borrow(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 					require.NoError(t, err)
 
 					sType := RequireGlobalType(t, checker.Elaboration, "S")
@@ -846,7 +854,7 @@ func TestCheckAccount_borrow(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 
 					errs := ExpectCheckerErrors(t, err, 1)
 
@@ -874,7 +882,7 @@ func TestCheckAccount_borrow(t *testing.T) {
 					),
 				)
 
-				if domain == common.PathDomainStorage {
+				if isStorageDomain(domain) {
 
 					errs := ExpectCheckerErrors(t, err, 1)
 
@@ -1151,6 +1159,102 @@ func TestCheckAccount_unlink(t *testing.T) {
 	}
 }
 
+func TestCheckAccount_linkAccount(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("allowed", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheckAccount(t,
+			`
+              #allowAccountLinking
+
+              let capability = authAccount.linkAccount(/private/account)
+            `,
+		)
+		require.NoError(t, err)
+
+		require.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.CapabilityType{
+					BorrowType: &sema.ReferenceType{
+						Type: sema.AuthAccountType,
+					},
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "capability"),
+		)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t,
+			`
+              fun test() {
+                  authAccount.linkAccount(/private/account)
+              }
+            `,
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.AccountLinkingNotAllowedError{}, errs[0])
+	})
+
+	t.Run("other pragma", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t,
+			`
+              #allowAccountLinking("foo")
+
+              fun test() {
+                  authAccount.linkAccount(/private/account)
+              }
+            `,
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.AccountLinkingNotAllowedError{}, errs[0])
+	})
+
+	for _, domain := range []common.PathDomain{
+		common.PathDomainStorage,
+		common.PathDomainPublic,
+	} {
+
+		domain := domain
+
+		t.Run(domain.Identifier(), func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheckAccount(t,
+				fmt.Sprintf(
+					`
+                      #allowAccountLinking
+
+                      fun test() {
+                          authAccount.linkAccount(/%s/account)
+                      }
+                    `,
+					domain.Identifier(),
+				),
+			)
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		})
+	}
+}
+
 func TestCheckAccount_getLinkTarget(t *testing.T) {
 
 	t.Parallel()
@@ -1508,3 +1612,176 @@ func TestPublicAccountContracts(t *testing.T) {
 	})
 
 }
+
+func TestCheckAccountCapabilities(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("storage capabilities", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          let capabilities: AuthAccount.Capabilities = authAccount.capabilities
+          let storageCapabilities: AuthAccount.StorageCapabilities = authAccount.capabilities.storage
+
+          let capability = authAccount.capabilities.storage.issue<&R>(/storage/r)
+          let id: UInt64 = capability.id
+
+          let controller: &StorageCapabilityController? =
+              authAccount.capabilities.storage.getController(byCapabilityID: id)
+          let controllers: [&StorageCapabilityController] =
+              authAccount.capabilities.storage.getControllers(forPath: /storage/r)
+
+          let capabilityID: UInt64 = controllers[0].capabilityID
+          let borrowType: Type = controllers[0].borrowType
+          let target: StoragePath = controllers[0].target()
+
+          fun test() {
+              controllers[0].revoke()
+          }
+        `)
+		require.NoError(t, err)
+
+		rType := RequireGlobalType(t, checker.Elaboration, "R")
+
+		capabilityType := &sema.CapabilityType{
+			BorrowType: &sema.ReferenceType{
+				Type: rType,
+			},
+		}
+
+		assert.True(t,
+			capabilityType.Equal(
+				RequireGlobalValue(t, checker.Elaboration, "capability"),
+			),
+		)
+	})
+
+	t.Run("issue non-reference type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          let capability = authAccount.capabilities.storage.issue<@R>(/storage/r)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("issue non-storage path", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          let capability = authAccount.capabilities.storage.issue<&R>(/public/r)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("publish and unpublish", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          fun test() {
+              let capability = authAccount.capabilities.storage.issue<&R>(/storage/r)
+              authAccount.capabilities.publish(capability, at: /public/r)
+          }
+
+          let unpublished = authAccount.capabilities.unpublish(/public/r)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.CapabilityType{},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "unpublished"),
+		)
+	})
+
+	t.Run("publish at private path", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          fun test() {
+              let capability = authAccount.capabilities.storage.issue<&R>(/storage/r)
+              authAccount.capabilities.publish(capability, at: /private/r)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("public account", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          let capabilities: PublicAccount.Capabilities = publicAccount.capabilities
+          let capability = publicAccount.capabilities.get<&R>(/public/r)
+          let ref = publicAccount.capabilities.borrow<&R>(/public/r)
+        `)
+		require.NoError(t, err)
+
+		rType := RequireGlobalType(t, checker.Elaboration, "R")
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.CapabilityType{
+					BorrowType: &sema.ReferenceType{
+						Type: rType,
+					},
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "capability"),
+		)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.ReferenceType{
+					Type: rType,
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "ref"),
+		)
+	})
+
+	t.Run("public account storage capabilities", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          resource R {}
+
+          fun test() {
+              publicAccount.capabilities.storage.issue<&R>(/storage/r)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+}
@@ -574,6 +574,43 @@ func TestCheckInvalidResourceArraySlice(t *testing.T) {
 	assert.IsType(t, &sema.ResourceLossError{}, errs[2])
 }
 
+func TestCheckArrayAt(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs = [1, 2, 3]
+      let x = xs.at(1)
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.OptionalType{Type: sema.IntType},
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+}
+
+func TestCheckInvalidResourceArrayAt(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource X {}
+
+      fun test() {
+          let xs <- [<-create X()]
+          let x <- xs.at(0)
+          destroy x
+          destroy xs
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+}
+
 func TestCheckArrayInsert(t *testing.T) {
 
 	t.Parallel()
@@ -699,9 +736,231 @@ func TestCheckInvalidArrayRemoveFirst(t *testing.T) {
       }
 	`)
 
+	errs := ExpectCheckerErrors(t, err, 2)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[1])
+}
+
+func TestCheckInvalidArrayRemoveFirstWhereNonFunction(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test(): [Int] {
+          let x = [1, 2, 3]
+          let old: Int? = x.removeFirst(where: 1)
+          return x
+      }
+	`)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckArrayRemoveFirstWhere(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource R {
+          let id: Int
+
+          init(id: Int) {
+              self.id = id
+          }
+      }
+
+      fun hasFirstID(_ r: &R): Bool {
+          return r.id == 1
+      }
+
+      let xs = [1, 2, 3]
+      let x: Int = xs.removeFirst(where: fun (x: Int): Bool { return x > 1 })
+
+      fun test() {
+          let rs <- [<-create R(id: 1)]
+          let r <- rs.removeFirst(where: hasFirstID)
+          destroy r
+          destroy rs
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidArrayRemoveFirstWhere(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let xs = [1, 2, 3]
+          xs.removeFirst(where: fun (x: String): Bool { return true })
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckArrayRemoveAll(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      resource R {}
+
+      fun isAny(_ r: &R): Bool {
+          return true
+      }
+
+      let xs = [1, 2, 3]
+      let removed = xs.removeAll(where: fun (x: Int): Bool { return x > 1 })
+
+      fun test() {
+          let rs <- [<-create R()]
+          let removedResources <- rs.removeAll(where: isAny)
+          destroy removedResources
+          destroy rs
+      }
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{Type: sema.IntType},
+		RequireGlobalValue(t, checker.Elaboration, "removed"),
+	)
+}
+
+func TestCheckInvalidArrayRemoveAllFromConstantSized(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let xs: [Int; 3] = [1, 2, 3]
+      let removed = xs.removeAll(where: fun (x: Int): Bool { return true })
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+}
+
+func TestCheckDictionaryFilter(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs = {"a": 1, "b": 2}
+      let filtered = xs.filter(fun (key: String, value: Int): Bool { return value > 1 })
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.DictionaryType{
+			KeyType:   sema.StringType,
+			ValueType: sema.IntType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "filtered"),
+	)
+}
+
+func TestCheckInvalidResourceDictionaryFilter(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource R {}
+
+      fun test(rs: @{String: R}) {
+          let filtered <- rs.filter(fun (key: String, value: @R): Bool {
+              destroy value
+              return true
+          })
+          destroy filtered
+          destroy rs
+      }
+    `)
+
 	errs := ExpectCheckerErrors(t, err, 1)
 
-	assert.IsType(t, &sema.ArgumentCountError{}, errs[0])
+	assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[0])
+}
+
+func TestCheckDictionaryForEachKey(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let xs = {"a": 1, "b": 2}
+          xs.forEachKey(fun (key: String): Bool {
+              return key != "b"
+          })
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckDictionaryForEachValue(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let xs = {"a": 1, "b": 2}
+          xs.forEachValue(fun (value: Int): Bool {
+              return value < 2
+          })
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidDictionaryForEachKeyFunction(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let xs = {"a": 1, "b": 2}
+          xs.forEachKey(fun (key: Int): Bool {
+              return true
+          })
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckInvalidResourceDictionaryForEachValue(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource R {}
+
+      fun test(rs: @{String: R}) {
+          rs.forEachValue(fun (value: @R): Bool {
+              destroy value
+              return true
+          })
+          destroy rs
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[0])
 }
 
 func TestCheckInvalidArrayRemoveFirstFromConstantSized(t *testing.T) {
@@ -753,6 +1012,221 @@ func TestCheckInvalidArrayRemoveLastFromConstantSized(t *testing.T) {
 	assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
 }
 
+func TestCheckConstantSizedArraySlice(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs: [Int; 3] = [1, 2, 3]
+      let ys = xs.slice(from: 1, upTo: 3)
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{Type: sema.IntType},
+		RequireGlobalValue(t, checker.Elaboration, "ys"),
+	)
+}
+
+func TestCheckArrayMap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let ys = xs.map(fun (x: Int): String {
+              return x.toString()
+          })
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{Type: sema.StringType},
+			RequireGlobalValue(t, checker.Elaboration, "ys"),
+		)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs: [Int; 3] = [1, 2, 3]
+          let ys = xs.map(fun (x: Int): Bool {
+              return x > 1
+          })
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.ConstantSizedType{Type: sema.BoolType, Size: 3},
+			RequireGlobalValue(t, checker.Elaboration, "ys"),
+		)
+	})
+
+	t.Run("invalid transform", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let ys = xs.map(fun (x: String): String {
+              return x
+          })
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckArrayFilter(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs: [Int; 3] = [1, 2, 3]
+      let ys = xs.filter(fun (x: Int): Bool {
+          return x > 1
+      })
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{Type: sema.IntType},
+		RequireGlobalValue(t, checker.Elaboration, "ys"),
+	)
+}
+
+func TestCheckArrayReduce(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs = [1, 2, 3]
+      let sum = xs.reduce(initial: 0 as UInt64, fun (acc: UInt64, x: Int): UInt64 {
+          return acc + UInt64(x)
+      })
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.UInt64Type,
+		RequireGlobalValue(t, checker.Elaboration, "sum"),
+	)
+}
+
+func TestCheckArrayReverse(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs: [Int; 3] = [1, 2, 3]
+      let ys = xs.reverse()
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.ConstantSizedType{Type: sema.IntType, Size: 3},
+		RequireGlobalValue(t, checker.Elaboration, "ys"),
+	)
+}
+
+func TestCheckArrayFirstIndex(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs = ["a", "b"]
+          let index = xs.firstIndex(of: "b")
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{Type: sema.IntType},
+			RequireGlobalValue(t, checker.Elaboration, "index"),
+		)
+	})
+
+	t.Run("non-equatable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs = [fun () {}]
+          let index = xs.firstIndex(of: fun () {})
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotEquatableTypeError{}, errs[0])
+	})
+}
+
+func TestCheckInvalidResourceArrayFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	for _, name := range []string{"map", "filter", "reduce", "reverse"} {
+
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t, fmt.Sprintf(
+				`
+                  resource X {}
+
+                  fun test(xs: &[X]) {
+                      let f = xs.%s
+                  }
+                `,
+				name,
+			))
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+		})
+	}
+
+	t.Run("firstIndex", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test(xs: &[X]) {
+              let f = xs.firstIndex
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+		assert.IsType(t, &sema.NotEquatableTypeError{}, errs[1])
+	})
+}
+
 func TestCheckArrayContains(t *testing.T) {
 
 	t.Parallel()
@@ -1010,6 +1484,223 @@ func TestCheckInvalidConstantSizedArrayDeclarationCountMismatchTooMany(t *testin
 	assert.IsType(t, &sema.ConstantSizedArrayLiteralSizeError{}, errs[0])
 }
 
+func TestCheckInvalidConstantSizedArrayLiteralSize(t *testing.T) {
+
+	t.Parallel()
+
+	for name, code := range map[string]string{
+		"argument": `
+          fun f(_ xs: [Int; 3]) {}
+
+          fun test() {
+              f([1, 2])
+          }
+        `,
+		"return": `
+          fun test(): [Int; 2] {
+              return [1, 2, 3]
+          }
+        `,
+		"assignment": `
+          fun test() {
+              var xs: [Int; 2] = [1, 2]
+              xs = [1]
+          }
+        `,
+		"nested": `
+          let xs: [[Int; 2]] = [[1, 2], [1]]
+        `,
+		"optional": `
+          let xs: [Int; 2]? = [1, 2, 3]
+        `,
+	} {
+		// Capture the loop variables
+		code := code
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t, code)
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			assert.IsType(t, &sema.ConstantSizedArrayLiteralSizeError{}, errs[0])
+		})
+	}
+}
+
+func TestCheckInvalidConstantSizedArrayImplicitConversion(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("to variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int; 2] = [1, 2]
+          let ys: [Int] = xs
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("to constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int] = [1, 2]
+          let ys: [Int; 2] = xs
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestCheckArrayToVariableSized(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs: [Int; 2] = [1, 2]
+      let ys = xs.toVariableSized()
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{Type: sema.IntType},
+		RequireGlobalValue(t, checker.Elaboration, "ys"),
+	)
+}
+
+func TestCheckInvalidArrayToVariableSized(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int] = [1, 2]
+          let ys = xs.toVariableSized()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test(rs: @[R; 1]) {
+              let ys <- rs.toVariableSized()
+              destroy ys
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+	})
+}
+
+func TestCheckArrayToConstantSized(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs: [Int] = [1, 2]
+      let ys = xs.toConstantSized<[Int; 2]>()
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.OptionalType{
+			Type: &sema.ConstantSizedType{
+				Type: sema.IntType,
+				Size: 2,
+			},
+		},
+		RequireGlobalValue(t, checker.Elaboration, "ys"),
+	)
+}
+
+func TestCheckInvalidArrayToConstantSized(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("missing type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int] = [1, 2]
+          let ys = xs.toConstantSized()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
+	})
+
+	t.Run("variable-sized type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int] = [1, 2]
+          let ys = xs.toConstantSized<[Int]>()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+	})
+
+	t.Run("mismatched element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int] = [1, 2]
+          let ys = xs.toConstantSized<[String; 2]>()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int; 2] = [1, 2]
+          let ys = xs.toConstantSized<[Int; 2]>()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+}
+
 func TestCheckInvalidConstantSizedArrayDeclarationOutOfRangeSize(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,399 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("structure base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {
+              pub let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("resource base", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+        `)
+
+		require.NoError(t, err)
+
+		attachmentType := RequireGlobalType(t, checker.Elaboration, "A").(*sema.CompositeType)
+		assert.True(t, attachmentType.IsResourceType())
+	})
+
+	t.Run("invalid base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          attachment A for Int {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentBaseTypeError{}, errs[0])
+	})
+
+	t.Run("base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {
+              pub fun getX(): Int {
+                  return base.x
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("base is not assignable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {
+              pub fun test() {
+                  base = &S() as &S
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.AssignmentToConstantError{}, errs[0])
+	})
+}
+
+func TestCheckAttachExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let s = attach A() to S()
+        `)
+
+		require.NoError(t, err)
+
+		sType := RequireGlobalType(t, checker.Elaboration, "S")
+		sValueType := RequireGlobalValue(t, checker.Elaboration, "s")
+
+		assert.Equal(t, sType, sValueType)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(): @R {
+              let r <- create R()
+              return <- attach A() to <-r
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("resource, missing move", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(): @R {
+              let r <- create R()
+              return <- attach A() to r
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 3)
+
+		assert.IsType(t, &sema.MissingMoveOperationError{}, errs[0])
+		assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+		assert.IsType(t, &sema.ResourceLossError{}, errs[2])
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          let t = attach A() to T()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("not an attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          let s = attach S() to S()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotAnAttachmentError{}, errs[0])
+	})
+
+	t.Run("construction outside of attach", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          let a = A()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentConstructionError{}, errs[0])
+	})
+}
+
+func TestCheckAttachmentAccess(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {
+              pub fun foo(): Int {
+                  return 1
+              }
+          }
+
+          let s = attach A() to S()
+          let a = s[A]
+          let x = s[A]?.foo()
+        `)
+
+		require.NoError(t, err)
+
+		attachmentType := RequireGlobalType(t, checker.Elaboration, "A")
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.ReferenceType{
+					Type: attachmentType,
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+		)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(r: &R): &A? {
+              return r[A]
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          let a = T()[A]
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentAccessError{}, errs[0])
+	})
+
+	t.Run("assignment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(s: S) {
+              s[A] = nil
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotIndexingAssignableTypeError{}, errs[0])
+	})
+}
+
+func TestCheckRemoveStatement(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          attachment A for R {}
+
+          fun test(r: @R): @R {
+              remove A from r
+              return <-r
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("not an attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          fun test(s: S) {
+              remove S from s
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotAnAttachmentError{}, errs[0])
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          struct T {}
+
+          attachment A for S {}
+
+          fun test(t: T) {
+              remove A from t
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAttachmentAccessError{}, errs[0])
+	})
+}
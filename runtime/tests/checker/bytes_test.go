@@ -0,0 +1,116 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckBytes(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let x = Bytes([1, 2, 3])
+      let length = x.length
+      let first = x[0]
+      let concatenated = x.concat(Bytes([4]))
+      let sliced = x.slice(from: 1, upTo: 3)
+      let array = x.toArray()
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.BytesType,
+		RequireGlobalValue(t, checker.Elaboration, "x"),
+	)
+
+	assert.Equal(t,
+		sema.IntType,
+		RequireGlobalValue(t, checker.Elaboration, "length"),
+	)
+
+	assert.Equal(t,
+		sema.UInt8Type,
+		RequireGlobalValue(t, checker.Elaboration, "first"),
+	)
+
+	assert.Equal(t,
+		sema.BytesType,
+		RequireGlobalValue(t, checker.Elaboration, "concatenated"),
+	)
+
+	assert.Equal(t,
+		sema.BytesType,
+		RequireGlobalValue(t, checker.Elaboration, "sliced"),
+	)
+
+	assert.Equal(t,
+		sema.ByteArrayType,
+		RequireGlobalValue(t, checker.Elaboration, "array"),
+	)
+}
+
+func TestCheckBytesEquality(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let x = Bytes([1, 2]) == Bytes([1, 2])
+      let y: {Bytes: Int} = {Bytes([1]): 1}
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidBytesIndexAssignment(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test() {
+          let x = Bytes([1, 2, 3])
+          x[0] = 4
+      }
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.NotIndexingAssignableTypeError{}, errs[0])
+}
+
+func TestCheckInvalidBytesConcat(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let x = Bytes([1, 2, 3]).concat([4])
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
@@ -6255,9 +6255,7 @@ func TestCheckUnnecessaryCasts(t *testing.T) {
 			require.IsType(t, &sema.UnnecessaryCastHint{}, hints[0])
 			castHint := hints[0].(*sema.UnnecessaryCastHint)
 			assert.Equal(t,
-				&sema.VariableSizedType{
-					Type: sema.IntType,
-				},
+				sema.NewVariableSizedType(sema.IntType),
 				castHint.TargetType)
 		})
 
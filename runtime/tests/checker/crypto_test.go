@@ -100,6 +100,55 @@ func TestCheckHashAlgorithmHashFunctions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCheckHashAlgorithmNewHasher(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+           fun test(): [UInt8] {
+               let hasher: Hasher = HashAlgorithm.SHA3_256.newHasher()
+               hasher.update([1, 2])
+               hasher.update([3])
+               return hasher.finalize()
+           }
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithPredeclaredValues(
+					stdlib.BuiltinValues().ToSemaValueDeclarations(),
+				),
+			},
+		},
+	)
+
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidHasherStorage(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheckWithOptions(t,
+		`
+           fun test(account: AuthAccount) {
+               account.save(HashAlgorithm.SHA3_256.newHasher(), to: /storage/hasher)
+           }
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithPredeclaredValues(
+					stdlib.BuiltinValues().ToSemaValueDeclarations(),
+				),
+			},
+		},
+	)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
 func TestCheckSignatureAlgorithmCases(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,448 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckEntitlementDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("basic", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          entitlement E
+        `)
+
+		require.NoError(t, err)
+
+		entitlementType := RequireGlobalType(t, checker.Elaboration, "E")
+		assert.IsType(t, &sema.EntitlementType{}, entitlementType)
+	})
+
+	t.Run("nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              entitlement E
+
+              struct S {
+                  access(E) let x: Int
+
+                  init() {
+                      self.x = 1
+                  }
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              entitlement E
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("direct type annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          entitlement E
+
+          fun test(e: E) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.DirectEntitlementAnnotationError{}, errs[0])
+	})
+}
+
+func TestCheckEntitlementMappingDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("basic", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          entitlement X
+          entitlement Y
+
+          entitlement mapping M {
+              X -> Y
+          }
+        `)
+
+		require.NoError(t, err)
+
+		mapType := RequireGlobalType(t, checker.Elaboration, "M")
+		require.IsType(t, &sema.EntitlementMapType{}, mapType)
+		assert.Len(t, mapType.(*sema.EntitlementMapType).Relations, 1)
+	})
+
+	t.Run("non-entitlement in mapping", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          entitlement X
+          struct S {}
+
+          entitlement mapping M {
+              X -> S
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNonEntitlementTypeInMapError{}, errs[0])
+	})
+}
+
+func TestCheckEntitlementAccess(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+      entitlement X
+      entitlement Y
+
+      struct S {
+          access(X) let x: Int
+          access(X, Y) let xy: Int
+          access(X | Y) let xOrY: Int
+
+          init() {
+              self.x = 1
+              self.xy = 2
+              self.xOrY = 3
+          }
+      }
+    `
+
+	t.Run("sufficient entitlements", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X, Y) &S): Int {
+              return ref.x + ref.xy + ref.xOrY
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("disjunctive requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(Y) &S): Int {
+              return ref.xOrY
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("insufficient entitlements", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X) &S): Int {
+              return ref.xy
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InsufficientEntitlementsError{}, errs[0])
+	})
+
+	t.Run("unauthorized reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: &S): Int {
+              return ref.x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InsufficientEntitlementsError{}, errs[0])
+	})
+
+	t.Run("owned value", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(s: S): Int {
+              return s.x + s.xy
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("non-entitlement in access", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct T {}
+
+          struct S {
+              access(T) let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNonEntitlementAccessError{}, errs[0])
+	})
+}
+
+func TestCheckEntitledReferenceSubtyping(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+      entitlement X
+      entitlement Y
+
+      struct S {}
+    `
+
+	t.Run("more entitlements", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X, Y) &S): auth(X) &S {
+              return ref
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("fewer entitlements", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X) &S): auth(X, Y) &S {
+              return ref
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("to disjunction", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X) &S): auth(X | Y) &S {
+              return ref
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("from disjunction", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X | Y) &S): auth(X) &S {
+              return ref
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("to unauthorized", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X) &S): &S {
+              return ref
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("non-entitlement authorization", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(S) &S) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNonEntitlementAuthorizationError{}, errs[0])
+	})
+}
+
+func TestCheckEntitlementMappedAccess(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+      entitlement X
+      entitlement Y
+      entitlement Z
+
+      entitlement mapping M {
+          X -> Y
+      }
+
+      struct T {
+          access(Y) let y: Int
+
+          init() {
+              self.y = 1
+          }
+      }
+
+      struct S {
+          access(M) let t: auth(M) &T
+
+          init(t: auth(Y) &T) {
+              self.t = t
+          }
+      }
+    `
+
+	t.Run("mapped", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(X) &S): auth(Y) &T {
+              return ref.t
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("unmapped", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, declarations+`
+          fun test(ref: auth(Z) &S): Int {
+              return ref.t.y
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InsufficientEntitlementsError{}, errs[0])
+	})
+
+	t.Run("mapped function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          entitlement X
+
+          entitlement mapping M {
+              X -> X
+          }
+
+          struct S {
+              access(M) fun test() {}
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidMappedEntitlementMemberError{}, errs[0])
+	})
+
+	t.Run("mapped authorization outside of field", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          entitlement X
+
+          entitlement mapping M {
+              X -> X
+          }
+
+          struct S {}
+
+          fun test(ref: auth(M) &S) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidMappedAuthorizationOutsideOfFieldError{}, errs[0])
+	})
+}
@@ -20,6 +20,8 @@ package checker
 
 import (
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -124,6 +126,39 @@ func TestCheckEnumRawType(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	rawTypes := append(
+		sema.AllSignedIntegerTypes[:],
+		sema.AllUnsignedIntegerTypes...,
+	)
+
+	for _, rawType := range rawTypes {
+
+		// Capture the loop variable
+		rawType := rawType
+
+		t.Run(fmt.Sprintf("raw type %s", rawType), func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      enum E: %s {
+                          case a
+                          case b
+                      }
+
+                      let e: E? = E(rawValue: 1)
+                      let cases: [E] = E.allCases
+                    `,
+					rawType,
+				),
+			)
+
+			require.NoError(t, err)
+		})
+	}
+
 	t.Run("more than one conformance", func(t *testing.T) {
 
 		t.Parallel()
@@ -140,6 +175,70 @@ func TestCheckEnumRawType(t *testing.T) {
 	})
 }
 
+func TestCheckEnumCaseCountExceedsRawType(t *testing.T) {
+
+	t.Parallel()
+
+	enumWithCases := func(rawType string, caseCount int) string {
+		var builder strings.Builder
+		fmt.Fprintf(&builder, "enum E: %s {\n", rawType)
+		for i := 0; i < caseCount; i++ {
+			fmt.Fprintf(&builder, "  case c%d\n", i)
+		}
+		builder.WriteString("}\n")
+		return builder.String()
+	}
+
+	t.Run("Int8, maximum", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, enumWithCases("Int8", 128))
+		require.NoError(t, err)
+	})
+
+	t.Run("Int8, exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, enumWithCases("Int8", 129))
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.EnumCaseCountExceedsRawTypeError{}, errs[0])
+		caseCountErr := errs[0].(*sema.EnumCaseCountExceedsRawTypeError)
+		assert.Equal(t, 129, caseCountErr.CaseCount)
+		assert.Equal(t, big.NewInt(128), caseCountErr.MaxCaseCount)
+	})
+
+	t.Run("UInt8, maximum", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, enumWithCases("UInt8", 256))
+		require.NoError(t, err)
+	})
+
+	t.Run("Word8, exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, enumWithCases("Word8", 257))
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.EnumCaseCountExceedsRawTypeError{}, errs[0])
+	})
+
+	t.Run("Int, unbounded", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, enumWithCases("Int", 300))
+		require.NoError(t, err)
+	})
+}
+
 func TestCheckInvalidEnumInterface(t *testing.T) {
 
 	t.Parallel()
@@ -240,3 +339,91 @@ func TestCheckEnumInContract(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestCheckEnumAllCases(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      enum E: UInt8 {
+          case a
+          case b
+      }
+
+      let cases: [E] = E.allCases
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckEnumLookup(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+              case b
+          }
+
+          let e: E? = E.lookup(rawValue: 1)
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid raw value type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+          }
+
+          let e = E.lookup(rawValue: "a")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("missing argument label", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+          }
+
+          let e = E.lookup(0)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	})
+}
+
+func TestCheckEnumCaseShadowsSyntheticMember(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      enum E: UInt8 {
+          case allCases
+          case lookup
+      }
+
+      let a: E = E.allCases
+      let b: E = E.lookup
+    `)
+
+	require.NoError(t, err)
+}
@@ -710,3 +710,81 @@ func TestCheckFixedPointMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFixedPointMathFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, ty sema.Type) {
+
+		checker, err := ParseAndCheck(t,
+			fmt.Sprintf(
+				`
+				  let x: %[1]s = 4.0
+				  let min = x.min(2.0)
+				  let max = x.max(2.0)
+				  let abs = x.abs()
+				  let pow = x.pow(2)
+				  let sqrt = x.sqrt()
+				`,
+				ty,
+			),
+		)
+		require.NoError(t, err)
+
+		for _, name := range []string{"min", "max", "abs", "pow", "sqrt"} {
+			require.Equal(t,
+				ty,
+				RequireGlobalValue(t, checker.Elaboration, name),
+			)
+		}
+	}
+
+	for _, ty := range sema.AllFixedPointTypes {
+		// Only test leaf types
+		switch ty {
+		case sema.FixedPointType, sema.SignedFixedPointType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+			test(t, ty)
+		})
+	}
+}
+
+func TestCheckInvalidFixedPointMathFunctionArgument(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let x: UFix64 = 4.0
+      let y: Fix64 = 2.0
+      let min = x.min(y)
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckInvalidFixedPointLiteralRangeErrorDetails(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `let x: UFix64 = 184467440738.0`)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	var rangeErr *sema.InvalidFixedPointLiteralRangeError
+	require.ErrorAs(t, errs[0], &rangeErr)
+
+	assert.Equal(t, "184467440738.0", rangeErr.Literal)
+	assert.Equal(t, sema.UFix64Type, rangeErr.ExpectedType)
+	assert.Equal(t,
+		"expected `UFix64`, in range [0.00000000, 184467440737.09551615]",
+		rangeErr.SecondaryError(),
+	)
+	assert.Equal(t, 16, rangeErr.StartPos.Offset)
+	assert.Equal(t, 29, rangeErr.EndPos.Offset)
+}
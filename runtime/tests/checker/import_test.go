@@ -736,3 +736,54 @@ func TestCheckImportVirtual(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestCheckInvalidImportedTypeMismatchSameName(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub struct Foo {}
+
+          pub fun makeFoo(): Foo {
+              return Foo()
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: utils.ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = ParseAndCheckWithOptions(t,
+		`
+          import makeFoo from "imported"
+
+          pub struct Foo {}
+
+          pub let foo: Foo = makeFoo()
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithImportHandler(
+					func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				),
+			},
+		},
+	)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	typeMismatchErr := errs[0].(*sema.TypeMismatchError)
+
+	assert.Equal(t,
+		"expected `S.test.Foo`, got `S.imported.Foo`: "+
+			"the types have the same name, but are declared in different locations",
+		typeMismatchErr.SecondaryError(),
+	)
+}
@@ -0,0 +1,285 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckInclusiveRange(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let a = InclusiveRange(1, 10)
+      let b = InclusiveRange(10, 1, step: -2)
+      let c = InclusiveRange<UInt8>(UInt8(1), UInt8(10))
+      let d = InclusiveRange(Int16(1), Int16(10), step: Int16(3))
+      let start = a.start
+      let end = c.end
+      let step = d.step
+      let contains = a.contains(5)
+    `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.TypeID("InclusiveRange<Int>"),
+		RequireGlobalValue(t, checker.Elaboration, "a").ID(),
+	)
+
+	assert.Equal(t,
+		sema.TypeID("InclusiveRange<Int>"),
+		RequireGlobalValue(t, checker.Elaboration, "b").ID(),
+	)
+
+	assert.Equal(t,
+		sema.TypeID("InclusiveRange<UInt8>"),
+		RequireGlobalValue(t, checker.Elaboration, "c").ID(),
+	)
+
+	assert.Equal(t,
+		sema.TypeID("InclusiveRange<Int16>"),
+		RequireGlobalValue(t, checker.Elaboration, "d").ID(),
+	)
+
+	assert.Equal(t,
+		sema.IntType,
+		RequireGlobalValue(t, checker.Elaboration, "start"),
+	)
+
+	assert.Equal(t,
+		sema.UInt8Type,
+		RequireGlobalValue(t, checker.Elaboration, "end"),
+	)
+
+	assert.Equal(t,
+		sema.Int16Type,
+		RequireGlobalValue(t, checker.Elaboration, "step"),
+	)
+
+	assert.Equal(t,
+		sema.BoolType,
+		RequireGlobalValue(t, checker.Elaboration, "contains"),
+	)
+}
+
+func TestCheckInclusiveRangeSubtyping(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let a: InclusiveRange = InclusiveRange(1, 10)
+      let b: InclusiveRange<Integer> = InclusiveRange(UInt8(1), UInt8(10))
+      let c: AnyStruct = InclusiveRange(1, 10)
+    `)
+
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidInclusiveRange(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("non-integer bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let r = InclusiveRange(1.0, 2.0)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("mismatched bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let r = InclusiveRange(UInt8(1), Int8(10))
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("mismatched step", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let r = InclusiveRange(UInt8(1), UInt8(10), step: 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+	})
+
+	t.Run("missing end", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let r = InclusiveRange(1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ArgumentCountError{}, errs[0])
+	})
+
+	t.Run("missing step label", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let r = InclusiveRange(1, 10, 2)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	})
+
+	for _, ty := range []sema.Type{
+		sema.IntegerType,
+		sema.SignedIntegerType,
+	} {
+		ty := ty
+
+		t.Run(fmt.Sprintf("abstract %s", ty), func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      let r = InclusiveRange<%[1]s>(1 as %[1]s, 10 as %[1]s)
+                    `,
+					ty,
+				),
+			)
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+		})
+	}
+
+	t.Run("not storable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {
+              let range: InclusiveRange<Int>
+
+              init() {
+                  self.range = InclusiveRange(1, 10)
+              }
+          }
+
+          fun test(account: AuthAccount) {
+              account.save(<-create R(), to: /storage/r)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("not equatable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let equal = InclusiveRange(1, 10) == InclusiveRange(1, 10)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidBinaryOperandsError{}, errs[0])
+	})
+}
+
+func TestCheckForInclusiveRange(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              for i in InclusiveRange(UInt8(1), UInt8(10)) {
+                  let x: UInt8 = i
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("index", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              for index, element in InclusiveRange(10, 1) {
+                  let x: Int = index
+                  let y: Int = element
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              for i in InclusiveRange(UInt8(1), UInt8(10)) {
+                  let x: Int = i
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
@@ -574,3 +574,225 @@ func TestCheckIntegerMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckInvalidIntegerLiteralRangeErrorDetails(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `let x: UInt8 = 300`)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		var rangeErr *sema.InvalidIntegerLiteralRangeError
+		require.ErrorAs(t, errs[0], &rangeErr)
+
+		assert.Equal(t, "300", rangeErr.Literal)
+		assert.Equal(t, sema.UInt8Type, rangeErr.ExpectedType)
+		assert.Equal(t, sema.UInt8TypeMinInt, rangeErr.ExpectedMinInt)
+		assert.Equal(t, sema.UInt8TypeMaxInt, rangeErr.ExpectedMaxInt)
+		assert.Equal(t, 15, rangeErr.StartPos.Offset)
+		assert.Equal(t, 17, rangeErr.EndPos.Offset)
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `let x = Int8(-129)`)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		var rangeErr *sema.InvalidIntegerLiteralRangeError
+		require.ErrorAs(t, errs[0], &rangeErr)
+
+		assert.Equal(t, "-129", rangeErr.Literal)
+		assert.Equal(t, sema.Int8Type, rangeErr.ExpectedType)
+		assert.Equal(t, 13, rangeErr.StartPos.Offset)
+		assert.Equal(t, 16, rangeErr.EndPos.Offset)
+	})
+}
+
+func TestCheckIntegerTruncating(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllIntegerTypes {
+		// Only test leaf types
+		switch ty {
+		case sema.IntegerType, sema.SignedIntegerType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			checker, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      let y: Int256 = 1000
+                      let x = %s.truncating(y)
+                    `,
+					ty,
+				),
+			)
+
+			// Only fixed-size integer types can be truncated to
+
+			switch ty {
+			case sema.IntType, sema.UIntType:
+				errs := ExpectCheckerErrors(t, err, 1)
+
+				require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+
+			default:
+				require.NoError(t, err)
+
+				require.Equal(t,
+					ty,
+					RequireGlobalValue(t, checker.Elaboration, "x"),
+				)
+			}
+		})
+	}
+}
+
+func TestCheckInvalidIntegerTruncatingArgument(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let x = UInt8.truncating(1.5)
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+}
+
+func TestCheckNumberFromBigEndianBytes(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllNumberTypes {
+		// Only test leaf types
+		switch ty {
+		case sema.NumberType, sema.SignedNumberType,
+			sema.IntegerType, sema.SignedIntegerType,
+			sema.FixedPointType, sema.SignedFixedPointType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			checker, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      let x = %s.fromBigEndianBytes([1, 2])
+                    `,
+					ty,
+				),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t,
+				&sema.OptionalType{
+					Type: ty,
+				},
+				RequireGlobalValue(t, checker.Elaboration, "x"),
+			)
+		})
+	}
+}
+
+func TestCheckIntegerTruncatingConvert(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllIntegerTypes {
+		// Only test leaf types
+		switch ty {
+		case sema.IntegerType, sema.SignedIntegerType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			checker, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      let y: Int256 = 1000
+                      let x = y.truncatingConvert<%s>()
+                    `,
+					ty,
+				),
+			)
+
+			// Only fixed-size integer types can be truncated to
+
+			switch ty {
+			case sema.IntType, sema.UIntType:
+				errs := ExpectCheckerErrors(t, err, 1)
+
+				require.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+
+			default:
+				require.NoError(t, err)
+
+				require.Equal(t,
+					ty,
+					RequireGlobalValue(t, checker.Elaboration, "x"),
+				)
+			}
+		})
+	}
+}
+
+func TestCheckInvalidIntegerTruncatingConvert(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("fixed-point target", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let y = 1
+          let x = y.truncatingConvert<Fix64>()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("missing type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let y = 1
+          let x = y.truncatingConvert()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
+	})
+
+	t.Run("fixed-point receiver", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let y = 1.5
+          let x = y.truncatingConvert<UInt8>()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+}
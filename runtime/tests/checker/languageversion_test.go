@@ -0,0 +1,226 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+func parseAndCheckWithLanguageVersion(
+	t *testing.T,
+	code string,
+	version sema.LanguageVersion,
+	options ...sema.Option,
+) (*sema.Checker, error) {
+	return ParseAndCheckWithOptions(t,
+		code,
+		ParseAndCheckOptions{
+			Options: append(
+				[]sema.Option{
+					sema.WithLanguageVersion(version),
+				},
+				options...,
+			),
+		},
+	)
+}
+
+func TestCheckLanguageVersionReservedIdentifiers(t *testing.T) {
+
+	t.Parallel()
+
+	declarations := map[string]string{
+		"variable":    `let %s = 1`,
+		"function":    `fun %s() {}`,
+		"parameter":   `fun test(%s: Int) {}`,
+		"struct":      `struct %s {}`,
+		"interface":   `struct interface %s {}`,
+		"entitlement": `entitlement %s`,
+		"for loop":    `fun test() { for %s in [1] {} }`,
+		"for index":   `fun test() { for %s, x in [1] {} }`,
+	}
+
+	for kind, format := range declarations {
+		kind := kind
+		code := fmt.Sprintf(format, "attach")
+
+		t.Run(kind, func(t *testing.T) {
+
+			t.Parallel()
+
+			t.Run("legacy", func(t *testing.T) {
+
+				t.Parallel()
+
+				_, err := parseAndCheckWithLanguageVersion(t, code, sema.LanguageVersionLegacy)
+				require.NoError(t, err)
+			})
+
+			t.Run("version 1", func(t *testing.T) {
+
+				t.Parallel()
+
+				_, err := parseAndCheckWithLanguageVersion(t, code, sema.LanguageVersion1)
+
+				errs := ExpectCheckerErrors(t, err, 1)
+
+				require.IsType(t, &sema.ReservedIdentifierError{}, errs[0])
+				assert.Equal(t, "attach", errs[0].(*sema.ReservedIdentifierError).Name)
+			})
+		})
+	}
+
+	t.Run("not reserved", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckWithLanguageVersion(t,
+			`let attached = 1`,
+			sema.LanguageVersion1,
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("default is legacy", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `let remove = 1`)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckLanguageVersionDeprecatedDeclarations(t *testing.T) {
+
+	t.Parallel()
+
+	valueDeclarations := stdlib.StandardLibraryFunctions{
+		{
+			Name: "foo",
+			Type: &sema.FunctionType{
+				ReturnTypeAnnotation: &sema.TypeAnnotation{
+					Type: sema.VoidType,
+				},
+			},
+		},
+	}.WithAliases(
+		stdlib.StandardLibraryFunctionAlias{
+			Name:       "bar",
+			Target:     "foo",
+			Deprecated: true,
+		},
+	).ToSemaValueDeclarations()
+
+	const code = `
+      fun test() {
+          bar()
+      }
+    `
+
+	t.Run("legacy", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithLanguageVersion(t,
+			code,
+			sema.LanguageVersionLegacy,
+			sema.WithPredeclaredValues(valueDeclarations),
+		)
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+		require.IsType(t, &sema.DeprecatedDeclarationHint{}, hints[0])
+	})
+
+	t.Run("version 1", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckWithLanguageVersion(t,
+			code,
+			sema.LanguageVersion1,
+			sema.WithPredeclaredValues(valueDeclarations),
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.DeprecatedDeclarationError{}, errs[0])
+
+		deprecationErr := errs[0].(*sema.DeprecatedDeclarationError)
+		assert.Equal(t, "bar", deprecationErr.Name)
+		assert.Equal(t,
+			"consider replacing with `foo`",
+			deprecationErr.SecondaryError(),
+		)
+	})
+}
+
+func TestCheckLanguageVersionImports(t *testing.T) {
+
+	t.Parallel()
+
+	// Imported programs are checked with the language version of the importing program
+
+	importedProgram, err := parser2.ParseProgram(`let mapping = 1`)
+	require.NoError(t, err)
+
+	_, err = parseAndCheckWithLanguageVersion(t,
+		`
+          import "imported"
+        `,
+		sema.LanguageVersion1,
+		sema.WithImportHandler(
+			func(checker *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				subChecker, err := checker.SubChecker(importedProgram, importedLocation)
+				if err != nil {
+					return nil, err
+				}
+				err = subChecker.Check()
+				if err != nil {
+					return nil, err
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: subChecker.Elaboration,
+				}, nil
+			},
+		),
+	)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	require.IsType(t, &sema.ImportedProgramError{}, errs[0])
+
+	importedProgramError := errs[0].(*sema.ImportedProgramError).Err
+
+	errs = ExpectCheckerErrors(t, importedProgramError, 1)
+
+	require.IsType(t, &sema.ReservedIdentifierError{}, errs[0])
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/stdlib"
 )
@@ -1012,3 +1013,93 @@ func TestCheckTopShotContract(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func BenchmarkCheckTopShotContract(b *testing.B) {
+
+	nftProgram, err := parser2.ParseProgram(realNonFungibleTokenContractInterface)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	nftChecker, err := sema.NewChecker(
+		nftProgram,
+		common.AddressLocation{
+			Address: common.MustBytesToAddress([]byte{0x1}),
+			Name:    "NonFungibleToken",
+		},
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	err = nftChecker.Check()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	program, err := parser2.ParseProgram(topShotContract)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	options := []sema.Option{
+		sema.WithImportHandler(
+			func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+				return sema.ElaborationImport{
+					Elaboration: nftChecker.Elaboration,
+				}, nil
+			},
+		),
+		sema.WithPredeclaredValues(
+			stdlib.StandardLibraryFunctions{
+				stdlib.PanicFunction,
+			}.ToSemaValueDeclarations(),
+		),
+	}
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x2}),
+		Name:    "TopShot",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		checker, err := sema.NewChecker(program, location, options...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = checker.Check()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCheckNonFungibleTokenContractInterface(b *testing.B) {
+
+	program, err := parser2.ParseProgram(realNonFungibleTokenContractInterface)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "NonFungibleToken",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		checker, err := sema.NewChecker(program, location)
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = checker.Check()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -20,7 +20,6 @@ package checker
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -339,7 +338,7 @@ func TestCheckSaturatedArithmeticFunctions(t *testing.T) {
 		sema.AllUnsignedFixedPointTypes...,
 	) {
 
-		if ty == sema.UIntType || strings.HasPrefix(ty.String(), "Word") {
+		if ty == sema.UIntType {
 			continue
 		}
 
@@ -34,9 +34,10 @@ func TestCheckPath(t *testing.T) {
 	t.Parallel()
 
 	domainTypes := map[common.PathDomain]sema.Type{
-		common.PathDomainStorage: sema.StoragePathType,
-		common.PathDomainPublic:  sema.PublicPathType,
-		common.PathDomainPrivate: sema.PrivatePathType,
+		common.PathDomainStorage:     sema.StoragePathType,
+		common.PathDomainPublic:      sema.PublicPathType,
+		common.PathDomainPrivate:     sema.PrivatePathType,
+		common.PathDomainTransaction: sema.StoragePathType,
 	}
 
 	test := func(domain common.PathDomain) {
@@ -61,6 +62,12 @@ func TestCheckPath(t *testing.T) {
 				domainTypes[domain],
 				RequireGlobalValue(t, checker.Elaboration, "x"),
 			)
+
+			pathExpressionDomains := checker.Elaboration.PathExpressionDomains
+			require.Len(t, pathExpressionDomains, 2)
+			for _, pathExpressionDomain := range pathExpressionDomains {
+				assert.Equal(t, domain, pathExpressionDomain)
+			}
 		})
 	}
 
@@ -159,7 +166,7 @@ func TestCheckConvertStringToPath(t *testing.T) {
 		})
 	}
 
-	for _, domain := range common.AllPathDomainsByIdentifier {
+	for domain := range domainTypes {
 		test(domain)
 	}
 }
@@ -97,3 +97,153 @@ func TestCheckPragmaInvalidInvocationExprTypeArgs(t *testing.T) {
 	errs := ExpectCheckerErrors(t, err, 1)
 	assert.IsType(t, &sema.InvalidPragmaError{Message: "type arguments not supported"}, errs[0])
 }
+
+func TestCheckPragmaStaticAssert(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+              case b
+          }
+
+          #assert(E.allCases.length == 2, "E has two cases")
+          #assert([1, 2, 3].length == 3)
+          #assert("abc".length == 3 && "a" != "b")
+          #assert(1 + 2 * 3 - 4 == 3 && 7 / 2 == 3 && -7 % 2 == -1)
+          #assert(!false || 1 / 0 == 1)
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+              case b
+          }
+
+          #assert(E.allCases.length == 3, "E has three cases")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.StaticAssertionFailedError{}, errs[0])
+		assert.Equal(t,
+			"static assertion failed: E has three cases",
+			errs[0].Error(),
+		)
+	})
+
+	t.Run("failed, without message", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #assert(1 > 2)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.StaticAssertionFailedError{}, errs[0])
+		assert.Equal(t,
+			"static assertion failed",
+			errs[0].Error(),
+		)
+	})
+
+	t.Run("non-constant condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = 1
+
+          #assert(x == 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NonConstantExpressionError{}, errs[0])
+	})
+
+	t.Run("enum case named allCases", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E: UInt8 {
+              case allCases
+          }
+
+          #assert(E.allCases.rawValue == 0)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NonConstantExpressionError{}, errs[0])
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #assert(1 / 0 == 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ConstantDivisionByZeroError{}, errs[0])
+	})
+
+	t.Run("non-boolean condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #assert(1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("non-literal message", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let message = "message"
+
+          #assert(true, message)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+	})
+
+	t.Run("missing condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #assert()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+	})
+}
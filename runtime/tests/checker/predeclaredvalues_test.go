@@ -216,4 +216,59 @@ func TestCheckPredeclaredValues(t *testing.T) {
 
 		require.IsType(t, &sema.NotDeclaredError{}, errs[1])
 	})
+	t.Run("deprecated alias", func(t *testing.T) {
+
+		valueDeclarations := stdlib.StandardLibraryFunctions{
+			{
+				Name: "foo",
+				Type: &sema.FunctionType{
+					ReturnTypeAnnotation: &sema.TypeAnnotation{
+						Type: sema.VoidType,
+					},
+				},
+			},
+		}.WithAliases(
+			stdlib.StandardLibraryFunctionAlias{
+				Name:       "bar",
+				Target:     "foo",
+				Deprecated: true,
+			},
+			stdlib.StandardLibraryFunctionAlias{
+				Name:   "baz",
+				Target: "foo",
+			},
+		)
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+            pub fun test() {
+                foo()
+                bar()
+                baz()
+            }
+        `,
+			ParseAndCheckOptions{
+				Options: []sema.Option{
+					sema.WithPredeclaredValues(
+						valueDeclarations.ToSemaValueDeclarations(),
+					),
+				},
+			},
+		)
+
+		require.NoError(t, err)
+
+		// Only the use of the deprecated alias 'bar' should be reported
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+		require.IsType(t, &sema.DeprecatedDeclarationHint{}, hints[0])
+
+		hint := hints[0].(*sema.DeprecatedDeclarationHint)
+		require.Equal(t, "bar", hint.Name)
+		require.Equal(t,
+			"`bar` is deprecated, consider replacing with `foo`",
+			hint.Hint(),
+		)
+	})
 }
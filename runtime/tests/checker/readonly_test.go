@@ -0,0 +1,123 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+func parseAndCheckReadOnly(t *testing.T, code string) (*sema.Checker, error) {
+	return ParseAndCheckWithOptions(t,
+		code,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithPredeclaredValues([]sema.ValueDeclaration{
+					stdlib.StandardLibraryValue{
+						Name: "authAccount",
+						Type: sema.AuthAccountType,
+						Kind: common.DeclarationKindConstant,
+					},
+				}),
+				sema.WithReadOnlyEnforcementEnabled(true),
+			},
+		},
+	)
+}
+
+func TestCheckReadOnly(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("read", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckReadOnly(t, `
+          fun test(): &Int? {
+              let balance = authAccount.balance
+              let keys = authAccount.keys.get(keyIndex: 0)
+              return authAccount.borrow<&Int>(from: /storage/foo)
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	writes := map[string]string{
+		"save":            `authAccount.save(1, to: /storage/foo)`,
+		"load":            `authAccount.load<Int>(from: /storage/foo)`,
+		"link":            `authAccount.link<&Int>(/public/foo, target: /storage/foo)`,
+		"unlink":          `authAccount.unlink(/public/foo)`,
+		"contracts.add":   `authAccount.contracts.add(name: "Foo", code: [])`,
+		"keys.revoke":     `authAccount.keys.revoke(keyIndex: 0)`,
+		"removePublicKey": `authAccount.removePublicKey(0)`,
+	}
+
+	for name, code := range writes {
+		code := code
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := parseAndCheckReadOnly(t, "fun test() { "+code+" }")
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			require.IsType(t, &sema.ReadOnlyViolationError{}, errs[0])
+		})
+	}
+
+	t.Run("not enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          fun test() {
+              authAccount.save(1, to: /storage/foo)
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("member name", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckReadOnly(t, `
+          fun test() {
+              let save = authAccount.save
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ReadOnlyViolationError{}, errs[0])
+
+		violationErr := errs[0].(*sema.ReadOnlyViolationError)
+		assert.Equal(t, "save", violationErr.Name)
+		assert.Equal(t, sema.AuthAccountType, violationErr.ContainerType)
+	})
+}
@@ -19,6 +19,7 @@
 package checker
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -324,3 +325,66 @@ func TestCheckStringToLower(t *testing.T) {
 		RequireGlobalValue(t, checker.Elaboration, "x"),
 	)
 }
+
+func TestCheckStringFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	optionalStringType := &sema.OptionalType{
+		Type: sema.StringType,
+	}
+
+	tests := map[string]sema.Type{
+		`"Abc".toUpper()`:                                         sema.StringType,
+		`"a,b".split(separator: ",")`:                             sema.StringArrayType,
+		`"abc".replaceAll(of: "b", with: "x")`:                    sema.StringType,
+		`"abc".index(of: "c")`:                                    sema.IntType,
+		`"abc".contains("b")`:                                     sema.BoolType,
+		`"7".padLeft(toLength: 3, with: "0")`:                     sema.StringType,
+		`"abc".utf16`:                                             sema.UTF16CodeUnitArrayType,
+		`String.fromUTF8([0x61, 0x62])`:                           optionalStringType,
+		`String.fromUTF16([0x61, 0x62])`:                          optionalStringType,
+		`String.join(["a", "b"], separator: ", ")`:                sema.StringType,
+		`("a" as Character).utf8`:                                 sema.ByteArrayType,
+		`("a" as Character).utf16`:                                sema.UTF16CodeUnitArrayType,
+		`("a" as Character).toString()`:                           sema.StringType,
+		`"abc"[0].toString().concat("d")`:                         sema.StringType,
+		`"abc".split(separator: "")[0].toUpper()`:                 sema.StringType,
+		`String.join("a,b".split(separator: ","), separator: "")`: sema.StringType,
+	}
+
+	for code, expectedType := range tests {
+
+		// Capture the loop variables
+		code := code
+		expectedType := expectedType
+
+		t.Run(code, func(t *testing.T) {
+
+			t.Parallel()
+
+			checker, err := ParseAndCheck(t,
+				fmt.Sprintf(`let x = %s`, code),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t,
+				expectedType,
+				RequireGlobalValue(t, checker.Elaboration, "x"),
+			)
+		})
+	}
+}
+
+func TestCheckInvalidStringPadLeftWithString(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+        let x = "7".padLeft(toLength: 3, with: "00")
+	`)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.InvalidCharacterLiteralError{}, errs[0])
+}
@@ -109,6 +109,51 @@ func TestCheckTransactions(t *testing.T) {
 		)
 	})
 
+	t.Run("ValidPrepareParameters, signer array", func(t *testing.T) {
+		test(
+			t,
+			`
+              transaction {
+
+                  prepare(x: AuthAccount, signers: [AuthAccount]) {
+                      let address: Address = signers[0].address
+                  }
+              }
+            `,
+			nil,
+		)
+	})
+
+	t.Run("InvalidPrepareParameters, signer array not last", func(t *testing.T) {
+		test(
+			t,
+			`
+              transaction {
+
+                  prepare(signers: [AuthAccount], y: AuthAccount) {}
+              }
+            `,
+			[]error{
+				&sema.InvalidTransactionPrepareParameterTypeError{},
+			},
+		)
+	})
+
+	t.Run("InvalidPrepareParameters, nested signer array", func(t *testing.T) {
+		test(
+			t,
+			`
+              transaction {
+
+                  prepare(signers: [[AuthAccount]]) {}
+              }
+            `,
+			[]error{
+				&sema.InvalidTransactionPrepareParameterTypeError{},
+			},
+		)
+	})
+
 	t.Run("InvalidFieldUninitialized", func(t *testing.T) {
 		test(
 			t,
@@ -0,0 +1,164 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package differential provides a harness for differential testing:
+// Programs are executed using two different execution paths,
+// e.g. the interpreter and a storage round-trip of the result,
+// and the results are cross-checked.
+// Diverging programs are minimized before they are reported.
+package differential
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/tests/reduce"
+)
+
+// Result is the result of executing a program.
+// Either the value or the error is set.
+//
+type Result struct {
+	Value cadence.Value
+	Error error
+}
+
+func (r Result) String() string {
+	if r.Error != nil {
+		return fmt.Sprintf("error: %s", r.Error)
+	}
+	if r.Value == nil {
+		return "<nil>"
+	}
+	ty := r.Value.Type()
+	if ty == nil {
+		return r.Value.String()
+	}
+	return fmt.Sprintf("%s: %s", ty.ID(), r.Value)
+}
+
+// Equal returns true if both results have the same value,
+// or if both results are errors with the same message.
+//
+func (r Result) Equal(other Result) bool {
+	return r.String() == other.String()
+}
+
+// Executor is an execution path for programs.
+// The program is expected to declare a function `main`,
+// which has no parameters and returns the result.
+//
+type Executor struct {
+	Name    string
+	Execute func(code string) Result
+}
+
+// Divergence is a program for which two executors produced different results.
+//
+type Divergence struct {
+	Program          string
+	MinimizedProgram string
+	Executors        [2]string
+	Results          [2]Result
+}
+
+func (d Divergence) String() string {
+	var builder strings.Builder
+	builder.WriteString("diverging program:\n")
+	builder.WriteString(d.MinimizedProgram)
+	builder.WriteString("\n")
+	for i, executor := range d.Executors {
+		_, _ = fmt.Fprintf(&builder, "%s: %s\n", executor, d.Results[i])
+	}
+	return builder.String()
+}
+
+// Harness cross-checks the results of two executors
+// for programs produced by a generator.
+//
+type Harness struct {
+	Executors [2]Executor
+	// Generate returns a new random program
+	Generate func(random *rand.Rand) string
+	// Minimize returns a smaller program for which the predicate still holds.
+	// If nil, diverging programs are not minimized.
+	Minimize func(program string, predicate func(program string) bool) string
+}
+
+// Check executes the given program using both executors
+// and returns the divergence if the results differ, or nil otherwise.
+//
+func (h Harness) Check(program string) *Divergence {
+	results, diverges := h.execute(program)
+	if !diverges {
+		return nil
+	}
+
+	minimizedProgram := program
+	if h.Minimize != nil {
+		minimizedProgram = h.Minimize(
+			program,
+			func(program string) bool {
+				_, diverges := h.execute(program)
+				return diverges
+			},
+		)
+		results, _ = h.execute(minimizedProgram)
+	}
+
+	return &Divergence{
+		Program:          program,
+		MinimizedProgram: minimizedProgram,
+		Executors: [2]string{
+			h.Executors[0].Name,
+			h.Executors[1].Name,
+		},
+		Results: results,
+	}
+}
+
+func (h Harness) execute(program string) (results [2]Result, diverges bool) {
+	for i, executor := range h.Executors {
+		results[i] = executor.Execute(program)
+	}
+	return results, !results[0].Equal(results[1])
+}
+
+// Run generates and checks the given number of programs,
+// and returns all divergences.
+//
+func (h Harness) Run(random *rand.Rand, count int) []Divergence {
+	var divergences []Divergence
+	for i := 0; i < count; i++ {
+		program := h.Generate(random)
+		divergence := h.Check(program)
+		if divergence != nil {
+			divergences = append(divergences, *divergence)
+		}
+	}
+	return divergences
+}
+
+// MinimizeLines removes lines from the program,
+// as long as the predicate still holds for the remaining program.
+//
+func MinimizeLines(program string, predicate func(program string) bool) string {
+	return reduce.ReduceLines(program, predicate)
+}
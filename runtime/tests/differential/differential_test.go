@@ -0,0 +1,163 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package differential
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateProgram(t *testing.T) {
+
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		program := GenerateProgram(random)
+
+		result := InterpreterExecutor.Execute(program)
+		require.NoError(t, result.Error, program)
+	}
+}
+
+func TestDifferentialInterpreterStorageRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	harness := Harness{
+		Executors: [2]Executor{
+			InterpreterExecutor,
+			StorageRoundTripExecutor,
+		},
+		Generate: GenerateProgram,
+		Minimize: MinimizeLines,
+	}
+
+	random := rand.New(rand.NewSource(1))
+
+	divergences := harness.Run(random, 200)
+	for _, divergence := range divergences {
+		t.Error(divergence)
+	}
+}
+
+func TestDifferentialHarness(t *testing.T) {
+
+	t.Parallel()
+
+	// An executor which diverges from the interpreter
+	// if the program appends the value 42
+
+	divergingExecutor := Executor{
+		Name: "diverging",
+		Execute: func(code string) Result {
+			result := InterpreterExecutor.Execute(code)
+			if result.Error == nil && strings.Contains(code, "append(42") {
+				return Result{
+					Value: nil,
+				}
+			}
+			return result
+		},
+	}
+
+	harness := Harness{
+		Executors: [2]Executor{
+			InterpreterExecutor,
+			divergingExecutor,
+		},
+		Minimize: MinimizeLines,
+	}
+
+	t.Run("equal", func(t *testing.T) {
+
+		t.Parallel()
+
+		divergence := harness.Check(`
+          pub fun main(): [AnyStruct] {
+              let values: [AnyStruct] = []
+              values.append(1)
+              return values
+          }
+        `)
+
+		assert.Nil(t, divergence)
+	})
+
+	t.Run("diverging", func(t *testing.T) {
+
+		t.Parallel()
+
+		const program = `
+pub fun main(): [AnyStruct] {
+    let values: [AnyStruct] = []
+    values.append(1)
+    values.append(42)
+    values.append("a")
+    return values
+}
+`
+
+		divergence := harness.Check(program)
+		require.NotNil(t, divergence)
+
+		assert.Equal(t, program, divergence.Program)
+		// NOTE: the empty lines are removed, too
+		assert.Equal(t,
+			`pub fun main(): [AnyStruct] {
+    let values: [AnyStruct] = []
+    values.append(42)
+    return values
+}`,
+			divergence.MinimizedProgram,
+		)
+		assert.Equal(t,
+			[2]string{"interpreter", "diverging"},
+			divergence.Executors,
+		)
+		assert.Equal(t,
+			"[42]",
+			divergence.Results[0].String(),
+		)
+		assert.Equal(t,
+			"<nil>",
+			divergence.Results[1].String(),
+		)
+	})
+}
+
+func TestMinimizeLines(t *testing.T) {
+
+	t.Parallel()
+
+	minimized := MinimizeLines(
+		"a\nb\nc\nd",
+		func(program string) bool {
+			return strings.Contains(program, "b") &&
+				strings.Contains(program, "d")
+		},
+	)
+
+	assert.Equal(t, "b\nd", minimized)
+}
@@ -0,0 +1,263 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package differential
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+const mainFunctionName = "main"
+
+// InterpreterExecutor executes the program using the interpreter,
+// and exports the result.
+//
+var InterpreterExecutor = Executor{
+	Name: "interpreter",
+	Execute: func(code string) Result {
+		return execute(code, nil)
+	},
+}
+
+// StorageRoundTripExecutor executes the program using the interpreter,
+// moves the result into account storage, commits the storage to a ledger,
+// reads the result back from a new storage, and exports it.
+// This cross-checks value transfers, encoding, and decoding.
+//
+var StorageRoundTripExecutor = Executor{
+	Name: "storage round-trip",
+	Execute: func(code string) Result {
+		return execute(code, storageRoundTrip)
+	},
+}
+
+var roundTripAddress = common.Address{0, 0, 0, 0, 0, 0, 0, 1}
+
+const roundTripDomain = "storage"
+const roundTripKey = "result"
+
+// roundTrip is an optional step of an executor,
+// which is performed on the result of the program before it is exported
+//
+type roundTrip func(
+	program *interpreter.Program,
+	inter *interpreter.Interpreter,
+	ledger atree.Ledger,
+	storage *runtime.Storage,
+	value interpreter.Value,
+) (
+	*interpreter.Interpreter,
+	interpreter.Value,
+)
+
+func storageRoundTrip(
+	program *interpreter.Program,
+	inter *interpreter.Interpreter,
+	ledger atree.Ledger,
+	storage *runtime.Storage,
+	value interpreter.Value,
+) (
+	*interpreter.Interpreter,
+	interpreter.Value,
+) {
+	value = value.Transfer(
+		inter,
+		interpreter.ReturnEmptyLocationRange,
+		atree.Address(roundTripAddress),
+		true,
+		nil,
+	)
+
+	storage.GetStorageMap(roundTripAddress, roundTripDomain).
+		WriteValue(inter, roundTripKey, value)
+
+	err := storage.Commit(inter, false)
+	if err != nil {
+		panic(err)
+	}
+
+	// Read the value from a new storage,
+	// so it is decoded from the ledger
+
+	newStorage := runtime.NewStorage(ledger)
+
+	newInter := newInterpreter(program, newStorage)
+
+	value = newStorage.GetStorageMap(roundTripAddress, roundTripDomain).
+		ReadValue(roundTripKey)
+
+	return newInter, value
+}
+
+func execute(code string, roundTrip roundTrip) (result Result) {
+
+	// Internal errors of the parser, checker, or interpreter
+	// are reported as results, as they might be the divergence
+
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			result = Result{
+				Error: err,
+			}
+		}
+	}()
+
+	program, err := parseAndCheck(code)
+	if err != nil {
+		return Result{
+			Error: err,
+		}
+	}
+
+	ledger := newLedger()
+	storage := runtime.NewStorage(ledger)
+
+	inter := newInterpreter(program, storage)
+
+	err = inter.Interpret()
+	if err != nil {
+		return Result{
+			Error: err,
+		}
+	}
+
+	value, err := inter.Invoke(mainFunctionName)
+	if err != nil {
+		return Result{
+			Error: err,
+		}
+	}
+
+	if roundTrip != nil {
+		inter, value = roundTrip(program, inter, ledger, storage, value)
+	}
+
+	exportedValue, err := runtime.ExportValue(value, inter)
+	if err != nil {
+		return Result{
+			Error: err,
+		}
+	}
+
+	return Result{
+		Value: exportedValue,
+	}
+}
+
+func parseAndCheck(code string) (*interpreter.Program, error) {
+	program, err := parser2.ParseProgram(code)
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		utils.TestLocation,
+		sema.WithAccessCheckMode(sema.AccessCheckModeNotSpecifiedUnrestricted),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	return interpreter.ProgramFromChecker(checker), nil
+}
+
+func newInterpreter(program *interpreter.Program, storage interpreter.Storage) *interpreter.Interpreter {
+	var uuid uint64
+
+	inter, err := interpreter.NewInterpreter(
+		program,
+		utils.TestLocation,
+		interpreter.WithStorage(storage),
+		interpreter.WithUUIDHandler(func() (uint64, error) {
+			uuid++
+			return uuid, nil
+		}),
+		interpreter.WithAtreeValueValidationEnabled(true),
+		// NOTE: storage validation is disabled, like in the runtime,
+		// as values are temporarily not referenced during transfers
+		interpreter.WithAtreeStorageValidationEnabled(false),
+		interpreter.WithInvariantChecksEnabled(true),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return inter
+}
+
+// ledger is a simple in-memory implementation of atree.Ledger
+//
+type ledger struct {
+	values         map[string][]byte
+	storageIndices map[string]uint64
+}
+
+var _ atree.Ledger = &ledger{}
+
+func newLedger() *ledger {
+	return &ledger{
+		values:         map[string][]byte{},
+		storageIndices: map[string]uint64{},
+	}
+}
+
+func ledgerKey(owner, key []byte) string {
+	return string(owner) + "|" + string(key)
+}
+
+func (l *ledger) GetValue(owner, key []byte) ([]byte, error) {
+	return l.values[ledgerKey(owner, key)], nil
+}
+
+func (l *ledger) SetValue(owner, key, value []byte) error {
+	l.values[ledgerKey(owner, key)] = value
+	return nil
+}
+
+func (l *ledger) ValueExists(owner, key []byte) (bool, error) {
+	return len(l.values[ledgerKey(owner, key)]) > 0, nil
+}
+
+func (l *ledger) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	index := l.storageIndices[string(owner)] + 1
+	l.storageIndices[string(owner)] = index
+
+	var result atree.StorageIndex
+	binary.BigEndian.PutUint64(result[:], index)
+	return result, nil
+}
@@ -0,0 +1,213 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package differential
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// maxValueDepth is the maximum nesting depth of generated values
+//
+const maxValueDepth = 3
+
+// GenerateProgram generates a program which declares a function `main`,
+// which returns an array of random storable values.
+// Each value is appended to the result in a separate statement,
+// so the program can be minimized line by line.
+//
+func GenerateProgram(random *rand.Rand) string {
+	var builder strings.Builder
+
+	builder.WriteString("pub fun main(): [AnyStruct] {\n")
+	builder.WriteString("    let values: [AnyStruct] = []\n")
+
+	count := 1 + random.Intn(5)
+	for i := 0; i < count; i++ {
+		ty := generateType(random, 0)
+		value := generateValue(random, ty, 0)
+		_, _ = fmt.Fprintf(&builder, "    values.append(%s as %s)\n", value, ty)
+	}
+
+	builder.WriteString("    return values\n")
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+type generatedType interface {
+	fmt.Stringer
+	isGeneratedType()
+}
+
+type simpleType string
+
+func (t simpleType) String() string {
+	return string(t)
+}
+
+func (simpleType) isGeneratedType() {}
+
+type arrayType struct {
+	elementType generatedType
+}
+
+func (t arrayType) String() string {
+	return fmt.Sprintf("[%s]", t.elementType)
+}
+
+func (arrayType) isGeneratedType() {}
+
+type dictionaryType struct {
+	keyType   simpleType
+	valueType generatedType
+}
+
+func (t dictionaryType) String() string {
+	return fmt.Sprintf("{%s: %s}", t.keyType, t.valueType)
+}
+
+func (dictionaryType) isGeneratedType() {}
+
+type optionalType struct {
+	innerType generatedType
+}
+
+func (t optionalType) String() string {
+	return fmt.Sprintf("%s?", t.innerType)
+}
+
+func (optionalType) isGeneratedType() {}
+
+var simpleTypes = []simpleType{
+	"Int",
+	"Int8",
+	"UInt64",
+	"Bool",
+	"String",
+	"Address",
+	"UFix64",
+}
+
+var keyTypes = []simpleType{
+	"Int",
+	"String",
+	"Bool",
+}
+
+func generateType(random *rand.Rand, depth int) generatedType {
+	if depth >= maxValueDepth {
+		return simpleTypes[random.Intn(len(simpleTypes))]
+	}
+
+	switch random.Intn(6) {
+	case 0:
+		return arrayType{
+			elementType: generateType(random, depth+1),
+		}
+	case 1:
+		return dictionaryType{
+			keyType:   keyTypes[random.Intn(len(keyTypes))],
+			valueType: generateType(random, depth+1),
+		}
+	case 2:
+		// NOTE: nested optionals are not generated,
+		// as `nil` would be ambiguous
+		innerType := generateType(random, depth+1)
+		if _, ok := innerType.(optionalType); ok {
+			return innerType
+		}
+		return optionalType{
+			innerType: innerType,
+		}
+	default:
+		return simpleTypes[random.Intn(len(simpleTypes))]
+	}
+}
+
+func generateValue(random *rand.Rand, ty generatedType, depth int) string {
+	switch ty := ty.(type) {
+	case arrayType:
+		count := random.Intn(4)
+		elements := make([]string, count)
+		for i := range elements {
+			elements[i] = generateValue(random, ty.elementType, depth+1)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
+
+	case dictionaryType:
+		count := random.Intn(4)
+		entries := make([]string, 0, count)
+		seenKeys := map[string]struct{}{}
+		for i := 0; i < count; i++ {
+			key := generateValue(random, ty.keyType, depth+1)
+			if _, ok := seenKeys[key]; ok {
+				continue
+			}
+			seenKeys[key] = struct{}{}
+			value := generateValue(random, ty.valueType, depth+1)
+			entries = append(entries, fmt.Sprintf("%s: %s", key, value))
+		}
+		return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+
+	case optionalType:
+		if random.Intn(3) == 0 {
+			return "nil"
+		}
+		return generateValue(random, ty.innerType, depth+1)
+
+	case simpleType:
+		return generateSimpleValue(random, ty)
+	}
+
+	panic(fmt.Errorf("unsupported type: %s", ty))
+}
+
+func generateSimpleValue(random *rand.Rand, ty simpleType) string {
+	switch ty {
+	case "Int":
+		return fmt.Sprint(random.Int63() - random.Int63())
+	case "Int8":
+		return fmt.Sprint(random.Intn(256) - 128)
+	case "UInt64":
+		return fmt.Sprint(random.Uint64())
+	case "Bool":
+		return fmt.Sprint(random.Intn(2) == 0)
+	case "String":
+		return fmt.Sprintf("%q", generateString(random))
+	case "Address":
+		return fmt.Sprintf("0x%x", random.Uint64())
+	case "UFix64":
+		return fmt.Sprintf("%d.%08d", random.Intn(1000000), random.Intn(100000000))
+	}
+
+	panic(fmt.Errorf("unsupported type: %s", ty))
+}
+
+const stringCharacters = "abcxyz019 _-"
+
+func generateString(random *rand.Rand) string {
+	length := random.Intn(8)
+	characters := make([]byte, length)
+	for i := range characters {
+		characters[i] = stringCharacters[random.Intn(len(stringCharacters))]
+	}
+	return string(characters)
+}
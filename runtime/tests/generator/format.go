@@ -0,0 +1,278 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// FormatFunctionDeclaration returns the source code for the given function declaration.
+//
+// Only the subset of the AST which is generated is supported:
+// The function may not have parameters, pre-conditions, or post-conditions.
+//
+func FormatFunctionDeclaration(declaration *ast.FunctionDeclaration) string {
+	var builder strings.Builder
+
+	if declaration.Access != ast.AccessNotSpecified {
+		builder.WriteString(declaration.Access.Keyword())
+		builder.WriteByte(' ')
+	}
+
+	builder.WriteString("fun ")
+	builder.WriteString(declaration.Identifier.Identifier)
+	builder.WriteString("()")
+
+	if declaration.ReturnTypeAnnotation != nil {
+		builder.WriteString(": ")
+		builder.WriteString(declaration.ReturnTypeAnnotation.Type.String())
+	}
+
+	builder.WriteByte(' ')
+	formatBlock(&builder, declaration.FunctionBlock.Block, 0)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+const indentation = "    "
+
+func formatBlock(builder *strings.Builder, block *ast.Block, indent int) {
+	builder.WriteString("{\n")
+
+	for _, statement := range block.Statements {
+		builder.WriteString(strings.Repeat(indentation, indent+1))
+		formatStatement(builder, statement, indent+1)
+		builder.WriteByte('\n')
+	}
+
+	builder.WriteString(strings.Repeat(indentation, indent))
+	builder.WriteString("}")
+}
+
+func formatStatement(builder *strings.Builder, statement ast.Statement, indent int) {
+	switch statement := statement.(type) {
+	case *ast.VariableDeclaration:
+		if statement.IsConstant {
+			builder.WriteString("let ")
+		} else {
+			builder.WriteString("var ")
+		}
+		builder.WriteString(statement.Identifier.Identifier)
+		if statement.TypeAnnotation != nil {
+			builder.WriteString(": ")
+			builder.WriteString(statement.TypeAnnotation.Type.String())
+		}
+		builder.WriteByte(' ')
+		builder.WriteString(statement.Transfer.Operation.Operator())
+		builder.WriteByte(' ')
+		builder.WriteString(FormatExpression(statement.Value))
+
+	case *ast.AssignmentStatement:
+		builder.WriteString(FormatExpression(statement.Target))
+		builder.WriteByte(' ')
+		builder.WriteString(statement.Transfer.Operation.Operator())
+		builder.WriteByte(' ')
+		builder.WriteString(FormatExpression(statement.Value))
+
+	case *ast.IfStatement:
+		builder.WriteString("if ")
+		builder.WriteString(FormatExpression(statement.Test.(ast.Expression)))
+		builder.WriteByte(' ')
+		formatBlock(builder, statement.Then, indent)
+		if statement.Else != nil {
+			builder.WriteString(" else ")
+			formatBlock(builder, statement.Else, indent)
+		}
+
+	case *ast.ForStatement:
+		builder.WriteString("for ")
+		builder.WriteString(statement.Identifier.Identifier)
+		builder.WriteString(" in ")
+		builder.WriteString(FormatExpression(statement.Value))
+		builder.WriteByte(' ')
+		formatBlock(builder, statement.Block, indent)
+
+	case *ast.ReturnStatement:
+		builder.WriteString("return")
+		if statement.Expression != nil {
+			builder.WriteByte(' ')
+			builder.WriteString(FormatExpression(statement.Expression))
+		}
+
+	case *ast.ExpressionStatement:
+		builder.WriteString(FormatExpression(statement.Expression))
+
+	default:
+		panic(fmt.Errorf("cannot format statement: %T", statement))
+	}
+}
+
+// FormatExpression returns the source code for the given expression.
+//
+// Unlike the documents returned by the Doc functions of the AST,
+// sub-expressions are parenthesized where necessary,
+// so parsing the result results in the same AST.
+//
+func FormatExpression(expression ast.Expression) string {
+	switch expression := expression.(type) {
+	case *ast.BoolExpression:
+		if expression.Value {
+			return "true"
+		}
+		return "false"
+
+	case *ast.NilExpression:
+		return "nil"
+
+	case *ast.StringExpression:
+		return ast.QuoteString(expression.Value)
+
+	case *ast.IntegerExpression:
+		return expression.String()
+
+	case *ast.FixedPointExpression:
+		if expression.Negative {
+			return "-" + expression.PositiveLiteral
+		}
+		return expression.PositiveLiteral
+
+	case *ast.IdentifierExpression:
+		return expression.Identifier.Identifier
+
+	case *ast.ArrayExpression:
+		values := make([]string, 0, len(expression.Values))
+		for _, value := range expression.Values {
+			values = append(values, FormatExpression(value))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(values, ", "))
+
+	case *ast.DictionaryExpression:
+		entries := make([]string, 0, len(expression.Entries))
+		for _, entry := range expression.Entries {
+			entries = append(
+				entries,
+				fmt.Sprintf(
+					"%s: %s",
+					FormatExpression(entry.Key),
+					FormatExpression(entry.Value),
+				),
+			)
+		}
+		return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+
+	case *ast.MemberExpression:
+		separator := "."
+		if expression.Optional {
+			separator = "?."
+		}
+		return formatOperand(expression.Expression) + separator + expression.Identifier.Identifier
+
+	case *ast.IndexExpression:
+		return fmt.Sprintf(
+			"%s[%s]",
+			formatOperand(expression.TargetExpression),
+			FormatExpression(expression.IndexingExpression),
+		)
+
+	case *ast.InvocationExpression:
+		arguments := make([]string, 0, len(expression.Arguments))
+		for _, argument := range expression.Arguments {
+			formattedArgument := FormatExpression(argument.Expression)
+			if argument.Label != "" {
+				formattedArgument = argument.Label + ": " + formattedArgument
+			}
+			arguments = append(arguments, formattedArgument)
+		}
+		return fmt.Sprintf(
+			"%s(%s)",
+			formatOperand(expression.InvokedExpression),
+			strings.Join(arguments, ", "),
+		)
+
+	case *ast.UnaryExpression:
+		return expression.Operation.Symbol() + formatOperand(expression.Expression)
+
+	case *ast.BinaryExpression:
+		return fmt.Sprintf(
+			"%s %s %s",
+			formatOperand(expression.Left),
+			expression.Operation.Symbol(),
+			formatOperand(expression.Right),
+		)
+
+	case *ast.ConditionalExpression:
+		return fmt.Sprintf(
+			"%s ? %s : %s",
+			formatOperand(expression.Test),
+			formatOperand(expression.Then),
+			formatOperand(expression.Else),
+		)
+
+	case *ast.CastingExpression:
+		return fmt.Sprintf(
+			"%s %s %s",
+			formatOperand(expression.Expression),
+			expression.Operation.Symbol(),
+			expression.TypeAnnotation.Type.String(),
+		)
+
+	default:
+		panic(fmt.Errorf("cannot format expression: %T", expression))
+	}
+}
+
+// formatOperand returns the source code for the given expression,
+// parenthesized if it is not a primary expression
+//
+func formatOperand(expression ast.Expression) string {
+	formatted := FormatExpression(expression)
+	if isPrimaryExpression(expression) {
+		return formatted
+	}
+	return fmt.Sprintf("(%s)", formatted)
+}
+
+func isPrimaryExpression(expression ast.Expression) bool {
+	switch expression := expression.(type) {
+	case *ast.BoolExpression,
+		*ast.NilExpression,
+		*ast.StringExpression,
+		*ast.IdentifierExpression,
+		*ast.ArrayExpression,
+		*ast.DictionaryExpression,
+		*ast.MemberExpression,
+		*ast.IndexExpression,
+		*ast.InvocationExpression:
+
+		return true
+
+	case *ast.IntegerExpression:
+		return expression.Value.Sign() >= 0
+
+	case *ast.FixedPointExpression:
+		return !expression.Negative
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,960 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package generator generates random, but type-correct Cadence programs,
+// for property-based testing of the parser, checker, and interpreter.
+//
+// Programs are generated as ASTs. The sema type system guides the generation:
+// Each expression is generated for an expected type, and only productions which
+// result in a value of the expected type are considered. For example, the members
+// of variables in scope are looked up through the sema types of the variables,
+// and a member is only accessed or invoked if it has or returns the expected type.
+//
+// Generated programs are deterministic, terminate, and are expected
+// to execute without errors.
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Config configures the size of generated programs.
+//
+type Config struct {
+	// MaxStatements is the maximum number of statements in a block,
+	// excluding the final return statement
+	MaxStatements int
+	// MaxBlockDepth is the maximum nesting depth of blocks
+	MaxBlockDepth int
+	// MaxExpressionDepth is the maximum nesting depth of expressions
+	MaxExpressionDepth int
+	// MaxTypeDepth is the maximum nesting depth of types
+	MaxTypeDepth int
+	// MaxElements is the maximum number of elements in array and dictionary literals
+	MaxElements int
+}
+
+var DefaultConfig = Config{
+	MaxStatements:      5,
+	MaxBlockDepth:      2,
+	MaxExpressionDepth: 3,
+	MaxTypeDepth:       2,
+	MaxElements:        3,
+}
+
+// Program is a generated program.
+// It consists of a single function, named main, which has no parameters.
+//
+type Program struct {
+	Declaration *ast.FunctionDeclaration
+	ReturnType  sema.Type
+}
+
+// AST returns the program as an AST program.
+//
+func (p *Program) AST() *ast.Program {
+	return ast.NewProgram([]ast.Declaration{p.Declaration})
+}
+
+// Code returns the source code of the program.
+//
+func (p *Program) Code() string {
+	return FormatFunctionDeclaration(p.Declaration)
+}
+
+// simpleTypes are the non-container types of generated values
+//
+var simpleTypes = []sema.Type{
+	sema.IntType,
+	sema.Int8Type,
+	sema.UInt8Type,
+	sema.UInt64Type,
+	sema.Word8Type,
+	sema.Fix64Type,
+	sema.UFix64Type,
+	sema.BoolType,
+	sema.StringType,
+	&sema.AddressType{},
+}
+
+// keyTypes are the key types of generated dictionaries
+//
+var keyTypes = []sema.Type{
+	sema.IntType,
+	sema.UInt8Type,
+	sema.BoolType,
+	sema.StringType,
+}
+
+// comparableTypes are the types for which comparison expressions are generated
+//
+var comparableTypes = []sema.Type{
+	sema.IntType,
+	sema.Int8Type,
+	sema.UInt64Type,
+	sema.Fix64Type,
+	sema.UFix64Type,
+}
+
+// integerTypes are the types which can be converted to Int
+//
+var integerTypes = []sema.Type{
+	sema.Int8Type,
+	sema.UInt8Type,
+	sema.UInt64Type,
+	sema.Word8Type,
+}
+
+// excludedMembers are the names of members which are never accessed,
+// because they may fail at run-time, e.g. because of an invalid index.
+//
+var excludedMembers = map[string]struct{}{
+	"abs":              {},
+	"decodeHex":        {},
+	"pow":              {},
+	"remove":           {},
+	"removeFirst":      {},
+	"removeLast":       {},
+	"saturatingDivide": {},
+	"slice":            {},
+	"sqrt":             {},
+}
+
+type variable struct {
+	name     string
+	ty       sema.Type
+	constant bool
+}
+
+// Generator generates random programs.
+//
+type Generator struct {
+	random        *rand.Rand
+	config        Config
+	scopes        [][]variable
+	variableCount int
+}
+
+// NewGenerator returns a new generator, which uses the given source of randomness.
+//
+func NewGenerator(random *rand.Rand, config Config) *Generator {
+	return &Generator{
+		random: random,
+		config: config,
+	}
+}
+
+// GenerateProgram generates a random program, using the default configuration.
+//
+func GenerateProgram(random *rand.Rand) *Program {
+	return NewGenerator(random, DefaultConfig).Program()
+}
+
+// Program generates a random program.
+//
+func (g *Generator) Program() *Program {
+	g.scopes = nil
+	g.variableCount = 0
+
+	returnType := g.randomType(g.config.MaxTypeDepth)
+
+	block := g.block(0, func() ast.Statement {
+		return &ast.ReturnStatement{
+			Expression: g.expression(returnType, g.config.MaxExpressionDepth, true),
+		}
+	})
+
+	return &Program{
+		Declaration: &ast.FunctionDeclaration{
+			Access: ast.AccessPublic,
+			Identifier: ast.Identifier{
+				Identifier: "main",
+			},
+			ParameterList: &ast.ParameterList{},
+			ReturnTypeAnnotation: &ast.TypeAnnotation{
+				Type: astType(returnType),
+			},
+			FunctionBlock: &ast.FunctionBlock{
+				Block: block,
+			},
+		},
+		ReturnType: returnType,
+	}
+}
+
+// Types
+
+func (g *Generator) randomType(depth int) sema.Type {
+	if depth <= 0 || g.random.Intn(2) == 0 {
+		return g.pick(simpleTypes)
+	}
+
+	switch g.random.Intn(3) {
+	case 0:
+		return &sema.VariableSizedType{
+			Type: g.randomType(depth - 1),
+		}
+
+	case 1:
+		return &sema.DictionaryType{
+			KeyType:   g.pick(keyTypes),
+			ValueType: g.randomType(depth - 1),
+		}
+
+	default:
+		return &sema.OptionalType{
+			Type: g.randomNonOptionalType(depth - 1),
+		}
+	}
+}
+
+func (g *Generator) randomNonOptionalType(depth int) sema.Type {
+	for {
+		ty := g.randomType(depth)
+		if _, ok := ty.(*sema.OptionalType); !ok {
+			return ty
+		}
+	}
+}
+
+func (g *Generator) pick(types []sema.Type) sema.Type {
+	return types[g.random.Intn(len(types))]
+}
+
+// isGeneratable returns true if values of the given type can be generated
+//
+func isGeneratable(ty sema.Type) bool {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		if _, ok := ty.Type.(*sema.OptionalType); ok {
+			return false
+		}
+		return isGeneratable(ty.Type)
+
+	case *sema.VariableSizedType:
+		return isGeneratable(ty.Type)
+
+	case *sema.DictionaryType:
+		return containsType(keyTypes, ty.KeyType) &&
+			isGeneratable(ty.ValueType)
+
+	default:
+		return containsType(simpleTypes, ty)
+	}
+}
+
+func containsType(types []sema.Type, ty sema.Type) bool {
+	for _, other := range types {
+		if ty.Equal(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// astType returns the AST type for the given sema type
+//
+func astType(ty sema.Type) ast.Type {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		return &ast.OptionalType{
+			Type: astType(ty.Type),
+		}
+
+	case *sema.VariableSizedType:
+		return &ast.VariableSizedType{
+			Type: astType(ty.Type),
+		}
+
+	case *sema.DictionaryType:
+		return &ast.DictionaryType{
+			KeyType:   astType(ty.KeyType),
+			ValueType: astType(ty.ValueType),
+		}
+
+	default:
+		return &ast.NominalType{
+			Identifier: ast.Identifier{
+				Identifier: ty.String(),
+			},
+		}
+	}
+}
+
+// Scopes
+
+func (g *Generator) enterScope() {
+	g.scopes = append(g.scopes, nil)
+}
+
+func (g *Generator) leaveScope() {
+	g.scopes = g.scopes[:len(g.scopes)-1]
+}
+
+func (g *Generator) declare(ty sema.Type, constant bool) string {
+	name := fmt.Sprintf("v%d", g.variableCount)
+	g.variableCount++
+
+	last := len(g.scopes) - 1
+	g.scopes[last] = append(
+		g.scopes[last],
+		variable{
+			name:     name,
+			ty:       ty,
+			constant: constant,
+		},
+	)
+
+	return name
+}
+
+func (g *Generator) variables(filter func(variable) bool) []variable {
+	var result []variable
+	for _, scope := range g.scopes {
+		for _, variable := range scope {
+			if filter(variable) {
+				result = append(result, variable)
+			}
+		}
+	}
+	return result
+}
+
+// Statements
+
+// block generates a block of statements, in a new scope.
+// If the given final function is not nil, the statement it generates is appended.
+//
+func (g *Generator) block(depth int, final func() ast.Statement) *ast.Block {
+	g.enterScope()
+	defer g.leaveScope()
+
+	count := g.random.Intn(g.config.MaxStatements + 1)
+
+	statements := make([]ast.Statement, 0, count+1)
+	for i := 0; i < count; i++ {
+		statements = append(statements, g.statement(depth))
+	}
+
+	if final != nil {
+		statements = append(statements, final())
+	}
+
+	return &ast.Block{
+		Statements: statements,
+	}
+}
+
+func (g *Generator) statement(depth int) ast.Statement {
+	var candidates []func() ast.Statement
+
+	candidates = append(candidates, g.variableDeclaration)
+
+	assignable := g.variables(func(variable variable) bool {
+		return !variable.constant
+	})
+	if len(assignable) > 0 {
+		candidates = append(candidates, func() ast.Statement {
+			return g.assignment(assignable)
+		})
+	}
+
+	if depth < g.config.MaxBlockDepth {
+		candidates = append(
+			candidates,
+			func() ast.Statement {
+				return g.ifStatement(depth)
+			},
+			func() ast.Statement {
+				return g.forStatement(depth)
+			},
+		)
+	}
+
+	return candidates[g.random.Intn(len(candidates))]()
+}
+
+func (g *Generator) variableDeclaration() ast.Statement {
+	ty := g.randomType(g.config.MaxTypeDepth)
+	constant := g.random.Intn(2) == 0
+
+	// NOTE: generate the value before declaring the variable,
+	// so the value does not refer to the variable itself
+
+	value := g.expression(ty, g.config.MaxExpressionDepth, true)
+
+	name := g.declare(ty, constant)
+
+	return &ast.VariableDeclaration{
+		IsConstant: constant,
+		Identifier: ast.Identifier{
+			Identifier: name,
+		},
+		TypeAnnotation: &ast.TypeAnnotation{
+			Type: astType(ty),
+		},
+		Value: value,
+		Transfer: &ast.Transfer{
+			Operation: ast.TransferOperationCopy,
+		},
+	}
+}
+
+func (g *Generator) assignment(assignable []variable) ast.Statement {
+	variable := assignable[g.random.Intn(len(assignable))]
+
+	return &ast.AssignmentStatement{
+		Target: identifierExpression(variable.name),
+		Transfer: &ast.Transfer{
+			Operation: ast.TransferOperationCopy,
+		},
+		Value: g.expression(variable.ty, g.config.MaxExpressionDepth, true),
+	}
+}
+
+func (g *Generator) ifStatement(depth int) ast.Statement {
+	statement := &ast.IfStatement{
+		Test: g.expression(sema.BoolType, g.config.MaxExpressionDepth, true),
+		Then: g.block(depth+1, nil),
+	}
+
+	if g.random.Intn(2) == 0 {
+		statement.Else = g.block(depth+1, nil)
+	}
+
+	return statement
+}
+
+func (g *Generator) forStatement(depth int) ast.Statement {
+	elementType := g.randomType(g.config.MaxTypeDepth - 1)
+
+	// NOTE: the type of the iterated value is not known from the context
+
+	value := g.expression(
+		&sema.VariableSizedType{
+			Type: elementType,
+		},
+		g.config.MaxExpressionDepth,
+		false,
+	)
+
+	// Declare the loop variable in a separate scope,
+	// so it is available in the body, but not after the loop
+
+	g.enterScope()
+	defer g.leaveScope()
+
+	name := g.declare(elementType, true)
+
+	return &ast.ForStatement{
+		Identifier: ast.Identifier{
+			Identifier: name,
+		},
+		Value: value,
+		Block: g.block(depth+1, nil),
+	}
+}
+
+// Expressions
+
+// expression generates an expression of the given type.
+//
+// If expected is false, the type of the expression is not known
+// from the context, and literals are cast to the given type.
+//
+func (g *Generator) expression(ty sema.Type, depth int, expected bool) ast.Expression {
+	var candidates []func() ast.Expression
+
+	candidates = append(candidates, func() ast.Expression {
+		return g.literal(ty, depth, expected)
+	})
+
+	for _, variable := range g.variables(func(variable variable) bool {
+		return variable.ty.Equal(ty)
+	}) {
+		name := variable.name
+		candidates = append(candidates, func() ast.Expression {
+			return identifierExpression(name)
+		})
+	}
+
+	if depth > 0 {
+		candidates = append(candidates, g.compositeExpressions(ty, depth)...)
+	}
+
+	return candidates[g.random.Intn(len(candidates))]()
+}
+
+// compositeExpressions returns generators for expressions of the given type,
+// which have sub-expressions
+//
+func (g *Generator) compositeExpressions(ty sema.Type, depth int) []func() ast.Expression {
+	var candidates []func() ast.Expression
+
+	candidates = append(candidates, func() ast.Expression {
+		return &ast.ConditionalExpression{
+			Test: g.expression(sema.BoolType, depth-1, true),
+			Then: g.expression(ty, depth-1, false),
+			Else: g.expression(ty, depth-1, false),
+		}
+	})
+
+	switch ty {
+	case sema.IntType:
+		candidates = append(
+			candidates,
+			g.binaryExpressions(ty, depth, ast.OperationPlus, ast.OperationMinus, ast.OperationMul)...,
+		)
+
+		candidates = append(candidates, func() ast.Expression {
+			return g.conversion(ty, g.pick(integerTypes), depth)
+		})
+
+	case sema.Word8Type:
+		candidates = append(
+			candidates,
+			g.binaryExpressions(ty, depth, ast.OperationPlus, ast.OperationMinus, ast.OperationMul)...,
+		)
+
+	case sema.BoolType:
+		candidates = append(
+			candidates,
+			func() ast.Expression {
+				return &ast.UnaryExpression{
+					Operation:  ast.OperationNegate,
+					Expression: g.expression(sema.BoolType, depth-1, true),
+				}
+			},
+			func() ast.Expression {
+				operandType := g.pick(comparableTypes)
+				operation := g.pickOperation(
+					ast.OperationLess,
+					ast.OperationLessEqual,
+					ast.OperationGreater,
+					ast.OperationGreaterEqual,
+				)
+				return g.binaryExpression(operandType, operation, depth)
+			},
+			func() ast.Expression {
+				operandType := g.pick(simpleTypes)
+				operation := g.pickOperation(
+					ast.OperationEqual,
+					ast.OperationNotEqual,
+				)
+				return g.binaryExpression(operandType, operation, depth)
+			},
+		)
+
+		candidates = append(
+			candidates,
+			g.binaryExpressions(ty, depth, ast.OperationAnd, ast.OperationOr)...,
+		)
+	}
+
+	if optionalType, ok := ty.(*sema.OptionalType); ok {
+		for _, variable := range g.variables(isIndexableBy(optionalType.Type)) {
+			dictionaryType := variable.ty.(*sema.DictionaryType)
+			name := variable.name
+			candidates = append(candidates, func() ast.Expression {
+				return &ast.IndexExpression{
+					TargetExpression:   identifierExpression(name),
+					IndexingExpression: g.expression(dictionaryType.KeyType, depth-1, true),
+				}
+			})
+		}
+	} else {
+		candidates = append(candidates, func() ast.Expression {
+			return &ast.BinaryExpression{
+				Operation: ast.OperationNilCoalesce,
+				Left: g.expression(
+					&sema.OptionalType{
+						Type: ty,
+					},
+					depth-1,
+					false,
+				),
+				Right: g.expression(ty, depth-1, false),
+			}
+		})
+	}
+
+	candidates = append(candidates, g.memberExpressions(ty, depth)...)
+
+	return candidates
+}
+
+// isIndexableBy returns a filter for dictionary variables with the given value type
+//
+func isIndexableBy(valueType sema.Type) func(variable) bool {
+	return func(variable variable) bool {
+		dictionaryType, ok := variable.ty.(*sema.DictionaryType)
+		return ok && dictionaryType.ValueType.Equal(valueType)
+	}
+}
+
+func (g *Generator) pickOperation(operations ...ast.Operation) ast.Operation {
+	return operations[g.random.Intn(len(operations))]
+}
+
+func (g *Generator) binaryExpressions(
+	ty sema.Type,
+	depth int,
+	operations ...ast.Operation,
+) []func() ast.Expression {
+	candidates := make([]func() ast.Expression, 0, len(operations))
+	for _, operation := range operations {
+		operation := operation
+		candidates = append(candidates, func() ast.Expression {
+			return g.binaryExpression(ty, operation, depth)
+		})
+	}
+	return candidates
+}
+
+func (g *Generator) binaryExpression(operandType sema.Type, operation ast.Operation, depth int) ast.Expression {
+	return &ast.BinaryExpression{
+		Operation: operation,
+		Left:      g.expression(operandType, depth-1, false),
+		Right:     g.expression(operandType, depth-1, false),
+	}
+}
+
+// conversion generates an invocation of the conversion function of the given type,
+// with an argument of the given argument type.
+//
+// The argument is always cast, as the parameter type of conversion functions is abstract.
+//
+func (g *Generator) conversion(ty sema.Type, argumentType sema.Type, depth int) ast.Expression {
+	return &ast.InvocationExpression{
+		InvokedExpression: identifierExpression(ty.String()),
+		Arguments: ast.Arguments{
+			{
+				Expression: castExpression(
+					g.expression(argumentType, depth-1, true),
+					argumentType,
+				),
+			},
+		},
+	}
+}
+
+// memberExpressions returns generators for member accesses and member function invocations
+// on variables in scope, which result in a value of the given type.
+//
+// The members are looked up using the sema type of each variable.
+//
+func (g *Generator) memberExpressions(ty sema.Type, depth int) []func() ast.Expression {
+	var candidates []func() ast.Expression
+
+	for _, variable := range g.variables(func(variable) bool { return true }) {
+
+		members := variable.ty.GetMembers()
+
+		// NOTE: iterate over the members in a deterministic order
+
+		names := make([]string, 0, len(members))
+		for name := range members {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if _, ok := excludedMembers[name]; ok {
+				continue
+			}
+
+			member := members[name].Resolve(name, ast.Range{}, func(error) {})
+			if member == nil {
+				continue
+			}
+
+			memberExpression := memberExpression(variable.name, name)
+
+			memberType := member.TypeAnnotation.Type
+
+			functionType, ok := memberType.(*sema.FunctionType)
+			if !ok {
+				if memberType.Equal(ty) {
+					candidates = append(candidates, func() ast.Expression {
+						return memberExpression
+					})
+				}
+				continue
+			}
+
+			if !isGeneratableInvocation(functionType, ty) {
+				continue
+			}
+
+			candidates = append(candidates, func() ast.Expression {
+				return g.invocation(memberExpression, functionType, depth)
+			})
+		}
+	}
+
+	return candidates
+}
+
+// isGeneratableInvocation returns true if an invocation of a function of the given type
+// results in a value of the given type, and arguments for all parameters can be generated.
+//
+// Parameters must have equatable types, as some functions, e.g. the contains function of arrays,
+// are only available if the argument type is equatable.
+//
+func isGeneratableInvocation(functionType *sema.FunctionType, ty sema.Type) bool {
+	if len(functionType.TypeParameters) > 0 ||
+		!functionType.ReturnTypeAnnotation.Type.Equal(ty) {
+
+		return false
+	}
+
+	for _, parameter := range functionType.Parameters {
+		parameterType := parameter.TypeAnnotation.Type
+		if !isGeneratable(parameterType) || !parameterType.IsEquatable() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (g *Generator) invocation(
+	invokedExpression ast.Expression,
+	functionType *sema.FunctionType,
+	depth int,
+) ast.Expression {
+	arguments := make(ast.Arguments, 0, len(functionType.Parameters))
+
+	for _, parameter := range functionType.Parameters {
+		label := parameter.EffectiveArgumentLabel()
+		if label == sema.ArgumentLabelNotRequired {
+			label = ""
+		}
+
+		arguments = append(
+			arguments,
+			&ast.Argument{
+				Label:      label,
+				Expression: g.expression(parameter.TypeAnnotation.Type, depth-1, true),
+			},
+		)
+	}
+
+	return &ast.InvocationExpression{
+		InvokedExpression: invokedExpression,
+		Arguments:         arguments,
+	}
+}
+
+func identifierExpression(name string) *ast.IdentifierExpression {
+	return &ast.IdentifierExpression{
+		Identifier: ast.Identifier{
+			Identifier: name,
+		},
+	}
+}
+
+func memberExpression(variableName string, memberName string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Expression: identifierExpression(variableName),
+		Identifier: ast.Identifier{
+			Identifier: memberName,
+		},
+	}
+}
+
+// Literals
+
+// literal generates a literal of the given type.
+//
+// If expected is false, the type of the literal is not known from the context,
+// and the literal is cast to the given type, unless it is the type inferred for the literal.
+//
+func (g *Generator) literal(ty sema.Type, depth int, expected bool) ast.Expression {
+	var literal ast.Expression
+	inferred := false
+
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		if g.random.Intn(3) == 0 {
+			literal = &ast.NilExpression{}
+		} else {
+			literal = g.literal(ty.Type, depth, true)
+		}
+
+	case *sema.VariableSizedType:
+		count := g.random.Intn(g.config.MaxElements + 1)
+		values := make([]ast.Expression, 0, count)
+		for i := 0; i < count; i++ {
+			values = append(values, g.element(ty.Type, depth))
+		}
+		literal = &ast.ArrayExpression{
+			Values: values,
+		}
+
+	case *sema.DictionaryType:
+		literal = g.dictionaryLiteral(ty, depth)
+
+	case *sema.AddressType:
+		literal = g.addressLiteral()
+
+	default:
+		switch ty {
+		case sema.BoolType:
+			literal = &ast.BoolExpression{
+				Value: g.random.Intn(2) == 0,
+			}
+			inferred = true
+
+		case sema.StringType:
+			literal = &ast.StringExpression{
+				Value: g.stringValue(),
+			}
+			inferred = true
+
+		case sema.IntType:
+			literal = integerLiteral(big.NewInt(int64(g.random.Intn(201) - 100)))
+			inferred = true
+
+		case sema.Int8Type:
+			literal = integerLiteral(big.NewInt(int64(g.random.Intn(256) - 128)))
+
+		case sema.UInt8Type, sema.Word8Type:
+			literal = integerLiteral(big.NewInt(int64(g.random.Intn(256))))
+
+		case sema.UInt64Type:
+			literal = integerLiteral(new(big.Int).SetUint64(g.random.Uint64()))
+
+		case sema.Fix64Type:
+			literal = g.fixedPointLiteral(g.random.Intn(2) == 0)
+
+		case sema.UFix64Type:
+			literal = g.fixedPointLiteral(false)
+
+		default:
+			panic(fmt.Errorf("cannot generate literal of type %s", ty))
+		}
+	}
+
+	if expected || inferred {
+		return literal
+	}
+
+	return castExpression(literal, ty)
+}
+
+func castExpression(expression ast.Expression, ty sema.Type) ast.Expression {
+	return &ast.CastingExpression{
+		Expression: expression,
+		Operation:  ast.OperationCast,
+		TypeAnnotation: &ast.TypeAnnotation{
+			Type: astType(ty),
+		},
+	}
+}
+
+// element generates an element of an array or dictionary literal
+//
+func (g *Generator) element(ty sema.Type, depth int) ast.Expression {
+	if depth > 0 {
+		return g.expression(ty, depth-1, true)
+	}
+	return g.literal(ty, 0, true)
+}
+
+func (g *Generator) dictionaryLiteral(ty *sema.DictionaryType, depth int) ast.Expression {
+	count := g.random.Intn(g.config.MaxElements + 1)
+
+	entries := make([]ast.DictionaryEntry, 0, count)
+
+	// NOTE: only use literal keys, and avoid duplicate keys,
+	// as the order of evaluation of duplicate keys would determine the result
+
+	keys := map[string]struct{}{}
+
+	for i := 0; i < count; i++ {
+		key := g.literal(ty.KeyType, 0, true)
+		formattedKey := FormatExpression(key)
+		if _, ok := keys[formattedKey]; ok {
+			continue
+		}
+		keys[formattedKey] = struct{}{}
+
+		entries = append(
+			entries,
+			ast.DictionaryEntry{
+				Key:   key,
+				Value: g.element(ty.ValueType, depth),
+			},
+		)
+	}
+
+	return &ast.DictionaryExpression{
+		Entries: entries,
+	}
+}
+
+func integerLiteral(value *big.Int) *ast.IntegerExpression {
+	return &ast.IntegerExpression{
+		PositiveLiteral: new(big.Int).Abs(value).String(),
+		Value:           value,
+		Base:            10,
+	}
+}
+
+func (g *Generator) addressLiteral() ast.Expression {
+	value := new(big.Int).SetUint64(uint64(g.random.Intn(0x10000)))
+	return &ast.IntegerExpression{
+		PositiveLiteral: fmt.Sprintf("0x%x", value),
+		Value:           value,
+		Base:            16,
+	}
+}
+
+func (g *Generator) fixedPointLiteral(negative bool) ast.Expression {
+	const scale = 8
+
+	integer := g.random.Intn(1000)
+	fractional := g.random.Intn(100_000_000)
+
+	return &ast.FixedPointExpression{
+		PositiveLiteral: fmt.Sprintf("%d.%0*d", integer, scale, fractional),
+		Negative:        negative,
+		UnsignedInteger: big.NewInt(int64(integer)),
+		Fractional:      big.NewInt(int64(fractional)),
+		Scale:           scale,
+	}
+}
+
+var stringCharacters = []string{"a", "b", "Z", "0", " ", "é", "ü", "日"}
+
+func (g *Generator) stringValue() string {
+	var builder strings.Builder
+	length := g.random.Intn(4)
+	for i := 0; i < length; i++ {
+		builder.WriteString(stringCharacters[g.random.Intn(len(stringCharacters))])
+	}
+	return builder.String()
+}
@@ -0,0 +1,132 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func check(t *testing.T, program *ast.Program, code string) *sema.Checker {
+	checker, err := sema.NewChecker(
+		program,
+		utils.TestLocation,
+		sema.WithAccessCheckMode(sema.AccessCheckModeNotSpecifiedUnrestricted),
+	)
+	require.NoError(t, err)
+
+	err = checker.Check()
+	require.NoError(t, err, code)
+
+	return checker
+}
+
+func TestGenerateProgramRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	const count = 300
+
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < count; i++ {
+
+		program := GenerateProgram(random)
+		code := program.Code()
+
+		// The generated AST is type-correct
+
+		check(t, program.AST(), code)
+
+		// Formatting the parsed program results in the same code
+
+		parsedProgram, err := parser2.ParseProgram(code)
+		require.NoError(t, err, code)
+
+		functionDeclarations := parsedProgram.FunctionDeclarations()
+		require.Len(t, functionDeclarations, 1)
+
+		require.Equal(t, code, FormatFunctionDeclaration(functionDeclarations[0]))
+
+		// The parsed program is type-correct, and executes without an error
+
+		checker := check(t, parsedProgram, code)
+
+		inter, err := interpreter.NewInterpreter(
+			interpreter.ProgramFromChecker(checker),
+			checker.Location,
+			interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+			interpreter.WithInvariantChecksEnabled(true),
+		)
+		require.NoError(t, err)
+
+		err = inter.Interpret()
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("main")
+		require.NoError(t, err, code)
+
+		// The result conforms to the declared return type
+
+		resultType, err := inter.ConvertStaticToSemaType(result.StaticType())
+		require.NoError(t, err)
+
+		assert.True(t,
+			sema.IsSubType(resultType, program.ReturnType),
+			"%s is not a subtype of %s:\n%s",
+			resultType,
+			program.ReturnType,
+			code,
+		)
+	}
+}
+
+func TestGenerateProgramDeterministic(t *testing.T) {
+
+	t.Parallel()
+
+	first := GenerateProgram(rand.New(rand.NewSource(42))).Code()
+	second := GenerateProgram(rand.New(rand.NewSource(42))).Code()
+
+	assert.Equal(t, first, second)
+}
+
+func TestFormatExpression(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `(1 + 2) * -3 ?? ((true ? a : b) as Int8)`
+
+	expression, errs := parser2.ParseExpression(code)
+	require.Empty(t, errs)
+
+	assert.Equal(t,
+		`((1 + 2) * (-3)) ?? ((true ? a : b) as Int8)`,
+		FormatExpression(expression),
+	)
+}
@@ -0,0 +1,189 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package golden provides utilities for snapshot ("golden file") testing.
+//
+// Checker diagnostics and execution results are rendered into canonical text snapshots,
+// which are compared against golden files. When tests are run with the -update flag,
+// the golden files are updated instead, so changes to e.g. error messages
+// can be reviewed as diffs of the golden files.
+package golden
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+var update = flag.Bool("update", false, "update golden files instead of comparing against them")
+
+// Extension is the file extension of golden files
+//
+const Extension = ".golden"
+
+// Path returns the path of the golden file for the given input file,
+// i.e. the input path with the extension replaced.
+//
+func Path(inputPath string) string {
+	return strings.TrimSuffix(inputPath, filepath.Ext(inputPath)) + Extension
+}
+
+// Inputs returns the paths of all files matching the given pattern, in sorted order.
+//
+func Inputs(t testing.TB, pattern string) []string {
+	paths, err := filepath.Glob(pattern)
+	require.NoError(t, err)
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// Assert compares the given snapshot against the contents of the golden file at the given path.
+//
+// If the -update flag is set, the golden file is written instead.
+//
+func Assert(t testing.TB, path string, actual string) {
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		require.NoError(t, err)
+
+		err = ioutil.WriteFile(path, []byte(actual), 0644)
+		require.NoError(t, err)
+
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		require.Failf(t,
+			"missing golden file",
+			"%s does not exist. run the test with -update to create it",
+			path,
+		)
+	}
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		string(expected),
+		actual,
+		"snapshot differs from golden file %s. run the test with -update to update it",
+		path,
+	)
+}
+
+// RenderErrors renders the given error into a canonical text snapshot.
+//
+// Parent errors, like checker errors, are flattened, and each child error is rendered on its own line,
+// prefixed with its position. Secondary error messages and error notes are rendered on the following lines.
+//
+func RenderErrors(err error) string {
+	var builder strings.Builder
+	renderErrors(&builder, err)
+	return builder.String()
+}
+
+func renderErrors(builder *strings.Builder, err error) {
+	if err == nil {
+		return
+	}
+
+	if parentError, ok := err.(errors.ParentError); ok {
+		for _, childError := range parentError.ChildErrors() {
+			renderErrors(builder, childError)
+		}
+		return
+	}
+
+	builder.WriteString(renderPosition(err))
+	builder.WriteString("error: ")
+	builder.WriteString(err.Error())
+	builder.WriteByte('\n')
+
+	if secondaryError, ok := err.(errors.SecondaryError); ok {
+		secondaryMessage := secondaryError.SecondaryError()
+		if secondaryMessage != "" {
+			builder.WriteString("  ")
+			builder.WriteString(secondaryMessage)
+			builder.WriteByte('\n')
+		}
+	}
+
+	if errorNotes, ok := err.(errors.ErrorNotes); ok {
+		for _, errorNote := range errorNotes.ErrorNotes() {
+			builder.WriteString("  ")
+			builder.WriteString(renderPosition(errorNote))
+			builder.WriteString("note: ")
+			builder.WriteString(errorNote.Message())
+			builder.WriteByte('\n')
+		}
+	}
+}
+
+// renderPosition renders the range of the given value, if it has a position,
+// in the format `start-end: `, where each position is `line:column`.
+//
+func renderPosition(value interface{}) string {
+	positioned, ok := value.(ast.HasPosition)
+	if !ok {
+		return ""
+	}
+
+	startPosition := positioned.StartPosition()
+	endPosition := positioned.EndPosition()
+
+	return fmt.Sprintf(
+		"%d:%d-%d:%d: ",
+		startPosition.Line,
+		startPosition.Column,
+		endPosition.Line,
+		endPosition.Column,
+	)
+}
+
+// RenderResult renders the result of an execution into a canonical text snapshot:
+// Either the error, or the type and the value of the result.
+//
+func RenderResult(value interpreter.Value, err error) string {
+	if err != nil {
+		return RenderErrors(err)
+	}
+
+	if value == nil {
+		return "result: <nil>\n"
+	}
+
+	return fmt.Sprintf(
+		"type: %s\nresult: %s\n",
+		value.StaticType(),
+		value,
+	)
+}
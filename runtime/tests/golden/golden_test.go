@@ -0,0 +1,99 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golden
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestPath(t *testing.T) {
+
+	t.Parallel()
+
+	assert.Equal(t, "testdata/test.golden", Path("testdata/test.cdc"))
+	assert.Equal(t, "testdata/test.golden", Path("testdata/test"))
+}
+
+type testError struct {
+	ast.Range
+}
+
+var _ errors.SecondaryError = testError{}
+
+func (testError) Error() string {
+	return "test error"
+}
+
+func (testError) SecondaryError() string {
+	return "secondary"
+}
+
+func TestRenderErrors(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t, "", RenderErrors(nil))
+	})
+
+	t.Run("parent", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := &sema.CheckerError{
+			Errors: []error{
+				testError{
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 2},
+						EndPos:   ast.Position{Line: 1, Column: 4},
+					},
+				},
+				fmt.Errorf("no position"),
+			},
+		}
+
+		assert.Equal(t,
+			"1:2-1:4: error: test error\n"+
+				"  secondary\n"+
+				"error: no position\n",
+			RenderErrors(err),
+		)
+	})
+}
+
+func TestRenderResult(t *testing.T) {
+
+	t.Parallel()
+
+	assert.Equal(t,
+		"type: Int\nresult: 42\n",
+		RenderResult(interpreter.NewIntValueFromInt64(42), nil),
+	)
+}
@@ -0,0 +1,485 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretAuthAccountStorageCapabilities(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      resource R {
+          let id: Int
+
+          init(id: Int) {
+              self.id = id
+          }
+      }
+
+      fun save() {
+          account.save(<-create R(id: 42), to: /storage/r)
+      }
+
+      fun issue(): Capability<&R> {
+          return account.capabilities.storage.issue<&R>(/storage/r)
+      }
+
+      fun borrow(_ cap: Capability<&R>): Int? {
+          return cap.borrow()?.id
+      }
+
+      fun check(_ cap: Capability<&R>): Bool {
+          return cap.check()
+      }
+
+      fun issueOther(): Capability<&R> {
+          return account.capabilities.storage.issue<&R>(/storage/other)
+      }
+
+      fun controllerIDs(): [UInt64] {
+          let ids: [UInt64] = []
+          for controller in account.capabilities.storage.getControllers(forPath: /storage/r) {
+              ids.append(controller.capabilityID)
+          }
+          return ids
+      }
+
+      fun controllerTarget(_ id: UInt64): StoragePath? {
+          return account.capabilities.storage.getController(byCapabilityID: id)?.target()
+      }
+
+      fun controllerBorrowType(_ id: UInt64): Type? {
+          return account.capabilities.storage.getController(byCapabilityID: id)?.borrowType
+      }
+
+      fun revoke(_ id: UInt64) {
+          account.capabilities.storage.getController(byCapabilityID: id)!.revoke()
+      }
+    `
+
+	address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+	t.Run("issue", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, getAccountValues := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("save")
+		require.NoError(t, err)
+
+		first, err := inter.Invoke("issue")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.CapabilityValue{}, first)
+		firstCapability := first.(*interpreter.CapabilityValue)
+		assert.Equal(t, address, firstCapability.Address)
+		assert.Equal(t, interpreter.UInt64Value(1), firstCapability.ID)
+		assert.Equal(t, interpreter.EmptyPathValue, firstCapability.Path)
+
+		second, err := inter.Invoke("issue")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.CapabilityValue{}, second)
+		assert.Equal(t, interpreter.UInt64Value(2), second.(*interpreter.CapabilityValue).ID)
+
+		// stored value + ID counter + two controllers + path index
+		require.Len(t, getAccountValues(), 5)
+
+		controllers := 0
+		for key, value := range getAccountValues() {
+			if key.domain != interpreter.CapabilityControllerStorageDomain {
+				continue
+			}
+			controllers++
+			require.IsType(t, interpreter.StorageCapabilityControllerValue{}, value)
+			assert.Equal(t,
+				interpreter.PathValue{
+					Domain:     1,
+					Identifier: "r",
+				},
+				value.(interpreter.StorageCapabilityControllerValue).TargetPath,
+			)
+		}
+		assert.Equal(t, 2, controllers)
+
+		ids, err := inter.Invoke("controllerIDs")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt64,
+				},
+				address.ToAddress(),
+				interpreter.UInt64Value(1),
+				interpreter.UInt64Value(2),
+			),
+			ids,
+		)
+
+		target, err := inter.Invoke("controllerTarget", interpreter.UInt64Value(1))
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewSomeValueNonCopying(
+				interpreter.PathValue{
+					Domain:     1,
+					Identifier: "r",
+				},
+			),
+			target,
+		)
+
+		borrowType, err := inter.Invoke("controllerBorrowType", interpreter.UInt64Value(2))
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.SomeValue{}, borrowType)
+		typeValue := borrowType.(*interpreter.SomeValue).Value.(interpreter.TypeValue)
+		assert.Equal(t, "&S.test.R", typeValue.Type.String())
+
+		missing, err := inter.Invoke("controllerTarget", interpreter.UInt64Value(3))
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, missing)
+	})
+
+	t.Run("borrow and check", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, code)
+
+		capability, err := inter.Invoke("issue")
+		require.NoError(t, err)
+
+		// Not stored yet
+
+		result, err := inter.Invoke("check", capability)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.BoolValue(false), result)
+
+		result, err = inter.Invoke("borrow", capability)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+
+		_, err = inter.Invoke("save")
+		require.NoError(t, err)
+
+		result, err = inter.Invoke("check", capability)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.BoolValue(true), result)
+
+		result, err = inter.Invoke("borrow", capability)
+		require.NoError(t, err)
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(42)),
+			result,
+		)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, getAccountValues := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("save")
+		require.NoError(t, err)
+
+		first, err := inter.Invoke("issue")
+		require.NoError(t, err)
+
+		second, err := inter.Invoke("issue")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("revoke", interpreter.UInt64Value(1))
+		require.NoError(t, err)
+
+		// stored value + ID counter + remaining controller + path index
+		require.Len(t, getAccountValues(), 4)
+
+		result, err := inter.Invoke("borrow", first)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+
+		result, err = inter.Invoke("check", first)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.BoolValue(false), result)
+
+		// Other capabilities for the same path are unaffected
+
+		result, err = inter.Invoke("check", second)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.BoolValue(true), result)
+
+		// IDs are not reused
+
+		third, err := inter.Invoke("issue")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.UInt64Value(3), third.(*interpreter.CapabilityValue).ID)
+	})
+
+	t.Run("get controllers", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, getAccountValues := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("issue")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("issueOther")
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("issue")
+		require.NoError(t, err)
+
+		// Only the controllers for the path are returned
+
+		ids, err := inter.Invoke("controllerIDs")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt64,
+				},
+				address.ToAddress(),
+				interpreter.UInt64Value(1),
+				interpreter.UInt64Value(3),
+			),
+			ids,
+		)
+
+		// Revoking all controllers for the path removes its index
+
+		_, err = inter.Invoke("revoke", interpreter.UInt64Value(1))
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("revoke", interpreter.UInt64Value(3))
+		require.NoError(t, err)
+
+		ids, err = inter.Invoke("controllerIDs")
+		require.NoError(t, err)
+		assert.Equal(t, 0, ids.(*interpreter.ArrayValue).Count())
+
+		for key := range getAccountValues() {
+			if key.domain != interpreter.PathCapabilityStorageDomain {
+				continue
+			}
+			assert.Equal(t, "other", key.key)
+		}
+	})
+}
+
+func TestInterpretAuthAccountCapabilitiesPublish(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      resource R {
+          let id: Int
+
+          init(id: Int) {
+              self.id = id
+          }
+      }
+
+      resource interface I {}
+
+      fun publish() {
+          account.save(<-create R(id: 42), to: /storage/r)
+          let cap = account.capabilities.storage.issue<&R>(/storage/r)
+          account.capabilities.publish(cap, at: /public/r)
+      }
+
+      fun publishTwice() {
+          let cap = account.capabilities.storage.issue<&R>(/storage/r)
+          account.capabilities.publish(cap, at: /public/r)
+          account.capabilities.publish(cap, at: /public/r)
+      }
+
+      fun get(): Capability<&R>? {
+          return pubAccount.capabilities.get<&R>(/public/r)
+      }
+
+      fun getAsAuth(): Capability<auth &R>? {
+          return pubAccount.capabilities.get<auth &R>(/public/r)
+      }
+
+      fun borrow(): Int? {
+          return pubAccount.capabilities.borrow<&R>(/public/r)?.id
+      }
+
+      fun borrowLegacy(): Int? {
+          return pubAccount.getCapability<&R>(/public/r).borrow()?.id
+      }
+
+      fun publishPathCapability() {
+          account.save(<-create R(id: 42), to: /storage/r)
+          account.link<&R>(/private/r, target: /storage/r)
+          let cap = account.getCapability<&R>(/private/r)
+          account.capabilities.publish(cap, at: /public/r)
+      }
+
+      fun checkLegacy(): Bool {
+          return pubAccount.getCapability<&R>(/public/r).check()
+      }
+
+      fun borrowLegacyAsAuth(): Int? {
+          return pubAccount.getCapability<auth &R>(/public/r).borrow()?.id
+      }
+
+      fun unpublish(): Capability? {
+          return account.capabilities.unpublish(/public/r)
+      }
+
+      fun revoke() {
+          account.capabilities.storage.getController(byCapabilityID: 1)!.revoke()
+      }
+    `
+
+	address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+	t.Run("get and borrow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("publish")
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("get")
+		require.NoError(t, err)
+		require.IsType(t, &interpreter.SomeValue{}, result)
+
+		capability := result.(*interpreter.SomeValue).Value
+		require.IsType(t, &interpreter.CapabilityValue{}, capability)
+		assert.Equal(t, interpreter.UInt64Value(1), capability.(*interpreter.CapabilityValue).ID)
+
+		result, err = inter.Invoke("getAsAuth")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+
+		expected := interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(42))
+
+		result, err = inter.Invoke("borrow")
+		require.NoError(t, err)
+		AssertValuesEqual(t, inter, expected, result)
+
+		// Published capabilities can also be borrowed through path capabilities
+
+		result, err = inter.Invoke("borrowLegacy")
+		require.NoError(t, err)
+		AssertValuesEqual(t, inter, expected, result)
+
+		_, err = inter.Invoke("revoke")
+		require.NoError(t, err)
+
+		result, err = inter.Invoke("borrow")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+	})
+
+	t.Run("path capability", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("publishPathCapability")
+		require.NoError(t, err)
+
+		expected := interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(42))
+
+		result, err := inter.Invoke("borrow")
+		require.NoError(t, err)
+		AssertValuesEqual(t, inter, expected, result)
+
+		// The published path capability is followed to its target
+
+		result, err = inter.Invoke("borrowLegacy")
+		require.NoError(t, err)
+		AssertValuesEqual(t, inter, expected, result)
+
+		result, err = inter.Invoke("checkLegacy")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.BoolValue(true), result)
+
+		// The borrow type of the published capability is checked
+
+		result, err = inter.Invoke("borrowLegacyAsAuth")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+	})
+
+	t.Run("unpublish", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("publish")
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("unpublish")
+		require.NoError(t, err)
+		require.IsType(t, &interpreter.SomeValue{}, result)
+
+		result, err = inter.Invoke("get")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+
+		result, err = inter.Invoke("unpublish")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NilValue{}, result)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, code)
+
+		_, err := inter.Invoke("publishTwice")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.OverwriteError{})
+	})
+}
@@ -21,7 +21,6 @@ package interpreter_test
 import (
 	"fmt"
 	"math"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -696,6 +695,98 @@ func TestInterpretSaturatedArithmeticFunctions(t *testing.T) {
 				},
 			},
 		},
+		sema.Word8Type: {
+			add: testCalls{
+				overflow: testCall{
+					interpreter.Word8Value(math.MaxUint8),
+					interpreter.Word8Value(2),
+					interpreter.Word8Value(math.MaxUint8),
+				},
+			},
+			subtract: testCalls{
+				underflow: testCall{
+					interpreter.Word8Value(0),
+					interpreter.Word8Value(2),
+					interpreter.Word8Value(0),
+				},
+			},
+			multiply: testCalls{
+				overflow: testCall{
+					interpreter.Word8Value(math.MaxUint8),
+					interpreter.Word8Value(2),
+					interpreter.Word8Value(math.MaxUint8),
+				},
+			},
+		},
+		sema.Word16Type: {
+			add: testCalls{
+				overflow: testCall{
+					interpreter.Word16Value(math.MaxUint16),
+					interpreter.Word16Value(2),
+					interpreter.Word16Value(math.MaxUint16),
+				},
+			},
+			subtract: testCalls{
+				underflow: testCall{
+					interpreter.Word16Value(0),
+					interpreter.Word16Value(2),
+					interpreter.Word16Value(0),
+				},
+			},
+			multiply: testCalls{
+				overflow: testCall{
+					interpreter.Word16Value(math.MaxUint16),
+					interpreter.Word16Value(2),
+					interpreter.Word16Value(math.MaxUint16),
+				},
+			},
+		},
+		sema.Word32Type: {
+			add: testCalls{
+				overflow: testCall{
+					interpreter.Word32Value(math.MaxUint32),
+					interpreter.Word32Value(2),
+					interpreter.Word32Value(math.MaxUint32),
+				},
+			},
+			subtract: testCalls{
+				underflow: testCall{
+					interpreter.Word32Value(0),
+					interpreter.Word32Value(2),
+					interpreter.Word32Value(0),
+				},
+			},
+			multiply: testCalls{
+				overflow: testCall{
+					interpreter.Word32Value(math.MaxUint32),
+					interpreter.Word32Value(2),
+					interpreter.Word32Value(math.MaxUint32),
+				},
+			},
+		},
+		sema.Word64Type: {
+			add: testCalls{
+				overflow: testCall{
+					interpreter.Word64Value(math.MaxUint64),
+					interpreter.Word64Value(2),
+					interpreter.Word64Value(math.MaxUint64),
+				},
+			},
+			subtract: testCalls{
+				underflow: testCall{
+					interpreter.Word64Value(0),
+					interpreter.Word64Value(2),
+					interpreter.Word64Value(0),
+				},
+			},
+			multiply: testCalls{
+				overflow: testCall{
+					interpreter.Word64Value(math.MaxUint64),
+					interpreter.Word64Value(2),
+					interpreter.Word64Value(math.MaxUint64),
+				},
+			},
+		},
 		sema.UFix64Type: {
 			add: testCalls{
 				overflow: testCall{
@@ -754,10 +845,6 @@ func TestInterpretSaturatedArithmeticFunctions(t *testing.T) {
 		sema.AllUnsignedFixedPointTypes...,
 	) {
 
-		if strings.HasPrefix(ty.String(), "Word") {
-			continue
-		}
-
 		testCase, ok := testCases[ty]
 		require.True(t, ok, "missing test case for %s", ty)
 
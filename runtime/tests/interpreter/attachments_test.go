@@ -0,0 +1,357 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/runtime/tests/utils"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretAttachExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          attachment A for S {
+              pub let y: Int
+
+              init(y: Int) {
+                  self.y = y + base.x
+              }
+
+              pub fun sum(): Int {
+                  return self.y + base.x
+              }
+          }
+
+          fun test(): Int? {
+              let s = attach A(y: 2) to S()
+              return s[A]?.sum()
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewSomeValueNonCopying(
+				interpreter.NewIntValueFromInt64(4),
+			),
+			value,
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              pub var x: Int
+
+              init() {
+                  self.x = 1
+              }
+
+              pub fun increment() {
+                  self.x = self.x + 1
+              }
+          }
+
+          attachment A for R {
+              pub fun increment() {
+                  base.increment()
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R()
+              r[A]?.increment()
+              let x = r.x
+              destroy r
+              return x
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(2),
+			value,
+		)
+	})
+
+	t.Run("missing attachment", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): &A? {
+              return S()[A]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NilValue{},
+			value,
+		)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test() {
+              let s = attach A() to S()
+              attach A() to s
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.DuplicateAttachmentError{})
+	})
+
+	t.Run("copy of structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): Bool {
+              let s = S()
+              let s2 = attach A() to s
+              return s[A] == nil && s2[A] != nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+}
+
+func TestInterpretRemoveStatement(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          attachment A for S {}
+
+          fun test(): Bool {
+              let s = attach A() to S()
+              remove A from s
+              return s[A] == nil
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(true),
+			value,
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          var destroyed = 0
+
+          resource R {}
+
+          attachment A for R {
+              destroy() {
+                  destroyed = destroyed + 1
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R()
+              remove A from r
+              let removed = r[A] == nil
+              destroy r
+              if !removed {
+                  return -1
+              }
+              return destroyed
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(1),
+			value,
+		)
+	})
+
+	t.Run("destroy base", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          var destroyed = 0
+
+          resource R {
+              pub let x: Int
+
+              init() {
+                  self.x = 2
+              }
+          }
+
+          attachment A for R {
+              destroy() {
+                  destroyed = destroyed + base.x
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R()
+              destroy r
+              return destroyed
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(2),
+			value,
+		)
+	})
+
+	t.Run("reference, destroy base", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {}
+
+          attachment A for R {
+              pub let n: Int
+
+              init() {
+                  self.n = 7
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R()
+              let a = r[A]!
+              destroy r
+              return a.n
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.InvalidatedResourceError{})
+	})
+
+	t.Run("reference, remove", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {}
+
+          attachment A for R {
+              pub let n: Int
+
+              init() {
+                  self.n = 7
+              }
+          }
+
+          fun test(): Int {
+              let r <- attach A() to <-create R()
+              let a = r[A]!
+              remove A from r
+              let n = a.n
+              destroy r
+              return n
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.InvalidatedResourceError{})
+	})
+}
@@ -0,0 +1,220 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretBytes(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let bytes = Bytes([1, 2, 3, 4])
+      let length = bytes.length
+      let second = bytes[1]
+      let concatenated = bytes.concat(Bytes([5, 6]))
+      let sliced = bytes.slice(from: 1, upTo: 3)
+      let empty = bytes.slice(from: 2, upTo: 2)
+      let array = sliced.toArray()
+      let equal = sliced == Bytes([2, 3])
+      let unequal = sliced == Bytes([2, 4])
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewBytesValue([]byte{1, 2, 3, 4}),
+		inter.Globals["bytes"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(4),
+		inter.Globals["length"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.UInt8Value(2),
+		inter.Globals["second"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewBytesValue([]byte{1, 2, 3, 4, 5, 6}),
+		inter.Globals["concatenated"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewBytesValue([]byte{2, 3}),
+		inter.Globals["sliced"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewBytesValue([]byte{}),
+		inter.Globals["empty"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeUInt8,
+			},
+			common.Address{},
+			interpreter.UInt8Value(2),
+			interpreter.UInt8Value(3),
+		),
+		inter.Globals["array"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.BoolValue(true),
+		inter.Globals["equal"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.BoolValue(false),
+		inter.Globals["unequal"].GetValue(),
+	)
+}
+
+func TestInterpretBytesSliceConcat(t *testing.T) {
+
+	t.Parallel()
+
+	// Slices share the bytes of the sliced value,
+	// so concatenating a slice must not modify the sliced value
+
+	inter := parseCheckAndInterpret(t, `
+      let bytes = Bytes([1, 2, 3, 4])
+      let concatenated = bytes.slice(from: 0, upTo: 2).concat(Bytes([5]))
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewBytesValue([]byte{1, 2, 3, 4}),
+		inter.Globals["bytes"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewBytesValue([]byte{1, 2, 5}),
+		inter.Globals["concatenated"].GetValue(),
+	)
+}
+
+func TestInterpretBytesDictionaryKey(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): Int? {
+          let dict: {Bytes: Int} = {Bytes([1, 2]): 1, Bytes([3]): 2}
+          return dict[Bytes([1, 2, 3]).slice(from: 0, upTo: 2)]
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(1)),
+		result,
+	)
+}
+
+func TestInterpretInvalidBytesAccess(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("index out of bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): UInt8 {
+              return Bytes([1, 2])[2]
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ArrayIndexOutOfBoundsError{})
+	})
+
+	t.Run("slice out of bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Bytes {
+              return Bytes([1, 2]).slice(from: 1, upTo: 3)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ArraySliceIndicesError{})
+	})
+
+	t.Run("invalid slice indices", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Bytes {
+              return Bytes([1, 2]).slice(from: 2, upTo: 1)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.InvalidSliceIndexError{})
+	})
+}
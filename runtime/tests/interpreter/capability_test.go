@@ -225,7 +225,7 @@ func TestInterpretCapability_borrow(t *testing.T) {
 
 			require.Equal(t,
 				cyclicLinkErr.Error(),
-				"cyclic link in account 0x2a: /public/loop1 -> /public/loop2 -> /public/loop1",
+				"cyclic link in account 0x000000000000002a: /public/loop1 -> /public/loop2 -> /public/loop1",
 			)
 		})
 
@@ -450,7 +450,7 @@ func TestInterpretCapability_borrow(t *testing.T) {
 
 			require.Equal(t,
 				cyclicLinkErr.Error(),
-				"cyclic link in account 0x2a: /public/loop1 -> /public/loop2 -> /public/loop1",
+				"cyclic link in account 0x000000000000002a: /public/loop1 -> /public/loop2 -> /public/loop1",
 			)
 		})
 
@@ -673,7 +673,7 @@ func TestInterpretCapability_check(t *testing.T) {
 
 			require.Equal(t,
 				cyclicLinkErr.Error(),
-				"cyclic link in account 0x2a: /public/loop1 -> /public/loop2 -> /public/loop1",
+				"cyclic link in account 0x000000000000002a: /public/loop1 -> /public/loop2 -> /public/loop1",
 			)
 		})
 
@@ -890,7 +890,7 @@ func TestInterpretCapability_check(t *testing.T) {
 
 			require.Equal(t,
 				cyclicLinkErr.Error(),
-				"cyclic link in account 0x2a: /public/loop1 -> /public/loop2 -> /public/loop1",
+				"cyclic link in account 0x000000000000002a: /public/loop1 -> /public/loop2 -> /public/loop1",
 			)
 		})
 
@@ -0,0 +1,168 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretDebugger(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun add(_ a: Int, _ b: Int): Int {
+          let sum = a + b
+          return sum
+      }
+
+      fun test(): Int {
+          let x = 1
+          let y = add(x, 2)
+          return y
+      }
+    `
+
+	type result struct {
+		value interpreter.Value
+		err   error
+	}
+
+	start := func(t *testing.T, debugger *interpreter.Debugger) <-chan result {
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithDebugger(debugger),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		results := make(chan result, 1)
+
+		go func() {
+			value, err := inter.Invoke("test")
+			results <- result{value, err}
+		}()
+
+		return results
+	}
+
+	finish := func(t *testing.T, debugger *interpreter.Debugger, results <-chan result) {
+		require.True(t, debugger.Continue())
+
+		result := <-results
+		require.NoError(t, result.err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(3), result.value)
+	}
+
+	line := func(stop interpreter.Stop) int {
+		return stop.Statement.StartPosition().Line
+	}
+
+	t.Run("breakpoint and stepping", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+		debugger.AddBreakpoint(utils.TestLocation, 9)
+
+		results := start(t, debugger)
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, interpreter.StopReasonBreakpoint, stop.Reason)
+		assert.Equal(t, 9, line(stop))
+		assert.Empty(t, debugger.CallStack())
+
+		x := debugger.CurrentActivation(stop.Interpreter).Find("x")
+		require.NotNil(t, x)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(1), x.GetValue())
+
+		debugger.RemoveBreakpoint(utils.TestLocation, 9)
+
+		stop = debugger.StepInto()
+		assert.Equal(t, interpreter.StopReasonStep, stop.Reason)
+		assert.Equal(t, 3, line(stop))
+
+		callStack := debugger.CallStack()
+		require.Len(t, callStack, 1)
+		assert.Equal(t, 9, callStack[0].Invocation.StartPosition().Line)
+		assert.NotNil(t, callStack[0].Activation().Find("x"))
+
+		locals := debugger.CurrentActivation(stop.Interpreter).FunctionValues()
+		assert.Contains(t, locals, "a")
+		assert.Contains(t, locals, "b")
+		assert.NotContains(t, locals, "x")
+
+		stop = debugger.StepOver()
+		assert.Equal(t, 4, line(stop))
+
+		stop = debugger.StepOut()
+		assert.Equal(t, 10, line(stop))
+		assert.Empty(t, debugger.CallStack())
+
+		finish(t, debugger, results)
+	})
+
+	t.Run("step over invocation", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+		debugger.AddBreakpoint(utils.TestLocation, 9)
+
+		results := start(t, debugger)
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, 9, line(stop))
+
+		debugger.ClearBreakpoints()
+		assert.False(t, debugger.HasBreakpoint(utils.TestLocation, 9))
+
+		stop = debugger.StepOver()
+		assert.Equal(t, interpreter.StopReasonStep, stop.Reason)
+		assert.Equal(t, 10, line(stop))
+
+		finish(t, debugger, results)
+	})
+
+	t.Run("pause", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+		debugger.RequestPause()
+
+		results := start(t, debugger)
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, interpreter.StopReasonPause, stop.Reason)
+		assert.Equal(t, 8, line(stop))
+
+		finish(t, debugger, results)
+	})
+}
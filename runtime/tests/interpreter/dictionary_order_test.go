@@ -0,0 +1,255 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+// The iteration order of dictionaries is relied on by consensus.
+// These tests pin the order, so that any change to it, e.g. by an update of atree
+// or of the hashing of dictionary keys, is detected.
+//
+// The order depends on the storage ID of a dictionary,
+// so the pinned orders of dictionaries created by programs change
+// when the programs allocate storage differently.
+
+func TestDictionaryIterationOrderAtStorageID(t *testing.T) {
+
+	t.Parallel()
+
+	storage := interpreter.NewInMemoryStorage()
+
+	inter, err := interpreter.NewInterpreter(
+		&interpreter.Program{
+			Program:     ast.NewProgram([]ast.Declaration{}),
+			Elaboration: sema.NewElaboration(),
+		},
+		utils.TestLocation,
+		interpreter.WithStorage(storage),
+	)
+	require.NoError(t, err)
+
+	keysAndValues := make([]interpreter.Value, 0, 40)
+	for i := 0; i < 20; i++ {
+		keysAndValues = append(
+			keysAndValues,
+			interpreter.NewIntValueFromInt64(int64(i)),
+			interpreter.BoolValue(true),
+		)
+	}
+
+	dictionary := interpreter.NewDictionaryValueWithAddress(
+		inter,
+		interpreter.DictionaryStaticType{
+			KeyType:   interpreter.PrimitiveStaticTypeInt,
+			ValueType: interpreter.PrimitiveStaticTypeBool,
+		},
+		common.Address{0x1},
+		keysAndValues...,
+	)
+
+	require.Equal(t,
+		atree.StorageID{
+			Address: atree.Address{0x1},
+			Index:   atree.StorageIndex{0, 0, 0, 0, 0, 0, 0, 1},
+		},
+		dictionary.StorageID(),
+	)
+
+	var keys []string
+	dictionary.Iterate(func(key, _ interpreter.Value) (resume bool) {
+		keys = append(keys, key.String())
+		return true
+	})
+
+	assert.Equal(t,
+		[]string{
+			"5", "10", "9", "3", "7", "6", "19", "1", "0", "18",
+			"17", "13", "14", "4", "11", "16", "15", "8", "12", "2",
+		},
+		keys,
+	)
+}
+
+func TestInterpretDictionaryIterationOrder(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun ints(): {Int: Bool} {
+          let dict: {Int: Bool} = {}
+          var i = 0
+          while i < 20 {
+              dict[i] = true
+              i = i + 1
+          }
+          return dict
+      }
+
+      fun intKeys(): [Int] {
+          return ints().keys
+      }
+
+      fun stringKeys(): [String] {
+          let dict = {
+              "alpha": 1,
+              "bravo": 2,
+              "charlie": 3,
+              "delta": 4,
+              "echo": 5,
+              "foxtrot": 6,
+              "golf": 7,
+              "hotel": 8
+          }
+          return dict.keys
+      }
+
+      fun reinsertedKeys(): [[Int]] {
+          let dict = ints()
+          let keys = dict.keys
+
+          // Remove all entries and insert them again in reverse order
+
+          for key in keys {
+              dict.remove(key: key)
+          }
+
+          var i = 19
+          while i >= 0 {
+              dict[i] = true
+              i = i - 1
+          }
+
+          return [keys, dict.keys]
+      }
+
+      fun keysMatchValues(): Bool {
+          let dict = {
+              "alpha": 1,
+              "bravo": 2,
+              "charlie": 3,
+              "delta": 4,
+              "echo": 5,
+              "foxtrot": 6,
+              "golf": 7,
+              "hotel": 8
+          }
+          let keys = dict.keys
+          let values = dict.values
+
+          var i = 0
+          while i < keys.length {
+              if dict[keys[i]]! != values[i] {
+                  return false
+              }
+              i = i + 1
+          }
+          return true
+      }
+    `
+
+	t.Run("integer keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		value, err := inter.Invoke("intKeys")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"[9, 8, 19, 0, 14, 13, 12, 7, 1, 3, 15, 17, 16, 18, 2, 10, 6, 4, 5, 11]",
+			value.String(),
+		)
+	})
+
+	t.Run("string keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		value, err := inter.Invoke("stringKeys")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`["charlie", "echo", "bravo", "foxtrot", "alpha", "hotel", "golf", "delta"]`,
+			value.String(),
+		)
+	})
+
+	t.Run("independent of insertion order", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		value, err := inter.Invoke("reinsertedKeys")
+		require.NoError(t, err)
+
+		arrayValue := value.(*interpreter.ArrayValue)
+		elements := arrayElements(inter, arrayValue)
+		require.Len(t, elements, 2)
+
+		assert.Equal(t, elements[0].String(), elements[1].String())
+	})
+
+	t.Run("keys and values", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		value, err := inter.Invoke("keysMatchValues")
+		require.NoError(t, err)
+
+		assert.Equal(t, interpreter.BoolValue(true), value)
+	})
+
+	t.Run("repeated executions", func(t *testing.T) {
+
+		t.Parallel()
+
+		var orders []string
+
+		for i := 0; i < 3; i++ {
+			inter := parseCheckAndInterpret(t, code)
+
+			value, err := inter.Invoke("stringKeys")
+			require.NoError(t, err)
+
+			orders = append(orders, value.String())
+		}
+
+		assert.Equal(t, orders[0], orders[1])
+		assert.Equal(t, orders[0], orders[2])
+	})
+}
@@ -0,0 +1,160 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretEntitledReferenceAccess(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+        entitlement X
+        entitlement Y
+
+        struct S {
+            access(X) let x: Int
+            access(X | Y) let y: Int
+
+            init() {
+                self.x = 1
+                self.y = 2
+            }
+        }
+
+        fun test(): Int {
+            let s = S()
+            let ref = &s as auth(X) &S
+            return ref.x + ref.y
+        }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(3),
+		value,
+	)
+}
+
+func TestInterpretEntitledReferenceCasting(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+        entitlement X
+        entitlement Y
+        entitlement Z
+
+        struct S {}
+
+        fun testSufficient(): Bool {
+            let s = S()
+            let ref: AnyStruct = &s as auth(X, Y) &S
+            return (ref as? auth(X) &S) != nil
+        }
+
+        fun testInsufficient(): Bool {
+            let s = S()
+            let ref: AnyStruct = &s as auth(X, Y) &S
+            return (ref as? auth(Z) &S) != nil
+        }
+
+        fun testRuntimeType(): Bool {
+            return Type<auth(Y, X) &S>() == Type<auth(X, Y) &S>()
+        }
+    `)
+
+	for name, expected := range map[string]bool{
+		"testSufficient":   true,
+		"testInsufficient": false,
+		"testRuntimeType":  true,
+	} {
+		value, err := inter.Invoke(name)
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(expected),
+			value,
+		)
+	}
+}
+
+func TestInterpretEntitlementMappedAccess(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+        entitlement X
+        entitlement Y
+        entitlement Z
+
+        entitlement mapping M {
+            X -> Y
+        }
+
+        struct T {
+            access(Y) let y: Int
+
+            init() {
+                self.y = 1
+            }
+        }
+
+        struct S {
+            access(M) let t: auth(M) &T
+
+            init(t: auth(Y) &T) {
+                self.t = t
+            }
+        }
+
+        fun test(): Bool {
+            let t = T()
+            let s = S(t: &t as auth(Y, Z) &T)
+            let ref = &s as auth(X) &S
+            let mapped: AnyStruct = ref.t
+            return ref.t.y == 1
+                && (mapped as? auth(Y) &T) != nil
+                && (mapped as? auth(Z) &T) == nil
+        }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.BoolValue(true),
+		value,
+	)
+}
@@ -19,6 +19,8 @@
 package interpreter_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 func TestInterpretEnum(t *testing.T) {
@@ -265,3 +268,244 @@ func TestInterpretEnumInContract(t *testing.T) {
 		rawValue,
 	)
 }
+
+func TestInterpretEnumAllCases(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      enum E: UInt8 {
+          case a
+          case b
+          case c
+      }
+
+      fun test(): [UInt8] {
+          let rawValues: [UInt8] = []
+          for e in E.allCases {
+              rawValues.append(e.rawValue)
+          }
+          return rawValues
+      }
+
+      let res = [
+          E.allCases.length == 3,
+          E.allCases[0] == E.a,
+          E.allCases[2] == E.c
+      ]
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeBool,
+			},
+			common.Address{},
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+		),
+		inter.Globals["res"].GetValue(),
+	)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeUInt8,
+			},
+			common.Address{},
+			interpreter.UInt8Value(0),
+			interpreter.UInt8Value(1),
+			interpreter.UInt8Value(2),
+		),
+		value,
+	)
+}
+
+func TestInterpretEnumAllCasesMutation(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      enum E: UInt8 {
+          case a
+          case b
+      }
+
+      fun test(): Int {
+          E.allCases.append(E.a)
+          E.allCases.removeFirst()
+          return E.allCases.length
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(2),
+		value,
+	)
+}
+
+func TestInterpretEnumLookup(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      enum E: Int64 {
+          case a
+          case b
+      }
+
+      let res = [
+          E.lookup(rawValue: 0)! == E.a,
+          E.lookup(rawValue: 1)! == E.b,
+          E.lookup(rawValue: -1) == nil,
+          E.lookup(rawValue: 2) == nil
+      ]
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeBool,
+			},
+			common.Address{},
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+		),
+		inter.Globals["res"].GetValue(),
+	)
+}
+
+func TestInterpretEnumRawTypes(t *testing.T) {
+
+	t.Parallel()
+
+	rawTypes := append(
+		sema.AllSignedIntegerTypes[:],
+		sema.AllUnsignedIntegerTypes...,
+	)
+
+	for _, rawType := range rawTypes {
+
+		// Capture the loop variable
+		rawType := rawType
+
+		t.Run(rawType.String(), func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      enum E: %[1]s {
+                          case a
+                          case b
+                      }
+
+                      let res = [
+                          E(rawValue: 1)! == E.b,
+                          E(rawValue: 2) == nil,
+                          E.allCases.length == 2,
+                          E.allCases[1] == E.b,
+                          E.b.rawValue == (1 as %[1]s),
+                          E.b.rawValue.getType() == Type<%[1]s>()
+                      ]
+                    `,
+					rawType,
+				),
+			)
+
+			RequireValuesEqual(
+				t,
+				inter,
+				interpreter.NewArrayValue(
+					inter,
+					interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeBool,
+					},
+					common.Address{},
+					interpreter.BoolValue(true),
+					interpreter.BoolValue(true),
+					interpreter.BoolValue(true),
+					interpreter.BoolValue(true),
+					interpreter.BoolValue(true),
+					interpreter.BoolValue(true),
+				),
+				inter.Globals["res"].GetValue(),
+			)
+		})
+	}
+}
+
+func TestInterpretEnumRawTypeMaximumCaseCount(t *testing.T) {
+
+	t.Parallel()
+
+	var builder strings.Builder
+	builder.WriteString("enum E: UInt8 {\n")
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&builder, "  case c%d\n", i)
+	}
+	builder.WriteString("}\n")
+	builder.WriteString("let last = E.allCases[255].rawValue\n")
+	builder.WriteString("let lookedUp = E(rawValue: 255)! == E.c255\n")
+
+	inter := parseCheckAndInterpret(t, builder.String())
+
+	assert.Equal(t,
+		interpreter.UInt8Value(255),
+		inter.Globals["last"].GetValue(),
+	)
+	assert.Equal(t,
+		interpreter.BoolValue(true),
+		inter.Globals["lookedUp"].GetValue(),
+	)
+}
+
+func TestInterpretEnumCaseShadowsSyntheticMember(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      enum E: UInt8 {
+          case lookup
+          case allCases
+      }
+
+      let a = E.allCases.rawValue
+      let b = E.lookup.rawValue
+    `)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.UInt8Value(1),
+		inter.Globals["a"].GetValue(),
+	)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.UInt8Value(0),
+		inter.Globals["b"].GetValue(),
+	)
+}
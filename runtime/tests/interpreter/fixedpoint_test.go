@@ -592,3 +592,156 @@ func TestInterpretFixedPointMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestInterpretFixedPointMathFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		code     string
+		expected interpreter.Value
+	}
+
+	testCases := map[string]testCase{
+		"Fix64 min": {
+			code:     `let x: Fix64 = -1.5; let result = x.min(2.0)`,
+			expected: interpreter.Fix64Value(-150000000),
+		},
+		"Fix64 max": {
+			code:     `let x: Fix64 = -1.5; let result = x.max(2.0)`,
+			expected: interpreter.Fix64Value(200000000),
+		},
+		"UFix64 min": {
+			code:     `let x: UFix64 = 1.5; let result = x.min(2.0)`,
+			expected: interpreter.UFix64Value(150000000),
+		},
+		"UFix64 max": {
+			code:     `let x: UFix64 = 1.5; let result = x.max(2.0)`,
+			expected: interpreter.UFix64Value(200000000),
+		},
+		"Fix64 abs negative": {
+			code:     `let x: Fix64 = -1.5; let result = x.abs()`,
+			expected: interpreter.Fix64Value(150000000),
+		},
+		"Fix64 abs positive": {
+			code:     `let x: Fix64 = 1.5; let result = x.abs()`,
+			expected: interpreter.Fix64Value(150000000),
+		},
+		"UFix64 abs": {
+			code:     `let x: UFix64 = 1.5; let result = x.abs()`,
+			expected: interpreter.UFix64Value(150000000),
+		},
+		"Fix64 pow": {
+			code:     `let x: Fix64 = -1.5; let result = x.pow(3)`,
+			expected: interpreter.Fix64Value(-337500000),
+		},
+		"Fix64 pow zero": {
+			code:     `let x: Fix64 = -1.5; let result = x.pow(0)`,
+			expected: interpreter.Fix64Value(100000000),
+		},
+		"UFix64 pow": {
+			code:     `let x: UFix64 = 1.5; let result = x.pow(2)`,
+			expected: interpreter.UFix64Value(225000000),
+		},
+		"UFix64 pow fraction": {
+			code:     `let x: UFix64 = 0.5; let result = x.pow(64)`,
+			expected: interpreter.UFix64Value(0),
+		},
+		"Fix64 sqrt": {
+			code:     `let x: Fix64 = 2.25; let result = x.sqrt()`,
+			expected: interpreter.Fix64Value(150000000),
+		},
+		"UFix64 sqrt": {
+			code:     `let x: UFix64 = 2.0; let result = x.sqrt()`,
+			expected: interpreter.UFix64Value(141421356),
+		},
+		"UFix64 sqrt max": {
+			code:     `let result = UFix64.max.sqrt()`,
+			expected: interpreter.UFix64Value(42949672959999),
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		// Capture the loop variable
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t, testCase.code)
+
+			AssertValuesEqual(
+				t,
+				inter,
+				testCase.expected,
+				inter.Globals["result"].GetValue(),
+			)
+		})
+	}
+}
+
+func TestInterpretFixedPointMathFunctionErrors(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("Fix64 abs overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Fix64 {
+              return Fix64.min.abs()
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+	})
+
+	t.Run("UFix64 pow overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): UFix64 {
+              let x: UFix64 = 10.0
+              return x.pow(12)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+	})
+
+	t.Run("Fix64 pow underflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Fix64 {
+              let x: Fix64 = -10.0
+              return x.pow(11)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.UnderflowError{})
+	})
+
+	t.Run("Fix64 sqrt negative", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Fix64 {
+              let x: Fix64 = -1.0
+              return x.sqrt()
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.NegativeSquareRootError{})
+	})
+}
@@ -0,0 +1,55 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/tests/golden"
+)
+
+func TestInterpretGolden(t *testing.T) {
+
+	t.Parallel()
+
+	for _, path := range golden.Inputs(t, "testdata/*.cdc") {
+
+		path := path
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			code, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+
+			inter := parseCheckAndInterpret(t, string(code))
+
+			result, err := inter.Invoke("main")
+
+			golden.Assert(t, golden.Path(path), golden.RenderResult(result, err))
+		})
+	}
+}
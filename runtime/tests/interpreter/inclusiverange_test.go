@@ -0,0 +1,321 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretInclusiveRange(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = InclusiveRange(1, 10)
+      let b = InclusiveRange(10, 1)
+      let c = InclusiveRange(UInt8(0), UInt8(255), step: UInt8(5))
+
+      let aStart = a.start
+      let aEnd = a.end
+      let aStep = a.step
+      let bStep = b.step
+      let cStep = c.step
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(1),
+		inter.Globals["aStart"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(10),
+		inter.Globals["aEnd"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(1),
+		inter.Globals["aStep"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(-1),
+		inter.Globals["bStep"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.UInt8Value(5),
+		inter.Globals["cStep"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.InclusiveRangeStaticType{
+			ElementType: interpreter.PrimitiveStaticTypeUInt8,
+		},
+		inter.Globals["c"].GetValue().StaticType(),
+	)
+
+	assert.Equal(t,
+		"InclusiveRange<Int>(start: 1, end: 10, step: 1)",
+		inter.Globals["a"].GetValue().String(),
+	)
+}
+
+func TestInterpretInclusiveRangeContains(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let range = InclusiveRange(1, 10, step: 3)
+      let reversed = InclusiveRange(10, -10, step: -5)
+
+      let results = [
+          range.contains(1),
+          range.contains(4),
+          range.contains(10),
+          range.contains(5),
+          range.contains(13),
+          range.contains(-2),
+          reversed.contains(0),
+          reversed.contains(-10),
+          reversed.contains(1)
+      ]
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(false),
+			interpreter.BoolValue(false),
+			interpreter.BoolValue(false),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(false),
+		},
+		arrayElements(inter, inter.Globals["results"].GetValue().(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretForInclusiveRange(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("ascending", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let xs: [Int] = []
+              for x in InclusiveRange(1, 10, step: 4) {
+                  xs.append(x)
+              }
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValueSlicesEqual(
+			t,
+			inter,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(5),
+				interpreter.NewIntValueFromInt64(9),
+			},
+			arrayElements(inter, value.(*interpreter.ArrayValue)),
+		)
+	})
+
+	t.Run("descending, with index", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let xs: [Int] = []
+              for i, x in InclusiveRange(3, 1) {
+                  xs.append(i)
+                  xs.append(x)
+              }
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValueSlicesEqual(
+			t,
+			inter,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(0),
+				interpreter.NewIntValueFromInt64(3),
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(2),
+				interpreter.NewIntValueFromInt64(2),
+				interpreter.NewIntValueFromInt64(1),
+			},
+			arrayElements(inter, value.(*interpreter.ArrayValue)),
+		)
+	})
+
+	t.Run("bounds of element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): UInt8 {
+              var last: UInt8 = 0
+              for x in InclusiveRange(UInt8(250), UInt8(255)) {
+                  last = x
+              }
+              return last
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.UInt8Value(255),
+			value,
+		)
+	})
+
+	t.Run("break, continue, and return", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun sum(): Int {
+              var sum = 0
+              for x in InclusiveRange(1, 100) {
+                  if x % 2 == 0 {
+                      continue
+                  }
+                  if x > 7 {
+                      break
+                  }
+                  sum = sum + x
+              }
+              return sum
+          }
+
+          fun find(): Int {
+              for x in InclusiveRange(1, 1000000000) {
+                  if x * x > 50 {
+                      return x
+                  }
+              }
+              return -1
+          }
+        `)
+
+		value, err := inter.Invoke("sum")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(16),
+			value,
+		)
+
+		value, err = inter.Invoke("find")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(8),
+			value,
+		)
+	})
+}
+
+func TestInterpretInvalidInclusiveRange(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("zero step", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test() {
+              InclusiveRange(1, 10, step: 0)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.InclusiveRangeConstructionError{})
+	})
+
+	t.Run("step in wrong direction", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test() {
+              InclusiveRange(1, 10, step: -1)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.InclusiveRangeConstructionError{})
+	})
+
+	t.Run("unsigned, descending", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test() {
+              InclusiveRange(UInt8(10), UInt8(1))
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.InclusiveRangeConstructionError{})
+	})
+}
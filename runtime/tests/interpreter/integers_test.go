@@ -797,3 +797,394 @@ func TestInterpretIntegerMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestInterpretIntegerConversionErrorDetails(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): UInt8 {
+              let x = 300
+              return UInt8(x)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+
+		var conversionErr interpreter.NumberConversionError
+		require.ErrorAs(t, err, &conversionErr)
+
+		assert.Equal(t, interpreter.NewIntValueFromInt64(300), conversionErr.Value)
+		assert.Equal(t, sema.UInt8TypeName, conversionErr.TargetTypeName)
+		assert.Equal(t, interpreter.UInt8Value(0), conversionErr.Min)
+		assert.Equal(t, interpreter.UInt8Value(math.MaxUint8), conversionErr.Max)
+		assert.Equal(t, 4, conversionErr.StartPos.Line)
+
+		assert.Equal(t,
+			"cannot convert value `300` to type `UInt8`: overflow, expected value in range [0, 255]",
+			conversionErr.Error(),
+		)
+	})
+
+	t.Run("underflow, unbounded", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): UInt {
+              let x = -1
+              return UInt(x)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.UnderflowError{})
+
+		var conversionErr interpreter.NumberConversionError
+		require.ErrorAs(t, err, &conversionErr)
+
+		assert.Equal(t,
+			"cannot convert value `-1` to type `UInt`: underflow, expected value in range [0, ∞]",
+			conversionErr.Error(),
+		)
+	})
+
+	t.Run("fixed-point", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): UFix64 {
+              let x: Fix64 = -1.5
+              return UFix64(x)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		var conversionErr interpreter.NumberConversionError
+		require.ErrorAs(t, err, &conversionErr)
+
+		assert.Equal(t,
+			"cannot convert value `-1.50000000` to type `UFix64`: underflow, "+
+				"expected value in range [0.00000000, 184467440737.09551615]",
+			conversionErr.Error(),
+		)
+	})
+}
+
+func TestInterpretIntegerTruncating(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expected interpreter.Value) {
+
+		inter := parseCheckAndInterpret(t, code)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			expected,
+			inter.Globals["x"].GetValue(),
+		)
+	}
+
+	t.Run("in range", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, `let x = UInt8.truncating(42)`, interpreter.UInt8Value(42))
+	})
+
+	t.Run("unsigned, overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, `let x = UInt8.truncating(300)`, interpreter.UInt8Value(44))
+	})
+
+	t.Run("unsigned, negative", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, `let x = UInt16.truncating(-1)`, interpreter.UInt16Value(math.MaxUint16))
+	})
+
+	t.Run("signed, overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, `let x = Int8.truncating(200)`, interpreter.Int8Value(-56))
+	})
+
+	t.Run("signed, underflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t, `let x = Int8.truncating(-129)`, interpreter.Int8Value(math.MaxInt8))
+	})
+
+	t.Run("word, from big integer", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y: Int256 = 0x1_0000_0000_0000_0002
+              let x = Word64.truncating(y)
+            `,
+			interpreter.Word64Value(2),
+		)
+	})
+
+	t.Run("big integer, from unsigned", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y: UInt64 = 0xffff_ffff_ffff_ffff
+              let x = Int128.truncating(y)
+            `,
+			interpreter.NewInt128ValueFromBigInt(
+				new(big.Int).SetUint64(math.MaxUint64),
+			),
+		)
+	})
+}
+
+func TestInterpretNumberFromBigEndianBytes(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, ty := range sema.AllNumberTypes {
+			// Only test leaf types
+			switch ty {
+			case sema.NumberType, sema.SignedNumberType,
+				sema.IntegerType, sema.SignedIntegerType,
+				sema.FixedPointType, sema.SignedFixedPointType:
+				continue
+			}
+
+			for _, field := range []string{sema.NumberTypeMinFieldName, sema.NumberTypeMaxFieldName} {
+
+				var bounded bool
+				switch ty := ty.(type) {
+				case *sema.NumericType:
+					bounded = ty.MinInt() != nil && ty.MaxInt() != nil
+				case *sema.FixedPointNumericType:
+					bounded = true
+				}
+
+				if !bounded {
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s.%s", ty, field), func(t *testing.T) {
+
+					inter := parseCheckAndInterpret(t,
+						fmt.Sprintf(
+							`
+                              let x = %[1]s.%[2]s
+                              let y = %[1]s.fromBigEndianBytes(x.toBigEndianBytes())
+                            `,
+							ty,
+							field,
+						),
+					)
+
+					RequireValuesEqual(
+						t,
+						inter,
+						interpreter.NewSomeValueNonCopying(inter.Globals["x"].GetValue()),
+						inter.Globals["y"].GetValue(),
+					)
+				})
+			}
+		}
+	})
+
+	test := func(t *testing.T, code string, expected interpreter.Value) {
+
+		inter := parseCheckAndInterpret(t, code)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			expected,
+			inter.Globals["x"].GetValue(),
+		)
+	}
+
+	t.Run("Int, negative", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = Int.fromBigEndianBytes((-1234567890).toBigEndianBytes())`,
+			interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(-1234567890)),
+		)
+	})
+
+	t.Run("UInt, large", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = UInt.fromBigEndianBytes([1, 0, 0, 0, 0, 0, 0, 0, 0])`,
+			interpreter.NewSomeValueNonCopying(
+				interpreter.NewUIntValueFromBigInt(
+					new(big.Int).Lsh(big.NewInt(1), 64),
+				),
+			),
+		)
+	})
+
+	t.Run("signed, shorter than type", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = Int16.fromBigEndianBytes([0xff])`,
+			interpreter.NewSomeValueNonCopying(interpreter.Int16Value(-1)),
+		)
+	})
+
+	t.Run("unsigned, shorter than type", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = UInt32.fromBigEndianBytes([0xff])`,
+			interpreter.NewSomeValueNonCopying(interpreter.UInt32Value(255)),
+		)
+	})
+
+	t.Run("fixed-point", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = UFix64.fromBigEndianBytes([0, 0, 0, 0, 7, 84, 212, 192])`,
+			interpreter.NewSomeValueNonCopying(interpreter.UFix64Value(123_000_000)),
+		)
+	})
+
+	t.Run("longer than type", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = UInt8.fromBigEndianBytes([1, 2])`,
+			interpreter.NilValue{},
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`let x = Int.fromBigEndianBytes([])`,
+			interpreter.NilValue{},
+		)
+	})
+}
+
+func TestInterpretIntegerTruncatingConvert(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expected interpreter.Value) {
+
+		inter := parseCheckAndInterpret(t, code)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			expected,
+			inter.Globals["x"].GetValue(),
+		)
+	}
+
+	t.Run("in range", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y: Int16 = 42
+              let x = y.truncatingConvert<UInt8>()
+            `,
+			interpreter.UInt8Value(42),
+		)
+	})
+
+	t.Run("unsigned, overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y: UInt64 = 0x1_2345
+              let x = y.truncatingConvert<Word16>()
+            `,
+			interpreter.Word16Value(0x2345),
+		)
+	})
+
+	t.Run("signed, overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y: UInt8 = 255
+              let x = y.truncatingConvert<Int8>()
+            `,
+			interpreter.Int8Value(-1),
+		)
+	})
+
+	t.Run("big integer", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y = Int256.min
+              let x = y.truncatingConvert<Int128>()
+            `,
+			interpreter.NewInt128ValueFromInt64(0),
+		)
+	})
+
+	t.Run("Int", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let y = -1
+              let x = y.truncatingConvert<UInt256>()
+            `,
+			interpreter.NewUInt256ValueFromBigInt(
+				sema.UInt256TypeMaxIntBig,
+			),
+		)
+	})
+}
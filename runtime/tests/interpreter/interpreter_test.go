@@ -95,6 +95,7 @@ func parseCheckAndInterpretWithOptions(
 			interpreter.WithStorage(interpreter.NewInMemoryStorage()),
 			interpreter.WithAtreeValueValidationEnabled(true),
 			interpreter.WithAtreeStorageValidationEnabled(true),
+			interpreter.WithInvariantChecksEnabled(true),
 		},
 		options.Options...,
 	)
@@ -5441,6 +5442,732 @@ func TestInterpretArrayContains(t *testing.T) {
 	)
 }
 
+func TestInterpretArrayAt(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+      let fixed: [Int; 2] = [4, 5]
+
+      let first = xs.at(0)
+      let last = xs.at(2)
+      let tooLarge = xs.at(3)
+      let negative = xs.at(-1)
+      let fixedElement = fixed.at(1)
+      let fixedTooLarge = fixed.at(UInt8(2))
+      let maxUInt64 = xs.at(UInt64.max)
+      let maxUInt256 = xs.at(UInt256.max)
+      let hugeInt = xs.at(100_000_000_000_000_000_000)
+      let hugeNegativeInt = xs.at(-100_000_000_000_000_000_000)
+      let bigIndex = xs.at(UInt128(1))
+    `)
+
+	for name, expected := range map[string]interpreter.Value{
+		"first":           interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(1)),
+		"last":            interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(3)),
+		"tooLarge":        interpreter.NilValue{},
+		"negative":        interpreter.NilValue{},
+		"fixedElement":    interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(5)),
+		"fixedTooLarge":   interpreter.NilValue{},
+		"maxUInt64":       interpreter.NilValue{},
+		"maxUInt256":      interpreter.NilValue{},
+		"hugeInt":         interpreter.NilValue{},
+		"hugeNegativeInt": interpreter.NilValue{},
+		"bigIndex":        interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(2)),
+	} {
+		AssertValuesEqual(
+			t,
+			inter,
+			expected,
+			inter.Globals[name].GetValue(),
+		)
+	}
+}
+
+func TestInterpretArrayMap(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+      let fixed: [Int; 2] = [4, 5]
+
+      let strings = xs.map(fun (x: Int): String { return x.toString() })
+      let fixedDoubled = fixed.map(fun (x: Int): Int { return x * 2 })
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewStringValue("1"),
+			interpreter.NewStringValue("2"),
+			interpreter.NewStringValue("3"),
+		},
+		arrayElements(inter, inter.Globals["strings"].GetValue().(*interpreter.ArrayValue)),
+	)
+
+	fixedDoubled := inter.Globals["fixedDoubled"].GetValue().(*interpreter.ArrayValue)
+
+	assert.Equal(t,
+		interpreter.ConstantSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeInt,
+			Size: 2,
+		},
+		fixedDoubled.Type,
+	)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(8),
+			interpreter.NewIntValueFromInt64(10),
+		},
+		arrayElements(inter, fixedDoubled),
+	)
+}
+
+func TestInterpretArrayFilter(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs: [Int; 5] = [1, 2, 3, 4, 5]
+      let odd = xs.filter(fun (x: Int): Bool { return x % 2 == 1 })
+    `)
+
+	odd := inter.Globals["odd"].GetValue().(*interpreter.ArrayValue)
+
+	assert.Equal(t,
+		interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeInt,
+		},
+		odd.Type,
+	)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(3),
+			interpreter.NewIntValueFromInt64(5),
+		},
+		arrayElements(inter, odd),
+	)
+}
+
+func TestInterpretArrayReduce(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+      let joined = xs.reduce(initial: "", fun (acc: String, x: Int): String {
+          return acc.concat(x.toString())
+      })
+      let empty = ([] as [Int]).reduce(initial: 42, fun (acc: Int, x: Int): Int {
+          return acc + x
+      })
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("123"),
+		inter.Globals["joined"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(42),
+		inter.Globals["empty"].GetValue(),
+	)
+}
+
+func TestInterpretArrayReverse(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+      let reversed = xs.reverse()
+      let empty = ([] as [Int]).reverse()
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(3),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(1),
+		},
+		arrayElements(inter, inter.Globals["reversed"].GetValue().(*interpreter.ArrayValue)),
+	)
+
+	// The original array is not modified
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(3),
+		},
+		arrayElements(inter, inter.Globals["xs"].GetValue().(*interpreter.ArrayValue)),
+	)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		nil,
+		arrayElements(inter, inter.Globals["empty"].GetValue().(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretArrayFirstIndex(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3, 2]
+      let found = xs.firstIndex(of: 2)
+      let notFound = xs.firstIndex(of: 4)
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(1)),
+		inter.Globals["found"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NilValue{},
+		inter.Globals["notFound"].GetValue(),
+	)
+}
+
+func TestInterpretArraySliceConstantSized(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs: [Int; 3] = [1, 2, 3]
+      let ys = xs.slice(from: 1, upTo: 3)
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(3),
+		},
+		arrayElements(inter, inter.Globals["ys"].GetValue().(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretArrayRemoveFirstWhere(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3, 4]
+      let removed = xs.removeFirst(where: fun (x: Int): Bool { return x % 2 == 0 })
+
+      fun test() {
+          xs.removeFirst(where: fun (x: Int): Bool { return x > 10 })
+      }
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(3),
+			interpreter.NewIntValueFromInt64(4),
+		},
+		arrayElements(inter, inter.Globals["xs"].GetValue().(*interpreter.ArrayValue)),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(2),
+		inter.Globals["removed"].GetValue(),
+	)
+
+	_, err := inter.Invoke("test")
+	require.ErrorAs(t, err, &interpreter.ArrayElementNotFoundError{})
+}
+
+func TestInterpretArrayRemoveAll(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3, 4, 5]
+      let removed = xs.removeAll(where: fun (x: Int): Bool { return x % 2 == 1 })
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(4),
+		},
+		arrayElements(inter, inter.Globals["xs"].GetValue().(*interpreter.ArrayValue)),
+	)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(3),
+			interpreter.NewIntValueFromInt64(5),
+		},
+		arrayElements(inter, inter.Globals["removed"].GetValue().(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretArrayRemoveAllLarge(t *testing.T) {
+
+	t.Parallel()
+
+	// The array is stored in multiple slabs,
+	// and the long strings are stored in separate slabs
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [Int] {
+          let long = "0123456789".concat("0123456789").concat("0123456789").concat("0123456789")
+          let xs: [String] = []
+          var i = 0
+          while i < 1000 {
+              xs.append(long.concat(i.toString()))
+              i = i + 1
+          }
+
+          let removed = xs.removeAll(where: fun (x: String): Bool {
+              return x.length % 2 == 1
+          })
+
+          let kept = xs.removeAll(where: fun (x: String): Bool {
+              return true
+          })
+
+          return [removed.length, kept.length, xs.length, kept[0].length, removed[0].length]
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(910),
+			interpreter.NewIntValueFromInt64(90),
+			interpreter.NewIntValueFromInt64(0),
+			interpreter.NewIntValueFromInt64(42),
+			interpreter.NewIntValueFromInt64(41),
+		},
+		arrayElements(inter, value.(*interpreter.ArrayValue)),
+	)
+}
+
+func BenchmarkArrayRemoveAll(b *testing.B) {
+
+	inter, err := interpreter.NewInterpreter(
+		nil,
+		TestLocation,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+	)
+	require.NoError(b, err)
+
+	arrayType := interpreter.VariableSizedStaticType{
+		Type: interpreter.PrimitiveStaticTypeInt,
+	}
+
+	const count = 10_000
+
+	elements := make([]interpreter.Value, count)
+	for i := range elements {
+		elements[i] = interpreter.NewIntValueFromInt64(int64(i))
+	}
+
+	predicateType := &sema.FunctionType{
+		Parameters: []*sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "element",
+				TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+			},
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.BoolType),
+	}
+
+	for _, all := range []bool{true, false} {
+
+		name := "all match"
+		if !all {
+			name = "half match"
+		}
+
+		predicate := interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				element := invocation.Arguments[0].(interpreter.IntValue)
+				return interpreter.BoolValue(all || element.BigInt.Bit(0) == 0)
+			},
+			predicateType,
+		)
+
+		b.Run(name, func(b *testing.B) {
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+
+				array := interpreter.NewArrayValue(
+					inter,
+					arrayType,
+					common.Address{},
+					elements...,
+				)
+
+				b.StartTimer()
+
+				array.RemoveAll(inter, interpreter.ReturnEmptyLocationRange, predicate)
+			}
+		})
+	}
+}
+
+func TestInterpretResourceArrayRemoveAll(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      resource R {
+          let id: Int
+
+          init(id: Int) {
+              self.id = id
+          }
+      }
+
+      fun hasEvenID(_ r: &R): Bool {
+          return r.id % 2 == 0
+      }
+
+      fun test(): [Int] {
+          let rs <- [<-create R(id: 1), <-create R(id: 2), <-create R(id: 4)]
+          let removed <- rs.removeAll(where: hasEvenID)
+          let ids = [rs[0].id, removed[0].id, removed[1].id]
+          destroy removed
+          destroy rs
+          return ids
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(4),
+		},
+		arrayElements(inter, value.(*interpreter.ArrayValue)),
+	)
+}
+
+func TestInterpretArrayToVariableSized(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs: [Int; 3] = [1, 2, 3]
+      let ys = xs.toVariableSized()
+    `)
+
+	value := inter.Globals["ys"].GetValue().(*interpreter.ArrayValue)
+
+	assert.Equal(t,
+		interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeInt,
+		},
+		value.StaticType(),
+	)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(3),
+		},
+		arrayElements(inter, value),
+	)
+}
+
+func TestInterpretArrayToConstantSized(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs: [Int] = [1, 2]
+      let matching = xs.toConstantSized<[Int; 2]>()
+      let tooSmall = xs.toConstantSized<[Int; 1]>()
+      let tooLarge = xs.toConstantSized<[Int; 3]>()
+    `)
+
+	matching := inter.Globals["matching"].GetValue().(*interpreter.SomeValue)
+	value := matching.Value.(*interpreter.ArrayValue)
+
+	assert.Equal(t,
+		interpreter.ConstantSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeInt,
+			Size: 2,
+		},
+		value.StaticType(),
+	)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+		},
+		arrayElements(inter, value),
+	)
+
+	AssertValuesEqual(t, inter, interpreter.NilValue{}, inter.Globals["tooSmall"].GetValue())
+	AssertValuesEqual(t, inter, interpreter.NilValue{}, inter.Globals["tooLarge"].GetValue())
+}
+
+func TestInterpretDictionaryFilter(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = {"a": 1, "b": 2, "c": 3}
+      let filtered = xs.filter(fun (key: String, value: Int): Bool {
+          return key != "a" && value < 3
+      })
+    `)
+
+	AssertValueSlicesEqual(
+		t,
+		inter,
+		[]interpreter.Value{
+			interpreter.NewStringValue("b"),
+			interpreter.NewIntValueFromInt64(2),
+		},
+		dictionaryKeyValues(inter.Globals["filtered"].GetValue().(*interpreter.DictionaryValue)),
+	)
+
+	require.Equal(t, 3, inter.Globals["xs"].GetValue().(*interpreter.DictionaryValue).Count())
+}
+
+func TestInterpretDictionaryForEachKey(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = {"a": 1, "b": 2, "c": 3}
+
+      fun test(): [String] {
+          let keys: [String] = []
+          xs.forEachKey(fun (key: String): Bool {
+              keys.append(key)
+              return true
+          })
+          return keys
+      }
+
+      fun testFirst(): [String] {
+          let keys: [String] = []
+          xs.forEachKey(fun (key: String): Bool {
+              keys.append(key)
+              return false
+          })
+          return keys
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	// Keys are iterated in the same order as the keys field
+
+	RequireValuesEqual(
+		t,
+		inter,
+		inter.Globals["xs"].GetValue().(*interpreter.DictionaryValue).
+			GetMember(inter, interpreter.ReturnEmptyLocationRange, "keys"),
+		value,
+	)
+
+	value, err = inter.Invoke("testFirst")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, value.(*interpreter.ArrayValue).Count())
+}
+
+func TestInterpretDictionaryForEachValue(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = {"a": 1, "b": 2, "c": 3}
+
+      fun test(): Int {
+          var sum = 0
+          xs.forEachValue(fun (value: Int): Bool {
+              sum = sum + value
+              return true
+          })
+          return sum
+      }
+
+      fun testFirst(): Int {
+          var count = 0
+          xs.forEachValue(fun (value: Int): Bool {
+              count = count + 1
+              return false
+          })
+          return count
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(6),
+		value,
+	)
+
+	value, err = inter.Invoke("testFirst")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(1),
+		value,
+	)
+}
+
+// retrieveCountingStorage is an in-memory storage which counts the retrieved slabs
+//
+type retrieveCountingStorage struct {
+	interpreter.InMemoryStorage
+	retrieved int
+}
+
+func (s *retrieveCountingStorage) Retrieve(id atree.StorageID) (atree.Slab, bool, error) {
+	s.retrieved++
+	return s.InMemoryStorage.Retrieve(id)
+}
+
+func TestInterpretDictionaryForEachEarlyExit(t *testing.T) {
+
+	t.Parallel()
+
+	storage := &retrieveCountingStorage{
+		InMemoryStorage: interpreter.NewInMemoryStorage(),
+	}
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun fill(): {Int: Int} {
+              let xs: {Int: Int} = {}
+              var i = 0
+              while i < 1000 {
+                  xs[i] = i
+                  i = i + 1
+              }
+              return xs
+          }
+
+          let xs = fill()
+
+          fun forEachKey(_ resume: Bool): Int {
+              var count = 0
+              xs.forEachKey(fun (key: Int): Bool {
+                  count = count + 1
+                  return resume
+              })
+              return count
+          }
+
+          fun forEachValue(_ resume: Bool): Int {
+              var count = 0
+              xs.forEachValue(fun (value: Int): Bool {
+                  count = count + 1
+                  return resume
+              })
+              return count
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithStorage(storage),
+				interpreter.WithAtreeValueValidationEnabled(false),
+				interpreter.WithAtreeStorageValidationEnabled(false),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	for _, function := range []string{"forEachKey", "forEachValue"} {
+
+		storage.retrieved = 0
+
+		value, err := inter.Invoke(function, interpreter.BoolValue(true))
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.NewIntValueFromInt64(1000), value)
+
+		retrievedAll := storage.retrieved
+
+		storage.retrieved = 0
+
+		value, err = inter.Invoke(function, interpreter.BoolValue(false))
+		require.NoError(t, err)
+
+		AssertValuesEqual(t, inter, interpreter.NewIntValueFromInt64(1), value)
+
+		// The iteration is lazy, so an early exit does not retrieve
+		// the slabs of the remaining entries
+
+		retrievedFirst := storage.retrieved
+
+		assert.Less(t, retrievedFirst, retrievedAll/10, function)
+	}
+}
+
 func TestInterpretDictionaryContainsKey(t *testing.T) {
 
 	t.Parallel()
@@ -8710,6 +9437,7 @@ func newTestAuthAccountValue(
 				panicFunction,
 				panicFunction,
 				panicFunction,
+				panicFunction,
 				func(inter *interpreter.Interpreter) *interpreter.ArrayValue {
 					return interpreter.NewArrayValue(
 						inter,
@@ -9415,6 +10143,103 @@ func BenchmarkInterpretRecursionFib(b *testing.B) {
 	}
 }
 
+func BenchmarkInterpretDictionaryLookup(b *testing.B) {
+
+	// The same key values are used repeatedly,
+	// so their hash inputs are only computed once
+
+	inter, err := parseCheckAndInterpretWithOptions(b, `
+       enum Color: UInt8 {
+           pub case red
+           pub case green
+           pub case blue
+       }
+
+       let strings: {String: Int} = {"alpha": 1, "bravo": 2, "charlie": 3}
+
+       let stringKey = "charlie"
+
+       let enums: {Color: Int} = {Color.red: 1, Color.green: 2, Color.blue: 3}
+
+       let enumKey = Color.blue
+
+       fun lookupStrings(_ n: Int): Int {
+           var sum = 0
+           var i = 0
+           while i < n {
+               sum = sum + strings["alpha"]! + strings["bravo"]! + strings["charlie"]!
+               i = i + 1
+           }
+           return sum
+       }
+
+       fun lookupEnums(_ n: Int): Int {
+           let red = Color.red
+           let green = Color.green
+           let blue = Color.blue
+           var sum = 0
+           var i = 0
+           while i < n {
+               sum = sum + enums[red]! + enums[green]! + enums[blue]!
+               i = i + 1
+           }
+           return sum
+       }
+   `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithAtreeValueValidationEnabled(false),
+				interpreter.WithAtreeStorageValidationEnabled(false),
+			},
+		},
+	)
+	require.NoError(b, err)
+
+	const n = 100
+
+	expected := interpreter.NewIntValueFromInt64(6 * n)
+
+	for _, function := range []string{"lookupStrings", "lookupEnums"} {
+
+		b.Run(function, func(b *testing.B) {
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+
+				result, err := inter.Invoke(
+					function,
+					interpreter.NewIntValueFromInt64(n),
+				)
+				require.NoError(b, err)
+				RequireValuesEqual(b, inter, expected, result)
+			}
+		})
+	}
+
+	for _, names := range [][2]string{
+		{"strings", "stringKey"},
+		{"enums", "enumKey"},
+	} {
+		dictionary := inter.Globals[names[0]].GetValue().(*interpreter.DictionaryValue)
+		key := inter.Globals[names[1]].GetValue()
+
+		b.Run("get "+names[0], func(b *testing.B) {
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, ok := dictionary.Get(inter, interpreter.ReturnEmptyLocationRange, key)
+				if !ok {
+					b.Fatal("missing key")
+				}
+			}
+		})
+	}
+}
+
 func TestInterpretMissingMember(t *testing.T) {
 
 	t.Parallel()
@@ -41,3 +41,118 @@ func TestInterpretFunctionInvocationCheckArgumentTypes(t *testing.T) {
 
 	require.ErrorAs(t, err, &interpreter.ValueTransferTypeError{})
 }
+
+func TestInterpretCompositeFunctionInvocation(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      struct interface HasValue {
+          fun getValue(): Int {
+              post { result > 0 }
+          }
+      }
+
+      struct S: HasValue {
+          let value: Int
+          let getter: ((): Int)
+
+          init(value: Int) {
+              self.value = value
+              self.getter = fun (): Int {
+                  return value * 10
+              }
+          }
+
+          fun getValue(): Int {
+              return self.value
+          }
+
+          fun getDoubleValue(): Int {
+              return self.getValue() * 2
+          }
+      }
+
+      fun test(): [Int] {
+          let s1 = S(value: 1)
+          let s2 = S(value: 2)
+          let hasValue: {HasValue} = s2
+          let ref = &s1 as &S
+          return [
+              s1.getValue(),
+              s2.getValue(),
+              s1.getDoubleValue(),
+              hasValue.getValue(),
+              ref.getDoubleValue(),
+              s2.getter()
+          ]
+      }
+
+      fun testPostCondition() {
+          let s: {HasValue} = S(value: 0)
+          s.getValue()
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(20),
+		},
+		arrayElements(inter, result.(*interpreter.ArrayValue)),
+	)
+
+	// The function declared in the interface is wrapped
+	// and the post-condition is checked
+
+	_, err = inter.Invoke("testPostCondition")
+	require.Error(t, err)
+	require.ErrorAs(t, err, &interpreter.ConditionError{})
+}
+
+func BenchmarkInterpretCompositeFunctionInvocation(b *testing.B) {
+
+	inter := parseCheckAndInterpret(b, `
+      struct Adder {
+          let value: Int
+
+          init(value: Int) {
+              self.value = value
+          }
+
+          fun add(_ x: Int): Int {
+              return x + self.value
+          }
+      }
+
+      let adder = Adder(value: 1)
+
+      fun test(): Int {
+          var sum = 0
+          var i = 0
+          while i < 100 {
+              sum = adder.add(sum)
+              i = i + 1
+          }
+          return sum
+      }
+    `)
+
+	expected := interpreter.NewIntValueFromInt64(100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result, err := inter.Invoke("test")
+		require.NoError(b, err)
+		require.Equal(b, expected, result)
+	}
+}
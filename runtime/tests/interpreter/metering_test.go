@@ -19,6 +19,7 @@
 package interpreter_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -422,3 +423,384 @@ func TestInterpretFunctionInvocationHandler(t *testing.T) {
 		occurrences,
 	)
 }
+
+func TestInterpretMeterComputationHandler(t *testing.T) {
+
+	t.Parallel()
+
+	const iterations = 1000
+	const partLength = 10
+
+	var computations []common.ComputationKind
+	var intensity uint
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun test(): Int {
+              var s = ""
+              var i = 0
+              while i < 1000 {
+                  s = s.concat("abcdefghij")
+                  i = i + 1
+              }
+              return s.length
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithOnMeterComputationHandler(
+					func(_ *interpreter.Interpreter, kind common.ComputationKind, i uint) {
+						computations = append(computations, kind)
+						intensity += i
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	const length = iterations * partLength
+
+	assert.Equal(t, interpreter.NewIntValueFromInt64(length), result)
+
+	require.Len(t, computations, iterations)
+	for _, kind := range computations {
+		assert.Equal(t, common.ComputationKindStringConcatenation, kind)
+	}
+
+	// Repeated concatenation is linear, not quadratic:
+	// the number of copied bytes is proportional to the length of the result
+
+	assert.Less(t, intensity, uint(10*length))
+}
+
+type testComputationMeter func(kind common.ComputationKind, intensity uint) error
+
+var _ interpreter.ComputationMeter = testComputationMeter(nil)
+
+func (m testComputationMeter) MeterComputation(kind common.ComputationKind, intensity uint) error {
+	return m(kind, intensity)
+}
+
+func TestInterpretComputationMeter(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun add(_ a: Int, _ b: Int): Int {
+          return a + b
+      }
+
+      fun test(): Int {
+          let values = [1, 2, 3]
+          var sum = 0
+          for value in values {
+              sum = add(sum, value)
+          }
+          return sum
+      }
+    `
+
+	type computation struct {
+		kind      common.ComputationKind
+		intensity uint
+	}
+
+	run := func(t *testing.T) []computation {
+
+		var computations []computation
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithComputationMeter(
+						testComputationMeter(func(kind common.ComputationKind, intensity uint) error {
+							computations = append(computations, computation{kind, intensity})
+							return nil
+						}),
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		computations = nil
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, interpreter.NewIntValueFromInt64(6), result)
+
+		return computations
+	}
+
+	t.Run("kinds", func(t *testing.T) {
+
+		t.Parallel()
+
+		computations := run(t)
+
+		intensities := map[common.ComputationKind]uint{}
+		for _, computation := range computations {
+			intensities[computation.kind] += computation.intensity
+		}
+
+		assert.Equal(t,
+			map[common.ComputationKind]uint{
+				common.ComputationKindStatement:          10,
+				common.ComputationKindLoopIteration:      3,
+				common.ComputationKindFunctionInvocation: 3,
+				common.ComputationKindValueAllocation:    4,
+			},
+			intensities,
+		)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t, run(t), run(t))
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		meterErr := errors.New("limit exceeded")
+
+		var loopIterations int
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithComputationMeter(
+						testComputationMeter(func(kind common.ComputationKind, _ uint) error {
+							if kind != common.ComputationKindLoopIteration {
+								return nil
+							}
+							loopIterations++
+							if loopIterations > 2 {
+								return meterErr
+							}
+							return nil
+						}),
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorIs(t, err, meterErr)
+
+		assert.Equal(t, 3, loopIterations)
+	})
+}
+
+type testMemoryGauge func(usage common.MemoryUsage) error
+
+var _ common.MemoryGauge = testMemoryGauge(nil)
+
+func (g testMemoryGauge) MeterMemory(usage common.MemoryUsage) error {
+	return g(usage)
+}
+
+func TestInterpretMemoryGauge(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("kinds", func(t *testing.T) {
+
+		t.Parallel()
+
+		amounts := map[common.MemoryKind]uint64{}
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              struct S {
+                  let x: Int
+                  let y: Int
+
+                  init() {
+                      self.x = 1
+                      self.y = 2
+                  }
+              }
+
+              fun test(): [AnyStruct] {
+                  let s = "abc".concat("de")
+                  let big = 0x1_0000_0000_0000_0000 * 0x1_0000_0000_0000_0000
+                  let dict = {1: 2}
+                  return [s, big, dict, S()]
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(
+						testMemoryGauge(func(usage common.MemoryUsage) error {
+							amounts[usage.Kind] += usage.Amount
+							return nil
+						}),
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		for kind := range amounts {
+			delete(amounts, kind)
+		}
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			map[common.MemoryKind]uint64{
+				// "abc", "de", and "abcde"
+				common.MemoryKindString: 10,
+				// 2^128 has 3 words
+				common.MemoryKindBigInt: 24,
+				// 4 elements
+				common.MemoryKindArray: 128,
+				// 1 entry
+				common.MemoryKindDictionary: 80,
+				// 2 fields
+				common.MemoryKindComposite: 96,
+			},
+			amounts,
+		)
+	})
+
+	t.Run("container growth", func(t *testing.T) {
+
+		t.Parallel()
+
+		amounts := map[common.MemoryKind]uint64{}
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun test() {
+                  let array: [Int] = []
+                  array.append(1)
+                  array.append(2)
+                  array.insert(at: 0, 3)
+
+                  let dict: {Int: Int} = {}
+                  dict[1] = 1
+                  dict.insert(key: 2, 2)
+                  // existing keys do not grow the dictionary
+                  dict[1] = 3
+                  dict.insert(key: 2, 4)
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(
+						testMemoryGauge(func(usage common.MemoryUsage) error {
+							amounts[usage.Kind] += usage.Amount
+							return nil
+						}),
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		for kind := range amounts {
+			delete(amounts, kind)
+		}
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			map[common.MemoryKind]uint64{
+				// empty array, and 3 appended or inserted elements
+				common.MemoryKindArray: 112,
+				// empty dictionary, and 2 inserted entries
+				common.MemoryKindDictionary: 96,
+			},
+			amounts,
+		)
+	})
+
+	t.Run("container growth, error", func(t *testing.T) {
+
+		t.Parallel()
+
+		gaugeErr := errors.New("memory limit exceeded")
+
+		var arrayMemory uint64
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun test() {
+                  let array: [Int] = []
+                  while true {
+                      array.append(1)
+                  }
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(
+						testMemoryGauge(func(usage common.MemoryUsage) error {
+							if usage.Kind == common.MemoryKindArray {
+								arrayMemory += usage.Amount
+								if arrayMemory > 1000 {
+									return gaugeErr
+								}
+							}
+							return nil
+						}),
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorIs(t, err, gaugeErr)
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		gaugeErr := errors.New("memory limit exceeded")
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun test(): String {
+                  var s = "a"
+                  while true {
+                      s = s.concat(s)
+                  }
+                  return s
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithMemoryGauge(
+						testMemoryGauge(func(usage common.MemoryUsage) error {
+							if usage.Kind == common.MemoryKindString && usage.Amount > 1000 {
+								return gaugeErr
+							}
+							return nil
+						}),
+					),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorIs(t, err, gaugeErr)
+	})
+}
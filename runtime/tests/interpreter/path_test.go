@@ -138,7 +138,7 @@ func TestInterpretConvertStringToPath(t *testing.T) {
 		})
 	}
 
-	for _, domain := range common.AllPathDomainsByIdentifier {
+	for domain := range domainTypes {
 		test(domain)
 	}
 }
@@ -19,6 +19,7 @@
 package interpreter_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -205,3 +206,208 @@ func TestInterpretStringToLower(t *testing.T) {
 		result,
 	)
 }
+
+func TestInterpretStringRepeatedConcat(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): [String] {
+          var s = ""
+          var i = 0
+          while i < 100 {
+              s = s.concat("abcdefghij")
+              i = i + 1
+          }
+
+          // Concatenate the same string multiple times,
+          // the results must be independent
+
+          let a = s.concat("A")
+          let b = s.concat("B")
+          let c = a.concat("C")
+          let d = b.concat("D")
+          return [
+              s.slice(from: 990, upTo: 1000),
+              a.slice(from: 995, upTo: 1001),
+              b.slice(from: 995, upTo: 1001),
+              c.slice(from: 995, upTo: 1002),
+              d.slice(from: 995, upTo: 1002)
+          ]
+      }
+	`)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.IsType(t, &interpreter.ArrayValue{}, result)
+	array := result.(*interpreter.ArrayValue)
+
+	var values []string
+	array.Iterate(func(element interpreter.Value) (resume bool) {
+		values = append(values, element.(*interpreter.StringValue).Str)
+		return true
+	})
+
+	require.Equal(t,
+		[]string{
+			"abcdefghij",
+			"fghijA",
+			"fghijB",
+			"fghijAC",
+			"fghijBD",
+		},
+		values,
+	)
+}
+
+func TestInterpretStringFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	newStringArray := func(inter *interpreter.Interpreter, strs ...string) *interpreter.ArrayValue {
+		values := make([]interpreter.Value, len(strs))
+		for i, str := range strs {
+			values[i] = interpreter.NewStringValue(str)
+		}
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.StringArrayStaticType,
+			common.Address{},
+			values...,
+		)
+	}
+
+	newUTF16Array := func(inter *interpreter.Interpreter, codeUnits ...uint16) *interpreter.ArrayValue {
+		values := make([]interpreter.Value, len(codeUnits))
+		for i, codeUnit := range codeUnits {
+			values[i] = interpreter.UInt16Value(codeUnit)
+		}
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.UTF16CodeUnitArrayStaticType,
+			common.Address{},
+			values...,
+		)
+	}
+
+	type test struct {
+		code     string
+		expected func(inter *interpreter.Interpreter) interpreter.Value
+	}
+
+	stringValue := func(str string) func(inter *interpreter.Interpreter) interpreter.Value {
+		return func(_ *interpreter.Interpreter) interpreter.Value {
+			return interpreter.NewStringValue(str)
+		}
+	}
+
+	intValue := func(i int64) func(inter *interpreter.Interpreter) interpreter.Value {
+		return func(_ *interpreter.Interpreter) interpreter.Value {
+			return interpreter.NewIntValueFromInt64(i)
+		}
+	}
+
+	boolValue := func(b bool) func(inter *interpreter.Interpreter) interpreter.Value {
+		return func(_ *interpreter.Interpreter) interpreter.Value {
+			return interpreter.BoolValue(b)
+		}
+	}
+
+	stringArray := func(strs ...string) func(inter *interpreter.Interpreter) interpreter.Value {
+		return func(inter *interpreter.Interpreter) interpreter.Value {
+			return newStringArray(inter, strs...)
+		}
+	}
+
+	utf16Array := func(codeUnits ...uint16) func(inter *interpreter.Interpreter) interpreter.Value {
+		return func(inter *interpreter.Interpreter) interpreter.Value {
+			return newUTF16Array(inter, codeUnits...)
+		}
+	}
+
+	optionalString := func(str string) func(inter *interpreter.Interpreter) interpreter.Value {
+		return func(_ *interpreter.Interpreter) interpreter.Value {
+			return interpreter.NewSomeValueNonCopying(
+				interpreter.NewStringValue(str),
+			)
+		}
+	}
+
+	nilValue := func(_ *interpreter.Interpreter) interpreter.Value {
+		return interpreter.NilValue{}
+	}
+
+	tests := []test{
+		{`"Flowers".toUpper()`, stringValue("FLOWERS")},
+
+		{`"a,b,,c".split(separator: ",")`, stringArray("a", "b", "", "c")},
+		{`"a--b".split(separator: "--")`, stringArray("a", "b")},
+		{`"abc".split(separator: "x")`, stringArray("abc")},
+		{`"a\u{1F1E8}\u{1F1E6}b".split(separator: "")`, stringArray("a", "\U0001F1E8\U0001F1E6", "b")},
+
+		{`"a.b.c".replaceAll(of: ".", with: "::")`, stringValue("a::b::c")},
+		{`"abc".replaceAll(of: "", with: "x")`, stringValue("abc")},
+
+		{`"hello".index(of: "l")`, intValue(2)},
+		{`"hello".index(of: "")`, intValue(0)},
+		{`"hello".index(of: "x")`, intValue(-1)},
+		{`"\u{1F1E8}\u{1F1E6}ab".index(of: "b")`, intValue(2)},
+		// "e" is only part of the character "é"
+		{`"e\u{301}".index(of: "e")`, intValue(-1)},
+
+		{`"hello".contains("ell")`, boolValue(true)},
+		{`"hello".contains("olleh")`, boolValue(false)},
+
+		{`"7".padLeft(toLength: 3, with: "0")`, stringValue("007")},
+		{`"1234".padLeft(toLength: 3, with: "0")`, stringValue("1234")},
+
+		{`"a\u{1F490}".utf16`, utf16Array(0x61, 0xD83D, 0xDC90)},
+
+		{`String.fromUTF8([0x61, 0xF0, 0x9F, 0x92, 0x90])`, optionalString("a\U0001F490")},
+		{`String.fromUTF8([0xFF])`, nilValue},
+
+		{`String.fromUTF16([0x61, 0xD83D, 0xDC90])`, optionalString("a\U0001F490")},
+		{`String.fromUTF16([0xD83D])`, nilValue},
+		{`String.fromUTF16([0xDC90, 0xD83D])`, nilValue},
+
+		{`String.join(["a", "b", "c"], separator: ", ")`, stringValue("a, b, c")},
+		{`String.join([], separator: ", ")`, stringValue("")},
+
+		{`("\u{1F490}" as Character).utf8.length`, intValue(4)},
+		{`("\u{1F490}" as Character).utf16`, utf16Array(0xD83D, 0xDC90)},
+		{`"abc"[1].toString()`, stringValue("b")},
+	}
+
+	for _, test := range tests {
+
+		// Capture the loop variable
+		test := test
+
+		t.Run(test.code, func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): AnyStruct {
+                          return %s
+                      }
+                    `,
+					test.code,
+				),
+			)
+
+			result, err := inter.Invoke("test")
+			require.NoError(t, err)
+
+			RequireValuesEqual(
+				t,
+				inter,
+				test.expected(inter),
+				result,
+			)
+		})
+	}
+}
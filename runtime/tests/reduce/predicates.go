@@ -0,0 +1,124 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reduce
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+// ParserCrashes is a predicate which holds if parsing the program panics.
+//
+func ParserCrashes(program string) bool {
+	return panics(func() {
+		_, _ = parser2.ParseProgram(program)
+	})
+}
+
+// CheckerCrashes is a predicate which holds if parsing or checking the program panics.
+//
+// Programs which fail to parse are not interesting.
+//
+func CheckerCrashes(program string) bool {
+	return panics(func() {
+		parsedProgram, err := parser2.ParseProgram(program)
+		if err != nil {
+			return
+		}
+		_ = check(parsedProgram)
+	})
+}
+
+// ErrorContains returns a predicate which holds if parsing or checking the program
+// reports an error with a message that contains the given substring.
+//
+// Programs for which the parser or checker panics are not interesting.
+//
+func ErrorContains(substring string) Predicate {
+	return func(program string) (result bool) {
+		defer func() {
+			if recover() != nil {
+				result = false
+			}
+		}()
+
+		parsedProgram, err := parser2.ParseProgram(program)
+		if err == nil {
+			err = check(parsedProgram)
+		}
+
+		return errorContains(err, substring)
+	}
+}
+
+func check(program *ast.Program) error {
+	checker, err := sema.NewChecker(
+		program,
+		utils.TestLocation,
+		sema.WithPredeclaredValues(stdlib.BuiltinFunctions.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(stdlib.BuiltinTypes.ToTypeDeclarations()),
+		sema.WithAccessCheckMode(sema.AccessCheckModeNotSpecifiedUnrestricted),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checker.Check()
+}
+
+// errorContains returns true if the message of the given error,
+// or of any of its child errors, contains the given substring.
+//
+// The messages of parent errors are not considered,
+// as they might include the pretty-printed program.
+//
+func errorContains(err error, substring string) bool {
+	if err == nil {
+		return false
+	}
+
+	if parentError, ok := err.(errors.ParentError); ok {
+		for _, childError := range parentError.ChildErrors() {
+			if errorContains(childError, substring) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return strings.Contains(err.Error(), substring)
+}
+
+func panics(f func()) (result bool) {
+	defer func() {
+		if recover() != nil {
+			result = true
+		}
+	}()
+
+	f()
+
+	return false
+}
@@ -0,0 +1,211 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package reduce implements a delta-debugging reducer for Cadence programs.
+//
+// Given a program and a predicate which holds for it, e.g. "the checker crashes",
+// the reducer removes parts of the program for as long as the predicate still holds,
+// and returns a minimal program which still reproduces the behaviour.
+//
+// The program is first reduced line by line, and then token by token.
+// The algorithm is ddmin, as described by Zeller and Hildebrandt in
+// "Simplifying and Isolating Failure-Inducing Input".
+package reduce
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Predicate returns true if the given program is "interesting",
+// i.e. if it still reproduces the behaviour which should be preserved.
+//
+// Predicates must be deterministic.
+//
+type Predicate func(program string) bool
+
+// Reduce returns a minimal version of the given program for which the predicate still holds.
+//
+// If the predicate does not hold for the given program, the program is returned unchanged.
+//
+func Reduce(program string, predicate Predicate) string {
+	predicate = cachedPredicate(predicate)
+
+	if !predicate(program) {
+		return program
+	}
+
+	program = reduceLines(program, predicate)
+	program = reduceTokens(program, predicate)
+
+	return program
+}
+
+// ReduceLines returns a version of the given program with a minimal set of lines,
+// for which the predicate still holds.
+//
+// If the predicate does not hold for the given program, the program is returned unchanged.
+//
+func ReduceLines(program string, predicate Predicate) string {
+	predicate = cachedPredicate(predicate)
+
+	if !predicate(program) {
+		return program
+	}
+
+	return reduceLines(program, predicate)
+}
+
+// ReduceTokens returns a version of the given program with a minimal set of tokens,
+// for which the predicate still holds.
+//
+// If the predicate does not hold for the given program, the program is returned unchanged.
+//
+func ReduceTokens(program string, predicate Predicate) string {
+	predicate = cachedPredicate(predicate)
+
+	if !predicate(program) {
+		return program
+	}
+
+	return reduceTokens(program, predicate)
+}
+
+func reduceLines(program string, predicate Predicate) string {
+	lines := strings.Split(program, "\n")
+	lines = ddmin(
+		lines,
+		func(lines []string) bool {
+			return predicate(strings.Join(lines, "\n"))
+		},
+	)
+	return strings.Join(lines, "\n")
+}
+
+// tokenRegexp splits a program into coarse tokens:
+// identifiers and number literals, string literals, and any other single character.
+// Trailing whitespace is kept with the token, so joining the tokens results in the original program.
+//
+var tokenRegexp = regexp.MustCompile(`(?s)(?:[\p{L}\p{N}_]+|"(?:[^"\\\n]|\\.)*"|.)\s*`)
+
+func reduceTokens(program string, predicate Predicate) string {
+	tokens := tokenRegexp.FindAllString(program, -1)
+	tokens = ddmin(
+		tokens,
+		func(tokens []string) bool {
+			return predicate(strings.Join(tokens, ""))
+		},
+	)
+	return strings.Join(tokens, "")
+}
+
+// ddmin returns a 1-minimal subsequence of the given parts for which the test holds,
+// i.e. removing any single part of the result makes the test fail.
+//
+// The test must hold for the given parts.
+//
+func ddmin(parts []string, test func(parts []string) bool) []string {
+	granularity := 2
+
+	for len(parts) >= 2 {
+
+		chunkSize := (len(parts) + granularity - 1) / granularity
+		reduced := false
+
+		// Try to reduce to a single chunk
+
+		for start := 0; start < len(parts); start += chunkSize {
+			end := start + chunkSize
+			if end > len(parts) {
+				end = len(parts)
+			}
+
+			subset := parts[start:end]
+			if len(subset) < len(parts) && test(subset) {
+				parts = subset
+				granularity = 2
+				reduced = true
+				break
+			}
+		}
+
+		// Try to remove a single chunk
+
+		if !reduced && granularity > 2 {
+			for start := 0; start < len(parts); start += chunkSize {
+				end := start + chunkSize
+				if end > len(parts) {
+					end = len(parts)
+				}
+
+				complement := make([]string, 0, len(parts)-(end-start))
+				complement = append(complement, parts[:start]...)
+				complement = append(complement, parts[end:]...)
+
+				if test(complement) {
+					parts = complement
+					granularity--
+					if granularity < 2 {
+						granularity = 2
+					}
+					reduced = true
+					break
+				}
+			}
+		}
+
+		if reduced {
+			continue
+		}
+
+		// Increase the granularity, unless each chunk is already a single part
+
+		if granularity >= len(parts) {
+			break
+		}
+
+		granularity *= 2
+		if granularity > len(parts) {
+			granularity = len(parts)
+		}
+	}
+
+	// An empty program might also be interesting
+
+	if len(parts) == 1 && test(nil) {
+		return nil
+	}
+
+	return parts
+}
+
+// cachedPredicate returns a predicate which only calls the given predicate
+// once for each distinct program.
+//
+func cachedPredicate(predicate Predicate) Predicate {
+	results := map[string]bool{}
+
+	return func(program string) bool {
+		result, ok := results[program]
+		if !ok {
+			result = predicate(program)
+			results[program] = result
+		}
+		return result
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reduce
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduceLines(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("minimal", func(t *testing.T) {
+
+		t.Parallel()
+
+		reduced := ReduceLines(
+			"a\nb\nc\nd\ne\nf\ng\nh",
+			func(program string) bool {
+				return strings.Contains(program, "b") &&
+					strings.Contains(program, "g")
+			},
+		)
+
+		assert.Equal(t, "b\ng", reduced)
+	})
+
+	t.Run("not interesting", func(t *testing.T) {
+
+		t.Parallel()
+
+		reduced := ReduceLines(
+			"a\nb",
+			func(program string) bool {
+				return false
+			},
+		)
+
+		assert.Equal(t, "a\nb", reduced)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		reduced := ReduceLines(
+			"a\nb",
+			func(program string) bool {
+				return true
+			},
+		)
+
+		assert.Equal(t, "", reduced)
+	})
+}
+
+func TestReduceTokens(t *testing.T) {
+
+	t.Parallel()
+
+	reduced := ReduceTokens(
+		`let x = "a b" + foo(1, 2)`,
+		func(program string) bool {
+			return strings.Contains(program, `"a b"`) &&
+				strings.Contains(program, "2")
+		},
+	)
+
+	assert.Equal(t, `"a b" 2`, reduced)
+}
+
+func TestReducePredicateCalls(t *testing.T) {
+
+	t.Parallel()
+
+	calls := map[string]int{}
+
+	_ = Reduce(
+		"a\nb\nc\nd",
+		func(program string) bool {
+			calls[program]++
+			return strings.Contains(program, "c")
+		},
+	)
+
+	for program, count := range calls {
+		assert.Equal(t, 1, count, program)
+	}
+}
+
+func TestReduceErrorContains(t *testing.T) {
+
+	t.Parallel()
+
+	const program = `
+      pub fun test(): Int {
+          let a = 1
+          let b = 2
+          let c: String = a + b
+          return a
+      }
+
+      pub fun other() {}
+    `
+
+	predicate := ErrorContains("mismatched types")
+	require.True(t, predicate(program))
+
+	reduced := Reduce(program, predicate)
+
+	assert.True(t, predicate(reduced))
+	assert.Less(t, len(reduced), len(program))
+	assert.NotContains(t, reduced, "other")
+}
+
+func TestPredicates(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid program", func(t *testing.T) {
+
+		t.Parallel()
+
+		const program = `pub fun test() {}`
+
+		assert.False(t, ParserCrashes(program))
+		assert.False(t, CheckerCrashes(program))
+		assert.False(t, ErrorContains("")(program))
+	})
+
+	t.Run("parser error", func(t *testing.T) {
+
+		t.Parallel()
+
+		const program = `pub fun test( {}`
+
+		assert.False(t, CheckerCrashes(program))
+		assert.True(t, ErrorContains("expected parameter")(program))
+		assert.False(t, ErrorContains("mismatched types")(program))
+	})
+}
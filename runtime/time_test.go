@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeTimeContract(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	executeScript := func(t *testing.T, code string) (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: &testRuntimeInterface{
+					storage: newTestLedger(nil, nil),
+				},
+				Location: utils.TestLocation,
+			},
+		)
+	}
+
+	t.Run("durations", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := executeScript(t, `
+          import Time
+
+          pub fun main(): [Int64] {
+              let hour = Time.hours(1)
+              let halfHour = hour.divide(2)
+              return [
+                  Time.nanoseconds(1).nanoseconds,
+                  Time.milliseconds(1).nanoseconds,
+                  Time.seconds(1).nanoseconds,
+                  Time.minutes(1).nanoseconds,
+                  Time.days(1).nanoseconds,
+                  hour.add(halfHour).nanoseconds,
+                  hour.subtract(halfHour.multiply(3)).nanoseconds,
+                  hour.negate().nanoseconds
+              ]
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt64(1),
+				cadence.NewInt64(1_000_000),
+				cadence.NewInt64(1_000_000_000),
+				cadence.NewInt64(60_000_000_000),
+				cadence.NewInt64(86_400_000_000_000),
+				cadence.NewInt64(5_400_000_000_000),
+				cadence.NewInt64(-1_800_000_000_000),
+				cadence.NewInt64(-3_600_000_000_000),
+			}),
+			result,
+		)
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := executeScript(t, `
+          import Time
+
+          pub fun main(): [AnyStruct] {
+              let start = Time.fromUnixSeconds(1_600_000_000.12345678)
+              let end = start.add(Time.minutes(5))
+              return [
+                  start.unixNanoseconds,
+                  end.since(start).nanoseconds,
+                  start.since(end).isNegative(),
+                  start.isBefore(end),
+                  start.isAfter(end),
+                  end.subtract(Time.minutes(5)).equals(start),
+                  start.toUnixSeconds(),
+                  Time.milliseconds(-1500).toSeconds()
+              ]
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt64(1_600_000_000_123_456_780),
+				cadence.NewInt64(300_000_000_000),
+				cadence.NewBool(true),
+				cadence.NewBool(true),
+				cadence.NewBool(false),
+				cadence.NewBool(true),
+				cadence.Fix64(1_600_000_000_12345678),
+				cadence.Fix64(-1_50000000),
+			}),
+			result,
+		)
+	})
+
+	t.Run("block timestamp", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := executeScript(t, `
+          import Time
+
+          pub fun main(): Int64 {
+              return Time.blockTimestamp(getCurrentBlock()).unixNanoseconds
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt64(1_000_000_000), result)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := executeScript(t, `
+          import Time
+
+          pub fun main() {
+              Time.days(1_000_000)
+          }
+        `)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+	})
+}
+
+func TestRuntimeTimeContractStorage(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	var loggedMessages []string
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{42}}, nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              import Time
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save(Time.fromUnixSeconds(1.5), to: /storage/timestamp)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              import Time
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      let timestamp = signer.load<Time.Timestamp>(from: /storage/timestamp)!
+                      log(timestamp.add(Time.seconds(1)).unixNanoseconds)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"2500000000"}, loggedMessages)
+}
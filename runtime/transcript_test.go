@@ -0,0 +1,145 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeTranscript(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xCA, 0xDE}
+
+	contract := []byte(`
+      pub contract Bank {
+
+          pub event Deposited(amount: Int)
+
+          pub resource Vault {
+              pub var balance: Int
+
+              init() {
+                  self.balance = 0
+              }
+
+              pub fun deposit(amount: Int) {
+                  self.balance = self.balance + amount
+                  emit Deposited(amount: amount)
+              }
+          }
+
+          pub fun createVault(): @Vault {
+              return <-create Vault()
+          }
+      }
+    `)
+
+	transaction := []byte(`
+      import Bank from 0xCADE
+
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(<-Bank.createVault(), to: /storage/vault)
+              let vault = signer.borrow<&Bank.Vault>(from: /storage/vault)!
+              vault.deposit(amount: 10)
+          }
+      }
+    `)
+
+	var accountCode []byte
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: utils.DeploymentTransaction("Bank", contract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	transcript := &interpreter.Transcript{}
+
+	location := nextTransactionLocation()
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: transaction,
+		},
+		Context{
+			Interface:  runtimeInterface,
+			Location:   location,
+			Transcript: transcript,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`call Bank.createVault (t.01:6)
+  call Vault (A.000000000000cade.Bank:20)
+call signer.save (t.01:6)
+  write 0xcade /storage/vault
+call signer.borrow (t.01:7)
+  read 0xcade /storage/vault
+read 0xcade /storage/vault
+read 0xcade /storage/vault
+call vault.deposit (t.01:8)
+  call Deposited (A.000000000000cade.Bank:15)
+  emit A.000000000000cade.Bank.Deposited(amount: 10)
+`,
+		transcript.String(),
+	)
+
+	eventEntry := transcript.Entries[len(transcript.Entries)-1]
+	assert.Equal(t, interpreter.TranscriptEntryKindEvent, eventEntry.Kind)
+	assert.Equal(t, common.TypeID("A.000000000000cade.Bank.Deposited"), eventEntry.EventType)
+}
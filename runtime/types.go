@@ -35,6 +35,14 @@ type Block struct {
 	Timestamp int64
 }
 
+// BlockHeader is the header of a block,
+// which in addition to the block also includes the hash of the parent block.
+//
+type BlockHeader struct {
+	Block
+	ParentHash BlockHash
+}
+
 type ResolvedLocation = sema.ResolvedLocation
 type Identifier = ast.Identifier
 type Location = common.Location
@@ -0,0 +1,219 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package analysis is a framework for static analyses of Cadence programs,
+// modeled on golang.org/x/tools/go/analysis.
+//
+// An Analyzer inspects a checked program through a Pass,
+// and reports problems as Diagnostics, optionally with SuggestedFixes,
+// which are edits of the program's code that resolve the problem.
+//
+// Analyzers are registered in a registry, which contains the built-in analyzers,
+// and can be extended with custom analyzers.
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Analyzer is a static analysis of a program.
+//
+// Name is a short, unique identifier of the analyzer, e.g. `unused-variable`,
+// which is used as the category of the reported diagnostics.
+//
+// Requires are the analyzers which must run before this analyzer.
+// Their results are available in the pass (see Pass.ResultOf).
+//
+// Run performs the analysis: it reports diagnostics through the pass,
+// and may return a result for the analyzers which require it.
+//
+type Analyzer struct {
+	Name        string
+	Description string
+	Requires    []*Analyzer
+	Run         func(*Pass) (interface{}, error)
+}
+
+func (a *Analyzer) String() string {
+	return a.Name
+}
+
+// Program is a parsed and checked program which can be analyzed.
+//
+// Code is the source code of the program. It is needed to suggest fixes,
+// and may be empty, in which case analyzers do not suggest fixes which depend on it.
+//
+// Occurrences are the occurrences of declarations in the program,
+// which are only recorded if the program was checked with position info enabled
+// (see sema.WithPositionInfoEnabled).
+//
+type Program struct {
+	Location    common.Location
+	Code        string
+	Program     *ast.Program
+	Elaboration *sema.Elaboration
+	Occurrences *sema.Occurrences
+}
+
+// ProgramFromChecker returns the program for the given checked checker and code
+//
+func ProgramFromChecker(checker *sema.Checker, code string) *Program {
+	return &Program{
+		Location:    checker.Location,
+		Code:        code,
+		Program:     checker.Program,
+		Elaboration: checker.Elaboration,
+		Occurrences: checker.Occurrences,
+	}
+}
+
+// ParseAndCheck parses and checks the given code, with position info enabled,
+// and returns the program which can be analyzed.
+//
+// The options are passed to the checker, e.g. to handle imports.
+//
+func ParseAndCheck(code string, location common.Location, options ...sema.Option) (*Program, error) {
+	program, err := parser2.ParseProgram(code)
+	if err != nil {
+		return nil, err
+	}
+
+	options = append(options, sema.WithPositionInfoEnabled(true))
+
+	checker, err := sema.NewChecker(program, location, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	return ProgramFromChecker(checker, code), nil
+}
+
+// Pass is the state of the run of one analyzer on one program
+//
+type Pass struct {
+	Analyzer *Analyzer
+	*Program
+	// ResultOf are the results of the required analyzers
+	ResultOf    map[*Analyzer]interface{}
+	diagnostics *[]Diagnostic
+}
+
+// Report reports the given diagnostic.
+//
+// The category of the diagnostic defaults to the name of the analyzer.
+//
+func (pass *Pass) Report(diagnostic Diagnostic) {
+	if diagnostic.Category == "" {
+		diagnostic.Category = pass.Analyzer.Name
+	}
+	if diagnostic.Location == nil {
+		diagnostic.Location = pass.Location
+	}
+	*pass.diagnostics = append(*pass.diagnostics, diagnostic)
+}
+
+// Reportf reports a diagnostic for the given range, with a formatted message
+//
+func (pass *Pass) Reportf(diagnosticRange ast.Range, format string, args ...interface{}) {
+	pass.Report(Diagnostic{
+		Range:   diagnosticRange,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Inspect calls the function for all elements of the program, in depth-first order,
+// like ast.Inspect
+//
+func (pass *Pass) Inspect(f func(ast.Element) bool) {
+	for _, declaration := range pass.Program.Program.Declarations() {
+		ast.Inspect(declaration, f)
+	}
+}
+
+// Analyze runs the given analyzers, and the analyzers they require, on the given program,
+// and returns the reported diagnostics, sorted by position.
+//
+// Each analyzer is run at most once, after the analyzers it requires.
+//
+func Analyze(program *Program, analyzers ...*Analyzer) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+
+	results := map[*Analyzer]interface{}{}
+	visiting := map[*Analyzer]bool{}
+
+	var run func(analyzer *Analyzer) error
+	run = func(analyzer *Analyzer) error {
+		if _, ok := results[analyzer]; ok {
+			return nil
+		}
+
+		if visiting[analyzer] {
+			return fmt.Errorf("cyclic requirement of analyzer %s", analyzer.Name)
+		}
+		visiting[analyzer] = true
+
+		resultOf := make(map[*Analyzer]interface{}, len(analyzer.Requires))
+		for _, required := range analyzer.Requires {
+			err := run(required)
+			if err != nil {
+				return err
+			}
+			resultOf[required] = results[required]
+		}
+
+		pass := &Pass{
+			Analyzer:    analyzer,
+			Program:     program,
+			ResultOf:    resultOf,
+			diagnostics: &diagnostics,
+		}
+
+		result, err := analyzer.Run(pass)
+		if err != nil {
+			return fmt.Errorf("analyzer %s failed: %w", analyzer.Name, err)
+		}
+
+		results[analyzer] = result
+
+		return nil
+	}
+
+	for _, analyzer := range analyzers {
+		err := run(analyzer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].StartPos.Compare(diagnostics[j].StartPos) < 0
+	})
+
+	return diagnostics, nil
+}
@@ -0,0 +1,221 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+var testLocation = common.StringLocation("test")
+
+func analyze(t *testing.T, code string, analyzers ...*Analyzer) []Diagnostic {
+	program, err := ParseAndCheck(code, testLocation)
+	require.NoError(t, err)
+
+	diagnostics, err := Analyze(program, analyzers...)
+	require.NoError(t, err)
+
+	return diagnostics
+}
+
+func diagnosticStrings(diagnostics []Diagnostic) []string {
+	result := make([]string, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		result = append(result, diagnostic.String())
+	}
+	return result
+}
+
+// applyFirstFixes applies the first suggested fix of each diagnostic to the code
+//
+func applyFirstFixes(t *testing.T, code string, diagnostics []Diagnostic) string {
+	var edits []TextEdit
+	for _, diagnostic := range diagnostics {
+		if len(diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+		edits = append(edits, diagnostic.SuggestedFixes[0].TextEdits...)
+	}
+
+	fixed, err := ApplyTextEdits(code, edits)
+	require.NoError(t, err)
+
+	return fixed
+}
+
+func TestAnalyze(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("requirements", func(t *testing.T) {
+
+		t.Parallel()
+
+		var runs []string
+
+		countFunctions := &Analyzer{
+			Name: "count-functions",
+			Run: func(pass *Pass) (interface{}, error) {
+				runs = append(runs, pass.Analyzer.Name)
+				return len(pass.Program.Program.FunctionDeclarations()), nil
+			},
+		}
+
+		tooManyFunctions := &Analyzer{
+			Name:     "too-many-functions",
+			Requires: []*Analyzer{countFunctions},
+			Run: func(pass *Pass) (interface{}, error) {
+				runs = append(runs, pass.Analyzer.Name)
+				count := pass.ResultOf[countFunctions].(int)
+				if count > 1 {
+					pass.Reportf(
+						ast.NewRangeFromPositioned(pass.Program.Program),
+						"%d functions",
+						count,
+					)
+				}
+				return nil, nil
+			},
+		}
+
+		diagnostics := analyze(t,
+			`
+              pub fun a() {}
+              pub fun b() {}
+            `,
+			tooManyFunctions,
+			countFunctions,
+		)
+
+		assert.Equal(t,
+			[]string{"count-functions", "too-many-functions"},
+			runs,
+		)
+
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "too-many-functions", diagnostics[0].Category)
+		assert.Equal(t, testLocation, diagnostics[0].Location)
+		assert.Equal(t, "2:14: too-many-functions: 2 functions", diagnostics[0].String())
+	})
+
+	t.Run("cyclic requirements", func(t *testing.T) {
+
+		t.Parallel()
+
+		a := &Analyzer{
+			Name: "a",
+			Run: func(_ *Pass) (interface{}, error) {
+				return nil, nil
+			},
+		}
+		b := &Analyzer{
+			Name:     "b",
+			Requires: []*Analyzer{a},
+			Run:      a.Run,
+		}
+		a.Requires = []*Analyzer{b}
+
+		program, err := ParseAndCheck("", testLocation)
+		require.NoError(t, err)
+
+		_, err = Analyze(program, a)
+		require.Error(t, err)
+	})
+}
+
+func TestRegistry(t *testing.T) {
+
+	t.Parallel()
+
+	names := make([]string, 0)
+	for _, analyzer := range Analyzers() {
+		names = append(names, analyzer.Name)
+	}
+
+	assert.Equal(t,
+		[]string{
+			"deprecated-api",
+			"force-cast",
+			"resource-loss",
+			"unused-variable",
+		},
+		names,
+	)
+
+	assert.Same(t, ForceCastAnalyzer, LookupAnalyzer("force-cast"))
+	assert.Nil(t, LookupAnalyzer("unknown"))
+
+	assert.Panics(t, func() {
+		Register(&Analyzer{Name: ForceCastAnalyzer.Name})
+	})
+}
+
+func TestApplyTextEdits(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "let x = 1"
+
+	fixed, err := ApplyTextEdits(
+		code,
+		[]TextEdit{
+			{
+				Replacement: "42",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 8, Line: 1, Column: 8},
+					EndPos:   ast.Position{Offset: 8, Line: 1, Column: 8},
+				},
+			},
+			{
+				Replacement: "var",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+					EndPos:   ast.Position{Offset: 2, Line: 1, Column: 2},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "var x = 42", fixed)
+
+	_, err = ApplyTextEdits(
+		code,
+		[]TextEdit{
+			{
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0},
+					EndPos:   ast.Position{Offset: 4},
+				},
+			},
+			{
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 2},
+					EndPos:   ast.Position{Offset: 6},
+				},
+			},
+		},
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,185 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnusedVariableAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      pub fun test(): Int {
+          let a = 1
+          let b = 2
+          var c = a
+          let d = a + b
+          return b
+      }
+    `
+
+	diagnostics := analyze(t, code, UnusedVariableAnalyzer)
+
+	assert.Equal(t,
+		[]string{
+			"5:14: unused-variable: `c` is declared but never used",
+			"6:14: unused-variable: `d` is declared but never used",
+		},
+		diagnosticStrings(diagnostics),
+	)
+
+	assert.Len(t, diagnostics[0].SuggestedFixes, 1)
+	assert.Empty(t, diagnostics[1].SuggestedFixes)
+
+	assert.Equal(t,
+		`
+      pub fun test(): Int {
+          let a = 1
+          let b = 2
+          
+          let d = a + b
+          return b
+      }
+    `,
+		applyFirstFixes(t, code, diagnostics),
+	)
+}
+
+func TestDeprecatedAPIAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      transaction(key: [UInt8]) {
+          prepare(signer: AuthAccount) {
+              signer.addPublicKey(key)
+              signer.removePublicKey(0)
+              signer.keys.revoke(keyIndex: 1)
+          }
+      }
+    `
+
+	diagnostics := analyze(t, code, DeprecatedAPIAnalyzer)
+
+	assert.Equal(t,
+		[]string{
+			"4:21: deprecated-api: `addPublicKey` is deprecated, use `keys.add` instead",
+			"5:21: deprecated-api: `removePublicKey` is deprecated, use `keys.revoke` instead",
+		},
+		diagnosticStrings(diagnostics),
+	)
+
+	assert.Equal(t,
+		`
+      transaction(key: [UInt8]) {
+          prepare(signer: AuthAccount) {
+              signer.addPublicKey(key)
+              signer.keys.revoke(0)
+              signer.keys.revoke(keyIndex: 1)
+          }
+      }
+    `,
+		applyFirstFixes(t, code, diagnostics),
+	)
+}
+
+func TestForceCastAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      pub fun test(x: Int, y: AnyStruct) {
+          let a = x as! Int
+          let b = y as! Int
+          let c = (y as? Int)!
+          let d = (x   as!   Integer)
+      }
+    `
+
+	diagnostics := analyze(t, code, ForceCastAnalyzer)
+
+	assert.Equal(t,
+		[]string{
+			"3:18: force-cast: force cast from `Int` to `Int` always succeeds, use a static cast (`as`)",
+			"5:19: force-cast: force-unwrapped failable cast, use a force cast (`as!`)",
+			"6:19: force-cast: force cast from `Int` to `Integer` always succeeds, use a static cast (`as`)",
+		},
+		diagnosticStrings(diagnostics),
+	)
+
+	assert.Equal(t,
+		`
+      pub fun test(x: Int, y: AnyStruct) {
+          let a = x as Int
+          let b = y as! Int
+          let c = (y as! Int)
+          let d = (x   as   Integer)
+      }
+    `,
+		applyFirstFixes(t, code, diagnostics),
+	)
+}
+
+func TestResourceLossAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := analyze(t,
+		`
+          pub resource R {
+              pub let id: UInt64
+
+              init(id: UInt64) {
+                  self.id = id
+              }
+          }
+
+          pub resource Collection {
+              pub var items: @{UInt64: R}
+
+              init() {
+                  self.items <- {}
+              }
+
+              pub fun deposit(item: @R) {
+                  self.items[item.id] <-! item
+              }
+
+              destroy() {
+                  destroy self.items
+              }
+          }
+        `,
+		ResourceLossAnalyzer,
+	)
+
+	assert.Equal(t,
+		[]string{
+			"18:18: resource-loss: forced move into a dictionary entry with a user-provided key " +
+				"aborts if the entry is occupied, so users may block the key for others in `Collection.deposit`",
+			"22:18: resource-loss: destroys a field into which anyone may deposit resources, " +
+				"so the resources of others are lost in `Collection.destroy`",
+		},
+		diagnosticStrings(diagnostics),
+	)
+}
@@ -0,0 +1,129 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// DeprecatedAPIAnalyzer reports uses of deprecated functions of the built-in types
+//
+var DeprecatedAPIAnalyzer = &Analyzer{
+	Name:        "deprecated-api",
+	Description: "reports uses of deprecated functions of the built-in types",
+	Run:         runDeprecatedAPIAnalyzer,
+}
+
+// deprecatedMember is a deprecated member of a built-in type.
+//
+// The replacement is the member expression which should be used instead.
+// It can be substituted automatically if the replacement has the same parameters.
+//
+type deprecatedMember struct {
+	replacement   string
+	substitutable bool
+}
+
+var deprecatedMembers = map[sema.Type]map[string]deprecatedMember{
+	sema.AuthAccountType: {
+		sema.AuthAccountAddPublicKeyField: {
+			replacement: "keys.add",
+		},
+		sema.AuthAccountRemovePublicKeyField: {
+			replacement:   "keys.revoke",
+			substitutable: true,
+		},
+	},
+}
+
+func runDeprecatedAPIAnalyzer(pass *Pass) (interface{}, error) {
+	memberInfos := pass.Elaboration.MemberExpressionMemberInfos
+
+	pass.Inspect(func(element ast.Element) bool {
+		memberExpression, ok := element.(*ast.MemberExpression)
+		if !ok {
+			return true
+		}
+
+		memberInfo, ok := memberInfos[memberExpression]
+		if !ok {
+			return true
+		}
+
+		members, ok := deprecatedMembers[unwrapAccessedType(memberInfo.AccessedType)]
+		if !ok {
+			return true
+		}
+
+		identifier := memberExpression.Identifier
+
+		member, ok := members[identifier.Identifier]
+		if !ok {
+			return true
+		}
+
+		diagnostic := Diagnostic{
+			Range: ast.NewRangeFromPositioned(identifier),
+			Message: fmt.Sprintf(
+				"`%s` is deprecated, use `%s` instead",
+				identifier.Identifier,
+				member.replacement,
+			),
+		}
+
+		if member.substitutable {
+			diagnostic.SuggestedFixes = []SuggestedFix{
+				{
+					Message: fmt.Sprintf("replace with `%s`", member.replacement),
+					TextEdits: []TextEdit{
+						{
+							Replacement: member.replacement,
+							Range:       ast.NewRangeFromPositioned(identifier),
+						},
+					},
+				},
+			}
+		}
+
+		pass.Report(diagnostic)
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// unwrapAccessedType returns the type which declares the accessed member,
+// i.e. the given type without optionals and references
+//
+func unwrapAccessedType(ty sema.Type) sema.Type {
+	for {
+		switch unwrapped := ty.(type) {
+		case *sema.OptionalType:
+			ty = unwrapped.Type
+		case *sema.ReferenceType:
+			ty = unwrapped.Type
+		default:
+			return ty
+		}
+	}
+}
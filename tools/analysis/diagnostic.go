@@ -0,0 +1,112 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Diagnostic is a problem reported by an analyzer.
+//
+// The category is the name of the analyzer which reported the diagnostic.
+//
+type Diagnostic struct {
+	Location       common.Location
+	Category       string
+	Message        string
+	SuggestedFixes []SuggestedFix
+	ast.Range
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf(
+		"%d:%d: %s: %s",
+		d.StartPos.Line,
+		d.StartPos.Column,
+		d.Category,
+		d.Message,
+	)
+}
+
+// SuggestedFix is a change of the code which resolves the problem of a diagnostic
+//
+type SuggestedFix struct {
+	Message   string
+	TextEdits []TextEdit
+}
+
+// TextEdit is the replacement of a range of code.
+//
+// Like all ranges of the AST, the end position is inclusive.
+// An insertion is an edit with an end position before the start position,
+// i.e. with an end offset one less than the start offset.
+//
+type TextEdit struct {
+	Replacement string
+	ast.Range
+}
+
+// ApplyTextEdits returns the given code with the given edits applied.
+//
+// The ranges of the edits must not overlap.
+//
+func ApplyTextEdits(code string, edits []TextEdit) (string, error) {
+	sortedEdits := make([]TextEdit, len(edits))
+	copy(sortedEdits, edits)
+
+	sort.SliceStable(sortedEdits, func(i, j int) bool {
+		return sortedEdits[i].StartPos.Offset < sortedEdits[j].StartPos.Offset
+	})
+
+	var builder strings.Builder
+
+	offset := 0
+
+	for _, edit := range sortedEdits {
+		startOffset := edit.StartPos.Offset
+		endOffset := edit.EndPos.Offset + 1
+
+		if startOffset < offset ||
+			endOffset < startOffset ||
+			endOffset > len(code) {
+
+			return "", fmt.Errorf(
+				"invalid text edit: %d:%d-%d:%d",
+				edit.StartPos.Line,
+				edit.StartPos.Column,
+				edit.EndPos.Line,
+				edit.EndPos.Column,
+			)
+		}
+
+		builder.WriteString(code[offset:startOffset])
+		builder.WriteString(edit.Replacement)
+
+		offset = endOffset
+	}
+
+	builder.WriteString(code[offset:])
+
+	return builder.String(), nil
+}
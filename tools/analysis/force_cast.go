@@ -0,0 +1,175 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ForceCastAnalyzer reports misuses of force casts (`as!`):
+// force casts which always succeed, and which should be static casts (`as`),
+// and failable casts (`as?`) whose result is force-unwrapped,
+// which should be force casts, as they abort with a more helpful message
+//
+var ForceCastAnalyzer = &Analyzer{
+	Name:        "force-cast",
+	Description: "reports force casts which always succeed, and force-unwrapped failable casts",
+	Run:         runForceCastAnalyzer,
+}
+
+func runForceCastAnalyzer(pass *Pass) (interface{}, error) {
+	elaboration := pass.Elaboration
+
+	pass.Inspect(func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.CastingExpression:
+			if element.Operation != ast.OperationForceCast {
+				break
+			}
+
+			valueType := elaboration.CastingStaticValueTypes[element]
+			targetType := elaboration.CastingTargetTypes[element]
+			if valueType == nil ||
+				targetType == nil ||
+				valueType.IsInvalidType() ||
+				!sema.IsSubType(valueType, targetType) {
+
+				break
+			}
+
+			diagnostic := Diagnostic{
+				Range: ast.NewRangeFromPositioned(element),
+				Message: fmt.Sprintf(
+					"force cast from `%s` to `%s` always succeeds, use a static cast (`%s`)",
+					valueType.QualifiedString(),
+					targetType.QualifiedString(),
+					ast.OperationCast.Symbol(),
+				),
+			}
+
+			operatorRange, ok := castingOperatorRange(pass.Code, element)
+			if ok {
+				diagnostic.SuggestedFixes = []SuggestedFix{
+					{
+						Message: "replace with a static cast",
+						TextEdits: []TextEdit{
+							{
+								Replacement: ast.OperationCast.Symbol(),
+								Range:       operatorRange,
+							},
+						},
+					},
+				}
+			}
+
+			pass.Report(diagnostic)
+
+		case *ast.ForceExpression:
+			castingExpression, ok := element.Expression.(*ast.CastingExpression)
+			if !ok || castingExpression.Operation != ast.OperationFailableCast {
+				break
+			}
+
+			diagnostic := Diagnostic{
+				Range: ast.NewRangeFromPositioned(element),
+				Message: fmt.Sprintf(
+					"force-unwrapped failable cast, use a force cast (`%s`)",
+					ast.OperationForceCast.Symbol(),
+				),
+			}
+
+			operatorRange, ok := castingOperatorRange(pass.Code, castingExpression)
+			if ok {
+				diagnostic.SuggestedFixes = []SuggestedFix{
+					{
+						Message: "replace with a force cast",
+						TextEdits: []TextEdit{
+							{
+								Replacement: ast.OperationForceCast.Symbol(),
+								Range:       operatorRange,
+							},
+							{
+								Range: ast.Range{
+									StartPos: element.EndPos,
+									EndPos:   element.EndPos,
+								},
+							},
+						},
+					},
+				}
+			}
+
+			pass.Report(diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// castingOperatorRange returns the range of the operator of the given casting expression
+// in the given code, if it can be determined.
+//
+// The operator is the first occurrence of its symbol
+// between the end of the casted expression and the start of the type annotation
+//
+func castingOperatorRange(code string, expression *ast.CastingExpression) (ast.Range, bool) {
+	startOffset := expression.Expression.EndPosition().Offset + 1
+	endOffset := expression.TypeAnnotation.StartPos.Offset
+
+	if code == "" ||
+		startOffset < 0 ||
+		endOffset > len(code) ||
+		startOffset > endOffset {
+
+		return ast.Range{}, false
+	}
+
+	symbol := expression.Operation.Symbol()
+
+	index := strings.Index(code[startOffset:endOffset], symbol)
+	if index < 0 {
+		return ast.Range{}, false
+	}
+
+	startPos := positionAtOffset(code, startOffset+index)
+	endPos := positionAtOffset(code, startOffset+index+len(symbol)-1)
+
+	return ast.Range{
+		StartPos: startPos,
+		EndPos:   endPos,
+	}, true
+}
+
+// positionAtOffset returns the position of the given offset in the given code
+//
+func positionAtOffset(code string, offset int) ast.Position {
+	line := 1 + strings.Count(code[:offset], "\n")
+	lineStart := strings.LastIndex(code[:offset], "\n") + 1
+	return ast.Position{
+		Offset: offset,
+		Line:   line,
+		Column: offset - lineStart,
+	}
+}
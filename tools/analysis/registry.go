@@ -0,0 +1,81 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+	analyzers map[string]*Analyzer
+}{
+	analyzers: map[string]*Analyzer{},
+}
+
+// Register adds the given analyzer to the registry.
+//
+// It panics if an analyzer with the same name is already registered.
+//
+func Register(analyzer *Analyzer) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.analyzers[analyzer.Name]; ok {
+		panic(fmt.Errorf("analyzer %s is already registered", analyzer.Name))
+	}
+
+	registry.analyzers[analyzer.Name] = analyzer
+}
+
+// LookupAnalyzer returns the registered analyzer with the given name, if any
+//
+func LookupAnalyzer(name string) *Analyzer {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return registry.analyzers[name]
+}
+
+// Analyzers returns all registered analyzers, sorted by name
+//
+func Analyzers() []*Analyzer {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	analyzers := make([]*Analyzer, 0, len(registry.analyzers))
+	for _, analyzer := range registry.analyzers { //nolint:maprangecheck
+		analyzers = append(analyzers, analyzer)
+	}
+
+	sort.Slice(analyzers, func(i, j int) bool {
+		return analyzers[i].Name < analyzers[j].Name
+	})
+
+	return analyzers
+}
+
+func init() {
+	Register(UnusedVariableAnalyzer)
+	Register(DeprecatedAPIAnalyzer)
+	Register(ForceCastAnalyzer)
+	Register(ResourceLossAnalyzer)
+}
@@ -0,0 +1,70 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// ResourceLossAnalyzer reports patterns of resource handling
+// which are known to cause loss of resources, or which allow users to grief others
+// (see runtime.AnalyzeResourceHazards)
+//
+var ResourceLossAnalyzer = &Analyzer{
+	Name:        "resource-loss",
+	Description: "reports patterns of resource handling which cause loss of resources or allow griefing",
+	Run:         runResourceLossAnalyzer,
+}
+
+var resourceHazardMessages = map[runtime.ResourceHazardKind]string{
+	runtime.ResourceHazardForcedMove: "forced move into a dictionary entry with a user-provided key " +
+		"aborts if the entry is occupied, so users may block the key for others",
+	runtime.ResourceHazardUserProvidedStoragePath: "save to a user-provided storage path " +
+		"aborts if the path is occupied",
+	runtime.ResourceHazardDestroyDepositedResources: "destroys a field into which anyone may deposit resources, " +
+		"so the resources of others are lost",
+	runtime.ResourceHazardUnrestrictedPublicLink: "public link to a resource reference which is authorized " +
+		"or not restricted to interfaces, so anyone may call all functions of the resource",
+}
+
+func runResourceLossAnalyzer(pass *Pass) (interface{}, error) {
+	hazards := runtime.AnalyzeResourceHazards(
+		&interpreter.Program{
+			Program:     pass.Program.Program,
+			Elaboration: pass.Elaboration,
+		},
+	)
+
+	for _, hazard := range hazards {
+		message, ok := resourceHazardMessages[hazard.Kind]
+		if !ok {
+			message = hazard.Kind.String()
+		}
+
+		pass.Reportf(
+			hazard.Range,
+			"%s in `%s`",
+			message,
+			hazard.Function,
+		)
+	}
+
+	return hazards, nil
+}
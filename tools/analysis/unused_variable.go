@@ -0,0 +1,120 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// UnusedVariableAnalyzer reports variables and constants which are declared, but never used.
+//
+// The analysis needs the occurrences of the program's declarations,
+// so it does not report anything for programs checked without position info.
+//
+// Unused resources are already rejected by the checker, so they are not reported.
+//
+var UnusedVariableAnalyzer = &Analyzer{
+	Name:        "unused-variable",
+	Description: "reports variables and constants which are declared, but never used",
+	Run:         runUnusedVariableAnalyzer,
+}
+
+func runUnusedVariableAnalyzer(pass *Pass) (interface{}, error) {
+	occurrences := pass.Occurrences
+	if occurrences == nil {
+		return nil, nil
+	}
+
+	pass.Inspect(func(element ast.Element) bool {
+		declaration, ok := element.(*ast.VariableDeclaration)
+		if !ok {
+			return true
+		}
+
+		identifier := declaration.Identifier
+
+		occurrence := occurrences.Find(sema.ASTToSemaPosition(identifier.Pos))
+		if occurrence == nil || occurrence.Origin == nil {
+			return true
+		}
+
+		origin := occurrence.Origin
+
+		// The declaration itself is the first occurrence
+
+		if len(origin.Occurrences) > 1 ||
+			origin.Type == nil ||
+			origin.Type.IsResourceType() {
+
+			return true
+		}
+
+		diagnostic := Diagnostic{
+			Range:   ast.NewRangeFromPositioned(identifier),
+			Message: "`" + identifier.Identifier + "` is declared but never used",
+		}
+
+		if isRemovableVariableDeclaration(declaration) {
+			diagnostic.SuggestedFixes = []SuggestedFix{
+				{
+					Message: "remove the declaration",
+					TextEdits: []TextEdit{
+						{
+							Range: ast.NewRangeFromPositioned(declaration),
+						},
+					},
+				},
+			}
+		}
+
+		pass.Report(diagnostic)
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// isRemovableVariableDeclaration returns true if the given declaration
+// can be removed without changing the behaviour of the program,
+// i.e. if it is not the test of an if-statement,
+// and the evaluation of its value has no side effects
+//
+func isRemovableVariableDeclaration(declaration *ast.VariableDeclaration) bool {
+	if declaration.ParentIfStatement != nil ||
+		declaration.SecondValue != nil {
+
+		return false
+	}
+
+	switch declaration.Value.(type) {
+	case *ast.BoolExpression,
+		*ast.NilExpression,
+		*ast.StringExpression,
+		*ast.IntegerExpression,
+		*ast.FixedPointExpression,
+		*ast.PathExpression,
+		*ast.IdentifierExpression:
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command contract-update-validator checks if a deployed contract can be updated to new code,
+// using the same validation as the update of a contract in the runtime.
+//
+// Usage: contract-update-validator [-name contract] old.cdc new.cdc
+//
+// Invalid updates are reported as errors, and the command exits with a non-zero status.
+// Changes which are allowed, but may be unintended, like removed fields, are reported as warnings.
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+var nameFlag = flag.String("name", "", "the name of the contract. if not provided, the name of the declared contract is used")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 2 {
+		cmd.ExitWithError("expected the paths of the old and the new program")
+	}
+
+	oldLocation := common.StringLocation(args[0])
+	newLocation := common.StringLocation(args[1])
+
+	codes := map[common.LocationID]string{}
+
+	oldProgram, _ := cmd.PrepareProgramFromFile(oldLocation, codes)
+	_, must := cmd.PrepareProgramFromFile(newLocation, codes)
+
+	name := *nameFlag
+	if name == "" {
+		name = contractName(oldProgram)
+	}
+
+	warnings, err := runtime.ValidateContractUpdate(
+		newLocation,
+		name,
+		[]byte(codes[oldLocation.ID()]),
+		[]byte(codes[newLocation.ID()]),
+	)
+
+	for _, warning := range warnings {
+		printWarning(newLocation, warning)
+	}
+
+	must(err)
+}
+
+// contractName returns the name of the sole contract or contract interface declared in the program
+//
+func contractName(program *ast.Program) string {
+	if declaration := program.SoleContractDeclaration(); declaration != nil {
+		return declaration.Identifier.Identifier
+	}
+
+	if declaration := program.SoleContractInterfaceDeclaration(); declaration != nil {
+		return declaration.Identifier.Identifier
+	}
+
+	cmd.ExitWithError("the old program must declare exactly one contract or contract interface")
+	return ""
+}
+
+func printWarning(location common.Location, warning error) {
+	message := warning.Error()
+
+	if positioned, ok := warning.(ast.HasPosition); ok {
+		startPos := positioned.StartPosition()
+		message = fmt.Sprintf("%s:%d:%d: warning: %s", location, startPos.Line, startPos.Column, message)
+	} else {
+		message = fmt.Sprintf("%s: warning: %s", location, message)
+	}
+
+	if secondaryError, ok := warning.(errors.SecondaryError); ok {
+		message = fmt.Sprintf("%s: %s", message, secondaryError.SecondaryError())
+	}
+
+	fmt.Println(message)
+}
@@ -0,0 +1,48 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+//export CadenceParse
+func CadenceParse(request *C.char) *C.char {
+	return C.CString(handle(C.GoString(request), parse))
+}
+
+//export CadenceCheck
+func CadenceCheck(request *C.char) *C.char {
+	return C.CString(handle(C.GoString(request), check))
+}
+
+//export CadenceFormat
+func CadenceFormat(request *C.char) *C.char {
+	return C.CString(handle(C.GoString(request), formatCode))
+}
+
+// The responses are allocated in C memory, so they are not garbage collected
+//
+//export CadenceFree
+func CadenceFree(response *C.char) {
+	C.free(unsafe.Pointer(response))
+}
@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/cadence/tools/format"
+)
+
+type request struct {
+	Code         string `json:"code"`
+	Location     string `json:"location,omitempty"`
+	MaxLineWidth int    `json:"maxLineWidth,omitempty"`
+}
+
+type diagnostic struct {
+	Message          string        `json:"message"`
+	SecondaryMessage string        `json:"secondaryMessage,omitempty"`
+	StartPos         *ast.Position `json:"startPos,omitempty"`
+	EndPos           *ast.Position `json:"endPos,omitempty"`
+}
+
+type parseResponse struct {
+	Program *ast.Program `json:"program,omitempty"`
+	Errors  []diagnostic `json:"errors,omitempty"`
+}
+
+type checkResponse struct {
+	Valid  bool         `json:"valid"`
+	Errors []diagnostic `json:"errors,omitempty"`
+}
+
+type formatResponse struct {
+	Code   string       `json:"code,omitempty"`
+	Errors []diagnostic `json:"errors,omitempty"`
+}
+
+var valueDeclarations = append(
+	stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
+	stdlib.BuiltinFunctions...,
+).ToSemaValueDeclarations()
+
+var typeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+// handle decodes the given JSON request, passes it to the given function,
+// and returns the JSON encoding of the response.
+//
+// Invalid requests and panics are reported as an error response,
+// so that callers on the other side of the FFI boundary always get a JSON object
+//
+func handle(input string, f func(request) interface{}) (output string) {
+	var response interface{}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				response = errorResponse(fmt.Errorf("internal error: %v\n%s", r, debug.Stack()))
+			}
+		}()
+
+		var req request
+		err := json.Unmarshal([]byte(input), &req)
+		if err != nil {
+			response = errorResponse(fmt.Errorf("invalid request: %w", err))
+			return
+		}
+
+		response = f(req)
+	}()
+
+	serialized, err := json.Marshal(response)
+	if err != nil {
+		serialized, _ = json.Marshal(errorResponse(err))
+	}
+
+	return string(serialized)
+}
+
+func errorResponse(err error) interface{} {
+	return struct {
+		Errors []diagnostic `json:"errors"`
+	}{
+		Errors: diagnostics(err),
+	}
+}
+
+func parse(req request) interface{} {
+	program, err := parser2.ParseProgram(req.Code)
+	if err != nil {
+		return parseResponse{
+			Errors: diagnostics(err),
+		}
+	}
+
+	return parseResponse{
+		Program: program,
+	}
+}
+
+func check(req request) interface{} {
+	program, err := parser2.ParseProgram(req.Code)
+	if err != nil {
+		return checkResponse{
+			Errors: diagnostics(err),
+		}
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		common.StringLocation(req.Location),
+		sema.WithPredeclaredValues(valueDeclarations),
+		sema.WithPredeclaredTypes(typeDeclarations),
+		sema.WithImportHandler(
+			func(_ *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				return nil, fmt.Errorf("cannot import `%s`: imports are not supported", importedLocation)
+			},
+		),
+	)
+	if err != nil {
+		return checkResponse{
+			Errors: diagnostics(err),
+		}
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return checkResponse{
+			Errors: diagnostics(err),
+		}
+	}
+
+	return checkResponse{
+		Valid: true,
+	}
+}
+
+func formatCode(req request) interface{} {
+	maxLineWidth := req.MaxLineWidth
+	if maxLineWidth <= 0 {
+		maxLineWidth = format.DefaultMaxLineWidth
+	}
+
+	formatted, err := format.Format(req.Code, maxLineWidth)
+	if err != nil {
+		return formatResponse{
+			Errors: diagnostics(err),
+		}
+	}
+
+	return formatResponse{
+		Code: formatted,
+	}
+}
+
+// diagnostics flattens the given error into a list of diagnostics,
+// one for each child error of parent errors, like parser and checker errors
+//
+func diagnostics(err error) []diagnostic {
+	var result []diagnostic
+
+	var walk func(err error)
+	walk = func(err error) {
+		if parentError, ok := err.(errors.ParentError); ok {
+			for _, childError := range parentError.ChildErrors() {
+				walk(childError)
+			}
+			return
+		}
+
+		result = append(result, newDiagnostic(err))
+	}
+
+	walk(err)
+
+	return result
+}
+
+func newDiagnostic(err error) diagnostic {
+	result := diagnostic{
+		Message: err.Error(),
+	}
+
+	if secondaryError, ok := err.(errors.SecondaryError); ok {
+		result.SecondaryMessage = secondaryError.SecondaryError()
+	}
+
+	if positioned, ok := err.(ast.HasPosition); ok {
+		startPos := positioned.StartPosition()
+		endPos := positioned.EndPosition()
+		result.StartPos = &startPos
+		result.EndPos = &endPos
+	}
+
+	return result
+}
@@ -0,0 +1,148 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+func TestParse(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		output := handle(`{"code": "let x = 1"}`, parse)
+
+		var response struct {
+			Program struct {
+				Declarations []struct {
+					Type string
+				}
+			} `json:"program"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &response))
+
+		require.Len(t, response.Program.Declarations, 1)
+		assert.Equal(t, "VariableDeclaration", response.Program.Declarations[0].Type)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.JSONEq(t,
+			`{
+              "errors": [
+                {
+                  "message": "expected expression",
+                  "startPos": {"Offset": 8, "Line": 1, "Column": 8},
+                  "endPos": {"Offset": 8, "Line": 1, "Column": 8}
+                }
+              ]
+            }`,
+			handle(`{"code": "let x = "}`, parse),
+		)
+	})
+}
+
+func TestCheck(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.JSONEq(t,
+			`{"valid": true}`,
+			handle(`{"code": "pub fun test() { log(1) }"}`, check),
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		output := handle(`{"code": "pub fun test() { let x: Int = true }"}`, check)
+
+		var response checkResponse
+		require.NoError(t, json.Unmarshal([]byte(output), &response))
+
+		assert.Equal(t,
+			checkResponse{
+				Errors: []diagnostic{
+					{
+						Message:          "mismatched types",
+						SecondaryMessage: "expected `Int`, got `Bool`",
+						StartPos:         &ast.Position{Offset: 30, Line: 1, Column: 30},
+						EndPos:           &ast.Position{Offset: 33, Line: 1, Column: 33},
+					},
+				},
+			},
+			response,
+		)
+	})
+
+	t.Run("import", func(t *testing.T) {
+
+		t.Parallel()
+
+		output := handle(`{"code": "import 0x1"}`, check)
+
+		var response checkResponse
+		require.NoError(t, json.Unmarshal([]byte(output), &response))
+
+		assert.False(t, response.Valid)
+		require.Len(t, response.Errors, 1)
+	})
+}
+
+func TestFormat(t *testing.T) {
+
+	t.Parallel()
+
+	assert.JSONEq(t,
+		`{"code": "pub fun test(a: Int, b: Int) {\n    return\n}\n"}`,
+		handle(`{"code": "pub  fun test(a:Int,b:Int){return}"}`, formatCode),
+	)
+
+	assert.JSONEq(t,
+		`{"code": "pub fun test(\n    a: Int,\n    b: Int\n) {\n    return\n}\n"}`,
+		handle(`{"code": "pub  fun test(a:Int,b:Int){return}", "maxLineWidth": 20}`, formatCode),
+	)
+}
+
+func TestInvalidRequest(t *testing.T) {
+
+	t.Parallel()
+
+	assert.JSONEq(t,
+		`{"errors": [{"message": "invalid request: unexpected end of JSON input"}]}`,
+		handle(`{`, parse),
+	)
+}
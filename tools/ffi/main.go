@@ -0,0 +1,30 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command ffi is a C-compatible wrapper around the parser, the checker, and the formatter,
+// so tools written in other languages can use them without running a separate process.
+//
+// Build: go build -buildmode=c-shared -o libcadence.so ./tools/ffi
+//
+// The exported functions CadenceParse, CadenceCheck, and CadenceFormat take a JSON request
+// and return a JSON response, which must be released using CadenceFree.
+package main
+
+func main() {
+	// required by the c-shared build mode, never called
+}
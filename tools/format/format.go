@@ -0,0 +1,249 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package format formats Cadence source code.
+//
+// The formatting is deterministic: it only depends on the program and the maximum line width,
+// not on the layout of the input. Indentation, spacing, and line breaks are normalized,
+// redundant parentheses are removed, and necessary ones are inserted.
+//
+// The formatter does not yet preserve comments which are not doc strings of declarations.
+// Instead of silently dropping them, formatting code which contains such comments fails
+// with an UnsupportedCommentsError.
+package format
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+// DefaultMaxLineWidth is the maximum line width used when no width is given
+//
+const DefaultMaxLineWidth = 80
+
+// Indent is the string used for one level of indentation
+//
+const Indent = "    "
+
+// UnsupportedCommentsError is returned when formatting code which contains comments
+// that would not be part of the formatted code, e.g. comments inside function bodies
+//
+type UnsupportedCommentsError struct{}
+
+func (UnsupportedCommentsError) Error() string {
+	return "code contains comments which cannot be preserved by the formatter"
+}
+
+// NonEquivalentProgramError is returned when the formatted code
+// does not parse to the same program as the original code.
+//
+// It indicates a bug in the formatter and is returned instead of the
+// formatted code to prevent changing the meaning of a program.
+//
+type NonEquivalentProgramError struct {
+	Err error
+}
+
+func (e NonEquivalentProgramError) Error() string {
+	message := "formatted code is not equivalent to original code"
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e NonEquivalentProgramError) Unwrap() error {
+	return e.Err
+}
+
+// Format parses and formats the given code,
+// so that lines are at most the given width, if possible.
+//
+// If the maximum line width is not positive, DefaultMaxLineWidth is used.
+//
+// Parsing errors are returned as is.
+//
+func Format(code string, maxLineWidth int) (string, error) {
+	program, err := parser2.ParseProgram(code)
+	if err != nil {
+		return "", err
+	}
+
+	formatted := PrettyPrint(program, maxLineWidth)
+
+	if !equalWords(commentWords(code), commentWords(formatted)) {
+		return "", UnsupportedCommentsError{}
+	}
+
+	formattedProgram, err := parser2.ParseProgram(formatted)
+	if err != nil {
+		return "", NonEquivalentProgramError{Err: err}
+	}
+
+	equivalent, err := equivalentPrograms(program, formattedProgram)
+	if err != nil {
+		return "", NonEquivalentProgramError{Err: err}
+	}
+	if !equivalent {
+		return "", NonEquivalentProgramError{}
+	}
+
+	return formatted, nil
+}
+
+// PrettyPrint returns the formatted code for the given program,
+// so that lines are at most the given width, if possible.
+//
+// If the maximum line width is not positive, DefaultMaxLineWidth is used.
+//
+// Unlike Format, PrettyPrint does not check that the result is equivalent to the program.
+//
+func PrettyPrint(program *ast.Program, maxLineWidth int) string {
+	if maxLineWidth <= 0 {
+		maxLineWidth = DefaultMaxLineWidth
+	}
+
+	var builder strings.Builder
+	prettier.Prettier(&builder, program.Doc(), maxLineWidth, Indent)
+
+	// Empty lines are indented by the pretty printer, remove the indentation.
+	// Other trailing whitespace is kept, as it might be part of a doc string
+
+	lines := strings.Split(builder.String(), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		}
+	}
+
+	result := strings.Join(lines, "\n")
+	if result == "" {
+		return result
+	}
+	return result + "\n"
+}
+
+// commentWords returns the words of all comments in the given code, in order.
+//
+// The comment delimiters are removed, so that doc strings written as block comments
+// and doc strings written as line comments have the same words
+//
+func commentWords(code string) []string {
+	tokens := lexer.Lex(code)
+	defer tokens.Reclaim()
+
+	var words []string
+
+	for {
+		token := tokens.Next()
+
+		switch token.Type {
+		case lexer.TokenEOF:
+			return words
+
+		case lexer.TokenLineComment, lexer.TokenBlockCommentContent:
+			comment, ok := token.Value.(string)
+			if !ok {
+				continue
+			}
+			comment = strings.Map(
+				func(r rune) rune {
+					if r == '/' || r == '*' {
+						return -1
+					}
+					return r
+				},
+				comment,
+			)
+			words = append(words, strings.Fields(comment)...)
+		}
+	}
+}
+
+func equalWords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, word := range a {
+		if b[i] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// equivalentPrograms returns true if the given programs are equal,
+// ignoring the positions of their elements
+//
+func equivalentPrograms(a, b *ast.Program) (bool, error) {
+	aValue, err := positionlessJSONValue(a)
+	if err != nil {
+		return false, err
+	}
+
+	bValue, err := positionlessJSONValue(b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(aValue, bValue), nil
+}
+
+func positionlessJSONValue(program *ast.Program) (interface{}, error) {
+	data, err := json.Marshal(program)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	removePositions(value)
+
+	return value, nil
+}
+
+// removePositions removes all positions from the given JSON value
+//
+func removePositions(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value { //nolint:maprangecheck
+			if strings.HasSuffix(key, "Pos") {
+				delete(value, key)
+				continue
+			}
+			removePositions(child)
+		}
+
+	case []interface{}:
+		for _, child := range value {
+			removePositions(child)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFormat(t *testing.T, code string, maxLineWidth int, expected string) {
+	formatted, err := Format(code, maxLineWidth)
+	require.NoError(t, err)
+	assert.Equal(t, expected, formatted)
+
+	// Formatting is idempotent
+
+	reformatted, err := Format(formatted, maxLineWidth)
+	require.NoError(t, err)
+	assert.Equal(t, formatted, reformatted)
+}
+
+func TestFormatDeclarations(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("imports and pragmas", func(t *testing.T) {
+
+		t.Parallel()
+
+		testFormat(t,
+			`
+              import   Foo , Bar   from 0x01
+              import "baz"
+              #foo
+            `,
+			DefaultMaxLineWidth,
+			`import Foo, Bar from 0x1
+import "baz"
+
+#foo
+`,
+		)
+	})
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		testFormat(t,
+			`
+              /// The test contract
+              pub contract  Test :Foo,Bar{
+                pub var total :UFix64
+                access(contract) let names: {String:[Int]}
+                pub event Deposited ( amount:UFix64 , to :Address? )
+
+                pub enum Color:UInt8 { pub case red
+                  pub case green }
+
+                /// A vault
+                pub resource Vault {
+                  pub var balance: UFix64
+                  init(balance: UFix64) { self.balance = balance }
+                  destroy() {}
+                }
+
+                pub resource interface Provider {
+                  pub fun withdraw(amount: UFix64): @Vault { pre { amount > 0.0: "must be positive" } }
+                }
+
+                init() { self.total = 0.0 ; self.names = {} }
+              }
+            `,
+			DefaultMaxLineWidth,
+			`/// The test contract
+pub contract Test: Foo, Bar {
+    pub var total: UFix64
+    access(contract) let names: {String: [Int]}
+
+    pub event Deposited(amount: UFix64, to: Address?)
+
+    pub enum Color: UInt8 {
+        pub case red
+        pub case green
+    }
+
+    /// A vault
+    pub resource Vault {
+        pub var balance: UFix64
+
+        init(balance: UFix64) {
+            self.balance = balance
+        }
+
+        destroy() {}
+    }
+
+    pub resource interface Provider {
+        pub fun withdraw(amount: UFix64): @Vault {
+            pre {
+                amount > 0.0: "must be positive"
+            }
+        }
+    }
+
+    init() {
+        self.total = 0.0
+        self.names = {}
+    }
+}
+`,
+		)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		testFormat(t,
+			`
+              /**
+               * Transfers tokens
+               */
+              transaction(amount: UFix64) {
+                let vault: @Vault
+                prepare(signer: AuthAccount) { self.vault <- signer.load<@Vault>(from: /storage/vault)! }
+                pre { amount > 0.0 }
+                execute { destroy self.vault }
+                post { true: "ok" }
+              }
+            `,
+			DefaultMaxLineWidth,
+			`/**
+               * Transfers tokens
+               */
+transaction(amount: UFix64) {
+    let vault: @Vault
+
+    prepare(signer: AuthAccount) {
+        self.vault <- signer.load<@Vault>(from: /storage/vault)!
+    }
+
+    pre {
+        amount > 0.0
+    }
+
+    execute {
+        destroy self.vault
+    }
+
+    post {
+        true: "ok"
+    }
+}
+`,
+		)
+	})
+
+	t.Run("empty transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		testFormat(t,
+			`transaction { }`,
+			DefaultMaxLineWidth,
+			"transaction {}\n",
+		)
+	})
+}
+
+func TestFormatStatements(t *testing.T) {
+
+	t.Parallel()
+
+	testFormat(t,
+		`
+          fun test() {
+            var i = 0
+            while i < 10 { i = i + 1 ; if i == 5 { break } else { continue } }
+            for index, element in [1,2] { log(element) }
+            if let x = y { log(x) } else if true { log(1) } else { log(2) }
+            switch i { case 1: log(1) default: log(2) }
+            let a <- b <- c
+            a <-> b
+            emit Foo(x: 1)
+            return
+          }
+        `,
+		DefaultMaxLineWidth,
+		`fun test() {
+    var i = 0
+    while i < 10 {
+        i = i + 1
+        if i == 5 {
+            break
+        } else {
+            continue
+        }
+    }
+    for index, element in [1, 2] {
+        log(element)
+    }
+    if let x = y {
+        log(x)
+    } else if true {
+        log(1)
+    } else {
+        log(2)
+    }
+    switch i {
+        case 1:
+            log(1)
+        default:
+            log(2)
+    }
+    let a <- b <- c
+    a <-> b
+    emit Foo(x: 1)
+    return
+}
+`,
+	)
+}
+
+func TestFormatParentheses(t *testing.T) {
+
+	t.Parallel()
+
+	testFormat(t,
+		`
+          let a = ((1 + 2)) * 3
+          let b = 1 - (2 - 3)
+          let c = (1 - 2) - 3
+          let d = x ?? (y ?? z)
+          let e = (x ?? y) ?? z
+          let f = -(1 + 2)
+          let g = (x as? Int)!
+          let h = (x as Int) + 1
+          let i = (1).toString()
+          let j = (true ? 1 : 2) as Int
+          let k = [1, 2][0]
+          let l = (create R()).foo
+        `,
+		DefaultMaxLineWidth,
+		`let a = (1 + 2) * 3
+
+let b = 1 - (2 - 3)
+
+let c = 1 - 2 - 3
+
+let d = x ?? y ?? z
+
+let e = (x ?? y) ?? z
+
+let f = -(1 + 2)
+
+let g = (x as? Int)!
+
+let h = x as Int + 1
+
+let i = (1).toString()
+
+let j = (true ? 1 : 2) as Int
+
+let k = [1, 2][0]
+
+let l = (create R()).foo
+`,
+	)
+}
+
+func TestFormatLineWrapping(t *testing.T) {
+
+	t.Parallel()
+
+	testFormat(t,
+		`
+          fun test(first: Int, second: String, third: [Int]): Int {
+            let sum = first + someFunction(label: second, other: third) * anotherFunction()
+            let array = [first, second, third]
+            return sum
+          }
+        `,
+		40,
+		`fun test(
+    first: Int,
+    second: String,
+    third: [Int]
+): Int {
+    let sum = first
+        + someFunction(
+            label: second,
+            other: third
+        )
+            * anotherFunction()
+    let array = [first, second, third]
+    return sum
+}
+`,
+	)
+}
+
+func TestFormatEmpty(t *testing.T) {
+
+	t.Parallel()
+
+	testFormat(t, "", DefaultMaxLineWidth, "")
+	testFormat(t, "  \n\n ", DefaultMaxLineWidth, "")
+}
+
+func TestFormatErrors(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("parsing error", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Format("fun", DefaultMaxLineWidth)
+		require.Error(t, err)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Format(
+			`
+              fun test() {
+                  // not a doc string
+                  return
+              }
+            `,
+			DefaultMaxLineWidth,
+		)
+		require.ErrorIs(t, err, UnsupportedCommentsError{})
+	})
+
+	t.Run("unattached doc string", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Format(
+			`
+              fun test() {
+                  /// not attached
+                  return
+              }
+            `,
+			DefaultMaxLineWidth,
+		)
+		require.ErrorIs(t, err, UnsupportedCommentsError{})
+	})
+}
@@ -0,0 +1,434 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gocodegen generates Go code for interacting with Cadence programs,
+// e.g. contracts, from their ABI.
+//
+// The generated code contains typed Go structs for the program's structs, enums, and events,
+// and for the arguments of its public functions,
+// together with functions which encode them to and decode them from Cadence values,
+// so they can be passed as JSON-Cadence encoded arguments of scripts and transactions,
+// and read from script results and emitted events.
+//
+package gocodegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// Generate returns the Go source code of a file in the package with the given name,
+// which contains the types and functions for the given ABI.
+//
+func Generate(abi *runtime.ABI, packageName string) ([]byte, error) {
+	g := newGenerator(abi)
+
+	err := g.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	var source bytes.Buffer
+
+	fmt.Fprintf(&source, "// Code generated by gocodegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", packageName)
+
+	source.WriteString("import (\n")
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports { //nolint:maprangecheck
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	// Standard library imports are grouped before other imports
+	sort.SliceStable(imports, func(i, j int) bool {
+		return !isThirdPartyImport(imports[i]) && isThirdPartyImport(imports[j])
+	})
+	for i, path := range imports {
+		if i > 0 && isThirdPartyImport(path) && !isThirdPartyImport(imports[i-1]) {
+			source.WriteString("\n")
+		}
+		fmt.Fprintf(&source, "\t%s\n", path)
+	}
+	source.WriteString(")\n\n")
+
+	source.Write(g.declarations.Bytes())
+	source.Write(g.helpers.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// GenerateForProgram returns the Go source code of a file in the package with the given name,
+// which contains the types and functions for the given checked program, e.g. a contract,
+// which has the given location.
+//
+func GenerateForProgram(
+	location runtime.Location,
+	program *interpreter.Program,
+	packageName string,
+) ([]byte, error) {
+	return Generate(runtime.NewABI(location, program), packageName)
+}
+
+const (
+	importFmt     = `"fmt"`
+	importBig     = `"math/big"`
+	importCadence = `"github.com/onflow/cadence"`
+	importJSON    = `jsoncdc "github.com/onflow/cadence/encoding/json"`
+	importCommon  = `"github.com/onflow/cadence/runtime/common"`
+)
+
+func isThirdPartyImport(path string) bool {
+	return strings.Contains(path, ".")
+}
+
+type generator struct {
+	abi          *runtime.ABI
+	imports      map[string]struct{}
+	declarations bytes.Buffer
+	helpers      bytes.Buffer
+	// composites are the struct and enum types declared in the ABI,
+	// for which Go types are generated, by type ID
+	composites map[string]cadence.Type
+	// generatedHelpers are the keys of the encoding and decoding helper functions
+	// which were already generated
+	generatedHelpers map[string]struct{}
+}
+
+func newGenerator(abi *runtime.ABI) *generator {
+	return &generator{
+		abi:              abi,
+		imports:          map[string]struct{}{},
+		composites:       map[string]cadence.Type{},
+		generatedHelpers: map[string]struct{}{},
+	}
+}
+
+func (g *generator) addImport(path string) {
+	g.imports[path] = struct{}{}
+}
+
+func (g *generator) generate() error {
+	g.addImport(importFmt)
+	g.addImport(importCadence)
+
+	for _, abiType := range g.abi.Types {
+		switch ty := abiType.Type.(type) {
+		case *cadence.StructType, *cadence.EnumType:
+			g.composites[ty.ID()] = ty
+		}
+	}
+
+	for _, abiType := range g.abi.Types {
+		switch ty := abiType.Type.(type) {
+		case *cadence.StructType:
+			g.generateStruct(ty)
+		case *cadence.EnumType:
+			g.generateEnum(ty)
+		}
+	}
+
+	for _, eventType := range g.abi.Events {
+		g.generateEvent(eventType)
+	}
+
+	for _, abiType := range g.abi.Types {
+		var qualifiedIdentifier string
+		switch ty := abiType.Type.(type) {
+		case *cadence.ContractType:
+			qualifiedIdentifier = ty.QualifiedIdentifier
+		case *cadence.ContractInterfaceType:
+			qualifiedIdentifier = ty.QualifiedIdentifier
+		default:
+			continue
+		}
+
+		prefix := qualifiedGoName(qualifiedIdentifier)
+		for _, function := range abiType.Functions {
+			g.generateArguments(prefix, function)
+			g.generateResult(prefix, function)
+		}
+	}
+
+	for _, function := range g.abi.Functions {
+		g.generateArguments("", function)
+		g.generateResult("", function)
+	}
+
+	return nil
+}
+
+// goName returns the exported Go name for the given Cadence identifier
+//
+func goName(identifier string) string {
+	if identifier == "" {
+		return identifier
+	}
+	runes := []rune(identifier)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// qualifiedGoName returns the exported Go name for the given qualified Cadence identifier,
+// e.g. `FooBar` for `Foo.bar`
+//
+func qualifiedGoName(qualifiedIdentifier string) string {
+	parts := strings.Split(qualifiedIdentifier, ".")
+	for i, part := range parts {
+		parts[i] = goName(part)
+	}
+	return strings.Join(parts, "")
+}
+
+func unexportedName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+func (g *generator) generateCompositeTypeVariable(
+	name string,
+	typeName string,
+	typeID string,
+	fields []cadence.Field,
+	extra string,
+) {
+	g.addImport(importCommon)
+	g.generateTypeIDHelper()
+
+	fmt.Fprintf(&g.declarations, "var %s = func() *cadence.%s {\n", name, typeName)
+	fmt.Fprintf(&g.declarations, "\tlocation, qualifiedIdentifier := mustDecodeTypeID(%q)\n", typeID)
+	fmt.Fprintf(&g.declarations, "\treturn &cadence.%s{\n", typeName)
+	fmt.Fprintf(&g.declarations, "\t\tLocation: location,\n")
+	fmt.Fprintf(&g.declarations, "\t\tQualifiedIdentifier: qualifiedIdentifier,\n")
+	g.declarations.WriteString(extra)
+	fmt.Fprintf(&g.declarations, "\t\tFields: []cadence.Field{\n")
+	for _, field := range fields {
+		fmt.Fprintf(&g.declarations, "\t\t\t{Identifier: %q},\n", field.Identifier)
+	}
+	fmt.Fprintf(&g.declarations, "\t\t},\n")
+	fmt.Fprintf(&g.declarations, "\t}\n")
+	fmt.Fprintf(&g.declarations, "}()\n\n")
+}
+
+func (g *generator) generateTypeIDHelper() {
+	const key = "mustDecodeTypeID"
+	if _, ok := g.generatedHelpers[key]; ok {
+		return
+	}
+	g.generatedHelpers[key] = struct{}{}
+
+	g.helpers.WriteString(`func mustDecodeTypeID(typeID string) (common.Location, string) {
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+	if err != nil {
+		panic(err)
+	}
+	return location, qualifiedIdentifier
+}
+
+`)
+}
+
+func (g *generator) generateFields(fields []cadence.Field) {
+	for _, field := range fields {
+		fmt.Fprintf(
+			&g.declarations,
+			"\t%s %s\n",
+			goName(field.Identifier),
+			g.goType(field.Type),
+		)
+	}
+}
+
+func (g *generator) generateStruct(ty *cadence.StructType) {
+	name := qualifiedGoName(ty.QualifiedIdentifier)
+	typeVariable := unexportedName(name) + "Type"
+
+	fmt.Fprintf(&g.declarations, "// %s is the Go representation of the Cadence struct `%s`\n", name, ty.ID())
+	fmt.Fprintf(&g.declarations, "type %s struct {\n", name)
+	g.generateFields(ty.Fields)
+	fmt.Fprintf(&g.declarations, "}\n\n")
+
+	g.generateCompositeTypeVariable(typeVariable, "StructType", ty.ID(), ty.Fields, "")
+
+	// Encoding
+
+	fmt.Fprintf(&g.declarations, "// ToCadenceValue converts the struct to a Cadence value\n")
+	fmt.Fprintf(&g.declarations, "func (v %s) ToCadenceValue() (cadence.Value, error) {\n", name)
+	fmt.Fprintf(&g.declarations, "\tfields := make([]cadence.Value, %d)\n", len(ty.Fields))
+	for i, field := range ty.Fields {
+		fmt.Fprintf(&g.declarations, "\tfield%d, err := %s(v.%s)\n", i, g.encoder(field.Type), goName(field.Identifier))
+		fmt.Fprintf(&g.declarations, "\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		fmt.Fprintf(&g.declarations, "\tfields[%d] = field%d\n", i, i)
+	}
+	fmt.Fprintf(&g.declarations, "\treturn cadence.NewStruct(fields).WithType(%s), nil\n", typeVariable)
+	fmt.Fprintf(&g.declarations, "}\n\n")
+
+	// Decoding
+
+	fmt.Fprintf(&g.declarations, "// Decode%s converts the given Cadence value to a %s\n", name, name)
+	fmt.Fprintf(&g.declarations, "func Decode%s(value cadence.Value) (result %s, err error) {\n", name, name)
+	fmt.Fprintf(&g.declarations, "\tstructValue, ok := value.(cadence.Struct)\n")
+	fmt.Fprintf(&g.declarations, "\tif !ok || structValue.StructType == nil || structValue.StructType.ID() != %q {\n", ty.ID())
+	fmt.Fprintf(&g.declarations, "\t\treturn result, fmt.Errorf(\"expected struct %s, got %%s\", value)\n", ty.ID())
+	fmt.Fprintf(&g.declarations, "\t}\n")
+	g.generateFieldDecoding("structValue.Fields", ty.Fields)
+	fmt.Fprintf(&g.declarations, "\treturn result, nil\n")
+	fmt.Fprintf(&g.declarations, "}\n\n")
+}
+
+func (g *generator) generateFieldDecoding(fieldsExpression string, fields []cadence.Field) {
+	fmt.Fprintf(&g.declarations, "\tif len(%s) != %d {\n", fieldsExpression, len(fields))
+	fmt.Fprintf(&g.declarations, "\t\treturn result, fmt.Errorf(\"expected %d fields, got %%d\", len(%s))\n", len(fields), fieldsExpression)
+	fmt.Fprintf(&g.declarations, "\t}\n")
+	for i, field := range fields {
+		fmt.Fprintf(
+			&g.declarations,
+			"\tresult.%s, err = %s(%s[%d])\n",
+			goName(field.Identifier),
+			g.decoder(field.Type),
+			fieldsExpression,
+			i,
+		)
+		fmt.Fprintf(&g.declarations, "\tif err != nil {\n")
+		fmt.Fprintf(&g.declarations, "\t\treturn result, fmt.Errorf(\"invalid field %s: %%w\", err)\n", field.Identifier)
+		fmt.Fprintf(&g.declarations, "\t}\n")
+	}
+}
+
+func (g *generator) generateEnum(ty *cadence.EnumType) {
+	name := qualifiedGoName(ty.QualifiedIdentifier)
+	typeVariable := unexportedName(name) + "Type"
+
+	fmt.Fprintf(&g.declarations, "// %s is the Go representation of the Cadence enum `%s`,\n", name, ty.ID())
+	fmt.Fprintf(&g.declarations, "// i.e. the raw value of the enum case\n")
+	fmt.Fprintf(&g.declarations, "type %s %s\n\n", name, g.goType(ty.RawType))
+
+	g.generateCompositeTypeVariable(typeVariable, "EnumType", ty.ID(), ty.Fields, "")
+
+	fmt.Fprintf(&g.declarations, "// ToCadenceValue converts the enum case to a Cadence value\n")
+	fmt.Fprintf(&g.declarations, "func (v %s) ToCadenceValue() (cadence.Value, error) {\n", name)
+	fmt.Fprintf(&g.declarations, "\trawValue, err := %s(%s(v))\n", g.encoder(ty.RawType), g.goType(ty.RawType))
+	fmt.Fprintf(&g.declarations, "\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(&g.declarations, "\treturn cadence.NewEnum([]cadence.Value{rawValue}).WithType(%s), nil\n", typeVariable)
+	fmt.Fprintf(&g.declarations, "}\n\n")
+
+	fmt.Fprintf(&g.declarations, "// Decode%s converts the given Cadence value to a %s\n", name, name)
+	fmt.Fprintf(&g.declarations, "func Decode%s(value cadence.Value) (result %s, err error) {\n", name, name)
+	fmt.Fprintf(&g.declarations, "\tenumValue, ok := value.(cadence.Enum)\n")
+	fmt.Fprintf(&g.declarations, "\tif !ok || enumValue.EnumType == nil || enumValue.EnumType.ID() != %q || len(enumValue.Fields) != 1 {\n", ty.ID())
+	fmt.Fprintf(&g.declarations, "\t\treturn result, fmt.Errorf(\"expected enum %s, got %%s\", value)\n", ty.ID())
+	fmt.Fprintf(&g.declarations, "\t}\n")
+	fmt.Fprintf(&g.declarations, "\trawValue, err := %s(enumValue.Fields[0])\n", g.decoder(ty.RawType))
+	fmt.Fprintf(&g.declarations, "\tif err != nil {\n\t\treturn result, err\n\t}\n")
+	fmt.Fprintf(&g.declarations, "\treturn %s(rawValue), nil\n", name)
+	fmt.Fprintf(&g.declarations, "}\n\n")
+}
+
+func (g *generator) generateEvent(ty *cadence.EventType) {
+	name := qualifiedGoName(ty.QualifiedIdentifier) + "Event"
+
+	fmt.Fprintf(&g.declarations, "// %sTypeID is the type ID of the Cadence event `%s`\n", name, ty.ID())
+	fmt.Fprintf(&g.declarations, "const %sTypeID = %q\n\n", name, ty.ID())
+
+	fmt.Fprintf(&g.declarations, "// %s is the Go representation of the Cadence event `%s`\n", name, ty.ID())
+	fmt.Fprintf(&g.declarations, "type %s struct {\n", name)
+	g.generateFields(ty.Fields)
+	fmt.Fprintf(&g.declarations, "}\n\n")
+
+	fmt.Fprintf(&g.declarations, "// Decode%s converts the given Cadence event to a %s\n", name, name)
+	fmt.Fprintf(&g.declarations, "func Decode%s(event cadence.Event) (result %s, err error) {\n", name, name)
+	fmt.Fprintf(&g.declarations, "\tif event.EventType == nil || event.EventType.ID() != %sTypeID {\n", name)
+	fmt.Fprintf(&g.declarations, "\t\treturn result, fmt.Errorf(\"expected event %s, got %%s\", event)\n", ty.ID())
+	fmt.Fprintf(&g.declarations, "\t}\n")
+	g.generateFieldDecoding("event.Fields", ty.Fields)
+	fmt.Fprintf(&g.declarations, "\treturn result, nil\n")
+	fmt.Fprintf(&g.declarations, "}\n\n")
+}
+
+func (g *generator) generateArguments(prefix string, function runtime.ABIFunction) {
+	name := prefix + goName(function.Identifier) + "Arguments"
+
+	g.addImport(importJSON)
+
+	parameters := function.Type.Parameters
+
+	fmt.Fprintf(&g.declarations, "// %s are the arguments of the Cadence function `%s`\n", name, function.Identifier)
+	fmt.Fprintf(&g.declarations, "type %s struct {\n", name)
+	for _, parameter := range parameters {
+		fmt.Fprintf(
+			&g.declarations,
+			"\t%s %s\n",
+			goName(parameter.Identifier),
+			g.goType(parameter.Type),
+		)
+	}
+	fmt.Fprintf(&g.declarations, "}\n\n")
+
+	fmt.Fprintf(&g.declarations, "// Values converts the arguments to Cadence values\n")
+	fmt.Fprintf(&g.declarations, "func (a %s) Values() ([]cadence.Value, error) {\n", name)
+	fmt.Fprintf(&g.declarations, "\tvalues := make([]cadence.Value, %d)\n", len(parameters))
+	for i, parameter := range parameters {
+		fmt.Fprintf(&g.declarations, "\tvalue%d, err := %s(a.%s)\n", i, g.encoder(parameter.Type), goName(parameter.Identifier))
+		fmt.Fprintf(&g.declarations, "\tif err != nil {\n")
+		fmt.Fprintf(&g.declarations, "\t\treturn nil, fmt.Errorf(\"invalid argument %s: %%w\", err)\n", parameter.Identifier)
+		fmt.Fprintf(&g.declarations, "\t}\n")
+		fmt.Fprintf(&g.declarations, "\tvalues[%d] = value%d\n", i, i)
+	}
+	fmt.Fprintf(&g.declarations, "\treturn values, nil\n")
+	fmt.Fprintf(&g.declarations, "}\n\n")
+
+	fmt.Fprintf(&g.declarations, "// Encode encodes the arguments as JSON-Cadence,\n")
+	fmt.Fprintf(&g.declarations, "// so they can be passed as the arguments of a script or transaction\n")
+	fmt.Fprintf(&g.declarations, "func (a %s) Encode() ([][]byte, error) {\n", name)
+	fmt.Fprintf(&g.declarations, "\tvalues, err := a.Values()\n")
+	fmt.Fprintf(&g.declarations, "\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(&g.declarations, "\tresult := make([][]byte, len(values))\n")
+	fmt.Fprintf(&g.declarations, "\tfor i, value := range values {\n")
+	fmt.Fprintf(&g.declarations, "\t\tresult[i], err = jsoncdc.Encode(value)\n")
+	fmt.Fprintf(&g.declarations, "\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+	fmt.Fprintf(&g.declarations, "\t}\n")
+	fmt.Fprintf(&g.declarations, "\treturn result, nil\n")
+	fmt.Fprintf(&g.declarations, "}\n\n")
+}
+
+func (g *generator) generateResult(prefix string, function runtime.ABIFunction) {
+	returnType := function.Type.ReturnType
+	if _, ok := returnType.(cadence.VoidType); ok || returnType == nil {
+		return
+	}
+
+	name := "Decode" + prefix + goName(function.Identifier) + "Result"
+
+	fmt.Fprintf(&g.declarations, "// %s converts the given result of the Cadence function `%s`\n", name, function.Identifier)
+	fmt.Fprintf(&g.declarations, "func %s(value cadence.Value) (%s, error) {\n", name, g.goType(returnType))
+	fmt.Fprintf(&g.declarations, "\treturn %s(value)\n", g.decoder(returnType))
+	fmt.Fprintf(&g.declarations, "}\n\n")
+}
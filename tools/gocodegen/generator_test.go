@@ -0,0 +1,201 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gocodegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func newTestProgram(t *testing.T, code string) (common.Location, *interpreter.Program) {
+
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Test",
+	}
+
+	checker, err := sema.NewChecker(program, location)
+	require.NoError(t, err)
+
+	err = checker.Check()
+	require.NoError(t, err)
+
+	return location, &interpreter.Program{
+		Program:     program,
+		Elaboration: checker.Elaboration,
+	}
+}
+
+func newTestABI(t *testing.T, code string) *runtime.ABI {
+	location, program := newTestProgram(t, code)
+	return runtime.NewABI(location, program)
+}
+
+func requireTypeChecks(t *testing.T, code []byte) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "generated.go", code, 0)
+	require.NoError(t, err)
+
+	config := types.Config{
+		Importer: importer.ForCompiler(fileSet, "source", nil),
+	}
+	_, err = config.Check("test", fileSet, []*ast.File{file}, nil)
+	require.NoError(t, err)
+}
+
+const testContract = `
+  pub contract Test {
+
+      pub enum Color: UInt8 {
+          pub case red
+          pub case green
+      }
+
+      pub struct Item {
+          pub let id: UInt64
+          pub let name: String
+          pub let tags: [String]
+          pub let color: Color
+          pub let price: UFix64?
+          pub let supply: UInt256
+          pub let metadata: {String: Int8}
+
+          init() {
+              self.id = 0
+              self.name = ""
+              self.tags = []
+              self.color = Color.red
+              self.price = nil
+              self.supply = 0
+              self.metadata = {}
+          }
+      }
+
+      pub event Listed(id: UInt64, seller: Address?, tags: [String])
+
+      pub resource Vault {}
+
+      pub fun list(items: [Item], seller: Address, colors: {Color: Bool}, path: PublicPath) {}
+
+      pub fun createVault(): @Vault {
+          return <-create Vault()
+      }
+  }
+
+  pub fun totalSupply(): UInt256 {
+      return 0
+  }
+`
+
+func TestGenerate(t *testing.T) {
+
+	t.Parallel()
+
+	abi := newTestABI(t, testContract)
+
+	code, err := Generate(abi, "test")
+	require.NoError(t, err)
+
+	source := string(code)
+
+	for _, expected := range []string{
+		"package test",
+		"type TestItem struct {",
+		"\tTags     []string\n",
+		"\tColor    TestColor\n",
+		"\tPrice    *cadence.UFix64\n",
+		"\tSupply   *big.Int\n",
+		"\tMetadata map[string]int8\n",
+		"type TestColor uint8",
+		`const TestListedEventTypeID = "A.0000000000000001.Test.Listed"`,
+		"type TestListedEvent struct {",
+		"\tSeller *cadence.Address\n",
+		"func DecodeTestListedEvent(event cadence.Event) (result TestListedEvent, err error) {",
+		"type TestListArguments struct {",
+		"\tColors map[TestColor]bool\n",
+		"\tPath   cadence.Value\n",
+		"func (a TestListArguments) Encode() ([][]byte, error) {",
+		"type TestCreateVaultArguments struct {",
+		"type TotalSupplyArguments struct {",
+		"func DecodeTotalSupplyResult(value cadence.Value) (*big.Int, error) {",
+	} {
+		assert.Contains(t, source, expected)
+	}
+
+	// The generated code must type-check
+
+	requireTypeChecks(t, code)
+}
+
+func TestGenerateForProgram(t *testing.T) {
+
+	t.Parallel()
+
+	location, program := newTestProgram(t, `
+      pub contract interface Market {
+
+          pub struct Offer {
+              pub let price: UFix64
+          }
+
+          pub event Sold(id: UInt64, price: UFix64)
+
+          pub fun offers(seller: Address): [Offer]
+
+          pub fun buy(id: UInt64, maxPrice: UFix64?): Bool
+      }
+    `)
+
+	code, err := GenerateForProgram(location, program, "market")
+	require.NoError(t, err)
+
+	source := string(code)
+
+	for _, expected := range []string{
+		"package market",
+		"type MarketOffer struct {",
+		"type MarketSoldEvent struct {",
+		"type MarketOffersArguments struct {",
+		"\tSeller cadence.Address\n",
+		"func DecodeMarketOffersResult(value cadence.Value) ([]MarketOffer, error) {",
+		"type MarketBuyArguments struct {",
+		"\tMaxPrice *cadence.UFix64\n",
+		"func DecodeMarketBuyResult(value cadence.Value) (bool, error) {",
+	} {
+		assert.Contains(t, source, expected)
+	}
+
+	requireTypeChecks(t, code)
+}
@@ -0,0 +1,453 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gocodegen
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+)
+
+// valueGoType is the Go type used for Cadence types which have no dedicated Go type,
+// e.g. resources, references, and paths
+//
+const valueGoType = "cadence.Value"
+
+type simpleType struct {
+	goType string
+	// cadenceType is the name of the type of the Cadence value
+	cadenceType string
+	// big is true if the Go type is *big.Int
+	big bool
+	// comparable is true if the Go type can be used as a map key
+	comparable bool
+}
+
+var simpleTypes = map[string]simpleType{
+	"Bool":    {goType: "bool", cadenceType: "Bool", comparable: true},
+	"String":  {goType: "string", cadenceType: "String", comparable: true},
+	"Address": {goType: "cadence.Address", cadenceType: "Address", comparable: true},
+	"Int8":    {goType: "int8", cadenceType: "Int8", comparable: true},
+	"Int16":   {goType: "int16", cadenceType: "Int16", comparable: true},
+	"Int32":   {goType: "int32", cadenceType: "Int32", comparable: true},
+	"Int64":   {goType: "int64", cadenceType: "Int64", comparable: true},
+	"UInt8":   {goType: "uint8", cadenceType: "UInt8", comparable: true},
+	"UInt16":  {goType: "uint16", cadenceType: "UInt16", comparable: true},
+	"UInt32":  {goType: "uint32", cadenceType: "UInt32", comparable: true},
+	"UInt64":  {goType: "uint64", cadenceType: "UInt64", comparable: true},
+	"Word8":   {goType: "uint8", cadenceType: "Word8", comparable: true},
+	"Word16":  {goType: "uint16", cadenceType: "Word16", comparable: true},
+	"Word32":  {goType: "uint32", cadenceType: "Word32", comparable: true},
+	"Word64":  {goType: "uint64", cadenceType: "Word64", comparable: true},
+	"Fix64":   {goType: "cadence.Fix64", cadenceType: "Fix64", comparable: true},
+	"UFix64":  {goType: "cadence.UFix64", cadenceType: "UFix64", comparable: true},
+	"Int":     {goType: "*big.Int", cadenceType: "Int", big: true},
+	"Int128":  {goType: "*big.Int", cadenceType: "Int128", big: true},
+	"Int256":  {goType: "*big.Int", cadenceType: "Int256", big: true},
+	"UInt":    {goType: "*big.Int", cadenceType: "UInt", big: true},
+	"UInt128": {goType: "*big.Int", cadenceType: "UInt128", big: true},
+	"UInt256": {goType: "*big.Int", cadenceType: "UInt256", big: true},
+}
+
+// goType returns the Go type for the given Cadence type
+//
+func (g *generator) goType(ty cadence.Type) string {
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		return "*" + g.goType(ty.Type)
+
+	case cadence.VariableSizedArrayType:
+		return "[]" + g.goType(ty.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		return "[]" + g.goType(ty.ElementType)
+
+	case cadence.DictionaryType:
+		if !g.isComparable(ty.KeyType) {
+			return valueGoType
+		}
+		return fmt.Sprintf("map[%s]%s", g.goType(ty.KeyType), g.goType(ty.ElementType))
+	}
+
+	if ty == nil {
+		return valueGoType
+	}
+
+	if simple, ok := simpleTypes[ty.ID()]; ok {
+		if simple.big {
+			g.addImport(importBig)
+		}
+		return simple.goType
+	}
+
+	if composite, ok := g.composites[ty.ID()]; ok {
+		return qualifiedGoName(compositeQualifiedIdentifier(composite))
+	}
+
+	return valueGoType
+}
+
+func (g *generator) isComparable(ty cadence.Type) bool {
+	if ty == nil {
+		return false
+	}
+
+	if simple, ok := simpleTypes[ty.ID()]; ok {
+		return simple.comparable
+	}
+
+	composite, ok := g.composites[ty.ID()]
+	if !ok {
+		return false
+	}
+	enumType, ok := composite.(*cadence.EnumType)
+	return ok && g.isComparable(enumType.RawType)
+}
+
+func compositeQualifiedIdentifier(ty cadence.Type) string {
+	switch ty := ty.(type) {
+	case *cadence.StructType:
+		return ty.QualifiedIdentifier
+	case *cadence.EnumType:
+		return ty.QualifiedIdentifier
+	}
+	panic(fmt.Errorf("unsupported composite type: %s", ty.ID()))
+}
+
+// helperName returns the suffix of the names of the encoding and decoding helper functions
+// for the given Cadence type
+//
+func (g *generator) helperName(ty cadence.Type) string {
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		return "Optional" + g.helperName(ty.Type)
+
+	case cadence.VariableSizedArrayType:
+		return "Array" + g.helperName(ty.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		return "Array" + g.helperName(ty.ElementType)
+
+	case cadence.DictionaryType:
+		if !g.isComparable(ty.KeyType) {
+			return "Value"
+		}
+		return "Dictionary" + g.helperName(ty.KeyType) + g.helperName(ty.ElementType)
+	}
+
+	if ty == nil {
+		return "Value"
+	}
+
+	if _, ok := simpleTypes[ty.ID()]; ok {
+		return ty.ID()
+	}
+
+	if composite, ok := g.composites[ty.ID()]; ok {
+		return qualifiedGoName(compositeQualifiedIdentifier(composite))
+	}
+
+	return "Value"
+}
+
+// encoder returns the name of the function which converts
+// a Go value of the Go type for the given Cadence type to a Cadence value,
+// and generates it if needed
+//
+func (g *generator) encoder(ty cadence.Type) string {
+	name := "encode" + g.helperName(ty)
+
+	if _, ok := g.generatedHelpers[name]; ok {
+		return name
+	}
+	g.generatedHelpers[name] = struct{}{}
+
+	goType := g.goType(ty)
+
+	var body string
+
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		body = fmt.Sprintf(
+			`if v == nil {
+				return cadence.NewOptional(nil), nil
+			}
+			value, err := %s(*v)
+			if err != nil {
+				return nil, err
+			}
+			return cadence.NewOptional(value), nil`,
+			g.encoder(ty.Type),
+		)
+
+	case cadence.VariableSizedArrayType:
+		body = g.arrayEncoderBody(ty.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		body = g.arrayEncoderBody(ty.ElementType)
+
+	case cadence.DictionaryType:
+		if goType != valueGoType {
+			g.addImport(`"sort"`)
+			body = fmt.Sprintf(
+				`pairs := make([]cadence.KeyValuePair, 0, len(v))
+				for key, element := range v {
+					keyValue, err := %s(key)
+					if err != nil {
+						return nil, err
+					}
+					elementValue, err := %s(element)
+					if err != nil {
+						return nil, err
+					}
+					pairs = append(pairs, cadence.KeyValuePair{Key: keyValue, Value: elementValue})
+				}
+				sort.Slice(pairs, func(i, j int) bool {
+					return pairs[i].Key.String() < pairs[j].Key.String()
+				})
+				return cadence.NewDictionary(pairs), nil`,
+				g.encoder(ty.KeyType),
+				g.encoder(ty.ElementType),
+			)
+		}
+	}
+
+	if body == "" {
+		body = g.simpleEncoderBody(ty, goType)
+	}
+
+	fmt.Fprintf(
+		&g.helpers,
+		"func %s(v %s) (cadence.Value, error) {\n%s\n}\n\n",
+		name,
+		goType,
+		body,
+	)
+
+	return name
+}
+
+func (g *generator) arrayEncoderBody(elementType cadence.Type) string {
+	return fmt.Sprintf(
+		`values := make([]cadence.Value, len(v))
+		for i, element := range v {
+			value, err := %s(element)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return cadence.NewArray(values), nil`,
+		g.encoder(elementType),
+	)
+}
+
+func (g *generator) simpleEncoderBody(ty cadence.Type, goType string) string {
+	if goType == valueGoType {
+		return `if v == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		return v, nil`
+	}
+
+	if _, ok := g.composites[ty.ID()]; ok {
+		return "return v.ToCadenceValue()"
+	}
+
+	simple := simpleTypes[ty.ID()]
+
+	switch {
+	case simple.big:
+		nilCheck := `if v == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		`
+		if simple.cadenceType == "Int" {
+			return nilCheck + "return cadence.NewIntFromBig(v), nil"
+		}
+		return nilCheck + fmt.Sprintf("return cadence.New%sFromBig(v)", simple.cadenceType)
+
+	case simple.cadenceType == "String":
+		return "return cadence.NewString(v)"
+
+	case goType == "cadence."+simple.cadenceType:
+		return "return v, nil"
+
+	default:
+		return fmt.Sprintf("return cadence.%s(v), nil", simple.cadenceType)
+	}
+}
+
+// decoder returns the name of the function which converts a Cadence value
+// to a Go value of the Go type for the given Cadence type,
+// and generates it if needed
+//
+func (g *generator) decoder(ty cadence.Type) string {
+	name := "decode" + g.helperName(ty)
+
+	if ty != nil {
+		if composite, ok := g.composites[ty.ID()]; ok {
+			return "Decode" + qualifiedGoName(compositeQualifiedIdentifier(composite))
+		}
+	}
+
+	if _, ok := g.generatedHelpers[name]; ok {
+		return name
+	}
+	g.generatedHelpers[name] = struct{}{}
+
+	goType := g.goType(ty)
+
+	var body string
+
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		body = fmt.Sprintf(
+			`optional, ok := value.(cadence.Optional)
+			if !ok {
+				return nil, fmt.Errorf("expected optional, got %%s", value)
+			}
+			if optional.Value == nil {
+				return nil, nil
+			}
+			result, err := %s(optional.Value)
+			if err != nil {
+				return nil, err
+			}
+			return &result, nil`,
+			g.decoder(ty.Type),
+		)
+
+	case cadence.VariableSizedArrayType:
+		body = g.arrayDecoderBody(ty.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		body = g.arrayDecoderBody(ty.ElementType)
+
+	case cadence.DictionaryType:
+		if goType != valueGoType {
+			body = fmt.Sprintf(
+				`dictionary, ok := value.(cadence.Dictionary)
+				if !ok {
+					return nil, fmt.Errorf("expected dictionary, got %%s", value)
+				}
+				result := make(%s, len(dictionary.Pairs))
+				for _, pair := range dictionary.Pairs {
+					key, err := %s(pair.Key)
+					if err != nil {
+						return nil, err
+					}
+					element, err := %s(pair.Value)
+					if err != nil {
+						return nil, err
+					}
+					result[key] = element
+				}
+				return result, nil`,
+				goType,
+				g.decoder(ty.KeyType),
+				g.decoder(ty.ElementType),
+			)
+		}
+	}
+
+	if body == "" {
+		body = g.simpleDecoderBody(ty, goType)
+	}
+
+	fmt.Fprintf(
+		&g.helpers,
+		"func %s(value cadence.Value) (%s, error) {\n%s\n}\n\n",
+		name,
+		goType,
+		body,
+	)
+
+	return name
+}
+
+func (g *generator) arrayDecoderBody(elementType cadence.Type) string {
+	return fmt.Sprintf(
+		`array, ok := value.(cadence.Array)
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %%s", value)
+		}
+		result := make(%s, len(array.Values))
+		for i, element := range array.Values {
+			var err error
+			result[i], err = %s(element)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return result, nil`,
+		"[]"+g.goType(elementType),
+		g.decoder(elementType),
+	)
+}
+
+func (g *generator) simpleDecoderBody(ty cadence.Type, goType string) string {
+	if goType == valueGoType {
+		return `if value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		return value, nil`
+	}
+
+	simple := simpleTypes[ty.ID()]
+
+	var result string
+	var zero string
+
+	switch {
+	case simple.big:
+		result = "v.Value"
+		zero = "nil"
+
+	case goType == "cadence."+simple.cadenceType:
+		result = "v"
+		zero = goType + "{}"
+		if simple.cadenceType != "Address" {
+			zero = "0"
+		}
+
+	default:
+		result = fmt.Sprintf("%s(v)", goType)
+		switch goType {
+		case "bool":
+			zero = "false"
+		case "string":
+			zero = `""`
+		default:
+			zero = "0"
+		}
+	}
+
+	return fmt.Sprintf(
+		`v, ok := value.(cadence.%s)
+		if !ok {
+			return %s, fmt.Errorf("expected %s, got %%s", value)
+		}
+		return %s, nil`,
+		simple.cadenceType,
+		zero,
+		simple.cadenceType,
+		result,
+	)
+}
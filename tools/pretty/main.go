@@ -25,11 +25,9 @@ import (
 	"log"
 	"net"
 	"net/http"
-	"strings"
-
-	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/tools/format"
 )
 
 func pretty(code string, maxLineWidth int) string {
@@ -38,23 +36,7 @@ func pretty(code string, maxLineWidth int) string {
 		return err.Error()
 	}
 
-	declarations := program.Declarations()
-
-	docs := make([]prettier.Doc, 0, len(declarations))
-
-	for _, declaration := range declarations {
-		// TODO: replace once Declaration implements Doc
-		hasDoc, ok := declaration.(interface{ Doc() prettier.Doc })
-		if !ok {
-			continue
-		}
-
-		docs = append(docs, hasDoc.Doc())
-	}
-
-	var b strings.Builder
-	prettier.Prettier(&b, prettier.Concat(docs), maxLineWidth, "    ")
-	return b.String()
+	return format.PrettyPrint(program, maxLineWidth)
 }
 
 //language=html
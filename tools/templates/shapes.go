@@ -0,0 +1,234 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// fungibleTokenShape is the shape of a fungible token vault:
+// a resource with a `balance: UFix64` field,
+// a `withdraw(amount: UFix64): @Vault` function,
+// and a `deposit(from: @Vault)` function
+//
+type fungibleTokenShape struct{}
+
+var _ shape = fungibleTokenShape{}
+
+func (fungibleTokenShape) match(contractType, resourceType *sema.CompositeType) (*shapeMatch, bool) {
+	if !hasField(resourceType, "balance", sema.UFix64Type) {
+		return nil, false
+	}
+
+	return matchResource(contractType, resourceType, sema.UFix64Type)
+}
+
+func (fungibleTokenShape) templates(match *shapeMatch) []Template {
+	contractName := match.contractType.QualifiedIdentifier()
+	resourceName := match.resourceType.QualifiedIdentifier()
+	identifierPrefix := lowerFirst(contractName)
+
+	storagePath := pathExpression(
+		match.contractType,
+		"VaultStoragePath",
+		sema.StoragePathType,
+		common.PathDomainStorage,
+		identifierPrefix+"Vault",
+	)
+
+	receiverPath := pathExpression(
+		match.contractType,
+		"ReceiverPublicPath",
+		sema.PublicPathType,
+		common.PathDomainPublic,
+		identifierPrefix+"Receiver",
+	)
+
+	balancePath := pathExpression(
+		match.contractType,
+		"BalancePublicPath",
+		sema.PublicPathType,
+		common.PathDomainPublic,
+		identifierPrefix+"Balance",
+	)
+
+	// Setup
+
+	setup := newTemplateBuilder()
+	setup.importType(match.contractType.Location, contractName)
+
+	setup.line("transaction {")
+	setup.line("")
+	setup.line("    prepare(signer: AuthAccount) {")
+	setup.line("        if signer.borrow<&%s>(from: %s) == nil {", resourceName, storagePath)
+	setup.line("            signer.save(<-%s.%s(), to: %s)", contractName, match.createFunction, storagePath)
+	setup.line("            signer.link<%s>(%s, target: %s)", setup.referenceType(match.resourceType, "deposit"), receiverPath, storagePath)
+	setup.line("            signer.link<%s>(%s, target: %s)", setup.referenceType(match.resourceType, "balance"), balancePath, storagePath)
+	setup.line("        }")
+	setup.line("    }")
+	setup.line("}")
+
+	// Transfer
+
+	transfer := newTemplateBuilder()
+	transfer.importType(match.contractType.Location, contractName)
+
+	withdrawnType := transfer.typeString(match.withdraw.ReturnTypeAnnotation.Type)
+
+	transfer.line("transaction(amount: UFix64, to: Address) {")
+	transfer.line("")
+	transfer.line("    let sentVault: @%s", withdrawnType)
+	transfer.line("")
+	transfer.line("    prepare(signer: AuthAccount) {")
+	transfer.line("        let vault = signer.borrow<&%s>(from: %s)", resourceName, storagePath)
+	transfer.line("            ?? panic(\"Could not borrow a reference to the %s vault\")", contractName)
+	transfer.line("        self.sentVault <- vault.withdraw(%s)", argumentList(match.withdraw, "amount"))
+	transfer.line("    }")
+	transfer.line("")
+	transfer.line("    execute {")
+	transfer.line("        let receiver = getAccount(to)")
+	transfer.line("            .getCapability(%s)", receiverPath)
+	transfer.line("            .borrow<%s>()", transfer.referenceType(match.resourceType, "deposit"))
+	transfer.line("            ?? panic(\"Could not borrow a reference to the %s receiver\")", contractName)
+	transfer.line("        receiver.deposit(%s)", argumentList(match.deposit, "<-self.sentVault"))
+	transfer.line("    }")
+	transfer.line("}")
+
+	return []Template{
+		{
+			Name:        "SetupVault",
+			Description: fmt.Sprintf("Sets up the signer's account to hold %s tokens", contractName),
+			Code:        setup.build(),
+		},
+		{
+			Name:        "TransferTokens",
+			Description: fmt.Sprintf("Transfers %s tokens from the signer to the given account", contractName),
+			Parameters: []cadence.Parameter{
+				{Identifier: "amount", Type: cadence.UFix64Type{}},
+				{Identifier: "to", Type: cadence.AddressType{}},
+			},
+			Code: transfer.build(),
+		},
+	}
+}
+
+// nonFungibleTokenShape is the shape of an NFT collection:
+// a resource with a `withdraw(withdrawID: UInt64): @NFT` function,
+// a `deposit(token: @NFT)` function,
+// and a `getIDs(): [UInt64]` function
+//
+type nonFungibleTokenShape struct{}
+
+var _ shape = nonFungibleTokenShape{}
+
+func (nonFungibleTokenShape) match(contractType, resourceType *sema.CompositeType) (*shapeMatch, bool) {
+	getIDs, ok := functionMember(resourceType, "getIDs")
+	if !ok ||
+		len(getIDs.Parameters) != 0 ||
+		!getIDs.ReturnTypeAnnotation.Type.Equal(&sema.VariableSizedType{Type: sema.UInt64Type}) {
+
+		return nil, false
+	}
+
+	return matchResource(contractType, resourceType, sema.UInt64Type)
+}
+
+func (nonFungibleTokenShape) templates(match *shapeMatch) []Template {
+	contractName := match.contractType.QualifiedIdentifier()
+	resourceName := match.resourceType.QualifiedIdentifier()
+	identifierPrefix := lowerFirst(contractName)
+
+	storagePath := pathExpression(
+		match.contractType,
+		"CollectionStoragePath",
+		sema.StoragePathType,
+		common.PathDomainStorage,
+		identifierPrefix+"Collection",
+	)
+
+	publicPath := pathExpression(
+		match.contractType,
+		"CollectionPublicPath",
+		sema.PublicPathType,
+		common.PathDomainPublic,
+		identifierPrefix+"Collection",
+	)
+
+	// Setup
+
+	setup := newTemplateBuilder()
+	setup.importType(match.contractType.Location, contractName)
+
+	setup.line("transaction {")
+	setup.line("")
+	setup.line("    prepare(signer: AuthAccount) {")
+	setup.line("        if signer.borrow<&%s>(from: %s) == nil {", resourceName, storagePath)
+	setup.line("            signer.save(<-%s.%s(), to: %s)", contractName, match.createFunction, storagePath)
+	setup.line("            signer.link<%s>(%s, target: %s)", setup.referenceType(match.resourceType, "deposit", "getIDs"), publicPath, storagePath)
+	setup.line("        }")
+	setup.line("    }")
+	setup.line("}")
+
+	// Transfer
+
+	transfer := newTemplateBuilder()
+	transfer.importType(match.contractType.Location, contractName)
+
+	withdrawnType := transfer.typeString(match.withdraw.ReturnTypeAnnotation.Type)
+
+	transfer.line("transaction(id: UInt64, to: Address) {")
+	transfer.line("")
+	transfer.line("    let token: @%s", withdrawnType)
+	transfer.line("")
+	transfer.line("    prepare(signer: AuthAccount) {")
+	transfer.line("        let collection = signer.borrow<&%s>(from: %s)", resourceName, storagePath)
+	transfer.line("            ?? panic(\"Could not borrow a reference to the %s collection\")", contractName)
+	transfer.line("        self.token <- collection.withdraw(%s)", argumentList(match.withdraw, "id"))
+	transfer.line("    }")
+	transfer.line("")
+	transfer.line("    execute {")
+	transfer.line("        let receiver = getAccount(to)")
+	transfer.line("            .getCapability(%s)", publicPath)
+	transfer.line("            .borrow<%s>()", transfer.referenceType(match.resourceType, "deposit"))
+	transfer.line("            ?? panic(\"Could not borrow a reference to the %s collection\")", contractName)
+	transfer.line("        receiver.deposit(%s)", argumentList(match.deposit, "<-self.token"))
+	transfer.line("    }")
+	transfer.line("}")
+
+	return []Template{
+		{
+			Name:        "SetupCollection",
+			Description: fmt.Sprintf("Sets up the signer's account to hold %s NFTs", contractName),
+			Code:        setup.build(),
+		},
+		{
+			Name:        "TransferNFT",
+			Description: fmt.Sprintf("Transfers a %s NFT from the signer to the given account", contractName),
+			Parameters: []cadence.Parameter{
+				{Identifier: "id", Type: cadence.UInt64Type{}},
+				{Identifier: "to", Type: cadence.AddressType{}},
+			},
+			Code: transfer.build(),
+		},
+	}
+}
@@ -0,0 +1,368 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package templates generates parameterized transaction templates
+// for common interactions with a contract, e.g. setting up an account and transferring tokens,
+// for use by wallets and dApp scaffolding.
+//
+// Templates are generated for the resources of the contract
+// which match the shape of a known interface, e.g. a fungible token vault or an NFT collection.
+//
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Template is a parameterized transaction
+//
+type Template struct {
+	// Name is the name of the template, e.g. `SetupVault`
+	Name string
+	// Description is a human-readable description of what the transaction does
+	Description string
+	// Parameters are the parameters of the transaction
+	Parameters []cadence.Parameter
+	// Code is the code of the transaction
+	Code string
+}
+
+// Generate generates transaction templates for the contract in the given checked program,
+// which is deployed at the given location.
+//
+// Templates are generated for all resources nested in the contract
+// which match the shape of a fungible token vault or an NFT collection.
+//
+func Generate(location common.AddressLocation, program *interpreter.Program) []Template {
+	var templates []Template
+
+	for _, compositeType := range program.Elaboration.DeclaredCompositeTypes() {
+		if compositeType.Kind != common.CompositeKindContract {
+			continue
+		}
+
+		nestedTypes := compositeType.GetNestedTypes()
+		if nestedTypes == nil {
+			continue
+		}
+
+		nestedTypes.Foreach(func(_ string, nestedType sema.Type) {
+			resourceType, ok := nestedType.(*sema.CompositeType)
+			if !ok || resourceType.Kind != common.CompositeKindResource {
+				return
+			}
+
+			for _, shape := range shapes {
+				match, ok := shape.match(compositeType, resourceType)
+				if !ok {
+					continue
+				}
+				templates = append(templates, shape.templates(match)...)
+			}
+		})
+	}
+
+	return templates
+}
+
+// shape is a known interface shape, for which templates can be generated
+//
+type shape interface {
+	// match returns the match for the given resource type nested in the given contract type,
+	// or false if the resource type does not match the shape
+	match(contractType, resourceType *sema.CompositeType) (*shapeMatch, bool)
+	// templates returns the templates for the given match
+	templates(match *shapeMatch) []Template
+}
+
+var shapes = []shape{
+	fungibleTokenShape{},
+	nonFungibleTokenShape{},
+}
+
+// shapeMatch is a resource type which matches a shape
+//
+type shapeMatch struct {
+	contractType *sema.CompositeType
+	resourceType *sema.CompositeType
+	// createFunction is the function of the contract which creates an empty resource
+	createFunction string
+	withdraw       *sema.FunctionType
+	deposit        *sema.FunctionType
+}
+
+// matchResource matches the functions of the given resource type
+// which all shapes have in common:
+// a contract function which creates an empty resource,
+// a `withdraw` function which returns a resource,
+// and a `deposit` function which takes a resource.
+//
+func matchResource(
+	contractType, resourceType *sema.CompositeType,
+	withdrawParameterType sema.Type,
+) (*shapeMatch, bool) {
+
+	withdraw, ok := functionMember(resourceType, "withdraw")
+	if !ok ||
+		len(withdraw.Parameters) != 1 ||
+		!withdraw.Parameters[0].TypeAnnotation.Type.Equal(withdrawParameterType) ||
+		!withdraw.ReturnTypeAnnotation.Type.IsResourceType() {
+
+		return nil, false
+	}
+
+	deposit, ok := functionMember(resourceType, "deposit")
+	if !ok ||
+		len(deposit.Parameters) != 1 ||
+		!deposit.Parameters[0].TypeAnnotation.Type.IsResourceType() {
+
+		return nil, false
+	}
+
+	createFunction, ok := createFunction(contractType, resourceType)
+	if !ok {
+		return nil, false
+	}
+
+	return &shapeMatch{
+		contractType:   contractType,
+		resourceType:   resourceType,
+		createFunction: createFunction,
+		withdraw:       withdraw,
+		deposit:        deposit,
+	}, true
+}
+
+// functionMember returns the type of the public function with the given name
+//
+func functionMember(compositeType *sema.CompositeType, name string) (*sema.FunctionType, bool) {
+	member, ok := compositeType.Members.Get(name)
+	if !ok ||
+		member.DeclarationKind != common.DeclarationKindFunction ||
+		member.Access != ast.AccessPublic {
+
+		return nil, false
+	}
+
+	functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+	return functionType, ok
+}
+
+// hasField returns true if the given composite type has a public field with the given name and type
+//
+func hasField(compositeType *sema.CompositeType, name string, fieldType sema.Type) bool {
+	member, ok := compositeType.Members.Get(name)
+	return ok &&
+		member.DeclarationKind == common.DeclarationKindField &&
+		member.Access == ast.AccessPublic &&
+		member.TypeAnnotation.Type.Equal(fieldType)
+}
+
+// createFunction returns the name of the first public function of the given contract
+// which has no parameters and returns the given resource type
+//
+func createFunction(contractType, resourceType *sema.CompositeType) (name string, ok bool) {
+	contractType.Members.Foreach(func(memberName string, member *sema.Member) {
+		if ok ||
+			member.DeclarationKind != common.DeclarationKindFunction ||
+			member.Access != ast.AccessPublic {
+
+			return
+		}
+
+		functionType, isFunction := member.TypeAnnotation.Type.(*sema.FunctionType)
+		if !isFunction ||
+			len(functionType.Parameters) != 0 ||
+			!functionType.ReturnTypeAnnotation.Type.Equal(resourceType) {
+
+			return
+		}
+
+		name = memberName
+		ok = true
+	})
+
+	return
+}
+
+// templateBuilder builds the code of a template
+//
+type templateBuilder struct {
+	imports map[string]common.AddressLocation
+	code    strings.Builder
+}
+
+func newTemplateBuilder() *templateBuilder {
+	return &templateBuilder{
+		imports: map[string]common.AddressLocation{},
+	}
+}
+
+// importType records the import of the contract which declares the given type,
+// and returns true if the type can be imported
+//
+func (b *templateBuilder) importType(location common.Location, qualifiedIdentifier string) bool {
+	addressLocation, ok := location.(common.AddressLocation)
+	if !ok {
+		return false
+	}
+
+	contractName := strings.SplitN(qualifiedIdentifier, ".", 2)[0]
+
+	b.imports[contractName] = common.AddressLocation{
+		Address: addressLocation.Address,
+		Name:    contractName,
+	}
+
+	return true
+}
+
+// referenceType returns the reference type to the given resource type,
+// restricted to the interfaces of the resource type which declare one of the given members.
+//
+// Interfaces which cannot be imported are ignored.
+// If the resource type conforms to no such interface, the reference type is unrestricted.
+//
+func (b *templateBuilder) referenceType(resourceType *sema.CompositeType, memberNames ...string) string {
+	var restrictions []string
+
+	for _, interfaceType := range resourceType.ExplicitInterfaceConformances {
+		for _, memberName := range memberNames {
+			if _, ok := interfaceType.Members.Get(memberName); !ok {
+				continue
+			}
+
+			if b.importType(interfaceType.Location, interfaceType.QualifiedIdentifier()) {
+				restrictions = append(restrictions, interfaceType.QualifiedIdentifier())
+			}
+			break
+		}
+	}
+
+	if len(restrictions) == 0 {
+		return fmt.Sprintf("&%s", resourceType.QualifiedIdentifier())
+	}
+
+	return fmt.Sprintf(
+		"&%s{%s}",
+		resourceType.QualifiedIdentifier(),
+		strings.Join(restrictions, ", "),
+	)
+}
+
+// typeString returns the string of the given type, and records the imports it requires.
+//
+func (b *templateBuilder) typeString(ty sema.Type) string {
+	switch ty := ty.(type) {
+	case *sema.CompositeType:
+		b.importType(ty.Location, ty.QualifiedIdentifier())
+	case *sema.InterfaceType:
+		b.importType(ty.Location, ty.QualifiedIdentifier())
+	case *sema.RestrictedType:
+		b.typeString(ty.Type)
+		for _, restriction := range ty.Restrictions {
+			b.typeString(restriction)
+		}
+	}
+
+	return ty.QualifiedString()
+}
+
+func (b *templateBuilder) line(format string, arguments ...interface{}) {
+	fmt.Fprintf(&b.code, format, arguments...)
+	b.code.WriteByte('\n')
+}
+
+// build returns the code of the template, prefixed with the recorded imports
+//
+func (b *templateBuilder) build() string {
+	contractNames := make([]string, 0, len(b.imports))
+	for contractName := range b.imports { //nolint:maprangecheck
+		contractNames = append(contractNames, contractName)
+	}
+	sort.Strings(contractNames)
+
+	var code strings.Builder
+
+	for _, contractName := range contractNames {
+		location := b.imports[contractName]
+		fmt.Fprintf(
+			&code,
+			"import %s from %s\n",
+			contractName,
+			location.Address.ShortHexWithPrefix(),
+		)
+	}
+
+	code.WriteByte('\n')
+	code.WriteString(b.code.String())
+
+	return code.String()
+}
+
+// argumentList returns the argument list for a call of a function with the given type,
+// with the given argument expressions
+//
+func argumentList(functionType *sema.FunctionType, arguments ...string) string {
+	parts := make([]string, len(arguments))
+	for i, argument := range arguments {
+		label := functionType.Parameters[i].EffectiveArgumentLabel()
+		if label == sema.ArgumentLabelNotRequired {
+			parts[i] = argument
+		} else {
+			parts[i] = fmt.Sprintf("%s: %s", label, argument)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pathExpression returns the expression for a path used by the given contract.
+//
+// If the contract declares a public path field with the given name and type,
+// the field is used. Otherwise, the given default path is used.
+//
+func pathExpression(
+	contractType *sema.CompositeType,
+	fieldName string,
+	fieldType sema.Type,
+	defaultDomain common.PathDomain,
+	defaultIdentifier string,
+) string {
+	if hasField(contractType, fieldName, fieldType) {
+		return fmt.Sprintf("%s.%s", contractType.QualifiedIdentifier(), fieldName)
+	}
+
+	return fmt.Sprintf("/%s/%s", defaultDomain.Identifier(), defaultIdentifier)
+}
+
+// lowerFirst returns the given identifier with its first letter in lower case
+//
+func lowerFirst(identifier string) string {
+	if identifier == "" {
+		return identifier
+	}
+	return strings.ToLower(identifier[:1]) + identifier[1:]
+}
@@ -0,0 +1,298 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+var testLocation = common.AddressLocation{
+	Address: common.MustBytesToAddress([]byte{0x1}),
+	Name:    "Test",
+}
+
+func parseAndCheck(t *testing.T, code string, location common.Location, imported *sema.Elaboration) *interpreter.Program {
+
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	valueDeclarations := append(
+		stdlib.FlowBuiltInFunctions(stdlib.FlowBuiltinImpls{}),
+		stdlib.BuiltinFunctions...,
+	).ToSemaValueDeclarations()
+
+	checker, err := sema.NewChecker(
+		program,
+		location,
+		sema.WithPredeclaredValues(valueDeclarations),
+		sema.WithImportHandler(
+			func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+				return sema.ElaborationImport{
+					Elaboration: imported,
+				}, nil
+			},
+		),
+	)
+	require.NoError(t, err)
+
+	err = checker.Check()
+	require.NoError(t, err)
+
+	return &interpreter.Program{
+		Program:     program,
+		Elaboration: checker.Elaboration,
+	}
+}
+
+func testTemplates(t *testing.T, contract string, expectedNames ...string) []Template {
+
+	program := parseAndCheck(t, contract, testLocation, nil)
+
+	templates := Generate(testLocation, program)
+
+	var names []string
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	require.Equal(t, expectedNames, names)
+
+	// All templates must be valid transactions
+
+	for _, template := range templates {
+		parseAndCheck(t, template.Code, common.TransactionLocation{}, program.Elaboration)
+	}
+
+	return templates
+}
+
+func TestGenerateFungibleToken(t *testing.T) {
+
+	t.Parallel()
+
+	templates := testTemplates(t,
+		`
+          pub contract Test {
+
+              pub resource interface Provider {
+                  pub fun withdraw(amount: UFix64): @Vault
+              }
+
+              pub resource interface Receiver {
+                  pub fun deposit(from: @Vault)
+              }
+
+              pub resource interface Balance {
+                  pub var balance: UFix64
+              }
+
+              pub resource Vault: Provider, Receiver, Balance {
+                  pub var balance: UFix64
+
+                  init(balance: UFix64) {
+                      self.balance = balance
+                  }
+
+                  pub fun withdraw(amount: UFix64): @Vault {
+                      self.balance = self.balance - amount
+                      return <-create Vault(balance: amount)
+                  }
+
+                  pub fun deposit(from: @Vault) {
+                      self.balance = self.balance + from.balance
+                      from.balance = 0.0
+                      destroy from
+                  }
+              }
+
+              pub fun createEmptyVault(): @Vault {
+                  return <-create Vault(balance: 0.0)
+              }
+          }
+        `,
+		"SetupVault",
+		"TransferTokens",
+	)
+
+	assert.Equal(t,
+		`import Test from 0x1
+
+transaction {
+
+    prepare(signer: AuthAccount) {
+        if signer.borrow<&Test.Vault>(from: /storage/testVault) == nil {
+            signer.save(<-Test.createEmptyVault(), to: /storage/testVault)
+            signer.link<&Test.Vault{Test.Receiver}>(/public/testReceiver, target: /storage/testVault)
+            signer.link<&Test.Vault{Test.Balance}>(/public/testBalance, target: /storage/testVault)
+        }
+    }
+}
+`,
+		templates[0].Code,
+	)
+
+	assert.Equal(t,
+		[]cadence.Parameter{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: cadence.AddressType{}},
+		},
+		templates[1].Parameters,
+	)
+
+	assert.Equal(t,
+		`import Test from 0x1
+
+transaction(amount: UFix64, to: Address) {
+
+    let sentVault: @Test.Vault
+
+    prepare(signer: AuthAccount) {
+        let vault = signer.borrow<&Test.Vault>(from: /storage/testVault)
+            ?? panic("Could not borrow a reference to the Test vault")
+        self.sentVault <- vault.withdraw(amount: amount)
+    }
+
+    execute {
+        let receiver = getAccount(to)
+            .getCapability(/public/testReceiver)
+            .borrow<&Test.Vault{Test.Receiver}>()
+            ?? panic("Could not borrow a reference to the Test receiver")
+        receiver.deposit(from: <-self.sentVault)
+    }
+}
+`,
+		templates[1].Code,
+	)
+}
+
+func TestGenerateNonFungibleToken(t *testing.T) {
+
+	t.Parallel()
+
+	templates := testTemplates(t,
+		`
+          pub contract Test {
+
+              pub let CollectionStoragePath: StoragePath
+              pub let CollectionPublicPath: PublicPath
+
+              pub resource NFT {
+                  pub let id: UInt64
+
+                  init(id: UInt64) {
+                      self.id = id
+                  }
+              }
+
+              pub resource interface CollectionPublic {
+                  pub fun deposit(token: @NFT)
+                  pub fun getIDs(): [UInt64]
+              }
+
+              pub resource Collection: CollectionPublic {
+                  pub var ownedNFTs: @{UInt64: NFT}
+
+                  init() {
+                      self.ownedNFTs <- {}
+                  }
+
+                  pub fun withdraw(withdrawID: UInt64): @NFT {
+                      let token <- self.ownedNFTs.remove(key: withdrawID) ?? panic("missing NFT")
+                      return <-token
+                  }
+
+                  pub fun deposit(token: @NFT) {
+                      self.ownedNFTs[token.id] <-! token
+                  }
+
+                  pub fun getIDs(): [UInt64] {
+                      return self.ownedNFTs.keys
+                  }
+
+                  destroy() {
+                      destroy self.ownedNFTs
+                  }
+              }
+
+              pub fun createEmptyCollection(): @Collection {
+                  return <-create Collection()
+              }
+
+              init() {
+                  self.CollectionStoragePath = /storage/collection
+                  self.CollectionPublicPath = /public/collection
+              }
+          }
+        `,
+		"SetupCollection",
+		"TransferNFT",
+	)
+
+	assert.Contains(t,
+		templates[0].Code,
+		"signer.link<&Test.Collection{Test.CollectionPublic}>(Test.CollectionPublicPath, target: Test.CollectionStoragePath)",
+	)
+
+	assert.Contains(t,
+		templates[1].Code,
+		"self.token <- collection.withdraw(withdrawID: id)",
+	)
+}
+
+func TestGenerateUnknownShape(t *testing.T) {
+
+	t.Parallel()
+
+	testTemplates(t,
+		`
+          pub contract Test {
+
+              pub resource Vault {
+                  pub var balance: UFix64
+
+                  init() {
+                      self.balance = 0.0
+                  }
+
+                  access(contract) fun withdraw(amount: UFix64): @Vault {
+                      return <-create Vault()
+                  }
+
+                  pub fun deposit(from: @Vault) {
+                      destroy from
+                  }
+              }
+
+              pub fun createEmptyVault(): @Vault {
+                  return <-create Vault()
+              }
+          }
+        `,
+	)
+}
@@ -20,6 +20,7 @@ package cadence
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -603,6 +604,42 @@ func (t *EventType) CompositeInitializers() [][]Parameter {
 	return [][]Parameter{t.Initializer}
 }
 
+// EventTypeSchema is a stable, machine-readable description of an event type,
+// e.g. for indexers which decode and store event payloads.
+//
+// The fields are in declaration order, i.e. in the order in which they are encoded in payloads,
+// and their types are described by their type IDs.
+//
+type EventTypeSchema struct {
+	TypeID string             `json:"typeID"`
+	Fields []EventFieldSchema `json:"fields"`
+}
+
+// EventFieldSchema describes a field of an event type.
+//
+type EventFieldSchema struct {
+	Identifier string `json:"identifier"`
+	TypeID     string `json:"typeID"`
+}
+
+// Schema returns the schema of the event type.
+//
+func (t *EventType) Schema() EventTypeSchema {
+	fields := make([]EventFieldSchema, len(t.Fields))
+
+	for i, field := range t.Fields {
+		fields[i] = EventFieldSchema{
+			Identifier: field.Identifier,
+			TypeID:     field.Type.ID(),
+		}
+	}
+
+	return EventTypeSchema{
+		TypeID: t.ID(),
+		Fields: fields,
+	}
+}
+
 // ContractType
 
 type ContractType struct {
@@ -784,8 +821,9 @@ func (t FunctionType) WithID(id string) FunctionType {
 // ReferenceType
 
 type ReferenceType struct {
-	Authorized bool
-	Type       Type
+	Authorized    bool
+	Authorization *EntitlementSetAuthorization
+	Type          Type
 }
 
 func (ReferenceType) isType() {}
@@ -794,10 +832,41 @@ func (t ReferenceType) ID() string {
 	id := fmt.Sprintf("&%s", t.Type.ID())
 	if t.Authorized {
 		id = "auth" + id
+	} else if t.Authorization != nil {
+		id = fmt.Sprintf("auth(%s) %s", t.Authorization, id)
 	}
 	return id
 }
 
+// EntitlementSetKind
+
+type EntitlementSetKind uint8
+
+const (
+	Conjunction EntitlementSetKind = iota
+	Disjunction
+)
+
+// EntitlementSetAuthorization
+
+type EntitlementSetAuthorization struct {
+	Entitlements []common.TypeID
+	Kind         EntitlementSetKind
+}
+
+func (a *EntitlementSetAuthorization) String() string {
+	separator := ", "
+	if a.Kind == Disjunction {
+		separator = " | "
+	}
+
+	entitlements := make([]string, len(a.Entitlements))
+	for i, entitlement := range a.Entitlements {
+		entitlements[i] = string(entitlement)
+	}
+	return strings.Join(entitlements, separator)
+}
+
 // RestrictedType
 
 type RestrictedType struct {
@@ -827,6 +896,16 @@ func (BlockType) ID() string {
 	return "Block"
 }
 
+// BlockHeaderType
+
+type BlockHeaderType struct{}
+
+func (BlockHeaderType) isType() {}
+
+func (BlockHeaderType) ID() string {
+	return "BlockHeader"
+}
+
 // PathType
 
 type PathType struct{}
@@ -929,6 +1008,43 @@ func (t *EnumType) CompositeInitializers() [][]Parameter {
 	return t.Initializers
 }
 
+// AttachmentType
+type AttachmentType struct {
+	Location            common.Location
+	QualifiedIdentifier string
+	BaseType            Type
+	Fields              []Field
+	Initializers        [][]Parameter
+}
+
+func (*AttachmentType) isType() {}
+
+func (t *AttachmentType) ID() string {
+	if t.Location == nil {
+		return t.QualifiedIdentifier
+	}
+
+	return string(t.Location.TypeID(t.QualifiedIdentifier))
+}
+
+func (*AttachmentType) isCompositeType() {}
+
+func (t *AttachmentType) CompositeTypeLocation() common.Location {
+	return t.Location
+}
+
+func (t *AttachmentType) CompositeTypeQualifiedIdentifier() string {
+	return t.QualifiedIdentifier
+}
+
+func (t *AttachmentType) CompositeFields() []Field {
+	return t.Fields
+}
+
+func (t *AttachmentType) CompositeInitializers() [][]Parameter {
+	return t.Initializers
+}
+
 // AuthAccountType
 type AuthAccountType struct{}
 
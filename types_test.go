@@ -163,3 +163,42 @@ func TestType_ID(t *testing.T) {
 		test(testCase.ty, testCase.expected)
 	}
 }
+
+func TestEventType_Schema(t *testing.T) {
+
+	t.Parallel()
+
+	eventType := &EventType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Transfer",
+		Fields: []Field{
+			{
+				Identifier: "amount",
+				Type:       UInt64Type{},
+			},
+			{
+				Identifier: "recipient",
+				Type: OptionalType{
+					Type: AddressType{},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t,
+		EventTypeSchema{
+			TypeID: "S.test.Transfer",
+			Fields: []EventFieldSchema{
+				{
+					Identifier: "amount",
+					TypeID:     "UInt64",
+				},
+				{
+					Identifier: "recipient",
+					TypeID:     "Address?",
+				},
+			},
+		},
+		eventType.Schema(),
+	)
+}
@@ -1255,6 +1255,9 @@ type Capability struct {
 	Path       Path
 	Address    Address
 	BorrowType Type
+	// ID is the identifier of capabilities issued by capability controllers.
+	// It is zero for capabilities created by linking, which target a path.
+	ID UInt64
 }
 
 func (Capability) isValue() {}
@@ -1268,6 +1271,13 @@ func (Capability) ToGoValue() interface{} {
 }
 
 func (v Capability) String() string {
+	if v.ID != 0 {
+		return format.IDCapability(
+			v.BorrowType.ID(),
+			v.Address.String(),
+			v.ID.String(),
+		)
+	}
 	return format.Capability(
 		v.BorrowType.ID(),
 		v.Address.String(),
@@ -1309,3 +1319,38 @@ func (v Enum) ToGoValue() interface{} {
 func (v Enum) String() string {
 	return formatComposite(v.EnumType.ID(), v.EnumType.Fields, v.Fields)
 }
+
+// Attachment
+type Attachment struct {
+	AttachmentType *AttachmentType
+	Fields         []Value
+}
+
+func NewAttachment(fields []Value) Attachment {
+	return Attachment{Fields: fields}
+}
+
+func (Attachment) isValue() {}
+
+func (v Attachment) Type() Type {
+	return v.AttachmentType
+}
+
+func (v Attachment) WithType(typ *AttachmentType) Attachment {
+	v.AttachmentType = typ
+	return v
+}
+
+func (v Attachment) ToGoValue() interface{} {
+	ret := make([]interface{}, len(v.Fields))
+
+	for i, field := range v.Fields {
+		ret[i] = field.ToGoValue()
+	}
+
+	return ret
+}
+
+func (v Attachment) String() string {
+	return formatComposite(v.AttachmentType.ID(), v.AttachmentType.Fields, v.Fields)
+}